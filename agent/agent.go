@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net"
+	"net/url"
 	"os"
 	"path"
 	"path/filepath"
@@ -28,6 +29,8 @@ import (
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/paths"
 	"github.com/juju/juju/mongo"
+	mongoutils "github.com/juju/juju/mongo/utils"
+	"github.com/juju/juju/utils/proxy"
 )
 
 var logger = loggo.GetLogger("juju.agent")
@@ -161,6 +164,12 @@ const (
 	MongoOplogSize    = "MONGO_OPLOG_SIZE"
 	NUMACtlPreference = "NUMA_CTL_PREFERENCE"
 
+	// ExternalMongoURI and ExternalMongoCACert hold the connection
+	// string and CA certificate of an externally managed replica set,
+	// used by a controller agent in place of its co-located juju-db.
+	ExternalMongoURI    = "EXTERNAL_MONGO_URI"
+	ExternalMongoCACert = "EXTERNAL_MONGO_CA_CERT"
+
 	MgoStatsEnabled = "MGO_STATS_ENABLED"
 
 	// LoggingOverride will set the logging for this agent to the value
@@ -179,6 +188,13 @@ const (
 	// CharmRevisionUpdateInterval controls how often the
 	// charm revision update worker runs.
 	CharmRevisionUpdateInterval = "CHARM_REVISION_UPDATE_INTERVAL"
+
+	// APIDialPreferredSubnets is a comma separated list of CIDRs. API
+	// addresses within them are dialled ahead of others of similar
+	// health. If it is not set, the subnets of the machine's own
+	// network interfaces are preferred; if it is set to "none", no
+	// subnets are preferred.
+	APIDialPreferredSubnets = "API_DIAL_PREFERRED_SUBNETS"
 )
 
 // The Config interface is the sole way that the agent gets access to the
@@ -285,6 +301,10 @@ type Config interface {
 	// NonSyncedWritesToRaftLog returns true if an fsync calls should not be
 	// performed after each write to the raft log.
 	NonSyncedWritesToRaftLog() bool
+
+	// APIProxy returns the proxy through which the agent connects to
+	// the API server, or nil if it connects directly.
+	APIProxy() *url.URL
 }
 
 type configSetterOnly interface {
@@ -336,6 +356,10 @@ type configSetterOnly interface {
 	// SetNonSyncedWritesToRaftLog selects whether fsync calls are performed
 	// after each write to the raft log.
 	SetNonSyncedWritesToRaftLog(bool)
+
+	// SetAPIProxy sets the proxy through which the agent connects
+	// to the API server; nil means it connects directly.
+	SetAPIProxy(*url.URL)
 }
 
 // LogFileName returns the filename for the Agent's log file.
@@ -343,6 +367,12 @@ func LogFilename(c Config) string {
 	return filepath.Join(c.LogDir(), c.Tag().String()+".log")
 }
 
+// LogSpoolFilename returns the filename of the file in which the agent
+// spools the log messages it has yet to send to the controller.
+func LogSpoolFilename(c Config) string {
+	return filepath.Join(c.Dir(), "log-spool")
+}
+
 // MachineLockLogFilename returns the filename for the machine lock log file.
 func MachineLockLogFilename(c Config) string {
 	return filepath.Join(c.LogDir(), machinelock.Filename)
@@ -410,6 +440,7 @@ type configInternal struct {
 	mongoMemoryProfile       string
 	jujuDBSnapChannel        string
 	nonSyncedWritesToRaftLog bool
+	apiProxy                 string
 }
 
 // AgentConfigParams holds the parameters required to create
@@ -429,6 +460,7 @@ type AgentConfigParams struct {
 	MongoMemoryProfile       mongo.MemoryProfile
 	JujuDBSnapChannel        string
 	NonSyncedWritesToRaftLog bool
+	APIProxy                 string
 }
 
 // NewAgentConfig returns a new config object suitable for use for a
@@ -471,6 +503,9 @@ func NewAgentConfig(configParams AgentConfigParams) (ConfigSetterWriter, error)
 	if len(configParams.CACert) == 0 {
 		return nil, errors.Trace(requiredError("CA certificate"))
 	}
+	if _, err := proxy.ParseAPIProxy(configParams.APIProxy); err != nil {
+		return nil, errors.Trace(err)
+	}
 	// Note that the password parts of the state and api information are
 	// blank.  This is by design: we want to generate a secure password
 	// for new agents. So, we create this config without a current password
@@ -491,6 +526,7 @@ func NewAgentConfig(configParams AgentConfigParams) (ConfigSetterWriter, error)
 		mongoMemoryProfile:       configParams.MongoMemoryProfile.String(),
 		jujuDBSnapChannel:        configParams.JujuDBSnapChannel,
 		nonSyncedWritesToRaftLog: configParams.NonSyncedWritesToRaftLog,
+		apiProxy:                 configParams.APIProxy,
 	}
 	if len(configParams.APIAddresses) > 0 {
 		config.apiDetails = &apiDetails{
@@ -555,7 +591,8 @@ func ConfigPath(dataDir string, tag names.Tag) string {
 	return filepath.Join(Dir(dataDir, tag), AgentConfigFilename)
 }
 
-// ReadConfig reads configuration data from the given location.
+// ReadConfig reads configuration data from the given location. Secrets
+// encrypted with the machine-local agent config key are decrypted.
 func ReadConfig(configFilePath string) (ConfigSetterWriter, error) {
 	var (
 		format formatter
@@ -570,6 +607,9 @@ func ReadConfig(configFilePath string) (ConfigSetterWriter, error) {
 		return nil, err
 	}
 	logger.Debugf("read agent config, format %q", format.version())
+	if err := config.decryptSecrets(); err != nil {
+		return nil, errors.Annotatef(err, "cannot read agent config %q", configFilePath)
+	}
 	config.configFilePath = configFilePath
 	return config, nil
 }
@@ -643,8 +683,14 @@ func (c *configInternal) SetPassword(newPassword string) {
 	}
 }
 
+// Write writes the config to its file, encrypting its secrets with the
+// machine-local agent config key if one has been created.
 func (c *configInternal) Write() error {
-	data, err := c.Render()
+	toWrite, err := c.encrypted()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	data, err := toWrite.Render()
 	if err != nil {
 		return err
 	}
@@ -797,6 +843,24 @@ func (c *configInternal) SetNonSyncedWritesToRaftLog(nonSyncedWrites bool) {
 	c.nonSyncedWritesToRaftLog = nonSyncedWrites
 }
 
+// APIProxy implements Config.
+func (c *configInternal) APIProxy() *url.URL {
+	proxyURL, err := proxy.ParseAPIProxy(c.apiProxy)
+	if err != nil {
+		logger.Warningf("ignoring API proxy: %v", err)
+		return nil
+	}
+	return proxyURL
+}
+
+// SetAPIProxy implements configSetterOnly.
+func (c *configInternal) SetAPIProxy(proxyURL *url.URL) {
+	c.apiProxy = ""
+	if proxyURL != nil {
+		c.apiProxy = proxyURL.String()
+	}
+}
+
 var validAddr = regexp.MustCompile("^.+:[0-9]+$")
 
 func checkAddrs(addrs []string, what string) error {
@@ -867,12 +931,36 @@ func (c *configInternal) APIInfo() (*api.Info, bool) {
 	}, true
 }
 
+// ExternalMongo returns the externally managed replica set that a
+// controller agent uses in place of its co-located juju-db, or nil if
+// the agent uses juju-db.
+func ExternalMongo(c Config) (*mongoutils.ExternalReplicaSet, error) {
+	uri := c.Value(ExternalMongoURI)
+	if uri == "" {
+		return nil, nil
+	}
+	rs, err := mongoutils.ParseExternalURI(uri)
+	return rs, errors.Annotate(err, "parsing external mongo URI")
+}
+
 // MongoInfo is defined on Config interface.
 func (c *configInternal) MongoInfo() (info *mongo.MongoInfo, ok bool) {
 	ssi, ok := c.StateServingInfo()
 	if !ok {
 		return nil, false
 	}
+	rs, err := ExternalMongo(c)
+	if err != nil {
+		logger.Errorf("%v", err)
+		return nil, false
+	}
+	if rs != nil {
+		return &mongo.MongoInfo{
+			Info:     mongo.ExternalInfo(rs, c.Value(ExternalMongoCACert)),
+			Password: c.statePassword,
+			Tag:      c.tag,
+		}, true
+	}
 	addrs := c.apiDetails.addresses
 	var netAddrs network.SpaceAddresses
 	for _, addr := range addrs {
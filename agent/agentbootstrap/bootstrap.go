@@ -32,6 +32,7 @@ import (
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/environs/space"
 	"github.com/juju/juju/mongo"
+	mongoutils "github.com/juju/juju/mongo/utils"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
@@ -110,7 +111,11 @@ func InitializeState(
 		return nil, errors.Trace(err)
 	}
 
-	session, err := initMongo(info.Info, dialOpts, info.Password)
+	externalMongo, err := agent.ExternalMongo(c)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	session, err := initMongo(info.Info, externalMongo, dialOpts, info.Password)
 	if err != nil {
 		return nil, errors.Annotate(err, "failed to initialize mongo")
 	}
@@ -406,11 +411,22 @@ func initRaft(agentConfig agent.Config) error {
 
 // initMongo dials the initial MongoDB connection, setting a
 // password for the admin user, and returning the session.
-func initMongo(info mongo.Info, dialOpts mongo.DialOpts, password string) (*mgo.Session, error) {
+// An externally managed replica set is first logged in to with
+// its configured credentials, as it is already running with
+// authentication enabled.
+func initMongo(
+	info mongo.Info, externalMongo *mongoutils.ExternalReplicaSet, dialOpts mongo.DialOpts, password string,
+) (*mgo.Session, error) {
 	session, err := mongo.DialWithInfo(mongo.MongoInfo{Info: info}, dialOpts)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if externalMongo != nil {
+		if err := mongo.LoginExternal(session, externalMongo); err != nil {
+			session.Close()
+			return nil, errors.Trace(err)
+		}
+	}
 	if err := mongo.SetAdminMongoPassword(session, mongo.AdminUser, password); err != nil {
 		session.Close()
 		return nil, errors.Trace(err)
@@ -0,0 +1,325 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/v2"
+)
+
+const (
+	// ConfigKeyFilename is the name of the file, in the agents
+	// directory, holding the machine-local key used to encrypt the
+	// secrets in the agent configs on the machine.
+	ConfigKeyFilename = "agent-config.key"
+
+	// SealedConfigKeyFilename is the name of the file, in the agents
+	// directory, holding the machine-local key sealed by the machine's
+	// TPM. It is used in preference to ConfigKeyFilename when present.
+	SealedConfigKeyFilename = "agent-config.key.sealed"
+
+	// configKeySize is the size of the AES-256 key.
+	configKeySize = 32
+
+	// encryptedValuePrefix marks an agent config value encrypted with
+	// the machine-local key. The rest of the value is the base64
+	// encoded AES-GCM nonce and ciphertext.
+	encryptedValuePrefix = "encrypted:"
+
+	// sealedKeyName is the name the key is sealed under, which must
+	// match when it is unsealed.
+	sealedKeyName = "juju-agent-config"
+)
+
+// ConfigKeySource stores the machine-local key used to encrypt the
+// secrets in agent configs.
+type ConfigKeySource interface {
+	// Key returns the key, or an error satisfying errors.IsNotFound
+	// if none has been stored.
+	Key() ([]byte, error)
+
+	// SetKey stores the key.
+	SetKey(key []byte) error
+}
+
+// NewFileConfigKeySource returns a ConfigKeySource which stores the key
+// in a file readable only by root.
+func NewFileConfigKeySource(path string) ConfigKeySource {
+	return fileConfigKeySource{path: path}
+}
+
+type fileConfigKeySource struct {
+	path string
+}
+
+// Key is part of the ConfigKeySource interface.
+func (s fileConfigKeySource) Key() ([]byte, error) {
+	data, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("agent config key %q", s.path)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return decodeConfigKey(data)
+}
+
+// SetKey is part of the ConfigKeySource interface.
+func (s fileConfigKeySource) SetKey(key []byte) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return errors.Trace(err)
+	}
+	data := []byte(base64.StdEncoding.EncodeToString(key))
+	return errors.Trace(utils.AtomicWriteFile(s.path, data, 0600))
+}
+
+// NewTPMConfigKeySource returns a ConfigKeySource which stores the key
+// in a file, sealed by the machine's TPM with systemd-creds so that it
+// can't be read off the machine's disk elsewhere.
+func NewTPMConfigKeySource(path string) ConfigKeySource {
+	return tpmConfigKeySource{path: path}
+}
+
+type tpmConfigKeySource struct {
+	path string
+}
+
+// Key is part of the ConfigKeySource interface.
+func (s tpmConfigKeySource) Key() ([]byte, error) {
+	if _, err := os.Stat(s.path); os.IsNotExist(err) {
+		return nil, errors.NotFoundf("sealed agent config key %q", s.path)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	data, err := runSystemdCreds(nil, "decrypt", "--name="+sealedKeyName, s.path, "-")
+	if err != nil {
+		return nil, errors.Annotate(err, "unsealing agent config key")
+	}
+	return decodeConfigKey(data)
+}
+
+// SetKey is part of the ConfigKeySource interface.
+func (s tpmConfigKeySource) SetKey(key []byte) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return errors.Trace(err)
+	}
+	data := []byte(base64.StdEncoding.EncodeToString(key))
+	_, err := runSystemdCreds(data, "encrypt", "--with-key=tpm2", "--name="+sealedKeyName, "-", s.path)
+	return errors.Annotate(err, "sealing agent config key")
+}
+
+// runSystemdCreds runs systemd-creds with the given input and
+// arguments, returning its output.
+var runSystemdCreds = func(input []byte, args ...string) ([]byte, error) {
+	cmd := exec.Command("systemd-creds", args...)
+	cmd.Stdin = bytes.NewReader(input)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Annotatef(err, "systemd-creds %s: %s", args[0], strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// tpmAvailable reports whether the machine has a TPM which keys can be
+// sealed with.
+var tpmAvailable = func() bool {
+	if _, err := os.Stat("/dev/tpmrm0"); err != nil {
+		return false
+	}
+	_, err := exec.LookPath("systemd-creds")
+	return err == nil
+}
+
+func decodeConfigKey(data []byte) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, errors.Annotate(err, "decoding agent config key")
+	}
+	if len(key) != configKeySize {
+		return nil, errors.NotValidf("agent config key of %d bytes", len(key))
+	}
+	return key, nil
+}
+
+// configKeySource returns the source of the key for the agent configs
+// in the given data directory: the TPM if a sealed key has been stored,
+// otherwise the key file.
+func configKeySource(dataDir string) ConfigKeySource {
+	sealedPath := filepath.Join(BaseDir(dataDir), SealedConfigKeyFilename)
+	if _, err := os.Stat(sealedPath); err == nil {
+		return NewTPMConfigKeySource(sealedPath)
+	}
+	return NewFileConfigKeySource(filepath.Join(BaseDir(dataDir), ConfigKeyFilename))
+}
+
+// EnsureConfigKey creates the machine-local key used to encrypt the
+// secrets in the agent configs in the given data directory, unless it
+// already exists. The key is sealed by the machine's TPM if it has one,
+// and stored in a file readable only by root otherwise. Agent configs
+// are encrypted with the key whenever they are next written.
+func EnsureConfigKey(dataDir string) error {
+	if _, err := configKeySource(dataDir).Key(); err == nil {
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	key := make([]byte, configKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return errors.Trace(err)
+	}
+	if tpmAvailable() {
+		sealedPath := filepath.Join(BaseDir(dataDir), SealedConfigKeyFilename)
+		err := NewTPMConfigKeySource(sealedPath).SetKey(key)
+		if err == nil {
+			logger.Infof("created agent config key sealed by the TPM")
+			return nil
+		}
+		logger.Warningf("cannot seal agent config key with the TPM, storing it in a file: %v", err)
+		_ = os.Remove(sealedPath)
+	}
+	keyPath := filepath.Join(BaseDir(dataDir), ConfigKeyFilename)
+	if err := NewFileConfigKeySource(keyPath).SetKey(key); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("created agent config key %q", keyPath)
+	return nil
+}
+
+// secretValues holds the keys of the agent config values which are
+// sensitive, and so are encrypted along with the config's secrets.
+var secretValues = []string{ExternalMongoURI}
+
+// secrets returns pointers to the sensitive fields of the config, which
+// are encrypted when a config key exists.
+func (c *configInternal) secrets() []*string {
+	fields := []*string{&c.statePassword, &c.oldPassword}
+	if c.apiDetails != nil {
+		fields = append(fields, &c.apiDetails.password)
+	}
+	if info := c.servingInfo; info != nil {
+		fields = append(fields, &info.PrivateKey, &info.CAPrivateKey, &info.SharedSecret, &info.SystemIdentity)
+	}
+	return fields
+}
+
+// updateSecrets replaces each of the config's non-empty secrets, and
+// secret values, with the result of calling update on it.
+func (c *configInternal) updateSecrets(update func(string) (string, error)) error {
+	for _, field := range c.secrets() {
+		if *field == "" {
+			continue
+		}
+		value, err := update(*field)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		*field = value
+	}
+	for _, key := range secretValues {
+		if c.values[key] == "" {
+			continue
+		}
+		value, err := update(c.values[key])
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.values[key] = value
+	}
+	return nil
+}
+
+// encrypted returns the config to write to disk: a copy of the config
+// with its secrets encrypted if a config key exists, or the config
+// itself otherwise.
+func (c *configInternal) encrypted() (*configInternal, error) {
+	key, err := configKeySource(c.paths.DataDir).Key()
+	if errors.IsNotFound(err) {
+		return c, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "reading agent config key")
+	}
+	out := c.Clone().(*configInternal)
+	err = out.updateSecrets(func(value string) (string, error) {
+		return encryptValue(key, value)
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return out, nil
+}
+
+// decryptSecrets decrypts the config's secrets in place. The config key
+// is only read if any of them are encrypted.
+func (c *configInternal) decryptSecrets() error {
+	var key []byte
+	return c.updateSecrets(func(value string) (string, error) {
+		if !strings.HasPrefix(value, encryptedValuePrefix) {
+			return value, nil
+		}
+		if key == nil {
+			var err error
+			key, err = configKeySource(c.paths.DataDir).Key()
+			if errors.IsNotFound(err) {
+				return "", errors.New("agent config secrets are encrypted but the agent config key is missing")
+			} else if err != nil {
+				return "", errors.Annotate(err, "reading agent config key")
+			}
+		}
+		return decryptValue(key, value)
+	})
+}
+
+func encryptValue(key []byte, value string) (string, error) {
+	gcm, err := newConfigCipher(key)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", errors.Trace(err)
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(value), nil)
+	return encryptedValuePrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func decryptValue(key []byte, value string) (string, error) {
+	gcm, err := newConfigCipher(key)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedValuePrefix))
+	if err != nil {
+		return "", errors.Annotate(err, "decoding encrypted agent config value")
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("encrypted agent config value too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", errors.New("cannot decrypt agent config value: wrong agent config key or corrupt value")
+	}
+	return string(plain), nil
+}
+
+func newConfigCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -6,3 +6,8 @@ package agent
 func EmptyConfig() Config {
 	return &configInternal{}
 }
+
+var (
+	TPMAvailable    = &tpmAvailable
+	RunSystemdCreds = &runSystemdCreds
+)
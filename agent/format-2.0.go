@@ -61,6 +61,8 @@ type format_2_0Serialization struct {
 	MongoMemoryProfile       string `yaml:"mongomemoryprofile,omitempty"`
 	JujuDBSnapChannel        string `yaml:"juju-db-snap-channel,omitempty"`
 	NonSyncedWritesToRaftLog bool   `yaml:"no-sync-writes-to-raft-log,omitempty"`
+
+	APIProxy string `yaml:"apiproxy,omitempty"`
 }
 
 func init() {
@@ -109,6 +111,7 @@ func (formatter_2_0) unmarshal(data []byte) (*configInternal, error) {
 		loggingConfig:            format.LoggingConfig,
 		values:                   format.Values,
 		nonSyncedWritesToRaftLog: format.NonSyncedWritesToRaftLog,
+		apiProxy:                 format.APIProxy,
 	}
 	if len(format.APIAddresses) > 0 {
 		config.apiDetails = &apiDetails{
@@ -175,6 +178,7 @@ func (formatter_2_0) marshal(config *configInternal) ([]byte, error) {
 		Values:            config.values,
 
 		NonSyncedWritesToRaftLog: config.nonSyncedWritesToRaftLog,
+		APIProxy:                 config.apiProxy,
 	}
 	if config.servingInfo != nil {
 		format.ControllerCert = config.servingInfo.Cert
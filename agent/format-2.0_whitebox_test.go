@@ -55,6 +55,7 @@ func (*format_2_0Suite) TestMarshalUnmarshal(c *gc.C) {
 	// configFilePath is not serialized as it is the location of the file.
 	config.configFilePath = ""
 	config.SetLoggingConfig(loggingConfig)
+	config.apiProxy = "socks5://10.0.0.1:1080"
 
 	data, err := format_2_0.marshal(config)
 	c.Assert(err, jc.ErrorIsNil)
@@ -63,6 +64,7 @@ func (*format_2_0Suite) TestMarshalUnmarshal(c *gc.C) {
 
 	c.Check(newConfig, gc.DeepEquals, config)
 	c.Check(newConfig.LoggingConfig(), gc.Equals, loggingConfig)
+	c.Check(newConfig.APIProxy().String(), gc.Equals, "socks5://10.0.0.1:1080")
 }
 
 var agentConfig2_0Contents = `
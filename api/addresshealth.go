@@ -0,0 +1,193 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package api
+
+import (
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+)
+
+const (
+	// unknownAddressLatency is the latency assumed for an address
+	// that has not yet been dialled successfully.
+	unknownAddressLatency = 250 * time.Millisecond
+
+	// addressFailureMemory is how long a failure to dial an address
+	// counts against it.
+	addressFailureMemory = 10 * time.Minute
+
+	// addressLatencyWeight is the weight given to the latest dial
+	// latency when updating an address's smoothed latency.
+	addressLatencyWeight = 0.3
+)
+
+// AddressHealth records the outcome of dialling controller API
+// addresses, and uses it to order addresses so that those most likely
+// to connect quickly are dialled first. Addresses with recent failures
+// are dialled last; of the rest, those in a preferred subnet are dialled
+// before others, with lower latency addresses first.
+//
+// An AddressHealth is safe for concurrent use, and is intended to be
+// shared by all the connections an agent makes.
+type AddressHealth struct {
+	clock            clock.Clock
+	preferredSubnets []*net.IPNet
+
+	mu    sync.Mutex
+	stats map[string]*addressStats
+}
+
+type addressStats struct {
+	latency     time.Duration
+	failures    int
+	lastFailure time.Time
+	lastSuccess time.Time
+}
+
+// NewAddressHealth returns a new AddressHealth. Addresses within any
+// of the preferred subnets, such as those of the local machine's
+// network interfaces, are favoured over others of similar health.
+func NewAddressHealth(clock clock.Clock, preferredSubnets []*net.IPNet) *AddressHealth {
+	return &AddressHealth{
+		clock:            clock,
+		preferredSubnets: preferredSubnets,
+		stats:            make(map[string]*addressStats),
+	}
+}
+
+// RecordSuccess records that the address, in host:port form, was
+// dialled successfully in the given time.
+func (h *AddressHealth) RecordSuccess(addr string, latency time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	stats := h.statsFor(addr)
+	if stats.lastSuccess.IsZero() {
+		stats.latency = latency
+	} else {
+		stats.latency = time.Duration(addressLatencyWeight*float64(latency) +
+			(1-addressLatencyWeight)*float64(stats.latency))
+	}
+	stats.failures = 0
+	stats.lastSuccess = h.clock.Now()
+}
+
+// RecordFailure records that dialling the address, in host:port form,
+// failed.
+func (h *AddressHealth) RecordFailure(addr string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	stats := h.statsFor(addr)
+	stats.failures++
+	stats.lastFailure = h.clock.Now()
+}
+
+func (h *AddressHealth) statsFor(addr string) *addressStats {
+	stats, ok := h.stats[addr]
+	if !ok {
+		stats = &addressStats{}
+		h.stats[addr] = stats
+	}
+	return stats
+}
+
+// Order returns the addresses, in host:port form, in the order in which
+// they should be dialled. Addresses of equal standing keep their
+// relative order, so callers can shuffle them beforehand to spread load.
+func (h *AddressHealth) Order(addrs []string) []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	type scored struct {
+		addr      string
+		failures  int
+		preferred bool
+		latency   time.Duration
+	}
+	now := h.clock.Now()
+	scores := make([]scored, len(addrs))
+	for i, addr := range addrs {
+		s := scored{
+			addr:      addr,
+			preferred: h.isPreferred(addr),
+			latency:   unknownAddressLatency,
+		}
+		if stats, ok := h.stats[addr]; ok {
+			if stats.failures > 0 && now.Sub(stats.lastFailure) < addressFailureMemory {
+				s.failures = stats.failures
+			}
+			if !stats.lastSuccess.IsZero() {
+				s.latency = stats.latency
+			}
+		}
+		scores[i] = s
+	}
+	sort.SliceStable(scores, func(i, j int) bool {
+		a, b := scores[i], scores[j]
+		if a.failures != b.failures {
+			return a.failures < b.failures
+		}
+		if a.preferred != b.preferred {
+			return a.preferred
+		}
+		return a.latency < b.latency
+	})
+
+	ordered := make([]string, len(scores))
+	for i, s := range scores {
+		ordered[i] = s.addr
+	}
+	return ordered
+}
+
+func (h *AddressHealth) isPreferred(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, subnet := range h.preferredSubnets {
+		if subnet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Report returns the recorded health of each address, for inclusion
+// in the agent's introspection output.
+func (h *AddressHealth) Report() map[string]interface{} {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	addresses := make(map[string]interface{}, len(h.stats))
+	for addr, stats := range h.stats {
+		report := map[string]interface{}{
+			"preferred": h.isPreferred(addr),
+		}
+		if !stats.lastSuccess.IsZero() {
+			report["latency"] = stats.latency.String()
+			report["last-success"] = stats.lastSuccess.Format(time.RFC3339)
+		}
+		if stats.failures > 0 {
+			report["failures"] = stats.failures
+			report["last-failure"] = stats.lastFailure.Format(time.RFC3339)
+		}
+		addresses[addr] = report
+	}
+	subnets := make([]string, len(h.preferredSubnets))
+	for i, subnet := range h.preferredSubnets {
+		subnets[i] = subnet.String()
+	}
+	return map[string]interface{}{
+		"preferred-subnets": subnets,
+		"addresses":         addresses,
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package api_test
+
+import (
+	"net"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api"
+)
+
+type addressHealthSuite struct {
+	testing.IsolationSuite
+
+	clock *testclock.Clock
+}
+
+var _ = gc.Suite(&addressHealthSuite{})
+
+func (s *addressHealthSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func (s *addressHealthSuite) TestOrderUnknownAddresses(c *gc.C) {
+	health := api.NewAddressHealth(s.clock, nil)
+	addrs := []string{"10.0.0.1:17070", "10.0.0.2:17070", "10.0.0.3:17070"}
+	c.Assert(health.Order(addrs), jc.DeepEquals, addrs)
+}
+
+func (s *addressHealthSuite) TestOrderByLatency(c *gc.C) {
+	health := api.NewAddressHealth(s.clock, nil)
+	health.RecordSuccess("10.0.0.1:17070", 500*time.Millisecond)
+	health.RecordSuccess("10.0.0.2:17070", 10*time.Millisecond)
+
+	// Unknown addresses are assumed to be of middling latency.
+	c.Assert(health.Order([]string{
+		"10.0.0.1:17070", "10.0.0.2:17070", "10.0.0.3:17070",
+	}), jc.DeepEquals, []string{
+		"10.0.0.2:17070", "10.0.0.3:17070", "10.0.0.1:17070",
+	})
+}
+
+func (s *addressHealthSuite) TestOrderFailuresLast(c *gc.C) {
+	health := api.NewAddressHealth(s.clock, nil)
+	health.RecordSuccess("10.0.0.1:17070", 10*time.Millisecond)
+	health.RecordFailure("10.0.0.1:17070")
+	health.RecordFailure("10.0.0.1:17070")
+	health.RecordFailure("10.0.0.2:17070")
+
+	addrs := []string{"10.0.0.1:17070", "10.0.0.2:17070", "10.0.0.3:17070"}
+	c.Assert(health.Order(addrs), jc.DeepEquals, []string{
+		"10.0.0.3:17070", "10.0.0.2:17070", "10.0.0.1:17070",
+	})
+
+	// Failures are forgotten after a while.
+	s.clock.Advance(time.Hour)
+	c.Assert(health.Order(addrs), jc.DeepEquals, []string{
+		"10.0.0.1:17070", "10.0.0.2:17070", "10.0.0.3:17070",
+	})
+}
+
+func (s *addressHealthSuite) TestSuccessClearsFailures(c *gc.C) {
+	health := api.NewAddressHealth(s.clock, nil)
+	health.RecordFailure("10.0.0.1:17070")
+	health.RecordSuccess("10.0.0.1:17070", 10*time.Millisecond)
+
+	c.Assert(health.Order([]string{
+		"10.0.0.2:17070", "10.0.0.1:17070",
+	}), jc.DeepEquals, []string{
+		"10.0.0.1:17070", "10.0.0.2:17070",
+	})
+}
+
+func (s *addressHealthSuite) TestOrderPreferredSubnets(c *gc.C) {
+	_, subnet, err := net.ParseCIDR("192.168.1.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	health := api.NewAddressHealth(s.clock, []*net.IPNet{subnet})
+	health.RecordSuccess("10.0.0.1:17070", 10*time.Millisecond)
+	health.RecordSuccess("192.168.1.2:17070", 50*time.Millisecond)
+	health.RecordFailure("192.168.1.3:17070")
+
+	c.Assert(health.Order([]string{
+		"controller.example:17070", "10.0.0.1:17070", "192.168.1.3:17070", "192.168.1.2:17070",
+	}), jc.DeepEquals, []string{
+		"192.168.1.2:17070", "10.0.0.1:17070", "controller.example:17070", "192.168.1.3:17070",
+	})
+}
+
+func (s *addressHealthSuite) TestReport(c *gc.C) {
+	_, subnet, err := net.ParseCIDR("10.0.0.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	health := api.NewAddressHealth(s.clock, []*net.IPNet{subnet})
+	health.RecordSuccess("10.0.0.1:17070", 100*time.Millisecond)
+	health.RecordSuccess("10.0.0.1:17070", 200*time.Millisecond)
+	health.RecordFailure("10.1.0.1:17070")
+
+	c.Assert(health.Report(), jc.DeepEquals, map[string]interface{}{
+		"preferred-subnets": []string{"10.0.0.0/24"},
+		"addresses": map[string]interface{}{
+			"10.0.0.1:17070": map[string]interface{}{
+				"preferred":    true,
+				"latency":      "130ms",
+				"last-success": "2021-01-01T00:00:00Z",
+			},
+			"10.1.0.1:17070": map[string]interface{}{
+				"preferred":    false,
+				"failures":     1,
+				"last-failure": "2021-01-01T00:00:00Z",
+			},
+		},
+	})
+}
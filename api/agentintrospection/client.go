@@ -0,0 +1,62 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package agentintrospection provides access to the AgentIntrospection
+// facade, which queries the introspection sockets of agents through
+// the controller.
+package agentintrospection
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the AgentIntrospection API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the AgentIntrospection
+// API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "AgentIntrospection")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Introspect asks the agent of the given machine or unit to query the
+// given path on its introspection socket, returning the id of the
+// request for use with Results.
+func (c *Client) Introspect(tag names.Tag, path string) (string, error) {
+	args := params.IntrospectArgs{
+		Args: []params.IntrospectArg{{Tag: tag.String(), Path: path}},
+	}
+	var results params.StringResults
+	if err := c.facade.FacadeCall("Introspect", args, &results); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return "", errors.Trace(err)
+	}
+	return results.Results[0].Result, nil
+}
+
+// Result returns the outcome of the introspection request with the
+// given id. The result is not Completed until the agent has responded.
+func (c *Client) Result(id string) (params.IntrospectionResult, error) {
+	args := params.IntrospectionRequestIds{Ids: []string{id}}
+	var results params.IntrospectionResults
+	if err := c.facade.FacadeCall("Results", args, &results); err != nil {
+		return params.IntrospectionResult{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.IntrospectionResult{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	return results.Results[0], nil
+}
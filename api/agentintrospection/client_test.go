@@ -0,0 +1,82 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentintrospection_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/agentintrospection"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestIntrospect(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "AgentIntrospection")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Introspect")
+			c.Check(a, jc.DeepEquals, params.IntrospectArgs{
+				Args: []params.IntrospectArg{{Tag: "unit-mysql-0", Path: "/depengine"}},
+			})
+			*(result.(*params.StringResults)) = params.StringResults{
+				Results: []params.StringResult{{Result: "machine-1#0"}},
+			}
+			return nil
+		})
+	id, err := agentintrospection.NewClient(apiCaller).Introspect(names.NewUnitTag("mysql/0"), "/depengine")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, "machine-1#0")
+}
+
+func (s *clientSuite) TestIntrospectError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(_ string, _ int, _, _ string, _, result interface{}) error {
+			*(result.(*params.StringResults)) = params.StringResults{
+				Results: []params.StringResult{{Error: &params.Error{Message: "boom"}}},
+			}
+			return nil
+		})
+	_, err := agentintrospection.NewClient(apiCaller).Introspect(names.NewMachineTag("0"), "/depengine")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *clientSuite) TestResult(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "AgentIntrospection")
+			c.Check(request, gc.Equals, "Results")
+			c.Check(a, jc.DeepEquals, params.IntrospectionRequestIds{Ids: []string{"machine-0#0"}})
+			*(result.(*params.IntrospectionResults)) = params.IntrospectionResults{
+				Results: []params.IntrospectionResult{{Id: "machine-0#0", Completed: true, Output: []byte("ok")}},
+			}
+			return nil
+		})
+	result, err := agentintrospection.NewClient(apiCaller).Result("machine-0#0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.IntrospectionResult{
+		Id:        "machine-0#0",
+		Completed: true,
+		Output:    []byte("ok"),
+	})
+}
+
+func (s *clientSuite) TestResultError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(_ string, _ int, _, _ string, _, _ interface{}) error {
+			return errors.New("boom")
+		})
+	_, err := agentintrospection.NewClient(apiCaller).Result("machine-0#0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
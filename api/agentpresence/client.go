@@ -0,0 +1,35 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package agentpresence provides access to the AgentPresence facade,
+// which reports the connections of a model's agents to the controller.
+package agentpresence
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the AgentPresence API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the AgentPresence API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "AgentPresence")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Agents returns the connections of the model's agents to the
+// controller's API servers, ordered by agent.
+func (c *Client) Agents() ([]params.AgentConnection, error) {
+	var result params.AgentConnectionResults
+	if err := c.facade.FacadeCall("Agents", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Results, nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentpresence_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/agentpresence"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestAgents(c *gc.C) {
+	connections := []params.AgentConnection{{
+		Tag:           "unit-mysql-0",
+		Server:        "machine-0",
+		ConnectionID:  3,
+		Status:        "alive",
+		RemoteAddress: "10.0.0.7:40000",
+		LastSeen:      time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC),
+	}}
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "AgentPresence")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Agents")
+			c.Check(a, gc.IsNil)
+			*(result.(*params.AgentConnectionResults)) = params.AgentConnectionResults{
+				Results: connections,
+			}
+			return nil
+		})
+	client := agentpresence.NewClient(apiCaller)
+	result, err := client.Agents()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result, jc.DeepEquals, connections)
+}
+
+func (s *clientSuite) TestAgentsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(_ string, _ int, _, _ string, _, _ interface{}) error {
+			return errors.New("boom")
+		})
+	client := agentpresence.NewClient(apiCaller)
+	_, err := client.Agents()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
 )
 
 // Client allows access to the annotations API end point.
@@ -41,6 +43,40 @@ func (c *Client) Set(annotations map[string]map[string]string) ([]params.ErrorRe
 	return results.Results, nil
 }
 
+// WatchAnnotations returns a StringsWatcher that reports the tags of
+// entities in the model whose annotations have changed.
+func (c *Client) WatchAnnotations() (watcher.StringsWatcher, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("WatchAnnotations on v%d facade", c.BestAPIVersion())
+	}
+	var result params.StringsWatchResult
+	if err := c.facade.FacadeCall("WatchAnnotations", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result), nil
+}
+
+// Query returns the annotations of the entities in the model whose
+// annotations match the selector, e.g. "env=prod,tier!=web". If kinds
+// are given, only entities of those tag kinds are returned.
+func (c *Client) Query(selector string, kinds ...string) ([]params.EntityAnnotations, error) {
+	if c.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("Query on v%d facade", c.BestAPIVersion())
+	}
+	args := params.AnnotationsQuery{
+		Selector: selector,
+		Kinds:    kinds,
+	}
+	var result params.AnnotationsQueryResults
+	if err := c.facade.FacadeCall("Query", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Results, nil
+}
+
 func entitiesFromTags(tags []string) params.Entities {
 	entities := []params.Entity{}
 	for _, tag := range tags {
@@ -4,6 +4,7 @@
 package annotations_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -91,3 +92,88 @@ func (s *annotationsMockSuite) TestGetEntitiesAnnotations(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 	c.Assert(found, gc.HasLen, 1)
 }
+
+func (s *annotationsMockSuite) TestWatchAnnotationsV2(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(
+			func(_ string, _ int, _, _ string, _, _ interface{}) error {
+				c.Errorf("shouldn't be called")
+				return nil
+			},
+		), 2}
+	annotationsClient := annotations.NewClient(apiCaller)
+	_, err := annotationsClient.WatchAnnotations()
+	c.Assert(err, gc.ErrorMatches, "WatchAnnotations on v2 facade not supported")
+}
+
+func (s *annotationsMockSuite) TestWatchAnnotationsError(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, response interface{},
+			) error {
+				called = true
+				c.Check(objType, gc.Equals, "Annotations")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "WatchAnnotations")
+				c.Check(a, gc.IsNil)
+				return errors.New("boom")
+			},
+		), 3}
+	annotationsClient := annotations.NewClient(apiCaller)
+	_, err := annotationsClient.WatchAnnotations()
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *annotationsMockSuite) TestQuery(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, response interface{},
+			) error {
+				called = true
+				c.Check(objType, gc.Equals, "Annotations")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "Query")
+				c.Check(a, jc.DeepEquals, params.AnnotationsQuery{
+					Selector: "env=prod",
+					Kinds:    []string{"unit"},
+				})
+				if results, ok := response.(*params.AnnotationsQueryResults); ok {
+					results.Results = []params.EntityAnnotations{{
+						EntityTag:   "unit-mysql-0",
+						Annotations: map[string]string{"env": "prod"},
+					}}
+				}
+				return nil
+			},
+		), 4}
+	annotationsClient := annotations.NewClient(apiCaller)
+	found, err := annotationsClient.Query("env=prod", "unit")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(found, jc.DeepEquals, []params.EntityAnnotations{{
+		EntityTag:   "unit-mysql-0",
+		Annotations: map[string]string{"env": "prod"},
+	}})
+}
+
+func (s *annotationsMockSuite) TestQueryV3(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(
+			func(_ string, _ int, _, _ string, _, _ interface{}) error {
+				c.Errorf("shouldn't be called")
+				return nil
+			},
+		), 3}
+	annotationsClient := annotations.NewClient(apiCaller)
+	_, err := annotationsClient.Query("env=prod")
+	c.Assert(err, gc.ErrorMatches, "Query on v3 facade not supported")
+}
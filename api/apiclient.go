@@ -158,6 +158,10 @@ type state struct {
 	// bakeryClient holds the client that will be used to
 	// authorize macaroon based login requests.
 	bakeryClient *httpbakery.Client
+
+	// proxy returns the proxy to use for websocket streams
+	// to the API server.
+	proxy func(*http.Request) (*url.URL, error)
 }
 
 // RedirectError is returned from Open when the controller
@@ -233,9 +237,15 @@ func Open(info *Info, opts DialOpts) (Connection, error) {
 	// Technically when there's no CACert, we don't need this
 	// machinery, because we could just use http.DefaultTransport
 	// for everything, but it's easier just to leave it in place.
+	primary := utils.NewHttpTLSTransport(dialResult.tlsConfig)
+	if opts.Proxy != nil {
+		if transport, ok := primary.(*http.Transport); ok {
+			transport.Proxy = http.ProxyURL(opts.Proxy)
+		}
+	}
 	bakeryClient.Client.Transport = &hostSwitchingTransport{
 		primaryHost: dialResult.addr,
-		primary:     utils.NewHttpTLSTransport(dialResult.tlsConfig),
+		primary:     primary,
 		fallback:    http.DefaultTransport,
 	}
 
@@ -265,6 +275,7 @@ func Open(info *Info, opts DialOpts) (Connection, error) {
 		tlsConfig:    dialResult.tlsConfig,
 		bakeryClient: bakeryClient,
 		modelTag:     info.ModelTag,
+		proxy:        proxyFunc(opts),
 	}
 	if !info.SkipLogin {
 		if err := loginWithContext(dialCtx, st, info); err != nil {
@@ -406,8 +417,12 @@ func (st *state) connectStream(path string, attrs url.Values, extraHeaders http.
 	// and when/if this changes localhost should resolve to IPv6 loopback
 	// in any case (lp:1644009). Review.
 
+	getProxy := st.proxy
+	if getProxy == nil {
+		getProxy = proxyFunc(DialOpts{})
+	}
 	dialer := &websocket.Dialer{
-		Proxy:           proxy.DefaultConfig.GetProxy,
+		Proxy:           getProxy,
 		TLSClientConfig: st.tlsConfig,
 		// In order to deal with the remote side not handling message
 		// fragmentation, we default to largeish frames.
@@ -624,7 +639,7 @@ func dialAPI(ctx context.Context, info *Info, opts0 DialOpts) (*dialResult, erro
 	// Set opts.DialWebsocket and opts.Clock here rather than in open because
 	// some tests call dialAPI directly.
 	if opts.DialWebsocket == nil {
-		opts.DialWebsocket = gorillaDialWebsocket
+		opts.DialWebsocket = gorillaDialWebsocket(proxyFunc(opts0))
 	}
 	if opts.IPAddrResolver == nil {
 		opts.IPAddrResolver = net.DefaultResolver
@@ -642,6 +657,11 @@ func dialAPI(ctx context.Context, info *Info, opts0 DialOpts) (*dialResult, erro
 	// Encourage load balancing by shuffling controller addresses.
 	addrs := info.Addrs[:]
 	rand.Shuffle(len(addrs), func(i, j int) { addrs[i], addrs[j] = addrs[j], addrs[i] })
+	if opts.AddressHealth != nil {
+		// Dial the healthiest addresses first; the shuffle
+		// above still spreads load between equals.
+		addrs = opts.AddressHealth.Order(addrs)
+	}
 
 	if opts.VerifyCA != nil {
 		if err := verifyCAMulti(ctx, addrs, &opts); err != nil {
@@ -662,12 +682,34 @@ func dialAPI(ctx context.Context, info *Info, opts0 DialOpts) (*dialResult, erro
 	return dialInfo, nil
 }
 
-// gorillaDialWebsocket makes a websocket connection using the
-// gorilla websocket package. The ipAddr parameter holds the
-// actual IP address that will be contacted - the host in urlStr
-// is used only for TLS verification when tlsConfig.ServerName
-// is empty.
-func gorillaDialWebsocket(ctx context.Context, urlStr string, tlsConfig *tls.Config, ipAddr string) (jsoncodec.JSONConn, error) {
+// proxyFunc returns the function used to choose the proxy
+// through which the API servers are reached.
+func proxyFunc(opts DialOpts) func(*http.Request) (*url.URL, error) {
+	if opts.Proxy != nil {
+		return http.ProxyURL(opts.Proxy)
+	}
+	return proxy.DefaultConfig.GetProxy
+}
+
+// gorillaDialWebsocket returns a function that makes websocket
+// connections through the given proxy using the gorilla websocket
+// package.
+func gorillaDialWebsocket(
+	getProxy func(*http.Request) (*url.URL, error),
+) func(context.Context, string, *tls.Config, string) (jsoncodec.JSONConn, error) {
+	return func(ctx context.Context, urlStr string, tlsConfig *tls.Config, ipAddr string) (jsoncodec.JSONConn, error) {
+		return gorillaDial(ctx, urlStr, tlsConfig, ipAddr, getProxy)
+	}
+}
+
+// gorillaDial makes a websocket connection using the gorilla
+// websocket package. The ipAddr parameter holds the actual IP
+// address that will be contacted - the host in urlStr is used
+// only for TLS verification when tlsConfig.ServerName is empty.
+func gorillaDial(
+	ctx context.Context, urlStr string, tlsConfig *tls.Config, ipAddr string,
+	getProxy func(*http.Request) (*url.URL, error),
+) (jsoncodec.JSONConn, error) {
 	url, err := url.Parse(urlStr)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -685,7 +727,7 @@ func gorillaDialWebsocket(ctx context.Context, urlStr string, tlsConfig *tls.Con
 			}
 			return netDialer.DialContext(ctx, netw, addr)
 		},
-		Proxy:            proxy.DefaultConfig.GetProxy,
+		Proxy:            getProxy,
 		HandshakeTimeout: 45 * time.Second,
 		TLSClientConfig:  tlsConfig,
 		// In order to deal with the remote side not handling message
@@ -1093,7 +1135,9 @@ func (d dialer) dial(_ <-chan struct{}) (io.Closer, error) {
 	a := retry.StartWithCancel(d.openAttempt, d.opts.Clock, d.ctx.Done())
 	var lastErr error = nil
 	for a.Next() {
+		start := d.opts.Clock.Now()
 		conn, tlsConfig, err := d.dial1()
+		d.recordHealth(start, err)
 		if err == nil {
 			return &dialResult{
 				conn:      conn,
@@ -1116,10 +1160,27 @@ func (d dialer) dial(_ <-chan struct{}) (io.Closer, error) {
 	return nil, errors.Trace(lastErr)
 }
 
+// recordHealth records the outcome of a dial attempt started at the
+// given time in the dial options' AddressHealth, if there is one.
+// Attempts abandoned because another address was dialled first are
+// not counted against the address.
+func (d dialer) recordHealth(start time.Time, err error) {
+	health := d.opts.AddressHealth
+	if health == nil {
+		return
+	}
+	if err == nil {
+		health.RecordSuccess(d.addr, d.opts.Clock.Now().Sub(start))
+	} else if d.ctx.Err() == nil {
+		health.RecordFailure(d.addr)
+	}
+}
+
 // dial1 makes a single dial attempt.
 func (d dialer) dial1() (jsoncodec.JSONConn, *tls.Config, error) {
 	tlsConfig := NewTLSConfig(d.opts.certPool)
 	tlsConfig.InsecureSkipVerify = d.opts.InsecureSkipVerify
+	tlsConfig.ClientSessionCache = d.opts.TLSSessionCache
 	if d.opts.certPool == nil {
 		tlsConfig.ServerName = d.serverName
 	}
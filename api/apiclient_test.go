@@ -128,6 +128,44 @@ func (s *apiclientSuite) TestDialAPIWithProxy(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "unable to connect to API: I'm a teapot")
 }
 
+func (s *apiclientSuite) TestDialAPIWithAPIProxy(c *gc.C) {
+	info := s.APIInfo(c)
+	fakeAddr := "testing.invalid:1234"
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "CONNECT" {
+			http.Error(w, fmt.Sprintf("invalid method %s", r.Method), http.StatusMethodNotAllowed)
+			return
+		}
+		if r.URL.Host != fakeAddr {
+			http.Error(w, fmt.Sprintf("unexpected host %s", r.URL.Host), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "🍵", http.StatusTeapot)
+	}
+	proxyServer := httptest.NewServer(http.HandlerFunc(handler))
+	defer proxyServer.Close()
+
+	// The API proxy is used in place of the process's proxy settings.
+	err := proxy.DefaultConfig.Set(proxyutils.Settings{
+		Https: "127.0.0.1:1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer proxy.DefaultConfig.Set(proxyutils.Settings{})
+
+	apiProxy, err := proxy.ParseAPIProxy(proxyServer.URL)
+	c.Assert(err, jc.ErrorIsNil)
+	opts := api.DialOpts{
+		IPAddrResolver: apitesting.IPAddrResolverMap{
+			"testing.invalid": {"0.1.1.1"},
+		},
+		Proxy: apiProxy,
+	}
+	info.Addrs = []string{fakeAddr}
+	_, _, err = api.DialAPI(info, opts)
+	c.Assert(err, gc.ErrorMatches, "unable to connect to API: I'm a teapot")
+}
+
 func (s *apiclientSuite) TestDialAPIMultipleError(c *gc.C) {
 	var addrs []string
 
@@ -755,6 +793,29 @@ func (s *apiclientSuite) TestDNSCacheUsed(c *gc.C) {
 	c.Assert(conn.IPAddr(), gc.Equals, "0.1.1.1:1234")
 }
 
+func (s *apiclientSuite) TestTLSSessionCacheUsed(c *gc.C) {
+	var sessions tls.ClientSessionCache
+	fakeDialer := func(ctx context.Context, urlStr string, tlsConfig *tls.Config, ipAddr string) (jsoncodec.JSONConn, error) {
+		sessions = tlsConfig.ClientSessionCache
+		return fakeConn{}, nil
+	}
+	cache := tls.NewLRUClientSessionCache(0)
+	conn, err := api.Open(&api.Info{
+		Addrs: []string{
+			"0.1.2.3:1234",
+		},
+		SkipLogin: true,
+		CACert:    jtesting.CACert,
+	}, api.DialOpts{
+		DialWebsocket:   fakeDialer,
+		IPAddrResolver:  apitesting.IPAddrResolverMap{},
+		TLSSessionCache: cache,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conn, gc.NotNil)
+	c.Assert(sessions, gc.Equals, cache)
+}
+
 func (s *apiclientSuite) TestNumericAddressIsNotAddedToCache(c *gc.C) {
 	fakeDialer := func(ctx context.Context, urlStr string, tlsConfig *tls.Config, ipAddr string) (jsoncodec.JSONConn, error) {
 		return fakeConn{}, nil
@@ -1080,6 +1141,31 @@ func (s *apiclientSuite) TestWithUnresolvableAddrAfterCacheFallback(c *gc.C) {
 	c.Assert(dialedReal, jc.IsTrue)
 }
 
+func (s *apiclientSuite) TestDialRecordsAddressHealth(c *gc.C) {
+	fakeDialer := func(ctx context.Context, urlStr string, tlsConfig *tls.Config, ipAddr string) (jsoncodec.JSONConn, error) {
+		return nil, errors.Errorf("cannot dial %s", ipAddr)
+	}
+	health := api.NewAddressHealth(clock.WallClock, nil)
+	_, err := api.Open(&api.Info{
+		Addrs: []string{
+			"0.1.1.1:1234",
+			"0.2.2.2:1234",
+		},
+		SkipLogin: true,
+		CACert:    jtesting.CACert,
+	}, api.DialOpts{
+		DialWebsocket: fakeDialer,
+		AddressHealth: health,
+	})
+	c.Assert(err, gc.ErrorMatches, `unable to connect to API: cannot dial .*`)
+
+	addresses := health.Report()["addresses"].(map[string]interface{})
+	c.Assert(addresses, gc.HasLen, 2)
+	for _, addr := range []string{"0.1.1.1:1234", "0.2.2.2:1234"} {
+		c.Check(addresses[addr].(map[string]interface{})["failures"], gc.Equals, 1)
+	}
+}
+
 func (s *apiclientSuite) TestAPICallNoError(c *gc.C) {
 	clock := &fakeClock{}
 	conn := api.NewTestingState(api.TestingStateParams{
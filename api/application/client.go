@@ -996,12 +996,16 @@ func (c *Client) SetRelationSuspended(relationIds []int, suspended bool, message
 
 // Consume adds a remote application to the model.
 func (c *Client) Consume(arg crossmodel.ConsumeApplicationArgs) (string, error) {
+	if apiVersion := c.BestAPIVersion(); arg.EncryptRelationData && apiVersion < 16 {
+		return "", errors.NotSupportedf("EncryptRelationData for Application facade v%v", apiVersion)
+	}
 	var consumeRes params.ErrorResults
 	args := params.ConsumeApplicationArgs{
 		Args: []params.ConsumeApplicationArg{{
 			ApplicationOfferDetails: arg.Offer,
 			ApplicationAlias:        arg.ApplicationAlias,
 			Macaroon:                arg.Macaroon,
+			EncryptRelationData:     arg.EncryptRelationData,
 		}},
 	}
 	if arg.ControllerInfo != nil {
@@ -1125,6 +1129,82 @@ func (c *Client) ResolveUnitErrors(units []string, all, retry bool) error {
 	return errors.Trace(results.Combine())
 }
 
+// DrainUnits asks the given units to drain ahead of their removal, by
+// running their charms' pre-remove hooks.
+func (c *Client) DrainUnits(units []names.UnitTag) ([]params.ErrorResult, error) {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 14 {
+		return nil, errors.NotSupportedf("DrainUnits for Application facade v%v", apiVersion)
+	}
+	args := params.Entities{Entities: make([]params.Entity, len(units))}
+	for i, unit := range units {
+		args.Entities[i].Tag = unit.String()
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("DrainUnits", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != len(units) {
+		return nil, errors.Errorf("expected %d results, got %d", len(units), n)
+	}
+	return results.Results, nil
+}
+
+// UnitsDrained reports whether each of the given units has completed its
+// pre-remove hook, and is ready to be removed.
+func (c *Client) UnitsDrained(units []names.UnitTag) ([]params.BoolResult, error) {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 14 {
+		return nil, errors.NotSupportedf("UnitsDrained for Application facade v%v", apiVersion)
+	}
+	args := params.Entities{Entities: make([]params.Entity, len(units))}
+	for i, unit := range units {
+		args.Entities[i].Tag = unit.String()
+	}
+	var results params.BoolResults
+	if err := c.facade.FacadeCall("UnitsDrained", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != len(units) {
+		return nil, errors.Errorf("expected %d results, got %d", len(units), n)
+	}
+	return results.Results, nil
+}
+
+// UpdateApplicationStorage updates the storage constraints used to
+// provision storage for the application's future units. Zero pool, size
+// or count values leave the current value unchanged.
+func (c *Client) UpdateApplicationStorage(application string, cons map[string]storage.Constraints) error {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 15 {
+		return errors.NotSupportedf("UpdateApplicationStorage for Application facade v%v", apiVersion)
+	}
+	storageConstraints := make(map[string]params.StorageConstraints)
+	for name, cons := range cons {
+		size, count := cons.Size, cons.Count
+		var sizePtr, countPtr *uint64
+		if size > 0 {
+			sizePtr = &size
+		}
+		if count > 0 {
+			countPtr = &count
+		}
+		storageConstraints[name] = params.StorageConstraints{
+			Pool:  cons.Pool,
+			Size:  sizePtr,
+			Count: countPtr,
+		}
+	}
+	args := params.ApplicationStorageUpdateArgs{
+		Args: []params.ApplicationStorageUpdate{{
+			ApplicationTag:     names.NewApplicationTag(application).String(),
+			StorageConstraints: storageConstraints,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("UpdateApplicationStorage", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
 func validateApplicationScale(scale, scaleChange int) error {
 	if scale < 0 && scaleChange == 0 {
 		return errors.NotValidf("scale < 0")
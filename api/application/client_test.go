@@ -758,6 +758,46 @@ func (s *applicationSuite) TestConsume(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *applicationSuite) TestConsumeEncryptRelationData(c *gc.C) {
+	offer := params.ApplicationOfferDetails{
+		SourceModelTag: "source model",
+		OfferName:      "an offer",
+		OfferUUID:      "offer-uuid",
+	}
+	var called bool
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, result interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "Consume")
+		c.Assert(a, jc.DeepEquals, params.ConsumeApplicationArgs{
+			Args: []params.ConsumeApplicationArg{{
+				ApplicationOfferDetails: offer,
+				EncryptRelationData:     true,
+			}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{Results: []params.ErrorResult{{}}}
+		return nil
+	}, 16)
+	name, err := client.Consume(crossmodel.ConsumeApplicationArgs{
+		Offer:               offer,
+		EncryptRelationData: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(name, gc.Equals, "an offer")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestConsumeEncryptRelationDataNotSupported(c *gc.C) {
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, result interface{}) error {
+		c.Fail()
+		return nil
+	}, 15)
+	_, err := client.Consume(crossmodel.ConsumeApplicationArgs{
+		Offer:               params.ApplicationOfferDetails{OfferName: "an offer"},
+		EncryptRelationData: true,
+	})
+	c.Assert(err, gc.ErrorMatches, "EncryptRelationData for Application facade v15 not supported")
+}
+
 func (s *applicationSuite) TestDestroyRelation(c *gc.C) {
 	false_ := false
 	true_ := true
@@ -1947,3 +1987,118 @@ func (s *applicationSuite) TestUnexposeVersionChecks(c *gc.C) {
 		}
 	}
 }
+
+func (s *applicationSuite) TestDrainUnits(c *gc.C) {
+	called := false
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "DrainUnits")
+		c.Assert(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{
+				{Tag: "unit-foo-0"},
+				{Tag: "unit-bar-1"},
+			}})
+		result, ok := response.(*params.ErrorResults)
+		c.Assert(ok, jc.IsTrue)
+		result.Results = []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "boom"}},
+		}
+		return nil
+	}, 14)
+	results, err := client.DrainUnits([]names.UnitTag{
+		names.NewUnitTag("foo/0"),
+		names.NewUnitTag("bar/1"),
+	})
+	c.Check(called, jc.IsTrue)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "boom"}},
+	})
+}
+
+func (s *applicationSuite) TestDrainUnitsNotSupported(c *gc.C) {
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fail()
+		return nil
+	}, 13)
+	_, err := client.DrainUnits([]names.UnitTag{names.NewUnitTag("foo/0")})
+	c.Assert(err, gc.ErrorMatches, "DrainUnits for Application facade v13 not supported")
+}
+
+func (s *applicationSuite) TestUnitsDrained(c *gc.C) {
+	called := false
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "UnitsDrained")
+		c.Assert(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{
+				{Tag: "unit-foo-0"},
+				{Tag: "unit-bar-1"},
+			}})
+		result, ok := response.(*params.BoolResults)
+		c.Assert(ok, jc.IsTrue)
+		result.Results = []params.BoolResult{
+			{Result: true},
+			{},
+		}
+		return nil
+	}, 14)
+	results, err := client.UnitsDrained([]names.UnitTag{
+		names.NewUnitTag("foo/0"),
+		names.NewUnitTag("bar/1"),
+	})
+	c.Check(called, jc.IsTrue)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.BoolResult{
+		{Result: true},
+		{},
+	})
+}
+
+func (s *applicationSuite) TestUnitsDrainedResultMismatch(c *gc.C) {
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		result, ok := response.(*params.BoolResults)
+		c.Assert(ok, jc.IsTrue)
+		result.Results = []params.BoolResult{{}, {}}
+		return nil
+	}, 14)
+	_, err := client.UnitsDrained([]names.UnitTag{names.NewUnitTag("foo/0")})
+	c.Assert(err, gc.ErrorMatches, "expected 1 results, got 2")
+}
+
+func (s *applicationSuite) TestUpdateApplicationStorage(c *gc.C) {
+	called := false
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "UpdateApplicationStorage")
+		size := uint64(2048)
+		c.Assert(a, jc.DeepEquals, params.ApplicationStorageUpdateArgs{
+			Args: []params.ApplicationStorageUpdate{{
+				ApplicationTag: "application-foo",
+				StorageConstraints: map[string]params.StorageConstraints{
+					"data": {Pool: "ebs", Size: &size},
+				},
+			}},
+		})
+		result, ok := response.(*params.ErrorResults)
+		c.Assert(ok, jc.IsTrue)
+		result.Results = []params.ErrorResult{{Error: &params.Error{Message: "boom"}}}
+		return nil
+	}, 15)
+	err := client.UpdateApplicationStorage("foo", map[string]storage.Constraints{
+		"data": {Pool: "ebs", Size: 2048},
+	})
+	c.Check(called, jc.IsTrue)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestUpdateApplicationStorageNotSupported(c *gc.C) {
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fail()
+		return nil
+	}, 14)
+	err := client.UpdateApplicationStorage("foo", map[string]storage.Constraints{"data": {Pool: "ebs"}})
+	c.Assert(err, gc.ErrorMatches, "UpdateApplicationStorage for Application facade v14 not supported")
+}
@@ -0,0 +1,24 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Verify asks the controller to restore the identified backup into a
+// throwaway database and check that it is usable.
+func (c *Client) Verify(id string) (*params.BackupsVerifyResult, error) {
+	if c.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("verifying backups on this version of Juju")
+	}
+	var result params.BackupsVerifyResult
+	args := params.BackupsVerifyArgs{ID: id}
+	if err := c.facade.FacadeCall("Verify", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &result, nil
+}
@@ -55,6 +55,10 @@ type Machine struct {
 	// HAPrimary indicates whether this machine has a primary mongo instance in replicaset and,
 	// thus, can be considered a primary controller machine in HA setup.
 	HAPrimary *bool
+	// CustomizationStatus and CustomizationMessage report the progress of
+	// the controller customization script on a controller machine.
+	CustomizationStatus  string
+	CustomizationMessage string
 }
 
 // ModelInfo holds information about a model.
@@ -5,6 +5,8 @@
 package charms
 
 import (
+	"io/ioutil"
+
 	"github.com/juju/charm/v9"
 	"github.com/juju/errors"
 	"gopkg.in/macaroon.v2"
@@ -131,6 +133,38 @@ func (c *Client) GetDownloadInfo(curl *charm.URL, origin apicharm.Origin, mac *m
 	}, nil
 }
 
+// DownloadBundle has the controller download the given CharmHub bundle
+// and writes the resulting archive to archivePath, so that the client
+// itself never needs to reach CharmHub.
+// DownloadBundle is only supported in version 5 and above.
+func (c *Client) DownloadBundle(curl *charm.URL, origin apicharm.Origin, archivePath string) (charm.Bundle, error) {
+	if c.facade.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("downloading bundles via the controller")
+	}
+
+	args := params.CharmURLAndOrigins{
+		Entities: []params.CharmURLAndOrigin{{
+			CharmURL: curl.String(),
+			Origin:   origin.ParamsCharmOrigin(),
+		}},
+	}
+	var results params.BundleArchiveResults
+	if err := c.facade.FacadeCall("DownloadBundles", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if num := len(results.Results); num != 1 {
+		return nil, errors.Errorf("expected one result, received %d", num)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	if err := ioutil.WriteFile(archivePath, result.Archive, 0644); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return charm.ReadBundleArchive(archivePath)
+}
+
 // AddCharm adds the given charm URL (which must include revision) to
 // the model, if it does not exist yet. Local charms are not
 // supported, only charm store and charm hub URLs. See also AddLocalCharm()
@@ -4,6 +4,9 @@
 package charms_test
 
 import (
+	"io/ioutil"
+	"path/filepath"
+
 	"github.com/golang/mock/gomock"
 	charm "github.com/juju/charm/v9"
 	csparams "github.com/juju/charmrepo/v7/csclient/params"
@@ -17,6 +20,7 @@ import (
 	apicharm "github.com/juju/juju/api/common/charm"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/arch"
+	"github.com/juju/juju/testcharms"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -191,6 +195,88 @@ func (s *charmsMockSuite) TestGetDownloadInfoIsNotSupported(c *gc.C) {
 	c.Assert(errors.IsNotSupported(err), jc.IsTrue)
 }
 
+func (s *charmsMockSuite) TestDownloadBundle(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	bundle := testcharms.RepoWithSeries("bionic").BundleArchive(c.MkDir(), "wordpress-simple")
+	archive, err := ioutil.ReadFile(bundle.Path)
+	c.Assert(err, jc.ErrorIsNil)
+
+	curl := charm.MustParseURL("ch:wordpress-simple")
+	origin := apicharm.Origin{Source: "charm-hub", Type: "bundle", Risk: "stable"}
+	facadeArgs := params.CharmURLAndOrigins{
+		Entities: []params.CharmURLAndOrigin{{
+			CharmURL: curl.String(),
+			Origin:   origin.ParamsCharmOrigin(),
+		}},
+	}
+	var results params.BundleArchiveResults
+	p := params.BundleArchiveResults{
+		Results: []params.BundleArchiveResult{{
+			Archive: archive,
+			Origin:  origin.ParamsCharmOrigin(),
+		}},
+	}
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(5)
+	mockFacadeCaller.EXPECT().FacadeCall("DownloadBundles", facadeArgs, &results).SetArg(2, p).Return(nil)
+
+	client := charms.NewClientWithFacade(mockFacadeCaller)
+	archivePath := filepath.Join(c.MkDir(), "bundle.zip")
+	got, err := client.DownloadBundle(curl, origin, archivePath)
+	c.Assert(err, jc.ErrorIsNil)
+
+	written, err := ioutil.ReadFile(archivePath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(written, gc.DeepEquals, archive)
+	c.Assert(got.Data(), jc.DeepEquals, bundle.Data())
+	c.Assert(got.ReadMe(), gc.Equals, bundle.ReadMe())
+}
+
+func (s *charmsMockSuite) TestDownloadBundleError(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	curl := charm.MustParseURL("ch:a-bundle")
+	origin := apicharm.Origin{Source: "charm-hub", Risk: "stable"}
+	facadeArgs := params.CharmURLAndOrigins{
+		Entities: []params.CharmURLAndOrigin{{
+			CharmURL: curl.String(),
+			Origin:   origin.ParamsCharmOrigin(),
+		}},
+	}
+	var results params.BundleArchiveResults
+	p := params.BundleArchiveResults{
+		Results: []params.BundleArchiveResult{{
+			Error: &params.Error{Message: "bundle not found"},
+		}},
+	}
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(5)
+	mockFacadeCaller.EXPECT().FacadeCall("DownloadBundles", facadeArgs, &results).SetArg(2, p).Return(nil)
+
+	client := charms.NewClientWithFacade(mockFacadeCaller)
+	_, err := client.DownloadBundle(curl, origin, filepath.Join(c.MkDir(), "bundle.zip"))
+	c.Assert(err, gc.ErrorMatches, "bundle not found")
+}
+
+func (s *charmsMockSuite) TestDownloadBundleIsNotSupported(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	curl := charm.MustParseURL("ch:a-bundle")
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(4)
+
+	client := charms.NewClientWithFacade(mockFacadeCaller)
+	_, err := client.DownloadBundle(curl, apicharm.Origin{Source: "charm-hub"}, filepath.Join(c.MkDir(), "bundle.zip"))
+	c.Assert(errors.IsNotSupported(err), jc.IsTrue)
+}
+
 func (s *charmsMockSuite) TestAddCharm(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
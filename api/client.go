@@ -5,6 +5,7 @@ package api
 
 import (
 	"archive/zip"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -50,14 +51,23 @@ type Client struct {
 
 // Status returns the status of the juju model.
 func (c *Client) Status(patterns []string) (*params.FullStatus, error) {
+	return c.FilteredStatus(params.StatusParams{Patterns: patterns})
+}
+
+// FilteredStatus returns the status of the juju model, restricted to the
+// entities matching the patterns and, if any are given, to the machines
+// with an address in one of the spaces or subnets and their units.
+func (c *Client) FilteredStatus(args params.StatusParams) (*params.FullStatus, error) {
+	if (len(args.Spaces) > 0 || len(args.Subnets) > 0) && c.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("filtering status by space or subnet on this version of Juju")
+	}
 	var result params.FullStatus
-	p := params.StatusParams{Patterns: patterns}
-	if err := c.facade.FacadeCall("FullStatus", p, &result); err != nil {
+	if err := c.facade.FacadeCall("FullStatus", args, &result); err != nil {
 		return nil, err
 	}
 	// Older servers don't fill out model type, but
 	// we know a missing type is an "iaas" model.
-	if result.Model.Type == "" {
+	if result.Model.Type == "" && !result.NotModified {
 		result.Model.Type = model.IAAS.String()
 	}
 	return &result, nil
@@ -274,6 +284,24 @@ func (c *Client) WatchAll() (*AllWatcher, error) {
 	return NewAllWatcher(c.st, &info.AllWatcherId), nil
 }
 
+// WatchEntities returns an AllWatcher that reports coalesced deltas for
+// just the given entities, from which you can request the Next collection
+// of Deltas. Watching an application also watches its units.
+func (c *Client) WatchEntities(tags ...names.Tag) (*AllWatcher, error) {
+	if c.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("watching groups of entities on this version of Juju")
+	}
+	args := params.Entities{Entities: make([]params.Entity, len(tags))}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var info params.AllWatcherId
+	if err := c.facade.FacadeCall("WatchEntities", args, &info); err != nil {
+		return nil, err
+	}
+	return NewAllWatcher(c.st, &info.AllWatcherId), nil
+}
+
 // Close closes the Client's underlying State connection
 // Client is unique among the api.State facades in closing its own State
 // connection, but it is conventional to use a Client object without any access
@@ -330,7 +358,10 @@ func (c *Client) AddLocalCharm(curl *charm.URL, ch charm.Charm, force bool) (*ch
 	}
 
 	// Package the charm for uploading.
-	var archive *os.File
+	var (
+		archive   *os.File
+		signature []byte
+	)
 	switch ch := ch.(type) {
 	case *charm.CharmDir:
 		var err error
@@ -351,6 +382,9 @@ func (c *Client) AddLocalCharm(curl *charm.URL, ch charm.Charm, force bool) (*ch
 			return nil, errors.Annotate(err, "cannot read charm archive")
 		}
 		defer archive.Close()
+		if signature, err = readCharmSignature(ch.Path); err != nil {
+			return nil, errors.Trace(err)
+		}
 	default:
 		return nil, errors.Errorf("unknown charm type %T", ch)
 	}
@@ -363,13 +397,32 @@ func (c *Client) AddLocalCharm(curl *charm.URL, ch charm.Charm, force bool) (*ch
 		return nil, errors.Errorf("invalid charm %q: has no hooks nor dispatch file", curl.Name)
 	}
 
-	curl, err = c.UploadCharm(curl, archive)
+	curl, err = c.uploadCharm(curl, archive, signature)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return curl, nil
 }
 
+// charmSignatureExtensions are the extensions of the detached signature
+// files which may sit alongside a charm archive.
+var charmSignatureExtensions = []string{".sig", ".asc"}
+
+// readCharmSignature returns the contents of the detached signature
+// file alongside the charm archive at path, or nil if there is none.
+func readCharmSignature(path string) ([]byte, error) {
+	for _, ext := range charmSignatureExtensions {
+		data, err := ioutil.ReadFile(path + ext)
+		if err == nil {
+			return data, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, errors.Annotate(err, "cannot read charm signature")
+		}
+	}
+	return nil, nil
+}
+
 var hasHooksOrDispatch = hasHooksFolderOrDispatchFile
 
 func hasHooksFolderOrDispatchFile(name string) (bool, error) {
@@ -407,10 +460,17 @@ func hasHooksFolderOrDispatchFile(name string) (bool, error) {
 
 // UploadCharm sends the content to the API server using an HTTP post.
 func (c *Client) UploadCharm(curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+	return c.uploadCharm(curl, content, nil)
+}
+
+func (c *Client) uploadCharm(curl *charm.URL, content io.ReadSeeker, signature []byte) (*charm.URL, error) {
 	args := url.Values{}
 	args.Add("series", curl.Series)
 	args.Add("schema", curl.Schema)
 	args.Add("revision", strconv.Itoa(curl.Revision))
+	if len(signature) > 0 {
+		args.Add("signature", base64.StdEncoding.EncodeToString(signature))
+	}
 	apiURI := url.URL{Path: "/charms", RawQuery: args.Encode()}
 
 	contentType := "application/zip"
@@ -687,6 +747,50 @@ func (c *Client) WatchDebugLog(args common.DebugLogParams) (<-chan common.LogMes
 	return common.StreamDebugLog(c.st, args)
 }
 
+// StatusHistoryStreamParams holds parameters for WatchStatusHistory.
+type StatusHistoryStreamParams struct {
+	// Backlog is the number of the unit's most recent status
+	// transitions to send before any new ones.
+	Backlog uint
+
+	// NoTail tells the server to only return the backlog, and not
+	// wait for new transitions.
+	NoTail bool
+}
+
+// WatchStatusHistory returns a channel of the unit agent and workload
+// status transitions of the named unit, as they happen. The channel is
+// closed when the connection to the server is closed.
+func (c *Client) WatchStatusHistory(unitName string, args StatusHistoryStreamParams) (<-chan params.StatusHistoryRecord, error) {
+	attrs := url.Values{"unit": {unitName}}
+	if args.Backlog > 0 {
+		attrs.Set("backlog", fmt.Sprint(args.Backlog))
+	}
+	if args.NoTail {
+		attrs.Set("noTail", fmt.Sprint(args.NoTail))
+	}
+
+	connection, err := c.st.ConnectStream("/statushistory", attrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	records := make(chan params.StatusHistoryRecord)
+	go func() {
+		defer close(records)
+		defer connection.Close()
+
+		for {
+			var record params.StatusHistoryRecord
+			if err := connection.ReadJSON(&record); err != nil {
+				return
+			}
+			records <- record
+		}
+	}()
+	return records, nil
+}
+
 // lxdCharmProfiler massages a charm.Charm into a LXDProfiler inside of the
 // core package.
 type lxdCharmProfiler struct {
@@ -5,10 +5,19 @@ package api_test
 
 import (
 	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math/big"
 	"net"
 	"net/http"
 	"net/url"
@@ -34,6 +43,7 @@ import (
 	"github.com/juju/juju/api/common"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
 	jujunames "github.com/juju/juju/juju/names"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/rpc"
@@ -169,6 +179,46 @@ func (s *clientSuite) TestAddLocalCharm(c *gc.C) {
 	c.Assert(savedURL.String(), gc.Equals, curl.WithRevision(43).String())
 }
 
+func (s *clientSuite) TestAddLocalCharmWithSignature(c *gc.C) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	c.Assert(err, jc.ErrorIsNil)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.CharmSignaturePolicy: controller.CharmSignaturePolicyRequire,
+		controller.CharmSigningKeys:     string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	charmArchive := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+	curl := charm.MustParseURL(
+		fmt.Sprintf("local:quantal/%s-%d", charmArchive.Meta().Name, charmArchive.Revision()),
+	)
+	client := s.APIState.Client()
+
+	// An unsigned charm is rejected.
+	_, err = client.AddLocalCharm(curl, charmArchive, false)
+	c.Assert(err, gc.ErrorMatches, `.*charm signature required by controller policy`)
+
+	// Sign the archive as cosign does.
+	data, err := ioutil.ReadFile(charmArchive.Path)
+	c.Assert(err, jc.ErrorIsNil)
+	digest := sha256.Sum256(data)
+	r, sv, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	c.Assert(err, jc.ErrorIsNil)
+	sig, err := asn1.Marshal(struct{ R, S *big.Int }{r, sv})
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(charmArchive.Path+".sig", []byte(base64.StdEncoding.EncodeToString(sig)), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	savedURL, err := client.AddLocalCharm(curl, charmArchive, false)
+	c.Assert(err, jc.ErrorIsNil)
+	ch, err := s.State.Charm(savedURL)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ch.Signature(), gc.NotNil)
+	c.Assert(ch.Signature().Format, gc.Equals, "cosign")
+}
+
 func (s *clientSuite) TestAddLocalCharmFindingHooksError(c *gc.C) {
 	s.assertAddLocalCharmFailed(c,
 		func(string) (bool, error) {
@@ -663,6 +713,27 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 	})
 }
 
+func (s *clientSuite) TestWatchStatusHistoryParamsEncoded(c *gc.C) {
+	catcher := urlCatcher{}
+	s.PatchValue(api.WebsocketDial, catcher.recordLocation)
+
+	client := s.APIState.Client()
+	_, err := client.WatchStatusHistory("mysql/0", api.StatusHistoryStreamParams{
+		Backlog: 20,
+		NoTail:  true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	connectURL, err := url.Parse(catcher.location)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(connectURL.Path, gc.Matches, ".*/statushistory")
+	c.Assert(connectURL.Query(), jc.DeepEquals, url.Values{
+		"unit":    {"mysql/0"},
+		"backlog": {"20"},
+		"noTail":  {"true"},
+	})
+}
+
 func (s *clientSuite) TestConnectStreamAtUUIDPath(c *gc.C) {
 	catcher := urlCatcher{}
 	s.PatchValue(api.WebsocketDial, catcher.recordLocation)
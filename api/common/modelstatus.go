@@ -105,14 +105,16 @@ func constructModelStatus(m names.ModelTag, owner names.UserTag, r params.ModelS
 			result.CoreCount += int(*mm.Hardware.Cores)
 		}
 		result.Machines[j] = base.Machine{
-			Id:          mm.Id,
-			InstanceId:  mm.InstanceId,
-			DisplayName: mm.DisplayName,
-			HasVote:     mm.HasVote,
-			WantsVote:   mm.WantsVote,
-			Status:      mm.Status,
-			Message:     mm.Message,
-			HAPrimary:   mm.HAPrimary,
+			Id:                   mm.Id,
+			InstanceId:           mm.InstanceId,
+			DisplayName:          mm.DisplayName,
+			HasVote:              mm.HasVote,
+			WantsVote:            mm.WantsVote,
+			Status:               mm.Status,
+			Message:              mm.Message,
+			HAPrimary:            mm.HAPrimary,
+			CustomizationStatus:  mm.CustomizationStatus,
+			CustomizationMessage: mm.CustomizationMessage,
 		}
 	}
 	return result
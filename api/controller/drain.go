@@ -0,0 +1,29 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// DrainController asks the API server on the given controller machine
+// to drain its connections and restart. Agents and clients connected
+// to it reconnect to the other controllers, so only drain a controller
+// when it has HA peers to take over.
+func (c *Client) DrainController(machine names.MachineTag) error {
+	if c.BestAPIVersion() < 13 {
+		return errors.NotSupportedf("draining controllers by this version of Juju")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: machine.String()}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("DrainControllers", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
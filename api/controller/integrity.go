@@ -0,0 +1,25 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// VerifyIntegrity runs consistency checks across the collections of every
+// model on the controller, and reports the problems found. If repair is
+// true, the problems which can be repaired safely are also repaired.
+func (c *Client) VerifyIntegrity(repair bool) (params.IntegrityReport, error) {
+	if c.BestAPIVersion() < 11 {
+		return params.IntegrityReport{}, errors.NotSupportedf("verifying integrity by this version of Juju")
+	}
+	var result params.IntegrityReport
+	err := c.facade.FacadeCall("VerifyIntegrity", params.VerifyIntegrityArgs{Repair: repair}, &result)
+	if err != nil {
+		return params.IntegrityReport{}, errors.Trace(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *Suite) TestVerifyIntegrityPriorV11(c *gc.C) {
+	called := false
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	_, err := client.VerifyIntegrity(false)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *Suite) TestVerifyIntegrity(c *gc.C) {
+	report := params.IntegrityReport{
+		Checks: []string{"refcounts", "life", "bindings"},
+		Problems: []params.IntegrityProblem{{
+			Check:      "refcounts",
+			Collection: "applications",
+			ModelTag:   "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			Id:         "mysql",
+			Detail:     "unit count is 2 but 1 units found",
+			Repairable: true,
+			Repaired:   true,
+		}},
+	}
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 11,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "VerifyIntegrity")
+			c.Check(arg, jc.DeepEquals, params.VerifyIntegrityArgs{Repair: true})
+			c.Assert(result, gc.FitsTypeOf, &params.IntegrityReport{})
+			*(result.(*params.IntegrityReport)) = report
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	result, err := client.VerifyIntegrity(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, report)
+}
+
+func (s *Suite) TestVerifyIntegrityCallError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 11,
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			return errors.New("boom")
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.VerifyIntegrity(false)
+	c.Check(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,25 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// FindOrphanedDocuments returns the documents, in every model on the
+// controller, which refer to entities that no longer exist. If remove is
+// true, the orphaned documents found are also removed.
+func (c *Client) FindOrphanedDocuments(remove bool) (params.OrphanedDocumentsResult, error) {
+	if c.BestAPIVersion() < 10 {
+		return params.OrphanedDocumentsResult{}, errors.NotSupportedf("finding orphaned documents by this version of Juju")
+	}
+	var result params.OrphanedDocumentsResult
+	err := c.facade.FacadeCall("FindOrphanedDocuments", params.FindOrphanedDocumentsArgs{Remove: remove}, &result)
+	if err != nil {
+		return params.OrphanedDocumentsResult{}, errors.Trace(err)
+	}
+	return result, nil
+}
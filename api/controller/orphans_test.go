@@ -0,0 +1,79 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *Suite) TestFindOrphanedDocumentsPriorV10(c *gc.C) {
+	called := false
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 9,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	_, err := client.FindOrphanedDocuments(false)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *Suite) TestFindOrphanedDocuments(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "FindOrphanedDocuments")
+			c.Check(arg, jc.DeepEquals, params.FindOrphanedDocumentsArgs{Remove: true})
+			c.Assert(result, gc.FitsTypeOf, &params.OrphanedDocumentsResult{})
+
+			*(result.(*params.OrphanedDocumentsResult)) = params.OrphanedDocumentsResult{
+				Documents: []params.OrphanedDocument{{
+					Collection: "units",
+					ModelTag:   "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+					Id:         "ghost/0",
+					Reason:     `application "ghost" not found`,
+				}},
+				Removed: true,
+			}
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	result, err := client.FindOrphanedDocuments(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.OrphanedDocumentsResult{
+		Documents: []params.OrphanedDocument{{
+			Collection: "units",
+			ModelTag:   "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			Id:         "ghost/0",
+			Reason:     `application "ghost" not found`,
+		}},
+		Removed: true,
+	})
+}
+
+func (s *Suite) TestFindOrphanedDocumentsCallError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			return errors.New("boom")
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.FindOrphanedDocuments(false)
+	c.Check(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,36 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// UpgradePlan returns the upgrade steps which would be run, in order,
+// when upgrading the controller between the versions given, without
+// running them. A zero from version means the current agent version of
+// the controller model; a zero to version means the controller's version.
+// Targets restricts the plan to steps for those machine targets.
+//
+// To plan an upgrade past the controller's version, the agent binaries
+// being upgraded to must have been uploaded to the controller, since
+// only they know the steps added since.
+func (c *Client) UpgradePlan(from, to version.Number, targets []string) (params.UpgradePlanResult, error) {
+	if c.BestAPIVersion() < 12 {
+		return params.UpgradePlanResult{}, errors.NotSupportedf("planning upgrades by this version of Juju")
+	}
+	args := params.UpgradePlanArgs{
+		FromVersion: from,
+		ToVersion:   to,
+		Targets:     targets,
+	}
+	var result params.UpgradePlanResult
+	if err := c.facade.FacadeCall("UpgradePlan", args, &result); err != nil {
+		return params.UpgradePlanResult{}, errors.Trace(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *Suite) TestUpgradePlanPriorV12(c *gc.C) {
+	called := false
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 11,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	_, err := client.UpgradePlan(version.Zero, version.Zero, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *Suite) TestUpgradePlan(c *gc.C) {
+	plan := params.UpgradePlanResult{
+		FromVersion: version.MustParse("2.8.0"),
+		ToVersion:   version.MustParse("2.9.0"),
+		Steps: []params.UpgradePlanStep{{
+			TargetVersion: version.MustParse("2.9.0"),
+			Description:   "add spaces",
+			Targets:       []string{"databaseMaster"},
+			State:         true,
+		}},
+	}
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 12,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "UpgradePlan")
+			c.Check(arg, jc.DeepEquals, params.UpgradePlanArgs{
+				FromVersion: plan.FromVersion,
+				ToVersion:   plan.ToVersion,
+				Targets:     []string{"controller"},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.UpgradePlanResult{})
+			*(result.(*params.UpgradePlanResult)) = plan
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	result, err := client.UpgradePlan(plan.FromVersion, plan.ToVersion, []string{"controller"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, plan)
+}
+
+func (s *Suite) TestUpgradePlanCallError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 12,
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			return errors.New("boom")
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.UpgradePlan(version.Zero, version.Zero, nil)
+	c.Check(err, gc.ErrorMatches, "boom")
+}
@@ -4,6 +4,8 @@
 package credentialvalidator
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
@@ -109,3 +111,45 @@ func (c *Facade) WatchModelCredential() (watcher.NotifyWatcher, error) {
 	w := apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), result)
 	return w, nil
 }
+
+// CredentialExpiry describes when the cloud credential that a model uses
+// expires.
+type CredentialExpiry struct {
+	// CloudCredential is the ID of the cloud credential, if the model
+	// has one.
+	CloudCredential string
+
+	// Status is "expiring" if the credential expires within the
+	// controller's warning period, "expired" if it has expired, and
+	// empty otherwise.
+	Status string
+
+	// Expiry is when the credential expires, or the zero time if it
+	// doesn't.
+	Expiry time.Time
+}
+
+// CheckModelCredentialExpiry checks whether the cloud credential that the
+// model uses is about to expire, or has expired, having the controller
+// notify its credential expiry webhook of any change.
+func (c *Facade) CheckModelCredentialExpiry() (CredentialExpiry, error) {
+	if v := c.facade.BestAPIVersion(); v < 3 {
+		return CredentialExpiry{}, errors.NotSupportedf("CheckModelCredentialExpiry on CredentialValidator v%v", v)
+	}
+	var result params.ModelCredentialExpiry
+	if err := c.facade.FacadeCall("CheckModelCredentialExpiry", nil, &result); err != nil {
+		return CredentialExpiry{}, errors.Trace(err)
+	}
+	expiry := CredentialExpiry{Status: result.Status}
+	if result.CloudCredential != "" {
+		tag, err := names.ParseCloudCredentialTag(result.CloudCredential)
+		if err != nil {
+			return CredentialExpiry{}, errors.Trace(err)
+		}
+		expiry.CloudCredential = tag.Id()
+	}
+	if result.Expiry != nil {
+		expiry.Expiry = *result.Expiry
+	}
+	return expiry, nil
+}
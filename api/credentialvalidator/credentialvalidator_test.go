@@ -4,6 +4,8 @@
 package credentialvalidator_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	"github.com/juju/testing"
@@ -180,3 +182,38 @@ func (s *CredentialValidatorSuite) TestWatchModelCredentialCallV1(c *gc.C) {
 	_, err := client.WatchModelCredential()
 	c.Assert(err, gc.ErrorMatches, "WatchModelCredential on CredentialValidator v1 not supported")
 }
+
+func (s *CredentialValidatorSuite) TestCheckModelCredentialExpiry(c *gc.C) {
+	expiry := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CredentialValidator")
+		c.Check(request, gc.Equals, "CheckModelCredentialExpiry")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.ModelCredentialExpiry{})
+		*(result.(*params.ModelCredentialExpiry)) = params.ModelCredentialExpiry{
+			CloudCredential: credentialTag.String(),
+			Status:          "expiring",
+			Expiry:          &expiry,
+		}
+		return nil
+	})
+
+	client := credentialvalidator.NewFacade(apitesting.BestVersionCaller{apiCaller, 3})
+	result, err := client.CheckModelCredentialExpiry()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, credentialvalidator.CredentialExpiry{
+		CloudCredential: "cloud/user/credential",
+		Status:          "expiring",
+		Expiry:          expiry,
+	})
+}
+
+func (s *CredentialValidatorSuite) TestCheckModelCredentialExpiryCallV2(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("foo")
+	})
+
+	client := credentialvalidator.NewFacade(apitesting.BestVersionCaller{apiCaller, 2})
+	_, err := client.CheckModelCredentialExpiry()
+	c.Assert(err, gc.ErrorMatches, "CheckModelCredentialExpiry on CredentialValidator v2 not supported")
+}
@@ -178,6 +178,14 @@ func (c *Client) RegisterRemoteRelations(relations ...params.RegisterRemoteRelat
 		retryIndices []int
 	)
 
+	if c.BestAPIVersion() < 3 {
+		for _, arg := range relations {
+			if arg.KeyGeneration > 0 {
+				return nil, errors.NotSupportedf("relation settings encryption on the offering controller")
+			}
+		}
+	}
+
 	args = params.RegisterRemoteRelationArgs{Relations: relations}
 	// Use any previously cached discharge macaroons.
 	for i, arg := range relations {
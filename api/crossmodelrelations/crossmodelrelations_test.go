@@ -220,6 +220,24 @@ func (s *CrossModelRelationsSuite) TestRegisterRemoteRelations(c *gc.C) {
 	c.Check(callCount, gc.Equals, 2)
 }
 
+func (s *CrossModelRelationsSuite) TestRegisterRemoteRelationsKeyGenerationNotSupported(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected api call")
+			return nil
+		}),
+		BestVersion: 2,
+	}
+	client := crossmodelrelations.NewClientWithCache(apiCaller, s.cache)
+	_, err := client.RegisterRemoteRelations(params.RegisterRemoteRelationArg{
+		RelationToken: "token",
+		OfferUUID:     "offer-uuid",
+		KeyGeneration: 1,
+	})
+	c.Assert(err, gc.ErrorMatches, "relation settings encryption on the offering controller not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *CrossModelRelationsSuite) TestRegisterRemoteRelationCount(c *gc.C) {
 	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
 		*(result.(*params.RegisterRemoteRelationResults)) = params.RegisterRemoteRelationResults{
@@ -0,0 +1,42 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package enginehealthreporter provides access to the
+// EngineHealthReporter facade, used by machine agents to report the
+// health of the workers in their dependency engines.
+package enginehealthreporter
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the EngineHealthReporter API end point.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the
+// EngineHealthReporter API.
+func NewClient(caller base.APICaller) *Client {
+	return &Client{facade: base.NewFacadeCaller(caller, "EngineHealthReporter")}
+}
+
+// ReportEngineHealth records the unhealthy workers in the dependency
+// engine of the machine agent with the given tag.
+func (c *Client) ReportEngineHealth(tag names.MachineTag, workers []params.EngineWorkerHealth) error {
+	args := params.EngineHealthReports{
+		Reports: []params.EngineHealthReport{{
+			Tag:     tag.String(),
+			Workers: workers,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ReportEngineHealth", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -0,0 +1,70 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package enginehealthreporter_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/enginehealthreporter"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+var workers = []params.EngineWorkerHealth{{
+	Name:   "machiner",
+	Health: "failing",
+	State:  "stopped",
+	Error:  "boom",
+}}
+
+func (s *clientSuite) TestReportEngineHealth(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "EngineHealthReporter")
+			c.Check(request, gc.Equals, "ReportEngineHealth")
+			c.Check(a, jc.DeepEquals, params.EngineHealthReports{
+				Reports: []params.EngineHealthReport{{
+					Tag:     "machine-0",
+					Workers: workers,
+				}},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		})
+	err := enginehealthreporter.NewClient(apiCaller).ReportEngineHealth(names.NewMachineTag("0"), workers)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestReportEngineHealthResultError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(_ string, _ int, _, _ string, _, result interface{}) error {
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+			}
+			return nil
+		})
+	err := enginehealthreporter.NewClient(apiCaller).ReportEngineHealth(names.NewMachineTag("0"), workers)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *clientSuite) TestReportEngineHealthCallError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(_ string, _ int, _, _ string, _, _ interface{}) error {
+			return errors.New("kaboom")
+		})
+	err := enginehealthreporter.NewClient(apiCaller).ReportEngineHealth(names.NewMachineTag("0"), workers)
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+}
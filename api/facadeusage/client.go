@@ -0,0 +1,42 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package facadeusage provides access to the FacadeUsage facade, which
+// reports the facade versions that clients and agents have called on
+// an API server.
+package facadeusage
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the FacadeUsage API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the FacadeUsage API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "FacadeUsage")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Usage returns the usage of the named facades, or of all facades if
+// none are named, recorded by the API server the client is connected
+// to. If oldVersionsOnly is true, only the usage of versions older
+// than the latest supported by the controller is returned.
+func (c *Client) Usage(oldVersionsOnly bool, facades ...string) (params.FacadeUsageResults, error) {
+	args := params.FacadeUsageArgs{
+		Facades:         facades,
+		OldVersionsOnly: oldVersionsOnly,
+	}
+	var result params.FacadeUsageResults
+	if err := c.facade.FacadeCall("Usage", args, &result); err != nil {
+		return params.FacadeUsageResults{}, errors.Trace(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package facadeusage_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/facadeusage"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestUsage(c *gc.C) {
+	now := time.Date(2021, 4, 1, 12, 0, 0, 0, time.UTC)
+	results := params.FacadeUsageResults{
+		Since: now.Add(-time.Hour),
+		Usage: []params.FacadeUsage{{
+			Facade:        "Uniter",
+			Version:       17,
+			LatestVersion: 18,
+			Client:        "juju/2.8.10",
+			EntityKind:    "unit",
+			Count:         12,
+			FirstSeen:     now.Add(-time.Hour),
+			LastSeen:      now,
+		}},
+	}
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "FacadeUsage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Usage")
+			c.Check(a, jc.DeepEquals, params.FacadeUsageArgs{
+				Facades:         []string{"Uniter"},
+				OldVersionsOnly: true,
+			})
+			*(result.(*params.FacadeUsageResults)) = results
+			return nil
+		})
+	client := facadeusage.NewClient(apiCaller)
+	result, err := client.Usage(true, "Uniter")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result, jc.DeepEquals, results)
+}
+
+func (s *clientSuite) TestUsageError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(_ string, _ int, _, _ string, _, _ interface{}) error {
+			return errors.New("boom")
+		})
+	client := facadeusage.NewClient(apiCaller)
+	_, err := client.Usage(false)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -14,14 +14,16 @@ var facadeVersions = map[string]int{
 	"Action":                       7,
 	"ActionPruner":                 1,
 	"Agent":                        2,
+	"AgentIntrospection":           1,
+	"AgentPresence":                1,
 	"AgentTools":                   1,
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
-	"Annotations":                  2,
-	"Application":                  13,
+	"Annotations":                  4,
+	"Application":                  16,
 	"ApplicationOffers":            3,
 	"ApplicationScaler":            1,
-	"Backups":                      3,
+	"Backups":                      4,
 	"Block":                        2,
 	"Bundle":                       4,
 	"CAASAgent":                    1,
@@ -37,23 +39,25 @@ var facadeVersions = map[string]int{
 	"CAASUnitProvisioner":          1,
 	"CharmHub":                     1,
 	"CharmRevisionUpdater":         2,
-	"Charms":                       4,
+	"Charms":                       5,
 	"Cleaner":                      2,
-	"Client":                       2,
+	"Client":                       4,
 	"Cloud":                        7,
-	"Controller":                   9,
+	"Controller":                   13,
 	"CredentialManager":            1,
-	"CredentialValidator":          2,
+	"CredentialValidator":          3,
 	"CrossController":              1,
-	"CrossModelRelations":          2,
+	"CrossModelRelations":          3,
 	"Deployer":                     1,
 	"DiskManager":                  2,
+	"EngineHealthReporter":         1,
 	"EntityWatcher":                2,
 	"ExternalControllerUpdater":    1,
+	"FacadeUsage":                  1,
 	"FanConfigurer":                1,
 	"FilesystemAttachmentsWatcher": 2,
-	"Firewaller":                   6,
-	"FirewallRules":                1,
+	"Firewaller":                   7,
+	"FirewallRules":                2,
 	"HighAvailability":             2,
 	"HostKeyReporter":              1,
 	"ImageManager":                 2,
@@ -61,6 +65,8 @@ var facadeVersions = map[string]int{
 	"ImageMetadataManager":         1,
 	"InstanceMutater":              2,
 	"InstancePoller":               4,
+	"IntrospectionResponder":       1,
+	"Inventory":                    1,
 	"KeyManager":                   1,
 	"KeyUpdater":                   1,
 	"LeadershipService":            2,
@@ -68,7 +74,7 @@ var facadeVersions = map[string]int{
 	"LogForwarding":                1,
 	"Logger":                       1,
 	"MachineActions":               1,
-	"MachineManager":               6,
+	"MachineManager":               10,
 	"MachineUndertaker":            1,
 	"Machiner":                     4,
 	"MeterStatus":                  2,
@@ -80,12 +86,18 @@ var facadeVersions = map[string]int{
 	"MigrationMinion":              1,
 	"MigrationStatusWatcher":       1,
 	"MigrationTarget":              1,
-	"ModelConfig":                  2,
+	"ModelCloner":                  1,
+	"ModelConfig":                  4,
+	"ModelDestruction":             1,
+	"ModelDiff":                    1,
 	"ModelGeneration":              4,
-	"ModelManager":                 9,
+	"ModelHealth":                  1,
+	"ModelManager":                 10,
+	"ModelReconciler":              1,
+	"ModelSpec":                    1,
 	"ModelSummaryWatcher":          1,
 	"ModelUpgrader":                1,
-	"NotifyWatcher":                1,
+	"NotifyWatcher":                2,
 	"OfferStatusWatcher":           1,
 	"Payloads":                     1,
 	"PayloadsHookContext":          1,
@@ -93,31 +105,36 @@ var facadeVersions = map[string]int{
 	"Provisioner":                  11,
 	"ProxyUpdater":                 2,
 	"Reboot":                       2,
+	"RelationSettings":             2,
 	"RelationStatusWatcher":        1,
 	"RelationUnitsWatcher":         1,
-	"RemoteRelations":              2,
+	"RemoteRelations":              3,
 	"RemoteRelationWatcher":        1,
 	"Resources":                    2,
-	"ResourcesHookContext":         1,
+	"ResourcesHookContext":         2,
 	"Resumer":                      2,
 	"RetryStrategy":                1,
+	"SandboxProfiles":              1,
+	"Schema":                       1,
+	"ScopedCredentials":            1,
 	"Singular":                     2,
-	"Spaces":                       6,
+	"Spaces":                       8,
 	"SSHClient":                    2,
 	"StatusHistory":                2,
-	"Storage":                      6,
+	"Storage":                      7,
 	"StorageProvisioner":           4,
-	"StringsWatcher":               1,
-	"Subnets":                      4,
+	"StringsWatcher":               2,
+	"Subnets":                      5,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       17,
+	"Uniter":                       23,
 	"Upgrader":                     1,
 	"UpgradeSeries":                3,
 	"UpgradeSteps":                 2,
 	"UserManager":                  2,
 	"VolumeAttachmentsWatcher":     2,
 	"VolumeAttachmentPlansWatcher": 1,
+	"WorkloadVersions":             1,
 }
 
 // bestVersion tries to find the newest version in the version list that we can
@@ -65,3 +65,42 @@ func (s *Application) ExposeInfo() (bool, map[string]params.ExposedEndpoint, err
 	}
 	return result.Exposed, result.ExposedEndpoints, nil
 }
+
+// FirewallMode returns the firewall-mode set in the application's config,
+// or an empty string if the model's firewall-mode applies.
+func (s *Application) FirewallMode() (string, error) {
+	if s.st.BestAPIVersion() < 7 {
+		// FirewallMode() was introduced in FirewallerAPIV7.
+		return "", errors.NotImplementedf("FirewallMode() (need V7+)")
+	}
+
+	var results params.StringResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("GetFirewallModes", args, &results)
+	if err != nil {
+		return "", err
+	}
+	if len(results.Results) != 1 {
+		return "", fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		if params.IsCodeNotFound(result.Error) {
+			return "", errors.NewNotFound(result.Error, "")
+		}
+		return "", result.Error
+	}
+	return result.Result, nil
+}
+
+// WatchFirewallMode returns a watcher that notifies when the application's
+// firewall-mode may have changed.
+func (s *Application) WatchFirewallMode() (watcher.NotifyWatcher, error) {
+	if s.st.BestAPIVersion() < 7 {
+		// WatchFirewallMode() was introduced in FirewallerAPIV7.
+		return nil, errors.NotImplementedf("WatchFirewallMode() (need V7+)")
+	}
+	return common.Watch(s.st.facade, "WatchFirewallModes", s.tag)
+}
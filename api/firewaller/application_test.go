@@ -7,9 +7,11 @@ import (
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/environschema.v1"
 
 	"github.com/juju/juju/api/firewaller"
 	"github.com/juju/juju/apiserver/params"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/watcher/watchertest"
 	"github.com/juju/juju/state"
@@ -93,3 +95,38 @@ func (s *applicationSuite) TestExposeInfo(c *gc.C) {
 	c.Assert(isExposed, jc.IsFalse)
 	c.Assert(exposedEndpoints, gc.HasLen, 0)
 }
+
+func (s *applicationSuite) TestFirewallMode(c *gc.C) {
+	mode, err := s.apiApplication.FirewallMode()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mode, gc.Equals, "")
+
+	err = s.application.UpdateApplicationConfig(coreapplication.ConfigAttributes{
+		"firewall-mode": "none",
+	}, nil, environschema.Fields{
+		"firewall-mode": {Type: environschema.Tstring},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mode, err = s.apiApplication.FirewallMode()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mode, gc.Equals, "none")
+}
+
+func (s *applicationSuite) TestWatchFirewallMode(c *gc.C) {
+	w, err := s.apiApplication.WatchFirewallMode()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertOneChange()
+
+	err = s.application.UpdateApplicationConfig(coreapplication.ConfigAttributes{
+		"firewall-mode": "none",
+	}, nil, environschema.Fields{
+		"firewall-mode": {Type: environschema.Tstring},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -52,3 +52,16 @@ func (c *Client) ListFirewallRules() ([]params.FirewallRule, error) {
 	}
 	return results.Rules, nil
 }
+
+// AuditFirewall returns the rules in the cloud that are not backed by any
+// port range opened in the model.
+func (c *Client) AuditFirewall() (params.FirewallAuditResults, error) {
+	var result params.FirewallAuditResults
+	if c.BestAPIVersion() < 2 {
+		return result, errors.NotSupportedf("auditing firewall rules on this version of Juju")
+	}
+	if err := c.facade.FacadeCall("AuditFirewall", nil, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
@@ -141,3 +141,53 @@ func (s *FirewallRulesSuite) TestListError(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.ErrorMatches, "fail")
 	c.Assert(called, jc.IsTrue)
 }
+
+func (s *FirewallRulesSuite) TestAuditFirewall(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "FirewallRules")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "AuditFirewall")
+			c.Check(a, gc.IsNil)
+
+			if results, ok := result.(*params.FirewallAuditResults); ok {
+				*results = params.FirewallAuditResults{
+					FirewallMode: "instance",
+					Orphans: []params.OrphanFirewallRule{{
+						MachineId: "0",
+						PortRange: params.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+					}},
+				}
+			}
+			return nil
+		})
+	client := firewallrules.NewClient(basetesting.BestVersionCaller{apiCaller, 2})
+	result, err := client.AuditFirewall()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.FirewallAuditResults{
+		FirewallMode: "instance",
+		Orphans: []params.OrphanFirewallRule{{
+			MachineId: "0",
+			PortRange: params.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+		}},
+	})
+}
+
+func (s *FirewallRulesSuite) TestAuditFirewallNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Fail()
+			return nil
+		})
+	client := firewallrules.NewClient(basetesting.BestVersionCaller{apiCaller, 1})
+	_, err := client.AuditFirewall()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
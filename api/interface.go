@@ -184,6 +184,24 @@ type DialOpts struct {
 	// automatically verified. If the callback returns a non-nil error then
 	// the connection attempt will be aborted.
 	VerifyCA func(host, endpoint string, caCert *x509.Certificate) error
+
+	// AddressHealth, if set, is used to order the addresses to dial
+	// according to the outcome of previous dials, and is updated with
+	// the outcome of this one. If it is nil, addresses are dialled in
+	// a random order.
+	AddressHealth *AddressHealth
+
+	// TLSSessionCache, if set, holds the TLS sessions established with
+	// the API servers, so that later connections to the same controller
+	// can resume them rather than making a full TLS handshake. It must
+	// only be shared by connections to the same controller.
+	TLSSessionCache tls.ClientSessionCache
+
+	// Proxy, if set, is the proxy through which the API servers are
+	// reached: an HTTP proxy, used with the CONNECT method, or a SOCKS5
+	// proxy. It takes the place of the proxy settings used for other
+	// web requests made by this process.
+	Proxy *url.URL
 }
 
 // IPAddrResolver implements a resolved from host name to the
@@ -0,0 +1,69 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package introspectionresponder provides access to the
+// IntrospectionResponder facade, used by machine agents to answer
+// introspection requests made of them through the controller.
+package introspectionresponder
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+// Client allows access to the IntrospectionResponder API end point.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the
+// IntrospectionResponder API.
+func NewClient(caller base.APICaller) *Client {
+	return &Client{facade: base.NewFacadeCaller(caller, "IntrospectionResponder")}
+}
+
+// WatchRequests returns a StringsWatcher reporting the ids of the
+// introspection requests made of the agent.
+func (c *Client) WatchRequests() (watcher.StringsWatcher, error) {
+	var result params.StringsWatchResult
+	if err := c.facade.FacadeCall("WatchRequests", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result), nil
+}
+
+// Request returns the introspection request with the given id.
+func (c *Client) Request(id string) (params.IntrospectionRequest, error) {
+	args := params.IntrospectionRequestIds{Ids: []string{id}}
+	var results params.IntrospectionRequestResults
+	if err := c.facade.FacadeCall("Requests", args, &results); err != nil {
+		return params.IntrospectionRequest{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.IntrospectionRequest{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.IntrospectionRequest{}, errors.Trace(result.Error)
+	}
+	return *result.Request, nil
+}
+
+// Respond records the agent's response to an introspection request.
+func (c *Client) Respond(response params.IntrospectionResponse) error {
+	args := params.IntrospectionResponses{
+		Responses: []params.IntrospectionResponse{response},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("Respond", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
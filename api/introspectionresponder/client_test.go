@@ -0,0 +1,96 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspectionresponder_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/introspectionresponder"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestWatchRequestsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "IntrospectionResponder")
+			c.Check(request, gc.Equals, "WatchRequests")
+			c.Check(a, gc.IsNil)
+			*(result.(*params.StringsWatchResult)) = params.StringsWatchResult{
+				Error: &params.Error{Message: "boom"},
+			}
+			return nil
+		})
+	w, err := introspectionresponder.NewClient(apiCaller).WatchRequests()
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(w, gc.IsNil)
+}
+
+func (s *clientSuite) TestRequest(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "IntrospectionResponder")
+			c.Check(request, gc.Equals, "Requests")
+			c.Check(a, jc.DeepEquals, params.IntrospectionRequestIds{Ids: []string{"machine-0#0"}})
+			*(result.(*params.IntrospectionRequestResults)) = params.IntrospectionRequestResults{
+				Results: []params.IntrospectionRequestResult{{
+					Request: &params.IntrospectionRequest{Id: "machine-0#0", Path: "/depengine"},
+				}},
+			}
+			return nil
+		})
+	req, err := introspectionresponder.NewClient(apiCaller).Request("machine-0#0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(req, jc.DeepEquals, params.IntrospectionRequest{Id: "machine-0#0", Path: "/depengine"})
+}
+
+func (s *clientSuite) TestRequestError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(_ string, _ int, _, _ string, _, result interface{}) error {
+			*(result.(*params.IntrospectionRequestResults)) = params.IntrospectionRequestResults{
+				Results: []params.IntrospectionRequestResult{{
+					Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+				}},
+			}
+			return nil
+		})
+	_, err := introspectionresponder.NewClient(apiCaller).Request("machine-1#0")
+	c.Assert(err, jc.Satisfies, params.IsCodeUnauthorized)
+}
+
+func (s *clientSuite) TestRespond(c *gc.C) {
+	response := params.IntrospectionResponse{Id: "machine-0#0", Output: []byte("ok")}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "IntrospectionResponder")
+			c.Check(request, gc.Equals, "Respond")
+			c.Check(a, jc.DeepEquals, params.IntrospectionResponses{
+				Responses: []params.IntrospectionResponse{response},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		})
+	err := introspectionresponder.NewClient(apiCaller).Respond(response)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestRespondError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(_ string, _ int, _, _ string, _, _ interface{}) error {
+			return errors.New("boom")
+		})
+	err := introspectionresponder.NewClient(apiCaller).Respond(params.IntrospectionResponse{Id: "machine-0#0"})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
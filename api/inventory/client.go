@@ -0,0 +1,35 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package inventory provides access to the Inventory facade, which
+// reports the machines, volumes and applications of all the models on a
+// controller.
+package inventory
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the Inventory API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the Inventory API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Inventory")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Report returns the inventory of every model on the controller.
+func (c *Client) Report() (params.InventoryReport, error) {
+	var result params.InventoryReport
+	if err := c.facade.FacadeCall("Report", nil, &result); err != nil {
+		return params.InventoryReport{}, errors.Trace(err)
+	}
+	return result, nil
+}
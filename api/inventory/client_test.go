@@ -0,0 +1,64 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package inventory_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/inventory"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestReport(c *gc.C) {
+	report := params.InventoryReport{
+		Models: []params.ModelInventory{{
+			ModelTag: coretesting.ModelTag.String(),
+			Name:     "prod",
+			OwnerTag: "user-bob",
+			Type:     "iaas",
+			Cloud:    "aws",
+			Machines: []params.MachineInventory{{Id: "0", Series: "focal"}},
+		}},
+	}
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Inventory")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Report")
+			c.Check(a, gc.IsNil)
+			*(result.(*params.InventoryReport)) = report
+			return nil
+		})
+	client := inventory.NewClient(apiCaller)
+	result, err := client.Report()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result, jc.DeepEquals, report)
+}
+
+func (s *clientSuite) TestReportError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(_ string, _ int, _, _ string, _, _ interface{}) error {
+			return errors.New("boom")
+		})
+	client := inventory.NewClient(apiCaller)
+	_, err := client.Report()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
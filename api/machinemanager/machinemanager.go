@@ -13,6 +13,7 @@ import (
 	apiwatcher "github.com/juju/juju/api/watcher"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/watcher"
 )
 
@@ -265,3 +266,128 @@ func (client *Client) GetUpgradeSeriesMessages(machineName, watcherId string) ([
 
 	return result.Result, nil
 }
+
+// SetEgressNATAddress declares the address of the NAT gateway through which
+// traffic from the input machine egresses. An empty address clears the
+// declaration.
+func (client *Client) SetEgressNATAddress(machineName, address string) error {
+	if client.BestAPIVersion() < 7 {
+		return errors.NotSupportedf("setting egress NAT addresses")
+	}
+	args := params.SetEgressNATAddressesArgs{
+		Args: []params.EntityString{{
+			Tag:   names.NewMachineTag(machineName).String(),
+			Value: address,
+		}},
+	}
+	var results params.ErrorResults
+	if err := client.facade.FacadeCall("SetEgressNATAddresses", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// RefreshMachineHardware reads the current hardware characteristics of
+// the machines' instances from the cloud, such as after they have been
+// resized outside of Juju, and records them in the model.
+func (client *Client) RefreshMachineHardware(machines ...string) ([]params.MachineHardwareResult, error) {
+	if client.BestAPIVersion() < 8 {
+		return nil, errors.NotSupportedf("refreshing machine hardware")
+	}
+	args := params.Entities{
+		Entities: make([]params.Entity, len(machines)),
+	}
+	for i, machine := range machines {
+		args.Entities[i].Tag = names.NewMachineTag(machine).String()
+	}
+	var results params.MachineHardwareResults
+	if err := client.facade.FacadeCall("RefreshMachineHardware", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != len(machines) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(machines), n)
+	}
+	return results.Results, nil
+}
+
+// PrepareResizeMachine stops the workloads on the machine so that its
+// instance can be resized with ResizeMachine. Progress is reported
+// through the machine's upgrade series notifications.
+func (client *Client) PrepareResizeMachine(machine string, cons constraints.Value) error {
+	if client.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("resizing machines")
+	}
+	args := params.ResizeMachinesArgs{
+		Args: []params.ResizeMachineArg{{
+			Tag:         names.NewMachineTag(machine).String(),
+			Constraints: cons,
+		}},
+	}
+	var results params.ErrorResults
+	if err := client.facade.FacadeCall("PrepareResizeMachines", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return apiservererrors.RestoreError(err)
+	}
+	return nil
+}
+
+// ResizeMachine resizes the machine's instance to the cheapest hardware
+// satisfying the constraints, returning its new hardware. Unless force
+// is true, the machine must first have been prepared with
+// PrepareResizeMachine.
+func (client *Client) ResizeMachine(machine string, cons constraints.Value, force bool) (*params.MachineHardware, error) {
+	if client.BestAPIVersion() < 9 {
+		return nil, errors.NotSupportedf("resizing machines")
+	}
+	args := params.ResizeMachinesArgs{
+		Args: []params.ResizeMachineArg{{
+			Tag:         names.NewMachineTag(machine).String(),
+			Constraints: cons,
+			Force:       force,
+		}},
+	}
+	var results params.MachineHardwareResults
+	if err := client.facade.FacadeCall("ResizeMachines", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, apiservererrors.RestoreError(err)
+	}
+	return results.Results[0].Hardware, nil
+}
+
+// ReplaceMachine advances the replacement of the machine with a new
+// machine, provisioned with the given constraints or, if they are nil,
+// those of the machine being replaced. The replacement only advances
+// while ReplaceMachine is called; it should be called repeatedly until
+// the result reports that the replacement is done.
+func (client *Client) ReplaceMachine(machine string, cons *constraints.Value) (params.ReplaceMachineResult, error) {
+	if client.BestAPIVersion() < 10 {
+		return params.ReplaceMachineResult{}, errors.NotSupportedf("replacing machines")
+	}
+	args := params.ReplaceMachinesArgs{
+		Args: []params.ReplaceMachineArg{{
+			Tag:         names.NewMachineTag(machine).String(),
+			Constraints: cons,
+		}},
+	}
+	var results params.ReplaceMachineResults
+	if err := client.facade.FacadeCall("ReplaceMachines", args, &results); err != nil {
+		return params.ReplaceMachineResult{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return params.ReplaceMachineResult{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.ReplaceMachineResult{}, apiservererrors.RestoreError(err)
+	}
+	return results.Results[0], nil
+}
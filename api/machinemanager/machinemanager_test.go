@@ -14,6 +14,7 @@ import (
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/machinemanager"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -248,3 +249,205 @@ func (s *MachinemanagerSuite) TestDestroyMachinesWithParamsNilWait(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, expected)
 }
+
+func (s *MachinemanagerSuite) TestSetEgressNATAddress(c *gc.C) {
+	var called bool
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 7,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				c.Check(request, gc.Equals, "SetEgressNATAddresses")
+				c.Check(a, jc.DeepEquals, params.SetEgressNATAddressesArgs{
+					Args: []params.EntityString{{Tag: "machine-0-lxd-1", Value: "203.0.113.10"}},
+				})
+				c.Assert(response, gc.FitsTypeOf, &params.ErrorResults{})
+				*(response.(*params.ErrorResults)) = params.ErrorResults{
+					Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+				}
+				return nil
+			})})
+	err := client.SetEgressNATAddress("0/lxd/1", "203.0.113.10")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *MachinemanagerSuite) TestSetEgressNATAddressNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 6,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			})})
+	err := client.SetEgressNATAddress("0", "203.0.113.10")
+	c.Assert(err, gc.ErrorMatches, "setting egress NAT addresses not supported")
+}
+
+func (s *MachinemanagerSuite) TestRefreshMachineHardware(c *gc.C) {
+	mem := uint64(16384)
+	var called bool
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 8,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				c.Check(request, gc.Equals, "RefreshMachineHardware")
+				c.Check(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "machine-0"}, {Tag: "machine-1"}},
+				})
+				c.Assert(response, gc.FitsTypeOf, &params.MachineHardwareResults{})
+				*(response.(*params.MachineHardwareResults)) = params.MachineHardwareResults{
+					Results: []params.MachineHardwareResult{
+						{Hardware: &params.MachineHardware{Mem: &mem}},
+						{Error: &params.Error{Message: "boom"}},
+					},
+				}
+				return nil
+			})})
+	results, err := client.RefreshMachineHardware("0", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(results, jc.DeepEquals, []params.MachineHardwareResult{
+		{Hardware: &params.MachineHardware{Mem: &mem}},
+		{Error: &params.Error{Message: "boom"}},
+	})
+}
+
+func (s *MachinemanagerSuite) TestRefreshMachineHardwareNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 7,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			})})
+	_, err := client.RefreshMachineHardware("0")
+	c.Assert(err, gc.ErrorMatches, "refreshing machine hardware not supported")
+}
+
+func (s *MachinemanagerSuite) TestPrepareResizeMachine(c *gc.C) {
+	var called bool
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 9,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				c.Check(request, gc.Equals, "PrepareResizeMachines")
+				c.Check(a, jc.DeepEquals, params.ResizeMachinesArgs{
+					Args: []params.ResizeMachineArg{{
+						Tag:         "machine-0",
+						Constraints: constraints.MustParse("mem=16G"),
+					}},
+				})
+				c.Assert(response, gc.FitsTypeOf, &params.ErrorResults{})
+				*(response.(*params.ErrorResults)) = params.ErrorResults{
+					Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+				}
+				return nil
+			})})
+	err := client.PrepareResizeMachine("0", constraints.MustParse("mem=16G"))
+	c.Assert(called, jc.IsTrue)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *MachinemanagerSuite) TestResizeMachine(c *gc.C) {
+	mem := uint64(16384)
+	var called bool
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 9,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				c.Check(request, gc.Equals, "ResizeMachines")
+				c.Check(a, jc.DeepEquals, params.ResizeMachinesArgs{
+					Args: []params.ResizeMachineArg{{
+						Tag:         "machine-0",
+						Constraints: constraints.MustParse("mem=16G"),
+						Force:       true,
+					}},
+				})
+				c.Assert(response, gc.FitsTypeOf, &params.MachineHardwareResults{})
+				*(response.(*params.MachineHardwareResults)) = params.MachineHardwareResults{
+					Results: []params.MachineHardwareResult{{Hardware: &params.MachineHardware{Mem: &mem}}},
+				}
+				return nil
+			})})
+	hw, err := client.ResizeMachine("0", constraints.MustParse("mem=16G"), true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(hw, jc.DeepEquals, &params.MachineHardware{Mem: &mem})
+}
+
+func (s *MachinemanagerSuite) TestResizeMachineNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 8,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			})})
+	err := client.PrepareResizeMachine("0", constraints.Value{})
+	c.Assert(err, gc.ErrorMatches, "resizing machines not supported")
+	_, err = client.ResizeMachine("0", constraints.Value{}, false)
+	c.Assert(err, gc.ErrorMatches, "resizing machines not supported")
+}
+
+func (s *MachinemanagerSuite) TestReplaceMachine(c *gc.C) {
+	cons := constraints.MustParse("mem=16G")
+	var called bool
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 10,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				c.Check(request, gc.Equals, "ReplaceMachines")
+				c.Check(a, jc.DeepEquals, params.ReplaceMachinesArgs{
+					Args: []params.ReplaceMachineArg{{
+						Tag:         "machine-0",
+						Constraints: &cons,
+					}},
+				})
+				c.Assert(response, gc.FitsTypeOf, &params.ReplaceMachineResults{})
+				*(response.(*params.ReplaceMachineResults)) = params.ReplaceMachineResults{
+					Results: []params.ReplaceMachineResult{{
+						Replacement: "machine-1",
+						Message:     "provisioning replacement machine 1",
+					}},
+				}
+				return nil
+			})})
+	result, err := client.ReplaceMachine("0", &cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result, jc.DeepEquals, params.ReplaceMachineResult{
+		Replacement: "machine-1",
+		Message:     "provisioning replacement machine 1",
+	})
+}
+
+func (s *MachinemanagerSuite) TestReplaceMachineError(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 10,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				*(response.(*params.ReplaceMachineResults)) = params.ReplaceMachineResults{
+					Results: []params.ReplaceMachineResult{{Error: &params.Error{Message: "boom"}}},
+				}
+				return nil
+			})})
+	_, err := client.ReplaceMachine("0", nil)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *MachinemanagerSuite) TestReplaceMachineNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 9,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			})})
+	_, err := client.ReplaceMachine("0", nil)
+	c.Assert(err, gc.ErrorMatches, "replacing machines not supported")
+}
@@ -0,0 +1,98 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelcloner
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
+)
+
+// Client provides methods for creating new models based on
+// existing ones.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new `Client` based on an existing authenticated API
+// connection.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ModelCloner")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// CloneModelArgs holds the arguments for cloning a model.
+type CloneModelArgs struct {
+	// Source is the model to clone.
+	Source names.ModelTag
+
+	// Name is the name of the new model.
+	Name string
+
+	// Owner is the owner of the new model. If it is empty, the
+	// new model is owned by the current user.
+	Owner names.UserTag
+
+	// IncludeApplications indicates whether the source model's
+	// applications should be exported as a bundle.
+	IncludeApplications bool
+}
+
+// CloneModelResult holds the details of a model created by cloning.
+type CloneModelResult struct {
+	ModelUUID string
+	Name      string
+	Owner     names.UserTag
+	Type      model.ModelType
+
+	// Bundle holds the source model's applications, machines and
+	// relations, if they were requested.
+	Bundle string
+}
+
+// CloneModel creates a new model with the same cloud, credential,
+// model config and constraints as the source model.
+func (c *Client) CloneModel(args CloneModelArgs) (CloneModelResult, error) {
+	arg := params.CloneModelArg{
+		SourceModelTag:      args.Source.String(),
+		Name:                args.Name,
+		IncludeApplications: args.IncludeApplications,
+	}
+	if args.Owner.Id() != "" {
+		arg.OwnerTag = args.Owner.String()
+	}
+	var results params.CloneModelResults
+	err := c.facade.FacadeCall("CloneModel", params.CloneModelArgs{
+		Models: []params.CloneModelArg{arg},
+	}, &results)
+	if err != nil {
+		return CloneModelResult{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return CloneModelResult{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return CloneModelResult{}, errors.Trace(result.Error)
+	}
+	modelTag, err := names.ParseModelTag(result.ModelTag)
+	if err != nil {
+		return CloneModelResult{}, errors.Trace(err)
+	}
+	ownerTag, err := names.ParseUserTag(result.OwnerTag)
+	if err != nil {
+		return CloneModelResult{}, errors.Trace(err)
+	}
+	return CloneModelResult{
+		ModelUUID: modelTag.Id(),
+		Name:      result.Name,
+		Owner:     ownerTag,
+		Type:      model.ModelType(result.Type),
+		Bundle:    result.Bundle,
+	}, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelcloner_test
+
+import (
+	"github.com/juju/names/v4"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelcloner"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type modelclonerSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&modelclonerSuite{})
+
+func (s *modelclonerSuite) TestCloneModel(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "ModelCloner")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "CloneModel")
+			c.Check(a, jc.DeepEquals, params.CloneModelArgs{
+				Models: []params.CloneModelArg{{
+					SourceModelTag:      coretesting.ModelTag.String(),
+					Name:                "clone",
+					OwnerTag:            "user-bob",
+					IncludeApplications: true,
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.CloneModelResults{})
+			*(result.(*params.CloneModelResults)) = params.CloneModelResults{
+				Results: []params.CloneModelResult{{
+					ModelTag: "model-deadbeef-0bad-400d-8000-4b1d0d06f00e",
+					Name:     "clone",
+					OwnerTag: "user-bob",
+					Type:     "iaas",
+					Bundle:   "applications: {}\n",
+				}},
+			}
+			return nil
+		},
+	)
+	client := modelcloner.NewClient(apiCaller)
+	result, err := client.CloneModel(modelcloner.CloneModelArgs{
+		Source:              coretesting.ModelTag,
+		Name:                "clone",
+		Owner:               names.NewUserTag("bob"),
+		IncludeApplications: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, modelcloner.CloneModelResult{
+		ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00e",
+		Name:      "clone",
+		Owner:     names.NewUserTag("bob"),
+		Type:      model.IAAS,
+		Bundle:    "applications: {}\n",
+	})
+}
+
+func (s *modelclonerSuite) TestCloneModelDefaultOwner(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(a, jc.DeepEquals, params.CloneModelArgs{
+				Models: []params.CloneModelArg{{
+					SourceModelTag: coretesting.ModelTag.String(),
+					Name:           "clone",
+				}},
+			})
+			*(result.(*params.CloneModelResults)) = params.CloneModelResults{
+				Results: []params.CloneModelResult{{
+					ModelTag: "model-deadbeef-0bad-400d-8000-4b1d0d06f00e",
+					Name:     "clone",
+					OwnerTag: "user-admin",
+					Type:     "iaas",
+				}},
+			}
+			return nil
+		},
+	)
+	client := modelcloner.NewClient(apiCaller)
+	result, err := client.CloneModel(modelcloner.CloneModelArgs{
+		Source: coretesting.ModelTag,
+		Name:   "clone",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Owner, gc.Equals, names.NewUserTag("admin"))
+}
+
+func (s *modelclonerSuite) TestCloneModelError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			*(result.(*params.CloneModelResults)) = params.CloneModelResults{
+				Results: []params.CloneModelResult{{
+					Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+				}},
+			}
+			return nil
+		},
+	)
+	client := modelcloner.NewClient(apiCaller)
+	_, err := client.CloneModel(modelcloner.CloneModelArgs{
+		Source: coretesting.ModelTag,
+		Name:   "clone",
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -103,3 +103,51 @@ func (c *Client) Sequences() (map[string]int, error) {
 	}
 	return result.Sequences, nil
 }
+
+// ModelConfigTrace returns the effective value of the named model config
+// attribute, along with the value set by each config source it is
+// resolved from.
+func (c *Client) ModelConfigTrace(key string) (config.ConfigValueTrace, error) {
+	if c.BestAPIVersion() < 3 {
+		return config.ConfigValueTrace{}, errors.NotSupportedf("ModelConfigTrace on v%d facade", c.BestAPIVersion())
+	}
+	var results params.ModelConfigTraceResults
+	err := c.facade.FacadeCall("ModelConfigTrace", params.ModelConfigTraceArgs{
+		Keys: []string{key},
+	}, &results)
+	if err != nil {
+		return config.ConfigValueTrace{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return config.ConfigValueTrace{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return config.ConfigValueTrace{}, errors.Trace(result.Error)
+	}
+	trace := config.ConfigValueTrace{
+		Value:  result.Value,
+		Source: result.Source,
+	}
+	for _, value := range result.Sources {
+		trace.Sources = append(trace.Sources, config.ConfigValue{
+			Value:  value.Value,
+			Source: value.Source,
+		})
+	}
+	return trace, nil
+}
+
+// CloudInitCustomizations returns the packages, commands and proxy
+// settings that are added to the cloud-init user-data of new machines
+// in the model.
+func (c *Client) CloudInitCustomizations() (params.CloudInitCustomizationsResult, error) {
+	if c.BestAPIVersion() < 4 {
+		return params.CloudInitCustomizationsResult{}, errors.NotSupportedf("CloudInitCustomizations on v%d facade", c.BestAPIVersion())
+	}
+	var result params.CloudInitCustomizationsResult
+	if err := c.facade.FacadeCall("CloudInitCustomizations", nil, &result); err != nil {
+		return params.CloudInitCustomizationsResult{}, errors.Trace(err)
+	}
+	return result, nil
+}
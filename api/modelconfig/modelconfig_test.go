@@ -220,3 +220,117 @@ func (s *modelconfigSuite) TestSequences(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 	c.Assert(sequences, jc.DeepEquals, map[string]int{"foo": 5, "bar": 2})
 }
+
+func (s *modelconfigSuite) TestModelConfigTraceV2(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(
+			func(_ string, _ int, _, _ string, _, _ interface{}) error {
+				c.Errorf("shouldn't be called")
+				return nil
+			},
+		), 2}
+	client := modelconfig.NewClient(apiCaller)
+	_, err := client.ModelConfigTrace("ftp-proxy")
+	c.Assert(err, gc.ErrorMatches, "ModelConfigTrace on v2 facade not supported")
+}
+
+func (s *modelconfigSuite) TestModelConfigTrace(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "ModelConfig")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "ModelConfigTrace")
+				c.Check(a, jc.DeepEquals, params.ModelConfigTraceArgs{
+					Keys: []string{"ftp-proxy"},
+				})
+				results := result.(*params.ModelConfigTraceResults)
+				results.Results = []params.ModelConfigTraceResult{{
+					Key:    "ftp-proxy",
+					Value:  "http://proxy",
+					Source: "controller",
+					Sources: []params.ConfigValue{
+						{"", "default"},
+						{"http://proxy", "controller"},
+					},
+				}}
+				return nil
+			},
+		), 3}
+	client := modelconfig.NewClient(apiCaller)
+	trace, err := client.ModelConfigTrace("ftp-proxy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(trace, jc.DeepEquals, config.ConfigValueTrace{
+		Value:  "http://proxy",
+		Source: "controller",
+		Sources: []config.ConfigValue{
+			{Value: "", Source: "default"},
+			{Value: "http://proxy", Source: "controller"},
+		},
+	})
+}
+
+func (s *modelconfigSuite) TestModelConfigTraceError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(
+			func(_ string, _ int, _, _ string, _, result interface{}) error {
+				results := result.(*params.ModelConfigTraceResults)
+				results.Results = []params.ModelConfigTraceResult{{
+					Key:   "bad",
+					Error: &params.Error{Message: `model config attribute "bad" not found`, Code: params.CodeNotFound},
+				}}
+				return nil
+			},
+		), 3}
+	client := modelconfig.NewClient(apiCaller)
+	_, err := client.ModelConfigTrace("bad")
+	c.Assert(err, gc.ErrorMatches, `model config attribute "bad" not found`)
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *modelconfigSuite) TestCloudInitCustomizationsV3(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(
+			func(_ string, _ int, _, _ string, _, _ interface{}) error {
+				c.Errorf("shouldn't be called")
+				return nil
+			},
+		), 3}
+	client := modelconfig.NewClient(apiCaller)
+	_, err := client.CloudInitCustomizations()
+	c.Assert(err, gc.ErrorMatches, "CloudInitCustomizations on v3 facade not supported")
+}
+
+func (s *modelconfigSuite) TestCloudInitCustomizations(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "ModelConfig")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "CloudInitCustomizations")
+				c.Check(a, gc.IsNil)
+				*(result.(*params.CloudInitCustomizationsResult)) = params.CloudInitCustomizationsResult{
+					Packages:    []string{"htop"},
+					PostRunCmds: []string{"mkdir /srv/data"},
+					AptProxy:    params.ProxyConfig{HTTP: "http://apt-proxy"},
+				}
+				return nil
+			},
+		), 4}
+	client := modelconfig.NewClient(apiCaller)
+	result, err := client.CloudInitCustomizations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CloudInitCustomizationsResult{
+		Packages:    []string{"htop"},
+		PostRunCmds: []string{"mkdir /srv/data"},
+		AptProxy:    params.ProxyConfig{HTTP: "http://apt-proxy"},
+	})
+}
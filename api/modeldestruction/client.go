@@ -0,0 +1,35 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modeldestruction provides access to the ModelDestruction
+// facade, which reports what the destruction of a model is waiting on.
+package modeldestruction
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the ModelDestruction API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the ModelDestruction API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ModelDestruction")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Progress returns the entities which remain to be removed before the
+// model, which must be dying or dead, can be removed.
+func (c *Client) Progress() (params.ModelDestructionProgress, error) {
+	var result params.ModelDestructionProgress
+	if err := c.facade.FacadeCall("Progress", nil, &result); err != nil {
+		return params.ModelDestructionProgress{}, errors.Trace(err)
+	}
+	return result, nil
+}
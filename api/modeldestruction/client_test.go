@@ -0,0 +1,56 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modeldestruction_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modeldestruction"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/life"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestProgress(c *gc.C) {
+	progress := params.ModelDestructionProgress{
+		Life: life.Dying,
+		Blockers: []params.ModelDestructionBlocker{{
+			Kind:    "machine",
+			Id:      "0",
+			Life:    life.Dying,
+			Status:  "running",
+			Message: "Running",
+		}},
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelDestruction")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Progress")
+			c.Check(a, gc.IsNil)
+			*(result.(*params.ModelDestructionProgress)) = progress
+			return nil
+		})
+	result, err := modeldestruction.NewClient(apiCaller).Progress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, progress)
+}
+
+func (s *clientSuite) TestProgressError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(_ string, _ int, _, _ string, _, _ interface{}) error {
+			return errors.New("boom")
+		})
+	_, err := modeldestruction.NewClient(apiCaller).Progress()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,42 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modeldiff provides access to the ModelDiff facade, which
+// compares two serialized model descriptions.
+package modeldiff
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the ModelDiff API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the ModelDiff API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ModelDiff")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Diff returns the differences between the two serialized model
+// descriptions. Fields expected to differ between otherwise identical
+// models, such as timestamps and UUIDs, are ignored unless
+// includeVolatile is true.
+func (c *Client) Diff(a, b string, includeVolatile bool) ([]params.ModelDifference, error) {
+	args := params.ModelDiffArgs{
+		A:               a,
+		B:               b,
+		IncludeVolatile: includeVolatile,
+	}
+	var result params.ModelDiffResult
+	if err := c.facade.FacadeCall("Diff", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Differences, nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modeldiff_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modeldiff"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestDiff(c *gc.C) {
+	diffs := []params.ModelDifference{{
+		Path: "applications[mysql].units[mysql/1]",
+		Kind: "added",
+		B:    "name: mysql/1",
+	}}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelDiff")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Diff")
+			c.Check(a, jc.DeepEquals, params.ModelDiffArgs{A: "a: 1", B: "b: 2", IncludeVolatile: true})
+			*(result.(*params.ModelDiffResult)) = params.ModelDiffResult{Differences: diffs}
+			return nil
+		})
+	result, err := modeldiff.NewClient(apiCaller).Diff("a: 1", "b: 2", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, diffs)
+}
+
+func (s *clientSuite) TestDiffError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(_ string, _ int, _, _ string, _, _ interface{}) error {
+			return errors.New("boom")
+		})
+	_, err := modeldiff.NewClient(apiCaller).Diff("a: 1", "b: 2", false)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,35 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelhealth provides access to the ModelHealth facade, which
+// summarises the problems found in a model.
+package modelhealth
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the ModelHealth API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the ModelHealth API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ModelHealth")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Summary returns the problems found in the model, most severe first,
+// along with the overall severity of the model's health.
+func (c *Client) Summary() (params.ModelHealthSummary, error) {
+	var result params.ModelHealthSummary
+	if err := c.facade.FacadeCall("Summary", nil, &result); err != nil {
+		return params.ModelHealthSummary{}, errors.Trace(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelhealth_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelhealth"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestSummary(c *gc.C) {
+	summary := params.ModelHealthSummary{
+		Severity: "critical",
+		Problems: []params.HealthProblem{{
+			Kind:     "machine-down",
+			Severity: "critical",
+			Tag:      "machine-0",
+			Message:  "agent is not communicating with the server",
+		}},
+	}
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "ModelHealth")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Summary")
+			c.Check(a, gc.IsNil)
+			*(result.(*params.ModelHealthSummary)) = summary
+			return nil
+		})
+	client := modelhealth.NewClient(apiCaller)
+	result, err := client.Summary()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result, jc.DeepEquals, summary)
+}
+
+func (s *clientSuite) TestSummaryError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(_ string, _ int, _, _ string, _, _ interface{}) error {
+			return errors.New("boom")
+		})
+	client := modelhealth.NewClient(apiCaller)
+	_, err := client.Summary()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -141,13 +141,15 @@ func convertParamsModelInfo(modelInfo params.ModelInfo) (base.ModelInfo, error)
 	result.Machines = make([]base.Machine, len(modelInfo.Machines))
 	for i, m := range modelInfo.Machines {
 		machine := base.Machine{
-			Id:          m.Id,
-			InstanceId:  m.InstanceId,
-			DisplayName: m.DisplayName,
-			HasVote:     m.HasVote,
-			WantsVote:   m.WantsVote,
-			Status:      m.Status,
-			HAPrimary:   m.HAPrimary,
+			Id:                   m.Id,
+			InstanceId:           m.InstanceId,
+			DisplayName:          m.DisplayName,
+			HasVote:              m.HasVote,
+			WantsVote:            m.WantsVote,
+			Status:               m.Status,
+			HAPrimary:            m.HAPrimary,
+			CustomizationStatus:  m.CustomizationStatus,
+			CustomizationMessage: m.CustomizationMessage,
 		}
 		if m.Hardware != nil {
 			machine.Hardware = &instance.HardwareCharacteristics{
@@ -604,6 +606,26 @@ func (c *Client) ChangeModelCredential(model names.ModelTag, credential names.Cl
 	return out.OneError()
 }
 
+// TransferModelOwnership makes the specified user the owner of a model.
+func (c *Client) TransferModelOwnership(model names.ModelTag, owner names.UserTag) error {
+	if bestVer := c.BestAPIVersion(); bestVer < 10 {
+		return errors.NotImplementedf("TransferModelOwnership in version %v", bestVer)
+	}
+
+	var out params.ErrorResults
+	in := params.TransferModelOwnershipParams{
+		Models: []params.TransferModelOwnershipParam{{
+			ModelTag: model.String(),
+			OwnerTag: owner.String(),
+		}},
+	}
+	err := c.facade.FacadeCall("TransferModelOwnership", in, &out)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return out.OneError()
+}
+
 // ValidateModelUpgrade checks to see if it's possible to upgrade a model,
 // before actually attempting to do the real model-upgrade.
 func (c *Client) ValidateModelUpgrade(model names.ModelTag, force bool) error {
@@ -711,6 +711,65 @@ func (s *modelmanagerSuite) TestChangeModelCredentialV4(c *gc.C) {
 	c.Assert(called, jc.IsFalse)
 }
 
+func (s *modelmanagerSuite) TestTransferModelOwnership(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelManager")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "TransferModelOwnership")
+			c.Check(arg, jc.DeepEquals, params.TransferModelOwnershipParams{
+				Models: []params.TransferModelOwnershipParam{{
+					ModelTag: coretesting.ModelTag.String(),
+					OwnerTag: "user-bob",
+				}},
+			})
+			c.Check(result, gc.FitsTypeOf, &params.ErrorResults{})
+			called = true
+			out := result.(*params.ErrorResults)
+			out.Results = []params.ErrorResult{{}}
+			return nil
+		},
+	}
+
+	client := modelmanager.NewClient(apiCaller)
+	err := client.TransferModelOwnership(coretesting.ModelTag, names.NewUserTag("bob"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *modelmanagerSuite) TestTransferModelOwnershipFailed(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			out := result.(*params.ErrorResults)
+			out.Results = []params.ErrorResult{{Error: &params.Error{Message: "transfer failed"}}}
+			return nil
+		},
+	}
+
+	client := modelmanager.NewClient(apiCaller)
+	err := client.TransferModelOwnership(coretesting.ModelTag, names.NewUserTag("bob"))
+	c.Assert(err, gc.ErrorMatches, `transfer failed`)
+}
+
+func (s *modelmanagerSuite) TestTransferModelOwnershipV9(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 9,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			called = true
+			return nil
+		},
+	}
+
+	client := modelmanager.NewClient(apiCaller)
+	err := client.TransferModelOwnership(coretesting.ModelTag, names.NewUserTag("bob"))
+	c.Assert(err, gc.ErrorMatches, `TransferModelOwnership in version 9 not implemented`)
+	c.Assert(called, jc.IsFalse)
+}
+
 type dumpModelSuite struct {
 	coretesting.BaseSuite
 }
@@ -0,0 +1,45 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelreconciler provides access to the ModelReconciler facade,
+// used by the worker which reconciles a model towards its spec.
+package modelreconciler
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+const modelReconcilerFacade = "ModelReconciler"
+
+// API provides access to the ModelReconciler API facade.
+type API struct {
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side ModelReconciler facade.
+func NewAPI(caller base.APICaller) *API {
+	facadeCaller := base.NewFacadeCaller(caller, modelReconcilerFacade)
+	return &API{facade: facadeCaller}
+}
+
+// Reconcile calls the server-side Reconcile method.
+func (api *API) Reconcile() error {
+	return errors.Trace(api.facade.FacadeCall("Reconcile", nil, nil))
+}
+
+// WatchModelSpec calls the server-side WatchModelSpec method.
+func (api *API) WatchModelSpec() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := api.facade.FacadeCall("WatchModelSpec", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(api.facade.RawAPICaller(), result), nil
+}
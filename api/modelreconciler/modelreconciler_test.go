@@ -0,0 +1,60 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelreconciler_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelreconciler"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type modelReconcilerSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&modelReconcilerSuite{})
+
+func (s *modelReconcilerSuite) TestReconcile(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "ModelReconciler")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Reconcile")
+			c.Check(a, gc.IsNil)
+			return nil
+		})
+	err := modelreconciler.NewAPI(apiCaller).Reconcile()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *modelReconcilerSuite) TestReconcileError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(_ string, _ int, _, _ string, _, _ interface{}) error {
+			return errors.New("boom")
+		})
+	err := modelreconciler.NewAPI(apiCaller).Reconcile()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *modelReconcilerSuite) TestWatchModelSpecError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelReconciler")
+			c.Check(request, gc.Equals, "WatchModelSpec")
+			*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+				Error: &params.Error{Message: "boom"},
+			}
+			return nil
+		})
+	_, err := modelreconciler.NewAPI(apiCaller).WatchModelSpec()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,51 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelspec provides access to the ModelSpec facade, used to set
+// the declarative spec a model is reconciled towards.
+package modelspec
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the ModelSpec API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the ModelSpec API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ModelSpec")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ModelSpec returns the declarative spec of the model, and the status
+// of reconciling the model towards it.
+func (c *Client) ModelSpec() (params.ModelSpecResult, error) {
+	var result params.ModelSpecResult
+	if err := c.facade.FacadeCall("ModelSpec", nil, &result); err != nil {
+		return params.ModelSpecResult{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// SetModelSpec sets the declarative spec of the model, and returns its
+// revision.
+func (c *Client) SetModelSpec(spec string) (int, error) {
+	args := params.SetModelSpecArgs{Spec: spec}
+	var result params.SetModelSpecResult
+	if err := c.facade.FacadeCall("SetModelSpec", args, &result); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return result.Revision, nil
+}
+
+// RemoveModelSpec removes the declarative spec of the model.
+func (c *Client) RemoveModelSpec() error {
+	return errors.Trace(c.facade.FacadeCall("RemoveModelSpec", nil, nil))
+}
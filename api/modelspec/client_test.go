@@ -0,0 +1,79 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelspec_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelspec"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestModelSpec(c *gc.C) {
+	spec := params.ModelSpecResult{
+		Spec:     "applications: {}\n",
+		Revision: 2,
+		Status:   &params.ModelSpecStatus{Revision: 2},
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelSpec")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ModelSpec")
+			c.Check(a, gc.IsNil)
+			*(result.(*params.ModelSpecResult)) = spec
+			return nil
+		})
+	result, err := modelspec.NewClient(apiCaller).ModelSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, spec)
+}
+
+func (s *clientSuite) TestSetModelSpec(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelSpec")
+			c.Check(request, gc.Equals, "SetModelSpec")
+			c.Check(a, jc.DeepEquals, params.SetModelSpecArgs{Spec: "applications: {}\n"})
+			*(result.(*params.SetModelSpecResult)) = params.SetModelSpecResult{Revision: 3}
+			return nil
+		})
+	revision, err := modelspec.NewClient(apiCaller).SetModelSpec("applications: {}\n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(revision, gc.Equals, 3)
+}
+
+func (s *clientSuite) TestRemoveModelSpec(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "ModelSpec")
+			c.Check(request, gc.Equals, "RemoveModelSpec")
+			c.Check(a, gc.IsNil)
+			return nil
+		})
+	err := modelspec.NewClient(apiCaller).RemoveModelSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *clientSuite) TestRemoveModelSpecError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(_ string, _ int, _, _ string, _, _ interface{}) error {
+			return errors.New("boom")
+		})
+	err := modelspec.NewClient(apiCaller).RemoveModelSpec()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -83,6 +83,10 @@ type ProxyConfiguration struct {
 	SnapStoreProxyId         string
 	SnapStoreProxyAssertions string
 	SnapStoreProxyURL        string
+
+	// APIProxy is the proxy through which agents connect to the
+	// controller, or empty if they connect directly.
+	APIProxy string
 }
 
 // ProxyConfig returns the proxy settings for the current model.
@@ -120,6 +124,8 @@ func (api *API) ProxyConfig() (ProxyConfiguration, error) {
 		SnapStoreProxyId:         result.SnapStoreProxyId,
 		SnapStoreProxyAssertions: result.SnapStoreProxyAssertions,
 		SnapStoreProxyURL:        result.SnapStoreProxyURL,
+
+		APIProxy: result.APIProxy,
 	}, nil
 }
 
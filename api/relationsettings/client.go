@@ -0,0 +1,53 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package relationsettings provides access to the RelationSettings
+// facade, which reports the settings on each side of a relation, and
+// the relations in a model as a matrix of application endpoints.
+package relationsettings
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the RelationSettings API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the RelationSettings API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "RelationSettings")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Snapshot returns the application and unit settings on each side of
+// the given relations.
+func (c *Client) Snapshot(relationIds []int) ([]params.RelationSnapshotResult, error) {
+	args := params.RelationIds{RelationIds: relationIds}
+	var results params.RelationSnapshotResults
+	if err := c.facade.FacadeCall("Snapshot", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(relationIds) {
+		return nil, errors.Errorf("expected %d results, got %d", len(relationIds), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// RelationMatrix returns the relations in the model as an adjacency
+// matrix of the application endpoints taking part in them.
+func (c *Client) RelationMatrix() (params.RelationMatrix, error) {
+	if c.BestAPIVersion() < 2 {
+		return params.RelationMatrix{}, errors.NotSupportedf("relation matrix")
+	}
+	var result params.RelationMatrix
+	if err := c.facade.FacadeCall("RelationMatrix", nil, &result); err != nil {
+		return params.RelationMatrix{}, errors.Trace(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package relationsettings_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/relationsettings"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestSnapshot(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "RelationSettings")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Snapshot")
+			c.Check(a, jc.DeepEquals, params.RelationIds{RelationIds: []int{1}})
+			*(result.(*params.RelationSnapshotResults)) = params.RelationSnapshotResults{
+				Results: []params.RelationSnapshotResult{{
+					Id:  1,
+					Key: "wordpress:db mysql:server",
+					Endpoints: []params.RelationEndpointSnapshot{{
+						ApplicationName:     "mysql",
+						Name:                "server",
+						Role:                "provider",
+						ApplicationSettings: map[string]interface{}{"database": "wordpress"},
+					}},
+				}},
+			}
+			return nil
+		})
+	client := relationsettings.NewClient(apiCaller)
+	results, err := client.Snapshot([]int{1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(results, jc.DeepEquals, []params.RelationSnapshotResult{{
+		Id:  1,
+		Key: "wordpress:db mysql:server",
+		Endpoints: []params.RelationEndpointSnapshot{{
+			ApplicationName:     "mysql",
+			Name:                "server",
+			Role:                "provider",
+			ApplicationSettings: map[string]interface{}{"database": "wordpress"},
+		}},
+	}})
+}
+
+func (s *clientSuite) TestSnapshotResultCountMismatch(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(_ string, _ int, _, _ string, _, _ interface{}) error {
+			return nil
+		})
+	client := relationsettings.NewClient(apiCaller)
+	_, err := client.Snapshot([]int{1})
+	c.Assert(err, gc.ErrorMatches, "expected 1 results, got 0")
+}
+
+func (s *clientSuite) TestRelationMatrix(c *gc.C) {
+	matrix := params.RelationMatrix{
+		Endpoints: []params.RelationMatrixEndpoint{{
+			ApplicationName: "mysql",
+			Name:            "cluster",
+			Role:            "peer",
+			Interface:       "mysql-ha",
+		}},
+		Relations: [][]*params.RelationMatrixEntry{{
+			{RelationId: 3, Key: "mysql:cluster", Scope: "global"},
+		}},
+	}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "RelationSettings")
+			c.Check(version, gc.Equals, 2)
+			c.Check(request, gc.Equals, "RelationMatrix")
+			c.Check(a, gc.IsNil)
+			*(result.(*params.RelationMatrix)) = matrix
+			return nil
+		},
+		BestVersion: 2,
+	}
+	result, err := relationsettings.NewClient(apiCaller).RelationMatrix()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, matrix)
+}
+
+func (s *clientSuite) TestRelationMatrixNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(_ string, _ int, _, _ string, _, _ interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 1,
+	}
+	_, err := relationsettings.NewClient(apiCaller).RelationMatrix()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -111,6 +111,29 @@ func (c *Client) SaveMacaroon(entity names.Tag, mac *macaroon.Macaroon) error {
 	return nil
 }
 
+// RotateRelationKeys increments the key generation of the cross-model
+// relation, so that the related units replace the keys with which they
+// encrypt the relation settings, and returns the new generation.
+func (c *Client) RotateRelationKeys(relationTag names.Tag) (int, error) {
+	if c.facade.BestAPIVersion() < 3 {
+		return 0, errors.NotSupportedf("RotateRelationKeys")
+	}
+	args := params.Entities{Entities: []params.Entity{{Tag: relationTag.String()}}}
+	var results params.IntResults
+	err := c.facade.FacadeCall("RotateRelationKeys", args, &results)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return 0, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return 0, errors.Trace(result.Error)
+	}
+	return result.Result, nil
+}
+
 // Relations returns information about the cross-model relations with the specified keys
 // in the local model.
 func (c *Client) Relations(keys []string) ([]params.RemoteRelationResult, error) {
@@ -4,6 +4,7 @@
 package remoterelations_test
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -398,6 +399,61 @@ func (s *remoteRelationsSuite) TestSaveMacaroon(c *gc.C) {
 	c.Check(callCount, gc.Equals, 1)
 }
 
+func (s *remoteRelationsSuite) TestRotateRelationKeys(c *gc.C) {
+	rel := names.NewRelationTag("mysql:db wordpress:db")
+	var callCount int
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "RemoteRelations")
+			c.Check(version, gc.Equals, 3)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "RotateRelationKeys")
+			c.Assert(arg, gc.DeepEquals, params.Entities{Entities: []params.Entity{{Tag: rel.String()}}})
+			c.Assert(result, gc.FitsTypeOf, &params.IntResults{})
+			*(result.(*params.IntResults)) = params.IntResults{
+				Results: []params.IntResult{{Result: 2}},
+			}
+			callCount++
+			return nil
+		}),
+		BestVersion: 3,
+	}
+	client := remoterelations.NewClient(apiCaller)
+	generation, err := client.RotateRelationKeys(rel)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(generation, gc.Equals, 2)
+	c.Check(callCount, gc.Equals, 1)
+}
+
+func (s *remoteRelationsSuite) TestRotateRelationKeysError(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			*(result.(*params.IntResults)) = params.IntResults{
+				Results: []params.IntResult{{Error: &params.Error{Message: "FAIL"}}},
+			}
+			return nil
+		}),
+		BestVersion: 3,
+	}
+	client := remoterelations.NewClient(apiCaller)
+	_, err := client.RotateRelationKeys(names.NewRelationTag("mysql:db wordpress:db"))
+	c.Check(err, gc.ErrorMatches, "FAIL")
+}
+
+func (s *remoteRelationsSuite) TestRotateRelationKeysNotSupported(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected api call")
+			return nil
+		}),
+		BestVersion: 2,
+	}
+	client := remoterelations.NewClient(apiCaller)
+	_, err := client.RotateRelationKeys(names.NewRelationTag("mysql:db wordpress:db"))
+	c.Check(err, gc.ErrorMatches, "RotateRelationKeys not supported")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *remoteRelationsSuite) TestSetRemoteApplicationStatus(c *gc.C) {
 	var callCount int
 	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
@@ -10,6 +10,7 @@ import (
 	"path"
 
 	"github.com/juju/errors"
+	"github.com/juju/names/v4"
 
 	api "github.com/juju/juju/api/resources"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
@@ -21,6 +22,9 @@ import (
 type FacadeCaller interface {
 	// FacadeCall makes an API request.
 	FacadeCall(request string, args, response interface{}) error
+
+	// BestAPIVersion returns the API version of the facade.
+	BestAPIVersion() int
 }
 
 // HTTPClient exposes the raw API HTTP caller functionality needed here.
@@ -109,6 +113,26 @@ func (c *UnitFacadeClient) getResourceInfo(resourceName string) (resource.Resour
 	return res, nil
 }
 
+// ShareResource shares the named resource of the unit's application
+// with a related subordinate application, so that the subordinate's
+// units can get the resource too.
+func (c *UnitFacadeClient) ShareResource(resourceName, applicationName string) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("sharing resources on v%d facade", c.BestAPIVersion())
+	}
+	args := params.ShareResourcesArgs{
+		Args: []params.ShareResourceArg{{
+			ResourceName:   resourceName,
+			ApplicationTag: names.NewApplicationTag(applicationName).String(),
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.FacadeCall("ShareResources", &args, &results); err != nil {
+		return errors.Annotate(err, "could not share resource")
+	}
+	return results.OneError()
+}
+
 type unitHTTPClient struct {
 	HTTPClient
 	unitName string
@@ -61,6 +61,31 @@ func (s *UnitFacadeClientSuite) TestGetResource(c *gc.C) {
 	c.Check(content, jc.DeepEquals, opened)
 }
 
+func (s *UnitFacadeClientSuite) TestShareResource(c *gc.C) {
+	s.api.ReturnVersion = 2
+	cl := client.NewUnitFacadeClient(context.Background(), s.api, s.api)
+
+	err := cl.ShareResource("spam", "a-subordinate")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "FacadeCall")
+	s.stub.CheckCall(c, 0, "FacadeCall", &params.ShareResourcesArgs{
+		Args: []params.ShareResourceArg{{
+			ResourceName:   "spam",
+			ApplicationTag: "application-a-subordinate",
+		}},
+	}, &params.ErrorResults{Results: []params.ErrorResult{{}}})
+}
+
+func (s *UnitFacadeClientSuite) TestShareResourceNotSupported(c *gc.C) {
+	s.api.ReturnVersion = 1
+	cl := client.NewUnitFacadeClient(context.Background(), s.api, s.api)
+
+	err := cl.ShareResource("spam", "a-subordinate")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	s.stub.CheckNoCalls(c)
+}
+
 func (s *UnitFacadeClientSuite) TestUnitDoer(c *gc.C) {
 	body := filetesting.NewStubFile(s.stub, nil)
 	req, err := http.NewRequest("GET", "/resources/eggs", body)
@@ -82,6 +107,7 @@ type stubAPI struct {
 	ReturnFacadeCall params.UnitResourcesResult
 	ReturnUnit       string
 	ReturnDo         *http.Response
+	ReturnVersion    int
 }
 
 func (s *stubAPI) setResource(info resource.Resource, reader io.ReadCloser) {
@@ -101,11 +127,19 @@ func (s *stubAPI) FacadeCall(request string, args, response interface{}) error {
 		return errors.Trace(err)
 	}
 
-	resp := response.(*params.UnitResourcesResult)
-	*resp = s.ReturnFacadeCall
+	switch resp := response.(type) {
+	case *params.UnitResourcesResult:
+		*resp = s.ReturnFacadeCall
+	case *params.ErrorResults:
+		*resp = params.ErrorResults{Results: []params.ErrorResult{{}}}
+	}
 	return nil
 }
 
+func (s *stubAPI) BestAPIVersion() int {
+	return s.ReturnVersion
+}
+
 func (s *stubAPI) Unit() string {
 	s.AddCall("Unit")
 	s.NextErr() // Pop one off.
@@ -0,0 +1,53 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package sandboxprofiles provides access to the SandboxProfiles
+// facade, used by machine agents to apply the sandboxing declared by
+// the charms of the units on their machines.
+package sandboxprofiles
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+// Client allows access to the SandboxProfiles API end point.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the SandboxProfiles API.
+func NewClient(caller base.APICaller) *Client {
+	return &Client{facade: base.NewFacadeCaller(caller, "SandboxProfiles")}
+}
+
+// WatchSandboxProfiles returns a NotifyWatcher which notifies when the
+// sandbox profiles to be applied to the agent's machine may have
+// changed.
+func (c *Client) WatchSandboxProfiles() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := c.facade.FacadeCall("WatchSandboxProfiles", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), result), nil
+}
+
+// SandboxProfiles returns the sandbox profiles to be applied to the
+// agent's machine.
+func (c *Client) SandboxProfiles() ([]params.SandboxProfile, error) {
+	var result params.SandboxProfilesResult
+	if err := c.facade.FacadeCall("SandboxProfiles", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Profiles, nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sandboxprofiles_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/sandboxprofiles"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestWatchSandboxProfilesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "SandboxProfiles")
+			c.Check(request, gc.Equals, "WatchSandboxProfiles")
+			c.Check(a, gc.IsNil)
+			*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+				Error: &params.Error{Message: "boom"},
+			}
+			return nil
+		})
+	w, err := sandboxprofiles.NewClient(apiCaller).WatchSandboxProfiles()
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(w, gc.IsNil)
+}
+
+func (s *clientSuite) TestSandboxProfiles(c *gc.C) {
+	profiles := []params.SandboxProfile{{
+		Application: "redis",
+		Sysctls:     map[string]string{"vm.overcommit_memory": "1"},
+	}}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "SandboxProfiles")
+			c.Check(request, gc.Equals, "SandboxProfiles")
+			c.Check(a, gc.IsNil)
+			*(result.(*params.SandboxProfilesResult)) = params.SandboxProfilesResult{Profiles: profiles}
+			return nil
+		})
+	result, err := sandboxprofiles.NewClient(apiCaller).SandboxProfiles()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, profiles)
+}
+
+func (s *clientSuite) TestSandboxProfilesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(_ string, _ int, _, _ string, _, result interface{}) error {
+			*(result.(*params.SandboxProfilesResult)) = params.SandboxProfilesResult{
+				Error: &params.Error{Message: "boom"},
+			}
+			return nil
+		})
+	_, err := sandboxprofiles.NewClient(apiCaller).SandboxProfiles()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,40 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package schema provides access to the Schema facade, which describes
+// the RPC schema of the running controller.
+package schema
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the Schema API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the Schema API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Schema")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// FacadeSchemas returns the JSON schemas of the named facades, or of
+// all of the controller's facades if none are named. If latest is
+// true, only the latest version of each facade is described.
+func (c *Client) FacadeSchemas(latest bool, facades ...string) (params.FacadeSchemaResults, error) {
+	args := params.FacadeSchemaArgs{
+		Facades: facades,
+		Latest:  latest,
+	}
+	var result params.FacadeSchemaResults
+	if err := c.facade.FacadeCall("FacadeSchemas", args, &result); err != nil {
+		return params.FacadeSchemaResults{}, errors.Trace(err)
+	}
+	return result, nil
+}
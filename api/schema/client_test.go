@@ -0,0 +1,65 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package schema_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/schema"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestFacadeSchemas(c *gc.C) {
+	results := params.FacadeSchemaResults{
+		JujuVersion: "2.9.0",
+		Facades: []params.FacadeSchema{{
+			Name:    "Pinger",
+			Version: 1,
+			Schema:  map[string]interface{}{"type": "object"},
+		}},
+	}
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Schema")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "FacadeSchemas")
+			c.Check(a, jc.DeepEquals, params.FacadeSchemaArgs{
+				Facades: []string{"Pinger"},
+				Latest:  true,
+			})
+			*(result.(*params.FacadeSchemaResults)) = results
+			return nil
+		})
+	client := schema.NewClient(apiCaller)
+	result, err := client.FacadeSchemas(true, "Pinger")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result, jc.DeepEquals, results)
+}
+
+func (s *clientSuite) TestFacadeSchemasError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(_ string, _ int, _, _ string, _, _ interface{}) error {
+			return errors.New("boom")
+		})
+	client := schema.NewClient(apiCaller)
+	_, err := client.FacadeSchemas(false)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,103 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package scopedcredentials provides access to the ScopedCredentials
+// facade, used by the worker which issues and rotates the credentials
+// scoped to trusted applications.
+package scopedcredentials
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloud"
+)
+
+const scopedCredentialsFacade = "ScopedCredentials"
+
+// Application describes an application which should be given a
+// credential scoped to it, or which holds one it no longer needs.
+type Application struct {
+	// Name is the name of the application.
+	Name string
+
+	// Expiry is when the application's current scoped credential
+	// expires, or the zero time if it has none.
+	Expiry time.Time
+
+	// Revoke is true if the application's scoped credential is no
+	// longer needed, and should be revoked.
+	Revoke bool
+}
+
+// API provides access to the ScopedCredentials API facade.
+type API struct {
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side ScopedCredentials facade.
+func NewAPI(caller base.APICaller) *API {
+	facadeCaller := base.NewFacadeCaller(caller, scopedCredentialsFacade)
+	return &API{facade: facadeCaller}
+}
+
+// Applications returns the applications which should be given a
+// credential scoped to them, or which hold one they no longer need.
+func (api *API) Applications() ([]Application, error) {
+	var result params.ScopedCredentialApplications
+	if err := api.facade.FacadeCall("Applications", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	apps := make([]Application, len(result.Applications))
+	for i, app := range result.Applications {
+		tag, err := names.ParseApplicationTag(app.ApplicationTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		apps[i] = Application{
+			Name:   tag.Id(),
+			Revoke: app.Revoke,
+		}
+		if app.Expiry != nil {
+			apps[i].Expiry = *app.Expiry
+		}
+	}
+	return apps, nil
+}
+
+// SetScopedCredential records the credential issued for the use of the
+// named application.
+func (api *API) SetScopedCredential(appName string, credential cloud.Credential, expiry time.Time) error {
+	args := params.SetScopedCredentialArgs{
+		Args: []params.SetScopedCredentialArg{{
+			ApplicationTag: names.NewApplicationTag(appName).String(),
+			Credential: params.CloudCredential{
+				AuthType:   string(credential.AuthType()),
+				Attributes: credential.Attributes(),
+			},
+			Expiry: expiry,
+		}},
+	}
+	var results params.ErrorResults
+	if err := api.facade.FacadeCall("SetScopedCredentials", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// RemoveScopedCredential removes the credential recorded for the use of
+// the named application.
+func (api *API) RemoveScopedCredential(appName string) error {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(appName).String()}},
+	}
+	var results params.ErrorResults
+	if err := api.facade.FacadeCall("RemoveScopedCredentials", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
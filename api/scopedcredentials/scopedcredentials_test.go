@@ -0,0 +1,108 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package scopedcredentials_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/scopedcredentials"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloud"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type scopedCredentialsSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&scopedCredentialsSuite{})
+
+var expiry = time.Date(2021, 6, 7, 8, 9, 10, 0, time.UTC)
+
+func (s *scopedCredentialsSuite) TestApplications(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ScopedCredentials")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Applications")
+			c.Check(a, gc.IsNil)
+			*(result.(*params.ScopedCredentialApplications)) = params.ScopedCredentialApplications{
+				Applications: []params.ScopedCredentialApplication{{
+					ApplicationTag: "application-wordpress",
+				}, {
+					ApplicationTag: "application-mysql",
+					Expiry:         &expiry,
+					Revoke:         true,
+				}},
+			}
+			return nil
+		})
+	apps, err := scopedcredentials.NewAPI(apiCaller).Applications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(apps, jc.DeepEquals, []scopedcredentials.Application{{
+		Name: "wordpress",
+	}, {
+		Name:   "mysql",
+		Expiry: expiry,
+		Revoke: true,
+	}})
+}
+
+func (s *scopedCredentialsSuite) TestApplicationsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(_ string, _ int, _, _ string, _, _ interface{}) error {
+			return errors.New("boom")
+		})
+	_, err := scopedcredentials.NewAPI(apiCaller).Applications()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *scopedCredentialsSuite) TestSetScopedCredential(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "ScopedCredentials")
+			c.Check(request, gc.Equals, "SetScopedCredentials")
+			c.Check(a, jc.DeepEquals, params.SetScopedCredentialArgs{
+				Args: []params.SetScopedCredentialArg{{
+					ApplicationTag: "application-wordpress",
+					Credential: params.CloudCredential{
+						AuthType:   "oauth2",
+						Attributes: map[string]string{"Token": "scoped-token"},
+					},
+					Expiry: expiry,
+				}},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		})
+	credential := cloud.NewCredential(cloud.OAuth2AuthType, map[string]string{"Token": "scoped-token"})
+	err := scopedcredentials.NewAPI(apiCaller).SetScopedCredential("wordpress", credential, expiry)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *scopedCredentialsSuite) TestRemoveScopedCredentialError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(request, gc.Equals, "RemoveScopedCredentials")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "application-wordpress"}},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+			}
+			return nil
+		})
+	err := scopedcredentials.NewAPI(apiCaller).RemoveScopedCredential("wordpress")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
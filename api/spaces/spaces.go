@@ -152,6 +152,42 @@ func (api *API) RenameSpace(oldName string, newName string) error {
 	return nil
 }
 
+// MergeSpaces merges each of the fromNames spaces into the toName space,
+// removing them once their subnets, endpoint bindings and constraints
+// have been moved over.
+func (api *API) MergeSpaces(toName string, fromNames []string, force bool) error {
+	if api.facade.BestAPIVersion() < 7 {
+		return errors.NotSupportedf("merging spaces")
+	}
+	args := params.MergeSpacesParams{Args: make([]params.MergeSpaceParams, len(fromNames))}
+	for i, fromName := range fromNames {
+		args.Args[i] = params.MergeSpaceParams{
+			FromSpaceTag: names.NewSpaceTag(fromName).String(),
+			ToSpaceTag:   names.NewSpaceTag(toName).String(),
+			Force:        force,
+		}
+	}
+	var response params.ErrorResults
+	if err := api.facade.FacadeCall("MergeSpaces", args, &response); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(response.Combine())
+}
+
+// CheckSpaces returns the problems found with the model's spaces, and
+// the default space, endpoint bindings and constraints which refer to
+// them.
+func (api *API) CheckSpaces() ([]params.SpaceIssue, error) {
+	if api.facade.BestAPIVersion() < 8 {
+		return nil, errors.NotSupportedf("checking spaces")
+	}
+	var response params.CheckSpacesResult
+	if err := api.facade.FacadeCall("CheckSpaces", nil, &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return response.Issues, nil
+}
+
 // RemoveSpace removes a space.
 func (api *API) RemoveSpace(name string, force bool, dryRun bool) (params.RemoveSpaceResult, error) {
 	var response params.RemoveSpaceResults
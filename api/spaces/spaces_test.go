@@ -241,6 +241,60 @@ func (s *spacesSuite) TestRenameSpaceError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "bam")
 }
 
+func (s *spacesSuite) TestMergeSpaces(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+	args := params.MergeSpacesParams{
+		Args: []params.MergeSpaceParams{{
+			FromSpaceTag: names.NewSpaceTag("db").String(),
+			ToSpaceTag:   names.NewSpaceTag("internal").String(),
+			Force:        true,
+		}, {
+			FromSpaceTag: names.NewSpaceTag("apps").String(),
+			ToSpaceTag:   names.NewSpaceTag("internal").String(),
+			Force:        true,
+		}},
+	}
+	resultSource := params.ErrorResults{Results: []params.ErrorResult{{}, {
+		Error: &params.Error{Message: "bam"},
+	}}}
+	s.fCaller.EXPECT().BestAPIVersion().Return(7)
+	s.fCaller.EXPECT().FacadeCall("MergeSpaces", args, gomock.Any()).SetArg(2, resultSource).Return(nil)
+
+	err := s.API.MergeSpaces("internal", []string{"db", "apps"}, true)
+	c.Assert(err, gc.ErrorMatches, "bam")
+}
+
+func (s *spacesSuite) TestMergeSpacesNotSupported(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+	s.fCaller.EXPECT().BestAPIVersion().Return(6)
+
+	err := s.API.MergeSpaces("internal", []string{"db"}, false)
+	c.Assert(err, gc.ErrorMatches, "merging spaces not supported")
+}
+
+func (s *spacesSuite) TestCheckSpaces(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+	issues := []params.SpaceIssue{{
+		Severity: "warning",
+		Entity:   "space-empty",
+		Message:  `space "empty" has no subnets`,
+	}}
+	s.fCaller.EXPECT().BestAPIVersion().Return(8)
+	s.fCaller.EXPECT().FacadeCall("CheckSpaces", nil, gomock.Any()).SetArg(2, params.CheckSpacesResult{Issues: issues}).Return(nil)
+
+	result, err := s.API.CheckSpaces()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, issues)
+}
+
+func (s *spacesSuite) TestCheckSpacesNotSupported(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+	s.fCaller.EXPECT().BestAPIVersion().Return(7)
+
+	_, err := s.API.CheckSpaces()
+	c.Assert(err, gc.ErrorMatches, "checking spaces not supported")
+}
+
 type SpacesSuite struct {
 	coretesting.BaseSuite
 
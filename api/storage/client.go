@@ -355,3 +355,86 @@ func (c *Client) Import(
 	}
 	return names.ParseStorageTag(results.Results[0].Result.StorageTag)
 }
+
+// CreateSnapshot takes a provider snapshot of the volume backing the
+// specified storage instance.
+func (c *Client) CreateSnapshot(storageId, description string) (params.StorageSnapshot, error) {
+	if c.BestAPIVersion() < 7 {
+		return params.StorageSnapshot{}, errors.New("storage snapshots are not supported by this version of Juju")
+	}
+	if !names.IsValidStorage(storageId) {
+		return params.StorageSnapshot{}, errors.NotValidf("storage ID %q", storageId)
+	}
+	var results params.StorageSnapshotResults
+	args := params.CreateStorageSnapshotsArgs{
+		Snapshots: []params.CreateStorageSnapshotArg{{
+			StorageTag:  names.NewStorageTag(storageId).String(),
+			Description: description,
+		}},
+	}
+	if err := c.facade.FacadeCall("CreateSnapshots", args, &results); err != nil {
+		return params.StorageSnapshot{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.StorageSnapshot{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.StorageSnapshot{}, err
+	}
+	return *results.Results[0].Result, nil
+}
+
+// ListSnapshots returns the provider snapshots of the volume backing
+// the specified storage instance.
+func (c *Client) ListSnapshots(storageId string) ([]params.StorageSnapshot, error) {
+	if c.BestAPIVersion() < 7 {
+		return nil, errors.New("storage snapshots are not supported by this version of Juju")
+	}
+	if !names.IsValidStorage(storageId) {
+		return nil, errors.NotValidf("storage ID %q", storageId)
+	}
+	var results params.StorageSnapshotsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewStorageTag(storageId).String()}},
+	}
+	if err := c.facade.FacadeCall("ListSnapshots", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Result, nil
+}
+
+// RestoreSnapshot creates a new volume from a snapshot of the specified
+// storage instance, and imports it into the model as detached storage.
+// The tag of the new storage instance is returned.
+func (c *Client) RestoreSnapshot(storageId, snapshotId, availabilityZone string) (names.StorageTag, error) {
+	if c.BestAPIVersion() < 7 {
+		return names.StorageTag{}, errors.New("storage snapshots are not supported by this version of Juju")
+	}
+	if !names.IsValidStorage(storageId) {
+		return names.StorageTag{}, errors.NotValidf("storage ID %q", storageId)
+	}
+	var results params.ImportStorageResults
+	args := params.RestoreStorageSnapshotsArgs{
+		Snapshots: []params.RestoreStorageSnapshotArg{{
+			StorageTag:       names.NewStorageTag(storageId).String(),
+			SnapshotId:       snapshotId,
+			AvailabilityZone: availabilityZone,
+		}},
+	}
+	if err := c.facade.FacadeCall("RestoreSnapshots", args, &results); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return names.StorageTag{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return names.StorageTag{}, err
+	}
+	return names.ParseStorageTag(results.Results[0].Result.StorageTag)
+}
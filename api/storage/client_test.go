@@ -1077,3 +1077,93 @@ func (s *storageMockSuite) TestUpdatePoolFacadeCallError(c *gc.C) {
 	err := storageClient.UpdatePool("", "", nil)
 	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
 }
+
+func (s *storageMockSuite) TestCreateSnapshot(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(request, gc.Equals, "CreateSnapshots")
+				c.Check(a, jc.DeepEquals, params.CreateStorageSnapshotsArgs{
+					Snapshots: []params.CreateStorageSnapshotArg{{
+						StorageTag:  "storage-data-0",
+						Description: "nightly",
+					}},
+				})
+				results := result.(*params.StorageSnapshotResults)
+				results.Results = []params.StorageSnapshotResult{{
+					Result: &params.StorageSnapshot{
+						StorageTag: "storage-data-0",
+						SnapshotId: "snap-0",
+					},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := storage.NewClient(apiCaller)
+	snapshot, err := client.CreateSnapshot("data/0", "nightly")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot, jc.DeepEquals, params.StorageSnapshot{
+		StorageTag: "storage-data-0",
+		SnapshotId: "snap-0",
+	})
+}
+
+func (s *storageMockSuite) TestCreateSnapshotNotSupported(c *gc.C) {
+	client := storage.NewClient(basetesting.BestVersionCaller{BestVersion: 6})
+	_, err := client.CreateSnapshot("data/0", "")
+	c.Assert(err, gc.ErrorMatches, "storage snapshots are not supported by this version of Juju")
+}
+
+func (s *storageMockSuite) TestListSnapshots(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(request, gc.Equals, "ListSnapshots")
+				c.Check(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "storage-data-0"}},
+				})
+				results := result.(*params.StorageSnapshotsResults)
+				results.Results = []params.StorageSnapshotsResult{{
+					Result: []params.StorageSnapshot{{SnapshotId: "snap-0"}},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := storage.NewClient(apiCaller)
+	snapshots, err := client.ListSnapshots("data/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, jc.DeepEquals, []params.StorageSnapshot{{SnapshotId: "snap-0"}})
+}
+
+func (s *storageMockSuite) TestRestoreSnapshot(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(request, gc.Equals, "RestoreSnapshots")
+				c.Check(a, jc.DeepEquals, params.RestoreStorageSnapshotsArgs{
+					Snapshots: []params.RestoreStorageSnapshotArg{{
+						StorageTag:       "storage-data-0",
+						SnapshotId:       "snap-0",
+						AvailabilityZone: "zone-a",
+					}},
+				})
+				results := result.(*params.ImportStorageResults)
+				results.Results = []params.ImportStorageResult{{
+					Error: &params.Error{Message: "boom"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := storage.NewClient(apiCaller)
+	_, err := client.RestoreSnapshot("data/0", "snap-0", "zone-a")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
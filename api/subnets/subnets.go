@@ -93,6 +93,21 @@ func (api *API) SubnetsByCIDR(cidrs []string) ([]params.SubnetsResult, error) {
 	return result.Results, nil
 }
 
+// ReloadSubnets re-queries the provider for its subnets and availability
+// zones, updating those known to the model. It returns the subnets that the
+// provider no longer reports.
+func (api *API) ReloadSubnets() ([]params.MissingSubnet, error) {
+	if api.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("reloading subnets")
+	}
+
+	var result params.ReloadSubnetsResult
+	if err := api.facade.FacadeCall("ReloadSubnets", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Missing, nil
+}
+
 func makeAddSubnetsParamsV2(cidr string, providerId network.Id, space names.SpaceTag, zones []string) params.AddSubnetsParamsV2 {
 	var subnetTag string
 	if cidr != "" {
@@ -246,3 +246,37 @@ func (s *SubnetsSuite) TestSubnetsByCIDRWithResults(c *gc.C) {
 		}},
 	}}, nil, "")
 }
+
+func (s *SubnetsSuite) TestReloadSubnets(c *gc.C) {
+	missing := []params.MissingSubnet{{
+		CIDR:     "10.0.0.0/24",
+		SpaceTag: "space-db",
+		Machines: []params.Entity{{Tag: "machine-0"}},
+	}}
+	apiCaller := apitesting.APICallChecker(c, apitesting.APICall{
+		Facade:  "Subnets",
+		Method:  "ReloadSubnets",
+		Results: params.ReloadSubnetsResult{Missing: missing},
+	})
+	api := subnets.NewAPI(&apitesting.BestVersionCaller{
+		BestVersion:   5,
+		APICallerFunc: apiCaller.APICallerFunc,
+	})
+
+	result, err := api.ReloadSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(apiCaller.CallCount, gc.Equals, 1)
+	c.Assert(result, jc.DeepEquals, missing)
+}
+
+func (s *SubnetsSuite) TestReloadSubnetsNotSupported(c *gc.C) {
+	apiCaller := apitesting.APICallChecker(c)
+	api := subnets.NewAPI(&apitesting.BestVersionCaller{
+		BestVersion:   4,
+		APICallerFunc: apiCaller.APICallerFunc,
+	})
+
+	_, err := api.ReloadSubnets()
+	c.Assert(err, gc.ErrorMatches, "reloading subnets not supported")
+	c.Assert(apiCaller.CallCount, gc.Equals, 0)
+}
@@ -10,10 +10,6 @@ import (
 	"github.com/juju/juju/core/life"
 )
 
-var (
-	NewSettings = newSettings
-)
-
 // CreateUnit creates uniter.Unit for tests.
 func CreateUnit(st *State, tag names.UnitTag) *Unit {
 	return &Unit{
@@ -25,6 +25,8 @@ type Relation struct {
 	life      life.Value
 	suspended bool
 	otherApp  string
+
+	keyGeneration int
 }
 
 // Tag returns the relation tag.
@@ -55,6 +57,13 @@ func (r *Relation) Suspended() bool {
 	return r.suspended
 }
 
+// KeyGeneration returns the generation of the keys with which the
+// related units encrypt their settings, or zero if the relation's
+// settings are not encrypted.
+func (r *Relation) KeyGeneration() int {
+	return r.keyGeneration
+}
+
 // UpdateSuspended updates the in memory value of the
 // relation's suspended attribute.
 func (r *Relation) UpdateSuspended(suspended bool) {
@@ -75,11 +84,12 @@ func (r *Relation) Refresh() error {
 	if err != nil {
 		return err
 	}
-	// NOTE: The status and life cycle information are the only
-	// things that can change - id, tag and endpoint
+	// NOTE: The status, life cycle and key generation information
+	// are the only things that can change - id, tag and endpoint
 	// information are static.
 	r.life = result.Life
 	r.suspended = result.Suspended
+	r.keyGeneration = result.KeyGeneration
 
 	return nil
 }
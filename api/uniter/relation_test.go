@@ -50,6 +50,7 @@ func (s *relationSuite) TestRelation(c *gc.C) {
 					},
 				},
 				OtherApplication: "mysql",
+				KeyGeneration:    1,
 			}},
 		}
 		return nil
@@ -63,6 +64,7 @@ func (s *relationSuite) TestRelation(c *gc.C) {
 	c.Assert(rel.Life(), gc.Equals, life.Alive)
 	c.Assert(rel.String(), gc.Equals, tag.Id())
 	c.Assert(rel.OtherApplication(), gc.Equals, "mysql")
+	c.Assert(rel.KeyGeneration(), gc.Equals, 1)
 	ep, err := rel.Endpoint()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(ep, jc.DeepEquals, &uniter.Endpoint{
@@ -89,8 +91,9 @@ func (s *relationSuite) TestRefresh(c *gc.C) {
 		c.Assert(result, gc.FitsTypeOf, &params.RelationResults{})
 		*(result.(*params.RelationResults)) = params.RelationResults{
 			Results: []params.RelationResult{{
-				Life:      life.Dying,
-				Suspended: true,
+				Life:          life.Dying,
+				Suspended:     true,
+				KeyGeneration: 2,
 			}},
 		}
 		return nil
@@ -103,6 +106,7 @@ func (s *relationSuite) TestRefresh(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(rel.Life(), gc.Equals, life.Dying)
 	c.Assert(rel.Suspended(), jc.IsTrue)
+	c.Assert(rel.KeyGeneration(), gc.Equals, 2)
 }
 
 func (s *relationSuite) TestSuspended(c *gc.C) {
@@ -18,7 +18,10 @@ type Settings struct {
 	dirty       bool
 }
 
-func newSettings(relationTag, unitTag string, settings params.Settings) *Settings {
+// NewSettings returns a Settings for the unit or application with the
+// given tag within the relation with the given tag, holding the supplied
+// settings.
+func NewSettings(relationTag, unitTag string, settings params.Settings) *Settings {
 	if settings == nil {
 		settings = make(params.Settings)
 	}
@@ -29,6 +32,13 @@ func newSettings(relationTag, unitTag string, settings params.Settings) *Setting
 	}
 }
 
+// WithSettings returns a new Settings for the same relation and unit
+// or application as s, holding the supplied settings in place of its
+// own and no changes.
+func (s *Settings) WithSettings(settings params.Settings) *Settings {
+	return NewSettings(s.relationTag, s.unitTag, settings)
+}
+
 // Map returns all keys and values of the node.
 func (s *Settings) Map() params.Settings {
 	settingsCopy := make(params.Settings)
@@ -21,7 +21,7 @@ type settingsSuite struct {
 var _ = gc.Suite(&settingsSuite{})
 
 func (s *settingsSuite) TestNewSettingsAndMap(c *gc.C) {
-	// Make sure newSettings accepts nil settings.
+	// Make sure NewSettings accepts nil settings.
 	settings := uniter.NewSettings("blah", "foo", nil)
 	theMap := settings.Map()
 	c.Assert(theMap, gc.NotNil)
@@ -87,6 +87,19 @@ func (s *settingsSuite) TestDelete(c *gc.C) {
 	})
 }
 
+func (s *settingsSuite) TestWithSettings(c *gc.C) {
+	settings := uniter.NewSettings("blah", "foo", params.Settings{"foo": "bar"})
+	settings.Set("abc", "123")
+
+	other := settings.WithSettings(params.Settings{"foo": "qaz"})
+	c.Assert(other.Map(), gc.DeepEquals, params.Settings{"foo": "qaz"})
+	c.Assert(other.IsDirty(), jc.IsFalse)
+	c.Assert(settings.Map(), gc.DeepEquals, params.Settings{
+		"foo": "bar",
+		"abc": "123",
+	})
+}
+
 func (s *settingsSuite) TestWrite(c *gc.C) {
 	settingsUpdated := false
 	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
@@ -20,11 +20,12 @@ import (
 
 // Unit represents a juju unit as seen by a uniter worker.
 type Unit struct {
-	st           *State
-	tag          names.UnitTag
-	life         life.Value
-	resolvedMode params.ResolvedMode
-	providerID   string
+	st             *State
+	tag            names.UnitTag
+	life           life.Value
+	resolvedMode   params.ResolvedMode
+	providerID     string
+	drainRequested bool
 }
 
 // Tag returns the unit's tag.
@@ -57,6 +58,12 @@ func (u *Unit) Resolved() params.ResolvedMode {
 	return u.resolvedMode
 }
 
+// DrainRequested returns whether the unit has been asked to drain
+// ahead of its removal.
+func (u *Unit) DrainRequested() bool {
+	return u.drainRequested
+}
+
 // Refresh updates the cached local copy of the unit's data.
 func (u *Unit) Refresh() error {
 	var results params.UnitRefreshResults
@@ -80,6 +87,7 @@ func (u *Unit) Refresh() error {
 	u.life = result.Life
 	u.resolvedMode = result.Resolved
 	u.providerID = result.ProviderID
+	u.drainRequested = result.DrainRequested
 	return nil
 }
 
@@ -559,6 +567,103 @@ func (u *Unit) ClearResolved() error {
 	return result.OneError()
 }
 
+// SetDrained records that the unit has completed its pre-remove hook,
+// and is ready to be removed.
+func (u *Unit) SetDrained() error {
+	if u.st.facade.BestAPIVersion() < 19 {
+		return errors.NotImplementedf("SetDrained")
+	}
+	var result params.ErrorResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("SetUnitsDrained", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
+// ResourceLimits returns the limits to apply to the workload processes
+// of the unit, from its application's cpu-quota and memory-limit
+// constraints.
+func (u *Unit) ResourceLimits() (params.ResourceLimits, error) {
+	if u.st.facade.BestAPIVersion() < 21 {
+		return params.ResourceLimits{}, errors.NotImplementedf("ResourceLimits")
+	}
+	var results params.ResourceLimitsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("ResourceLimits", args, &results)
+	if err != nil {
+		return params.ResourceLimits{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.ResourceLimits{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.ResourceLimits{}, result.Error
+	}
+	return result.Result, nil
+}
+
+// CreateStorageSnapshot takes a provider snapshot of the volume backing
+// the specified storage instance, which must be attached to the unit.
+func (u *Unit) CreateStorageSnapshot(storageTag names.StorageTag, description string) (params.StorageSnapshot, error) {
+	if u.st.facade.BestAPIVersion() < 22 {
+		return params.StorageSnapshot{}, errors.NotImplementedf("CreateStorageSnapshots")
+	}
+	var results params.StorageSnapshotResults
+	args := params.CreateUnitStorageSnapshotsArgs{
+		Snapshots: []params.CreateUnitStorageSnapshotArg{{
+			UnitTag:     u.tag.String(),
+			StorageTag:  storageTag.String(),
+			Description: description,
+		}},
+	}
+	err := u.st.facade.FacadeCall("CreateStorageSnapshots", args, &results)
+	if err != nil {
+		return params.StorageSnapshot{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.StorageSnapshot{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.StorageSnapshot{}, result.Error
+	}
+	return *result.Result, nil
+}
+
+// SecretConfigValue returns the value of the secret charm config option
+// with the given key. The read is recorded against the unit.
+func (u *Unit) SecretConfigValue(key string) (string, error) {
+	if u.st.facade.BestAPIVersion() < 23 {
+		return "", errors.NotImplementedf("SecretConfigValues")
+	}
+	var results params.StringResults
+	args := params.SecretConfigArgs{
+		Args: []params.SecretConfigArg{{
+			UnitTag: u.tag.String(),
+			Key:     key,
+		}},
+	}
+	err := u.st.facade.FacadeCall("SecretConfigValues", args, &results)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Result, nil
+}
+
 // WatchConfigSettingsHash returns a watcher for observing changes to
 // the unit's charm configuration settings (with a hash of the
 // settings content so we can determine whether it has changed since
@@ -876,13 +981,31 @@ func (u *Unit) AddStorage(constraints map[string][]params.StorageConstraints) er
 
 // NetworkInfo returns network interfaces/addresses for specified bindings.
 func (u *Unit) NetworkInfo(bindings []string, relationId *int) (map[string]params.NetworkInfoResult, error) {
-	var results params.NetworkInfoResults
+	return u.NetworkInfoForSchema(bindings, relationId, 0)
+}
+
+// NetworkInfoForSchema returns network interfaces/addresses for specified
+// bindings, rendered with the input NetworkInfoResult schema version.
+// A zero schema version requests the latest schema the controller supports.
+func (u *Unit) NetworkInfoForSchema(
+	bindings []string, relationId *int, schemaVersion int,
+) (map[string]params.NetworkInfoResult, error) {
 	args := params.NetworkInfoParams{
 		Unit:       u.tag.String(),
 		Endpoints:  bindings,
 		RelationId: relationId,
 	}
 
+	// Controllers prior to schema negotiation only render the first schema.
+	if u.st.facade.BestAPIVersion() < 20 {
+		if schemaVersion > params.NetworkInfoSchemaV1 {
+			return nil, errors.NotSupportedf("network info schema version %d", schemaVersion)
+		}
+	} else {
+		args.SchemaVersion = schemaVersion
+	}
+
+	var results params.NetworkInfoResults
 	err := u.st.facade.FacadeCall("NetworkInfo", args, &results)
 	if err != nil {
 		return nil, errors.Trace(err)
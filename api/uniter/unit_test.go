@@ -196,9 +196,10 @@ func (s *unitSuite) TestRefresh(c *gc.C) {
 		c.Assert(result, gc.FitsTypeOf, &params.UnitRefreshResults{})
 		*(result.(*params.UnitRefreshResults)) = params.UnitRefreshResults{
 			Results: []params.UnitRefreshResult{{
-				Life:       life.Dying,
-				Resolved:   params.ResolvedRetryHooks,
-				ProviderID: "666",
+				Life:           life.Dying,
+				Resolved:       params.ResolvedRetryHooks,
+				ProviderID:     "666",
+				DrainRequested: true,
 			}},
 		}
 		return nil
@@ -211,6 +212,7 @@ func (s *unitSuite) TestRefresh(c *gc.C) {
 	c.Assert(unit.Life(), gc.Equals, life.Dying)
 	c.Assert(unit.Resolved(), gc.Equals, params.ResolvedRetryHooks)
 	c.Assert(unit.Life(), gc.Equals, life.Dying)
+	c.Assert(unit.DrainRequested(), jc.IsTrue)
 }
 
 func (s *unitSuite) TestClearResolved(c *gc.C) {
@@ -231,6 +233,150 @@ func (s *unitSuite) TestClearResolved(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "biff")
 }
 
+func (s *unitSuite) TestSetDrained(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(request, gc.Equals, "SetUnitsDrained")
+		c.Assert(arg, gc.DeepEquals, params.Entities{Entities: []params.Entity{{Tag: "unit-mysql-0"}}})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{&params.Error{Message: "biff"}}},
+		}
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 19}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	err := unit.SetDrained()
+	c.Assert(err, gc.ErrorMatches, "biff")
+}
+
+func (s *unitSuite) TestSetDrainedNotImplemented(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected api call %q", request)
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 18}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	err := unit.SetDrained()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *unitSuite) TestResourceLimits(c *gc.C) {
+	quota := uint64(150)
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(request, gc.Equals, "ResourceLimits")
+		c.Assert(arg, gc.DeepEquals, params.Entities{Entities: []params.Entity{{Tag: "unit-mysql-0"}}})
+		c.Assert(result, gc.FitsTypeOf, &params.ResourceLimitsResults{})
+		*(result.(*params.ResourceLimitsResults)) = params.ResourceLimitsResults{
+			Results: []params.ResourceLimitsResult{{
+				Result: params.ResourceLimits{CPUQuota: &quota},
+			}},
+		}
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 21}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	limits, err := unit.ResourceLimits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(limits, jc.DeepEquals, params.ResourceLimits{CPUQuota: &quota})
+}
+
+func (s *unitSuite) TestResourceLimitsNotImplemented(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected api call %q", request)
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 20}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	_, err := unit.ResourceLimits()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *unitSuite) TestCreateStorageSnapshot(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(request, gc.Equals, "CreateStorageSnapshots")
+		c.Assert(arg, gc.DeepEquals, params.CreateUnitStorageSnapshotsArgs{
+			Snapshots: []params.CreateUnitStorageSnapshotArg{{
+				UnitTag:     "unit-mysql-0",
+				StorageTag:  "storage-data-0",
+				Description: "backup",
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.StorageSnapshotResults{})
+		*(result.(*params.StorageSnapshotResults)) = params.StorageSnapshotResults{
+			Results: []params.StorageSnapshotResult{{
+				Result: &params.StorageSnapshot{StorageTag: "storage-data-0", SnapshotId: "snap-0"},
+			}},
+		}
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 22}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	snapshot, err := unit.CreateStorageSnapshot(names.NewStorageTag("data/0"), "backup")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot, jc.DeepEquals, params.StorageSnapshot{StorageTag: "storage-data-0", SnapshotId: "snap-0"})
+}
+
+func (s *unitSuite) TestCreateStorageSnapshotNotImplemented(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected api call %q", request)
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 21}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	_, err := unit.CreateStorageSnapshot(names.NewStorageTag("data/0"), "")
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *unitSuite) TestSecretConfigValue(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(request, gc.Equals, "SecretConfigValues")
+		c.Assert(arg, gc.DeepEquals, params.SecretConfigArgs{
+			Args: []params.SecretConfigArg{{UnitTag: "unit-mysql-0", Key: "password"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.StringResults{})
+		*(result.(*params.StringResults)) = params.StringResults{
+			Results: []params.StringResult{{Result: "s3cret"}},
+		}
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 23}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	value, err := unit.SecretConfigValue("password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, gc.Equals, "s3cret")
+}
+
+func (s *unitSuite) TestSecretConfigValueNotImplemented(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected api call %q", request)
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 22}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	_, err := unit.SecretConfigValue("password")
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
 func (s *unitSuite) TestWatch(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
 		if objType == "NotifyWatcher" {
@@ -551,6 +697,60 @@ func (s *unitSuite) TestNetworkInfo(c *gc.C) {
 	c.Assert(result["db"].Error, gc.ErrorMatches, "FAIL")
 }
 
+func (s *unitSuite) TestNetworkInfoForSchema(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Uniter")
+		c.Check(request, gc.Equals, "NetworkInfo")
+		c.Check(arg, gc.DeepEquals, params.NetworkInfoParams{
+			Unit:          "unit-mysql-0",
+			Endpoints:     []string{"server"},
+			SchemaVersion: params.NetworkInfoSchemaV1,
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.NetworkInfoResults{})
+		*(result.(*params.NetworkInfoResults)) = params.NetworkInfoResults{
+			Results: map[string]params.NetworkInfoResult{
+				"server": {EgressSubnets: []string{"10.0.0.0/24"}},
+			},
+			SchemaVersion: params.NetworkInfoSchemaV1,
+		}
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 20}
+
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	result, err := unit.NetworkInfoForSchema([]string{"server"}, nil, params.NetworkInfoSchemaV1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result["server"].EgressSubnets, jc.DeepEquals, []string{"10.0.0.0/24"})
+}
+
+func (s *unitSuite) TestNetworkInfoForSchemaOldController(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		called = true
+		c.Check(arg, gc.DeepEquals, params.NetworkInfoParams{
+			Unit:      "unit-mysql-0",
+			Endpoints: []string{"server"},
+		})
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 19}
+
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	_, err := unit.NetworkInfoForSchema([]string{"server"}, nil, params.NetworkInfoSchemaV1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+
+	called = false
+	_, err = unit.NetworkInfoForSchema([]string{"server"}, nil, params.NetworkInfoSchemaV1+1)
+	c.Assert(err, gc.ErrorMatches, "network info schema version 2 not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(called, jc.IsFalse)
+}
+
 func (s *unitSuite) TestConfigSettings(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
 		c.Assert(objType, gc.Equals, "Uniter")
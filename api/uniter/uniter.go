@@ -237,12 +237,13 @@ func (st *State) Relation(relationTag names.RelationTag) (*Relation, error) {
 		return nil, err
 	}
 	return &Relation{
-		id:        result.Id,
-		tag:       relationTag,
-		life:      result.Life,
-		suspended: result.Suspended,
-		st:        st,
-		otherApp:  result.OtherApplication,
+		id:            result.Id,
+		tag:           relationTag,
+		life:          result.Life,
+		suspended:     result.Suspended,
+		st:            st,
+		otherApp:      result.OtherApplication,
+		keyGeneration: result.KeyGeneration,
 	}, nil
 }
 
@@ -338,12 +339,13 @@ func (st *State) RelationById(id int) (*Relation, error) {
 	}
 	relationTag := names.NewRelationTag(result.Key)
 	return &Relation{
-		id:        result.Id,
-		tag:       relationTag,
-		life:      result.Life,
-		suspended: result.Suspended,
-		st:        st,
-		otherApp:  result.OtherApplication,
+		id:            result.Id,
+		tag:           relationTag,
+		life:          result.Life,
+		suspended:     result.Suspended,
+		st:            st,
+		otherApp:      result.OtherApplication,
+		keyGeneration: result.KeyGeneration,
 	}, nil
 }
 
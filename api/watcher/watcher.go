@@ -138,10 +138,39 @@ func (w *commonWatcher) Wait() error {
 	return w.tomb.Wait()
 }
 
+// ResumableWatcher is implemented by watchers that can be resumed on a
+// new connection after their connection closes.
+type ResumableWatcher interface {
+	// ResumeToken returns the token for resuming the watcher from the
+	// last change it received, or "" if the watcher can not be resumed.
+	ResumeToken() string
+}
+
+// resumeToken records the continuation token from the most recent
+// Next call of a watcher.
+type resumeToken struct {
+	mu    sync.Mutex
+	token string
+}
+
+func (t *resumeToken) set(token string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.token = token
+}
+
+// ResumeToken is part of the ResumableWatcher interface.
+func (t *resumeToken) ResumeToken() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.token
+}
+
 // notifyWatcher will send events when something changes.
 // It does not send content for those changes.
 type notifyWatcher struct {
 	commonWatcher
+	resumeToken
 	caller          base.APICaller
 	notifyWatcherId string
 	out             chan struct{}
@@ -150,35 +179,84 @@ type notifyWatcher struct {
 // If an API call returns a NotifyWatchResult, you can use this to turn it into
 // a local Watcher.
 func NewNotifyWatcher(caller base.APICaller, result params.NotifyWatchResult) watcher.NotifyWatcher {
+	return newNotifyWatcher(caller, result.NotifyWatcherId, "", true)
+}
+
+// ResumeNotifyWatcher resumes the notify watcher that last returned the
+// given resume token, after the connection it was created on closed.
+// The resumed watcher does not send an initial event. Use
+// ResumeOrStartNotifyWatcher to fall back to a fresh watcher when the
+// watcher can not be resumed.
+func ResumeNotifyWatcher(caller base.APICaller, token string) (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	err := caller.APICall("NotifyWatcher", caller.BestFacadeVersion("NotifyWatcher"),
+		token, "Resume", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newNotifyWatcher(caller, result.NotifyWatcherId, token, false), nil
+}
+
+// ResumeOrStartNotifyWatcher resumes the notify watcher that last
+// returned the given resume token, or calls start for a fresh watcher if
+// there is no token or the watcher can not be resumed. Parked watchers
+// are only held by the API server whose connection closed, and only for
+// a short time, so they can not be resumed after reconnecting to another
+// controller, for example after an HA failover. A fresh watcher sends an
+// initial event, which the client must treat as a full resync.
+func ResumeOrStartNotifyWatcher(
+	caller base.APICaller, token string, start func() (watcher.NotifyWatcher, error),
+) (watcher.NotifyWatcher, error) {
+	if token != "" {
+		w, err := ResumeNotifyWatcher(caller, token)
+		if err == nil {
+			return w, nil
+		}
+		logger.Debugf("cannot resume notify watcher, starting a new one: %v", err)
+	}
+	return start()
+}
+
+func newNotifyWatcher(caller base.APICaller, id, token string, sendInitial bool) *notifyWatcher {
 	w := &notifyWatcher{
 		caller:          caller,
-		notifyWatcherId: result.NotifyWatcherId,
+		notifyWatcherId: id,
 		out:             make(chan struct{}),
 	}
-	w.tomb.Go(w.loop)
+	w.set(token)
+	w.tomb.Go(func() error {
+		return w.loop(sendInitial)
+	})
 	return w
 }
 
-func (w *notifyWatcher) loop() error {
-	// No results for this watcher type.
-	w.newResult = func() interface{} { return nil }
+func (w *notifyWatcher) loop(sendInitial bool) error {
+	// There are no changes for this watcher type, only a resume
+	// token from watchers that support resuming.
+	w.newResult = func() interface{} { return new(params.NotifyWatcherNextResult) }
 	w.call = makeWatcherAPICaller(w.caller, "NotifyWatcher", w.notifyWatcherId)
 	w.commonWatcher.init()
 	go w.commonLoop()
 
+	send := sendInitial
 	for {
-		select {
-		// Since for a notifyWatcher there are no changes to send, we
-		// just set the event (initial first, then after each change).
-		case w.out <- struct{}{}:
-		case <-w.tomb.Dying():
-			return nil
+		if send {
+			select {
+			// Since for a notifyWatcher there are no changes to send, we
+			// just set the event (initial first, then after each change).
+			case w.out <- struct{}{}:
+			case <-w.tomb.Dying():
+				return nil
+			}
 		}
-		if _, ok := <-w.in; !ok {
+		send = true
+		data, ok := <-w.in
+		if !ok {
 			// The tomb is already killed with the correct
 			// error at this point, so just return.
 			return nil
 		}
+		w.set(data.(*params.NotifyWatcherNextResult).ResumeToken)
 	}
 }
 
@@ -192,37 +270,84 @@ func (w *notifyWatcher) Changes() watcher.NotifyChannel {
 // The content of the changes is a list of strings.
 type stringsWatcher struct {
 	commonWatcher
+	resumeToken
 	caller           base.APICaller
 	stringsWatcherId string
 	out              chan []string
 }
 
 func NewStringsWatcher(caller base.APICaller, result params.StringsWatchResult) watcher.StringsWatcher {
+	w := newStringsWatcher(caller, result.StringsWatcherId, "")
+	w.tomb.Go(func() error {
+		return w.loop(result.Changes, true)
+	})
+	return w
+}
+
+// ResumeStringsWatcher resumes the strings watcher that last returned
+// the given resume token, after the connection it was created on
+// closed. The resumed watcher does not send an initial event. Use
+// ResumeOrStartStringsWatcher to fall back to a fresh watcher when the
+// watcher can not be resumed.
+func ResumeStringsWatcher(caller base.APICaller, token string) (watcher.StringsWatcher, error) {
+	var result params.StringsWatchResult
+	err := caller.APICall("StringsWatcher", caller.BestFacadeVersion("StringsWatcher"),
+		token, "Resume", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := newStringsWatcher(caller, result.StringsWatcherId, token)
+	w.tomb.Go(func() error {
+		return w.loop(nil, false)
+	})
+	return w, nil
+}
+
+// ResumeOrStartStringsWatcher resumes the strings watcher that last
+// returned the given resume token, or calls start for a fresh watcher if
+// there is no token or the watcher can not be resumed. See
+// ResumeOrStartNotifyWatcher.
+func ResumeOrStartStringsWatcher(
+	caller base.APICaller, token string, start func() (watcher.StringsWatcher, error),
+) (watcher.StringsWatcher, error) {
+	if token != "" {
+		w, err := ResumeStringsWatcher(caller, token)
+		if err == nil {
+			return w, nil
+		}
+		logger.Debugf("cannot resume strings watcher, starting a new one: %v", err)
+	}
+	return start()
+}
+
+func newStringsWatcher(caller base.APICaller, id, token string) *stringsWatcher {
 	w := &stringsWatcher{
 		caller:           caller,
-		stringsWatcherId: result.StringsWatcherId,
+		stringsWatcherId: id,
 		out:              make(chan []string),
 	}
-	w.tomb.Go(func() error {
-		return w.loop(result.Changes)
-	})
+	w.set(token)
 	return w
 }
 
-func (w *stringsWatcher) loop(initialChanges []string) error {
+func (w *stringsWatcher) loop(initialChanges []string, sendInitial bool) error {
 	changes := initialChanges
-	w.newResult = func() interface{} { return new(params.StringsWatchResult) }
+	w.newResult = func() interface{} { return new(params.StringsWatcherNextResult) }
 	w.call = makeWatcherAPICaller(w.caller, "StringsWatcher", w.stringsWatcherId)
 	w.commonWatcher.init()
 	go w.commonLoop()
 
+	send := sendInitial
 	for {
-		select {
-		// Send the initial event or subsequent change.
-		case w.out <- changes:
-		case <-w.tomb.Dying():
-			return nil
+		if send {
+			select {
+			// Send the initial event or subsequent change.
+			case w.out <- changes:
+			case <-w.tomb.Dying():
+				return nil
+			}
 		}
+		send = true
 		// Read the next change.
 		data, ok := <-w.in
 		if !ok {
@@ -230,7 +355,9 @@ func (w *stringsWatcher) loop(initialChanges []string) error {
 			// at this point, so just return.
 			return nil
 		}
-		changes = data.(*params.StringsWatchResult).Changes
+		result := data.(*params.StringsWatcherNextResult)
+		changes = result.Changes
+		w.set(result.ResumeToken)
 	}
 }
 
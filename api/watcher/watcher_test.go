@@ -25,6 +25,7 @@ import (
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/core/status"
 	corewatcher "github.com/juju/juju/core/watcher"
@@ -109,6 +110,67 @@ func (s *watcherSuite) TestNotifyWatcherStopsWithPendingSend(c *gc.C) {
 	wc.AssertStops()
 }
 
+func (s *watcherSuite) TestNotifyWatcherResumesAfterReconnect(c *gc.C) {
+	password, err := utils.RandomPassword()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.rawMachine.SetPassword(password)
+	c.Assert(err, jc.ErrorIsNil)
+	conn := s.OpenAPIAsMachine(c, s.rawMachine.Tag(), password, "fake_nonce")
+
+	var results params.NotifyWatchResults
+	args := params.Entities{Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}}}
+	err = conn.APICall("Machiner", conn.BestFacadeVersion("Machiner"), "", "Watch", args, &results)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	result := results.Results[0]
+	c.Assert(result.Error, gc.IsNil)
+
+	w := watcher.NewNotifyWatcher(conn, result)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	wc.AssertOneChange()
+	c.Assert(w.(watcher.ResumableWatcher).ResumeToken(), gc.Equals, "")
+
+	err = s.rawMachine.SetProviderAddresses(network.NewSpaceAddress("10.0.0.1"))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	token := w.(watcher.ResumableWatcher).ResumeToken()
+	c.Assert(token, gc.Not(gc.Equals), "")
+
+	// Dropping the connection parks the watcher on the server.
+	err = conn.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	workertest.DirtyKill(c, w)
+
+	resumed, err := watcher.ResumeNotifyWatcher(s.stateAPI, token)
+	c.Assert(err, jc.ErrorIsNil)
+	wc = watchertest.NewNotifyWatcherC(c, resumed, s.BackingState.StartSync)
+	defer wc.AssertStops()
+	wc.AssertNoChange()
+
+	err = s.rawMachine.SetProviderAddresses(network.NewSpaceAddress("10.0.0.2"))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	c.Assert(resumed.(watcher.ResumableWatcher).ResumeToken(), gc.Not(gc.Equals), token)
+
+	// A watcher can only be resumed once.
+	_, err = watcher.ResumeNotifyWatcher(s.stateAPI, token)
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+
+	// A client falls back to a fresh watcher when it can't resume.
+	fresh, err := watcher.ResumeOrStartNotifyWatcher(s.stateAPI, token, func() (corewatcher.NotifyWatcher, error) {
+		var results params.NotifyWatchResults
+		err := s.stateAPI.APICall("Machiner", s.stateAPI.BestFacadeVersion("Machiner"), "", "Watch", args, &results)
+		if err != nil {
+			return nil, err
+		}
+		return watcher.NewNotifyWatcher(s.stateAPI, results.Results[0]), nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc = watchertest.NewNotifyWatcherC(c, fresh, s.BackingState.StartSync)
+	defer wc.AssertStops()
+	wc.AssertOneChange()
+}
+
 func (s *watcherSuite) TestWatchUnitsKeepsEvents(c *gc.C) {
 	// Create two applications, relate them, and add one unit to each - a
 	// principal and a subordinate.
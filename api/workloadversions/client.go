@@ -0,0 +1,54 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package workloadversions provides access to the WorkloadVersions
+// facade, which reports the workload versions run by units.
+package workloadversions
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the WorkloadVersions API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the WorkloadVersions API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "WorkloadVersions")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// VersionHistory returns the workload versions reported by each of the
+// given units, most recent first.
+func (c *Client) VersionHistory(units []names.UnitTag) ([]params.WorkloadVersionHistoryResult, error) {
+	args := params.Entities{Entities: make([]params.Entity, len(units))}
+	for i, unit := range units {
+		args.Entities[i].Tag = unit.String()
+	}
+	var results params.WorkloadVersionHistoryResults
+	if err := c.facade.FacadeCall("VersionHistory", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(units) {
+		return nil, errors.Errorf("expected %d results, got %d", len(units), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// Summary returns the workload versions currently reported by the
+// units of each application in the model, and which units are running
+// an outdated version.
+func (c *Client) Summary() ([]params.ApplicationWorkloadVersions, error) {
+	var result params.WorkloadVersionSummary
+	if err := c.facade.FacadeCall("Summary", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Applications, nil
+}
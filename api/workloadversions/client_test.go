@@ -0,0 +1,94 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package workloadversions_test
+
+import (
+	"time"
+
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/workloadversions"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestVersionHistory(c *gc.C) {
+	since := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "WorkloadVersions")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "VersionHistory")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "unit-mysql-0"}},
+			})
+			*(result.(*params.WorkloadVersionHistoryResults)) = params.WorkloadVersionHistoryResults{
+				Results: []params.WorkloadVersionHistoryResult{{
+					History: []params.WorkloadVersion{{Version: "8.0", Since: &since}},
+				}},
+			}
+			return nil
+		})
+	client := workloadversions.NewClient(apiCaller)
+	results, err := client.VersionHistory([]names.UnitTag{names.NewUnitTag("mysql/0")})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(results, jc.DeepEquals, []params.WorkloadVersionHistoryResult{{
+		History: []params.WorkloadVersion{{Version: "8.0", Since: &since}},
+	}})
+}
+
+func (s *clientSuite) TestVersionHistoryResultCountMismatch(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(_ string, _ int, _, _ string, _, _ interface{}) error {
+			return nil
+		})
+	client := workloadversions.NewClient(apiCaller)
+	_, err := client.VersionHistory([]names.UnitTag{names.NewUnitTag("mysql/0")})
+	c.Assert(err, gc.ErrorMatches, "expected 1 results, got 0")
+}
+
+func (s *clientSuite) TestSummary(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "WorkloadVersions")
+			c.Check(request, gc.Equals, "Summary")
+			c.Check(a, gc.IsNil)
+			*(result.(*params.WorkloadVersionSummary)) = params.WorkloadVersionSummary{
+				Applications: []params.ApplicationWorkloadVersions{{
+					ApplicationTag: "application-mysql",
+					LatestVersion:  "8.0",
+					OutdatedUnits:  []string{"unit-mysql-1"},
+				}},
+			}
+			return nil
+		})
+	client := workloadversions.NewClient(apiCaller)
+	summary, err := client.Summary()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(summary, jc.DeepEquals, []params.ApplicationWorkloadVersions{{
+		ApplicationTag: "application-mysql",
+		LatestVersion:  "8.0",
+		OutdatedUnits:  []string{"unit-mysql-1"},
+	}})
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/juju/juju/core/auditlog"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/pubsub/apiserver"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/state"
 	jujuversion "github.com/juju/juju/version"
@@ -102,17 +103,24 @@ func (a *admin) login(ctx context.Context, req params.LoginRequest, loginVersion
 	}
 
 	// Fetch the API server addresses from state.
-	// If the login comes from a client, return all available addresses.
-	// Otherwise return the addresses suitable for agent use.
+	// If the login comes from a client, return all available addresses
+	// allowed by the controller's address advertisement config.
+	// Otherwise return the addresses suitable for agent use, which have
+	// already been filtered by that config.
 	ctrlSt := a.root.shared.statePool.SystemState()
 	getHostPorts := ctrlSt.APIHostPortsForAgents
+	isUser := false
 	if k, _ := names.TagKind(req.AuthTag); k == names.UserTagKind {
 		getHostPorts = ctrlSt.APIHostPortsForClients
+		isUser = true
 	}
 	hostPorts, err := getHostPorts()
 	if err != nil {
 		return fail, errors.Trace(err)
 	}
+	if isUser {
+		hostPorts = a.root.shared.filterAdvertisedHostPorts(hostPorts)
+	}
 	pServers := make([]network.HostPorts, len(hostPorts))
 	for i, hps := range hostPorts {
 		pServers[i] = hps.HostPorts()
@@ -522,7 +530,19 @@ func setupPingTimeoutDisconnect(clock clock.Clock, root *apiHandler, entity stat
 			logger.Errorf("error closing the RPC connection: %v", err)
 		}
 	}
-	pingTimeout := newPingTimeout(action, clock, maxClientPingInterval)
+	// Each ping is published so that the presence of the agent
+	// records when it was last heard from.
+	connection := apiserver.APIConnection{
+		AgentTag:     tag.String(),
+		ModelUUID:    root.model.UUID(),
+		ConnectionID: root.connectionID,
+	}
+	onPing := func() {
+		if _, err := root.shared.centralHub.Publish(apiserver.ActivityTopic, connection); err != nil {
+			logger.Debugf("cannot publish activity of %s: %v", tag, err)
+		}
+	}
+	pingTimeout := newPingTimeout(action, onPing, clock, root.shared.agentPingTimeout())
 	return root.getResources().RegisterNamed("pingTimeout", pingTimeout)
 }
 
@@ -19,9 +19,11 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/credentialvalidator"
 	"github.com/juju/juju/apiserver/facades/agent/deployer"
 	"github.com/juju/juju/apiserver/facades/agent/diskmanager"
+	"github.com/juju/juju/apiserver/facades/agent/enginehealthreporter"
 	"github.com/juju/juju/apiserver/facades/agent/fanconfigurer"
 	"github.com/juju/juju/apiserver/facades/agent/hostkeyreporter"
 	"github.com/juju/juju/apiserver/facades/agent/instancemutater"
+	"github.com/juju/juju/apiserver/facades/agent/introspectionresponder"
 	"github.com/juju/juju/apiserver/facades/agent/keyupdater"
 	"github.com/juju/juju/apiserver/facades/agent/leadership"
 	loggerapi "github.com/juju/juju/apiserver/facades/agent/logger"
@@ -37,6 +39,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/reboot"
 	"github.com/juju/juju/apiserver/facades/agent/resourceshookcontext"
 	"github.com/juju/juju/apiserver/facades/agent/retrystrategy"
+	"github.com/juju/juju/apiserver/facades/agent/sandboxprofiles"
 	"github.com/juju/juju/apiserver/facades/agent/storageprovisioner"
 	"github.com/juju/juju/apiserver/facades/agent/unitassigner"
 	"github.com/juju/juju/apiserver/facades/agent/uniter"
@@ -44,6 +47,8 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/upgradeseries"
 	"github.com/juju/juju/apiserver/facades/agent/upgradesteps"
 	"github.com/juju/juju/apiserver/facades/client/action"
+	"github.com/juju/juju/apiserver/facades/client/agentintrospection"
+	"github.com/juju/juju/apiserver/facades/client/agentpresence"
 	"github.com/juju/juju/apiserver/facades/client/annotations" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/application" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/applicationoffers"
@@ -56,23 +61,33 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/cloud"      // ModelUser Read
 	"github.com/juju/juju/apiserver/facades/client/controller" // ModelUser Admin (although some methods check for read only)
 	"github.com/juju/juju/apiserver/facades/client/credentialmanager"
+	"github.com/juju/juju/apiserver/facades/client/facadeusage"
 	"github.com/juju/juju/apiserver/facades/client/firewallrules"
 	"github.com/juju/juju/apiserver/facades/client/highavailability" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/imagemanager"     // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/imagemetadatamanager"
+	"github.com/juju/juju/apiserver/facades/client/inventory"
 	"github.com/juju/juju/apiserver/facades/client/keymanager"     // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/machinemanager" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/metricsdebug"   // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/modelcloner"    // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/modelconfig"    // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/modeldestruction"
+	"github.com/juju/juju/apiserver/facades/client/modeldiff"
 	"github.com/juju/juju/apiserver/facades/client/modelgeneration"
+	"github.com/juju/juju/apiserver/facades/client/modelhealth"
 	"github.com/juju/juju/apiserver/facades/client/modelmanager" // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/modelspec"    // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/payloads"
+	"github.com/juju/juju/apiserver/facades/client/relationsettings"
 	"github.com/juju/juju/apiserver/facades/client/resources"
+	"github.com/juju/juju/apiserver/facades/client/schema"
 	"github.com/juju/juju/apiserver/facades/client/spaces"    // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/sshclient" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/storage"
 	"github.com/juju/juju/apiserver/facades/client/subnets"
 	"github.com/juju/juju/apiserver/facades/client/usermanager"
+	"github.com/juju/juju/apiserver/facades/client/workloadversions"
 	"github.com/juju/juju/apiserver/facades/controller/actionpruner"
 	"github.com/juju/juju/apiserver/facades/controller/agenttools"
 	"github.com/juju/juju/apiserver/facades/controller/applicationscaler"
@@ -96,9 +111,11 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/metricsmanager"
 	"github.com/juju/juju/apiserver/facades/controller/migrationmaster"
 	"github.com/juju/juju/apiserver/facades/controller/migrationtarget"
+	"github.com/juju/juju/apiserver/facades/controller/modelreconciler"
 	"github.com/juju/juju/apiserver/facades/controller/modelupgrader"
 	"github.com/juju/juju/apiserver/facades/controller/remoterelations"
 	"github.com/juju/juju/apiserver/facades/controller/resumer"
+	"github.com/juju/juju/apiserver/facades/controller/scopedcredentials"
 	"github.com/juju/juju/apiserver/facades/controller/singular"
 	"github.com/juju/juju/apiserver/facades/controller/statushistory"
 	"github.com/juju/juju/apiserver/facades/controller/undertaker"
@@ -136,8 +153,12 @@ func AllFacades() *facade.Registry {
 	reg("Action", 7, action.NewActionAPIV7)
 	reg("ActionPruner", 1, actionpruner.NewAPI)
 	reg("Agent", 2, agent.NewAgentAPIV2)
+	reg("AgentIntrospection", 1, agentintrospection.NewFacade)
+	reg("AgentPresence", 1, agentpresence.NewFacade)
 	reg("AgentTools", 1, agenttools.NewFacade)
-	reg("Annotations", 2, annotations.NewAPI)
+	reg("Annotations", 2, annotations.NewAPIV2)
+	reg("Annotations", 3, annotations.NewAPIV3)
+	reg("Annotations", 4, annotations.NewAPI)
 
 	// Application facade versions 1-4 share NewFacadeV4 as
 	// the newer methodology for versioning wasn't started with
@@ -155,12 +176,16 @@ func AllFacades() *facade.Registry {
 	reg("Application", 11, application.NewFacadeV11) // Get call returns the endpoint bindings
 	reg("Application", 12, application.NewFacadeV12) // Adds UnitsInfo()
 	reg("Application", 13, application.NewFacadeV13) // Adds CharmOrigin to Deploy
+	reg("Application", 14, application.NewFacadeV14) // Adds DrainUnits and UnitsDrained
+	reg("Application", 15, application.NewFacadeV15) // Adds UpdateApplicationStorage
+	reg("Application", 16, application.NewFacadeV16) // Adds EncryptRelationData to Consume
 
 	reg("ApplicationOffers", 1, applicationoffers.NewOffersAPI)
 	reg("ApplicationOffers", 2, applicationoffers.NewOffersAPIV2)
 	reg("ApplicationOffers", 3, applicationoffers.NewOffersAPIV3) // Add user to consume offers details  args.
 	reg("ApplicationScaler", 1, applicationscaler.NewAPI)
 	reg("Backups", 3, backups.NewFacadeV3)
+	reg("Backups", 4, backups.NewFacadeV4) // adds Verify
 	reg("Block", 2, block.NewAPI)
 	reg("Bundle", 1, bundle.NewFacadeV1)
 	reg("Bundle", 2, bundle.NewFacadeV2)
@@ -171,9 +196,12 @@ func AllFacades() *facade.Registry {
 	reg("Charms", 2, charms.NewFacadeV2)
 	reg("Charms", 3, charms.NewFacadeV3)
 	reg("Charms", 4, charms.NewFacadeV4)
+	reg("Charms", 5, charms.NewFacadeV5) // Adds DownloadBundles.
 	reg("Cleaner", 2, cleaner.NewCleanerAPI)
 	reg("Client", 1, client.NewFacadeV1)
-	reg("Client", 2, client.NewFacade)
+	reg("Client", 2, client.NewFacadeV2)
+	reg("Client", 3, client.NewFacadeV3)
+	reg("Client", 4, client.NewFacade) // Adds WatchEntities.
 	reg("Cloud", 1, cloud.NewFacadeV1)
 	reg("Cloud", 2, cloud.NewFacadeV2) // adds AddCloud, AddCredentials, CredentialContents, RemoveClouds
 	reg("Cloud", 3, cloud.NewFacadeV3) // changes signature of UpdateCredentials, adds ModifyCloudAccess
@@ -203,22 +231,32 @@ func AllFacades() *facade.Registry {
 	reg("Controller", 7, controller.NewControllerAPIv7)
 	reg("Controller", 8, controller.NewControllerAPIv8)
 	reg("Controller", 9, controller.NewControllerAPIv9)
+	reg("Controller", 10, controller.NewControllerAPIv10) // Adds FindOrphanedDocuments.
+	reg("Controller", 11, controller.NewControllerAPIv11) // Adds VerifyIntegrity.
+	reg("Controller", 12, controller.NewControllerAPIv12) // Adds UpgradePlan.
+	reg("Controller", 13, controller.NewControllerAPIv13) // Adds DrainControllers.
 	reg("CrossModelRelations", 1, crossmodelrelations.NewStateCrossModelRelationsAPIV1)
 	reg("CrossModelRelations", 2, crossmodelrelations.NewStateCrossModelRelationsAPI) // Adds WatchRelationChanges, removes WatchRelationUnits
+	reg("CrossModelRelations", 3, crossmodelrelations.NewStateCrossModelRelationsAPI) // Adds KeyGeneration to RegisterRemoteRelations
 	reg("CrossController", 1, crosscontroller.NewStateCrossControllerAPI)
 	reg("CredentialManager", 1, credentialmanager.NewCredentialManagerAPI)
 	reg("CredentialValidator", 1, credentialvalidator.NewCredentialValidatorAPIv1)
-	reg("CredentialValidator", 2, credentialvalidator.NewCredentialValidatorAPI) // adds WatchModelCredential
+	reg("CredentialValidator", 2, credentialvalidator.NewCredentialValidatorAPIv2) // adds WatchModelCredential
+	reg("CredentialValidator", 3, credentialvalidator.NewCredentialValidatorAPI)   // adds CheckModelCredentialExpiry
 	reg("ExternalControllerUpdater", 1, externalcontrollerupdater.NewStateAPI)
 
 	reg("Deployer", 1, deployer.NewDeployerAPI)
 	reg("DiskManager", 2, diskmanager.NewDiskManagerAPI)
+	reg("EngineHealthReporter", 1, enginehealthreporter.NewFacade)
+	reg("FacadeUsage", 1, newFacadeUsageFacade)
 	reg("FanConfigurer", 1, fanconfigurer.NewFanConfigurerAPI)
 	reg("Firewaller", 3, firewaller.NewStateFirewallerAPIV3)
 	reg("Firewaller", 4, firewaller.NewStateFirewallerAPIV4)
 	reg("Firewaller", 5, firewaller.NewStateFirewallerAPIV5)
 	reg("Firewaller", 6, firewaller.NewStateFirewallerAPIV6)
-	reg("FirewallRules", 1, firewallrules.NewFacade)
+	reg("Firewaller", 7, firewaller.NewStateFirewallerAPIV7)
+	reg("FirewallRules", 1, firewallrules.NewFacadeV1)
+	reg("FirewallRules", 2, firewallrules.NewFacade)
 	reg("HighAvailability", 2, highavailability.NewHighAvailabilityAPI)
 	reg("HostKeyReporter", 1, hostkeyreporter.NewFacade)
 	reg("ImageManager", 2, imagemanager.NewImageManagerAPI)
@@ -231,6 +269,8 @@ func AllFacades() *facade.Registry {
 
 	reg("InstancePoller", 3, instancepoller.NewFacadeV3)
 	reg("InstancePoller", 4, instancepoller.NewFacade)
+	reg("IntrospectionResponder", 1, introspectionresponder.NewFacade)
+	reg("Inventory", 1, inventory.NewFacade)
 	reg("KeyManager", 1, keymanager.NewKeyManagerAPI)
 	reg("KeyUpdater", 1, keyupdater.NewKeyUpdaterAPI)
 
@@ -242,10 +282,14 @@ func AllFacades() *facade.Registry {
 	reg("MachineActions", 1, machineactions.NewExternalFacade)
 
 	reg("MachineManager", 2, machinemanager.NewFacade)
-	reg("MachineManager", 3, machinemanager.NewFacade)   // Adds DestroyMachine and ForceDestroyMachine.
-	reg("MachineManager", 4, machinemanager.NewFacadeV4) // Adds DestroyMachineWithParams.
-	reg("MachineManager", 5, machinemanager.NewFacadeV5) // Adds UpgradeSeriesPrepare, removes UpdateMachineSeries.
-	reg("MachineManager", 6, machinemanager.NewFacadeV6) // DestroyMachinesWithParams gains maxWait.
+	reg("MachineManager", 3, machinemanager.NewFacade)     // Adds DestroyMachine and ForceDestroyMachine.
+	reg("MachineManager", 4, machinemanager.NewFacadeV4)   // Adds DestroyMachineWithParams.
+	reg("MachineManager", 5, machinemanager.NewFacadeV5)   // Adds UpgradeSeriesPrepare, removes UpdateMachineSeries.
+	reg("MachineManager", 6, machinemanager.NewFacadeV6)   // DestroyMachinesWithParams gains maxWait.
+	reg("MachineManager", 7, machinemanager.NewFacadeV7)   // Adds SetEgressNATAddresses.
+	reg("MachineManager", 8, machinemanager.NewFacadeV8)   // Adds RefreshMachineHardware.
+	reg("MachineManager", 9, machinemanager.NewFacadeV9)   // Adds PrepareResizeMachines and ResizeMachines.
+	reg("MachineManager", 10, machinemanager.NewFacadeV10) // Adds ReplaceMachines.
 
 	reg("MachineUndertaker", 1, machineundertaker.NewFacade)
 	reg("Machiner", 1, machine.NewMachinerAPIV1)
@@ -265,20 +309,29 @@ func AllFacades() *facade.Registry {
 	reg("MigrationMinion", 1, migrationminion.NewFacade)
 	reg("MigrationTarget", 1, migrationtarget.NewFacade)
 
+	reg("ModelCloner", 1, modelcloner.NewFacade)
 	reg("ModelConfig", 1, modelconfig.NewFacadeV1)
 	reg("ModelConfig", 2, modelconfig.NewFacadeV2)
+	reg("ModelConfig", 3, modelconfig.NewFacadeV3)
+	reg("ModelConfig", 4, modelconfig.NewFacadeV4) // Adds CloudInitCustomizations
+	reg("ModelDestruction", 1, modeldestruction.NewFacade)
+	reg("ModelDiff", 1, modeldiff.NewFacade)
 	reg("ModelGeneration", 1, modelgeneration.NewModelGenerationFacade)
 	reg("ModelGeneration", 2, modelgeneration.NewModelGenerationFacadeV2)
 	reg("ModelGeneration", 3, modelgeneration.NewModelGenerationFacadeV3)
 	reg("ModelGeneration", 4, modelgeneration.NewModelGenerationFacadeV4)
+	reg("ModelHealth", 1, modelhealth.NewFacade)
 	reg("ModelManager", 2, modelmanager.NewFacadeV2)
 	reg("ModelManager", 3, modelmanager.NewFacadeV3)
 	reg("ModelManager", 4, modelmanager.NewFacadeV4)
-	reg("ModelManager", 5, modelmanager.NewFacadeV5) // Adds ChangeModelCredential
-	reg("ModelManager", 6, modelmanager.NewFacadeV6) // Adds cloud specific default config
-	reg("ModelManager", 7, modelmanager.NewFacadeV7) // DestroyModels gains 'force' and max-wait' parameters.
-	reg("ModelManager", 8, modelmanager.NewFacadeV8) // ModelInfo gains credential validity in return.
-	reg("ModelManager", 9, modelmanager.NewFacadeV9) // Adds ValidateModelUpgrade
+	reg("ModelManager", 5, modelmanager.NewFacadeV5)   // Adds ChangeModelCredential
+	reg("ModelManager", 6, modelmanager.NewFacadeV6)   // Adds cloud specific default config
+	reg("ModelManager", 7, modelmanager.NewFacadeV7)   // DestroyModels gains 'force' and max-wait' parameters.
+	reg("ModelManager", 8, modelmanager.NewFacadeV8)   // ModelInfo gains credential validity in return.
+	reg("ModelManager", 9, modelmanager.NewFacadeV9)   // Adds ValidateModelUpgrade
+	reg("ModelManager", 10, modelmanager.NewFacadeV10) // Adds TransferModelOwnership
+	reg("ModelReconciler", 1, modelreconciler.NewModelReconcilerAPI)
+	reg("ModelSpec", 1, modelspec.NewFacade)
 	reg("ModelUpgrader", 1, modelupgrader.NewStateFacade)
 
 	reg("Payloads", 1, payloads.NewFacade)
@@ -302,15 +355,22 @@ func AllFacades() *facade.Registry {
 	reg("ProxyUpdater", 1, proxyupdater.NewFacadeV1)
 	reg("ProxyUpdater", 2, proxyupdater.NewFacadeV2)
 	reg("Reboot", 2, reboot.NewRebootAPI)
+	reg("RelationSettings", 1, relationsettings.NewFacadeV1)
+	reg("RelationSettings", 2, relationsettings.NewFacadeV2) // adds RelationMatrix
 	reg("RemoteRelations", 1, remoterelations.NewAPIv1)
-	reg("RemoteRelations", 2, remoterelations.NewAPI) // Adds UpdateControllersForModels and WatchLocalRelationChanges.
+	reg("RemoteRelations", 2, remoterelations.NewAPIv2) // Adds UpdateControllersForModels and WatchLocalRelationChanges.
+	reg("RemoteRelations", 3, remoterelations.NewAPI)   // Adds RotateRelationKeys.
 
 	reg("Resources", 1, resources.NewFacadeV1)
 	reg("Resources", 2, resources.NewFacadeV2)
-	reg("ResourcesHookContext", 1, resourceshookcontext.NewStateFacade)
+	reg("ResourcesHookContext", 1, resourceshookcontext.NewStateFacadeV1)
+	reg("ResourcesHookContext", 2, resourceshookcontext.NewStateFacade)
 
 	reg("Resumer", 2, resumer.NewResumerAPI)
 	reg("RetryStrategy", 1, retrystrategy.NewRetryStrategyAPI)
+	reg("SandboxProfiles", 1, sandboxprofiles.NewFacade)
+	reg("Schema", 1, newSchemaFacade)
+	reg("ScopedCredentials", 1, scopedcredentials.NewScopedCredentialsAPI)
 	reg("Singular", 2, singular.NewExternalFacade)
 
 	reg("SSHClient", 1, sshclient.NewFacade)
@@ -320,20 +380,24 @@ func AllFacades() *facade.Registry {
 	reg("Spaces", 3, spaces.NewAPIv3)
 	reg("Spaces", 4, spaces.NewAPIv4)
 	reg("Spaces", 5, spaces.NewAPIv5)
-	reg("Spaces", 6, spaces.NewAPI)
+	reg("Spaces", 6, spaces.NewAPIv6)
+	reg("Spaces", 7, spaces.NewAPIv7)
+	reg("Spaces", 8, spaces.NewAPI) // Adds CheckSpaces.
 
 	reg("StatusHistory", 2, statushistory.NewAPI)
 
 	reg("Storage", 3, storage.NewStorageAPIV3)
 	reg("Storage", 4, storage.NewStorageAPIV4) // changes Destroy() method signature.
 	reg("Storage", 5, storage.NewStorageAPIV5) // Update and Delete storage pools and CreatePool bulk calls.
-	reg("Storage", 6, storage.NewStorageAPI)   // modify Remove to support force and maxWait; add DetachStorage to support force and maxWait.
+	reg("Storage", 6, storage.NewStorageAPIV6) // modify Remove to support force and maxWait; add DetachStorage to support force and maxWait.
+	reg("Storage", 7, storage.NewStorageAPI)   // Adds CreateSnapshots, ListSnapshots and RestoreSnapshots.
 
 	reg("StorageProvisioner", 3, storageprovisioner.NewFacadeV3)
 	reg("StorageProvisioner", 4, storageprovisioner.NewFacadeV4)
 	reg("Subnets", 2, subnets.NewAPIv2)
 	reg("Subnets", 3, subnets.NewAPIv3)
-	reg("Subnets", 4, subnets.NewAPI) // Adds SubnetsByCIDR; removes AllSpaces.
+	reg("Subnets", 4, subnets.NewAPIv4) // Adds SubnetsByCIDR; removes AllSpaces.
+	reg("Subnets", 5, subnets.NewAPI)   // Adds ReloadSubnets.
 	reg("Undertaker", 1, undertaker.NewUndertakerAPI)
 	reg("UnitAssigner", 1, unitassigner.New)
 
@@ -350,7 +414,13 @@ func AllFacades() *facade.Registry {
 	reg("Uniter", 14, uniter.NewUniterAPIV14)
 	reg("Uniter", 15, uniter.NewUniterAPIV15)
 	reg("Uniter", 16, uniter.NewUniterAPIV16)
-	reg("Uniter", 17, uniter.NewUniterAPI)
+	reg("Uniter", 17, uniter.NewUniterAPIV17)
+	reg("Uniter", 18, uniter.NewUniterAPIV18)
+	reg("Uniter", 19, uniter.NewUniterAPIV19)
+	reg("Uniter", 20, uniter.NewUniterAPIV20)
+	reg("Uniter", 21, uniter.NewUniterAPIV21)
+	reg("Uniter", 22, uniter.NewUniterAPIV22) // Adds CreateStorageSnapshots.
+	reg("Uniter", 23, uniter.NewUniterAPI)    // Adds SecretConfigValues.
 
 	reg("Upgrader", 1, upgrader.NewUpgraderFacade)
 
@@ -362,6 +432,7 @@ func AllFacades() *facade.Registry {
 	reg("UpgradeSteps", 2, upgradesteps.NewFacadeV2)
 	reg("UserManager", 1, usermanager.NewUserManagerAPI)
 	reg("UserManager", 2, usermanager.NewUserManagerAPI) // Adds ResetPassword
+	reg("WorkloadVersions", 1, workloadversions.NewFacade)
 
 	regRaw("AllWatcher", 1, NewAllWatcher, reflect.TypeOf((*SrvAllWatcher)(nil)))
 	// Note: AllModelWatcher uses the same infrastructure as AllWatcher
@@ -370,7 +441,9 @@ func AllFacades() *facade.Registry {
 	// checks).
 	regRaw("AllModelWatcher", 2, NewAllWatcher, reflect.TypeOf((*SrvAllWatcher)(nil)))
 	regRaw("NotifyWatcher", 1, newNotifyWatcher, reflect.TypeOf((*srvNotifyWatcher)(nil)))
+	regRaw("NotifyWatcher", 2, newNotifyWatcherV2, reflect.TypeOf((*srvNotifyWatcherV2)(nil)))
 	regRaw("StringsWatcher", 1, newStringsWatcher, reflect.TypeOf((*srvStringsWatcher)(nil)))
+	regRaw("StringsWatcher", 2, newStringsWatcherV2, reflect.TypeOf((*srvStringsWatcherV2)(nil)))
 	regRaw("OfferStatusWatcher", 1, newOfferStatusWatcher, reflect.TypeOf((*srvOfferStatusWatcher)(nil)))
 	regRaw("RelationStatusWatcher", 1, newRelationStatusWatcher, reflect.TypeOf((*srvRelationStatusWatcher)(nil)))
 	regRaw("RelationUnitsWatcher", 1, newRelationUnitsWatcher, reflect.TypeOf((*srvRelationUnitsWatcher)(nil)))
@@ -385,6 +458,19 @@ func AllFacades() *facade.Registry {
 	return registry
 }
 
+// newSchemaFacade returns a Schema facade which describes the facades
+// registered by AllFacades.
+func newSchemaFacade(ctx facade.Context) (*schema.API, error) {
+	return schema.NewAPI(AllFacades(), ctx.Auth())
+}
+
+// newFacadeUsageFacade returns a FacadeUsage facade which reports the
+// usage recorded by this API server against the facades registered by
+// AllFacades.
+func newFacadeUsageFacade(ctx facade.Context) (*facadeusage.API, error) {
+	return facadeusage.NewAPI(ctx.FacadeUsage(), AllFacades(), ctx.Auth(), ctx.State().ControllerTag())
+}
+
 // adminAPIFactories holds methods used to create
 // admin APIs with specific versions.
 var adminAPIFactories = map[int]adminAPIFactory{
@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"os/signal"
 	"path"
 	"path/filepath"
 	"strings"
@@ -41,9 +42,11 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/auditlog"
 	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/facadeusage"
 	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/multiwatcher"
 	"github.com/juju/juju/core/presence"
+	envtools "github.com/juju/juju/environs/tools"
 	"github.com/juju/juju/pubsub/apiserver"
 	controllermsg "github.com/juju/juju/pubsub/controller"
 	"github.com/juju/juju/resource"
@@ -51,6 +54,7 @@ import (
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/jsoncodec"
 	"github.com/juju/juju/state"
+	jworker "github.com/juju/juju/worker"
 )
 
 var logger = loggo.GetLogger("juju.apiserver")
@@ -86,6 +90,10 @@ type Server struct {
 	metricsCollector       *Collector
 	execEmbeddedCommand    ExecEmbeddedCommandFunc
 
+	// draining is closed when the server starts draining connections.
+	draining  chan struct{}
+	drainOnce sync.Once
+
 	// mu guards the fields below it.
 	mu sync.Mutex
 
@@ -261,18 +269,29 @@ func NewServer(cfg ServerConfig) (*Server, error) {
 
 const readyTimeout = time.Second * 30
 
+// drainTimeout is how long a draining server waits for outstanding
+// requests to complete before restarting the agent regardless.
+const drainTimeout = time.Minute
+
 func newServer(cfg ServerConfig) (_ *Server, err error) {
 	controllerConfig, err := cfg.StatePool.SystemState().ControllerConfig()
 	if err != nil {
 		return nil, errors.Annotate(err, "unable to get controller config")
 	}
 
+	// Facade usage is recorded for every API connection, so that
+	// operators can find out which clients are still calling old
+	// facade versions.
+	facadeUsage := facadeusage.NewTracker(cfg.Clock)
 	shared, err := newSharedServerContext(sharedServerConfig{
 		statePool:           cfg.StatePool,
 		controller:          cfg.Controller,
 		multiwatcherFactory: cfg.MultiwatcherFactory,
 		centralHub:          cfg.Hub,
 		presence:            cfg.Presence,
+		facadeUsage:         facadeUsage,
+		crashLoops:          crashLoopCounter{collector: cfg.MetricsCollector},
+		resumableWatchers:   newResumableWatchers(cfg.Clock),
 		leaseManager:        cfg.LeaseManager,
 		controllerConfig:    controllerConfig,
 		logger:              loggo.GetLogger("juju.apiserver"),
@@ -280,10 +299,14 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	newObserver := observer.ObserverFactoryMultiplexer(
+		cfg.NewObserver,
+		observer.NewFacadeUsageObserverFactory(facadeUsage),
+	)
 	srv := &Server{
 		clock:                         cfg.Clock,
 		pingClock:                     cfg.pingClock(),
-		newObserver:                   cfg.NewObserver,
+		newObserver:                   newObserver,
 		shared:                        shared,
 		tag:                           cfg.Tag,
 		dataDir:                       cfg.DataDir,
@@ -308,10 +331,12 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 		},
 		metricsCollector:    cfg.MetricsCollector,
 		execEmbeddedCommand: cfg.ExecEmbeddedCommand,
+		draining:            make(chan struct{}),
 
 		healthStatus: "starting",
 	}
 	srv.updateAgentRateLimiter(controllerConfig)
+	envtools.SetAgentMetadataPublicKey(controllerConfig.AgentMetadataPublicKey())
 
 	// We are able to get the current controller config before subscribing to changes
 	// because the changes are only ever published in response to an API call,
@@ -324,6 +349,7 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 				return
 			}
 			srv.updateAgentRateLimiter(data.Config)
+			envtools.SetAgentMetadataPublicKey(data.Config.AgentMetadataPublicKey())
 		})
 	if err != nil {
 		logger.Criticalf("programming error in subscribe function: %v", err)
@@ -359,12 +385,28 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 		return nil, errors.Annotate(err, "unable to subscribe to restart message")
 	}
 
+	unsubscribeDrain, err := cfg.Hub.Subscribe(apiserver.DrainTopic, func(topic string, data apiserver.Drain, err error) {
+		if err != nil {
+			logger.Criticalf("programming error in %s message data: %v", topic, err)
+			return
+		}
+		if !srv.isDrainTarget(data) {
+			return
+		}
+		srv.Drain()
+	})
+	if err != nil {
+		unsubscribe()
+		return nil, errors.Annotate(err, "unable to subscribe to drain message")
+	}
+
 	ready := make(chan struct{})
 	srv.tomb.Go(func() error {
 		defer srv.dbloggers.dispose()
 		defer srv.logSinkWriter.Close()
 		defer srv.shared.Close()
 		defer unsubscribe()
+		defer unsubscribeDrain()
 		defer unsubscribeControllerConfig()
 		return srv.loop(ready)
 	})
@@ -386,6 +428,7 @@ func (srv *Server) Report() map[string]interface{} {
 	result := map[string]interface{}{
 		"agent-ratelimit-max":  srv.agentRateLimitMax,
 		"agent-ratelimit-rate": srv.agentRateLimitRate,
+		"health":               srv.healthStatus,
 	}
 
 	if srv.publicDNSName_ != "" {
@@ -406,6 +449,27 @@ func (srv *Server) Stop() error {
 	return srv.tomb.Wait()
 }
 
+// Drain stops the server accepting new connections and requests, and
+// closes existing connections once their outstanding requests have
+// completed. Agents will then reconnect to another controller. Once
+// drained, or after drainTimeout, the server exits with
+// ErrRestartAgent so that the controller agent restarts.
+func (srv *Server) Drain() {
+	srv.drainOnce.Do(func() {
+		close(srv.draining)
+	})
+}
+
+// isDrainTarget reports whether the drain message is for this server.
+// Drain messages naming a machine are forwarded to every controller,
+// so only the server running on that machine acts on them.
+func (srv *Server) isDrainTarget(msg apiserver.Drain) bool {
+	if msg.MachineID == "" {
+		return msg.LocalOnly
+	}
+	return srv.tag != nil && srv.tag.Id() == msg.MachineID
+}
+
 // Kill implements worker.Worker.Kill.
 func (srv *Server) Kill() {
 	srv.tomb.Kill(nil)
@@ -508,18 +572,56 @@ func (srv *Server) loop(ready chan struct{}) error {
 	}
 
 	close(ready)
-	srv.mu.Lock()
-	srv.healthStatus = "running"
-	srv.mu.Unlock()
+	srv.setHealthStatus("running")
+
+	drainSignal := make(chan os.Signal, 1)
+	notifyDrainSignal(drainSignal)
+	defer signal.Stop(drainSignal)
 
-	<-srv.tomb.Dying()
+	draining := srv.draining
+	for draining != nil {
+		select {
+		case <-srv.tomb.Dying():
+			srv.setHealthStatus("stopping")
+			srv.wg.Wait() // wait for any outstanding requests to complete.
+			return tomb.ErrDying
+		case <-drainSignal:
+			logger.Infof("received drain signal")
+			srv.Drain()
+		case <-draining:
+			draining = nil
+		}
+	}
 
+	logger.Infof("draining API connections")
+	srv.setHealthStatus("draining")
+	// Existing connections are closed by serveConn, and
+	// new requests are refused by trackRequests.
+	drained := make(chan struct{})
+	go func() {
+		srv.wg.Wait()
+		close(drained)
+	}()
+	select {
+	case <-srv.tomb.Dying():
+		srv.setHealthStatus("stopping")
+		<-drained
+		return tomb.ErrDying
+	case <-drained:
+		logger.Infof("API connections drained")
+	case <-srv.clock.After(drainTimeout):
+		logger.Warningf("API connections not drained after %v, restarting anyway", drainTimeout)
+	}
+	srv.setHealthStatus("drained")
+	// The drain was requested ahead of a restart, so restart
+	// the agent now that the connections have gone.
+	return jworker.ErrRestartAgent
+}
+
+func (srv *Server) setHealthStatus(status string) {
 	srv.mu.Lock()
-	srv.healthStatus = "stopping"
+	srv.healthStatus = status
 	srv.mu.Unlock()
-
-	srv.wg.Wait() // wait for any outstanding requests to complete.
-	return tomb.ErrDying
 }
 
 func (srv *Server) endpoints() []apihttp.Endpoint {
@@ -582,6 +684,8 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	debugLogHandler := newDebugLogDBHandler(
 		httpCtxt, srv.authenticator,
 		tagKindAuthorizer{names.MachineTagKind, names.ControllerAgentTagKind, names.UserTagKind, names.ApplicationTagKind})
+	statusHistoryHandler := newStatusHistoryStreamHandler(
+		httpCtxt, srv.authenticator, tagKindAuthorizer{names.UserTagKind})
 	pubsubHandler := newPubSubHandler(httpCtxt, srv.shared.centralHub)
 	logSinkHandler := logsink.NewHTTPHandler(
 		newAgentLogWriteCloserFunc(httpCtxt, srv.logSinkWriter, &srv.dbloggers),
@@ -673,6 +777,7 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		ctxt:          httpCtxt,
 		dataDir:       srv.dataDir,
 		stateAuthFunc: httpCtxt.stateForMigrationImporting,
+		migrating:     true,
 	}
 	migrateCharmsHTTPHandler := &CharmsHTTPHandler{
 		PostHandler: migrateCharmsHandler.ServePost,
@@ -690,6 +795,11 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	registerHandler := &registerUserHandler{ctxt: httpCtxt}
 	dashboardArchiveHandler := &dashboardArchiveHandler{ctxt: httpCtxt}
 	dashboardVersionHandler := &dashboardVersionHandler{ctxt: httpCtxt}
+	schemaHandler := &schemaHandler{}
+	gatewayModelsHandler := &gatewayHandler{ctxt: httpCtxt, query: gatewayModels}
+	gatewayStatusHandler := &gatewayHandler{ctxt: httpCtxt, query: gatewayModelStatus}
+	gatewayOffersHandler := &gatewayHandler{ctxt: httpCtxt, query: gatewayOffers}
+	gatewayGRPC := newGatewayGRPCServer(httpCtxt)
 
 	// HTTP handler for application offer macaroon authentication.
 	addOfferAuthHandlers(srv.offerAuthCtxt, srv.mux)
@@ -715,6 +825,13 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		// The authentication is handled within the debugLogHandler in order
 		// for discharge required errors to be handled correctly.
 		unauthenticated: true,
+	}, {
+		pattern: modelRoutePrefix + "/statushistory",
+		handler: statusHistoryHandler,
+		tracked: true,
+		// As for debug-log, the authentication is handled within the
+		// handler.
+		unauthenticated: true,
 	}, {
 		pattern:    modelRoutePrefix + "/logsink",
 		handler:    logSinkHandler,
@@ -803,6 +920,38 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		handler:         healthHandler,
 		unauthenticated: true,
 		noModelUUID:     true,
+	}, {
+		pattern:    "/schema",
+		handler:    schemaHandler,
+		authorizer: tagKindAuthorizer{names.UserTagKind},
+	}, {
+		// The API gateway authenticates requests with its own
+		// bearer token rather than with entity credentials.
+		pattern:         gatewayRoutePrefix + "/models",
+		methods:         []string{"GET"},
+		handler:         gatewayModelsHandler,
+		unauthenticated: true,
+		noModelUUID:     true,
+	}, {
+		pattern:         gatewayRoutePrefix + "/models/:modeluuid/status",
+		methods:         []string{"GET"},
+		handler:         gatewayStatusHandler,
+		unauthenticated: true,
+		noModelUUID:     true,
+	}, {
+		pattern:         gatewayRoutePrefix + "/models/:modeluuid/offers",
+		methods:         []string{"GET"},
+		handler:         gatewayOffersHandler,
+		unauthenticated: true,
+		noModelUUID:     true,
+	}, {
+		// gRPC requests are made over HTTP/2, to a path naming the
+		// service and method.
+		pattern:         "/" + gatewayGRPCService + "/:method",
+		methods:         []string{"POST"},
+		handler:         gatewayGRPC,
+		unauthenticated: true,
+		noModelUUID:     true,
 	}, {
 		pattern:         "/register",
 		handler:         registerHandler,
@@ -891,6 +1040,10 @@ func (srv *Server) trackRequests(handler http.Handler) http.Handler {
 			// shutting down, do not consider this request as in progress,
 			// just send a 503 and return.
 			http.Error(w, "apiserver shutdown in progress", 503)
+		case <-srv.draining:
+			// The server is draining ahead of a restart, so the
+			// client should connect to another controller.
+			http.Error(w, "apiserver draining", 503)
 		default:
 			// If we get here then the tomb was not killed therefore the
 			// listener is still open. It is safe to increment the
@@ -950,6 +1103,12 @@ func (srv *Server) serveConn(
 	apiObserver observer.Observer,
 	host string,
 ) error {
+	// Ping the client so that a half-open connection is noticed and the
+	// connection closed, releasing any watchers waiting in Next.
+	keepAliveDone := make(chan struct{})
+	defer close(keepAliveDone)
+	wsConn.KeepAlive(websocket.APIPingPeriod, websocket.APIPongDelay, keepAliveDone)
+
 	codec := jsoncodec.NewWebsocket(wsConn.Conn)
 	recorderFactory := observer.NewRecorderFactory(
 		apiObserver, nil, observer.NoCaptureArgs)
@@ -994,6 +1153,11 @@ func (srv *Server) serveConn(
 	select {
 	case <-conn.Dead():
 	case <-srv.tomb.Dying():
+	case <-srv.draining:
+		// Close waits for outstanding requests to complete before
+		// closing the connection, prompting the client to reconnect
+		// to another controller.
+		logger.Debugf("closing connection %d while draining", connectionID)
 	}
 	return conn.Close()
 }
@@ -47,6 +47,7 @@ import (
 	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/testing"
 	coretesting "github.com/juju/juju/testing"
+	jworker "github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/modelcache"
 	"github.com/juju/juju/worker/multiwatcher"
@@ -348,6 +349,78 @@ func (s *apiserverSuite) TestRestartMessage(c *gc.C) {
 	c.Assert(err, gc.Equals, dependency.ErrBounce)
 }
 
+func (s *apiserverSuite) TestDrainMessage(c *gc.C) {
+	_, err := s.config.Hub.Publish(psapiserver.DrainTopic, psapiserver.Drain{
+		LocalOnly: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Once drained, the server exits so the agent restarts.
+	err = workertest.CheckKilled(c, s.apiServer)
+	c.Assert(err, gc.Equals, jworker.ErrRestartAgent)
+}
+
+func (s *apiserverSuite) TestDrainMessageForThisMachine(c *gc.C) {
+	_, err := s.config.Hub.Publish(psapiserver.DrainTopic, psapiserver.Drain{
+		MachineID: "0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, s.apiServer)
+	c.Assert(err, gc.Equals, jworker.ErrRestartAgent)
+}
+
+func (s *apiserverSuite) TestDrainMessageForOtherMachine(c *gc.C) {
+	done, err := s.config.Hub.Publish(psapiserver.DrainTopic, psapiserver.Drain{
+		MachineID: "1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-done:
+	case <-time.After(testing.LongWait):
+		c.Fatalf("drain message not handled")
+	}
+
+	s.waitForHealth(c, "running", http.StatusOK)
+	workertest.CheckAlive(c, s.apiServer)
+}
+
+func (s *apiserverSuite) TestHealthDraining(c *gc.C) {
+	wg := apiserver.ServerWaitGroup(s.apiServer)
+	wg.Add(1)
+
+	s.apiServer.Drain()
+	s.waitForHealth(c, "draining", http.StatusServiceUnavailable)
+
+	// A draining server refuses new API connections.
+	_, err := api.Open(s.APIInfo(s.apiServer), api.DialOpts{})
+	c.Assert(err, gc.NotNil)
+	workertest.CheckAlive(c, s.apiServer)
+
+	wg.Done()
+	err = workertest.CheckKilled(c, s.apiServer)
+	c.Assert(err, gc.Equals, jworker.ErrRestartAgent)
+}
+
+func (s *apiserverSuite) waitForHealth(c *gc.C, expected string, expectedCode int) {
+	// There is a race between the test and the goroutine setting
+	// the value, so loop until we see the right health.
+	timeout := time.After(testing.LongWait)
+	for {
+		health, statusCode := s.getHealth(c)
+		if health == expected {
+			c.Assert(statusCode, gc.Equals, expectedCode)
+			return
+		}
+		select {
+		case <-timeout:
+			c.Fatalf("health not set to %s", expected)
+		case <-time.After(testing.ShortWait):
+			// Look again.
+		}
+	}
+}
+
 func (s *apiserverSuite) getHealth(c *gc.C) (string, int) {
 	uri := s.server.URL + "/health"
 	resp := apitesting.SendHTTPRequest(c, apitesting.HTTPRequestParams{Method: "GET", URL: uri})
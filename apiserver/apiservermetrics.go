@@ -26,6 +26,9 @@ const MetricLabelModelUUID = "model_uuid"
 // MetricLabelState defines a constant for the LogWriteCount Label
 const MetricLabelState = "state"
 
+// MetricLabelWorker defines a constant for the WorkerCrashLoops Label
+const MetricLabelWorker = "worker"
+
 // MetricAPIConnectionsLabelNames defines a series of labels for the
 // APIConnections metric.
 var MetricAPIConnectionsLabelNames = []string{
@@ -46,6 +49,13 @@ var MetricLogLabelNames = []string{
 	MetricLabelState,
 }
 
+// MetricWorkerCrashLoopLabelNames defines a series of labels for the
+// WorkerCrashLoops metric.
+var MetricWorkerCrashLoopLabelNames = []string{
+	MetricLabelModelUUID,
+	MetricLabelWorker,
+}
+
 // Collector is a prometheus.Collector that collects metrics based
 // on apiserver status.
 type Collector struct {
@@ -56,6 +66,7 @@ type Collector struct {
 	PingFailureCount   *prometheus.CounterVec
 	LogWriteCount      *prometheus.CounterVec
 	LogReadCount       *prometheus.CounterVec
+	WorkerCrashLoops   *prometheus.CounterVec
 }
 
 // NewMetricsCollector returns a new Collector.
@@ -109,6 +120,12 @@ func NewMetricsCollector() *Collector {
 			Name:      "log_read_count",
 			Help:      "Current number of log reads",
 		}, MetricLogLabelNames),
+		WorkerCrashLoops: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: apiserverMetricsNamespace,
+			Subsystem: apiserverSubsystemNamespace,
+			Name:      "worker_crash_loop_count",
+			Help:      "Number of agent workers found to be restarting repeatedly",
+		}, MetricWorkerCrashLoopLabelNames),
 	}
 }
 
@@ -121,6 +138,7 @@ func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
 	c.PingFailureCount.Describe(ch)
 	c.LogWriteCount.Describe(ch)
 	c.LogReadCount.Describe(ch)
+	c.WorkerCrashLoops.Describe(ch)
 }
 
 // Collect is part of the prometheus.Collector interface.
@@ -132,4 +150,16 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 	c.PingFailureCount.Collect(ch)
 	c.LogWriteCount.Collect(ch)
 	c.LogReadCount.Collect(ch)
+	c.WorkerCrashLoops.Collect(ch)
+}
+
+// crashLoopCounter is a facade.CrashLoopRecorder which counts crash
+// loops in the apiserver metrics.
+type crashLoopCounter struct {
+	collector *Collector
+}
+
+// RecordCrashLoop is part of the facade.CrashLoopRecorder interface.
+func (c crashLoopCounter) RecordCrashLoop(modelUUID, worker string) {
+	c.collector.WorkerCrashLoops.WithLabelValues(modelUUID, worker).Inc()
 }
@@ -36,7 +36,7 @@ func (s *apiservermetricsSuite) TestDescribe(c *gc.C) {
 	for desc := range ch {
 		descs = append(descs, desc)
 	}
-	c.Assert(descs, gc.HasLen, 7)
+	c.Assert(descs, gc.HasLen, 8)
 	c.Assert(descs[0].String(), gc.Matches, `.*fqName: "juju_apiserver_connections_total".*`)
 	c.Assert(descs[1].String(), gc.Matches, `.*fqName: "juju_apiserver_connections".*`)
 	c.Assert(descs[2].String(), gc.Matches, `.*fqName: "juju_apiserver_active_login_attempts".*`)
@@ -44,6 +44,7 @@ func (s *apiservermetricsSuite) TestDescribe(c *gc.C) {
 	c.Assert(descs[4].String(), gc.Matches, `.*fqName: "juju_apiserver_ping_failure_count".*`)
 	c.Assert(descs[5].String(), gc.Matches, `.*fqName: "juju_apiserver_log_write_count".*`)
 	c.Assert(descs[6].String(), gc.Matches, `.*fqName: "juju_apiserver_log_read_count".*`)
+	c.Assert(descs[7].String(), gc.Matches, `.*fqName: "juju_apiserver_worker_crash_loop_count".*`)
 }
 
 func (s *apiservermetricsSuite) TestCollect(c *gc.C) {
@@ -83,6 +84,11 @@ func (s *apiservermetricsSuite) TestLabelNames(c *gc.C) {
 			labels:  apiserver.MetricLogLabelNames,
 			checker: jc.IsTrue,
 		},
+		{
+			name:    "worker crash loop label names",
+			labels:  apiserver.MetricWorkerCrashLoopLabelNames,
+			checker: jc.IsTrue,
+		},
 		{
 			name:    "invalid names",
 			labels:  []string{"model-uuid"},
@@ -7,6 +7,7 @@ import (
 	"archive/zip"
 	"bytes"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
 	"io"
 	"io/ioutil"
@@ -18,6 +19,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/juju/charm/v9"
 	"github.com/juju/errors"
@@ -27,8 +29,9 @@ import (
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facades/client/application"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	corecharm "github.com/juju/juju/core/charm"
 	"github.com/juju/juju/state"
-	"github.com/juju/juju/state/storage"
 )
 
 type FailableHandlerFunc func(http.ResponseWriter, *http.Request) error
@@ -78,6 +81,12 @@ type charmsHandler struct {
 	ctxt          httpContext
 	dataDir       string
 	stateAuthFunc func(*http.Request) (*state.PooledState, error)
+
+	// migrating is set for the handler which receives the charms of
+	// a model being imported by migration. Those charms were admitted
+	// by the source controller and are not checked against the
+	// target's charm signature policy.
+	migrating bool
 }
 
 // bundleContentSenderFunc functions are responsible for sending a
@@ -231,6 +240,16 @@ func (h *charmsHandler) processPost(r *http.Request, st *state.State) (*charm.UR
 	}
 	defer os.Remove(charmFileName)
 
+	// The signature covers the archive as uploaded, so verify it before
+	// the archive is repackaged.
+	var signature *state.CharmSignature
+	if !h.migrating {
+		signature, err = verifyCharmSignature(st, charmFileName, query.Get("signature"), charm.Schema(schema) == charm.Local)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
 	err = h.processUploadedArchive(charmFileName)
 	if err != nil {
 		return nil, err
@@ -295,13 +314,56 @@ func (h *charmsHandler) processPost(r *http.Request, st *state.State) (*charm.UR
 
 	// Now we need to repackage it with the reserved URL, upload it to
 	// provider storage and update the state.
-	err = RepackageAndUploadCharm(st, archive, curl)
+	err = repackageAndUploadCharm(st, archive, curl, signature)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return curl, nil
 }
 
+// verifyCharmSignature checks the base64 encoded detached signature
+// uploaded with the charm archive at path against the controller's
+// trusted charm signing keys, according to the controller's charm
+// signature policy. It returns the signature to record in state, or
+// nil if there is none or the policy does not check signatures.
+// Unsigned local charms are rejected if the policy requires signatures.
+func verifyCharmSignature(st *state.State, path, encodedSig string, local bool) (*state.CharmSignature, error) {
+	cfg, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	policy := cfg.CharmSignaturePolicy()
+	if policy == controller.CharmSignaturePolicyNone {
+		return nil, nil
+	}
+	if encodedSig == "" {
+		if local && policy == controller.CharmSignaturePolicyRequire {
+			return nil, errors.BadRequestf("charm signature required by controller policy")
+		}
+		return nil, nil
+	}
+	sig, err := base64.StdEncoding.DecodeString(encodedSig)
+	if err != nil {
+		return nil, errors.NewBadRequest(errors.NewNotValid(err, "charm signature"), "")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer f.Close()
+	verified, err := corecharm.VerifySignature(f, sig, cfg.CharmSigningKeys())
+	if err != nil {
+		return nil, errors.BadRequestf("cannot verify charm signature: %v", err)
+	}
+	return &state.CharmSignature{
+		Format:     verified.Format,
+		KeyID:      verified.KeyID,
+		Signature:  sig,
+		VerifiedAt: time.Now().UTC(),
+	}, nil
+}
+
 // processUploadedArchive opens the given charm archive from path,
 // inspects it to see if it has all files at the root of the archive
 // or it has subdirs. It repackages the archive so it has all the
@@ -393,6 +455,10 @@ func (d byDepth) Less(i, j int) bool { return depth(d[i]) < depth(d[j]) }
 // temporary directory, repackages it with the given curl's revision,
 // then uploads it to storage, and finally updates the state.
 func RepackageAndUploadCharm(st *state.State, archive *charm.CharmArchive, curl *charm.URL) error {
+	return repackageAndUploadCharm(st, archive, curl, nil)
+}
+
+func repackageAndUploadCharm(st *state.State, archive *charm.CharmArchive, curl *charm.URL, signature *state.CharmSignature) error {
 	// Create a temp dir to contain the extracted charm dir.
 	tempDir, err := ioutil.TempDir("", "charm-download")
 	if err != nil {
@@ -442,6 +508,7 @@ func RepackageAndUploadCharm(st *state.State, archive *charm.CharmArchive, curl
 		Size:         int64(repackagedArchive.Len()),
 		SHA256:       bundleSHA256,
 		CharmVersion: version,
+		Signature:    signature,
 	}
 	// Store the charm archive in environment storage.
 	shim := application.NewStateShim(st)
@@ -480,7 +547,7 @@ func (h *charmsHandler) processGet(r *http.Request, st *state.State) (
 		fileArg = "icon.svg"
 	}
 
-	store := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	store := st.BlobStorage()
 	// Use the storage to retrieve and save the charm archive.
 	ch, err := st.Charm(curl)
 	if err != nil {
@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	apitesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/controller"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
@@ -454,6 +455,35 @@ func (s *charmsSuite) TestMigrateCharm(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *charmsSuite) TestMigrateCharmIgnoresSignaturePolicy(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.CharmSignaturePolicy: controller.CharmSignaturePolicyRequire,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	newSt := s.Factory.MakeModel(c, nil)
+	defer newSt.Close()
+	importedModel, err := newSt.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = importedModel.SetMigrationMode(state.MigrationModeImporting)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The unsigned local charm was admitted by the source controller.
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+	url := s.charmsURL("series=quantal")
+	url.Path = "/migrate/charms"
+	resp := s.sendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method:      "POST",
+		URL:         url.String(),
+		ContentType: "application/zip",
+		Body:        &fileReader{path: ch.Path},
+		ExtraHeaders: map[string]string{
+			params.MigrationModelHTTPHeader: importedModel.UUID(),
+		},
+	})
+	expectedURL := charm.MustParseURL("local:quantal/dummy-1")
+	s.assertUploadResponse(c, resp, expectedURL.String())
+}
+
 func (s *charmsSuite) TestMigrateCharmNotMigrating(c *gc.C) {
 	migratedModel := s.Factory.MakeModel(c, nil)
 	defer migratedModel.Close()
@@ -153,6 +153,17 @@ type Relation interface {
 	// SetSuspended sets the suspended status of the relation.
 	SetSuspended(bool, string) error
 
+	// KeyGeneration returns the generation of the keys with which the
+	// related units encrypt the relation settings, or zero if the
+	// settings are not encrypted.
+	KeyGeneration() int
+
+	// RotateKeys increments the key generation of the relation.
+	RotateKeys() (int, error)
+
+	// SetKeyGeneration records the key generation of the relation.
+	SetKeyGeneration(int) error
+
 	// ReplaceApplicationSettings replaces the application's settings within the
 	// relation.
 	ReplaceApplicationSettings(appName string, settings map[string]interface{}) error
@@ -264,6 +275,10 @@ type RemoteApplication interface {
 	// from a registration operation by a consuming model.
 	IsConsumerProxy() bool
 
+	// EncryptRelationData returns whether the settings exchanged over
+	// relations to the application are to be encrypted.
+	EncryptRelationData() bool
+
 	// Life returns the lifecycle state of the application.
 	Life() state.Life
 
@@ -61,6 +61,7 @@ type ControllerNode interface {
 	Id() string
 	HasVote() bool
 	WantsVote() bool
+	Customization() (state.ControllerCustomization, bool)
 }
 
 type Machine interface {
@@ -116,9 +117,13 @@ func ModelMachineInfo(st ModelManagerBackend) (machineInfo []params.ModelMachine
 	}
 	hasVote := make(map[string]bool)
 	wantsVote := make(map[string]bool)
+	customizations := make(map[string]state.ControllerCustomization)
 	for _, n := range controllerNodes {
 		hasVote[n.Id()] = n.HasVote()
 		wantsVote[n.Id()] = n.WantsVote()
+		if customization, ok := n.Customization(); ok {
+			customizations[n.Id()] = customization
+		}
 	}
 	var primaryID string
 	primaryHA, err := st.HAPrimaryMachine()
@@ -156,6 +161,10 @@ func ModelMachineInfo(st ModelManagerBackend) (machineInfo []params.ModelMachine
 			Status:    aStatus,
 			Message:   statusMessage,
 		}
+		if customization, ok := customizations[m.Id()]; ok {
+			mInfo.CustomizationStatus = string(customization.Status)
+			mInfo.CustomizationMessage = customization.Message
+		}
 		if primaryID != "" {
 			if isPrimary := primaryID == m.Id(); isPrimary {
 				mInfo.HAPrimary = &isPrimary
@@ -282,6 +282,43 @@ func (s *machineSuite) TestMachineInstanceInfoWithHAPrimary(c *gc.C) {
 	})
 }
 
+func (s *machineSuite) TestMachineInstanceInfoWithCustomization(c *gc.C) {
+	st := mockState{
+		machines: map[string]*mockMachine{
+			"1": {
+				id:     "1",
+				instId: "123",
+				status: status.Started,
+			},
+		},
+		controllerNodes: map[string]*mockControllerNode{
+			"1": {
+				id:        "1",
+				hasVote:   true,
+				wantsVote: true,
+				customization: &state.ControllerCustomization{
+					Hash:    "deadbeef",
+					Status:  state.CustomizationFailed,
+					Message: "exit status 1: no such package",
+				},
+			},
+		},
+	}
+	info, err := common.ModelMachineInfo(&st)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, []params.ModelMachineInfo{
+		{
+			Id:                   "1",
+			InstanceId:           "123",
+			Status:               "started",
+			HasVote:              true,
+			WantsVote:            true,
+			CustomizationStatus:  "failed",
+			CustomizationMessage: "exit status 1: no such package",
+		},
+	})
+}
+
 type mockState struct {
 	common.ModelManagerBackend
 	machines          map[string]*mockMachine
@@ -325,9 +362,10 @@ func (st *mockState) HAPrimaryMachine() (names.MachineTag, error) {
 }
 
 type mockControllerNode struct {
-	id        string
-	hasVote   bool
-	wantsVote bool
+	id            string
+	hasVote       bool
+	wantsVote     bool
+	customization *state.ControllerCustomization
 }
 
 func (m *mockControllerNode) Id() string {
@@ -342,6 +380,13 @@ func (m *mockControllerNode) HasVote() bool {
 	return m.hasVote
 }
 
+func (m *mockControllerNode) Customization() (state.ControllerCustomization, bool) {
+	if m.customization == nil {
+		return state.ControllerCustomization{}, false
+	}
+	return *m.customization, true
+}
+
 type mockMachine struct {
 	state.Machine
 	id                 string
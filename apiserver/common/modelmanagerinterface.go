@@ -117,6 +117,7 @@ type Model interface {
 	AddUser(state.UserAccessSpec) (permission.UserAccess, error)
 	AutoConfigureContainerNetworking(environ environs.BootstrapEnviron) error
 	SetCloudCredential(tag names.CloudCredentialTag) (bool, error)
+	TransferOwnership(newOwner names.UserTag) error
 }
 
 var _ ModelManagerBackend = (*modelManagerStateShim)(nil)
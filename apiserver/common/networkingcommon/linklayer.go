@@ -246,6 +246,15 @@ func (o *MachineLinkLayerOp) DeviceAddresses(dev LinkLayerDevice) []LinkLayerAdd
 	return addrs
 }
 
+// AddDeviceOps returns transaction operations for adding the input incoming
+// device to the machine, along with the supplied addresses.
+func (o *MachineLinkLayerOp) AddDeviceOps(
+	dev network.InterfaceInfo, addrs ...state.LinkLayerDeviceAddress,
+) ([]txn.Op, error) {
+	ops, err := o.machine.AddLinkLayerDeviceOps(networkDeviceToStateArgs(dev), addrs...)
+	return ops, errors.Trace(err)
+}
+
 // AssertAliveOp returns a transaction operation for asserting that the machine
 // for which we are updating link-layer data is alive.
 func (o *MachineLinkLayerOp) AssertAliveOp() txn.Op {
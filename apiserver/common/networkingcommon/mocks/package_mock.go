@@ -94,6 +94,20 @@ func (mr *MockBackingSpaceMockRecorder) Subnets() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subnets", reflect.TypeOf((*MockBackingSpace)(nil).Subnets))
 }
 
+// UUID mocks base method
+func (m *MockBackingSpace) UUID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UUID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// UUID indicates an expected call of UUID
+func (mr *MockBackingSpaceMockRecorder) UUID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UUID", reflect.TypeOf((*MockBackingSpace)(nil).UUID))
+}
+
 // MockBackingSubnet is a mock of BackingSubnet interface
 type MockBackingSubnet struct {
 	ctrl     *gomock.Controller
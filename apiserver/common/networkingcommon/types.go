@@ -89,6 +89,9 @@ type BackingSpace interface {
 	// Name returns the space name.
 	Name() string
 
+	// UUID returns the immutable UUID of the space.
+	UUID() string
+
 	// Subnets returns the subnets in the space
 	Subnets() ([]BackingSubnet, error)
 
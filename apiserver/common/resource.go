@@ -77,6 +77,20 @@ func (rs *Resources) RegisterNamed(name string, r facade.Resource) error {
 	return nil
 }
 
+// Replace replaces the resource registered with the given id, keeping
+// its place in the order of destruction. The replaced resource is not
+// stopped. It is an error if no resource is registered with the id.
+func (rs *Resources) Replace(id string, r facade.Resource) error {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if _, ok := rs.resources[id]; !ok {
+		return fmt.Errorf("resource %q not registered", id)
+	}
+	rs.resources[id] = r
+	logger.Tracef("replaced resource: %s", id)
+	return nil
+}
+
 // Stop stops the resource with the given id and unregisters it.
 // It returns any error from the underlying Stop call.
 // It does not return an error if the resource has already
@@ -136,6 +136,29 @@ func (resourceSuite) TestStop(c *gc.C) {
 	c.Assert(rs.Count(), gc.Equals, 1)
 }
 
+func (resourceSuite) TestReplace(c *gc.C) {
+	rs := common.NewResources()
+	r1 := &fakeResource{}
+	id := rs.Register(r1)
+	r2 := &fakeResource{}
+	err := rs.Replace(id, r2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r1.stopped, jc.IsFalse)
+	c.Assert(rs.Get(id), gc.Equals, r2)
+	c.Assert(rs.Count(), gc.Equals, 1)
+
+	rs.StopAll()
+	c.Assert(r1.stopped, jc.IsFalse)
+	c.Assert(r2.stopped, jc.IsTrue)
+}
+
+func (resourceSuite) TestReplaceNotRegistered(c *gc.C) {
+	rs := common.NewResources()
+	err := rs.Replace("1", &fakeResource{})
+	c.Assert(err, gc.ErrorMatches, `resource "1" not registered`)
+	c.Assert(rs.Count(), gc.Equals, 0)
+}
+
 func (resourceSuite) TestStopAll(c *gc.C) {
 	rs := common.NewResources()
 	r1 := &fakeResource{}
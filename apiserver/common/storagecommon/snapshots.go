@@ -0,0 +1,131 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storagecommon
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/poolmanager"
+)
+
+// StorageVolumeSnapshotter returns the storage.VolumeSnapshotter that
+// can take snapshots of the volume backing the specified storage
+// instance, along with the provisioned volume's info. An error
+// satisfying errors.IsNotSupported is returned if the storage
+// provider does not support volume snapshots.
+func StorageVolumeSnapshotter(
+	va VolumeAccess,
+	tag names.StorageTag,
+	poolManager poolmanager.PoolManager,
+	registry storage.ProviderRegistry,
+) (storage.VolumeSnapshotter, state.VolumeInfo, error) {
+	volume, err := va.StorageInstanceVolume(tag)
+	if errors.IsNotFound(err) {
+		return nil, state.VolumeInfo{}, errors.NotSupportedf(
+			"snapshots of %s, which is not backed by a volume,", names.ReadableString(tag),
+		)
+	} else if err != nil {
+		return nil, state.VolumeInfo{}, errors.Trace(err)
+	}
+	info, err := volume.Info()
+	if err != nil {
+		return nil, state.VolumeInfo{}, errors.Trace(err)
+	}
+	snapshotter, err := PoolVolumeSnapshotter(info.Pool, poolManager, registry)
+	if err != nil {
+		return nil, state.VolumeInfo{}, errors.Trace(err)
+	}
+	return snapshotter, info, nil
+}
+
+// PoolVolumeSnapshotter returns the storage.VolumeSnapshotter for the
+// named storage pool. If there is no such pool, but the name identifies
+// a storage provider, then that provider's default configuration is used.
+func PoolVolumeSnapshotter(
+	pool string,
+	poolManager poolmanager.PoolManager,
+	registry storage.ProviderRegistry,
+) (storage.VolumeSnapshotter, error) {
+	cfg, err := poolManager.Get(pool)
+	if errors.IsNotFound(err) {
+		cfg, err = storage.NewConfig(pool, storage.ProviderType(pool), map[string]interface{}{})
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting pool %q", pool)
+	}
+	provider, err := registry.StorageProvider(cfg.Provider())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !provider.Supports(storage.StorageKindBlock) {
+		return nil, errors.NotSupportedf("snapshots with storage provider %q", cfg.Provider())
+	}
+	volumeSource, err := provider.VolumeSource(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshotter, ok := volumeSource.(storage.VolumeSnapshotter)
+	if !ok {
+		return nil, errors.NotSupportedf("snapshots with storage provider %q", cfg.Provider())
+	}
+	return snapshotter, nil
+}
+
+// CreateStorageSnapshot takes a snapshot of the volume backing the
+// specified storage instance, setting the given resource tags on it.
+func CreateStorageSnapshot(
+	ctx context.ProviderCallContext,
+	va VolumeAccess,
+	tag names.StorageTag,
+	description string,
+	resourceTags map[string]string,
+	poolManager poolmanager.PoolManager,
+	registry storage.ProviderRegistry,
+) (params.StorageSnapshot, error) {
+	snapshotter, info, err := StorageVolumeSnapshotter(va, tag, poolManager, registry)
+	if err != nil {
+		return params.StorageSnapshot{}, errors.Trace(err)
+	}
+	results, err := snapshotter.CreateVolumeSnapshots(ctx, []storage.VolumeSnapshotParams{{
+		VolumeId:     info.VolumeId,
+		Description:  description,
+		ResourceTags: resourceTags,
+	}})
+	if err != nil {
+		return params.StorageSnapshot{}, errors.Trace(err)
+	}
+	if len(results) != 1 {
+		return params.StorageSnapshot{}, errors.Errorf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Error != nil {
+		return params.StorageSnapshot{}, errors.Trace(results[0].Error)
+	}
+	return VolumeSnapshotFromStorage(tag, *results[0].Snapshot), nil
+}
+
+// VolumeSnapshotFromStorage converts a storage.VolumeSnapshot to
+// params.StorageSnapshot.
+func VolumeSnapshotFromStorage(tag names.StorageTag, snap storage.VolumeSnapshot) params.StorageSnapshot {
+	var created *time.Time
+	if !snap.Created.IsZero() {
+		t := snap.Created
+		created = &t
+	}
+	return params.StorageSnapshot{
+		StorageTag:  tag.String(),
+		SnapshotId:  snap.SnapshotId,
+		VolumeId:    snap.VolumeId,
+		Size:        snap.Size,
+		Description: snap.Description,
+		Status:      snap.Status,
+		Created:     created,
+	}
+}
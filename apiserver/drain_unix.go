@@ -0,0 +1,20 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !windows
+
+package apiserver
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// DrainSignal is the signal that causes the API server to drain its
+// connections ahead of a controller restart.
+const DrainSignal = syscall.SIGUSR1
+
+func notifyDrainSignal(c chan<- os.Signal) {
+	signal.Notify(c, DrainSignal)
+}
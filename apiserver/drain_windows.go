@@ -0,0 +1,12 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"os"
+)
+
+// notifyDrainSignal does nothing on Windows, which has no
+// equivalent signal; draining is triggered over the hub instead.
+func notifyDrainSignal(c chan<- os.Signal) {}
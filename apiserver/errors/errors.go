@@ -341,6 +341,8 @@ func ServerError(err error) *params.Error {
 		}.AsMap()
 	case errors.IsQuotaLimitExceeded(err):
 		code = params.CodeQuotaLimitExceeded
+	case errors.IsTimeout(err):
+		code = params.CodeTimeout
 	case params.IsIncompatibleClientError(err):
 		code = params.CodeIncompatibleClient
 		rawErr := errors.Cause(err).(*params.IncompatibleClientError)
@@ -443,6 +445,8 @@ func RestoreError(err error) error {
 		return err
 	case params.IsCodeQuotaLimitExceeded(err):
 		return errors.NewQuotaLimitExceeded(nil, msg)
+	case params.IsCodeTimeout(err):
+		return errors.NewTimeout(nil, msg)
 	default:
 		return err
 	}
@@ -215,6 +215,11 @@ var errorTransformTests = []struct {
 	code:       params.CodeQuotaLimitExceeded,
 	status:     http.StatusInternalServerError,
 	helperFunc: params.IsCodeQuotaLimitExceeded,
+}, {
+	err:        errors.Timeoutf("waiting for agent"),
+	code:       params.CodeTimeout,
+	status:     http.StatusInternalServerError,
+	helperFunc: params.IsCodeTimeout,
 }, {
 	err: &params.IncompatibleClientError{
 		ServerVersion: jujuversion.Current,
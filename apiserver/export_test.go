@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/stateauthenticator"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/state"
@@ -22,7 +23,7 @@ import (
 
 var (
 	NewPingTimeout        = newPingTimeout
-	MaxClientPingInterval = maxClientPingInterval
+	MaxClientPingInterval = controller.DefaultAgentPingTimeout
 	NewBackups            = &newBackups
 	BZMimeType            = bzMimeType
 	JSMimeType            = jsMimeType
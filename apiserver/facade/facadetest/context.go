@@ -24,6 +24,8 @@ type Context struct {
 	StatePool_           *state.StatePool
 	Controller_          *cache.Controller
 	MultiwatcherFactory_ multiwatcher.Factory
+	FacadeUsage_         facade.FacadeUsage
+	CrashLoops_          facade.CrashLoopRecorder
 	ID_                  string
 	Cancel_              <-chan struct{}
 
@@ -104,6 +106,16 @@ func (context Context) ModelPresence(modelUUID string) facade.ModelPresence {
 	return nil
 }
 
+// FacadeUsage implements facade.Context.
+func (context Context) FacadeUsage() facade.FacadeUsage {
+	return context.FacadeUsage_
+}
+
+// CrashLoops implements facade.Context.
+func (context Context) CrashLoops() facade.CrashLoopRecorder {
+	return context.CrashLoops_
+}
+
 // LeadershipClaimer implements facade.Context.
 func (context Context) LeadershipClaimer(modelUUID string) (leadership.Claimer, error) {
 	return context.LeadershipClaimer_, nil
@@ -4,9 +4,12 @@
 package facade
 
 import (
+	"time"
+
 	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/facadeusage"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/multiwatcher"
@@ -91,6 +94,14 @@ type Context interface {
 	// the current model presence.
 	Presence() Presence
 
+	// FacadeUsage returns a record of the facade versions called on
+	// this API server, and by which clients.
+	FacadeUsage() FacadeUsage
+
+	// CrashLoops returns a recorder for agent workers found to be
+	// restarting repeatedly.
+	CrashLoops() CrashLoopRecorder
+
 	// Hub returns the central hub that the API server holds.
 	// At least at this stage, facades only need to publish events.
 	Hub() Hub
@@ -206,6 +217,24 @@ type Presence interface {
 type ModelPresence interface {
 	// For a given non controller agent, return the Status for that agent.
 	AgentStatus(agent string) (presence.Status, error)
+
+	// Values returns the connections of the model's agents.
+	Values() []presence.Value
+}
+
+// FacadeUsage reports the facade versions called on the API server.
+type FacadeUsage interface {
+	// Since returns the time the API server started recording usage.
+	Since() time.Time
+
+	// Usage returns the usage recorded so far.
+	Usage() []facadeusage.Usage
+}
+
+// CrashLoopRecorder records agent workers found to be restarting
+// repeatedly, so that they can be alerted on.
+type CrashLoopRecorder interface {
+	RecordCrashLoop(modelUUID, worker string)
 }
 
 // Hub represents the central hub that the API server has.
@@ -8,6 +8,7 @@ import (
 	"github.com/juju/names/v4"
 
 	jujucloud "github.com/juju/juju/cloud"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/state"
 )
 
@@ -29,6 +30,20 @@ type Backend interface {
 
 	// WatchModelCredential returns a watcher that is keeping an eye on what cloud credential a model uses.
 	WatchModelCredential() (state.NotifyWatcher, error)
+
+	// CloudCredential returns the cloud credential with the given tag.
+	CloudCredential(tag names.CloudCredentialTag) (state.Credential, error)
+
+	// CredentialModels returns the names of the models which use the
+	// given cloud credential, keyed on model UUID.
+	CredentialModels(tag names.CloudCredentialTag) (map[string]string, error)
+
+	// SetCloudCredentialExpiryNotified records that the given expiry
+	// status has been notified for the cloud credential.
+	SetCloudCredentialExpiryNotified(tag names.CloudCredentialTag, expiryStatus state.CredentialExpiryStatus) error
+
+	// ControllerConfig returns the controller's config.
+	ControllerConfig() (controller.Config, error)
 }
 
 func NewBackend(st StateAccessor) Backend {
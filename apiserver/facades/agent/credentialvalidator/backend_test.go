@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/credentialvalidator"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
@@ -170,6 +171,21 @@ func (b *mockState) Cloud(name string) (cloud.Cloud, error) {
 	return b.aCloud, nil
 }
 
+func (b *mockState) CredentialModels(tag names.CloudCredentialTag) (map[string]string, error) {
+	b.AddCall("CredentialModels", tag)
+	return nil, b.NextErr()
+}
+
+func (b *mockState) SetCloudCredentialExpiryNotified(tag names.CloudCredentialTag, expiryStatus state.CredentialExpiryStatus) error {
+	b.AddCall("SetCloudCredentialExpiryNotified", tag, expiryStatus)
+	return b.NextErr()
+}
+
+func (b *mockState) ControllerConfig() (controller.Config, error) {
+	b.AddCall("ControllerConfig")
+	return controller.Config{}, b.NextErr()
+}
+
 type mockModel struct {
 	*testing.Stub
 
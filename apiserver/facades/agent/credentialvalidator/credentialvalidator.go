@@ -4,6 +4,7 @@
 package credentialvalidator
 
 import (
+	"github.com/juju/clock"
 	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
 
@@ -16,6 +17,16 @@ import (
 
 var logger = loggo.GetLogger("juju.api.credentialvalidator")
 
+// CredentialValidatorV3 defines the methods on version 3 facade for the
+// credentialvalidator API endpoint.
+type CredentialValidatorV3 interface {
+	CheckModelCredentialExpiry() (params.ModelCredentialExpiry, error)
+	InvalidateModelCredential(params.InvalidateCredentialArg) (params.ErrorResult, error)
+	ModelCredential() (params.ModelCredential, error)
+	WatchCredential(params.Entity) (params.NotifyWatchResult, error)
+	WatchModelCredential() (params.NotifyWatchResult, error)
+}
+
 // CredentialValidatorV2 defines the methods on version 2 facade for the
 // credentialvalidator API endpoint.
 type CredentialValidatorV2 interface {
@@ -38,32 +49,47 @@ type CredentialValidatorAPI struct {
 
 	backend   Backend
 	resources facade.Resources
+	clock     clock.Clock
 }
 
-type CredentialValidatorAPIV1 struct {
+type CredentialValidatorAPIV2 struct {
 	*CredentialValidatorAPI
 }
 
+type CredentialValidatorAPIV1 struct {
+	*CredentialValidatorAPIV2
+}
+
 var (
-	_ CredentialValidatorV2 = (*CredentialValidatorAPI)(nil)
+	_ CredentialValidatorV3 = (*CredentialValidatorAPI)(nil)
+	_ CredentialValidatorV2 = (*CredentialValidatorAPIV2)(nil)
 	_ CredentialValidatorV1 = (*CredentialValidatorAPIV1)(nil)
 )
 
 // NewCredentialValidatorAPI creates a new CredentialValidator API endpoint on server-side.
 func NewCredentialValidatorAPI(ctx facade.Context) (*CredentialValidatorAPI, error) {
-	return internalNewCredentialValidatorAPI(NewBackend(NewStateShim(ctx.State())), ctx.Resources(), ctx.Auth())
+	return internalNewCredentialValidatorAPI(NewBackend(NewStateShim(ctx.State())), ctx.Resources(), ctx.Auth(), clock.WallClock)
+}
+
+// NewCredentialValidatorAPIv2 creates a new CredentialValidator API endpoint on server-side.
+func NewCredentialValidatorAPIv2(ctx facade.Context) (*CredentialValidatorAPIV2, error) {
+	v3, err := NewCredentialValidatorAPI(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &CredentialValidatorAPIV2{v3}, nil
 }
 
 // NewCredentialValidatorAPIv1 creates a new CredentialValidator API endpoint on server-side.
 func NewCredentialValidatorAPIv1(ctx facade.Context) (*CredentialValidatorAPIV1, error) {
-	v2, err := NewCredentialValidatorAPI(ctx)
+	v2, err := NewCredentialValidatorAPIv2(ctx)
 	if err != nil {
 		return nil, err
 	}
 	return &CredentialValidatorAPIV1{v2}, nil
 }
 
-func internalNewCredentialValidatorAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*CredentialValidatorAPI, error) {
+func internalNewCredentialValidatorAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer, clock clock.Clock) (*CredentialValidatorAPI, error) {
 	if !(authorizer.AuthMachineAgent() || authorizer.AuthUnitAgent() || authorizer.AuthApplicationAgent()) {
 		return nil, apiservererrors.ErrPerm
 	}
@@ -72,6 +98,7 @@ func internalNewCredentialValidatorAPI(backend Backend, resources facade.Resourc
 		CredentialManagerAPI: credentialcommon.NewCredentialManagerAPI(backend),
 		resources:            resources,
 		backend:              backend,
+		clock:                clock,
 	}, nil
 }
 
@@ -131,6 +158,9 @@ func (api *CredentialValidatorAPI) ModelCredential() (params.ModelCredential, er
 // WatchModelCredential did not exist prior to v2.
 func (*CredentialValidatorAPIV1) WatchModelCredential(_, _ struct{}) {}
 
+// CheckModelCredentialExpiry did not exist prior to v3.
+func (*CredentialValidatorAPIV2) CheckModelCredentialExpiry(_, _ struct{}) {}
+
 // WatchModelCredential returns a NotifyWatcher that watches what cloud credential a model uses.
 func (api *CredentialValidatorAPI) WatchModelCredential() (params.NotifyWatchResult, error) {
 	result := params.NotifyWatchResult{}
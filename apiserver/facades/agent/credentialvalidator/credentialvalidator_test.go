@@ -4,6 +4,14 @@
 package credentialvalidator_test
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	"github.com/juju/testing"
@@ -15,7 +23,10 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/credentialvalidator"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -25,6 +36,7 @@ type CredentialValidatorSuite struct {
 	resources  *common.Resources
 	authorizer apiservertesting.FakeAuthorizer
 	backend    *testBackend
+	clock      *testclock.Clock
 
 	api *credentialvalidator.CredentialValidatorAPI
 }
@@ -41,7 +53,8 @@ func (s *CredentialValidatorSuite) SetUpTest(c *gc.C) {
 	}
 	s.AddCleanup(func(_ *gc.C) { s.resources.StopAll() })
 
-	api, err := credentialvalidator.NewCredentialValidatorAPIForTest(s.backend, s.resources, s.authorizer)
+	s.clock = testclock.NewClock(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	api, err := credentialvalidator.NewCredentialValidatorAPIForTest(s.backend, s.resources, s.authorizer, s.clock)
 	c.Assert(err, jc.ErrorIsNil)
 	s.api = api
 }
@@ -120,6 +133,97 @@ func (s *CredentialValidatorSuite) TestWatchModelCredentialError(c *gc.C) {
 	c.Assert(s.resources.Count(), gc.Equals, 0)
 }
 
+// tokenExpiringAt returns a JSON web token which expires at the given time.
+func tokenExpiringAt(t time.Time) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"RS256"}`)) + "." +
+		enc.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, t.Unix()))) + "." +
+		enc.EncodeToString([]byte("signature"))
+}
+
+func (s *CredentialValidatorSuite) setCredentialExpiry(expiry time.Time) {
+	s.backend.credential = statetesting.CloudCredential(cloud.OAuth2AuthType, map[string]string{
+		"Token": tokenExpiringAt(expiry),
+	})
+}
+
+func (s *CredentialValidatorSuite) TestCheckModelCredentialExpiryNoExpiry(c *gc.C) {
+	s.backend.credential = statetesting.NewEmptyCredential()
+	result, err := s.api.CheckModelCredentialExpiry()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ModelCredentialExpiry{
+		CloudCredential: credentialTag.String(),
+	})
+	s.backend.CheckCallNames(c, "ModelCredential", "CloudCredential", "ControllerConfig")
+}
+
+func (s *CredentialValidatorSuite) TestCheckModelCredentialExpiryInvalidCredential(c *gc.C) {
+	s.backend.mc.Valid = false
+	result, err := s.api.CheckModelCredentialExpiry()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ModelCredentialExpiry{})
+	s.backend.CheckCallNames(c, "ModelCredential")
+}
+
+func (s *CredentialValidatorSuite) TestCheckModelCredentialExpiring(c *gc.C) {
+	var notification map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(json.NewDecoder(r.Body).Decode(&notification), jc.ErrorIsNil)
+	}))
+	defer srv.Close()
+	s.backend.config = controller.Config{controller.CredentialExpiryWebhookURL: srv.URL}
+	expiry := s.clock.Now().Add(48 * time.Hour)
+	s.setCredentialExpiry(expiry)
+
+	result, err := s.api.CheckModelCredentialExpiry()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Status, gc.Equals, "expiring")
+	c.Assert(result.Expiry, gc.NotNil)
+	c.Assert(result.Expiry.Equal(expiry), jc.IsTrue)
+	c.Assert(notification, jc.DeepEquals, map[string]interface{}{
+		"cloud-credential": credentialTag.Id(),
+		"status":           "expiring",
+		"expiry":           expiry.Format(time.RFC3339),
+		"models":           []interface{}{"foo"},
+	})
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"ModelCredential", nil},
+		{"CloudCredential", []interface{}{credentialTag}},
+		{"ControllerConfig", nil},
+		{"CredentialModels", []interface{}{credentialTag}},
+		{"SetCloudCredentialExpiryNotified", []interface{}{credentialTag, state.CredentialExpiring}},
+	})
+}
+
+func (s *CredentialValidatorSuite) TestCheckModelCredentialExpiringWebhookFails(c *gc.C) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	s.backend.config = controller.Config{controller.CredentialExpiryWebhookURL: srv.URL}
+	s.setCredentialExpiry(s.clock.Now().Add(48 * time.Hour))
+
+	_, err := s.api.CheckModelCredentialExpiry()
+	c.Assert(err, gc.ErrorMatches, "credential expiry webhook returned 503 Service Unavailable")
+	s.backend.CheckCallNames(c, "ModelCredential", "CloudCredential", "ControllerConfig", "CredentialModels")
+}
+
+func (s *CredentialValidatorSuite) TestCheckModelCredentialExpired(c *gc.C) {
+	expiry := s.clock.Now().Add(-time.Hour)
+	s.setCredentialExpiry(expiry)
+
+	result, err := s.api.CheckModelCredentialExpiry()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Status, gc.Equals, "expired")
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"ModelCredential", nil},
+		{"CloudCredential", []interface{}{credentialTag}},
+		{"ControllerConfig", nil},
+		// The credential is only warned about, not invalidated.
+		{"SetCloudCredentialExpiryNotified", []interface{}{credentialTag, state.CredentialExpired}},
+	})
+}
+
 // modelUUID is the model tag we're using in the tests.
 var modelUUID = "01234567-89ab-cdef-0123-456789abcdef"
 
@@ -144,8 +248,10 @@ func newMockBackend() *testBackend {
 type testBackend struct {
 	*testing.Stub
 
-	mc     *credentialvalidator.ModelCredential
-	isUsed bool
+	mc         *credentialvalidator.ModelCredential
+	isUsed     bool
+	credential state.Credential
+	config     controller.Config
 }
 
 func (b *testBackend) ModelCredential() (*credentialvalidator.ModelCredential, error) {
@@ -181,3 +287,23 @@ func (b *testBackend) WatchModelCredential() (state.NotifyWatcher, error) {
 	}
 	return apiservertesting.NewFakeNotifyWatcher(), nil
 }
+
+func (b *testBackend) CloudCredential(tag names.CloudCredentialTag) (state.Credential, error) {
+	b.AddCall("CloudCredential", tag)
+	return b.credential, b.NextErr()
+}
+
+func (b *testBackend) CredentialModels(tag names.CloudCredentialTag) (map[string]string, error) {
+	b.AddCall("CredentialModels", tag)
+	return map[string]string{modelUUID: "foo"}, b.NextErr()
+}
+
+func (b *testBackend) SetCloudCredentialExpiryNotified(tag names.CloudCredentialTag, expiryStatus state.CredentialExpiryStatus) error {
+	b.AddCall("SetCloudCredentialExpiryNotified", tag, expiryStatus)
+	return b.NextErr()
+}
+
+func (b *testBackend) ControllerConfig() (controller.Config, error) {
+	b.AddCall("ControllerConfig")
+	return b.config, b.NextErr()
+}
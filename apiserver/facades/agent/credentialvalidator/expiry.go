@@ -0,0 +1,137 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialvalidator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	jujuhttp "github.com/juju/http"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// credentialExpiryWebhookTimeout is how long to wait for the credential
+// expiry webhook to respond.
+const credentialExpiryWebhookTimeout = 30 * time.Second
+
+// credentialExpiryNotification is posted as JSON to the controller's
+// credential expiry webhook when a cloud credential is about to expire,
+// and again once it has expired.
+type credentialExpiryNotification struct {
+	CloudCredential string    `json:"cloud-credential"`
+	Status          string    `json:"status"`
+	Expiry          time.Time `json:"expiry"`
+	Models          []string  `json:"models"`
+}
+
+// CheckModelCredentialExpiry checks whether the cloud credential that the
+// model uses is about to expire, or has expired. Each change in expiry
+// status is posted once to the controller's credential expiry webhook,
+// if there is one. An expired credential is only warned about, not
+// marked invalid: the expiry is read from the credential's attributes,
+// and the cloud may still accept it, for instance if the token is
+// refreshed outside of Juju.
+func (api *CredentialValidatorAPI) CheckModelCredentialExpiry() (params.ModelCredentialExpiry, error) {
+	result, err := api.checkModelCredentialExpiry()
+	if err != nil {
+		return params.ModelCredentialExpiry{}, apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
+
+func (api *CredentialValidatorAPI) checkModelCredentialExpiry() (params.ModelCredentialExpiry, error) {
+	mc, err := api.backend.ModelCredential()
+	if err != nil {
+		return params.ModelCredentialExpiry{}, errors.Trace(err)
+	}
+	if !mc.Exists || !mc.Valid {
+		// Nothing to warn about, or the model is already suspended.
+		return params.ModelCredentialExpiry{}, nil
+	}
+	credential, err := api.backend.CloudCredential(mc.Credential)
+	if err != nil {
+		return params.ModelCredentialExpiry{}, errors.Trace(err)
+	}
+	cfg, err := api.backend.ControllerConfig()
+	if err != nil {
+		return params.ModelCredentialExpiry{}, errors.Trace(err)
+	}
+
+	expiryStatus, expiry := credential.ExpiryStatus(api.clock.Now(), cfg.CredentialExpiryWarningPeriod())
+	result := params.ModelCredentialExpiry{
+		CloudCredential: mc.Credential.String(),
+		Status:          string(expiryStatus),
+	}
+	if !expiry.IsZero() {
+		result.Expiry = &expiry
+	}
+	if expiryStatus == state.CredentialNotExpiring {
+		return result, nil
+	}
+
+	if expiryStatus != credential.ExpiryNotified() {
+		if webhookURL := cfg.CredentialExpiryWebhookURL(); webhookURL != "" {
+			if err := api.notifyCredentialExpiry(webhookURL, mc, expiryStatus, expiry); err != nil {
+				// Try again at the next check.
+				return result, errors.Trace(err)
+			}
+		}
+		if err := api.backend.SetCloudCredentialExpiryNotified(mc.Credential, expiryStatus); err != nil {
+			return result, errors.Trace(err)
+		}
+	}
+	return result, nil
+}
+
+// notifyCredentialExpiry posts the credential's expiry status to the
+// webhook. Any response other than 200 OK is an error.
+func (api *CredentialValidatorAPI) notifyCredentialExpiry(
+	webhookURL string, mc *ModelCredential, expiryStatus state.CredentialExpiryStatus, expiry time.Time,
+) error {
+	models, err := api.backend.CredentialModels(mc.Credential)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	notification := credentialExpiryNotification{
+		CloudCredential: mc.Credential.Id(),
+		Status:          string(expiryStatus),
+		Expiry:          expiry.UTC(),
+		Models:          make([]string, 0, len(models)),
+	}
+	for _, name := range models {
+		notification.Models = append(notification.Models, name)
+	}
+	sort.Strings(notification.Models)
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), credentialExpiryWebhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "POST", webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := jujuhttp.NewClient(jujuhttp.Config{Logger: logger.Child("credentialexpiry")})
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Annotatef(err, "notifying credential expiry webhook")
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("credential expiry webhook returned %s", resp.Status)
+	}
+	return nil
+}
@@ -4,9 +4,11 @@
 package credentialvalidator
 
 import (
+	"github.com/juju/clock"
+
 	"github.com/juju/juju/apiserver/facade"
 )
 
-func NewCredentialValidatorAPIForTest(b Backend, resources facade.Resources, authorizer facade.Authorizer) (*CredentialValidatorAPI, error) {
-	return internalNewCredentialValidatorAPI(b, resources, authorizer)
+func NewCredentialValidatorAPIForTest(b Backend, resources facade.Resources, authorizer facade.Authorizer, clock clock.Clock) (*CredentialValidatorAPI, error) {
+	return internalNewCredentialValidatorAPI(b, resources, authorizer, clock)
 }
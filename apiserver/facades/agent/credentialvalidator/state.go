@@ -7,6 +7,7 @@ import (
 	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/state"
 )
 
@@ -25,6 +26,9 @@ type StateAccessor interface {
 	WatchCredential(names.CloudCredentialTag) state.NotifyWatcher
 	InvalidateModelCredential(reason string) error
 	Cloud(name string) (cloud.Cloud, error)
+	CredentialModels(tag names.CloudCredentialTag) (map[string]string, error)
+	SetCloudCredentialExpiryNotified(tag names.CloudCredentialTag, expiryStatus state.CredentialExpiryStatus) error
+	ControllerConfig() (controller.Config, error)
 }
 
 type stateShim struct {
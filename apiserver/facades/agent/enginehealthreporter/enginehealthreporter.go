@@ -0,0 +1,186 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package enginehealthreporter implements the API facade used by the
+// engine-health-reporter worker, which records the health of the
+// workers in a machine agent's dependency engine.
+//
+// Workers reported as bouncing are treated as crash looping: each new
+// crash loop is counted in the API server's metrics, and the machine
+// agent's status message lists the crash looping workers until they
+// recover.
+package enginehealthreporter
+
+import (
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+const (
+	// healthBouncing is the health reported for a worker which keeps
+	// restarting.
+	healthBouncing = "bouncing"
+
+	// crashLoopMessagePrefix starts the machine agent status message
+	// set while any of its workers are crash looping.
+	crashLoopMessagePrefix = "workers restarting repeatedly: "
+)
+
+// Backend defines the state methods used by the facade.
+type Backend interface {
+	ModelUUID() string
+	Machine(id string) (Machine, error)
+}
+
+// Machine defines the machine methods used by the facade.
+type Machine interface {
+	EngineHealth() (state.EngineHealth, error)
+	SetEngineHealth([]state.EngineWorkerHealth) error
+	Status() (status.StatusInfo, error)
+	SetStatus(status.StatusInfo) error
+}
+
+type backendShim struct {
+	st *state.State
+}
+
+// ModelUUID implements Backend.
+func (b backendShim) ModelUUID() string {
+	return b.st.ModelUUID()
+}
+
+// Machine implements Backend.
+func (b backendShim) Machine(id string) (Machine, error) {
+	m, err := b.st.Machine(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return m, nil
+}
+
+// Facade implements the API required by the engine-health-reporter
+// worker.
+type Facade struct {
+	backend    Backend
+	authorizer facade.Authorizer
+	crashLoops facade.CrashLoopRecorder
+}
+
+// NewFacade is used for API registration.
+func NewFacade(ctx facade.Context) (*Facade, error) {
+	return New(backendShim{st: ctx.State()}, ctx.Auth(), ctx.CrashLoops())
+}
+
+// New returns a new API facade for the engine-health-reporter worker.
+func New(backend Backend, authorizer facade.Authorizer, crashLoops facade.CrashLoopRecorder) (*Facade, error) {
+	if !authorizer.AuthMachineAgent() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &Facade{
+		backend:    backend,
+		authorizer: authorizer,
+		crashLoops: crashLoops,
+	}, nil
+}
+
+// ReportEngineHealth records the unhealthy workers in the dependency
+// engines of one or more machine agents.
+func (f *Facade) ReportEngineHealth(args params.EngineHealthReports) params.ErrorResults {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Reports)),
+	}
+	for i, report := range args.Reports {
+		err := f.reportEngineHealth(report)
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results
+}
+
+func (f *Facade) reportEngineHealth(report params.EngineHealthReport) error {
+	tag, err := names.ParseMachineTag(report.Tag)
+	if err != nil || !f.authorizer.AuthOwner(tag) {
+		return apiservererrors.ErrPerm
+	}
+	m, err := f.backend.Machine(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	previous, err := m.EngineHealth()
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	wasBouncing := set.NewStrings()
+	for _, w := range previous.Workers {
+		if w.Health == healthBouncing {
+			wasBouncing.Add(w.Name)
+		}
+	}
+
+	workers := make([]state.EngineWorkerHealth, len(report.Workers))
+	var crashLooping []string
+	for i, w := range report.Workers {
+		workers[i] = state.EngineWorkerHealth{
+			Name:     w.Name,
+			Health:   w.Health,
+			State:    w.State,
+			Error:    w.Error,
+			Restarts: w.Restarts,
+		}
+		if w.Health != healthBouncing {
+			continue
+		}
+		crashLooping = append(crashLooping, w.Name)
+		if !wasBouncing.Contains(w.Name) {
+			f.crashLoops.RecordCrashLoop(f.backend.ModelUUID(), workerKind(w.Name))
+		}
+	}
+	if err := m.SetEngineHealth(workers); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(updateAgentStatus(m, crashLooping))
+}
+
+// updateAgentStatus sets the machine agent's status message to list
+// the crash looping workers, or clears it once there are none. Only a
+// started agent's status is changed, and messages set by anything else
+// are left alone.
+func updateAgentStatus(m Machine, crashLooping []string) error {
+	current, err := m.Status()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if current.Status != status.Started {
+		return nil
+	}
+	if current.Message != "" && !strings.HasPrefix(current.Message, crashLoopMessagePrefix) {
+		return nil
+	}
+	var message string
+	if len(crashLooping) > 0 {
+		message = crashLoopMessagePrefix + strings.Join(crashLooping, ", ")
+	}
+	if message == current.Message {
+		return nil
+	}
+	return errors.Trace(m.SetStatus(status.StatusInfo{
+		Status:  status.Started,
+		Message: message,
+		Data:    current.Data,
+	}))
+}
+
+// workerKind returns the manifold name of a reported worker, without
+// the unit name prefixed to the workers of deployed units, so that the
+// metric's labels stay few.
+func workerKind(name string) string {
+	return name[strings.LastIndex(name, "/")+1:]
+}
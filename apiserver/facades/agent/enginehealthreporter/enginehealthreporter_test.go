@@ -0,0 +1,181 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package enginehealthreporter_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/agent/enginehealthreporter"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+type facadeSuite struct {
+	testing.IsolationSuite
+
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	crashLoops *mockCrashLoops
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{machines: map[string]*mockMachine{
+		"0": {status: status.StatusInfo{Status: status.Started}},
+		"1": {status: status.StatusInfo{Status: status.Started}},
+	}}
+	s.crashLoops = &mockCrashLoops{}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("1"),
+	}
+}
+
+func (s *facadeSuite) TestNewRequiresMachineAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewUnitTag("mysql/0")
+	_, err := enginehealthreporter.New(s.backend, s.authorizer, s.crashLoops)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *facadeSuite) TestReportEngineHealth(c *gc.C) {
+	facade, err := enginehealthreporter.New(s.backend, s.authorizer, s.crashLoops)
+	c.Assert(err, jc.ErrorIsNil)
+
+	workers := []params.EngineWorkerHealth{{
+		Name:   "machiner",
+		Health: "failing",
+		State:  "stopped",
+		Error:  "boom",
+	}}
+	result := facade.ReportEngineHealth(params.EngineHealthReports{
+		Reports: []params.EngineHealthReport{
+			{Tag: "machine-0", Workers: workers},
+			{Tag: "machine-1", Workers: workers},
+			{Tag: "unit-mysql-0", Workers: workers},
+		},
+	})
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	c.Assert(s.backend.machines["0"].workers, gc.IsNil)
+	c.Assert(s.backend.machines["1"].workers, jc.DeepEquals, []state.EngineWorkerHealth{{
+		Name:   "machiner",
+		Health: "failing",
+		State:  "stopped",
+		Error:  "boom",
+	}})
+}
+
+func (s *facadeSuite) report(c *gc.C, workers ...params.EngineWorkerHealth) {
+	facade, err := enginehealthreporter.New(s.backend, s.authorizer, s.crashLoops)
+	c.Assert(err, jc.ErrorIsNil)
+	result := facade.ReportEngineHealth(params.EngineHealthReports{
+		Reports: []params.EngineHealthReport{{Tag: "machine-1", Workers: workers}},
+	})
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+}
+
+var bouncingUniter = params.EngineWorkerHealth{
+	Name:     "mysql/0/uniter",
+	Health:   "bouncing",
+	State:    "starting",
+	Restarts: 4,
+}
+
+func (s *facadeSuite) TestCrashLoopRecordedOnce(c *gc.C) {
+	s.report(c, bouncingUniter)
+	c.Assert(s.crashLoops.recorded, jc.DeepEquals, []string{"model-uuid uniter"})
+	c.Assert(s.backend.machines["1"].status, jc.DeepEquals, status.StatusInfo{
+		Status:  status.Started,
+		Message: "workers restarting repeatedly: mysql/0/uniter",
+	})
+
+	// Still bouncing, so not a new crash loop.
+	bouncingUniter := bouncingUniter
+	bouncingUniter.Restarts = 6
+	s.report(c, bouncingUniter)
+	c.Assert(s.crashLoops.recorded, gc.HasLen, 1)
+}
+
+func (s *facadeSuite) TestCrashLoopStatusCleared(c *gc.C) {
+	s.report(c, bouncingUniter)
+	s.report(c)
+	c.Assert(s.backend.machines["1"].status, jc.DeepEquals, status.StatusInfo{
+		Status: status.Started,
+	})
+}
+
+func (s *facadeSuite) TestCrashLoopLeavesOtherStatus(c *gc.C) {
+	s.backend.machines["1"].status = status.StatusInfo{
+		Status:  status.Error,
+		Message: "cannot start",
+	}
+	s.report(c, bouncingUniter)
+	c.Assert(s.crashLoops.recorded, gc.HasLen, 1)
+	c.Assert(s.backend.machines["1"].status, jc.DeepEquals, status.StatusInfo{
+		Status:  status.Error,
+		Message: "cannot start",
+	})
+}
+
+type mockBackend struct {
+	machines map[string]*mockMachine
+}
+
+func (b *mockBackend) ModelUUID() string {
+	return "model-uuid"
+}
+
+func (b *mockBackend) Machine(id string) (enginehealthreporter.Machine, error) {
+	m, ok := b.machines[id]
+	if !ok {
+		return nil, errors.NotFoundf("machine %s", id)
+	}
+	return m, nil
+}
+
+type mockMachine struct {
+	workers []state.EngineWorkerHealth
+	status  status.StatusInfo
+}
+
+func (m *mockMachine) EngineHealth() (state.EngineHealth, error) {
+	if m.workers == nil {
+		return state.EngineHealth{}, errors.NotFoundf("engine health")
+	}
+	return state.EngineHealth{Workers: m.workers}, nil
+}
+
+func (m *mockMachine) SetEngineHealth(workers []state.EngineWorkerHealth) error {
+	m.workers = workers
+	return nil
+}
+
+func (m *mockMachine) Status() (status.StatusInfo, error) {
+	return m.status, nil
+}
+
+func (m *mockMachine) SetStatus(info status.StatusInfo) error {
+	m.status = info
+	return nil
+}
+
+type mockCrashLoops struct {
+	recorded []string
+}
+
+func (r *mockCrashLoops) RecordCrashLoop(modelUUID, worker string) {
+	r.recorded = append(r.recorded, modelUUID+" "+worker)
+}
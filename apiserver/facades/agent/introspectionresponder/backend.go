@@ -0,0 +1,46 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspectionresponder
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/state"
+)
+
+// Backend provides access to the introspection requests made of an
+// agent.
+type Backend interface {
+	IntrospectionRequest(id string) (IntrospectionRequest, error)
+	WatchIntrospectionRequests(agent names.Tag) state.StringsWatcher
+}
+
+// IntrospectionRequest describes a request for an agent to query its
+// introspection socket.
+type IntrospectionRequest interface {
+	Id() string
+	Agent() names.Tag
+	Path() string
+	Completed() bool
+	Complete(output []byte, message string) error
+}
+
+type backendShim struct {
+	st *state.State
+}
+
+// IntrospectionRequest implements Backend.
+func (b backendShim) IntrospectionRequest(id string) (IntrospectionRequest, error) {
+	req, err := b.st.IntrospectionRequest(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return req, nil
+}
+
+// WatchIntrospectionRequests implements Backend.
+func (b backendShim) WatchIntrospectionRequests(agent names.Tag) state.StringsWatcher {
+	return b.st.WatchIntrospectionRequests(agent)
+}
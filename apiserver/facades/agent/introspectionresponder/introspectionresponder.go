@@ -0,0 +1,101 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package introspectionresponder provides the IntrospectionResponder
+// facade, used by machine agents to answer the introspection requests
+// made of them through the AgentIntrospection facade.
+package introspectionresponder
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/watcher"
+)
+
+// API implements the IntrospectionResponder facade.
+type API struct {
+	backend   Backend
+	resources facade.Resources
+	agent     names.Tag
+}
+
+// NewFacade is used for API registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(backendShim{st: ctx.State()}, ctx.Resources(), ctx.Auth())
+}
+
+// NewAPI returns a new IntrospectionResponder API facade.
+func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthMachineAgent() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &API{
+		backend:   backend,
+		resources: resources,
+		agent:     authorizer.GetAuthTag(),
+	}, nil
+}
+
+// WatchRequests returns a watcher which reports the ids of the
+// introspection requests made of the calling agent.
+func (api *API) WatchRequests() (params.StringsWatchResult, error) {
+	w := api.backend.WatchIntrospectionRequests(api.agent)
+	if changes, ok := <-w.Changes(); ok {
+		return params.StringsWatchResult{
+			StringsWatcherId: api.resources.Register(w),
+			Changes:          changes,
+		}, nil
+	}
+	return params.StringsWatchResult{}, watcher.EnsureErr(w)
+}
+
+// Requests returns the given introspection requests.
+func (api *API) Requests(args params.IntrospectionRequestIds) params.IntrospectionRequestResults {
+	results := make([]params.IntrospectionRequestResult, len(args.Ids))
+	for i, id := range args.Ids {
+		req, err := api.request(id)
+		if err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results[i].Request = &params.IntrospectionRequest{
+			Id:        req.Id(),
+			Path:      req.Path(),
+			Completed: req.Completed(),
+		}
+	}
+	return params.IntrospectionRequestResults{Results: results}
+}
+
+// Respond records the calling agent's responses to introspection
+// requests.
+func (api *API) Respond(args params.IntrospectionResponses) params.ErrorResults {
+	results := make([]params.ErrorResult, len(args.Responses))
+	for i, resp := range args.Responses {
+		req, err := api.request(resp.Id)
+		if err == nil {
+			err = req.Complete(resp.Output, resp.Error)
+		}
+		results[i].Error = apiservererrors.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}
+}
+
+// request returns the introspection request with the given id, if it
+// was made of the calling agent.
+func (api *API) request(id string) (IntrospectionRequest, error) {
+	req, err := api.backend.IntrospectionRequest(id)
+	if errors.IsNotFound(err) {
+		return nil, apiservererrors.ErrPerm
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if req.Agent() != api.agent {
+		return nil, apiservererrors.ErrPerm
+	}
+	return req, nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspectionresponder_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/agent/introspectionresponder"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type introspectionResponderSuite struct {
+	testing.IsolationSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+	resources  *common.Resources
+	backend    *mockBackend
+}
+
+var _ = gc.Suite(&introspectionResponderSuite{})
+
+func (s *introspectionResponderSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	s.backend = &mockBackend{
+		requests: map[string]*mockRequest{
+			"machine-0#0": {id: "machine-0#0", agent: names.NewMachineTag("0"), path: "/depengine"},
+			"machine-1#1": {id: "machine-1#1", agent: names.NewMachineTag("1"), path: "/depengine"},
+		},
+	}
+}
+
+func (s *introspectionResponderSuite) newAPI(c *gc.C) *introspectionresponder.API {
+	api, err := introspectionresponder.NewAPI(s.backend, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *introspectionResponderSuite) TestNewAPIRequiresMachineAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin")
+	_, err := introspectionresponder.NewAPI(s.backend, s.resources, s.authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *introspectionResponderSuite) TestWatchRequests(c *gc.C) {
+	changes := make(chan []string, 1)
+	changes <- []string{"machine-0#0"}
+	s.backend.watcher = statetesting.NewMockStringsWatcher(changes)
+
+	result, err := s.newAPI(c).WatchRequests()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResult{
+		StringsWatcherId: "1",
+		Changes:          []string{"machine-0#0"},
+	})
+	c.Assert(s.backend.watched, gc.Equals, names.NewMachineTag("0"))
+	c.Assert(s.resources.Get("1"), gc.Equals, s.backend.watcher)
+}
+
+func (s *introspectionResponderSuite) TestRequests(c *gc.C) {
+	result := s.newAPI(c).Requests(params.IntrospectionRequestIds{
+		Ids: []string{"machine-0#0", "machine-1#1", "machine-0#9"},
+	})
+	c.Assert(result, jc.DeepEquals, params.IntrospectionRequestResults{
+		Results: []params.IntrospectionRequestResult{{
+			Request: &params.IntrospectionRequest{Id: "machine-0#0", Path: "/depengine"},
+		}, {
+			Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+		}, {
+			Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+		}},
+	})
+}
+
+func (s *introspectionResponderSuite) TestRespond(c *gc.C) {
+	result := s.newAPI(c).Respond(params.IntrospectionResponses{
+		Responses: []params.IntrospectionResponse{
+			{Id: "machine-0#0", Output: []byte("ok")},
+			{Id: "machine-1#1", Error: "boom"},
+		},
+	})
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized}},
+		},
+	})
+	req := s.backend.requests["machine-0#0"]
+	c.Assert(req.completed, jc.IsTrue)
+	c.Assert(string(req.output), gc.Equals, "ok")
+	c.Assert(s.backend.requests["machine-1#1"].completed, jc.IsFalse)
+}
+
+type mockBackend struct {
+	requests map[string]*mockRequest
+	watcher  state.StringsWatcher
+	watched  names.Tag
+}
+
+func (b *mockBackend) IntrospectionRequest(id string) (introspectionresponder.IntrospectionRequest, error) {
+	req, ok := b.requests[id]
+	if !ok {
+		return nil, errors.NotFoundf("introspection request %q", id)
+	}
+	return req, nil
+}
+
+func (b *mockBackend) WatchIntrospectionRequests(agent names.Tag) state.StringsWatcher {
+	b.watched = agent
+	return b.watcher
+}
+
+type mockRequest struct {
+	id        string
+	agent     names.Tag
+	path      string
+	completed bool
+	output    []byte
+	message   string
+}
+
+func (r *mockRequest) Id() string       { return r.id }
+func (r *mockRequest) Agent() names.Tag { return r.agent }
+func (r *mockRequest) Path() string     { return r.path }
+func (r *mockRequest) Completed() bool  { return r.completed }
+
+func (r *mockRequest) Complete(output []byte, message string) error {
+	if r.completed {
+		return errors.Errorf("introspection request %q already completed", r.id)
+	}
+	r.completed = true
+	r.output = output
+	r.message = message
+	return nil
+}
@@ -4,12 +4,17 @@
 package provisioner_test
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/facade/facadetest"
 	"github.com/juju/juju/apiserver/facades/agent/provisioner"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/environs/imagemetadata"
 	imagetesting "github.com/juju/juju/environs/imagemetadata/testing"
 	sstesting "github.com/juju/juju/environs/simplestreams/testing"
@@ -97,6 +102,129 @@ func (s *ImageMetadataSuite) TestMetadataFromState(c *gc.C) {
 	s.assertImageMetadataResults(c, result, expected...)
 }
 
+func (s *ImageMetadataSuite) TestMetadataFromService(c *gc.C) {
+	var queries int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries++
+		fmt.Fprint(w, `{"images": [{
+			"id": "ami-site",
+			"arch": "amd64",
+			"version": "12.10",
+			"region": "dummy_region",
+			"virt": "hvm",
+			"root_store": "ebs",
+			"stream": "site"
+		}]}`)
+	}))
+	defer server.Close()
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"image-metadata-service-url": server.URL,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := provisioner.NewProvisionerAPI(facadetest.Context{
+		Auth_:      s.authorizer,
+		State_:     s.State,
+		StatePool_: s.StatePool,
+		Resources_: s.resources,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := make([][]params.CloudImageMetadata, len(s.machines))
+	for i := range s.machines {
+		expected[i] = []params.CloudImageMetadata{{
+			ImageId:         "ami-site",
+			Region:          "dummy_region",
+			Version:         "12.10",
+			Series:          "quantal",
+			Arch:            "amd64",
+			VirtType:        "hvm",
+			RootStorageType: "ebs",
+			Stream:          "site",
+			Source:          "image-metadata-service",
+			Priority:        50,
+		}}
+	}
+	result, err := api.ProvisioningInfo(s.getTestMachinesTags(c))
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertImageMetadataResults(c, result, expected...)
+	c.Assert(queries, gc.Equals, len(s.machines))
+
+	// The images are cached in state, and used when the
+	// service cannot be reached.
+	server.Close()
+	result, err = api.ProvisioningInfo(s.getTestMachinesTags(c))
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertImageMetadataResults(c, result, expected...)
+}
+
+func (s *ImageMetadataSuite) TestCachedServiceMetadataMatchesTags(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"images": [{
+			"id": "ami-gpu",
+			"arch": "amd64",
+			"version": "12.10",
+			"region": "dummy_region",
+			"virt": "hvm",
+			"root_store": "ebs",
+			"stream": "daily"
+		}]}`)
+	}))
+	defer server.Close()
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"image-metadata-service-url": server.URL,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.setMachineConstraints(c, "tags=gpu")
+
+	api, err := provisioner.NewProvisionerAPI(facadetest.Context{
+		Auth_:      s.authorizer,
+		State_:     s.State,
+		StatePool_: s.StatePool,
+		Resources_: s.resources,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := make([][]params.CloudImageMetadata, len(s.machines))
+	for i := range s.machines {
+		expected[i] = []params.CloudImageMetadata{{
+			ImageId:         "ami-gpu",
+			Region:          "dummy_region",
+			Version:         "12.10",
+			Series:          "quantal",
+			Arch:            "amd64",
+			VirtType:        "hvm",
+			RootStorageType: "ebs",
+			Stream:          "daily",
+			Source:          "image-metadata-service tags=gpu",
+			Priority:        50,
+		}}
+	}
+	result, err := api.ProvisioningInfo(s.getTestMachinesTags(c))
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertImageMetadataResults(c, result, expected...)
+
+	// The images cached for the gpu tag aren't used for machines
+	// without it, even though they match the model's stream.
+	server.Close()
+	s.setMachineConstraints(c, "")
+	result, err = api.ProvisioningInfo(s.getTestMachinesTags(c))
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertImageMetadataResults(c, result, make([][]params.CloudImageMetadata, len(s.machines))...)
+
+	s.setMachineConstraints(c, "tags=gpu")
+	result, err = api.ProvisioningInfo(s.getTestMachinesTags(c))
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertImageMetadataResults(c, result, expected...)
+}
+
+func (s *ImageMetadataSuite) setMachineConstraints(c *gc.C, cons string) {
+	for _, m := range s.machines {
+		err := m.SetConstraints(constraints.MustParse(cons))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
 func (s *ImageMetadataSuite) getTestMachinesTags(c *gc.C) params.Entities {
 
 	testMachines := make([]params.Entity, len(s.machines))
@@ -487,6 +487,7 @@ func (api *ProvisionerAPI) ContainerConfig() (params.ContainerConfig, error) {
 	result.SnapStoreAssertions = cfg.SnapStoreAssertions()
 	result.SnapStoreProxyID = cfg.SnapStoreProxy()
 	result.SnapStoreProxyURL = cfg.SnapStoreProxyURL()
+	result.APIProxy = cfg.AgentAPIProxy()
 	result.CloudInitUserData = cfg.CloudInitUserData()
 	result.ContainerInheritProperties = cfg.ContainerInheritProperties()
 	return result, nil
@@ -1563,6 +1563,7 @@ func (s *withoutControllerSuite) TestContainerConfig(c *gc.C) {
 		"snap-store-proxy":             "b4dc0ffee",
 		"cloudinit-userdata":           validCloudInitUserData,
 		"container-inherit-properties": "ca-certs,apt-primary",
+		"agent-api-proxy":              "socks5://10.0.0.1:1080",
 	}
 	err := s.Model.UpdateModelConfig(attrs, nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -1600,6 +1601,7 @@ func (s *withoutControllerSuite) TestContainerConfig(c *gc.C) {
 		"postruncmd":      []interface{}{"mkdir /tmp/postruncmd", "mkdir /tmp/postruncmd2"},
 		"package_upgrade": false})
 	c.Check(results.ContainerInheritProperties, gc.DeepEquals, "ca-certs,apt-primary")
+	c.Check(results.APIProxy, gc.Equals, "socks5://10.0.0.1:1080")
 }
 
 func (s *withoutControllerSuite) TestContainerConfigLegacy(c *gc.C) {
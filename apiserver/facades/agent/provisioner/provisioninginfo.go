@@ -17,6 +17,7 @@ import (
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
@@ -632,13 +633,21 @@ func (api *ProvisionerAPI) translateEndpointBindingsToSpaces(spaceInfos network.
 func (api *ProvisionerAPI) availableImageMetadata(
 	m *state.Machine, env environs.Environ,
 ) ([]params.CloudImageMetadata, error) {
-	imageConstraint, err := api.constructImageConstraint(m, env)
+	cons, err := m.Constraints()
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get machine constraints for machine %v", m.MachineTag().Id())
+	}
+	imageConstraint, err := api.constructImageConstraint(m, cons, env)
 	if err != nil {
 		return nil, errors.Annotate(err, "could not construct image constraint")
 	}
+	var imageTags []string
+	if cons.Tags != nil {
+		imageTags = *cons.Tags
+	}
 
 	// Look for image metadata in state.
-	data, err := api.findImageMetadata(imageConstraint, env)
+	data, err := api.findImageMetadata(imageConstraint, imageTags, env)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -648,17 +657,14 @@ func (api *ProvisionerAPI) availableImageMetadata(
 }
 
 // constructImageConstraint returns model-specific criteria used to look for image metadata.
-func (api *ProvisionerAPI) constructImageConstraint(m *state.Machine, env environs.Environ) (*imagemetadata.ImageConstraint, error) {
+func (api *ProvisionerAPI) constructImageConstraint(
+	m *state.Machine, cons constraints.Value, env environs.Environ,
+) (*imagemetadata.ImageConstraint, error) {
 	lookup := simplestreams.LookupParams{
 		Series: []string{m.Series()},
 		Stream: env.Config().ImageStream(),
 	}
 
-	cons, err := m.Constraints()
-	if err != nil {
-		return nil, errors.Annotatef(err, "cannot get machine constraints for machine %v", m.MachineTag().Id())
-	}
-
 	if cons.Arch != nil {
 		lookup.Arches = []string{*cons.Arch}
 	}
@@ -679,9 +685,21 @@ func (api *ProvisionerAPI) constructImageConstraint(m *state.Machine, env enviro
 }
 
 // findImageMetadata returns all image metadata or an error fetching them.
-// It looks for image metadata in state.
+// If the model has an image metadata service, it is asked for images
+// matching the constraint and tags first. Otherwise, or if the service
+// has no matching images, it looks for image metadata in state.
 // If none are found, we fall back on original image search in simple streams.
-func (api *ProvisionerAPI) findImageMetadata(imageConstraint *imagemetadata.ImageConstraint, env environs.Environ) ([]params.CloudImageMetadata, error) {
+func (api *ProvisionerAPI) findImageMetadata(
+	imageConstraint *imagemetadata.ImageConstraint, imageTags []string, env environs.Environ,
+) ([]params.CloudImageMetadata, error) {
+	if serviceURL, ok := env.Config().ImageMetadataServiceURL(); ok {
+		serviceMetadata := api.imageMetadataFromService(serviceURL, imageConstraint, imageTags)
+		logger.Debugf("got from image metadata service %d metadata", len(serviceMetadata))
+		if len(serviceMetadata) != 0 {
+			return serviceMetadata, nil
+		}
+	}
+
 	// Look for image metadata in state.
 	stateMetadata, err := api.imageMetadataFromState(imageConstraint)
 	if err != nil && !errors.IsNotFound(err) {
@@ -724,31 +742,142 @@ func (api *ProvisionerAPI) imageMetadataFromState(constraint *imagemetadata.Imag
 		return nil, errors.Trace(err)
 	}
 
-	toParams := func(m cloudimagemetadata.Metadata) params.CloudImageMetadata {
-		return params.CloudImageMetadata{
-			ImageId:         m.ImageId,
-			Stream:          m.Stream,
-			Region:          m.Region,
-			Version:         m.Version,
-			Series:          m.Series,
-			Arch:            m.Arch,
-			VirtType:        m.VirtType,
-			RootStorageType: m.RootStorageType,
-			RootStorageSize: m.RootStorageSize,
-			Source:          m.Source,
-			Priority:        m.Priority,
-		}
-	}
-
 	var all []params.CloudImageMetadata
-	for _, ms := range stored {
+	for source, ms := range stored {
+		// Images from the image metadata service were found for
+		// particular tags, so are only used by imageMetadataFromService.
+		if strings.HasPrefix(source, imageServiceSource) {
+			continue
+		}
 		for _, m := range ms {
-			all = append(all, toParams(m))
+			all = append(all, cloudImageMetadataToParams(m))
 		}
 	}
 	return all, nil
 }
 
+// imageServiceSource is the source recorded against image metadata
+// returned by a model's image metadata service.
+const imageServiceSource = "image-metadata-service"
+
+// imageServiceSourceForTags returns the source recorded against image
+// metadata returned by the image metadata service for the given tags.
+// The tags are part of the source so that images cached for one set of
+// tags are not used for another.
+func imageServiceSourceForTags(imageTags []string) string {
+	if len(imageTags) == 0 {
+		return imageServiceSource
+	}
+	sorted := set.NewStrings(imageTags...).SortedValues()
+	return fmt.Sprintf("%s tags=%s", imageServiceSource, strings.Join(sorted, ","))
+}
+
+// imageMetadataFromService asks the image metadata service at serviceURL
+// for images matching the given constraint and tags, and caches them in
+// state. If the service cannot be queried, the images it returned for
+// the same constraint and tags previously are used instead.
+func (api *ProvisionerAPI) imageMetadataFromService(
+	serviceURL string, constraint *imagemetadata.ImageConstraint, imageTags []string,
+) []params.CloudImageMetadata {
+	source := imageServiceSourceForTags(imageTags)
+	found, err := imagemetadata.FetchFromService(serviceURL, constraint, imageTags)
+	if err != nil {
+		logger.Warningf("%v; using cached image metadata", err)
+		return api.cachedServiceImageMetadata(constraint, source)
+	}
+
+	var metadataState []cloudimagemetadata.Metadata
+	for _, m := range found {
+		mSeries, err := series.VersionSeries(m.Version)
+		if err != nil {
+			logger.Warningf("could not determine series for image id %s: %v", m.Id, err)
+			continue
+		}
+		metadataState = append(metadataState, toCloudImageMetadata(
+			m, mSeries, source, simplestreams.CUSTOM_CLOUD_DATA, constraint.Stream,
+		))
+	}
+	if len(metadataState) == 0 {
+		return nil
+	}
+	if err := api.st.CloudImageMetadataStorage.SaveMetadata(metadataState); err != nil {
+		// No need to react here, just take note
+		logger.Warningf("failed to save image metadata service images: %v", err)
+	}
+
+	result := make([]params.CloudImageMetadata, len(metadataState))
+	for i, m := range metadataState {
+		result[i] = cloudImageMetadataToParams(m)
+	}
+	return result
+}
+
+// cachedServiceImageMetadata returns the image metadata stored in state
+// from the given image metadata service source that matches the given
+// constraint. The service decides which stream its images belong to, so
+// the constraint's stream is not matched.
+func (api *ProvisionerAPI) cachedServiceImageMetadata(
+	constraint *imagemetadata.ImageConstraint, source string,
+) []params.CloudImageMetadata {
+	stored, err := api.st.CloudImageMetadataStorage.FindMetadata(cloudimagemetadata.MetadataFilter{
+		Series: constraint.Series,
+		Arches: constraint.Arches,
+		Region: constraint.Region,
+	})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			logger.Warningf("could not get cached image metadata from controller: %v", err)
+		}
+		return nil
+	}
+	var result []params.CloudImageMetadata
+	for _, m := range stored[source] {
+		result = append(result, cloudImageMetadataToParams(m))
+	}
+	return result
+}
+
+func cloudImageMetadataToParams(m cloudimagemetadata.Metadata) params.CloudImageMetadata {
+	return params.CloudImageMetadata{
+		ImageId:         m.ImageId,
+		Stream:          m.Stream,
+		Region:          m.Region,
+		Version:         m.Version,
+		Series:          m.Series,
+		Arch:            m.Arch,
+		VirtType:        m.VirtType,
+		RootStorageType: m.RootStorageType,
+		RootStorageSize: m.RootStorageSize,
+		Source:          m.Source,
+		Priority:        m.Priority,
+	}
+}
+
+// toCloudImageMetadata converts image metadata found in simplestreams or
+// returned by an image metadata service to the form stored in state.
+func toCloudImageMetadata(
+	m *imagemetadata.ImageMetadata, mSeries, source string, priority int, stream string,
+) cloudimagemetadata.Metadata {
+	result := cloudimagemetadata.Metadata{
+		MetadataAttributes: cloudimagemetadata.MetadataAttributes{
+			Region:          m.RegionName,
+			Arch:            m.Arch,
+			VirtType:        m.VirtType,
+			RootStorageType: m.Storage,
+			Source:          source,
+			Series:          mSeries,
+			Stream:          m.Stream,
+			Version:         m.Version,
+		},
+		Priority: priority,
+		ImageId:  m.Id,
+	}
+	if result.Stream == "" {
+		result.Stream = stream
+	}
+	return result
+}
+
 // imageMetadataFromDataSources finds image metadata that match specified criteria in existing data sources.
 func (api *ProvisionerAPI) imageMetadataFromDataSources(env environs.Environ, constraint *imagemetadata.ImageConstraint) ([]params.CloudImageMetadata, error) {
 	sources, err := environs.ImageMetadataSources(env)
@@ -756,32 +885,12 @@ func (api *ProvisionerAPI) imageMetadataFromDataSources(env environs.Environ, co
 		return nil, errors.Trace(err)
 	}
 
-	cfg := env.Config()
-	toModel := func(m *imagemetadata.ImageMetadata, mSeries string, source string, priority int) cloudimagemetadata.Metadata {
-		result := cloudimagemetadata.Metadata{
-			MetadataAttributes: cloudimagemetadata.MetadataAttributes{
-				Region:          m.RegionName,
-				Arch:            m.Arch,
-				VirtType:        m.VirtType,
-				RootStorageType: m.Storage,
-				Source:          source,
-				Series:          mSeries,
-				Stream:          m.Stream,
-				Version:         m.Version,
-			},
-			Priority: priority,
-			ImageId:  m.Id,
-		}
-		// TODO (anastasiamac 2016-08-24) This is a band-aid solution.
-		// Once correct value is read from simplestreams, this needs to go.
-		// Bug# 1616295
-		if result.Stream == "" {
-			result.Stream = constraint.Stream
-		}
-		if result.Stream == "" {
-			result.Stream = cfg.ImageStream()
-		}
-		return result
+	// TODO (anastasiamac 2016-08-24) This is a band-aid solution.
+	// Once correct value is read from simplestreams, this needs to go.
+	// Bug# 1616295
+	stream := constraint.Stream
+	if stream == "" {
+		stream = env.Config().ImageStream()
 	}
 
 	var metadataState []cloudimagemetadata.Metadata
@@ -799,7 +908,7 @@ func (api *ProvisionerAPI) imageMetadataFromDataSources(env environs.Environ, co
 				logger.Warningf("could not determine series for image id %s: %v", m.Id, err)
 				continue
 			}
-			metadataState = append(metadataState, toModel(m, mSeries, info.Source, source.Priority()))
+			metadataState = append(metadataState, toCloudImageMetadata(m, mSeries, info.Source, source.Priority(), stream))
 		}
 	}
 	if len(metadataState) > 0 {
@@ -12,6 +12,7 @@ import (
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
@@ -62,7 +63,7 @@ func newFacadeBase(ctx facade.Context) (*APIBase, error) {
 	}
 	return NewAPIBase(
 		ctx.StatePool().SystemState(),
-		model,
+		backendShim{Model: model, st: st},
 		ctx.Resources(),
 		ctx.Auth(),
 	)
@@ -90,6 +91,44 @@ type APIBase struct {
 type Backend interface {
 	ModelConfig() (*config.Config, error)
 	WatchForModelConfigChanges() state.NotifyWatcher
+
+	// ApplicationConfigForUnit returns the application config of the
+	// named unit's application, used to override the model's proxy
+	// settings for that unit.
+	ApplicationConfigForUnit(unitName string) (application.ConfigAttributes, error)
+
+	// WatchApplicationConfigForUnit returns a watcher that notifies of
+	// changes to the application config of the named unit's application.
+	WatchApplicationConfigForUnit(unitName string) (state.NotifyWatcher, error)
+}
+
+type backendShim struct {
+	*state.Model
+	st *state.State
+}
+
+func (b backendShim) ApplicationConfigForUnit(unitName string) (application.ConfigAttributes, error) {
+	app, err := b.unitApplication(unitName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app.ApplicationConfig()
+}
+
+func (b backendShim) WatchApplicationConfigForUnit(unitName string) (state.NotifyWatcher, error) {
+	app, err := b.unitApplication(unitName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app.WatchApplicationConfig(), nil
+}
+
+func (b backendShim) unitApplication(unitName string) (*state.Application, error) {
+	unit, err := b.st.Unit(unitName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return unit.Application()
 }
 
 // ControllerBackend defines the controller state methods this facade needs,
@@ -112,12 +151,27 @@ func NewAPIBase(controller ControllerBackend, backend Backend, resources facade.
 	}, nil
 }
 
-func (api *APIBase) oneWatch() params.NotifyWatchResult {
+func (api *APIBase) oneWatch(tag names.Tag) params.NotifyWatchResult {
 	var result params.NotifyWatchResult
 
-	watch := common.NewMultiNotifyWatcher(
+	watchers := []state.NotifyWatcher{
 		api.backend.WatchForModelConfigChanges(),
-		api.controller.WatchAPIHostPortsForAgents())
+		api.controller.WatchAPIHostPortsForAgents(),
+	}
+	// Units also need to know when their application's proxy
+	// overrides change.
+	if tag.Kind() == names.UnitTagKind {
+		appWatcher, err := api.backend.WatchApplicationConfigForUnit(tag.Id())
+		if err != nil {
+			for _, w := range watchers {
+				_ = w.Stop()
+			}
+			result.Error = apiservererrors.ServerError(err)
+			return result
+		}
+		watchers = append(watchers, appWatcher)
+	}
+	watch := common.NewMultiNotifyWatcher(watchers...)
 
 	if _, ok := <-watch.Changes(); ok {
 		result = params.NotifyWatchResult{
@@ -136,9 +190,10 @@ func (api *APIBase) WatchForProxyConfigAndAPIHostPortChanges(args params.Entitie
 	}
 	errors, _ := api.authEntities(args)
 
-	for i := range args.Entities {
+	for i, entity := range args.Entities {
 		if errors.Results[i].Error == nil {
-			results.Results[i] = api.oneWatch()
+			tag, _ := names.ParseTag(entity.Tag)
+			results.Results[i] = api.oneWatch(tag)
 		} else {
 			results.Results[i].Error = errors.Results[i].Error
 		}
@@ -179,8 +234,20 @@ func (api *APIBase) authEntities(args params.Entities) (params.ErrorResults, boo
 	return result, ok
 }
 
-func (api *APIBase) proxyConfig() params.ProxyConfigResult {
+// proxyConfig returns the proxy settings for the given entity. Units
+// get the model's settings with any overrides from their application's
+// config applied.
+func (api *APIBase) proxyConfig(tag names.Tag) params.ProxyConfigResult {
 	var result params.ProxyConfigResult
+	var appConfig application.ConfigAttributes
+	if tag.Kind() == names.UnitTagKind {
+		var err error
+		if appConfig, err = api.backend.ApplicationConfigForUnit(tag.Id()); err != nil {
+			result.Error = apiservererrors.ServerError(err)
+			return result
+		}
+	}
+
 	config, err := api.backend.ModelConfig()
 	if err != nil {
 		result.Error = apiservererrors.ServerError(err)
@@ -193,7 +260,7 @@ func (api *APIBase) proxyConfig() params.ProxyConfigResult {
 		return result
 	}
 
-	jujuProxySettings := config.JujuProxySettings()
+	jujuProxySettings := appConfig.JujuProxySettings(config.JujuProxySettings())
 	legacyProxySettings := config.LegacyProxySettings()
 
 	if jujuProxySettings.HasProxySet() {
@@ -204,33 +271,33 @@ func (api *APIBase) proxyConfig() params.ProxyConfigResult {
 	result.JujuProxySettings = toParams(jujuProxySettings)
 	result.LegacyProxySettings = toParams(legacyProxySettings)
 
-	result.APTProxySettings = toParams(config.AptProxySettings())
+	result.APTProxySettings = toParams(appConfig.AptProxySettings(config.AptProxySettings()))
 
-	result.SnapProxySettings = toParams(config.SnapProxySettings())
+	result.SnapProxySettings = toParams(appConfig.SnapProxySettings(config.SnapProxySettings()))
 	result.SnapStoreProxyId = config.SnapStoreProxy()
 	result.SnapStoreProxyAssertions = config.SnapStoreAssertions()
 	result.SnapStoreProxyURL = config.SnapStoreProxyURL()
 
+	result.APIProxy = config.AgentAPIProxy()
+
 	return result
 }
 
-// ProxyConfig returns the proxy settings for the current model.
+// ProxyConfig returns the proxy settings for the current model, with
+// any application overrides applied for unit entities.
 func (api *APIBase) ProxyConfig(args params.Entities) params.ProxyConfigResults {
-	var result params.ProxyConfigResult
-	errors, ok := api.authEntities(args)
-
-	if ok {
-		result = api.proxyConfig()
-	}
+	errors, _ := api.authEntities(args)
 
 	results := params.ProxyConfigResults{
 		Results: make([]params.ProxyConfigResult, len(args.Entities)),
 	}
-	for i := range args.Entities {
-		if errors.Results[i].Error == nil {
-			results.Results[i] = result
+	for i, entity := range args.Entities {
+		if errors.Results[i].Error != nil {
+			results.Results[i].Error = errors.Results[i].Error
+			continue
 		}
-		results.Results[i].Error = errors.Results[i].Error
+		tag, _ := names.ParseTag(entity.Tag)
+		results.Results[i] = api.proxyConfig(tag)
 	}
 
 	return results
@@ -238,25 +305,23 @@ func (api *APIBase) ProxyConfig(args params.Entities) params.ProxyConfigResults
 
 // ProxyConfig returns the proxy settings for the current model.
 func (api *APIv1) ProxyConfig(args params.Entities) params.ProxyConfigResultsV1 {
-	var result params.ProxyConfigResultV1
-	errors, ok := api.authEntities(args)
-
-	if ok {
-		v2 := api.proxyConfig()
-		result = params.ProxyConfigResultV1{
-			ProxySettings:    v2.LegacyProxySettings,
-			APTProxySettings: v2.APTProxySettings,
-		}
-	}
+	errors, _ := api.authEntities(args)
 
 	results := params.ProxyConfigResultsV1{
 		Results: make([]params.ProxyConfigResultV1, len(args.Entities)),
 	}
-	for i := range args.Entities {
-		if errors.Results[i].Error == nil {
-			results.Results[i] = result
+	for i, entity := range args.Entities {
+		if errors.Results[i].Error != nil {
+			results.Results[i].Error = errors.Results[i].Error
+			continue
+		}
+		tag, _ := names.ParseTag(entity.Tag)
+		v2 := api.proxyConfig(tag)
+		results.Results[i] = params.ProxyConfigResultV1{
+			ProxySettings:    v2.LegacyProxySettings,
+			APTProxySettings: v2.APTProxySettings,
+			Error:            v2.Error,
 		}
-		results.Results[i].Error = errors.Results[i].Error
 	}
 
 	return results
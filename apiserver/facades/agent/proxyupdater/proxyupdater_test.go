@@ -6,6 +6,7 @@ package proxyupdater_test
 import (
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -16,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/proxyupdater"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
@@ -29,7 +31,7 @@ type ProxyUpdaterSuite struct {
 	resources  *common.Resources
 	authorizer apiservertesting.FakeAuthorizer
 	facade     *proxyupdater.APIv2
-	tag        names.MachineTag
+	tag        names.Tag
 }
 
 var _ = gc.Suite(&ProxyUpdaterSuite{})
@@ -246,14 +248,94 @@ func (s *ProxyUpdaterSuite) TestSnapProxyConfig(c *gc.C) {
 	})
 }
 
+func (s *ProxyUpdaterSuite) TestAPIProxyConfig(c *gc.C) {
+	s.state.SetModelConfig(coretesting.Attrs{
+		"agent-api-proxy": "http://proxy.example.com:3128",
+	})
+	cfg := s.facade.ProxyConfig(s.oneEntity())
+
+	expectedNoProxy := "0.1.2.3,0.1.2.4,0.1.2.5"
+
+	c.Assert(cfg.Results[0], jc.DeepEquals, params.ProxyConfigResult{
+		LegacyProxySettings: params.ProxyConfig{NoProxy: expectedNoProxy},
+		APIProxy:            "http://proxy.example.com:3128",
+	})
+}
+
+func (s *ProxyUpdaterSuite) setUpUnitFacade(c *gc.C) {
+	s.tag = names.NewUnitTag("mysql/0")
+	s.authorizer.Tag = s.tag
+	api, err := proxyupdater.NewAPIBase(s.state, s.state, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = &proxyupdater.APIv2{api}
+}
+
+func (s *ProxyUpdaterSuite) TestWatchForProxyConfigAndAPIHostPortChangesUnit(c *gc.C) {
+	s.setUpUnitFacade(c)
+	result := s.facade.WatchForProxyConfigAndAPIHostPortChanges(s.oneEntity())
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+
+	s.state.Stub.CheckCalls(c, []testing.StubCall{
+		{"WatchForModelConfigChanges", nil},
+		{"WatchAPIHostPortsForAgents", nil},
+		{"WatchApplicationConfigForUnit", []interface{}{"mysql/0"}},
+	})
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+}
+
+func (s *ProxyUpdaterSuite) TestProxyConfigApplicationOverrides(c *gc.C) {
+	s.setUpUnitFacade(c)
+	s.state.SetModelConfig(coretesting.Attrs{
+		"juju-http-proxy":  "http proxy",
+		"juju-https-proxy": "https proxy",
+		"apt-http-proxy":   "apt http proxy",
+		"snap-http-proxy":  "snap http proxy",
+	})
+	s.state.appConfig = application.ConfigAttributes{
+		"juju-http-proxy":  "http://app.proxy:3128",
+		"juju-no-proxy":    "10.0.0.1",
+		"apt-https-proxy":  "https://app.apt.proxy",
+		"snap-https-proxy": "https://app.snap.proxy",
+	}
+
+	cfg := s.facade.ProxyConfig(s.oneEntity())
+	s.state.Stub.CheckCalls(c, []testing.StubCall{
+		{"ApplicationConfigForUnit", []interface{}{"mysql/0"}},
+		{"ModelConfig", nil},
+		{"APIHostPortsForAgents", nil},
+	})
+
+	c.Assert(cfg.Results[0], jc.DeepEquals, params.ProxyConfigResult{
+		JujuProxySettings: params.ProxyConfig{
+			HTTP: "http://app.proxy:3128", HTTPS: "https proxy", NoProxy: "0.1.2.3,0.1.2.4,0.1.2.5,10.0.0.1"},
+		APTProxySettings: params.ProxyConfig{
+			HTTP: "http://apt http proxy", HTTPS: "https://app.apt.proxy"},
+		SnapProxySettings: params.ProxyConfig{
+			HTTP: "snap http proxy", HTTPS: "https://app.snap.proxy"},
+	})
+}
+
+func (s *ProxyUpdaterSuite) TestProxyConfigApplicationConfigError(c *gc.C) {
+	s.setUpUnitFacade(c)
+	s.state.SetErrors(errors.New("boom"))
+
+	cfg := s.facade.ProxyConfig(s.oneEntity())
+	c.Assert(cfg.Results, gc.HasLen, 1)
+	c.Assert(cfg.Results[0].Error, gc.ErrorMatches, "boom")
+	s.state.Stub.CheckCallNames(c, "ApplicationConfigForUnit")
+}
+
 type stubBackend struct {
 	*testing.Stub
 
 	EnvConfig   *config.Config
 	c           *gc.C
 	configAttrs coretesting.Attrs
+	appConfig   application.ConfigAttributes
 	hpWatcher   workertest.NotAWatcher
 	confWatcher workertest.NotAWatcher
+	appWatcher  workertest.NotAWatcher
 }
 
 func (sb *stubBackend) SetUp(c *gc.C) {
@@ -267,11 +349,13 @@ func (sb *stubBackend) SetUp(c *gc.C) {
 	}
 	sb.hpWatcher = workertest.NewFakeWatcher(1, 1)
 	sb.confWatcher = workertest.NewFakeWatcher(1, 1)
+	sb.appWatcher = workertest.NewFakeWatcher(1, 1)
 }
 
 func (sb *stubBackend) Kill() {
 	sb.hpWatcher.Kill()
 	sb.confWatcher.Kill()
+	sb.appWatcher.Kill()
 }
 
 func (sb *stubBackend) SetModelConfig(ca coretesting.Attrs) {
@@ -308,3 +392,19 @@ func (sb *stubBackend) WatchForModelConfigChanges() state.NotifyWatcher {
 	sb.MethodCall(sb, "WatchForModelConfigChanges")
 	return sb.confWatcher
 }
+
+func (sb *stubBackend) ApplicationConfigForUnit(unitName string) (application.ConfigAttributes, error) {
+	sb.MethodCall(sb, "ApplicationConfigForUnit", unitName)
+	if err := sb.NextErr(); err != nil {
+		return nil, err
+	}
+	return sb.appConfig, nil
+}
+
+func (sb *stubBackend) WatchApplicationConfigForUnit(unitName string) (state.NotifyWatcher, error) {
+	sb.MethodCall(sb, "WatchApplicationConfigForUnit", unitName)
+	if err := sb.NextErr(); err != nil {
+		return nil, err
+	}
+	return sb.appWatcher, nil
+}
@@ -46,3 +46,12 @@ func (s *stubUnitDataStore) ListResources() (resource.ApplicationResources, erro
 
 	return s.ReturnListResources, nil
 }
+
+func (s *stubUnitDataStore) ShareResource(name, subordinateID string) (resource.Resource, error) {
+	s.AddCall("ShareResource", name, subordinateID)
+	if err := s.NextErr(); err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+
+	return s.ReturnGetResource, nil
+}
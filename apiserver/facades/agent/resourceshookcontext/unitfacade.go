@@ -24,6 +24,16 @@ func NewHookContextFacade(st *state.State, unit *state.Unit) (interface{}, error
 	return NewUnitFacade(&resourcesUnitDataStore{res, unit}), nil
 }
 
+// NewStateFacadeV1 provides the signature to register version 1 of
+// this resource facade.
+func NewStateFacadeV1(ctx facade.Context) (*UnitFacadeV1, error) {
+	api, err := NewStateFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &UnitFacadeV1{api}, nil
+}
+
 // NewStateFacade provides the signature to register this resource facade
 func NewStateFacade(ctx facade.Context) (*UnitFacade, error) {
 	authorizer := ctx.Auth()
@@ -80,11 +90,20 @@ func (ds *resourcesUnitDataStore) ListResources() (resource.ApplicationResources
 	return ds.resources.ListResources(ds.unit.ApplicationName())
 }
 
+// ShareResource implements UnitDataStore.
+func (ds *resourcesUnitDataStore) ShareResource(name, subordinateID string) (resource.Resource, error) {
+	return ds.resources.ShareResource(ds.unit.ApplicationName(), name, subordinateID, ds.unit.Name())
+}
+
 // UnitDataStore exposes the data storage functionality needed here.
 // All functionality is tied to the unit's application.
 type UnitDataStore interface {
 	// ListResources lists all the resources for the application.
 	ListResources() (resource.ApplicationResources, error)
+
+	// ShareResource shares the application's named resource with the
+	// identified subordinate application.
+	ShareResource(name, subordinateID string) (resource.Resource, error)
 }
 
 // NewUnitFacade returns the resources portion of the uniter's API facade.
@@ -100,6 +119,12 @@ type UnitFacade struct {
 	DataStore UnitDataStore
 }
 
+// UnitFacadeV1 is version 1 of the resources portion of the uniter's
+// API facade, which does not support sharing resources.
+type UnitFacadeV1 struct {
+	*UnitFacade
+}
+
 // GetResourceInfo returns the resource info for each of the given
 // resource names (for the implicit application). If any one is missing then
 // the corresponding result is set with errors.NotFound.
@@ -125,6 +150,27 @@ func (uf UnitFacade) GetResourceInfo(args params.ListUnitResourcesArgs) (params.
 	return r, nil
 }
 
+// ShareResources shares resources of the unit's application with
+// subordinate applications related to it, so that the subordinate units
+// can get the resources without them being uploaded again. A shared
+// resource always has the content of the application's resource.
+func (uf UnitFacade) ShareResources(args params.ShareResourcesArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		appTag, err := names.ParseApplicationTag(arg.ApplicationTag)
+		if err == nil {
+			_, err = uf.DataStore.ShareResource(arg.ResourceName, appTag.Id())
+		}
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
+// ShareResources isn't on the V1 API.
+func (*UnitFacadeV1) ShareResources(_, _ struct{}) {}
+
 func lookUpResource(name string, resources []resource.Resource) (resource.Resource, bool) {
 	for _, res := range resources {
 		if name == res.Name {
@@ -107,3 +107,32 @@ func (s *UnitFacadeSuite) TestGetResourceInfoNotFound(c *gc.C) {
 		}},
 	})
 }
+
+func (s *UnitFacadeSuite) TestShareResources(c *gc.C) {
+	store := &stubUnitDataStore{Stub: s.stub}
+	s.stub.SetErrors(nil, errors.NotFoundf(`resource "eggs"`))
+	uf := resourceshookcontext.UnitFacade{DataStore: store}
+
+	results, err := uf.ShareResources(params.ShareResourcesArgs{
+		Args: []params.ShareResourceArg{{
+			ResourceName:   "spam",
+			ApplicationTag: "application-a-subordinate",
+		}, {
+			ResourceName:   "eggs",
+			ApplicationTag: "application-a-subordinate",
+		}, {
+			ResourceName:   "spam",
+			ApplicationTag: "unit-a-subordinate-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{"ShareResource", []interface{}{"spam", "a-subordinate"}},
+		{"ShareResource", []interface{}{"eggs", "a-subordinate"}},
+	})
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `"unit-a-subordinate-0" is not a valid application tag`)
+}
@@ -90,25 +90,73 @@ func (h *RetryStrategyAPI) RetryStrategy(args params.Entities) (params.RetryStra
 		}
 		err = apiservererrors.ErrPerm
 		if canAccess(tag) {
-			// Right now the only real configurable value is ShouldRetry,
-			// which is taken from the model.
-			// The rest are hardcoded.
-			results.Results[i].Result = &params.RetryStrategy{
-				ShouldRetry:     config.AutomaticallyRetryHooks(),
-				MinRetryTime:    MinRetryTime,
-				MaxRetryTime:    MaxRetryTime,
-				JitterRetryTime: JitterRetryTime,
-				RetryTimeFactor: RetryTimeFactor,
-			}
-			err = nil
+			results.Results[i].Result, err = h.retryStrategy(tag, config.AutomaticallyRetryHooks())
 		}
 		results.Results[i].Error = apiservererrors.ServerError(err)
 	}
 	return results, nil
 }
 
-// WatchRetryStrategy watches for changes to the model. Currently we only allow
-// changes to the boolean that determines whether retries should be attempted or not.
+// retryStrategy returns the retry strategy for the unit or application
+// with the given tag. The model's automatically-retry-hooks setting is
+// used unless the application's hook retry policy overrides it; the
+// remaining values default to the hardcoded ones.
+func (h *RetryStrategyAPI) retryStrategy(tag names.Tag, shouldRetry bool) (*params.RetryStrategy, error) {
+	app, err := h.application(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	appConfig, err := app.ApplicationConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	policy, err := appConfig.HookRetryPolicy()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	strategy := &params.RetryStrategy{
+		ShouldRetry:     shouldRetry,
+		MinRetryTime:    MinRetryTime,
+		MaxRetryTime:    MaxRetryTime,
+		JitterRetryTime: JitterRetryTime,
+		RetryTimeFactor: RetryTimeFactor,
+		MaxRetries:      policy.MaxRetries,
+		Hooks:           policy.Hooks,
+	}
+	if policy.Enabled != nil {
+		strategy.ShouldRetry = *policy.Enabled
+	}
+	if policy.MaxDelay > 0 {
+		strategy.MaxRetryTime = policy.MaxDelay
+		if strategy.MinRetryTime > policy.MaxDelay {
+			strategy.MinRetryTime = policy.MaxDelay
+		}
+	}
+	return strategy, nil
+}
+
+// application returns the application for the given unit or
+// application tag.
+func (h *RetryStrategyAPI) application(tag names.Tag) (*state.Application, error) {
+	var appName string
+	switch tag := tag.(type) {
+	case names.UnitTag:
+		var err error
+		appName, err = names.UnitApplication(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	case names.ApplicationTag:
+		appName = tag.Id()
+	default:
+		return nil, errors.NotValidf("tag %q", tag)
+	}
+	return h.st.Application(appName)
+}
+
+// WatchRetryStrategy watches for changes to the model config and to the
+// config of the unit's or application's application, either of which may
+// change the retry strategy.
 func (h *RetryStrategyAPI) WatchRetryStrategy(args params.Entities) (params.NotifyWatchResults, error) {
 	results := params.NotifyWatchResults{
 		Results: make([]params.NotifyWatchResult, len(args.Entities)),
@@ -125,7 +173,16 @@ func (h *RetryStrategyAPI) WatchRetryStrategy(args params.Entities) (params.Noti
 		}
 		err = apiservererrors.ErrPerm
 		if canAccess(tag) {
-			watch := h.model.WatchForModelConfigChanges()
+			var app *state.Application
+			app, err = h.application(tag)
+			if err != nil {
+				results.Results[i].Error = apiservererrors.ServerError(err)
+				continue
+			}
+			watch := common.NewMultiNotifyWatcher(
+				h.model.WatchForModelConfigChanges(),
+				app.WatchApplicationConfig(),
+			)
 			// Consume the initial event. Technically, API calls to Watch
 			// 'transmit' the initial event in the Watch response. But
 			// NotifyWatchers have no state to transmit.
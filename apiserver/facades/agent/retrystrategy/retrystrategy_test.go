@@ -5,13 +5,17 @@
 package retrystrategy_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/environschema.v1"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facades/agent/retrystrategy"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/application"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
@@ -131,6 +135,41 @@ func (s *retryStrategySuite) assertRetryStrategy(c *gc.C, tag string) {
 	c.Assert(r.Results[0].Result, jc.DeepEquals, expected)
 }
 
+func (s *retryStrategySuite) TestRetryStrategyApplicationPolicy(c *gc.C) {
+	s.setHookRetryPolicy(c, application.ConfigAttributes{
+		"hook-retry":           false,
+		"hook-retry-max":       3,
+		"hook-retry-max-delay": "2s",
+		"hook-retry-hooks":     "install config-changed",
+	})
+	args := params.Entities{Entities: []params.Entity{{Tag: s.unit.Tag().String()}}}
+	r, err := s.strategy.RetryStrategy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Results, gc.HasLen, 1)
+	c.Assert(r.Results[0].Error, gc.IsNil)
+	c.Assert(r.Results[0].Result, jc.DeepEquals, &params.RetryStrategy{
+		ShouldRetry:     false,
+		MinRetryTime:    2 * time.Second,
+		MaxRetryTime:    2 * time.Second,
+		JitterRetryTime: retrystrategy.JitterRetryTime,
+		RetryTimeFactor: retrystrategy.RetryTimeFactor,
+		MaxRetries:      3,
+		Hooks:           []string{"install", "config-changed"},
+	})
+}
+
+func (s *retryStrategySuite) setHookRetryPolicy(c *gc.C, attrs application.ConfigAttributes) {
+	app, err := s.unit.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.UpdateApplicationConfig(attrs, nil, environschema.Fields{
+		"hook-retry":           {Type: environschema.Tbool},
+		"hook-retry-max":       {Type: environschema.Tint},
+		"hook-retry-max-delay": {Type: environschema.Tstring},
+		"hook-retry-hooks":     {Type: environschema.Tstring},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *retryStrategySuite) setRetryStrategy(c *gc.C, automaticallyRetryHooks bool) {
 	err := s.Model.UpdateModelConfig(map[string]interface{}{"automatically-retry-hooks": automaticallyRetryHooks}, nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -193,4 +232,7 @@ func (s *retryStrategySuite) TestWatchRetryStrategy(c *gc.C) {
 	s.setRetryStrategy(c, false)
 	c.Assert(err, jc.ErrorIsNil)
 	wc.AssertOneChange()
+
+	s.setHookRetryPolicy(c, application.ConfigAttributes{"hook-retry-max": 3})
+	wc.AssertOneChange()
 }
@@ -0,0 +1,36 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sandboxprofiles
+
+import (
+	"github.com/juju/errors"
+
+	corecharm "github.com/juju/juju/core/charm"
+	"github.com/juju/juju/state"
+)
+
+// Backend provides access to the machines whose sandbox profiles are
+// applied.
+type Backend interface {
+	Machine(id string) (Machine, error)
+}
+
+// Machine provides the sandbox profiles to be applied to a machine.
+type Machine interface {
+	SandboxProfiles() (map[string]*corecharm.SandboxProfile, error)
+	WatchSandboxProfiles() state.NotifyWatcher
+}
+
+type backendShim struct {
+	st *state.State
+}
+
+// Machine implements Backend.
+func (b backendShim) Machine(id string) (Machine, error) {
+	m, err := b.st.Machine(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return m, nil
+}
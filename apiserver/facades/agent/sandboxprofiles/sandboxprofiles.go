@@ -0,0 +1,92 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package sandboxprofiles provides the SandboxProfiles facade, used by
+// machine agents to apply the sandboxing declared by the charms of the
+// units on their machines.
+package sandboxprofiles
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/watcher"
+)
+
+// API implements the SandboxProfiles facade.
+type API struct {
+	backend   Backend
+	resources facade.Resources
+	machineId string
+}
+
+// NewFacade is used for API registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(backendShim{st: ctx.State()}, ctx.Resources(), ctx.Auth())
+}
+
+// NewAPI returns a new SandboxProfiles API facade.
+func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthMachineAgent() {
+		return nil, apiservererrors.ErrPerm
+	}
+	tag, ok := authorizer.GetAuthTag().(names.MachineTag)
+	if !ok {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &API{
+		backend:   backend,
+		resources: resources,
+		machineId: tag.Id(),
+	}, nil
+}
+
+// WatchSandboxProfiles returns a watcher which notifies when the
+// sandbox profiles to be applied to the calling agent's machine may
+// have changed.
+func (api *API) WatchSandboxProfiles() (params.NotifyWatchResult, error) {
+	m, err := api.backend.Machine(api.machineId)
+	if err != nil {
+		return params.NotifyWatchResult{}, errors.Trace(err)
+	}
+	w := m.WatchSandboxProfiles()
+	if _, ok := <-w.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: api.resources.Register(w),
+		}, nil
+	}
+	return params.NotifyWatchResult{}, watcher.EnsureErr(w)
+}
+
+// SandboxProfiles returns the sandbox profiles to be applied to the
+// calling agent's machine, one for each application with units there
+// whose charm declares one.
+func (api *API) SandboxProfiles() params.SandboxProfilesResult {
+	m, err := api.backend.Machine(api.machineId)
+	if err != nil {
+		return params.SandboxProfilesResult{Error: apiservererrors.ServerError(err)}
+	}
+	profiles, err := m.SandboxProfiles()
+	if err != nil {
+		return params.SandboxProfilesResult{Error: apiservererrors.ServerError(err)}
+	}
+	result := params.SandboxProfilesResult{
+		Profiles: make([]params.SandboxProfile, 0, len(profiles)),
+	}
+	for appName, profile := range profiles {
+		result.Profiles = append(result.Profiles, params.SandboxProfile{
+			Application:      appName,
+			Sysctls:          profile.Sysctls,
+			AppArmorProfiles: profile.AppArmorProfiles,
+		})
+	}
+	sort.Slice(result.Profiles, func(i, j int) bool {
+		return result.Profiles[i].Application < result.Profiles[j].Application
+	})
+	return result
+}
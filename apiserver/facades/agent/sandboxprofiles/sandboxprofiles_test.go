@@ -0,0 +1,115 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sandboxprofiles_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/agent/sandboxprofiles"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	corecharm "github.com/juju/juju/core/charm"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type sandboxProfilesSuite struct {
+	testing.IsolationSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+	resources  *common.Resources
+	backend    *mockBackend
+}
+
+var _ = gc.Suite(&sandboxProfilesSuite{})
+
+func (s *sandboxProfilesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	s.backend = &mockBackend{
+		machines: map[string]*mockMachine{
+			"0": {profiles: map[string]*corecharm.SandboxProfile{
+				"redis": {Sysctls: map[string]string{"vm.overcommit_memory": "1"}},
+				"nginx": {AppArmorProfiles: map[string]string{"worker": "profile worker {}"}},
+			}},
+		},
+	}
+}
+
+func (s *sandboxProfilesSuite) newAPI(c *gc.C) *sandboxprofiles.API {
+	api, err := sandboxprofiles.NewAPI(s.backend, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *sandboxProfilesSuite) TestNewAPIRequiresMachineAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewUnitTag("redis/0")
+	_, err := sandboxprofiles.NewAPI(s.backend, s.resources, s.authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *sandboxProfilesSuite) TestWatchSandboxProfiles(c *gc.C) {
+	changes := make(chan struct{}, 1)
+	changes <- struct{}{}
+	w := statetesting.NewMockNotifyWatcher(changes)
+	s.backend.machines["0"].watcher = w
+
+	result, err := s.newAPI(c).WatchSandboxProfiles()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+	c.Assert(s.resources.Get("1"), gc.Equals, w)
+}
+
+func (s *sandboxProfilesSuite) TestSandboxProfiles(c *gc.C) {
+	result := s.newAPI(c).SandboxProfiles()
+	c.Assert(result, jc.DeepEquals, params.SandboxProfilesResult{
+		Profiles: []params.SandboxProfile{{
+			Application:      "nginx",
+			AppArmorProfiles: map[string]string{"worker": "profile worker {}"},
+		}, {
+			Application: "redis",
+			Sysctls:     map[string]string{"vm.overcommit_memory": "1"},
+		}},
+	})
+}
+
+func (s *sandboxProfilesSuite) TestSandboxProfilesMachineNotFound(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("1")
+	result := s.newAPI(c).SandboxProfiles()
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+type mockBackend struct {
+	machines map[string]*mockMachine
+}
+
+func (b *mockBackend) Machine(id string) (sandboxprofiles.Machine, error) {
+	m, ok := b.machines[id]
+	if !ok {
+		return nil, errors.NotFoundf("machine %s", id)
+	}
+	return m, nil
+}
+
+type mockMachine struct {
+	profiles map[string]*corecharm.SandboxProfile
+	watcher  state.NotifyWatcher
+}
+
+func (m *mockMachine) SandboxProfiles() (map[string]*corecharm.SandboxProfile, error) {
+	return m.profiles, nil
+}
+
+func (m *mockMachine) WatchSandboxProfiles() state.NotifyWatcher {
+	return m.watcher
+}
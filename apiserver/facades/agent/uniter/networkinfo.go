@@ -51,6 +51,24 @@ type NetworkInfoBase struct {
 	app           *state.Application
 	defaultEgress []string
 	bindings      map[string]string
+
+	// egressNATAddress is the address of the NAT gateway through which
+	// traffic from the unit egresses, if one has been declared for the
+	// unit's machine or the model. It is used as the default egress for
+	// cross-model relations.
+	egressNATAddress string
+
+	// addressUnit is the unit whose addresses are used for the unit's
+	// relations. For a subordinate, this is its principal unit, so
+	// that offered subordinate endpoints resolve to addresses that
+	// consuming models can reach.
+	addressUnit *state.Unit
+
+	// crossModelIngressAddresses are the ingress addresses pinned by
+	// the operator for cross-model relations, keyed by endpoint name.
+	// They replace the automatically selected unit addresses, for
+	// example when the units are behind NAT.
+	crossModelIngressAddresses map[string]string
 }
 
 // NewNetworkInfo initialises and returns a new NetworkInfo
@@ -85,6 +103,13 @@ func NewNetworkInfoForStrategy(
 		return nil, errors.Trace(err)
 	}
 
+	addressUnit := unit
+	if principalName, ok := unit.PrincipalName(); ok {
+		if addressUnit, err = st.Unit(principalName); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
 	// Get the ID for the model's configured default space name.
 	// We don't need to hit the DB if it is unset or is the alpha space.
 	// TODO (manadart 2020-12-07): For Juju 3.0 this config item should be
@@ -121,15 +146,28 @@ func NewNetworkInfoForStrategy(
 		allBindings[ep] = space
 	}
 
+	appCfg, err := app.ApplicationConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	crossModelIngress, err := appCfg.CrossModelIngressAddresses()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	base := &NetworkInfoBase{
 		st:            st,
 		unit:          unit,
+		addressUnit:   addressUnit,
 		app:           app,
 		bindings:      allBindings,
 		defaultEgress: cfg.EgressSubnets(),
 		retryFactory:  retryFactory,
 		lookupHost:    lookupHost,
+
+		crossModelIngressAddresses: crossModelIngress,
 	}
+	base.egressNATAddress, _ = cfg.EgressNATAddress()
 
 	var netInfo NetworkInfo
 	if unit.ShouldBeAssigned() {
@@ -196,7 +234,7 @@ func (n *NetworkInfoBase) maybeGetUnitAddress(
 		return nil, nil
 	}
 
-	address, err := n.pollForAddress(n.unit.PublicAddress)
+	address, err := n.pollForAddress(n.addressUnit.PublicAddress)
 	if err != nil {
 		logger.Warningf("no public address for unit %q in cross model relation %q", n.unit.Name(), rel)
 	} else if address.Value != "" {
@@ -208,7 +246,7 @@ func (n *NetworkInfoBase) maybeGetUnitAddress(
 	}
 
 	logger.Warningf("attempting fallback to private address")
-	address, err = n.pollForAddress(n.unit.PrivateAddress)
+	address, err = n.pollForAddress(n.addressUnit.PrivateAddress)
 	if err != nil {
 		logger.Warningf("no private address for unit %q in relation %q", n.unit.Name(), rel)
 	} else if address.Value != "" {
@@ -218,8 +256,25 @@ func (n *NetworkInfoBase) maybeGetUnitAddress(
 	return nil, nil
 }
 
+// maybeGetCrossModelIngress returns the ingress address pinned for the
+// endpoint if the input relation is cross-model and one is configured.
+func (n *NetworkInfoBase) maybeGetCrossModelIngress(
+	endpoint string, rel *state.Relation,
+) (network.SpaceAddresses, error) {
+	addr, ok := n.crossModelIngressAddresses[endpoint]
+	if !ok {
+		return nil, nil
+	}
+	_, crossModel, err := rel.RemoteApplication()
+	if err != nil || !crossModel {
+		return nil, errors.Trace(err)
+	}
+	return network.NewSpaceAddresses(addr), nil
+}
+
 // getEgressForRelation returns any explicitly defined egress subnets
-// for the relation, falling back to configured model egress.
+// for the relation. For cross-model relations, a declared egress NAT
+// address is used next. Otherwise it falls back to configured model egress.
 // If there are none, it attempts to resolve a subnet from the input
 // ingress addresses.
 func (n *NetworkInfoBase) getEgressForRelation(
@@ -239,6 +294,16 @@ func (n *NetworkInfoBase) getEgressForRelation(
 		}
 	}
 
+	if n.egressNATAddress != "" {
+		_, crossModel, err := rel.RemoteApplication()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if crossModel {
+			return subnetsForAddresses([]string{n.egressNATAddress}), nil
+		}
+	}
+
 	if len(n.defaultEgress) > 0 {
 		return n.defaultEgress, nil
 	}
@@ -317,14 +382,59 @@ func (n *NetworkInfoBase) resolveHostAddress(hostName string) string {
 }
 
 // subnetsForAddresses wraps the core/network method of the same name,
-// limiting the return to container at most one result.
-// TODO (manadart 2020-11-19): This preserves prior behaviour,
-// but should we just return them all?
+// limiting the return to at most one result for each address family,
+// so that dual-stack units get both IPv4 and IPv6 egress without
+// listing every subnet of a multi-homed machine.
 func subnetsForAddresses(addrs []string) []string {
-	if egress := network.SubnetsForAddresses(addrs); len(egress) > 0 {
-		return egress[:1]
+	var egress []string
+	families := set.NewStrings()
+	for _, subnet := range network.SubnetsForAddresses(addrs) {
+		family := string(addressFamily(subnet))
+		if families.Contains(family) {
+			continue
+		}
+		families.Add(family)
+		egress = append(egress, subnet)
 	}
-	return nil
+	return egress
+}
+
+// preferredAddressFamily returns the address family to list first in
+// NetworkInfo results for the input requested family.
+// An empty family indicates no preference.
+func preferredAddressFamily(requested string) (network.AddressType, error) {
+	switch family := network.AddressType(requested); family {
+	case "", network.IPv4Address, network.IPv6Address:
+		return family, nil
+	default:
+		return "", errors.NotValidf("preferred address family %q", requested)
+	}
+}
+
+// orderByAddressFamily returns the input IP addresses or CIDRs with those
+// of the input family first, otherwise preserving their order.
+// If no family is preferred, the input is returned unchanged.
+func orderByAddressFamily(values []string, family network.AddressType) []string {
+	if family == "" {
+		return values
+	}
+	var preferred, others []string
+	for _, v := range values {
+		if addressFamily(v) == family {
+			preferred = append(preferred, v)
+		} else {
+			others = append(others, v)
+		}
+	}
+	return append(preferred, others...)
+}
+
+// addressFamily returns the type of the input IP address or CIDR.
+func addressFamily(value string) network.AddressType {
+	if ip, _, err := net.ParseCIDR(value); err == nil {
+		value = ip.String()
+	}
+	return network.DeriveAddressType(value)
 }
 
 func (n *NetworkInfoBase) pollForAddress(
@@ -350,6 +460,9 @@ func uniqueNetworkInfoResults(info params.NetworkInfoResults) params.NetworkInfo
 		}
 		res.IngressAddresses = uniqueStringsPreservingOrder(res.IngressAddresses)
 		res.EgressSubnets = uniqueStringsPreservingOrder(res.EgressSubnets)
+		if len(res.ShadowAddresses) > 0 {
+			res.ShadowAddresses = uniqueStringsPreservingOrder(res.ShadowAddresses)
+		}
 		for infoIdx, info := range res.Info {
 			res.Info[infoIdx].Addresses = uniqueInterfaceAddresses(info.Addresses)
 		}
@@ -359,6 +472,37 @@ func uniqueNetworkInfoResults(info params.NetworkInfoResults) params.NetworkInfo
 	return info
 }
 
+// networkInfoSchemaVersion returns the NetworkInfoResult schema version
+// to render for the input requested version, which may be zero to
+// indicate the latest.
+func networkInfoSchemaVersion(requested int) (int, error) {
+	if requested == 0 {
+		return params.NetworkInfoSchemaLatest, nil
+	}
+	if requested < params.NetworkInfoSchemaV1 || requested > params.NetworkInfoSchemaLatest {
+		return 0, errors.NotSupportedf("network info schema version %d", requested)
+	}
+	return requested, nil
+}
+
+// renderNetworkInfoSchema returns the input results with any fields not
+// included in the input schema version removed.
+// Fields added to NetworkInfoResult after NetworkInfoSchemaV1 must be
+// cleared here for the versions that precede them.
+func renderNetworkInfoSchema(info params.NetworkInfoResults, version int) params.NetworkInfoResults {
+	rendered := params.NetworkInfoResults{
+		Results:       make(map[string]params.NetworkInfoResult, len(info.Results)),
+		SchemaVersion: version,
+	}
+	for endpoint, result := range info.Results {
+		if version < params.NetworkInfoSchemaV2 {
+			result.ShadowAddresses = nil
+		}
+		rendered.Results[endpoint] = result
+	}
+	return rendered
+}
+
 func uniqueStringsPreservingOrder(values []string) []string {
 	// Ideally, we would use a set.Strings(values).Values() here but since
 	// it does not preserve the insertion order we need to do this manually.
@@ -4,9 +4,12 @@
 package uniter
 
 import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/network"
 )
 
 type networkInfoSuite struct {
@@ -137,3 +140,95 @@ func (s *networkInfoSuite) TestUniqueNetworkInfoResults(c *gc.C) {
 	filteredRes := uniqueNetworkInfoResults(resWithDups)
 	c.Assert(filteredRes, gc.DeepEquals, expRes)
 }
+
+func (s *networkInfoSuite) TestNetworkInfoSchemaVersion(c *gc.C) {
+	version, err := networkInfoSchemaVersion(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(version, gc.Equals, params.NetworkInfoSchemaLatest)
+
+	version, err = networkInfoSchemaVersion(params.NetworkInfoSchemaV1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(version, gc.Equals, params.NetworkInfoSchemaV1)
+
+	for _, requested := range []int{-1, params.NetworkInfoSchemaLatest + 1} {
+		_, err = networkInfoSchemaVersion(requested)
+		c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	}
+}
+
+func (s *networkInfoSuite) TestRenderNetworkInfoSchema(c *gc.C) {
+	info := params.NetworkInfoResults{
+		Results: map[string]params.NetworkInfoResult{
+			"ep0": {
+				Info: []params.NetworkInfo{{
+					InterfaceName: "eth0",
+					Addresses:     []params.InterfaceAddress{{Hostname: "foo", Address: "10.0.0.1", CIDR: "10.0.0.0/24"}},
+				}},
+				IngressAddresses: []string{"10.0.0.1"},
+			},
+		},
+	}
+
+	rendered := renderNetworkInfoSchema(info, params.NetworkInfoSchemaV1)
+	c.Check(rendered.SchemaVersion, gc.Equals, params.NetworkInfoSchemaV1)
+	c.Check(rendered.Results, gc.DeepEquals, info.Results)
+}
+
+func (s *networkInfoSuite) TestRenderNetworkInfoSchemaShadowAddresses(c *gc.C) {
+	info := params.NetworkInfoResults{
+		Results: map[string]params.NetworkInfoResult{
+			"ep0": {
+				Info: []params.NetworkInfo{{
+					InterfaceName: "eth0",
+					Addresses:     []params.InterfaceAddress{{Address: "10.0.0.1", CIDR: "10.0.0.0/24"}},
+				}},
+				IngressAddresses: []string{"10.0.0.1"},
+				ShadowAddresses:  []string{"54.32.1.2"},
+			},
+		},
+	}
+
+	rendered := renderNetworkInfoSchema(info, params.NetworkInfoSchemaV2)
+	c.Check(rendered.SchemaVersion, gc.Equals, params.NetworkInfoSchemaV2)
+	c.Check(rendered.Results["ep0"].ShadowAddresses, gc.DeepEquals, []string{"54.32.1.2"})
+
+	// Shadow addresses are not part of the first schema.
+	rendered = renderNetworkInfoSchema(info, params.NetworkInfoSchemaV1)
+	c.Check(rendered.SchemaVersion, gc.Equals, params.NetworkInfoSchemaV1)
+	c.Check(rendered.Results["ep0"].ShadowAddresses, gc.IsNil)
+	c.Check(rendered.Results["ep0"].IngressAddresses, gc.DeepEquals, []string{"10.0.0.1"})
+
+	// The input results are left untouched.
+	c.Check(info.Results["ep0"].ShadowAddresses, gc.DeepEquals, []string{"54.32.1.2"})
+}
+
+func (s *networkInfoSuite) TestPreferredAddressFamily(c *gc.C) {
+	for _, requested := range []string{"", "ipv4", "ipv6"} {
+		family, err := preferredAddressFamily(requested)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(family, gc.Equals, network.AddressType(requested))
+	}
+
+	_, err := preferredAddressFamily("hostname")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *networkInfoSuite) TestOrderByAddressFamily(c *gc.C) {
+	values := []string{"10.0.0.1", "2001:db8::1", "10.0.0.0/24", "2001:db8::/64"}
+
+	c.Check(orderByAddressFamily(values, ""), gc.DeepEquals, values)
+	c.Check(orderByAddressFamily(values, network.IPv4Address), gc.DeepEquals, []string{
+		"10.0.0.1", "10.0.0.0/24", "2001:db8::1", "2001:db8::/64",
+	})
+	c.Check(orderByAddressFamily(values, network.IPv6Address), gc.DeepEquals, []string{
+		"2001:db8::1", "2001:db8::/64", "10.0.0.1", "10.0.0.0/24",
+	})
+}
+
+func (s *networkInfoSuite) TestSubnetsForAddressesDualStack(c *gc.C) {
+	addrs := []string{"10.0.0.1", "10.0.0.2", "2001:db8::1", "2001:db8::2"}
+	c.Check(subnetsForAddresses(addrs), gc.DeepEquals, []string{"10.0.0.1/32", "2001:db8::1/128"})
+
+	addrs = []string{"2001:db8::1", "10.0.0.1"}
+	c.Check(subnetsForAddresses(addrs), gc.DeepEquals, []string{"2001:db8::1/128", "10.0.0.1/32"})
+}
@@ -15,11 +15,13 @@ import (
 	"github.com/juju/retry"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/environschema.v1"
 
 	"github.com/juju/juju/apiserver/facades/agent/uniter"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas/kubernetes/provider"
 	k8stesting "github.com/juju/juju/caas/kubernetes/provider/testing"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
@@ -73,6 +75,40 @@ func (s *networkInfoSuite) TestNetworksForRelation(c *gc.C) {
 	c.Assert(egress, gc.DeepEquals, []string{"10.2.3.4/32"})
 }
 
+func (s *networkInfoSuite) setExternalLBAddresses(c *gc.C, app *state.Application, value string) {
+	fields := environschema.Fields{
+		coreapplication.ExternalLBAddressesConfigKey: environschema.Attr{Type: environschema.Tstring},
+	}
+	err := app.UpdateApplicationConfig(coreapplication.ConfigAttributes{
+		coreapplication.ExternalLBAddressesConfigKey: value,
+	}, nil, fields, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *networkInfoSuite) TestNetworksForRelationExternalLBAddress(c *gc.C) {
+	prr := s.newProReqRelation(c, charm.ScopeGlobal)
+	s.setExternalLBAddresses(c, prr.papp, "server=10.2.3.100")
+	err := prr.pu0.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := prr.pu0.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.SetProviderAddresses(
+		network.NewScopedSpaceAddress("10.2.3.4", network.ScopeCloudLocal),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The load balancer's address is advertised for ingress,
+	// but traffic still egresses from the unit's machine.
+	netInfo := s.newNetworkInfo(c, prr.pu0.UnitTag(), nil, nil)
+	_, ingress, egress, err := netInfo.NetworksForRelation("server", prr.rel, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ingress, gc.DeepEquals, network.NewSpaceAddresses("10.2.3.100"))
+	c.Assert(egress, gc.DeepEquals, []string{"10.2.3.4/32"})
+}
+
 func (s *networkInfoSuite) addDevicesWithAddresses(c *gc.C, machine *state.Machine, addresses ...string) {
 	for _, address := range addresses {
 		name := fmt.Sprintf("e%x", rand.Int31())
@@ -159,6 +195,143 @@ func (s *networkInfoSuite) TestProcessAPIRequestForBinding(c *gc.C) {
 	c.Check(ingress[0], gc.Equals, "100.2.3.4")
 }
 
+func (s *networkInfoSuite) TestProcessAPIRequestForBindingDualStack(c *gc.C) {
+	for _, cidr := range []string{"10.2.0.0/16", "2001:db8::/64"} {
+		_, err := s.State.AddSubnet(network.SubnetInfo{
+			CIDR:    cidr,
+			SpaceID: network.AlphaSpaceId,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	bindings := map[string]string{
+		"":             network.AlphaSpaceName,
+		"server-admin": network.AlphaSpaceName,
+	}
+	app := s.AddTestingApplicationWithBindings(c, "mysql", s.AddTestingCharm(c, "mysql"), bindings)
+
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.AssignToNewMachine(), jc.ErrorIsNil)
+
+	id, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.SetProviderAddresses(network.NewSpaceAddress("10.2.3.4"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.addDevicesWithAddresses(c, machine, "10.2.3.4/16", "2001:db8::4/64")
+
+	netInfo := s.newNetworkInfo(c, unit.UnitTag(), nil, nil)
+
+	// Both address families are returned, IPv4 first by default.
+	result, err := netInfo.ProcessAPIRequest(params.NetworkInfoParams{
+		Unit:      unit.UnitTag().String(),
+		Endpoints: []string{"server-admin"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	binding := result.Results["server-admin"]
+	c.Assert(binding.Error, gc.IsNil)
+	c.Check(binding.IngressAddresses, gc.DeepEquals, []string{"10.2.3.4", "2001:db8::4"})
+	c.Check(binding.EgressSubnets, gc.DeepEquals, []string{"10.2.3.4/32", "2001:db8::4/128"})
+
+	// IPv6 is listed first when preferred.
+	result, err = netInfo.ProcessAPIRequest(params.NetworkInfoParams{
+		Unit:                   unit.UnitTag().String(),
+		Endpoints:              []string{"server-admin"},
+		PreferredAddressFamily: "ipv6",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	binding = result.Results["server-admin"]
+	c.Assert(binding.Error, gc.IsNil)
+	c.Check(binding.IngressAddresses, gc.DeepEquals, []string{"2001:db8::4", "10.2.3.4"})
+	c.Check(binding.EgressSubnets, gc.DeepEquals, []string{"2001:db8::4/128", "10.2.3.4/32"})
+}
+
+func (s *networkInfoSuite) TestProcessAPIRequestForBindingExternalLBAddress(c *gc.C) {
+	_, err := s.State.AddSubnet(network.SubnetInfo{
+		CIDR:    "10.2.0.0/16",
+		SpaceID: network.AlphaSpaceId,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	app := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	s.setExternalLBAddresses(c, app, "server-admin=10.2.3.100")
+
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.AssignToNewMachine(), jc.ErrorIsNil)
+
+	id, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.SetProviderAddresses(network.NewSpaceAddress("10.2.3.4/16"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.addDevicesWithAddresses(c, machine, "10.2.3.4/16")
+
+	netInfo := s.newNetworkInfo(c, unit.UnitTag(), nil, nil)
+	result, err := netInfo.ProcessAPIRequest(params.NetworkInfoParams{
+		Unit:      unit.UnitTag().String(),
+		Endpoints: []string{"server", "server-admin"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	res := result.Results
+	c.Assert(res, gc.HasLen, 2)
+
+	// Only the endpoint behind the load balancer advertises its address.
+	c.Check(res["server"].IngressAddresses, gc.DeepEquals, []string{"10.2.3.4"})
+	c.Check(res["server-admin"].IngressAddresses, gc.DeepEquals, []string{"10.2.3.100"})
+	c.Check(res["server-admin"].EgressSubnets, gc.DeepEquals, []string{"10.2.3.4/32"})
+	c.Check(res["server-admin"].Info, gc.DeepEquals, res["server"].Info)
+}
+
+func (s *networkInfoSuite) TestProcessAPIRequestForBindingShadowAddresses(c *gc.C) {
+	_, err := s.State.AddSubnet(network.SubnetInfo{
+		CIDR:    "10.2.0.0/16",
+		SpaceID: network.AlphaSpaceId,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	app := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.AssignToNewMachine(), jc.ErrorIsNil)
+
+	id, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The public address is NATed to the machine by the provider,
+	// so it is not configured on any of the machine's devices.
+	err = machine.SetProviderAddresses(
+		network.NewScopedSpaceAddress("10.2.3.4", network.ScopeCloudLocal),
+		network.NewScopedSpaceAddress("54.32.1.2", network.ScopePublic),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.addDevicesWithAddresses(c, machine, "10.2.3.4/16")
+
+	netInfo := s.newNetworkInfo(c, unit.UnitTag(), nil, nil)
+	result, err := netInfo.ProcessAPIRequest(params.NetworkInfoParams{
+		Unit:      unit.UnitTag().String(),
+		Endpoints: []string{"server"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	res := result.Results["server"]
+	c.Check(res.Error, gc.IsNil)
+	c.Check(res.ShadowAddresses, gc.DeepEquals, []string{"54.32.1.2"})
+	c.Assert(res.Info, gc.HasLen, 1)
+	c.Assert(res.Info[0].Addresses, gc.HasLen, 1)
+	c.Check(res.Info[0].Addresses[0].Address, gc.Equals, "10.2.3.4")
+}
+
 func (s *networkInfoSuite) TestAPIRequestForRelationIAASHostNameIngressNoEgress(c *gc.C) {
 	prr := s.newProReqRelation(c, charm.ScopeGlobal)
 	err := prr.pu0.AssignToNewMachine()
@@ -327,6 +500,164 @@ func (s *networkInfoSuite) TestNetworksForRelationRemoteRelation(c *gc.C) {
 	c.Assert(egress, gc.DeepEquals, []string{"4.3.2.1/32"})
 }
 
+func (s *networkInfoSuite) TestNetworksForRelationRemoteRelationEgressNATAddress(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"egress-subnets":     "192.168.0.0/16",
+		"egress-nat-address": "198.51.100.1",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	prr := s.newRemoteProReqRelation(c)
+	err = prr.ru0.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := prr.ru0.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.SetProviderAddresses(
+		network.NewScopedSpaceAddress("1.2.3.4", network.ScopeCloudLocal),
+		network.NewScopedSpaceAddress("4.3.2.1", network.ScopePublic),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The model's NAT address is used over its egress subnets.
+	netInfo := s.newNetworkInfo(c, prr.ru0.UnitTag(), nil, nil)
+	_, _, egress, err := netInfo.NetworksForRelation("", prr.rel, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(egress, gc.DeepEquals, []string{"198.51.100.1/32"})
+
+	// One declared for the machine is used over the model's.
+	err = machine.SetEgressNATAddress("203.0.113.10")
+	c.Assert(err, jc.ErrorIsNil)
+
+	netInfo = s.newNetworkInfo(c, prr.ru0.UnitTag(), nil, nil)
+	_, _, egress, err = netInfo.NetworksForRelation("", prr.rel, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(egress, gc.DeepEquals, []string{"203.0.113.10/32"})
+}
+
+func (s *networkInfoSuite) TestNetworksForRelationEgressNATAddressNotCrossModel(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"egress-nat-address": "198.51.100.1",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	prr := s.newProReqRelation(c, charm.ScopeGlobal)
+	err = prr.pu0.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := prr.pu0.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.SetProviderAddresses(network.NewScopedSpaceAddress("1.2.3.4", network.ScopeCloudLocal))
+	c.Assert(err, jc.ErrorIsNil)
+
+	netInfo := s.newNetworkInfo(c, prr.pu0.UnitTag(), nil, nil)
+	_, _, egress, err := netInfo.NetworksForRelation("", prr.rel, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(egress, gc.DeepEquals, []string{"1.2.3.4/32"})
+}
+
+func (s *networkInfoSuite) setCrossModelIngressAddresses(c *gc.C, app *state.Application, value string) {
+	fields := environschema.Fields{
+		coreapplication.CrossModelIngressAddressesConfigKey: environschema.Attr{Type: environschema.Tstring},
+	}
+	err := app.UpdateApplicationConfig(coreapplication.ConfigAttributes{
+		coreapplication.CrossModelIngressAddressesConfigKey: value,
+	}, nil, fields, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *networkInfoSuite) TestNetworksForRelationRemoteRelationCrossModelIngressAddress(c *gc.C) {
+	prr := s.newRemoteProReqRelation(c)
+	s.setCrossModelIngressAddresses(c, prr.rapp, "db=203.0.113.50")
+	err := prr.ru0.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := prr.ru0.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.SetProviderAddresses(
+		network.NewScopedSpaceAddress("1.2.3.4", network.ScopeCloudLocal),
+		network.NewScopedSpaceAddress("4.3.2.1", network.ScopePublic),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The pinned address is advertised instead of the public address,
+	// but egress is still derived from the unit's address.
+	netInfo := s.newNetworkInfo(c, prr.ru0.UnitTag(), nil, nil)
+	_, ingress, egress, err := netInfo.NetworksForRelation("db", prr.rel, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ingress, gc.DeepEquals, network.NewSpaceAddresses("203.0.113.50"))
+	c.Assert(egress, gc.DeepEquals, []string{"4.3.2.1/32"})
+}
+
+func (s *networkInfoSuite) TestNetworksForRelationCrossModelIngressAddressNotCrossModel(c *gc.C) {
+	prr := s.newProReqRelation(c, charm.ScopeGlobal)
+	s.setCrossModelIngressAddresses(c, prr.papp, "server=203.0.113.50")
+	err := prr.pu0.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := prr.pu0.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.SetProviderAddresses(network.NewScopedSpaceAddress("1.2.3.4", network.ScopeCloudLocal))
+	c.Assert(err, jc.ErrorIsNil)
+
+	netInfo := s.newNetworkInfo(c, prr.pu0.UnitTag(), nil, nil)
+	_, ingress, _, err := netInfo.NetworksForRelation("server", prr.rel, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ingress, gc.DeepEquals,
+		network.SpaceAddresses{network.NewScopedSpaceAddress("1.2.3.4", network.ScopeCloudLocal)})
+}
+
+func (s *networkInfoSuite) TestNetworksForRelationRemoteRelationSubordinate(c *gc.C) {
+	// The logging subordinates are created before their principals
+	// are assigned, so addresses must be resolved via the principal.
+	prr := s.newProReqRelation(c, charm.ScopeContainer)
+	err := prr.pu0.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := prr.pu0.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.SetProviderAddresses(
+		network.NewScopedSpaceAddress("1.2.3.4", network.ScopeCloudLocal),
+		network.NewScopedSpaceAddress("4.3.2.1", network.ScopePublic),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Relate the offered logging application to a consuming model.
+	_, err = s.State.AddRemoteApplication(state.AddRemoteApplicationParams{
+		Name:        "consumer",
+		SourceModel: coretesting.ModelTag,
+		Endpoints: []charm.Relation{{
+			Interface: "logging",
+			Name:      "logs",
+			Role:      charm.RoleRequirer,
+			Scope:     charm.ScopeGlobal,
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	eps, err := s.State.InferEndpoints("consumer", "logging")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	netInfo := s.newNetworkInfo(c, prr.ru0.UnitTag(), nil, nil)
+	boundSpace, ingress, egress, err := netInfo.NetworksForRelation("logging-client", rel, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(boundSpace, gc.Equals, network.AlphaSpaceId)
+	c.Assert(ingress, gc.DeepEquals,
+		network.SpaceAddresses{network.NewScopedSpaceAddress("4.3.2.1", network.ScopePublic)})
+	c.Assert(egress, gc.DeepEquals, []string{"4.3.2.1/32"})
+}
+
 func (s *networkInfoSuite) TestNetworksForRelationRemoteRelationNoPublicAddr(c *gc.C) {
 	prr := s.newRemoteProReqRelation(c)
 	err := prr.ru0.AssignToNewMachine()
@@ -39,6 +39,7 @@ func newNetworkInfoCAAS(base *NetworkInfoBase) (*NetworkInfoCAAS, error) {
 // ProcessAPIRequest handles a request to the uniter API NetworkInfo method.
 func (n *NetworkInfoCAAS) ProcessAPIRequest(args params.NetworkInfoParams) (params.NetworkInfoResults, error) {
 	validEndpoints, result := n.validateEndpoints(args.Endpoints)
+	family := network.AddressType(args.PreferredAddressFamily)
 
 	// We record the interface addresses as the machine local ones.
 	// These are used later as the binding addresses.
@@ -54,10 +55,13 @@ func (n *NetworkInfoCAAS) ProcessAPIRequest(args params.NetworkInfoParams) (para
 		}
 	}
 
+	defaultIngressAddresses = orderByAddressFamily(defaultIngressAddresses, family)
+
 	defaultEgress := n.defaultEgress
 	if len(defaultEgress) == 0 {
 		defaultEgress = subnetsForAddresses(defaultIngressAddresses)
 	}
+	defaultEgress = orderByAddressFamily(defaultEgress, family)
 
 	// If we are working in a relation context,
 	// get the network information for the relation
@@ -70,8 +74,8 @@ func (n *NetworkInfoCAAS) ProcessAPIRequest(args params.NetworkInfoParams) (para
 
 		result.Results[endpoint] = params.NetworkInfoResult{
 			Info:             []params.NetworkInfo{{Addresses: interfaceAddr}},
-			EgressSubnets:    egress,
-			IngressAddresses: ingress.Values(),
+			EgressSubnets:    orderByAddressFamily(egress, family),
+			IngressAddresses: orderByAddressFamily(ingress.Values(), family),
 		}
 	}
 
@@ -160,5 +164,16 @@ func (n *NetworkInfoCAAS) NetworksForRelation(
 		return "", nil, nil, errors.Trace(err)
 	}
 
+	// An ingress address pinned for cross-model relations replaces
+	// the service or pod addresses. Egress is still determined
+	// from those addresses above.
+	pinned, err := n.maybeGetCrossModelIngress(endpoint, rel)
+	if err != nil {
+		return "", nil, nil, errors.Trace(err)
+	}
+	if len(pinned) > 0 {
+		ingress = pinned
+	}
+
 	return network.AlphaSpaceId, ingress, egress, nil
 }
@@ -23,6 +23,12 @@ type NetworkInfoIAAS struct {
 	// machineNetworkInfos container network info for the unit's machine,
 	// keyed by space ID.
 	machineNetworkInfos map[string]params.NetworkInfoResult
+
+	// externalLBAddresses are the virtual addresses of external load
+	// balancers fronting the application, keyed by endpoint name.
+	// They are advertised as ingress addresses in place of those of
+	// the unit's machine.
+	externalLBAddresses map[string]string
 }
 
 func newNetworkInfoIAAS(base *NetworkInfoBase) (*NetworkInfoIAAS, error) {
@@ -31,7 +37,19 @@ func newNetworkInfoIAAS(base *NetworkInfoBase) (*NetworkInfoIAAS, error) {
 		spaces.Add(binding)
 	}
 
-	netInfo := &NetworkInfoIAAS{NetworkInfoBase: base}
+	cfg, err := base.app.ApplicationConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	lbAddrs, err := cfg.ExternalLBAddresses()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	netInfo := &NetworkInfoIAAS{
+		NetworkInfoBase:     base,
+		externalLBAddresses: lbAddrs,
+	}
 	if err := netInfo.populateMachineNetworkInfos(); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -43,6 +61,7 @@ func (n *NetworkInfoIAAS) ProcessAPIRequest(args params.NetworkInfoParams) (para
 	spaces := set.NewStrings()
 	bindings := make(map[string]string)
 	endpointEgressSubnets := make(map[string][]string)
+	family := network.AddressType(args.PreferredAddressFamily)
 
 	// For each of the valid endpoints in the request,
 	// get the bound space and initialise the endpoint egress
@@ -85,10 +104,23 @@ func (n *NetworkInfoIAAS) ProcessAPIRequest(args params.NetworkInfoParams) (para
 			network.SortAddresses(ingress)
 			info.IngressAddresses = ingress.Values()
 		}
+		info.IngressAddresses = orderByAddressFamily(info.IngressAddresses, family)
 
 		if len(info.EgressSubnets) == 0 {
 			info.EgressSubnets = subnetsForAddresses(info.IngressAddresses)
 		}
+		info.EgressSubnets = orderByAddressFamily(info.EgressSubnets, family)
+		info.ShadowAddresses = orderByAddressFamily(info.ShadowAddresses, family)
+
+		// Traffic from the unit still egresses from the machine,
+		// so only the ingress is replaced by a load balancer address.
+		// Relation ingress has already been resolved with any load
+		// balancer address by NetworksForRelation.
+		if _, ok := endpointIngressAddresses[endpoint]; !ok {
+			if lbAddr, ok := n.externalLBAddresses[endpoint]; ok {
+				info.IngressAddresses = []string{lbAddr}
+			}
+		}
 
 		result.Results[endpoint] = n.resolveResultIngressHostNames(n.resolveResultInfoHostNames(info))
 	}
@@ -150,13 +182,30 @@ func (n *NetworkInfoIAAS) NetworksForRelation(
 		return "", nil, nil, errors.Trace(err)
 	}
 
+	// If the endpoint is fronted by an external load balancer,
+	// advertise its address to the other side of the relation.
+	// Egress was determined from the unit's own addresses above.
+	if lbAddr, ok := n.externalLBAddresses[endpoint]; ok {
+		ingress = network.NewSpaceAddresses(lbAddr)
+	}
+
+	// An ingress address pinned for cross-model relations
+	// takes precedence over any other.
+	pinned, err := n.maybeGetCrossModelIngress(endpoint, rel)
+	if err != nil {
+		return "", nil, nil, errors.Trace(err)
+	}
+	if len(pinned) > 0 {
+		ingress = pinned
+	}
+
 	return boundSpace, ingress, egress, nil
 }
 
 // machineNetworkInfos sets network info for the unit's machine
 // based on devices with addresses in the unit's bound spaces.
 func (n *NetworkInfoIAAS) populateMachineNetworkInfos() error {
-	machineID, err := n.unit.AssignedMachineId()
+	machineID, err := n.addressUnit.AssignedMachineId()
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -164,6 +213,9 @@ func (n *NetworkInfoIAAS) populateMachineNetworkInfos() error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if addr := machine.EgressNATAddress(); addr != "" {
+		n.egressNATAddress = addr
+	}
 
 	spaceSet := set.NewStrings()
 	for _, binding := range n.bindings {
@@ -215,7 +267,12 @@ func (n *NetworkInfoIAAS) populateMachineNetworkInfos() error {
 	logger.Debugf("Looking for address from %v in spaces %v", addresses, spaceSet.Values())
 
 	var privateLinkLayerAddress *state.Address
+	shadowAddresses := make(map[string][]string)
 	for _, addr := range addresses {
+		if addr.IsShadow() {
+			shadowAddresses[addr.DeviceName()] = append(shadowAddresses[addr.DeviceName()], addr.Value())
+		}
+
 		subnet, err := addr.Subnet()
 		switch {
 		case errors.IsNotFound(err):
@@ -288,6 +345,22 @@ func (n *NetworkInfoIAAS) populateMachineNetworkInfos() error {
 		results[network.AlphaSpaceId] = r
 	}
 
+	// Shadow addresses are not configured on the machine's devices,
+	// so they are not bind addresses, but they route to those devices.
+	// Providers do not report which device a public or floating address
+	// routes to, so those are advertised for every binding.
+	machineShadowAddresses := machineShadowAddresses(machine, addresses)
+	for id, r := range results {
+		if r.Error != nil {
+			continue
+		}
+		for _, info := range r.Info {
+			r.ShadowAddresses = append(r.ShadowAddresses, shadowAddresses[info.InterfaceName]...)
+		}
+		r.ShadowAddresses = append(r.ShadowAddresses, machineShadowAddresses...)
+		results[id] = r
+	}
+
 	for _, id := range spaceSet.Values() {
 		if _, ok := results[id]; !ok {
 			results[id] = params.NetworkInfoResult{
@@ -301,6 +374,33 @@ func (n *NetworkInfoIAAS) populateMachineNetworkInfos() error {
 	return nil
 }
 
+// machineShadowAddresses returns the public IP addresses that the
+// provider reports for the machine, but which are not configured on any
+// of its link-layer devices, such as EC2 public IPs or OpenStack
+// floating IPs.
+// Without link-layer addresses, which space-less providers may not
+// report, configured addresses can not be told apart from shadow ones,
+// so none are returned.
+func machineShadowAddresses(machine *state.Machine, addresses []*state.Address) []string {
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	configured := set.NewStrings()
+	for _, addr := range addresses {
+		configured.Add(addr.Value())
+	}
+
+	var shadows []string
+	for _, addr := range machine.ProviderAddresses() {
+		if addr.Type == network.HostName || addr.Scope != network.ScopePublic || configured.Contains(addr.Value) {
+			continue
+		}
+		shadows = append(shadows, addr.Value)
+	}
+	return shadows
+}
+
 // Add address to a device in list or create a new device with this address.
 func addAddressToResult(networkInfos []params.NetworkInfo, address *state.Address) ([]params.NetworkInfo, error) {
 	deviceAddr := params.InterfaceAddress{
@@ -0,0 +1,126 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/state"
+)
+
+// SetRelationSchemas isn't on the v17 API.
+func (u *UniterAPIV17) SetRelationSchemas(_, _ struct{}) {}
+
+// SetRelationSchemas isn't on the v15 API.
+func (u *UniterAPIV15) SetRelationSchemas(_, _ struct{}) {}
+
+// SetRelationSchemas registers, on behalf of each unit's application, the
+// schema that relation data written by the other side of relations over
+// an endpoint must satisfy. Only the application leader may do this.
+func (u *UniterAPI) SetRelationSchemas(args params.RelationSchemaArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
+			continue
+		}
+		err = u.setOneRelationSchema(tag, arg)
+		result.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) setOneRelationSchema(tag names.UnitTag, arg params.RelationSchemaArg) error {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	token := u.leadershipChecker.LeadershipCheck(unit.ApplicationName(), unit.Name())
+	if err := token.Check(0, nil); err != nil {
+		if leadership.IsNotLeaderError(err) {
+			return apiservererrors.ErrPerm
+		}
+		return errors.Trace(err)
+	}
+	app, err := unit.Application()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	schema := app.RelationSchema(arg.Endpoint)
+	if arg.Application {
+		schema.Application = arg.Schema
+	} else {
+		schema.Unit = arg.Schema
+	}
+	return app.SetRelationSchema(arg.Endpoint, schema)
+}
+
+// relatedSchema is the schema registered by the application at the other
+// end of a relation for the endpoint it is related over.
+type relatedSchema struct {
+	endpoint state.Endpoint
+	schema   state.RelationSchema
+}
+
+// relatedSchemas returns the schemas that data written to the relation by
+// the unit must satisfy. Remote applications have no registered schemas
+// and are skipped.
+func (u *UniterAPI) relatedSchemas(rel *state.Relation, unit *state.Unit) ([]relatedSchema, error) {
+	eps, err := rel.RelatedEndpoints(unit.ApplicationName())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var schemas []relatedSchema
+	for _, ep := range eps {
+		app, err := u.st.Application(ep.ApplicationName)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		schema := app.RelationSchema(ep.Name)
+		if schema.IsEmpty() {
+			continue
+		}
+		schemas = append(schemas, relatedSchema{endpoint: ep, schema: schema})
+	}
+	return schemas, nil
+}
+
+// validateUnitSettings checks the unit settings about to be written to a
+// relation against the schemas of the related applications.
+func validateUnitSettings(schemas []relatedSchema, settings map[string]interface{}) error {
+	for _, s := range schemas {
+		if err := s.schema.ValidateUnitSettings(settings); err != nil {
+			return errors.Annotatef(err, "invalid relation data for %q", s.endpoint.String())
+		}
+	}
+	return nil
+}
+
+// validateApplicationSettings checks the application settings about to be
+// written to a relation against the schemas of the related applications.
+func validateApplicationSettings(schemas []relatedSchema, settings map[string]interface{}) error {
+	for _, s := range schemas {
+		if err := s.schema.ValidateApplicationSettings(settings); err != nil {
+			return errors.Annotatef(err, "invalid relation data for %q", s.endpoint.String())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// ResourceLimits isn't on the v20 API.
+func (u *UniterAPIV20) ResourceLimits(_, _ struct{}) {}
+
+// ResourceLimits isn't on the v15 API.
+func (u *UniterAPIV15) ResourceLimits(_, _ struct{}) {}
+
+// ResourceLimits returns the limits to apply to the workload processes
+// of each given unit, from the cpu-quota and memory-limit constraints
+// of the unit's application.
+func (u *UniterAPI) ResourceLimits(args params.Entities) (params.ResourceLimitsResults, error) {
+	result := params.ResourceLimitsResults{
+		Results: make([]params.ResourceLimitsResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ResourceLimitsResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil || !canAccess(tag) {
+			result.Results[i].Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
+			continue
+		}
+		limits, err := u.resourceLimits(tag)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = limits
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) resourceLimits(tag names.UnitTag) (params.ResourceLimits, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return params.ResourceLimits{}, errors.Trace(err)
+	}
+	app, err := unit.Application()
+	if err != nil {
+		return params.ResourceLimits{}, errors.Trace(err)
+	}
+	cons, err := app.Constraints()
+	if err != nil {
+		return params.ResourceLimits{}, errors.Trace(err)
+	}
+	var limits params.ResourceLimits
+	if cons.HasCpuQuota() {
+		limits.CPUQuota = cons.CpuQuota
+	}
+	if cons.HasMemoryLimit() {
+		limits.MemoryLimit = cons.MemoryLimit
+	}
+	return limits, nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// SecretConfigValues isn't on the v22 API.
+func (u *UniterAPIV22) SecretConfigValues(_, _ struct{}) {}
+
+// SecretConfigValues isn't on the v15 API.
+func (u *UniterAPIV15) SecretConfigValues(_, _ struct{}) {}
+
+// SecretConfigValues returns the values of the given secret charm config
+// options of each unit's application. Each value returned is recorded
+// against the unit which read it.
+func (u *UniterAPI) SecretConfigValues(args params.SecretConfigArgs) (params.StringResults, error) {
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.StringResults{}, err
+	}
+	for i, arg := range args.Args {
+		tag, err := names.ParseUnitTag(arg.UnitTag)
+		if err != nil || !canAccess(tag) {
+			result.Results[i].Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		value, err := unit.SecretConfigValue(arg.Key)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = value
+	}
+	return result, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/common/storagecommon"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
+	"github.com/juju/juju/storage/poolmanager"
+)
+
+// CreateStorageSnapshots isn't on the v21 API.
+func (u *UniterAPIV21) CreateStorageSnapshots(_, _ struct{}) {}
+
+// CreateStorageSnapshots isn't on the v15 API.
+func (u *UniterAPIV15) CreateStorageSnapshots(_, _ struct{}) {}
+
+// CreateStorageSnapshots takes provider snapshots of the volumes backing
+// storage attached to the given units. Charms use this, once they have
+// quiesced their workload, to take application-consistent snapshots.
+func (u *UniterAPI) CreateStorageSnapshots(args params.CreateUnitStorageSnapshotsArgs) (params.StorageSnapshotResults, error) {
+	result := params.StorageSnapshotResults{
+		Results: make([]params.StorageSnapshotResult, len(args.Snapshots)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.StorageSnapshotResults{}, err
+	}
+	for i, arg := range args.Snapshots {
+		unitTag, err := names.ParseUnitTag(arg.UnitTag)
+		if err != nil || !canAccess(unitTag) {
+			result.Results[i].Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
+			continue
+		}
+		snapshot, err := u.createStorageSnapshot(unitTag, arg)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = &snapshot
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) createStorageSnapshot(unitTag names.UnitTag, arg params.CreateUnitStorageSnapshotArg) (params.StorageSnapshot, error) {
+	storageTag, err := names.ParseStorageTag(arg.StorageTag)
+	if err != nil {
+		return params.StorageSnapshot{}, errors.Trace(err)
+	}
+	// Units may only take snapshots of storage attached to them.
+	if _, err := u.StorageAPI.storage.StorageAttachment(storageTag, unitTag); err != nil {
+		return params.StorageSnapshot{}, errors.Trace(err)
+	}
+	storageInstance, err := u.StorageAPI.storage.StorageInstance(storageTag)
+	if err != nil {
+		return params.StorageSnapshot{}, errors.Trace(err)
+	}
+	modelConfig, err := u.m.ModelConfig()
+	if err != nil {
+		return params.StorageSnapshot{}, errors.Trace(err)
+	}
+	resourceTags, err := storagecommon.StorageTags(
+		storageInstance, u.m.UUID(), u.m.ControllerUUID(), modelConfig,
+	)
+	if err != nil {
+		return params.StorageSnapshot{}, errors.Trace(err)
+	}
+	registry, err := stateenvirons.NewStorageProviderRegistryForModel(
+		u.m,
+		stateenvirons.GetNewEnvironFunc(environs.New),
+		stateenvirons.GetNewCAASBrokerFunc(caas.New),
+	)
+	if err != nil {
+		return params.StorageSnapshot{}, errors.Trace(err)
+	}
+	poolManager := poolmanager.New(state.NewStateSettings(u.st), registry)
+	return storagecommon.CreateStorageSnapshot(
+		context.CallContext(u.st),
+		u.StorageAPI.storage.VolumeAccess(),
+		storageTag,
+		arg.Description,
+		resourceTags,
+		poolManager,
+		registry,
+	)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
 	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/life"
@@ -42,9 +43,8 @@ var logger = loggo.GetLogger("juju.apiserver.uniter")
 // TODO (manadart 2020-10-21): Remove the ModelUUID method
 // from the next version of this facade.
 
-// UniterAPI implements the latest version (v17) of the Uniter API, which
-// augments the payload of the CommitHookChanges API call and introduces
-// the OpenedMachinePortRanges call as a replacement for AllMachinePorts.
+// UniterAPI implements the latest version (v23) of the Uniter API, which
+// adds SecretConfigValues.
 type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
@@ -83,10 +83,48 @@ type UniterAPI struct {
 	cloudSpec       cloudspec.CloudSpecAPI
 }
 
+// UniterAPIV18 implements version (v18) of the Uniter API, which
+// adds SetRelationSchemas and validates relation settings against the
+// schemas registered by related applications.
+type UniterAPIV18 struct {
+	UniterAPIV19
+}
+
+// UniterAPIV19 implements version (v19) of the Uniter API, which
+// adds SetUnitsDrained and reports drain requests when refreshing units.
+type UniterAPIV19 struct {
+	UniterAPIV20
+}
+
+// UniterAPIV20 implements version (v20) of the Uniter API, which
+// allows the NetworkInfo result schema version to be requested.
+type UniterAPIV20 struct {
+	UniterAPIV21
+}
+
+// UniterAPIV21 implements version (v21) of the Uniter API, which
+// adds ResourceLimits.
+type UniterAPIV21 struct {
+	UniterAPIV22
+}
+
+// UniterAPIV22 implements version (v22) of the Uniter API, which
+// adds CreateStorageSnapshots.
+type UniterAPIV22 struct {
+	UniterAPI
+}
+
+// UniterAPIV17 implements version (v17) of the Uniter API, which
+// augments the payload of the CommitHookChanges API call and introduces
+// the OpenedMachinePortRanges call as a replacement for AllMachinePorts.
+type UniterAPIV17 struct {
+	UniterAPIV18
+}
+
 // UniterAPIV16 implements version (v16) of the Uniter API, which adds
 // LXDProfileAPIV2.
 type UniterAPIV16 struct {
-	UniterAPI
+	UniterAPIV17
 }
 
 // UniterAPIV15 implements version (v15) of the Uniter API, which adds
@@ -262,14 +300,80 @@ func NewUniterAPI(context facade.Context) (*UniterAPI, error) {
 	}, nil
 }
 
+// NewUniterAPIV18 creates an instance of the V18 uniter API.
+func NewUniterAPIV18(context facade.Context) (*UniterAPIV18, error) {
+	uniterAPI, err := NewUniterAPIV19(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV18{
+		UniterAPIV19: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV19 creates an instance of the V19 uniter API.
+func NewUniterAPIV19(context facade.Context) (*UniterAPIV19, error) {
+	uniterAPI, err := NewUniterAPIV20(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV19{
+		UniterAPIV20: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV20 creates an instance of the V20 uniter API.
+func NewUniterAPIV20(context facade.Context) (*UniterAPIV20, error) {
+	uniterAPI, err := NewUniterAPIV21(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV20{
+		UniterAPIV21: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV21 creates an instance of the V21 uniter API.
+func NewUniterAPIV21(context facade.Context) (*UniterAPIV21, error) {
+	uniterAPI, err := NewUniterAPIV22(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV21{
+		UniterAPIV22: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV22 creates an instance of the V22 uniter API.
+func NewUniterAPIV22(context facade.Context) (*UniterAPIV22, error) {
+	uniterAPI, err := NewUniterAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV22{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV17 creates an instance of the V17 uniter API.
+func NewUniterAPIV17(context facade.Context) (*UniterAPIV17, error) {
+	uniterAPI, err := NewUniterAPIV18(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV17{
+		UniterAPIV18: *uniterAPI,
+	}, nil
+}
+
 // NewUniterAPIV16 creates an instance of the V16 uniter API.
 func NewUniterAPIV16(context facade.Context) (*UniterAPIV16, error) {
-	uniterAPI, err := NewUniterAPI(context)
+	uniterAPI, err := NewUniterAPIV17(context)
 	if err != nil {
 		return nil, err
 	}
 	return &UniterAPIV16{
-		UniterAPI: *uniterAPI,
+		UniterAPIV17: *uniterAPI,
 	}, nil
 }
 
@@ -1584,6 +1688,7 @@ func (u *UniterAPI) Refresh(args params.Entities) (params.UnitRefreshResults, er
 			if unit, err = u.getUnit(tag); err == nil {
 				result.Results[i].Life = life.Value(unit.Life().String())
 				result.Results[i].Resolved = params.ResolvedMode(unit.Resolved())
+				result.Results[i].DrainRequested = unit.DrainStatus() == state.UnitDrainRequested
 
 				var err1 error
 				result.Results[i].ProviderID, err1 = u.getProviderID(unit)
@@ -1985,11 +2090,17 @@ func (u *UniterAPI) updateUnitAndApplicationSettingsOp(arg params.RelationUnitSe
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	appSettingsUpdateOp, err := u.updateApplicationSettingsOp(rel, unit, arg.ApplicationSettings)
+	var schemas []relatedSchema
+	if len(arg.Settings)+len(arg.ApplicationSettings) > 0 {
+		if schemas, err = u.relatedSchemas(rel, unit); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	appSettingsUpdateOp, err := u.updateApplicationSettingsOp(rel, unit, arg.ApplicationSettings, schemas)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	unitSettingsUpdateOp, err := u.updateUnitSettingsOp(relUnit, arg.Settings)
+	unitSettingsUpdateOp, err := u.updateUnitSettingsOp(relUnit, arg.Settings, schemas)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -1997,7 +2108,7 @@ func (u *UniterAPI) updateUnitAndApplicationSettingsOp(arg params.RelationUnitSe
 	return state.ComposeModelOperations(appSettingsUpdateOp, unitSettingsUpdateOp), nil
 }
 
-func (u *UniterAPI) updateUnitSettingsOp(relUnit *state.RelationUnit, newSettings params.Settings) (state.ModelOperation, error) {
+func (u *UniterAPI) updateUnitSettingsOp(relUnit *state.RelationUnit, newSettings params.Settings, schemas []relatedSchema) (state.ModelOperation, error) {
 	if len(newSettings) == 0 {
 		return nil, nil
 	}
@@ -2012,10 +2123,13 @@ func (u *UniterAPI) updateUnitSettingsOp(relUnit *state.RelationUnit, newSetting
 			settings.Set(k, v)
 		}
 	}
+	if err := validateUnitSettings(schemas, settings.Map()); err != nil {
+		return nil, errors.Trace(err)
+	}
 	return settings.WriteOperation(), nil
 }
 
-func (u *UniterAPI) updateApplicationSettingsOp(rel *state.Relation, unit *state.Unit, settings params.Settings) (state.ModelOperation, error) {
+func (u *UniterAPI) updateApplicationSettingsOp(rel *state.Relation, unit *state.Unit, settings params.Settings, schemas []relatedSchema) (state.ModelOperation, error) {
 	if len(settings) == 0 {
 		return nil, nil
 	}
@@ -2024,6 +2138,24 @@ func (u *UniterAPI) updateApplicationSettingsOp(rel *state.Relation, unit *state
 	for k, v := range settings {
 		settingsMap[k] = v
 	}
+	if len(schemas) > 0 {
+		// Validate the settings as they will be once the changes are
+		// applied; empty values delete keys.
+		merged, err := rel.ApplicationSettings(unit.ApplicationName())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for k, v := range settings {
+			if v == "" {
+				delete(merged, k)
+			} else {
+				merged[k] = v
+			}
+		}
+		if err := validateApplicationSettings(schemas, merged); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 
 	return rel.UpdateApplicationSettingsOperation(unit.ApplicationName(), token, settingsMap)
 }
@@ -2269,6 +2401,7 @@ func (u *UniterAPI) prepareRelationResult(rel *state.Relation, applicationName s
 			Relation:        params.NewCharmRelation(ep.Relation),
 		},
 		OtherApplication: otherAppName,
+		KeyGeneration:    rel.KeyGeneration(),
 	}, nil
 }
 
@@ -2605,6 +2738,14 @@ func (u *UniterAPI) NetworkInfo(args params.NetworkInfoParams) (params.NetworkIn
 		return params.NetworkInfoResults{}, apiservererrors.ErrPerm
 	}
 
+	schemaVersion, err := networkInfoSchemaVersion(args.SchemaVersion)
+	if err != nil {
+		return params.NetworkInfoResults{}, err
+	}
+	if _, err := preferredAddressFamily(args.PreferredAddressFamily); err != nil {
+		return params.NetworkInfoResults{}, err
+	}
+
 	netInfo, err := NewNetworkInfo(u.st, unitTag)
 	if err != nil {
 		return params.NetworkInfoResults{}, err
@@ -2614,7 +2755,7 @@ func (u *UniterAPI) NetworkInfo(args params.NetworkInfoParams) (params.NetworkIn
 	if err != nil {
 		return params.NetworkInfoResults{}, err
 	}
-	return uniqueNetworkInfoResults(res), nil
+	return renderNetworkInfoSchema(uniqueNetworkInfoResults(res), schemaVersion), nil
 }
 
 // WatchUnitRelations returns a StringsWatcher, for each given
@@ -2917,6 +3058,27 @@ func (u *UniterAPIV6) NetworkInfo(args params.NetworkInfoParams) (params.Network
 	return networkInfoResultsToV6(v6Results), nil
 }
 
+// NetworkInfo implements UniterAPIV19 version of NetworkInfo, which
+// always renders NetworkInfoSchemaV1 results.
+func (u *UniterAPIV19) NetworkInfo(args params.NetworkInfoParams) (params.NetworkInfoResults, error) {
+	return networkInfoV1(&u.UniterAPI, args)
+}
+
+// NetworkInfo implements UniterAPIV15 version of NetworkInfo, which
+// always renders NetworkInfoSchemaV1 results.
+func (u *UniterAPIV15) NetworkInfo(args params.NetworkInfoParams) (params.NetworkInfoResults, error) {
+	return networkInfoV1(&u.UniterAPI, args)
+}
+
+// networkInfoV1 returns NetworkInfoSchemaV1 results for facade versions
+// that predate schema negotiation, ignoring any requested version.
+func networkInfoV1(u *UniterAPI, args params.NetworkInfoParams) (params.NetworkInfoResults, error) {
+	args.SchemaVersion = params.NetworkInfoSchemaV1
+	results, err := u.NetworkInfo(args)
+	results.SchemaVersion = 0
+	return results, errors.Trace(err)
+}
+
 // Mask the SetPodSpec method from the v7 API. The API reflection code
 // in rpc/rpcreflect/type.go:newMethod skips 2-argument methods, so
 // this removes the method as far as the RPC machinery is concerned.
@@ -3130,7 +3292,66 @@ func (u *UniterAPI) CloudSpec() (params.CloudSpecResult, error) {
 		return params.CloudSpecResult{Error: apiservererrors.ServerError(apiservererrors.ErrPerm)}, nil
 	}
 
-	return u.cloudSpec.GetCloudSpec(u.m.Tag().(names.ModelTag)), nil
+	result := u.cloudSpec.GetCloudSpec(u.m.Tag().(names.ModelTag))
+	if result.Error != nil {
+		return result, nil
+	}
+	credential, err := u.scopedCredential()
+	if err != nil {
+		return params.CloudSpecResult{Error: apiservererrors.ServerError(err)}, nil
+	}
+	if credential != nil {
+		result.Result.Credential = credential
+	}
+	return result, nil
+}
+
+// scopedCredential returns the credential scoped to the authenticated
+// application, if the application's trust is scoped to it, in place of
+// the model's credential. It returns nil if the application is given
+// the model's credential.
+func (u *UniterAPI) scopedCredential() (*params.CloudCredential, error) {
+	var appName string
+	switch tag := u.auth.GetAuthTag().(type) {
+	case names.UnitTag:
+		unit, err := u.st.Unit(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		appName = unit.ApplicationName()
+	case names.ApplicationTag:
+		appName = tag.Id()
+	default:
+		return nil, errors.Errorf("expected names.UnitTag or names.ApplicationTag, got %T", tag)
+	}
+	app, err := u.st.Application(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	config, err := app.ApplicationConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	scope, err := config.TrustScope()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if scope != coreapplication.TrustScopeApplication {
+		return nil, nil
+	}
+	// The model's credential is never given in place of a scoped
+	// credential which has not yet been issued, or has expired.
+	scoped, err := app.ScopedCredential()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !scoped.Expiry.After(u.clock.Now()) {
+		return nil, errors.NotFoundf("unexpired scoped credential for application %q", appName)
+	}
+	return &params.CloudCredential{
+		AuthType:   string(scoped.Credential.AuthType()),
+		Attributes: scoped.Credential.Attributes(),
+	}, nil
 }
 
 // GoalStates returns information of charm units and relations.
@@ -32,8 +32,10 @@ import (
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/caas/kubernetes/provider"
 	k8stesting "github.com/juju/juju/caas/kubernetes/provider/testing"
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/controller"
 	coreapplication "github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/model"
@@ -2998,6 +3000,120 @@ func (s *uniterSuite) TestUpdateSettingsWithAppSettingsOnly(c *gc.C) {
 	})
 }
 
+const mysqlServerSchema = `{
+	"type": "object",
+	"properties": {"database": {"type": "string", "minLength": 1}},
+	"required": ["database"]
+}`
+
+func (s *uniterSuite) TestUpdateSettingsValidatesUnitSettings(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	relUnit, err := rel.Unit(s.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = relUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetRelationSchema("server", state.RelationSchema{Unit: mysqlServerSchema})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.RelationUnitsSettings{RelationUnits: []params.RelationUnitSettings{{
+		Relation: rel.Tag().String(),
+		Unit:     "unit-wordpress-0",
+		Settings: params.Settings{"db": "wordpress"},
+	}, {
+		Relation: rel.Tag().String(),
+		Unit:     "unit-wordpress-0",
+		Settings: params.Settings{"database": "wordpress"},
+	}}}
+	result, err := s.uniter.UpdateSettings(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `invalid relation data for "mysql:server": unit settings: .*database.*required.*`)
+	c.Assert(result.Results[1].Error, gc.IsNil)
+
+	readSettings, err := relUnit.ReadSettings(s.wordpressUnit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(readSettings, gc.DeepEquals, map[string]interface{}{
+		"database": "wordpress",
+	})
+}
+
+func (s *uniterSuite) TestUpdateSettingsValidatesAppSettings(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	relUnit, err := rel.Unit(s.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = relUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.UpdateApplicationSettings("wordpress", &token{isLeader: true}, map[string]interface{}{
+		"database": "wordpress",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetRelationSchema("server", state.RelationSchema{Application: mysqlServerSchema})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.leadershipChecker.isLeader = true
+
+	// Deleting a required key leaves the settings invalid.
+	args := params.RelationUnitsSettings{RelationUnits: []params.RelationUnitSettings{{
+		Relation:            rel.Tag().String(),
+		Unit:                "unit-wordpress-0",
+		ApplicationSettings: params.Settings{"database": ""},
+	}, {
+		Relation:            rel.Tag().String(),
+		Unit:                "unit-wordpress-0",
+		ApplicationSettings: params.Settings{"extra": "stuff"},
+	}}}
+	result, err := s.uniter.UpdateSettings(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `invalid relation data for "mysql:server": application settings: .*database.*required.*`)
+	c.Assert(result.Results[1].Error, gc.IsNil)
+
+	readSettings, err := rel.ApplicationSettings("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(readSettings, gc.DeepEquals, map[string]interface{}{
+		"database": "wordpress",
+		"extra":    "stuff",
+	})
+}
+
+func (s *uniterSuite) TestSetRelationSchemas(c *gc.C) {
+	s.leadershipChecker.isLeader = true
+	args := params.RelationSchemaArgs{Args: []params.RelationSchemaArg{
+		{Tag: "unit-wordpress-0", Endpoint: "db", Schema: mysqlServerSchema},
+		{Tag: "unit-wordpress-0", Endpoint: "db", Application: true, Schema: mysqlServerSchema},
+		{Tag: "unit-wordpress-0", Endpoint: "foo", Schema: mysqlServerSchema},
+		{Tag: "unit-mysql-0", Endpoint: "server", Schema: mysqlServerSchema},
+		{Tag: "application-wordpress", Endpoint: "db", Schema: mysqlServerSchema},
+	}}
+	result, err := s.uniter.SetRelationSchemas(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 5)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.IsNil)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `cannot set relation schema: application "wordpress" has no "foo" relation`)
+	c.Assert(result.Results[3].Error, gc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(result.Results[4].Error, gc.DeepEquals, apiservertesting.ErrUnauthorized)
+
+	err = s.wordpress.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.wordpress.RelationSchema("db"), jc.DeepEquals, state.RelationSchema{
+		Unit:        mysqlServerSchema,
+		Application: mysqlServerSchema,
+	})
+}
+
+func (s *uniterSuite) TestSetRelationSchemasNotLeader(c *gc.C) {
+	s.leadershipChecker.isLeader = false
+	args := params.RelationSchemaArgs{Args: []params.RelationSchemaArg{
+		{Tag: "unit-wordpress-0", Endpoint: "db", Schema: mysqlServerSchema},
+	}}
+	result, err := s.uniter.SetRelationSchemas(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{apiservertesting.ErrUnauthorized}},
+	})
+}
+
 func (s *uniterSuite) TestWatchRelationUnits(c *gc.C) {
 	// Add a relation between wordpress and mysql and enter scope with
 	// mysqlUnit.
@@ -3827,6 +3943,125 @@ func (s *uniterSuite) TestRefresh(c *gc.C) {
 	c.Assert(results, gc.DeepEquals, expect)
 }
 
+func (s *uniterSuite) TestRefreshDrainRequested(c *gc.C) {
+	err := s.wordpressUnit.RequestDrain()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{{s.wordpressUnit.Tag().String()}}}
+	results, err := s.uniter.Refresh(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.DeepEquals, params.UnitRefreshResults{
+		Results: []params.UnitRefreshResult{
+			{Life: life.Alive, Resolved: params.ResolvedNone, DrainRequested: true},
+		},
+	})
+}
+
+func (s *uniterSuite) TestSetUnitsDrained(c *gc.C) {
+	err := s.wordpressUnit.RequestDrain()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+	}}
+	result, err := s.uniter.SetUnitsDrained(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.wordpressUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.wordpressUnit.DrainStatus(), gc.Equals, state.UnitDrainCompleted)
+}
+
+func (s *uniterSuite) TestResourceLimits(c *gc.C) {
+	err := s.wordpress.SetConstraints(constraints.MustParse("cpu-quota=150 memory-limit=1G mem=4G"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.ResourceLimits(args)
+	c.Assert(err, jc.ErrorIsNil)
+	quota, limit := uint64(150), uint64(1024)
+	c.Assert(result, jc.DeepEquals, params.ResourceLimitsResults{
+		Results: []params.ResourceLimitsResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: params.ResourceLimits{CPUQuota: &quota, MemoryLimit: &limit}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestResourceLimitsUnset(c *gc.C) {
+	args := params.Entities{Entities: []params.Entity{{Tag: "unit-wordpress-0"}}}
+	result, err := s.uniter.ResourceLimits(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ResourceLimitsResults{
+		Results: []params.ResourceLimitsResult{{}},
+	})
+}
+
+func (s *uniterSuite) TestCreateStorageSnapshotsNotAttached(c *gc.C) {
+	args := params.CreateUnitStorageSnapshotsArgs{Snapshots: []params.CreateUnitStorageSnapshotArg{
+		{UnitTag: "unit-mysql-0", StorageTag: "storage-data-0"},
+		{UnitTag: "unit-wordpress-0", StorageTag: "storage-data-0"},
+		{UnitTag: "unit-wordpress-0", StorageTag: "volume-0"},
+	}}
+	result, err := s.uniter.CreateStorageSnapshots(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StorageSnapshotResults{
+		Results: []params.StorageSnapshotResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError("storage attachment data/0:wordpress/0")},
+			{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+		},
+	})
+}
+
+func (s *uniterSuite) TestSecretConfigValues(c *gc.C) {
+	err := s.wordpress.UpdateApplicationConfig(coreapplication.ConfigAttributes{
+		coreapplication.SecretOptionsConfigKey: "blog-title",
+	}, nil, environschema.Fields{
+		coreapplication.SecretOptionsConfigKey: {Type: environschema.Tstring},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpress.UpdateCharmConfig(model.GenerationMaster, charm.Settings{"blog-title": "s3cret"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.SecretConfigArgs{Args: []params.SecretConfigArg{
+		{UnitTag: "unit-mysql-0", Key: "blog-title"},
+		{UnitTag: "unit-wordpress-0", Key: "blog-title"},
+		{UnitTag: "unit-wordpress-0", Key: "unknown"},
+		{UnitTag: "application-wordpress", Key: "blog-title"},
+	}}
+	result, err := s.uniter.SecretConfigValues(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: "s3cret"},
+			{Error: &params.Error{Message: `secret config option "unknown" not valid`}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	accesses, err := s.wordpress.SecretConfigAccesses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(accesses, gc.HasLen, 1)
+	c.Assert(accesses[0].Unit, gc.Equals, "wordpress/0")
+	c.Assert(accesses[0].Key, gc.Equals, "blog-title")
+}
+
 func (s *uniterSuite) TestRefreshNoArgs(c *gc.C) {
 	results, err := s.uniter.Refresh(params.Entities{Entities: []params.Entity{}})
 	c.Assert(err, jc.ErrorIsNil)
@@ -5510,6 +5745,54 @@ func (s *cloudSpecUniterSuite) TestGetCloudSpecReturnsSpecWhenTrusted(c *gc.C) {
 	c.Assert(result.Result.Credential.Attributes, gc.DeepEquals, exp)
 }
 
+func (s *cloudSpecUniterSuite) scopeTrust(c *gc.C) {
+	conf := map[string]interface{}{coreapplication.TrustScopeConfigKey: coreapplication.TrustScopeApplication}
+	fields := map[string]environschema.Attr{
+		application.TrustConfigOptionName:   {Type: environschema.Tbool},
+		coreapplication.TrustScopeConfigKey: {Type: environschema.Tstring},
+	}
+	err := s.wordpress.UpdateApplicationConfig(conf, nil, fields, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *cloudSpecUniterSuite) TestGetCloudSpecReturnsScopedCredential(c *gc.C) {
+	s.scopeTrust(c)
+	scoped := cloud.NewCredential(cloud.OAuth2AuthType, map[string]string{"Token": "scoped-token"})
+	err := s.wordpress.SetScopedCredential(scoped, time.Now().Add(24*time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.CloudSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result.Name, gc.Equals, "dummy")
+	c.Assert(result.Result.Credential, jc.DeepEquals, &params.CloudCredential{
+		AuthType:   "oauth2",
+		Attributes: map[string]string{"Token": "scoped-token"},
+	})
+}
+
+func (s *cloudSpecUniterSuite) TestGetCloudSpecScopedCredentialNotIssued(c *gc.C) {
+	s.scopeTrust(c)
+
+	result, err := s.uniter.CloudSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result, gc.IsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `scoped credential for application "wordpress" not found`)
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *cloudSpecUniterSuite) TestGetCloudSpecScopedCredentialExpired(c *gc.C) {
+	s.scopeTrust(c)
+	scoped := cloud.NewCredential(cloud.OAuth2AuthType, map[string]string{"Token": "scoped-token"})
+	err := s.wordpress.SetScopedCredential(scoped, time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.CloudSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result, gc.IsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `unexpired scoped credential for application "wordpress" not found`)
+}
+
 type fakeBroker struct {
 	caas.Broker
 }
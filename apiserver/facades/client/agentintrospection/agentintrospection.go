@@ -0,0 +1,200 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package agentintrospection provides the AgentIntrospection facade,
+// which lets clients query the introspection socket of a machine agent
+// without SSH access to the machine. Requests are recorded in state,
+// answered by the agent's introspection-responder worker, and the
+// results collected with Results.
+package agentintrospection
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/permission"
+)
+
+// allowedPaths holds the introspection paths which may be requested.
+// Paths ending in "/" also allow any path below them.
+var allowedPaths = []string{
+	"/debug/pprof/",
+	"/depengine",
+	"/leases",
+	"/machinelock",
+	"/metrics",
+	"/presence",
+	"/pubsub",
+	"/statepool",
+	"/units",
+}
+
+// API implements the AgentIntrospection facade.
+type API struct {
+	backend       Backend
+	authorizer    facade.Authorizer
+	controllerTag names.ControllerTag
+	modelTag      names.ModelTag
+}
+
+// NewFacade is used for API registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	return NewAPI(
+		backendShim{st: st},
+		ctx.Auth(),
+		st.ControllerTag(),
+		names.NewModelTag(st.ModelUUID()),
+	)
+}
+
+// NewAPI returns a new AgentIntrospection API facade.
+func NewAPI(
+	backend Backend,
+	authorizer facade.Authorizer,
+	controllerTag names.ControllerTag,
+	modelTag names.ModelTag,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &API{
+		backend:       backend,
+		authorizer:    authorizer,
+		controllerTag: controllerTag,
+		modelTag:      modelTag,
+	}, nil
+}
+
+// checkIsAdmin returns an error unless the user is a controller
+// superuser or a model admin. An agent's introspection data reveals
+// the internals of the agent, so reading the model isn't enough.
+func (api *API) checkIsAdmin() error {
+	isSuperUser, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.controllerTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if isSuperUser {
+		return nil
+	}
+	isAdmin, err := api.authorizer.HasPermission(permission.AdminAccess, api.modelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isAdmin {
+		return apiservererrors.ErrPerm
+	}
+	return nil
+}
+
+// Introspect asks the agents of the given machines, or of the machines
+// the given units are assigned to, to query their introspection
+// sockets. It returns the id of each request, for use with Results.
+func (api *API) Introspect(args params.IntrospectArgs) (params.StringResults, error) {
+	if err := api.checkIsAdmin(); err != nil {
+		return params.StringResults{}, errors.Trace(err)
+	}
+	results := make([]params.StringResult, len(args.Args))
+	for i, arg := range args.Args {
+		id, err := api.introspect(arg)
+		if err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results[i].Result = id
+	}
+	return params.StringResults{Results: results}, nil
+}
+
+func (api *API) introspect(arg params.IntrospectArg) (string, error) {
+	if err := validatePath(arg.Path); err != nil {
+		return "", errors.Trace(err)
+	}
+	agent, err := api.agentTag(arg.Tag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	req, err := api.backend.AddIntrospectionRequest(agent, arg.Path)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return req.Id(), nil
+}
+
+// agentTag returns the tag of the agent to introspect for the given
+// machine or unit tag.
+func (api *API) agentTag(tagString string) (names.Tag, error) {
+	tag, err := names.ParseTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch tag := tag.(type) {
+	case names.MachineTag:
+		m, err := api.backend.Machine(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return m.Tag(), nil
+	case names.UnitTag:
+		u, err := api.backend.Unit(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		machineId, err := u.AssignedMachineId()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return names.NewMachineTag(machineId), nil
+	default:
+		return nil, errors.NotValidf("introspecting %s", names.ReadableString(tag))
+	}
+}
+
+func validatePath(path string) error {
+	u, err := url.Parse(path)
+	if err != nil || strings.Contains(u.Path, "..") {
+		return errors.NotValidf("introspection path %q", path)
+	}
+	for _, allowed := range allowedPaths {
+		if u.Path == allowed || strings.HasSuffix(allowed, "/") && strings.HasPrefix(u.Path, allowed) {
+			return nil
+		}
+	}
+	return errors.NotValidf("introspection path %q", path)
+}
+
+// Results returns the outcome of the given introspection requests.
+// Requests the agent has responded to are removed once their result
+// has been returned.
+func (api *API) Results(args params.IntrospectionRequestIds) (params.IntrospectionResults, error) {
+	if err := api.checkIsAdmin(); err != nil {
+		return params.IntrospectionResults{}, errors.Trace(err)
+	}
+	results := make([]params.IntrospectionResult, len(args.Ids))
+	for i, id := range args.Ids {
+		results[i].Id = id
+		req, err := api.backend.IntrospectionRequest(id)
+		if err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		if !req.Completed() {
+			continue
+		}
+		results[i].Completed = true
+		results[i].Output = req.Output()
+		if msg := req.Message(); msg != "" {
+			results[i].Error = &params.Error{Message: msg}
+		}
+		if err := req.Remove(); err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+		}
+	}
+	return params.IntrospectionResults{Results: results}, nil
+}
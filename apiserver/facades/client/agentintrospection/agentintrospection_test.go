@@ -0,0 +1,187 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentintrospection_test
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/agentintrospection"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type agentIntrospectionSuite struct {
+	testing.IsolationSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+	backend    *mockBackend
+}
+
+var _ = gc.Suite(&agentIntrospectionSuite{})
+
+func (s *agentIntrospectionSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	s.backend = &mockBackend{
+		machines: map[string]string{"0": "", "1": ""},
+		units:    map[string]string{"mysql/0": "1"},
+		requests: make(map[string]*mockRequest),
+	}
+}
+
+func (s *agentIntrospectionSuite) newAPI(c *gc.C) *agentintrospection.API {
+	api, err := agentintrospection.NewAPI(s.backend, s.authorizer, coretesting.ControllerTag, coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *agentIntrospectionSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := agentintrospection.NewAPI(s.backend, s.authorizer, coretesting.ControllerTag, coretesting.ModelTag)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *agentIntrospectionSuite) TestIntrospect(c *gc.C) {
+	result, err := s.newAPI(c).Introspect(params.IntrospectArgs{
+		Args: []params.IntrospectArg{
+			{Tag: "machine-0", Path: "/depengine"},
+			{Tag: "unit-mysql-0", Path: "/debug/pprof/goroutine?debug=1"},
+			{Tag: "machine-2", Path: "/depengine"},
+			{Tag: "application-mysql", Path: "/depengine"},
+			{Tag: "machine-0", Path: "/debug/pprof/../../etc"},
+			{Tag: "machine-0", Path: "/secrets"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{
+			{Result: "machine-0#0"},
+			{Result: "machine-1#1"},
+			{Error: &params.Error{Message: `machine 2 not found`, Code: params.CodeNotFound}},
+			{Error: &params.Error{Message: `introspecting application mysql not valid`}},
+			{Error: &params.Error{Message: `introspection path "/debug/pprof/../../etc" not valid`}},
+			{Error: &params.Error{Message: `introspection path "/secrets" not valid`}},
+		},
+	})
+	c.Assert(s.backend.requests["machine-1#1"].path, gc.Equals, "/debug/pprof/goroutine?debug=1")
+}
+
+func (s *agentIntrospectionSuite) TestIntrospectRequiresAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("readbob")
+	_, err := s.newAPI(c).Introspect(params.IntrospectArgs{
+		Args: []params.IntrospectArg{{Tag: "machine-0", Path: "/depengine"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(s.backend.requests, gc.HasLen, 0)
+}
+
+func (s *agentIntrospectionSuite) TestResults(c *gc.C) {
+	s.backend.requests["machine-0#0"] = &mockRequest{id: "machine-0#0"}
+	s.backend.requests["machine-0#1"] = &mockRequest{id: "machine-0#1", completed: true, output: []byte("ok")}
+	s.backend.requests["machine-0#2"] = &mockRequest{id: "machine-0#2", completed: true, message: "boom"}
+
+	result, err := s.newAPI(c).Results(params.IntrospectionRequestIds{
+		Ids: []string{"machine-0#0", "machine-0#1", "machine-0#2", "machine-0#3"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.IntrospectionResults{
+		Results: []params.IntrospectionResult{
+			{Id: "machine-0#0"},
+			{Id: "machine-0#1", Completed: true, Output: []byte("ok")},
+			{Id: "machine-0#2", Completed: true, Error: &params.Error{Message: "boom"}},
+			{Id: "machine-0#3", Error: &params.Error{Message: `introspection request "machine-0#3" not found`, Code: params.CodeNotFound}},
+		},
+	})
+	// Completed requests are removed once their results are returned.
+	c.Assert(s.backend.requests, gc.HasLen, 1)
+	c.Assert(s.backend.requests["machine-0#0"], gc.NotNil)
+}
+
+func (s *agentIntrospectionSuite) TestResultsRequiresAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("readbob")
+	_, err := s.newAPI(c).Results(params.IntrospectionRequestIds{Ids: []string{"machine-0#0"}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type mockBackend struct {
+	machines map[string]string
+	units    map[string]string
+	requests map[string]*mockRequest
+	seq      int
+}
+
+func (b *mockBackend) Machine(id string) (agentintrospection.Machine, error) {
+	if _, ok := b.machines[id]; !ok {
+		return nil, errors.NotFoundf("machine %s", id)
+	}
+	return mockMachine{tag: names.NewMachineTag(id)}, nil
+}
+
+func (b *mockBackend) Unit(name string) (agentintrospection.Unit, error) {
+	machineId, ok := b.units[name]
+	if !ok {
+		return nil, errors.NotFoundf("unit %s", name)
+	}
+	return mockUnit{machineId: machineId}, nil
+}
+
+func (b *mockBackend) AddIntrospectionRequest(agent names.Tag, path string) (agentintrospection.IntrospectionRequest, error) {
+	req := &mockRequest{
+		backend: b,
+		id:      fmt.Sprintf("%s#%d", agent, b.seq),
+		path:    path,
+	}
+	b.seq++
+	b.requests[req.id] = req
+	return req, nil
+}
+
+func (b *mockBackend) IntrospectionRequest(id string) (agentintrospection.IntrospectionRequest, error) {
+	req, ok := b.requests[id]
+	if !ok {
+		return nil, errors.NotFoundf("introspection request %q", id)
+	}
+	req.backend = b
+	return req, nil
+}
+
+type mockMachine struct {
+	tag names.Tag
+}
+
+func (m mockMachine) Tag() names.Tag { return m.tag }
+
+type mockUnit struct {
+	machineId string
+}
+
+func (u mockUnit) AssignedMachineId() (string, error) { return u.machineId, nil }
+
+type mockRequest struct {
+	backend   *mockBackend
+	id        string
+	path      string
+	completed bool
+	output    []byte
+	message   string
+}
+
+func (r *mockRequest) Id() string      { return r.id }
+func (r *mockRequest) Completed() bool { return r.completed }
+func (r *mockRequest) Output() []byte  { return r.output }
+func (r *mockRequest) Message() string { return r.message }
+
+func (r *mockRequest) Remove() error {
+	delete(r.backend.requests, r.id)
+	return nil
+}
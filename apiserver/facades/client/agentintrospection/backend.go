@@ -0,0 +1,81 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentintrospection
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/state"
+)
+
+// Backend provides access to the agents to introspect, and the
+// introspection requests made of them.
+type Backend interface {
+	Machine(id string) (Machine, error)
+	Unit(name string) (Unit, error)
+	AddIntrospectionRequest(agent names.Tag, path string) (IntrospectionRequest, error)
+	IntrospectionRequest(id string) (IntrospectionRequest, error)
+}
+
+// Machine describes a machine whose agent may be introspected.
+type Machine interface {
+	Tag() names.Tag
+}
+
+// Unit describes a unit, which is introspected through the agent of
+// the machine it is assigned to.
+type Unit interface {
+	AssignedMachineId() (string, error)
+}
+
+// IntrospectionRequest describes a request for an agent to query its
+// introspection socket.
+type IntrospectionRequest interface {
+	Id() string
+	Completed() bool
+	Output() []byte
+	Message() string
+	Remove() error
+}
+
+type backendShim struct {
+	st *state.State
+}
+
+// Machine implements Backend.
+func (b backendShim) Machine(id string) (Machine, error) {
+	m, err := b.st.Machine(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return m, nil
+}
+
+// Unit implements Backend.
+func (b backendShim) Unit(name string) (Unit, error) {
+	u, err := b.st.Unit(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return u, nil
+}
+
+// AddIntrospectionRequest implements Backend.
+func (b backendShim) AddIntrospectionRequest(agent names.Tag, path string) (IntrospectionRequest, error) {
+	req, err := b.st.AddIntrospectionRequest(agent, path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return req, nil
+}
+
+// IntrospectionRequest implements Backend.
+func (b backendShim) IntrospectionRequest(id string) (IntrospectionRequest, error) {
+	req, err := b.st.IntrospectionRequest(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return req, nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package agentpresence provides the AgentPresence facade, which reports
+// the connections of a model's agents to the controller's API servers.
+package agentpresence
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/permission"
+)
+
+// API implements the AgentPresence facade.
+type API struct {
+	presence   facade.ModelPresence
+	authorizer facade.Authorizer
+	modelTag   names.ModelTag
+}
+
+// NewFacade is used for API registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	modelTag := names.NewModelTag(ctx.State().ModelUUID())
+	return NewAPI(ctx.Presence().ModelPresence(modelTag.Id()), ctx.Auth(), modelTag)
+}
+
+// NewAPI returns a new AgentPresence API facade.
+func NewAPI(
+	presence facade.ModelPresence,
+	authorizer facade.Authorizer,
+	modelTag names.ModelTag,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &API{
+		presence:   presence,
+		authorizer: authorizer,
+		modelTag:   modelTag,
+	}, nil
+}
+
+// Agents returns the connections of the model's agents to the API
+// servers, ordered by agent: the API server each is connected to, the
+// address it connected from, and when it was last heard from.
+func (api *API) Agents() (params.AgentConnectionResults, error) {
+	canRead, err := api.authorizer.HasPermission(permission.ReadAccess, api.modelTag)
+	if err != nil {
+		return params.AgentConnectionResults{}, errors.Trace(err)
+	}
+	if !canRead {
+		return params.AgentConnectionResults{}, apiservererrors.ErrPerm
+	}
+
+	results := []params.AgentConnection{}
+	for _, value := range api.presence.Values() {
+		results = append(results, params.AgentConnection{
+			Tag:           value.Agent,
+			Server:        value.Server,
+			ConnectionID:  value.ConnectionID,
+			Status:        value.Status.String(),
+			RemoteAddress: value.RemoteAddress,
+			LastSeen:      value.LastSeen,
+		})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		ri, rj := results[i], results[j]
+		if ri.Tag != rj.Tag {
+			return ri.Tag < rj.Tag
+		}
+		if ri.Server != rj.Server {
+			return ri.Server < rj.Server
+		}
+		return ri.ConnectionID < rj.ConnectionID
+	})
+	return params.AgentConnectionResults{Results: results}, nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentpresence_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/agentpresence"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/presence"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type agentPresenceSuite struct {
+	testing.IsolationSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+	presence   mockPresence
+	lastSeen   time.Time
+}
+
+var _ = gc.Suite(&agentPresenceSuite{})
+
+func (s *agentPresenceSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	s.lastSeen = time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	s.presence = mockPresence{{
+		Server:        "machine-1",
+		Agent:         "unit-mysql-0",
+		ConnectionID:  7,
+		Status:        presence.Missing,
+		RemoteAddress: "10.0.0.7:40000",
+		LastSeen:      s.lastSeen,
+	}, {
+		Server:        "machine-0",
+		Agent:         "machine-2",
+		ConnectionID:  4,
+		Status:        presence.Alive,
+		RemoteAddress: "10.0.0.2:50000",
+		LastSeen:      s.lastSeen.Add(time.Minute),
+	}, {
+		Server:       "machine-0",
+		Agent:        "unit-mysql-0",
+		ConnectionID: 3,
+		Status:       presence.Alive,
+		LastSeen:     s.lastSeen.Add(2 * time.Minute),
+	}}
+}
+
+func (s *agentPresenceSuite) newAPI(c *gc.C) *agentpresence.API {
+	api, err := agentpresence.NewAPI(s.presence, s.authorizer, coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *agentPresenceSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := agentpresence.NewAPI(s.presence, s.authorizer, coretesting.ModelTag)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *agentPresenceSuite) TestAgents(c *gc.C) {
+	result, err := s.newAPI(c).Agents()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AgentConnectionResults{
+		Results: []params.AgentConnection{{
+			Tag:           "machine-2",
+			Server:        "machine-0",
+			ConnectionID:  4,
+			Status:        "alive",
+			RemoteAddress: "10.0.0.2:50000",
+			LastSeen:      s.lastSeen.Add(time.Minute),
+		}, {
+			Tag:          "unit-mysql-0",
+			Server:       "machine-0",
+			ConnectionID: 3,
+			Status:       "alive",
+			LastSeen:     s.lastSeen.Add(2 * time.Minute),
+		}, {
+			Tag:           "unit-mysql-0",
+			Server:        "machine-1",
+			ConnectionID:  7,
+			Status:        "missing",
+			RemoteAddress: "10.0.0.7:40000",
+			LastSeen:      s.lastSeen,
+		}},
+	})
+}
+
+func (s *agentPresenceSuite) TestAgentsNoConnections(c *gc.C) {
+	s.presence = nil
+	result, err := s.newAPI(c).Agents()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AgentConnectionResults{
+		Results: []params.AgentConnection{},
+	})
+}
+
+func (s *agentPresenceSuite) TestAgentsPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.newAPI(c).Agents()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type mockPresence []presence.Value
+
+func (p mockPresence) AgentStatus(agent string) (presence.Status, error) {
+	return presence.Unknown, errors.NotImplementedf("AgentStatus")
+}
+
+func (p mockPresence) Values() []presence.Value {
+	return p
+}
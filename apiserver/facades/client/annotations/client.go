@@ -4,14 +4,19 @@
 package annotations
 
 import (
+	"sort"
+
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	coreannotations "github.com/juju/juju/core/annotations"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
 )
 
 var getState = func(st *state.State, m *state.Model) annotationAccess {
@@ -22,15 +27,28 @@ var getState = func(st *state.State, m *state.Model) annotationAccess {
 type Annotations interface {
 	Get(args params.Entities) params.AnnotationsGetResults
 	Set(args params.AnnotationsSet) params.ErrorResults
+	WatchAnnotations() (params.StringsWatchResult, error)
+	Query(args params.AnnotationsQuery) (params.AnnotationsQueryResults, error)
 }
 
 // API implements the service interface and is the concrete
 // implementation of the api end point.
 type API struct {
 	access     annotationAccess
+	resources  facade.Resources
 	authorizer facade.Authorizer
 }
 
+// APIv3 provides the Annotations API facade for version 3.
+type APIv3 struct {
+	*API
+}
+
+// APIv2 provides the Annotations API facade for version 2.
+type APIv2 struct {
+	*APIv3
+}
+
 // NewAPI returns a new charm annotator API facade.
 func NewAPI(
 	st *state.State,
@@ -47,10 +65,37 @@ func NewAPI(
 
 	return &API{
 		access:     getState(st, m),
+		resources:  resources,
 		authorizer: authorizer,
 	}, nil
 }
 
+// NewAPIV3 returns a new charm annotator API facade for version 3.
+func NewAPIV3(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv3, error) {
+	api, err := NewAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv3{api}, nil
+}
+
+// NewAPIV2 returns a new charm annotator API facade for version 2.
+func NewAPIV2(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv2, error) {
+	api, err := NewAPIV3(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv2{api}, nil
+}
+
 func (api *API) checkCanRead() error {
 	canRead, err := api.authorizer.HasPermission(permission.ReadAccess, api.access.ModelTag())
 	if err != nil {
@@ -118,6 +163,65 @@ func (api *API) Set(args params.AnnotationsSet) params.ErrorResults {
 	return params.ErrorResults{Results: setErrors}
 }
 
+// WatchAnnotations returns a StringsWatcher that notifies of changes to
+// the annotations of entities in the model. The watcher reports the tags
+// of the changed entities, whose annotations can then be retrieved with
+// Get; the initial event reports all entities that have annotations.
+func (api *API) WatchAnnotations() (params.StringsWatchResult, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.StringsWatchResult{}, errors.Trace(err)
+	}
+	w := api.access.WatchAnnotations()
+	if changes, ok := <-w.Changes(); ok {
+		return params.StringsWatchResult{
+			StringsWatcherId: api.resources.Register(w),
+			Changes:          changes,
+		}, nil
+	}
+	return params.StringsWatchResult{}, watcher.EnsureErr(w)
+}
+
+// WatchAnnotations isn't on the V2 API.
+func (*APIv2) WatchAnnotations(_, _ struct{}) {}
+
+// Query returns the annotations of the entities in the model whose
+// annotations match the given selector, sorted by tag. If kinds are
+// given, only entities of those tag kinds are returned.
+func (api *API) Query(args params.AnnotationsQuery) (params.AnnotationsQueryResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.AnnotationsQueryResults{}, errors.Trace(err)
+	}
+	selector, err := coreannotations.ParseSelector(args.Selector)
+	if err != nil {
+		return params.AnnotationsQueryResults{}, errors.Trace(err)
+	}
+	all, err := api.access.AllAnnotations()
+	if err != nil {
+		return params.AnnotationsQueryResults{}, errors.Trace(err)
+	}
+	kinds := set.NewStrings(args.Kinds...)
+	results := []params.EntityAnnotations{}
+	for tag, annotations := range all {
+		if !kinds.IsEmpty() && !kinds.Contains(tag.Kind()) {
+			continue
+		}
+		if !selector.Matches(annotations) {
+			continue
+		}
+		results = append(results, params.EntityAnnotations{
+			EntityTag:   tag.String(),
+			Annotations: annotations,
+		})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].EntityTag < results[j].EntityTag
+	})
+	return params.AnnotationsQueryResults{Results: results}, nil
+}
+
+// Query isn't on the V3 API.
+func (*APIv3) Query(_, _ struct{}) {}
+
 func annotateError(err error, tag, op string) *params.Error {
 	return apiservererrors.ServerError(
 		errors.Trace(
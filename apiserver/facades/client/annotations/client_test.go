@@ -10,11 +10,13 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facades/client/annotations"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -76,6 +78,86 @@ func (s *annotationSuite) TestApplicationAnnotations(c *gc.C) {
 	s.assertAnnotationsRemoval(c, wordpress.Tag())
 }
 
+func (s *annotationSuite) TestWatchAnnotations(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Jobs: []state.MachineJob{state.JobHostUnits},
+	})
+	s.setupEntity(c, []string{machine.Tag().String()}, map[string]string{"mykey": "myvalue"})
+
+	resources := common.NewResources()
+	s.AddCleanup(func(_ *gc.C) { resources.StopAll() })
+	api, err := annotations.NewAPI(s.State, resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.WatchAnnotations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Changes, jc.SameContents, []string{machine.Tag().String()})
+	c.Assert(resources.Count(), gc.Equals, 1)
+
+	w := resources.Get(result.StringsWatcherId).(state.StringsWatcher)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertNoChange()
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	s.setupEntity(c, []string{model.Tag().String()}, map[string]string{"mykey": "myvalue"})
+	wc.AssertChangeInSingleEvent(model.Tag().String())
+	wc.AssertNoChange()
+}
+
+func (s *annotationSuite) TestWatchAnnotationsPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("fred")
+	api, err := annotations.NewAPI(s.State, common.NewResources(), s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.WatchAnnotations()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *annotationSuite) TestQuery(c *gc.C) {
+	wordpress := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+	unit0 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: wordpress})
+	unit1 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: wordpress})
+	s.setupEntity(c, []string{wordpress.Tag().String(), unit0.Tag().String()}, map[string]string{"env": "prod", "tier": "web"})
+	s.setupEntity(c, []string{unit1.Tag().String()}, map[string]string{"env": "prod", "tier": "db"})
+
+	result, err := s.annotationsAPI.Query(params.AnnotationsQuery{Selector: "env=prod,tier!=web"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, []params.EntityAnnotations{{
+		EntityTag:   unit1.Tag().String(),
+		Annotations: map[string]string{"env": "prod", "tier": "db"},
+	}})
+
+	result, err = s.annotationsAPI.Query(params.AnnotationsQuery{Selector: "env=prod"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].EntityTag, gc.Equals, wordpress.Tag().String())
+
+	result, err = s.annotationsAPI.Query(params.AnnotationsQuery{
+		Selector: "tier=web",
+		Kinds:    []string{names.UnitTagKind},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, []params.EntityAnnotations{{
+		EntityTag:   unit0.Tag().String(),
+		Annotations: map[string]string{"env": "prod", "tier": "web"},
+	}})
+}
+
+func (s *annotationSuite) TestQueryInvalidSelector(c *gc.C) {
+	_, err := s.annotationsAPI.Query(params.AnnotationsQuery{Selector: "env="})
+	c.Assert(err, gc.ErrorMatches, `selector "env=": requirement "env=" with no value not valid`)
+}
+
+func (s *annotationSuite) TestQueryPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("fred")
+	api, err := annotations.NewAPI(s.State, common.NewResources(), s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.Query(params.AnnotationsQuery{Selector: "env=prod"})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *annotationSuite) assertAnnotationsRemoval(c *gc.C, tag names.Tag) {
 	entity := tag.String()
 	entities := params.Entities{[]params.Entity{{entity}}}
@@ -14,6 +14,8 @@ type annotationAccess interface {
 	FindEntity(tag names.Tag) (state.Entity, error)
 	Annotations(entity state.GlobalEntity) (map[string]string, error)
 	SetAnnotations(entity state.GlobalEntity, annotations map[string]string) error
+	WatchAnnotations() state.StringsWatcher
+	AllAnnotations() (map[names.Tag]map[string]string, error)
 }
 
 // TODO - CAAS(externalreality): After all relevant methods are moved from
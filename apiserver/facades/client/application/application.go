@@ -11,6 +11,7 @@ import (
 	"math"
 	"net"
 	"reflect"
+	"sort"
 
 	"github.com/juju/charm/v9"
 	csparams "github.com/juju/charmrepo/v7/csclient/params"
@@ -111,6 +112,24 @@ type APIv12 struct {
 // It adds CharmOrigin. The ApplicationsInfo call populates the exposed
 // endpoints field in its response entries.
 type APIv13 struct {
+	*APIv14
+}
+
+// APIv14 provides the Application API facade for version 14.
+// It adds the DrainUnits and UnitsDrained methods.
+type APIv14 struct {
+	*APIv15
+}
+
+// APIv15 provides the Application API facade for version 15.
+// It adds the UpdateApplicationStorage method.
+type APIv15 struct {
+	*APIv16
+}
+
+// APIv16 provides the Application API facade for version 16.
+// The Consume call accepts the EncryptRelationData flag.
+type APIv16 struct {
 	*APIBase
 }
 
@@ -226,13 +245,37 @@ func NewFacadeV12(ctx facade.Context) (*APIv12, error) {
 }
 
 func NewFacadeV13(ctx facade.Context) (*APIv13, error) {
-	api, err := newFacadeBase(ctx)
+	api, err := NewFacadeV14(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv13{api}, nil
 }
 
+func NewFacadeV14(ctx facade.Context) (*APIv14, error) {
+	api, err := NewFacadeV15(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv14{api}, nil
+}
+
+func NewFacadeV15(ctx facade.Context) (*APIv15, error) {
+	api, err := NewFacadeV16(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv15{api}, nil
+}
+
+func NewFacadeV16(ctx facade.Context) (*APIv16, error) {
+	api, err := newFacadeBase(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv16{api}, nil
+}
+
 type caasBrokerInterface interface {
 	ValidateStorageClass(config map[string]interface{}) error
 	Version() (*version.Number, error)
@@ -495,9 +538,48 @@ func (api *APIBase) Deploy(args params.ApplicationsDeploy) (params.ErrorResults,
 	return result, nil
 }
 
+// iaasConfigFields and caasConfigFields hold the application config
+// fields defined by Juju for each type of model, in addition to trust.
+var (
+	iaasConfigFields = []environschema.Fields{
+		hookRetryFields,
+		firewallModeFields,
+		externalLBFields,
+		crossModelIngressFields,
+		secretOptionsFields,
+		proxyFields,
+	}
+	caasConfigFields = []environschema.Fields{
+		hookRetryFields,
+		trustScopeFields,
+		secretOptionsFields,
+		crossModelIngressFields,
+	}
+)
+
+// addSchemaFields returns a new set of schema fields holding the extra
+// fields and each of the given field sets. It is an error for a field to
+// be defined more than once.
+func addSchemaFields(extra environschema.Fields, fieldSets ...environschema.Fields) (environschema.Fields, error) {
+	fields := make(environschema.Fields)
+	for name, field := range extra {
+		fields[name] = field
+	}
+	for _, fieldSet := range fieldSets {
+		for name, field := range fieldSet {
+			if _, ok := fields[name]; ok {
+				return nil, errors.Errorf("config field %q clashes with common config", name)
+			}
+			fields[name] = field
+		}
+	}
+	return fields, nil
+}
+
 func applicationConfigSchema(modelType state.ModelType) (environschema.Fields, schema.Defaults, error) {
 	if modelType != state.ModelTypeCAAS {
-		return trustFields, trustDefaults, nil
+		configSchema, err := addSchemaFields(trustFields, iaasConfigFields...)
+		return configSchema, trustDefaults, err
 	}
 	// TODO(caas) - get the schema from the provider
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
@@ -505,6 +587,9 @@ func applicationConfigSchema(modelType state.ModelType) (environschema.Fields, s
 	if err != nil {
 		return nil, nil, err
 	}
+	if configSchema, err = addSchemaFields(configSchema, caasConfigFields...); err != nil {
+		return nil, nil, err
+	}
 	return AddTrustSchemaAndDefaults(configSchema, defaults)
 }
 
@@ -688,6 +773,9 @@ func deployApplication(
 	if err := checkMachinePlacement(backend, args); err != nil {
 		return errors.Trace(err)
 	}
+	if err := checkUnitQuota(backend, args.NumUnits); err != nil {
+		return errors.Trace(err)
+	}
 
 	// Try to find the charm URL in state first.
 	ch, err := backend.Charm(curl)
@@ -698,6 +786,15 @@ func deployApplication(
 	if err := jujuversion.CheckJujuMinVersion(ch.Meta().MinJujuVersion, jujuversion.Current); err != nil {
 		return errors.Trace(err)
 	}
+	if err := checkCharmSignature(backend, curl, ch); err != nil {
+		return errors.Trace(err)
+	}
+	if err := checkCharmSandboxProfile(backend, curl, ch); err != nil {
+		return errors.Trace(err)
+	}
+	if err := vetCharm(backend, model, curl, ch, args.ApplicationName); err != nil {
+		return errors.Trace(err)
+	}
 
 	modelType := model.Type()
 	if modelType != state.ModelTypeIAAS {
@@ -865,6 +962,15 @@ func parseCharmSettings(modelType state.ModelType, ch Charm, appName string, con
 	if err != nil {
 		return nil, nil, nil, errors.Trace(err)
 	}
+	if _, err := appConfig.Attributes().HookRetryPolicy(); err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
+	if _, err := appConfig.Attributes().ExternalLBAddresses(); err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
+	if _, err := appConfig.Attributes().CrossModelIngressAddresses(); err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
 
 	charmSettings := make(charm.Settings)
 	if len(charmYamlConfig) > 0 {
@@ -1231,6 +1337,15 @@ func (api *APIBase) setCharmWithAgentValidation(
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := checkCharmSignature(api.backend, curl, newCharm); err != nil {
+		return errors.Trace(err)
+	}
+	if err := checkCharmSandboxProfile(api.backend, curl, newCharm); err != nil {
+		return errors.Trace(err)
+	}
+	if err := vetCharm(api.backend, api.model, curl, newCharm, params.AppName); err != nil {
+		return errors.Trace(err)
+	}
 	oneApplication := params.Application
 	currentCharm, _, err := oneApplication.Charm()
 	if err != nil {
@@ -1671,6 +1786,9 @@ func addApplicationUnits(backend Backend, modelType state.ModelType, args params
 	if args.NumUnits < 1 {
 		return nil, errors.New("must add at least one unit")
 	}
+	if err := checkUnitQuota(backend, args.NumUnits); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	assignUnits := true
 	if modelType != state.ModelTypeIAAS {
@@ -1718,6 +1836,33 @@ func addApplicationUnits(backend Backend, modelType state.ModelType, args params
 	)
 }
 
+// checkUnitQuota returns a QuotaLimitExceeded error if adding numUnits
+// units would take the model over the controller's units per model limit.
+func checkUnitQuota(backend Backend, numUnits int) error {
+	if numUnits < 1 {
+		return nil
+	}
+	cfg, err := backend.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	maxUnits := cfg.MaxUnitsPerModel()
+	if maxUnits == 0 {
+		return nil
+	}
+	count, err := backend.UnitCount()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if count+numUnits > maxUnits {
+		return errors.QuotaLimitExceededf(
+			"adding %d unit(s) to a model with %d units would exceed the controller limit of %d units per model",
+			numUnits, count, maxUnits,
+		)
+	}
+	return nil
+}
+
 // DestroyUnits removes a given set of application units.
 //
 // NOTE(axw) this exists only for backwards compatibility,
@@ -1851,9 +1996,10 @@ func (api *APIBase) DestroyUnit(args params.DestroyUnitsParams) (params.DestroyU
 		}
 		return &info, nil
 	}
+	order := api.unitDestroyOrder(args.Units)
 	results := make([]params.DestroyUnitResult, len(args.Units))
-	for i, entity := range args.Units {
-		info, err := destroyUnit(entity)
+	for _, i := range order {
+		info, err := destroyUnit(args.Units[i])
 		if err != nil {
 			results[i].Error = apiservererrors.ServerError(err)
 			continue
@@ -1863,6 +2009,61 @@ func (api *APIBase) DestroyUnit(args params.DestroyUnitsParams) (params.DestroyU
 	return params.DestroyUnitResults{results}, nil
 }
 
+// unitDestroyOrder returns the order in which the given units should be
+// destroyed, as indices into args. Units holding storage are destroyed
+// first, and application leaders, which charms use to mark the primary
+// holder of shared storage, are destroyed last; this stops clustered
+// workloads from handing their data off to a unit that is itself being
+// removed. Otherwise, the requested order is preserved. State cleanup
+// also holds back removal of a leader's storage while other dying units
+// of its application hold storage, so this ordering only gets the
+// removals under way in the right order.
+func (api *APIBase) unitDestroyOrder(args []params.DestroyUnitParams) []int {
+	order := make([]int, len(args))
+	for i := range order {
+		order[i] = i
+	}
+	if len(args) < 2 {
+		return order
+	}
+	leaders, err := api.leadershipReader.Leaders()
+	if err != nil {
+		logger.Warningf("cannot get application leaders, destroying units in the requested order: %v", err)
+		return order
+	}
+
+	const (
+		storageHolder = iota
+		other
+		primary
+	)
+	rank := make([]int, len(args))
+	for i, arg := range args {
+		rank[i] = other
+		// Invalid units are reported when they are destroyed.
+		unitTag, err := names.ParseUnitTag(arg.UnitTag)
+		if err != nil {
+			continue
+		}
+		appName, err := names.UnitApplication(unitTag.Id())
+		if err != nil {
+			continue
+		}
+		if leaders[appName] == unitTag.Id() {
+			rank[i] = primary
+			continue
+		}
+		unitStorage, err := storagecommon.UnitStorage(api.storageAccess, unitTag)
+		if err == nil && len(unitStorage) > 0 {
+			rank[i] = storageHolder
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return rank[order[i]] < rank[order[j]]
+	})
+	return order
+}
+
 // Destroy destroys a given application, local or remote.
 //
 // NOTE(axw) this exists only for backwards compatibility,
@@ -2089,6 +2290,15 @@ func (api *APIBase) ScaleApplications(args params.ScaleApplicationsParams) (para
 			}
 		}
 
+		// Only an increase in scale adds units.
+		numUnits := arg.ScaleChange
+		if arg.ScaleChange == 0 {
+			numUnits = arg.Scale - app.GetScale()
+		}
+		if err := checkUnitQuota(api.backend, numUnits); err != nil {
+			return nil, errors.Trace(err)
+		}
+
 		var info params.ScaleApplicationInfo
 		if arg.ScaleChange != 0 {
 			newScale, err := app.ChangeScale(arg.ScaleChange)
@@ -2353,7 +2563,7 @@ func (api *APIBase) consumeOne(arg params.ConsumeApplicationArg) error {
 	if appName == "" {
 		appName = arg.OfferName
 	}
-	_, err = api.saveRemoteApplication(sourceModelTag, appName, arg.ApplicationOfferDetails, arg.Macaroon)
+	_, err = api.saveRemoteApplication(sourceModelTag, appName, arg.ApplicationOfferDetails, arg.Macaroon, arg.EncryptRelationData)
 	return err
 }
 
@@ -2364,6 +2574,7 @@ func (api *APIBase) saveRemoteApplication(
 	applicationName string,
 	offer params.ApplicationOfferDetails,
 	mac *macaroon.Macaroon,
+	encryptRelationData bool,
 ) (RemoteApplication, error) {
 	remoteEps := make([]charm.Relation, len(offer.Endpoints))
 	for j, ep := range offer.Endpoints {
@@ -2389,14 +2600,15 @@ func (api *APIBase) saveRemoteApplication(
 	}
 
 	return api.backend.AddRemoteApplication(state.AddRemoteApplicationParams{
-		Name:        applicationName,
-		OfferUUID:   offer.OfferUUID,
-		URL:         offer.OfferURL,
-		SourceModel: sourceModelTag,
-		Endpoints:   remoteEps,
-		Spaces:      remoteSpaces,
-		Bindings:    offer.Bindings,
-		Macaroon:    mac,
+		Name:                applicationName,
+		OfferUUID:           offer.OfferUUID,
+		URL:                 offer.OfferURL,
+		SourceModel:         sourceModelTag,
+		Endpoints:           remoteEps,
+		Spaces:              remoteSpaces,
+		Bindings:            offer.Bindings,
+		Macaroon:            mac,
+		EncryptRelationData: encryptRelationData,
 	})
 }
 
@@ -2801,6 +3013,7 @@ func (api *APIBase) ApplicationsInfo(in params.Entities) (params.ApplicationInfo
 
 		out[i].Result = &params.ApplicationResult{
 			Tag:              tag.String(),
+			UUID:             app.UUID(),
 			Charm:            details.Charm,
 			Series:           details.Series,
 			Channel:          details.Channel,
@@ -3020,6 +3233,7 @@ func (api *APIBase) UnitsInfo(in params.Entities) (params.UnitInfoResults, error
 
 		result := &params.UnitResult{
 			Tag:             tag.String(),
+			UUID:            unit.UUID(),
 			WorkloadVersion: workloadVersion,
 			Machine:         machineId,
 			Charm:           curl.String(),
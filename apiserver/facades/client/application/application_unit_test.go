@@ -4,6 +4,9 @@
 package application_test
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
 	"strings"
 	"time"
@@ -25,9 +28,8 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/application"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
-	"github.com/juju/juju/caas"
-	k8s "github.com/juju/juju/caas/kubernetes/provider"
 	k8sconstants "github.com/juju/juju/caas/kubernetes/provider/constants"
+	"github.com/juju/juju/controller"
 	coreapplication "github.com/juju/juju/core/application"
 	corecharm "github.com/juju/juju/core/charm"
 	"github.com/juju/juju/core/constraints"
@@ -62,7 +64,7 @@ type ApplicationSuite struct {
 	env          environs.Environ
 	blockChecker mockBlockChecker
 	authorizer   apiservertesting.FakeAuthorizer
-	api          *application.APIv13
+	api          *application.APIv15
 	deployParams map[string]application.DeployApplicationParams
 }
 
@@ -93,12 +95,13 @@ func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		s.caasBroker,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &application.APIv13{api}
+	s.api = &application.APIv15{&application.APIv16{api}}
 }
 
 func (s *ApplicationSuite) SetUpTest(c *gc.C) {
 	s.IsolationSuite.SetUpTest(c)
 	s.JujuOSEnvSuite.SetUpTest(c)
+	s.leadership = mockLeadership{}
 	agentTools := &tools.Tools{
 		Version: version.Binary{
 			Number: version.Number{Major: 2, Minor: 6, Patch: 0},
@@ -134,6 +137,7 @@ func (s *ApplicationSuite) SetUpTest(c *gc.C) {
 		applications: map[string]*mockApplication{
 			"postgresql": {
 				name:        "postgresql",
+				uuid:        "deadbeef-0bad-400d-8000-4b1d0d06f00d",
 				series:      "quantal",
 				subordinate: false,
 				curl:        charm.MustParseURL("cs:postgresql-42"),
@@ -150,6 +154,7 @@ func (s *ApplicationSuite) SetUpTest(c *gc.C) {
 				units: []*mockUnit{
 					{
 						name:       "postgresql/0",
+						uuid:       "deadbeef-0bad-400d-8000-4b1d0d06f00e",
 						tag:        names.NewUnitTag("postgresql/0"),
 						machineId:  "0",
 						agentTools: agentTools,
@@ -385,7 +390,7 @@ func (s *ApplicationSuite) TestUpdateCAASApplicationSettings(c *gc.C) {
 		ApplicationName: "postgresql",
 		SettingsYAML:    "postgresql:\n  stringOption: bar\n  juju-external-hostname: foo",
 	}
-	api := &application.APIv12{s.api}
+	api := &application.APIv12{&application.APIv13{s.api}}
 	err := api.Update(args)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -394,10 +399,7 @@ func (s *ApplicationSuite) TestUpdateCAASApplicationSettings(c *gc.C) {
 		"stringOption": "bar",
 	})
 
-	appDefaults := caas.ConfigDefaults(k8s.ConfigDefaults())
-	appCfgSchema, err := caas.ConfigSchema(k8s.ConfigSchema())
-	c.Assert(err, jc.ErrorIsNil)
-	appCfgSchema, appDefaults, err = application.AddTrustSchemaAndDefaults(appCfgSchema, appDefaults)
+	appCfgSchema, appDefaults, err := application.ApplicationConfigSchema(state.ModelTypeCAAS)
 	c.Assert(err, jc.ErrorIsNil)
 
 	appCfg, err := coreapplication.NewConfig(map[string]interface{}{
@@ -433,10 +435,7 @@ func (s *ApplicationSuite) TestSetCAASConfigSettings(c *gc.C) {
 		"stringOption": "bar",
 	})
 
-	appDefaults := caas.ConfigDefaults(k8s.ConfigDefaults())
-	appCfgSchema, err := caas.ConfigSchema(k8s.ConfigSchema())
-	c.Assert(err, jc.ErrorIsNil)
-	appCfgSchema, appDefaults, err = application.AddTrustSchemaAndDefaults(appCfgSchema, appDefaults)
+	appCfgSchema, appDefaults, err := application.ApplicationConfigSchema(state.ModelTypeCAAS)
 	c.Assert(err, jc.ErrorIsNil)
 
 	appCfg, err := coreapplication.NewConfig(map[string]interface{}{
@@ -463,7 +462,7 @@ func (s *ApplicationSuite) TestUpdateCAASApplicationSettingsInIAASModelTriggersE
 		ApplicationName: "postgresql",
 		SettingsYAML:    "postgresql:\n  stringOption: bar\n  juju-external-hostname: foo",
 	}
-	api := &application.APIv12{s.api}
+	api := &application.APIv12{&application.APIv13{s.api}}
 	err := api.Update(args)
 	c.Assert(err, gc.ErrorMatches, `.*unknown option "juju-external-hostname"`, gc.Commentf("expected to get an error when attempting to set CAAS-specific app setting in IAAS model"))
 }
@@ -849,26 +848,95 @@ func (s *ApplicationSuite) assertDestroyUnit(c *gc.C, force bool, maxWait *time.
 		Info: &params.DestroyUnitInfo{},
 	}})
 
+	// postgresql/0 is the leader, so it is destroyed last.
 	s.backend.CheckCallNames(c,
+		"UnitStorageAttachments",
 		"Unit",
 		"Application",
 		"UnitStorageAttachments",
+		"ApplyOperation",
+		"Unit",
+		"UnitStorageAttachments",
 		"StorageInstance",
 		"StorageInstance",
 		"StorageInstanceFilesystem",
 		"StorageInstanceFilesystem",
 		"ApplyOperation",
-		"Unit",
-		"UnitStorageAttachments",
-		"ApplyOperation",
 	)
+	s.backend.CheckCall(c, 4, "ApplyOperation", &state.DestroyUnitOperation{
+		DestroyStorage: true,
+	})
 	expectedOp := &state.DestroyUnitOperation{ForcedOperation: state.ForcedOperation{Force: force}}
 	if force {
 		expectedOp.MaxWait = common.MaxWait(maxWait)
 	}
-	s.backend.CheckCall(c, 7, "ApplyOperation", expectedOp)
-	s.backend.CheckCall(c, 10, "ApplyOperation", &state.DestroyUnitOperation{
-		DestroyStorage: true,
+	s.backend.CheckCall(c, 11, "ApplyOperation", expectedOp)
+}
+
+func (s *ApplicationSuite) TestDestroyUnitOrder(c *gc.C) {
+	s.backend.unitStorageAttachments["postgresql/1"] = []state.StorageAttachment{
+		&mockStorageAttachment{
+			unit:    names.NewUnitTag("postgresql/1"),
+			storage: names.NewStorageTag("pgdata/0"),
+		},
+	}
+
+	results, err := s.api.DestroyUnit(params.DestroyUnitsParams{
+		Units: []params.DestroyUnitParams{
+			{UnitTag: "unit-postgresql-0"},
+			{UnitTag: "unit-redis-0"},
+			{UnitTag: "unit-postgresql-1"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	for _, result := range results.Results {
+		c.Assert(result.Error, gc.IsNil)
+	}
+
+	// Storage holders are destroyed first, and the leader last.
+	var destroyed []interface{}
+	for _, call := range s.backend.Calls() {
+		if call.FuncName == "Unit" {
+			destroyed = append(destroyed, call.Args...)
+		}
+	}
+	c.Assert(destroyed, jc.DeepEquals, []interface{}{
+		"postgresql/1", "redis/0", "postgresql/0",
+	})
+}
+
+func (s *ApplicationSuite) TestDestroyUnitOrderLeadersError(c *gc.C) {
+	s.leadership.err = errors.New("boom")
+	s.backend.unitStorageAttachments["postgresql/1"] = []state.StorageAttachment{
+		&mockStorageAttachment{
+			unit:    names.NewUnitTag("postgresql/1"),
+			storage: names.NewStorageTag("pgdata/0"),
+		},
+	}
+
+	results, err := s.api.DestroyUnit(params.DestroyUnitsParams{
+		Units: []params.DestroyUnitParams{
+			{UnitTag: "unit-postgresql-0"},
+			{UnitTag: "unit-redis-0"},
+			{UnitTag: "unit-postgresql-1"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	for _, result := range results.Results {
+		c.Assert(result.Error, gc.IsNil)
+	}
+
+	// Without leadership, units are destroyed in the requested order.
+	var destroyed []interface{}
+	for _, call := range s.backend.Calls() {
+		if call.FuncName == "Unit" {
+			destroyed = append(destroyed, call.Args...)
+		}
+	}
+	c.Assert(destroyed, jc.DeepEquals, []interface{}{
+		"postgresql/0", "redis/0", "postgresql/1",
 	})
 }
 
@@ -1182,6 +1250,269 @@ func (s *ApplicationSuite) TestAddUnits(c *gc.C) {
 	app.addedUnit.CheckCall(c, 0, "AssignWithPolicy", state.AssignCleanEmpty)
 }
 
+func (s *ApplicationSuite) TestAddUnitsQuotaExceeded(c *gc.C) {
+	s.backend.controllerCfg = map[string]interface{}{
+		controller.MaxUnitsPerModel: 10,
+	}
+	s.backend.unitCount = 9
+	_, err := s.api.AddUnits(params.AddApplicationUnits{
+		ApplicationName: "postgresql",
+		NumUnits:        2,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsQuotaLimitExceeded)
+	c.Assert(err, gc.ErrorMatches, `adding 2 unit\(s\) to a model with 9 units would exceed the controller limit of 10 units per model`)
+	app := s.backend.applications["postgresql"]
+	for _, call := range app.Calls() {
+		c.Assert(call.FuncName, gc.Not(gc.Equals), "AddUnit")
+	}
+}
+
+func (s *ApplicationSuite) TestAddUnitsWithinQuota(c *gc.C) {
+	s.backend.controllerCfg = map[string]interface{}{
+		controller.MaxUnitsPerModel: 10,
+	}
+	s.backend.unitCount = 9
+	results, err := s.api.AddUnits(params.AddApplicationUnits{
+		ApplicationName: "postgresql",
+		NumUnits:        1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Units, gc.HasLen, 1)
+}
+
+func (s *ApplicationSuite) TestDeployQuotaExceeded(c *gc.C) {
+	s.backend.controllerCfg = map[string]interface{}{
+		controller.MaxUnitsPerModel: 10,
+	}
+	s.backend.unitCount = 10
+	args := params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			CharmOrigin:     &params.CharmOrigin{Source: "local"},
+			NumUnits:        1,
+		}, {
+			ApplicationName: "bar",
+			CharmURL:        "local:bar-1",
+			CharmOrigin:     &params.CharmOrigin{Source: "local"},
+		}},
+	}
+	results, err := s.api.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeQuotaLimitExceeded)
+	c.Assert(results.Results[1].Error, gc.IsNil)
+}
+
+func (s *ApplicationSuite) TestDeployCharmVettingRejected(c *gc.C) {
+	var vetted map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(json.NewDecoder(r.Body).Decode(&vetted), jc.ErrorIsNil)
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "charm not on the allow list"}`))
+	}))
+	defer srv.Close()
+	s.backend.controllerCfg = map[string]interface{}{
+		controller.CharmVettingWebhookURL: srv.URL,
+	}
+
+	args := params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			CharmOrigin:     &params.CharmOrigin{Source: "local"},
+			NumUnits:        1,
+		}},
+	}
+	results, err := s.api.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeForbidden)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches,
+		`charm "local:foo-0" rejected by charm vetting webhook: charm not on the allow list`)
+	c.Assert(vetted["application-name"], gc.Equals, "foo")
+	c.Assert(vetted["charm-url"], gc.Equals, "local:foo-0")
+	c.Assert(vetted["model-uuid"], gc.Equals, s.model.UUID())
+	c.Assert(vetted["meta"], gc.NotNil)
+}
+
+func (s *ApplicationSuite) TestDeployCharmVettingAllowed(c *gc.C) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+	s.backend.controllerCfg = map[string]interface{}{
+		controller.CharmVettingWebhookURL: srv.URL,
+	}
+
+	args := params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			CharmOrigin:     &params.CharmOrigin{Source: "local"},
+			NumUnits:        1,
+		}},
+	}
+	results, err := s.api.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+}
+
+func (s *ApplicationSuite) TestDeployCharmSignatureRequired(c *gc.C) {
+	s.backend.controllerCfg = map[string]interface{}{
+		controller.CharmSignaturePolicy: controller.CharmSignaturePolicyRequire,
+		controller.CharmSigningKeys:     "-----BEGIN PUBLIC KEY-----",
+	}
+
+	args := params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			CharmOrigin:     &params.CharmOrigin{Source: "local"},
+			NumUnits:        1,
+		}},
+	}
+	results, err := s.api.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeForbidden)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches,
+		`charm "local:foo-0" has no verified signature, required by controller policy`)
+
+	s.backend.charm.signature = &state.CharmSignature{Format: "pgp", KeyID: "0123456789ABCDEF"}
+	results, err = s.api.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+}
+
+func (s *ApplicationSuite) TestSetCharmSignatureRequired(c *gc.C) {
+	s.backend.controllerCfg = map[string]interface{}{
+		controller.CharmSignaturePolicy: controller.CharmSignaturePolicyRequire,
+		controller.CharmSigningKeys:     "-----BEGIN PUBLIC KEY-----",
+	}
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsForbidden)
+	c.Assert(err, gc.ErrorMatches, `charm "cs:postgresql" has no verified signature, required by controller policy`)
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestDeployCharmSandboxProfileNotAllowed(c *gc.C) {
+	s.backend.charm.sandbox = &corecharm.SandboxProfile{
+		Sysctls: map[string]string{"vm.max_map_count": "262144"},
+	}
+
+	args := params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			CharmOrigin:     &params.CharmOrigin{Source: "local"},
+			NumUnits:        1,
+		}},
+	}
+	results, err := s.api.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeForbidden)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches,
+		`charm "local:foo-0" sandbox profile: sysctl "vm.max_map_count" is not allowed by the controller`)
+
+	s.backend.controllerCfg = map[string]interface{}{
+		controller.CharmSandboxSysctls: []interface{}{"vm.*"},
+	}
+	results, err = s.api.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+}
+
+func (s *ApplicationSuite) TestSetCharmSandboxProfileNotAllowed(c *gc.C) {
+	s.backend.charm.sandbox = &corecharm.SandboxProfile{
+		AppArmorProfiles: map[string]string{"worker": "profile worker {}"},
+	}
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsForbidden)
+	c.Assert(err, gc.ErrorMatches, `charm "cs:postgresql" sandbox profile: AppArmor profiles are not allowed by the controller`)
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestDeployCharmVettingUnavailable(c *gc.C) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	s.backend.controllerCfg = map[string]interface{}{
+		controller.CharmVettingWebhookURL: srv.URL,
+	}
+
+	args := params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			CharmOrigin:     &params.CharmOrigin{Source: "local"},
+			NumUnits:        1,
+		}},
+	}
+	results, err := s.api.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeTryAgain)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches,
+		`cannot vet charm "local:foo-0", charm vetting webhook unavailable: 503 Service Unavailable: try again`)
+}
+
+func (s *ApplicationSuite) TestDeployCharmVettingUnreachable(c *gc.C) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.Close()
+	s.backend.controllerCfg = map[string]interface{}{
+		controller.CharmVettingWebhookURL: srv.URL,
+	}
+
+	args := params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			CharmOrigin:     &params.CharmOrigin{Source: "local"},
+			NumUnits:        1,
+		}},
+	}
+	results, err := s.api.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeTryAgain)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches,
+		`cannot vet charm "local:foo-0", charm vetting webhook unavailable: .*`)
+}
+
+func (s *ApplicationSuite) TestSetCharmVettingRejected(c *gc.C) {
+	var vetted map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(json.NewDecoder(r.Body).Decode(&vetted), jc.ErrorIsNil)
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+	s.backend.controllerCfg = map[string]interface{}{
+		controller.CharmVettingWebhookURL: srv.URL,
+	}
+
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsForbidden)
+	c.Assert(err, gc.ErrorMatches, `charm "cs:postgresql" rejected by charm vetting webhook: 403 Forbidden`)
+	c.Assert(vetted["application-name"], gc.Equals, "postgresql")
+	c.Assert(vetted["charm-url"], gc.Equals, "cs:postgresql")
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
 func (s *ApplicationSuite) TestAddUnitsCAASModel(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
 	_, err := s.api.AddUnits(params.AddApplicationUnits{
@@ -1224,7 +1555,52 @@ func (s *ApplicationSuite) TestScaleApplicationsCAASModel(c *gc.C) {
 		}},
 	})
 	app := s.backend.applications["postgresql"]
-	app.CheckCall(c, 1, "Scale", 5)
+	app.CheckCall(c, 2, "Scale", 5)
+}
+
+func (s *ApplicationSuite) TestScaleApplicationsQuotaExceeded(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	s.backend.controllerCfg = map[string]interface{}{
+		controller.MaxUnitsPerModel: 10,
+	}
+	s.backend.unitCount = 8
+	s.backend.applications["postgresql"].scale = 2
+	results, err := s.api.ScaleApplications(params.ScaleApplicationsParams{
+		Applications: []params.ScaleApplicationParams{{
+			ApplicationTag: "application-postgresql",
+			Scale:          5,
+		}, {
+			ApplicationTag: "application-postgresql",
+			ScaleChange:    3,
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeQuotaLimitExceeded)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `adding 3 unit\(s\) to a model with 8 units would exceed the controller limit of 10 units per model`)
+	c.Assert(results.Results[1].Error, jc.Satisfies, params.IsCodeQuotaLimitExceeded)
+	app := s.backend.applications["postgresql"]
+	for _, call := range app.Calls() {
+		c.Assert(call.FuncName, gc.Not(gc.Equals), "Scale")
+		c.Assert(call.FuncName, gc.Not(gc.Equals), "ChangeScale")
+	}
+}
+
+func (s *ApplicationSuite) TestScaleApplicationsDownIgnoresQuota(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	s.backend.controllerCfg = map[string]interface{}{
+		controller.MaxUnitsPerModel: 10,
+	}
+	s.backend.unitCount = 12
+	s.backend.applications["postgresql"].scale = 4
+	results, err := s.api.ScaleApplications(params.ScaleApplicationsParams{
+		Applications: []params.ScaleApplicationParams{{
+			ApplicationTag: "application-postgresql",
+			Scale:          2,
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Info, jc.DeepEquals, &params.ScaleApplicationInfo{Scale: 2})
 }
 
 func (s *ApplicationSuite) TestScaleApplicationsNotAllowedForOperator(c *gc.C) {
@@ -1496,6 +1872,26 @@ func (s *ApplicationSuite) TestConsumeIdempotent(c *gc.C) {
 	})
 }
 
+func (s *ApplicationSuite) TestConsumeEncryptRelationData(c *gc.C) {
+	results, err := s.api.Consume(params.ConsumeApplicationArgs{
+		Args: []params.ConsumeApplicationArg{{
+			ApplicationOfferDetails: params.ApplicationOfferDetails{
+				SourceModelTag: coretesting.ModelTag.String(),
+				OfferName:      "hosted-mysql",
+				OfferUUID:      "hosted-mysql-uuid",
+				Endpoints:      []params.RemoteEndpoint{{Name: "database", Interface: "mysql", Role: "provider"}},
+				OfferURL:       "othermodel.hosted-mysql",
+			},
+			EncryptRelationData: true,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.IsNil)
+	obtained, ok := s.backend.remoteApplications["hosted-mysql"]
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(obtained.(*mockRemoteApplication).encrypt, jc.IsTrue)
+}
+
 func (s *ApplicationSuite) TestConsumeFromExternalController(c *gc.C) {
 	mac, err := apitesting.NewMacaroon("test")
 	c.Assert(err, jc.ErrorIsNil)
@@ -1848,9 +2244,54 @@ func (s *ApplicationSuite) TestSetApplicationConfigEmptyUsesMaster(c *gc.C) {
 	s.testSetApplicationConfig(c, "")
 }
 
+func (s *ApplicationSuite) TestSetApplicationConfigInvalidHookRetry(c *gc.C) {
+	api := &application.APIv12{&application.APIv13{s.api}}
+	result, err := api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"hook-retry-hooks": "install bogus",
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `parsing settings for application: hook-retry-hooks hook "bogus" not valid`)
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "Charm", "Name")
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigInvalidFirewallMode(c *gc.C) {
+	api := &application.APIv12{&application.APIv13{s.api}}
+	result, err := api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"firewall-mode": "sometimes",
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `parsing settings for application: firewall-mode: expected one of \[instance global none\], got "sometimes"`)
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "Charm", "Name")
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigInvalidExternalLBAddresses(c *gc.C) {
+	api := &application.APIv12{&application.APIv13{s.api}}
+	result, err := api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"external-lb-addresses": "db=lb.example.com",
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `parsing settings for application: external-lb-addresses address "lb.example.com" for endpoint "db" not valid`)
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "Charm", "Name")
+}
+
 func (s *ApplicationSuite) testSetApplicationConfig(c *gc.C, branchName string) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
-	api := &application.APIv12{s.api}
+	api := &application.APIv12{&application.APIv13{s.api}}
 	result, err := api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
 		Args: []params.ApplicationConfigSet{{
 			ApplicationName: "postgresql",
@@ -1866,10 +2307,7 @@ func (s *ApplicationSuite) testSetApplicationConfig(c *gc.C, branchName string)
 	app := s.backend.applications["postgresql"]
 	app.CheckCallNames(c, "Charm", "Name", "UpdateCharmConfig", "UpdateApplicationConfig")
 
-	appCfgSchema, err := caas.ConfigSchema(k8s.ConfigSchema())
-	c.Assert(err, jc.ErrorIsNil)
-	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
-	appCfgSchema, defaults, err = application.AddTrustSchemaAndDefaults(appCfgSchema, defaults)
+	appCfgSchema, defaults, err := application.ApplicationConfigSchema(state.ModelTypeCAAS)
 	c.Assert(err, jc.ErrorIsNil)
 
 	appCfg, err := coreapplication.NewConfig(map[string]interface{}{
@@ -1886,7 +2324,7 @@ func (s *ApplicationSuite) testSetApplicationConfig(c *gc.C, branchName string)
 
 func (s *ApplicationSuite) TestSetApplicationConfigBranch(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
-	api := &application.APIv12{s.api}
+	api := &application.APIv12{&application.APIv13{s.api}}
 	result, err := api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
 		Args: []params.ApplicationConfigSet{{
 			ApplicationName: "postgresql",
@@ -1902,10 +2340,7 @@ func (s *ApplicationSuite) TestSetApplicationConfigBranch(c *gc.C) {
 	app := s.backend.applications["postgresql"]
 	app.CheckCallNames(c, "Charm", "Name", "UpdateCharmConfig", "UpdateApplicationConfig", "Name")
 
-	appCfgSchema, err := caas.ConfigSchema(k8s.ConfigSchema())
-	c.Assert(err, jc.ErrorIsNil)
-	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
-	appCfgSchema, defaults, err = application.AddTrustSchemaAndDefaults(appCfgSchema, defaults)
+	appCfgSchema, defaults, err := application.ApplicationConfigSchema(state.ModelTypeCAAS)
 	c.Assert(err, jc.ErrorIsNil)
 
 	appCfg, err := coreapplication.NewConfig(map[string]interface{}{
@@ -1936,10 +2371,7 @@ func (s *ApplicationSuite) TestSetConfigBranch(c *gc.C) {
 	app := s.backend.applications["postgresql"]
 	app.CheckCallNames(c, "Charm", "Name", "UpdateCharmConfig", "UpdateApplicationConfig", "Name")
 
-	appCfgSchema, err := caas.ConfigSchema(k8s.ConfigSchema())
-	c.Assert(err, jc.ErrorIsNil)
-	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
-	appCfgSchema, defaults, err = application.AddTrustSchemaAndDefaults(appCfgSchema, defaults)
+	appCfgSchema, defaults, err := application.ApplicationConfigSchema(state.ModelTypeCAAS)
 	c.Assert(err, jc.ErrorIsNil)
 
 	appCfg, err := coreapplication.NewConfig(map[string]interface{}{
@@ -1955,7 +2387,7 @@ func (s *ApplicationSuite) TestSetConfigBranch(c *gc.C) {
 
 func (s *ApplicationSuite) TestBlockSetApplicationConfig(c *gc.C) {
 	s.blockChecker.SetErrors(errors.New("blocked"))
-	api := &application.APIv12{s.api}
+	api := &application.APIv12{&application.APIv13{s.api}}
 	_, err := api.SetApplicationsConfig(params.ApplicationConfigSetArgs{})
 	c.Assert(err, gc.ErrorMatches, "blocked")
 	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
@@ -1964,7 +2396,7 @@ func (s *ApplicationSuite) TestBlockSetApplicationConfig(c *gc.C) {
 
 func (s *ApplicationSuite) TestSetApplicationConfigPermissionDenied(c *gc.C) {
 	s.setAPIUser(c, names.NewUserTag("fred"))
-	api := &application.APIv12{s.api}
+	api := &application.APIv12{&application.APIv13{s.api}}
 	_, err := api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
 		Args: []params.ApplicationConfigSet{{
 			ApplicationName: "postgresql",
@@ -1988,10 +2420,7 @@ func (s *ApplicationSuite) TestUnsetApplicationConfig(c *gc.C) {
 	app := s.backend.applications["postgresql"]
 	app.CheckCallNames(c, "UpdateApplicationConfig", "UpdateCharmConfig")
 
-	schema, err := caas.ConfigSchema(k8s.ConfigSchema())
-	c.Assert(err, jc.ErrorIsNil)
-	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
-	schema, defaults, err = application.AddTrustSchemaAndDefaults(schema, defaults)
+	schema, defaults, err := application.ApplicationConfigSchema(state.ModelTypeCAAS)
 	c.Assert(err, jc.ErrorIsNil)
 
 	app.CheckCall(c, 0, "UpdateApplicationConfig", coreapplication.ConfigAttributes(nil),
@@ -2073,6 +2502,101 @@ func (s *ApplicationSuite) TestResolveUnitErrorsPermissionDenied(c *gc.C) {
 	s.application.CheckNoCalls(c)
 }
 
+func (s *ApplicationSuite) TestDrainUnits(c *gc.C) {
+	result, err := s.api.DrainUnits(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-postgresql-0"},
+		{Tag: "unit-postgresql-42"},
+		{Tag: "application-postgresql"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: `unit "postgresql/42" does not exist`}},
+		{Error: &params.Error{Message: `"application-postgresql" is not a valid unit tag`}},
+	}})
+	s.blockChecker.CheckCallNames(c, "RemoveAllowed")
+	s.backend.applications["postgresql"].units[0].CheckCallNames(c, "RequestDrain")
+}
+
+func (s *ApplicationSuite) TestBlockDrainUnits(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.DrainUnits(params.Entities{Entities: []params.Entity{{Tag: "unit-postgresql-0"}}})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.backend.applications["postgresql"].units[0].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestDrainUnitsPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.DrainUnits(params.Entities{Entities: []params.Entity{{Tag: "unit-postgresql-0"}}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.applications["postgresql"].units[0].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestUnitsDrained(c *gc.C) {
+	s.backend.applications["postgresql"].units[0].drain = state.UnitDrainCompleted
+	s.backend.applications["postgresql"].units[1].drain = state.UnitDrainRequested
+	result, err := s.api.UnitsDrained(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-postgresql-0"},
+		{Tag: "unit-postgresql-1"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.BoolResults{Results: []params.BoolResult{
+		{Result: true},
+		{Result: false},
+	}})
+}
+
+func (s *ApplicationSuite) TestUpdateApplicationStorage(c *gc.C) {
+	size := uint64(2048)
+	result, err := s.api.UpdateApplicationStorage(params.ApplicationStorageUpdateArgs{
+		Args: []params.ApplicationStorageUpdate{{
+			ApplicationTag: "application-postgresql",
+			StorageConstraints: map[string]params.StorageConstraints{
+				"pgdata": {Pool: "ebs", Size: &size},
+			},
+		}, {
+			ApplicationTag: "application-postgresql",
+		}, {
+			ApplicationTag: "unit-postgresql-0",
+			StorageConstraints: map[string]params.StorageConstraints{
+				"pgdata": {Pool: "ebs"},
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "empty storage constraints not valid", Code: params.CodeNotValid}},
+		{Error: &params.Error{Message: `"unit-postgresql-0" is not a valid application tag`}},
+	}})
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "UpdateStorageConstraints")
+	app.CheckCall(c, 0, "UpdateStorageConstraints", map[string]state.StorageConstraints{
+		"pgdata": {Pool: "ebs", Size: 2048},
+	})
+}
+
+func (s *ApplicationSuite) TestBlockUpdateApplicationStorage(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.UpdateApplicationStorage(params.ApplicationStorageUpdateArgs{
+		Args: []params.ApplicationStorageUpdate{{
+			ApplicationTag: "application-postgresql",
+			StorageConstraints: map[string]params.StorageConstraints{
+				"pgdata": {Pool: "ebs"},
+			},
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestUpdateApplicationStoragePermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.UpdateApplicationStorage(params.ApplicationStorageUpdateArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *ApplicationSuite) TestCAASExposeWithoutHostname(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
 	err := s.api.Expose(params.ApplicationExpose{
@@ -2101,6 +2625,7 @@ func (s *ApplicationSuite) TestApplicationsInfoOne(c *gc.C) {
 	c.Assert(result.Results, gc.HasLen, len(entities))
 	c.Assert(*result.Results[0].Result, gc.DeepEquals, params.ApplicationResult{
 		Tag:         "application-postgresql",
+		UUID:        "deadbeef-0bad-400d-8000-4b1d0d06f00d",
 		Charm:       "charm-postgresql",
 		Series:      "quantal",
 		Channel:     "development",
@@ -2111,7 +2636,7 @@ func (s *ApplicationSuite) TestApplicationsInfoOne(c *gc.C) {
 		},
 	})
 	app := s.backend.applications["postgresql"]
-	app.CheckCallNames(c, "CharmConfig", "Charm", "ApplicationConfig", "IsPrincipal", "Constraints", "EndpointBindings", "Series", "Channel", "EndpointBindings", "ExposedEndpoints", "IsPrincipal", "IsExposed", "IsRemote")
+	app.CheckCallNames(c, "CharmConfig", "Charm", "ApplicationConfig", "IsPrincipal", "Constraints", "EndpointBindings", "Series", "Channel", "EndpointBindings", "ExposedEndpoints", "UUID", "IsPrincipal", "IsExposed", "IsRemote")
 }
 
 func (s *ApplicationSuite) TestApplicationsInfoOneWithExposedEndpoints(c *gc.C) {
@@ -2135,6 +2660,7 @@ func (s *ApplicationSuite) TestApplicationsInfoOneWithExposedEndpoints(c *gc.C)
 	c.Assert(result.Results, gc.HasLen, len(entities))
 	c.Assert(*result.Results[0].Result, gc.DeepEquals, params.ApplicationResult{
 		Tag:         "application-postgresql",
+		UUID:        "deadbeef-0bad-400d-8000-4b1d0d06f00d",
 		Charm:       "charm-postgresql",
 		Series:      "quantal",
 		Channel:     "development",
@@ -2150,7 +2676,7 @@ func (s *ApplicationSuite) TestApplicationsInfoOneWithExposedEndpoints(c *gc.C)
 			},
 		},
 	})
-	app.CheckCallNames(c, "CharmConfig", "Charm", "ApplicationConfig", "IsPrincipal", "Constraints", "EndpointBindings", "Series", "Channel", "EndpointBindings", "ExposedEndpoints", "IsPrincipal", "IsExposed", "IsRemote")
+	app.CheckCallNames(c, "CharmConfig", "Charm", "ApplicationConfig", "IsPrincipal", "Constraints", "EndpointBindings", "Series", "Channel", "EndpointBindings", "ExposedEndpoints", "UUID", "IsPrincipal", "IsExposed", "IsRemote")
 }
 
 func (s *ApplicationSuite) TestApplicationsInfoDetailsErr(c *gc.C) {
@@ -2189,6 +2715,7 @@ func (s *ApplicationSuite) TestApplicationsInfoMany(c *gc.C) {
 	c.Assert(result.Results, gc.HasLen, len(entities))
 	c.Assert(*result.Results[0].Result, gc.DeepEquals, params.ApplicationResult{
 		Tag:         "application-postgresql",
+		UUID:        "deadbeef-0bad-400d-8000-4b1d0d06f00d",
 		Charm:       "charm-postgresql",
 		Series:      "quantal",
 		Channel:     "development",
@@ -2201,7 +2728,7 @@ func (s *ApplicationSuite) TestApplicationsInfoMany(c *gc.C) {
 	c.Assert(result.Results[1].Error, gc.ErrorMatches, `application "wordpress" not found`)
 	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"unit-postgresql-0" is not a valid application tag`)
 	app := s.backend.applications["postgresql"]
-	app.CheckCallNames(c, "CharmConfig", "Charm", "ApplicationConfig", "IsPrincipal", "Constraints", "EndpointBindings", "Series", "Channel", "EndpointBindings", "ExposedEndpoints", "IsPrincipal", "IsExposed", "IsRemote")
+	app.CheckCallNames(c, "CharmConfig", "Charm", "ApplicationConfig", "IsPrincipal", "Constraints", "EndpointBindings", "Series", "Channel", "EndpointBindings", "ExposedEndpoints", "UUID", "IsPrincipal", "IsExposed", "IsRemote")
 }
 
 func (s *ApplicationSuite) TestApplicationMergeBindingsErr(c *gc.C) {
@@ -2234,6 +2761,7 @@ func (s *ApplicationSuite) TestUnitsInfo(c *gc.C) {
 	c.Assert(result.Results[0].Error, gc.IsNil)
 	c.Assert(*result.Results[0].Result, gc.DeepEquals, params.UnitResult{
 		Tag:             "unit-postgresql-0",
+		UUID:            "deadbeef-0bad-400d-8000-4b1d0d06f00e",
 		WorkloadVersion: "666",
 		Machine:         "0",
 		OpenedPorts:     []string{"100-102/tcp"},
@@ -44,6 +44,7 @@ type Backend interface {
 	InferEndpoints(...string) ([]state.Endpoint, error)
 	Machine(string) (Machine, error)
 	Unit(string) (Unit, error)
+	UnitCount() (int, error)
 	UnitsInError() ([]Unit, error)
 	SaveController(info crossmodel.ControllerInfo, modelUUID string) (ExternalController, error)
 	ControllerTag() names.ControllerTag
@@ -69,6 +70,7 @@ type BlockChecker interface {
 // the same names.
 type Application interface {
 	Name() string
+	UUID() string
 	AddUnit(state.AddUnitParams) (Unit, error)
 	AllUnits() ([]Unit, error)
 	ApplicationConfig() (application.ConfigAttributes, error)
@@ -95,13 +97,16 @@ type Application interface {
 	SetMetricCredentials([]byte) error
 	SetMinUnits(int) error
 	UpdateApplicationSeries(string, bool) error
+	UpdateStorageConstraints(map[string]state.StorageConstraints) error
 	UpdateCharmConfig(string, charm.Settings) error
 	UpdateApplicationConfig(application.ConfigAttributes, []string, environschema.Fields, schema.Defaults) error
+	GetScale() int
 	SetScale(int, int64, bool) error
 	ChangeScale(int) (int, error)
 	AgentTools() (*tools.Tools, error)
 	MergeBindings(*state.Bindings, bool) error
 	Relations() ([]Relation, error)
+	SecretConfigKeys() ([]string, error)
 }
 
 // Bindings defines a subset of the functionality provided by the
@@ -163,6 +168,7 @@ type RelationUnit interface {
 // the same names.
 type Unit interface {
 	Name() string
+	UUID() string
 	Tag() names.Tag
 	UnitTag() names.UnitTag
 	ApplicationName() string
@@ -171,6 +177,8 @@ type Unit interface {
 	IsPrincipal() bool
 	Life() state.Life
 	Resolve(retryHooks bool) error
+	RequestDrain() error
+	DrainStatus() state.UnitDrainStatus
 	AgentTools() (*tools.Tools, error)
 
 	AssignedMachineId() (string, error)
@@ -0,0 +1,33 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/charm/v9"
+	"github.com/juju/errors"
+
+	corecharm "github.com/juju/juju/core/charm"
+)
+
+// sandboxedCharm is implemented by charms which record the sandbox
+// profile declared in their archive, such as those stored in state.
+type sandboxedCharm interface {
+	SandboxProfile() *corecharm.SandboxProfile
+}
+
+// checkCharmSandboxProfile rejects charms whose sandbox profile sets
+// sysctls or loads AppArmor profiles which the controller does not
+// allow charms to apply to their machines.
+func checkCharmSandboxProfile(backend Backend, curl *charm.URL, ch Charm) error {
+	sc, ok := ch.(sandboxedCharm)
+	if !ok || sc.SandboxProfile().Empty() {
+		return nil
+	}
+	cfg, err := backend.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = sc.SandboxProfile().CheckAllowed(cfg.CharmSandboxSysctls(), cfg.CharmSandboxAppArmor())
+	return errors.Annotatef(err, "charm %q sandbox profile", curl)
+}
@@ -0,0 +1,37 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/charm/v9"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+)
+
+// signedCharm is implemented by charms which record the signature
+// verified when their archive was uploaded, such as those stored in
+// state.
+type signedCharm interface {
+	Signature() *state.CharmSignature
+}
+
+// checkCharmSignature rejects charms without a verified signature when
+// the controller's charm signature policy requires one. Charms from the
+// charm store and charm hub are not published with detached signatures,
+// so they are only accepted when the policy does not require them.
+func checkCharmSignature(backend Backend, curl *charm.URL, ch Charm) error {
+	cfg, err := backend.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if cfg.CharmSignaturePolicy() != controller.CharmSignaturePolicyRequire {
+		return nil
+	}
+	if sc, ok := ch.(signedCharm); ok && sc.Signature() != nil {
+		return nil
+	}
+	return errors.Forbiddenf("charm %q has no verified signature, required by controller policy", curl)
+}
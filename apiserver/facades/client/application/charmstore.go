@@ -316,6 +316,10 @@ type CharmArchive struct {
 
 	// Charm Version contains semantic version of charm, typically the output of git describe.
 	CharmVersion string
+
+	// Signature is the verified detached signature uploaded with the
+	// archive, if any.
+	Signature *state.CharmSignature
 }
 
 // StoreCharmArchive stores a charm archive in environment storage.
@@ -340,6 +344,7 @@ func StoreCharmArchive(st State, archive CharmArchive) error {
 		SHA256:      archive.SHA256,
 		Macaroon:    archive.Macaroon,
 		Version:     archive.CharmVersion,
+		Signature:   archive.Signature,
 	}
 
 	// Now update the charm data in state and mark it as no longer pending.
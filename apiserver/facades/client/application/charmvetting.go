@@ -0,0 +1,120 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/juju/charm/v9"
+	"github.com/juju/errors"
+	jujuhttp "github.com/juju/http"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+)
+
+// charmVettingTimeout is how long to wait for the charm vetting webhook
+// to respond before rejecting the deploy.
+const charmVettingTimeout = 30 * time.Second
+
+// charmVettingRequest is posted as JSON to the controller's charm vetting
+// webhook before a charm is deployed.
+type charmVettingRequest struct {
+	ModelUUID       string      `json:"model-uuid"`
+	ModelName       string      `json:"model-name"`
+	ApplicationName string      `json:"application-name"`
+	CharmURL        string      `json:"charm-url"`
+	SHA256          string      `json:"sha256,omitempty"`
+	Meta            *charm.Meta `json:"meta"`
+}
+
+// charmVettingResponse is the optional JSON body of the webhook's
+// response, used to explain why a deploy was rejected.
+type charmVettingResponse struct {
+	Message string `json:"message"`
+}
+
+// charmVettingClient is shared by all requests to the charm vetting
+// webhook, so that connections to it are reused.
+var charmVettingClient = jujuhttp.NewClient(jujuhttp.Config{Logger: logger.Child("charmvetting")})
+
+// sha256Charm is implemented by charms which know the SHA256 hash of
+// their archive, such as those stored in state.
+type sha256Charm interface {
+	BundleSha256() string
+}
+
+// vetCharm asks the controller's charm vetting webhook, if there is one,
+// whether the charm may be deployed as, or used to refresh, the given
+// application. A 200 OK response allows it. A 4xx response rejects it
+// with a forbidden error. Failing to reach the webhook, or any other
+// response, means the charm couldn't be vetted; that is reported as a
+// try-again error, so that callers can tell it from a rejection.
+func vetCharm(backend Backend, model Model, curl *charm.URL, ch Charm, appName string) error {
+	cfg, err := backend.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	webhookURL := cfg.CharmVettingWebhookURL()
+	if webhookURL == "" {
+		return nil
+	}
+
+	req := charmVettingRequest{
+		ModelUUID:       model.ModelTag().Id(),
+		ModelName:       model.Name(),
+		ApplicationName: appName,
+		CharmURL:        curl.String(),
+		Meta:            ch.Meta(),
+	}
+	if sc, ok := ch.(sha256Charm); ok {
+		req.SHA256 = sc.BundleSha256()
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), charmVettingTimeout)
+	defer cancel()
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := charmVettingClient.Do(httpReq)
+	if err != nil {
+		return charmVettingUnavailable(curl, err.Error())
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	reason := resp.Status
+	if data, err := ioutil.ReadAll(resp.Body); err == nil {
+		var vettingResp charmVettingResponse
+		if err := json.Unmarshal(data, &vettingResp); err == nil && vettingResp.Message != "" {
+			reason = vettingResp.Message
+		} else if msg := string(bytes.TrimSpace(data)); msg != "" {
+			reason = msg
+		}
+	}
+	if resp.StatusCode < 400 || resp.StatusCode >= 500 {
+		return charmVettingUnavailable(curl, reason)
+	}
+	return errors.Forbiddenf("charm %q rejected by charm vetting webhook: %s", curl, reason)
+}
+
+// charmVettingUnavailable returns the error used when the charm vetting
+// webhook couldn't give an answer, rather than rejecting the charm.
+func charmVettingUnavailable(curl *charm.URL, reason string) error {
+	return errors.Annotatef(apiservererrors.ErrTryAgain,
+		"cannot vet charm %q, charm vetting webhook unavailable: %s", curl, reason)
+}
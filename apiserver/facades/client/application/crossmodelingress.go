@@ -0,0 +1,18 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/core/application"
+)
+
+var crossModelIngressFields = environschema.Fields{
+	application.CrossModelIngressAddressesConfigKey: {
+		Description: "Comma-separated endpoint=address pairs pinning the ingress address advertised for the endpoint in cross-model relations",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+}
@@ -0,0 +1,71 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// DrainUnits isn't on the v13 API.
+func (u *APIv13) DrainUnits(_, _ struct{}) {}
+
+// UnitsDrained isn't on the v13 API.
+func (u *APIv13) UnitsDrained(_, _ struct{}) {}
+
+// DrainUnits asks each of the given units to drain ahead of its removal,
+// by running its charm's pre-remove hook.
+func (api *APIBase) DrainUnits(args params.Entities) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.ErrorResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		unitTag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		unit, err := api.backend.Unit(unitTag.Id())
+		if errors.IsNotFound(err) {
+			err = errors.Errorf("unit %q does not exist", unitTag.Id())
+		} else if err == nil {
+			err = unit.RequestDrain()
+		}
+		results[i].Error = apiservererrors.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+// UnitsDrained reports whether each of the given units has completed
+// its pre-remove hook, and is ready to be removed.
+func (api *APIBase) UnitsDrained(args params.Entities) (params.BoolResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.BoolResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.BoolResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		unitTag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		unit, err := api.backend.Unit(unitTag.Id())
+		if err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results[i].Result = unit.DrainStatus() == state.UnitDrainCompleted
+	}
+	return params.BoolResults{Results: results}, nil
+}
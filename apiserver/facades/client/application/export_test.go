@@ -9,8 +9,33 @@ var (
 	ParseSettingsCompatible = parseSettingsCompatible
 	NewStateStorage         = &newStateStorage
 	GetStorageState         = getStorageState
+	ApplicationConfigSchema = applicationConfigSchema
 )
 
+func HookRetryFieldDescription(name string) string {
+	return hookRetryFields[name].Description
+}
+
+func FirewallModeFieldDescription() string {
+	return firewallModeFields["firewall-mode"].Description
+}
+
+func ExternalLBFieldDescription() string {
+	return externalLBFields["external-lb-addresses"].Description
+}
+
+func CrossModelIngressFieldDescription() string {
+	return crossModelIngressFields["cross-model-ingress-addresses"].Description
+}
+
+func SecretOptionsFieldDescription() string {
+	return secretOptionsFields["secret-options"].Description
+}
+
+func ProxyFieldDescription(name string) string {
+	return proxyFields[name].Description
+}
+
 func GetState(st *state.State) Backend {
 	return stateShim{st}
 }
@@ -19,6 +44,6 @@ func GetModel(m *state.Model) Model {
 	return modelShim{m}
 }
 
-func SetModelType(api *APIv13, modelType state.ModelType) {
+func SetModelType(api *APIv15, modelType state.ModelType) {
 	api.modelType = modelType
 }
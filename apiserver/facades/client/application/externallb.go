@@ -0,0 +1,18 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/core/application"
+)
+
+var externalLBFields = environschema.Fields{
+	application.ExternalLBAddressesConfigKey: {
+		Description: "Comma-separated endpoint=address pairs advertising a load balancer's virtual address as the endpoint's ingress address",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+}
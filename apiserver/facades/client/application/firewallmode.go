@@ -0,0 +1,23 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/core/application"
+)
+
+var firewallModeFields = environschema.Fields{
+	application.FirewallModeConfigKey: {
+		Description: "How the application's ports are firewalled: instance, global or none (defaults to the model's firewall-mode)",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+		Values: []interface{}{
+			application.FirewallModeInstance,
+			application.FirewallModeGlobal,
+			application.FirewallModeNone,
+		},
+	},
+}
@@ -79,6 +79,17 @@ func (api *APIBase) getConfig(
 	if err != nil {
 		return params.ApplicationGetResults{}, err
 	}
+	secretOptions, err := appConfig.SecretOptions()
+	if err != nil {
+		return params.ApplicationGetResults{}, err
+	}
+	if len(secretOptions) > 0 {
+		secretKeys, err := app.SecretConfigKeys()
+		if err != nil {
+			return params.ApplicationGetResults{}, err
+		}
+		describeSecretOptions(configInfo, secretOptions, secretKeys)
+	}
 
 	providerSchema, providerDefaults, err := applicationConfigSchema(api.modelType)
 	if err != nil {
@@ -16,15 +16,14 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/application"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
-	"github.com/juju/juju/caas"
 	"github.com/juju/juju/caas/kubernetes/provider"
-	k8s "github.com/juju/juju/caas/kubernetes/provider"
 	k8stesting "github.com/juju/juju/caas/kubernetes/provider/testing"
 	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -63,7 +62,7 @@ func (s *getSuite) SetUpTest(c *gc.C) {
 		nil, // CAAS Broker not used in this suite.
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.applicationAPI = &application.APIv13{api}
+	s.applicationAPI = &application.APIv13{&application.APIv14{&application.APIv15{&application.APIv16{api}}}}
 }
 
 func (s *getSuite) TestClientApplicationGetSmokeTestV4(c *gc.C) {
@@ -161,14 +160,14 @@ func (s *getSuite) TestClientApplicationGetIAASModelSmokeTest(c *gc.C) {
 				"value":       "My Title",
 			},
 		},
-		ApplicationConfig: map[string]interface{}{
+		ApplicationConfig: withUnsetAppConfig(map[string]interface{}{
 			"trust": map[string]interface{}{
 				"default":     false,
 				"description": "Does this application have access to trusted credentials",
 				"source":      "default",
 				"type":        environschema.Tbool,
 				"value":       false,
-			}},
+			}}, false),
 		Series: "quantal",
 		EndpointBindings: map[string]string{
 			"":                network.AlphaSpaceName,
@@ -192,11 +191,7 @@ func (s *getSuite) TestClientApplicationGetCAASModelSmokeTest(c *gc.C) {
 	ch := f.MakeCharm(c, &factory.CharmParams{Name: "dashboard4miner", Series: "kubernetes"})
 	app := f.MakeApplication(c, &factory.ApplicationParams{Name: "dashboard4miner", Charm: ch})
 
-	schemaFields, err := caas.ConfigSchema(k8s.ConfigSchema())
-	c.Assert(err, jc.ErrorIsNil)
-	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
-
-	schemaFields, defaults, err = application.AddTrustSchemaAndDefaults(schemaFields, defaults)
+	schemaFields, defaults, err := application.ApplicationConfigSchema(state.ModelTypeCAAS)
 	c.Assert(err, jc.ErrorIsNil)
 
 	appConfig, err := coreapplication.NewConfig(map[string]interface{}{"juju-external-hostname": "ext"}, schemaFields, defaults)
@@ -261,7 +256,13 @@ func (s *getSuite) TestClientApplicationGetCAASModelSmokeTest(c *gc.C) {
 				&application.APIv11{
 					&application.APIv12{
 						&application.APIv13{
-							api,
+							&application.APIv14{
+								&application.APIv15{
+									&application.APIv16{
+										api,
+									},
+								},
+							},
 						},
 					},
 				},
@@ -343,7 +344,7 @@ var getTests = []struct {
 				"type":        "int",
 			},
 		},
-		ApplicationConfig: map[string]interface{}{
+		ApplicationConfig: withUnsetAppConfig(map[string]interface{}{
 			"trust": map[string]interface{}{
 				"value":       false,
 				"default":     false,
@@ -351,7 +352,7 @@ var getTests = []struct {
 				"source":      "default",
 				"type":        "bool",
 			},
-		},
+		}, true),
 		Series: "quantal",
 		EndpointBindings: map[string]string{
 			"": network.AlphaSpaceName,
@@ -405,7 +406,7 @@ var getTests = []struct {
 				"value": float64(0),
 			},
 		},
-		ApplicationConfig: map[string]interface{}{
+		ApplicationConfig: withUnsetAppConfig(map[string]interface{}{
 			"trust": map[string]interface{}{
 				"value":       false,
 				"default":     false,
@@ -413,7 +414,7 @@ var getTests = []struct {
 				"source":      "default",
 				"type":        "bool",
 			},
-		},
+		}, true),
 		Series: "quantal",
 		EndpointBindings: map[string]string{
 			"": network.AlphaSpaceName,
@@ -425,7 +426,7 @@ var getTests = []struct {
 	expect: params.ApplicationGetResults{
 		CharmConfig: map[string]interface{}{},
 		Series:      "quantal",
-		ApplicationConfig: map[string]interface{}{
+		ApplicationConfig: withUnsetAppConfig(map[string]interface{}{
 			"trust": map[string]interface{}{
 				"value":       false,
 				"default":     false,
@@ -433,7 +434,7 @@ var getTests = []struct {
 				"source":      "default",
 				"type":        "bool",
 			},
-		},
+		}, true),
 		EndpointBindings: map[string]string{
 			"":                  network.AlphaSpaceName,
 			"info":              network.AlphaSpaceName,
@@ -496,3 +497,90 @@ func (s *getSuite) TestGetMaxResolutionInt(c *gc.C) {
 		"value":       asFloat,
 	})
 }
+
+func (s *getSuite) TestGetSecretOptions(c *gc.C) {
+	ch := s.AddTestingCharm(c, "dummy")
+	app := s.AddTestingApplication(c, "test-application", ch)
+
+	err := app.UpdateApplicationConfig(coreapplication.ConfigAttributes{
+		coreapplication.SecretOptionsConfigKey: "username,outlook",
+	}, nil, environschema.Fields{
+		coreapplication.SecretOptionsConfigKey: environschema.Attr{Type: environschema.Tstring},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.UpdateCharmConfig(model.GenerationMaster, charm.Settings{"username": "s3cret"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := apiapplication.NewClient(s.APIState)
+	got, err := client.Get(model.GenerationMaster, app.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got.CharmConfig["username"], jc.DeepEquals, map[string]interface{}{
+		"default":     "admin001",
+		"description": "The name of the initial account (given admin permissions).",
+		"source":      "user",
+		"type":        "secret",
+	})
+	c.Assert(got.CharmConfig["outlook"], jc.DeepEquals, map[string]interface{}{
+		"description": "No default outlook.",
+		"source":      "unset",
+		"type":        "secret",
+	})
+	c.Assert(got.CharmConfig["title"], jc.DeepEquals, map[string]interface{}{
+		"default":     "My Title",
+		"description": "A descriptive title used for the application.",
+		"source":      "default",
+		"type":        "string",
+		"value":       "My Title",
+	})
+}
+
+// withUnsetAppConfig adds the unset hook retry policy, firewall mode,
+// external load balancer, cross-model ingress and proxy settings to the
+// expected application config. Field types are plain strings once they
+// have been through the API.
+func withUnsetAppConfig(appConfig map[string]interface{}, viaAPI bool) map[string]interface{} {
+	for name, fieldType := range map[string]environschema.FieldType{
+		"hook-retry":                    environschema.Tbool,
+		"hook-retry-max":                environschema.Tint,
+		"hook-retry-max-delay":          environschema.Tstring,
+		"hook-retry-hooks":              environschema.Tstring,
+		"firewall-mode":                 environschema.Tstring,
+		"external-lb-addresses":         environschema.Tstring,
+		"cross-model-ingress-addresses": environschema.Tstring,
+		"juju-http-proxy":               environschema.Tstring,
+		"juju-https-proxy":              environschema.Tstring,
+		"juju-no-proxy":                 environschema.Tstring,
+		"apt-http-proxy":                environschema.Tstring,
+		"apt-https-proxy":               environschema.Tstring,
+		"snap-http-proxy":               environschema.Tstring,
+		"snap-https-proxy":              environschema.Tstring,
+		"secret-options":                environschema.Tstring,
+	} {
+		var description string
+		switch name {
+		case "firewall-mode":
+			description = application.FirewallModeFieldDescription()
+		case "external-lb-addresses":
+			description = application.ExternalLBFieldDescription()
+		case "cross-model-ingress-addresses":
+			description = application.CrossModelIngressFieldDescription()
+		case "secret-options":
+			description = application.SecretOptionsFieldDescription()
+		case "juju-http-proxy", "juju-https-proxy", "juju-no-proxy",
+			"apt-http-proxy", "apt-https-proxy", "snap-http-proxy", "snap-https-proxy":
+			description = application.ProxyFieldDescription(name)
+		default:
+			description = application.HookRetryFieldDescription(name)
+		}
+		info := map[string]interface{}{
+			"description": description,
+			"source":      "unset",
+			"type":        fieldType,
+		}
+		if viaAPI {
+			info["type"] = string(fieldType)
+		}
+		appConfig[name] = info
+	}
+	return appConfig
+}
@@ -0,0 +1,33 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/core/application"
+)
+
+var hookRetryFields = environschema.Fields{
+	application.HookRetryConfigKey: {
+		Description: "Whether failed hooks are retried automatically (defaults to the model's automatically-retry-hooks)",
+		Type:        environschema.Tbool,
+		Group:       environschema.JujuGroup,
+	},
+	application.HookRetryMaxConfigKey: {
+		Description: "The maximum number of automatic retries of a failed hook (0 means no limit)",
+		Type:        environschema.Tint,
+		Group:       environschema.JujuGroup,
+	},
+	application.HookRetryMaxDelayConfigKey: {
+		Description: "The longest delay between automatic retries of a failed hook, e.g. 30s",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	application.HookRetryHooksConfigKey: {
+		Description: "The space separated kinds of hook retried automatically, e.g. \"install config-changed\" (empty means all)",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/controller"
 	coreapplication "github.com/juju/juju/core/application"
+	corecharm "github.com/juju/juju/core/charm"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/instance"
@@ -65,6 +66,8 @@ type mockCharm struct {
 	config     *charm.Config
 	meta       *charm.Meta
 	lxdProfile *charm.LXDProfile
+	signature  *state.CharmSignature
+	sandbox    *corecharm.SandboxProfile
 }
 
 func (c *mockCharm) Meta() *charm.Meta {
@@ -83,6 +86,15 @@ func (c *mockCharm) LXDProfile() *charm.LXDProfile {
 	return c.lxdProfile
 }
 
+func (c *mockCharm) Signature() *state.CharmSignature {
+	c.MethodCall(c, "Signature")
+	return c.signature
+}
+
+func (c *mockCharm) SandboxProfile() *corecharm.SandboxProfile {
+	return c.sandbox
+}
+
 type mockApplication struct {
 	jtesting.Stub
 	application.Application
@@ -93,6 +105,7 @@ type mockApplication struct {
 	endpoints        []state.Endpoint
 	exposedEndpoints map[string]state.ExposedEndpoint
 	name             string
+	uuid             string
 	scale            int
 	subordinate      bool
 	series           string
@@ -111,6 +124,11 @@ func (m *mockApplication) Name() string {
 	return m.name
 }
 
+func (m *mockApplication) UUID() string {
+	m.MethodCall(m, "UUID")
+	return m.uuid
+}
+
 func (m *mockApplication) Channel() csparams.Channel {
 	m.MethodCall(m, "Channel")
 	return m.channel
@@ -213,6 +231,11 @@ func (a *mockApplication) UpdateApplicationSeries(series string, force bool) err
 	return a.NextErr()
 }
 
+func (a *mockApplication) UpdateStorageConstraints(cons map[string]state.StorageConstraints) error {
+	a.MethodCall(a, "UpdateStorageConstraints", cons)
+	return a.NextErr()
+}
+
 func (a *mockApplication) Series() string {
 	a.MethodCall(a, "Series")
 	a.PopNoErr()
@@ -316,6 +339,7 @@ type mockRemoteApplication struct {
 	offerUUID      string
 	offerURL       string
 	mac            *macaroon.Macaroon
+	encrypt        bool
 }
 
 func (m *mockRemoteApplication) Name() string {
@@ -383,6 +407,8 @@ type mockBackend struct {
 	machines                   map[string]*mockMachine
 	generation                 *mockGeneration
 	spaceInfos                 network.SpaceInfos
+	controllerCfg              map[string]interface{}
+	unitCount                  int
 }
 
 type mockFilesystemAccess struct {
@@ -407,7 +433,16 @@ func (m *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, err
 }
 
 func (m *mockBackend) ControllerConfig() (controller.Config, error) {
-	return controller.NewConfig(coretesting.ControllerTag.Id(), coretesting.CACert, map[string]interface{}{})
+	attrs := map[string]interface{}{}
+	for k, v := range m.controllerCfg {
+		attrs[k] = v
+	}
+	return controller.NewConfig(coretesting.ControllerTag.Id(), coretesting.CACert, attrs)
+}
+
+func (m *mockBackend) UnitCount() (int, error) {
+	m.MethodCall(m, "UnitCount")
+	return m.unitCount, m.NextErr()
 }
 
 func (m *mockBackend) Charm(curl *charm.URL) (application.Charm, error) {
@@ -471,9 +506,14 @@ func (m *mockBackend) Space(_ string) (*state.Space, error) {
 	return nil, nil
 }
 
-type mockLeadership struct{}
+type mockLeadership struct {
+	err error
+}
 
-func (mockLeadership) Leaders() (map[string]string, error) {
+func (m *mockLeadership) Leaders() (map[string]string, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
 	return map[string]string{
 		"postgresql": "postgresql/0",
 	}, nil
@@ -504,6 +544,10 @@ func (m *mockModel) UUID() string {
 	return m.uuid
 }
 
+func (m *mockModel) Name() string {
+	return "default"
+}
+
 func (m *mockModel) ModelTag() names.ModelTag {
 	return names.NewModelTag(m.UUID())
 }
@@ -717,6 +761,7 @@ func (m *mockBackend) AddRemoteApplication(args state.AddRemoteApplicationParams
 		offerURL:       args.URL,
 		bindings:       args.Bindings,
 		mac:            args.Macaroon,
+		encrypt:        args.EncryptRelationData,
 	}
 	for _, ep := range args.Endpoints {
 		app.endpoints = append(app.endpoints, state.Endpoint{
@@ -940,7 +985,9 @@ type mockUnit struct {
 	tag        names.UnitTag
 	machineId  string
 	name       string
+	uuid       string
 	agentTools *tools.Tools
+	drain      state.UnitDrainStatus
 }
 
 func (u *mockUnit) Tag() names.Tag {
@@ -977,6 +1024,16 @@ func (u *mockUnit) Resolve(retryHooks bool) error {
 	return u.NextErr()
 }
 
+func (u *mockUnit) RequestDrain() error {
+	u.MethodCall(u, "RequestDrain")
+	return u.NextErr()
+}
+
+func (u *mockUnit) DrainStatus() state.UnitDrainStatus {
+	u.MethodCall(u, "DrainStatus")
+	return u.drain
+}
+
 func (u *mockUnit) AssignedMachineId() (string, error) {
 	u.MethodCall(u, "AssignedMachineId")
 	return u.machineId, u.NextErr()
@@ -987,6 +1044,11 @@ func (u *mockUnit) Name() string {
 	return u.name
 }
 
+func (u *mockUnit) UUID() string {
+	u.MethodCall(u, "UUID")
+	return u.uuid
+}
+
 func (u *mockUnit) ApplicationName() string {
 	u.MethodCall(u, "ApplicationName")
 	appName, _ := names.UnitApplication(u.name)
@@ -0,0 +1,48 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/core/application"
+)
+
+var proxyFields = environschema.Fields{
+	application.JujuHTTPProxyConfigKey: {
+		Description: "The HTTP proxy value for this application's units, overriding the model's juju-http-proxy",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	application.JujuHTTPSProxyConfigKey: {
+		Description: "The HTTPS proxy value for this application's units, overriding the model's juju-https-proxy",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	application.JujuNoProxyConfigKey: {
+		Description: "List of domain addresses not to be proxied for this application's units, overriding the model's juju-no-proxy",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	application.AptHTTPProxyConfigKey: {
+		Description: "The APT HTTP proxy for this application's units, overriding the model's apt-http-proxy",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	application.AptHTTPSProxyConfigKey: {
+		Description: "The APT HTTPS proxy for this application's units, overriding the model's apt-https-proxy",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	application.SnapHTTPProxyConfigKey: {
+		Description: "The snap HTTP proxy for this application's units, overriding the model's snap-http-proxy",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	application.SnapHTTPSProxyConfigKey: {
+		Description: "The snap HTTPS proxy for this application's units, overriding the model's snap-https-proxy",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+}
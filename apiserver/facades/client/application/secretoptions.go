@@ -0,0 +1,44 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/collections/set"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/core/application"
+)
+
+var secretOptionsFields = environschema.Fields{
+	application.SecretOptionsConfigKey: {
+		Description: "Comma-separated list of the charm's string config options whose values are secrets, which are stored sealed and only delivered to units through the secret-config-get hook tool",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+}
+
+// describeSecretOptions marks the application's secret charm config
+// options in the described config, and removes any values set for them.
+// The values of secret options are never reported to clients.
+func describeSecretOptions(configInfo map[string]interface{}, secretOptions, setKeys []string) {
+	isSet := set.NewStrings(setKeys...)
+	for _, name := range secretOptions {
+		info, ok := configInfo[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		info["type"] = application.SecretOptionType
+		if !isSet.Contains(name) {
+			continue
+		}
+		delete(info, "value")
+		if _, ok := info["source"]; ok {
+			info["source"] = "user"
+		}
+		// Older clients are told whether the value is the default.
+		if isDefault, ok := info["default"].(bool); ok && isDefault {
+			delete(info, "default")
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// UpdateApplicationStorage isn't on the v14 API.
+func (u *APIv14) UpdateApplicationStorage(_, _ struct{}) {}
+
+// UpdateApplicationStorage updates the storage constraints used to
+// provision storage for the future units of each given application.
+// Storage instances which already exist are not affected.
+func (api *APIBase) UpdateApplicationStorage(args params.ApplicationStorageUpdateArgs) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.ErrorResult, len(args.Args))
+	for i, arg := range args.Args {
+		results[i].Error = apiservererrors.ServerError(api.updateApplicationStorage(arg))
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (api *APIBase) updateApplicationStorage(arg params.ApplicationStorageUpdate) error {
+	tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(arg.StorageConstraints) == 0 {
+		return errors.NotValidf("empty storage constraints")
+	}
+	app, err := api.backend.Application(tag.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	updates := make(map[string]state.StorageConstraints)
+	for name, cons := range arg.StorageConstraints {
+		stateCons := state.StorageConstraints{Pool: cons.Pool}
+		if cons.Size != nil {
+			stateCons.Size = *cons.Size
+		}
+		if cons.Count != nil {
+			stateCons.Count = *cons.Count
+		}
+		updates[name] = stateCons
+	}
+	return app.UpdateStorageConstraints(updates)
+}
@@ -4,7 +4,6 @@
 package application
 
 import (
-	"github.com/juju/errors"
 	"github.com/juju/schema"
 	"gopkg.in/juju/environschema.v1"
 )
@@ -27,7 +26,7 @@ var trustDefaults = schema.Defaults{
 
 // AddTrustSchemaAndDefaults adds trust schema fields and defaults to an existing set of schema fields and defaults.
 func AddTrustSchemaAndDefaults(schema environschema.Fields, defaults schema.Defaults) (environschema.Fields, schema.Defaults, error) {
-	newSchema, err := addTrustSchema(schema)
+	newSchema, err := addSchemaFields(schema, trustFields)
 	newDefaults := addTrustDefaults(defaults)
 	return newSchema, newDefaults, err
 }
@@ -42,20 +41,3 @@ func addTrustDefaults(defaults schema.Defaults) schema.Defaults {
 	}
 	return newDefaults
 }
-
-// [TODO](externalreality): This is copied from CAAS configuration code. This is
-// a generic builder pattern and can likely be generalized to all application
-// schema fields.
-func addTrustSchema(extra environschema.Fields) (environschema.Fields, error) {
-	fields := make(environschema.Fields)
-	for name, field := range trustFields {
-		fields[name] = field
-	}
-	for name, field := range extra {
-		if _, ok := trustFields[name]; ok {
-			return nil, errors.Errorf("config field %q clashes with common config", name)
-		}
-		fields[name] = field
-	}
-	return fields, nil
-}
@@ -0,0 +1,22 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/core/application"
+)
+
+var trustScopeFields = environschema.Fields{
+	application.TrustScopeConfigKey: {
+		Description: "Which credential a trusted application is given: model, for the model's credential, or application, for a time-limited credential scoped to the application where the cloud supports it (defaults to model)",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+		Values: []interface{}{
+			application.TrustScopeModel,
+			application.TrustScopeApplication,
+		},
+	},
+}
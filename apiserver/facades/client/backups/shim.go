@@ -34,8 +34,23 @@ func (s *stateShim) MachineSeries(id string) (string, error) {
 	return m.Series(), nil
 }
 
-// NewFacadeV3 provides the required signature for facade registration.
-func NewFacadeV3(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+// APIv3 provides the backups facade APIs for v3.
+type APIv3 struct {
+	*API
+}
+
+// NewFacadeV3 provides the required signature for facade registration
+// of version 3.
+func NewFacadeV3(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*APIv3, error) {
+	api, err := NewFacadeV4(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv3{api}, nil
+}
+
+// NewFacadeV4 provides the required signature for facade registration.
+func NewFacadeV4(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
 	model, err := st.Model()
 	if err != nil {
 		return nil, errors.Trace(err)
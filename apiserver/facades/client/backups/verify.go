@@ -0,0 +1,33 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Verify restores the identified backup into a throwaway database on
+// this controller machine, and reports whether it could be restored
+// and checked cleanly. The controller's own database is not touched.
+func (a *API) Verify(args params.BackupsVerifyArgs) (params.BackupsVerifyResult, error) {
+	backups, closer := newBackups(a.backend)
+	defer closer.Close()
+
+	meta, result, err := backups.Verify(args.ID)
+	if err != nil {
+		return params.BackupsVerifyResult{}, errors.Trace(err)
+	}
+	return params.BackupsVerifyResult{
+		ID:          meta.ID(),
+		Restorable:  result.Restorable(),
+		Databases:   result.Databases,
+		Collections: result.Collections,
+		Problems:    result.Problems,
+	}, nil
+}
+
+// Verify isn't on the v3 API.
+func (*APIv3) Verify(_, _ struct{}) {}
@@ -0,0 +1,48 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/backups"
+)
+
+func (s *backupsSuite) TestVerifyOkay(c *gc.C) {
+	s.meta.SetID("some-id")
+	fake := s.setBackups(c, s.meta, "")
+	fake.VerifyResult = &backups.VerifyResult{
+		Databases:   []string{"juju", "logs"},
+		Collections: 42,
+	}
+	result, err := s.api.Verify(params.BackupsVerifyArgs{ID: "some-id"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fake.IDArg, gc.Equals, "some-id")
+	c.Check(result, jc.DeepEquals, params.BackupsVerifyResult{
+		ID:          "some-id",
+		Restorable:  true,
+		Databases:   []string{"juju", "logs"},
+		Collections: 42,
+	})
+}
+
+func (s *backupsSuite) TestVerifyNotRestorable(c *gc.C) {
+	s.meta.SetID("some-id")
+	fake := s.setBackups(c, s.meta, "")
+	fake.VerifyResult = &backups.VerifyResult{
+		Problems: []string{"cannot unpack archive: unexpected EOF"},
+	}
+	result, err := s.api.Verify(params.BackupsVerifyArgs{ID: "some-id"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Restorable, jc.IsFalse)
+	c.Check(result.Problems, jc.DeepEquals, []string{"cannot unpack archive: unexpected EOF"})
+}
+
+func (s *backupsSuite) TestVerifyError(c *gc.C) {
+	s.setBackups(c, nil, "failed!")
+	_, err := s.api.Verify(params.BackupsVerifyArgs{ID: "some-id"})
+	c.Assert(err, gc.ErrorMatches, "failed!")
+}
@@ -6,6 +6,9 @@ package charms
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/juju/charm/v9"
@@ -43,6 +46,7 @@ type API struct {
 	csResolverGetterFunc CSResolverGetterFunc
 	getStrategyFunc      func(source string) StrategyFunc
 	newStorage           func(modelUUID string, session *mgo.Session) storage.Storage
+	newCharmHubClient    func(charmhub.Config) (CharmHubClient, error)
 	tag                  names.ModelTag
 }
 
@@ -51,6 +55,10 @@ type APIv2 struct {
 }
 
 type APIv3 struct {
+	*APIv4
+}
+
+type APIv4 struct {
 	*API
 }
 
@@ -84,14 +92,12 @@ func (a *API) checkCanWrite() error {
 // NewFacadeV2 provides the signature required for facade V2 registration.
 // It is unknown where V1 is.
 func NewFacadeV2(ctx facade.Context) (*APIv2, error) {
-	v4, err := NewFacadeV4(ctx)
+	v3, err := NewFacadeV3(ctx)
 	if err != nil {
 		return nil, nil
 	}
 	return &APIv2{
-		APIv3: &APIv3{
-			API: v4,
-		},
+		APIv3: v3,
 	}, nil
 }
 
@@ -101,11 +107,20 @@ func NewFacadeV3(ctx facade.Context) (*APIv3, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &APIv3{API: api}, nil
+	return &APIv3{APIv4: api}, nil
 }
 
 // NewFacadeV4 provides the signature required for facade V4 registration.
-func NewFacadeV4(ctx facade.Context) (*API, error) {
+func NewFacadeV4(ctx facade.Context) (*APIv4, error) {
+	api, err := NewFacadeV5(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv4{API: api}, nil
+}
+
+// NewFacadeV5 provides the signature required for facade V5 registration.
+func NewFacadeV5(ctx facade.Context) (*API, error) {
 	authorizer := ctx.Auth()
 	if !authorizer.AuthClient() {
 		return nil, apiservererrors.ErrPerm
@@ -130,6 +145,7 @@ func NewFacadeV4(ctx facade.Context) (*API, error) {
 		csResolverGetterFunc: csResolverGetter,
 		getStrategyFunc:      getStrategyFunc,
 		newStorage:           storage.NewStorage,
+		newCharmHubClient:    newCharmHubClient,
 		tag:                  m.ModelTag(),
 	}, nil
 }
@@ -149,10 +165,15 @@ func NewCharmsAPI(
 		csResolverGetterFunc: csResolverFunc,
 		getStrategyFunc:      getStrategyFunc,
 		newStorage:           newStorage,
+		newCharmHubClient:    newCharmHubClient,
 		tag:                  m.ModelTag(),
 	}, nil
 }
 
+func newCharmHubClient(cfg charmhub.Config) (CharmHubClient, error) {
+	return charmhub.NewClient(cfg)
+}
+
 // CharmInfo returns information about the requested charm.
 // NOTE: thumper 2016-06-29, this is not a bulk call and probably should be.
 func (a *API) CharmInfo(args params.CharmURL) (params.Charm, error) {
@@ -268,6 +289,76 @@ func (a *API) getDownloadInfo(arg params.CharmURLAndOrigin) (params.DownloadInfo
 	}, nil
 }
 
+// DownloadBundles is not available via the V4 API.
+func (a *APIv4) DownloadBundles(_ struct{}) {}
+
+// DownloadBundles downloads the given CharmHub bundles on behalf of the
+// client and returns their archives. This allows clients which cannot
+// reach CharmHub themselves to deploy bundles from it.
+func (a *API) DownloadBundles(args params.CharmURLAndOrigins) (params.BundleArchiveResults, error) {
+	logger.Tracef("DownloadBundles %+v", args)
+	if err := a.checkCanRead(); err != nil {
+		return params.BundleArchiveResults{}, errors.Trace(err)
+	}
+
+	results := params.BundleArchiveResults{
+		Results: make([]params.BundleArchiveResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		result, err := a.downloadBundle(arg)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+func (a *API) downloadBundle(arg params.CharmURLAndOrigin) (params.BundleArchiveResult, error) {
+	curl, err := charm.ParseURL(arg.CharmURL)
+	if err != nil {
+		return params.BundleArchiveResult{}, errors.Trace(err)
+	}
+	if !charm.CharmHub.Matches(curl.Schema) {
+		return params.BundleArchiveResult{}, errors.NotValidf("downloading non CharmHub bundle %q", curl)
+	}
+
+	charmOrigin, err := normalizeCharmOrigin(arg.Origin)
+	if err != nil {
+		return params.BundleArchiveResult{}, errors.Trace(err)
+	}
+	charmOrigin.Type = "bundle"
+
+	repo, err := a.charmHubRepository()
+	if err != nil {
+		return params.BundleArchiveResult{}, errors.Trace(err)
+	}
+	durl, origin, err := repo.FindDownloadURL(curl, convertParamsOrigin(charmOrigin))
+	if err != nil {
+		return params.BundleArchiveResult{}, errors.Trace(err)
+	}
+
+	dir, err := ioutil.TempDir("", "bundle")
+	if err != nil {
+		return params.BundleArchiveResult{}, errors.Trace(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	archivePath := filepath.Join(dir, "bundle.zip")
+	if _, err := repo.DownloadBundle(durl.String(), archivePath); err != nil {
+		return params.BundleArchiveResult{}, errors.Annotatef(err, "downloading bundle %q", curl)
+	}
+	archive, err := ioutil.ReadFile(archivePath)
+	if err != nil {
+		return params.BundleArchiveResult{}, errors.Trace(err)
+	}
+	return params.BundleArchiveResult{
+		Archive: archive,
+		Origin:  convertOrigin(origin),
+	}, nil
+}
+
 func normalizeCharmOrigin(origin params.CharmOrigin) (params.CharmOrigin, error) {
 	// If the series is set to all, we need to ensure that we remove that, so
 	// that we can attempt to derive it at a later stage. Juju itself doesn't
@@ -597,7 +688,11 @@ func getStrategyFunc(source string) StrategyFunc {
 func (a *API) repository(origin params.CharmOrigin, mac *macaroon.Macaroon) (corecharm.Repository, error) {
 	switch origin.Source {
 	case corecharm.CharmHub.String():
-		return a.charmHubRepository()
+		repo, err := a.charmHubRepository()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return repo, nil
 	case corecharm.CharmStore.String():
 		return a.charmStoreRepository(origin, mac)
 	}
@@ -621,7 +716,7 @@ func (a *API) charmStoreRepository(origin params.CharmOrigin, mac *macaroon.Maca
 	return &csRepo{repo: client}, nil
 }
 
-func (a *API) charmHubRepository() (corecharm.Repository, error) {
+func (a *API) charmHubRepository() (*chRepo, error) {
 	cfg, err := a.backendModel.Config()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -637,7 +732,7 @@ func (a *API) charmHubRepository() (corecharm.Repository, error) {
 		return nil, errors.Trace(err)
 	}
 
-	chClient, err := charmhub.NewClient(chCfg)
+	chClient, err := a.newCharmHubClient(chCfg)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
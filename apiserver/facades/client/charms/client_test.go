@@ -4,6 +4,10 @@
 package charms_test
 
 import (
+	"context"
+	"io/ioutil"
+	"net/url"
+
 	"github.com/golang/mock/gomock"
 	"github.com/juju/charm/v9"
 	csparams "github.com/juju/charmrepo/v7/csclient/params"
@@ -21,6 +25,8 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/charms/mocks"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/charmhub"
+	"github.com/juju/juju/charmhub/transport"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/arch"
 	"github.com/juju/juju/core/cache"
@@ -58,6 +64,8 @@ func (ctx *charmsSuiteContext) State() *state.State                           {
 func (ctx *charmsSuiteContext) StatePool() *state.StatePool                   { return nil }
 func (ctx *charmsSuiteContext) ID() string                                    { return "" }
 func (ctx *charmsSuiteContext) Presence() facade.Presence                     { return nil }
+func (ctx *charmsSuiteContext) FacadeUsage() facade.FacadeUsage               { return nil }
+func (ctx *charmsSuiteContext) CrashLoops() facade.CrashLoopRecorder          { return nil }
 func (ctx *charmsSuiteContext) Hub() facade.Hub                               { return nil }
 func (ctx *charmsSuiteContext) Controller() *cache.Controller                 { return nil }
 func (ctx *charmsSuiteContext) CachedModel(uuid string) (*cache.Model, error) { return nil, nil }
@@ -79,7 +87,7 @@ func (s *charmsSuite) SetUpTest(c *gc.C) {
 	}
 
 	var err error
-	s.api, err = charms.NewFacadeV4(&charmsSuiteContext{cs: s})
+	s.api, err = charms.NewFacadeV5(&charmsSuiteContext{cs: s})
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -234,6 +242,83 @@ func (s *charmsMockSuite) TestResolveCharmsUnknownSchema(c *gc.C) {
 	c.Assert(result.Results[0].Error, gc.ErrorMatches, `unknown schema for charm URL "local:testme"`)
 }
 
+func (s *charmsMockSuite) TestDownloadBundlesNotCharmHub(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	api := s.api(c)
+
+	args := params.CharmURLAndOrigins{
+		Entities: []params.CharmURLAndOrigin{
+			{CharmURL: "cs:testme"},
+			{CharmURL: "local:testme"},
+		},
+	}
+
+	result, err := api.DownloadBundles(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `downloading non CharmHub bundle "cs:testme" not valid`)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `downloading non CharmHub bundle "local:testme" not valid`)
+}
+
+func (s *charmsMockSuite) TestDownloadBundles(c *gc.C) {
+	ctrl := s.setupMocks(c)
+	defer ctrl.Finish()
+	s.model.EXPECT().Config().Return(testing.ModelConfig(c), nil)
+
+	archive := []byte("bundle archive")
+	downloadURL := "https://api.charmhub.io/api/v1/bundles/download/wordpress_18.bundle"
+	chClient := mocks.NewMockCharmHubClient(ctrl)
+	chClient.EXPECT().Info(gomock.Any(), "wordpress").Return(transport.InfoResponse{
+		Name: "wordpress",
+		Type: "bundle",
+		ID:   "bundleBUNDLEbundleBUNDLEbundle01",
+		ChannelMap: []transport.InfoChannelMap{{
+			Channel: transport.Channel{
+				Name:     "stable",
+				Platform: transport.Platform{Architecture: arch.DefaultArchitecture, OS: "ubuntu", Series: "focal"},
+				Risk:     "stable",
+				Track:    "latest",
+			},
+			Revision: transport.InfoRevision{
+				Download:  transport.Download{URL: downloadURL},
+				Platforms: []transport.Platform{{Architecture: arch.DefaultArchitecture, OS: "ubuntu", Series: "focal"}},
+				Revision:  18,
+			},
+		}},
+	}, nil)
+	chClient.EXPECT().DownloadAndReadBundle(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, resourceURL *url.URL, archivePath string, _ ...charmhub.DownloadOption) (charm.Bundle, error) {
+			c.Check(resourceURL.String(), gc.Equals, downloadURL)
+			return nil, ioutil.WriteFile(archivePath, archive, 0644)
+		},
+	)
+	api := s.api(c)
+	charms.SetCharmHubClient(api, chClient)
+
+	track := "latest"
+	args := params.CharmURLAndOrigins{
+		Entities: []params.CharmURLAndOrigin{{
+			CharmURL: "ch:wordpress",
+			Origin: params.CharmOrigin{
+				Source:       "charm-hub",
+				Type:         "bundle",
+				Risk:         "stable",
+				Track:        &track,
+				Architecture: arch.DefaultArchitecture,
+				Series:       "focal",
+			},
+		}},
+	}
+	result, err := api.DownloadBundles(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Archive, gc.DeepEquals, archive)
+	c.Assert(result.Results[0].Origin.Type, gc.Equals, "bundle")
+	c.Assert(result.Results[0].Origin.Revision, gc.NotNil)
+	c.Assert(*result.Results[0].Origin.Revision, gc.Equals, 18)
+}
+
 func (s *charmsMockSuite) TestResolveCharmNoDefinedSeries(c *gc.C) {
 	defer s.setupMocks(c).Finish()
 	s.expectResolveWithPreferredChannelNoSeries()
@@ -0,0 +1,15 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charms
+
+import (
+	"github.com/juju/juju/charmhub"
+)
+
+// SetCharmHubClient has the API use the given client to reach CharmHub.
+func SetCharmHubClient(api *API, client CharmHubClient) {
+	api.newCharmHubClient = func(charmhub.Config) (CharmHubClient, error) {
+		return client, nil
+	}
+}
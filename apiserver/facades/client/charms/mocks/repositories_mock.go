@@ -115,6 +115,26 @@ func (mr *MockCharmHubClientMockRecorder) DownloadAndRead(arg0, arg1, arg2 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadAndRead", reflect.TypeOf((*MockCharmHubClient)(nil).DownloadAndRead), varargs...)
 }
 
+// DownloadAndReadBundle mocks base method
+func (m *MockCharmHubClient) DownloadAndReadBundle(arg0 context.Context, arg1 *url.URL, arg2 string, arg3 ...charmhub.DownloadOption) (charm.Bundle, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DownloadAndReadBundle", varargs...)
+	ret0, _ := ret[0].(charm.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadAndReadBundle indicates an expected call of DownloadAndReadBundle
+func (mr *MockCharmHubClientMockRecorder) DownloadAndReadBundle(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadAndReadBundle", reflect.TypeOf((*MockCharmHubClient)(nil).DownloadAndReadBundle), varargs...)
+}
+
 // Info mocks base method
 func (m *MockCharmHubClient) Info(arg0 context.Context, arg1 string, arg2 ...charmhub.InfoOption) (transport.InfoResponse, error) {
 	m.ctrl.T.Helper()
@@ -34,6 +34,7 @@ var logger = loggo.GetLogger("juju.apiserver.charms")
 // client to install or upgrade a CharmHub charm.
 type CharmHubClient interface {
 	DownloadAndRead(ctx context.Context, resourceURL *url.URL, archivePath string, options ...charmhub.DownloadOption) (*charm.CharmArchive, error)
+	DownloadAndReadBundle(ctx context.Context, resourceURL *url.URL, archivePath string, options ...charmhub.DownloadOption) (charm.Bundle, error)
 	Info(ctx context.Context, name string, options ...charmhub.InfoOption) (transport.InfoResponse, error)
 	Refresh(ctx context.Context, config charmhub.RefreshConfig) ([]transport.RefreshResponse, error)
 }
@@ -112,6 +113,17 @@ func (c *chRepo) DownloadCharm(resourceURL string, archivePath string) (*charm.C
 	return c.client.DownloadAndRead(context.TODO(), curl, archivePath)
 }
 
+// DownloadBundle downloads the provided download URL from CharmHub into
+// a bundle archive at the provided path.
+func (c *chRepo) DownloadBundle(resourceURL string, archivePath string) (charm.Bundle, error) {
+	logger.Debugf("DownloadBundle from CharmHub %q", resourceURL)
+	curl, err := url.Parse(resourceURL)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c.client.DownloadAndReadBundle(context.TODO(), curl, archivePath)
+}
+
 // FindDownloadURL returns the url from which to download the CharmHub
 // charm defined by the provided curl and charm origin.  An updated
 // charm origin is also returned with the ID and hash for the charm
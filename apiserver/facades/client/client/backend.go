@@ -37,6 +37,7 @@ type Backend interface {
 	AddRelation(...state.Endpoint) (*state.Relation, error)
 	AllApplications() ([]*state.Application, error)
 	AllApplicationOffers() ([]*crossmodel.ApplicationOffer, error)
+	AllEngineHealth() (map[string]state.EngineHealth, error)
 	AllRemoteApplications() ([]*state.RemoteApplication, error)
 	AllMachines() ([]*state.Machine, error)
 	AllModelUUIDs() ([]string, error)
@@ -81,9 +81,19 @@ type Client struct {
 	openCSRepo  application.OpenCSRepoFunc
 }
 
+// ClientV3 serves the (v3) client-specific API methods.
+type ClientV3 struct {
+	*Client
+}
+
+// ClientV2 serves the (v2) client-specific API methods.
+type ClientV2 struct {
+	*ClientV3
+}
+
 // ClientV1 serves the (v1) client-specific API methods.
 type ClientV1 struct {
-	*Client
+	*ClientV2
 }
 
 func (c *Client) checkCanRead() error {
@@ -134,14 +144,32 @@ func (c *Client) checkIsAdmin() error {
 	return nil
 }
 
-// NewFacade creates a version 1 Client facade to handle API requests.
+// NewFacade creates a version 4 Client facade to handle API requests.
 func NewFacade(ctx facade.Context) (*Client, error) {
 	return newFacade(ctx)
 }
 
+// NewFacadeV3 creates a version 3 Client facade to handle API requests.
+func NewFacadeV3(ctx facade.Context) (*ClientV3, error) {
+	client, err := newFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ClientV3{client}, nil
+}
+
+// NewFacadeV2 creates a version 2 Client facade to handle API requests.
+func NewFacadeV2(ctx facade.Context) (*ClientV2, error) {
+	client, err := NewFacadeV3(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ClientV2{client}, nil
+}
+
 // NewFacadeV1 creates a version 1 Client facade to handle API requests.
 func NewFacadeV1(ctx facade.Context) (*ClientV1, error) {
-	client, err := newFacade(ctx)
+	client, err := NewFacadeV2(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -188,7 +216,7 @@ func newFacade(ctx facade.Context) (*Client, error) {
 	return NewClient(
 		&stateShim{st, model, nil},
 		&poolShim{ctx.StatePool()},
-		&modelconfig.ModelConfigAPIV1{modelConfigAPI},
+		&modelconfig.ModelConfigAPIV1{&modelconfig.ModelConfigAPIV2{&modelconfig.ModelConfigAPIV3{modelConfigAPI}}},
 		resources,
 		authorizer,
 		presence,
@@ -274,6 +302,28 @@ func (c *Client) WatchAll() (params.AllWatcherId, error) {
 	}, nil
 }
 
+// WatchEntities initiates a watcher for a group of entities in the
+// connected model. The watcher returns coalesced deltas for just those
+// entities, so that a client need not start a watcher for each of them.
+// Watching an application also watches its units.
+func (c *Client) WatchEntities(args params.Entities) (params.AllWatcherId, error) {
+	if err := c.checkCanRead(); err != nil {
+		return params.AllWatcherId{}, err
+	}
+	filter, err := newEntityFilter(args.Entities)
+	if err != nil {
+		return params.AllWatcherId{}, errors.Trace(err)
+	}
+	modelUUID := c.api.stateAccessor.ModelUUID()
+	w := c.api.multiwatcherFactory.WatchModel(modelUUID)
+	return params.AllWatcherId{
+		AllWatcherId: c.api.resources.Register(&entityWatcher{Watcher: w, filter: filter}),
+	}, nil
+}
+
+// WatchEntities is not available before version 4.
+func (*ClientV3) WatchEntities(_, _ struct{}) {}
+
 type stripApplicationOffers struct {
 	multiwatcher.Watcher
 }
@@ -718,6 +718,10 @@ func clearContollerTimestamp(status *params.FullStatus) {
 	status.ControllerTimestamp = nil
 }
 
+func clearRevno(status *params.FullStatus) {
+	status.Revno = 0
+}
+
 func (s *clientSuite) TestClientStatus(c *gc.C) {
 	loggo.GetLogger("juju.core.cache").SetLogLevel(loggo.TRACE)
 	loggo.GetLogger("juju.state.allwatcher").SetLogLevel(loggo.TRACE)
@@ -725,6 +729,7 @@ func (s *clientSuite) TestClientStatus(c *gc.C) {
 	status, err := s.APIState.Client().Status(nil)
 	clearSinceTimes(status)
 	clearContollerTimestamp(status)
+	clearRevno(status)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(status, jc.DeepEquals, scenarioStatus)
 }
@@ -737,6 +742,41 @@ func (s *clientSuite) TestClientStatusControllerTimestamp(c *gc.C) {
 	c.Assert(status.ControllerTimestamp, gc.NotNil)
 }
 
+func (s *clientSuite) TestClientStatusNotModified(c *gc.C) {
+	s.setUpScenario(c)
+	client := s.APIState.Client()
+	status, err := client.FilteredStatus(params.StatusParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Revno, gc.Not(gc.Equals), int64(0))
+	c.Assert(status.NotModified, jc.IsFalse)
+
+	// The cached model may still be catching up with the scenario, in
+	// which case the full status is returned again with a later revno.
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		revno := status.Revno
+		status, err = client.FilteredStatus(params.StatusParams{KnownRevno: revno})
+		c.Assert(err, jc.ErrorIsNil)
+		if status.NotModified {
+			c.Assert(status.Revno, gc.Equals, revno)
+			c.Assert(status.IsEmpty(), jc.IsTrue)
+			return
+		}
+	}
+	c.Fatalf("status never reported as not modified")
+}
+
+func (s *clientSuite) TestClientStatusModified(c *gc.C) {
+	s.setUpScenario(c)
+	client := s.APIState.Client()
+	status, err := client.FilteredStatus(params.StatusParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err = client.FilteredStatus(params.StatusParams{KnownRevno: status.Revno - 1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.NotModified, jc.IsFalse)
+	c.Assert(status.Applications, gc.Not(gc.HasLen), 0)
+}
+
 func assertLife(c *gc.C, entity state.Living, life state.Life) {
 	err := entity.Refresh()
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,89 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/multiwatcher"
+)
+
+// entityFilter matches the multiwatcher entities of a group of model
+// entities. An application matches its units as well as itself.
+type entityFilter struct {
+	model        bool
+	applications set.Strings
+	units        set.Strings
+	machines     set.Strings
+}
+
+func newEntityFilter(entities []params.Entity) (*entityFilter, error) {
+	f := &entityFilter{
+		applications: set.NewStrings(),
+		units:        set.NewStrings(),
+		machines:     set.NewStrings(),
+	}
+	for _, entity := range entities {
+		tag, err := names.ParseTag(entity.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		switch tag := tag.(type) {
+		case names.ModelTag:
+			f.model = true
+		case names.ApplicationTag:
+			f.applications.Add(tag.Id())
+		case names.UnitTag:
+			f.units.Add(tag.Id())
+		case names.MachineTag:
+			f.machines.Add(tag.Id())
+		default:
+			return nil, errors.NotSupportedf("watching %s entities", tag.Kind())
+		}
+	}
+	return f, nil
+}
+
+func (f *entityFilter) match(info multiwatcher.EntityInfo) bool {
+	switch info := info.(type) {
+	case *multiwatcher.ModelInfo:
+		return f.model
+	case *multiwatcher.ApplicationInfo:
+		return f.applications.Contains(info.Name)
+	case *multiwatcher.UnitInfo:
+		return f.units.Contains(info.Name) || f.applications.Contains(info.Application)
+	case *multiwatcher.MachineInfo:
+		return f.machines.Contains(info.ID)
+	}
+	return false
+}
+
+// entityWatcher restricts the deltas of a model watcher
+// to those for the entities matched by its filter.
+type entityWatcher struct {
+	multiwatcher.Watcher
+	filter *entityFilter
+}
+
+// Next implements multiwatcher.Watcher.
+func (w *entityWatcher) Next() ([]multiwatcher.Delta, error) {
+	var result []multiwatcher.Delta
+	// As with the model watcher, only return once
+	// there is something of interest.
+	for len(result) == 0 {
+		deltas, err := w.Watcher.Next()
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range deltas {
+			if w.filter.match(d.Entity) {
+				result = append(result, d)
+			}
+		}
+	}
+	return result, nil
+}
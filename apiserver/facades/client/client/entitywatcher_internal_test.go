@@ -0,0 +1,108 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/multiwatcher"
+)
+
+type entityWatcherSuite struct{}
+
+var _ = gc.Suite(&entityWatcherSuite{})
+
+// fakeModelWatcher returns each of its batches of deltas in turn.
+type fakeModelWatcher struct {
+	batches [][]multiwatcher.Delta
+}
+
+func (w *fakeModelWatcher) Next() ([]multiwatcher.Delta, error) {
+	deltas := w.batches[0]
+	w.batches = w.batches[1:]
+	return deltas, nil
+}
+
+func (w *fakeModelWatcher) Stop() error {
+	return nil
+}
+
+func (*entityWatcherSuite) TestNewEntityFilterInvalidTag(c *gc.C) {
+	_, err := newEntityFilter([]params.Entity{{Tag: "bad"}})
+	c.Assert(err, gc.ErrorMatches, `"bad" is not a valid tag`)
+
+	_, err = newEntityFilter([]params.Entity{{Tag: "user-bob"}})
+	c.Assert(err, gc.ErrorMatches, `watching user entities not supported`)
+}
+
+func (*entityWatcherSuite) TestNextFiltersDeltas(c *gc.C) {
+	filter, err := newEntityFilter([]params.Entity{
+		{Tag: "application-mysql"},
+		{Tag: "unit-wordpress-1"},
+		{Tag: "machine-0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	mysql := &multiwatcher.ApplicationInfo{Name: "mysql"}
+	mysql0 := &multiwatcher.UnitInfo{Name: "mysql/0", Application: "mysql"}
+	wordpress := &multiwatcher.ApplicationInfo{Name: "wordpress"}
+	wordpress0 := &multiwatcher.UnitInfo{Name: "wordpress/0", Application: "wordpress"}
+	wordpress1 := &multiwatcher.UnitInfo{Name: "wordpress/1", Application: "wordpress"}
+	machine0 := &multiwatcher.MachineInfo{ID: "0"}
+	machine1 := &multiwatcher.MachineInfo{ID: "1"}
+	model := &multiwatcher.ModelInfo{Name: "default"}
+
+	w := &entityWatcher{
+		Watcher: &fakeModelWatcher{batches: [][]multiwatcher.Delta{{
+			{Entity: model},
+			{Entity: mysql},
+			{Entity: mysql0},
+			{Entity: wordpress},
+			{Entity: wordpress0},
+			{Entity: wordpress1},
+			{Entity: machine0},
+			{Entity: machine1},
+		}, {
+			// Nothing of interest, so Next waits for the following batch.
+			{Entity: wordpress0},
+		}, {
+			{Entity: mysql0, Removed: true},
+		}}},
+		filter: filter,
+	}
+
+	deltas, err := w.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(deltas, jc.DeepEquals, []multiwatcher.Delta{
+		{Entity: mysql},
+		{Entity: mysql0},
+		{Entity: wordpress1},
+		{Entity: machine0},
+	})
+
+	deltas, err = w.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(deltas, jc.DeepEquals, []multiwatcher.Delta{
+		{Entity: mysql0, Removed: true},
+	})
+}
+
+func (*entityWatcherSuite) TestNextModel(c *gc.C) {
+	filter, err := newEntityFilter([]params.Entity{{Tag: "model-deadbeef-0bad-400d-8000-4b1d0d06f00d"}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model := &multiwatcher.ModelInfo{Name: "default"}
+	w := &entityWatcher{
+		Watcher: &fakeModelWatcher{batches: [][]multiwatcher.Delta{{
+			{Entity: &multiwatcher.MachineInfo{ID: "0"}},
+			{Entity: model},
+		}}},
+		filter: filter,
+	}
+	deltas, err := w.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(deltas, jc.DeepEquals, []multiwatcher.Delta{{Entity: model}})
+}
@@ -10,6 +10,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 
@@ -118,6 +119,84 @@ func BuildPredicateFor(patterns []string) Predicate {
 	}
 }
 
+// BuildNetworkPredicateFor returns a Predicate which matches machines with
+// an address in one of the given spaces or subnets, and the units assigned
+// to those machines. Applications are never matched directly; they are
+// kept when one of their units matches. The machine addresses and their
+// spaces are those gathered from the link-layer device addresses.
+func BuildNetworkPredicateFor(
+	spaceNames, subnetCIDRs []string,
+	addresses map[string][]*state.Address,
+	machineSpaces map[string]map[string]set.Strings,
+	spaceInfos network.SpaceInfos,
+) (Predicate, error) {
+	for _, name := range spaceNames {
+		if !spaceInfos.ContainsName(name) {
+			return nil, errors.NotFoundf("space %q", name)
+		}
+	}
+	wantSpaces := set.NewStrings(spaceNames...)
+	var wantSubnets []*net.IPNet
+	for _, cidr := range subnetCIDRs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, errors.NotValidf("subnet %q", cidr)
+		}
+		wantSubnets = append(wantSubnets, ipNet)
+	}
+
+	matchedMachines := set.NewStrings()
+	for machineID, devices := range machineSpaces {
+		for _, spaces := range devices {
+			if !spaces.Intersection(wantSpaces).IsEmpty() {
+				matchedMachines.Add(machineID)
+			}
+		}
+	}
+	for machineID, addrs := range addresses {
+		for _, addr := range addrs {
+			ip := net.ParseIP(addr.Value())
+			for _, ipNet := range wantSubnets {
+				if addr.SubnetCIDR() == ipNet.String() || (ip != nil && ipNet.Contains(ip)) {
+					matchedMachines.Add(machineID)
+				}
+			}
+		}
+	}
+
+	return func(i interface{}) (bool, error) {
+		switch e := i.(type) {
+		default:
+			panic(errors.Errorf("expected a machine or an applications or a unit, got %T", i))
+		case *state.Machine:
+			return matchedMachines.Contains(e.Id()), nil
+		case *state.Unit:
+			machineID, err := e.AssignedMachineId()
+			if errors.IsNotAssigned(err) {
+				return false, nil
+			} else if err != nil {
+				return false, errors.Trace(err)
+			}
+			return matchedMachines.Contains(machineID), nil
+		case *state.Application:
+			return false, nil
+		}
+	}, nil
+}
+
+// andPredicates returns a Predicate which matches when all of the given
+// predicates match.
+func andPredicates(predicates ...Predicate) Predicate {
+	return func(i interface{}) (bool, error) {
+		for _, p := range predicates {
+			if matches, err := p(i); err != nil || !matches {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+}
+
 // Predicate is a function that when given a unit, machine, or
 // service, will determine whether the unit meets some criteria.
 type Predicate func(interface{}) (matches bool, _ error)
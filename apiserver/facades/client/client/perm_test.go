@@ -218,6 +218,7 @@ func opClientStatus(c *gc.C, st api.Connection, mst *state.State) (func(), error
 	clearSinceTimes(status)
 	clearSinceTimes(scenarioStatus)
 	clearContollerTimestamp(status)
+	clearRevno(status)
 	c.Assert(status, jc.DeepEquals, scenarioStatus)
 	return func() {}, nil
 }
@@ -166,12 +166,24 @@ func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.Stat
 	return results
 }
 
-// FullStatus gives the information needed for juju status over the api
+// FullStatus gives the information needed for juju status over the api.
+// If args.KnownRevno matches the model's current revno, the status is not
+// collected and the result only reports that it is not modified.
 func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error) {
 	if err := c.checkCanRead(); err != nil {
 		return params.FullStatus{}, err
 	}
 
+	// The revno is read before the status is collected, so that any
+	// change made while collecting it is seen by the next call.
+	var revno int64
+	if c.api.modelCache != nil {
+		revno = c.api.modelCache.Revno()
+		if args.KnownRevno != 0 && args.KnownRevno == revno {
+			return params.FullStatus{Revno: revno, NotModified: true}, nil
+		}
+	}
+
 	var noStatus params.FullStatus
 	var context statusContext
 	context.cachedModel = c.api.modelCache
@@ -217,6 +229,9 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	if err = context.fetchOpenPortRangesForAllMachines(c.api.stateAccessor); err != nil {
 		return noStatus, errors.Annotate(err, "could not fetch open port ranges")
 	}
+	if context.engineHealth, err = c.api.stateAccessor.AllEngineHealth(); err != nil {
+		return noStatus, errors.Annotate(err, "could not fetch engine health")
+	}
 	if context.controllerNodes, err = fetchControllerNodes(c.api.stateAccessor); err != nil {
 		return noStatus, errors.Annotate(err, "could not fetch controller nodes")
 	}
@@ -255,8 +270,20 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	logger.Tracef("Offers: %v", context.offers)
 	logger.Tracef("Relations: %v", context.relations)
 
-	if len(args.Patterns) > 0 {
+	filterByNetwork := len(args.Spaces) > 0 || len(args.Subnets) > 0
+	if len(args.Patterns) > 0 || filterByNetwork {
 		predicate := BuildPredicateFor(args.Patterns)
+		if len(args.Patterns) == 0 {
+			predicate = func(interface{}) (bool, error) { return true, nil }
+		}
+		if filterByNetwork {
+			networkPredicate, err := BuildNetworkPredicateFor(
+				args.Spaces, args.Subnets, context.ipAddresses, context.spaces, context.spaceInfos)
+			if err != nil {
+				return noStatus, errors.Trace(err)
+			}
+			predicate = andPredicates(predicate, networkPredicate)
+		}
 
 		// First, attempt to match machines. Any units on those
 		// machines are implicitly matched.
@@ -400,9 +427,19 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 		Relations:           context.processRelations(),
 		ControllerTimestamp: context.controllerTimestamp,
 		Branches:            context.processBranches(),
+		Revno:               revno,
 	}, nil
 }
 
+// FullStatus gives the information needed for juju status over the api.
+// Filtering by space or subnet isn't available before version 3, so
+// those arguments are ignored.
+func (c *ClientV2) FullStatus(args params.StatusParams) (params.FullStatus, error) {
+	args.Spaces = nil
+	args.Subnets = nil
+	return c.Client.FullStatus(args)
+}
+
 func filterBranches(ctxBranches map[string]cache.Branch, matchedApps, matchedForBranches set.Strings) map[string]cache.Branch {
 	// Filter branches based on matchedApps which contains
 	// the application name if matching on application or unit.
@@ -542,6 +579,10 @@ type statusContext struct {
 	// opened ports by machine.
 	openPortRangesByMachine map[string]state.MachinePortRanges
 
+	// engineHealth: machine id -> health of the machine agent's
+	// dependency engine
+	engineHealth map[string]state.EngineHealth
+
 	// offers: offer name -> offer
 	offers map[string]offerStatus
 
@@ -958,6 +999,7 @@ func (c *statusContext) makeMachineStatus(machine *state.Machine, appStatusInfo
 
 	var err error
 	status.Id = machineID
+	status.UUID = machine.UUID()
 	agentStatus := c.processMachine(machine)
 	status.AgentStatus = agentStatus
 
@@ -1064,6 +1106,21 @@ func (c *statusContext) makeMachineStatus(machine *state.Machine, appStatusInfo
 	}
 	status.Containers = make(map[string]params.MachineStatus)
 
+	if health, ok := c.engineHealth[machineID]; ok {
+		status.EngineHealth = &params.EngineHealth{
+			Updated: health.Updated,
+		}
+		for _, w := range health.Workers {
+			status.EngineHealth.Workers = append(status.EngineHealth.Workers, params.EngineWorkerHealth{
+				Name:     w.Name,
+				Health:   w.Health,
+				State:    w.State,
+				Error:    w.Error,
+				Restarts: w.Restarts,
+			})
+		}
+	}
+
 	lxdProfiles := make(map[string]params.LXDProfile)
 	charmProfiles := c.allInstances.CharmProfiles(machineID)
 	if charmProfiles != nil {
@@ -1102,6 +1159,7 @@ func (context *statusContext) processRelations() []params.RelationStatus {
 		}
 		relStatus := params.RelationStatus{
 			Id:        relation.Id(),
+			UUID:      relation.UUID(),
 			Key:       relation.String(),
 			Interface: relationInterface,
 			Scope:     string(scope),
@@ -1178,6 +1236,7 @@ func (context *statusContext) processApplication(application *state.Application)
 	}
 
 	var processedStatus = params.ApplicationStatus{
+		UUID:             application.UUID(),
 		Charm:            applicationCharm.URL().String(),
 		Series:           application.Series(),
 		Exposed:          application.IsExposed(),
@@ -1430,7 +1489,7 @@ func (context *statusContext) unitPublicAddress(unit *state.Unit) string {
 }
 
 func (context *statusContext) processUnit(unit *state.Unit, applicationCharm string, expectWorkload bool) params.UnitStatus {
-	var result params.UnitStatus
+	result := params.UnitStatus{UUID: unit.UUID()}
 	if context.model.Type() == state.ModelTypeIAAS {
 		result.PublicAddress = context.unitPublicAddress(unit)
 
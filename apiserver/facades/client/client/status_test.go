@@ -220,6 +220,40 @@ func (s *statusSuite) TestFullStatusInterfaceScaling(c *gc.C) {
 			"in the way the addresses are processed"))
 }
 
+func (s *statusSuite) TestFullStatusFilterByNetwork(c *gc.C) {
+	inSpace := s.addMachine(c)
+	other := s.addMachine(c)
+	s.createSpaceAndSubnetWithProviderID(c, "db-space", "10.20.0.0/24", "prov-ffff")
+	s.createSpaceAndSubnetWithProviderID(c, "public", "10.0.0.0/24", "prov-0000")
+	s.createNICWithIP(c, inSpace, "eth0", "10.20.0.42/24")
+	s.createNICWithIP(c, other, "eth0", "10.0.0.11/24")
+
+	client := s.APIState.Client()
+	status, err := client.FilteredStatus(params.StatusParams{Spaces: []string{"db-space"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.Machines, gc.HasLen, 1)
+	_, ok := status.Machines[inSpace.Id()]
+	c.Check(ok, jc.IsTrue)
+
+	status, err = client.FilteredStatus(params.StatusParams{Subnets: []string{"10.0.0.0/24"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.Machines, gc.HasLen, 1)
+	_, ok = status.Machines[other.Id()]
+	c.Check(ok, jc.IsTrue)
+
+	status, err = client.FilteredStatus(params.StatusParams{
+		Patterns: []string{other.Id()},
+		Spaces:   []string{"db-space"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.Machines, gc.HasLen, 0)
+
+	_, err = client.FilteredStatus(params.StatusParams{Spaces: []string{"missing"}})
+	c.Check(err, gc.ErrorMatches, `space "missing" not found`)
+	_, err = client.FilteredStatus(params.StatusParams{Subnets: []string{"bad"}})
+	c.Check(err, gc.ErrorMatches, `subnet "bad" not valid`)
+}
+
 func (s *statusSuite) createSpaceAndSubnetWithProviderID(c *gc.C, spaceName, CIDR, providerSubnetID string) {
 	space, err := s.State.AddSpace(spaceName, network.Id(spaceName), nil, true)
 	c.Assert(err, jc.ErrorIsNil)
@@ -6,17 +6,28 @@
 package controller
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
+	"github.com/juju/os/v2/series"
 	"github.com/juju/txn"
+	"github.com/juju/utils/v2/arch"
+	"github.com/juju/version"
 	"gopkg.in/macaroon.v2"
 
+	agenttools "github.com/juju/juju/agent/tools"
 	"github.com/juju/juju/api"
 	controllerclient "github.com/juju/juju/api/controller"
 	"github.com/juju/juju/api/migrationtarget"
@@ -31,9 +42,13 @@ import (
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/multiwatcher"
 	"github.com/juju/juju/core/permission"
+	jujunames "github.com/juju/juju/juju/names"
 	"github.com/juju/juju/migration"
+	"github.com/juju/juju/pubsub/apiserver"
 	"github.com/juju/juju/pubsub/controller"
 	"github.com/juju/juju/state"
+	coretools "github.com/juju/juju/tools"
+	"github.com/juju/juju/upgrades"
 	jujuversion "github.com/juju/juju/version"
 )
 
@@ -57,10 +72,34 @@ type ControllerAPI struct {
 	multiwatcherFactory multiwatcher.Factory
 }
 
+// ControllerAPIv12 provides the v12 Controller API. The only difference
+// between this and v13 is that v12 doesn't have DrainControllers.
+type ControllerAPIv12 struct {
+	*ControllerAPI
+}
+
+// ControllerAPIv11 provides the v11 Controller API. The only difference
+// between this and v12 is that v11 doesn't have UpgradePlan.
+type ControllerAPIv11 struct {
+	*ControllerAPIv12
+}
+
+// ControllerAPIv10 provides the v10 Controller API. The only difference
+// between this and v11 is that v10 doesn't have VerifyIntegrity.
+type ControllerAPIv10 struct {
+	*ControllerAPIv11
+}
+
+// ControllerAPIv9 provides the v9 Controller API. The only difference
+// between this and v10 is that v9 doesn't have FindOrphanedDocuments.
+type ControllerAPIv9 struct {
+	*ControllerAPIv10
+}
+
 // ControllerAPIv8 provides the v8 Controller API. The only difference
 // between this and v9 is that v8 doesn't have the model summary watchers.
 type ControllerAPIv8 struct {
-	*ControllerAPI
+	*ControllerAPIv9
 }
 
 // ControllerAPIv7 provides the v7 Controller API. The only difference
@@ -95,10 +134,10 @@ type ControllerAPIv3 struct {
 
 // LatestAPI is used for testing purposes to create the latest
 // controller API.
-var LatestAPI = NewControllerAPIv9
+var LatestAPI = NewControllerAPIv13
 
-// NewControllerAPIv9 creates a new ControllerAPIv9.
-func NewControllerAPIv9(ctx facade.Context) (*ControllerAPI, error) {
+// NewControllerAPIv13 creates a new ControllerAPIv13.
+func NewControllerAPIv13(ctx facade.Context) (*ControllerAPI, error) {
 	st := ctx.State()
 	authorizer := ctx.Auth()
 	pool := ctx.StatePool()
@@ -120,6 +159,42 @@ func NewControllerAPIv9(ctx facade.Context) (*ControllerAPI, error) {
 	)
 }
 
+// NewControllerAPIv12 creates a new ControllerAPIv12.
+func NewControllerAPIv12(ctx facade.Context) (*ControllerAPIv12, error) {
+	v13, err := NewControllerAPIv13(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv12{v13}, nil
+}
+
+// NewControllerAPIv11 creates a new ControllerAPIv11.
+func NewControllerAPIv11(ctx facade.Context) (*ControllerAPIv11, error) {
+	v12, err := NewControllerAPIv12(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv11{v12}, nil
+}
+
+// NewControllerAPIv10 creates a new ControllerAPIv10.
+func NewControllerAPIv10(ctx facade.Context) (*ControllerAPIv10, error) {
+	v11, err := NewControllerAPIv11(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv10{v11}, nil
+}
+
+// NewControllerAPIv9 creates a new ControllerAPIv9.
+func NewControllerAPIv9(ctx facade.Context) (*ControllerAPIv9, error) {
+	v10, err := NewControllerAPIv10(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv9{v10}, nil
+}
+
 // NewControllerAPIv8 creates a new ControllerAPIv8.
 func NewControllerAPIv8(ctx facade.Context) (*ControllerAPIv8, error) {
 	v9, err := NewControllerAPIv9(ctx)
@@ -309,6 +384,243 @@ func (c *ControllerAPI) MongoVersion() (params.StringResult, error) {
 	return result, nil
 }
 
+// FindOrphanedDocuments isn't on the v9 API.
+func (c *ControllerAPIv9) FindOrphanedDocuments(_, _ struct{}) {}
+
+// FindOrphanedDocuments looks for documents, in every model on the
+// controller, which refer to entities that no longer exist, such as units
+// of removed applications. If args.Remove is set, the orphaned documents
+// found are also removed.
+func (c *ControllerAPI) FindOrphanedDocuments(args params.FindOrphanedDocumentsArgs) (params.OrphanedDocumentsResult, error) {
+	result := params.OrphanedDocumentsResult{}
+	if err := c.checkIsSuperUser(); err != nil {
+		return result, errors.Trace(err)
+	}
+	orphans, err := c.state.FindOrphanedDocuments()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if args.Remove && len(orphans) > 0 {
+		if err := c.state.RemoveOrphanedDocuments(orphans); err != nil {
+			return result, errors.Trace(err)
+		}
+		logger.Infof("removed %d orphaned documents", len(orphans))
+		result.Removed = true
+	}
+	for _, orphan := range orphans {
+		result.Documents = append(result.Documents, params.OrphanedDocument{
+			Collection: orphan.Collection,
+			ModelTag:   names.NewModelTag(orphan.ModelUUID).String(),
+			Id:         orphan.Id,
+			Reason:     orphan.Reason,
+		})
+	}
+	return result, nil
+}
+
+// VerifyIntegrity isn't on the v10 API.
+func (c *ControllerAPIv10) VerifyIntegrity(_, _ struct{}) {}
+
+// VerifyIntegrity runs consistency checks across the collections of every
+// model on the controller, such as checking application refcounts, life
+// invariants and references to spaces, and reports the problems found. If
+// args.Repair is set, the problems which can be repaired safely are
+// repaired.
+func (c *ControllerAPI) VerifyIntegrity(args params.VerifyIntegrityArgs) (params.IntegrityReport, error) {
+	result := params.IntegrityReport{}
+	if err := c.checkIsSuperUser(); err != nil {
+		return result, errors.Trace(err)
+	}
+	problems, err := c.state.VerifyIntegrity(args.Repair)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Checks = state.IntegrityChecks
+	for _, problem := range problems {
+		if problem.Repaired {
+			logger.Infof("repaired %s in model %s: %s %q: %s",
+				problem.Check, problem.ModelUUID, problem.Collection, problem.Id, problem.Detail)
+		}
+		result.Problems = append(result.Problems, params.IntegrityProblem{
+			Check:      problem.Check,
+			Collection: problem.Collection,
+			ModelTag:   names.NewModelTag(problem.ModelUUID).String(),
+			Id:         problem.Id,
+			Detail:     problem.Detail,
+			Repairable: problem.Repairable,
+			Repaired:   problem.Repaired,
+		})
+	}
+	return result, nil
+}
+
+// DrainControllers isn't on the v12 API.
+func (c *ControllerAPIv12) DrainControllers(_, _ struct{}) {}
+
+// DrainControllers asks the API servers on the given controller machines
+// to drain their connections, so that agents and clients reconnect to
+// another controller, and then restart. Draining every controller at
+// once leaves nothing for agents to reconnect to, so callers should
+// drain one controller at a time.
+func (c *ControllerAPI) DrainControllers(args params.Entities) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	if err := c.checkIsSuperUser(); err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, arg := range args.Entities {
+		result.Results[i].Error = apiservererrors.ServerError(c.drainController(arg.Tag))
+	}
+	return result, nil
+}
+
+func (c *ControllerAPI) drainController(tagString string) error {
+	tag, err := names.ParseMachineTag(tagString)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	m, err := c.state.Machine(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !m.IsManager() {
+		return errors.NotValidf("non-controller machine %q", tag.Id())
+	}
+	// The message isn't local-only, so it is forwarded to the other
+	// controllers; only the API server on the named machine acts on it.
+	if _, err := c.hub.Publish(
+		apiserver.DrainTopic,
+		apiserver.Drain{MachineID: tag.Id()}); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// UpgradePlan isn't on the v11 API.
+func (c *ControllerAPIv11) UpgradePlan(_, _ struct{}) {}
+
+// UpgradePlan reports the upgrade steps which would be run, in order,
+// when upgrading between the versions given, without running them. The
+// controller only knows the steps up to its own version, so to plan an
+// upgrade past it, the plan is made by the agent binaries being
+// upgraded to, which must already have been uploaded to the controller.
+func (c *ControllerAPI) UpgradePlan(args params.UpgradePlanArgs) (params.UpgradePlanResult, error) {
+	result := params.UpgradePlanResult{}
+	if err := c.checkIsSuperUser(); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	from := args.FromVersion
+	if from == version.Zero {
+		model, err := c.state.Model()
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		if from, err = model.AgentVersion(); err != nil {
+			return result, errors.Trace(err)
+		}
+	}
+	to := args.ToVersion
+	if to == version.Zero {
+		to = jujuversion.Current
+	}
+	if from.Compare(to) > 0 {
+		return result, errors.NotValidf("upgrade from %s to %s", from, to)
+	}
+
+	targets := upgrades.AllTargets
+	if len(args.Targets) > 0 {
+		targets = make([]upgrades.Target, len(args.Targets))
+		for i, target := range args.Targets {
+			if !validUpgradeTarget(upgrades.Target(target)) {
+				return result, errors.NotValidf("upgrade target %q", target)
+			}
+			targets[i] = upgrades.Target(target)
+		}
+	}
+
+	if to.Compare(jujuversion.Current) > 0 {
+		result, err := planWithAgentBinaries(c.state, from, to, args.Targets)
+		return result, errors.Annotatef(err, "planning upgrade to %s", to)
+	}
+	return upgrades.PlanResult(from, to, targets), nil
+}
+
+func validUpgradeTarget(target upgrades.Target) bool {
+	for _, valid := range upgrades.AllTargets {
+		if target == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// upgradePlanTimeout is how long the agent binaries being upgraded to
+// are given to report their upgrade plan.
+const upgradePlanTimeout = time.Minute
+
+// planWithAgentBinaries has the jujud in the agent binaries for the "to"
+// version, stored in the controller, plan the upgrade. It is a variable
+// so it can be replaced in tests.
+var planWithAgentBinaries = func(st *state.State, from, to version.Number, targets []string) (params.UpgradePlanResult, error) {
+	hostSeries, err := series.HostSeries()
+	if err != nil {
+		return params.UpgradePlanResult{}, errors.Trace(err)
+	}
+	vers := version.Binary{
+		Number: to,
+		Arch:   arch.HostArch(),
+		Series: hostSeries,
+	}
+
+	storage, err := st.ToolsStorage()
+	if err != nil {
+		return params.UpgradePlanResult{}, errors.Trace(err)
+	}
+	defer func() { _ = storage.Close() }()
+	metadata, r, err := storage.Open(vers.String())
+	if errors.IsNotFound(err) {
+		return params.UpgradePlanResult{}, errors.NotFoundf("agent binaries %s in the controller", vers)
+	} else if err != nil {
+		return params.UpgradePlanResult{}, errors.Trace(err)
+	}
+	defer func() { _ = r.Close() }()
+
+	dataDir, err := ioutil.TempDir("", "juju-upgrade-plan")
+	if err != nil {
+		return params.UpgradePlanResult{}, errors.Trace(err)
+	}
+	defer func() { _ = os.RemoveAll(dataDir) }()
+	if err := agenttools.UnpackTools(dataDir, &coretools.Tools{
+		Version: vers,
+		Size:    metadata.Size,
+		SHA256:  metadata.SHA256,
+	}, r); err != nil {
+		return params.UpgradePlanResult{}, errors.Annotatef(err, "unpacking agent binaries %s", vers)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), upgradePlanTimeout)
+	defer cancel()
+	cmdArgs := []string{"upgrade-plan", "--from", from.String()}
+	if len(targets) > 0 {
+		cmdArgs = append(cmdArgs, "--targets", strings.Join(targets, ","))
+	}
+	jujud := filepath.Join(agenttools.SharedToolsDir(dataDir, vers), jujunames.Jujud)
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, jujud, cmdArgs...)
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return params.UpgradePlanResult{}, errors.Annotatef(err, "running jujud %s: %s", to, strings.TrimSpace(stderr.String()))
+	}
+	var result params.UpgradePlanResult
+	if err := json.Unmarshal(output, &result); err != nil {
+		return params.UpgradePlanResult{}, errors.Annotatef(err, "reading jujud %s upgrade plan", to)
+	}
+	return result, nil
+}
+
 // AllModels allows controller administrators to get the list of all the
 // models in the controller.
 func (c *ControllerAPI) AllModels() (params.UserModelList, error) {
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/pubsub"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/v2"
+	"github.com/juju/version"
 	"github.com/juju/worker/v2/workertest"
 	"github.com/prometheus/client_golang/prometheus"
 	gc "gopkg.in/check.v1"
@@ -33,11 +34,13 @@ import (
 	"github.com/juju/juju/environs"
 	environscloudspec "github.com/juju/juju/environs/cloudspec"
 	"github.com/juju/juju/environs/config"
+	psapiserver "github.com/juju/juju/pubsub/apiserver"
 	pscontroller "github.com/juju/juju/pubsub/controller"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
+	jujuversion "github.com/juju/juju/version"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/modelcache"
 	"github.com/juju/juju/worker/multiwatcher"
@@ -903,7 +906,6 @@ func (s *controllerSuite) TestModelStatusV3(c *gc.C) {
 	api, err := controller.NewControllerAPIv3(
 		facadetest.Context{
 			State_:     s.State,
-			StatePool_: s.StatePool,
 			Resources_: s.resources,
 			Auth_:      s.authorizer,
 		})
@@ -1025,6 +1027,221 @@ func (s *controllerSuite) TestConfigSetPublishesEvent(c *gc.C) {
 	c.Assert(config.Features().SortedValues(), jc.DeepEquals, []string{"bar", "foo"})
 }
 
+func (s *controllerSuite) addOrphanedUnit(c *gc.C) {
+	units := s.State.MongoSession().DB("juju").C("units")
+	uuid := s.State.ModelUUID()
+	err := units.Insert(map[string]interface{}{
+		"_id":         uuid + ":ghost/0",
+		"name":        "ghost/0",
+		"model-uuid":  uuid,
+		"application": "ghost",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *controllerSuite) TestFindOrphanedDocuments(c *gc.C) {
+	s.addOrphanedUnit(c)
+
+	result, err := s.controller.FindOrphanedDocuments(params.FindOrphanedDocumentsArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.OrphanedDocumentsResult{
+		Documents: []params.OrphanedDocument{{
+			Collection: "units",
+			ModelTag:   s.Model.ModelTag().String(),
+			Id:         "ghost/0",
+			Reason:     `application "ghost" not found`,
+		}},
+	})
+
+	// Without Remove, the document is left alone.
+	result, err = s.controller.FindOrphanedDocuments(params.FindOrphanedDocumentsArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Documents, gc.HasLen, 1)
+}
+
+func (s *controllerSuite) TestFindOrphanedDocumentsRemove(c *gc.C) {
+	s.addOrphanedUnit(c)
+
+	result, err := s.controller.FindOrphanedDocuments(params.FindOrphanedDocumentsArgs{Remove: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Documents, gc.HasLen, 1)
+	c.Assert(result.Removed, jc.IsTrue)
+
+	result, err = s.controller.FindOrphanedDocuments(params.FindOrphanedDocumentsArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.OrphanedDocumentsResult{})
+}
+
+func (s *controllerSuite) TestFindOrphanedDocumentsRequiresSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Access: permission.ReadAccess,
+	})
+	anAuthoriser := apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	}
+	endpoint, err := controller.LatestAPI(
+		facadetest.Context{
+			State_:     s.State,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endpoint.FindOrphanedDocuments(params.FindOrphanedDocumentsArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *controllerSuite) TestVerifyIntegrity(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	applications := s.State.MongoSession().DB("juju").C("applications")
+	err := applications.UpdateId(s.State.ModelUUID()+":"+app.Name(), map[string]interface{}{
+		"$set": map[string]interface{}{"unitcount": 2},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.controller.VerifyIntegrity(params.VerifyIntegrityArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.IntegrityReport{
+		Checks: []string{"refcounts", "life", "bindings"},
+		Problems: []params.IntegrityProblem{{
+			Check:      "refcounts",
+			Collection: "applications",
+			ModelTag:   s.Model.ModelTag().String(),
+			Id:         app.Name(),
+			Detail:     "unit count is 2 but 0 units found",
+			Repairable: true,
+		}},
+	})
+
+	result, err = s.controller.VerifyIntegrity(params.VerifyIntegrityArgs{Repair: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Problems, gc.HasLen, 1)
+	c.Assert(result.Problems[0].Repaired, jc.IsTrue)
+
+	result, err = s.controller.VerifyIntegrity(params.VerifyIntegrityArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Problems, gc.HasLen, 0)
+}
+
+func (s *controllerSuite) TestVerifyIntegrityRequiresSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Access: permission.ReadAccess,
+	})
+	anAuthoriser := apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	}
+	endpoint, err := controller.LatestAPI(
+		facadetest.Context{
+			State_:     s.State,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endpoint.VerifyIntegrity(params.VerifyIntegrityArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *controllerSuite) TestUpgradePlanDefaults(c *gc.C) {
+	result, err := s.controller.UpgradePlan(params.UpgradePlanArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	agentVersion, err := s.Model.AgentVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.FromVersion, gc.Equals, agentVersion)
+	c.Assert(result.ToVersion, gc.Equals, jujuversion.Current)
+}
+
+func (s *controllerSuite) TestUpgradePlanWithinControllerVersion(c *gc.C) {
+	from := version.MustParse("2.8.0")
+	s.PatchValue(&jujuversion.Current, version.MustParse("2.9.0"))
+	controller.SetPlanWithAgentBinaries(s, func(_, _ version.Number, _ []string) (params.UpgradePlanResult, error) {
+		c.Fatalf("unexpected use of agent binaries")
+		return params.UpgradePlanResult{}, nil
+	})
+	result, err := s.controller.UpgradePlan(params.UpgradePlanArgs{
+		FromVersion: from,
+		ToVersion:   version.MustParse("2.9.0"),
+		Targets:     []string{"controller"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.FromVersion, gc.Equals, from)
+	c.Assert(result.ToVersion, gc.Equals, version.MustParse("2.9.0"))
+	for _, step := range result.Steps {
+		c.Check(step.TargetVersion.Compare(from) > 0, jc.IsTrue)
+		c.Check(step.TargetVersion.Compare(result.ToVersion) <= 0, jc.IsTrue)
+	}
+}
+
+func (s *controllerSuite) TestUpgradePlanPastControllerVersion(c *gc.C) {
+	s.PatchValue(&jujuversion.Current, version.MustParse("2.9.0"))
+	expected := params.UpgradePlanResult{
+		FromVersion: version.MustParse("2.8.0"),
+		ToVersion:   version.MustParse("3.0.0"),
+		Steps: []params.UpgradePlanStep{{
+			TargetVersion: version.MustParse("3.0.0"),
+			Description:   "a step only 3.0.0 knows about",
+			Targets:       []string{"controller"},
+		}},
+	}
+	controller.SetPlanWithAgentBinaries(s, func(from, to version.Number, targets []string) (params.UpgradePlanResult, error) {
+		c.Check(from, gc.Equals, version.MustParse("2.8.0"))
+		c.Check(to, gc.Equals, version.MustParse("3.0.0"))
+		c.Check(targets, jc.DeepEquals, []string{"controller"})
+		return expected, nil
+	})
+	result, err := s.controller.UpgradePlan(params.UpgradePlanArgs{
+		FromVersion: version.MustParse("2.8.0"),
+		ToVersion:   version.MustParse("3.0.0"),
+		Targets:     []string{"controller"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expected)
+}
+
+func (s *controllerSuite) TestUpgradePlanAgentBinariesNotUploaded(c *gc.C) {
+	s.PatchValue(&jujuversion.Current, version.MustParse("2.9.0"))
+	_, err := s.controller.UpgradePlan(params.UpgradePlanArgs{
+		FromVersion: version.MustParse("2.8.0"),
+		ToVersion:   version.MustParse("3.0.0"),
+	})
+	c.Assert(err, gc.ErrorMatches, `planning upgrade to 3.0.0: agent binaries 3.0.0-.* in the controller not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *controllerSuite) TestUpgradePlanInvalidTarget(c *gc.C) {
+	_, err := s.controller.UpgradePlan(params.UpgradePlanArgs{
+		Targets: []string{"bogus"},
+	})
+	c.Assert(err, gc.ErrorMatches, `upgrade target "bogus" not valid`)
+}
+
+func (s *controllerSuite) TestUpgradePlanDowngrade(c *gc.C) {
+	s.PatchValue(&jujuversion.Current, version.MustParse("2.9.0"))
+	_, err := s.controller.UpgradePlan(params.UpgradePlanArgs{
+		FromVersion: version.MustParse("2.9.1"),
+	})
+	c.Assert(err, gc.ErrorMatches, `upgrade from 2.9.1 to 2.9.0 not valid`)
+}
+
+func (s *controllerSuite) TestUpgradePlanRequiresSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Access: permission.ReadAccess,
+	})
+	anAuthoriser := apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	}
+	endpoint, err := controller.LatestAPI(
+		facadetest.Context{
+			State_:     s.State,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endpoint.UpgradePlan(params.UpgradePlanArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *controllerSuite) TestMongoVersion(c *gc.C) {
 	result, err := s.controller.MongoVersion()
 	c.Assert(err, jc.ErrorIsNil)
@@ -1183,3 +1400,67 @@ func (noopRegisterer) Register(prometheus.Collector) error {
 func (noopRegisterer) Unregister(prometheus.Collector) bool {
 	return true
 }
+
+func (s *controllerSuite) TestDrainControllers(c *gc.C) {
+	m := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Jobs: []state.MachineJob{state.JobManageModel},
+	})
+	done := make(chan struct{})
+	var drain psapiserver.Drain
+	s.hub.Subscribe(psapiserver.DrainTopic, func(topic string, data psapiserver.Drain, err error) {
+		c.Check(err, jc.ErrorIsNil)
+		drain = data
+		close(done)
+	})
+
+	result, err := s.controller.DrainControllers(params.Entities{
+		Entities: []params.Entity{{Tag: m.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+
+	select {
+	case <-done:
+	case <-time.After(testing.LongWait):
+		c.Fatal("no event sent")
+	}
+	// The message must be forwarded to the other controllers.
+	c.Assert(drain, jc.DeepEquals, psapiserver.Drain{MachineID: m.Id()})
+}
+
+func (s *controllerSuite) TestDrainControllersInvalidMachines(c *gc.C) {
+	m := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Jobs: []state.MachineJob{state.JobHostUnits},
+	})
+	result, err := s.controller.DrainControllers(params.Entities{
+		Entities: []params.Entity{
+			{Tag: m.Tag().String()},
+			{Tag: "machine-42"},
+			{Tag: "unit-mysql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Check(result.Results[0].Error, gc.ErrorMatches, `non-controller machine ".*" not valid`)
+	c.Check(result.Results[1].Error, gc.ErrorMatches, `machine 42 not found`)
+	c.Check(result.Results[2].Error, gc.ErrorMatches, `"unit-mysql-0" is not a valid machine tag`)
+}
+
+func (s *controllerSuite) TestDrainControllersRequiresSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Access: permission.ReadAccess,
+	})
+	anAuthoriser := apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	}
+	endpoint, err := controller.LatestAPI(
+		facadetest.Context{
+			State_:     s.State,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endpoint.DrainControllers(params.Entities{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
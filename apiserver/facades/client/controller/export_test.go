@@ -4,7 +4,10 @@
 package controller
 
 import (
+	"github.com/juju/version"
+
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/state"
 )
@@ -18,3 +21,9 @@ func SetPrecheckResult(p patcher, err error) {
 		return err
 	})
 }
+
+func SetPlanWithAgentBinaries(p patcher, f func(from, to version.Number, targets []string) (params.UpgradePlanResult, error)) {
+	p.PatchValue(&planWithAgentBinaries, func(_ *state.State, from, to version.Number, targets []string) (params.UpgradePlanResult, error) {
+		return f(from, to, targets)
+	})
+}
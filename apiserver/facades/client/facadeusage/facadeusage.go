@@ -0,0 +1,104 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package facadeusage provides the FacadeUsage facade, which reports
+// the facade versions that clients and agents have called on an API
+// server. Operators can use it to find out what will break before old
+// facade versions are dropped.
+//
+// Usage is recorded in memory by each API server since it started, so
+// in an HA controller each API server reports only the calls it has
+// served.
+package facadeusage
+
+import (
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/permission"
+)
+
+// Registry provides the facades registered with the controller.
+type Registry interface {
+	ListDetails() []facade.Details
+}
+
+// API implements the FacadeUsage facade.
+type API struct {
+	usage         facade.FacadeUsage
+	registry      Registry
+	authorizer    facade.Authorizer
+	controllerTag names.ControllerTag
+}
+
+// NewAPI returns a new FacadeUsage API facade, reporting the usage
+// recorded by usage against the facades in the registry.
+func NewAPI(
+	usage facade.FacadeUsage,
+	registry Registry,
+	authorizer facade.Authorizer,
+	controllerTag names.ControllerTag,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &API{
+		usage:         usage,
+		registry:      registry,
+		authorizer:    authorizer,
+		controllerTag: controllerTag,
+	}, nil
+}
+
+// Usage returns the facade versions called on this API server, by
+// client and kind of entity, along with the latest version of each
+// facade supported by the controller. Only controller superusers may
+// request the usage.
+func (api *API) Usage(args params.FacadeUsageArgs) (params.FacadeUsageResults, error) {
+	isSuperUser, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.controllerTag)
+	if err != nil {
+		return params.FacadeUsageResults{}, errors.Trace(err)
+	}
+	if !isSuperUser {
+		return params.FacadeUsageResults{}, apiservererrors.ErrPerm
+	}
+
+	latest := make(map[string]int)
+	for _, details := range api.registry.ListDetails() {
+		if details.Version > latest[details.Name] {
+			latest[details.Name] = details.Version
+		}
+	}
+
+	wanted := set.NewStrings(args.Facades...)
+	result := params.FacadeUsageResults{
+		Since: api.usage.Since(),
+		Usage: []params.FacadeUsage{},
+	}
+	for _, u := range api.usage.Usage() {
+		if !wanted.IsEmpty() && !wanted.Contains(u.Facade) {
+			continue
+		}
+		// A facade which is no longer registered has no latest
+		// version, and any version of it is old.
+		latestVersion := latest[u.Facade]
+		if args.OldVersionsOnly && latestVersion != 0 && u.Version >= latestVersion {
+			continue
+		}
+		result.Usage = append(result.Usage, params.FacadeUsage{
+			Facade:        u.Facade,
+			Version:       u.Version,
+			LatestVersion: latestVersion,
+			Client:        u.Client,
+			EntityKind:    u.EntityKind,
+			Count:         u.Count,
+			FirstSeen:     u.FirstSeen,
+			LastSeen:      u.LastSeen,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package facadeusage_test
+
+import (
+	"time"
+
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facades/client/facadeusage"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	corefacadeusage "github.com/juju/juju/core/facadeusage"
+	coretesting "github.com/juju/juju/testing"
+)
+
+var (
+	since = time.Date(2021, 4, 1, 12, 0, 0, 0, time.UTC)
+	seen  = since.Add(time.Hour)
+)
+
+type facadeUsageSuite struct {
+	testing.IsolationSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+	usage      fakeUsage
+	registry   fakeRegistry
+}
+
+var _ = gc.Suite(&facadeUsageSuite{})
+
+func (s *facadeUsageSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("admin"),
+		AdminTag: names.NewUserTag("admin"),
+	}
+	s.registry = fakeRegistry{
+		{Name: "Client", Version: 2},
+		{Name: "Client", Version: 3},
+		{Name: "Uniter", Version: 17},
+		{Name: "Uniter", Version: 18},
+	}
+	s.usage = fakeUsage{
+		usage(corefacadeusage.Call{Facade: "Client", Version: 3, Client: "juju/2.9.0", EntityKind: "user"}, 4),
+		usage(corefacadeusage.Call{Facade: "Gone", Version: 1, Client: "python-libjuju/2.8.6", EntityKind: "user"}, 1),
+		usage(corefacadeusage.Call{Facade: "Uniter", Version: 17, Client: "juju/2.8.10", EntityKind: "unit"}, 12),
+		usage(corefacadeusage.Call{Facade: "Uniter", Version: 18, Client: "juju/2.9.0", EntityKind: "unit"}, 30),
+	}
+}
+
+func usage(call corefacadeusage.Call, count int64) corefacadeusage.Usage {
+	return corefacadeusage.Usage{
+		Call:      call,
+		Count:     count,
+		FirstSeen: since,
+		LastSeen:  seen,
+	}
+}
+
+func (s *facadeUsageSuite) newAPI(c *gc.C) *facadeusage.API {
+	api, err := facadeusage.NewAPI(s.usage, s.registry, s.authorizer, coretesting.ControllerTag)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *facadeUsageSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := facadeusage.NewAPI(s.usage, s.registry, s.authorizer, coretesting.ControllerTag)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *facadeUsageSuite) TestUsageRequiresSuperuser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.newAPI(c).Usage(params.FacadeUsageArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *facadeUsageSuite) TestUsage(c *gc.C) {
+	result, err := s.newAPI(c).Usage(params.FacadeUsageArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.FacadeUsageResults{
+		Since: since,
+		Usage: []params.FacadeUsage{{
+			Facade: "Client", Version: 3, LatestVersion: 3,
+			Client: "juju/2.9.0", EntityKind: "user",
+			Count: 4, FirstSeen: since, LastSeen: seen,
+		}, {
+			Facade: "Gone", Version: 1, LatestVersion: 0,
+			Client: "python-libjuju/2.8.6", EntityKind: "user",
+			Count: 1, FirstSeen: since, LastSeen: seen,
+		}, {
+			Facade: "Uniter", Version: 17, LatestVersion: 18,
+			Client: "juju/2.8.10", EntityKind: "unit",
+			Count: 12, FirstSeen: since, LastSeen: seen,
+		}, {
+			Facade: "Uniter", Version: 18, LatestVersion: 18,
+			Client: "juju/2.9.0", EntityKind: "unit",
+			Count: 30, FirstSeen: since, LastSeen: seen,
+		}},
+	})
+}
+
+func (s *facadeUsageSuite) TestUsageOldVersionsOnly(c *gc.C) {
+	result, err := s.newAPI(c).Usage(params.FacadeUsageArgs{OldVersionsOnly: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Usage, gc.HasLen, 2)
+	c.Check(result.Usage[0].Facade, gc.Equals, "Gone")
+	c.Check(result.Usage[1].Facade, gc.Equals, "Uniter")
+	c.Check(result.Usage[1].Version, gc.Equals, 17)
+}
+
+func (s *facadeUsageSuite) TestUsageSelectedFacades(c *gc.C) {
+	result, err := s.newAPI(c).Usage(params.FacadeUsageArgs{Facades: []string{"Client"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Usage, gc.HasLen, 1)
+	c.Check(result.Usage[0].Facade, gc.Equals, "Client")
+}
+
+func (s *facadeUsageSuite) TestUsageEmpty(c *gc.C) {
+	s.usage = nil
+	result, err := s.newAPI(c).Usage(params.FacadeUsageArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.FacadeUsageResults{
+		Since: since,
+		Usage: []params.FacadeUsage{},
+	})
+}
+
+type fakeUsage []corefacadeusage.Usage
+
+func (u fakeUsage) Since() time.Time {
+	return since
+}
+
+func (u fakeUsage) Usage() []corefacadeusage.Usage {
+	return u
+}
+
+type fakeRegistry []facade.Details
+
+func (r fakeRegistry) ListDetails() []facade.Details {
+	return r
+}
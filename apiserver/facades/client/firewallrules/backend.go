@@ -7,6 +7,10 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/state"
 )
 
@@ -17,6 +21,25 @@ type Backend interface {
 	ModelTag() names.ModelTag
 	SaveFirewallRule(state.FirewallRule) error
 	ListFirewallRules() ([]*state.FirewallRule, error)
+	ModelConfig() (*config.Config, error)
+	AllMachines() ([]Machine, error)
+	OpenedPortRangesForAllMachines() ([]state.MachinePortRanges, error)
+}
+
+// Machine defines the machine functionality required by the
+// firewallrules facade. For details on the methods, see the methods
+// on state.Machine with the same names.
+type Machine interface {
+	Id() string
+	InstanceId() (instance.Id, error)
+}
+
+// Environ defines the provider functionality required by the
+// firewallrules facade to audit the rules in the cloud. Environs
+// using the global firewall mode must also implement
+// environs.Firewaller.
+type Environ interface {
+	Instances(ctx context.ProviderCallContext, ids []instance.Id) ([]instances.Instance, error)
 }
 
 // BlockChecker defines the block-checking functionality required by
@@ -57,3 +80,15 @@ func (s stateShim) ListFirewallRules() ([]*state.FirewallRule, error) {
 	api := state.NewFirewallRules(s.State)
 	return api.AllRules()
 }
+
+func (s stateShim) AllMachines() ([]Machine, error) {
+	machines, err := s.State.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Machine, len(machines))
+	for i, m := range machines {
+		result[i] = m
+	}
+	return result, nil
+}
@@ -12,31 +12,65 @@ import (
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/network/firewall"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.firewallrules")
 
-// API provides the firewallrules facade APIs for v1.
+// API provides the firewallrules facade APIs for v2.
 type API struct {
-	backend    Backend
-	authorizer facade.Authorizer
-	check      BlockChecker
+	backend     Backend
+	authorizer  facade.Authorizer
+	check       BlockChecker
+	newEnviron  func() (Environ, error)
+	callContext context.ProviderCallContext
+}
+
+// APIv1 provides the firewallrules facade APIs for v1.
+type APIv1 struct {
+	*API
+}
+
+// NewFacadeV1 provides the signature required for facade registration
+// of version 1.
+func NewFacadeV1(ctx facade.Context) (*APIv1, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv1{api}, nil
 }
 
 // NewFacade provides the signature required for facade registration.
 func NewFacade(ctx facade.Context) (*API, error) {
-	backend, err := NewStateBackend(ctx.State())
+	st := ctx.State()
+	backend, err := NewStateBackend(st)
 	if err != nil {
 		return nil, errors.Annotate(err, "getting state")
 	}
-	blockChecker := common.NewBlockChecker(ctx.State())
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	newEnviron := func() (Environ, error) {
+		return stateenvirons.GetNewEnvironFunc(environs.New)(model)
+	}
+	blockChecker := common.NewBlockChecker(st)
 	return NewAPI(
 		backend,
 		ctx.Auth(),
 		blockChecker,
+		newEnviron,
+		context.CallContext(st),
 	)
 }
 
@@ -45,14 +79,18 @@ func NewAPI(
 	backend Backend,
 	authorizer facade.Authorizer,
 	blockChecker BlockChecker,
+	newEnviron func() (Environ, error),
+	callContext context.ProviderCallContext,
 ) (*API, error) {
 	if !authorizer.AuthClient() {
 		return nil, apiservererrors.ErrPerm
 	}
 	return &API{
-		backend:    backend,
-		authorizer: authorizer,
-		check:      blockChecker,
+		backend:     backend,
+		authorizer:  authorizer,
+		check:       blockChecker,
+		newEnviron:  newEnviron,
+		callContext: callContext,
 	}, nil
 }
 
@@ -115,3 +153,106 @@ func (api *API) ListFirewallRules() (params.ListFirewallRulesResults, error) {
 	}
 	return listResults, nil
 }
+
+// AuditFirewall compares the ingress rules in the cloud with the port
+// ranges opened in the model, and reports any rules that are not backed
+// by an opened port range. Such orphan rules are left behind when units
+// or machines go away while the firewaller isn't running.
+func (api *API) AuditFirewall() (params.FirewallAuditResults, error) {
+	var result params.FirewallAuditResults
+	if err := api.checkCanRead(); err != nil {
+		return result, errors.Trace(err)
+	}
+	cfg, err := api.backend.ModelConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.FirewallMode = cfg.FirewallMode()
+	if result.FirewallMode == config.FwNone {
+		return result, nil
+	}
+
+	openedByMachine := make(map[string][]network.PortRange)
+	opened, err := api.backend.OpenedPortRangesForAllMachines()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, machinePortRanges := range opened {
+		machineID := machinePortRanges.MachineID()
+		openedByMachine[machineID] = append(openedByMachine[machineID], machinePortRanges.UniquePortRanges()...)
+	}
+
+	env, err := api.newEnviron()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if result.FirewallMode == config.FwGlobal {
+		fw, ok := env.(environs.Firewaller)
+		if !ok {
+			return result, errors.NotSupportedf("auditing global firewall rules")
+		}
+		rules, err := fw.IngressRules(api.callContext)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		var allOpened []network.PortRange
+		for _, portRanges := range openedByMachine {
+			allOpened = append(allOpened, portRanges...)
+		}
+		result.Orphans = orphanRules("", rules, allOpened)
+		return result, nil
+	}
+
+	machines, err := api.backend.AllMachines()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, m := range machines {
+		instId, err := m.InstanceId()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return result, errors.Trace(err)
+		}
+		envInstances, err := env.Instances(api.callContext, []instance.Id{instId})
+		if err == environs.ErrNoInstances {
+			continue
+		} else if err != nil {
+			return result, errors.Trace(err)
+		}
+		fwInstance, ok := envInstances[0].(instances.InstanceFirewaller)
+		if !ok {
+			continue
+		}
+		rules, err := fwInstance.IngressRules(api.callContext, m.Id())
+		if err != nil {
+			return result, errors.Annotatef(err, "getting ingress rules for machine %q", m.Id())
+		}
+		result.Orphans = append(result.Orphans, orphanRules(m.Id(), rules, openedByMachine[m.Id()])...)
+	}
+	return result, nil
+}
+
+// orphanRules returns the rules whose port range is not one of the
+// opened port ranges.
+func orphanRules(machineID string, rules firewall.IngressRules, opened []network.PortRange) []params.OrphanFirewallRule {
+	backed := make(map[network.PortRange]bool)
+	for _, pr := range opened {
+		backed[pr] = true
+	}
+	var result []params.OrphanFirewallRule
+	for _, rule := range rules {
+		if backed[rule.PortRange] {
+			continue
+		}
+		result = append(result, params.OrphanFirewallRule{
+			MachineId:   machineID,
+			PortRange:   params.FromNetworkPortRange(rule.PortRange),
+			SourceCIDRs: rule.SourceCIDRs.SortedValues(),
+		})
+	}
+	return result
+}
+
+// AuditFirewall isn't on the v1 API.
+func (*APIv1) AuditFirewall(_, _ struct{}) {}
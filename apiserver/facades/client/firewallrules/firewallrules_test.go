@@ -13,7 +13,12 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/firewallrules"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/network/firewall"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -24,6 +29,7 @@ type FirewallRulesSuite struct {
 	backend mockBackend
 
 	blockChecker mockBlockChecker
+	environ      mockEnviron
 	authorizer   apiservertesting.FakeAuthorizer
 	api          *firewallrules.API
 }
@@ -36,6 +42,10 @@ func (s *FirewallRulesSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		&s.backend,
 		s.authorizer,
 		&s.blockChecker,
+		func() (firewallrules.Environ, error) {
+			return &s.environ, nil
+		},
+		context.NewCloudCallContext(),
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	s.api = api
@@ -52,10 +62,15 @@ func (s *FirewallRulesSuite) SetUpTest(c *gc.C) {
 		rules:     make(map[string]state.FirewallRule),
 	}
 	s.blockChecker = mockBlockChecker{}
+	s.environ = mockEnviron{}
 	api, err := firewallrules.NewAPI(
 		&s.backend,
 		s.authorizer,
 		&s.blockChecker,
+		func() (firewallrules.Environ, error) {
+			return &s.environ, nil
+		},
+		context.NewCloudCallContext(),
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	s.api = api
@@ -118,3 +133,80 @@ func (s *FirewallRulesSuite) TestListFirewallRulesPermission(c *gc.C) {
 	_, err := s.api.ListFirewallRules()
 	c.Assert(err, gc.ErrorMatches, ".*permission denied.*")
 }
+
+func (s *FirewallRulesSuite) setFirewallMode(c *gc.C, mode string) {
+	cfg, err := config.New(config.UseDefaults, coretesting.FakeConfig().Merge(coretesting.Attrs{
+		"firewall-mode": mode,
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.cfg = cfg
+}
+
+func (s *FirewallRulesSuite) TestAuditFirewallGlobal(c *gc.C) {
+	s.setFirewallMode(c, config.FwGlobal)
+	s.backend.opened = []state.MachinePortRanges{
+		&mockMachinePortRanges{machineID: "0", portRanges: []network.PortRange{network.MustParsePortRange("80/tcp")}},
+		&mockMachinePortRanges{machineID: "1", portRanges: []network.PortRange{network.MustParsePortRange("443/tcp")}},
+	}
+	s.environ.rules = firewall.IngressRules{
+		firewall.NewIngressRule(network.MustParsePortRange("80/tcp"), firewall.AllNetworksIPV4CIDR),
+		firewall.NewIngressRule(network.MustParsePortRange("443/tcp"), firewall.AllNetworksIPV4CIDR),
+		firewall.NewIngressRule(network.MustParsePortRange("8080/tcp"), "10.0.0.0/24", firewall.AllNetworksIPV4CIDR),
+	}
+
+	result, err := s.api.AuditFirewall()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.FirewallAuditResults{
+		FirewallMode: config.FwGlobal,
+		Orphans: []params.OrphanFirewallRule{{
+			PortRange:   params.PortRange{FromPort: 8080, ToPort: 8080, Protocol: "tcp"},
+			SourceCIDRs: []string{"0.0.0.0/0", "10.0.0.0/24"},
+		}},
+	})
+}
+
+func (s *FirewallRulesSuite) TestAuditFirewallInstance(c *gc.C) {
+	s.setFirewallMode(c, config.FwInstance)
+	s.backend.machines = []firewallrules.Machine{
+		&mockMachine{id: "0", instId: "inst-0"},
+		&mockMachine{id: "1", instId: "inst-1"},
+		&mockMachine{id: "2"},
+	}
+	s.backend.opened = []state.MachinePortRanges{
+		&mockMachinePortRanges{machineID: "0", portRanges: []network.PortRange{network.MustParsePortRange("80/tcp")}},
+	}
+	s.environ.instances = map[instance.Id]instances.Instance{
+		"inst-0": &mockInstance{rules: map[string]firewall.IngressRules{
+			"0": {firewall.NewIngressRule(network.MustParsePortRange("80/tcp"), firewall.AllNetworksIPV4CIDR)},
+		}},
+		"inst-1": &mockInstance{rules: map[string]firewall.IngressRules{
+			"1": {firewall.NewIngressRule(network.MustParsePortRange("80/tcp"), firewall.AllNetworksIPV4CIDR)},
+		}},
+	}
+
+	result, err := s.api.AuditFirewall()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.FirewallAuditResults{
+		FirewallMode: config.FwInstance,
+		Orphans: []params.OrphanFirewallRule{{
+			MachineId:   "1",
+			PortRange:   params.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+			SourceCIDRs: []string{"0.0.0.0/0"},
+		}},
+	})
+}
+
+func (s *FirewallRulesSuite) TestAuditFirewallNone(c *gc.C) {
+	s.setFirewallMode(c, config.FwNone)
+
+	result, err := s.api.AuditFirewall()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.FirewallAuditResults{FirewallMode: config.FwNone})
+	s.backend.CheckCallNames(c, "ModelTag", "ModelConfig")
+}
+
+func (s *FirewallRulesSuite) TestAuditFirewallPermission(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("mary"))
+	_, err := s.api.AuditFirewall()
+	c.Assert(err, gc.ErrorMatches, ".*permission denied.*")
+}
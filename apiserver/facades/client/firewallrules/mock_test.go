@@ -4,11 +4,18 @@
 package firewallrules_test
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	jtesting "github.com/juju/testing"
 
 	"github.com/juju/juju/apiserver/facades/client/firewallrules"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/network/firewall"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/state"
 )
 
@@ -18,6 +25,9 @@ type mockBackend struct {
 
 	modelUUID string
 	rules     map[string]state.FirewallRule
+	cfg       *config.Config
+	machines  []firewallrules.Machine
+	opened    []state.MachinePortRanges
 }
 
 func (m *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
@@ -46,6 +56,94 @@ func (m *mockBackend) ListFirewallRules() ([]*state.FirewallRule, error) {
 	return frls, nil
 }
 
+func (m *mockBackend) ModelConfig() (*config.Config, error) {
+	m.MethodCall(m, "ModelConfig")
+	return m.cfg, m.NextErr()
+}
+
+func (m *mockBackend) AllMachines() ([]firewallrules.Machine, error) {
+	m.MethodCall(m, "AllMachines")
+	return m.machines, m.NextErr()
+}
+
+func (m *mockBackend) OpenedPortRangesForAllMachines() ([]state.MachinePortRanges, error) {
+	m.MethodCall(m, "OpenedPortRangesForAllMachines")
+	return m.opened, m.NextErr()
+}
+
+type mockMachine struct {
+	id     string
+	instId instance.Id
+}
+
+func (m *mockMachine) Id() string {
+	return m.id
+}
+
+func (m *mockMachine) InstanceId() (instance.Id, error) {
+	if m.instId == "" {
+		return "", errors.NotProvisionedf("machine %v", m.id)
+	}
+	return m.instId, nil
+}
+
+type mockMachinePortRanges struct {
+	state.MachinePortRanges
+
+	machineID  string
+	portRanges []network.PortRange
+}
+
+func (m *mockMachinePortRanges) MachineID() string {
+	return m.machineID
+}
+
+func (m *mockMachinePortRanges) UniquePortRanges() []network.PortRange {
+	return m.portRanges
+}
+
+type mockEnviron struct {
+	environs.Firewaller
+
+	rules     firewall.IngressRules
+	instances map[instance.Id]instances.Instance
+}
+
+func (e *mockEnviron) IngressRules(context.ProviderCallContext) (firewall.IngressRules, error) {
+	return e.rules, nil
+}
+
+func (e *mockEnviron) Instances(_ context.ProviderCallContext, ids []instance.Id) ([]instances.Instance, error) {
+	var result []instances.Instance
+	for _, id := range ids {
+		if inst, ok := e.instances[id]; ok {
+			result = append(result, inst)
+		}
+	}
+	if len(result) == 0 {
+		return nil, environs.ErrNoInstances
+	}
+	return result, nil
+}
+
+type mockInstance struct {
+	instances.Instance
+
+	rules map[string]firewall.IngressRules
+}
+
+func (i *mockInstance) OpenPorts(context.ProviderCallContext, string, firewall.IngressRules) error {
+	return errors.NotImplementedf("OpenPorts")
+}
+
+func (i *mockInstance) ClosePorts(context.ProviderCallContext, string, firewall.IngressRules) error {
+	return errors.NotImplementedf("ClosePorts")
+}
+
+func (i *mockInstance) IngressRules(_ context.ProviderCallContext, machineId string) (firewall.IngressRules, error) {
+	return i.rules[machineId], nil
+}
+
 type mockBlockChecker struct {
 	jtesting.Stub
 }
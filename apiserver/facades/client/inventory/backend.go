@@ -0,0 +1,133 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package inventory
+
+import (
+	"github.com/juju/charm/v9"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/state"
+)
+
+// Backend provides access to the models on the controller.
+type Backend interface {
+	// AllModelUUIDs returns the UUIDs of all the models on the
+	// controller.
+	AllModelUUIDs() ([]string, error)
+
+	// GetModel returns the model with the given UUID, along with a
+	// function that must be called to release it.
+	GetModel(modelUUID string) (Model, func(), error)
+}
+
+// Model describes the parts of a model needed for its inventory.
+type Model interface {
+	ModelTag() names.ModelTag
+	Name() string
+	Owner() names.UserTag
+	Type() state.ModelType
+	CloudName() string
+	CloudRegion() string
+	AllMachines() ([]Machine, error)
+	AllVolumes() ([]state.Volume, error)
+	AllApplications() ([]Application, error)
+}
+
+// Machine describes the parts of a machine needed for an inventory.
+type Machine interface {
+	Id() string
+	Series() string
+	InstanceId() (instance.Id, error)
+	Constraints() (constraints.Value, error)
+	HardwareCharacteristics() (*instance.HardwareCharacteristics, error)
+}
+
+// Application describes the parts of an application needed for an
+// inventory.
+type Application interface {
+	Name() string
+	CharmURL() (*charm.URL, bool)
+	CharmVersion() (string, error)
+	UnitCount() int
+}
+
+type backendShim struct {
+	st   *state.State
+	pool *state.StatePool
+}
+
+// AllModelUUIDs implements Backend.
+func (b backendShim) AllModelUUIDs() ([]string, error) {
+	return b.st.AllModelUUIDs()
+}
+
+// GetModel implements Backend.
+func (b backendShim) GetModel(modelUUID string) (Model, func(), error) {
+	st, err := b.pool.Get(modelUUID)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	model, err := st.Model()
+	if err != nil {
+		st.Release()
+		return nil, nil, errors.Trace(err)
+	}
+	return &modelShim{Model: model, st: st.State}, func() { st.Release() }, nil
+}
+
+type modelShim struct {
+	*state.Model
+	st *state.State
+}
+
+// AllMachines implements Model.
+func (m *modelShim) AllMachines() ([]Machine, error) {
+	machines, err := m.st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Machine, len(machines))
+	for i, machine := range machines {
+		result[i] = machine
+	}
+	return result, nil
+}
+
+// AllVolumes implements Model.
+func (m *modelShim) AllVolumes() ([]state.Volume, error) {
+	sb, err := state.NewStorageBackend(m.st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return sb.AllVolumes()
+}
+
+// AllApplications implements Model.
+func (m *modelShim) AllApplications() ([]Application, error) {
+	apps, err := m.st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Application, len(apps))
+	for i, app := range apps {
+		result[i] = applicationShim{app}
+	}
+	return result, nil
+}
+
+type applicationShim struct {
+	*state.Application
+}
+
+// CharmVersion implements Application.
+func (a applicationShim) CharmVersion() (string, error) {
+	ch, _, err := a.Application.Charm()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return ch.Version(), nil
+}
@@ -0,0 +1,210 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package inventory provides the Inventory facade, which reports the
+// machines, volumes and applications of all the models on a controller.
+package inventory
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/state"
+)
+
+// API implements the Inventory facade.
+type API struct {
+	backend       Backend
+	authorizer    facade.Authorizer
+	controllerTag names.ControllerTag
+}
+
+// NewFacade is used for API registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	return NewAPI(
+		backendShim{st: st, pool: ctx.StatePool()},
+		ctx.Auth(),
+		st.ControllerTag(),
+	)
+}
+
+// NewAPI returns a new Inventory API facade.
+func NewAPI(
+	backend Backend,
+	authorizer facade.Authorizer,
+	controllerTag names.ControllerTag,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &API{
+		backend:       backend,
+		authorizer:    authorizer,
+		controllerTag: controllerTag,
+	}, nil
+}
+
+// Report returns the inventory of every model on the controller: its
+// machines with their instances and hardware, its volumes, and its
+// applications with their charms and unit counts. Only controller
+// superusers may request the report. A model whose inventory cannot be
+// gathered is reported with an error rather than failing the report.
+func (api *API) Report() (params.InventoryReport, error) {
+	isSuperUser, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.controllerTag)
+	if err != nil {
+		return params.InventoryReport{}, errors.Trace(err)
+	}
+	if !isSuperUser {
+		return params.InventoryReport{}, apiservererrors.ErrPerm
+	}
+
+	uuids, err := api.backend.AllModelUUIDs()
+	if err != nil {
+		return params.InventoryReport{}, errors.Trace(err)
+	}
+	models := make([]params.ModelInventory, 0, len(uuids))
+	for _, uuid := range uuids {
+		inventory, err := api.modelInventory(uuid)
+		if err != nil {
+			inventory.ModelTag = names.NewModelTag(uuid).String()
+			inventory.Error = apiservererrors.ServerError(err)
+		}
+		models = append(models, inventory)
+	}
+	sort.Slice(models, func(i, j int) bool {
+		if models[i].OwnerTag != models[j].OwnerTag {
+			return models[i].OwnerTag < models[j].OwnerTag
+		}
+		return models[i].Name < models[j].Name
+	})
+	return params.InventoryReport{Models: models}, nil
+}
+
+func (api *API) modelInventory(uuid string) (params.ModelInventory, error) {
+	model, release, err := api.backend.GetModel(uuid)
+	if err != nil {
+		return params.ModelInventory{}, errors.Trace(err)
+	}
+	defer release()
+
+	result := params.ModelInventory{
+		ModelTag:    model.ModelTag().String(),
+		Name:        model.Name(),
+		OwnerTag:    model.Owner().String(),
+		Type:        string(model.Type()),
+		Cloud:       model.CloudName(),
+		CloudRegion: model.CloudRegion(),
+	}
+
+	machines, err := model.AllMachines()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, m := range machines {
+		machine, err := machineInventory(m)
+		if err != nil {
+			return result, errors.Annotatef(err, "machine %q", m.Id())
+		}
+		result.Machines = append(result.Machines, machine)
+	}
+
+	volumes, err := model.AllVolumes()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, v := range volumes {
+		result.Volumes = append(result.Volumes, volumeInventory(v))
+	}
+
+	apps, err := model.AllApplications()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, app := range apps {
+		application, err := applicationInventory(app)
+		if err != nil {
+			return result, errors.Annotatef(err, "application %q", app.Name())
+		}
+		result.Applications = append(result.Applications, application)
+	}
+	return result, nil
+}
+
+func machineInventory(m Machine) (params.MachineInventory, error) {
+	result := params.MachineInventory{
+		Id:     m.Id(),
+		Series: m.Series(),
+	}
+	instId, err := m.InstanceId()
+	if err != nil && !errors.IsNotProvisioned(err) {
+		return result, errors.Trace(err)
+	}
+	result.InstanceId = string(instId)
+
+	// The instance type actually used isn't recorded, so report the
+	// one the machine was constrained to, if any.
+	cons, err := m.Constraints()
+	if err != nil && !errors.IsNotFound(err) {
+		return result, errors.Trace(err)
+	}
+	if cons.HasInstanceType() {
+		result.InstanceType = *cons.InstanceType
+	}
+
+	hc, err := m.HardwareCharacteristics()
+	if errors.IsNotFound(err) {
+		return result, nil
+	} else if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Hardware = &params.MachineHardware{
+		Arch:             hc.Arch,
+		Mem:              hc.Mem,
+		RootDisk:         hc.RootDisk,
+		Cores:            hc.CpuCores,
+		CpuPower:         hc.CpuPower,
+		Tags:             hc.Tags,
+		AvailabilityZone: hc.AvailabilityZone,
+	}
+	return result, nil
+}
+
+func volumeInventory(v state.Volume) params.VolumeInventory {
+	result := params.VolumeInventory{
+		Id: v.VolumeTag().Id(),
+	}
+	if info, err := v.Info(); err == nil {
+		result.ProviderId = info.VolumeId
+		result.Pool = info.Pool
+		result.Size = info.Size
+		result.Persistent = info.Persistent
+	} else if volumeParams, ok := v.Params(); ok {
+		// Not yet provisioned, so report what was asked for.
+		result.Pool = volumeParams.Pool
+		result.Size = volumeParams.Size
+	}
+	return result
+}
+
+func applicationInventory(app Application) (params.ApplicationInventory, error) {
+	result := params.ApplicationInventory{
+		Name:      app.Name(),
+		UnitCount: app.UnitCount(),
+	}
+	if curl, _ := app.CharmURL(); curl != nil {
+		result.CharmURL = curl.String()
+	}
+	version, err := app.CharmVersion()
+	if err != nil && !errors.IsNotFound(err) {
+		return result, errors.Trace(err)
+	}
+	result.CharmVersion = version
+	return result, nil
+}
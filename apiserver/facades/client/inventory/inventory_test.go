@@ -0,0 +1,345 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package inventory_test
+
+import (
+	"github.com/juju/charm/v9"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/inventory"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+const otherModelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00e"
+
+type inventorySuite struct {
+	testing.IsolationSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+	backend    *mockBackend
+	api        *inventory.API
+}
+
+var _ = gc.Suite(&inventorySuite{})
+
+func (s *inventorySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("admin"),
+		AdminTag: names.NewUserTag("admin"),
+	}
+	arch := "amd64"
+	mem := uint64(8192)
+	cores := uint64(4)
+	s.backend = &mockBackend{
+		models: map[string]*mockModel{
+			coretesting.ModelTag.Id(): {
+				tag:    coretesting.ModelTag,
+				name:   "prod",
+				owner:  names.NewUserTag("bob"),
+				cloud:  "aws",
+				region: "us-east-1",
+				machines: []inventory.Machine{
+					&mockMachine{
+						id:          "0",
+						series:      "focal",
+						instId:      "i-0123",
+						constraints: constraints.MustParse("instance-type=m5.large"),
+						hardware: &instance.HardwareCharacteristics{
+							Arch:     &arch,
+							Mem:      &mem,
+							CpuCores: &cores,
+						},
+					},
+					&mockMachine{id: "1", series: "focal"},
+				},
+				volumes: []state.Volume{
+					&mockVolume{
+						tag:  names.NewVolumeTag("0"),
+						info: &state.VolumeInfo{VolumeId: "vol-0123", Pool: "ebs", Size: 10240, Persistent: true},
+					},
+					&mockVolume{
+						tag:    names.NewVolumeTag("1"),
+						params: &state.VolumeParams{Pool: "ebs-ssd", Size: 2048},
+					},
+				},
+				applications: []inventory.Application{
+					&mockApplication{
+						name:         "postgresql",
+						curl:         charm.MustParseURL("ch:amd64/focal/postgresql-42"),
+						charmVersion: "v1.2.3",
+						units:        3,
+					},
+				},
+			},
+			otherModelUUID: {
+				tag:    names.NewModelTag(otherModelUUID),
+				name:   "controller",
+				owner:  names.NewUserTag("admin"),
+				cloud:  "aws",
+				region: "us-east-1",
+			},
+		},
+	}
+	s.api = s.newAPI(c)
+}
+
+func (s *inventorySuite) newAPI(c *gc.C) *inventory.API {
+	api, err := inventory.NewAPI(s.backend, s.authorizer, coretesting.ControllerTag)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *inventorySuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := inventory.NewAPI(s.backend, s.authorizer, coretesting.ControllerTag)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *inventorySuite) TestReportRequiresSuperuser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.newAPI(c).Report()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *inventorySuite) TestReport(c *gc.C) {
+	report, err := s.api.Report()
+	c.Assert(err, jc.ErrorIsNil)
+
+	arch := "amd64"
+	mem := uint64(8192)
+	cores := uint64(4)
+	c.Assert(report, jc.DeepEquals, params.InventoryReport{
+		Models: []params.ModelInventory{{
+			ModelTag:    names.NewModelTag(otherModelUUID).String(),
+			Name:        "controller",
+			OwnerTag:    "user-admin",
+			Type:        "iaas",
+			Cloud:       "aws",
+			CloudRegion: "us-east-1",
+		}, {
+			ModelTag:    coretesting.ModelTag.String(),
+			Name:        "prod",
+			OwnerTag:    "user-bob",
+			Type:        "iaas",
+			Cloud:       "aws",
+			CloudRegion: "us-east-1",
+			Machines: []params.MachineInventory{{
+				Id:           "0",
+				Series:       "focal",
+				InstanceId:   "i-0123",
+				InstanceType: "m5.large",
+				Hardware: &params.MachineHardware{
+					Arch:  &arch,
+					Mem:   &mem,
+					Cores: &cores,
+				},
+			}, {
+				Id:     "1",
+				Series: "focal",
+			}},
+			Volumes: []params.VolumeInventory{{
+				Id:         "0",
+				ProviderId: "vol-0123",
+				Pool:       "ebs",
+				Size:       10240,
+				Persistent: true,
+			}, {
+				Id:   "1",
+				Pool: "ebs-ssd",
+				Size: 2048,
+			}},
+			Applications: []params.ApplicationInventory{{
+				Name:         "postgresql",
+				CharmURL:     "ch:amd64/focal/postgresql-42",
+				CharmVersion: "v1.2.3",
+				UnitCount:    3,
+			}},
+		}},
+	})
+	s.backend.CheckCallNames(c, "AllModelUUIDs", "GetModel", "GetModel")
+}
+
+func (s *inventorySuite) TestReportModelError(c *gc.C) {
+	s.backend.SetErrors(nil, nil, errors.New("boom"))
+	report, err := s.api.Report()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.Models, gc.HasLen, 2)
+
+	var failed []params.ModelInventory
+	for _, model := range report.Models {
+		if model.Error != nil {
+			failed = append(failed, model)
+		}
+	}
+	c.Assert(failed, gc.HasLen, 1)
+	c.Assert(failed[0].Error, gc.ErrorMatches, "boom")
+	c.Assert(failed[0].ModelTag, gc.Not(gc.Equals), "")
+}
+
+type mockBackend struct {
+	testing.Stub
+	models map[string]*mockModel
+}
+
+func (b *mockBackend) AllModelUUIDs() ([]string, error) {
+	b.MethodCall(b, "AllModelUUIDs")
+	var uuids []string
+	for uuid := range b.models {
+		uuids = append(uuids, uuid)
+	}
+	return uuids, b.NextErr()
+}
+
+func (b *mockBackend) GetModel(modelUUID string) (inventory.Model, func(), error) {
+	b.MethodCall(b, "GetModel", modelUUID)
+	if err := b.NextErr(); err != nil {
+		return nil, nil, err
+	}
+	model, ok := b.models[modelUUID]
+	if !ok {
+		return nil, nil, errors.NotFoundf("model %q", modelUUID)
+	}
+	return model, func() {}, nil
+}
+
+type mockModel struct {
+	tag          names.ModelTag
+	name         string
+	owner        names.UserTag
+	cloud        string
+	region       string
+	machines     []inventory.Machine
+	volumes      []state.Volume
+	applications []inventory.Application
+}
+
+func (m *mockModel) ModelTag() names.ModelTag {
+	return m.tag
+}
+
+func (m *mockModel) Name() string {
+	return m.name
+}
+
+func (m *mockModel) Owner() names.UserTag {
+	return m.owner
+}
+
+func (m *mockModel) Type() state.ModelType {
+	return state.ModelTypeIAAS
+}
+
+func (m *mockModel) CloudName() string {
+	return m.cloud
+}
+
+func (m *mockModel) CloudRegion() string {
+	return m.region
+}
+
+func (m *mockModel) AllMachines() ([]inventory.Machine, error) {
+	return m.machines, nil
+}
+
+func (m *mockModel) AllVolumes() ([]state.Volume, error) {
+	return m.volumes, nil
+}
+
+func (m *mockModel) AllApplications() ([]inventory.Application, error) {
+	return m.applications, nil
+}
+
+type mockMachine struct {
+	id          string
+	series      string
+	instId      instance.Id
+	constraints constraints.Value
+	hardware    *instance.HardwareCharacteristics
+}
+
+func (m *mockMachine) Id() string {
+	return m.id
+}
+
+func (m *mockMachine) Series() string {
+	return m.series
+}
+
+func (m *mockMachine) InstanceId() (instance.Id, error) {
+	if m.instId == "" {
+		return "", errors.NotProvisionedf("machine %v", m.id)
+	}
+	return m.instId, nil
+}
+
+func (m *mockMachine) Constraints() (constraints.Value, error) {
+	return m.constraints, nil
+}
+
+func (m *mockMachine) HardwareCharacteristics() (*instance.HardwareCharacteristics, error) {
+	if m.hardware == nil {
+		return nil, errors.NotFoundf("hardware characteristics for machine %v", m.id)
+	}
+	return m.hardware, nil
+}
+
+type mockVolume struct {
+	state.Volume
+	tag    names.VolumeTag
+	info   *state.VolumeInfo
+	params *state.VolumeParams
+}
+
+func (v *mockVolume) VolumeTag() names.VolumeTag {
+	return v.tag
+}
+
+func (v *mockVolume) Info() (state.VolumeInfo, error) {
+	if v.info == nil {
+		return state.VolumeInfo{}, errors.NotProvisionedf("volume %v", v.tag.Id())
+	}
+	return *v.info, nil
+}
+
+func (v *mockVolume) Params() (state.VolumeParams, bool) {
+	if v.params == nil {
+		return state.VolumeParams{}, false
+	}
+	return *v.params, true
+}
+
+type mockApplication struct {
+	name         string
+	curl         *charm.URL
+	charmVersion string
+	units        int
+}
+
+func (a *mockApplication) Name() string {
+	return a.name
+}
+
+func (a *mockApplication) CharmURL() (*charm.URL, bool) {
+	return a.curl, false
+}
+
+func (a *mockApplication) CharmVersion() (string, error) {
+	return a.charmVersion, nil
+}
+
+func (a *mockApplication) UnitCount() int {
+	return a.units
+}
@@ -5,3 +5,6 @@ package machinemanager
 
 var InstanceTypes = instanceTypes
 var IsSeriesLessThan = isSeriesLessThan
+var RefreshMachineHardware = refreshMachineHardware
+var PrepareResizeMachines = prepareResizeMachines
+var ResizeMachines = resizeMachines
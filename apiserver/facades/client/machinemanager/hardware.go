@@ -0,0 +1,106 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"github.com/juju/errors"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+)
+
+// RefreshMachineHardware isn't on the v7 API.
+func (mm *MachineManagerAPIV7) RefreshMachineHardware(_, _ struct{}) {}
+
+// RefreshMachineHardware reads the current hardware characteristics of
+// the machines' instances from the cloud and records them in state,
+// returning the refreshed hardware. This brings the recorded hardware up
+// to date after instances have been resized outside of Juju.
+func (mm *MachineManagerAPI) RefreshMachineHardware(args params.Entities) (params.MachineHardwareResults, error) {
+	return refreshMachineHardware(mm, environs.GetEnviron, args)
+}
+
+func refreshMachineHardware(
+	mm *MachineManagerAPI,
+	getEnviron environGetFunc,
+	args params.Entities,
+) (params.MachineHardwareResults, error) {
+	if err := mm.checkCanWrite(); err != nil {
+		return params.MachineHardwareResults{}, err
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return params.MachineHardwareResults{}, errors.Trace(err)
+	}
+
+	results := params.MachineHardwareResults{
+		Results: make([]params.MachineHardwareResult, len(args.Entities)),
+	}
+	machines := make([]Machine, len(args.Entities))
+	var (
+		ids     []instance.Id
+		indices []int
+	)
+	for i, arg := range args.Entities {
+		machine, err := mm.machineFromTag(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		id, err := machine.InstanceId()
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		machines[i] = machine
+		ids = append(ids, id)
+		indices = append(indices, i)
+	}
+	if len(ids) == 0 {
+		return results, nil
+	}
+
+	env, err := modelEnviron(mm, getEnviron)
+	if err != nil {
+		return params.MachineHardwareResults{}, errors.Trace(err)
+	}
+	reader, ok := env.(environs.InstanceHardwareReader)
+	if !ok {
+		return params.MachineHardwareResults{}, errors.NotSupportedf("refreshing machine hardware on this cloud")
+	}
+	hardware, err := reader.InstanceHardware(mm.callContext, ids)
+	if err != nil && err != environs.ErrPartialInstances && err != environs.ErrNoInstances {
+		return params.MachineHardwareResults{}, errors.Annotate(err, "reading instance hardware")
+	}
+
+	for n, i := range indices {
+		var hc *instance.HardwareCharacteristics
+		if n < len(hardware) {
+			hc = hardware[n]
+		}
+		if hc == nil {
+			results.Results[i].Error = apiservererrors.ServerError(errors.NotFoundf("instance %q", ids[n]))
+			continue
+		}
+		if err := machines[i].SetHardwareCharacteristics(*hc); err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results.Results[i].Hardware = machineHardwareParams(hc)
+	}
+	return results, nil
+}
+
+func machineHardwareParams(hc *instance.HardwareCharacteristics) *params.MachineHardware {
+	return &params.MachineHardware{
+		Arch:             hc.Arch,
+		Mem:              hc.Mem,
+		RootDisk:         hc.RootDisk,
+		Cores:            hc.CpuCores,
+		CpuPower:         hc.CpuPower,
+		Tags:             hc.Tags,
+		AvailabilityZone: hc.AvailabilityZone,
+	}
+}
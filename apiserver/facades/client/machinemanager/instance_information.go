@@ -27,20 +27,7 @@ func instanceTypes(mm *MachineManagerAPI,
 	getEnviron environGetFunc,
 	cons params.ModelInstanceTypesConstraints,
 ) (params.InstanceTypesResults, error) {
-	model, err := mm.st.Model()
-	if err != nil {
-		return params.InstanceTypesResults{}, errors.Trace(err)
-	}
-
-	cloudSpec := func() (environscloudspec.CloudSpec, error) {
-		return stateenvirons.CloudSpecForModel(model)
-	}
-	backend := common.EnvironConfigGetterFuncs{
-		CloudSpecFunc:   cloudSpec,
-		ModelConfigFunc: model.Config,
-	}
-
-	env, err := getEnviron(backend, environs.New)
+	env, err := modelEnviron(mm, getEnviron)
 	if err != nil {
 		return params.InstanceTypesResults{}, errors.Trace(err)
 	}
@@ -65,3 +52,21 @@ func instanceTypes(mm *MachineManagerAPI,
 
 	return params.InstanceTypesResults{Results: result}, nil
 }
+
+// modelEnviron returns the environ of the facade's model.
+func modelEnviron(mm *MachineManagerAPI, getEnviron environGetFunc) (environs.Environ, error) {
+	model, err := mm.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	cloudSpec := func() (environscloudspec.CloudSpec, error) {
+		return stateenvirons.CloudSpecForModel(model)
+	}
+	backend := common.EnvironConfigGetterFuncs{
+		CloudSpecFunc:   cloudSpec,
+		ModelConfigFunc: model.Config,
+	}
+	env, err := getEnviron(backend, environs.New)
+	return env, errors.Trace(err)
+}
@@ -101,6 +101,30 @@ type MachineManagerAPIV5 struct {
 // Version 6 of Machine Manager API.
 // Changes input parameters to DestroyMachineWithParams and ForceDestroyMachine.
 type MachineManagerAPIV6 struct {
+	*MachineManagerAPIV7
+}
+
+// Version 7 of Machine Manager API.
+// Adds SetEgressNATAddresses.
+type MachineManagerAPIV7 struct {
+	*MachineManagerAPIV8
+}
+
+// Version 8 of Machine Manager API.
+// Adds RefreshMachineHardware.
+type MachineManagerAPIV8 struct {
+	*MachineManagerAPIV9
+}
+
+// Version 9 of Machine Manager API.
+// Adds PrepareResizeMachines and ResizeMachines.
+type MachineManagerAPIV9 struct {
+	*MachineManagerAPIV10
+}
+
+// Version 10 of Machine Manager API.
+// Adds ReplaceMachines.
+type MachineManagerAPIV10 struct {
 	*MachineManagerAPI
 }
 
@@ -124,11 +148,47 @@ func NewFacadeV5(ctx facade.Context) (*MachineManagerAPIV5, error) {
 
 // NewFacadeV6 creates a new server-side MachineManager API facade.
 func NewFacadeV6(ctx facade.Context) (*MachineManagerAPIV6, error) {
+	machineManagerAPIv7, err := NewFacadeV7(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV6{machineManagerAPIv7}, nil
+}
+
+// NewFacadeV7 creates a new server-side MachineManager API facade.
+func NewFacadeV7(ctx facade.Context) (*MachineManagerAPIV7, error) {
+	machineManagerAPIv8, err := NewFacadeV8(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV7{machineManagerAPIv8}, nil
+}
+
+// NewFacadeV8 creates a new server-side MachineManager API facade.
+func NewFacadeV8(ctx facade.Context) (*MachineManagerAPIV8, error) {
+	machineManagerAPIv9, err := NewFacadeV9(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV8{machineManagerAPIv9}, nil
+}
+
+// NewFacadeV9 creates a new server-side MachineManager API facade.
+func NewFacadeV9(ctx facade.Context) (*MachineManagerAPIV9, error) {
+	machineManagerAPIv10, err := NewFacadeV10(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV9{machineManagerAPIv10}, nil
+}
+
+// NewFacadeV10 creates a new server-side MachineManager API facade.
+func NewFacadeV10(ctx facade.Context) (*MachineManagerAPIV10, error) {
 	machineManagerAPI, err := NewFacade(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &MachineManagerAPIV6{machineManagerAPI}, nil
+	return &MachineManagerAPIV10{machineManagerAPI}, nil
 }
 
 // NewMachineManagerAPI creates a new server-side MachineManager API facade.
@@ -682,6 +742,34 @@ func (mm *MachineManagerAPI) GetUpgradeSeriesMessages(args params.UpgradeSeriesN
 	return results, nil
 }
 
+// SetEgressNATAddresses isn't on the v6 API.
+func (mm *MachineManagerAPIV6) SetEgressNATAddresses(_, _ struct{}) {}
+
+// SetEgressNATAddresses declares, for each input machine, the address of the
+// NAT gateway through which its traffic egresses. This address is used as the
+// egress subnet for cross-model relations of units on the machine.
+// An empty address clears the declaration.
+func (mm *MachineManagerAPI) SetEgressNATAddresses(args params.SetEgressNATAddressesArgs) (params.ErrorResults, error) {
+	if err := mm.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, err
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		machine, err := mm.machineFromTag(arg.Tag)
+		if err == nil {
+			err = machine.SetEgressNATAddress(arg.Value)
+		}
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
 func (mm *MachineManagerAPI) machineFromTag(tag string) (Machine, error) {
 	machineTag, err := names.ParseMachineTag(tag)
 	if err != nil {
@@ -4,6 +4,7 @@
 package machinemanager_test
 
 import (
+	"fmt"
 	"sort"
 	"strings"
 	"time"
@@ -24,8 +25,11 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/state"
 	stateerrors "github.com/juju/juju/state/errors"
@@ -523,7 +527,430 @@ func (s *MachineManagerSuite) setupUpgradeSeries(c *gc.C) {
 }
 
 func (s *MachineManagerSuite) apiV5() machinemanager.MachineManagerAPIV5 {
-	return machinemanager.MachineManagerAPIV5{MachineManagerAPIV6: &machinemanager.MachineManagerAPIV6{s.api}}
+	return machinemanager.MachineManagerAPIV5{MachineManagerAPIV6: &machinemanager.MachineManagerAPIV6{&machinemanager.MachineManagerAPIV7{&machinemanager.MachineManagerAPIV8{&machinemanager.MachineManagerAPIV9{&machinemanager.MachineManagerAPIV10{s.api}}}}}}
+}
+
+func (s *MachineManagerSuite) TestSetEgressNATAddresses(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	m0 := &mockMachine{}
+	m1 := &mockMachine{}
+	m1.SetErrors(errors.NotValidf(`egress NAT address "bad"`))
+	s.st.machines["0"] = m0
+	s.st.machines["1"] = m1
+
+	results, err := s.api.SetEgressNATAddresses(params.SetEgressNATAddressesArgs{
+		Args: []params.EntityString{
+			{Tag: "machine-0", Value: "203.0.113.10"},
+			{Tag: "machine-1", Value: "bad"},
+			{Tag: "machine-2", Value: ""},
+			{Tag: "unit-foo-0", Value: ""},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `egress NAT address "bad" not valid`)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `machine 2 not found`)
+	c.Check(results.Results[3].Error, gc.ErrorMatches, `"unit-foo-0" is not a valid machine tag`)
+
+	m0.CheckCall(c, 0, "SetEgressNATAddress", "203.0.113.10")
+}
+
+func (s *MachineManagerSuite) TestSetEgressNATAddressesPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("fred")
+	defer s.setup(c).Finish()
+
+	_, err := s.api.SetEgressNATAddresses(params.SetEgressNATAddressesArgs{
+		Args: []params.EntityString{{Tag: "machine-0", Value: "203.0.113.10"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *MachineManagerSuite) TestRefreshMachineHardware(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	m0 := &mockMachine{id: "0", instanceId: "i-0"}
+	s.st.machines["0"] = m0
+	s.st.machines["1"] = &mockMachine{id: "1"}
+	s.st.machines["2"] = &mockMachine{id: "2", instanceId: "i-gone"}
+
+	mem, cores := uint64(16384), uint64(8)
+	env := &mockHardwareEnviron{
+		hardware: map[instance.Id]*instance.HardwareCharacteristics{
+			"i-0": {Mem: &mem, CpuCores: &cores},
+		},
+	}
+	getEnviron := func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return env, nil
+	}
+	results, err := machinemanager.RefreshMachineHardware(s.api, getEnviron, params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: "machine-1"},
+			{Tag: "machine-2"},
+			{Tag: "machine-3"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[0].Hardware, jc.DeepEquals, &params.MachineHardware{Mem: &mem, Cores: &cores})
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `machine 1 not provisioned`)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `instance "i-gone" not found`)
+	c.Check(results.Results[3].Error, gc.ErrorMatches, `machine 3 not found`)
+
+	env.CheckCall(c, 0, "InstanceHardware", []instance.Id{"i-0", "i-gone"})
+	m0.CheckCall(c, 1, "SetHardwareCharacteristics", instance.HardwareCharacteristics{Mem: &mem, CpuCores: &cores})
+}
+
+func (s *MachineManagerSuite) TestRefreshMachineHardwareNotSupported(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.st.machines["0"] = &mockMachine{id: "0", instanceId: "i-0"}
+	getEnviron := func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return &mockEnviron{}, nil
+	}
+	_, err := machinemanager.RefreshMachineHardware(s.api, getEnviron, params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *MachineManagerSuite) TestRefreshMachineHardwarePermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("fred")
+	defer s.setup(c).Finish()
+
+	_, err := s.api.RefreshMachineHardware(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *MachineManagerSuite) TestPrepareResizeMachines(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	m0 := &mockMachine{id: "0", instanceId: "i-0", series: "focal"}
+	s.st.machines["0"] = m0
+	s.st.machines["1"] = &mockMachine{id: "1", instanceId: "i-1", isManager: true}
+	s.st.machines["2"] = &mockMachine{id: "2"}
+	s.st.machines["3"] = &mockMachine{id: "3", instanceId: "i-3", isLockedForSeriesUpgrade: true}
+
+	cons := constraints.MustParse("mem=16G")
+	env := &mockHardwareEnviron{}
+	getEnviron := func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return env, nil
+	}
+	results, err := machinemanager.PrepareResizeMachines(s.api, getEnviron, params.ResizeMachinesArgs{
+		Args: []params.ResizeMachineArg{
+			{Tag: "machine-0", Constraints: cons},
+			{Tag: "machine-1", Constraints: cons},
+			{Tag: "machine-2", Constraints: cons},
+			{Tag: "machine-3", Constraints: cons},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `machine 1 is a controller and cannot be resized`)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `machine 2 not provisioned`)
+	c.Check(results.Results[3].Error, gc.ErrorMatches, `machine 3 is already being upgraded or resized`)
+
+	m0.CheckCallNames(c, "IsManager", "InstanceId", "IsLockedForSeriesUpgrade", "Series",
+		"Principals", "VerifyUnitsSeries", "CreateUpgradeSeriesLock", "SetModificationStatus")
+	m0.CheckCall(c, 6, "CreateUpgradeSeriesLock", []string{}, "focal")
+	m0.CheckCall(c, 7, "SetModificationStatus", status.StatusInfo{
+		Status:  status.Resizing,
+		Message: `stopping workloads to resize to "mem=16384M"`,
+	})
+	env.CheckNoCalls(c)
+}
+
+func (s *MachineManagerSuite) TestPrepareResizeMachinesNotSupported(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	m0 := &mockMachine{id: "0", instanceId: "i-0"}
+	s.st.machines["0"] = m0
+	getEnviron := func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return &mockEnviron{}, nil
+	}
+	_, err := machinemanager.PrepareResizeMachines(s.api, getEnviron, params.ResizeMachinesArgs{
+		Args: []params.ResizeMachineArg{{Tag: "machine-0"}},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	m0.CheckNoCalls(c)
+}
+
+func (s *MachineManagerSuite) TestResizeMachines(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	m0 := &mockMachine{id: "0", instanceId: "i-0", upgradeSeriesStatus: model.UpgradeSeriesPrepareCompleted}
+	m2 := &mockMachine{id: "2", instanceId: "i-2", upgradeSeriesStatusErr: errors.NotFoundf("upgrade lock")}
+	s.st.machines["0"] = m0
+	s.st.machines["1"] = &mockMachine{id: "1", instanceId: "i-1", upgradeSeriesStatusErr: errors.NotFoundf("upgrade lock")}
+	s.st.machines["2"] = m2
+	s.st.machines["3"] = &mockMachine{id: "3", instanceId: "i-3", upgradeSeriesStatus: model.UpgradeSeriesPrepareStarted}
+
+	mem, cores := uint64(16384), uint64(4)
+	hc := instance.HardwareCharacteristics{Mem: &mem, CpuCores: &cores}
+	env := &mockHardwareEnviron{resized: &hc}
+	getEnviron := func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return env, nil
+	}
+	cons := constraints.MustParse("mem=16G")
+	results, err := machinemanager.ResizeMachines(s.api, getEnviron, params.ResizeMachinesArgs{
+		Args: []params.ResizeMachineArg{
+			{Tag: "machine-0", Constraints: cons},
+			{Tag: "machine-1", Constraints: cons},
+			{Tag: "machine-2", Constraints: cons, Force: true},
+			{Tag: "machine-3", Constraints: cons},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[0].Hardware, jc.DeepEquals, &params.MachineHardware{Mem: &mem, Cores: &cores})
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `machine 1 has not been prepared for resizing`)
+	c.Check(results.Results[2].Error, gc.IsNil)
+	c.Check(results.Results[3].Error, gc.ErrorMatches,
+		`workloads on machine 3 are still being stopped; series upgrade is in the "prepare started" state`)
+
+	env.CheckCalls(c, []jtesting.StubCall{
+		{"ResizeInstance", []interface{}{instance.Id("i-0"), cons}},
+		{"ResizeInstance", []interface{}{instance.Id("i-2"), cons}},
+	})
+	m0.CheckCallNames(c, "InstanceId", "UpgradeSeriesStatus", "SetModificationStatus",
+		"SetHardwareCharacteristics", "CompleteUpgradeSeries", "SetModificationStatus")
+	m0.CheckCall(c, 3, "SetHardwareCharacteristics", hc)
+	m0.CheckCall(c, 5, "SetModificationStatus", status.StatusInfo{
+		Status:  status.Applied,
+		Message: "resized to cores=4 mem=16384M",
+	})
+	// A forced resize of an unprepared machine has no workloads to restart.
+	m2.CheckCallNames(c, "InstanceId", "UpgradeSeriesStatus", "SetModificationStatus",
+		"SetHardwareCharacteristics", "SetModificationStatus")
+}
+
+func (s *MachineManagerSuite) TestResizeMachinesProviderError(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	m0 := &mockMachine{id: "0", instanceId: "i-0", upgradeSeriesStatus: model.UpgradeSeriesPrepareCompleted}
+	s.st.machines["0"] = m0
+	env := &mockHardwareEnviron{}
+	env.SetErrors(errors.New("boom"))
+	getEnviron := func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return env, nil
+	}
+	results, err := machinemanager.ResizeMachines(s.api, getEnviron, params.ResizeMachinesArgs{
+		Args: []params.ResizeMachineArg{{Tag: "machine-0", Constraints: constraints.MustParse("cores=8")}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Error, gc.ErrorMatches, `resizing instance: boom`)
+	c.Check(results.Results[0].Hardware, gc.IsNil)
+
+	m0.CheckCallNames(c, "InstanceId", "UpgradeSeriesStatus", "SetModificationStatus", "SetModificationStatus")
+	m0.CheckCall(c, 3, "SetModificationStatus", status.StatusInfo{
+		Status:  status.Error,
+		Message: "resizing instance: boom",
+	})
+}
+
+func (s *MachineManagerSuite) TestReplaceMachinesStart(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	m0 := &mockMachine{id: "0", series: "focal", constraints: constraints.MustParse("mem=4G")}
+	m0.unitsF = func() ([]machinemanager.Unit, error) {
+		return []machinemanager.Unit{
+			&mockUnit{tag: names.NewUnitTag("foo/0")},
+			&mockUnit{tag: names.NewUnitTag("bar/0"), subordinate: true},
+		}, nil
+	}
+	s.st.machines["0"] = m0
+	s.st.machines["1"] = &mockMachine{id: "1", isManager: true}
+	s.st.machines["2"] = &mockMachine{id: "2", isLockedForSeriesUpgrade: true}
+	s.st.machines["3"] = &mockMachine{id: "3", containers: []string{"3/lxd/0"}}
+	s.st.machines["4"] = &mockMachine{id: "4", life: state.Dying}
+
+	results, err := s.api.ReplaceMachines(params.ReplaceMachinesArgs{
+		Args: []params.ReplaceMachineArg{
+			{Tag: "machine-0"},
+			{Tag: "machine-1"},
+			{Tag: "machine-2"},
+			{Tag: "machine-3"},
+			{Tag: "machine-4"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 5)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[0].Done, jc.IsFalse)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `machine 1 is a controller and cannot be replaced`)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `machine 2 is being upgraded or resized`)
+	c.Check(results.Results[3].Error, gc.ErrorMatches, `machine 3 hosts containers 3/lxd/0, which cannot be moved`)
+	c.Check(results.Results[4].Error, gc.ErrorMatches, `machine 4 is not alive`)
+
+	c.Assert(s.st.machineTemplates, jc.DeepEquals, []state.MachineTemplate{{
+		Series:      "focal",
+		Constraints: constraints.MustParse("mem=4G"),
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+	}})
+	// Only the detachable storage of the principal unit moves with it.
+	c.Check(m0.modificationStatus.Status, gc.Equals, status.Replacing)
+	c.Check(m0.modificationStatus.Data["replacement-plan"], gc.Equals,
+		`{"machine":"","units":[{"unit":"foo/0","storage":["disks/0"]}]}`)
+}
+
+func (s *MachineManagerSuite) TestReplaceMachinesWithConstraints(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	m0 := &mockMachine{id: "0", series: "focal", constraints: constraints.MustParse("mem=4G")}
+	m0.unitsF = func() ([]machinemanager.Unit, error) { return nil, nil }
+	s.st.machines["0"] = m0
+
+	cons := constraints.MustParse("mem=16G")
+	results, err := s.api.ReplaceMachines(params.ReplaceMachinesArgs{
+		Args: []params.ReplaceMachineArg{{Tag: "machine-0", Constraints: &cons}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Assert(s.st.machineTemplates, gc.HasLen, 1)
+	c.Check(s.st.machineTemplates[0].Constraints, jc.DeepEquals, cons)
+	m0.CheckCallNames(c, "ModificationStatus", "IsManager", "Life", "IsLockedForSeriesUpgrade",
+		"Containers", "Units", "Series", "SetModificationStatus")
+}
+
+func (s *MachineManagerSuite) TestReplaceMachinesWaitsForReplacement(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	m0 := &mockMachine{id: "0", modificationStatus: replacementStatus(`{"machine":"1"}`)}
+	s.st.machines["0"] = m0
+	s.st.machines["1"] = &mockMachine{id: "1", agentStatus: status.StatusInfo{Status: status.Pending}}
+
+	results, err := s.api.ReplaceMachines(params.ReplaceMachinesArgs{
+		Args: []params.ReplaceMachineArg{{Tag: "machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ReplaceMachineResult{{
+		Replacement: "machine-1",
+		Message:     "waiting for replacement machine 1 to start",
+	}})
+	c.Check(s.st.machineTemplates, gc.HasLen, 0)
+}
+
+func (s *MachineManagerSuite) TestReplaceMachinesReplacementFailed(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	m0 := &mockMachine{id: "0", modificationStatus: replacementStatus(`{"machine":"1"}`)}
+	s.st.machines["0"] = m0
+	s.st.machines["1"] = &mockMachine{id: "1", agentStatus: status.StatusInfo{
+		Status:  status.Error,
+		Message: "no matching instance types",
+	}}
+
+	results, err := s.api.ReplaceMachines(params.ReplaceMachinesArgs{
+		Args: []params.ReplaceMachineArg{{Tag: "machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Error, gc.ErrorMatches,
+		`replacement machine 1 failed to start: no matching instance types`)
+	// The plan is kept so that the replacement can be resumed.
+	c.Check(m0.modificationStatus, jc.DeepEquals, status.StatusInfo{
+		Status:  status.Error,
+		Message: "replacement machine 1 failed to start: no matching instance types",
+		Data:    map[string]interface{}{"replacement-plan": `{"machine":"1"}`},
+	})
+}
+
+func (s *MachineManagerSuite) TestReplaceMachinesMovesUnits(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	foo1 := &mockUnit{tag: names.NewUnitTag("foo/1")}
+	m0 := &mockMachine{id: "0", modificationStatus: replacementStatus(
+		`{"machine":"1","units":[{"unit":"foo/0","storage":["disks/0"]},{"unit":"foo/1"}]}`,
+	)}
+	m0.unitsF = func() ([]machinemanager.Unit, error) {
+		// foo/0 has been removed, foo/1 has yet to be.
+		return []machinemanager.Unit{foo1}, nil
+	}
+	s.st.machines["0"] = m0
+	s.st.machines["1"] = &mockMachine{id: "1", agentStatus: status.StatusInfo{Status: status.Started}}
+	s.st.nextUnit = 9
+
+	results, err := s.api.ReplaceMachines(params.ReplaceMachinesArgs{
+		Args: []params.ReplaceMachineArg{{Tag: "machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ReplaceMachineResult{{
+		Replacement: "machine-1",
+		Message:     "waiting for foo/1 to be removed",
+	}})
+	foo1.CheckCallNames(c, "Destroy")
+	c.Check(addedUnits(s.st), jc.DeepEquals, []jtesting.StubCall{{
+		FuncName: "AddUnitToMachine",
+		Args:     []interface{}{"foo", "1", []names.StorageTag{names.NewStorageTag("disks/0")}},
+	}})
+	// The redeployed unit is recorded before moving on.
+	m0.CheckCallNames(c, "ModificationStatus", "Units", "SetModificationStatus", "SetModificationStatus")
+	c.Check(m0.modificationStatus.Data["replacement-plan"], gc.Equals,
+		`{"machine":"1","units":[{"unit":"foo/0","storage":["disks/0"],"replacement":"foo/10"},{"unit":"foo/1"}]}`)
+}
+
+func (s *MachineManagerSuite) TestReplaceMachinesDone(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	m0 := &mockMachine{id: "0", modificationStatus: replacementStatus(
+		`{"machine":"1","units":[{"unit":"foo/0","replacement":"foo/10"}]}`,
+	)}
+	m0.unitsF = func() ([]machinemanager.Unit, error) { return nil, nil }
+	m1 := &mockMachine{id: "1", agentStatus: status.StatusInfo{Status: status.Started}}
+	s.st.machines["0"] = m0
+	s.st.machines["1"] = m1
+
+	results, err := s.api.ReplaceMachines(params.ReplaceMachinesArgs{
+		Args: []params.ReplaceMachineArg{{Tag: "machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ReplaceMachineResult{{
+		Replacement: "machine-1",
+		Message:     "replaced by machine 1",
+		Done:        true,
+	}})
+	m0.CheckCallNames(c, "ModificationStatus", "Units", "Life", "Destroy", "Id", "SetModificationStatus")
+	m1.CheckCall(c, 1, "SetModificationStatus", status.StatusInfo{
+		Status:  status.Applied,
+		Message: "replaced machine 0",
+	})
+	c.Check(addedUnits(s.st), gc.HasLen, 0)
+}
+
+func (s *MachineManagerSuite) TestReplaceMachinesPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("fred")
+	defer s.setup(c).Finish()
+
+	_, err := s.api.ReplaceMachines(params.ReplaceMachinesArgs{
+		Args: []params.ReplaceMachineArg{{Tag: "machine-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func addedUnits(st *mockState) []jtesting.StubCall {
+	var calls []jtesting.StubCall
+	for _, call := range st.Calls() {
+		if call.FuncName == "AddUnitToMachine" {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+func replacementStatus(plan string) status.StatusInfo {
+	return status.StatusInfo{
+		Status: status.Replacing,
+		Data:   map[string]interface{}{"replacement-plan": plan},
+	}
 }
 
 func (s *MachineManagerSuite) TestUpgradeSeriesValidateOK(c *gc.C) {
@@ -912,6 +1339,7 @@ type mockState struct {
 	err              error
 	blockMsg         string
 	block            state.BlockType
+	nextUnit         int
 
 	unitStorageAttachmentsF func(tag names.UnitTag) ([]state.StorageAttachment, error)
 }
@@ -962,6 +1390,15 @@ func (st *mockState) AddOneMachine(template state.MachineTemplate) (*state.Machi
 	return &m, st.err
 }
 
+func (st *mockState) AddUnitToMachine(appName, machineId string, storage []names.StorageTag) (machinemanager.Unit, error) {
+	st.MethodCall(st, "AddUnitToMachine", appName, machineId, storage)
+	if err := st.NextErr(); err != nil {
+		return nil, err
+	}
+	st.nextUnit++
+	return &mockUnit{tag: names.NewUnitTag(fmt.Sprintf("%s/%d", appName, st.nextUnit))}, nil
+}
+
 func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	st.MethodCall(st, "GetBlockForType", t)
 	if st.block == t {
@@ -1044,6 +1481,7 @@ type mockMachine struct {
 	machinemanager.Machine
 
 	id                       string
+	instanceId               instance.Id
 	keep                     bool
 	series                   string
 	units                    []string
@@ -1051,6 +1489,13 @@ type mockMachine struct {
 	unitState                status.Status
 	isManager                bool
 	isLockedForSeriesUpgrade bool
+	upgradeSeriesStatus      model.UpgradeSeriesStatus
+	upgradeSeriesStatusErr   error
+	modificationStatus       status.StatusInfo
+	agentStatus              status.StatusInfo
+	life                     state.Life
+	containers               []string
+	constraints              constraints.Value
 
 	unitsF func() ([]machinemanager.Unit, error)
 }
@@ -1081,6 +1526,24 @@ func (m *mockMachine) SetKeepInstance(keep bool) error {
 	return nil
 }
 
+func (m *mockMachine) SetEgressNATAddress(address string) error {
+	m.MethodCall(m, "SetEgressNATAddress", address)
+	return m.NextErr()
+}
+
+func (m *mockMachine) InstanceId() (instance.Id, error) {
+	m.MethodCall(m, "InstanceId")
+	if m.instanceId == "" {
+		return "", errors.NotProvisionedf("machine %v", m.id)
+	}
+	return m.instanceId, nil
+}
+
+func (m *mockMachine) SetHardwareCharacteristics(hc instance.HardwareCharacteristics) error {
+	m.MethodCall(m, "SetHardwareCharacteristics", hc)
+	return m.NextErr()
+}
+
 func (m *mockMachine) Series() string {
 	m.MethodCall(m, "Series")
 	return m.series
@@ -1138,13 +1601,79 @@ func (m *mockMachine) IsLockedForSeriesUpgrade() (bool, error) {
 
 func (m *mockMachine) UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error) {
 	m.MethodCall(m, "UpgradeSeriesStatus")
+	if m.upgradeSeriesStatusErr != nil {
+		return "", m.upgradeSeriesStatusErr
+	}
+	if m.upgradeSeriesStatus != "" {
+		return m.upgradeSeriesStatus, nil
+	}
 	return model.UpgradeSeriesNotStarted, nil
 }
 
+func (m *mockMachine) SetModificationStatus(info status.StatusInfo) error {
+	m.MethodCall(m, "SetModificationStatus", info)
+	m.modificationStatus = info
+	return nil
+}
+
+func (m *mockMachine) ModificationStatus() (status.StatusInfo, error) {
+	m.MethodCall(m, "ModificationStatus")
+	return m.modificationStatus, nil
+}
+
+func (m *mockMachine) Status() (status.StatusInfo, error) {
+	m.MethodCall(m, "Status")
+	return m.agentStatus, nil
+}
+
+func (m *mockMachine) Life() state.Life {
+	m.MethodCall(m, "Life")
+	return m.life
+}
+
+func (m *mockMachine) Containers() ([]string, error) {
+	m.MethodCall(m, "Containers")
+	return m.containers, nil
+}
+
+func (m *mockMachine) Constraints() (constraints.Value, error) {
+	m.MethodCall(m, "Constraints")
+	return m.constraints, nil
+}
+
+type mockHardwareEnviron struct {
+	environs.Environ
+	jtesting.Stub
+
+	hardware map[instance.Id]*instance.HardwareCharacteristics
+	resized  *instance.HardwareCharacteristics
+}
+
+func (e *mockHardwareEnviron) InstanceHardware(ctx context.ProviderCallContext, ids []instance.Id) ([]*instance.HardwareCharacteristics, error) {
+	e.MethodCall(e, "InstanceHardware", ids)
+	results := make([]*instance.HardwareCharacteristics, len(ids))
+	for i, id := range ids {
+		results[i] = e.hardware[id]
+	}
+	return results, environs.ErrPartialInstances
+}
+
+func (e *mockHardwareEnviron) ResizeInstance(ctx context.ProviderCallContext, id instance.Id, cons constraints.Value) (*instance.HardwareCharacteristics, error) {
+	e.MethodCall(e, "ResizeInstance", id, cons)
+	if err := e.NextErr(); err != nil {
+		return nil, err
+	}
+	return e.resized, nil
+}
+
 type mockUnit struct {
+	jtesting.Stub
+
 	tag         names.UnitTag
 	agentStatus status.Status
 	unitStatus  status.Status
+	subordinate bool
+	life        state.Life
 }
 
 func (u *mockUnit) UnitTag() names.UnitTag {
@@ -1167,6 +1696,19 @@ func (u *mockUnit) ApplicationName() string {
 	return strings.Split(u.tag.String(), "-")[1]
 }
 
+func (u *mockUnit) IsPrincipal() bool {
+	return !u.subordinate
+}
+
+func (u *mockUnit) Life() state.Life {
+	return u.life
+}
+
+func (u *mockUnit) Destroy() error {
+	u.MethodCall(u, "Destroy")
+	return u.NextErr()
+}
+
 type mockStorage struct {
 	state.StorageInstance
 	tag  names.StorageTag
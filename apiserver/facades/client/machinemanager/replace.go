@@ -0,0 +1,324 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/common/storagecommon"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+// replacementPlanKey is the key of the replacement plan in the data of
+// the modification status of a machine being replaced.
+const replacementPlanKey = "replacement-plan"
+
+// replacementPlan records the progress of replacing a machine. It is
+// kept in the modification status of the machine being replaced, so
+// that the replacement may be resumed by a later ReplaceMachines call.
+type replacementPlan struct {
+	// Machine is the ID of the replacement machine.
+	Machine string `json:"machine"`
+
+	// Units holds the principal units being moved.
+	Units []replacementUnit `json:"units,omitempty"`
+}
+
+// replacementUnit records the move of a principal unit to the
+// replacement machine.
+type replacementUnit struct {
+	// Unit is the name of the unit on the machine being replaced.
+	Unit string `json:"unit"`
+
+	// Storage holds the IDs of the unit's detachable storage, which
+	// is attached to the unit that replaces it.
+	Storage []string `json:"storage,omitempty"`
+
+	// Replacement is the name of the unit that replaces it, once it
+	// has been deployed to the replacement machine.
+	Replacement string `json:"replacement,omitempty"`
+}
+
+// ReplaceMachines isn't on the v9 API.
+func (mm *MachineManagerAPIV9) ReplaceMachines(_, _ struct{}) {}
+
+// ReplaceMachines moves the units of the machines to new machines, and
+// removes the old machines. Each call advances the replacement of each
+// machine as far as it can without waiting: the replacement machine is
+// provisioned, the old units are removed leaving their detachable
+// storage behind, the units are redeployed to the replacement machine
+// with that storage attached, and finally the old machine is removed.
+// Callers should call ReplaceMachines again until the results report
+// that each replacement is done. Progress is recorded in the old
+// machine's modification status.
+func (mm *MachineManagerAPI) ReplaceMachines(args params.ReplaceMachinesArgs) (params.ReplaceMachineResults, error) {
+	if err := mm.checkCanWrite(); err != nil {
+		return params.ReplaceMachineResults{}, err
+	}
+	// Replacing a machine removes its units and the machine itself.
+	if err := mm.check.RemoveAllowed(); err != nil {
+		return params.ReplaceMachineResults{}, errors.Trace(err)
+	}
+	results := params.ReplaceMachineResults{
+		Results: make([]params.ReplaceMachineResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		result, err := mm.replaceMachine(arg)
+		if err != nil {
+			result.Error = apiservererrors.ServerError(err)
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPI) replaceMachine(arg params.ReplaceMachineArg) (params.ReplaceMachineResult, error) {
+	machine, err := mm.machineFromTag(arg.Tag)
+	if err != nil {
+		return params.ReplaceMachineResult{}, errors.Trace(err)
+	}
+	plan, err := machineReplacementPlan(machine)
+	if err != nil {
+		return params.ReplaceMachineResult{}, errors.Trace(err)
+	}
+	if plan == nil {
+		if plan, err = mm.startMachineReplacement(machine, arg); err != nil {
+			return params.ReplaceMachineResult{}, errors.Trace(err)
+		}
+		return mm.setReplacementProgress(machine, plan,
+			fmt.Sprintf("provisioning replacement machine %s", plan.Machine), false)
+	}
+
+	replacement, err := mm.st.Machine(plan.Machine)
+	if err != nil {
+		return params.ReplaceMachineResult{}, errors.Annotatef(err, "getting replacement machine %s", plan.Machine)
+	}
+	agentStatus, err := replacement.Status()
+	if err != nil {
+		return params.ReplaceMachineResult{}, errors.Trace(err)
+	}
+	switch agentStatus.Status {
+	case status.Started:
+	case status.Error:
+		err := errors.Errorf("replacement machine %s failed to start: %s", plan.Machine, agentStatus.Message)
+		mm.setReplacementError(machine, plan, err)
+		return params.ReplaceMachineResult{}, err
+	default:
+		return mm.setReplacementProgress(machine, plan,
+			fmt.Sprintf("waiting for replacement machine %s to start", plan.Machine), false)
+	}
+
+	remaining, err := mm.moveUnits(machine, plan)
+	if err != nil {
+		mm.setReplacementError(machine, plan, err)
+		return params.ReplaceMachineResult{}, errors.Trace(err)
+	}
+	if len(remaining) > 0 {
+		return mm.setReplacementProgress(machine, plan,
+			fmt.Sprintf("waiting for %s to be removed", strings.Join(remaining, ", ")), false)
+	}
+
+	if machine.Life() == state.Alive {
+		if err := machine.Destroy(); err != nil {
+			mm.setReplacementError(machine, plan, err)
+			return params.ReplaceMachineResult{}, errors.Annotate(err, "removing machine")
+		}
+	}
+	if err := replacement.SetModificationStatus(status.StatusInfo{
+		Status:  status.Applied,
+		Message: fmt.Sprintf("replaced machine %s", machine.Id()),
+	}); err != nil {
+		return params.ReplaceMachineResult{}, errors.Trace(err)
+	}
+	return mm.setReplacementProgress(machine, plan,
+		fmt.Sprintf("replaced by machine %s", plan.Machine), true)
+}
+
+// startMachineReplacement checks that the machine can be replaced, records
+// the principal units to move and their detachable storage, and adds the
+// replacement machine.
+func (mm *MachineManagerAPI) startMachineReplacement(machine Machine, arg params.ReplaceMachineArg) (*replacementPlan, error) {
+	if machine.IsManager() {
+		return nil, errors.Errorf("machine %s is a controller and cannot be replaced", machine.Id())
+	}
+	if machine.Life() != state.Alive {
+		return nil, errors.Errorf("machine %s is not alive", machine.Id())
+	}
+	if locked, err := machine.IsLockedForSeriesUpgrade(); err != nil {
+		return nil, errors.Trace(err)
+	} else if locked {
+		return nil, errors.Errorf("machine %s is being upgraded or resized", machine.Id())
+	}
+	containers, err := machine.Containers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(containers) > 0 {
+		return nil, errors.Errorf("machine %s hosts containers %s, which cannot be moved",
+			machine.Id(), strings.Join(containers, ", "))
+	}
+
+	units, err := machine.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	plan := &replacementPlan{}
+	for _, unit := range units {
+		if !unit.IsPrincipal() {
+			// Subordinates follow their principals.
+			continue
+		}
+		storage, err := storagecommon.UnitStorage(mm.storageAccess, unit.UnitTag())
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting storage for unit %s", unit.UnitTag().Id())
+		}
+		_, detached, err := storagecommon.ClassifyDetachedStorage(
+			mm.storageAccess.VolumeAccess(), mm.storageAccess.FilesystemAccess(), storage)
+		if err != nil {
+			return nil, errors.Annotatef(err, "classifying storage for unit %s", unit.UnitTag().Id())
+		}
+		move := replacementUnit{Unit: unit.UnitTag().Id()}
+		for _, entity := range detached {
+			storageTag, err := names.ParseStorageTag(entity.Tag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			move.Storage = append(move.Storage, storageTag.Id())
+		}
+		plan.Units = append(plan.Units, move)
+	}
+
+	cons := arg.Constraints
+	if cons == nil {
+		machineCons, err := machine.Constraints()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		cons = &machineCons
+	}
+	replacement, err := mm.st.AddOneMachine(state.MachineTemplate{
+		Series:      machine.Series(),
+		Constraints: *cons,
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "adding replacement machine")
+	}
+	plan.Machine = replacement.Id()
+	return plan, nil
+}
+
+// moveUnits removes the units being moved from the machine, and deploys
+// the units that have been removed to the replacement machine with their
+// storage attached. It returns the names of the units still waiting to
+// be removed.
+func (mm *MachineManagerAPI) moveUnits(machine Machine, plan *replacementPlan) ([]string, error) {
+	units, err := machine.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	byName := make(map[string]Unit)
+	for _, unit := range units {
+		byName[unit.UnitTag().Id()] = unit
+	}
+
+	var remaining []string
+	for i, move := range plan.Units {
+		if move.Replacement != "" {
+			continue
+		}
+		if unit, ok := byName[move.Unit]; ok {
+			if unit.Life() == state.Alive {
+				if err := unit.Destroy(); err != nil {
+					return nil, errors.Annotatef(err, "removing unit %s", move.Unit)
+				}
+			}
+			remaining = append(remaining, move.Unit)
+			continue
+		}
+
+		appName, err := names.UnitApplication(move.Unit)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		storage := make([]names.StorageTag, len(move.Storage))
+		for j, id := range move.Storage {
+			storage[j] = names.NewStorageTag(id)
+		}
+		unit, err := mm.st.AddUnitToMachine(appName, plan.Machine, storage)
+		if err != nil {
+			return nil, errors.Annotatef(err, "redeploying unit %s", move.Unit)
+		}
+		plan.Units[i].Replacement = unit.UnitTag().Id()
+		// Record each redeployed unit straight away, so that it is
+		// not redeployed again should a later step fail.
+		if err := setReplacementPlan(machine, status.Replacing,
+			fmt.Sprintf("redeployed unit %s as %s", move.Unit, unit.UnitTag().Id()), plan,
+		); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return remaining, nil
+}
+
+func (mm *MachineManagerAPI) setReplacementProgress(
+	machine Machine, plan *replacementPlan, message string, done bool,
+) (params.ReplaceMachineResult, error) {
+	if err := setReplacementPlan(machine, status.Replacing, message, plan); err != nil {
+		return params.ReplaceMachineResult{}, errors.Trace(err)
+	}
+	return params.ReplaceMachineResult{
+		Replacement: names.NewMachineTag(plan.Machine).String(),
+		Message:     message,
+		Done:        done,
+	}, nil
+}
+
+func (mm *MachineManagerAPI) setReplacementError(machine Machine, plan *replacementPlan, err error) {
+	// The plan is kept so that the replacement can be resumed
+	// once the problem has been resolved.
+	if statusErr := setReplacementPlan(machine, status.Error, err.Error(), plan); statusErr != nil {
+		logger.Errorf("cannot set modification status of machine %s: %v", machine.Id(), statusErr)
+	}
+}
+
+// machineReplacementPlan returns the plan recorded for replacing the
+// machine, or nil if the machine is not being replaced.
+func machineReplacementPlan(machine Machine) (*replacementPlan, error) {
+	info, err := machine.ModificationStatus()
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	value, ok := info.Data[replacementPlanKey].(string)
+	if !ok {
+		return nil, nil
+	}
+	var plan replacementPlan
+	if err := json.Unmarshal([]byte(value), &plan); err != nil {
+		return nil, errors.Annotatef(err, "reading replacement plan of machine %s", machine.Id())
+	}
+	return &plan, nil
+}
+
+func setReplacementPlan(machine Machine, s status.Status, message string, plan *replacementPlan) error {
+	data, err := json.Marshal(plan)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(machine.SetModificationStatus(status.StatusInfo{
+		Status:  s,
+		Message: message,
+		Data:    map[string]interface{}{replacementPlanKey: string(data)},
+	}))
+}
@@ -0,0 +1,206 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+)
+
+// PrepareResizeMachines isn't on the v8 API.
+func (mm *MachineManagerAPIV8) PrepareResizeMachines(_, _ struct{}) {}
+
+// ResizeMachines isn't on the v8 API.
+func (mm *MachineManagerAPIV8) ResizeMachines(_, _ struct{}) {}
+
+// PrepareResizeMachines stops the workloads on the machines so that
+// their instances can be resized with ResizeMachines. Workloads are
+// stopped by running the pre-series-upgrade hooks of the machines'
+// units, as for a series upgrade to the machines' current series; the
+// upgrade series notifications report their progress.
+func (mm *MachineManagerAPI) PrepareResizeMachines(args params.ResizeMachinesArgs) (params.ErrorResults, error) {
+	return prepareResizeMachines(mm, environs.GetEnviron, args)
+}
+
+func prepareResizeMachines(
+	mm *MachineManagerAPI,
+	getEnviron environGetFunc,
+	args params.ResizeMachinesArgs,
+) (params.ErrorResults, error) {
+	if err := mm.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, err
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	// Check up front that the cloud can resize instances, rather
+	// than stopping workloads for nothing.
+	if _, err := instanceResizer(mm, getEnviron); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := mm.prepareResizeMachine(arg)
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPI) prepareResizeMachine(arg params.ResizeMachineArg) error {
+	machine, err := mm.machineFromTag(arg.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if machine.IsManager() {
+		return errors.Errorf("machine %s is a controller and cannot be resized", machine.Id())
+	}
+	if _, err := machine.InstanceId(); err != nil {
+		return errors.Trace(err)
+	}
+	if locked, err := machine.IsLockedForSeriesUpgrade(); err != nil {
+		return errors.Trace(err)
+	} else if locked {
+		return errors.Errorf("machine %s is already being upgraded or resized", machine.Id())
+	}
+
+	// The units are upgraded to the series they're already running.
+	series := machine.Series()
+	unitNames, err := mm.verifiedUnits(machine, series, false)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := machine.CreateUpgradeSeriesLock(unitNames, series); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(machine.SetModificationStatus(status.StatusInfo{
+		Status:  status.Resizing,
+		Message: fmt.Sprintf("stopping workloads to resize to %q", arg.Constraints.String()),
+	}))
+}
+
+// ResizeMachines resizes the instances of the machines to the cheapest
+// hardware satisfying the given constraints, and records their new
+// hardware. The machines' workloads must first have been stopped with
+// PrepareResizeMachines, unless Force is set; once the instance is
+// resized they are started again, which the upgrade series
+// notifications report. The machines' modification status reports the
+// progress of each resize.
+func (mm *MachineManagerAPI) ResizeMachines(args params.ResizeMachinesArgs) (params.MachineHardwareResults, error) {
+	return resizeMachines(mm, environs.GetEnviron, args)
+}
+
+func resizeMachines(
+	mm *MachineManagerAPI,
+	getEnviron environGetFunc,
+	args params.ResizeMachinesArgs,
+) (params.MachineHardwareResults, error) {
+	if err := mm.checkCanWrite(); err != nil {
+		return params.MachineHardwareResults{}, err
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return params.MachineHardwareResults{}, errors.Trace(err)
+	}
+	resizer, err := instanceResizer(mm, getEnviron)
+	if err != nil {
+		return params.MachineHardwareResults{}, errors.Trace(err)
+	}
+
+	results := params.MachineHardwareResults{
+		Results: make([]params.MachineHardwareResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		hw, err := mm.resizeMachine(resizer, arg)
+		results.Results[i].Hardware = hw
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPI) resizeMachine(resizer environs.InstanceResizer, arg params.ResizeMachineArg) (*params.MachineHardware, error) {
+	machine, err := mm.machineFromTag(arg.Tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	instanceId, err := machine.InstanceId()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// A machine prepared for resizing holds an upgrade series lock,
+	// which has been prepared once all the workloads are stopped.
+	prepared := true
+	lockStatus, err := machine.UpgradeSeriesStatus()
+	if errors.IsNotFound(err) {
+		prepared = false
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !arg.Force {
+		if !prepared {
+			return nil, errors.Errorf("machine %s has not been prepared for resizing", machine.Id())
+		}
+		if lockStatus != model.UpgradeSeriesPrepareCompleted {
+			return nil, errors.Errorf("workloads on machine %s are still being stopped; series upgrade is in the %q state",
+				machine.Id(), lockStatus)
+		}
+	}
+
+	if err := machine.SetModificationStatus(status.StatusInfo{
+		Status:  status.Resizing,
+		Message: fmt.Sprintf("resizing instance to %q", arg.Constraints.String()),
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	hc, err := resizer.ResizeInstance(mm.callContext, instanceId, arg.Constraints)
+	if err != nil {
+		if statusErr := machine.SetModificationStatus(status.StatusInfo{
+			Status:  status.Error,
+			Message: fmt.Sprintf("resizing instance: %v", err),
+		}); statusErr != nil {
+			logger.Errorf("cannot set modification status of machine %s: %v", machine.Id(), statusErr)
+		}
+		return nil, errors.Annotate(err, "resizing instance")
+	}
+	if err := machine.SetHardwareCharacteristics(*hc); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Start the workloads again.
+	if prepared && lockStatus == model.UpgradeSeriesPrepareCompleted {
+		if err := machine.CompleteUpgradeSeries(); err != nil {
+			return nil, errors.Annotate(err, "restarting workloads")
+		}
+	}
+	if err := machine.SetModificationStatus(status.StatusInfo{
+		Status:  status.Applied,
+		Message: "resized to " + hc.String(),
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machineHardwareParams(hc), nil
+}
+
+// instanceResizer returns the model's environ as an InstanceResizer,
+// or a NotSupported error if the cloud can't resize instances.
+func instanceResizer(mm *MachineManagerAPI, getEnviron environGetFunc) (environs.InstanceResizer, error) {
+	env, err := modelEnviron(mm, getEnviron)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resizer, ok := env.(environs.InstanceResizer)
+	if !ok {
+		return nil, errors.NotSupportedf("resizing machines on this cloud")
+	}
+	return resizer, nil
+}
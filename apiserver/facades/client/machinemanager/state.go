@@ -11,6 +11,7 @@ import (
 	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/apiserver/common/storagecommon"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
@@ -28,6 +29,7 @@ type Backend interface {
 	AddOneMachine(template state.MachineTemplate) (*state.Machine, error)
 	AddMachineInsideNewMachine(template, parentTemplate state.MachineTemplate, containerType instance.ContainerType) (*state.Machine, error)
 	AddMachineInsideMachine(template state.MachineTemplate, parentId string, containerType instance.ContainerType) (*state.Machine, error)
+	AddUnitToMachine(application, machineId string, storage []names.StorageTag) (Unit, error)
 }
 
 type Pool interface {
@@ -60,6 +62,15 @@ type Machine interface {
 	IsManager() bool
 	IsLockedForSeriesUpgrade() (bool, error)
 	UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error)
+	SetEgressNATAddress(string) error
+	InstanceId() (instance.Id, error)
+	SetHardwareCharacteristics(instance.HardwareCharacteristics) error
+	SetModificationStatus(status.StatusInfo) error
+	ModificationStatus() (status.StatusInfo, error)
+	Status() (status.StatusInfo, error)
+	Life() state.Life
+	Containers() ([]string, error)
+	Constraints() (constraints.Value, error)
 }
 
 type stateShim struct {
@@ -78,6 +89,31 @@ func (s stateShim) Model() (Model, error) {
 	return s.State.Model()
 }
 
+// AddUnitToMachine adds a unit of the application, attached to the given
+// storage, and assigns it to the machine.
+func (s stateShim) AddUnitToMachine(appName, machineId string, storage []names.StorageTag) (Unit, error) {
+	app, err := s.State.Application(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machine, err := s.State.Machine(machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unit, err := app.AddUnit(state.AddUnitParams{AttachStorage: storage})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := unit.AssignToMachine(machine); err != nil {
+		// Don't leave an unassigned unit holding the storage.
+		if destroyErr := unit.Destroy(); destroyErr != nil {
+			logger.Errorf("cannot destroy unassigned unit %s: %v", unit.Name(), destroyErr)
+		}
+		return nil, errors.Trace(err)
+	}
+	return unit, nil
+}
+
 type poolShim struct {
 	pool *state.StatePool
 }
@@ -111,6 +147,10 @@ type Unit interface {
 	Name() string
 	AgentStatus() (status.StatusInfo, error)
 	Status() (status.StatusInfo, error)
+	ApplicationName() string
+	IsPrincipal() bool
+	Life() state.Life
+	Destroy() error
 }
 
 func (m machineShim) VerifyUnitsSeries(unitNames []string, series string, force bool) ([]Unit, error) {
@@ -0,0 +1,90 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelcloner
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facades/client/bundle"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+// Backend provides access to the models being cloned.
+type Backend interface {
+	// GetModel returns the model with the given UUID, along with a
+	// function that must be called to release it.
+	GetModel(modelUUID string) (Model, func(), error)
+}
+
+// Model describes the parts of a model needed to clone it.
+type Model interface {
+	ModelTag() names.ModelTag
+	CloudName() string
+	CloudRegion() string
+	CloudCredentialTag() (names.CloudCredentialTag, bool)
+	ModelConfigValues() (config.ConfigValues, error)
+	ModelConstraints() (constraints.Value, error)
+	SetModelConstraints(cons constraints.Value) error
+
+	// ExportBundle returns the model's applications, machines and
+	// relations as a bundle.
+	ExportBundle(authorizer facade.Authorizer) (string, error)
+}
+
+// ModelCreator creates new models.
+type ModelCreator interface {
+	CreateModel(args params.ModelCreateArgs) (params.ModelInfo, error)
+}
+
+type backendShim struct {
+	pool *state.StatePool
+}
+
+// GetModel implements Backend.
+func (b backendShim) GetModel(modelUUID string) (Model, func(), error) {
+	st, err := b.pool.Get(modelUUID)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	model, err := st.Model()
+	if err != nil {
+		st.Release()
+		return nil, nil, errors.Trace(err)
+	}
+	return &modelShim{Model: model, st: st.State}, func() { st.Release() }, nil
+}
+
+type modelShim struct {
+	*state.Model
+	st *state.State
+}
+
+// ModelConstraints implements Model.
+func (m *modelShim) ModelConstraints() (constraints.Value, error) {
+	return m.st.ModelConstraints()
+}
+
+// SetModelConstraints implements Model.
+func (m *modelShim) SetModelConstraints(cons constraints.Value) error {
+	return m.st.SetModelConstraints(cons)
+}
+
+// ExportBundle implements Model, using the same export as the
+// Bundle facade so that the result can be deployed with juju deploy.
+func (m *modelShim) ExportBundle(authorizer facade.Authorizer) (string, error) {
+	api, err := bundle.NewBundleAPI(bundle.NewStateShim(m.st), authorizer, m.ModelTag())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	result, err := api.ExportBundle()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return result.Result, nil
+}
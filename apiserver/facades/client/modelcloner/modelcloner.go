@@ -0,0 +1,202 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelcloner provides the ModelCloner facade, which creates
+// new models based on existing ones.
+package modelcloner
+
+import (
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facades/client/modelmanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/environs/config"
+)
+
+// skipConfigAttrs holds the model config attributes that are
+// specific to a model, and so are never copied to its clones.
+var skipConfigAttrs = set.NewStrings(
+	config.NameKey,
+	config.UUIDKey,
+	config.TypeKey,
+	config.AgentVersionKey,
+)
+
+// API implements the ModelCloner facade.
+type API struct {
+	backend       Backend
+	modelCreator  ModelCreator
+	authorizer    facade.Authorizer
+	apiUser       names.UserTag
+	controllerTag names.ControllerTag
+}
+
+// NewFacade is used for API registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	modelManager, err := modelmanager.NewFacadeV10(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPI(
+		backendShim{pool: ctx.StatePool()},
+		modelManager,
+		ctx.Auth(),
+		ctx.State().ControllerTag(),
+	)
+}
+
+// NewAPI returns a new ModelCloner API facade.
+func NewAPI(
+	backend Backend,
+	modelCreator ModelCreator,
+	authorizer facade.Authorizer,
+	controllerTag names.ControllerTag,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, apiservererrors.ErrPerm
+	}
+	apiUser, _ := authorizer.GetAuthTag().(names.UserTag)
+	return &API{
+		backend:       backend,
+		modelCreator:  modelCreator,
+		authorizer:    authorizer,
+		apiUser:       apiUser,
+		controllerTag: controllerTag,
+	}, nil
+}
+
+// CloneModel creates new models based on existing ones. Each new model
+// uses the same cloud, region and cloud credential as its source, and
+// has the same explicitly set model config and model constraints. When
+// requested, the source model's applications, machines and relations
+// are returned as a bundle, for the client to deploy to the new model;
+// no workload data is copied.
+func (api *API) CloneModel(args params.CloneModelArgs) (params.CloneModelResults, error) {
+	results := make([]params.CloneModelResult, len(args.Models))
+	for i, arg := range args.Models {
+		result, err := api.cloneModel(arg)
+		results[i] = result
+		if err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+		}
+	}
+	return params.CloneModelResults{Results: results}, nil
+}
+
+func (api *API) cloneModel(arg params.CloneModelArg) (params.CloneModelResult, error) {
+	var result params.CloneModelResult
+	sourceTag, err := names.ParseModelTag(arg.SourceModelTag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if err := api.checkCanClone(sourceTag); err != nil {
+		return result, errors.Trace(err)
+	}
+	ownerTag := api.apiUser
+	if arg.OwnerTag != "" {
+		if ownerTag, err = names.ParseUserTag(arg.OwnerTag); err != nil {
+			return result, errors.Trace(err)
+		}
+	}
+
+	source, release, err := api.backend.GetModel(sourceTag.Id())
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	defer release()
+
+	createArgs, err := createArgsFromModel(source, arg.Name, ownerTag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	cons, err := source.ModelConstraints()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	// Export the applications before creating the model, so that a
+	// failure doesn't leave a partial clone behind.
+	if arg.IncludeApplications {
+		if result.Bundle, err = source.ExportBundle(api.authorizer); err != nil {
+			return result, errors.Annotate(err, "exporting applications")
+		}
+	}
+
+	info, err := api.modelCreator.CreateModel(createArgs)
+	if err != nil {
+		return params.CloneModelResult{}, errors.Trace(err)
+	}
+	result.ModelTag = names.NewModelTag(info.UUID).String()
+	result.Name = info.Name
+	result.OwnerTag = info.OwnerTag
+	result.Type = info.Type
+
+	if cons.String() != "" {
+		if err := api.setModelConstraints(info.UUID, cons); err != nil {
+			return result, errors.Annotatef(err, "setting constraints on model %q", info.Name)
+		}
+	}
+	return result, nil
+}
+
+// checkCanClone returns an error if the API user is not allowed to
+// clone the specified model. Cloning exposes the model's config, so
+// requires admin access to it.
+func (api *API) checkCanClone(modelTag names.ModelTag) error {
+	isSuperuser, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.controllerTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if isSuperuser {
+		return nil
+	}
+	isAdmin, err := api.authorizer.HasPermission(permission.AdminAccess, modelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isAdmin {
+		return apiservererrors.ErrPerm
+	}
+	return nil
+}
+
+func (api *API) setModelConstraints(modelUUID string, cons constraints.Value) error {
+	model, release, err := api.backend.GetModel(modelUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer release()
+	return errors.Trace(model.SetModelConstraints(cons))
+}
+
+// createArgsFromModel returns the arguments needed to create a new
+// model like the supplied one.
+func createArgsFromModel(model Model, name string, owner names.UserTag) (params.ModelCreateArgs, error) {
+	values, err := model.ModelConfigValues()
+	if err != nil {
+		return params.ModelCreateArgs{}, errors.Trace(err)
+	}
+	attrs := make(map[string]interface{})
+	for key, value := range values {
+		if value.Source != config.JujuModelConfigSource || skipConfigAttrs.Contains(key) {
+			continue
+		}
+		attrs[key] = value.Value
+	}
+	args := params.ModelCreateArgs{
+		Name:        name,
+		OwnerTag:    owner.String(),
+		Config:      attrs,
+		CloudTag:    names.NewCloudTag(model.CloudName()).String(),
+		CloudRegion: model.CloudRegion(),
+	}
+	if credentialTag, ok := model.CloudCredentialTag(); ok {
+		args.CloudCredentialTag = credentialTag.String()
+	}
+	return args, nil
+}
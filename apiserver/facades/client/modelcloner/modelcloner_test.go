@@ -0,0 +1,306 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelcloner_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facades/client/modelcloner"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs/config"
+	coretesting "github.com/juju/juju/testing"
+)
+
+const newModelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00e"
+
+type modelClonerSuite struct {
+	testing.IsolationSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+	backend    *mockBackend
+	creator    *mockModelCreator
+	source     *mockModel
+	newModel   *mockModel
+	api        *modelcloner.API
+}
+
+var _ = gc.Suite(&modelClonerSuite{})
+
+func (s *modelClonerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	s.source = &mockModel{
+		tag:        coretesting.ModelTag,
+		cloud:      "dummy",
+		region:     "dummy-region",
+		credential: names.NewCloudCredentialTag("dummy/admin/default"),
+		config: config.ConfigValues{
+			"name":                        {Value: "source", Source: config.JujuModelConfigSource},
+			"uuid":                        {Value: coretesting.ModelTag.Id(), Source: config.JujuModelConfigSource},
+			"type":                        {Value: "dummy", Source: config.JujuModelConfigSource},
+			"agent-version":               {Value: "2.9.0", Source: config.JujuModelConfigSource},
+			"logging-config":              {Value: "<root>=DEBUG", Source: config.JujuModelConfigSource},
+			"default-series":              {Value: "focal", Source: config.JujuModelConfigSource},
+			"apt-mirror":                  {Value: "http://mirror", Source: config.JujuControllerSource},
+			"update-status-hook-interval": {Value: "5m", Source: config.JujuDefaultSource},
+		},
+		constraints: constraints.MustParse("mem=4G spaces=db"),
+		bundle:      "applications: {}\n",
+	}
+	s.newModel = &mockModel{tag: names.NewModelTag(newModelUUID)}
+	s.backend = &mockBackend{
+		models: map[string]*mockModel{
+			coretesting.ModelTag.Id(): s.source,
+			newModelUUID:              s.newModel,
+		},
+	}
+	s.creator = &mockModelCreator{
+		info: params.ModelInfo{
+			UUID:     newModelUUID,
+			Name:     "clone",
+			OwnerTag: "user-admin",
+			Type:     "iaas",
+		},
+	}
+	s.api = s.newAPI(c)
+}
+
+func (s *modelClonerSuite) newAPI(c *gc.C) *modelcloner.API {
+	api, err := modelcloner.NewAPI(s.backend, s.creator, s.authorizer, coretesting.ControllerTag)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *modelClonerSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := modelcloner.NewAPI(s.backend, s.creator, s.authorizer, coretesting.ControllerTag)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelClonerSuite) TestCloneModel(c *gc.C) {
+	results, err := s.api.CloneModel(params.CloneModelArgs{
+		Models: []params.CloneModelArg{{
+			SourceModelTag: coretesting.ModelTag.String(),
+			Name:           "clone",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.CloneModelResult{{
+		ModelTag: names.NewModelTag(newModelUUID).String(),
+		Name:     "clone",
+		OwnerTag: "user-admin",
+		Type:     "iaas",
+	}})
+
+	s.creator.CheckCalls(c, []testing.StubCall{{
+		FuncName: "CreateModel",
+		Args: []interface{}{params.ModelCreateArgs{
+			Name:     "clone",
+			OwnerTag: "user-admin",
+			Config: map[string]interface{}{
+				"logging-config": "<root>=DEBUG",
+				"default-series": "focal",
+			},
+			CloudTag:           "cloud-dummy",
+			CloudRegion:        "dummy-region",
+			CloudCredentialTag: "cloudcred-dummy_admin_default",
+		}},
+	}})
+	s.source.CheckCallNames(c, "ModelConfigValues", "ModelConstraints")
+	s.newModel.CheckCalls(c, []testing.StubCall{{
+		FuncName: "SetModelConstraints",
+		Args:     []interface{}{constraints.MustParse("mem=4G spaces=db")},
+	}})
+}
+
+func (s *modelClonerSuite) TestCloneModelWithApplications(c *gc.C) {
+	results, err := s.api.CloneModel(params.CloneModelArgs{
+		Models: []params.CloneModelArg{{
+			SourceModelTag:      coretesting.ModelTag.String(),
+			Name:                "clone",
+			OwnerTag:            "user-bob",
+			IncludeApplications: true,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Bundle, gc.Equals, "applications: {}\n")
+	s.source.CheckCallNames(c, "ModelConfigValues", "ModelConstraints", "ExportBundle")
+
+	args := s.creator.Calls()[0].Args[0].(params.ModelCreateArgs)
+	c.Assert(args.OwnerTag, gc.Equals, "user-bob")
+}
+
+func (s *modelClonerSuite) TestCloneModelNoConstraints(c *gc.C) {
+	s.source.constraints = constraints.Value{}
+	results, err := s.api.CloneModel(params.CloneModelArgs{
+		Models: []params.CloneModelArg{{
+			SourceModelTag: coretesting.ModelTag.String(),
+			Name:           "clone",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	s.newModel.CheckNoCalls(c)
+}
+
+func (s *modelClonerSuite) TestCloneModelExportFails(c *gc.C) {
+	s.source.SetErrors(nil, nil, errors.New("boom"))
+	results, err := s.api.CloneModel(params.CloneModelArgs{
+		Models: []params.CloneModelArg{{
+			SourceModelTag:      coretesting.ModelTag.String(),
+			Name:                "clone",
+			IncludeApplications: true,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "exporting applications: boom")
+	s.creator.CheckNoCalls(c)
+}
+
+func (s *modelClonerSuite) TestCloneModelCreateFails(c *gc.C) {
+	s.creator.SetErrors(errors.AlreadyExistsf("model %q for admin", "clone"))
+	results, err := s.api.CloneModel(params.CloneModelArgs{
+		Models: []params.CloneModelArg{{
+			SourceModelTag: coretesting.ModelTag.String(),
+			Name:           "clone",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0], jc.DeepEquals, params.CloneModelResult{
+		Error: &params.Error{
+			Message: `model "clone" for admin already exists`,
+			Code:    params.CodeAlreadyExists,
+		},
+	})
+	s.newModel.CheckNoCalls(c)
+}
+
+func (s *modelClonerSuite) TestCloneModelConstraintsFail(c *gc.C) {
+	s.newModel.SetErrors(errors.New("space not found"))
+	results, err := s.api.CloneModel(params.CloneModelArgs{
+		Models: []params.CloneModelArg{{
+			SourceModelTag: coretesting.ModelTag.String(),
+			Name:           "clone",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	// The model was created, so its details are still returned.
+	c.Assert(results.Results[0].ModelTag, gc.Equals, names.NewModelTag(newModelUUID).String())
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `setting constraints on model "clone": space not found`)
+}
+
+func (s *modelClonerSuite) TestCloneModelBadArgs(c *gc.C) {
+	results, err := s.api.CloneModel(params.CloneModelArgs{
+		Models: []params.CloneModelArg{{
+			SourceModelTag: "bad-tag",
+		}, {
+			SourceModelTag: coretesting.ModelTag.String(),
+			OwnerTag:       "bad-owner",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `"bad-tag" is not a valid tag`)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"bad-owner" is not a valid tag`)
+	s.creator.CheckNoCalls(c)
+}
+
+func (s *modelClonerSuite) TestCloneModelPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	api := s.newAPI(c)
+	results, err := api.CloneModel(params.CloneModelArgs{
+		Models: []params.CloneModelArg{{
+			SourceModelTag: coretesting.ModelTag.String(),
+			Name:           "clone",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
+	s.creator.CheckNoCalls(c)
+}
+
+type mockBackend struct {
+	models map[string]*mockModel
+}
+
+func (b *mockBackend) GetModel(modelUUID string) (modelcloner.Model, func(), error) {
+	model, ok := b.models[modelUUID]
+	if !ok {
+		return nil, nil, errors.NotFoundf("model %q", modelUUID)
+	}
+	return model, func() {}, nil
+}
+
+type mockModel struct {
+	testing.Stub
+
+	tag         names.ModelTag
+	cloud       string
+	region      string
+	credential  names.CloudCredentialTag
+	config      config.ConfigValues
+	constraints constraints.Value
+	bundle      string
+}
+
+func (m *mockModel) ModelTag() names.ModelTag {
+	return m.tag
+}
+
+func (m *mockModel) CloudName() string {
+	return m.cloud
+}
+
+func (m *mockModel) CloudRegion() string {
+	return m.region
+}
+
+func (m *mockModel) CloudCredentialTag() (names.CloudCredentialTag, bool) {
+	return m.credential, m.credential != names.CloudCredentialTag{}
+}
+
+func (m *mockModel) ModelConfigValues() (config.ConfigValues, error) {
+	m.MethodCall(m, "ModelConfigValues")
+	return m.config, m.NextErr()
+}
+
+func (m *mockModel) ModelConstraints() (constraints.Value, error) {
+	m.MethodCall(m, "ModelConstraints")
+	return m.constraints, m.NextErr()
+}
+
+func (m *mockModel) SetModelConstraints(cons constraints.Value) error {
+	m.MethodCall(m, "SetModelConstraints", cons)
+	return m.NextErr()
+}
+
+func (m *mockModel) ExportBundle(authorizer facade.Authorizer) (string, error) {
+	m.MethodCall(m, "ExportBundle")
+	return m.bundle, m.NextErr()
+}
+
+type mockModelCreator struct {
+	testing.Stub
+	info params.ModelInfo
+}
+
+func (m *mockModelCreator) CreateModel(args params.ModelCreateArgs) (params.ModelInfo, error) {
+	m.MethodCall(m, "CreateModel", args)
+	if err := m.NextErr(); err != nil {
+		return params.ModelInfo{}, err
+	}
+	return m.info, nil
+}
@@ -17,12 +17,14 @@ type Backend interface {
 	common.BlockGetter
 	ControllerTag() names.ControllerTag
 	ModelTag() names.ModelTag
+	ModelConfig() (*config.Config, error)
 	ModelConfigValues() (config.ConfigValues, error)
+	ModelConfigValueTrace(key string) (config.ConfigValueTrace, error)
 	UpdateModelConfig(map[string]interface{}, []string, ...state.ValidateConfigFunc) error
 	Sequences() (map[string]int, error)
 	SetSLA(level, owner string, credentials []byte) error
 	SLALevel() (string, error)
-	SpaceByName(string) error
+	SpaceSubnetCount(string) (int, error)
 }
 
 type stateShim struct {
@@ -34,10 +36,18 @@ func (st stateShim) UpdateModelConfig(u map[string]interface{}, r []string, a ..
 	return st.model.UpdateModelConfig(u, r, a...)
 }
 
+func (st stateShim) ModelConfig() (*config.Config, error) {
+	return st.model.ModelConfig()
+}
+
 func (st stateShim) ModelConfigValues() (config.ConfigValues, error) {
 	return st.model.ModelConfigValues()
 }
 
+func (st stateShim) ModelConfigValueTrace(key string) (config.ConfigValueTrace, error) {
+	return st.model.ModelConfigValueTrace(key)
+}
+
 func (st stateShim) ModelTag() names.ModelTag {
 	m, err := st.State.Model()
 	if err != nil {
@@ -47,9 +57,17 @@ func (st stateShim) ModelTag() names.ModelTag {
 	return m.ModelTag()
 }
 
-func (st stateShim) SpaceByName(name string) error {
-	_, err := st.State.SpaceByName(name)
-	return err
+// SpaceSubnetCount returns the number of subnets in the named space.
+func (st stateShim) SpaceSubnetCount(name string) (int, error) {
+	space, err := st.State.SpaceByName(name)
+	if err != nil {
+		return 0, err
+	}
+	subnets, err := space.Subnets()
+	if err != nil {
+		return 0, err
+	}
+	return len(subnets), nil
 }
 
 // NewStateBackend creates a backend for the facade to use.
@@ -6,18 +6,21 @@ package modelconfig
 import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/proxy"
 
 	"github.com/juju/juju/apiserver/common"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/logsink"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 )
 
-// NewFacade is used for API registration.
-func NewFacadeV2(ctx facade.Context) (*ModelConfigAPIV2, error) {
+// NewFacadeV4 is used for API registration.
+func NewFacadeV4(ctx facade.Context) (*ModelConfigAPIV4, error) {
 	auth := ctx.Auth()
 
 	model, err := ctx.State().Model()
@@ -27,6 +30,24 @@ func NewFacadeV2(ctx facade.Context) (*ModelConfigAPIV2, error) {
 	return NewModelConfigAPI(NewStateBackend(model), auth)
 }
 
+// NewFacadeV3 is used for API registration.
+func NewFacadeV3(ctx facade.Context) (*ModelConfigAPIV3, error) {
+	api, err := NewFacadeV4(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ModelConfigAPIV3{api}, nil
+}
+
+// NewFacadeV2 is used for API registration.
+func NewFacadeV2(ctx facade.Context) (*ModelConfigAPIV2, error) {
+	api, err := NewFacadeV3(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ModelConfigAPIV2{api}, nil
+}
+
 // NewFacadeV1 is used for API registration.
 func NewFacadeV1(ctx facade.Context) (*ModelConfigAPIV1, error) {
 	api, err := NewFacadeV2(ctx)
@@ -37,25 +58,35 @@ func NewFacadeV1(ctx facade.Context) (*ModelConfigAPIV1, error) {
 }
 
 // ModelConfigAPI provides the base implementation of the methods
-// for the V2 and V1 api calls.
+// for the V4, V3, V2 and V1 api calls.
 type ModelConfigAPI struct {
 	backend Backend
 	auth    facade.Authorizer
 	check   *common.BlockChecker
 }
 
-// ModelConfigAPIV2 is currently the latest.
-type ModelConfigAPIV2 struct {
+// ModelConfigAPIV4 is currently the latest.
+type ModelConfigAPIV4 struct {
 	*ModelConfigAPI
 }
 
+// ModelConfigAPIV3 hides V4 functionality
+type ModelConfigAPIV3 struct {
+	*ModelConfigAPIV4
+}
+
+// ModelConfigAPIV2 hides V3 functionality
+type ModelConfigAPIV2 struct {
+	*ModelConfigAPIV3
+}
+
 // ModelConfigAPIV1 hides V2 functionality
 type ModelConfigAPIV1 struct {
 	*ModelConfigAPIV2
 }
 
 // NewModelConfigAPI creates a new instance of the ModelConfig Facade.
-func NewModelConfigAPI(backend Backend, authorizer facade.Authorizer) (*ModelConfigAPIV2, error) {
+func NewModelConfigAPI(backend Backend, authorizer facade.Authorizer) (*ModelConfigAPIV4, error) {
 	if !authorizer.AuthClient() {
 		return nil, apiservererrors.ErrPerm
 	}
@@ -64,7 +95,7 @@ func NewModelConfigAPI(backend Backend, authorizer facade.Authorizer) (*ModelCon
 		auth:    authorizer,
 		check:   common.NewBlockChecker(backend),
 	}
-	return &ModelConfigAPIV2{client}, nil
+	return &ModelConfigAPIV4{client}, nil
 }
 
 func (c *ModelConfigAPI) checkCanWrite() error {
@@ -133,6 +164,84 @@ func (c *ModelConfigAPI) ModelGet() (params.ModelConfigResults, error) {
 	return result, nil
 }
 
+// ModelConfigTrace returns the effective value of each of the specified
+// model config attributes, along with the value set by each of the config
+// sources it is resolved from: Juju defaults, controller defaults, region
+// defaults and the model itself.
+func (c *ModelConfigAPI) ModelConfigTrace(args params.ModelConfigTraceArgs) (params.ModelConfigTraceResults, error) {
+	result := params.ModelConfigTraceResults{}
+	if err := c.canReadModel(); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	result.Results = make([]params.ModelConfigTraceResult, len(args.Keys))
+	for i, key := range args.Keys {
+		result.Results[i].Key = key
+		// Authorized keys are excluded from ModelGet; keep
+		// them out of here too.
+		if key == config.AuthorizedKeysKey {
+			result.Results[i].Error = apiservererrors.ServerError(errors.NotFoundf("model config attribute %q", key))
+			continue
+		}
+		trace, err := c.backend.ModelConfigValueTrace(key)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		result.Results[i].Value = trace.Value
+		result.Results[i].Source = trace.Source
+		for _, value := range trace.Sources {
+			result.Results[i].Sources = append(result.Results[i].Sources, params.ConfigValue{
+				Value:  value.Value,
+				Source: value.Source,
+			})
+		}
+	}
+	return result, nil
+}
+
+// CloudInitCustomizations returns the packages, commands and proxy
+// settings that the provisioner adds to the cloud-init user-data of
+// new machines in the model.
+func (c *ModelConfigAPI) CloudInitCustomizations() (params.CloudInitCustomizationsResult, error) {
+	result := params.CloudInitCustomizationsResult{}
+	if err := c.canReadModel(); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	cfg, err := c.backend.ModelConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	userData := cfg.CloudInitUserData()
+	result.Packages = userDataStrings(userData, "packages")
+	result.PreRunCmds = userDataStrings(userData, "preruncmd")
+	result.PostRunCmds = userDataStrings(userData, "postruncmd")
+	result.AptProxy = toProxyConfig(cfg.AptProxySettings())
+	result.SnapProxy = toProxyConfig(cfg.SnapProxySettings())
+	return result, nil
+}
+
+func userDataStrings(userData map[string]interface{}, key string) []string {
+	values, _ := userData[key].([]interface{})
+	var result []string
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+func toProxyConfig(settings proxy.Settings) params.ProxyConfig {
+	return params.ProxyConfig{
+		HTTP:    settings.Http,
+		HTTPS:   settings.Https,
+		FTP:     settings.Ftp,
+		NoProxy: settings.NoProxy,
+	}
+}
+
 // ModelSet implements the server-side part of the
 // set-model-config CLI command.
 func (c *ModelConfigAPI) ModelSet(args params.ModelSet) error {
@@ -153,7 +262,7 @@ func (c *ModelConfigAPI) ModelSet(args params.ModelSet) error {
 	// Only controller admins can set trace level debugging on a model.
 	checkLogTrace := c.checkLogTrace()
 
-	// Make sure DefaultSpace exists.
+	// Make sure DefaultSpace exists and has subnets.
 	checkDefaultSpace := c.checkDefaultSpace()
 
 	// Replace any deprecated attributes with their new values.
@@ -167,7 +276,11 @@ func (c *ModelConfigAPI) checkLogTrace() state.ValidateConfigFunc {
 		if !ok {
 			return nil
 		}
-		logCfg, err := loggo.ParseConfigString(spec.(string))
+		sinkCfg, err := logsink.ParseConfig(spec.(string))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		logCfg, err := loggo.ParseConfigString(sinkCfg.Modules)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -219,7 +332,18 @@ func (c *ModelConfigAPI) checkDefaultSpace() state.ValidateConfigFunc {
 			// No need to verify if a space isn't defined.
 			return nil
 		}
-		return c.backend.SpaceByName(spaceName)
+		count, err := c.backend.SpaceSubnetCount(spaceName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// The alpha space is always usable, as providers without
+		// support for spaces have no subnets to put in it.
+		if count == 0 && spaceName != network.AlphaSpaceName {
+			return errors.Errorf(
+				"space %q has no subnets; move subnets to it with \"juju move-to-space\", "+
+					"or run \"juju check-spaces\" for suggestions", spaceName)
+		}
+		return nil
 	}
 }
 
@@ -289,3 +413,9 @@ func (c *ModelConfigAPI) Sequences() (params.ModelSequencesResult, error) {
 
 // Sequences isn't on the V1 API.
 func (a *ModelConfigAPIV1) Sequences(_, _ struct{}) {}
+
+// ModelConfigTrace isn't on the V2 API.
+func (a *ModelConfigAPIV2) ModelConfigTrace(_, _ struct{}) {}
+
+// CloudInitCustomizations isn't on the V3 API.
+func (a *ModelConfigAPIV3) CloudInitCustomizations(_, _ struct{}) {}
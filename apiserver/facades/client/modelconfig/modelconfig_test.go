@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/modelconfig"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/provider/dummy"
 	_ "github.com/juju/juju/provider/dummy"
@@ -24,7 +25,7 @@ type modelconfigSuite struct {
 	gitjujutesting.IsolationSuite
 	backend    *mockBackend
 	authorizer apiservertesting.FakeAuthorizer
-	api        *modelconfig.ModelConfigAPIV2
+	api        *modelconfig.ModelConfigAPIV4
 }
 
 var _ = gc.Suite(&modelconfigSuite{})
@@ -149,6 +150,38 @@ func (s *modelconfigSuite) TestModelSetCannotChangeCharmHubURL(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *modelconfigSuite) TestModelSetDefaultSpace(c *gc.C) {
+	s.backend.spaceSubnets = map[string]int{"db": 2}
+	err := s.api.ModelSet(params.ModelSet{
+		map[string]interface{}{"default-space": "db"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertConfigValue(c, "default-space", "db")
+}
+
+func (s *modelconfigSuite) TestModelSetDefaultSpaceNotFound(c *gc.C) {
+	err := s.api.ModelSet(params.ModelSet{
+		map[string]interface{}{"default-space": "db"},
+	})
+	c.Assert(err, gc.ErrorMatches, `space "db" not found`)
+	s.assertConfigValueMissing(c, "default-space")
+}
+
+func (s *modelconfigSuite) TestModelSetDefaultSpaceNoSubnets(c *gc.C) {
+	s.backend.spaceSubnets = map[string]int{"db": 0, network.AlphaSpaceName: 0}
+	err := s.api.ModelSet(params.ModelSet{
+		map[string]interface{}{"default-space": "db"},
+	})
+	c.Assert(err, gc.ErrorMatches, `space "db" has no subnets; .*"juju check-spaces".*`)
+	s.assertConfigValueMissing(c, "default-space")
+
+	// The alpha space can always be the default.
+	err = s.api.ModelSet(params.ModelSet{
+		map[string]interface{}{"default-space": network.AlphaSpaceName},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *modelconfigSuite) TestAdminCanSetLogTrace(c *gc.C) {
 	args := params.ModelSet{
 		map[string]interface{}{"logging-config": "<root>=DEBUG;somepackage=TRACE"},
@@ -230,17 +263,120 @@ func (s *modelconfigSuite) TestSetSupportCredentals(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *modelconfigSuite) TestModelConfigTrace(c *gc.C) {
+	s.backend.traces = map[string]config.ConfigValueTrace{
+		"ftp-proxy": {
+			Value:  "http://proxy",
+			Source: "model",
+			Sources: []config.ConfigValue{
+				{"", "default"},
+				{"http://controller-proxy", "controller"},
+				{"http://proxy", "model"},
+			},
+		},
+	}
+	result, err := s.api.ModelConfigTrace(params.ModelConfigTraceArgs{
+		Keys: []string{"ftp-proxy", "not-there", "authorized-keys"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelConfigTraceResults{
+		Results: []params.ModelConfigTraceResult{{
+			Key:    "ftp-proxy",
+			Value:  "http://proxy",
+			Source: "model",
+			Sources: []params.ConfigValue{
+				{"", "default"},
+				{"http://controller-proxy", "controller"},
+				{"http://proxy", "model"},
+			},
+		}, {
+			Key: "not-there",
+			Error: &params.Error{
+				Message: `model config attribute "not-there" not found`,
+				Code:    params.CodeNotFound,
+			},
+		}, {
+			Key: "authorized-keys",
+			Error: &params.Error{
+				Message: `model config attribute "authorized-keys" not found`,
+				Code:    params.CodeNotFound,
+			},
+		}},
+	})
+}
+
+func (s *modelconfigSuite) TestModelConfigTracePermission(c *gc.C) {
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("charlie@local"),
+	}
+	api, err := modelconfig.NewModelConfigAPI(s.backend, &s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.ModelConfigTrace(params.ModelConfigTraceArgs{
+		Keys: []string{"ftp-proxy"},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelconfigSuite) TestCloudInitCustomizations(c *gc.C) {
+	cfg, err := config.New(config.UseDefaults, dummy.SampleConfig().Merge(testing.Attrs{
+		"cloudinit-userdata": "preruncmd: ['mkdir /srv']",
+		"cloudinit-packages": "htop,nfs-common",
+		"cloudinit-runcmds":  "['mkdir /srv/data']",
+		"apt-http-proxy":     "http://apt-proxy",
+		"snap-https-proxy":   "https://snap-proxy",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.old = cfg
+
+	result, err := s.api.CloudInitCustomizations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CloudInitCustomizationsResult{
+		Packages:    []string{"htop", "nfs-common"},
+		PreRunCmds:  []string{"mkdir /srv"},
+		PostRunCmds: []string{"mkdir /srv/data"},
+		AptProxy: params.ProxyConfig{
+			HTTP:    "http://apt-proxy",
+			NoProxy: "127.0.0.1,localhost,::1",
+		},
+		SnapProxy: params.ProxyConfig{HTTPS: "https://snap-proxy"},
+	})
+}
+
+func (s *modelconfigSuite) TestCloudInitCustomizationsPermission(c *gc.C) {
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("charlie@local"),
+	}
+	api, err := modelconfig.NewModelConfigAPI(s.backend, &s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.CloudInitCustomizations()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 type mockBackend struct {
-	cfg config.ConfigValues
-	old *config.Config
-	b   state.BlockType
-	msg string
+	cfg          config.ConfigValues
+	traces       map[string]config.ConfigValueTrace
+	old          *config.Config
+	b            state.BlockType
+	msg          string
+	spaceSubnets map[string]int
+}
+
+func (m *mockBackend) ModelConfig() (*config.Config, error) {
+	return m.old, nil
 }
 
 func (m *mockBackend) ModelConfigValues() (config.ConfigValues, error) {
 	return m.cfg, nil
 }
 
+func (m *mockBackend) ModelConfigValueTrace(key string) (config.ConfigValueTrace, error) {
+	trace, ok := m.traces[key]
+	if !ok {
+		return config.ConfigValueTrace{}, errors.NotFoundf("model config attribute %q", key)
+	}
+	return trace, nil
+}
+
 func (m *mockBackend) Sequences() (map[string]int, error) {
 	return nil, nil
 }
@@ -284,8 +420,12 @@ func (m *mockBackend) SLALevel() (string, error) {
 	return "mock-level", nil
 }
 
-func (m *mockBackend) SpaceByName(string) error {
-	return nil
+func (m *mockBackend) SpaceSubnetCount(name string) (int, error) {
+	count, ok := m.spaceSubnets[name]
+	if !ok {
+		return 0, errors.NotFoundf("space %q", name)
+	}
+	return count, nil
 }
 
 type mockBlock struct {
@@ -0,0 +1,174 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modeldestruction
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+// Backend provides access to the model being destroyed and the
+// entities it is waiting on.
+type Backend interface {
+	Model() (Model, error)
+	AllMachines() ([]Machine, error)
+	AllApplications() ([]Application, error)
+	AllVolumes() ([]Volume, error)
+	AllFilesystems() ([]Filesystem, error)
+	AllRelations() ([]Relation, error)
+}
+
+// Model describes the parts of a model needed to report the progress
+// of its destruction.
+type Model interface {
+	Life() state.Life
+	ForceDestroyed() bool
+	TimeOfDying() time.Time
+}
+
+// Machine describes the parts of a machine needed to report it as
+// blocking the destruction of its model. The instance status is
+// reported, since that tracks the removal of the machine's instance.
+type Machine interface {
+	Id() string
+	Life() state.Life
+	InstanceStatus() (status.StatusInfo, error)
+}
+
+// Application describes the parts of an application needed to report
+// it as blocking the destruction of its model.
+type Application interface {
+	Name() string
+	Life() state.Life
+	Status() (status.StatusInfo, error)
+}
+
+// Volume describes the parts of a volume needed to report it as
+// blocking the destruction of its model.
+type Volume interface {
+	VolumeTag() names.VolumeTag
+	Life() state.Life
+	Status() (status.StatusInfo, error)
+}
+
+// Filesystem describes the parts of a filesystem needed to report it
+// as blocking the destruction of its model.
+type Filesystem interface {
+	FilesystemTag() names.FilesystemTag
+	Life() state.Life
+	Status() (status.StatusInfo, error)
+}
+
+// Relation describes the parts of a relation needed to report it as
+// blocking the destruction of its model.
+type Relation interface {
+	String() string
+	Life() state.Life
+	Status() (status.StatusInfo, error)
+
+	// IsCrossModel returns whether the relation is with an
+	// application in another model.
+	IsCrossModel() (bool, error)
+}
+
+type backendShim struct {
+	st *state.State
+}
+
+// Model implements Backend.
+func (b backendShim) Model() (Model, error) {
+	m, err := b.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return m, nil
+}
+
+// AllMachines implements Backend.
+func (b backendShim) AllMachines() ([]Machine, error) {
+	machines, err := b.st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Machine, len(machines))
+	for i, m := range machines {
+		result[i] = m
+	}
+	return result, nil
+}
+
+// AllApplications implements Backend.
+func (b backendShim) AllApplications() ([]Application, error) {
+	apps, err := b.st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Application, len(apps))
+	for i, app := range apps {
+		result[i] = app
+	}
+	return result, nil
+}
+
+// AllVolumes implements Backend.
+func (b backendShim) AllVolumes() ([]Volume, error) {
+	sb, err := state.NewStorageBackend(b.st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumes, err := sb.AllVolumes()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Volume, len(volumes))
+	for i, v := range volumes {
+		result[i] = v
+	}
+	return result, nil
+}
+
+// AllFilesystems implements Backend.
+func (b backendShim) AllFilesystems() ([]Filesystem, error) {
+	sb, err := state.NewStorageBackend(b.st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	filesystems, err := sb.AllFilesystems()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Filesystem, len(filesystems))
+	for i, f := range filesystems {
+		result[i] = f
+	}
+	return result, nil
+}
+
+// AllRelations implements Backend.
+func (b backendShim) AllRelations() ([]Relation, error) {
+	relations, err := b.st.AllRelations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Relation, len(relations))
+	for i, rel := range relations {
+		result[i] = relationShim{rel}
+	}
+	return result, nil
+}
+
+type relationShim struct {
+	*state.Relation
+}
+
+// IsCrossModel implements Relation.
+func (r relationShim) IsCrossModel() (bool, error) {
+	_, crossModel, err := r.Relation.RemoteApplication()
+	return crossModel, errors.Trace(err)
+}
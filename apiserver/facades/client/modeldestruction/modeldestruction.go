@@ -0,0 +1,205 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modeldestruction provides the ModelDestruction facade, which
+// reports what the destruction of a model is still waiting on, so that
+// destroy-model can show more than a count of remaining entities.
+package modeldestruction
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/naturalsort"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+// Kinds of entity reported as blocking the destruction of a model, in
+// the order they are reported.
+const (
+	KindMachine     = "machine"
+	KindApplication = "application"
+	KindVolume      = "volume"
+	KindFilesystem  = "filesystem"
+	KindRelation    = "relation"
+)
+
+// API implements the ModelDestruction facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+	modelTag   names.ModelTag
+}
+
+// NewFacade is used for API registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	return NewAPI(backendShim{st: st}, ctx.Auth(), names.NewModelTag(st.ModelUUID()))
+}
+
+// NewAPI returns a new ModelDestruction API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer, modelTag names.ModelTag) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+		modelTag:   modelTag,
+	}, nil
+}
+
+func (api *API) checkCanRead() error {
+	canRead, err := api.authorizer.HasPermission(permission.ReadAccess, api.modelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
+		return apiservererrors.ErrPerm
+	}
+	return nil
+}
+
+// Progress returns the machines, applications, storage and cross-model
+// relations which remain to be removed before the model can be, along
+// with their current status and how long they have had it. It is an
+// error to ask about a model which is not being destroyed.
+func (api *API) Progress() (params.ModelDestructionProgress, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ModelDestructionProgress{}, err
+	}
+	model, err := api.backend.Model()
+	if err != nil {
+		return params.ModelDestructionProgress{}, errors.Trace(err)
+	}
+	if model.Life() == state.Alive {
+		return params.ModelDestructionProgress{}, errors.NotValidf("progress of model that is not being destroyed")
+	}
+	result := params.ModelDestructionProgress{
+		Life:       life.Value(model.Life().String()),
+		Force:      model.ForceDestroyed(),
+		DyingSince: timePtr(model.TimeOfDying()),
+		Blockers:   []params.ModelDestructionBlocker{},
+	}
+
+	machines, err := api.backend.AllMachines()
+	if err != nil {
+		return params.ModelDestructionProgress{}, errors.Trace(err)
+	}
+	blockers := make(map[string]params.ModelDestructionBlocker)
+	for _, m := range machines {
+		info, err := m.InstanceStatus()
+		if err != nil && !errors.IsNotFound(err) {
+			return params.ModelDestructionProgress{}, errors.Annotatef(err, "machine %q", m.Id())
+		}
+		blockers[m.Id()] = blocker(KindMachine, m.Id(), m.Life(), info)
+	}
+	result.Blockers = appendSorted(result.Blockers, blockers)
+
+	apps, err := api.backend.AllApplications()
+	if err != nil {
+		return params.ModelDestructionProgress{}, errors.Trace(err)
+	}
+	blockers = make(map[string]params.ModelDestructionBlocker)
+	for _, app := range apps {
+		info, err := app.Status()
+		if err != nil && !errors.IsNotFound(err) {
+			return params.ModelDestructionProgress{}, errors.Annotatef(err, "application %q", app.Name())
+		}
+		blockers[app.Name()] = blocker(KindApplication, app.Name(), app.Life(), info)
+	}
+	result.Blockers = appendSorted(result.Blockers, blockers)
+
+	volumes, err := api.backend.AllVolumes()
+	if err != nil {
+		return params.ModelDestructionProgress{}, errors.Trace(err)
+	}
+	blockers = make(map[string]params.ModelDestructionBlocker)
+	for _, v := range volumes {
+		id := v.VolumeTag().Id()
+		info, err := v.Status()
+		if err != nil && !errors.IsNotFound(err) {
+			return params.ModelDestructionProgress{}, errors.Annotatef(err, "volume %q", id)
+		}
+		blockers[id] = blocker(KindVolume, id, v.Life(), info)
+	}
+	result.Blockers = appendSorted(result.Blockers, blockers)
+
+	filesystems, err := api.backend.AllFilesystems()
+	if err != nil {
+		return params.ModelDestructionProgress{}, errors.Trace(err)
+	}
+	blockers = make(map[string]params.ModelDestructionBlocker)
+	for _, f := range filesystems {
+		id := f.FilesystemTag().Id()
+		info, err := f.Status()
+		if err != nil && !errors.IsNotFound(err) {
+			return params.ModelDestructionProgress{}, errors.Annotatef(err, "filesystem %q", id)
+		}
+		blockers[id] = blocker(KindFilesystem, id, f.Life(), info)
+	}
+	result.Blockers = appendSorted(result.Blockers, blockers)
+
+	// Relations within the model are removed along with their
+	// applications, so only those with other models are of interest.
+	relations, err := api.backend.AllRelations()
+	if err != nil {
+		return params.ModelDestructionProgress{}, errors.Trace(err)
+	}
+	blockers = make(map[string]params.ModelDestructionBlocker)
+	for _, rel := range relations {
+		crossModel, err := rel.IsCrossModel()
+		if err != nil {
+			return params.ModelDestructionProgress{}, errors.Annotatef(err, "relation %q", rel)
+		}
+		if !crossModel {
+			continue
+		}
+		info, err := rel.Status()
+		if err != nil && !errors.IsNotFound(err) {
+			return params.ModelDestructionProgress{}, errors.Annotatef(err, "relation %q", rel)
+		}
+		blockers[rel.String()] = blocker(KindRelation, rel.String(), rel.Life(), info)
+	}
+	result.Blockers = appendSorted(result.Blockers, blockers)
+	return result, nil
+}
+
+// appendSorted appends the input blockers to the result in order of ID.
+func appendSorted(result []params.ModelDestructionBlocker, blockers map[string]params.ModelDestructionBlocker) []params.ModelDestructionBlocker {
+	ids := make([]string, 0, len(blockers))
+	for id := range blockers {
+		ids = append(ids, id)
+	}
+	naturalsort.Sort(ids)
+	for _, id := range ids {
+		result = append(result, blockers[id])
+	}
+	return result
+}
+
+func blocker(kind, id string, entityLife state.Life, info status.StatusInfo) params.ModelDestructionBlocker {
+	return params.ModelDestructionBlocker{
+		Kind:    kind,
+		Id:      id,
+		Life:    life.Value(entityLife.String()),
+		Status:  string(info.Status),
+		Message: info.Message,
+		Since:   info.Since,
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
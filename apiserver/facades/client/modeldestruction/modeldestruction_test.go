@@ -0,0 +1,266 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modeldestruction_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/modeldestruction"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type modelDestructionSuite struct {
+	testing.IsolationSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+	backend    *mockBackend
+	now        time.Time
+}
+
+var _ = gc.Suite(&modelDestructionSuite{})
+
+func (s *modelDestructionSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	s.now = time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	s.backend = &mockBackend{
+		model: mockModel{
+			life:        state.Dying,
+			timeOfDying: s.now,
+		},
+		machines: []*mockEntity{
+			s.newEntity("10", state.Dying, status.Running, "", 1),
+			s.newEntity("2", state.Dead, status.ProvisioningError, "instance not terminated", 5),
+		},
+		apps: []*mockEntity{
+			s.newEntity("mysql", state.Dying, status.Terminated, "", 2),
+		},
+		volumes: []*mockEntity{
+			s.newEntity("0", state.Dying, status.Error, "failed to destroy volume", 3),
+		},
+		filesystems: []*mockEntity{
+			s.newEntity("1", state.Dying, status.Detaching, "", 4),
+		},
+		relations: []*mockEntity{
+			s.newEntity("wordpress:db mysql:server", state.Dying, status.Suspended, "", 6),
+			s.newEntity("mysql:cluster", state.Dying, status.Joined, "", 6),
+		},
+	}
+	s.backend.relations[0].crossModel = true
+}
+
+func (s *modelDestructionSuite) newEntity(id string, entityLife state.Life, value status.Status, message string, minutes int) *mockEntity {
+	since := s.now.Add(time.Duration(minutes) * time.Minute)
+	return &mockEntity{
+		id:   id,
+		life: entityLife,
+		info: status.StatusInfo{
+			Status:  value,
+			Message: message,
+			Since:   &since,
+		},
+	}
+}
+
+func (s *modelDestructionSuite) newAPI(c *gc.C) *modeldestruction.API {
+	api, err := modeldestruction.NewAPI(s.backend, s.authorizer, coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *modelDestructionSuite) blocker(kind, id string, entityLife life.Value, value status.Status, message string, minutes int) params.ModelDestructionBlocker {
+	since := s.now.Add(time.Duration(minutes) * time.Minute)
+	return params.ModelDestructionBlocker{
+		Kind:    kind,
+		Id:      id,
+		Life:    entityLife,
+		Status:  string(value),
+		Message: message,
+		Since:   &since,
+	}
+}
+
+func (s *modelDestructionSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := modeldestruction.NewAPI(s.backend, s.authorizer, coretesting.ModelTag)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelDestructionSuite) TestProgress(c *gc.C) {
+	result, err := s.newAPI(c).Progress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelDestructionProgress{
+		Life:       life.Dying,
+		DyingSince: &s.now,
+		Blockers: []params.ModelDestructionBlocker{
+			s.blocker("machine", "2", life.Dead, status.ProvisioningError, "instance not terminated", 5),
+			s.blocker("machine", "10", life.Dying, status.Running, "", 1),
+			s.blocker("application", "mysql", life.Dying, status.Terminated, "", 2),
+			s.blocker("volume", "0", life.Dying, status.Error, "failed to destroy volume", 3),
+			s.blocker("filesystem", "1", life.Dying, status.Detaching, "", 4),
+			s.blocker("relation", "wordpress:db mysql:server", life.Dying, status.Suspended, "", 6),
+		},
+	})
+}
+
+func (s *modelDestructionSuite) TestProgressNothingRemaining(c *gc.C) {
+	s.backend = &mockBackend{model: mockModel{life: state.Dead, force: true}}
+	result, err := s.newAPI(c).Progress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelDestructionProgress{
+		Life:     life.Dead,
+		Force:    true,
+		Blockers: []params.ModelDestructionBlocker{},
+	})
+}
+
+func (s *modelDestructionSuite) TestProgressModelAlive(c *gc.C) {
+	s.backend.model.life = state.Alive
+	_, err := s.newAPI(c).Progress()
+	c.Assert(err, gc.ErrorMatches, "progress of model that is not being destroyed not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *modelDestructionSuite) TestProgressStatusError(c *gc.C) {
+	s.backend.volumes[0].err = errors.New("boom")
+	_, err := s.newAPI(c).Progress()
+	c.Assert(err, gc.ErrorMatches, `volume "0": boom`)
+}
+
+func (s *modelDestructionSuite) TestProgressPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.newAPI(c).Progress()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type mockBackend struct {
+	model       mockModel
+	machines    []*mockEntity
+	apps        []*mockEntity
+	volumes     []*mockEntity
+	filesystems []*mockEntity
+	relations   []*mockEntity
+}
+
+func (b *mockBackend) Model() (modeldestruction.Model, error) {
+	return b.model, nil
+}
+
+func (b *mockBackend) AllMachines() ([]modeldestruction.Machine, error) {
+	result := make([]modeldestruction.Machine, len(b.machines))
+	for i, e := range b.machines {
+		result[i] = e
+	}
+	return result, nil
+}
+
+func (b *mockBackend) AllApplications() ([]modeldestruction.Application, error) {
+	result := make([]modeldestruction.Application, len(b.apps))
+	for i, e := range b.apps {
+		result[i] = e
+	}
+	return result, nil
+}
+
+func (b *mockBackend) AllVolumes() ([]modeldestruction.Volume, error) {
+	result := make([]modeldestruction.Volume, len(b.volumes))
+	for i, e := range b.volumes {
+		result[i] = e
+	}
+	return result, nil
+}
+
+func (b *mockBackend) AllFilesystems() ([]modeldestruction.Filesystem, error) {
+	result := make([]modeldestruction.Filesystem, len(b.filesystems))
+	for i, e := range b.filesystems {
+		result[i] = e
+	}
+	return result, nil
+}
+
+func (b *mockBackend) AllRelations() ([]modeldestruction.Relation, error) {
+	result := make([]modeldestruction.Relation, len(b.relations))
+	for i, e := range b.relations {
+		result[i] = e
+	}
+	return result, nil
+}
+
+type mockModel struct {
+	life        state.Life
+	force       bool
+	timeOfDying time.Time
+}
+
+func (m mockModel) Life() state.Life {
+	return m.life
+}
+
+func (m mockModel) ForceDestroyed() bool {
+	return m.force
+}
+
+func (m mockModel) TimeOfDying() time.Time {
+	return m.timeOfDying
+}
+
+// mockEntity implements each of the kinds of entity reported by the
+// facade.
+type mockEntity struct {
+	id         string
+	life       state.Life
+	info       status.StatusInfo
+	crossModel bool
+	err        error
+}
+
+func (e *mockEntity) Id() string {
+	return e.id
+}
+
+func (e *mockEntity) Name() string {
+	return e.id
+}
+
+func (e *mockEntity) String() string {
+	return e.id
+}
+
+func (e *mockEntity) VolumeTag() names.VolumeTag {
+	return names.NewVolumeTag(e.id)
+}
+
+func (e *mockEntity) FilesystemTag() names.FilesystemTag {
+	return names.NewFilesystemTag(e.id)
+}
+
+func (e *mockEntity) Life() state.Life {
+	return e.life
+}
+
+func (e *mockEntity) Status() (status.StatusInfo, error) {
+	return e.info, e.err
+}
+
+func (e *mockEntity) InstanceStatus() (status.StatusInfo, error) {
+	return e.info, e.err
+}
+
+func (e *mockEntity) IsCrossModel() (bool, error) {
+	return e.crossModel, nil
+}
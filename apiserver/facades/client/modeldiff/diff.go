@@ -0,0 +1,256 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modeldiff
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/description/v2"
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// volatileKeys holds the keys, at any depth of a model description,
+// whose values are expected to differ between otherwise identical
+// models: timestamps, histories, credentials and identifiers generated
+// by the controller.
+var volatileKeys = set.NewStrings(
+	"controller-uuid",
+	"nonce",
+	"password-hash",
+	"sequences",
+	"status-history",
+	"updated",
+	"uuid",
+)
+
+// identityKeys holds the keys, in order of preference, used to match
+// up the items of a list in two model descriptions.
+var identityKeys = []string{"name", "id", "key"}
+
+// Kinds of difference between two model descriptions.
+const (
+	added   = "added"
+	removed = "removed"
+	changed = "changed"
+)
+
+// diffDescriptions compares the two serialized model descriptions. Both
+// are deserialized and serialized again first, so that descriptions
+// written by different versions of the description package compare
+// equal when they describe the same model.
+func diffDescriptions(a, b string, includeVolatile bool) ([]params.ModelDifference, error) {
+	treeA, err := normalize(a, includeVolatile)
+	if err != nil {
+		return nil, errors.Annotate(err, "first model description")
+	}
+	treeB, err := normalize(b, includeVolatile)
+	if err != nil {
+		return nil, errors.Annotate(err, "second model description")
+	}
+	var diffs []params.ModelDifference
+	diffTrees("", treeA, treeB, &diffs)
+	return diffs, nil
+}
+
+func normalize(serialized string, includeVolatile bool) (interface{}, error) {
+	model, err := description.Deserialize([]byte(serialized))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	bytes, err := description.Serialize(model)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var tree interface{}
+	if err := yaml.Unmarshal(bytes, &tree); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return normalizeTree(tree, includeVolatile), nil
+}
+
+// normalizeTree converts the maps in the tree to map[string]interface{},
+// drops volatile keys unless asked not to, and collapses the
+// {version: N, items: [...]} wrappers the description package puts
+// around lists, since the versions are the same after serialization.
+func normalizeTree(tree interface{}, includeVolatile bool) interface{} {
+	switch tree := tree.(type) {
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{})
+		for k, v := range tree {
+			key := fmt.Sprint(k)
+			if !includeVolatile && volatileKeys.Contains(key) {
+				continue
+			}
+			result[key] = normalizeTree(v, includeVolatile)
+		}
+		if len(result) == 2 {
+			if _, ok := result["version"]; ok {
+				for k, v := range result {
+					if _, isList := v.([]interface{}); isList && k != "version" {
+						return v
+					}
+				}
+			}
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(tree))
+		for i, v := range tree {
+			result[i] = normalizeTree(v, includeVolatile)
+		}
+		return result
+	default:
+		return tree
+	}
+}
+
+func diffTrees(path string, a, b interface{}, diffs *[]params.ModelDifference) {
+	mapA, aIsMap := a.(map[string]interface{})
+	mapB, bIsMap := b.(map[string]interface{})
+	if aIsMap && bIsMap {
+		diffMaps(path, mapA, mapB, diffs)
+		return
+	}
+	listA, aIsList := a.([]interface{})
+	listB, bIsList := b.([]interface{})
+	if aIsList && bIsList {
+		diffLists(path, listA, listB, diffs)
+		return
+	}
+	if !reflect.DeepEqual(a, b) {
+		*diffs = append(*diffs, params.ModelDifference{
+			Path: path,
+			Kind: changed,
+			A:    render(a),
+			B:    render(b),
+		})
+	}
+}
+
+func diffMaps(path string, a, b map[string]interface{}, diffs *[]params.ModelDifference) {
+	keys := set.NewStrings()
+	for k := range a {
+		keys.Add(k)
+	}
+	for k := range b {
+		keys.Add(k)
+	}
+	for _, k := range keys.SortedValues() {
+		diffChild(joinPath(path, k), a, b, k, diffs)
+	}
+}
+
+// diffLists matches up the items of the lists by their identity key if
+// they have one, and by position otherwise.
+func diffLists(path string, a, b []interface{}, diffs *[]params.ModelDifference) {
+	if key := identityKey(a, b); key != "" {
+		byA, byB := indexBy(a, key), indexBy(b, key)
+		keys := set.NewStrings()
+		for k := range byA {
+			keys.Add(k)
+		}
+		for k := range byB {
+			keys.Add(k)
+		}
+		for _, k := range keys.SortedValues() {
+			diffChild(fmt.Sprintf("%s[%s]", path, k), byA, byB, k, diffs)
+		}
+		return
+	}
+	for i := 0; i < len(a) || i < len(b); i++ {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= len(b):
+			*diffs = append(*diffs, params.ModelDifference{Path: itemPath, Kind: removed, A: render(a[i])})
+		case i >= len(a):
+			*diffs = append(*diffs, params.ModelDifference{Path: itemPath, Kind: added, B: render(b[i])})
+		default:
+			diffTrees(itemPath, a[i], b[i], diffs)
+		}
+	}
+}
+
+func diffChild(path string, a, b map[string]interface{}, key string, diffs *[]params.ModelDifference) {
+	valueA, inA := a[key]
+	valueB, inB := b[key]
+	switch {
+	case !inB:
+		*diffs = append(*diffs, params.ModelDifference{Path: path, Kind: removed, A: render(valueA)})
+	case !inA:
+		*diffs = append(*diffs, params.ModelDifference{Path: path, Kind: added, B: render(valueB)})
+	default:
+		diffTrees(path, valueA, valueB, diffs)
+	}
+}
+
+// identityKey returns the first of identityKeys which every item of
+// both lists has a distinct scalar value for, or "" if there is none.
+func identityKey(a, b []interface{}) string {
+	for _, key := range identityKeys {
+		if hasIdentity(a, key) && hasIdentity(b, key) {
+			return key
+		}
+	}
+	return ""
+}
+
+func hasIdentity(items []interface{}, key string) bool {
+	seen := set.NewStrings()
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		value, ok := m[key]
+		if !ok {
+			return false
+		}
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			return false
+		}
+		id := fmt.Sprint(value)
+		if seen.Contains(id) {
+			return false
+		}
+		seen.Add(id)
+	}
+	return true
+}
+
+func indexBy(items []interface{}, key string) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, item := range items {
+		result[fmt.Sprint(item.(map[string]interface{})[key])] = item
+	}
+	return result
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// render returns the value as it would appear in the model description.
+// Maps and lists are rendered as YAML, with their keys sorted.
+func render(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		out, err := yaml.Marshal(value)
+		if err != nil {
+			return fmt.Sprint(value)
+		}
+		return strings.TrimSuffix(string(out), "\n")
+	default:
+		return fmt.Sprint(value)
+	}
+}
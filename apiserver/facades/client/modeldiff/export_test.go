@@ -0,0 +1,25 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modeldiff
+
+import (
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// DiffYAML compares two YAML documents as though they were serialized
+// model descriptions, without deserializing them.
+func DiffYAML(a, b string, includeVolatile bool) ([]params.ModelDifference, error) {
+	var treeA, treeB interface{}
+	if err := yaml.Unmarshal([]byte(a), &treeA); err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal([]byte(b), &treeB); err != nil {
+		return nil, err
+	}
+	var diffs []params.ModelDifference
+	diffTrees("", normalizeTree(treeA, includeVolatile), normalizeTree(treeB, includeVolatile), &diffs)
+	return diffs, nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modeldiff provides the ModelDiff facade, which compares two
+// serialized model descriptions, such as those written by dump-model.
+// It's intended for checking that a migrated model matches the
+// original, or that two environments' models have not drifted apart.
+package modeldiff
+
+import (
+	"github.com/juju/errors"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// API implements the ModelDiff facade.
+type API struct{}
+
+// NewFacade is used for API registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(ctx.Auth())
+}
+
+// NewAPI returns a new ModelDiff API facade.
+func NewAPI(authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &API{}, nil
+}
+
+// Diff returns the differences between two serialized model
+// descriptions, ordered by path. Fields expected to differ between
+// otherwise identical models, such as timestamps, UUIDs and password
+// hashes, are ignored unless IncludeVolatile is set.
+func (api *API) Diff(args params.ModelDiffArgs) (params.ModelDiffResult, error) {
+	diffs, err := diffDescriptions(args.A, args.B, args.IncludeVolatile)
+	if err != nil {
+		return params.ModelDiffResult{}, errors.Trace(err)
+	}
+	return params.ModelDiffResult{Differences: diffs}, nil
+}
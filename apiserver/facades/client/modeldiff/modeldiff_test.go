@@ -0,0 +1,191 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modeldiff_test
+
+import (
+	"time"
+
+	"github.com/juju/description/v2"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/modeldiff"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+)
+
+type modelDiffSuite struct {
+	testing.IsolationSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&modelDiffSuite{})
+
+func (s *modelDiffSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+}
+
+func (s *modelDiffSuite) newAPI(c *gc.C) *modeldiff.API {
+	api, err := modeldiff.NewAPI(s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func newModel(uuid string, updated time.Time) description.Model {
+	model := description.NewModel(description.ModelArgs{
+		Type:  description.IAAS,
+		Owner: names.NewUserTag("admin"),
+		Config: map[string]interface{}{
+			"name": "prod",
+			"type": "ec2",
+			"uuid": uuid,
+		},
+		Cloud:       "aws",
+		CloudRegion: "us-east-1",
+	})
+	model.SetStatus(description.StatusArgs{
+		Value:   "available",
+		Updated: updated,
+	})
+	return model
+}
+
+func serialize(c *gc.C, model description.Model) string {
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	return string(bytes)
+}
+
+func (s *modelDiffSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := modeldiff.NewAPI(s.authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelDiffSuite) TestDiffIgnoresVolatileFields(c *gc.C) {
+	a := newModel("deadbeef-0bad-400d-8000-4b1d0d06f00d", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	b := newModel("0badf00d-dead-4eef-8000-4b1d0d06f00d", time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC))
+
+	result, err := s.newAPI(c).Diff(params.ModelDiffArgs{A: serialize(c, a), B: serialize(c, b)})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Differences, gc.HasLen, 0)
+}
+
+func (s *modelDiffSuite) TestDiffIncludeVolatile(c *gc.C) {
+	a := newModel("deadbeef-0bad-400d-8000-4b1d0d06f00d", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	b := newModel("0badf00d-dead-4eef-8000-4b1d0d06f00d", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	result, err := s.newAPI(c).Diff(params.ModelDiffArgs{
+		A:               serialize(c, a),
+		B:               serialize(c, b),
+		IncludeVolatile: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Differences, jc.DeepEquals, []params.ModelDifference{{
+		Path: "config.uuid",
+		Kind: "changed",
+		A:    "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		B:    "0badf00d-dead-4eef-8000-4b1d0d06f00d",
+	}})
+}
+
+func (s *modelDiffSuite) TestDiffChanged(c *gc.C) {
+	now := time.Now()
+	a := newModel("deadbeef-0bad-400d-8000-4b1d0d06f00d", now)
+	a.AddSpace(description.SpaceArgs{Id: "1", Name: "db", ProviderID: "subnet-db"})
+	a.AddSpace(description.SpaceArgs{Id: "2", Name: "web"})
+	b := newModel("deadbeef-0bad-400d-8000-4b1d0d06f00d", now)
+	b.AddSpace(description.SpaceArgs{Id: "1", Name: "db", ProviderID: "subnet-db2"})
+	b.AddSpace(description.SpaceArgs{Id: "3", Name: "metrics"})
+	b.SetStatus(description.StatusArgs{Value: "busy", Updated: now})
+
+	result, err := s.newAPI(c).Diff(params.ModelDiffArgs{A: serialize(c, a), B: serialize(c, b)})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Differences, gc.HasLen, 4)
+	c.Check(result.Differences[0], jc.DeepEquals, params.ModelDifference{
+		Path: "spaces[db].provider-id",
+		Kind: "changed",
+		A:    "subnet-db",
+		B:    "subnet-db2",
+	})
+	c.Check(result.Differences[1].Path, gc.Equals, "spaces[metrics]")
+	c.Check(result.Differences[1].Kind, gc.Equals, "added")
+	c.Check(result.Differences[2].Path, gc.Equals, "spaces[web]")
+	c.Check(result.Differences[2].Kind, gc.Equals, "removed")
+	c.Check(result.Differences[3], jc.DeepEquals, params.ModelDifference{
+		Path: "status.value",
+		Kind: "changed",
+		A:    "available",
+		B:    "busy",
+	})
+}
+
+func (s *modelDiffSuite) TestDiffInvalidDescription(c *gc.C) {
+	a := newModel("deadbeef-0bad-400d-8000-4b1d0d06f00d", time.Now())
+	_, err := s.newAPI(c).Diff(params.ModelDiffArgs{A: serialize(c, a), B: "version: 9999\n"})
+	c.Assert(err, gc.ErrorMatches, "second model description: .*")
+}
+
+func (s *modelDiffSuite) TestDiffTrees(c *gc.C) {
+	a := `
+applications:
+  version: 8
+  applications:
+  - name: mysql
+    charm-url: cs:mysql-1
+    units:
+      version: 2
+      units:
+      - name: mysql/0
+        password-hash: abc
+        workload-status: {value: active, updated: "2021-01-01"}
+  - name: wordpress
+    charm-url: cs:wordpress-3
+    exposed-endpoints: [www, admin]
+`
+	b := `
+applications:
+  version: 8
+  applications:
+  - name: mysql
+    charm-url: cs:mysql-2
+    units:
+      version: 2
+      units:
+      - name: mysql/0
+        password-hash: def
+        workload-status: {value: blocked, updated: "2021-02-01"}
+      - name: mysql/1
+  - name: wordpress
+    charm-url: cs:wordpress-3
+    exposed-endpoints: [www]
+`
+	diffs, err := modeldiff.DiffYAML(a, b, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(diffs, jc.DeepEquals, []params.ModelDifference{{
+		Path: "applications[mysql].charm-url",
+		Kind: "changed",
+		A:    "cs:mysql-1",
+		B:    "cs:mysql-2",
+	}, {
+		Path: "applications[mysql].units[mysql/0].workload-status.value",
+		Kind: "changed",
+		A:    "active",
+		B:    "blocked",
+	}, {
+		Path: "applications[mysql].units[mysql/1]",
+		Kind: "added",
+		B:    "name: mysql/1",
+	}, {
+		Path: "applications[wordpress].exposed-endpoints[1]",
+		Kind: "removed",
+		A:    "admin",
+	}})
+}
@@ -0,0 +1,93 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelhealth
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/tools"
+)
+
+// Backend provides access to the entities whose health is summarised.
+type Backend interface {
+	AgentVersion() (version.Number, error)
+	AllMachines() ([]Machine, error)
+	AllApplications() ([]Application, error)
+}
+
+// Machine describes the parts of a machine needed to assess its
+// health.
+type Machine interface {
+	common.MachineStatusGetter
+	AgentTools() (*tools.Tools, error)
+}
+
+// Application describes the parts of an application needed to assess
+// the health of its units.
+type Application interface {
+	AllUnits() ([]Unit, error)
+}
+
+// Unit describes the parts of a unit needed to assess its health.
+type Unit interface {
+	common.UnitStatusGetter
+	AgentHistory() status.StatusHistoryGetter
+}
+
+type backendShim struct {
+	st    *state.State
+	model *state.Model
+}
+
+// AgentVersion implements Backend.
+func (b backendShim) AgentVersion() (version.Number, error) {
+	return b.model.AgentVersion()
+}
+
+// AllMachines implements Backend.
+func (b backendShim) AllMachines() ([]Machine, error) {
+	machines, err := b.st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Machine, len(machines))
+	for i, m := range machines {
+		result[i] = m
+	}
+	return result, nil
+}
+
+// AllApplications implements Backend.
+func (b backendShim) AllApplications() ([]Application, error) {
+	apps, err := b.st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Application, len(apps))
+	for i, app := range apps {
+		result[i] = applicationShim{app}
+	}
+	return result, nil
+}
+
+type applicationShim struct {
+	*state.Application
+}
+
+// AllUnits implements Application.
+func (a applicationShim) AllUnits() ([]Unit, error) {
+	units, err := a.Application.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Unit, len(units))
+	for i, unit := range units {
+		result[i] = unit
+	}
+	return result, nil
+}
@@ -0,0 +1,267 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelhealth provides the ModelHealth facade, which summarises
+// the problems found in a model.
+package modelhealth
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/apiserver/common"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/status"
+)
+
+// The kinds of problem reported by Summary.
+const (
+	UnitError      = "unit-error"
+	HookFailing    = "hook-failing"
+	AgentLost      = "agent-lost"
+	MachineDown    = "machine-down"
+	UpgradePending = "upgrade-pending"
+)
+
+// The severities of problems reported by Summary, and the overall
+// severity of a model without problems.
+const (
+	SeverityOK       = "ok"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+var severityRank = map[string]int{
+	SeverityOK:       0,
+	SeverityWarning:  1,
+	SeverityCritical: 2,
+}
+
+const (
+	// hookHistorySize is the number of recent unit agent status
+	// history entries considered when looking for repeated hook
+	// failures.
+	hookHistorySize = 20
+
+	// hookFailureThreshold is the number of hook failures within the
+	// recent history of a unit in error at which its hook is reported
+	// as failing repeatedly.
+	hookFailureThreshold = 3
+)
+
+// API implements the ModelHealth facade.
+type API struct {
+	backend    Backend
+	presence   *common.ModelPresenceContext
+	authorizer facade.Authorizer
+	modelTag   names.ModelTag
+}
+
+// NewFacade is used for API registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	presence := &common.ModelPresenceContext{
+		Presence: ctx.Presence().ModelPresence(model.UUID()),
+	}
+	return NewAPI(backendShim{st: st, model: model}, presence, ctx.Auth(), model.ModelTag())
+}
+
+// NewAPI returns a new ModelHealth API facade.
+func NewAPI(
+	backend Backend,
+	presence *common.ModelPresenceContext,
+	authorizer facade.Authorizer,
+	modelTag names.ModelTag,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		presence:   presence,
+		authorizer: authorizer,
+		modelTag:   modelTag,
+	}, nil
+}
+
+func (api *API) checkCanRead() error {
+	canRead, err := api.authorizer.HasPermission(permission.ReadAccess, api.modelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
+		return apiservererrors.ErrPerm
+	}
+	return nil
+}
+
+// Summary returns the problems found in the model, each with a
+// severity: units in error, units whose hooks are failing repeatedly,
+// unit agents that are lost, machines that are down, and machine agents
+// still to be upgraded to the model's agent version.
+func (api *API) Summary() (params.ModelHealthSummary, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ModelHealthSummary{}, errors.Trace(err)
+	}
+	agentVersion, err := api.backend.AgentVersion()
+	if err != nil {
+		return params.ModelHealthSummary{}, errors.Trace(err)
+	}
+
+	problems := []params.HealthProblem{}
+	machines, err := api.backend.AllMachines()
+	if err != nil {
+		return params.ModelHealthSummary{}, errors.Trace(err)
+	}
+	for _, m := range machines {
+		machineProblems, err := api.machineProblems(m, agentVersion)
+		if err != nil {
+			return params.ModelHealthSummary{}, errors.Annotatef(err, "machine %q", m.Id())
+		}
+		problems = append(problems, machineProblems...)
+	}
+
+	apps, err := api.backend.AllApplications()
+	if err != nil {
+		return params.ModelHealthSummary{}, errors.Trace(err)
+	}
+	for _, app := range apps {
+		units, err := app.AllUnits()
+		if err != nil {
+			return params.ModelHealthSummary{}, errors.Trace(err)
+		}
+		for _, unit := range units {
+			unitProblems, err := api.unitProblems(unit)
+			if err != nil {
+				return params.ModelHealthSummary{}, errors.Annotatef(err, "unit %q", unit.Name())
+			}
+			problems = append(problems, unitProblems...)
+		}
+	}
+
+	sort.Slice(problems, func(i, j int) bool {
+		pi, pj := problems[i], problems[j]
+		if pi.Severity != pj.Severity {
+			return severityRank[pi.Severity] > severityRank[pj.Severity]
+		}
+		if pi.Tag != pj.Tag {
+			return pi.Tag < pj.Tag
+		}
+		return pi.Kind < pj.Kind
+	})
+	result := params.ModelHealthSummary{
+		Severity: SeverityOK,
+		Problems: problems,
+	}
+	if len(problems) > 0 {
+		result.Severity = problems[0].Severity
+	}
+	return result, nil
+}
+
+func (api *API) machineProblems(m Machine, agentVersion version.Number) ([]params.HealthProblem, error) {
+	var problems []params.HealthProblem
+	tag := names.NewMachineTag(m.Id()).String()
+
+	machineStatus, err := api.presence.MachineStatus(m)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if machineStatus.Status == status.Down {
+		problems = append(problems, params.HealthProblem{
+			Kind:     MachineDown,
+			Severity: SeverityCritical,
+			Tag:      tag,
+			Message:  machineStatus.Message,
+			Since:    machineStatus.Since,
+		})
+	}
+
+	agentTools, err := m.AgentTools()
+	if errors.IsNotFound(err) {
+		// The machine agent hasn't started yet.
+		return problems, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if agentTools.Version.Number != agentVersion {
+		problems = append(problems, params.HealthProblem{
+			Kind:     UpgradePending,
+			Severity: SeverityWarning,
+			Tag:      tag,
+			Message: fmt.Sprintf(
+				"agent is running %s, model agent version is %s",
+				agentTools.Version.Number, agentVersion,
+			),
+		})
+	}
+	return problems, nil
+}
+
+func (api *API) unitProblems(unit Unit) ([]params.HealthProblem, error) {
+	var problems []params.HealthProblem
+	tag := names.NewUnitTag(unit.Name()).String()
+
+	agent, workload := api.presence.UnitStatus(unit)
+	if agent.Err != nil {
+		return nil, errors.Trace(agent.Err)
+	}
+	if workload.Err != nil {
+		return nil, errors.Trace(workload.Err)
+	}
+	if workload.Status.Status == status.Error {
+		failures, err := recentHookFailures(unit)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		problem := params.HealthProblem{
+			Kind:     UnitError,
+			Severity: SeverityCritical,
+			Tag:      tag,
+			Message:  workload.Status.Message,
+			Since:    workload.Status.Since,
+		}
+		if failures >= hookFailureThreshold {
+			problem.Kind = HookFailing
+			problem.Message = fmt.Sprintf("%s (failed %d times recently)", workload.Status.Message, failures)
+		}
+		problems = append(problems, problem)
+	}
+	if agent.Status.Status == status.Lost {
+		problems = append(problems, params.HealthProblem{
+			Kind:     AgentLost,
+			Severity: SeverityWarning,
+			Tag:      tag,
+			Message:  agent.Status.Message,
+			Since:    agent.Status.Since,
+		})
+	}
+	return problems, nil
+}
+
+// recentHookFailures returns the number of times the unit's agent has
+// recently reported an error.
+func recentHookFailures(unit Unit) (int, error) {
+	history, err := unit.AgentHistory().StatusHistory(status.StatusHistoryFilter{Size: hookHistorySize})
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	var failures int
+	for _, info := range history {
+		if info.Status == status.Error {
+			failures++
+		}
+	}
+	return failures, nil
+}
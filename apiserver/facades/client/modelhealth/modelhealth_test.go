@@ -0,0 +1,304 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelhealth_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/modelhealth"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/presence"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/tools"
+)
+
+type modelHealthSuite struct {
+	testing.IsolationSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+	backend    *mockBackend
+	presence   mockPresence
+	api        *modelhealth.API
+	since      time.Time
+}
+
+var _ = gc.Suite(&modelHealthSuite{})
+
+func (s *modelHealthSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	s.since = time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	s.presence = mockPresence{
+		"machine-0":      presence.Alive,
+		"machine-1":      presence.Missing,
+		"unit-mysql-0":   presence.Alive,
+		"unit-mysql-1":   presence.Alive,
+		"unit-mysql-2":   presence.Alive,
+		"unit-mysql-3":   presence.Missing,
+		"machine-2":      presence.Missing,
+		"unit-haproxy-0": presence.Alive,
+	}
+	errorStatus := func(hook string) status.StatusInfo {
+		return status.StatusInfo{Status: status.Error, Message: `hook failed: "` + hook + `"`, Since: &s.since}
+	}
+	s.backend = &mockBackend{
+		agentVersion: version.MustParse("2.9.1"),
+		machines: []*mockMachine{
+			s.newMachine("0", status.Started, "2.9.1"),
+			s.newMachine("1", status.Started, "2.9.0"),
+			s.newMachine("2", status.Pending, ""),
+		},
+		apps: []*mockApplication{{
+			units: []*mockUnit{
+				s.newUnit("mysql/0", status.StatusInfo{Status: status.Active}),
+				s.newUnit("mysql/1", errorStatus("install"), status.Error, status.Executing),
+				s.newUnit("mysql/2", errorStatus("config-changed"), status.Error, status.Executing, status.Error, status.Executing, status.Error),
+				s.newUnit("mysql/3", status.StatusInfo{Status: status.Active, Since: &s.since}),
+			},
+		}, {
+			units: []*mockUnit{
+				s.newUnit("haproxy/0", status.StatusInfo{Status: status.Active}),
+			},
+		}},
+	}
+	s.api = s.newAPI(c)
+}
+
+func (s *modelHealthSuite) newAPI(c *gc.C) *modelhealth.API {
+	api, err := modelhealth.NewAPI(
+		s.backend, &common.ModelPresenceContext{Presence: s.presence}, s.authorizer, coretesting.ModelTag,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *modelHealthSuite) newMachine(id string, machineStatus status.Status, agentVersion string) *mockMachine {
+	m := &mockMachine{
+		id:     id,
+		status: status.StatusInfo{Status: machineStatus, Since: &s.since},
+	}
+	if agentVersion != "" {
+		m.tools = &tools.Tools{Version: version.MustParseBinary(agentVersion + "-ubuntu-amd64")}
+	}
+	return m
+}
+
+func (s *modelHealthSuite) newUnit(name string, workload status.StatusInfo, history ...status.Status) *mockUnit {
+	unit := &mockUnit{
+		name:     name,
+		agent:    status.StatusInfo{Status: status.Idle, Since: &s.since},
+		workload: workload,
+	}
+	for _, st := range history {
+		unit.history = append(unit.history, status.StatusInfo{Status: st})
+	}
+	return unit
+}
+
+func (s *modelHealthSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := modelhealth.NewAPI(
+		s.backend, &common.ModelPresenceContext{Presence: s.presence}, s.authorizer, coretesting.ModelTag,
+	)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelHealthSuite) TestSummary(c *gc.C) {
+	result, err := s.api.Summary()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelHealthSummary{
+		Severity: "critical",
+		Problems: []params.HealthProblem{{
+			Kind:     "machine-down",
+			Severity: "critical",
+			Tag:      "machine-1",
+			Message:  "agent is not communicating with the server",
+			Since:    &s.since,
+		}, {
+			Kind:     "unit-error",
+			Severity: "critical",
+			Tag:      "unit-mysql-1",
+			Message:  `hook failed: "install"`,
+			Since:    &s.since,
+		}, {
+			Kind:     "hook-failing",
+			Severity: "critical",
+			Tag:      "unit-mysql-2",
+			Message:  `hook failed: "config-changed" (failed 3 times recently)`,
+			Since:    &s.since,
+		}, {
+			Kind:     "upgrade-pending",
+			Severity: "warning",
+			Tag:      "machine-1",
+			Message:  "agent is running 2.9.0, model agent version is 2.9.1",
+		}, {
+			Kind:     "agent-lost",
+			Severity: "warning",
+			Tag:      "unit-mysql-3",
+			Message:  "agent is not communicating with the server",
+			Since:    &s.since,
+		}},
+	})
+	s.backend.apps[0].units[2].CheckCalls(c, []testing.StubCall{{
+		FuncName: "StatusHistory",
+		Args:     []interface{}{status.StatusHistoryFilter{Size: 20}},
+	}})
+}
+
+func (s *modelHealthSuite) TestSummaryHealthy(c *gc.C) {
+	s.backend.machines = s.backend.machines[:1]
+	s.backend.apps = s.backend.apps[1:]
+	result, err := s.api.Summary()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelHealthSummary{
+		Severity: "ok",
+		Problems: []params.HealthProblem{},
+	})
+}
+
+func (s *modelHealthSuite) TestSummaryWarningsOnly(c *gc.C) {
+	s.backend.machines = s.backend.machines[:1]
+	s.backend.apps[0].units = s.backend.apps[0].units[3:]
+	result, err := s.api.Summary()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Severity, gc.Equals, "warning")
+	c.Assert(result.Problems, gc.HasLen, 1)
+}
+
+func (s *modelHealthSuite) TestSummaryError(c *gc.C) {
+	s.backend.apps[1].units[0].err = errors.New("boom")
+	_, err := s.api.Summary()
+	c.Assert(err, gc.ErrorMatches, `unit "haproxy/0": boom`)
+}
+
+func (s *modelHealthSuite) TestSummaryPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.newAPI(c).Summary()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type mockPresence map[string]presence.Status
+
+func (p mockPresence) AgentStatus(agent string) (presence.Status, error) {
+	return p[agent], nil
+}
+
+type mockBackend struct {
+	agentVersion version.Number
+	machines     []*mockMachine
+	apps         []*mockApplication
+}
+
+func (b *mockBackend) AgentVersion() (version.Number, error) {
+	return b.agentVersion, nil
+}
+
+func (b *mockBackend) AllMachines() ([]modelhealth.Machine, error) {
+	result := make([]modelhealth.Machine, len(b.machines))
+	for i, m := range b.machines {
+		result[i] = m
+	}
+	return result, nil
+}
+
+func (b *mockBackend) AllApplications() ([]modelhealth.Application, error) {
+	result := make([]modelhealth.Application, len(b.apps))
+	for i, app := range b.apps {
+		result[i] = app
+	}
+	return result, nil
+}
+
+type mockMachine struct {
+	id     string
+	status status.StatusInfo
+	tools  *tools.Tools
+}
+
+func (m *mockMachine) Id() string {
+	return m.id
+}
+
+func (m *mockMachine) Life() state.Life {
+	return state.Alive
+}
+
+func (m *mockMachine) Status() (status.StatusInfo, error) {
+	return m.status, nil
+}
+
+func (m *mockMachine) AgentTools() (*tools.Tools, error) {
+	if m.tools == nil {
+		return nil, errors.NotFoundf("agent binaries for machine %v", m.id)
+	}
+	return m.tools, nil
+}
+
+type mockApplication struct {
+	units []*mockUnit
+}
+
+func (a *mockApplication) AllUnits() ([]modelhealth.Unit, error) {
+	result := make([]modelhealth.Unit, len(a.units))
+	for i, unit := range a.units {
+		result[i] = unit
+	}
+	return result, nil
+}
+
+type mockUnit struct {
+	testing.Stub
+	name     string
+	agent    status.StatusInfo
+	workload status.StatusInfo
+	history  []status.StatusInfo
+	err      error
+}
+
+func (u *mockUnit) Name() string {
+	return u.name
+}
+
+func (u *mockUnit) Life() state.Life {
+	return state.Alive
+}
+
+func (u *mockUnit) ShouldBeAssigned() bool {
+	return true
+}
+
+func (u *mockUnit) IsEmbedded() (bool, error) {
+	return false, nil
+}
+
+func (u *mockUnit) AgentStatus() (status.StatusInfo, error) {
+	return u.agent, u.err
+}
+
+func (u *mockUnit) Status() (status.StatusInfo, error) {
+	return u.workload, nil
+}
+
+func (u *mockUnit) AgentHistory() status.StatusHistoryGetter {
+	return u
+}
+
+func (u *mockUnit) StatusHistory(filter status.StatusHistoryFilter) ([]status.StatusInfo, error) {
+	u.MethodCall(u, "StatusHistory", filter)
+	return u.history, u.NextErr()
+}
@@ -185,7 +185,9 @@ func (s *modelInfoSuite) setAPIUser(c *gc.C, user names.UserTag) {
 func (s *modelInfoSuite) TestModelInfoV7(c *gc.C) {
 	api := &modelmanager.ModelManagerAPIV7{
 		&modelmanager.ModelManagerAPIV8{
-			s.modelmanager,
+			&modelmanager.ModelManagerAPIV9{
+				s.modelmanager,
+			},
 		},
 	}
 
@@ -652,6 +654,8 @@ type mockState struct {
 	block           state.BlockType
 	migration       *mockMigration
 	modelConfig     *config.Config
+	controllerCfg   map[string]interface{}
+	modelsForUser   []state.ModelAccessInfo
 
 	modelDetailsForUser func() ([]state.ModelSummary, error)
 }
@@ -797,9 +801,13 @@ func (st *mockState) IsController() bool {
 
 func (st *mockState) ControllerConfig() (controller.Config, error) {
 	st.MethodCall(st, "ControllerConfig")
-	return controller.Config{
+	cfg := controller.Config{
 		controller.ControllerUUIDKey: "deadbeef-1bad-500d-9000-4b1d0d06f00d",
-	}, st.NextErr()
+	}
+	for k, v := range st.controllerCfg {
+		cfg[k] = v
+	}
+	return cfg, st.NextErr()
 }
 
 func (st *mockState) ControllerNodes() ([]common.ControllerNode, error) {
@@ -869,7 +877,7 @@ func (st *mockState) ModelSummariesForUser(user names.UserTag, all bool) ([]stat
 
 func (st *mockState) ModelBasicInfoForUser(user names.UserTag) ([]state.ModelAccessInfo, error) {
 	st.MethodCall(st, "ModelBasicInfoForUser", user)
-	return []state.ModelAccessInfo{}, st.NextErr()
+	return append([]state.ModelAccessInfo{}, st.modelsForUser...), st.NextErr()
 }
 
 func (st *mockState) RemoveUserAccess(subject names.UserTag, target names.Tag) error {
@@ -1015,9 +1023,10 @@ func (m mockBlock) Message() string { return m.m }
 func (m mockBlock) ModelUUID() string { return "" }
 
 type mockControllerNode struct {
-	id        string
-	hasVote   bool
-	wantsVote bool
+	id            string
+	hasVote       bool
+	wantsVote     bool
+	customization *state.ControllerCustomization
 }
 
 func (m *mockControllerNode) Id() string {
@@ -1032,6 +1041,13 @@ func (m *mockControllerNode) HasVote() bool {
 	return m.hasVote
 }
 
+func (m *mockControllerNode) Customization() (state.ControllerCustomization, bool) {
+	if m.customization == nil {
+		return state.ControllerCustomization{}, false
+	}
+	return *m.customization, true
+}
+
 type mockMachine struct {
 	common.Machine
 	id            string
@@ -1233,6 +1249,11 @@ func (m *mockModel) SetCloudCredential(tag names.CloudCredentialTag) (bool, erro
 	return m.setCloudCredentialF(tag)
 }
 
+func (m *mockModel) TransferOwnership(newOwner names.UserTag) error {
+	m.MethodCall(m, "TransferOwnership", newOwner)
+	return m.NextErr()
+}
+
 type mockModelUser struct {
 	gitjujutesting.Stub
 	userName       string
@@ -27,6 +27,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
 	jujucloud "github.com/juju/juju/cloud"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/controller/modelmanager"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/permission"
@@ -42,6 +43,13 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.modelmanager")
 
+// ModelManagerV10 defines the methods on the version 10 facade for the
+// modelmanager API endpoint.
+type ModelManagerV10 interface {
+	ModelManagerV9
+	TransferModelOwnership(args params.TransferModelOwnershipParams) (params.ErrorResults, error)
+}
+
 // ModelManagerV9 defines the methods on the version 9 facade for the
 // modelmanager API endpoint.
 type ModelManagerV9 interface {
@@ -157,10 +165,16 @@ type ModelManagerAPI struct {
 	callContext context.ProviderCallContext
 }
 
+// ModelManagerAPIV9 provides a way to wrap the different calls between
+// version 9 and version 10 of the model manager API
+type ModelManagerAPIV9 struct {
+	*ModelManagerAPI
+}
+
 // ModelManagerAPIV8 provides a way to wrap the different calls between
-// version 8 and version 8 of the model manager API
+// version 8 and version 9 of the model manager API
 type ModelManagerAPIV8 struct {
-	*ModelManagerAPI
+	*ModelManagerAPIV9
 }
 
 // ModelManagerAPIV7 provides a way to wrap the different calls between
@@ -200,18 +214,19 @@ type ModelManagerAPIV2 struct {
 }
 
 var (
-	_ ModelManagerV9 = (*ModelManagerAPI)(nil)
-	_ ModelManagerV8 = (*ModelManagerAPIV8)(nil)
-	_ ModelManagerV7 = (*ModelManagerAPIV7)(nil)
-	_ ModelManagerV6 = (*ModelManagerAPIV6)(nil)
-	_ ModelManagerV5 = (*ModelManagerAPIV5)(nil)
-	_ ModelManagerV4 = (*ModelManagerAPIV4)(nil)
-	_ ModelManagerV3 = (*ModelManagerAPIV3)(nil)
-	_ ModelManagerV2 = (*ModelManagerAPIV2)(nil)
+	_ ModelManagerV10 = (*ModelManagerAPI)(nil)
+	_ ModelManagerV9  = (*ModelManagerAPIV9)(nil)
+	_ ModelManagerV8  = (*ModelManagerAPIV8)(nil)
+	_ ModelManagerV7  = (*ModelManagerAPIV7)(nil)
+	_ ModelManagerV6  = (*ModelManagerAPIV6)(nil)
+	_ ModelManagerV5  = (*ModelManagerAPIV5)(nil)
+	_ ModelManagerV4  = (*ModelManagerAPIV4)(nil)
+	_ ModelManagerV3  = (*ModelManagerAPIV3)(nil)
+	_ ModelManagerV2  = (*ModelManagerAPIV2)(nil)
 )
 
-// NewFacadeV9 is used for API registration.
-func NewFacadeV9(ctx facade.Context) (*ModelManagerAPI, error) {
+// NewFacadeV10 is used for API registration.
+func NewFacadeV10(ctx facade.Context) (*ModelManagerAPI, error) {
 	st := ctx.State()
 	pool := ctx.StatePool()
 	ctlrSt := pool.SystemState()
@@ -251,6 +266,15 @@ func NewFacadeV9(ctx facade.Context) (*ModelManagerAPI, error) {
 	)
 }
 
+// NewFacadeV9 is used for API registration.
+func NewFacadeV9(ctx facade.Context) (*ModelManagerAPIV9, error) {
+	v10, err := NewFacadeV10(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ModelManagerAPIV9{v10}, nil
+}
+
 // NewFacadeV8 is used for API registration.
 func NewFacadeV8(ctx facade.Context) (*ModelManagerAPIV8, error) {
 	v9, err := NewFacadeV9(ctx)
@@ -604,6 +628,32 @@ func (m *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Model
 	return m.getModelInfo(model.ModelTag())
 }
 
+// checkModelQuota returns a QuotaLimitExceeded error if the owner
+// already owns the maximum number of models allowed by the controller.
+func (m *ModelManagerAPI) checkModelQuota(controllerCfg controller.Config, ownerTag names.UserTag) error {
+	maxModels := controllerCfg.MaxModelsPerUser()
+	if maxModels == 0 {
+		return nil
+	}
+	models, err := m.state.ModelBasicInfoForUser(ownerTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var owned int
+	for _, model := range models {
+		if model.Owner == ownerTag.Id() {
+			owned++
+		}
+	}
+	if owned >= maxModels {
+		return errors.QuotaLimitExceededf(
+			"user %q already owns %d models, the maximum allowed by this controller",
+			ownerTag.Id(), owned,
+		)
+	}
+	return nil
+}
+
 func (m *ModelManagerAPI) newCAASModel(
 	cloudSpec environscloudspec.CloudSpec,
 	createArgs params.ModelCreateArgs,
@@ -621,6 +671,9 @@ func (m *ModelManagerAPI) newCAASModel(
 	if err != nil {
 		return nil, errors.Annotate(err, "getting controller config")
 	}
+	if err := m.checkModelQuota(controllerConfig, ownerTag); err != nil {
+		return nil, errors.Trace(err)
+	}
 	broker, err := m.getBroker(environs.OpenParams{
 		ControllerUUID: controllerConfig.ControllerUUID(),
 		Cloud:          cloudSpec,
@@ -684,6 +737,9 @@ func (m *ModelManagerAPI) newModel(
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := m.checkModelQuota(controllerCfg, ownerTag); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	// Create the Environ.
 	env, err := environs.New(environs.OpenParams{
@@ -1667,6 +1723,50 @@ func (m *ModelManagerAPI) ChangeModelCredential(args params.ChangeModelCredentia
 	return params.ErrorResults{Results: results}, nil
 }
 
+// TransferModelOwnership makes another user the owner of each of the
+// specified models. The new owner is given admin access to the model and
+// to all of the offers it hosts. Only controller superusers may transfer
+// model ownership.
+func (m *ModelManagerAPI) TransferModelOwnership(args params.TransferModelOwnershipParams) (params.ErrorResults, error) {
+	if err := m.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	controllerAdmin, err := m.authorizer.HasPermission(permission.SuperuserAccess, m.state.ControllerTag())
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	transferOwnership := func(arg params.TransferModelOwnershipParam) error {
+		if !controllerAdmin {
+			return apiservererrors.ErrPerm
+		}
+		modelTag, err := names.ParseModelTag(arg.ModelTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ownerTag, err := names.ParseUserTag(arg.OwnerTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		model, releaser, err := m.state.GetModel(modelTag.Id())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer releaser()
+
+		return errors.Trace(model.TransferOwnership(ownerTag))
+	}
+
+	results := make([]params.ErrorResult, len(args.Models))
+	for i, arg := range args.Models {
+		if err := transferOwnership(arg); err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
 // ValidateModelUpgrades validates if a model is allowed to perform an upgrade.
 // Examples of why you would want to block a model upgrade, would be situations
 // like upgrade-series. If performing an upgrade-series we don't know the
@@ -1762,3 +1862,6 @@ func (*ModelManagerAPIV5) ModelDefaultsForClouds(_, _ struct{}) {}
 
 // ValidateModelUpgrade did not exist prior to v9.
 func (*ModelManagerAPIV8) ValidateModelUpgrade(_, _ struct{}) {}
+
+// TransferModelOwnership did not exist prior to v10.
+func (*ModelManagerAPIV9) TransferModelOwnership(_, _ struct{}) {}
@@ -22,6 +22,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
@@ -324,6 +325,54 @@ func (s *modelManagerSuite) TestCreateModelArgs(c *gc.C) {
 	})
 }
 
+func (s *modelManagerSuite) TestCreateModelQuotaExceeded(c *gc.C) {
+	s.st.controllerCfg = map[string]interface{}{
+		controller.MaxModelsPerUser: 2,
+	}
+	s.st.modelsForUser = []state.ModelAccessInfo{
+		{Name: "one", Owner: "admin"},
+		{Name: "two", Owner: "admin"},
+		{Name: "shared", Owner: "bob"},
+	}
+	args := params.ModelCreateArgs{
+		Name:               "foo",
+		OwnerTag:           "user-admin",
+		CloudCredentialTag: "cloudcred-some-cloud_admin_some-credential",
+	}
+	_, err := s.api.CreateModel(args)
+	c.Assert(err, jc.Satisfies, errors.IsQuotaLimitExceeded)
+	c.Assert(err, gc.ErrorMatches, `user "admin" already owns 2 models, the maximum allowed by this controller`)
+	s.st.CheckCallNames(c,
+		"ControllerTag",
+		"ModelUUID",
+		"Model",
+		"ControllerTag",
+		"Cloud",
+		"CloudCredential",
+		"ComposeNewModelConfig",
+		"ControllerConfig",
+		"ModelBasicInfoForUser",
+	)
+	s.st.CheckCall(c, 8, "ModelBasicInfoForUser", names.NewUserTag("admin"))
+}
+
+func (s *modelManagerSuite) TestCreateModelWithinQuota(c *gc.C) {
+	s.st.controllerCfg = map[string]interface{}{
+		controller.MaxModelsPerUser: 2,
+	}
+	s.st.modelsForUser = []state.ModelAccessInfo{
+		{Name: "one", Owner: "admin"},
+		{Name: "shared", Owner: "bob"},
+	}
+	args := params.ModelCreateArgs{
+		Name:               "foo",
+		OwnerTag:           "user-admin",
+		CloudCredentialTag: "cloudcred-some-cloud_admin_some-credential",
+	}
+	_, err := s.api.CreateModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *modelManagerSuite) TestCreateModelArgsWithCloud(c *gc.C) {
 	args := params.ModelCreateArgs{
 		Name:     "foo",
@@ -690,7 +739,9 @@ func (s *modelManagerSuite) TestDumpModelV2(c *gc.C) {
 					&modelmanager.ModelManagerAPIV6{
 						&modelmanager.ModelManagerAPIV7{
 							&modelmanager.ModelManagerAPIV8{
-								s.api,
+								&modelmanager.ModelManagerAPIV9{
+									s.api,
+								},
 							},
 						},
 					},
@@ -862,7 +913,9 @@ func (s *modelManagerSuite) TestDestroyModelsV3(c *gc.C) {
 				&modelmanager.ModelManagerAPIV6{
 					&modelmanager.ModelManagerAPIV7{
 						&modelmanager.ModelManagerAPIV8{
-							s.api,
+							&modelmanager.ModelManagerAPIV9{
+								s.api,
+							},
 						},
 					},
 				},
@@ -1721,7 +1774,9 @@ func (s *modelManagerSuite) TestModelStatusV2(c *gc.C) {
 					&modelmanager.ModelManagerAPIV6{
 						&modelmanager.ModelManagerAPIV7{
 							&modelmanager.ModelManagerAPIV8{
-								s.api,
+								&modelmanager.ModelManagerAPIV9{
+									s.api,
+								},
 							},
 						},
 					},
@@ -1762,7 +1817,9 @@ func (s *modelManagerSuite) TestModelStatusV3(c *gc.C) {
 				&modelmanager.ModelManagerAPIV6{
 					&modelmanager.ModelManagerAPIV7{
 						&modelmanager.ModelManagerAPIV8{
-							s.api,
+							&modelmanager.ModelManagerAPIV9{
+								s.api,
+							},
 						},
 					},
 				},
@@ -1909,6 +1966,59 @@ func (s *modelManagerSuite) TestChangeModelCredentialNotUpdated(c *gc.C) {
 	c.Assert(results.Results[0].Error, gc.ErrorMatches, `model deadbeef-0bad-400d-8000-4b1d0d06f00d already uses credential foo/bob/bar`)
 }
 
+func (s *modelManagerSuite) TestTransferModelOwnership(c *gc.C) {
+	modelTag := s.st.ModelTag().String()
+	s.st.model.ResetCalls()
+	results, err := s.api.TransferModelOwnership(params.TransferModelOwnershipParams{
+		Models: []params.TransferModelOwnershipParam{{
+			ModelTag: modelTag,
+			OwnerTag: names.NewUserTag("bob").String(),
+		}, {
+			ModelTag: "bad-model-tag",
+		}, {
+			ModelTag: modelTag,
+			OwnerTag: "bad-owner-tag",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"bad-model-tag" is not a valid tag`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"bad-owner-tag" is not a valid tag`)
+	s.st.model.CheckCall(c, 0, "TransferOwnership", names.NewUserTag("bob"))
+}
+
+func (s *modelManagerSuite) TestTransferModelOwnershipError(c *gc.C) {
+	s.st.model.SetErrors(errors.AlreadyExistsf("model %q for bob", "foo"))
+	results, err := s.api.TransferModelOwnership(params.TransferModelOwnershipParams{
+		Models: []params.TransferModelOwnershipParam{{
+			ModelTag: s.st.ModelTag().String(),
+			OwnerTag: names.NewUserTag("bob").String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `model "foo" for bob already exists`)
+	c.Assert(results.Results[0].Error.Code, gc.Equals, params.CodeAlreadyExists)
+}
+
+func (s *modelManagerSuite) TestTransferModelOwnershipUnauthorisedUser(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("bob@remote"))
+	modelTag := s.st.ModelTag().String()
+	s.st.model.ResetCalls()
+
+	results, err := s.api.TransferModelOwnership(params.TransferModelOwnershipParams{
+		Models: []params.TransferModelOwnershipParam{{
+			ModelTag: modelTag,
+			OwnerTag: names.NewUserTag("bob").String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `permission denied`)
+	s.st.model.CheckNoCalls(c)
+}
+
 type fakeProvider struct {
 	environs.CloudEnvironProvider
 }
@@ -0,0 +1,115 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelspec provides the ModelSpec facade, used to set the
+// declarative spec a model is reconciled towards.
+package modelspec
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/modelspec"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend provides access to the spec of a model.
+type Backend interface {
+	ModelSpec() (state.ModelSpec, error)
+	SetModelSpec(spec string) (int, error)
+	RemoveModelSpec() error
+	ModelSpecStatus() (state.ModelSpecStatus, error)
+}
+
+// API implements the ModelSpec facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+	modelTag   names.ModelTag
+}
+
+// NewFacade is used for API registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	return NewAPI(st, ctx.Auth(), names.NewModelTag(st.ModelUUID()))
+}
+
+// NewAPI returns a new ModelSpec API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer, modelTag names.ModelTag) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+		modelTag:   modelTag,
+	}, nil
+}
+
+func (api *API) checkAccess(access permission.Access) error {
+	ok, err := api.authorizer.HasPermission(access, api.modelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return apiservererrors.ErrPerm
+	}
+	return nil
+}
+
+// ModelSpec returns the declarative spec of the model, and the status
+// of reconciling the model towards it.
+func (api *API) ModelSpec() (params.ModelSpecResult, error) {
+	if err := api.checkAccess(permission.ReadAccess); err != nil {
+		return params.ModelSpecResult{}, errors.Trace(err)
+	}
+	spec, err := api.backend.ModelSpec()
+	if err != nil {
+		return params.ModelSpecResult{}, errors.Trace(err)
+	}
+	result := params.ModelSpecResult{
+		Spec:     spec.Spec,
+		Revision: spec.Revision,
+	}
+	status, err := api.backend.ModelSpecStatus()
+	if errors.IsNotFound(err) {
+		return result, nil
+	} else if err != nil {
+		return params.ModelSpecResult{}, errors.Trace(err)
+	}
+	result.Status = &params.ModelSpecStatus{
+		Revision:   status.Revision,
+		Reconciled: status.Reconciled,
+		Drift:      status.Drift,
+	}
+	return result, nil
+}
+
+// SetModelSpec validates and sets the declarative spec of the model,
+// which the model is then reconciled towards.
+func (api *API) SetModelSpec(args params.SetModelSpecArgs) (params.SetModelSpecResult, error) {
+	if err := api.checkAccess(permission.WriteAccess); err != nil {
+		return params.SetModelSpecResult{}, errors.Trace(err)
+	}
+	if _, err := modelspec.Parse([]byte(args.Spec)); err != nil {
+		return params.SetModelSpecResult{}, errors.Trace(err)
+	}
+	revision, err := api.backend.SetModelSpec(args.Spec)
+	if err != nil {
+		return params.SetModelSpecResult{}, errors.Trace(err)
+	}
+	return params.SetModelSpecResult{Revision: revision}, nil
+}
+
+// RemoveModelSpec removes the declarative spec of the model. The model
+// is left as it is, and is no longer reconciled.
+func (api *API) RemoveModelSpec() error {
+	if err := api.checkAccess(permission.WriteAccess); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(api.backend.RemoveModelSpec())
+}
@@ -0,0 +1,158 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelspec_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/modelspec"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type modelSpecSuite struct {
+	testing.IsolationSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+	backend    *mockBackend
+}
+
+var _ = gc.Suite(&modelSpecSuite{})
+
+const validSpec = `
+applications:
+  mysql:
+    charm: cs:mysql
+    units: 2
+`
+
+func (s *modelSpecSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	s.backend = &mockBackend{}
+}
+
+func (s *modelSpecSuite) newAPI(c *gc.C) *modelspec.API {
+	api, err := modelspec.NewAPI(s.backend, s.authorizer, coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *modelSpecSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := modelspec.NewAPI(s.backend, s.authorizer, coretesting.ModelTag)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelSpecSuite) TestModelSpec(c *gc.C) {
+	reconciled := time.Date(2021, 5, 4, 3, 2, 1, 0, time.UTC)
+	s.backend.spec = &state.ModelSpec{Spec: validSpec, Revision: 2}
+	s.backend.status = &state.ModelSpecStatus{
+		Revision:   1,
+		Reconciled: reconciled,
+		Drift:      []string{`application "mysql" is not deployed`},
+	}
+	result, err := s.newAPI(c).ModelSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelSpecResult{
+		Spec:     validSpec,
+		Revision: 2,
+		Status: &params.ModelSpecStatus{
+			Revision:   1,
+			Reconciled: reconciled,
+			Drift:      []string{`application "mysql" is not deployed`},
+		},
+	})
+}
+
+func (s *modelSpecSuite) TestModelSpecNotReconciled(c *gc.C) {
+	s.backend.spec = &state.ModelSpec{Spec: validSpec, Revision: 1}
+	result, err := s.newAPI(c).ModelSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelSpecResult{Spec: validSpec, Revision: 1})
+}
+
+func (s *modelSpecSuite) TestModelSpecNotFound(c *gc.C) {
+	_, err := s.newAPI(c).ModelSpec()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *modelSpecSuite) TestModelSpecPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.newAPI(c).ModelSpec()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelSpecSuite) TestSetModelSpec(c *gc.C) {
+	result, err := s.newAPI(c).SetModelSpec(params.SetModelSpecArgs{Spec: validSpec})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.SetModelSpecResult{Revision: 1})
+	s.backend.CheckCall(c, 0, "SetModelSpec", validSpec)
+}
+
+func (s *modelSpecSuite) TestSetModelSpecInvalid(c *gc.C) {
+	_, err := s.newAPI(c).SetModelSpec(params.SetModelSpecArgs{Spec: "applications: {mysql: {units: 1}}"})
+	c.Assert(err, gc.ErrorMatches, `application "mysql" with no charm not valid`)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *modelSpecSuite) TestSetModelSpecReadOnly(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("readbob")
+	_, err := s.newAPI(c).SetModelSpec(params.SetModelSpecArgs{Spec: validSpec})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *modelSpecSuite) TestRemoveModelSpec(c *gc.C) {
+	err := s.newAPI(c).RemoveModelSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "RemoveModelSpec")
+}
+
+func (s *modelSpecSuite) TestRemoveModelSpecReadOnly(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("readbob")
+	err := s.newAPI(c).RemoveModelSpec()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+type mockBackend struct {
+	testing.Stub
+	spec   *state.ModelSpec
+	status *state.ModelSpecStatus
+}
+
+func (b *mockBackend) ModelSpec() (state.ModelSpec, error) {
+	if b.spec == nil {
+		return state.ModelSpec{}, errors.NotFoundf("model spec")
+	}
+	return *b.spec, nil
+}
+
+func (b *mockBackend) SetModelSpec(spec string) (int, error) {
+	b.AddCall("SetModelSpec", spec)
+	return 1, b.NextErr()
+}
+
+func (b *mockBackend) RemoveModelSpec() error {
+	b.AddCall("RemoveModelSpec")
+	return b.NextErr()
+}
+
+func (b *mockBackend) ModelSpecStatus() (state.ModelSpecStatus, error) {
+	if b.status == nil {
+		return state.ModelSpecStatus{}, errors.NotFoundf("model spec status")
+	}
+	return *b.status, nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package relationsettings
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/state"
+)
+
+// Backend provides access to the relations whose settings are read.
+type Backend interface {
+	Relation(id int) (Relation, error)
+	AllRelations() ([]Relation, error)
+	RemoteApplicationNames() ([]string, error)
+}
+
+// Relation describes the parts of a relation needed to read its
+// settings.
+type Relation interface {
+	Id() int
+	String() string
+	Endpoints() []state.Endpoint
+	ApplicationSettings(appName string) (map[string]interface{}, error)
+	AllUnitSettings() (map[string]map[string]interface{}, error)
+}
+
+type backendShim struct {
+	st *state.State
+}
+
+// Relation implements Backend.
+func (b backendShim) Relation(id int) (Relation, error) {
+	rel, err := b.st.Relation(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return rel, nil
+}
+
+// AllRelations implements Backend.
+func (b backendShim) AllRelations() ([]Relation, error) {
+	rels, err := b.st.AllRelations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Relation, len(rels))
+	for i, rel := range rels {
+		result[i] = rel
+	}
+	return result, nil
+}
+
+// RemoteApplicationNames implements Backend.
+func (b backendShim) RemoteApplicationNames() ([]string, error) {
+	apps, err := b.st.AllRemoteApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	names := make([]string, len(apps))
+	for i, app := range apps {
+		names[i] = app.Name()
+	}
+	return names, nil
+}
@@ -0,0 +1,218 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package relationsettings provides the RelationSettings facade, which
+// reports the application and unit settings on each side of a relation,
+// and the relations in a model as a matrix of application endpoints.
+package relationsettings
+
+import (
+	"sort"
+
+	"github.com/juju/charm/v9"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/permission"
+)
+
+// API implements the RelationSettings facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+	modelTag   names.ModelTag
+}
+
+// APIv1 implements version 1 of the RelationSettings facade.
+type APIv1 struct {
+	*API
+}
+
+// NewFacadeV1 is used for API registration of version 1.
+func NewFacadeV1(ctx facade.Context) (*APIv1, error) {
+	api, err := NewFacadeV2(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv1{api}, nil
+}
+
+// NewFacadeV2 is used for API registration.
+func NewFacadeV2(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	return NewAPI(backendShim{st: st}, ctx.Auth(), names.NewModelTag(st.ModelUUID()))
+}
+
+// NewAPI returns a new RelationSettings API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer, modelTag names.ModelTag) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+		modelTag:   modelTag,
+	}, nil
+}
+
+func (api *API) checkCanRead() error {
+	canRead, err := api.authorizer.HasPermission(permission.ReadAccess, api.modelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
+		return apiservererrors.ErrPerm
+	}
+	return nil
+}
+
+// Snapshot returns the application settings and the settings of each
+// unit in scope, on each side of the given relations. It's intended for
+// debugging relations whose data isn't as expected.
+func (api *API) Snapshot(args params.RelationIds) (params.RelationSnapshotResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.RelationSnapshotResults{}, errors.Trace(err)
+	}
+	results := make([]params.RelationSnapshotResult, len(args.RelationIds))
+	for i, id := range args.RelationIds {
+		result, err := api.snapshot(id)
+		if err != nil {
+			results[i].Id = id
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results[i] = result
+	}
+	return params.RelationSnapshotResults{Results: results}, nil
+}
+
+func (api *API) snapshot(id int) (params.RelationSnapshotResult, error) {
+	rel, err := api.backend.Relation(id)
+	if err != nil {
+		return params.RelationSnapshotResult{}, errors.Trace(err)
+	}
+	unitSettings, err := rel.AllUnitSettings()
+	if err != nil {
+		return params.RelationSnapshotResult{}, errors.Trace(err)
+	}
+	result := params.RelationSnapshotResult{
+		Id:  rel.Id(),
+		Key: rel.String(),
+	}
+	for _, ep := range rel.Endpoints() {
+		appSettings, err := rel.ApplicationSettings(ep.ApplicationName)
+		if err != nil {
+			return params.RelationSnapshotResult{}, errors.Trace(err)
+		}
+		snapshot := params.RelationEndpointSnapshot{
+			ApplicationName:     ep.ApplicationName,
+			Name:                ep.Name,
+			Role:                string(ep.Role),
+			ApplicationSettings: appSettings,
+			UnitSettings:        make(map[string]map[string]interface{}),
+		}
+		for unitName, settings := range unitSettings {
+			appName, err := names.UnitApplication(unitName)
+			if err != nil {
+				return params.RelationSnapshotResult{}, errors.Trace(err)
+			}
+			if appName == ep.ApplicationName {
+				snapshot.UnitSettings[unitName] = settings
+			}
+		}
+		result.Endpoints = append(result.Endpoints, snapshot)
+	}
+	sort.Slice(result.Endpoints, func(i, j int) bool {
+		return result.Endpoints[i].ApplicationName < result.Endpoints[j].ApplicationName
+	})
+	return result, nil
+}
+
+// RelationMatrix returns the relations in the model as an adjacency
+// matrix of the application endpoints taking part in them.
+func (api *API) RelationMatrix() (params.RelationMatrix, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.RelationMatrix{}, errors.Trace(err)
+	}
+	rels, err := api.backend.AllRelations()
+	if err != nil {
+		return params.RelationMatrix{}, errors.Trace(err)
+	}
+	remoteNames, err := api.backend.RemoteApplicationNames()
+	if err != nil {
+		return params.RelationMatrix{}, errors.Trace(err)
+	}
+	remote := set.NewStrings(remoteNames...)
+
+	var endpoints []params.RelationMatrixEndpoint
+	seen := set.NewStrings()
+	for _, rel := range rels {
+		for _, ep := range rel.Endpoints() {
+			if seen.Contains(ep.String()) {
+				continue
+			}
+			seen.Add(ep.String())
+			endpoints = append(endpoints, params.RelationMatrixEndpoint{
+				ApplicationName: ep.ApplicationName,
+				Name:            ep.Name,
+				Role:            string(ep.Role),
+				Interface:       ep.Interface,
+				Remote:          remote.Contains(ep.ApplicationName),
+			})
+		}
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].ApplicationName != endpoints[j].ApplicationName {
+			return endpoints[i].ApplicationName < endpoints[j].ApplicationName
+		}
+		return endpoints[i].Name < endpoints[j].Name
+	})
+	index := make(map[string]int)
+	for i, ep := range endpoints {
+		index[ep.ApplicationName+":"+ep.Name] = i
+	}
+
+	matrix := make([][]*params.RelationMatrixEntry, len(endpoints))
+	for i := range matrix {
+		matrix[i] = make([]*params.RelationMatrixEntry, len(endpoints))
+	}
+	for _, rel := range rels {
+		eps := rel.Endpoints()
+		entry := &params.RelationMatrixEntry{
+			RelationId: rel.Id(),
+			Key:        rel.String(),
+			Scope:      string(charm.ScopeGlobal),
+		}
+		for _, ep := range eps {
+			// A relation is container scoped if either of its
+			// endpoints is.
+			if ep.Scope == charm.ScopeContainer {
+				entry.Scope = string(charm.ScopeContainer)
+			}
+			if remote.Contains(ep.ApplicationName) {
+				entry.CrossModel = true
+			}
+		}
+		// Peer relations have a single endpoint, and so are found
+		// on the diagonal.
+		for _, from := range eps {
+			for _, to := range eps {
+				if len(eps) > 1 && from.String() == to.String() {
+					continue
+				}
+				matrix[index[from.String()]][index[to.String()]] = entry
+			}
+		}
+	}
+	return params.RelationMatrix{
+		Endpoints: endpoints,
+		Relations: matrix,
+	}, nil
+}
+
+// RelationMatrix isn't on the v1 API.
+func (*APIv1) RelationMatrix(_, _ struct{}) {}
@@ -0,0 +1,266 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package relationsettings_test
+
+import (
+	"sort"
+
+	"github.com/juju/charm/v9"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/relationsettings"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type relationSettingsSuite struct {
+	testing.IsolationSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+	backend    *mockBackend
+	api        *relationsettings.API
+}
+
+var _ = gc.Suite(&relationSettingsSuite{})
+
+func (s *relationSettingsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	s.backend = &mockBackend{
+		relations: map[int]*mockRelation{
+			1: {
+				id:  1,
+				key: "wordpress:db mysql:server",
+				endpoints: []state.Endpoint{{
+					ApplicationName: "wordpress",
+					Relation:        charm.Relation{Name: "db", Role: charm.RoleRequirer},
+				}, {
+					ApplicationName: "mysql",
+					Relation:        charm.Relation{Name: "server", Role: charm.RoleProvider},
+				}},
+				appSettings: map[string]map[string]interface{}{
+					"wordpress": {},
+					"mysql":     {"database": "wordpress"},
+				},
+				unitSettings: map[string]map[string]interface{}{
+					"mysql/0":     {"host": "10.0.0.1", "password": "secret"},
+					"wordpress/0": {"host": "10.0.0.2"},
+					"wordpress/1": {"host": "10.0.0.3"},
+				},
+			},
+		},
+	}
+	s.api = s.newAPI(c)
+}
+
+func (s *relationSettingsSuite) newAPI(c *gc.C) *relationsettings.API {
+	api, err := relationsettings.NewAPI(s.backend, s.authorizer, coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *relationSettingsSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := relationsettings.NewAPI(s.backend, s.authorizer, coretesting.ModelTag)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *relationSettingsSuite) TestSnapshot(c *gc.C) {
+	results, err := s.api.Snapshot(params.RelationIds{RelationIds: []int{1, 2}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0], jc.DeepEquals, params.RelationSnapshotResult{
+		Id:  1,
+		Key: "wordpress:db mysql:server",
+		Endpoints: []params.RelationEndpointSnapshot{{
+			ApplicationName:     "mysql",
+			Name:                "server",
+			Role:                "provider",
+			ApplicationSettings: map[string]interface{}{"database": "wordpress"},
+			UnitSettings: map[string]map[string]interface{}{
+				"mysql/0": {"host": "10.0.0.1", "password": "secret"},
+			},
+		}, {
+			ApplicationName:     "wordpress",
+			Name:                "db",
+			Role:                "requirer",
+			ApplicationSettings: map[string]interface{}{},
+			UnitSettings: map[string]map[string]interface{}{
+				"wordpress/0": {"host": "10.0.0.2"},
+				"wordpress/1": {"host": "10.0.0.3"},
+			},
+		}},
+	})
+	c.Assert(results.Results[1].Id, gc.Equals, 2)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `relation 2 not found`)
+	c.Assert(results.Results[1].Error.Code, gc.Equals, params.CodeNotFound)
+}
+
+func (s *relationSettingsSuite) TestSnapshotError(c *gc.C) {
+	s.backend.relations[1].err = errors.New("boom")
+	results, err := s.api.Snapshot(params.RelationIds{RelationIds: []int{1}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "boom")
+}
+
+func (s *relationSettingsSuite) TestSnapshotPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	api := s.newAPI(c)
+	_, err := api.Snapshot(params.RelationIds{RelationIds: []int{1}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *relationSettingsSuite) TestRelationMatrix(c *gc.C) {
+	s.backend.relations = map[int]*mockRelation{
+		1: {
+			id:  1,
+			key: "wordpress:db mysql:server",
+			endpoints: []state.Endpoint{{
+				ApplicationName: "wordpress",
+				Relation:        charm.Relation{Name: "db", Role: charm.RoleRequirer, Interface: "mysql", Scope: charm.ScopeGlobal},
+			}, {
+				ApplicationName: "mysql",
+				Relation:        charm.Relation{Name: "server", Role: charm.RoleProvider, Interface: "mysql", Scope: charm.ScopeGlobal},
+			}},
+		},
+		2: {
+			id:  2,
+			key: "wordpress:juju-info logging:info",
+			endpoints: []state.Endpoint{{
+				ApplicationName: "wordpress",
+				Relation:        charm.Relation{Name: "juju-info", Role: charm.RoleProvider, Interface: "juju-info", Scope: charm.ScopeGlobal},
+			}, {
+				ApplicationName: "logging",
+				Relation:        charm.Relation{Name: "info", Role: charm.RoleRequirer, Interface: "juju-info", Scope: charm.ScopeContainer},
+			}},
+		},
+		3: {
+			id:  3,
+			key: "mysql:cluster",
+			endpoints: []state.Endpoint{{
+				ApplicationName: "mysql",
+				Relation:        charm.Relation{Name: "cluster", Role: charm.RolePeer, Interface: "mysql-ha", Scope: charm.ScopeGlobal},
+			}},
+		},
+		4: {
+			id:  4,
+			key: "wordpress:db backup:db",
+			endpoints: []state.Endpoint{{
+				ApplicationName: "wordpress",
+				Relation:        charm.Relation{Name: "db", Role: charm.RoleRequirer, Interface: "mysql", Scope: charm.ScopeGlobal},
+			}, {
+				ApplicationName: "backup",
+				Relation:        charm.Relation{Name: "db", Role: charm.RoleProvider, Interface: "mysql", Scope: charm.ScopeGlobal},
+			}},
+		},
+	}
+	s.backend.remoteApplications = []string{"backup"}
+
+	result, err := s.api.RelationMatrix()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Endpoints, jc.DeepEquals, []params.RelationMatrixEndpoint{
+		{ApplicationName: "backup", Name: "db", Role: "provider", Interface: "mysql", Remote: true},
+		{ApplicationName: "logging", Name: "info", Role: "requirer", Interface: "juju-info"},
+		{ApplicationName: "mysql", Name: "cluster", Role: "peer", Interface: "mysql-ha"},
+		{ApplicationName: "mysql", Name: "server", Role: "provider", Interface: "mysql"},
+		{ApplicationName: "wordpress", Name: "db", Role: "requirer", Interface: "mysql"},
+		{ApplicationName: "wordpress", Name: "juju-info", Role: "provider", Interface: "juju-info"},
+	})
+
+	rel1 := &params.RelationMatrixEntry{RelationId: 1, Key: "wordpress:db mysql:server", Scope: "global"}
+	rel2 := &params.RelationMatrixEntry{RelationId: 2, Key: "wordpress:juju-info logging:info", Scope: "container"}
+	rel3 := &params.RelationMatrixEntry{RelationId: 3, Key: "mysql:cluster", Scope: "global"}
+	rel4 := &params.RelationMatrixEntry{RelationId: 4, Key: "wordpress:db backup:db", Scope: "global", CrossModel: true}
+	c.Assert(result.Relations, jc.DeepEquals, [][]*params.RelationMatrixEntry{
+		{nil, nil, nil, nil, rel4, nil},
+		{nil, nil, nil, nil, nil, rel2},
+		{nil, nil, rel3, nil, nil, nil},
+		{nil, nil, nil, nil, rel1, nil},
+		{rel4, nil, nil, rel1, nil, nil},
+		{nil, rel2, nil, nil, nil, nil},
+	})
+}
+
+func (s *relationSettingsSuite) TestRelationMatrixNoRelations(c *gc.C) {
+	s.backend.relations = nil
+	result, err := s.api.RelationMatrix()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Endpoints, gc.HasLen, 0)
+	c.Assert(result.Relations, gc.HasLen, 0)
+}
+
+func (s *relationSettingsSuite) TestRelationMatrixPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	api := s.newAPI(c)
+	_, err := api.RelationMatrix()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type mockBackend struct {
+	relations          map[int]*mockRelation
+	remoteApplications []string
+}
+
+func (b *mockBackend) Relation(id int) (relationsettings.Relation, error) {
+	rel, ok := b.relations[id]
+	if !ok {
+		return nil, errors.NotFoundf("relation %d", id)
+	}
+	return rel, nil
+}
+
+func (b *mockBackend) AllRelations() ([]relationsettings.Relation, error) {
+	var ids []int
+	for id := range b.relations {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	var result []relationsettings.Relation
+	for _, id := range ids {
+		result = append(result, b.relations[id])
+	}
+	return result, nil
+}
+
+func (b *mockBackend) RemoteApplicationNames() ([]string, error) {
+	return b.remoteApplications, nil
+}
+
+type mockRelation struct {
+	id           int
+	key          string
+	endpoints    []state.Endpoint
+	appSettings  map[string]map[string]interface{}
+	unitSettings map[string]map[string]interface{}
+	err          error
+}
+
+func (r *mockRelation) Id() int {
+	return r.id
+}
+
+func (r *mockRelation) String() string {
+	return r.key
+}
+
+func (r *mockRelation) Endpoints() []state.Endpoint {
+	return r.endpoints
+}
+
+func (r *mockRelation) ApplicationSettings(appName string) (map[string]interface{}, error) {
+	return r.appSettings[appName], nil
+}
+
+func (r *mockRelation) AllUnitSettings() (map[string]map[string]interface{}, error) {
+	return r.unitSettings, r.err
+}
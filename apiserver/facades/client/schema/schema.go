@@ -0,0 +1,117 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package schema provides the Schema facade, which describes the RPC
+// schema of the running controller: its facades and their versions,
+// along with the parameters and results of their methods. Client
+// generators can use it rather than a copy of the schema which may be
+// out of date.
+package schema
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	jsonschema "github.com/juju/jsonschema-gen"
+	"github.com/juju/rpcreflect"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	jujuversion "github.com/juju/juju/version"
+)
+
+// Registry provides the facades registered with the controller.
+type Registry interface {
+	ListDetails() []facade.Details
+}
+
+// API implements the Schema facade.
+type API struct {
+	registry Registry
+}
+
+// NewAPI returns a new Schema API facade describing the facades in
+// the registry.
+func NewAPI(registry Registry, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &API{registry: registry}, nil
+}
+
+// FacadeSchemas returns the JSON schemas of the selected facades, or
+// of all of the controller's facades if none are selected.
+func (api *API) FacadeSchemas(args params.FacadeSchemaArgs) (params.FacadeSchemaResults, error) {
+	return FacadeSchemas(api.registry, args)
+}
+
+// FacadeSchemas returns the JSON schemas of the facades in the registry
+// selected by args. It is an error to select a facade which is not
+// registered.
+func FacadeSchemas(registry Registry, args params.FacadeSchemaArgs) (params.FacadeSchemaResults, error) {
+	wanted := set.NewStrings(args.Facades...)
+	found := set.NewStrings()
+	latest := make(map[string]int)
+	var selected []facade.Details
+	for _, details := range registry.ListDetails() {
+		if !wanted.IsEmpty() && !wanted.Contains(details.Name) {
+			continue
+		}
+		found.Add(details.Name)
+		selected = append(selected, details)
+		if details.Version > latest[details.Name] {
+			latest[details.Name] = details.Version
+		}
+	}
+	if missing := wanted.Difference(found); !missing.IsEmpty() {
+		return params.FacadeSchemaResults{}, errors.NotFoundf("facade %s", strings.Join(missing.SortedValues(), ", "))
+	}
+	sort.Slice(selected, func(i, j int) bool {
+		if selected[i].Name != selected[j].Name {
+			return selected[i].Name < selected[j].Name
+		}
+		return selected[i].Version < selected[j].Version
+	})
+
+	result := params.FacadeSchemaResults{
+		JujuVersion: jujuversion.Current.String(),
+		Facades:     make([]params.FacadeSchema, 0, len(selected)),
+	}
+	for _, details := range selected {
+		if args.Latest && details.Version != latest[details.Name] {
+			continue
+		}
+		schema, err := facadeSchema(details)
+		if err != nil {
+			return params.FacadeSchemaResults{}, errors.Annotatef(err, "describing facade %s version %d", details.Name, details.Version)
+		}
+		result.Facades = append(result.Facades, params.FacadeSchema{
+			Name:    details.Name,
+			Version: details.Version,
+			Schema:  schema,
+		})
+	}
+	return result, nil
+}
+
+// facadeSchema returns the JSON schema of the facade's methods, in the
+// same form as the schema generated by generate/schemagen.
+func facadeSchema(details facade.Details) (map[string]interface{}, error) {
+	objType := rpcreflect.ObjTypeOf(details.Type)
+	if objType == nil {
+		return nil, errors.NotValidf("facade type %v", details.Type)
+	}
+	data, err := json.Marshal(jsonschema.ReflectFromObjType(objType))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var schema map[string]interface{}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return schema, nil
+}
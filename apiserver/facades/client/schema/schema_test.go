@@ -0,0 +1,128 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package schema_test
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facades/client/schema"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujuversion "github.com/juju/juju/version"
+)
+
+type schemaSuite struct {
+	testing.IsolationSuite
+
+	registry fakeRegistry
+	api      *schema.API
+}
+
+var _ = gc.Suite(&schemaSuite{})
+
+func (s *schemaSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.registry = fakeRegistry{{
+		Name:    "Echo",
+		Version: 1,
+		Type:    reflect.TypeOf((*echoV1)(nil)),
+	}, {
+		Name:    "Echo",
+		Version: 2,
+		Type:    reflect.TypeOf((*echoV2)(nil)),
+	}, {
+		Name:    "Alpha",
+		Version: 1,
+		Type:    reflect.TypeOf((*echoV1)(nil)),
+	}}
+
+	var err error
+	s.api, err = schema.NewAPI(s.registry, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("bob"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *schemaSuite) TestNewAPIRequiresClient(c *gc.C) {
+	_, err := schema.NewAPI(s.registry, apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *schemaSuite) TestFacadeSchemas(c *gc.C) {
+	result, err := s.api.FacadeSchemas(params.FacadeSchemaArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.JujuVersion, gc.Equals, jujuversion.Current.String())
+	c.Assert(facadeVersions(result), jc.DeepEquals, []string{"Alpha 1", "Echo 1", "Echo 2"})
+
+	echo := result.Facades[2].Schema
+	c.Assert(echo["type"], gc.Equals, "object")
+	properties := echo["properties"].(map[string]interface{})
+	c.Assert(properties, gc.HasLen, 2)
+	shout := properties["Shout"].(map[string]interface{})
+	c.Assert(shout["properties"], jc.DeepEquals, map[string]interface{}{
+		"Params": map[string]interface{}{"$ref": "#/definitions/Entities"},
+		"Result": map[string]interface{}{"$ref": "#/definitions/StringResults"},
+	})
+	definitions := echo["definitions"].(map[string]interface{})
+	c.Assert(definitions["Entities"], gc.NotNil)
+	c.Assert(definitions["StringResults"], gc.NotNil)
+}
+
+func (s *schemaSuite) TestFacadeSchemasSelected(c *gc.C) {
+	result, err := s.api.FacadeSchemas(params.FacadeSchemaArgs{
+		Facades: []string{"Echo"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(facadeVersions(result), jc.DeepEquals, []string{"Echo 1", "Echo 2"})
+}
+
+func (s *schemaSuite) TestFacadeSchemasLatest(c *gc.C) {
+	result, err := s.api.FacadeSchemas(params.FacadeSchemaArgs{Latest: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(facadeVersions(result), jc.DeepEquals, []string{"Alpha 1", "Echo 2"})
+}
+
+func (s *schemaSuite) TestFacadeSchemasUnknownFacade(c *gc.C) {
+	_, err := s.api.FacadeSchemas(params.FacadeSchemaArgs{
+		Facades: []string{"Echo", "Zulu", "Bravo"},
+	})
+	c.Assert(err, gc.ErrorMatches, "facade Bravo, Zulu not found")
+}
+
+func facadeVersions(result params.FacadeSchemaResults) []string {
+	var versions []string
+	for _, f := range result.Facades {
+		versions = append(versions, fmt.Sprintf("%s %d", f.Name, f.Version))
+	}
+	return versions
+}
+
+type fakeRegistry []facade.Details
+
+func (r fakeRegistry) ListDetails() []facade.Details {
+	return r
+}
+
+type echoV1 struct{}
+
+func (*echoV1) Say(params.Entities) params.StringResults {
+	return params.StringResults{}
+}
+
+type echoV2 struct {
+	echoV1
+}
+
+func (*echoV2) Shout(params.Entities) (params.StringResults, error) {
+	return params.StringResults{}, nil
+}
@@ -0,0 +1,284 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package spaces
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/state"
+)
+
+const (
+	spaceIssueError   = "error"
+	spaceIssueWarning = "warning"
+)
+
+// CheckSpaces is not available via the V7 API.
+func (u *APIv7) CheckSpaces(_, _ struct{}) {}
+
+// CheckSpaces cross-references the model's spaces and their subnets with
+// the default space, endpoint bindings and constraints which refer to
+// them. It reports the problems which would cause deployments to fail,
+// with suggestions for fixing them.
+func (api *API) CheckSpaces() (params.CheckSpacesResult, error) {
+	canRead, err := api.auth.HasPermission(permission.ReadAccess, api.backing.ModelTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return params.CheckSpacesResult{}, errors.Trace(err)
+	}
+	if !canRead {
+		return params.CheckSpacesResult{}, apiservererrors.ServerError(apiservererrors.ErrPerm)
+	}
+
+	checker, err := api.newSpaceChecker()
+	if err != nil {
+		return params.CheckSpacesResult{}, apiservererrors.ServerError(err)
+	}
+	if err := checker.checkDefaultSpace(); err != nil {
+		return params.CheckSpacesResult{}, apiservererrors.ServerError(err)
+	}
+	if err := checker.checkBindings(); err != nil {
+		return params.CheckSpacesResult{}, apiservererrors.ServerError(err)
+	}
+	if err := checker.checkConstraints(); err != nil {
+		return params.CheckSpacesResult{}, apiservererrors.ServerError(err)
+	}
+	checker.checkUnusedSpaces()
+	return params.CheckSpacesResult{Issues: checker.issues}, nil
+}
+
+// spaceChecker accumulates the issues found by CheckSpaces.
+type spaceChecker struct {
+	backing Backing
+	spaces  network.SpaceInfos
+
+	// usable holds the names of the spaces which units can be
+	// deployed to, for suggesting alternatives.
+	usable []string
+
+	// reported holds the names of the empty spaces which have already
+	// been reported as in use.
+	reported set.Strings
+
+	issues []params.SpaceIssue
+}
+
+func (api *API) newSpaceChecker() (*spaceChecker, error) {
+	spaceInfos, err := api.backing.AllSpaceInfos()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Slice(spaceInfos, func(i, j int) bool {
+		return spaceInfos[i].Name < spaceInfos[j].Name
+	})
+	checker := &spaceChecker{
+		backing:  api.backing,
+		spaces:   spaceInfos,
+		reported: set.NewStrings(),
+	}
+	for _, space := range spaceInfos {
+		if !isEmptySpace(space) {
+			checker.usable = append(checker.usable, string(space.Name))
+		}
+	}
+	return checker, nil
+}
+
+// isEmptySpace returns true if units cannot be deployed to the space as
+// it has no subnets. The alpha space is always usable, as providers
+// without support for spaces have no subnets to put in it.
+func isEmptySpace(space network.SpaceInfo) bool {
+	return space.Name != network.AlphaSpaceName && len(space.Subnets) == 0
+}
+
+func (c *spaceChecker) add(severity string, entity names.Tag, suggestion, format string, args ...interface{}) {
+	c.issues = append(c.issues, params.SpaceIssue{
+		Severity:   severity,
+		Entity:     entity.String(),
+		Message:    fmt.Sprintf(format, args...),
+		Suggestion: suggestion,
+	})
+}
+
+// checkSpace reports a problem if the named space does not exist or has
+// no subnets. The alternative describes how to use another space instead.
+func (c *spaceChecker) checkSpace(entity names.Tag, spaceName, alternative, what string) {
+	space := c.spaces.GetByName(spaceName)
+	if space == nil {
+		c.add(spaceIssueError, entity, c.useAnotherSpace(alternative),
+			"%s space %q, which does not exist", what, spaceName)
+		return
+	}
+	if !isEmptySpace(*space) {
+		return
+	}
+	c.reported.Add(spaceName)
+	c.add(spaceIssueError, entity, c.addSubnets(spaceName)+", or "+c.useAnotherSpace(alternative),
+		"%s space %q, which has no subnets", what, spaceName)
+}
+
+func (c *spaceChecker) checkDefaultSpace() error {
+	spaceName, err := c.backing.DefaultSpaceName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if spaceName == "" {
+		return nil
+	}
+	c.checkSpace(c.backing.ModelTag(), spaceName,
+		`"juju model-config default-space=<space>"`, "default-space refers to")
+	return nil
+}
+
+func (c *spaceChecker) checkBindings() error {
+	allBindings, err := c.backing.AllEndpointBindings()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	appNames := make([]string, 0, len(allBindings))
+	for appName := range allBindings {
+		appNames = append(appNames, appName)
+	}
+	sort.Strings(appNames)
+
+	for _, appName := range appNames {
+		// Group the endpoints by space, to report each space once.
+		endpointsBySpace := make(map[string][]string)
+		for endpoint, spaceID := range allBindings[appName].Map() {
+			endpointsBySpace[spaceID] = append(endpointsBySpace[spaceID], endpoint)
+		}
+		spaceIDs := make([]string, 0, len(endpointsBySpace))
+		for spaceID := range endpointsBySpace {
+			spaceIDs = append(spaceIDs, spaceID)
+		}
+		sort.Strings(spaceIDs)
+
+		appTag := names.NewApplicationTag(appName)
+		for _, spaceID := range spaceIDs {
+			endpoints := endpointsBySpace[spaceID]
+			sort.Strings(endpoints)
+			alternative := fmt.Sprintf(`"juju bind %s %s"`, appName, bindArgs(endpoints))
+			what := fmt.Sprintf("%s bound to", describeEndpoints(endpoints))
+
+			space := c.spaces.GetByID(spaceID)
+			if space == nil {
+				c.add(spaceIssueError, appTag, c.useAnotherSpace(alternative),
+					"%s space ID %q, which does not exist", what, spaceID)
+				continue
+			}
+			c.checkSpace(appTag, string(space.Name), alternative, what)
+		}
+	}
+	return nil
+}
+
+func (c *spaceChecker) checkConstraints() error {
+	allCons, err := c.backing.AllConstraints()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	type entityCons struct {
+		tag    names.Tag
+		spaces []string
+	}
+	var found []entityCons
+	for _, cons := range allCons {
+		tag := state.TagFromDocID(cons.ID())
+		if tag == nil {
+			continue
+		}
+		// The constraints of existing machines and units only record
+		// how they were provisioned, so only the model's and the
+		// applications' constraints affect future deployments.
+		switch tag.Kind() {
+		case names.ModelTagKind:
+			tag = c.backing.ModelTag()
+		case names.ApplicationTagKind:
+		default:
+			continue
+		}
+		value := cons.Value()
+		if spaces := value.IncludeSpaces(); len(spaces) > 0 {
+			found = append(found, entityCons{tag: tag, spaces: spaces})
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].tag.String() < found[j].tag.String()
+	})
+
+	for _, cons := range found {
+		alternative := `"juju set-model-constraints spaces=<space>"`
+		if cons.tag.Kind() == names.ApplicationTagKind {
+			alternative = fmt.Sprintf(`"juju set-constraints %s spaces=<space>"`, cons.tag.Id())
+		}
+		for _, spaceName := range cons.spaces {
+			c.checkSpace(cons.tag, spaceName, alternative, "constraints refer to")
+		}
+	}
+	return nil
+}
+
+// checkUnusedSpaces warns about empty spaces which nothing refers to
+// yet, as deploying to them will fail.
+func (c *spaceChecker) checkUnusedSpaces() {
+	for _, space := range c.spaces {
+		name := string(space.Name)
+		if !isEmptySpace(space) || c.reported.Contains(name) {
+			continue
+		}
+		c.add(spaceIssueWarning, names.NewSpaceTag(name),
+			c.addSubnets(name)+fmt.Sprintf(`, or remove it with "juju remove-space %s"`, name),
+			"space %q has no subnets", name)
+	}
+}
+
+func (c *spaceChecker) addSubnets(spaceName string) string {
+	return fmt.Sprintf(
+		`run "juju reload-spaces" to discover new provider subnets and move subnets to it with "juju move-to-space %s <CIDR>"`,
+		spaceName)
+}
+
+func (c *spaceChecker) useAnotherSpace(alternative string) string {
+	suggestion := "use another space with " + alternative
+	if len(c.usable) > 0 {
+		suggestion += fmt.Sprintf(" (spaces with subnets: %s)", strings.Join(c.usable, ", "))
+	}
+	return suggestion
+}
+
+func describeEndpoints(endpoints []string) string {
+	quoted := make([]string, len(endpoints))
+	for i, endpoint := range endpoints {
+		if endpoint == "" {
+			quoted[i] = "default binding"
+		} else {
+			quoted[i] = fmt.Sprintf("endpoint %q", endpoint)
+		}
+	}
+	if len(quoted) == 1 {
+		return quoted[0] + " is"
+	}
+	return strings.Join(quoted, ", ") + " are"
+}
+
+func bindArgs(endpoints []string) string {
+	args := make([]string, len(endpoints))
+	for i, endpoint := range endpoints {
+		if endpoint == "" {
+			args[i] = "<space>"
+		} else {
+			args[i] = endpoint + "=<space>"
+		}
+	}
+	return strings.Join(args, " ")
+}
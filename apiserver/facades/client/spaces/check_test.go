@@ -0,0 +1,128 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package spaces_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/spaces"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/network"
+)
+
+type checkSpacesSuite struct {
+	spaces.APISuite
+}
+
+var _ = gc.Suite(&checkSpacesSuite{})
+
+func (s *checkSpacesSuite) expectSpaces() {
+	subnets := network.SubnetInfos{{CIDR: "10.0.0.0/24"}}
+	s.Backing.EXPECT().AllSpaceInfos().Return(network.SpaceInfos{
+		{ID: "3", Name: "unused"},
+		{ID: "0", Name: network.AlphaSpaceName},
+		{ID: "1", Name: "db", Subnets: subnets},
+		{ID: "2", Name: "empty"},
+	}, nil)
+}
+
+func (s *checkSpacesSuite) expectBindings(ctrl *gomock.Controller, bindings map[string]map[string]string) {
+	all := make(map[string]spaces.Bindings)
+	for app, m := range bindings {
+		b := spaces.NewMockBindings(ctrl)
+		b.EXPECT().Map().Return(m)
+		all[app] = b
+	}
+	s.Backing.EXPECT().AllEndpointBindings().Return(all, nil)
+}
+
+func (s *checkSpacesSuite) expectConstraints(ctrl *gomock.Controller, cons map[string]string) {
+	var all []spaces.Constraints
+	for id, value := range cons {
+		c := spaces.NewMockConstraints(ctrl)
+		c.EXPECT().ID().Return(id)
+		c.EXPECT().Value().Return(constraints.MustParse(value)).AnyTimes()
+		all = append(all, c)
+	}
+	s.Backing.EXPECT().AllConstraints().Return(all, nil)
+}
+
+func (s *checkSpacesSuite) TestCheckSpaces(c *gc.C) {
+	ctrl, unReg := s.SetupMocks(c, true, false)
+	defer ctrl.Finish()
+	defer unReg()
+
+	s.Backing.EXPECT().ModelTag().Return(names.NewModelTag("123")).AnyTimes()
+	s.Backing.EXPECT().DefaultSpaceName().Return("empty", nil)
+	s.expectSpaces()
+	s.expectBindings(ctrl, map[string]map[string]string{
+		"mysql":     {"": "0", "db": "2"},
+		"wordpress": {"db": "9", "website": "1"},
+	})
+	s.expectConstraints(ctrl, map[string]string{
+		"deadbeef:a#mysql": "spaces=db,^unused",
+		"deadbeef:m#0":     "spaces=empty",
+		"deadbeef:e":       "spaces=gone",
+	})
+
+	result, err := s.API.CheckSpaces()
+	c.Assert(err, jc.ErrorIsNil)
+
+	anotherSpace := ` (spaces with subnets: alpha, db)`
+	c.Assert(result.Issues, jc.DeepEquals, []params.SpaceIssue{{
+		Severity: "error",
+		Entity:   "model-123",
+		Message:  `default-space refers to space "empty", which has no subnets`,
+		Suggestion: `run "juju reload-spaces" to discover new provider subnets and move subnets to it with "juju move-to-space empty <CIDR>", ` +
+			`or use another space with "juju model-config default-space=<space>"` + anotherSpace,
+	}, {
+		Severity: "error",
+		Entity:   "application-mysql",
+		Message:  `endpoint "db" is bound to space "empty", which has no subnets`,
+		Suggestion: `run "juju reload-spaces" to discover new provider subnets and move subnets to it with "juju move-to-space empty <CIDR>", ` +
+			`or use another space with "juju bind mysql db=<space>"` + anotherSpace,
+	}, {
+		Severity:   "error",
+		Entity:     "application-wordpress",
+		Message:    `endpoint "db" is bound to space ID "9", which does not exist`,
+		Suggestion: `use another space with "juju bind wordpress db=<space>"` + anotherSpace,
+	}, {
+		Severity:   "error",
+		Entity:     "model-123",
+		Message:    `constraints refer to space "gone", which does not exist`,
+		Suggestion: `use another space with "juju set-model-constraints spaces=<space>"` + anotherSpace,
+	}, {
+		Severity: "warning",
+		Entity:   "space-unused",
+		Message:  `space "unused" has no subnets`,
+		Suggestion: `run "juju reload-spaces" to discover new provider subnets and move subnets to it with "juju move-to-space unused <CIDR>", ` +
+			`or remove it with "juju remove-space unused"`,
+	}})
+}
+
+func (s *checkSpacesSuite) TestCheckSpacesNoIssues(c *gc.C) {
+	ctrl, unReg := s.SetupMocks(c, true, false)
+	defer ctrl.Finish()
+	defer unReg()
+
+	s.Backing.EXPECT().DefaultSpaceName().Return("db", nil)
+	s.Backing.EXPECT().AllSpaceInfos().Return(network.SpaceInfos{
+		{ID: "0", Name: network.AlphaSpaceName},
+		{ID: "1", Name: "db", Subnets: network.SubnetInfos{{CIDR: "10.0.0.0/24"}}},
+	}, nil)
+	s.expectBindings(ctrl, map[string]map[string]string{
+		"mysql": {"": "0", "db": "1"},
+	})
+	s.expectConstraints(ctrl, map[string]string{
+		"deadbeef:a#mysql": "spaces=db",
+	})
+
+	result, err := s.API.CheckSpaces()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Issues, gc.HasLen, 0)
+}
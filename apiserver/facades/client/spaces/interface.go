@@ -102,6 +102,10 @@ type Backing interface {
 	// ControllerConfig returns the controller config.
 	ControllerConfig() (controller.Config, error)
 
+	// DefaultSpaceName returns the name of the model's default space,
+	// as set by the "default-space" model config.
+	DefaultSpaceName() (string, error)
+
 	// AllConstraints returns all constraints in the model.
 	AllConstraints() ([]Constraints, error)
 
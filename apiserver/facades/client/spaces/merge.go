@@ -0,0 +1,218 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package spaces
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"gopkg.in/mgo.v2/txn"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/state"
+)
+
+// MergeSpace describes a space that can be merged into another.
+type MergeSpace interface {
+	Refresh() error
+	Name() string
+	RemoveSpaceOps() []txn.Op
+}
+
+// MergeSpaceState describes state operations required
+// to execute the mergeSpace operation.
+type MergeSpaceState interface {
+	RenameSpaceState
+
+	// MoveEndpointBindingsOps returns the operations required to rebind
+	// all endpoints bound to one space ID to another.
+	MoveEndpointBindingsOps(fromSpaceID, toSpaceID string) ([]txn.Op, error)
+}
+
+type mergeSpaceState struct {
+	renameSpaceState
+}
+
+type spaceMergeModelOp struct {
+	st           MergeSpaceState
+	isController bool
+	settings     Settings
+	space        MergeSpace
+	spaceID      string
+	subnets      []Subnet
+	toID         string
+	toName       string
+}
+
+// NewMergeSpaceOp returns an operation that moves the subnets, constraints,
+// endpoint bindings and controller settings referring to the input space
+// over to the space identified by toID and toName, then removes the space.
+func NewMergeSpaceOp(
+	isController bool, settings Settings, st MergeSpaceState,
+	space MergeSpace, spaceID string, subnets []Subnet, toID, toName string,
+) *spaceMergeModelOp {
+	return &spaceMergeModelOp{
+		st:           st,
+		isController: isController,
+		settings:     settings,
+		space:        space,
+		spaceID:      spaceID,
+		subnets:      subnets,
+		toID:         toID,
+		toName:       toName,
+	}
+}
+
+// Build (state.ModelOperation) creates and returns a slice of transaction
+// operations necessary to merge a space into another.
+func (o *spaceMergeModelOp) Build(attempt int) ([]txn.Op, error) {
+	if attempt > 0 {
+		if err := o.space.Refresh(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	var ops []txn.Op
+	for _, subnet := range o.subnets {
+		ops = append(ops, subnet.UpdateSpaceOps(o.toID)...)
+	}
+
+	constraintsWithSpace, err := o.st.ConstraintsBySpaceName(o.space.Name())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, cons := range constraintsWithSpace {
+		ops = append(ops, cons.ChangeSpaceNameOps(o.space.Name(), o.toName)...)
+	}
+
+	bindingOps, err := o.st.MoveEndpointBindingsOps(o.spaceID, o.toID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, bindingOps...)
+
+	if o.isController {
+		cfg, err := o.st.ControllerConfig()
+		if err != nil {
+			return nil, errors.Annotatef(err, "retrieving settings changes")
+		}
+		if delta := controllerSpaceChanges(cfg, o.space.Name(), o.toName); len(delta) > 0 {
+			settingsOps, err := o.settings.DeltaOps(state.ControllerSettingsGlobalKey, delta)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, settingsOps...)
+		}
+	}
+
+	return append(ops, o.space.RemoveSpaceOps()...), nil
+}
+
+// Done (state.ModelOperation) returns the result of running the operation.
+func (o *spaceMergeModelOp) Done(err error) error {
+	return err
+}
+
+// MergeSpaces isn't on the v6 API.
+func (*APIv6) MergeSpaces(_, _ struct{}) {}
+
+// MergeSpaces merges each of the input spaces into another space. Subnets,
+// endpoint bindings, constraints and controller settings that refer to the
+// merged space are updated in the same transaction that removes it.
+func (api *API) MergeSpaces(args params.MergeSpacesParams) (params.ErrorResults, error) {
+	result := params.ErrorResults{}
+
+	if err := api.ensureSpacesAreMutable(); err != nil {
+		return result, err
+	}
+
+	result.Results = make([]params.ErrorResult, len(args.Args))
+	for i, arg := range args.Args {
+		if err := api.mergeSpace(arg); err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(errors.Trace(err))
+		}
+	}
+	return result, nil
+}
+
+func (api *API) mergeSpace(arg params.MergeSpaceParams) error {
+	fromTag, err := names.ParseSpaceTag(arg.FromSpaceTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	toTag, err := names.ParseSpaceTag(arg.ToSpaceTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	fromName, toName := fromTag.Id(), toTag.Id()
+
+	if fromName == network.AlphaSpaceName {
+		return errors.Errorf("the %q space cannot be merged into another space", network.AlphaSpaceName)
+	}
+	if fromName == toName {
+		return errors.Errorf("cannot merge space %q into itself", fromName)
+	}
+	if _, err := api.backing.SpaceByName(fromName); err != nil {
+		return errors.Annotatef(err, "retrieving space %q", fromName)
+	}
+	if _, err := api.backing.SpaceByName(toName); err != nil {
+		return errors.Annotatef(err, "retrieving space %q", toName)
+	}
+
+	if err := api.ensureSpacesCanBeMerged(fromName, toName, arg.Force); err != nil {
+		return errors.Trace(err)
+	}
+
+	operation, err := api.opFactory.NewMergeSpaceOp(fromName, toName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(api.backing.ApplyOperation(operation))
+}
+
+// ensureSpacesCanBeMerged checks that no entity has space constraints that
+// would contradict each other once the from space is renamed to the to
+// space, such as requiring one of the spaces while excluding the other.
+// If force is true we only log a warning for violations, otherwise an error
+// is returned.
+func (api *API) ensureSpacesCanBeMerged(fromName, toName string, force bool) error {
+	constraints, err := api.backing.ConstraintsBySpaceName(fromName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var conflicting []string
+	for _, cons := range constraints {
+		val := cons.Value()
+		if !val.HasSpaces() {
+			continue
+		}
+		spaces := set.NewStrings(*val.Spaces...)
+		if !(spaces.Contains(fromName) && spaces.Contains("^"+toName)) &&
+			!(spaces.Contains("^"+fromName) && spaces.Contains(toName)) {
+			continue
+		}
+		tag := state.TagFromDocID(cons.ID())
+		if tag == nil {
+			return errors.Errorf("unable to determine an entity to which constraint %q applies", cons.ID())
+		}
+		conflicting = append(conflicting, names.ReadableString(tag))
+	}
+	if len(conflicting) == 0 {
+		return nil
+	}
+
+	sort.Strings(conflicting)
+	msg := "merging space %q into %q would leave contradictory space constraints for: %s"
+	if force {
+		logger.Warningf(msg, fromName, toName, strings.Join(conflicting, ", "))
+		return nil
+	}
+	return errors.Errorf(msg, fromName, toName, strings.Join(conflicting, ", "))
+}
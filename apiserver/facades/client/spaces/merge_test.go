@@ -0,0 +1,201 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package spaces_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/apiserver/facades/client/spaces"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/settings"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type SpaceMergeSuite struct {
+	spaceName string
+
+	state    *spaces.MockMergeSpaceState
+	space    *spaces.MockMergeSpace
+	settings *spaces.MockSettings
+	subnet   *spaces.MockSubnet
+	cons     *spaces.MockConstraints
+}
+
+var _ = gc.Suite(&SpaceMergeSuite{})
+
+func (s *SpaceMergeSuite) TestBuildSuccess(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert,
+		map[string]interface{}{controller.JujuManagementSpace: s.spaceName})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.subnet.EXPECT().UpdateSpaceOps("2").Return([]txn.Op{{}})
+	s.state.EXPECT().ConstraintsBySpaceName(s.spaceName).Return([]spaces.Constraints{s.cons}, nil)
+	s.cons.EXPECT().ChangeSpaceNameOps(s.spaceName, "external").Return([]txn.Op{{}})
+	s.state.EXPECT().MoveEndpointBindingsOps("1", "2").Return([]txn.Op{{}, {}}, nil)
+	s.state.EXPECT().ControllerConfig().Return(cfg, nil)
+	s.settings.EXPECT().DeltaOps(state.ControllerSettingsGlobalKey, settings.ItemChanges{{
+		Type:     1,
+		Key:      controller.JujuManagementSpace,
+		OldValue: s.spaceName,
+		NewValue: "external",
+	}}).Return([]txn.Op{{}}, nil)
+	s.space.EXPECT().RemoveSpaceOps().Return([]txn.Op{{}})
+
+	op := spaces.NewMergeSpaceOp(
+		true, s.settings, s.state, s.space, "1", []spaces.Subnet{s.subnet}, "2", "external")
+	ops, err := op.Build(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ops, gc.HasLen, 6)
+}
+
+func (s *SpaceMergeSuite) TestBuildNotControllerModelSuccess(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.subnet.EXPECT().UpdateSpaceOps("2").Return([]txn.Op{{}})
+	s.state.EXPECT().ConstraintsBySpaceName(s.spaceName).Return(nil, nil)
+	s.state.EXPECT().MoveEndpointBindingsOps("1", "2").Return(nil, nil)
+	s.space.EXPECT().RemoveSpaceOps().Return([]txn.Op{{}})
+
+	op := spaces.NewMergeSpaceOp(
+		false, s.settings, s.state, s.space, "1", []spaces.Subnet{s.subnet}, "2", "external")
+	ops, err := op.Build(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ops, gc.HasLen, 2)
+}
+
+func (s *SpaceMergeSuite) TestBuildBindingsError(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.state.EXPECT().ConstraintsBySpaceName(s.spaceName).Return(nil, nil)
+	s.state.EXPECT().MoveEndpointBindingsOps("1", "2").Return(nil, errors.New("bam"))
+
+	op := spaces.NewMergeSpaceOp(false, s.settings, s.state, s.space, "1", nil, "2", "external")
+	_, err := op.Build(0)
+	c.Assert(err, gc.ErrorMatches, "bam")
+}
+
+func (s *SpaceMergeSuite) setupMocks(c *gc.C) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+
+	s.spaceName = "db"
+	s.space = spaces.NewMockMergeSpace(ctrl)
+	s.space.EXPECT().Name().Return(s.spaceName).AnyTimes()
+
+	s.state = spaces.NewMockMergeSpaceState(ctrl)
+	s.settings = spaces.NewMockSettings(ctrl)
+	s.subnet = spaces.NewMockSubnet(ctrl)
+	s.cons = spaces.NewMockConstraints(ctrl)
+
+	return ctrl
+}
+
+func (s *APISuite) TestMergeSpacesSuccess(c *gc.C) {
+	ctrl, unreg := s.setupMocks(c, true, false)
+	defer ctrl.Finish()
+	defer unreg()
+	from, to := "bla", "blub"
+
+	s.expectDefaultSpace(ctrl, from, nil, nil)
+	s.expectDefaultSpace(ctrl, to, nil, nil)
+	s.Backing.EXPECT().ConstraintsBySpaceName(from).Return(nil, nil)
+	s.OpFactory.EXPECT().NewMergeSpaceOp(from, to).Return(s.renameSpaceOp, nil)
+	s.Backing.EXPECT().ApplyOperation(s.renameSpaceOp).Return(nil)
+
+	res, err := s.API.MergeSpaces(s.getMergeArgs(from, to, false))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results[0].Error, gc.IsNil)
+}
+
+func (s *APISuite) TestMergeAlphaSpaceError(c *gc.C) {
+	ctrl, unreg := s.setupMocks(c, true, false)
+	defer ctrl.Finish()
+	defer unreg()
+
+	res, err := s.API.MergeSpaces(s.getMergeArgs("alpha", "blub", false))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results[0].Error, gc.ErrorMatches, `the "alpha" space cannot be merged into another space`)
+}
+
+func (s *APISuite) TestMergeSpaceIntoItselfError(c *gc.C) {
+	ctrl, unreg := s.setupMocks(c, true, false)
+	defer ctrl.Finish()
+	defer unreg()
+
+	res, err := s.API.MergeSpaces(s.getMergeArgs("blub", "blub", false))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results[0].Error, gc.ErrorMatches, `cannot merge space "blub" into itself`)
+}
+
+func (s *APISuite) TestMergeSpacesTargetNotFound(c *gc.C) {
+	ctrl, unreg := s.setupMocks(c, true, false)
+	defer ctrl.Finish()
+	defer unreg()
+	from, to := "bla", "blub"
+
+	s.expectDefaultSpace(ctrl, from, nil, nil)
+	s.expectDefaultSpace(ctrl, to, errors.NotFoundf("space %q", to), nil)
+
+	res, err := s.API.MergeSpaces(s.getMergeArgs(from, to, false))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results[0].Error, gc.ErrorMatches, `retrieving space "blub": space "blub" not found`)
+}
+
+func (s *APISuite) TestMergeSpacesConstraintsConflict(c *gc.C) {
+	ctrl, unreg := s.setupMocks(c, true, false)
+	defer ctrl.Finish()
+	defer unreg()
+	from, to := "bla", "blub"
+
+	s.expectDefaultSpace(ctrl, from, nil, nil)
+	s.expectDefaultSpace(ctrl, to, nil, nil)
+	s.expectConflictingConstraints(from, to)
+
+	res, err := s.API.MergeSpaces(s.getMergeArgs(from, to, false))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results[0].Error, gc.ErrorMatches,
+		`merging space "bla" into "blub" would leave contradictory space constraints for: application mysql`)
+}
+
+func (s *APISuite) TestMergeSpacesConstraintsConflictForce(c *gc.C) {
+	ctrl, unreg := s.setupMocks(c, true, false)
+	defer ctrl.Finish()
+	defer unreg()
+	from, to := "bla", "blub"
+
+	s.expectDefaultSpace(ctrl, from, nil, nil)
+	s.expectDefaultSpace(ctrl, to, nil, nil)
+	s.expectConflictingConstraints(from, to)
+	s.OpFactory.EXPECT().NewMergeSpaceOp(from, to).Return(s.renameSpaceOp, nil)
+	s.Backing.EXPECT().ApplyOperation(s.renameSpaceOp).Return(nil)
+
+	res, err := s.API.MergeSpaces(s.getMergeArgs(from, to, true))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results[0].Error, gc.IsNil)
+}
+
+func (s *APISuite) expectConflictingConstraints(from, to string) {
+	s.Constraints.EXPECT().Value().Return(constraints.MustParse("spaces=" + from + ",^" + to))
+	s.Constraints.EXPECT().ID().Return("c9741ea1-0c2a-444d-82f5-787583a48557:a#mysql")
+	s.Backing.EXPECT().ConstraintsBySpaceName(from).Return([]spaces.Constraints{s.Constraints}, nil)
+}
+
+func (s *APISuite) getMergeArgs(from, to string, force bool) params.MergeSpacesParams {
+	return params.MergeSpacesParams{
+		Args: []params.MergeSpaceParams{{
+			FromSpaceTag: names.NewSpaceTag(from).String(),
+			ToSpaceTag:   names.NewSpaceTag(to).String(),
+			Force:        force,
+		}},
+	}
+}
@@ -21,6 +21,9 @@ type OpFactory interface {
 
 	// NewMoveSubnetsOp returns an operation for updating a space with new CIDRs.
 	NewMoveSubnetsOp(spaceID string, subnets []MovingSubnet) (MoveSubnetsOp, error)
+
+	// NewMergeSpaceOp returns an operation for merging a space into another.
+	NewMergeSpaceOp(fromName, toName string) (state.ModelOperation, error)
 }
 
 type opFactory struct {
@@ -69,3 +72,27 @@ func (f *opFactory) NewMoveSubnetsOp(spaceName string, subnets []MovingSubnet) (
 	}
 	return NewMoveSubnetsOp(networkingcommon.NewSpaceShim(space), subnets), nil
 }
+
+// NewMergeSpaceOp (OpFactory) returns an operation
+// for merging a space into another.
+func (f *opFactory) NewMergeSpaceOp(fromName, toName string) (state.ModelOperation, error) {
+	space, err := f.st.SpaceByName(fromName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	toSpace, err := f.st.SpaceByName(toName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	subnets, err := space.Subnets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	n := make([]Subnet, len(subnets))
+	for i, subnet := range subnets {
+		n[i] = subnet
+	}
+	return NewMergeSpaceOp(
+		f.st.IsController(), f.st.NewControllerSettings(), &mergeSpaceState{renameSpaceState{f.st}},
+		space, space.Id(), n, toSpace.Id(), toSpace.Name()), nil
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/juju/juju/apiserver/facades/client/spaces (interfaces: Backing,BlockChecker,Machine,RenameSpace,RenameSpaceState,Settings,OpFactory,RemoveSpace,Subnet,Constraints,MovingSubnet,MoveSubnetsOp,Address,Unit,ReloadSpaces,ReloadSpacesState,ReloadSpacesEnviron,EnvironSpaces,AuthorizerState,Bindings)
+// Source: github.com/juju/juju/apiserver/facades/client/spaces (interfaces: Backing,BlockChecker,Machine,RenameSpace,RenameSpaceState,Settings,OpFactory,RemoveSpace,Subnet,Constraints,MovingSubnet,MoveSubnetsOp,Address,Unit,ReloadSpaces,ReloadSpacesState,ReloadSpacesEnviron,EnvironSpaces,AuthorizerState,Bindings,MergeSpace,MergeSpaceState)
 
 // Package spaces is a generated GoMock package.
 package spaces
@@ -195,6 +195,21 @@ func (mr *MockBackingMockRecorder) ControllerConfig() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ControllerConfig", reflect.TypeOf((*MockBacking)(nil).ControllerConfig))
 }
 
+// DefaultSpaceName mocks base method
+func (m *MockBacking) DefaultSpaceName() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DefaultSpaceName")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DefaultSpaceName indicates an expected call of DefaultSpaceName
+func (mr *MockBackingMockRecorder) DefaultSpaceName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DefaultSpaceName", reflect.TypeOf((*MockBacking)(nil).DefaultSpaceName))
+}
+
 // IsController mocks base method
 func (m *MockBacking) IsController() bool {
 	m.ctrl.T.Helper()
@@ -595,6 +610,21 @@ func (m *MockOpFactory) EXPECT() *MockOpFactoryMockRecorder {
 	return m.recorder
 }
 
+// NewMergeSpaceOp mocks base method
+func (m *MockOpFactory) NewMergeSpaceOp(arg0, arg1 string) (state.ModelOperation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewMergeSpaceOp", arg0, arg1)
+	ret0, _ := ret[0].(state.ModelOperation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewMergeSpaceOp indicates an expected call of NewMergeSpaceOp
+func (mr *MockOpFactoryMockRecorder) NewMergeSpaceOp(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewMergeSpaceOp", reflect.TypeOf((*MockOpFactory)(nil).NewMergeSpaceOp), arg0, arg1)
+}
+
 // NewMoveSubnetsOp mocks base method
 func (m *MockOpFactory) NewMoveSubnetsOp(arg0 string, arg1 []MovingSubnet) (MoveSubnetsOp, error) {
 	m.ctrl.T.Helper()
@@ -1423,3 +1453,136 @@ func (mr *MockBindingsMockRecorder) Map() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Map", reflect.TypeOf((*MockBindings)(nil).Map))
 }
+
+// MockMergeSpace is a mock of MergeSpace interface
+type MockMergeSpace struct {
+	ctrl     *gomock.Controller
+	recorder *MockMergeSpaceMockRecorder
+}
+
+// MockMergeSpaceMockRecorder is the mock recorder for MockMergeSpace
+type MockMergeSpaceMockRecorder struct {
+	mock *MockMergeSpace
+}
+
+// NewMockMergeSpace creates a new mock instance
+func NewMockMergeSpace(ctrl *gomock.Controller) *MockMergeSpace {
+	mock := &MockMergeSpace{ctrl: ctrl}
+	mock.recorder = &MockMergeSpaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockMergeSpace) EXPECT() *MockMergeSpaceMockRecorder {
+	return m.recorder
+}
+
+// Name mocks base method
+func (m *MockMergeSpace) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name
+func (mr *MockMergeSpaceMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockMergeSpace)(nil).Name))
+}
+
+// Refresh mocks base method
+func (m *MockMergeSpace) Refresh() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refresh indicates an expected call of Refresh
+func (mr *MockMergeSpaceMockRecorder) Refresh() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockMergeSpace)(nil).Refresh))
+}
+
+// RemoveSpaceOps mocks base method
+func (m *MockMergeSpace) RemoveSpaceOps() []txn.Op {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSpaceOps")
+	ret0, _ := ret[0].([]txn.Op)
+	return ret0
+}
+
+// RemoveSpaceOps indicates an expected call of RemoveSpaceOps
+func (mr *MockMergeSpaceMockRecorder) RemoveSpaceOps() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSpaceOps", reflect.TypeOf((*MockMergeSpace)(nil).RemoveSpaceOps))
+}
+
+// MockMergeSpaceState is a mock of MergeSpaceState interface
+type MockMergeSpaceState struct {
+	ctrl     *gomock.Controller
+	recorder *MockMergeSpaceStateMockRecorder
+}
+
+// MockMergeSpaceStateMockRecorder is the mock recorder for MockMergeSpaceState
+type MockMergeSpaceStateMockRecorder struct {
+	mock *MockMergeSpaceState
+}
+
+// NewMockMergeSpaceState creates a new mock instance
+func NewMockMergeSpaceState(ctrl *gomock.Controller) *MockMergeSpaceState {
+	mock := &MockMergeSpaceState{ctrl: ctrl}
+	mock.recorder = &MockMergeSpaceStateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockMergeSpaceState) EXPECT() *MockMergeSpaceStateMockRecorder {
+	return m.recorder
+}
+
+// ConstraintsBySpaceName mocks base method
+func (m *MockMergeSpaceState) ConstraintsBySpaceName(arg0 string) ([]Constraints, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConstraintsBySpaceName", arg0)
+	ret0, _ := ret[0].([]Constraints)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConstraintsBySpaceName indicates an expected call of ConstraintsBySpaceName
+func (mr *MockMergeSpaceStateMockRecorder) ConstraintsBySpaceName(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConstraintsBySpaceName", reflect.TypeOf((*MockMergeSpaceState)(nil).ConstraintsBySpaceName), arg0)
+}
+
+// ControllerConfig mocks base method
+func (m *MockMergeSpaceState) ControllerConfig() (controller.Config, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ControllerConfig")
+	ret0, _ := ret[0].(controller.Config)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ControllerConfig indicates an expected call of ControllerConfig
+func (mr *MockMergeSpaceStateMockRecorder) ControllerConfig() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ControllerConfig", reflect.TypeOf((*MockMergeSpaceState)(nil).ControllerConfig))
+}
+
+// MoveEndpointBindingsOps mocks base method
+func (m *MockMergeSpaceState) MoveEndpointBindingsOps(arg0, arg1 string) ([]txn.Op, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveEndpointBindingsOps", arg0, arg1)
+	ret0, _ := ret[0].([]txn.Op)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveEndpointBindingsOps indicates an expected call of MoveEndpointBindingsOps
+func (mr *MockMergeSpaceStateMockRecorder) MoveEndpointBindingsOps(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveEndpointBindingsOps", reflect.TypeOf((*MockMergeSpaceState)(nil).MoveEndpointBindingsOps), arg0, arg1)
+}
@@ -120,18 +120,23 @@ func (o *spaceRenameModelOp) getSettingsChanges(fromSpaceName, toName string) (s
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	return controllerSpaceChanges(currentControllerConfig, fromSpaceName, toName), nil
+}
 
+// controllerSpaceChanges returns the controller config changes needed for
+// the management and HA spaces to follow a space from one name to another.
+func controllerSpaceChanges(cfg controller.Config, fromSpaceName, toName string) settings.ItemChanges {
 	var deltas settings.ItemChanges
 
-	if mgmtSpace := currentControllerConfig.JujuManagementSpace(); mgmtSpace == fromSpaceName {
+	if mgmtSpace := cfg.JujuManagementSpace(); mgmtSpace == fromSpaceName {
 		change := settings.MakeModification(controller.JujuManagementSpace, fromSpaceName, toName)
 		deltas = append(deltas, change)
 	}
-	if haSpace := currentControllerConfig.JujuHASpace(); haSpace == fromSpaceName {
+	if haSpace := cfg.JujuHASpace(); haSpace == fromSpaceName {
 		change := settings.MakeModification(controller.JujuHASpace, fromSpaceName, toName)
 		deltas = append(deltas, change)
 	}
-	return deltas, nil
+	return deltas
 }
 
 // RenameSpace renames a space.
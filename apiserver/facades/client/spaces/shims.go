@@ -175,3 +175,12 @@ func (s *stateShim) ConstraintsBySpaceName(spaceName string) ([]Constraints, err
 	}
 	return cons, nil
 }
+
+// DefaultSpaceName returns the "default-space" model config value.
+func (s *stateShim) DefaultSpaceName() (string, error) {
+	cfg, err := s.model.ModelConfig()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return cfg.DefaultSpace(), nil
+}
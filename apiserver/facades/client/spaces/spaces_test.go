@@ -57,7 +57,7 @@ func (s *APISuite) TestShowSpaceDefault(c *gc.C) {
 
 	expected := params.ShowSpaceResults{Results: []params.ShowSpaceResult{
 		{
-			Space: params.Space{Id: "1", Name: "default", Subnets: []params.Subnet{{
+			Space: params.Space{Id: "1", UUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d", Name: "default", Subnets: []params.Subnet{{
 				CIDR:              "192.168.0.0/24",
 				ProviderId:        "0",
 				ProviderNetworkId: "1",
@@ -534,6 +534,7 @@ func (s *APISuite) expectDefaultSpace(ctrl *gomock.Controller, name string, spac
 	spacesMock := netmocks.NewMockBackingSpace(ctrl)
 	spacesMock.EXPECT().Id().Return("1").AnyTimes()
 	spacesMock.EXPECT().Name().Return(name).AnyTimes()
+	spacesMock.EXPECT().UUID().Return("deadbeef-0bad-400d-8000-4b1d0d06f00d").AnyTimes()
 	spacesMock.EXPECT().Subnets().Return([]networkingcommon.BackingSubnet{subnetMock}, subnetErr).AnyTimes()
 	if spacesErr != nil {
 		s.Backing.EXPECT().SpaceByName(name).Return(nil, spacesErr)
@@ -601,6 +602,10 @@ func (sb *stubBacking) ControllerConfig() (controller.Config, error) {
 	panic("should not be called")
 }
 
+func (sb *stubBacking) DefaultSpaceName() (string, error) {
+	panic("should not be called")
+}
+
 func (sb *stubBacking) SpaceByName(_ string) (networkingcommon.BackingSpace, error) {
 	panic("should not be called")
 }
@@ -1052,7 +1057,7 @@ func (s *LegacySuite) TestCreateSpacesBlocked(c *gc.C) {
 }
 
 func (s *LegacySuite) TestCreateSpacesAPIv4(c *gc.C) {
-	apiV4 := &spaces.APIv4{APIv5: &spaces.APIv5{API: s.facade}}
+	apiV4 := &spaces.APIv4{APIv5: &spaces.APIv5{APIv6: &spaces.APIv6{APIv7: &spaces.APIv7{API: s.facade}}}}
 	results, err := apiV4.CreateSpaces(params.CreateSpacesParamsV4{
 		Spaces: []params.CreateSpaceParamsV4{
 			{
@@ -1067,7 +1072,7 @@ func (s *LegacySuite) TestCreateSpacesAPIv4(c *gc.C) {
 }
 
 func (s *LegacySuite) TestCreateSpacesAPIv4FailCIDR(c *gc.C) {
-	apiV4 := &spaces.APIv4{APIv5: &spaces.APIv5{API: s.facade}}
+	apiV4 := &spaces.APIv4{APIv5: &spaces.APIv5{APIv6: &spaces.APIv6{APIv7: &spaces.APIv7{API: s.facade}}}}
 	results, err := apiV4.CreateSpaces(params.CreateSpacesParamsV4{
 		Spaces: []params.CreateSpaceParamsV4{
 			{
@@ -1082,7 +1087,7 @@ func (s *LegacySuite) TestCreateSpacesAPIv4FailCIDR(c *gc.C) {
 }
 
 func (s *LegacySuite) TestCreateSpacesAPIv4FailTag(c *gc.C) {
-	apiV4 := &spaces.APIv4{APIv5: &spaces.APIv5{API: s.facade}}
+	apiV4 := &spaces.APIv4{APIv5: &spaces.APIv5{APIv6: &spaces.APIv6{APIv7: &spaces.APIv7{API: s.facade}}}}
 	results, err := apiV4.CreateSpaces(params.CreateSpacesParamsV4{
 		Spaces: []params.CreateSpaceParamsV4{
 			{
@@ -75,7 +75,9 @@ func (s *baseStorageSuite) SetUpTest(c *gc.C) {
 	s.apiv3 = &storage.StorageAPIv3{
 		StorageAPIv4: storage.StorageAPIv4{
 			StorageAPIv5: storage.StorageAPIv5{
-				StorageAPI: *newAPI,
+				StorageAPIv6: storage.StorageAPIv6{
+					StorageAPI: *newAPI,
+				},
 			},
 		},
 	}
@@ -323,6 +323,11 @@ func (m *mockStorageInstance) StorageTag() names.StorageTag {
 	return m.storageTag.(names.StorageTag)
 }
 
+func (m *mockStorageInstance) StorageName() string {
+	name, _ := names.StorageName(m.storageTag.Id())
+	return name
+}
+
 func (m *mockStorageInstance) CharmURL() *charm.URL {
 	panic("not implemented for test")
 }
@@ -0,0 +1,243 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider/dummy"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type snapshotSuite struct {
+	baseStorageSuite
+	volumeSource volumeSnapshotter
+	created      time.Time
+}
+
+var _ = gc.Suite(&snapshotSuite{})
+
+func (s *snapshotSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	s.state.modelTag = coretesting.ModelTag
+	s.created = time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	s.volume.info = &state.VolumeInfo{
+		VolumeId: "vol-0",
+		Pool:     "radiance",
+		Size:     1024,
+	}
+	s.volumeSource = volumeSnapshotter{
+		VolumeSource: &dummy.VolumeSource{},
+		snapshots: []storage.VolumeSnapshot{{
+			SnapshotId: "snap-0",
+			VolumeId:   "vol-0",
+			Size:       1024,
+			Status:     "completed",
+			Created:    s.created,
+		}},
+	}
+	s.registry.Providers["radiance"] = &dummy.StorageProvider{
+		StorageScope: storage.ScopeEnviron,
+		IsDynamic:    true,
+		SupportsFunc: func(kind storage.StorageKind) bool {
+			return kind == storage.StorageKindBlock
+		},
+		VolumeSourceFunc: func(*storage.Config) (storage.VolumeSource, error) {
+			return s.volumeSource, nil
+		},
+	}
+}
+
+func (s *snapshotSuite) TestCreateSnapshots(c *gc.C) {
+	results, err := s.api.CreateSnapshots(params.CreateStorageSnapshotsArgs{
+		Snapshots: []params.CreateStorageSnapshotArg{{
+			StorageTag:  s.storageTag.String(),
+			Description: "before upgrade",
+		}, {
+			StorageTag: "volume-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.StorageSnapshotResult{{
+		Result: &params.StorageSnapshot{
+			StorageTag:  "storage-data-0",
+			SnapshotId:  "snap-1",
+			VolumeId:    "vol-0",
+			Size:        1024,
+			Description: "before upgrade",
+			Status:      "pending",
+		},
+	}, {
+		Error: &params.Error{Message: `"volume-0" is not a valid storage tag`},
+	}})
+	s.volumeSource.CheckCalls(c, []testing.StubCall{
+		{"CreateVolumeSnapshots", []interface{}{
+			s.callContext,
+			[]storage.VolumeSnapshotParams{{
+				VolumeId:    "vol-0",
+				Description: "before upgrade",
+				ResourceTags: map[string]string{
+					"juju-model-uuid":       "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+					"juju-controller-uuid":  "deadbeef-1bad-500d-9000-4b1d0d06f00d",
+					"juju-storage-instance": "data/0",
+					"juju-storage-owner":    "mysql/0",
+				},
+			}},
+		}},
+	})
+}
+
+func (s *snapshotSuite) TestCreateSnapshotsNotSupported(c *gc.C) {
+	s.registry.Providers["radiance"].(*dummy.StorageProvider).VolumeSourceFunc = func(*storage.Config) (storage.VolumeSource, error) {
+		return &dummy.VolumeSource{}, nil
+	}
+	results, err := s.api.CreateSnapshots(params.CreateStorageSnapshotsArgs{
+		Snapshots: []params.CreateStorageSnapshotArg{{StorageTag: s.storageTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeNotSupported)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `snapshots with storage provider "radiance" not supported`)
+}
+
+func (s *snapshotSuite) TestCreateSnapshotsBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestCreateSnapshotsBlocked")
+	_, err := s.api.CreateSnapshots(params.CreateStorageSnapshotsArgs{
+		Snapshots: []params.CreateStorageSnapshotArg{{StorageTag: s.storageTag.String()}},
+	})
+	s.assertBlocked(c, err, "TestCreateSnapshotsBlocked")
+}
+
+func (s *snapshotSuite) TestListSnapshots(c *gc.C) {
+	results, err := s.api.ListSnapshots(params.Entities{
+		Entities: []params.Entity{{Tag: s.storageTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.StorageSnapshotsResult{{
+		Result: []params.StorageSnapshot{{
+			StorageTag: "storage-data-0",
+			SnapshotId: "snap-0",
+			VolumeId:   "vol-0",
+			Size:       1024,
+			Status:     "completed",
+			Created:    &s.created,
+		}},
+	}})
+	s.volumeSource.CheckCalls(c, []testing.StubCall{
+		{"VolumeSnapshots", []interface{}{s.callContext, "vol-0"}},
+	})
+}
+
+func (s *snapshotSuite) TestRestoreSnapshots(c *gc.C) {
+	results, err := s.api.RestoreSnapshots(params.RestoreStorageSnapshotsArgs{
+		Snapshots: []params.RestoreStorageSnapshotArg{{
+			StorageTag: s.storageTag.String(),
+			SnapshotId: "snap-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ImportStorageResult{{
+		Result: &params.ImportStorageDetails{
+			StorageTag: "storage-data-0",
+		},
+	}})
+	s.volumeSource.CheckCalls(c, []testing.StubCall{
+		{"VolumeSnapshots", []interface{}{s.callContext, "vol-0"}},
+		{"CreateVolumeFromSnapshot", []interface{}{
+			s.callContext,
+			storage.VolumeFromSnapshotParams{
+				SnapshotId: "snap-0",
+				ResourceTags: map[string]string{
+					"juju-model-uuid":      "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+					"juju-controller-uuid": "deadbeef-1bad-500d-9000-4b1d0d06f00d",
+				},
+			},
+		}},
+	})
+	s.stub.CheckCall(c, len(s.stub.Calls())-1, addExistingFilesystemCall,
+		state.FilesystemInfo{
+			Pool: "radiance",
+			Size: 1024,
+		},
+		&state.VolumeInfo{
+			VolumeId:   "vol-1",
+			Pool:       "radiance",
+			Size:       1024,
+			Persistent: true,
+		},
+		"data",
+	)
+}
+
+func (s *snapshotSuite) TestRestoreSnapshotsUnknownSnapshot(c *gc.C) {
+	results, err := s.api.RestoreSnapshots(params.RestoreStorageSnapshotsArgs{
+		Snapshots: []params.RestoreStorageSnapshotArg{{
+			StorageTag: s.storageTag.String(),
+			SnapshotId: "snap-42",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `snapshot "snap-42" of storage data/0 not found`)
+	s.volumeSource.CheckCallNames(c, "VolumeSnapshots")
+}
+
+func (s *snapshotSuite) TestRestoreSnapshotsBlockStorage(c *gc.C) {
+	s.storageInstance.kind = state.StorageKindBlock
+	results, err := s.api.RestoreSnapshots(params.RestoreStorageSnapshotsArgs{
+		Snapshots: []params.RestoreStorageSnapshotArg{{
+			StorageTag: s.storageTag.String(),
+			SnapshotId: "snap-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "restoring snapshots of block storage not supported")
+	s.volumeSource.CheckNoCalls(c)
+}
+
+type volumeSnapshotter struct {
+	*dummy.VolumeSource
+	snapshots []storage.VolumeSnapshot
+}
+
+// CreateVolumeSnapshots is part of the storage.VolumeSnapshotter interface.
+func (v volumeSnapshotter) CreateVolumeSnapshots(ctx context.ProviderCallContext, args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	v.MethodCall(v, "CreateVolumeSnapshots", ctx, args)
+	results := make([]storage.CreateVolumeSnapshotsResult, len(args))
+	for i, arg := range args {
+		results[i].Snapshot = &storage.VolumeSnapshot{
+			SnapshotId:  "snap-1",
+			VolumeId:    arg.VolumeId,
+			Size:        1024,
+			Description: arg.Description,
+			Status:      "pending",
+		}
+	}
+	return results, v.NextErr()
+}
+
+// VolumeSnapshots is part of the storage.VolumeSnapshotter interface.
+func (v volumeSnapshotter) VolumeSnapshots(ctx context.ProviderCallContext, volumeId string) ([]storage.VolumeSnapshot, error) {
+	v.MethodCall(v, "VolumeSnapshots", ctx, volumeId)
+	return v.snapshots, v.NextErr()
+}
+
+// CreateVolumeFromSnapshot is part of the storage.VolumeSnapshotter interface.
+func (v volumeSnapshotter) CreateVolumeFromSnapshot(ctx context.ProviderCallContext, args storage.VolumeFromSnapshotParams) (storage.VolumeInfo, error) {
+	v.MethodCall(v, "CreateVolumeFromSnapshot", ctx, args)
+	return storage.VolumeInfo{
+		VolumeId:   "vol-1",
+		Size:       1024,
+		Persistent: true,
+	}, v.NextErr()
+}
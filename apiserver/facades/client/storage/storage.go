@@ -31,7 +31,7 @@ import (
 	"github.com/juju/juju/storage/poolmanager"
 )
 
-// StorageAPI implements the latest version (v6) of the Storage API.
+// StorageAPI implements the latest version (v7) of the Storage API.
 type StorageAPI struct {
 	backend       backend
 	storageAccess storageAccess
@@ -42,9 +42,14 @@ type StorageAPI struct {
 	modelType     state.ModelType
 }
 
+// StorageAPIv6 implements the storage v6 API.
+type StorageAPIv6 struct {
+	StorageAPI
+}
+
 // APIv5 implements the storage v5 API.
 type StorageAPIv5 struct {
-	StorageAPI
+	StorageAPIv6
 }
 
 // APIv4 implements the storage v4 API adding AddToUnit, Import and Remove (replacing Destroy)
@@ -105,14 +110,25 @@ func newStorageAPI(
 	}
 }
 
+// NewStorageAPIV6 returns a new storage v6 API facade.
+func NewStorageAPIV6(context facade.Context) (*StorageAPIv6, error) {
+	storageAPI, err := NewStorageAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageAPIv6{
+		StorageAPI: *storageAPI,
+	}, nil
+}
+
 // NewStorageAPIV5 returns a new storage v5 API facade.
 func NewStorageAPIV5(context facade.Context) (*StorageAPIv5, error) {
-	storageAPI, err := NewStorageAPI(context)
+	storageAPI, err := NewStorageAPIV6(context)
 	if err != nil {
 		return nil, err
 	}
 	return &StorageAPIv5{
-		StorageAPI: *storageAPI,
+		StorageAPIv6: *storageAPI,
 	}, nil
 }
 
@@ -1210,10 +1226,206 @@ func (a *StorageAPI) UpdatePool(p params.StoragePoolArgs) (params.ErrorResults,
 	return results, nil
 }
 
+// CreateSnapshots takes provider snapshots of the volumes backing the
+// specified storage instances.
+// A "CHANGE" block can block this operation.
+func (a *StorageAPI) CreateSnapshots(args params.CreateStorageSnapshotsArgs) (params.StorageSnapshotResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.StorageSnapshotResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.StorageSnapshotResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.StorageSnapshotResult, len(args.Snapshots))
+	for i, arg := range args.Snapshots {
+		snapshot, err := a.createSnapshot(arg)
+		if err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results[i].Result = &snapshot
+	}
+	return params.StorageSnapshotResults{Results: results}, nil
+}
+
+func (a *StorageAPI) createSnapshot(arg params.CreateStorageSnapshotArg) (params.StorageSnapshot, error) {
+	storageTag, err := names.ParseStorageTag(arg.StorageTag)
+	if err != nil {
+		return params.StorageSnapshot{}, errors.Trace(err)
+	}
+	storageInstance, err := a.storageAccess.StorageInstance(storageTag)
+	if err != nil {
+		return params.StorageSnapshot{}, errors.Trace(err)
+	}
+	resourceTags := map[string]string{
+		tags.JujuModel:           a.backend.ModelTag().Id(),
+		tags.JujuController:      a.backend.ControllerTag().Id(),
+		tags.JujuStorageInstance: storageTag.Id(),
+	}
+	if owner, ok := storageInstance.Owner(); ok {
+		resourceTags[tags.JujuStorageOwner] = owner.Id()
+	}
+	return storagecommon.CreateStorageSnapshot(
+		a.callContext,
+		a.storageAccess.VolumeAccess(),
+		storageTag,
+		arg.Description,
+		resourceTags,
+		a.poolManager,
+		a.registry,
+	)
+}
+
+// ListSnapshots returns the provider snapshots of the volumes backing
+// the specified storage instances.
+func (a *StorageAPI) ListSnapshots(args params.Entities) (params.StorageSnapshotsResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.StorageSnapshotsResults{}, errors.Trace(err)
+	}
+	results := make([]params.StorageSnapshotsResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		snapshots, err := a.listSnapshots(entity.Tag)
+		if err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results[i].Result = snapshots
+	}
+	return params.StorageSnapshotsResults{Results: results}, nil
+}
+
+func (a *StorageAPI) listSnapshots(tag string) ([]params.StorageSnapshot, error) {
+	storageTag, err := names.ParseStorageTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshotter, info, err := storagecommon.StorageVolumeSnapshotter(
+		a.storageAccess.VolumeAccess(), storageTag, a.poolManager, a.registry,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshots, err := snapshotter.VolumeSnapshots(a.callContext, info.VolumeId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]params.StorageSnapshot, len(snapshots))
+	for i, snap := range snapshots {
+		results[i] = storagecommon.VolumeSnapshotFromStorage(storageTag, snap)
+	}
+	return results, nil
+}
+
+// RestoreSnapshots creates new volumes from snapshots of the specified
+// storage instances, and imports them into the model as detached
+// storage with the same storage name as the original.
+// A "CHANGE" block can block this operation.
+func (a *StorageAPI) RestoreSnapshots(args params.RestoreStorageSnapshotsArgs) (params.ImportStorageResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ImportStorageResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ImportStorageResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.ImportStorageResult, len(args.Snapshots))
+	for i, arg := range args.Snapshots {
+		details, err := a.restoreSnapshot(arg)
+		if err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results[i].Result = details
+	}
+	return params.ImportStorageResults{Results: results}, nil
+}
+
+func (a *StorageAPI) restoreSnapshot(arg params.RestoreStorageSnapshotArg) (*params.ImportStorageDetails, error) {
+	storageTag, err := names.ParseStorageTag(arg.StorageTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	storageInstance, err := a.storageAccess.StorageInstance(storageTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if storageInstance.Kind() != state.StorageKindFilesystem {
+		// Volumes cannot yet be imported into the model, so only
+		// filesystem storage may be restored.
+		return nil, errors.NotSupportedf("restoring snapshots of block storage")
+	}
+	snapshotter, info, err := storagecommon.StorageVolumeSnapshotter(
+		a.storageAccess.VolumeAccess(), storageTag, a.poolManager, a.registry,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Only snapshots of the storage instance's own volume may be restored.
+	snapshots, err := snapshotter.VolumeSnapshots(a.callContext, info.VolumeId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var found bool
+	for _, snap := range snapshots {
+		if snap.SnapshotId == arg.SnapshotId {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, errors.NotFoundf("snapshot %q of %s", arg.SnapshotId, names.ReadableString(storageTag))
+	}
+
+	resourceTags := map[string]string{
+		tags.JujuModel:      a.backend.ModelTag().Id(),
+		tags.JujuController: a.backend.ControllerTag().Id(),
+	}
+	volumeInfo, err := snapshotter.CreateVolumeFromSnapshot(a.callContext, storage.VolumeFromSnapshotParams{
+		SnapshotId:       arg.SnapshotId,
+		AvailabilityZone: arg.AvailabilityZone,
+		ResourceTags:     resourceTags,
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "creating volume from snapshot")
+	}
+	newStorageTag, err := a.storageAccess.FilesystemAccess().AddExistingFilesystem(
+		state.FilesystemInfo{
+			Pool: info.Pool,
+			Size: volumeInfo.Size,
+		},
+		&state.VolumeInfo{
+			HardwareId: volumeInfo.HardwareId,
+			WWN:        volumeInfo.WWN,
+			Size:       volumeInfo.Size,
+			Pool:       info.Pool,
+			VolumeId:   volumeInfo.VolumeId,
+			Persistent: volumeInfo.Persistent,
+		},
+		storageInstance.StorageName(),
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &params.ImportStorageDetails{
+		StorageTag: newStorageTag.String(),
+	}, nil
+}
+
 // Mask out old methods from the new API versions. The API reflection
 // code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
 // so this removes the method as far as the RPC machinery is concerned.
 
+// Added in v7 api version
+func (*StorageAPIv6) CreateSnapshots(_, _ struct{})  {}
+func (*StorageAPIv6) ListSnapshots(_, _ struct{})    {}
+func (*StorageAPIv6) RestoreSnapshots(_, _ struct{}) {}
+
 // Added in v6 api version
 func (*StorageAPIv5) DetachStorage(_, _ struct{}) {}
 
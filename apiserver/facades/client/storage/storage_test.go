@@ -371,7 +371,9 @@ func (s *storageSuite) TestDestroyV3(c *gc.C) {
 
 func (s *storageSuite) TestDetachV5(c *gc.C) {
 	apiv5 := &facadestorage.StorageAPIv5{
-		StorageAPI: *s.api,
+		StorageAPIv6: facadestorage.StorageAPIv6{
+			StorageAPI: *s.api,
+		},
 	}
 	results, err := apiv5.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-data-0", UnitTag: "unit-mysql-0"},
@@ -442,7 +444,9 @@ func (s *storageSuite) TestDetachV6(c *gc.C) {
 
 func (s *storageSuite) TestDetachSpecifiedNotFound(c *gc.C) {
 	apiv5 := &facadestorage.StorageAPIv5{
-		StorageAPI: *s.api,
+		StorageAPIv6: facadestorage.StorageAPIv6{
+			StorageAPI: *s.api,
+		},
 	}
 	results, err := apiv5.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-data-0", UnitTag: "unit-foo-42"},
@@ -512,7 +516,9 @@ func (s *storageSuite) TestDetachAttachmentNotFoundConcurrentv5(c *gc.C) {
 		)
 	}
 	apiv5 := &facadestorage.StorageAPIv5{
-		StorageAPI: *s.api,
+		StorageAPIv6: facadestorage.StorageAPIv6{
+			StorageAPI: *s.api,
+		},
 	}
 	results, err := apiv5.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-data-0"},
@@ -568,7 +574,9 @@ func (s *storageSuite) TestDetachAttachmentNotFoundConcurrentV6(c *gc.C) {
 
 func (s *storageSuite) TestDetachNoAttachmentsStorageNotFoundv5(c *gc.C) {
 	apiv5 := &facadestorage.StorageAPIv5{
-		StorageAPI: *s.api,
+		StorageAPIv6: facadestorage.StorageAPIv6{
+			StorageAPI: *s.api,
+		},
 	}
 	results, err := apiv5.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-foo-42"},
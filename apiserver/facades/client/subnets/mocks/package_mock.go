@@ -82,6 +82,21 @@ func (mr *MockBackingMockRecorder) AllSubnets() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllSubnets", reflect.TypeOf((*MockBacking)(nil).AllSubnets))
 }
 
+// ApplicationsBySpaceID mocks base method
+func (m *MockBacking) ApplicationsBySpaceID() (map[string][]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationsBySpaceID")
+	ret0, _ := ret[0].(map[string][]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplicationsBySpaceID indicates an expected call of ApplicationsBySpaceID
+func (mr *MockBackingMockRecorder) ApplicationsBySpaceID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationsBySpaceID", reflect.TypeOf((*MockBacking)(nil).ApplicationsBySpaceID))
+}
+
 // AvailabilityZones mocks base method
 func (m *MockBacking) AvailabilityZones() (network.AvailabilityZones, error) {
 	m.ctrl.T.Helper()
@@ -112,6 +127,21 @@ func (mr *MockBackingMockRecorder) CloudSpec() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudSpec", reflect.TypeOf((*MockBacking)(nil).CloudSpec))
 }
 
+// MachinesBySubnetCIDR mocks base method
+func (m *MockBacking) MachinesBySubnetCIDR() (map[string][]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MachinesBySubnetCIDR")
+	ret0, _ := ret[0].(map[string][]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MachinesBySubnetCIDR indicates an expected call of MachinesBySubnetCIDR
+func (mr *MockBackingMockRecorder) MachinesBySubnetCIDR() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MachinesBySubnetCIDR", reflect.TypeOf((*MockBacking)(nil).MachinesBySubnetCIDR))
+}
+
 // ModelConfig mocks base method
 func (m *MockBacking) ModelConfig() (*config.Config, error) {
 	m.ctrl.T.Helper()
@@ -141,6 +171,20 @@ func (mr *MockBackingMockRecorder) ModelTag() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModelTag", reflect.TypeOf((*MockBacking)(nil).ModelTag))
 }
 
+// SaveProviderSubnets mocks base method
+func (m *MockBacking) SaveProviderSubnets(arg0 []network.SubnetInfo, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveProviderSubnets", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveProviderSubnets indicates an expected call of SaveProviderSubnets
+func (mr *MockBackingMockRecorder) SaveProviderSubnets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveProviderSubnets", reflect.TypeOf((*MockBacking)(nil).SaveProviderSubnets), arg0, arg1)
+}
+
 // SetAvailabilityZones mocks base method
 func (m *MockBacking) SetAvailabilityZones(arg0 network.AvailabilityZones) error {
 	m.ctrl.T.Helper()
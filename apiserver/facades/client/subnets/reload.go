@@ -0,0 +1,153 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package subnets
+
+import (
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/common/networkingcommon"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/network"
+)
+
+// ReloadSubnets isn't on the v4 API.
+func (*APIv4) ReloadSubnets(_, _ struct{}) {}
+
+// ReloadSubnets re-queries the provider for its subnets and availability
+// zones, adding new subnets to the model and updating those already known.
+// Subnets known to the model but no longer reported by the provider are not
+// removed; they are returned along with the machines and endpoint bindings
+// that still refer to them.
+func (api *API) ReloadSubnets() (params.ReloadSubnetsResult, error) {
+	var result params.ReloadSubnetsResult
+	if err := api.checkCanWrite(); err != nil {
+		return result, err
+	}
+
+	netEnv, err := networkingEnviron(api.backing)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if _, err := updateZones(api.context, api.backing); err != nil && !errors.IsNotSupported(err) {
+		return result, errors.Annotate(err, "cannot update known zones")
+	}
+
+	providerSubnets, err := netEnv.Subnets(api.context, instance.UnknownId, nil)
+	if err != nil {
+		return result, errors.Annotate(err, "cannot get provider subnets")
+	}
+	if err := api.saveProviderSubnets(providerSubnets); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	result.Missing, err = api.missingSubnets(providerSubnets)
+	return result, errors.Trace(err)
+}
+
+// saveProviderSubnets saves the input subnets, adding each new subnet to
+// the space with a matching provider ID, or the default space otherwise.
+func (api *API) saveProviderSubnets(subnets []network.SubnetInfo) error {
+	spaces, err := api.backing.AllSpaces()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	spaceIDsByProviderId := make(map[network.Id]string)
+	for _, space := range spaces {
+		if space.ProviderId() != "" {
+			spaceIDsByProviderId[space.ProviderId()] = space.Id()
+		}
+	}
+
+	var spaceIDs []string
+	bySpaceID := make(map[string][]network.SubnetInfo)
+	for _, subnet := range subnets {
+		if !network.IsValidCIDR(subnet.CIDR) {
+			logger.Warningf("ignoring provider subnet %q with invalid CIDR %q", subnet.ProviderId, subnet.CIDR)
+			continue
+		}
+		spaceID := spaceIDsByProviderId[subnet.ProviderSpaceId]
+		if _, ok := bySpaceID[spaceID]; !ok {
+			spaceIDs = append(spaceIDs, spaceID)
+		}
+		bySpaceID[spaceID] = append(bySpaceID[spaceID], subnet)
+	}
+
+	for _, spaceID := range spaceIDs {
+		if err := api.backing.SaveProviderSubnets(bySpaceID[spaceID], spaceID); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// missingSubnets returns the subnets in the model that are not among the
+// input provider subnets. Subnets are matched by provider ID, falling back
+// to the CIDR for subnets without one. FAN overlays are considered present
+// while their underlay is.
+func (api *API) missingSubnets(providerSubnets []network.SubnetInfo) ([]params.MissingSubnet, error) {
+	providerIds := set.NewStrings()
+	cidrs := set.NewStrings()
+	for _, subnet := range providerSubnets {
+		providerIds.Add(string(subnet.ProviderId))
+		cidrs.Add(subnet.CIDR)
+	}
+
+	subnets, err := api.backing.AllSubnets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var missing []networkingcommon.BackingSubnet
+	spacesInUse := set.NewStrings()
+	for _, subnet := range subnets {
+		providerId := string(subnet.ProviderId())
+		if idx := strings.Index(providerId, "-"+network.InFan+"-"); idx > 0 {
+			providerId = providerId[:idx]
+		}
+		if (providerId != "" && providerIds.Contains(providerId)) ||
+			(providerId == "" && cidrs.Contains(subnet.CIDR())) {
+			spacesInUse.Add(subnet.SpaceID())
+			continue
+		}
+		missing = append(missing, subnet)
+	}
+	if len(missing) == 0 {
+		return nil, nil
+	}
+
+	machinesByCIDR, err := api.backing.MachinesBySubnetCIDR()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	appsBySpaceID, err := api.backing.ApplicationsBySpaceID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	results := make([]params.MissingSubnet, len(missing))
+	for i, subnet := range missing {
+		results[i] = params.MissingSubnet{
+			CIDR:       subnet.CIDR(),
+			ProviderId: string(subnet.ProviderId()),
+			SpaceTag:   names.NewSpaceTag(subnet.SpaceName()).String(),
+		}
+		for _, id := range set.NewStrings(machinesByCIDR[subnet.CIDR()]...).SortedValues() {
+			results[i].Machines = append(results[i].Machines, params.Entity{Tag: names.NewMachineTag(id).String()})
+		}
+		// Bindings only stop being satisfiable when the
+		// last subnet in their space has gone.
+		if spacesInUse.Contains(subnet.SpaceID()) {
+			continue
+		}
+		for _, name := range set.NewStrings(appsBySpaceID[subnet.SpaceID()]...).SortedValues() {
+			results[i].Bindings = append(results[i].Bindings, params.Entity{Tag: names.NewApplicationTag(name).String()})
+		}
+	}
+	return results, nil
+}
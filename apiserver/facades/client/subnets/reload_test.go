@@ -0,0 +1,130 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package subnets_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common/networkingcommon"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/network"
+)
+
+func (s *SubnetsSuite) TestReloadSubnets(c *gc.C) {
+	apiservertesting.BackingInstance.SetUp(
+		c, apiservertesting.StubZonedNetworkingEnvironName,
+		apiservertesting.WithZones, apiservertesting.WithSpaces, apiservertesting.WithSubnets,
+	)
+	apiservertesting.BackingInstance.Subnets = append(apiservertesting.BackingInstance.Subnets,
+		// Gone, but the dmz space still has a subnet.
+		&apiservertesting.FakeSubnet{Info: networkingcommon.BackingSubnetInfo{
+			CIDR:       "10.99.0.0/24",
+			ProviderId: "sn-gone",
+			SpaceName:  "dmz",
+			SpaceID:    "2",
+		}},
+		// Gone, and the last subnet in the default space.
+		&apiservertesting.FakeSubnet{Info: networkingcommon.BackingSubnetInfo{
+			CIDR:       "10.98.0.0/24",
+			ProviderId: "sn-gone-too",
+			SpaceName:  "default",
+			SpaceID:    "1",
+		}},
+		// A FAN overlay of a subnet still known to the provider.
+		&apiservertesting.FakeSubnet{Info: networkingcommon.BackingSubnetInfo{
+			CIDR:       "253.10.0.0/16",
+			ProviderId: "sn-zadf00d-INFAN-10-10-0-0-24",
+			SpaceName:  "private",
+			SpaceID:    "3",
+		}},
+	)
+	s.backing.machinesByCIDR = map[string][]string{
+		"10.99.0.0/24": {"1", "0", "1"},
+		"10.10.0.0/24": {"2"},
+	}
+	s.backing.appsBySpaceID = map[string][]string{
+		"1": {"mysql"},
+		"2": {"wordpress"},
+	}
+
+	result, err := s.facade.ReloadSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Missing, jc.DeepEquals, []params.MissingSubnet{{
+		CIDR:       "10.99.0.0/24",
+		ProviderId: "sn-gone",
+		SpaceTag:   "space-dmz",
+		Machines:   []params.Entity{{Tag: "machine-0"}, {Tag: "machine-1"}},
+	}, {
+		CIDR:       "10.98.0.0/24",
+		ProviderId: "sn-gone-too",
+		SpaceTag:   "space-default",
+		Bindings:   []params.Entity{{Tag: "application-mysql"}},
+	}})
+
+	// Only subnets with valid CIDRs are saved, all to the default space
+	// as none of the stub spaces have a provider ID.
+	var saved []network.SubnetInfo
+	for _, call := range apiservertesting.SharedStub.Calls() {
+		if call.FuncName != "SaveProviderSubnets" {
+			continue
+		}
+		c.Check(call.Args[1], gc.Equals, "")
+		saved = append(saved, call.Args[0].([]network.SubnetInfo)...)
+	}
+	c.Assert(saved, gc.HasLen, 10)
+	for _, subnet := range saved {
+		c.Check(network.IsValidCIDR(subnet.CIDR), jc.IsTrue)
+	}
+}
+
+func (s *SubnetsSuite) TestReloadSubnetsNothingMissing(c *gc.C) {
+	apiservertesting.BackingInstance.SetUp(
+		c, apiservertesting.StubZonedNetworkingEnvironName,
+		apiservertesting.WithZones, apiservertesting.WithSpaces, apiservertesting.WithSubnets,
+	)
+
+	result, err := s.facade.ReloadSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Missing, gc.HasLen, 0)
+	for _, call := range apiservertesting.SharedStub.Calls() {
+		c.Check(call.FuncName, gc.Not(gc.Equals), "MachinesBySubnetCIDR")
+	}
+}
+
+func (s *SubnetsSuite) TestReloadSubnetsNetworkingNotSupported(c *gc.C) {
+	apiservertesting.BackingInstance.SetUp(
+		c, apiservertesting.StubEnvironName,
+		apiservertesting.WithZones, apiservertesting.WithSpaces, apiservertesting.WithSubnets,
+	)
+
+	_, err := s.facade.ReloadSubnets()
+	c.Assert(err, gc.ErrorMatches, "model networking features not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *SubnetsSuite) TestReloadSubnetsSaveError(c *gc.C) {
+	apiservertesting.BackingInstance.SetUp(
+		c, apiservertesting.StubZonedNetworkingEnvironName,
+		apiservertesting.WithZones, apiservertesting.WithSpaces, apiservertesting.WithSubnets,
+	)
+	apiservertesting.SharedStub.SetErrors(
+		nil,                // Backing.ModelConfig
+		nil,                // Backing.CloudSpec
+		nil,                // Provider.Open
+		nil,                // Backing.ModelConfig
+		nil,                // Backing.CloudSpec
+		nil,                // Provider.Open
+		nil,                // ZonedNetworkingEnviron.AvailabilityZones
+		nil,                // Backing.SetAvailabilityZones
+		nil,                // ZonedNetworkingEnviron.Subnets
+		nil,                // Backing.AllSpaces
+		errors.New("boom"), // Backing.SaveProviderSubnets
+	)
+
+	_, err := s.facade.ReloadSubnets()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -4,6 +4,7 @@
 package subnets
 
 import (
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 
@@ -106,3 +107,42 @@ func (s *stateShim) AllSpaces() ([]networkingcommon.BackingSpace, error) {
 	}
 	return spaces, nil
 }
+
+// MachinesBySubnetCIDR returns the IDs of the machines with addresses in
+// each subnet, keyed by subnet CIDR.
+func (s *stateShim) MachinesBySubnetCIDR() (map[string][]string, error) {
+	addresses, err := s.State.AllIPAddresses()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machines := make(map[string][]string)
+	for _, address := range addresses {
+		cidr := address.SubnetCIDR()
+		machines[cidr] = append(machines[cidr], address.MachineID())
+	}
+	return machines, nil
+}
+
+// ApplicationsBySpaceID returns the names of the applications with
+// endpoints bound to each space, keyed by space ID.
+func (s *stateShim) ApplicationsBySpaceID() (map[string][]string, error) {
+	model, err := s.State.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	allBindings, err := model.AllEndpointBindings()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	apps := make(map[string][]string)
+	for appName, bindings := range allBindings {
+		spaceIDs := set.NewStrings()
+		for _, spaceID := range bindings.Map() {
+			spaceIDs.Add(spaceID)
+		}
+		for _, spaceID := range spaceIDs.Values() {
+			apps[spaceID] = append(apps[spaceID], appName)
+		}
+	}
+	return apps, nil
+}
@@ -52,6 +52,18 @@ type Backing interface {
 	// AllSpaces returns all known Juju network spaces.
 	AllSpaces() ([]networkingcommon.BackingSpace, error)
 
+	// SaveProviderSubnets adds the input provider subnets to the space
+	// with the input ID, or updates them if they are already known.
+	SaveProviderSubnets([]network.SubnetInfo, string) error
+
+	// MachinesBySubnetCIDR returns the IDs of the machines with addresses
+	// in each subnet, keyed by subnet CIDR.
+	MachinesBySubnetCIDR() (map[string][]string, error)
+
+	// ApplicationsBySpaceID returns the names of the applications with
+	// endpoints bound to each space, keyed by space ID.
+	ApplicationsBySpaceID() (map[string][]string, error)
+
 	// ModelTag returns the tag of the model this state is associated to.
 	ModelTag() names.ModelTag
 }
@@ -63,10 +75,15 @@ type APIv2 struct {
 
 // APIv3 provides the subnets API facade for versions 3.
 type APIv3 struct {
+	*APIv4
+}
+
+// APIv4 provides the subnets API facade for version 4.
+type APIv4 struct {
 	*API
 }
 
-// API provides the subnets API facade for version 4.
+// API provides the subnets API facade for version 5.
 type API struct {
 	backing    Backing
 	resources  facade.Resources
@@ -85,13 +102,22 @@ func NewAPIv2(st *state.State, res facade.Resources, auth facade.Authorizer) (*A
 
 // NewAPIv3 is a wrapper that creates a V3 subnets API.
 func NewAPIv3(st *state.State, res facade.Resources, auth facade.Authorizer) (*APIv3, error) {
-	api, err := NewAPI(st, res, auth)
+	api, err := NewAPIv4(st, res, auth)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv3{api}, nil
 }
 
+// NewAPIv4 is a wrapper that creates a V4 subnets API.
+func NewAPIv4(st *state.State, res facade.Resources, auth facade.Authorizer) (*APIv4, error) {
+	api, err := NewAPI(st, res, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv4{api}, nil
+}
+
 // NewAPI creates a new Subnets API server-side facade with a
 // state.State backing.
 func NewAPI(st *state.State, res facade.Resources, auth facade.Authorizer) (*API, error) {
@@ -109,6 +109,7 @@ type SubnetsSuite struct {
 
 	resources  *common.Resources
 	authorizer apiservertesting.FakeAuthorizer
+	backing    *stubBacking
 	facade     *subnets.API
 
 	callContext context.ProviderCallContext
@@ -116,12 +117,31 @@ type SubnetsSuite struct {
 
 type stubBacking struct {
 	*apiservertesting.StubBacking
+
+	machinesByCIDR map[string][]string
+	appsBySpaceID  map[string][]string
 }
 
 func (sb *stubBacking) SubnetsByCIDR(_ string) ([]networkingcommon.BackingSubnet, error) {
 	panic("should not be called")
 }
 
+func (sb *stubBacking) MachinesBySubnetCIDR() (map[string][]string, error) {
+	sb.MethodCall(sb, "MachinesBySubnetCIDR")
+	if err := sb.NextErr(); err != nil {
+		return nil, err
+	}
+	return sb.machinesByCIDR, nil
+}
+
+func (sb *stubBacking) ApplicationsBySpaceID() (map[string][]string, error) {
+	sb.MethodCall(sb, "ApplicationsBySpaceID")
+	if err := sb.NextErr(); err != nil {
+		return nil, err
+	}
+	return sb.appsBySpaceID, nil
+}
+
 var _ = gc.Suite(&SubnetsSuite{})
 
 func (s *SubnetsSuite) SetUpSuite(c *gc.C) {
@@ -144,9 +164,10 @@ func (s *SubnetsSuite) SetUpTest(c *gc.C) {
 	}
 
 	s.callContext = context.NewCloudCallContext()
+	s.backing = &stubBacking{StubBacking: apiservertesting.BackingInstance}
 	var err error
 	s.facade, err = subnets.NewAPIWithBacking(
-		&stubBacking{apiservertesting.BackingInstance},
+		s.backing,
 		s.callContext,
 		s.resources, s.authorizer,
 	)
@@ -190,7 +211,7 @@ func (s *SubnetsSuite) AssertAllSpacesResult(c *gc.C, got params.SpaceResults, e
 func (s *SubnetsSuite) TestNewAPIWithBacking(c *gc.C) {
 	// Clients are allowed.
 	facade, err := subnets.NewAPIWithBacking(
-		&stubBacking{apiservertesting.BackingInstance},
+		&stubBacking{StubBacking: apiservertesting.BackingInstance},
 		s.callContext,
 		s.resources, s.authorizer,
 	)
@@ -203,7 +224,7 @@ func (s *SubnetsSuite) TestNewAPIWithBacking(c *gc.C) {
 	agentAuthorizer := s.authorizer
 	agentAuthorizer.Tag = names.NewMachineTag("42")
 	facade, err = subnets.NewAPIWithBacking(
-		&stubBacking{apiservertesting.BackingInstance},
+		&stubBacking{StubBacking: apiservertesting.BackingInstance},
 		s.callContext,
 		s.resources, agentAuthorizer,
 	)
@@ -392,7 +413,7 @@ func (s *SubnetsSuite) testAllSpacesSuccess(c *gc.C, withBackingSpaces apiserver
 		apiservertesting.WithSubnets,
 	)
 
-	api := &subnets.APIv3{API: s.facade}
+	api := &subnets.APIv3{APIv4: &subnets.APIv4{API: s.facade}}
 	results, err := api.AllSpaces()
 	c.Assert(err, jc.ErrorIsNil)
 	s.AssertAllSpacesResult(c, results, apiservertesting.BackingInstance.Spaces)
@@ -405,7 +426,7 @@ func (s *SubnetsSuite) testAllSpacesSuccess(c *gc.C, withBackingSpaces apiserver
 func (s *SubnetsSuite) TestAllSpacesFailure(c *gc.C) {
 	apiservertesting.SharedStub.SetErrors(errors.NotFoundf("boom"))
 
-	api := &subnets.APIv3{API: s.facade}
+	api := &subnets.APIv3{APIv4: &subnets.APIv4{API: s.facade}}
 	results, err := api.AllSpaces()
 	c.Assert(err, gc.ErrorMatches, "boom not found")
 	// Verify the cause is not obscured.
@@ -605,7 +626,7 @@ func (s *SubnetsSuite) TestAddSubnetAPI(c *gc.C) {
 func (s *SubnetsSuite) TestAddSubnetAPIv2(c *gc.C) {
 	apiservertesting.BackingInstance.SetUp(c, apiservertesting.StubNetworkingEnvironName,
 		apiservertesting.WithZones, apiservertesting.WithSpaces, apiservertesting.WithSubnets)
-	apiV2 := &subnets.APIv2{APIv3: &subnets.APIv3{API: s.facade}}
+	apiV2 := &subnets.APIv2{APIv3: &subnets.APIv3{APIv4: &subnets.APIv4{API: s.facade}}}
 	results, err := apiV2.AddSubnets(params.AddSubnetsParamsV2{
 		Subnets: []params.AddSubnetParamsV2{
 			{
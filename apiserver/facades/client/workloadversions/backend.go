@@ -0,0 +1,85 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package workloadversions
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+// Backend provides access to the units whose workload versions are
+// reported.
+type Backend interface {
+	AllApplications() ([]Application, error)
+	Unit(name string) (Unit, error)
+}
+
+// Application describes the parts of an application needed to
+// summarise its workload versions.
+type Application interface {
+	Name() string
+	AllUnits() ([]Unit, error)
+}
+
+// Unit describes the parts of a unit needed to report its workload
+// versions.
+type Unit interface {
+	Name() string
+	WorkloadVersionInfo() (status.StatusInfo, error)
+	WorkloadVersionHistory() status.StatusHistoryGetter
+}
+
+type backendShim struct {
+	st *state.State
+}
+
+// AllApplications implements Backend.
+func (b backendShim) AllApplications() ([]Application, error) {
+	apps, err := b.st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Application, len(apps))
+	for i, app := range apps {
+		result[i] = applicationShim{app}
+	}
+	return result, nil
+}
+
+// Unit implements Backend.
+func (b backendShim) Unit(name string) (Unit, error) {
+	unit, err := b.st.Unit(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return unitShim{unit}, nil
+}
+
+type applicationShim struct {
+	*state.Application
+}
+
+// AllUnits implements Application.
+func (a applicationShim) AllUnits() ([]Unit, error) {
+	units, err := a.Application.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Unit, len(units))
+	for i, unit := range units {
+		result[i] = unitShim{unit}
+	}
+	return result, nil
+}
+
+type unitShim struct {
+	*state.Unit
+}
+
+// WorkloadVersionHistory implements Unit.
+func (u unitShim) WorkloadVersionHistory() status.StatusHistoryGetter {
+	return u.Unit.WorkloadVersionHistory()
+}
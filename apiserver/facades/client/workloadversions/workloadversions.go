@@ -0,0 +1,179 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package workloadversions provides the WorkloadVersions facade, which
+// reports the workload versions run by the units of a model.
+package workloadversions
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/status"
+)
+
+// maxHistory is the maximum number of workload versions returned for
+// each unit by VersionHistory. Older versions are subject to status
+// history pruning in any case.
+const maxHistory = 100
+
+// API implements the WorkloadVersions facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+	modelTag   names.ModelTag
+}
+
+// NewFacade is used for API registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	return NewAPI(backendShim{st: st}, ctx.Auth(), names.NewModelTag(st.ModelUUID()))
+}
+
+// NewAPI returns a new WorkloadVersions API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer, modelTag names.ModelTag) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+		modelTag:   modelTag,
+	}, nil
+}
+
+func (api *API) checkCanRead() error {
+	canRead, err := api.authorizer.HasPermission(permission.ReadAccess, api.modelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
+		return apiservererrors.ErrPerm
+	}
+	return nil
+}
+
+// VersionHistory returns the workload versions reported by each of the
+// given units, most recent first, along with the time each version was
+// first reported.
+func (api *API) VersionHistory(args params.Entities) (params.WorkloadVersionHistoryResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.WorkloadVersionHistoryResults{}, errors.Trace(err)
+	}
+	results := make([]params.WorkloadVersionHistoryResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		history, err := api.versionHistory(entity.Tag)
+		if err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results[i].History = history
+	}
+	return params.WorkloadVersionHistoryResults{Results: results}, nil
+}
+
+func (api *API) versionHistory(tagString string) ([]params.WorkloadVersion, error) {
+	tag, err := names.ParseUnitTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unit, err := api.backend.Unit(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	history, err := unit.WorkloadVersionHistory().StatusHistory(status.StatusHistoryFilter{Size: maxHistory})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]params.WorkloadVersion, 0, len(history))
+	for _, info := range history {
+		if info.Message == "" {
+			continue
+		}
+		result = append(result, params.WorkloadVersion{
+			Version: info.Message,
+			Since:   info.Since,
+		})
+	}
+	return result, nil
+}
+
+// Summary returns the workload versions currently reported by the
+// units of each application in the model. For each application, the
+// version most recently reported by any unit is taken to be the latest;
+// units still reporting another version, for example after a partial
+// upgrade, are listed as outdated.
+func (api *API) Summary() (params.WorkloadVersionSummary, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.WorkloadVersionSummary{}, errors.Trace(err)
+	}
+	apps, err := api.backend.AllApplications()
+	if err != nil {
+		return params.WorkloadVersionSummary{}, errors.Trace(err)
+	}
+	result := params.WorkloadVersionSummary{
+		Applications: make([]params.ApplicationWorkloadVersions, 0, len(apps)),
+	}
+	for _, app := range apps {
+		summary, err := applicationSummary(app)
+		if err != nil {
+			return params.WorkloadVersionSummary{}, errors.Annotatef(err, "application %q", app.Name())
+		}
+		result.Applications = append(result.Applications, summary)
+	}
+	sort.Slice(result.Applications, func(i, j int) bool {
+		return result.Applications[i].ApplicationTag < result.Applications[j].ApplicationTag
+	})
+	return result, nil
+}
+
+func applicationSummary(app Application) (params.ApplicationWorkloadVersions, error) {
+	summary := params.ApplicationWorkloadVersions{
+		ApplicationTag: names.NewApplicationTag(app.Name()).String(),
+		Units:          []params.UnitWorkloadVersion{},
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return summary, errors.Trace(err)
+	}
+	sort.Slice(units, func(i, j int) bool {
+		return names.NewUnitTag(units[i].Name()).Number() < names.NewUnitTag(units[j].Name()).Number()
+	})
+	var latest *params.UnitWorkloadVersion
+	for _, unit := range units {
+		info, err := unit.WorkloadVersionInfo()
+		if err != nil {
+			return summary, errors.Annotatef(err, "unit %q", unit.Name())
+		}
+		unitVersion := params.UnitWorkloadVersion{
+			UnitTag: names.NewUnitTag(unit.Name()).String(),
+			Version: info.Message,
+			Since:   info.Since,
+		}
+		summary.Units = append(summary.Units, unitVersion)
+		if unitVersion.Version == "" || unitVersion.Since == nil {
+			continue
+		}
+		if latest == nil || unitVersion.Since.After(*latest.Since) {
+			latest = &unitVersion
+		}
+	}
+	if latest == nil {
+		return summary, nil
+	}
+	summary.LatestVersion = latest.Version
+	// Units that have never reported a version aren't considered
+	// outdated; there's nothing to compare.
+	for _, unit := range summary.Units {
+		if unit.Version != "" && unit.Version != summary.LatestVersion {
+			summary.OutdatedUnits = append(summary.OutdatedUnits, unit.UnitTag)
+		}
+	}
+	return summary, nil
+}
@@ -0,0 +1,235 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package workloadversions_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/workloadversions"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type workloadVersionsSuite struct {
+	testing.IsolationSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+	backend    *mockBackend
+	api        *workloadversions.API
+	now        time.Time
+}
+
+var _ = gc.Suite(&workloadVersionsSuite{})
+
+func (s *workloadVersionsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	s.now = time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	s.backend = &mockBackend{
+		apps: []*mockApplication{{
+			name: "postgresql",
+			units: []*mockUnit{
+				s.newUnit("postgresql/10", "12.6", 3),
+				s.newUnit("postgresql/2", "12.5", 1),
+				s.newUnit("postgresql/0", "12.6", 2),
+				s.newUnit("postgresql/1", "", 0),
+			},
+		}, {
+			name: "haproxy",
+			units: []*mockUnit{
+				s.newUnit("haproxy/0", "2.2", 1),
+			},
+		}},
+	}
+	var err error
+	s.api, err = workloadversions.NewAPI(s.backend, s.authorizer, coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *workloadVersionsSuite) newUnit(name, version string, minutes int) *mockUnit {
+	unit := &mockUnit{name: name}
+	if version != "" {
+		since := s.now.Add(time.Duration(minutes) * time.Minute)
+		unit.info = status.StatusInfo{Status: status.Active, Message: version, Since: &since}
+	}
+	return unit
+}
+
+func (s *workloadVersionsSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := workloadversions.NewAPI(s.backend, s.authorizer, coretesting.ModelTag)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *workloadVersionsSuite) TestSummary(c *gc.C) {
+	at := func(minutes int) *time.Time {
+		t := s.now.Add(time.Duration(minutes) * time.Minute)
+		return &t
+	}
+	result, err := s.api.Summary()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.WorkloadVersionSummary{
+		Applications: []params.ApplicationWorkloadVersions{{
+			ApplicationTag: "application-haproxy",
+			LatestVersion:  "2.2",
+			Units: []params.UnitWorkloadVersion{
+				{UnitTag: "unit-haproxy-0", Version: "2.2", Since: at(1)},
+			},
+		}, {
+			ApplicationTag: "application-postgresql",
+			LatestVersion:  "12.6",
+			Units: []params.UnitWorkloadVersion{
+				{UnitTag: "unit-postgresql-0", Version: "12.6", Since: at(2)},
+				{UnitTag: "unit-postgresql-1"},
+				{UnitTag: "unit-postgresql-2", Version: "12.5", Since: at(1)},
+				{UnitTag: "unit-postgresql-10", Version: "12.6", Since: at(3)},
+			},
+			OutdatedUnits: []string{"unit-postgresql-2"},
+		}},
+	})
+}
+
+func (s *workloadVersionsSuite) TestSummaryNoVersions(c *gc.C) {
+	s.backend.apps = []*mockApplication{{
+		name:  "ubuntu",
+		units: []*mockUnit{{name: "ubuntu/0"}},
+	}}
+	result, err := s.api.Summary()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Applications, jc.DeepEquals, []params.ApplicationWorkloadVersions{{
+		ApplicationTag: "application-ubuntu",
+		Units:          []params.UnitWorkloadVersion{{UnitTag: "unit-ubuntu-0"}},
+	}})
+}
+
+func (s *workloadVersionsSuite) TestSummaryError(c *gc.C) {
+	s.backend.apps[1].units[0].err = errors.New("boom")
+	_, err := s.api.Summary()
+	c.Assert(err, gc.ErrorMatches, `application "haproxy": unit "haproxy/0": boom`)
+}
+
+func (s *workloadVersionsSuite) TestSummaryPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	api, err := workloadversions.NewAPI(s.backend, s.authorizer, coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.Summary()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *workloadVersionsSuite) TestVersionHistory(c *gc.C) {
+	first := s.now
+	second := s.now.Add(time.Hour)
+	s.backend.apps[0].units[0].history = []status.StatusInfo{
+		{Status: status.Active, Message: "12.6", Since: &second},
+		{Status: status.Active, Message: "", Since: &first},
+		{Status: status.Active, Message: "12.5", Since: &first},
+	}
+	result, err := s.api.VersionHistory(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "unit-postgresql-10"},
+			{Tag: "unit-postgresql-9"},
+			{Tag: "application-postgresql"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0], jc.DeepEquals, params.WorkloadVersionHistoryResult{
+		History: []params.WorkloadVersion{
+			{Version: "12.6", Since: &second},
+			{Version: "12.5", Since: &first},
+		},
+	})
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `unit "postgresql/9" not found`)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"application-postgresql" is not a valid unit tag`)
+	s.backend.apps[0].units[0].CheckCalls(c, []testing.StubCall{{
+		FuncName: "StatusHistory",
+		Args:     []interface{}{status.StatusHistoryFilter{Size: 100}},
+	}})
+}
+
+func (s *workloadVersionsSuite) TestVersionHistoryPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	api, err := workloadversions.NewAPI(s.backend, s.authorizer, coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.VersionHistory(params.Entities{
+		Entities: []params.Entity{{Tag: "unit-postgresql-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type mockBackend struct {
+	apps []*mockApplication
+}
+
+func (b *mockBackend) AllApplications() ([]workloadversions.Application, error) {
+	result := make([]workloadversions.Application, len(b.apps))
+	for i, app := range b.apps {
+		result[i] = app
+	}
+	return result, nil
+}
+
+func (b *mockBackend) Unit(name string) (workloadversions.Unit, error) {
+	for _, app := range b.apps {
+		for _, unit := range app.units {
+			if unit.name == name {
+				return unit, nil
+			}
+		}
+	}
+	return nil, errors.NotFoundf("unit %q", name)
+}
+
+type mockApplication struct {
+	name  string
+	units []*mockUnit
+}
+
+func (a *mockApplication) Name() string {
+	return a.name
+}
+
+func (a *mockApplication) AllUnits() ([]workloadversions.Unit, error) {
+	result := make([]workloadversions.Unit, len(a.units))
+	for i, unit := range a.units {
+		result[i] = unit
+	}
+	return result, nil
+}
+
+type mockUnit struct {
+	testing.Stub
+	name    string
+	info    status.StatusInfo
+	history []status.StatusInfo
+	err     error
+}
+
+func (u *mockUnit) Name() string {
+	return u.name
+}
+
+func (u *mockUnit) WorkloadVersionInfo() (status.StatusInfo, error) {
+	return u.info, u.err
+}
+
+func (u *mockUnit) WorkloadVersionHistory() status.StatusHistoryGetter {
+	return u
+}
+
+func (u *mockUnit) StatusHistory(filter status.StatusHistoryFilter) ([]status.StatusInfo, error) {
+	u.MethodCall(u, "StatusHistory", filter)
+	return u.history, u.NextErr()
+}
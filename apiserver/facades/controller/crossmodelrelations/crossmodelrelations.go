@@ -256,12 +256,13 @@ func (api *CrossModelRelationsAPI) registerRemoteRelation(relation params.Regist
 		return nil, errors.Trace(err)
 	}
 	_, err = api.st.AddRemoteApplication(state.AddRemoteApplicationParams{
-		Name:            uniqueRemoteApplicationName,
-		OfferUUID:       relation.OfferUUID,
-		SourceModel:     sourceModelTag,
-		Token:           relation.ApplicationToken,
-		Endpoints:       []charm.Relation{remoteEndpoint.Relation},
-		IsConsumerProxy: true,
+		Name:                uniqueRemoteApplicationName,
+		OfferUUID:           relation.OfferUUID,
+		SourceModel:         sourceModelTag,
+		Token:               relation.ApplicationToken,
+		Endpoints:           []charm.Relation{remoteEndpoint.Relation},
+		IsConsumerProxy:     true,
+		EncryptRelationData: relation.KeyGeneration > 0,
 	})
 	// If it already exists, that's fine.
 	if err != nil && !errors.IsAlreadyExists(err) {
@@ -303,6 +304,15 @@ func (api *CrossModelRelationsAPI) registerRemoteRelation(relation params.Regist
 	}
 	logger.Debugf("relation token %v exported for %v ", relation.RelationToken, localRel.Tag().Id())
 
+	// The consuming model rotates the relation keys each time the
+	// relation is registered, so that the units on this side replace
+	// their keys too.
+	if relation.KeyGeneration > 0 {
+		if err := localRel.SetKeyGeneration(relation.KeyGeneration); err != nil {
+			return nil, errors.Annotatef(err, "setting key generation for relation %v", localRel.Tag().Id())
+		}
+	}
+
 	// Export the local offer from this model so we can tell the caller what the remote id is.
 	// The offer is exported as an application name since it models the behaviour of an application
 	// as far as the consuming side is concerned, and also needs to be unique.
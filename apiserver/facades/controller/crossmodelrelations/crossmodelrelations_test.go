@@ -199,7 +199,7 @@ func (s *crossmodelRelationsSuite) TestPublishRelationsChangesDyingForceCleanup(
 	s.assertPublishRelationsChanges(c, life.Dying, "", true)
 }
 
-func (s *crossmodelRelationsSuite) assertRegisterRemoteRelations(c *gc.C) {
+func (s *crossmodelRelationsSuite) assertRegisterRemoteRelations(c *gc.C, keyGeneration int) {
 	app := &mockApplication{}
 	app.eps = []state.Endpoint{{
 		ApplicationName: "offeredapp",
@@ -236,6 +236,7 @@ func (s *crossmodelRelationsSuite) assertRegisterRemoteRelations(c *gc.C) {
 			RemoteEndpoint:    params.RemoteEndpoint{Name: "remote"},
 			OfferUUID:         "offer-uuid",
 			LocalEndpointName: "local",
+			KeyGeneration:     keyGeneration,
 			Macaroons:         macaroon.Slice{mac.M()},
 		}}})
 	c.Assert(err, jc.ErrorIsNil)
@@ -261,10 +262,11 @@ func (s *crossmodelRelationsSuite) assertRegisterRemoteRelations(c *gc.C) {
 	expectedRemoteApp := s.st.remoteApplications["remote-apptoken"]
 	expectedRemoteApp.Stub = testing.Stub{} // don't care about api calls
 	c.Check(expectedRemoteApp, jc.DeepEquals, &mockRemoteApplication{
-		sourceModelUUID: coretesting.ModelTag.Id(), consumerproxy: true})
+		sourceModelUUID: coretesting.ModelTag.Id(), consumerproxy: true, encrypt: keyGeneration > 0})
 	expectedRel := s.st.relations["offeredapp:local remote-apptoken:remote"]
 	expectedRel.Stub = testing.Stub{} // don't care about api calls
-	c.Check(expectedRel, jc.DeepEquals, &mockRelation{id: 0, key: "offeredapp:local remote-apptoken:remote"})
+	c.Check(expectedRel, jc.DeepEquals, &mockRelation{
+		id: 0, key: "offeredapp:local remote-apptoken:remote", keyGeneration: keyGeneration})
 	c.Check(s.st.remoteEntities, gc.HasLen, 2)
 	c.Check(s.st.remoteEntities[names.NewApplicationTag("offered")], gc.Equals, "token-offered")
 	c.Check(s.st.remoteEntities[names.NewRelationTag("offeredapp:local remote-apptoken:remote")], gc.Equals, "rel-token")
@@ -280,12 +282,21 @@ func (s *crossmodelRelationsSuite) assertRegisterRemoteRelations(c *gc.C) {
 }
 
 func (s *crossmodelRelationsSuite) TestRegisterRemoteRelations(c *gc.C) {
-	s.assertRegisterRemoteRelations(c)
+	s.assertRegisterRemoteRelations(c, 0)
 }
 
 func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsIdempotent(c *gc.C) {
-	s.assertRegisterRemoteRelations(c)
-	s.assertRegisterRemoteRelations(c)
+	s.assertRegisterRemoteRelations(c, 0)
+	s.assertRegisterRemoteRelations(c, 0)
+}
+
+func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsEncrypted(c *gc.C) {
+	s.assertRegisterRemoteRelations(c, 1)
+}
+
+func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsRotatesKeys(c *gc.C) {
+	s.assertRegisterRemoteRelations(c, 1)
+	s.assertRegisterRemoteRelations(c, 2)
 }
 
 func (s *crossmodelRelationsSuite) TestRelationUnitSettings(c *gc.C) {
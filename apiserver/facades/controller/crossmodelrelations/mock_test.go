@@ -156,7 +156,8 @@ func (st *mockState) EndpointsRelation(eps ...state.Endpoint) (commoncrossmodel.
 func (st *mockState) AddRemoteApplication(params state.AddRemoteApplicationParams) (commoncrossmodel.RemoteApplication, error) {
 	app := &mockRemoteApplication{
 		sourceModelUUID: params.SourceModel.Id(),
-		consumerproxy:   params.IsConsumerProxy}
+		consumerproxy:   params.IsConsumerProxy,
+		encrypt:         params.EncryptRelationData}
 	st.remoteApplications[params.Name] = app
 	return app, nil
 }
@@ -355,6 +356,7 @@ type mockRelation struct {
 	endpoints       []state.Endpoint
 	watchers        map[string]*mockUnitsWatcher
 	appSettings     map[string]map[string]interface{}
+	keyGeneration   int
 }
 
 func newMockRelation(id int) *mockRelation {
@@ -371,6 +373,17 @@ func (r *mockRelation) Id() int {
 	return r.id
 }
 
+func (r *mockRelation) SetKeyGeneration(generation int) error {
+	r.MethodCall(r, "SetKeyGeneration", generation)
+	if err := r.NextErr(); err != nil {
+		return err
+	}
+	if generation > r.keyGeneration {
+		r.keyGeneration = generation
+	}
+	return nil
+}
+
 func (r *mockRelation) Tag() names.Tag {
 	r.MethodCall(r, "Tag")
 	return names.NewRelationTag(r.key)
@@ -487,6 +500,7 @@ type mockRemoteApplication struct {
 	commoncrossmodel.RemoteApplication
 	testing.Stub
 	consumerproxy   bool
+	encrypt         bool
 	sourceModelUUID string
 }
 
@@ -65,6 +65,11 @@ type FirewallerAPIV6 struct {
 	*FirewallerAPIV5
 }
 
+// FirewallerAPIV7 provides access to the Firewaller v7 API facade.
+type FirewallerAPIV7 struct {
+	*FirewallerAPIV6
+}
+
 // NewStateFirewallerAPIV3 creates a new server-side FirewallerAPIV3 facade.
 func NewStateFirewallerAPIV3(context facade.Context) (*FirewallerAPIV3, error) {
 	st := context.State()
@@ -119,6 +124,17 @@ func NewStateFirewallerAPIV6(context facade.Context) (*FirewallerAPIV6, error) {
 	}, nil
 }
 
+// NewStateFirewallerAPIV7 creates a new server-side FirewallerAPIV7 facade.
+func NewStateFirewallerAPIV7(context facade.Context) (*FirewallerAPIV7, error) {
+	facadev6, err := NewStateFirewallerAPIV6(context)
+	if err != nil {
+		return nil, err
+	}
+	return &FirewallerAPIV7{
+		FirewallerAPIV6: facadev6,
+	}, nil
+}
+
 // NewFirewallerAPI creates a new server-side FirewallerAPIV3 facade.
 func NewFirewallerAPI(
 	st State,
@@ -723,3 +739,77 @@ func (f *FirewallerAPIV6) watchModelSubnets(filterFn func(interface{}) bool) (st
 	}
 	return "", nil, watcher.EnsureErr(watch)
 }
+
+// GetFirewallModes returns the firewall-mode set in the application config
+// of each of the specified applications. An empty result means that the
+// model's firewall-mode applies.
+func (f *FirewallerAPIV7) GetFirewallModes(args params.Entities) (params.StringResults, error) {
+	canAccess, err := f.accessApplication()
+	if err != nil {
+		return params.StringResults{}, err
+	}
+
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
+			continue
+		}
+		application, err := f.getApplication(canAccess, tag)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		appConfig, err := application.ApplicationConfig()
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		mode, err := appConfig.FirewallMode()
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = mode
+	}
+	return result, nil
+}
+
+// WatchFirewallModes returns a NotifyWatcher for each of the specified
+// applications, which notifies when the application config holding its
+// firewall-mode changes.
+func (f *FirewallerAPIV7) WatchFirewallModes(args params.Entities) (params.NotifyWatchResults, error) {
+	canAccess, err := f.accessApplication()
+	if err != nil {
+		return params.NotifyWatchResults{}, err
+	}
+
+	result := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
+			continue
+		}
+		application, err := f.getApplication(canAccess, tag)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		watch := application.WatchApplicationConfig()
+		// Consume the initial event. Technically, API calls to Watch
+		// 'transmit' the initial event in the Watch response. But
+		// NotifyWatchers have no state to transmit.
+		if _, ok := <-watch.Changes(); ok {
+			result.Results[i].NotifyWatcherId = f.resources.Register(watch)
+		} else {
+			result.Results[i].Error = apiservererrors.ServerError(watcher.EnsureErr(watch))
+		}
+	}
+	return result, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/environschema.v1"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/cloudspec"
@@ -17,6 +18,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/firewaller"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
@@ -218,6 +220,79 @@ func (s *firewallerSuite) TestGetExposeInfo(c *gc.C) {
 	s.testGetExposeInfo(c, apiv6)
 }
 
+func (s *firewallerSuite) TestGetFirewallModes(c *gc.C) {
+	apiv7 := s.firewallerV7()
+	err := s.application.UpdateApplicationConfig(coreapplication.ConfigAttributes{
+		"firewall-mode": "none",
+	}, nil, environschema.Fields{
+		"firewall-mode": {Type: environschema.Tstring},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := addFakeEntities(params.Entities{Entities: []params.Entity{
+		{Tag: s.application.Tag().String()},
+		{Tag: "application-mysql"},
+	}})
+	result, err := apiv7.GetFirewallModes(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{
+			{Result: "none"},
+			{Result: ""},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError(`application "bar"`)},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *firewallerSuite) TestWatchFirewallModes(c *gc.C) {
+	apiv7 := s.firewallerV7()
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	result, err := apiv7.WatchFirewallModes(params.Entities{Entities: []params.Entity{
+		{Tag: s.application.Tag().String()},
+		{Tag: s.units[0].Tag().String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{NotifyWatcherId: "1"},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	w := s.resources.Get("1")
+	defer statetesting.AssertStop(c, w)
+
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	err = s.application.UpdateApplicationConfig(coreapplication.ConfigAttributes{
+		"firewall-mode": "none",
+	}, nil, environschema.Fields{
+		"firewall-mode": {Type: environschema.Tstring},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *firewallerSuite) firewallerV7() *firewaller.FirewallerAPIV7 {
+	return &firewaller.FirewallerAPIV7{
+		&firewaller.FirewallerAPIV6{
+			&firewaller.FirewallerAPIV5{
+				&firewaller.FirewallerAPIV4{
+					FirewallerAPIV3:     s.firewaller,
+					ControllerConfigAPI: common.NewControllerConfig(newMockState(coretesting.ModelTag.Id())),
+				},
+			},
+		},
+	}
+}
+
 func (s *firewallerSuite) TestWatchSubnets(c *gc.C) {
 	// Set up a spaces with two subnets
 	sp, err := s.State.AddSpace("outer-space", network.Id("outer-1"), nil, true)
@@ -192,7 +192,7 @@ func maybeUpdateMachineProviderAddresses(m StateMachine, newSpaceAddrs network.S
 }
 
 func (a *InstancePollerAPI) mergeLinkLayer(m StateMachine, devs network.InterfaceInfos) error {
-	return errors.Trace(a.st.ApplyOperation(newMergeMachineLinkLayerOp(m, devs)))
+	return errors.Trace(a.st.ApplyOperation(newMergeMachineLinkLayerOp(m, devs, a.st)))
 }
 
 // mapNetworkConfigsToProviderAddresses iterates the list of incoming network
@@ -923,6 +923,7 @@ func (s *InstancePollerSuite) TestSetProviderNetworkConfigRelinquishUnseen(c *gc
 	// Address should be set back to machine origin.
 	addr := mocks.NewMockLinkLayerAddress(ctrl)
 	addr.EXPECT().DeviceName().Return("eth0")
+	addr.EXPECT().Origin().Return(network.OriginMachine)
 	addr.EXPECT().SetOriginOps(network.OriginMachine).Return([]txn.Op{{C: "address-origin-manual"}})
 
 	s.st.SetMachineInfo(c, machineInfo{
@@ -952,6 +953,7 @@ func (s *InstancePollerSuite) TestSetProviderNetworkConfigRelinquishUnseen(c *gc
 				{C: "machine-alive"},
 				{C: "dev-provider-id"},
 				{C: "address-origin-manual"},
+				{C: "machine-network-changed"},
 			}})
 		}
 	}
@@ -1031,6 +1033,283 @@ func (s *InstancePollerSuite) TestSetProviderNetworkClaimProviderOrigin(c *gc.C)
 				{C: "dev-provider-id"},
 				{C: "addr-provider-id"},
 				{C: "addr-provider-net-ids"},
+				{C: "machine-network-changed"},
+			}})
+		}
+	}
+	c.Assert(buildCalled, jc.IsTrue)
+}
+
+func (s *InstancePollerSuite) TestSetProviderNetworkAddsNewProviderAddresses(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	s.setDefaultSpaceInfo()
+
+	dev := mocks.NewMockLinkLayerDevice(ctrl)
+	dExp := dev.EXPECT()
+	dExp.MACAddress().Return("00:00:00:00:00:00").AnyTimes()
+	dExp.Name().Return("eth0").MinTimes(1)
+	dExp.ProviderID().Return(network.Id("p-dev"))
+	dExp.SetProviderIDOps(network.Id("p-dev")).Return(nil, nil)
+
+	// The address we already know about is matched as usual.
+	addr := mocks.NewMockLinkLayerAddress(ctrl)
+	aExp := addr.EXPECT()
+	aExp.DeviceName().Return("eth0")
+	aExp.Value().Return("10.0.0.42")
+	aExp.SetProviderIDOps(network.Id("p-addr")).Return(nil, nil)
+	aExp.SetProviderNetIDsOps(network.Id("p-net"), network.Id("p-sub")).Return(nil)
+
+	// The address assigned on the provider side is added to the device.
+	dExp.AddAddressOps(gomock.Any()).DoAndReturn(func(arg state.LinkLayerDeviceAddress) ([]txn.Op, error) {
+		c.Check(arg.DeviceName, gc.Equals, "eth0")
+		c.Check(arg.CIDRAddress, gc.Equals, "10.0.0.43/24")
+		c.Check(arg.Origin, gc.Equals, network.OriginProvider)
+		return []txn.Op{{C: "add-addr"}}, nil
+	})
+
+	s.st.SetMachineInfo(c, machineInfo{
+		id:               "1",
+		instanceStatus:   statusInfo("foo"),
+		linkLayerDevices: []networkingcommon.LinkLayerDevice{dev},
+		addresses:        []networkingcommon.LinkLayerAddress{addr},
+	})
+
+	result, err := s.api.SetProviderNetworkConfig(params.SetProviderNetworkConfig{
+		Args: []params.ProviderNetworkConfig{
+			{
+				Tag: "machine-1",
+				Configs: []params.NetworkConfig{
+					{
+						InterfaceName:     "eth0",
+						MACAddress:        "00:00:00:00:00:00",
+						ProviderId:        "p-dev",
+						ProviderAddressId: "p-addr",
+						ProviderNetworkId: "p-net",
+						ProviderSubnetId:  "p-sub",
+						CIDR:              "10.0.0.0/24",
+						Addresses:         []params.Address{{Value: "10.0.0.42"}},
+					},
+					{
+						InterfaceName: "eth0",
+						MACAddress:    "00:00:00:00:00:00",
+						ProviderId:    "p-dev",
+						CIDR:          "10.0.0.0/24",
+						Addresses:     []params.Address{{Value: "10.0.0.43"}},
+					},
+				},
+			},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+
+	var buildCalled bool
+	for _, call := range s.st.Calls() {
+		if call.FuncName == "ApplyOperation.Build" {
+			buildCalled = true
+			c.Check(call.Args, gc.DeepEquals, []interface{}{[]txn.Op{
+				{C: "machine-alive"},
+				{C: "add-addr"},
+				{C: "add-subnet", Id: "10.0.0.0/24"},
+				{C: "machine-network-changed"},
+			}})
+		}
+	}
+	c.Assert(buildCalled, jc.IsTrue)
+}
+
+func (s *InstancePollerSuite) TestSetProviderNetworkAddsNewDevices(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	s.setDefaultSpaceInfo()
+
+	dev := mocks.NewMockLinkLayerDevice(ctrl)
+	dExp := dev.EXPECT()
+	dExp.MACAddress().Return("00:00:00:00:00:00").AnyTimes()
+	dExp.Name().Return("eth0").MinTimes(1)
+	dExp.ProviderID().Return(network.Id("p-dev"))
+	dExp.SetProviderIDOps(network.Id("p-dev")).Return(nil, nil)
+
+	s.st.SetMachineInfo(c, machineInfo{
+		id:               "1",
+		instanceStatus:   statusInfo("foo"),
+		linkLayerDevices: []networkingcommon.LinkLayerDevice{dev},
+		addresses:        []networkingcommon.LinkLayerAddress{},
+	})
+
+	result, err := s.api.SetProviderNetworkConfig(params.SetProviderNetworkConfig{
+		Args: []params.ProviderNetworkConfig{
+			{
+				Tag: "machine-1",
+				Configs: []params.NetworkConfig{
+					{
+						InterfaceName: "eth0",
+						MACAddress:    "00:00:00:00:00:00",
+						ProviderId:    "p-dev",
+					},
+					{
+						// This device was attached on the provider side.
+						InterfaceName: "eth1",
+						MACAddress:    "00:00:00:00:00:01",
+						ProviderId:    "p-dev-1",
+						CIDR:          "10.0.1.0/24",
+						Addresses:     []params.Address{{Value: "10.0.1.5"}},
+					},
+				},
+			},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+
+	var buildCalled bool
+	for _, call := range s.st.Calls() {
+		switch call.FuncName {
+		case "AddLinkLayerDeviceOps":
+			devArgs := call.Args[0].(state.LinkLayerDeviceArgs)
+			c.Check(devArgs.Name, gc.Equals, "eth1")
+			c.Check(devArgs.MACAddress, gc.Equals, "00:00:00:00:00:01")
+			c.Check(devArgs.ProviderID, gc.Equals, network.Id("p-dev-1"))
+
+			addrArgs := call.Args[1].([]state.LinkLayerDeviceAddress)
+			c.Assert(addrArgs, gc.HasLen, 1)
+			c.Check(addrArgs[0].CIDRAddress, gc.Equals, "10.0.1.5/24")
+			c.Check(addrArgs[0].Origin, gc.Equals, network.OriginProvider)
+		case "ApplyOperation.Build":
+			buildCalled = true
+			c.Check(call.Args, gc.DeepEquals, []interface{}{[]txn.Op{
+				{C: "machine-alive"},
+				{C: "add-device", Id: "eth1"},
+				{C: "add-subnet", Id: "10.0.1.0/24"},
+				{C: "machine-network-changed"},
+			}})
+		}
+	}
+	c.Assert(buildCalled, jc.IsTrue)
+}
+
+func (s *InstancePollerSuite) TestSetProviderNetworkRemovesStaleProviderAddresses(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	s.setDefaultSpaceInfo()
+
+	dev := mocks.NewMockLinkLayerDevice(ctrl)
+	dExp := dev.EXPECT()
+	dExp.MACAddress().Return("00:00:00:00:00:00").AnyTimes()
+	dExp.Name().Return("eth0").MinTimes(1)
+	dExp.ProviderID().Return(network.Id("p-dev"))
+	dExp.SetProviderIDOps(network.Id("p-dev")).Return(nil, nil)
+
+	// The provider no longer reports this address, which it added.
+	providerAddr := mocks.NewMockLinkLayerAddress(ctrl)
+	pExp := providerAddr.EXPECT()
+	pExp.DeviceName().Return("eth0").MinTimes(1)
+	pExp.Value().Return("10.0.0.43").MinTimes(1)
+	pExp.Origin().Return(network.OriginProvider)
+	pExp.RemoveOps().Return([]txn.Op{{C: "remove-addr"}})
+
+	// This address is the machine agent's responsibility and is left alone.
+	machineAddr := mocks.NewMockLinkLayerAddress(ctrl)
+	mExp := machineAddr.EXPECT()
+	mExp.DeviceName().Return("eth0")
+	mExp.Value().Return("10.0.0.44")
+	mExp.Origin().Return(network.OriginMachine)
+	mExp.SetOriginOps(network.OriginMachine).Return(nil)
+
+	s.st.SetMachineInfo(c, machineInfo{
+		id:               "1",
+		instanceStatus:   statusInfo("foo"),
+		linkLayerDevices: []networkingcommon.LinkLayerDevice{dev},
+		addresses:        []networkingcommon.LinkLayerAddress{providerAddr, machineAddr},
+	})
+
+	result, err := s.api.SetProviderNetworkConfig(params.SetProviderNetworkConfig{
+		Args: []params.ProviderNetworkConfig{
+			{
+				Tag: "machine-1",
+				Configs: []params.NetworkConfig{
+					{
+						InterfaceName: "eth0",
+						MACAddress:    "00:00:00:00:00:00",
+						ProviderId:    "p-dev",
+					},
+				},
+			},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+
+	var buildCalled bool
+	for _, call := range s.st.Calls() {
+		if call.FuncName == "ApplyOperation.Build" {
+			buildCalled = true
+			c.Check(call.Args, gc.DeepEquals, []interface{}{[]txn.Op{
+				{C: "machine-alive"},
+				{C: "remove-addr"},
+				{C: "machine-network-changed"},
+			}})
+		}
+	}
+	c.Assert(buildCalled, jc.IsTrue)
+}
+
+func (s *InstancePollerSuite) TestSetProviderNetworkAddressesOnlyDoesNotRemove(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	s.setDefaultSpaceInfo()
+
+	dev := mocks.NewMockLinkLayerDevice(ctrl)
+	dExp := dev.EXPECT()
+	dExp.MACAddress().Return("00:00:00:00:00:00").AnyTimes()
+	dExp.Name().Return("eth0").MinTimes(1)
+	dExp.SetProviderIDOps(network.Id("")).Return(nil, nil)
+
+	// Without device names or hardware addresses, we can not tell that the
+	// address was removed, so it is relinquished to the machine agent.
+	addr := mocks.NewMockLinkLayerAddress(ctrl)
+	addr.EXPECT().DeviceName().Return("eth0")
+	addr.EXPECT().SetOriginOps(network.OriginMachine).Return([]txn.Op{{C: "address-origin-manual"}})
+
+	s.st.SetMachineInfo(c, machineInfo{
+		id:               "1",
+		instanceStatus:   statusInfo("foo"),
+		linkLayerDevices: []networkingcommon.LinkLayerDevice{dev},
+		addresses:        []networkingcommon.LinkLayerAddress{addr},
+	})
+
+	result, err := s.api.SetProviderNetworkConfig(params.SetProviderNetworkConfig{
+		Args: []params.ProviderNetworkConfig{
+			{
+				Tag: "machine-1",
+				Configs: []params.NetworkConfig{{
+					CIDR:      "10.0.0.0/24",
+					Addresses: []params.Address{{Value: "10.0.0.42"}},
+				}},
+			},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+
+	var buildCalled bool
+	for _, call := range s.st.Calls() {
+		c.Check(call.FuncName, gc.Not(gc.Equals), "AddLinkLayerDeviceOps")
+		if call.FuncName == "ApplyOperation.Build" {
+			buildCalled = true
+			c.Check(call.Args, gc.DeepEquals, []interface{}{[]txn.Op{
+				{C: "machine-alive"},
+				{C: "address-origin-manual"},
+				{C: "machine-network-changed"},
 			}})
 		}
 	}
@@ -16,12 +16,32 @@ import (
 	"github.com/juju/juju/state"
 )
 
+// mergeMachine describes a machine whose link-layer data
+// can be merged with provider-sourced network configuration.
+type mergeMachine interface {
+	networkingcommon.LinkLayerMachine
+
+	// NetworkChangedOp returns a transaction operation that records a change
+	// to the machine's link-layer data, so that units on the machine refresh
+	// their network info.
+	NetworkChangedOp() txn.Op
+}
+
 // mergeMachineLinkLayerOp is a model operation used to merge incoming
 // provider-sourced network configuration with existing data for a single
 // machine/host/container.
 type mergeMachineLinkLayerOp struct {
 	*networkingcommon.MachineLinkLayerOp
 
+	machine mergeMachine
+	st      networkingcommon.AddSubnetsState
+
+	// authoritative is true if the incoming devices are identified by name
+	// or hardware address. Providers that do not support network interface
+	// discovery supply only addresses, which we can not use to determine
+	// that devices or addresses have been removed.
+	authoritative bool
+
 	// namelessHWAddrs stores the hardware addresses of
 	// incoming devices that have no accompanying name.
 	namelessHWAddrs set.Strings
@@ -30,13 +50,28 @@ type mergeMachineLinkLayerOp struct {
 	// We consult it to ensure that the same provider ID is not being
 	// used for multiple NICs.
 	providerIDs map[network.Id]string
+
+	// discoveredCIDRs records the subnets for which we have
+	// generated operations, so that each is only added once.
+	discoveredCIDRs set.Strings
 }
 
 func newMergeMachineLinkLayerOp(
-	machine networkingcommon.LinkLayerMachine, incoming network.InterfaceInfos,
+	machine mergeMachine, incoming network.InterfaceInfos, st networkingcommon.AddSubnetsState,
 ) *mergeMachineLinkLayerOp {
+	var authoritative bool
+	for _, dev := range incoming {
+		if dev.InterfaceName != "" || dev.MACAddress != "" {
+			authoritative = true
+			break
+		}
+	}
+
 	return &mergeMachineLinkLayerOp{
 		MachineLinkLayerOp: networkingcommon.NewMachineLinkLayerOp(machine, incoming),
+		machine:            machine,
+		st:                 st,
+		authoritative:      authoritative,
 		namelessHWAddrs:    set.NewStrings(),
 	}
 }
@@ -46,6 +81,7 @@ func newMergeMachineLinkLayerOp(
 func (o *mergeMachineLinkLayerOp) Build(attempt int) ([]txn.Op, error) {
 	o.ClearProcessed()
 	o.providerIDs = make(map[network.Id]string)
+	o.discoveredCIDRs = set.NewStrings()
 
 	if err := o.PopulateExistingDevices(); err != nil {
 		return nil, errors.Trace(err)
@@ -77,10 +113,17 @@ func (o *mergeMachineLinkLayerOp) Build(attempt int) ([]txn.Op, error) {
 		ops = append(ops, devOps...)
 	}
 
-	o.processNewDevices()
+	newDevOps, err := o.processNewDevices()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, newDevOps...)
 
+	// Any change to the link-layer data is recorded against the machine,
+	// so that units on it refresh their network info.
 	if len(ops) > 0 {
-		return append([]txn.Op{o.AssertAliveOp()}, ops...), nil
+		ops = append([]txn.Op{o.AssertAliveOp()}, ops...)
+		return append(ops, o.machine.NetworkChangedOp()), nil
 	}
 	return ops, nil
 }
@@ -198,9 +241,8 @@ func (o *mergeMachineLinkLayerOp) processExistingDevice(dev networkingcommon.Lin
 	}
 
 	// Collect normalised addresses for the incoming device.
-	// TODO (manadart 2020-07-15): We also need to set shadow addresses.
-	// These are sent where appropriate by the provider,
-	// but we do not yet process them.
+	// Shadow addresses are not assigned to devices; they are recorded
+	// as machine provider addresses before the merge is applied.
 	incomingAddrs := o.MatchingIncomingAddrs(dev.Name())
 
 	for _, addr := range o.DeviceAddresses(dev) {
@@ -211,15 +253,18 @@ func (o *mergeMachineLinkLayerOp) processExistingDevice(dev networkingcommon.Lin
 		ops = append(ops, addrOps...)
 	}
 
-	// TODO (manadart 2020-07-15): Process (log) new addresses on the device.
+	newAddrOps, err := o.processExistingDeviceNewAddresses(dev, incomingAddrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	o.MarkDevProcessed(dev.Name())
-	return ops, nil
+	return append(ops, newAddrOps...), nil
 }
 
 // opsForDeviceOriginRelinquishment returns transaction operations required to
-// ensure that a device has no provider ID and that the origin for all
-// addresses on the device is relinquished to the machine.
+// ensure that a device has no provider ID and that the addresses on the
+// device are either relinquished to the machine or removed.
 func (o *mergeMachineLinkLayerOp) opsForDeviceOriginRelinquishment(
 	dev networkingcommon.LinkLayerDevice,
 ) ([]txn.Op, error) {
@@ -229,12 +274,25 @@ func (o *mergeMachineLinkLayerOp) opsForDeviceOriginRelinquishment(
 	}
 
 	for _, addr := range o.DeviceAddresses(dev) {
-		ops = append(ops, addr.SetOriginOps(network.OriginMachine)...)
+		ops = append(ops, o.opsForUnobservedAddress(addr)...)
 	}
 
 	return ops, nil
 }
 
+// opsForUnobservedAddress returns transaction operations for an existing
+// address that is not present in the incoming provider data.
+// If the provider is the authority for the address and the incoming data
+// is authoritative, the address no longer exists and is removed.
+// Otherwise responsibility for the address is relinquished to the machiner.
+func (o *mergeMachineLinkLayerOp) opsForUnobservedAddress(addr networkingcommon.LinkLayerAddress) []txn.Op {
+	if o.authoritative && addr.Origin() == network.OriginProvider {
+		logger.Infof("removing stale provider address %q from device %q", addr.Value(), addr.DeviceName())
+		return addr.RemoveOps()
+	}
+	return addr.SetOriginOps(network.OriginMachine)
+}
+
 func (o *mergeMachineLinkLayerOp) processExistingDeviceAddress(
 	dev networkingcommon.LinkLayerDevice,
 	addr networkingcommon.LinkLayerAddress,
@@ -263,23 +321,118 @@ func (o *mergeMachineLinkLayerOp) processExistingDeviceAddress(
 		}
 	}
 
-	// Otherwise relinquish responsibility for this device to the machiner.
-	return addr.SetOriginOps(network.OriginMachine), nil
+	return o.opsForUnobservedAddress(addr), nil
+}
+
+// processExistingDeviceNewAddresses adds any incoming addresses for the
+// device that were not matched to one we already have in state.
+// This allows addresses assigned on the provider side after the machine agent
+// last reported its configuration to converge on the next poll.
+// The provider is recorded as the origin of such addresses.
+func (o *mergeMachineLinkLayerOp) processExistingDeviceNewAddresses(
+	dev networkingcommon.LinkLayerDevice, incomingAddrs []state.LinkLayerDeviceAddress,
+) ([]txn.Op, error) {
+	var ops []txn.Op
+	name := dev.Name()
+	for _, addr := range incomingAddrs {
+		addrValue := strings.Split(addr.CIDRAddress, "/")[0]
+		if o.IsAddrProcessed(name, addrValue) {
+			continue
+		}
+
+		logger.Infof("adding provider address %q to device %q", addr.CIDRAddress, name)
+
+		addr.Origin = network.OriginProvider
+		addOps, err := dev.AddAddressOps(addr)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, addOps...)
+
+		o.MarkAddrProcessed(name, addrValue)
+	}
+
+	if len(ops) == 0 {
+		return nil, nil
+	}
+
+	subnetOps, err := o.processSubnets(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, subnetOps...), nil
 }
 
 // processNewDevices handles incoming devices that did not match any we already
-// have in state.
-// TODO (manadart 2020-06-12): It should be unlikely for the provider to be
-// aware of devices that the machiner knows nothing about.
-// At the time of writing we preserve existing behaviour and do not add them.
-// Log for now and consider adding such devices in the future.
-func (o *mergeMachineLinkLayerOp) processNewDevices() {
+// have in state. Such devices have been attached on the provider side since
+// the machine agent last reported its configuration.
+// They are added along with their addresses, for which the provider is
+// recorded as the origin. The machine agent takes over responsibility for
+// them when it next reports its configuration.
+// Devices without a name can not be added; these can only be matched to
+// existing devices by hardware address.
+func (o *mergeMachineLinkLayerOp) processNewDevices() ([]txn.Op, error) {
+	var ops []txn.Op
 	for _, dev := range o.Incoming() {
-		if !o.IsDevProcessed(dev) {
+		if o.IsDevProcessed(dev) {
+			continue
+		}
+
+		if dev.InterfaceName == "" {
 			logger.Debugf(
-				"ignoring unrecognised device %q (%s) with addresses %v",
-				dev.InterfaceName, dev.MACAddress, dev.Addresses,
+				"ignoring unrecognised device with hardware address %q and addresses %v",
+				dev.MACAddress, dev.Addresses,
 			)
+			continue
+		}
+
+		addrs := o.MatchingIncomingAddrs(dev.InterfaceName)
+		addrValues := make([]string, len(addrs))
+		for i := range addrs {
+			addrs[i].Origin = network.OriginProvider
+			addrValues[i] = addrs[i].CIDRAddress
+		}
+
+		logger.Infof("machine %q: adding provider device %q (%s) with addresses %v",
+			o.machine.Id(), dev.InterfaceName, dev.MACAddress, addrValues)
+
+		addOps, err := o.AddDeviceOps(dev, addrs...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, addOps...)
+
+		subnetOps, err := o.processSubnets(dev.InterfaceName)
+		if err != nil {
+			return nil, errors.Trace(err)
 		}
+		ops = append(ops, subnetOps...)
+
+		o.MarkDevProcessed(dev.InterfaceName)
 	}
+	return ops, nil
+}
+
+// processSubnets ensures that the subnets of incoming
+// addresses on the device with the input name are present in state.
+// Loopback subnets are ignored.
+func (o *mergeMachineLinkLayerOp) processSubnets(name string) ([]txn.Op, error) {
+	var ops []txn.Op
+	for _, dev := range o.Incoming().GetByName(name) {
+		cidr := dev.CIDR
+		if cidr == "" || dev.InterfaceType == network.LoopbackInterface || o.discoveredCIDRs.Contains(cidr) {
+			continue
+		}
+		o.discoveredCIDRs.Add(cidr)
+
+		addOps, err := o.st.AddSubnetOps(network.SubnetInfo{CIDR: cidr})
+		if err != nil {
+			if errors.IsAlreadyExists(err) {
+				continue
+			}
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, addOps...)
+	}
+	return ops, nil
 }
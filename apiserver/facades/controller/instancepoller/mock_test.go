@@ -234,6 +234,12 @@ func (m *mockState) Machine(id string) (instancepoller.StateMachine, error) {
 	return machine, nil
 }
 
+// AddSubnetOps implements StateInterface.
+func (m *mockState) AddSubnetOps(args network.SubnetInfo) ([]txn.Op, error) {
+	m.MethodCall(m, "AddSubnetOps", args)
+	return []txn.Op{{C: "add-subnet", Id: args.CIDR}}, nil
+}
+
 // AllSpaceInfos implements network.AllSpaceInfos.
 // This method never throws an error.
 func (m *mockState) AllSpaceInfos() (network.SpaceInfos, error) {
@@ -359,6 +365,17 @@ func (m *mockMachine) AllAddresses() ([]networkingcommon.LinkLayerAddress, error
 	return m.addresses, nil
 }
 
+// AddLinkLayerDeviceOps implements StateMachine.
+func (m *mockMachine) AddLinkLayerDeviceOps(
+	devArgs state.LinkLayerDeviceArgs, addrArgs ...state.LinkLayerDeviceAddress,
+) ([]txn.Op, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MethodCall(m, "AddLinkLayerDeviceOps", devArgs, addrArgs)
+	return []txn.Op{{C: "add-device", Id: devArgs.Name}}, nil
+}
+
 // InstanceStatus implements StateMachine.
 func (m *mockMachine) InstanceStatus() (status.StatusInfo, error) {
 	m.mu.Lock()
@@ -421,6 +438,15 @@ func (m *mockMachine) AssertAliveOp() txn.Op {
 	return txn.Op{C: "machine-alive"}
 }
 
+// NetworkChangedOp implements StateMachine.
+func (m *mockMachine) NetworkChangedOp() txn.Op {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MethodCall(m, "NetworkChangedOp")
+	return txn.Op{C: "machine-network-changed"}
+}
+
 type mockBaseWatcher struct {
 	err error
 
@@ -4,6 +4,8 @@
 package instancepoller
 
 import (
+	"gopkg.in/mgo.v2/txn"
+
 	"github.com/juju/juju/apiserver/common/networkingcommon"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/network"
@@ -27,6 +29,10 @@ type StateMachine interface {
 	Life() state.Life
 	Status() (status.StatusInfo, error)
 	IsManual() (bool, error)
+
+	// NetworkChangedOp returns a transaction operation that records a change
+	// to the machine's link-layer data.
+	NetworkChangedOp() txn.Op
 }
 
 type StateInterface interface {
@@ -34,6 +40,7 @@ type StateInterface interface {
 	state.ModelMachinesWatcher
 	state.EntityFinder
 	network.SpaceLookup
+	networkingcommon.AddSubnetsState
 
 	Machine(id string) (StateMachine, error)
 
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	constraints "github.com/juju/juju/core/constraints"
 	migration "github.com/juju/juju/migration"
 	resource "github.com/juju/juju/resource"
 	state "github.com/juju/juju/state"
@@ -202,6 +203,36 @@ func (mr *MockPrecheckBackendMockRecorder) Model() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Model", reflect.TypeOf((*MockPrecheckBackend)(nil).Model))
 }
 
+// ModelConstraints mocks base method
+func (m *MockPrecheckBackend) ModelConstraints() (constraints.Value, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModelConstraints")
+	ret0, _ := ret[0].(constraints.Value)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModelConstraints indicates an expected call of ModelConstraints
+func (mr *MockPrecheckBackendMockRecorder) ModelConstraints() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModelConstraints", reflect.TypeOf((*MockPrecheckBackend)(nil).ModelConstraints))
+}
+
+// ModelSpec mocks base method
+func (m *MockPrecheckBackend) ModelSpec() (state.ModelSpec, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModelSpec")
+	ret0, _ := ret[0].(state.ModelSpec)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModelSpec indicates an expected call of ModelSpec
+func (mr *MockPrecheckBackendMockRecorder) ModelSpec() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModelSpec", reflect.TypeOf((*MockPrecheckBackend)(nil).ModelSpec))
+}
+
 // NeedsCleanup mocks base method
 func (m *MockPrecheckBackend) NeedsCleanup() (bool, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,101 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelreconciler implements the API used by the model
+// reconciler worker, which reconciles a model towards the declarative
+// spec set with juju apply.
+package modelreconciler
+
+import (
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/modelspec"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.modelreconciler")
+
+// ModelReconcilerAPI implements the API used by the model reconciler
+// worker.
+type ModelReconcilerAPI struct {
+	st        *state.State
+	resources facade.Resources
+	clock     clock.Clock
+}
+
+// NewModelReconcilerAPI creates a new instance of the ModelReconciler API.
+func NewModelReconcilerAPI(ctx facade.Context) (*ModelReconcilerAPI, error) {
+	return NewAPI(ctx.State(), ctx.Resources(), ctx.Auth(), clock.WallClock)
+}
+
+// NewAPI creates a new instance of the ModelReconciler API with the
+// given dependencies.
+func NewAPI(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+	clock clock.Clock,
+) (*ModelReconcilerAPI, error) {
+	if !authorizer.AuthController() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &ModelReconcilerAPI{
+		st:        st,
+		resources: resources,
+		clock:     clock,
+	}, nil
+}
+
+// WatchModelSpec returns a watcher which notifies when the model's spec
+// is set or removed.
+func (api *ModelReconcilerAPI) WatchModelSpec() (params.NotifyWatchResult, error) {
+	watch := api.st.WatchModelSpec()
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: api.resources.Register(watch),
+		}, nil
+	}
+	return params.NotifyWatchResult{
+		Error: apiservererrors.ServerError(watcher.EnsureErr(watch)),
+	}, nil
+}
+
+// Reconcile reconciles the model towards its spec, if it has one, and
+// records what could not be reconciled in the model's spec status.
+func (api *ModelReconcilerAPI) Reconcile() error {
+	spec, err := api.st.ModelSpec()
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	parsed, err := modelspec.Parse([]byte(spec.Spec))
+	if err != nil {
+		// The spec is validated when it is set, so this
+		// should never happen.
+		return errors.Annotatef(err, "model spec revision %d", spec.Revision)
+	}
+	r, err := newReconciler(api.st, parsed)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := r.reconcile(); err != nil {
+		return errors.Trace(err)
+	}
+	err = api.st.SetModelSpecStatus(state.ModelSpecStatus{
+		Revision:   spec.Revision,
+		Reconciled: api.clock.Now().UTC(),
+		Drift:      r.drift,
+	})
+	if errors.IsNotFound(err) {
+		// The spec was removed while reconciling.
+		return nil
+	}
+	return errors.Trace(err)
+}
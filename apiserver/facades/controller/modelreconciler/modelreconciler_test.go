@@ -0,0 +1,253 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelreconciler_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facades/controller/modelreconciler"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/model"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type modelReconcilerSuite struct {
+	jujutesting.JujuConnSuite
+
+	clock     *testclock.Clock
+	resources *common.Resources
+	api       *modelreconciler.ModelReconcilerAPI
+	wordpress *state.Application
+	mysql     *state.Application
+}
+
+var _ = gc.Suite(&modelReconcilerSuite{})
+
+func (s *modelReconcilerSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2021, 5, 4, 3, 2, 1, 0, time.UTC))
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+
+	authorizer := apiservertesting.FakeAuthorizer{Controller: true}
+	api, err := modelreconciler.NewAPI(s.State, s.resources, authorizer, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+
+	s.wordpress = s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.mysql = s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+}
+
+func (s *modelReconcilerSuite) setSpec(c *gc.C, spec string) {
+	_, err := s.State.SetModelSpec(spec)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *modelReconcilerSuite) assertDrift(c *gc.C, drift ...string) {
+	status, err := s.State.ModelSpecStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Reconciled, gc.Equals, s.clock.Now().UTC())
+	c.Assert(status.Drift, jc.DeepEquals, drift)
+}
+
+func (s *modelReconcilerSuite) TestNewAPIRequiresController(c *gc.C) {
+	authorizer := apiservertesting.FakeAuthorizer{Controller: false}
+	api, err := modelreconciler.NewAPI(s.State, s.resources, authorizer, s.clock)
+	c.Assert(api, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(apiservererrors.ServerError(err), jc.Satisfies, params.IsCodeUnauthorized)
+}
+
+func (s *modelReconcilerSuite) TestWatchModelSpec(c *gc.C) {
+	result, err := s.api.WatchModelSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.NotifyWatcherId, gc.Equals, "1")
+
+	resource := s.resources.Get("1")
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	s.setSpec(c, "applications: {}\n")
+	wc.AssertOneChange()
+}
+
+func (s *modelReconcilerSuite) TestReconcileNoSpec(c *gc.C) {
+	err := s.api.Reconcile()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ModelSpecStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *modelReconcilerSuite) TestReconcileUnits(c *gc.C) {
+	s.setSpec(c, `
+applications:
+  wordpress:
+    charm: local:quantal/wordpress
+    units: 2
+  mysql:
+    charm: local:quantal/mysql
+`)
+	err := s.api.Reconcile()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertDrift(c)
+	s.assertUnits(c, s.wordpress, "wordpress/0", "wordpress/1")
+	// The number of units is left alone if not in the spec.
+	s.assertUnits(c, s.mysql)
+
+	s.setSpec(c, `
+applications:
+  wordpress:
+    charm: local:quantal/wordpress
+    units: 1
+`)
+	err = s.api.Reconcile()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertDrift(c)
+	// The newest unit is removed.
+	s.assertUnits(c, s.wordpress, "wordpress/0")
+}
+
+func (s *modelReconcilerSuite) assertUnits(c *gc.C, app *state.Application, expected ...string) {
+	units, err := app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	var alive []string
+	for _, unit := range units {
+		if unit.Life() == state.Alive {
+			alive = append(alive, unit.Name())
+		}
+	}
+	c.Assert(alive, jc.SameContents, expected)
+}
+
+func (s *modelReconcilerSuite) TestReconcileConfig(c *gc.C) {
+	s.setSpec(c, `
+applications:
+  wordpress:
+    charm: local:quantal/wordpress
+    config:
+      blog-title: Spec Title
+`)
+	err := s.api.Reconcile()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertDrift(c)
+	cfg, err := s.wordpress.CharmConfig(model.GenerationMaster)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg["blog-title"], gc.Equals, "Spec Title")
+}
+
+func (s *modelReconcilerSuite) TestReconcileInvalidConfig(c *gc.C) {
+	s.setSpec(c, `
+applications:
+  wordpress:
+    charm: local:quantal/wordpress
+    config:
+      no-such-option: foo
+`)
+	err := s.api.Reconcile()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertDrift(c, `config of application "wordpress" is not valid: unknown option "no-such-option"`)
+}
+
+func (s *modelReconcilerSuite) TestReconcileRelations(c *gc.C) {
+	s.setSpec(c, `
+applications:
+  wordpress:
+    charm: local:quantal/wordpress
+  mysql:
+    charm: local:quantal/mysql
+relations:
+- [wordpress, mysql]
+`)
+	err := s.api.Reconcile()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertDrift(c)
+	rels, err := s.wordpress.Relations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rels, gc.HasLen, 1)
+	c.Assert(rels[0].String(), gc.Equals, "wordpress:db mysql:server")
+
+	// Reconciling again leaves the relation alone.
+	err = s.api.Reconcile()
+	c.Assert(err, jc.ErrorIsNil)
+	rels, err = s.wordpress.Relations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rels, gc.HasLen, 1)
+}
+
+func (s *modelReconcilerSuite) TestReconcileOffers(c *gc.C) {
+	s.setSpec(c, `
+applications:
+  mysql:
+    charm: local:quantal/mysql
+offers:
+  hosted-mysql:
+    application: mysql
+    endpoints: [server]
+`)
+	err := s.api.Reconcile()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertDrift(c)
+	offer, err := state.NewApplicationOffers(s.State).ApplicationOffer("hosted-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offer.ApplicationName, gc.Equals, "mysql")
+	c.Assert(offer.Endpoints, gc.HasLen, 1)
+	c.Assert(offer.Endpoints["server"].Name, gc.Equals, "server")
+
+	s.setSpec(c, `
+applications:
+  mysql:
+    charm: local:quantal/mysql
+offers:
+  hosted-mysql:
+    application: mysql
+    endpoints: [server, server-admin]
+`)
+	err = s.api.Reconcile()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertDrift(c)
+	offer, err = state.NewApplicationOffers(s.State).ApplicationOffer("hosted-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offer.Endpoints, gc.HasLen, 2)
+}
+
+func (s *modelReconcilerSuite) TestReconcileDrift(c *gc.C) {
+	s.setSpec(c, `
+applications:
+  wordpress:
+    charm: local:quantal/wordpress-99
+  mysql:
+    charm: local:quantal/mysql
+  varnish:
+    charm: cs:varnish
+relations:
+- [wordpress, varnish]
+- [wordpress:db, mysql:server-admin]
+offers:
+  hosted-varnish:
+    application: varnish
+    endpoints: [webcache]
+`)
+	err := s.api.Reconcile()
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := s.wordpress.CharmURL()
+	// The relation and offer of the missing application are not
+	// reported separately.
+	s.assertDrift(c,
+		`application "varnish" is not deployed`,
+		fmt.Sprintf(`application "wordpress" has charm %s, not local:quantal/wordpress-99`, curl),
+		`cannot relate wordpress:db and mysql:server-admin: no relations found`,
+	)
+}
@@ -0,0 +1,287 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelreconciler
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/juju/charm/v9"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/modelspec"
+	"github.com/juju/juju/state"
+)
+
+// reconciler reconciles a model towards its spec. Applications are not
+// deployed, and their charms are not refreshed, as that needs charms and
+// resources that only the client can provide; these differences, and any
+// changes which fail, are recorded as drift.
+type reconciler struct {
+	st     *state.State
+	model  *state.Model
+	offers crossmodel.ApplicationOffers
+	spec   *modelspec.Spec
+
+	// notDeployed holds the names of the applications in the spec
+	// which are not deployed.
+	notDeployed set.Strings
+
+	// drift describes the differences between the model and its
+	// spec which could not be reconciled.
+	drift []string
+}
+
+func newReconciler(st *state.State, spec *modelspec.Spec) (*reconciler, error) {
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &reconciler{
+		st:          st,
+		model:       m,
+		offers:      state.NewApplicationOffers(st),
+		spec:        spec,
+		notDeployed: set.NewStrings(),
+	}, nil
+}
+
+func (r *reconciler) driftf(format string, args ...interface{}) {
+	r.drift = append(r.drift, fmt.Sprintf(format, args...))
+}
+
+func (r *reconciler) reconcile() error {
+	for _, name := range r.spec.ApplicationNames() {
+		if err := r.reconcileApplication(name, r.spec.Applications[name]); err != nil {
+			return errors.Annotatef(err, "reconciling application %q", name)
+		}
+	}
+	for _, relation := range r.spec.Relations {
+		if err := r.reconcileRelation(relation); err != nil {
+			return errors.Annotatef(err, "reconciling relation %q", strings.Join(relation, " "))
+		}
+	}
+	for _, name := range r.spec.OfferNames() {
+		if err := r.reconcileOffer(name, r.spec.Offers[name]); err != nil {
+			return errors.Annotatef(err, "reconciling offer %q", name)
+		}
+	}
+	return nil
+}
+
+func (r *reconciler) reconcileApplication(name string, spec modelspec.ApplicationSpec) error {
+	app, err := r.st.Application(name)
+	if errors.IsNotFound(err) {
+		r.notDeployed.Add(name)
+		r.driftf("application %q is not deployed", name)
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if app.Life() != state.Alive {
+		r.notDeployed.Add(name)
+		r.driftf("application %q is %s", name, app.Life())
+		return nil
+	}
+
+	curl, _ := app.CharmURL()
+	want, err := charm.ParseURL(spec.Charm)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !charmMatches(curl, want) {
+		r.driftf("application %q has charm %s, not %s", name, curl, spec.Charm)
+	}
+	if len(spec.Config) > 0 {
+		if err := r.reconcileConfig(app, spec.Config); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if spec.Units != nil && app.IsPrincipal() {
+		if err := r.reconcileUnits(app, *spec.Units); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// charmMatches returns whether the charm URL matches the URL in the spec,
+// which may leave out the series and revision.
+func charmMatches(curl, want *charm.URL) bool {
+	if curl == nil {
+		return false
+	}
+	if curl.Schema != want.Schema || curl.Name != want.Name {
+		return false
+	}
+	if want.Series != "" && curl.Series != want.Series {
+		return false
+	}
+	return want.Revision < 0 || curl.Revision == want.Revision
+}
+
+func (r *reconciler) reconcileConfig(app *state.Application, config map[string]interface{}) error {
+	ch, _, err := app.Charm()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	want, err := ch.Config().ValidateSettings(charm.Settings(config))
+	if err != nil {
+		r.driftf("config of application %q is not valid: %v", app.Name(), err)
+		return nil
+	}
+	current, err := app.CharmConfig(model.GenerationMaster)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	changes := make(charm.Settings)
+	for key, value := range want {
+		if !reflect.DeepEqual(current[key], value) {
+			changes[key] = value
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	if err := app.UpdateCharmConfig(model.GenerationMaster, changes); err != nil {
+		r.driftf("cannot update config of application %q: %v", app.Name(), err)
+		return nil
+	}
+	keys := make([]string, 0, len(changes))
+	for key := range changes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	logger.Infof("updated config %s of application %q", strings.Join(keys, ", "), app.Name())
+	return nil
+}
+
+func (r *reconciler) reconcileUnits(app *state.Application, want int) error {
+	if r.model.Type() == state.ModelTypeCAAS {
+		if app.GetScale() == want {
+			return nil
+		}
+		if err := app.SetScale(want, 0, true); err != nil {
+			r.driftf("cannot scale application %q to %d units: %v", app.Name(), want, err)
+			return nil
+		}
+		logger.Infof("scaled application %q to %d units", app.Name(), want)
+		return nil
+	}
+
+	units, err := app.AllUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var alive []*state.Unit
+	for _, unit := range units {
+		if unit.Life() == state.Alive {
+			alive = append(alive, unit)
+		}
+	}
+	for i := len(alive); i < want; i++ {
+		unit, err := app.AddUnit(state.AddUnitParams{})
+		if err != nil {
+			r.driftf("cannot add unit to application %q: %v", app.Name(), err)
+			return nil
+		}
+		if err := r.st.AssignUnit(unit, state.AssignCleanEmpty); err != nil {
+			r.driftf("cannot assign unit %s: %v", unit.Name(), err)
+			return nil
+		}
+		logger.Infof("added unit %s", unit.Name())
+	}
+	if len(alive) <= want {
+		return nil
+	}
+	// Remove the newest units first.
+	sort.Slice(alive, func(i, j int) bool {
+		return unitNumber(alive[i]) > unitNumber(alive[j])
+	})
+	for _, unit := range alive[:len(alive)-want] {
+		if err := unit.Destroy(); err != nil {
+			r.driftf("cannot remove unit %s: %v", unit.Name(), err)
+			return nil
+		}
+		logger.Infof("removed unit %s", unit.Name())
+	}
+	return nil
+}
+
+func unitNumber(unit *state.Unit) int {
+	n, _ := names.UnitNumber(unit.Name())
+	return n
+}
+
+func (r *reconciler) reconcileRelation(relation []string) error {
+	for _, endpoint := range relation {
+		if r.notDeployed.Contains(strings.SplitN(endpoint, ":", 2)[0]) {
+			// The missing application has already been reported.
+			return nil
+		}
+	}
+	eps, err := r.st.InferEndpoints(relation...)
+	if err != nil {
+		r.driftf("cannot relate %s: %v", strings.Join(relation, " and "), err)
+		return nil
+	}
+	if _, err := r.st.EndpointsRelation(eps...); err == nil {
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	rel, err := r.st.AddRelation(eps...)
+	if err != nil {
+		r.driftf("cannot relate %s: %v", strings.Join(relation, " and "), err)
+		return nil
+	}
+	logger.Infof("added relation %q", rel)
+	return nil
+}
+
+func (r *reconciler) reconcileOffer(name string, spec modelspec.OfferSpec) error {
+	if r.notDeployed.Contains(spec.Application) {
+		return nil
+	}
+	endpoints := make(map[string]string)
+	for _, endpoint := range spec.Endpoints {
+		endpoints[endpoint] = endpoint
+	}
+	args := crossmodel.AddApplicationOfferArgs{
+		OfferName:       name,
+		ApplicationName: spec.Application,
+		Endpoints:       endpoints,
+		Owner:           r.model.Owner().Id(),
+	}
+	offer, err := r.offers.ApplicationOffer(name)
+	if errors.IsNotFound(err) {
+		if _, err := r.offers.AddOffer(args); err != nil {
+			r.driftf("cannot add offer %q: %v", name, err)
+			return nil
+		}
+		logger.Infof("added offer %q", name)
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	current := set.NewStrings()
+	for endpoint := range offer.Endpoints {
+		current.Add(endpoint)
+	}
+	if offer.ApplicationName == spec.Application && reflect.DeepEqual(current.SortedValues(), set.NewStrings(spec.Endpoints...).SortedValues()) {
+		return nil
+	}
+	if _, err := r.offers.UpdateOffer(args); err != nil {
+		r.driftf("cannot update offer %q: %v", name, err)
+		return nil
+	}
+	logger.Infof("updated offer %q", name)
+	return nil
+}
@@ -241,6 +241,7 @@ type mockRelation struct {
 	endpoints             []state.Endpoint
 	endpointUnitsWatchers map[string]*mockRelationUnitsWatcher
 	appSettings           map[string]map[string]interface{}
+	keyGeneration         int
 }
 
 func newMockRelation(id int) *mockRelation {
@@ -279,6 +280,23 @@ func (r *mockRelation) Suspended() bool {
 	return r.suspended
 }
 
+func (r *mockRelation) KeyGeneration() int {
+	r.MethodCall(r, "KeyGeneration")
+	return r.keyGeneration
+}
+
+func (r *mockRelation) RotateKeys() (int, error) {
+	r.MethodCall(r, "RotateKeys")
+	if err := r.NextErr(); err != nil {
+		return 0, err
+	}
+	if r.keyGeneration == 0 {
+		return 0, errors.NotSupportedf("rotating keys of unencrypted relation")
+	}
+	r.keyGeneration++
+	return r.keyGeneration, nil
+}
+
 func (r *mockRelation) Unit(unitId string) (common.RelationUnit, error) {
 	r.MethodCall(r, "Unit", unitId)
 	if err := r.NextErr(); err != nil {
@@ -344,6 +362,7 @@ type mockRemoteApplication struct {
 	message       string
 	eps           []charm.Relation
 	consumerproxy bool
+	encrypt       bool
 }
 
 func newMockRemoteApplication(name, url string) *mockRemoteApplication {
@@ -372,6 +391,11 @@ func (r *mockRemoteApplication) IsConsumerProxy() bool {
 	return r.consumerproxy
 }
 
+func (r *mockRemoteApplication) EncryptRelationData() bool {
+	r.MethodCall(r, "EncryptRelationData")
+	return r.encrypt
+}
+
 func (r *mockRemoteApplication) Life() state.Life {
 	r.MethodCall(r, "Life")
 	return r.life
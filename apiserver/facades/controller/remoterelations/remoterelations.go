@@ -18,8 +18,13 @@ import (
 	"github.com/juju/juju/state/watcher"
 )
 
-// API provides access to version 1 of the remote relations API facade.
+// APIv1 provides access to version 1 of the remote relations API facade.
 type APIv1 struct {
+	*APIv2
+}
+
+// APIv2 provides access to version 2 of the remote relations API facade.
+type APIv2 struct {
 	*API
 }
 
@@ -31,15 +36,24 @@ type API struct {
 	authorizer facade.Authorizer
 }
 
-// NewAPI creates a new server-side API facade backed by global state.
+// NewAPIv1 creates a new server-side API facade backed by global state.
 func NewAPIv1(ctx facade.Context) (*APIv1, error) {
-	api, err := NewAPI(ctx)
+	api, err := NewAPIv2(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv1{api}, nil
 }
 
+// NewAPIv2 creates a new server-side API facade backed by global state.
+func NewAPIv2(ctx facade.Context) (*APIv2, error) {
+	api, err := NewAPI(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv2{api}, nil
+}
+
 // NewAPI creates a new server-side API facade backed by global state.
 func NewAPI(ctx facade.Context) (*API, error) {
 	return NewRemoteRelationsAPI(
@@ -147,6 +161,42 @@ func (api *API) SaveMacaroons(args params.EntityMacaroonArgs) (params.ErrorResul
 	return results, nil
 }
 
+// RotateRelationKeys increments the key generation of the given
+// cross-model relations, so that the related units replace the keys with
+// which they encrypt the relation settings, and returns the new
+// generations. It is called whenever a relation is registered with the
+// offering model, when the relation macaroon is also reissued.
+func (api *API) RotateRelationKeys(args params.Entities) (params.IntResults, error) {
+	results := params.IntResults{
+		Results: make([]params.IntResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		generation, err := api.rotateRelationKeys(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = generation
+	}
+	return results, nil
+}
+
+func (api *API) rotateRelationKeys(tag string) (int, error) {
+	relationTag, err := names.ParseRelationTag(tag)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	rel, err := api.st.KeyRelation(relationTag.Id())
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	generation, err := rel.RotateKeys()
+	return generation, errors.Trace(err)
+}
+
+// RotateRelationKeys is not available via the V2 API.
+func (api *APIv2) RotateRelationKeys(_, _ struct{}) {}
+
 // RelationUnitSettings returns the relation unit settings for the
 // given relation units in the local model. (Removed in v2 of the API
 // - the settings are included in the events from
@@ -277,12 +327,13 @@ func (api *API) RemoteApplications(entities params.Entities) (params.RemoteAppli
 			return nil, errors.Trace(err)
 		}
 		return &params.RemoteApplication{
-			Name:            remoteApp.Name(),
-			OfferUUID:       remoteApp.OfferUUID(),
-			Life:            life.Value(remoteApp.Life().String()),
-			ModelUUID:       remoteApp.SourceModel().Id(),
-			IsConsumerProxy: remoteApp.IsConsumerProxy(),
-			Macaroon:        mac,
+			Name:                remoteApp.Name(),
+			OfferUUID:           remoteApp.OfferUUID(),
+			Life:                life.Value(remoteApp.Life().String()),
+			ModelUUID:           remoteApp.SourceModel().Id(),
+			IsConsumerProxy:     remoteApp.IsConsumerProxy(),
+			Macaroon:            mac,
+			EncryptRelationData: remoteApp.EncryptRelationData(),
 		}, nil
 	}
 	for i, entity := range entities.Entities {
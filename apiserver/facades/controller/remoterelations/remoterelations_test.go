@@ -130,7 +130,7 @@ func (s *remoteRelationsSuite) TestWatchLocalRelationUnits(c *gc.C) {
 	s.st.applications["django"] = newMockApplication("django")
 
 	// WatchLocalRelationUnits has been removed from the V2 API.
-	api := &remoterelations.APIv1{s.api}
+	api := &remoterelations.APIv1{&remoterelations.APIv2{s.api}}
 	results, err := api.WatchLocalRelationUnits(params.Entities{[]params.Entity{
 		{"relation-django:db#db2:db"},
 		{"relation-hadoop:db#db2:db"},
@@ -353,6 +353,38 @@ func (s *remoteRelationsSuite) TestSaveMacaroons(c *gc.C) {
 	})
 }
 
+func (s *remoteRelationsSuite) TestRotateRelationKeys(c *gc.C) {
+	rel := newMockRelation(1)
+	rel.keyGeneration = 1
+	s.st.relations["mysql:db wordpress:db"] = rel
+	result, err := s.api.RotateRelationKeys(params.Entities{Entities: []params.Entity{
+		{Tag: "relation-mysql.db#wordpress.db"},
+		{Tag: "application-mysql"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, []params.IntResult{
+		{Result: 2},
+		{Error: &params.Error{Message: `"application-mysql" is not a valid relation tag`}},
+	})
+	s.st.CheckCalls(c, []testing.StubCall{
+		{"KeyRelation", []interface{}{"mysql:db wordpress:db"}},
+	})
+	rel.CheckCalls(c, []testing.StubCall{
+		{"RotateKeys", nil},
+	})
+}
+
+func (s *remoteRelationsSuite) TestRotateRelationKeysUnencrypted(c *gc.C) {
+	rel := newMockRelation(1)
+	s.st.relations["mysql:db wordpress:db"] = rel
+	result, err := s.api.RotateRelationKeys(params.Entities{Entities: []params.Entity{
+		{Tag: "relation-mysql.db#wordpress.db"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `rotating keys of unencrypted relation not supported`)
+}
+
 func (s *remoteRelationsSuite) TestRelationUnitSettings(c *gc.C) {
 	djangoRelationUnit := newMockRelationUnit()
 	djangoRelationUnit.settings["key"] = "value"
@@ -361,7 +393,7 @@ func (s *remoteRelationsSuite) TestRelationUnitSettings(c *gc.C) {
 	s.st.relations["db2:db django:db"] = db2Relation
 	s.st.applications["django"] = newMockApplication("django")
 	// RelationUnitSettings has been removed from the V2 API.
-	api := &remoterelations.APIv1{s.api}
+	api := &remoterelations.APIv1{&remoterelations.APIv2{s.api}}
 	result, err := api.RelationUnitSettings(params.RelationUnits{
 		RelationUnits: []params.RelationUnit{{Relation: "relation-db2.db#django.db", Unit: "unit-django-0"}}})
 	c.Assert(err, jc.ErrorIsNil)
@@ -372,18 +404,21 @@ func (s *remoteRelationsSuite) TestRelationUnitSettings(c *gc.C) {
 }
 
 func (s *remoteRelationsSuite) TestRemoteApplications(c *gc.C) {
-	s.st.remoteApplications["django"] = newMockRemoteApplication("django", "me/model.riak")
+	remoteApp := newMockRemoteApplication("django", "me/model.riak")
+	remoteApp.encrypt = true
+	s.st.remoteApplications["django"] = remoteApp
 	result, err := s.api.RemoteApplications(params.Entities{Entities: []params.Entity{{Tag: "application-django"}}})
 	c.Assert(err, jc.ErrorIsNil)
 	mac, err := apitesting.NewMacaroon("test")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result.Results, jc.DeepEquals, []params.RemoteApplicationResult{{
 		Result: &params.RemoteApplication{
-			Name:      "django",
-			OfferUUID: "django-uuid",
-			Life:      "alive",
-			ModelUUID: "model-uuid",
-			Macaroon:  mac,
+			Name:                "django",
+			OfferUUID:           "django-uuid",
+			Life:                "alive",
+			ModelUUID:           "model-uuid",
+			Macaroon:            mac,
+			EncryptRelationData: true,
 		}}})
 	s.st.CheckCalls(c, []testing.StubCall{
 		{"RemoteApplication", []interface{}{"django"}},
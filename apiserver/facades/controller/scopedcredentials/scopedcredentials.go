@@ -0,0 +1,148 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package scopedcredentials implements the API used by the worker which
+// issues and rotates the credentials scoped to trusted applications.
+package scopedcredentials
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facades/client/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloud"
+	coreapplication "github.com/juju/juju/core/application"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.scopedcredentials")
+
+// ScopedCredentialsAPI implements the API used by the scoped
+// credentials worker.
+type ScopedCredentialsAPI struct {
+	st *state.State
+}
+
+// NewScopedCredentialsAPI creates a new instance of the
+// ScopedCredentials API.
+func NewScopedCredentialsAPI(ctx facade.Context) (*ScopedCredentialsAPI, error) {
+	return NewAPI(ctx.State(), ctx.Auth())
+}
+
+// NewAPI creates a new instance of the ScopedCredentials API with the
+// given dependencies.
+func NewAPI(st *state.State, authorizer facade.Authorizer) (*ScopedCredentialsAPI, error) {
+	if !authorizer.AuthController() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &ScopedCredentialsAPI{st: st}, nil
+}
+
+// Applications returns the applications which are trusted and have
+// their trust scoped to them, with the expiry of the credential each
+// currently holds, and the applications which hold a scoped credential
+// they no longer need.
+func (api *ScopedCredentialsAPI) Applications() (params.ScopedCredentialApplications, error) {
+	apps, err := api.st.AllApplications()
+	if err != nil {
+		return params.ScopedCredentialApplications{}, errors.Trace(err)
+	}
+	result := params.ScopedCredentialApplications{
+		Applications: []params.ScopedCredentialApplication{},
+	}
+	for _, app := range apps {
+		if app.Life() != state.Alive {
+			continue
+		}
+		scoped, err := wantsScopedCredential(app)
+		if err != nil {
+			// A single application with bad config should not
+			// stop credentials being rotated for the others.
+			logger.Warningf("cannot determine trust scope of application %q: %v", app.Name(), err)
+			continue
+		}
+		var expiry *time.Time
+		credential, err := app.ScopedCredential()
+		if err == nil {
+			expiry = &credential.Expiry
+		} else if !errors.IsNotFound(err) {
+			return params.ScopedCredentialApplications{}, errors.Trace(err)
+		}
+		if !scoped && expiry == nil {
+			continue
+		}
+		result.Applications = append(result.Applications, params.ScopedCredentialApplication{
+			ApplicationTag: app.Tag().String(),
+			Expiry:         expiry,
+			Revoke:         !scoped,
+		})
+	}
+	return result, nil
+}
+
+func wantsScopedCredential(app *state.Application) (bool, error) {
+	config, err := app.ApplicationConfig()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if !config.GetBool(application.TrustConfigOptionName, false) {
+		return false, nil
+	}
+	scope, err := config.TrustScope()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return scope == coreapplication.TrustScopeApplication, nil
+}
+
+// SetScopedCredentials records the credentials issued for the use of
+// the given applications.
+func (api *ScopedCredentialsAPI) SetScopedCredentials(args params.SetScopedCredentialArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		app, err := api.application(arg.ApplicationTag)
+		if err == nil {
+			credential := cloud.NewCredential(cloud.AuthType(arg.Credential.AuthType), arg.Credential.Attributes)
+			err = app.SetScopedCredential(credential, arg.Expiry)
+		}
+		result.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
+
+// RemoveScopedCredentials removes the credentials recorded for the use
+// of the given applications, once they have been revoked.
+func (api *ScopedCredentialsAPI) RemoveScopedCredentials(args params.Entities) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		app, err := api.application(arg.Tag)
+		if errors.IsNotFound(err) {
+			// The credential was removed along with the application.
+			continue
+		}
+		if err == nil {
+			err = app.RemoveScopedCredential()
+		}
+		result.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
+
+func (api *ScopedCredentialsAPI) application(tagString string) (*state.Application, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := api.st.Application(tag.Id())
+	return app, errors.Trace(err)
+}
@@ -0,0 +1,159 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package scopedcredentials_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/environschema.v1"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facades/controller/scopedcredentials"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/cloud"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+)
+
+type scopedCredentialsSuite struct {
+	jujutesting.JujuConnSuite
+
+	api       *scopedcredentials.ScopedCredentialsAPI
+	wordpress *state.Application
+	mysql     *state.Application
+}
+
+var _ = gc.Suite(&scopedCredentialsSuite{})
+
+var expiry = time.Date(2021, 6, 7, 8, 9, 10, 0, time.UTC)
+
+func (s *scopedCredentialsSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+
+	authorizer := apiservertesting.FakeAuthorizer{Controller: true}
+	api, err := scopedcredentials.NewAPI(s.State, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+
+	s.wordpress = s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.mysql = s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+}
+
+func (s *scopedCredentialsSuite) setTrust(c *gc.C, app *state.Application, trust bool, scope string) {
+	conf := map[string]interface{}{
+		"trust":       trust,
+		"trust-scope": scope,
+	}
+	fields := environschema.Fields{
+		"trust":       {Type: environschema.Tbool},
+		"trust-scope": {Type: environschema.Tstring},
+	}
+	err := app.UpdateApplicationConfig(conf, nil, fields, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *scopedCredentialsSuite) setCredential(c *gc.C, app *state.Application) {
+	credential := cloud.NewCredential(cloud.OAuth2AuthType, map[string]string{"Token": "scoped-token"})
+	err := app.SetScopedCredential(credential, expiry)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *scopedCredentialsSuite) TestNewAPIRequiresController(c *gc.C) {
+	authorizer := apiservertesting.FakeAuthorizer{Controller: false}
+	api, err := scopedcredentials.NewAPI(s.State, authorizer)
+	c.Assert(api, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(apiservererrors.ServerError(err), jc.Satisfies, params.IsCodeUnauthorized)
+}
+
+func (s *scopedCredentialsSuite) TestApplicationsNone(c *gc.C) {
+	// Trust which is not scoped to the application needs no
+	// scoped credential.
+	s.setTrust(c, s.wordpress, true, "model")
+
+	result, err := s.api.Applications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Applications, gc.HasLen, 0)
+}
+
+func (s *scopedCredentialsSuite) TestApplicationsScoped(c *gc.C) {
+	s.setTrust(c, s.wordpress, true, "application")
+	s.setTrust(c, s.mysql, true, "application")
+	s.setCredential(c, s.mysql)
+
+	result, err := s.api.Applications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Applications, jc.SameContents, []params.ScopedCredentialApplication{{
+		ApplicationTag: "application-wordpress",
+	}, {
+		ApplicationTag: "application-mysql",
+		Expiry:         &expiry,
+	}})
+}
+
+func (s *scopedCredentialsSuite) TestApplicationsRevoke(c *gc.C) {
+	s.setTrust(c, s.wordpress, false, "application")
+	s.setCredential(c, s.wordpress)
+	s.setTrust(c, s.mysql, true, "model")
+	s.setCredential(c, s.mysql)
+
+	result, err := s.api.Applications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Applications, jc.SameContents, []params.ScopedCredentialApplication{{
+		ApplicationTag: "application-wordpress",
+		Expiry:         &expiry,
+		Revoke:         true,
+	}, {
+		ApplicationTag: "application-mysql",
+		Expiry:         &expiry,
+		Revoke:         true,
+	}})
+}
+
+func (s *scopedCredentialsSuite) TestSetScopedCredentials(c *gc.C) {
+	result, err := s.api.SetScopedCredentials(params.SetScopedCredentialArgs{
+		Args: []params.SetScopedCredentialArg{{
+			ApplicationTag: "application-wordpress",
+			Credential: params.CloudCredential{
+				AuthType:   "oauth2",
+				Attributes: map[string]string{"Token": "scoped-token"},
+			},
+			Expiry: expiry,
+		}, {
+			ApplicationTag: "application-unknown",
+		}, {
+			ApplicationTag: "unit-wordpress-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `application "unknown" not found`)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"unit-wordpress-0" is not a valid application tag`)
+
+	credential, err := s.wordpress.ScopedCredential()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(credential.Credential.Attributes(), jc.DeepEquals, map[string]string{"Token": "scoped-token"})
+	c.Assert(credential.Expiry, gc.Equals, expiry)
+}
+
+func (s *scopedCredentialsSuite) TestRemoveScopedCredentials(c *gc.C) {
+	s.setCredential(c, s.wordpress)
+
+	result, err := s.api.RemoveScopedCredentials(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-wordpress"},
+			{Tag: "application-unknown"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Combine(), jc.ErrorIsNil)
+
+	_, err = s.wordpress.ScopedCredential()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -0,0 +1,284 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// gatewayRoutePrefix is the path under which the read-only API gateway
+// is served.
+const gatewayRoutePrefix = "/gateway/v1"
+
+// gatewayHandler serves a query of the read-only API gateway, which
+// lets integrations that cannot speak the websocket RPC protocol read
+// the controller's models, their status and their offers as plain JSON.
+//
+// Requests must carry the controller's api-gateway-token as a bearer
+// token, which grants read access to every model on the controller.
+// The gateway is disabled while no token is configured.
+type gatewayHandler struct {
+	ctxt  httpContext
+	query gatewayQuery
+}
+
+// gatewayQuery answers a query of the API gateway. The model UUID is
+// empty for queries which are not about a model.
+type gatewayQuery func(pool *state.StatePool, modelUUID string) (interface{}, error)
+
+func (h *gatewayHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if err := h.serveGet(w, req); err != nil {
+		if err := sendError(w, errors.Trace(err)); err != nil {
+			logger.Errorf("%v", err)
+		}
+	}
+}
+
+func (h *gatewayHandler) serveGet(w http.ResponseWriter, req *http.Request) error {
+	const prefix = "Bearer "
+	auth := req.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, prefix) {
+		auth = ""
+	}
+	if err := authenticateGateway(h.ctxt, strings.TrimPrefix(auth, prefix)); err != nil {
+		return errors.Trace(err)
+	}
+	result, err := h.query(h.ctxt.srv.shared.statePool, req.URL.Query().Get(":modeluuid"))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(sendStatusAndJSON(w, http.StatusOK, result))
+}
+
+// authenticateGateway checks that the bearer token sent with a gateway
+// request is the gateway token.
+func authenticateGateway(ctxt httpContext, bearer string) error {
+	token := ctxt.srv.shared.apiGatewayToken()
+	if token == "" {
+		return errors.NotFoundf("API gateway")
+	}
+	if bearer == "" {
+		return errors.Unauthorizedf("API gateway token required")
+	}
+	if subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) != 1 {
+		return errors.Unauthorizedf("invalid API gateway token")
+	}
+	return nil
+}
+
+// gatewayModels returns all of the controller's models.
+func gatewayModels(pool *state.StatePool, _ string) (interface{}, error) {
+	uuids, err := pool.SystemState().AllModelUUIDs()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := params.GatewayModels{
+		Models: make([]params.GatewayModel, 0, len(uuids)),
+	}
+	for _, uuid := range uuids {
+		model, ph, err := pool.GetModel(uuid)
+		if errors.IsNotFound(err) {
+			// The model has been removed since listing them.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		details, err := gatewayModel(model)
+		ph.Release()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.Models = append(result.Models, details)
+	}
+	sort.Slice(result.Models, func(i, j int) bool {
+		return result.Models[i].Name < result.Models[j].Name
+	})
+	return result, nil
+}
+
+func gatewayModel(model *state.Model) (params.GatewayModel, error) {
+	status, err := model.Status()
+	if err != nil {
+		return params.GatewayModel{}, errors.Trace(err)
+	}
+	return params.GatewayModel{
+		UUID:    model.UUID(),
+		Name:    model.Name(),
+		Owner:   model.Owner().Id(),
+		Type:    string(model.Type()),
+		Life:    model.Life().String(),
+		Cloud:   model.CloudName(),
+		Region:  model.CloudRegion(),
+		Status:  string(status.Status),
+		Message: status.Message,
+	}, nil
+}
+
+// gatewayModelState returns the state and model of the model with the
+// given UUID. The state must be released after use.
+func gatewayModelState(pool *state.StatePool, uuid string) (*state.PooledState, *state.Model, error) {
+	if !names.IsValidModel(uuid) {
+		return nil, nil, errors.NotFoundf("model %q", uuid)
+	}
+	exists, err := pool.SystemState().ModelExists(uuid)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if !exists {
+		return nil, nil, errors.NotFoundf("model %q", uuid)
+	}
+	st, err := pool.Get(uuid)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	model, err := st.Model()
+	if err != nil {
+		st.Release()
+		return nil, nil, errors.Trace(err)
+	}
+	return st, model, nil
+}
+
+// gatewayModelStatus returns the status of the model's machines,
+// applications and units.
+func gatewayModelStatus(pool *state.StatePool, modelUUID string) (interface{}, error) {
+	st, model, err := gatewayModelState(pool, modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer st.Release()
+	details, err := gatewayModel(model)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := params.GatewayModelStatus{
+		Model:        details,
+		Machines:     []params.GatewayMachine{},
+		Applications: []params.GatewayApplication{},
+	}
+
+	machines, err := st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, m := range machines {
+		status, err := m.Status()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		instId, err := m.InstanceId()
+		if err != nil && !errors.IsNotProvisioned(err) {
+			return nil, errors.Trace(err)
+		}
+		result.Machines = append(result.Machines, params.GatewayMachine{
+			Id:         m.Id(),
+			InstanceId: string(instId),
+			Status:     string(status.Status),
+			Message:    status.Message,
+		})
+	}
+
+	applications, err := st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, app := range applications {
+		details, err := gatewayApplication(app)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.Applications = append(result.Applications, details)
+	}
+	sort.Slice(result.Applications, func(i, j int) bool {
+		return result.Applications[i].Name < result.Applications[j].Name
+	})
+	return result, nil
+}
+
+func gatewayApplication(app *state.Application) (params.GatewayApplication, error) {
+	status, err := app.Status()
+	if err != nil {
+		return params.GatewayApplication{}, errors.Trace(err)
+	}
+	result := params.GatewayApplication{
+		Name:    app.Name(),
+		Status:  string(status.Status),
+		Message: status.Message,
+		Units:   []params.GatewayUnit{},
+	}
+	if curl, _ := app.CharmURL(); curl != nil {
+		result.Charm = curl.String()
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return params.GatewayApplication{}, errors.Trace(err)
+	}
+	for _, unit := range units {
+		workload, err := unit.Status()
+		if err != nil {
+			return params.GatewayApplication{}, errors.Trace(err)
+		}
+		agent, err := unit.AgentStatus()
+		if err != nil {
+			return params.GatewayApplication{}, errors.Trace(err)
+		}
+		machineId, err := unit.AssignedMachineId()
+		if err != nil && !errors.IsNotAssigned(err) {
+			return params.GatewayApplication{}, errors.Trace(err)
+		}
+		result.Units = append(result.Units, params.GatewayUnit{
+			Name:            unit.Name(),
+			Machine:         machineId,
+			WorkloadStatus:  string(workload.Status),
+			WorkloadMessage: workload.Message,
+			AgentStatus:     string(agent.Status),
+		})
+	}
+	sort.Slice(result.Units, func(i, j int) bool {
+		return result.Units[i].Name < result.Units[j].Name
+	})
+	return result, nil
+}
+
+// gatewayOffers returns the application offers made from the model.
+func gatewayOffers(pool *state.StatePool, modelUUID string) (interface{}, error) {
+	st, _, err := gatewayModelState(pool, modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer st.Release()
+	offers, err := state.NewApplicationOffers(st.State).AllApplicationOffers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := params.GatewayOffers{
+		Offers: make([]params.GatewayOffer, 0, len(offers)),
+	}
+	for _, offer := range offers {
+		endpoints := make([]string, 0, len(offer.Endpoints))
+		for name := range offer.Endpoints {
+			endpoints = append(endpoints, name)
+		}
+		sort.Strings(endpoints)
+		result.Offers = append(result.Offers, params.GatewayOffer{
+			UUID:        offer.OfferUUID,
+			Name:        offer.OfferName,
+			Application: offer.ApplicationName,
+			Endpoints:   endpoints,
+		})
+	}
+	sort.Slice(result.Offers, func(i, j int) bool {
+		return result.Offers[i].Name < result.Offers[j].Name
+	})
+	return result, nil
+}
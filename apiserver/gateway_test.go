@@ -0,0 +1,265 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	jc "github.com/juju/testing/checkers"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/testing"
+	"github.com/juju/juju/apiserver/params"
+	corecontroller "github.com/juju/juju/controller"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+const gatewayToken = "0123456789abcdef-gateway"
+
+type gatewaySuite struct {
+	apiserverBaseSuite
+}
+
+var _ = gc.Suite(&gatewaySuite{})
+
+func (s *gatewaySuite) enableGateway(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		corecontroller.APIGatewayToken: gatewayToken,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	// The token is read from the controller config when the server
+	// starts, so restart it.
+	s.newServer(c, s.config)
+}
+
+func (s *gatewaySuite) get(c *gc.C, path, token string, expectStatus int) []byte {
+	headers := map[string]string{}
+	if token != "" {
+		headers["Authorization"] = "Bearer " + token
+	}
+	resp := apitesting.SendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method:       "GET",
+		URL:          s.URL(path, nil).String(),
+		ExtraHeaders: headers,
+	})
+	return apitesting.AssertResponse(c, resp, expectStatus, params.ContentTypeJSON)
+}
+
+func (s *gatewaySuite) TestDisabled(c *gc.C) {
+	body := s.get(c, "/gateway/v1/models", gatewayToken, http.StatusNotFound)
+	var result params.ErrorResult
+	err := json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("Body: %s", body))
+	c.Assert(result.Error.Message, gc.Equals, "API gateway not found")
+}
+
+func (s *gatewaySuite) TestTokenRequired(c *gc.C) {
+	s.enableGateway(c)
+	body := s.get(c, "/gateway/v1/models", "", http.StatusUnauthorized)
+	var result params.ErrorResult
+	err := json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("Body: %s", body))
+	c.Assert(result.Error.Message, gc.Equals, "API gateway token required")
+}
+
+func (s *gatewaySuite) TestInvalidToken(c *gc.C) {
+	s.enableGateway(c)
+	body := s.get(c, "/gateway/v1/models", "not-the-gateway-token", http.StatusUnauthorized)
+	var result params.ErrorResult
+	err := json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("Body: %s", body))
+	c.Assert(result.Error.Message, gc.Equals, "invalid API gateway token")
+}
+
+func (s *gatewaySuite) TestModels(c *gc.C) {
+	s.enableGateway(c)
+	body := s.get(c, "/gateway/v1/models", gatewayToken, http.StatusOK)
+	var result params.GatewayModels
+	err := json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("Body: %s", body))
+	c.Assert(result.Models, gc.HasLen, 1)
+	c.Assert(result.Models[0].UUID, gc.Equals, s.Model.UUID())
+	c.Assert(result.Models[0].Name, gc.Equals, s.Model.Name())
+	c.Assert(result.Models[0].Owner, gc.Equals, s.Model.Owner().Id())
+	c.Assert(result.Models[0].Life, gc.Equals, "alive")
+}
+
+func (s *gatewaySuite) TestModelStatus(c *gc.C) {
+	s.enableGateway(c)
+	app := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+
+	body := s.get(c, "/gateway/v1/models/"+s.Model.UUID()+"/status", gatewayToken, http.StatusOK)
+	var result params.GatewayModelStatus
+	err = json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("Body: %s", body))
+	c.Assert(result.Model.UUID, gc.Equals, s.Model.UUID())
+	c.Assert(result.Machines, gc.HasLen, 1)
+	c.Assert(result.Machines[0].Id, gc.Equals, machineId)
+	c.Assert(result.Applications, gc.HasLen, 1)
+	c.Assert(result.Applications[0].Name, gc.Equals, app.Name())
+	c.Assert(result.Applications[0].Units, gc.HasLen, 1)
+	c.Assert(result.Applications[0].Units[0].Name, gc.Equals, unit.Name())
+	c.Assert(result.Applications[0].Units[0].Machine, gc.Equals, machineId)
+}
+
+func (s *gatewaySuite) TestModelStatusNotFound(c *gc.C) {
+	s.enableGateway(c)
+	s.get(c, "/gateway/v1/models/deadbeef-0bad-400d-8000-4b1d0d06f00e/status", gatewayToken, http.StatusNotFound)
+}
+
+func (s *gatewaySuite) TestOffers(c *gc.C) {
+	s.enableGateway(c)
+	app := s.Factory.MakeApplication(c, nil)
+	offer, err := state.NewApplicationOffers(s.State).AddOffer(crossmodel.AddApplicationOfferArgs{
+		OfferName:       "hosted-db",
+		ApplicationName: app.Name(),
+		Endpoints:       map[string]string{"server": "server"},
+		Owner:           s.Owner.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	body := s.get(c, "/gateway/v1/models/"+s.Model.UUID()+"/offers", gatewayToken, http.StatusOK)
+	var result params.GatewayOffers
+	err = json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("Body: %s", body))
+	c.Assert(result, jc.DeepEquals, params.GatewayOffers{
+		Offers: []params.GatewayOffer{{
+			UUID:        offer.OfferUUID,
+			Name:        "hosted-db",
+			Application: app.Name(),
+			Endpoints:   []string{"server"},
+		}},
+	})
+}
+
+// dialGRPC returns a gRPC connection to the API server. gRPC needs
+// HTTP/2, which the suite's HTTP server doesn't offer, so another is
+// started in front of the same mux.
+func (s *gatewaySuite) dialGRPC(c *gc.C) *grpc.ClientConn {
+	server := httptest.NewUnstartedServer(s.mux)
+	server.TLS = s.tlsConfig.Clone()
+	server.TLS.NextProtos = []string{"h2"}
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	s.AddCleanup(func(c *gc.C) { server.Close() })
+
+	creds := credentials.NewTLS(&tls.Config{
+		RootCAs:    s.tlsConfig.RootCAs,
+		ServerName: s.tlsConfig.ServerName,
+	})
+	conn, err := grpc.Dial(server.Listener.Addr().String(), grpc.WithTransportCredentials(creds))
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { _ = conn.Close() })
+	return conn
+}
+
+func (s *gatewaySuite) invokeGRPC(c *gc.C, method, token string, in interface{}, out interface{}) error {
+	conn := s.dialGRPC(c)
+	ctx := context.Background()
+	if token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+	}
+	var result structpb.Struct
+	if err := conn.Invoke(ctx, "/juju.gateway.v1.Gateway/"+method, in, &result); err != nil {
+		return err
+	}
+	data, err := protojson.Marshal(&result)
+	c.Assert(err, jc.ErrorIsNil)
+	err = json.Unmarshal(data, out)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("Result: %s", data))
+	return nil
+}
+
+func (s *gatewaySuite) TestGRPCDisabled(c *gc.C) {
+	var result params.GatewayModels
+	err := s.invokeGRPC(c, "ListModels", gatewayToken, &emptypb.Empty{}, &result)
+	c.Assert(status.Code(err), gc.Equals, codes.NotFound)
+	c.Assert(status.Convert(err).Message(), gc.Equals, "API gateway not found")
+}
+
+func (s *gatewaySuite) TestGRPCTokenRequired(c *gc.C) {
+	s.enableGateway(c)
+	var result params.GatewayModels
+	err := s.invokeGRPC(c, "ListModels", "", &emptypb.Empty{}, &result)
+	c.Assert(status.Code(err), gc.Equals, codes.Unauthenticated)
+	c.Assert(status.Convert(err).Message(), gc.Equals, "API gateway token required")
+}
+
+func (s *gatewaySuite) TestGRPCInvalidToken(c *gc.C) {
+	s.enableGateway(c)
+	var result params.GatewayModels
+	err := s.invokeGRPC(c, "ListModels", "not-the-gateway-token", &emptypb.Empty{}, &result)
+	c.Assert(status.Code(err), gc.Equals, codes.Unauthenticated)
+	c.Assert(status.Convert(err).Message(), gc.Equals, "invalid API gateway token")
+}
+
+func (s *gatewaySuite) TestGRPCListModels(c *gc.C) {
+	s.enableGateway(c)
+	var result params.GatewayModels
+	err := s.invokeGRPC(c, "ListModels", gatewayToken, &emptypb.Empty{}, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Models, gc.HasLen, 1)
+	c.Assert(result.Models[0].UUID, gc.Equals, s.Model.UUID())
+	c.Assert(result.Models[0].Name, gc.Equals, s.Model.Name())
+}
+
+func (s *gatewaySuite) TestGRPCModelStatus(c *gc.C) {
+	s.enableGateway(c)
+	app := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+
+	var result params.GatewayModelStatus
+	err := s.invokeGRPC(c, "ModelStatus", gatewayToken, &wrapperspb.StringValue{Value: s.Model.UUID()}, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Model.UUID, gc.Equals, s.Model.UUID())
+	c.Assert(result.Applications, gc.HasLen, 1)
+	c.Assert(result.Applications[0].Name, gc.Equals, app.Name())
+	c.Assert(result.Applications[0].Units, gc.HasLen, 1)
+	c.Assert(result.Applications[0].Units[0].Name, gc.Equals, unit.Name())
+}
+
+func (s *gatewaySuite) TestGRPCModelStatusNotFound(c *gc.C) {
+	s.enableGateway(c)
+	var result params.GatewayModelStatus
+	uuid := &wrapperspb.StringValue{Value: "deadbeef-0bad-400d-8000-4b1d0d06f00e"}
+	err := s.invokeGRPC(c, "ModelStatus", gatewayToken, uuid, &result)
+	c.Assert(status.Code(err), gc.Equals, codes.NotFound)
+}
+
+func (s *gatewaySuite) TestGRPCListOffers(c *gc.C) {
+	s.enableGateway(c)
+	app := s.Factory.MakeApplication(c, nil)
+	_, err := state.NewApplicationOffers(s.State).AddOffer(crossmodel.AddApplicationOfferArgs{
+		OfferName:       "hosted-db",
+		ApplicationName: app.Name(),
+		Endpoints:       map[string]string{"server": "server"},
+		Owner:           s.Owner.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var result params.GatewayOffers
+	err = s.invokeGRPC(c, "ListOffers", gatewayToken, &wrapperspb.StringValue{Value: s.Model.UUID()}, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Offers, gc.HasLen, 1)
+	c.Assert(result.Offers[0].Name, gc.Equals, "hosted-db")
+	c.Assert(result.Offers[0].Endpoints, jc.DeepEquals, []string{"server"})
+}
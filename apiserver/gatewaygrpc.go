@@ -0,0 +1,134 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/juju/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// gatewayGRPCService is the full name of the gRPC service of the API
+// gateway. It is served over HTTP/2 on the API server's port, at
+// /juju.gateway.v1.Gateway/<method>.
+const gatewayGRPCService = "juju.gateway.v1.Gateway"
+
+// newGatewayGRPCServer returns a gRPC server which serves the same
+// read-only queries as the API gateway's REST endpoints, authenticated
+// with the same token, sent as a bearer token in the "authorization"
+// request metadata.
+//
+// The service uses only the protobuf well-known types, so that clients
+// need no juju-specific generated code:
+//
+//	service Gateway {
+//	  rpc ListModels(google.protobuf.Empty) returns (google.protobuf.Struct);
+//	  // The request holds the model UUID.
+//	  rpc ModelStatus(google.protobuf.StringValue) returns (google.protobuf.Struct);
+//	  rpc ListOffers(google.protobuf.StringValue) returns (google.protobuf.Struct);
+//	}
+//
+// Each result holds the same fields as the corresponding REST result.
+func newGatewayGRPCServer(ctxt httpContext) *grpc.Server {
+	server := grpc.NewServer()
+	server.RegisterService(&gatewayServiceDesc, &gatewayGRPCServer{ctxt: ctxt})
+	return server
+}
+
+// gatewayGRPCHandler is implemented by gatewayGRPCServer. It is the
+// handler type of the service, which gRPC requires to be an interface.
+type gatewayGRPCHandler interface {
+	call(ctx context.Context, modelUUID string, query gatewayQuery) (*structpb.Struct, error)
+}
+
+var gatewayServiceDesc = grpc.ServiceDesc{
+	ServiceName: gatewayGRPCService,
+	HandlerType: (*gatewayGRPCHandler)(nil),
+	Methods: []grpc.MethodDesc{
+		gatewayMethod("ListModels", gatewayModels, false),
+		gatewayMethod("ModelStatus", gatewayModelStatus, true),
+		gatewayMethod("ListOffers", gatewayOffers, true),
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+// gatewayMethod returns the description of a unary method answering
+// the query. The request of a model query is the model's UUID; the
+// request of any other query is empty.
+func gatewayMethod(name string, query gatewayQuery, modelQuery bool) grpc.MethodDesc {
+	// The server is created without interceptors, so there are none
+	// to call.
+	handler := func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+		var modelUUID string
+		if modelQuery {
+			in := new(wrapperspb.StringValue)
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+			modelUUID = in.GetValue()
+		} else if err := dec(new(emptypb.Empty)); err != nil {
+			return nil, err
+		}
+		return srv.(gatewayGRPCHandler).call(ctx, modelUUID, query)
+	}
+	return grpc.MethodDesc{MethodName: name, Handler: handler}
+}
+
+// gatewayGRPCServer implements the gRPC service of the API gateway.
+type gatewayGRPCServer struct {
+	ctxt httpContext
+}
+
+// call authenticates the request and answers the query.
+func (s *gatewayGRPCServer) call(ctx context.Context, modelUUID string, query gatewayQuery) (*structpb.Struct, error) {
+	const prefix = "Bearer "
+	var bearer string
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, auth := range md.Get("authorization") {
+		if strings.HasPrefix(auth, prefix) {
+			bearer = strings.TrimPrefix(auth, prefix)
+			break
+		}
+	}
+	if err := authenticateGateway(s.ctxt, bearer); err != nil {
+		return nil, gatewayGRPCError(err)
+	}
+	result, err := query(s.ctxt.srv.shared.statePool, modelUUID)
+	if err != nil {
+		return nil, gatewayGRPCError(err)
+	}
+	// The result is converted by way of JSON, so that it has the same
+	// fields as the REST result.
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, gatewayGRPCError(err)
+	}
+	var out structpb.Struct
+	if err := protojson.Unmarshal(data, &out); err != nil {
+		return nil, gatewayGRPCError(err)
+	}
+	return &out, nil
+}
+
+// gatewayGRPCError returns the gRPC status error for err.
+func gatewayGRPCError(err error) error {
+	code := codes.Internal
+	switch {
+	case errors.IsNotFound(err):
+		code = codes.NotFound
+	case errors.IsUnauthorized(err):
+		code = codes.Unauthenticated
+	}
+	return status.Error(code, err.Error())
+}
@@ -0,0 +1,102 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package observer
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/facadeusage"
+	"github.com/juju/juju/rpc"
+)
+
+// FacadeUsageRecorder records the facade versions called over API
+// connections.
+type FacadeUsageRecorder interface {
+	Record(facadeusage.Call)
+}
+
+// NewFacadeUsageObserverFactory returns an ObserverFactory whose
+// observers record every facade call made over their connection with
+// the recorder.
+func NewFacadeUsageObserverFactory(recorder FacadeUsageRecorder) ObserverFactory {
+	return func() Observer {
+		return &FacadeUsageObserver{recorder: recorder}
+	}
+}
+
+// FacadeUsageObserver records the facade versions called over an API
+// connection, along with the client and the kind of entity calling them.
+type FacadeUsageObserver struct {
+	recorder FacadeUsageRecorder
+
+	mu         sync.Mutex
+	client     string
+	entityKind string
+}
+
+// Join is part of the Observer interface.
+func (o *FacadeUsageObserver) Join(req *http.Request, connectionID uint64) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.client = clientFromRequest(req)
+}
+
+// clientFromRequest identifies the client making the request. Juju's
+// own clients and agents send their version in a header; anything else
+// is identified by its user agent.
+func clientFromRequest(req *http.Request) string {
+	if version := req.Header.Get(params.JujuClientVersion); version != "" {
+		return "juju/" + version
+	}
+	if userAgent := req.UserAgent(); userAgent != "" {
+		return userAgent
+	}
+	return "unknown"
+}
+
+// Login is part of the Observer interface.
+func (o *FacadeUsageObserver) Login(entity names.Tag, _ names.ModelTag, _ bool, _ string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.entityKind = entity.Kind()
+}
+
+// Leave is part of the Observer interface.
+func (*FacadeUsageObserver) Leave() {}
+
+// RPCObserver is part of the Observer interface.
+func (o *FacadeUsageObserver) RPCObserver() rpc.Observer {
+	return facadeUsageRPCObserver{o}
+}
+
+func (o *FacadeUsageObserver) record(req rpc.Request) {
+	o.mu.Lock()
+	call := facadeusage.Call{
+		Facade:     req.Type,
+		Version:    req.Version,
+		Client:     o.client,
+		EntityKind: o.entityKind,
+	}
+	o.mu.Unlock()
+	o.recorder.Record(call)
+}
+
+type facadeUsageRPCObserver struct {
+	o *FacadeUsageObserver
+}
+
+// ServerRequest is part of the rpc.Observer interface.
+func (r facadeUsageRPCObserver) ServerRequest(hdr *rpc.Header, body interface{}) {
+	if hdr.Request.Type == "" {
+		return
+	}
+	r.o.record(hdr.Request)
+}
+
+// ServerReply is part of the rpc.Observer interface.
+func (facadeUsageRPCObserver) ServerReply(req rpc.Request, hdr *rpc.Header, body interface{}) {}
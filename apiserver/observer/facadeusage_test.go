@@ -0,0 +1,94 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package observer_test
+
+import (
+	"net/http"
+
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/facadeusage"
+	"github.com/juju/juju/rpc"
+)
+
+type FacadeUsageObserverSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FacadeUsageObserverSuite{})
+
+type fakeUsageRecorder struct {
+	calls []facadeusage.Call
+}
+
+func (r *fakeUsageRecorder) Record(call facadeusage.Call) {
+	r.calls = append(r.calls, call)
+}
+
+func (*FacadeUsageObserverSuite) serverRequest(o observer.Observer, facade string, version int) {
+	o.RPCObserver().ServerRequest(&rpc.Header{
+		Request: rpc.Request{Type: facade, Version: version, Action: "Method"},
+	}, nil)
+}
+
+func (s *FacadeUsageObserverSuite) TestRecordsJujuClient(c *gc.C) {
+	recorder := &fakeUsageRecorder{}
+	o := observer.NewFacadeUsageObserverFactory(recorder)()
+
+	req := &http.Request{Header: http.Header{}}
+	req.Header.Set(params.JujuClientVersion, "2.8.10")
+	req.Header.Set("User-Agent", "Go-http-client/1.1")
+	o.Join(req, 1)
+	s.serverRequest(o, "Admin", 3)
+	o.Login(names.NewUnitTag("mariadb/0"), names.NewModelTag("fake-uuid"), false, "")
+	s.serverRequest(o, "Uniter", 15)
+
+	c.Assert(recorder.calls, jc.DeepEquals, []facadeusage.Call{
+		{Facade: "Admin", Version: 3, Client: "juju/2.8.10"},
+		{Facade: "Uniter", Version: 15, Client: "juju/2.8.10", EntityKind: "unit"},
+	})
+}
+
+func (s *FacadeUsageObserverSuite) TestRecordsUserAgent(c *gc.C) {
+	recorder := &fakeUsageRecorder{}
+	o := observer.NewFacadeUsageObserverFactory(recorder)()
+
+	req := &http.Request{Header: http.Header{}}
+	req.Header.Set("User-Agent", "python-libjuju/2.8.6")
+	o.Join(req, 1)
+	o.Login(names.NewUserTag("bob"), names.NewModelTag("fake-uuid"), false, "")
+	s.serverRequest(o, "Client", 2)
+
+	c.Assert(recorder.calls, jc.DeepEquals, []facadeusage.Call{
+		{Facade: "Client", Version: 2, Client: "python-libjuju/2.8.6", EntityKind: "user"},
+	})
+}
+
+func (s *FacadeUsageObserverSuite) TestUnknownClient(c *gc.C) {
+	recorder := &fakeUsageRecorder{}
+	o := observer.NewFacadeUsageObserverFactory(recorder)()
+
+	o.Join(&http.Request{Header: http.Header{}}, 1)
+	o.Login(names.NewMachineTag("0"), names.NewModelTag("fake-uuid"), false, "")
+	s.serverRequest(o, "Provisioner", 11)
+
+	c.Assert(recorder.calls, jc.DeepEquals, []facadeusage.Call{
+		{Facade: "Provisioner", Version: 11, Client: "unknown", EntityKind: "machine"},
+	})
+}
+
+func (s *FacadeUsageObserverSuite) TestIgnoresUnrecognisedRequests(c *gc.C) {
+	recorder := &fakeUsageRecorder{}
+	o := observer.NewFacadeUsageObserverFactory(recorder)()
+
+	o.Join(&http.Request{Header: http.Header{}}, 1)
+	s.serverRequest(o, "", 0)
+
+	c.Assert(recorder.calls, gc.HasLen, 0)
+}
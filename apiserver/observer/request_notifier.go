@@ -38,6 +38,7 @@ type RequestObserver struct {
 	// this type.
 	state struct {
 		id                 uint64
+		remoteAddress      string
 		websocketConnected time.Time
 		tag                string
 		model              string
@@ -101,6 +102,7 @@ func (n *RequestObserver) Login(entity names.Tag, model names.ModelTag, fromCont
 			ModelUUID:       model.Id(),
 			ConnectionID:    n.state.id,
 			UserData:        userData,
+			RemoteAddress:   n.state.remoteAddress,
 		})
 	}
 }
@@ -108,6 +110,7 @@ func (n *RequestObserver) Login(entity names.Tag, model names.ModelTag, fromCont
 // Join implements Observer.
 func (n *RequestObserver) Join(req *http.Request, connectionID uint64) {
 	n.state.id = connectionID
+	n.state.remoteAddress = req.RemoteAddr
 	n.state.websocketConnected = n.clock.Now()
 
 	n.logger.Debugf(
@@ -4,6 +4,7 @@
 package observer_test
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/juju/clock/testclock"
@@ -49,6 +50,23 @@ func (s *RequestObserverSuite) TestAgentConnectionPublished(c *gc.C) {
 	})
 }
 
+func (s *RequestObserverSuite) TestAgentConnectionPublishedWithRemoteAddress(c *gc.C) {
+	notifier, hub := s.makeNotifier(c)
+
+	notifier.Join(&http.Request{RemoteAddr: "10.0.0.5:49152"}, 1234)
+	agent := names.NewUnitTag("mariadb/0")
+	model := names.NewModelTag("fake-uuid")
+	notifier.Login(agent, model, false, "")
+
+	c.Assert(hub.called, gc.Equals, 1)
+	c.Assert(hub.details, jc.DeepEquals, apiserver.APIConnection{
+		AgentTag:      "unit-mariadb-0",
+		ModelUUID:     "fake-uuid",
+		ConnectionID:  1234,
+		RemoteAddress: "10.0.0.5:49152",
+	})
+}
+
 func (s *RequestObserverSuite) assertControllerAgentConnectionPublished(c *gc.C, agent names.Tag) {
 	notifier, hub := s.makeNotifier(c)
 
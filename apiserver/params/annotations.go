@@ -25,3 +25,21 @@ type EntityAnnotations struct {
 	EntityTag   string            `json:"entity"`
 	Annotations map[string]string `json:"annotations"`
 }
+
+// AnnotationsQuery holds the selector used to query the annotations
+// of the entities in a model.
+type AnnotationsQuery struct {
+	// Selector is a comma separated list of requirements on the
+	// annotations of the entities, e.g. "env=prod,tier!=web".
+	Selector string `json:"selector"`
+
+	// Kinds, if set, restricts the query to entities of the given
+	// tag kinds, e.g. "unit".
+	Kinds []string `json:"kinds,omitempty"`
+}
+
+// AnnotationsQueryResults holds the annotations of the entities which
+// match an annotations query.
+type AnnotationsQueryResults struct {
+	Results []EntityAnnotations `json:"results"`
+}
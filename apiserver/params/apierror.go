@@ -5,6 +5,7 @@ package params
 
 import (
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -213,6 +214,7 @@ const (
 	CodeCloudRegionRequired       = "cloud region required"
 	CodeIncompatibleClouds        = "incompatible clouds"
 	CodeQuotaLimitExceeded        = "quota limit exceeded"
+	CodeTimeout                   = "timeout"
 )
 
 // ErrCode returns the error code associated with
@@ -222,12 +224,13 @@ func ErrCode(err error) string {
 	type ErrorCoder interface {
 		ErrorCode() string
 	}
-	switch err := errors.Cause(err).(type) {
-	case ErrorCoder:
-		return err.ErrorCode()
-	default:
-		return ""
+	// Look through errors wrapped with the %w verb of fmt.Errorf
+	// as well as those annotated by the errors package.
+	var coder ErrorCoder
+	if stderrors.As(errors.Cause(err), &coder) {
+		return coder.ErrorCode()
 	}
+	return ""
 }
 
 func IsCodeActionNotAvailable(err error) bool {
@@ -396,3 +399,8 @@ func IsCodeCloudRegionRequired(err error) bool {
 func IsCodeQuotaLimitExceeded(err error) bool {
 	return ErrCode(err) == CodeQuotaLimitExceeded
 }
+
+// IsCodeTimeout returns true if err includes a Timeout error code.
+func IsCodeTimeout(err error) bool {
+	return ErrCode(err) == CodeTimeout
+}
@@ -4,7 +4,11 @@
 package params_test
 
 import (
+	stderrors "errors"
+	"fmt"
+
 	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/params"
@@ -25,3 +29,59 @@ func (*errorSuite) TestErrCode(c *gc.C) {
 	err = errors.Trace(err)
 	c.Check(params.ErrCode(err), gc.Equals, params.CodeDead)
 }
+
+func (*errorSuite) TestErrCodeStdlibWrapped(c *gc.C) {
+	err := fmt.Errorf("getting unit: %w", &params.Error{Code: params.CodeNotFound, Message: "unit not found"})
+	c.Check(params.ErrCode(err), gc.Equals, params.CodeNotFound)
+	c.Check(params.ErrCode(errors.New("no code")), gc.Equals, "")
+}
+
+func (*errorSuite) TestErrClass(c *gc.C) {
+	for code, class := range map[string]params.ErrorClass{
+		params.CodeUnauthorized:       params.ErrorClassPermission,
+		params.CodeForbidden:          params.ErrorClassPermission,
+		params.CodeQuotaLimitExceeded: params.ErrorClassQuota,
+		params.CodeNotProvisioned:     params.ErrorClassNotProvisioned,
+		params.CodeTryAgain:           params.ErrorClassRetryable,
+		params.CodeUpgradeInProgress:  params.ErrorClassRetryable,
+		params.CodeAlreadyExists:      params.ErrorClassConflict,
+		params.CodeBadRequest:         params.ErrorClassInvalid,
+		params.CodeNotSupported:       params.ErrorClassUnsupported,
+		params.CodeModelNotFound:      params.ErrorClassNotFound,
+		params.CodeRedirect:           params.ErrorClassUnknown,
+		"unknown code":                params.ErrorClassUnknown,
+	} {
+		err := errors.Annotate(&params.Error{Code: code, Message: "boom"}, "context")
+		c.Check(params.ErrClass(err), gc.Equals, class, gc.Commentf("code %q", code))
+	}
+	c.Check(params.ErrClass(errors.New("no code")), gc.Equals, params.ErrorClassUnknown)
+}
+
+func (*errorSuite) TestErrorClassHelpers(c *gc.C) {
+	err := &params.Error{Code: params.CodeLoginExpired}
+	c.Check(params.IsPermissionError(err), jc.IsTrue)
+	c.Check(params.IsRetryableError(err), jc.IsFalse)
+
+	err = &params.Error{Code: params.CodeQuotaLimitExceeded}
+	c.Check(params.IsQuotaError(err), jc.IsTrue)
+	c.Check(params.IsPermissionError(err), jc.IsFalse)
+
+	err = &params.Error{Code: params.CodeNoAddressSet}
+	c.Check(params.IsNotProvisionedError(err), jc.IsTrue)
+
+	err = &params.Error{Code: params.CodeExcessiveContention}
+	c.Check(params.IsRetryableError(err), jc.IsTrue)
+}
+
+func (*errorSuite) TestWithErrorCode(c *gc.C) {
+	cause := errors.New("connection timed out")
+	err := errors.Annotate(params.WithErrorCode(cause, params.CodeTimeout), "dialing controller")
+	c.Check(err, gc.ErrorMatches, "dialing controller: connection timed out")
+	c.Check(params.ErrCode(err), gc.Equals, params.CodeTimeout)
+	c.Check(params.IsRetryableError(err), jc.IsTrue)
+	c.Check(params.UnwrapErrorCode(err), gc.Equals, cause)
+	c.Check(stderrors.Is(errors.Cause(err), cause), jc.IsTrue)
+
+	c.Check(params.WithErrorCode(nil, params.CodeTimeout), gc.IsNil)
+	c.Check(params.UnwrapErrorCode(errors.Trace(cause)), gc.Equals, cause)
+}
@@ -570,6 +570,7 @@ type ScaleApplicationInfo struct {
 // NOTE: we should look to combine ApplicationResult and ApplicationInfo.
 type ApplicationResult struct {
 	Tag              string                     `json:"tag"`
+	UUID             string                     `json:"uuid,omitempty"`
 	Charm            string                     `json:"charm,omitempty"`
 	Series           string                     `json:"series,omitempty"`
 	Channel          string                     `json:"channel,omitempty"`
@@ -610,6 +611,7 @@ type EndpointRelationData struct {
 // UnitResult holds unit info.
 type UnitResult struct {
 	Tag             string                 `json:"tag"`
+	UUID            string                 `json:"uuid,omitempty"`
 	WorkloadVersion string                 `json:"workload-version"`
 	Machine         string                 `json:"machine,omitempty"`
 	OpenedPorts     []string               `json:"opened-ports"`
@@ -651,3 +653,104 @@ type ExposeInfoResult struct {
 	// for the application will be exposed to 0.0.0.0/0.
 	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
 }
+
+// WorkloadVersion holds a workload version reported by a unit, and the
+// time it was first reported.
+type WorkloadVersion struct {
+	Version string     `json:"version"`
+	Since   *time.Time `json:"since,omitempty"`
+}
+
+// WorkloadVersionHistoryResult holds the workload version history of
+// a unit, most recent first, or a retrieval error.
+type WorkloadVersionHistoryResult struct {
+	History []WorkloadVersion `json:"history,omitempty"`
+	Error   *Error            `json:"error,omitempty"`
+}
+
+// WorkloadVersionHistoryResults holds the results of a
+// WorkloadVersions.VersionHistory call.
+type WorkloadVersionHistoryResults struct {
+	Results []WorkloadVersionHistoryResult `json:"results"`
+}
+
+// UnitWorkloadVersion holds the workload version currently reported
+// by a unit.
+type UnitWorkloadVersion struct {
+	UnitTag string     `json:"unit-tag"`
+	Version string     `json:"version"`
+	Since   *time.Time `json:"since,omitempty"`
+}
+
+// ApplicationWorkloadVersions summarises the workload versions reported
+// by the units of an application. The latest version is the version most
+// recently reported by any unit; units reporting a different version are
+// listed as outdated.
+type ApplicationWorkloadVersions struct {
+	ApplicationTag string                `json:"application-tag"`
+	LatestVersion  string                `json:"latest-version"`
+	Units          []UnitWorkloadVersion `json:"units"`
+	OutdatedUnits  []string              `json:"outdated-units,omitempty"`
+}
+
+// WorkloadVersionSummary holds the result of a WorkloadVersions.Summary
+// call.
+type WorkloadVersionSummary struct {
+	Applications []ApplicationWorkloadVersions `json:"applications"`
+}
+
+// RelationEndpointSnapshot holds the settings of the application and
+// units on one side of a relation.
+type RelationEndpointSnapshot struct {
+	ApplicationName     string                            `json:"application-name"`
+	Name                string                            `json:"name"`
+	Role                string                            `json:"role"`
+	ApplicationSettings map[string]interface{}            `json:"application-settings"`
+	UnitSettings        map[string]map[string]interface{} `json:"unit-settings"`
+}
+
+// RelationSnapshotResult holds the settings on each side of a relation,
+// or a retrieval error.
+type RelationSnapshotResult struct {
+	Id        int                        `json:"id"`
+	Key       string                     `json:"key"`
+	Endpoints []RelationEndpointSnapshot `json:"endpoints,omitempty"`
+	Error     *Error                     `json:"error,omitempty"`
+}
+
+// RelationSnapshotResults holds the results of a
+// RelationSettings.Snapshot call.
+type RelationSnapshotResults struct {
+	Results []RelationSnapshotResult `json:"results"`
+}
+
+// RelationMatrixEndpoint describes an application endpoint which is
+// both a row and a column of a RelationMatrix.
+type RelationMatrixEndpoint struct {
+	ApplicationName string `json:"application-name"`
+	Name            string `json:"name"`
+	Role            string `json:"role"`
+	Interface       string `json:"interface"`
+
+	// Remote is true when the application is a remote application
+	// offered by another model.
+	Remote bool `json:"remote,omitempty"`
+}
+
+// RelationMatrixEntry describes the relation between two endpoints of
+// a RelationMatrix.
+type RelationMatrixEntry struct {
+	RelationId int    `json:"relation-id"`
+	Key        string `json:"key"`
+	Scope      string `json:"scope"`
+	CrossModel bool   `json:"cross-model,omitempty"`
+}
+
+// RelationMatrix holds the result of a RelationSettings.RelationMatrix
+// call. Relations[i][j] describes the relation between Endpoints[i] and
+// Endpoints[j], or is nil if they are not related. The matrix is
+// symmetric, and peer relations are found on its diagonal.
+type RelationMatrix struct {
+	Endpoints []RelationMatrixEndpoint `json:"endpoints"`
+	Relations [][]*RelationMatrixEntry `json:"relations"`
+}
@@ -41,6 +41,21 @@ type BackupsRemoveArgs struct {
 	IDs []string `json:"ids"`
 }
 
+// BackupsVerifyArgs holds the args for the API Verify method.
+type BackupsVerifyArgs struct {
+	ID string `json:"id"`
+}
+
+// BackupsVerifyResult holds the outcome of restoring a backup into a
+// throwaway database and checking it.
+type BackupsVerifyResult struct {
+	ID          string   `json:"id"`
+	Restorable  bool     `json:"restorable"`
+	Databases   []string `json:"databases,omitempty"`
+	Collections int      `json:"collections"`
+	Problems    []string `json:"problems,omitempty"`
+}
+
 // BackupsListResult holds the list of all stored backups.
 type BackupsListResult struct {
 	List []BackupsMetadataResult `json:"list"`
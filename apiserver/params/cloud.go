@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // Cloud holds information about a cloud.
 type Cloud struct {
 	Type             string                            `json:"type"`
@@ -350,3 +352,44 @@ type RevokeCredentialArgs struct {
 	// Credentials holds credentials to revoke.
 	Credentials []RevokeCredentialArg `json:"credentials"`
 }
+
+// ScopedCredentialApplication describes an application which should be
+// given a credential scoped to it, or which holds a scoped credential it
+// no longer needs.
+type ScopedCredentialApplication struct {
+	// ApplicationTag holds the tag of the application.
+	ApplicationTag string `json:"application-tag"`
+
+	// Expiry holds when the application's current scoped credential
+	// expires, if it has one.
+	Expiry *time.Time `json:"expiry,omitempty"`
+
+	// Revoke is true if the application holds a scoped credential but
+	// is no longer trusted, or no longer has its trust scoped to it.
+	Revoke bool `json:"revoke,omitempty"`
+}
+
+// ScopedCredentialApplications holds the applications which should be
+// given a scoped credential, or which hold one they no longer need.
+type ScopedCredentialApplications struct {
+	Applications []ScopedCredentialApplication `json:"applications"`
+}
+
+// SetScopedCredentialArg holds a credential issued for the use of a
+// single application.
+type SetScopedCredentialArg struct {
+	// ApplicationTag holds the tag of the application.
+	ApplicationTag string `json:"application-tag"`
+
+	// Credential holds the issued credential.
+	Credential CloudCredential `json:"credential"`
+
+	// Expiry holds when the credential expires.
+	Expiry time.Time `json:"expiry"`
+}
+
+// SetScopedCredentialArgs holds credentials issued for the use of
+// applications.
+type SetScopedCredentialArgs struct {
+	Args []SetScopedCredentialArg `json:"args"`
+}
@@ -3,7 +3,11 @@
 
 package params
 
-import "github.com/juju/juju/core/life"
+import (
+	"github.com/juju/version"
+
+	"github.com/juju/juju/core/life"
+)
 
 // DestroyControllerArgs holds the arguments for destroying a controller.
 type DestroyControllerArgs struct {
@@ -115,3 +119,141 @@ type ControllerVersionResults struct {
 	Version   string `json:"version"`
 	GitCommit string `json:"git-commit"`
 }
+
+// InventoryReport holds an inventory of the models on a controller, for
+// license and capacity reporting.
+type InventoryReport struct {
+	Models []ModelInventory `json:"models"`
+}
+
+// ModelInventory holds the inventory of a single model.
+type ModelInventory struct {
+	ModelTag     string                 `json:"model-tag"`
+	Name         string                 `json:"name"`
+	OwnerTag     string                 `json:"owner-tag"`
+	Type         string                 `json:"type"`
+	Cloud        string                 `json:"cloud"`
+	CloudRegion  string                 `json:"cloud-region,omitempty"`
+	Machines     []MachineInventory     `json:"machines,omitempty"`
+	Volumes      []VolumeInventory      `json:"volumes,omitempty"`
+	Applications []ApplicationInventory `json:"applications,omitempty"`
+	Error        *Error                 `json:"error,omitempty"`
+}
+
+// MachineInventory describes a machine in a model inventory.
+type MachineInventory struct {
+	Id           string           `json:"id"`
+	Series       string           `json:"series"`
+	InstanceId   string           `json:"instance-id,omitempty"`
+	InstanceType string           `json:"instance-type,omitempty"`
+	Hardware     *MachineHardware `json:"hardware,omitempty"`
+}
+
+// VolumeInventory describes a volume in a model inventory. Size is
+// in MiB.
+type VolumeInventory struct {
+	Id         string `json:"id"`
+	ProviderId string `json:"provider-id,omitempty"`
+	Pool       string `json:"pool,omitempty"`
+	Size       uint64 `json:"size"`
+	Persistent bool   `json:"persistent"`
+}
+
+// ApplicationInventory describes an application in a model inventory.
+type ApplicationInventory struct {
+	Name         string `json:"name"`
+	CharmURL     string `json:"charm-url"`
+	CharmVersion string `json:"charm-version,omitempty"`
+	UnitCount    int    `json:"unit-count"`
+}
+
+// FindOrphanedDocumentsArgs holds the arguments for the
+// FindOrphanedDocuments call.
+type FindOrphanedDocumentsArgs struct {
+	// Remove is set if the orphaned documents found should be removed.
+	Remove bool `json:"remove,omitempty"`
+}
+
+// OrphanedDocument describes a document in the controller's database
+// which refers to an entity that no longer exists.
+type OrphanedDocument struct {
+	Collection string `json:"collection"`
+	ModelTag   string `json:"model-tag"`
+	Id         string `json:"id"`
+	Reason     string `json:"reason"`
+}
+
+// OrphanedDocumentsResult holds the orphaned documents found on a
+// controller.
+type OrphanedDocumentsResult struct {
+	Documents []OrphanedDocument `json:"documents,omitempty"`
+
+	// Removed is set if the documents were removed.
+	Removed bool `json:"removed,omitempty"`
+}
+
+// VerifyIntegrityArgs holds the arguments for the VerifyIntegrity call.
+type VerifyIntegrityArgs struct {
+	// Repair is set if the problems found which can be repaired
+	// safely should be repaired.
+	Repair bool `json:"repair,omitempty"`
+}
+
+// IntegrityProblem describes an inconsistency between documents in the
+// controller's database.
+type IntegrityProblem struct {
+	Check      string `json:"check"`
+	Collection string `json:"collection"`
+	ModelTag   string `json:"model-tag"`
+	Id         string `json:"id"`
+	Detail     string `json:"detail"`
+	Repairable bool   `json:"repairable,omitempty"`
+	Repaired   bool   `json:"repaired,omitempty"`
+}
+
+// IntegrityReport holds the results of verifying the integrity of the
+// controller's database.
+type IntegrityReport struct {
+	// Checks holds the classes of check which were run.
+	Checks []string `json:"checks"`
+
+	Problems []IntegrityProblem `json:"problems,omitempty"`
+}
+
+// UpgradePlanArgs holds the arguments for the UpgradePlan call.
+type UpgradePlanArgs struct {
+	// FromVersion is the version being upgraded from. If it is zero,
+	// the agent version of the controller model is used.
+	FromVersion version.Number `json:"from-version"`
+
+	// ToVersion is the version being upgraded to. If it is zero, the
+	// controller's version is used. If it is later than the
+	// controller's version, the agent binaries for it must have been
+	// uploaded to the controller.
+	ToVersion version.Number `json:"to-version"`
+
+	// Targets holds the machine targets to plan the upgrade for, such
+	// as "controller" or "hostMachine". If empty, all targets are used.
+	Targets []string `json:"targets,omitempty"`
+}
+
+// UpgradePlanStep describes an upgrade step that would be run.
+type UpgradePlanStep struct {
+	TargetVersion version.Number `json:"target-version"`
+	Description   string         `json:"description"`
+	Targets       []string       `json:"targets"`
+
+	// State is set if the step is run directly against the database.
+	State bool `json:"state,omitempty"`
+}
+
+// UpgradePlanResult holds the upgrade steps which would be run, in
+// order, when upgrading between two versions.
+type UpgradePlanResult struct {
+	// FromVersion and ToVersion hold the versions the plan was made
+	// for, which are filled in when not given in the request.
+	FromVersion version.Number `json:"from-version"`
+	ToVersion   version.Number `json:"to-version"`
+
+	Steps []UpgradePlanStep `json:"steps,omitempty"`
+}
@@ -188,6 +188,10 @@ type ConsumeApplicationArg struct {
 
 	// ApplicationAlias is the name of the alias to use for the application name.
 	ApplicationAlias string `json:"application-alias,omitempty"`
+
+	// EncryptRelationData is true if the settings exchanged over
+	// relations to the consumed application are to be encrypted.
+	EncryptRelationData bool `json:"encrypt-relation-data,omitempty"`
 }
 
 // ConsumeApplicationArgs is a collection of arg for consuming applications.
@@ -256,6 +260,10 @@ type RemoteApplication struct {
 
 	// Macaroon is used for authentication.
 	Macaroon *macaroon.Macaroon `json:"macaroon,omitempty"`
+
+	// EncryptRelationData is true if the settings exchanged over
+	// relations to the application are to be encrypted.
+	EncryptRelationData bool `json:"encrypt-relation-data,omitempty"`
 }
 
 // GetTokenArgs holds the arguments to a GetTokens API call.
@@ -511,6 +519,11 @@ type RegisterRemoteRelationArg struct {
 	// LocalEndpointName is the name of the endpoint in the local model.
 	LocalEndpointName string `json:"local-endpoint-name"`
 
+	// KeyGeneration is the generation of the keys with which the
+	// related units encrypt the relation settings, or zero if they
+	// are not encrypted.
+	KeyGeneration int `json:"key-generation,omitempty"`
+
 	// Macaroons are used for authentication.
 	Macaroons macaroon.Slice `json:"macaroons,omitempty"`
 
@@ -0,0 +1,183 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "github.com/juju/errors"
+
+// ErrorClass is a broad category of API error codes. Codes in the same
+// class can be handled in the same way by clients, which need not then
+// check for each individual code.
+type ErrorClass string
+
+const (
+	// ErrorClassUnknown is the class of errors with no code, or with a
+	// code that is not classified.
+	ErrorClassUnknown ErrorClass = ""
+
+	// ErrorClassNotFound is the class of errors indicating that the
+	// requested entity does not exist.
+	ErrorClassNotFound ErrorClass = "not found"
+
+	// ErrorClassPermission is the class of errors indicating that the
+	// caller is not authenticated, or is not permitted to perform the
+	// operation.
+	ErrorClassPermission ErrorClass = "permission"
+
+	// ErrorClassQuota is the class of errors indicating that a quota
+	// would be exceeded by the operation.
+	ErrorClassQuota ErrorClass = "quota"
+
+	// ErrorClassNotProvisioned is the class of errors indicating that
+	// an entity has not yet been provisioned, assigned or addressed.
+	ErrorClassNotProvisioned ErrorClass = "not provisioned"
+
+	// ErrorClassRetryable is the class of errors indicating a transient
+	// condition; the operation may succeed if retried later.
+	ErrorClassRetryable ErrorClass = "retryable"
+
+	// ErrorClassConflict is the class of errors indicating that the
+	// operation conflicts with the current state of the model.
+	ErrorClassConflict ErrorClass = "conflict"
+
+	// ErrorClassInvalid is the class of errors indicating that the
+	// request was malformed or its arguments were not valid.
+	ErrorClassInvalid ErrorClass = "invalid"
+
+	// ErrorClassUnsupported is the class of errors indicating that the
+	// operation is not supported by the controller or the client.
+	ErrorClassUnsupported ErrorClass = "unsupported"
+)
+
+// errorCodeClasses holds the class of each classified error code.
+// New error codes should be added here.
+var errorCodeClasses = map[string]ErrorClass{
+	CodeNotFound:           ErrorClassNotFound,
+	CodeUserNotFound:       ErrorClassNotFound,
+	CodeModelNotFound:      ErrorClassNotFound,
+	CodeActionNotAvailable: ErrorClassNotFound,
+
+	CodeUnauthorized:      ErrorClassPermission,
+	CodeLoginExpired:      ErrorClassPermission,
+	CodeNoCreds:           ErrorClassPermission,
+	CodeForbidden:         ErrorClassPermission,
+	CodeDischargeRequired: ErrorClassPermission,
+	CodeOperationBlocked:  ErrorClassPermission,
+
+	CodeQuotaLimitExceeded: ErrorClassQuota,
+
+	CodeNotProvisioned: ErrorClassNotProvisioned,
+	CodeNotAssigned:    ErrorClassNotProvisioned,
+	CodeNoAddressSet:   ErrorClassNotProvisioned,
+
+	CodeTryAgain:            ErrorClassRetryable,
+	CodeRetry:               ErrorClassRetryable,
+	CodeExcessiveContention: ErrorClassRetryable,
+	CodeUpgradeInProgress:   ErrorClassRetryable,
+	CodeMigrationInProgress: ErrorClassRetryable,
+	CodeCannotEnterScopeYet: ErrorClassRetryable,
+	CodeTimeout:             ErrorClassRetryable,
+
+	CodeAlreadyExists:             ErrorClassConflict,
+	CodeCannotEnterScope:          ErrorClassConflict,
+	CodeUnitHasSubordinates:       ErrorClassConflict,
+	CodeStopped:                   ErrorClassConflict,
+	CodeDead:                      ErrorClassConflict,
+	CodeHasAssignedUnits:          ErrorClassConflict,
+	CodeHasHostedModels:           ErrorClassConflict,
+	CodeHasPersistentStorage:      ErrorClassConflict,
+	CodeModelNotEmpty:             ErrorClassConflict,
+	CodeMachineHasAttachedStorage: ErrorClassConflict,
+	CodeMachineHasContainers:      ErrorClassConflict,
+	CodeStorageAttached:           ErrorClassConflict,
+	CodeLeadershipClaimDenied:     ErrorClassConflict,
+	CodeLeaseClaimDenied:          ErrorClassConflict,
+
+	CodeBadRequest:          ErrorClassInvalid,
+	CodeIncompatibleSeries:  ErrorClassInvalid,
+	CodeCloudRegionRequired: ErrorClassInvalid,
+	CodeIncompatibleClouds:  ErrorClassInvalid,
+
+	CodeNotSupported:       ErrorClassUnsupported,
+	CodeNotImplemented:     ErrorClassUnsupported,
+	CodeMethodNotAllowed:   ErrorClassUnsupported,
+	CodeIncompatibleClient: ErrorClassUnsupported,
+}
+
+// ErrorCodeClass returns the class of the given error code.
+func ErrorCodeClass(code string) ErrorClass {
+	return errorCodeClasses[code]
+}
+
+// ErrClass returns the class of the error code associated with
+// the given error, or ErrorClassUnknown if there is none.
+func ErrClass(err error) ErrorClass {
+	return ErrorCodeClass(ErrCode(err))
+}
+
+// IsPermissionError returns true if err has an error code in
+// ErrorClassPermission.
+func IsPermissionError(err error) bool {
+	return ErrClass(err) == ErrorClassPermission
+}
+
+// IsQuotaError returns true if err has an error code in
+// ErrorClassQuota.
+func IsQuotaError(err error) bool {
+	return ErrClass(err) == ErrorClassQuota
+}
+
+// IsNotProvisionedError returns true if err has an error code in
+// ErrorClassNotProvisioned.
+func IsNotProvisionedError(err error) bool {
+	return ErrClass(err) == ErrorClassNotProvisioned
+}
+
+// IsRetryableError returns true if err has an error code in
+// ErrorClassRetryable.
+func IsRetryableError(err error) bool {
+	return ErrClass(err) == ErrorClassRetryable
+}
+
+// codedError associates an error code with an error
+// raised on the client side.
+type codedError struct {
+	err  error
+	code string
+}
+
+// Error implements error.
+func (e *codedError) Error() string {
+	return e.err.Error()
+}
+
+// ErrorCode returns the error code associated with the error.
+func (e *codedError) ErrorCode() string {
+	return e.code
+}
+
+// Unwrap returns the error wrapped with WithErrorCode.
+func (e *codedError) Unwrap() error {
+	return e.err
+}
+
+// WithErrorCode returns an error with the message of err, associated
+// with the given error code. It allows errors raised by API clients
+// to be classified in the same way as those returned by the API.
+// The original error can be retrieved with UnwrapErrorCode.
+func WithErrorCode(err error, code string) error {
+	if err == nil {
+		return nil
+	}
+	return &codedError{err: err, code: code}
+}
+
+// UnwrapErrorCode returns the error wrapped with WithErrorCode,
+// or the cause of err if it was not wrapped.
+func UnwrapErrorCode(err error) error {
+	err = errors.Cause(err)
+	if coded, ok := err.(*codedError); ok {
+		return coded.err
+	}
+	return err
+}
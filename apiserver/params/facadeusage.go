@@ -0,0 +1,49 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// FacadeUsageArgs selects the facade usage reported by the FacadeUsage
+// facade.
+type FacadeUsageArgs struct {
+	// Facades holds the names of the facades to report on. All facades
+	// are reported on if it is empty.
+	Facades []string `json:"facades,omitempty"`
+
+	// OldVersionsOnly selects only the usage of facade versions older
+	// than the latest version supported by the controller.
+	OldVersionsOnly bool `json:"old-versions-only,omitempty"`
+}
+
+// FacadeUsageResults holds the facade usage recorded by an API server.
+type FacadeUsageResults struct {
+	// Since holds the time the API server started recording usage.
+	Since time.Time     `json:"since"`
+	Usage []FacadeUsage `json:"usage"`
+}
+
+// FacadeUsage holds how often, and when, a version of a facade has been
+// called by a client.
+type FacadeUsage struct {
+	Facade  string `json:"facade"`
+	Version int    `json:"version"`
+
+	// LatestVersion holds the latest version of the facade supported
+	// by the controller. It is zero if the controller no longer
+	// supports the facade at all.
+	LatestVersion int `json:"latest-version"`
+
+	// Client identifies the software making the calls, such as
+	// "juju/2.9.0".
+	Client string `json:"client"`
+
+	// EntityKind is the kind of entity making the calls, such as
+	// "user", "machine" or "unit".
+	EntityKind string `json:"entity-kind,omitempty"`
+
+	Count     int64     `json:"count"`
+	FirstSeen time.Time `json:"first-seen"`
+	LastSeen  time.Time `json:"last-seen"`
+}
@@ -27,6 +27,31 @@ type FirewallRule struct {
 	WhitelistCIDRS []string `json:"whitelist-cidrs,omitempty"`
 }
 
+// FirewallAuditResults holds the results of auditing the provider's
+// firewall rules against the ports opened in the model.
+type FirewallAuditResults struct {
+	// FirewallMode is the firewall mode of the model.
+	FirewallMode string `json:"firewall-mode"`
+
+	// Orphans are the provider rules that are not backed by any
+	// opened port range in the model.
+	Orphans []OrphanFirewallRule `json:"orphans,omitempty"`
+}
+
+// OrphanFirewallRule is a provider ingress rule that is not backed by
+// any opened port range in the model.
+type OrphanFirewallRule struct {
+	// MachineId is the machine whose instance has the rule. It is
+	// empty for rules that apply to the whole model.
+	MachineId string `json:"machine-id,omitempty"`
+
+	// PortRange is the port range allowed by the rule.
+	PortRange PortRange `json:"port-range"`
+
+	// SourceCIDRs are the subnets allowed access by the rule.
+	SourceCIDRs []string `json:"source-cidrs,omitempty"`
+}
+
 // KnownServiceArgs holds the parameters for retrieving firewall rules.
 type KnownServiceArgs struct {
 	// KnownServices are the well known services for a firewall rule.
@@ -0,0 +1,71 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// GatewayModels is returned by the API gateway's models query.
+type GatewayModels struct {
+	Models []GatewayModel `json:"models"`
+}
+
+// GatewayModel describes a model in the API gateway's results.
+type GatewayModel struct {
+	UUID    string `json:"uuid"`
+	Name    string `json:"name"`
+	Owner   string `json:"owner"`
+	Type    string `json:"type"`
+	Life    string `json:"life"`
+	Cloud   string `json:"cloud"`
+	Region  string `json:"region,omitempty"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// GatewayModelStatus is returned by the API gateway's model status
+// query.
+type GatewayModelStatus struct {
+	Model        GatewayModel         `json:"model"`
+	Machines     []GatewayMachine     `json:"machines"`
+	Applications []GatewayApplication `json:"applications"`
+}
+
+// GatewayMachine describes a machine in the API gateway's results.
+type GatewayMachine struct {
+	Id         string `json:"id"`
+	InstanceId string `json:"instance-id,omitempty"`
+	Status     string `json:"status"`
+	Message    string `json:"message,omitempty"`
+}
+
+// GatewayApplication describes an application, and its units, in the
+// API gateway's results.
+type GatewayApplication struct {
+	Name    string        `json:"name"`
+	Charm   string        `json:"charm"`
+	Status  string        `json:"status"`
+	Message string        `json:"message,omitempty"`
+	Units   []GatewayUnit `json:"units"`
+}
+
+// GatewayUnit describes a unit in the API gateway's results.
+type GatewayUnit struct {
+	Name            string `json:"name"`
+	Machine         string `json:"machine,omitempty"`
+	WorkloadStatus  string `json:"workload-status"`
+	WorkloadMessage string `json:"workload-message,omitempty"`
+	AgentStatus     string `json:"agent-status"`
+}
+
+// GatewayOffers is returned by the API gateway's offers query.
+type GatewayOffers struct {
+	Offers []GatewayOffer `json:"offers"`
+}
+
+// GatewayOffer describes an application offer in the API gateway's
+// results.
+type GatewayOffer struct {
+	UUID        string   `json:"uuid"`
+	Name        string   `json:"name"`
+	Application string   `json:"application"`
+	Endpoints   []string `json:"endpoints"`
+}
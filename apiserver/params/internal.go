@@ -387,6 +387,22 @@ type RelationUnitsSettings struct {
 	RelationUnits []RelationUnitSettings `json:"relation-units"`
 }
 
+// RelationSchemaArg holds the parameters for registering the schema a
+// unit's application requires of the relation data it receives over
+// one of its endpoints. An empty schema removes any registered schema.
+type RelationSchemaArg struct {
+	Tag         string `json:"tag"`
+	Endpoint    string `json:"endpoint"`
+	Application bool   `json:"application"`
+	Schema      string `json:"schema"`
+}
+
+// RelationSchemaArgs holds the parameters for registering a set of
+// relation schemas.
+type RelationSchemaArgs struct {
+	Args []RelationSchemaArg `json:"args"`
+}
+
 // RelationResults holds the result of an API call that returns
 // information about multiple relations.
 type RelationResults struct {
@@ -403,6 +419,7 @@ type RelationResult struct {
 	Key              string     `json:"key"`
 	Endpoint         Endpoint   `json:"endpoint"`
 	OtherApplication string     `json:"other-application,omitempty"`
+	KeyGeneration    int        `json:"key-generation,omitempty"`
 }
 
 // RelationResultV5 returns information about a single relation,
@@ -629,6 +646,19 @@ type StringsWatchResults struct {
 	Results []StringsWatchResult `json:"results"`
 }
 
+// NotifyWatcherNextResult holds the result of a NotifyWatcher Next call,
+// with the token for resuming the watcher from this change.
+type NotifyWatcherNextResult struct {
+	ResumeToken string `json:"resume-token"`
+}
+
+// StringsWatcherNextResult holds the changes from a StringsWatcher Next
+// call, with the token for resuming the watcher from these changes.
+type StringsWatcherNextResult struct {
+	Changes     []string `json:"changes,omitempty"`
+	ResumeToken string   `json:"resume-token"`
+}
+
 // EntitiesWatchResult holds a EntitiesWatcher id, changes and an error
 // (if any).
 type EntitiesWatchResult struct {
@@ -971,10 +1001,11 @@ type ResourceUploadResult struct {
 // UnitRefreshResult is used to return the latest values for attributes
 // on a unit.
 type UnitRefreshResult struct {
-	Life       life.Value
-	Resolved   ResolvedMode
-	Error      *Error
-	ProviderID string `json:"provider-id,omitempty"`
+	Life           life.Value
+	Resolved       ResolvedMode
+	Error          *Error
+	ProviderID     string `json:"provider-id,omitempty"`
+	DrainRequested bool   `json:"drain-requested,omitempty"`
 }
 
 // UnitRefreshResults holds the results for any API call which ends
@@ -983,6 +1014,42 @@ type UnitRefreshResults struct {
 	Results []UnitRefreshResult
 }
 
+// ResourceLimits holds the limits applied to the workload processes
+// of a unit on an IAAS machine, taken from the cpu-quota and
+// memory-limit constraints of its application.
+type ResourceLimits struct {
+	// CPUQuota is the CPU time available to the unit, as a percentage
+	// of a single CPU.
+	CPUQuota *uint64 `json:"cpu-quota,omitempty"`
+
+	// MemoryLimit is the memory available to the unit, in MiB.
+	MemoryLimit *uint64 `json:"memory-limit,omitempty"`
+}
+
+// ResourceLimitsResult holds the resource limits of a unit, or an
+// error.
+type ResourceLimitsResult struct {
+	Result ResourceLimits `json:"result"`
+	Error  *Error         `json:"error,omitempty"`
+}
+
+// ResourceLimitsResults holds the results of a ResourceLimits call.
+type ResourceLimitsResults struct {
+	Results []ResourceLimitsResult `json:"results"`
+}
+
+// SecretConfigArg identifies a secret charm config option whose value
+// is requested by a unit.
+type SecretConfigArg struct {
+	UnitTag string `json:"unit-tag"`
+	Key     string `json:"key"`
+}
+
+// SecretConfigArgs holds the arguments to a SecretConfigValues call.
+type SecretConfigArgs struct {
+	Args []SecretConfigArg `json:"args"`
+}
+
 // EntityString holds an entity tag and a string value.
 type EntityString struct {
 	Tag   string `json:"tag"`
@@ -0,0 +1,84 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// IntrospectArgs holds the arguments for an
+// AgentIntrospection.Introspect call.
+type IntrospectArgs struct {
+	Args []IntrospectArg `json:"args"`
+}
+
+// IntrospectArg identifies an agent, and the path to query on its
+// introspection socket.
+type IntrospectArg struct {
+	// Tag is the tag of a machine or unit. Units are introspected
+	// through the agent of the machine they are assigned to.
+	Tag string `json:"tag"`
+
+	// Path is the path, and any query, to request, such as
+	// "/depengine" or "/debug/pprof/goroutine?debug=1".
+	Path string `json:"path"`
+}
+
+// IntrospectionRequestIds holds the ids of introspection requests.
+type IntrospectionRequestIds struct {
+	Ids []string `json:"ids"`
+}
+
+// IntrospectionResult holds the outcome of an introspection request.
+type IntrospectionResult struct {
+	Id string `json:"id"`
+
+	// Completed is true once the agent has responded to the request.
+	Completed bool `json:"completed"`
+
+	// Output holds the response from the agent's introspection socket.
+	Output []byte `json:"output,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+}
+
+// IntrospectionResults holds the results of an
+// AgentIntrospection.Results call.
+type IntrospectionResults struct {
+	Results []IntrospectionResult `json:"results"`
+}
+
+// IntrospectionRequest is a request for an agent to query its
+// introspection socket.
+type IntrospectionRequest struct {
+	Id        string `json:"id"`
+	Path      string `json:"path"`
+	Completed bool   `json:"completed"`
+}
+
+// IntrospectionRequestResult holds an introspection request, or an
+// error retrieving it.
+type IntrospectionRequestResult struct {
+	Request *IntrospectionRequest `json:"request,omitempty"`
+	Error   *Error                `json:"error,omitempty"`
+}
+
+// IntrospectionRequestResults holds the results of an
+// IntrospectionResponder.Requests call.
+type IntrospectionRequestResults struct {
+	Results []IntrospectionRequestResult `json:"results"`
+}
+
+// IntrospectionResponse holds an agent's response to an introspection
+// request.
+type IntrospectionResponse struct {
+	Id     string `json:"id"`
+	Output []byte `json:"output,omitempty"`
+
+	// Error describes why the agent could not query its
+	// introspection socket.
+	Error string `json:"error,omitempty"`
+}
+
+// IntrospectionResponses holds the arguments for an
+// IntrospectionResponder.Respond call.
+type IntrospectionResponses struct {
+	Responses []IntrospectionResponse `json:"responses"`
+}
@@ -86,6 +86,38 @@ type ModelUnset struct {
 	Keys []string `json:"keys"`
 }
 
+// ModelConfigTraceArgs contains the arguments for the ModelConfigTrace
+// client API call.
+type ModelConfigTraceArgs struct {
+	Keys []string `json:"keys"`
+}
+
+// ModelConfigTraceResult holds the effective value of a model config
+// attribute and the value set by each config source it is resolved from.
+type ModelConfigTraceResult struct {
+	Key     string        `json:"key"`
+	Value   interface{}   `json:"value,omitempty"`
+	Source  string        `json:"source,omitempty"`
+	Sources []ConfigValue `json:"sources,omitempty"`
+	Error   *Error        `json:"error,omitempty"`
+}
+
+// ModelConfigTraceResults holds the results of a ModelConfigTrace call.
+type ModelConfigTraceResults struct {
+	Results []ModelConfigTraceResult `json:"results"`
+}
+
+// CloudInitCustomizationsResult holds the customizations that the
+// provisioner applies to the cloud-init user-data of new machines in
+// a model.
+type CloudInitCustomizationsResult struct {
+	Packages    []string    `json:"packages,omitempty"`
+	PreRunCmds  []string    `json:"preruncmds,omitempty"`
+	PostRunCmds []string    `json:"postruncmds,omitempty"`
+	AptProxy    ProxyConfig `json:"apt-proxy"`
+	SnapProxy   ProxyConfig `json:"snap-proxy"`
+}
+
 // ModelSLA contains the arguments for the SetSLALevel client API
 // call.
 type ModelSLA struct {
@@ -307,6 +339,10 @@ type ModelMachineInfo struct {
 	// HAPrimary indicates whether this machine has a primary mongo instance in replicaset and,
 	// thus, can be considered a primary controller machine in HA setup.
 	HAPrimary *bool `json:"ha-primary,omitempty"`
+	// CustomizationStatus and CustomizationMessage report the progress of
+	// the controller customization script on a controller machine.
+	CustomizationStatus  string `json:"customization-status,omitempty"`
+	CustomizationMessage string `json:"customization-message,omitempty"`
 }
 
 // MachineHardware holds information about a machine's hardware characteristics.
@@ -438,6 +474,22 @@ type ModelCredential struct {
 	Valid bool `json:"valid,omitempty"`
 }
 
+// ModelCredentialExpiry holds the result of checking whether the cloud
+// credential that a model uses is about to expire.
+type ModelCredentialExpiry struct {
+	// CloudCredential is the tag for the cloud credential that the
+	// model uses, if it has one.
+	CloudCredential string `json:"credential-tag,omitempty"`
+
+	// Status is "expiring" if the credential expires within the
+	// controller's warning period, "expired" if it has expired, and
+	// empty otherwise.
+	Status string `json:"status,omitempty"`
+
+	// Expiry holds when the credential expires, if it does.
+	Expiry *time.Time `json:"expiry,omitempty"`
+}
+
 // ChangeModelCredentialParams holds the argument to replace cloud credential
 // used by a model.
 type ChangeModelCredentialParams struct {
@@ -454,6 +506,71 @@ type ChangeModelCredentialsParams struct {
 	Models []ChangeModelCredentialParams `json:"model-credentials"`
 }
 
+// TransferModelOwnershipParam holds the arguments to transfer ownership
+// of a model to another user.
+type TransferModelOwnershipParam struct {
+	// ModelTag is the tag of the model being transferred.
+	ModelTag string `json:"model-tag"`
+
+	// OwnerTag is the tag of the user to become the model owner.
+	OwnerTag string `json:"owner-tag"`
+}
+
+// TransferModelOwnershipParams holds the arguments for transferring
+// ownership of models.
+type TransferModelOwnershipParams struct {
+	Models []TransferModelOwnershipParam `json:"models"`
+}
+
+// CloneModelArg holds the arguments to create a new model based on an
+// existing one.
+type CloneModelArg struct {
+	// SourceModelTag is the tag of the model to clone.
+	SourceModelTag string `json:"source-model-tag"`
+
+	// Name is the name of the new model.
+	Name string `json:"name"`
+
+	// OwnerTag is the tag of the user to own the new model. If empty,
+	// the new model is owned by the user making the request.
+	OwnerTag string `json:"owner-tag,omitempty"`
+
+	// IncludeApplications indicates whether the application topology
+	// of the source model should be exported as a bundle.
+	IncludeApplications bool `json:"include-applications,omitempty"`
+}
+
+// CloneModelArgs holds the arguments for cloning models.
+type CloneModelArgs struct {
+	Models []CloneModelArg `json:"models"`
+}
+
+// CloneModelResult holds the details of a model created by cloning.
+type CloneModelResult struct {
+	// ModelTag is the tag of the new model.
+	ModelTag string `json:"model-tag,omitempty"`
+
+	// Name is the name of the new model.
+	Name string `json:"name,omitempty"`
+
+	// OwnerTag is the tag of the new model's owner.
+	OwnerTag string `json:"owner-tag,omitempty"`
+
+	// Type is the type of the new model.
+	Type string `json:"type,omitempty"`
+
+	// Bundle holds the application topology of the source model, if
+	// it was requested.
+	Bundle string `json:"bundle,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+}
+
+// CloneModelResults holds the results of cloning models.
+type CloneModelResults struct {
+	Results []CloneModelResult `json:"results"`
+}
+
 // ValidateModelUpgradeParams is used to ensure that a model can be upgraded.
 type ValidateModelUpgradeParams struct {
 	Models []ValidateModelUpgradeParam `json:"model"`
@@ -466,3 +583,108 @@ type ValidateModelUpgradeParam struct {
 	// ModelTag is a tag for the model that you want to upgrade.
 	ModelTag string `json:"model-tag"`
 }
+
+// SetModelSpecArgs holds the declarative spec of a model to set.
+type SetModelSpecArgs struct {
+	// Spec holds the YAML of the spec.
+	Spec string `json:"spec"`
+}
+
+// SetModelSpecResult holds the result of setting a model's spec.
+type SetModelSpecResult struct {
+	// Revision is the revision of the spec.
+	Revision int `json:"revision"`
+}
+
+// ModelSpecResult holds the declarative spec of a model, and the status
+// of reconciling the model towards it.
+type ModelSpecResult struct {
+	// Spec holds the YAML of the spec.
+	Spec string `json:"spec"`
+
+	// Revision is the revision of the spec.
+	Revision int `json:"revision"`
+
+	// Status is the status of reconciling the model towards its spec,
+	// or nil if the model has not yet been reconciled.
+	Status *ModelSpecStatus `json:"status,omitempty"`
+}
+
+// ModelSpecStatus holds the status of reconciling a model towards its
+// spec.
+type ModelSpecStatus struct {
+	// Revision is the revision of the spec last reconciled.
+	Revision int `json:"revision"`
+
+	// Reconciled is when the model was last reconciled.
+	Reconciled time.Time `json:"reconciled"`
+
+	// Drift describes the differences between the model and its spec
+	// which could not be reconciled.
+	Drift []string `json:"drift,omitempty"`
+}
+
+// ModelDiffArgs holds the model descriptions to compare with
+// ModelDiff.Diff.
+type ModelDiffArgs struct {
+	// A and B hold the serialized model descriptions, as written by
+	// dump-model.
+	A string `json:"a"`
+	B string `json:"b"`
+
+	// IncludeVolatile includes fields expected to differ between
+	// otherwise identical models, such as timestamps and UUIDs, in
+	// the comparison.
+	IncludeVolatile bool `json:"include-volatile,omitempty"`
+}
+
+// ModelDifference describes a difference between two model
+// descriptions.
+type ModelDifference struct {
+	// Path identifies the differing part of the description, such as
+	// "applications[mysql].units[mysql/0].workload-status.value".
+	// List items are identified by their name or ID where they have
+	// one, and by their index otherwise.
+	Path string `json:"path"`
+
+	// Kind is one of "added", "removed" or "changed".
+	Kind string `json:"kind"`
+
+	// A and B hold the values in each description, rendered as YAML.
+	A string `json:"a,omitempty"`
+	B string `json:"b,omitempty"`
+}
+
+// ModelDiffResult holds the result of a ModelDiff.Diff call.
+type ModelDiffResult struct {
+	Differences []ModelDifference `json:"differences"`
+}
+
+// ModelDestructionProgress reports what the destruction of a model is
+// still waiting on.
+type ModelDestructionProgress struct {
+	Life  life.Value `json:"life"`
+	Force bool       `json:"force,omitempty"`
+
+	// DyingSince is when the model was destroyed.
+	DyingSince *time.Time `json:"dying-since,omitempty"`
+
+	// Blockers holds the entities which remain to be removed,
+	// ordered by kind and ID.
+	Blockers []ModelDestructionBlocker `json:"blockers"`
+}
+
+// ModelDestructionBlocker describes an entity which must be removed
+// before a dying model can be.
+type ModelDestructionBlocker struct {
+	// Kind is one of "machine", "application", "volume", "filesystem"
+	// or "relation". Only cross-model relations are reported.
+	Kind string     `json:"kind"`
+	Id   string     `json:"id"`
+	Life life.Value `json:"life"`
+
+	// Status, Message and Since describe the entity's current status.
+	Status  string     `json:"status,omitempty"`
+	Message string     `json:"message,omitempty"`
+	Since   *time.Time `json:"since,omitempty"`
+}
@@ -934,6 +934,25 @@ type SubnetsFilters struct {
 	Zone     string `json:"zone,omitempty"`
 }
 
+// ReloadSubnetsResult holds the result of a ReloadSubnets API call.
+// Missing lists the subnets known to Juju that the provider no longer
+// reports.
+type ReloadSubnetsResult struct {
+	Missing []MissingSubnet `json:"missing,omitempty"`
+}
+
+// MissingSubnet describes a subnet that the provider no longer reports.
+// Machines are the machines with addresses in the subnet and Bindings are
+// the applications with endpoints bound to its space, when no other subnet
+// in that space remains.
+type MissingSubnet struct {
+	CIDR       string   `json:"cidr"`
+	ProviderId string   `json:"provider-id,omitempty"`
+	SpaceTag   string   `json:"space-tag"`
+	Machines   []Entity `json:"machines,omitempty"`
+	Bindings   []Entity `json:"bindings,omitempty"`
+}
+
 // AddSubnetsParams holds the arguments of AddSubnets API call.
 type AddSubnetsParams struct {
 	Subnets []AddSubnetParams `json:"subnets"`
@@ -997,6 +1016,22 @@ type RenameSpacesParams struct {
 	Changes []RenameSpaceParams `json:"changes"`
 }
 
+// MergeSpaceParams holds params to merge a space into another.
+// A `from` and `to` space tag.
+type MergeSpaceParams struct {
+	FromSpaceTag string `json:"from-space-tag"`
+	ToSpaceTag   string `json:"to-space-tag"`
+
+	// Force, when true, merges the spaces despite existing constraints
+	// that would contradict each other after the merge.
+	Force bool `json:"force"`
+}
+
+// MergeSpacesParams holds the arguments of the MergeSpaces API call.
+type MergeSpacesParams struct {
+	Args []MergeSpaceParams `json:"args"`
+}
+
 // CreateSpacesParams holds the arguments of the AddSpaces API call.
 type CreateSpacesParamsV4 struct {
 	Spaces []CreateSpaceParamsV4 `json:"spaces"`
@@ -1093,6 +1128,29 @@ type ShowSpaceResults struct {
 	Results []ShowSpaceResult `json:"results"`
 }
 
+// SpaceIssue describes a problem found by CheckSpaces, with a
+// suggested fix.
+type SpaceIssue struct {
+	// Severity is "error" for problems which will cause deployments
+	// to fail, and "warning" for anything else.
+	Severity string `json:"severity"`
+
+	// Entity is the tag of the model, application or space which has
+	// the problem.
+	Entity string `json:"entity"`
+
+	// Message describes the problem.
+	Message string `json:"message"`
+
+	// Suggestion describes how the problem can be fixed.
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// CheckSpacesResult holds the problems found by CheckSpaces.
+type CheckSpacesResult struct {
+	Issues []SpaceIssue `json:"issues"`
+}
+
 // ListSpacesResults holds the list of all available spaces.
 type ListSpacesResults struct {
 	Results []Space `json:"results"`
@@ -1101,6 +1159,7 @@ type ListSpacesResults struct {
 // Space holds the information about a single space and its associated subnets.
 type Space struct {
 	Id      string   `json:"id"`
+	UUID    string   `json:"uuid,omitempty"`
 	Name    string   `json:"name"`
 	Subnets []Subnet `json:"subnets"`
 	Error   *Error   `json:"error,omitempty"`
@@ -1130,6 +1189,7 @@ type ProxyConfigResult struct {
 	SnapStoreProxyId         string      `json:"snap-store-id,omitempty"`
 	SnapStoreProxyAssertions string      `json:"snap-store-assertions,omitempty"`
 	SnapStoreProxyURL        string      `json:"snap-store-proxy-url,omitempty"`
+	APIProxy                 string      `json:"api-proxy,omitempty"`
 	Error                    *Error      `json:"error,omitempty"`
 }
 
@@ -1184,13 +1244,38 @@ type NetworkInfoResult struct {
 	Info             []NetworkInfo `json:"bind-addresses,omitempty" yaml:"bind-addresses,omitempty"`
 	EgressSubnets    []string      `json:"egress-subnets,omitempty" yaml:"egress-subnets,omitempty"`
 	IngressAddresses []string      `json:"ingress-addresses,omitempty" yaml:"ingress-addresses,omitempty"`
+
+	// ShadowAddresses are provider addresses, such as public or floating
+	// IPs, that are routed to the binding's devices but not configured on
+	// them. It is only populated from NetworkInfoSchemaV2.
+	ShadowAddresses []string `json:"shadow-addresses,omitempty" yaml:"shadow-addresses,omitempty"`
 }
 
 // NetworkInfoResults holds a mapping from binding name to NetworkInfoResult.
 type NetworkInfoResults struct {
 	Results map[string]NetworkInfoResult `json:"results"`
+
+	// SchemaVersion is the version of the NetworkInfoResult schema that
+	// the results were rendered with. It is not set by controllers that
+	// predate schema negotiation, which only render NetworkInfoSchemaV1.
+	SchemaVersion int `json:"schema-version,omitempty"`
 }
 
+const (
+	// NetworkInfoSchemaV1 is the NetworkInfoResult schema rendered
+	// before the schema version could be requested.
+	NetworkInfoSchemaV1 = 1
+
+	// NetworkInfoSchemaV2 adds the shadow addresses of the binding's
+	// devices to NetworkInfoResult.
+	NetworkInfoSchemaV2 = 2
+
+	// NetworkInfoSchemaLatest is the most recent NetworkInfoResult schema.
+	// New result fields must only be populated for schema versions that
+	// include them, so that charms parsing older schemas are not broken.
+	NetworkInfoSchemaLatest = NetworkInfoSchemaV2
+)
+
 // NetworkInfoResultV6 holds either and error or a list of NetworkInfos for given binding.
 type NetworkInfoResultV6 struct {
 	Error *Error        `json:"error,omitempty" yaml:"error,omitempty"`
@@ -1212,6 +1297,17 @@ type NetworkInfoParams struct {
 	// Change it to "endpoints" if bumping the facade version for another
 	// purpose.
 	Endpoints []string `json:"bindings"`
+
+	// SchemaVersion is the version of the NetworkInfoResult schema
+	// that the results should be rendered with.
+	// If zero, NetworkInfoSchemaLatest is used.
+	SchemaVersion int `json:"schema-version,omitempty"`
+
+	// PreferredAddressFamily is the address family ("ipv4" or "ipv6")
+	// whose ingress addresses and egress subnets should be listed first
+	// in the results. Addresses of both families are always returned.
+	// If empty, IPv4 addresses are listed first.
+	PreferredAddressFamily string `json:"preferred-address-family,omitempty"`
 }
 
 // FanConfigEntry holds configuration for a single fan.
@@ -294,6 +294,72 @@ type DestroyMachinesParams struct {
 	MaxWait *time.Duration `json:"max-wait,omitempty"`
 }
 
+// SetEgressNATAddressesArgs holds the egress NAT gateway addresses to
+// declare for machines. An empty value clears the declaration.
+type SetEgressNATAddressesArgs struct {
+	Args []EntityString `json:"args"`
+}
+
+// MachineHardwareResult holds the hardware characteristics of a machine
+// or an error.
+type MachineHardwareResult struct {
+	Hardware *MachineHardware `json:"hardware,omitempty"`
+	Error    *Error           `json:"error,omitempty"`
+}
+
+// MachineHardwareResults holds the results of refreshing the hardware
+// characteristics of machines.
+type MachineHardwareResults struct {
+	Results []MachineHardwareResult `json:"results"`
+}
+
+// ResizeMachineArg holds the constraints to resize a machine's instance
+// to. Force resizes the instance without first stopping the workloads
+// on the machine.
+type ResizeMachineArg struct {
+	Tag         string            `json:"tag"`
+	Constraints constraints.Value `json:"constraints"`
+	Force       bool              `json:"force,omitempty"`
+}
+
+// ResizeMachinesArgs holds the parameters for resizing machines.
+type ResizeMachinesArgs struct {
+	Args []ResizeMachineArg `json:"args"`
+}
+
+// ReplaceMachineArg identifies a machine to replace with a new machine.
+// The replacement is provisioned with the given constraints, or with
+// those of the machine being replaced if there are none.
+type ReplaceMachineArg struct {
+	Tag         string             `json:"tag"`
+	Constraints *constraints.Value `json:"constraints,omitempty"`
+}
+
+// ReplaceMachinesArgs holds the parameters for replacing machines.
+type ReplaceMachinesArgs struct {
+	Args []ReplaceMachineArg `json:"args"`
+}
+
+// ReplaceMachineResult holds the progress of replacing a machine.
+type ReplaceMachineResult struct {
+	// Replacement is the tag of the replacement machine.
+	Replacement string `json:"replacement,omitempty"`
+
+	// Message describes the current step of the replacement.
+	Message string `json:"message,omitempty"`
+
+	// Done is true once the units have been moved to the
+	// replacement machine and the old machine is being removed.
+	Done bool `json:"done,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+}
+
+// ReplaceMachineResults holds the results of a ReplaceMachines call.
+type ReplaceMachineResults struct {
+	Results []ReplaceMachineResult `json:"results"`
+}
+
 // UpdateSeriesArg holds the parameters for updating the series for the
 // specified application or machine. For Application, only known by facade
 // version 5 and greater. For MachineManger, only known by facade version
@@ -503,6 +569,20 @@ type ApplicationMergeBindings struct {
 	Force          bool              `json:"force"`
 }
 
+// ApplicationStorageUpdateArgs holds the parameters for updating the
+// storage constraints of applications.
+type ApplicationStorageUpdateArgs struct {
+	Args []ApplicationStorageUpdate `json:"args"`
+}
+
+// ApplicationStorageUpdate holds the storage constraints, keyed by
+// storage name, to use for the application's future units. Unset
+// fields keep their current values.
+type ApplicationStorageUpdate struct {
+	ApplicationTag     string                        `json:"application-tag"`
+	StorageConstraints map[string]StorageConstraints `json:"storage-constraints"`
+}
+
 // DestroyApplicationUnits holds parameters for the deprecated
 // Application.DestroyUnits call.
 type DestroyApplicationUnits struct {
@@ -659,6 +739,19 @@ type DownloadInfoResult struct {
 	Origin CharmOrigin `json:"charm-origin"`
 }
 
+// BundleArchiveResults holds the results of a DownloadBundles call.
+type BundleArchiveResults struct {
+	Results []BundleArchiveResult `json:"results"`
+}
+
+// BundleArchiveResult holds a bundle archive downloaded by the controller
+// on behalf of a client.
+type BundleArchiveResult struct {
+	Archive []byte      `json:"archive,omitempty"`
+	Origin  CharmOrigin `json:"charm-origin"`
+	Error   *Error      `json:"error,omitempty"`
+}
+
 // AllWatcherId holds the id of an AllWatcher.
 type AllWatcherId struct {
 	AllWatcherId string `json:"watcher-id"`
@@ -741,6 +834,7 @@ type ContainerConfig struct {
 	AptMirror                  string                 `json:"apt-mirror"`
 	CloudInitUserData          map[string]interface{} `json:"cloudinit-userdata,omitempty"`
 	ContainerInheritProperties string                 `json:"container-inherit-properties,omitempty"`
+	APIProxy                   string                 `json:"api-proxy,omitempty"`
 	*UpdateBehavior
 }
 
@@ -29,3 +29,21 @@ type UnitResourceResult struct {
 	// Resource is the info for the requested resource.
 	Resource Resource `json:"resource"`
 }
+
+// ShareResourcesArgs holds the arguments for an API request to share
+// resources of the unit's application with subordinate applications.
+type ShareResourcesArgs struct {
+	// Args holds the resources to share.
+	Args []ShareResourceArg `json:"args"`
+}
+
+// ShareResourceArg identifies a resource of the unit's application and
+// the subordinate application to share it with.
+type ShareResourceArg struct {
+	// ResourceName is the name of the resource to share.
+	ResourceName string `json:"resource-name"`
+
+	// ApplicationTag is the tag of the subordinate application that
+	// the resource is shared with.
+	ApplicationTag string `json:"application-tag"`
+}
@@ -15,6 +15,8 @@ type RetryStrategy struct {
 	MaxRetryTime    time.Duration `json:"max-retry-time"`
 	JitterRetryTime bool          `json:"jitter-retry-time"`
 	RetryTimeFactor int64         `json:"retry-time-factor"`
+	MaxRetries      int           `json:"max-retries,omitempty"`
+	Hooks           []string      `json:"hooks,omitempty"`
 }
 
 // RetryStrategyResult holds a RetryStrategy or an error.
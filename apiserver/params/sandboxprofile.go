@@ -0,0 +1,26 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// SandboxProfile holds the sandboxing an application's charm declares
+// its units need on their machine.
+type SandboxProfile struct {
+	// Application is the name of the application needing the profile.
+	Application string `json:"application"`
+
+	// Sysctls holds the values of the kernel parameters to set, keyed
+	// by name.
+	Sysctls map[string]string `json:"sysctls,omitempty"`
+
+	// AppArmorProfiles holds the text of the AppArmor profiles to
+	// load, keyed by a name unique within the application.
+	AppArmorProfiles map[string]string `json:"apparmor-profiles,omitempty"`
+}
+
+// SandboxProfilesResult holds the sandbox profiles to be applied to a
+// machine, or an error.
+type SandboxProfilesResult struct {
+	Profiles []SandboxProfile `json:"profiles"`
+	Error    *Error           `json:"error,omitempty"`
+}
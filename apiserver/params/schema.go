@@ -0,0 +1,29 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// FacadeSchemaArgs selects the facades described by the Schema facade
+// and the /schema endpoint.
+type FacadeSchemaArgs struct {
+	// Facades holds the names of the facades to describe. All of the
+	// controller's facades are described if it is empty.
+	Facades []string `json:"facades,omitempty"`
+
+	// Latest selects only the latest version of each facade.
+	Latest bool `json:"latest,omitempty"`
+}
+
+// FacadeSchemaResults holds the RPC schema of a running controller.
+type FacadeSchemaResults struct {
+	JujuVersion string         `json:"juju-version"`
+	Facades     []FacadeSchema `json:"facades"`
+}
+
+// FacadeSchema holds the JSON schema of a version of a facade, which
+// describes its methods along with their parameters and results.
+type FacadeSchema struct {
+	Name    string                 `json:"name"`
+	Version int                    `json:"version"`
+	Schema  map[string]interface{} `json:"schema"`
+}
@@ -18,6 +18,17 @@ import (
 // StatusParams holds parameters for the Status call.
 type StatusParams struct {
 	Patterns []string `json:"patterns"`
+
+	// Spaces and Subnets restrict the status to machines with an
+	// address in one of the given spaces or subnets (CIDRs), and the
+	// units on those machines.
+	Spaces  []string `json:"spaces,omitempty"`
+	Subnets []string `json:"subnets,omitempty"`
+
+	// KnownRevno, if set, is the revno of a status the client already
+	// holds. If the model hasn't changed since, the status isn't
+	// returned and the result has NotModified set instead.
+	KnownRevno int64 `json:"known-revno,omitempty"`
 }
 
 // TODO(ericsnow) Add FullStatusResult.
@@ -32,6 +43,14 @@ type FullStatus struct {
 	Relations           []RelationStatus                   `json:"relations"`
 	ControllerTimestamp *time.Time                         `json:"controller-timestamp"`
 	Branches            map[string]BranchStatus            `json:"branches"`
+
+	// Revno identifies the state of the model the status was taken
+	// from. It is zero if the controller can't identify it.
+	Revno int64 `json:"revno,omitempty"`
+
+	// NotModified is set, and the status left empty, if the model
+	// hasn't changed since the known revno requested.
+	NotModified bool `json:"not-modified,omitempty"`
 }
 
 // IsEmpty checks all collections on FullStatus to determine if the status is empty.
@@ -103,6 +122,9 @@ type MachineStatus struct {
 	// Id is the Juju identifier for this machine in this model.
 	Id string `json:"id"`
 
+	// UUID is the machine's UUID, which never changes.
+	UUID string `json:"uuid,omitempty"`
+
 	// NetworkInterfaces holds a map of NetworkInterface for this machine.
 	NetworkInterfaces map[string]NetworkInterface `json:"network-interfaces,omitempty"`
 
@@ -129,6 +151,44 @@ type MachineStatus struct {
 	// PrimaryControllerMachine indicates whether this machine has a primary mongo instance in replicaset and,
 	//	// thus, can be considered a primary controller machine in HA setup.
 	PrimaryControllerMachine *bool `json:"primary-controller-machine,omitempty"`
+
+	// EngineHealth holds the health of the workers in the machine
+	// agent's dependency engine, as last reported by the agent.
+	EngineHealth *EngineHealth `json:"engine-health,omitempty"`
+}
+
+// EngineHealth summarises the health of the workers in an agent's
+// dependency engine. Only unhealthy workers are included.
+type EngineHealth struct {
+	Workers []EngineWorkerHealth `json:"workers,omitempty"`
+	Updated time.Time            `json:"updated"`
+}
+
+// EngineWorkerHealth describes an unhealthy worker in a dependency
+// engine.
+type EngineWorkerHealth struct {
+	Name string `json:"name"`
+
+	// Health is "failing" for a worker which has stopped with an
+	// error, or "bouncing" for a worker which keeps restarting.
+	Health string `json:"health"`
+
+	State    string `json:"state"`
+	Error    string `json:"error,omitempty"`
+	Restarts int    `json:"restarts,omitempty"`
+}
+
+// EngineHealthReports holds the arguments for an
+// EngineHealthReporter.ReportEngineHealth call.
+type EngineHealthReports struct {
+	Reports []EngineHealthReport `json:"reports"`
+}
+
+// EngineHealthReport holds the unhealthy workers in the dependency
+// engine of the agent with the given tag.
+type EngineHealthReport struct {
+	Tag     string               `json:"tag"`
+	Workers []EngineWorkerHealth `json:"workers"`
 }
 
 // LXDProfile holds status info about a LXDProfile
@@ -141,6 +201,7 @@ type LXDProfile struct {
 // ApplicationStatus holds status info about an application.
 type ApplicationStatus struct {
 	Err              *Error                     `json:"err,omitempty"`
+	UUID             string                     `json:"uuid,omitempty"`
 	Charm            string                     `json:"charm"`
 	Series           string                     `json:"series"`
 	Exposed          bool                       `json:"exposed"`
@@ -206,6 +267,9 @@ type MeterStatus struct {
 
 // UnitStatus holds status info about a unit.
 type UnitStatus struct {
+	// UUID is the unit's UUID, which never changes.
+	UUID string `json:"uuid,omitempty"`
+
 	// AgentStatus holds the status for a unit's agent.
 	AgentStatus DetailedStatus `json:"agent-status"`
 
@@ -228,6 +292,7 @@ type UnitStatus struct {
 // RelationStatus holds status info about a relation.
 type RelationStatus struct {
 	Id        int              `json:"id"`
+	UUID      string           `json:"uuid,omitempty"`
 	Key       string           `json:"key"`
 	Interface string           `json:"interface"`
 	Scope     string           `json:"scope"`
@@ -350,3 +415,89 @@ type BranchStatus struct {
 	Created       int64               `json:"created"`
 	CreatedBy     string              `json:"created-by"`
 }
+
+// HealthProblem describes a single problem found in a model by the
+// ModelHealth facade.
+type HealthProblem struct {
+	// Kind identifies the kind of problem, for example "unit-error"
+	// or "machine-down".
+	Kind string `json:"kind"`
+
+	// Severity is either "warning" or "critical".
+	Severity string `json:"severity"`
+
+	// Tag identifies the unit or machine with the problem.
+	Tag string `json:"tag"`
+
+	// Message describes the problem.
+	Message string `json:"message"`
+
+	// Since records when the problem was first reported, if known.
+	Since *time.Time `json:"since,omitempty"`
+}
+
+// ModelHealthSummary holds the result of a ModelHealth.Summary call.
+type ModelHealthSummary struct {
+	// Severity is the most severe of the problems found, or "ok" if
+	// there are none.
+	Severity string `json:"severity"`
+
+	// Problems holds the problems found in the model, most severe
+	// first.
+	Problems []HealthProblem `json:"problems"`
+}
+
+// AgentConnection describes a single connection of an agent to an API
+// server, as reported by the AgentPresence facade.
+type AgentConnection struct {
+	// Tag identifies the machine, unit or application agent.
+	Tag string `json:"tag"`
+
+	// Server is the tag of the controller machine whose API server the
+	// agent is connected to.
+	Server string `json:"server"`
+
+	// ConnectionID is the identifier given to the connection by the
+	// API server.
+	ConnectionID uint64 `json:"connection-id"`
+
+	// Status is "alive", or "missing" if the API server has gone away
+	// and not yet come back.
+	Status string `json:"status"`
+
+	// RemoteAddress is the address the agent connected from, if known.
+	RemoteAddress string `json:"remote-address,omitempty"`
+
+	// LastSeen records when the agent connected or last pinged the
+	// API server.
+	LastSeen time.Time `json:"last-seen"`
+}
+
+// AgentConnectionResults holds the result of an AgentPresence.Agents
+// call.
+type AgentConnectionResults struct {
+	Results []AgentConnection `json:"results"`
+}
+
+// StatusHistoryRecord is a single status transition of a unit, sent to
+// clients following the unit's status history over a websocket.
+type StatusHistoryRecord struct {
+	// Kind is the kind of status changed: the unit agent's
+	// ("juju-unit") or the workload's ("workload").
+	Kind string `json:"kind"`
+
+	Status  string                 `json:"status"`
+	Message string                 `json:"message"`
+	Data    map[string]interface{} `json:"data,omitempty"`
+	Since   time.Time              `json:"since"`
+
+	// Hook holds the name of the hook the unit agent started running,
+	// if this transition reports the start of a hook.
+	Hook string `json:"hook,omitempty"`
+
+	// CompletedHook holds the name of the hook that the unit agent
+	// was running before this transition, and HookDuration how long
+	// it ran for.
+	CompletedHook string        `json:"completed-hook,omitempty"`
+	HookDuration  time.Duration `json:"hook-duration,omitempty"`
+}
@@ -951,3 +951,112 @@ type AddStorageDetails struct {
 	// of the added storage instances.
 	StorageTags []string `json:"storage-tags"`
 }
+
+// CreateStorageSnapshotsArgs contains the parameters for taking snapshots
+// of a collection of storage instances.
+type CreateStorageSnapshotsArgs struct {
+	Snapshots []CreateStorageSnapshotArg `json:"snapshots"`
+}
+
+// CreateStorageSnapshotArg contains the parameters for taking a snapshot
+// of a storage instance.
+type CreateStorageSnapshotArg struct {
+	// StorageTag is the tag of the storage instance to take a
+	// snapshot of.
+	StorageTag string `json:"storage-tag"`
+
+	// Description is an optional description of the snapshot.
+	Description string `json:"description,omitempty"`
+}
+
+// StorageSnapshotResults contains the results of taking snapshots of
+// a collection of storage instances.
+type StorageSnapshotResults struct {
+	Results []StorageSnapshotResult `json:"results"`
+}
+
+// StorageSnapshotResult contains the result of taking a snapshot of a
+// storage instance.
+type StorageSnapshotResult struct {
+	Result *StorageSnapshot `json:"result,omitempty"`
+	Error  *Error           `json:"error,omitempty"`
+}
+
+// StorageSnapshotsResults contains the snapshots of a collection of
+// storage instances.
+type StorageSnapshotsResults struct {
+	Results []StorageSnapshotsResult `json:"results"`
+}
+
+// StorageSnapshotsResult contains the snapshots of a storage instance.
+type StorageSnapshotsResult struct {
+	Result []StorageSnapshot `json:"result,omitempty"`
+	Error  *Error            `json:"error,omitempty"`
+}
+
+// StorageSnapshot describes a provider snapshot of the volume backing
+// a storage instance.
+type StorageSnapshot struct {
+	// StorageTag is the tag of the storage instance that the
+	// snapshot was taken of.
+	StorageTag string `json:"storage-tag"`
+
+	// SnapshotId is the storage provider's unique ID for the snapshot.
+	SnapshotId string `json:"snapshot-id"`
+
+	// VolumeId is the storage provider's unique ID for the volume
+	// that the snapshot was taken of.
+	VolumeId string `json:"volume-id"`
+
+	// Size is the size of the snapshotted volume, in MiB.
+	Size uint64 `json:"size"`
+
+	// Description is the description of the snapshot, if any.
+	Description string `json:"description,omitempty"`
+
+	// Status is the provider-specific status of the snapshot.
+	Status string `json:"status"`
+
+	// Created is the time at which the snapshot was started, if known.
+	Created *time.Time `json:"created,omitempty"`
+}
+
+// RestoreStorageSnapshotsArgs contains the parameters for restoring a
+// collection of storage snapshots.
+type RestoreStorageSnapshotsArgs struct {
+	Snapshots []RestoreStorageSnapshotArg `json:"snapshots"`
+}
+
+// RestoreStorageSnapshotArg contains the parameters for restoring a
+// snapshot of a storage instance as new, detached, storage.
+type RestoreStorageSnapshotArg struct {
+	// StorageTag is the tag of the storage instance that the
+	// snapshot was taken of.
+	StorageTag string `json:"storage-tag"`
+
+	// SnapshotId is the storage provider's unique ID for the snapshot.
+	SnapshotId string `json:"snapshot-id"`
+
+	// AvailabilityZone is the availability zone in which to create the
+	// restored volume. If empty, the zone of the original volume is used.
+	AvailabilityZone string `json:"availability-zone,omitempty"`
+}
+
+// CreateUnitStorageSnapshotsArgs contains the parameters for units to take
+// snapshots of their attached storage.
+type CreateUnitStorageSnapshotsArgs struct {
+	Snapshots []CreateUnitStorageSnapshotArg `json:"snapshots"`
+}
+
+// CreateUnitStorageSnapshotArg contains the parameters for a unit to take
+// a snapshot of a storage instance attached to it.
+type CreateUnitStorageSnapshotArg struct {
+	// UnitTag is the tag of the unit taking the snapshot.
+	UnitTag string `json:"unit-tag"`
+
+	// StorageTag is the tag of the attached storage instance.
+	StorageTag string `json:"storage-tag"`
+
+	// Description is an optional description of the snapshot.
+	Description string `json:"description,omitempty"`
+}
@@ -37,6 +37,7 @@ type Pinger interface {
 type pingTimeout struct {
 	tomb    tomb.Tomb
 	action  func()
+	onPing  func()
 	clock   clock.Clock
 	timeout time.Duration
 	reset   chan struct{}
@@ -45,10 +46,12 @@ type pingTimeout struct {
 // newPingTimeout returns a new pingTimeout instance
 // that invokes the given action asynchronously if there
 // is more than the given timeout interval between calls
-// to its Ping method.
-func newPingTimeout(action func(), clock clock.Clock, timeout time.Duration) Pinger {
+// to its Ping method. If onPing is not nil, it is called
+// on each ping.
+func newPingTimeout(action, onPing func(), clock clock.Clock, timeout time.Duration) Pinger {
 	pt := &pingTimeout{
 		action:  action,
+		onPing:  onPing,
 		clock:   clock,
 		timeout: timeout,
 		reset:   make(chan struct{}),
@@ -62,8 +65,12 @@ func newPingTimeout(action func(), clock clock.Clock, timeout time.Duration) Pin
 func (pt *pingTimeout) Ping() {
 	select {
 	case <-pt.tomb.Dying():
+		return
 	case pt.reset <- struct{}{}:
 	}
+	if pt.onPing != nil {
+		pt.onPing()
+	}
 }
 
 // Stop terminates the ping timeout.
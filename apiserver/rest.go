@@ -15,7 +15,6 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
-	"github.com/juju/juju/state/storage"
 )
 
 // RestHTTPHandler creates is a http.Handler which serves ReST requests.
@@ -112,7 +111,7 @@ func (h *modelRestHandler) processRemoteApplication(r *http.Request, w http.Resp
 		return errors.Trace(err)
 	}
 
-	store := storage.NewStorage(sourceSt.ModelUUID(), sourceSt.MongoSession())
+	store := sourceSt.BlobStorage()
 	// Use the storage to retrieve and save the charm archive.
 	charmPath, err := common.ReadCharmFromStorage(store, h.dataDir, ch.StoragePath())
 	if errors.IsNotFound(err) {
@@ -55,6 +55,7 @@ var commonModelFacadeNames = set.NewStrings(
 	"RemoteRelations",
 	"Resumer",
 	"RetryStrategy",
+	"Schema",
 	"Singular",
 	"StatusHistory",
 	"Storage",
@@ -65,6 +66,13 @@ var commonModelFacadeNames = set.NewStrings(
 	"Upgrader",
 	"VolumeAttachmentsWatcher",
 	"RemoteRelationWatcher",
+	"WorkloadVersions",
+	"RelationSettings",
+	"ModelHealth",
+	"AgentPresence",
+	"ModelDiff",
+	"ModelDestruction",
+	"ScopedCredentials",
 )
 
 // caasModelFacadeNames lists facades that are only used with CAAS
@@ -19,7 +19,10 @@ var controllerFacadeNames = set.NewStrings(
 	"Cloud",
 	"Controller",
 	"CrossController",
+	"FacadeUsage",
+	"Inventory",
 	"MigrationTarget",
+	"ModelCloner",
 	"ModelManager",
 	"ModelSummaryWatcher",
 	"UserManager",
@@ -30,6 +33,7 @@ var controllerFacadeNames = set.NewStrings(
 var commonFacadeNames = set.NewStrings(
 	"Pinger",
 	"Bundle",
+	"Schema",
 
 	// TODO(mjs) - bug 1632172 - Exposed for model logins for
 	// backwards compatibility. Remove once we're sure no non-Juju
@@ -0,0 +1,249 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/utils/v2"
+
+	"github.com/juju/juju/apiserver/facade"
+)
+
+// resumeWatcherTimeout is how long a watcher is held after its
+// connection closes, waiting for the client to resume it on a new
+// connection.
+const resumeWatcherTimeout = time.Minute
+
+// resumableWatchersResource is the name of the connection resource that
+// holds the server's resumableWatchers.
+const resumableWatchersResource = "resumableWatchers"
+
+// errWatcherParked is returned from an outstanding Next call when the
+// watcher's connection closes and the watcher is parked for resumption.
+var errWatcherParked = errors.New("watcher parked")
+
+// resumableWatchers holds the watchers of closed connections so that
+// clients can resume them on a new connection, using the continuation
+// token returned from their last Next call. Parked watchers that are not
+// resumed within resumeWatcherTimeout are stopped.
+//
+// Parked watchers live only in the memory of the API server whose
+// connection closed. A client that reconnects to another controller, for
+// example after an HA failover, or to this one after it restarts, cannot
+// resume them and must start a fresh watcher instead.
+type resumableWatchers struct {
+	clock clock.Clock
+
+	mu     sync.Mutex
+	closed bool
+	parked map[string]*resumableWatcher
+}
+
+func newResumableWatchers(clock clock.Clock) *resumableWatchers {
+	return &resumableWatchers{
+		clock:  clock,
+		parked: make(map[string]*resumableWatcher),
+	}
+}
+
+// park holds w until it is resumed or the resume timeout expires. It
+// returns false if there is no registry or it has been closed, in which
+// case the caller is responsible for stopping the watcher.
+func (r *resumableWatchers) park(w *resumableWatcher) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return false
+	}
+	r.parked[w.key] = w
+	w.expiry = r.clock.AfterFunc(resumeWatcherTimeout, func() {
+		r.mu.Lock()
+		expired := r.parked[w.key] == w
+		if expired {
+			delete(r.parked, w.key)
+		}
+		r.mu.Unlock()
+		if expired {
+			logger.Debugf("parked watcher %q expired", w.key)
+			_ = w.watcher.Stop()
+		}
+	})
+	return true
+}
+
+// claim removes the watcher identified by the token from the registry,
+// as long as it belongs to the given owner and model and the token is
+// current. A token one behind the watcher's sequence means the client
+// missed the last result, which is then delivered again.
+func (r *resumableWatchers) claim(token, ownerTag, modelUUID string) (*resumableWatcher, error) {
+	key, seq, err := parseResumeToken(token)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	w, ok := r.parked[key]
+	if !ok || w.ownerTag != ownerTag || w.modelUUID != modelUUID {
+		return nil, errors.NotFoundf("resumable watcher")
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	switch {
+	case seq == w.seq:
+	case seq+1 == w.seq:
+		w.redeliver = true
+	default:
+		return nil, errors.NotValidf("stale resume token")
+	}
+	delete(r.parked, key)
+	w.expiry.Stop()
+	w.stopped = false
+	w.abort = make(chan struct{})
+	return w, nil
+}
+
+// Close stops all parked watchers. Watchers stopped after Close are not
+// parked.
+func (r *resumableWatchers) Close() {
+	r.mu.Lock()
+	r.closed = true
+	parked := r.parked
+	r.parked = nil
+	r.mu.Unlock()
+	for _, w := range parked {
+		w.expiry.Stop()
+		_ = w.watcher.Stop()
+	}
+}
+
+// resumableWatcher wraps a watcher resource so that it is parked rather
+// than stopped when its connection closes.
+type resumableWatcher struct {
+	registry  *resumableWatchers
+	key       string
+	ownerTag  string
+	modelUUID string
+	watcher   facade.Resource
+
+	// pull waits for the next change from the watcher, returning
+	// errWatcherParked if abort is closed first.
+	pull func(abort <-chan struct{}) (interface{}, error)
+
+	// nextMu serialises calls to next, so that a call left outstanding
+	// by a closed connection finishes before the resumed one starts.
+	nextMu sync.Mutex
+
+	mu        sync.Mutex
+	seq       uint64
+	last      interface{}
+	redeliver bool
+	discarded bool
+	stopped   bool
+	abort     chan struct{}
+	expiry    clock.Timer
+}
+
+func newResumableWatcher(
+	registry *resumableWatchers,
+	watcher facade.Resource,
+	ownerTag, modelUUID string,
+	pull func(abort <-chan struct{}) (interface{}, error),
+) (*resumableWatcher, error) {
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &resumableWatcher{
+		registry:  registry,
+		key:       uuid.String(),
+		ownerTag:  ownerTag,
+		modelUUID: modelUUID,
+		watcher:   watcher,
+		pull:      pull,
+		abort:     make(chan struct{}),
+	}, nil
+}
+
+// next returns the next change from the watcher along with the token
+// the client can use to resume the watcher from that point.
+func (w *resumableWatcher) next() (interface{}, string, error) {
+	w.nextMu.Lock()
+	defer w.nextMu.Unlock()
+
+	w.mu.Lock()
+	if w.redeliver {
+		w.redeliver = false
+		result, token := w.last, w.tokenLocked()
+		w.mu.Unlock()
+		return result, token, nil
+	}
+	abort := w.abort
+	w.mu.Unlock()
+
+	result, err := w.pull(abort)
+	if err != nil {
+		return nil, "", err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.seq++
+	w.last = result
+	return result, w.tokenLocked(), nil
+}
+
+func (w *resumableWatcher) tokenLocked() string {
+	return fmt.Sprintf("%s.%d", w.key, w.seq)
+}
+
+// discard marks the watcher as explicitly stopped by the client, so
+// that it is not parked.
+func (w *resumableWatcher) discard() {
+	w.mu.Lock()
+	w.discarded = true
+	w.mu.Unlock()
+}
+
+// Stop is part of the facade.Resource interface. Unless the client has
+// discarded the watcher, it is parked for resumption rather than
+// stopped. Stopping the watcher again before it is resumed does
+// nothing.
+func (w *resumableWatcher) Stop() error {
+	w.mu.Lock()
+	if w.stopped {
+		w.mu.Unlock()
+		return nil
+	}
+	w.stopped = true
+	discarded := w.discarded
+	if !discarded {
+		close(w.abort)
+	}
+	w.mu.Unlock()
+	if discarded || !w.registry.park(w) {
+		return w.watcher.Stop()
+	}
+	return nil
+}
+
+func parseResumeToken(token string) (string, uint64, error) {
+	i := strings.LastIndex(token, ".")
+	if i <= 0 {
+		return "", 0, errors.NotValidf("resume token %q", token)
+	}
+	seq, err := strconv.ParseUint(token[i+1:], 10, 64)
+	if err != nil {
+		return "", 0, errors.NotValidf("resume token %q", token)
+	}
+	return token[:i], seq, nil
+}
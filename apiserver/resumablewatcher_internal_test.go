@@ -0,0 +1,246 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade/facadetest"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/testing"
+)
+
+type resumableWatcherSuite struct {
+	testing.BaseSuite
+
+	clock      *testclock.Clock
+	registry   *resumableWatchers
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&resumableWatcherSuite{})
+
+func (s *resumableWatcherSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Now())
+	s.registry = newResumableWatchers(s.clock)
+	s.AddCleanup(func(*gc.C) { s.registry.Close() })
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:       names.NewMachineTag("0"),
+		ModelUUID: testing.ModelTag.Id(),
+	}
+}
+
+// newConnResources returns the resources of a new connection to the
+// server under test.
+func (s *resumableWatcherSuite) newConnResources(c *gc.C) *common.Resources {
+	resources := common.NewResources()
+	err := resources.RegisterNamed(resumableWatchersResource, common.ValueResource{Value: s.registry})
+	c.Assert(err, jc.ErrorIsNil)
+	return resources
+}
+
+func (s *resumableWatcherSuite) stringsWatcher(c *gc.C, resources *common.Resources, id string) *srvStringsWatcherV2 {
+	f, err := newStringsWatcherV2(facadetest.Context{
+		Resources_: resources,
+		Auth_:      s.authorizer,
+		ID_:        id,
+		Dispose_:   func() {},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return f.(*srvStringsWatcherV2)
+}
+
+func (s *resumableWatcherSuite) TestResumeAfterConnectionCloses(c *gc.C) {
+	w := &stubStringsWatcher{ch: make(chan []string, 1)}
+	resources := s.newConnResources(c)
+	id := resources.Register(w)
+
+	w.ch <- []string{"a"}
+	result, err := s.stringsWatcher(c, resources, id).Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Changes, jc.DeepEquals, []string{"a"})
+	c.Assert(result.ResumeToken, gc.Not(gc.Equals), "")
+
+	resources.StopAll()
+	c.Assert(w.stopped, jc.IsFalse)
+
+	resumed := s.newConnResources(c)
+	resumeResult, err := s.stringsWatcher(c, resumed, result.ResumeToken).Resume()
+	c.Assert(err, jc.ErrorIsNil)
+
+	w.ch <- []string{"b"}
+	next, err := s.stringsWatcher(c, resumed, resumeResult.StringsWatcherId).Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next.Changes, jc.DeepEquals, []string{"b"})
+	c.Assert(next.ResumeToken, gc.Not(gc.Equals), result.ResumeToken)
+}
+
+func (s *resumableWatcherSuite) TestResumeRedeliversMissedChange(c *gc.C) {
+	w := &stubStringsWatcher{ch: make(chan []string, 1)}
+	resources := s.newConnResources(c)
+	id := resources.Register(w)
+	facade := s.stringsWatcher(c, resources, id)
+
+	w.ch <- []string{"a"}
+	first, err := facade.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	// The result of this call never reaches the client.
+	w.ch <- []string{"b"}
+	_, err = facade.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	resources.StopAll()
+
+	resumed := s.newConnResources(c)
+	resumeResult, err := s.stringsWatcher(c, resumed, first.ResumeToken).Resume()
+	c.Assert(err, jc.ErrorIsNil)
+	next, err := s.stringsWatcher(c, resumed, resumeResult.StringsWatcherId).Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next.Changes, jc.DeepEquals, []string{"b"})
+}
+
+func (s *resumableWatcherSuite) TestResumeStaleToken(c *gc.C) {
+	w := &stubStringsWatcher{ch: make(chan []string, 1)}
+	resources := s.newConnResources(c)
+	id := resources.Register(w)
+	facade := s.stringsWatcher(c, resources, id)
+
+	var tokens []string
+	for _, change := range []string{"a", "b", "c"} {
+		w.ch <- []string{change}
+		result, err := facade.Next()
+		c.Assert(err, jc.ErrorIsNil)
+		tokens = append(tokens, result.ResumeToken)
+	}
+	resources.StopAll()
+
+	_, err := s.stringsWatcher(c, s.newConnResources(c), tokens[0]).Resume()
+	c.Assert(err, gc.ErrorMatches, "stale resume token not valid")
+}
+
+func (s *resumableWatcherSuite) TestResumeWrongOwner(c *gc.C) {
+	w := &stubStringsWatcher{ch: make(chan []string, 1)}
+	resources := s.newConnResources(c)
+	id := resources.Register(w)
+
+	w.ch <- []string{"a"}
+	result, err := s.stringsWatcher(c, resources, id).Next()
+	c.Assert(err, jc.ErrorIsNil)
+	resources.StopAll()
+
+	s.authorizer.Tag = names.NewMachineTag("1")
+	_, err = s.stringsWatcher(c, s.newConnResources(c), result.ResumeToken).Resume()
+	c.Assert(err, gc.Equals, apiservererrors.ErrUnknownWatcher)
+}
+
+func (s *resumableWatcherSuite) TestParkedWatcherExpires(c *gc.C) {
+	w := &stubStringsWatcher{ch: make(chan []string, 1)}
+	resources := s.newConnResources(c)
+	id := resources.Register(w)
+
+	w.ch <- []string{"a"}
+	result, err := s.stringsWatcher(c, resources, id).Next()
+	c.Assert(err, jc.ErrorIsNil)
+	resources.StopAll()
+	c.Assert(w.stopped, jc.IsFalse)
+
+	err = s.clock.WaitAdvance(resumeWatcherTimeout, testing.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.stopped, jc.IsTrue)
+
+	_, err = s.stringsWatcher(c, s.newConnResources(c), result.ResumeToken).Resume()
+	c.Assert(err, gc.Equals, apiservererrors.ErrUnknownWatcher)
+}
+
+func (s *resumableWatcherSuite) TestStoppedWatcherNotParked(c *gc.C) {
+	w := &stubStringsWatcher{ch: make(chan []string, 1)}
+	resources := s.newConnResources(c)
+	id := resources.Register(w)
+	facade := s.stringsWatcher(c, resources, id)
+
+	w.ch <- []string{"a"}
+	result, err := facade.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	err = facade.Stop()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.stopped, jc.IsTrue)
+
+	_, err = s.stringsWatcher(c, s.newConnResources(c), result.ResumeToken).Resume()
+	c.Assert(err, gc.Equals, apiservererrors.ErrUnknownWatcher)
+}
+
+func (s *resumableWatcherSuite) TestStopTwice(c *gc.C) {
+	w := &stubStringsWatcher{ch: make(chan []string, 1)}
+	resources := s.newConnResources(c)
+	id := resources.Register(w)
+	facade := s.stringsWatcher(c, resources, id)
+
+	w.ch <- []string{"a"}
+	result, err := facade.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	resumable := resources.Get(id).(*resumableWatcher)
+	c.Assert(resumable.Stop(), jc.ErrorIsNil)
+	c.Assert(resumable.Stop(), jc.ErrorIsNil)
+	c.Assert(w.stopped, jc.IsFalse)
+
+	_, err = s.stringsWatcher(c, s.newConnResources(c), result.ResumeToken).Resume()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *resumableWatcherSuite) TestCloseStopsParkedWatchers(c *gc.C) {
+	w := &stubStringsWatcher{ch: make(chan []string, 1)}
+	resources := s.newConnResources(c)
+	id := resources.Register(w)
+	_ = s.stringsWatcher(c, resources, id)
+	resources.StopAll()
+	c.Assert(w.stopped, jc.IsFalse)
+
+	s.registry.Close()
+	c.Assert(w.stopped, jc.IsTrue)
+}
+
+func (s *resumableWatcherSuite) TestNotifyWatcherNext(c *gc.C) {
+	w := apiservertesting.NewFakeNotifyWatcher()
+	resources := s.newConnResources(c)
+	id := resources.Register(w)
+
+	f, err := newNotifyWatcherV2(facadetest.Context{
+		Resources_: resources,
+		Auth_:      s.authorizer,
+		ID_:        id,
+		Dispose_:   func() {},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := f.(*srvNotifyWatcherV2).Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.Not(gc.Equals), params.NotifyWatcherNextResult{})
+}
+
+type stubStringsWatcher struct {
+	ch      chan []string
+	stopped bool
+}
+
+func (w *stubStringsWatcher) Changes() <-chan []string {
+	return w.ch
+}
+
+func (w *stubStringsWatcher) Kill() {}
+
+func (w *stubStringsWatcher) Wait() error {
+	return nil
+}
+
+func (w *stubStringsWatcher) Stop() error {
+	w.stopped = true
+	return nil
+}
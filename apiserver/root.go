@@ -9,7 +9,6 @@ import (
 	"net/url"
 	"reflect"
 	"sync"
-	"time"
 
 	"github.com/juju/clock"
 	"github.com/juju/errors"
@@ -30,14 +29,6 @@ import (
 	jujuversion "github.com/juju/juju/version"
 )
 
-var (
-	// maxClientPingInterval defines the timeframe until the ping timeout
-	// closes the monitored connection. TODO(mue): Idea by Roger:
-	// Move to API (e.g. params) so that the pinging there may
-	// depend on the interval.
-	maxClientPingInterval = 3 * time.Minute
-)
-
 type objectKey struct {
 	name    string
 	version int
@@ -109,6 +100,14 @@ func newAPIHandler(srv *Server, st *state.State, rpcConn *rpc.Conn, modelUUID st
 	if err := r.resources.RegisterNamed("logDir", common.StringResource(srv.logDir)); err != nil {
 		return nil, errors.Trace(err)
 	}
+	// Watchers served by the raw watcher facades are parked here when
+	// the connection closes, so that clients can resume them.
+	if err := r.resources.RegisterNamed(
+		resumableWatchersResource,
+		common.ValueResource{Value: srv.shared.resumableWatchers},
+	); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	// Facades involved with managing application offers need the auth context
 	// to mint and validate macaroons.
@@ -455,6 +454,16 @@ func (ctx *facadeContext) Hub() facade.Hub {
 	return ctx.r.shared.centralHub
 }
 
+// FacadeUsage implements facade.Context.
+func (ctx *facadeContext) FacadeUsage() facade.FacadeUsage {
+	return ctx.r.shared.facadeUsage
+}
+
+// CrashLoops implements facade.Context.
+func (ctx *facadeContext) CrashLoops() facade.CrashLoopRecorder {
+	return ctx.r.shared.crashLoops
+}
+
 // Controller implements facade.Context.
 func (ctx *facadeContext) Controller() *cache.Controller {
 	return ctx.r.shared.controller
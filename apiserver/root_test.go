@@ -34,7 +34,7 @@ func (r *pingSuite) TestPingTimeout(c *gc.C) {
 		close(triggered)
 	}
 	clock := testclock.NewClock(time.Now())
-	timeout := apiserver.NewPingTimeout(action, clock, 50*time.Millisecond)
+	timeout := apiserver.NewPingTimeout(action, nil, clock, 50*time.Millisecond)
 	for i := 0; i < 2; i++ {
 		waitAlarm(c, clock)
 		clock.Advance(10 * time.Millisecond)
@@ -63,7 +63,7 @@ func (r *pingSuite) TestPingTimeoutStopped(c *gc.C) {
 		close(triggered)
 	}
 	clock := testclock.NewClock(time.Now())
-	timeout := apiserver.NewPingTimeout(action, clock, 20*time.Millisecond)
+	timeout := apiserver.NewPingTimeout(action, nil, clock, 20*time.Millisecond)
 
 	waitAlarm(c, clock)
 	timeout.Stop()
@@ -77,6 +77,23 @@ func (r *pingSuite) TestPingTimeoutStopped(c *gc.C) {
 	}
 }
 
+func (r *pingSuite) TestPingTimeoutOnPing(c *gc.C) {
+	pinged := make(chan struct{}, 1)
+	onPing := func() {
+		pinged <- struct{}{}
+	}
+	clock := testclock.NewClock(time.Now())
+	timeout := apiserver.NewPingTimeout(func() {}, onPing, clock, time.Minute)
+	defer timeout.Stop()
+
+	timeout.Ping()
+	select {
+	case <-pinged:
+	case <-time.After(testing.LongWait):
+		c.Fatalf("onPing never called")
+	}
+}
+
 func waitAlarm(c *gc.C, clock *testclock.Clock) {
 	select {
 	case <-time.After(testing.LongWait):
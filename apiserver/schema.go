@@ -0,0 +1,59 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/facades/client/schema"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// schemaHandler serves the RPC schema of the controller's facades, as
+// also returned by the Schema facade. The facades to describe may be
+// selected with one or more "facade" query parameters, and "latest=true"
+// selects only the latest version of each.
+type schemaHandler struct{}
+
+func (h *schemaHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "GET" {
+		if err := sendError(w, errors.MethodNotAllowedf("unsupported method: %q", req.Method)); err != nil {
+			logger.Errorf("%v", err)
+		}
+		return
+	}
+	if err := h.serveGet(w, req); err != nil {
+		if err := sendError(w, errors.Trace(err)); err != nil {
+			logger.Errorf("%v", err)
+		}
+	}
+}
+
+func (h *schemaHandler) serveGet(w http.ResponseWriter, req *http.Request) error {
+	query := req.URL.Query()
+	var args params.FacadeSchemaArgs
+	for _, value := range query["facade"] {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				args.Facades = append(args.Facades, name)
+			}
+		}
+	}
+	if value := query.Get("latest"); value != "" {
+		latest, err := strconv.ParseBool(value)
+		if err != nil {
+			return errors.BadRequestf("invalid latest value %q", value)
+		}
+		args.Latest = latest
+	}
+	result, err := schema.FacadeSchemas(AllFacades(), args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(sendStatusAndJSON(w, http.StatusOK, result))
+}
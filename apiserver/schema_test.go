@@ -0,0 +1,84 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/testing"
+	"github.com/juju/juju/apiserver/params"
+	jujuversion "github.com/juju/juju/version"
+)
+
+type schemaSuite struct {
+	apiserverBaseSuite
+}
+
+var _ = gc.Suite(&schemaSuite{})
+
+func (s *schemaSuite) getSchema(c *gc.C, query url.Values, expectStatus int) []byte {
+	resp := s.sendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method: "GET",
+		URL:    s.URL("/schema", query).String(),
+	})
+	return apitesting.AssertResponse(c, resp, expectStatus, params.ContentTypeJSON)
+}
+
+func (s *schemaSuite) TestSchema(c *gc.C) {
+	body := s.getSchema(c, url.Values{"facade": {"Pinger,Schema"}}, http.StatusOK)
+	var result params.FacadeSchemaResults
+	err := json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("Body: %s", body))
+
+	c.Assert(result.JujuVersion, gc.Equals, jujuversion.Current.String())
+	c.Assert(result.Facades, gc.HasLen, 2)
+	c.Assert(result.Facades[0].Name, gc.Equals, "Pinger")
+	c.Assert(result.Facades[0].Version, gc.Equals, 1)
+	c.Assert(result.Facades[0].Schema["properties"], gc.DeepEquals, map[string]interface{}{
+		"Ping": map[string]interface{}{"type": "object"},
+		"Stop": map[string]interface{}{"type": "object"},
+	})
+	c.Assert(result.Facades[1].Name, gc.Equals, "Schema")
+	c.Assert(result.Facades[1].Schema["properties"], gc.HasLen, 1)
+}
+
+func (s *schemaSuite) TestSchemaLatest(c *gc.C) {
+	body := s.getSchema(c, url.Values{"facade": {"Spaces"}, "latest": {"true"}}, http.StatusOK)
+	var result params.FacadeSchemaResults
+	err := json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("Body: %s", body))
+	c.Assert(result.Facades, gc.HasLen, 1)
+	c.Assert(result.Facades[0].Name, gc.Equals, "Spaces")
+	c.Assert(result.Facades[0].Version, gc.Equals, 8)
+}
+
+func (s *schemaSuite) TestSchemaUnknownFacade(c *gc.C) {
+	body := s.getSchema(c, url.Values{"facade": {"Bogus"}}, http.StatusNotFound)
+	var result params.ErrorResult
+	err := json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("Body: %s", body))
+	c.Assert(result.Error.Message, gc.Equals, "facade Bogus not found")
+}
+
+func (s *schemaSuite) TestSchemaMethodNotAllowed(c *gc.C) {
+	resp := s.sendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method: "POST",
+		URL:    s.URL("/schema", nil).String(),
+	})
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusMethodNotAllowed)
+}
+
+func (s *schemaSuite) TestSchemaUnauthenticated(c *gc.C) {
+	resp := apitesting.SendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method: "GET",
+		URL:    s.URL("/schema", nil).String(),
+	})
+	body := apitesting.AssertResponse(c, resp, http.StatusUnauthorized, "text/plain; charset=utf-8")
+	c.Assert(string(body), gc.Equals, "authentication failed: no credentials provided\n")
+}
@@ -11,10 +11,13 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 
+	"github.com/juju/juju/apiserver/facade"
 	jujucontroller "github.com/juju/juju/controller"
 	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/facadeusage"
 	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/multiwatcher"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/pubsub/controller"
 	"github.com/juju/juju/state"
@@ -41,6 +44,9 @@ type sharedServerContext struct {
 	multiwatcherFactory multiwatcher.Factory
 	centralHub          SharedHub
 	presence            presence.Recorder
+	facadeUsage         *facadeusage.Tracker
+	crashLoops          facade.CrashLoopRecorder
+	resumableWatchers   *resumableWatchers
 	leaseManager        lease.Manager
 	logger              loggo.Logger
 	cancel              <-chan struct{}
@@ -58,6 +64,9 @@ type sharedServerConfig struct {
 	multiwatcherFactory multiwatcher.Factory
 	centralHub          SharedHub
 	presence            presence.Recorder
+	facadeUsage         *facadeusage.Tracker
+	crashLoops          facade.CrashLoopRecorder
+	resumableWatchers   *resumableWatchers
 	leaseManager        lease.Manager
 	controllerConfig    jujucontroller.Config
 	logger              loggo.Logger
@@ -79,6 +88,15 @@ func (c *sharedServerConfig) validate() error {
 	if c.presence == nil {
 		return errors.NotValidf("nil presence")
 	}
+	if c.facadeUsage == nil {
+		return errors.NotValidf("nil facadeUsage")
+	}
+	if c.crashLoops == nil {
+		return errors.NotValidf("nil crashLoops")
+	}
+	if c.resumableWatchers == nil {
+		return errors.NotValidf("nil resumableWatchers")
+	}
 	if c.leaseManager == nil {
 		return errors.NotValidf("nil leaseManager")
 	}
@@ -98,6 +116,9 @@ func newSharedServerContext(config sharedServerConfig) (*sharedServerContext, er
 		multiwatcherFactory: config.multiwatcherFactory,
 		centralHub:          config.centralHub,
 		presence:            config.presence,
+		facadeUsage:         config.facadeUsage,
+		crashLoops:          config.crashLoops,
+		resumableWatchers:   config.resumableWatchers,
 		leaseManager:        config.leaseManager,
 		logger:              config.logger,
 		controllerConfig:    config.controllerConfig,
@@ -118,6 +139,7 @@ func newSharedServerContext(config sharedServerConfig) (*sharedServerContext, er
 
 func (c *sharedServerContext) Close() {
 	c.unsubscribe()
+	c.resumableWatchers.Close()
 }
 
 func (c *sharedServerContext) onConfigChanged(topic string, data controller.ConfigChangedMessage, err error) {
@@ -147,8 +169,26 @@ func (c *sharedServerContext) featureEnabled(flag string) bool {
 	return c.features.Contains(flag)
 }
 
+func (c *sharedServerContext) agentPingTimeout() time.Duration {
+	c.configMutex.RLock()
+	defer c.configMutex.RUnlock()
+	return c.controllerConfig.AgentPingTimeout()
+}
+
 func (c *sharedServerContext) maxDebugLogDuration() time.Duration {
 	c.configMutex.RLock()
 	defer c.configMutex.RUnlock()
 	return c.controllerConfig.MaxDebugLogDuration()
 }
+
+func (c *sharedServerContext) apiGatewayToken() string {
+	c.configMutex.RLock()
+	defer c.configMutex.RUnlock()
+	return c.controllerConfig.APIGatewayToken()
+}
+
+func (c *sharedServerContext) filterAdvertisedHostPorts(hostPorts []network.SpaceHostPorts) []network.SpaceHostPorts {
+	c.configMutex.RLock()
+	defer c.configMutex.RUnlock()
+	return c.controllerConfig.FilterAdvertisedHostPorts(hostPorts)
+}
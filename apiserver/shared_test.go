@@ -17,6 +17,7 @@ import (
 
 	corecontroller "github.com/juju/juju/controller"
 	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/facadeusage"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/pubsub/controller"
 	"github.com/juju/juju/state"
@@ -76,6 +77,9 @@ func (s *sharedServerContextSuite) SetUpTest(c *gc.C) {
 		multiwatcherFactory: multiWatcherWorker,
 		centralHub:          s.hub,
 		presence:            presence.New(clock.WallClock),
+		facadeUsage:         facadeusage.NewTracker(clock.WallClock),
+		crashLoops:          crashLoopCounter{collector: NewMetricsCollector()},
+		resumableWatchers:   newResumableWatchers(clock.WallClock),
 		leaseManager:        &lease.Manager{},
 		controllerConfig:    controllerConfig,
 		logger:              loggo.GetLogger("test"),
@@ -117,6 +121,27 @@ func (s *sharedServerContextSuite) TestConfigNoPresence(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "nil presence not valid")
 }
 
+func (s *sharedServerContextSuite) TestConfigNoFacadeUsage(c *gc.C) {
+	s.config.facadeUsage = nil
+	err := s.config.validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, "nil facadeUsage not valid")
+}
+
+func (s *sharedServerContextSuite) TestConfigNoCrashLoops(c *gc.C) {
+	s.config.crashLoops = nil
+	err := s.config.validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, "nil crashLoops not valid")
+}
+
+func (s *sharedServerContextSuite) TestConfigNoResumableWatchers(c *gc.C) {
+	s.config.resumableWatchers = nil
+	err := s.config.validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, "nil resumableWatchers not valid")
+}
+
 func (s *sharedServerContextSuite) TestConfigNoLeaseManager(c *gc.C) {
 	s.config.leaseManager = nil
 	err := s.config.validate()
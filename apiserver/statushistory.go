@@ -0,0 +1,336 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/httpcontext"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/websocket"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// statusHistoryStreamHandler takes requests to follow the status
+// history of a unit, sending each of the unit's agent and workload
+// status transitions over a websocket as it happens.
+type statusHistoryStreamHandler struct {
+	ctxt          httpContext
+	authenticator httpcontext.Authenticator
+	authorizer    httpcontext.Authorizer
+}
+
+func newStatusHistoryStreamHandler(
+	ctxt httpContext,
+	authenticator httpcontext.Authenticator,
+	authorizer httpcontext.Authorizer,
+) *statusHistoryStreamHandler {
+	return &statusHistoryStreamHandler{
+		ctxt:          ctxt,
+		authenticator: authenticator,
+		authorizer:    authorizer,
+	}
+}
+
+// ServeHTTP will serve up connections as a websocket for the
+// status history streaming API.
+//
+// As for debug-log, authentication and authorization are done after
+// the http request has been upgraded to a websocket, so that discharge
+// required errors are returned in the initial error sent over the
+// websocket.
+//
+// Args for the HTTP request are as follows:
+//
+//	unit -> string - the name of the unit to follow
+//	backlog -> uint - send this many of the unit's most recent status
+//	   transitions before any new ones
+//	noTail -> string - one of [true, false], if true, the backlog is
+//	   sent back but the request does not wait for new transitions.
+func (h *statusHistoryStreamHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	handler := func(conn *websocket.Conn) {
+		socket := &statusHistorySocketImpl{debugLogSocketImpl{conn}}
+		defer conn.Close()
+
+		authInfo, err := h.authenticator.Authenticate(req)
+		if err != nil {
+			socket.sendError(errors.Annotate(err, "authentication failed"))
+			return
+		}
+		if err := h.authorizer.Authorize(authInfo); err != nil {
+			socket.sendError(errors.Annotate(err, "authorization failed"))
+			return
+		}
+
+		reqParams, err := readStatusHistoryParams(req.URL.Query())
+		if err != nil {
+			socket.sendError(err)
+			return
+		}
+
+		st, err := h.ctxt.stateForRequestUnauthenticated(req)
+		if err != nil {
+			socket.sendError(err)
+			return
+		}
+		defer st.Release()
+
+		unit, err := st.Unit(reqParams.unit)
+		if err != nil {
+			socket.sendError(err)
+			return
+		}
+
+		if err := handleStatusHistoryRequest(unit, reqParams, socket, h.ctxt.stop()); err != nil {
+			if isBrokenPipe(err) {
+				logger.Tracef("status history handler stopped (client disconnected)")
+			} else {
+				logger.Errorf("status history handler error: %v", err)
+			}
+		}
+	}
+	websocket.Serve(w, req, handler)
+}
+
+// statusHistoryUnit describes the unit methods needed to follow its
+// status history. It is implemented by *state.Unit.
+type statusHistoryUnit interface {
+	WatchStatus() state.NotifyWatcher
+	StatusHistory(status.StatusHistoryFilter) ([]status.StatusInfo, error)
+	AgentHistory() status.StatusHistoryGetter
+}
+
+// statusHistorySocket describes the functionality required to send
+// status history records to the client.
+type statusHistorySocket interface {
+	// sendOk sends a nil error response, indicating there were no errors.
+	sendOk()
+
+	// sendError sends a JSON-encoded error response.
+	sendError(err error)
+
+	// sendStatusRecord sends record JSON encoded.
+	sendStatusRecord(record *params.StatusHistoryRecord) error
+}
+
+type statusHistorySocketImpl struct {
+	debugLogSocketImpl
+}
+
+// sendStatusRecord implements statusHistorySocket.
+func (s *statusHistorySocketImpl) sendStatusRecord(record *params.StatusHistoryRecord) error {
+	return s.conn.WriteJSON(record)
+}
+
+// statusHistoryParams contains the parsed status history streaming
+// request parameters.
+type statusHistoryParams struct {
+	unit    string
+	backlog uint
+	noTail  bool
+}
+
+func readStatusHistoryParams(queryMap url.Values) (statusHistoryParams, error) {
+	var params statusHistoryParams
+
+	params.unit = queryMap.Get("unit")
+	if !names.IsValidUnit(params.unit) {
+		return params, errors.Errorf("unit value %q is not a valid unit name", params.unit)
+	}
+
+	if value := queryMap.Get("backlog"); value != "" {
+		num, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return params, errors.Errorf("backlog value %q is not a valid unsigned number", value)
+		}
+		params.backlog = uint(num)
+	}
+
+	if value := queryMap.Get("noTail"); value != "" {
+		noTail, err := strconv.ParseBool(value)
+		if err != nil {
+			return params, errors.Errorf("noTail value %q is not a valid boolean", value)
+		}
+		params.noTail = noTail
+	}
+
+	return params, nil
+}
+
+func handleStatusHistoryRequest(
+	unit statusHistoryUnit,
+	reqParams statusHistoryParams,
+	socket statusHistorySocket,
+	stop <-chan struct{},
+) error {
+	// Start watching before reading the backlog, so that no
+	// transitions are missed in between.
+	w := unit.WatchStatus()
+	defer func() { _ = w.Stop() }()
+
+	follower := &statusHistoryFollower{unit: unit}
+	records, err := follower.backlog(reqParams.backlog)
+	if err != nil {
+		socket.sendError(err)
+		return errors.Trace(err)
+	}
+
+	// Indicate that all is well.
+	socket.sendOk()
+
+	for {
+		for _, record := range records {
+			if err := socket.sendStatusRecord(record); err != nil {
+				return errors.Annotate(err, "sending failed")
+			}
+		}
+		if reqParams.noTail {
+			return nil
+		}
+
+		select {
+		case <-stop:
+			return nil
+		case _, ok := <-w.Changes():
+			if !ok {
+				return watcher.EnsureErr(w)
+			}
+		}
+		if records, err = follower.next(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// runningHookRE matches the unit agent status message reported while
+// a hook runs, capturing the name of the hook.
+var runningHookRE = regexp.MustCompile(`^running (\S+) hook`)
+
+// statusHistoryFollower reads the status transitions of a unit that
+// have not yet been seen, annotating unit agent transitions with the
+// hooks started and completed.
+type statusHistoryFollower struct {
+	unit statusHistoryUnit
+
+	lastAgent    time.Time
+	lastWorkload time.Time
+
+	hook      string
+	hookStart time.Time
+}
+
+// backlog returns the most recent n transitions of the unit, oldest
+// first, and records them as seen.
+func (f *statusHistoryFollower) backlog(n uint) ([]*params.StatusHistoryRecord, error) {
+	// At least one entry of each kind is read, even when no backlog
+	// is requested, to find where to follow on from and whether a
+	// hook is running.
+	size := int(n)
+	if size == 0 {
+		size = 1
+	}
+	records, err := f.read(status.StatusHistoryFilter{Size: size}, status.StatusHistoryFilter{Size: size})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(records) > int(n) {
+		records = records[len(records)-int(n):]
+	}
+	return records, nil
+}
+
+// next returns the transitions of the unit since those last seen,
+// oldest first, and records them as seen.
+func (f *statusHistoryFollower) next() ([]*params.StatusHistoryRecord, error) {
+	return f.read(historySince(f.lastAgent), historySince(f.lastWorkload))
+}
+
+// historySince returns a filter selecting the status history entries
+// after the given time, or all entries if it is zero.
+func historySince(t time.Time) status.StatusHistoryFilter {
+	if t.IsZero() {
+		// The filter requires some bound.
+		delta := maxStatusHistoryAge
+		return status.StatusHistoryFilter{Delta: &delta}
+	}
+	return status.StatusHistoryFilter{FromDate: &t}
+}
+
+// maxStatusHistoryAge is the age of the oldest status history entry
+// read when following a unit without any history.
+const maxStatusHistoryAge = 100 * 365 * 24 * time.Hour
+
+func (f *statusHistoryFollower) read(agentFilter, workloadFilter status.StatusHistoryFilter) ([]*params.StatusHistoryRecord, error) {
+	agentHistory, err := f.unit.AgentHistory().StatusHistory(agentFilter)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Annotate(err, "reading unit agent status history")
+	}
+	workloadHistory, err := f.unit.StatusHistory(workloadFilter)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Annotate(err, "reading workload status history")
+	}
+
+	var records []*params.StatusHistoryRecord
+	for _, info := range agentHistory {
+		records = append(records, statusHistoryRecord(status.KindUnitAgent, info))
+	}
+	for _, info := range workloadHistory {
+		records = append(records, statusHistoryRecord(status.KindWorkload, info))
+	}
+	// History is read newest first; send it oldest first.
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Since.Before(records[j].Since)
+	})
+	for _, record := range records {
+		f.observe(record)
+	}
+	return records, nil
+}
+
+// observe records the given transition as seen, and annotates unit
+// agent transitions with the hook started or completed.
+func (f *statusHistoryFollower) observe(record *params.StatusHistoryRecord) {
+	if record.Kind == string(status.KindWorkload) {
+		if record.Since.After(f.lastWorkload) {
+			f.lastWorkload = record.Since
+		}
+		return
+	}
+	if record.Since.After(f.lastAgent) {
+		f.lastAgent = record.Since
+	}
+	if f.hook != "" {
+		record.CompletedHook = f.hook
+		record.HookDuration = record.Since.Sub(f.hookStart)
+		f.hook = ""
+	}
+	if match := runningHookRE.FindStringSubmatch(record.Message); match != nil {
+		record.Hook = match[1]
+		f.hook = record.Hook
+		f.hookStart = record.Since
+	}
+}
+
+func statusHistoryRecord(kind status.HistoryKind, info status.StatusInfo) *params.StatusHistoryRecord {
+	record := &params.StatusHistoryRecord{
+		Kind:    string(kind),
+		Status:  string(info.Status),
+		Message: info.Message,
+		Data:    info.Data,
+	}
+	if info.Since != nil {
+		record.Since = *info.Since
+	}
+	return record
+}
@@ -0,0 +1,222 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type statusHistoryIntSuite struct {
+	coretesting.BaseSuite
+	sock    *fakeStatusHistorySocket
+	unit    *fakeStatusHistoryUnit
+	watcher *apiservertesting.FakeNotifyWatcher
+	start   time.Time
+}
+
+var _ = gc.Suite(&statusHistoryIntSuite{})
+
+func (s *statusHistoryIntSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.sock = &fakeStatusHistorySocket{writes: make(chan string, 10)}
+	s.watcher = apiservertesting.NewFakeNotifyWatcher()
+	s.unit = &fakeStatusHistoryUnit{watcher: s.watcher}
+	s.start = time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (s *statusHistoryIntSuite) TestReadParams(c *gc.C) {
+	reqParams, err := readStatusHistoryParams(url.Values{
+		"unit":    {"mysql/0"},
+		"backlog": {"10"},
+		"noTail":  {"true"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reqParams, jc.DeepEquals, statusHistoryParams{
+		unit:    "mysql/0",
+		backlog: 10,
+		noTail:  true,
+	})
+}
+
+func (s *statusHistoryIntSuite) TestReadParamsInvalid(c *gc.C) {
+	_, err := readStatusHistoryParams(url.Values{"unit": {"mysql"}})
+	c.Assert(err, gc.ErrorMatches, `unit value "mysql" is not a valid unit name`)
+	_, err = readStatusHistoryParams(url.Values{"unit": {"mysql/0"}, "backlog": {"-1"}})
+	c.Assert(err, gc.ErrorMatches, `backlog value "-1" is not a valid unsigned number`)
+	_, err = readStatusHistoryParams(url.Values{"unit": {"mysql/0"}, "noTail": {"maybe"}})
+	c.Assert(err, gc.ErrorMatches, `noTail value "maybe" is not a valid boolean`)
+}
+
+func (s *statusHistoryIntSuite) TestBacklogNoTail(c *gc.C) {
+	s.unit.addAgent(s.start, status.Allocating, "")
+	s.unit.addAgent(s.start.Add(time.Second), status.Executing, "running install hook")
+	s.unit.addWorkload(s.start.Add(2*time.Second), status.Maintenance, "installing")
+	s.unit.addAgent(s.start.Add(5*time.Second), status.Idle, "")
+
+	err := handleStatusHistoryRequest(s.unit, statusHistoryParams{backlog: 3, noTail: true}, s.sock, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertOutput(c, []string{
+		"ok",
+		"juju-unit 12:00:01 executing running install hook hook=install",
+		"workload 12:00:02 maintenance installing",
+		"juju-unit 12:00:05 idle  completed=install duration=4s",
+	})
+	c.Assert(s.sock.writes, gc.HasLen, 0)
+}
+
+func (s *statusHistoryIntSuite) TestFollow(c *gc.C) {
+	s.unit.addAgent(s.start, status.Executing, "running install hook")
+
+	stop := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- handleStatusHistoryRequest(s.unit, statusHistoryParams{}, s.sock, stop)
+	}()
+	s.assertOutput(c, []string{"ok"})
+
+	s.unit.addAgent(s.start.Add(3*time.Second), status.Executing, "running start hook")
+	s.unit.addWorkload(s.start.Add(4*time.Second), status.Active, "ready")
+	s.watcher.C <- struct{}{}
+	s.assertOutput(c, []string{
+		"juju-unit 12:00:03 executing running start hook hook=start completed=install duration=3s",
+		"workload 12:00:04 active ready",
+	})
+
+	s.unit.addAgent(s.start.Add(6*time.Second), status.Idle, "")
+	s.watcher.C <- struct{}{}
+	s.assertOutput(c, []string{
+		"juju-unit 12:00:06 idle  completed=start duration=3s",
+	})
+
+	close(stop)
+	select {
+	case err := <-done:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for request handler to stop")
+	}
+	c.Assert(s.sock.writes, gc.HasLen, 0)
+}
+
+func (s *statusHistoryIntSuite) TestHistoryError(c *gc.C) {
+	s.unit.err = fmt.Errorf("boom")
+	err := handleStatusHistoryRequest(s.unit, statusHistoryParams{}, s.sock, nil)
+	c.Assert(err, gc.ErrorMatches, "reading unit agent status history: boom")
+	s.assertOutput(c, []string{"err: reading unit agent status history: boom"})
+}
+
+func (s *statusHistoryIntSuite) assertOutput(c *gc.C, expectedWrites []string) {
+	timeout := time.After(coretesting.LongWait)
+	for i, expectedWrite := range expectedWrites {
+		select {
+		case actualWrite := <-s.sock.writes:
+			c.Assert(actualWrite, gc.Equals, expectedWrite)
+		case <-timeout:
+			c.Fatalf("timed out waiting for socket write (received %d)", i)
+		}
+	}
+}
+
+type fakeStatusHistoryUnit struct {
+	watcher *apiservertesting.FakeNotifyWatcher
+	err     error
+
+	mu       sync.Mutex
+	agent    []status.StatusInfo
+	workload []status.StatusInfo
+}
+
+func (u *fakeStatusHistoryUnit) addAgent(since time.Time, st status.Status, message string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.agent = append(u.agent, status.StatusInfo{Status: st, Message: message, Since: &since})
+}
+
+func (u *fakeStatusHistoryUnit) addWorkload(since time.Time, st status.Status, message string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.workload = append(u.workload, status.StatusInfo{Status: st, Message: message, Since: &since})
+}
+
+func (u *fakeStatusHistoryUnit) WatchStatus() state.NotifyWatcher {
+	return u.watcher
+}
+
+func (u *fakeStatusHistoryUnit) StatusHistory(filter status.StatusHistoryFilter) ([]status.StatusInfo, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.filter(u.workload, filter)
+}
+
+func (u *fakeStatusHistoryUnit) AgentHistory() status.StatusHistoryGetter {
+	return statusHistoryGetterFunc(func(filter status.StatusHistoryFilter) ([]status.StatusInfo, error) {
+		u.mu.Lock()
+		defer u.mu.Unlock()
+		return u.filter(u.agent, filter)
+	})
+}
+
+// filter returns the entries of history selected by filter, newest
+// first, as state does.
+func (u *fakeStatusHistoryUnit) filter(history []status.StatusInfo, filter status.StatusHistoryFilter) ([]status.StatusInfo, error) {
+	if u.err != nil {
+		return nil, u.err
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	var result []status.StatusInfo
+	for i := len(history) - 1; i >= 0; i-- {
+		if filter.FromDate != nil && !history[i].Since.After(*filter.FromDate) {
+			continue
+		}
+		if filter.Size > 0 && len(result) == filter.Size {
+			break
+		}
+		result = append(result, history[i])
+	}
+	return result, nil
+}
+
+type statusHistoryGetterFunc func(status.StatusHistoryFilter) ([]status.StatusInfo, error)
+
+func (f statusHistoryGetterFunc) StatusHistory(filter status.StatusHistoryFilter) ([]status.StatusInfo, error) {
+	return f(filter)
+}
+
+type fakeStatusHistorySocket struct {
+	writes chan string
+}
+
+func (s *fakeStatusHistorySocket) sendOk() {
+	s.writes <- "ok"
+}
+
+func (s *fakeStatusHistorySocket) sendError(err error) {
+	s.writes <- fmt.Sprintf("err: %v", err)
+}
+
+func (s *fakeStatusHistorySocket) sendStatusRecord(r *params.StatusHistoryRecord) error {
+	write := fmt.Sprintf("%s %s %s %s", r.Kind, r.Since.Format("15:04:05"), r.Status, r.Message)
+	if r.Hook != "" {
+		write += " hook=" + r.Hook
+	}
+	if r.CompletedHook != "" {
+		write += fmt.Sprintf(" completed=%s duration=%v", r.CompletedHook, r.HookDuration)
+	}
+	s.writes <- write
+	return nil
+}
@@ -139,6 +139,7 @@ type errReturner func() error
 type FakeSpace struct {
 	SpaceId   string
 	SpaceName string
+	SpaceUUID string
 	SubnetIds []string
 	Public    bool
 	NextErr   errReturner
@@ -154,6 +155,10 @@ func (f *FakeSpace) Name() string {
 	return f.SpaceName
 }
 
+func (f *FakeSpace) UUID() string {
+	return f.SpaceUUID
+}
+
 func (f *FakeSpace) Subnets() (bs []networkingcommon.BackingSubnet, err error) {
 	outputSubnets := []networkingcommon.BackingSubnet{}
 
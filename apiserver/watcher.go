@@ -612,6 +612,233 @@ func (w *srvStringsWatcher) Next() (params.StringsWatchResult, error) {
 	return params.StringsWatchResult{}, err
 }
 
+// pullFunc waits for the next change from a watcher, returning
+// errWatcherParked if abort is closed first.
+type pullFunc func(abort <-chan struct{}) (interface{}, error)
+
+// resumableWatcherFacade holds the behaviour shared by the watcher
+// facades that return continuation tokens from Next. Passing the token
+// to Resume on a new connection, with the token as the facade id,
+// continues the watcher from where the client left off.
+type resumableWatcherFacade struct {
+	watcherCommon
+	registry  *resumableWatchers
+	ownerTag  string
+	modelUUID string
+	watcher   *resumableWatcher
+}
+
+// resourceReplacer is implemented by connection resources that can
+// swap a registered resource for another.
+type resourceReplacer interface {
+	Replace(id string, r facade.Resource) error
+}
+
+func newResumableWatcherFacade(context facade.Context, pullFor func(facade.Resource) pullFunc) (*resumableWatcherFacade, error) {
+	auth := context.Auth()
+	resources := context.Resources()
+
+	// TODO(wallyworld) - enhance this watcher to support
+	// anonymous api calls with macaroons.
+	if auth.GetAuthTag() != nil && !isAgentOrUser(auth) {
+		return nil, apiservererrors.ErrPerm
+	}
+	var ownerTag string
+	if tag := auth.GetAuthTag(); tag != nil {
+		ownerTag = tag.String()
+	}
+	var registry *resumableWatchers
+	if v, ok := resources.Get(resumableWatchersResource).(common.ValueResource); ok {
+		registry, _ = v.Value.(*resumableWatchers)
+	}
+	f := &resumableWatcherFacade{
+		watcherCommon: newWatcherCommon(context),
+		registry:      registry,
+		ownerTag:      ownerTag,
+		modelUUID:     auth.ConnectedModel(),
+	}
+
+	switch r := resources.Get(f.id).(type) {
+	case nil:
+		// The id is expected to be a resume token, claimed by Resume.
+	case *resumableWatcher:
+		f.watcher = r
+	default:
+		pull := pullFor(r)
+		if pull == nil {
+			return nil, apiservererrors.ErrUnknownWatcher
+		}
+		replacer, ok := resources.(resourceReplacer)
+		if !ok {
+			return nil, errors.NotSupportedf("resuming watchers")
+		}
+		w, err := newResumableWatcher(registry, r, ownerTag, f.modelUUID, pull)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := replacer.Replace(f.id, w); err != nil {
+			return nil, errors.Trace(err)
+		}
+		f.watcher = w
+	}
+	return f, nil
+}
+
+func (f *resumableWatcherFacade) next() (interface{}, string, error) {
+	if f.watcher == nil {
+		return nil, "", apiservererrors.ErrUnknownWatcher
+	}
+	result, token, err := f.watcher.next()
+	if err == errWatcherParked {
+		err = apiservererrors.ErrStoppedWatcher
+	}
+	return result, token, err
+}
+
+// resume claims the parked watcher identified by the resume token used
+// as the facade id, and registers it with this connection.
+func (f *resumableWatcherFacade) resume() (string, error) {
+	if f.watcher != nil || f.registry == nil {
+		return "", apiservererrors.ErrUnknownWatcher
+	}
+	w, err := f.registry.claim(f.id, f.ownerTag, f.modelUUID)
+	if errors.IsNotFound(err) {
+		return "", apiservererrors.ErrUnknownWatcher
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	return f.resources.Register(w), nil
+}
+
+// Stop stops the watcher. A stopped watcher can not be resumed.
+func (f *resumableWatcherFacade) Stop() error {
+	if f.watcher != nil {
+		f.watcher.discard()
+	}
+	return f.watcherCommon.Stop()
+}
+
+func newNotifyWatcherV2(context facade.Context) (facade.Facade, error) {
+	f, err := newResumableWatcherFacade(context, notifyWatcherPull)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &srvNotifyWatcherV2{f}, nil
+}
+
+func notifyWatcherPull(r facade.Resource) pullFunc {
+	w, ok := r.(cache.NotifyWatcher)
+	if !ok {
+		return nil
+	}
+	return func(abort <-chan struct{}) (interface{}, error) {
+		select {
+		case _, ok := <-w.Changes():
+			if ok {
+				return nil, nil
+			}
+			return nil, stoppedWatcherErr(w)
+		case <-abort:
+			return nil, errWatcherParked
+		}
+	}
+}
+
+// srvNotifyWatcherV2 extends srvNotifyWatcher with continuation tokens,
+// so that clients can resume the watcher after reconnecting.
+type srvNotifyWatcherV2 struct {
+	*resumableWatcherFacade
+}
+
+// Next returns when a change has occurred to the entity being
+// watched, along with a token for resuming the watcher from that
+// change.
+func (w *srvNotifyWatcherV2) Next() (params.NotifyWatcherNextResult, error) {
+	_, token, err := w.next()
+	if err != nil {
+		return params.NotifyWatcherNextResult{}, err
+	}
+	return params.NotifyWatcherNextResult{ResumeToken: token}, nil
+}
+
+// Resume moves the watcher parked with the resume token used as the
+// facade id to this connection, returning the watcher's new id.
+func (w *srvNotifyWatcherV2) Resume() (params.NotifyWatchResult, error) {
+	id, err := w.resume()
+	if err != nil {
+		return params.NotifyWatchResult{}, err
+	}
+	return params.NotifyWatchResult{NotifyWatcherId: id}, nil
+}
+
+func newStringsWatcherV2(context facade.Context) (facade.Facade, error) {
+	f, err := newResumableWatcherFacade(context, stringsWatcherPull)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &srvStringsWatcherV2{f}, nil
+}
+
+func stringsWatcherPull(r facade.Resource) pullFunc {
+	w, ok := r.(cache.StringsWatcher)
+	if !ok {
+		return nil
+	}
+	return func(abort <-chan struct{}) (interface{}, error) {
+		select {
+		case changes, ok := <-w.Changes():
+			if ok {
+				return changes, nil
+			}
+			return nil, stoppedWatcherErr(w)
+		case <-abort:
+			return nil, errWatcherParked
+		}
+	}
+}
+
+// srvStringsWatcherV2 extends srvStringsWatcher with continuation
+// tokens, so that clients can resume the watcher after reconnecting.
+type srvStringsWatcherV2 struct {
+	*resumableWatcherFacade
+}
+
+// Next returns when a change has occurred to an entity of the
+// collection being watched, along with a token for resuming the
+// watcher from that change.
+func (w *srvStringsWatcherV2) Next() (params.StringsWatcherNextResult, error) {
+	changes, token, err := w.next()
+	if err != nil {
+		return params.StringsWatcherNextResult{}, err
+	}
+	return params.StringsWatcherNextResult{
+		Changes:     changes.([]string),
+		ResumeToken: token,
+	}, nil
+}
+
+// Resume moves the watcher parked with the resume token used as the
+// facade id to this connection, returning the watcher's new id.
+func (w *srvStringsWatcherV2) Resume() (params.StringsWatchResult, error) {
+	id, err := w.resume()
+	if err != nil {
+		return params.StringsWatchResult{}, err
+	}
+	return params.StringsWatchResult{StringsWatcherId: id}, nil
+}
+
+// stoppedWatcherErr returns the error that stopped the watcher.
+func stoppedWatcherErr(w interface{}) error {
+	var err error
+	if e, ok := w.(hasErr); ok {
+		err = e.Err()
+	}
+	if err == nil {
+		err = apiservererrors.ErrStoppedWatcher
+	}
+	return err
+}
+
 // srvRelationUnitsWatcher defines the API wrapping a state.RelationUnitsWatcher.
 // It notifies about units entering and leaving the scope of a RelationUnit,
 // and changes to the settings of those units known to have entered.
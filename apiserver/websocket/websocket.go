@@ -32,6 +32,16 @@ const (
 
 	// WriteWait is how long the write call can take before it errors out.
 	WriteWait = 10 * time.Second
+
+	// APIPingPeriod is how often ping messages are sent on API connections.
+	// It is shorter than PingPeriod so that a connection left half-open by,
+	// for example, a firewall dropping its state is noticed while the
+	// client is blocked waiting for a watcher to fire.
+	APIPingPeriod = 10 * time.Second
+
+	// APIPongDelay is how long the server will wait for a pong on an API
+	// connection before the websocket is considered broken.
+	APIPongDelay = 30 * time.Second
 )
 
 var websocketUpgrader = websocket.Upgrader{
@@ -55,6 +65,39 @@ func Serve(w http.ResponseWriter, req *http.Request, handler func(ws *Conn)) {
 	handler(&Conn{conn})
 }
 
+// KeepAlive configures the ping/pong handling for the websocket so the
+// server can notice when the remote end goes away, even if no other
+// traffic is flowing. A ping is sent every pingPeriod, and reads fail if
+// no pong is received within pongDelay of the last one. Pings are sent
+// until stop is closed or a ping cannot be written.
+//
+// KeepAlive must be called before the websocket is read from.
+func (conn *Conn) KeepAlive(pingPeriod, pongDelay time.Duration, stop <-chan struct{}) {
+	_ = conn.SetReadDeadline(time.Now().Add(pongDelay))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongDelay))
+	})
+
+	go func() {
+		ticker := time.NewTicker(pingPeriod)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				deadline := time.Now().Add(WriteWait)
+				if err := conn.WriteControl(websocket.PingMessage, []byte{}, deadline); err != nil {
+					// This error is expected if the other end goes away.
+					// The failing reads will then close the connection.
+					logger.Debugf("failed to write ping: %s", err)
+					return
+				}
+			}
+		}
+	}()
+}
+
 // SendInitialErrorV0 writes out the error as a params.ErrorResult serialized
 // with JSON with a new line character at the end.
 //
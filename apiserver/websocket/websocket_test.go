@@ -0,0 +1,113 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package websocket_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	gorillaws "github.com/gorilla/websocket"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/websocket"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type keepAliveSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&keepAliveSuite{})
+
+// serve starts a websocket server that enables keepalive on each
+// connection and reports the error that ends reading from it.
+func (s *keepAliveSuite) serve(c *gc.C, pongDelay time.Duration) (*gorillaws.Conn, <-chan error) {
+	readErr := make(chan error, 1)
+	stop := make(chan struct{})
+	s.AddCleanup(func(*gc.C) { close(stop) })
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		websocket.Serve(w, req, func(conn *websocket.Conn) {
+			defer conn.Close()
+			conn.KeepAlive(10*time.Millisecond, pongDelay, stop)
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					readErr <- err
+					return
+				}
+			}
+		})
+	}))
+	s.AddCleanup(func(*gc.C) { srv.Close() })
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http")
+	conn, _, err := gorillaws.DefaultDialer.Dial(url, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { conn.Close() })
+	return conn, readErr
+}
+
+// readUntilClosed reads from the client side of the websocket so that
+// control messages are processed.
+func readUntilClosed(conn *gorillaws.Conn) {
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+}
+
+func (s *keepAliveSuite) TestPingsAnsweredKeepConnectionOpen(c *gc.C) {
+	conn, readErr := s.serve(c, 100*time.Millisecond)
+
+	pinged := make(chan struct{}, 10)
+	defaultHandler := conn.PingHandler()
+	conn.SetPingHandler(func(data string) error {
+		select {
+		case pinged <- struct{}{}:
+		default:
+		}
+		return defaultHandler(data)
+	})
+	readUntilClosed(conn)
+
+	select {
+	case <-pinged:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for ping")
+	}
+
+	// Responding to pings keeps the connection open for longer than
+	// the pong delay.
+	select {
+	case err := <-readErr:
+		c.Fatalf("unexpected read error: %v", err)
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
+func (s *keepAliveSuite) TestUnansweredPingsCloseConnection(c *gc.C) {
+	conn, readErr := s.serve(c, 50*time.Millisecond)
+
+	// Ignore pings, as would appear to happen when the
+	// connection is half-open.
+	conn.SetPingHandler(func(string) error { return nil })
+	readUntilClosed(conn)
+
+	select {
+	case err := <-readErr:
+		netErr, ok := err.(net.Error)
+		c.Assert(ok, jc.IsTrue, gc.Commentf("unexpected error %v", err))
+		c.Assert(netErr.Timeout(), jc.IsTrue)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for connection to be considered broken")
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package clientconfig
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// kindContextPrefix prefixes the name kind gives to the kubeconfig
+	// context (and cluster) of each cluster it creates.
+	kindContextPrefix = "kind-"
+
+	// minikubeContextName is the default name minikube gives to the
+	// kubeconfig context (and cluster) it creates.
+	minikubeContextName = "minikube"
+
+	// minikubeClusterExtension is the cluster extension which minikube
+	// adds to the clusters it creates, whatever their profile name.
+	minikubeClusterExtension = "cluster_info"
+)
+
+// LocalCluster describes a cluster running on this machine which was
+// created by a local Kubernetes tool.
+type LocalCluster struct {
+	// ContextName is the name of the kubeconfig context for the cluster.
+	ContextName string
+
+	// Tool is the name of the tool which created the cluster,
+	// "kind" or "minikube".
+	Tool string
+}
+
+// LocalClusters returns the clusters in the kubeconfig content which
+// were created by kind or minikube, sorted by context name. MicroK8s
+// clusters are not included as they are available as a built-in cloud.
+func LocalClusters(content []byte) ([]LocalCluster, error) {
+	config, err := parseKubeConfig(content)
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to parse Kubernetes config")
+	}
+	var result []LocalCluster
+	for name, context := range config.Contexts {
+		if tool := localClusterTool(name, context, config.Clusters[context.Cluster]); tool != "" {
+			result = append(result, LocalCluster{ContextName: name, Tool: tool})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ContextName < result[j].ContextName
+	})
+	return result, nil
+}
+
+func localClusterTool(contextName string, context *clientcmdapi.Context, cluster *clientcmdapi.Cluster) string {
+	if cluster == nil {
+		return ""
+	}
+	if strings.HasPrefix(contextName, kindContextPrefix) && context.Cluster == contextName {
+		return "kind"
+	}
+	if _, ok := cluster.Extensions[minikubeClusterExtension]; ok || contextName == minikubeContextName {
+		return "minikube"
+	}
+	return ""
+}
@@ -0,0 +1,114 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package clientconfig_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas/kubernetes/clientconfig"
+)
+
+type localClustersSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&localClustersSuite{})
+
+var localClustersConfigYAML = `
+apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://127.0.0.1:40123
+    certificate-authority-data: QQ==
+  name: kind-dev
+- cluster:
+    server: https://192.168.49.2:8443
+    certificate-authority-data: QQ==
+  name: minikube
+- cluster:
+    server: https://192.168.58.2:8443
+    certificate-authority-data: QQ==
+    extensions:
+    - extension:
+        provider: minikube.sigs.k8s.io
+        version: v1.18.1
+      name: cluster_info
+  name: staging
+- cluster:
+    server: https://1.1.1.1:8888
+    certificate-authority-data: QQ==
+  name: the-cluster
+contexts:
+- context:
+    cluster: kind-dev
+    user: kind-dev
+  name: kind-dev
+- context:
+    cluster: the-cluster
+    user: the-user
+  name: kind-of-remote
+- context:
+    cluster: minikube
+    user: minikube
+  name: minikube
+- context:
+    cluster: staging
+    user: staging
+  name: staging
+- context:
+    cluster: the-cluster
+    user: the-user
+  name: the-context
+- context:
+    cluster: missing
+    user: the-user
+  name: kind-missing
+current-context: the-context
+preferences: {}
+users:
+- name: kind-dev
+  user:
+    token: kind-token
+- name: minikube
+  user:
+    token: minikube-token
+- name: staging
+  user:
+    token: staging-token
+- name: the-user
+  user:
+    token: the-token
+`
+
+func (s *localClustersSuite) TestLocalClusters(c *gc.C) {
+	clusters, err := clientconfig.LocalClusters([]byte(localClustersConfigYAML))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(clusters, jc.DeepEquals, []clientconfig.LocalCluster{
+		{ContextName: "kind-dev", Tool: "kind"},
+		{ContextName: "minikube", Tool: "minikube"},
+		{ContextName: "staging", Tool: "minikube"},
+	})
+}
+
+func (s *localClustersSuite) TestLocalClustersNone(c *gc.C) {
+	clusters, err := clientconfig.LocalClusters([]byte(`
+apiVersion: v1
+kind: Config
+clusters: []
+contexts: []
+current-context: ""
+preferences: {}
+users: []
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(clusters, gc.HasLen, 0)
+}
+
+func (s *localClustersSuite) TestLocalClustersInvalid(c *gc.C) {
+	_, err := clientconfig.LocalClusters([]byte("not: [valid"))
+	c.Assert(err, gc.ErrorMatches, "failed to parse Kubernetes config: .*")
+}
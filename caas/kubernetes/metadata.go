@@ -57,6 +57,34 @@ type ClusterMetadataChecker interface {
 	EnsureStorageProvisioner(cfg StorageProvisioner) (*StorageProvisioner, bool, error)
 }
 
+// ClusterReadinessChecker provides an API to check that a cluster has
+// what Juju needs to run models on it.
+type ClusterReadinessChecker interface {
+	// CheckClusterReadiness returns the storage classes and ingress
+	// classes available on the cluster, and any permissions that Juju
+	// needs but the credential does not have.
+	CheckClusterReadiness() (*ClusterReadiness, error)
+}
+
+// ClusterReadiness describes how ready a cluster is for Juju.
+type ClusterReadiness struct {
+	// StorageClasses are the names of the cluster's storage classes.
+	StorageClasses []string
+
+	// DefaultStorageClass is the cluster's default storage class, if any.
+	DefaultStorageClass string
+
+	// IngressClasses are the names of the cluster's ingress classes.
+	IngressClasses []string
+
+	// DefaultIngressClass is the cluster's default ingress class, if any.
+	DefaultIngressClass string
+
+	// MissingPermissions describes the cluster permissions Juju needs
+	// which the credential does not have, such as "create namespaces".
+	MissingPermissions []string
+}
+
 // ClusterMetadata defines metadata about a cluster.
 type ClusterMetadata struct {
 	NominatedStorageClass *StorageProvisioner
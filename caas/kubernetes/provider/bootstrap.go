@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/url"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -36,6 +37,7 @@ import (
 	"github.com/juju/juju/cloudconfig/podcfg"
 	k8sannotations "github.com/juju/juju/core/annotations"
 	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/docker"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/bootstrap"
 	"github.com/juju/juju/mongo"
@@ -59,6 +61,35 @@ const (
 	apiServerContainerName = "api-server"
 )
 
+// pushImageArchive is patched in tests.
+var pushImageArchive = docker.PushImageArchive
+
+// pushOCIImages pushes the local OCI image archives, keyed by image
+// name, to the registry the controller's pods are run from.
+func pushOCIImages(ctx environs.BootstrapContext, pcfg *podcfg.ControllerPodConfig, archivePaths map[string]string) error {
+	names := make([]string, 0, len(archivePaths))
+	for name := range archivePaths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var imagePath string
+		switch name {
+		case podcfg.JujudOCIName:
+			imagePath = pcfg.GetControllerImagePath()
+		case podcfg.JujudbOCIName:
+			imagePath = pcfg.GetJujuDbOCIImagePath()
+		default:
+			return errors.NotValidf("OCI image name %q", name)
+		}
+		ctx.Infof("Pushing OCI image %s", imagePath)
+		if err := pushImageArchive(archivePaths[name], imagePath); err != nil {
+			return errors.Annotatef(err, "pushing OCI image %s", imagePath)
+		}
+	}
+	return nil
+}
+
 type controllerServiceSpec struct {
 	// ServiceType is required.
 	ServiceType core.ServiceType
@@ -915,3 +915,35 @@ $JUJU_TOOLS_DIR/jujud machine --data-dir $JUJU_DATA_DIR --controller-id 0 --log-
 		c.Fatalf("timed out waiting for deploy return")
 	}
 }
+
+func (s *bootstrapSuite) TestPushOCIImages(c *gc.C) {
+	s.pcfg.Controller.Config[controller.CAASImageRepo] = "registry.example.com/juju"
+	var pushed [][]string
+	s.PatchValue(provider.PushImageArchive, func(archivePath, imagePath string) error {
+		pushed = append(pushed, []string{archivePath, imagePath})
+		return nil
+	})
+
+	err := provider.PushOCIImages(envtesting.BootstrapContext(c), s.pcfg, map[string]string{
+		"jujud-operator": "/bundle/jujud-operator.tar",
+		"juju-db":        "/bundle/juju-db.tar",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pushed, jc.DeepEquals, [][]string{
+		{"/bundle/juju-db.tar", "registry.example.com/juju/juju-db:4.0"},
+		{"/bundle/jujud-operator.tar", s.pcfg.GetControllerImagePath()},
+	})
+	c.Assert(s.pcfg.GetControllerImagePath(), jc.HasPrefix, "registry.example.com/juju/jujud-operator:")
+}
+
+func (s *bootstrapSuite) TestPushOCIImagesError(c *gc.C) {
+	s.pcfg.Controller.Config[controller.CAASImageRepo] = "registry.example.com/juju"
+	s.PatchValue(provider.PushImageArchive, func(archivePath, imagePath string) error {
+		return errors.New("boom")
+	})
+
+	err := provider.PushOCIImages(envtesting.BootstrapContext(c), s.pcfg, map[string]string{
+		"juju-db": "/bundle/juju-db.tar",
+	})
+	c.Assert(err, gc.ErrorMatches, "pushing OCI image registry.example.com/juju/juju-db:4.0: boom")
+}
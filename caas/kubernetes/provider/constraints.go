@@ -16,6 +16,10 @@ var unsupportedConstraints = []string{
 	constraints.InstanceType,
 	constraints.Spaces,
 	constraints.AllocatePublicIP,
+	// Pod resource limits are taken from mem and cpu-power; the
+	// IAAS unit limits below are applied by the machine agent only.
+	constraints.CpuQuota,
+	constraints.MemoryLimit,
 }
 
 // ConstraintsValidator returns a Validator value which is used to
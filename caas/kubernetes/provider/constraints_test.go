@@ -71,6 +71,8 @@ func (s *ConstraintsSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 		"root-disk=10M",
 		"spaces=foo",
 		"container=kvm",
+		"cpu-quota=150",
+		"memory-limit=1G",
 	}, " "))
 	unsupported, err := validator.Validate(cons)
 	c.Assert(err, jc.ErrorIsNil)
@@ -82,6 +84,8 @@ func (s *ConstraintsSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 		"instance-type",
 		"spaces",
 		"container",
+		"cpu-quota",
+		"memory-limit",
 	}
 	c.Check(unsupported, jc.SameContents, expected)
 }
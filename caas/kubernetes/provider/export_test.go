@@ -47,10 +47,14 @@ var (
 
 	LabelSetToRequirements = labelSetToRequirements
 	MergeSelectors         = mergeSelectors
+	CheckClusterReadiness  = checkClusterReadiness
 
 	UpdateStrategyForDeployment  = updateStrategyForDeployment
 	UpdateStrategyForStatefulSet = updateStrategyForStatefulSet
 	UpdateStrategyForDaemonSet   = updateStrategyForDaemonSet
+
+	PushOCIImages    = pushOCIImages
+	PushImageArchive = &pushImageArchive
 )
 
 type (
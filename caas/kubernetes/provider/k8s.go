@@ -47,6 +47,7 @@ import (
 	k8swatcher "github.com/juju/juju/caas/kubernetes/provider/watcher"
 	"github.com/juju/juju/caas/specs"
 	"github.com/juju/juju/cloudconfig/podcfg"
+	"github.com/juju/juju/controller"
 	k8sannotations "github.com/juju/juju/core/annotations"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
@@ -372,6 +373,9 @@ func (k *kubernetesClient) Bootstrap(
 	if args.BootstrapSeries != "" {
 		return nil, errors.NotSupportedf("set series for bootstrapping to kubernetes")
 	}
+	if len(args.OCIImagePaths) > 0 && args.ControllerConfig.CAASImageRepo() == "" {
+		return nil, errors.NotValidf("bootstrapping with OCI images without %q", controller.CAASImageRepo)
+	}
 
 	storageClass, err := k.validateOperatorStorage()
 	if err != nil {
@@ -424,6 +428,10 @@ please choose a different hosted model name then try again.`, hostedModelName),
 			return errors.Trace(err)
 		}
 
+		if err := pushOCIImages(ctx, pcfg, args.OCIImagePaths); err != nil {
+			return errors.Trace(err)
+		}
+
 		// create configmap, secret, volume, statefulset, etc resources for controller stack.
 		controllerStack, err := newcontrollerStack(ctx, JujuControllerStackName, storageClass, k, pcfg)
 		if err != nil {
@@ -0,0 +1,96 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	authorization "k8s.io/api/authorization/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	k8s "github.com/juju/juju/caas/kubernetes"
+	k8sannotations "github.com/juju/juju/core/annotations"
+)
+
+// clusterReadinessTimeout is how long to wait for the cluster to answer
+// the readiness checks.
+const clusterReadinessTimeout = 30 * time.Second
+
+// requiredClusterPermissions are the cluster scoped permissions which
+// Juju needs to bootstrap a controller and add models to a cluster.
+var requiredClusterPermissions = []authorization.ResourceAttributes{
+	{Verb: "create", Resource: "namespaces"},
+	{Verb: "create", Group: "rbac.authorization.k8s.io", Resource: "clusterroles"},
+	{Verb: "create", Group: "rbac.authorization.k8s.io", Resource: "clusterrolebindings"},
+	{Verb: "create", Group: "apiextensions.k8s.io", Resource: "customresourcedefinitions"},
+	{Verb: "create", Group: "admissionregistration.k8s.io", Resource: "mutatingwebhookconfigurations"},
+}
+
+// CheckClusterReadiness implements ClusterReadinessChecker.
+func (k *kubernetesClient) CheckClusterReadiness() (*k8s.ClusterReadiness, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), clusterReadinessTimeout)
+	defer cancel()
+	return checkClusterReadiness(ctx, k.client())
+}
+
+func checkClusterReadiness(ctx context.Context, client kubernetes.Interface) (*k8s.ClusterReadiness, error) {
+	var result k8s.ClusterReadiness
+
+	storageClasses, err := client.StorageV1().StorageClasses().List(ctx, v1.ListOptions{})
+	if err != nil {
+		return nil, errors.Annotate(err, "listing storage classes")
+	}
+	for _, sc := range storageClasses.Items {
+		result.StorageClasses = append(result.StorageClasses, sc.Name)
+		if result.DefaultStorageClass == "" && isDefaultStorageClass(sc) {
+			result.DefaultStorageClass = sc.Name
+		}
+	}
+	sort.Strings(result.StorageClasses)
+
+	ingressClasses, err := client.NetworkingV1beta1().IngressClasses().List(ctx, v1.ListOptions{})
+	// Clusters older than 1.18 don't serve ingress classes.
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, errors.Annotate(err, "listing ingress classes")
+	}
+	if err == nil {
+		for _, ic := range ingressClasses.Items {
+			result.IngressClasses = append(result.IngressClasses, ic.Name)
+			isDefault := k8sannotations.New(ic.GetAnnotations()).Has("ingressclass.kubernetes.io/is-default-class", "true")
+			if result.DefaultIngressClass == "" && isDefault {
+				result.DefaultIngressClass = ic.Name
+			}
+		}
+		sort.Strings(result.IngressClasses)
+	}
+
+	for _, attrs := range requiredClusterPermissions {
+		attrs := attrs
+		review := &authorization.SelfSubjectAccessReview{
+			Spec: authorization.SelfSubjectAccessReviewSpec{ResourceAttributes: &attrs},
+		}
+		review, err := client.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, review, v1.CreateOptions{})
+		if err != nil {
+			return nil, errors.Annotatef(err, "checking permission to %s", describePermission(attrs))
+		}
+		if !review.Status.Allowed {
+			result.MissingPermissions = append(result.MissingPermissions, describePermission(attrs))
+		}
+	}
+	return &result, nil
+}
+
+// describePermission returns a permission as "<verb> <resource>[.<group>]".
+func describePermission(attrs authorization.ResourceAttributes) string {
+	resource := attrs.Resource
+	if attrs.Group != "" {
+		resource += "." + attrs.Group
+	}
+	return attrs.Verb + " " + resource
+}
@@ -0,0 +1,78 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"context"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	authorization "k8s.io/api/authorization/v1"
+	networking "k8s.io/api/networking/v1beta1"
+	storagev1 "k8s.io/api/storage/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	k8s "github.com/juju/juju/caas/kubernetes"
+	"github.com/juju/juju/caas/kubernetes/provider"
+)
+
+type readinessSuite struct{}
+
+var _ = gc.Suite(&readinessSuite{})
+
+// allowAllExcept makes access reviews allow everything except creating
+// the given resources.
+func allowAllExcept(client *fake.Clientset, denied ...string) {
+	client.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorization.SelfSubjectAccessReview)
+		review.Status.Allowed = true
+		for _, resource := range denied {
+			if review.Spec.ResourceAttributes.Resource == resource {
+				review.Status.Allowed = false
+			}
+		}
+		return true, review, nil
+	})
+}
+
+func (s *readinessSuite) TestCheckClusterReadiness(c *gc.C) {
+	client := fake.NewSimpleClientset(
+		&storagev1.StorageClass{ObjectMeta: v1.ObjectMeta{Name: "slow"}},
+		&storagev1.StorageClass{ObjectMeta: v1.ObjectMeta{
+			Name:        "fast",
+			Annotations: map[string]string{"storageclass.kubernetes.io/is-default-class": "true"},
+		}},
+		&networking.IngressClass{ObjectMeta: v1.ObjectMeta{
+			Name:        "nginx",
+			Annotations: map[string]string{"ingressclass.kubernetes.io/is-default-class": "true"},
+		}},
+	)
+	allowAllExcept(client)
+
+	readiness, err := provider.CheckClusterReadiness(context.Background(), client)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(readiness, jc.DeepEquals, &k8s.ClusterReadiness{
+		StorageClasses:      []string{"fast", "slow"},
+		DefaultStorageClass: "fast",
+		IngressClasses:      []string{"nginx"},
+		DefaultIngressClass: "nginx",
+	})
+}
+
+func (s *readinessSuite) TestCheckClusterReadinessMissingPermissions(c *gc.C) {
+	client := fake.NewSimpleClientset()
+	allowAllExcept(client, "namespaces", "clusterrolebindings")
+
+	readiness, err := provider.CheckClusterReadiness(context.Background(), client)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(readiness.StorageClasses, gc.HasLen, 0)
+	c.Assert(readiness.IngressClasses, gc.HasLen, 0)
+	c.Assert(readiness.MissingPermissions, jc.DeepEquals, []string{
+		"create namespaces",
+		"create clusterrolebindings.rbac.authorization.k8s.io",
+	})
+}
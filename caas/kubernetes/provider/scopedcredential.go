@@ -0,0 +1,147 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	core "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas/kubernetes/provider/constants"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	envcontext "github.com/juju/juju/environs/context"
+)
+
+var _ environs.CredentialScoper = (*kubernetesClient)(nil)
+
+// scopedCredentialName returns the name of the service account, role
+// and role binding backing the credentials issued to the application.
+// Application names cannot contain dots, so the name cannot clash
+// with the service account of another application.
+func scopedCredentialName(appName string) string {
+	return fmt.Sprintf("%s.juju-trust", appName)
+}
+
+// scopedCredentialRules returns the rules of the role granted to the
+// application's scoped credentials. The application may read the
+// workloads and services in the model's namespace, and change only its
+// own. Secrets and RBAC resources are not granted at all.
+func scopedCredentialRules(appName string) []rbacv1.PolicyRule {
+	ownNames := []string{appName, appName + "-endpoints"}
+	return []rbacv1.PolicyRule{{
+		APIGroups: []string{""},
+		Resources: []string{"pods", "pods/log", "services", "endpoints", "configmaps", "persistentvolumeclaims", "events"},
+		Verbs:     []string{"get", "list", "watch"},
+	}, {
+		APIGroups: []string{"apps"},
+		Resources: []string{"statefulsets", "deployments", "daemonsets"},
+		Verbs:     []string{"get", "list", "watch"},
+	}, {
+		APIGroups:     []string{""},
+		Resources:     []string{"services"},
+		ResourceNames: ownNames,
+		Verbs:         []string{"update", "patch"},
+	}, {
+		APIGroups:     []string{"apps"},
+		Resources:     []string{"statefulsets", "deployments", "daemonsets"},
+		ResourceNames: []string{appName},
+		Verbs:         []string{"update", "patch"},
+	}}
+}
+
+// ScopedCredential is part of the environs.CredentialScoper interface.
+// The credential is a token for a service account whose role is
+// limited to the application's needs within the model's namespace.
+func (k *kubernetesClient) ScopedCredential(
+	_ envcontext.ProviderCallContext, appName string, duration time.Duration,
+) (environs.ScopedCredential, error) {
+	name := scopedCredentialName(appName)
+	meta := v1.ObjectMeta{
+		Name:      name,
+		Namespace: k.namespace,
+		Labels:    RBACLabels(appName, k.CurrentModel(), false, k.IsLegacyLabels()),
+	}
+	if _, _, err := k.ensureServiceAccount(&core.ServiceAccount{
+		ObjectMeta: meta,
+	}); err != nil {
+		return environs.ScopedCredential{}, errors.Annotatef(err, "ensuring service account for %q", appName)
+	}
+	if _, _, err := k.ensureRole(&rbacv1.Role{
+		ObjectMeta: meta,
+		Rules:      scopedCredentialRules(appName),
+	}); err != nil {
+		return environs.ScopedCredential{}, errors.Annotatef(err, "ensuring role for %q", appName)
+	}
+	// The role binding is created once only, rather than ensured, as
+	// role bindings are replaced rather than updated, which would
+	// briefly revoke the credentials already issued.
+	if _, err := k.getRoleBinding(name); errors.IsNotFound(err) {
+		_, err = k.createRoleBinding(&rbacv1.RoleBinding{
+			ObjectMeta: meta,
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "Role",
+				Name:     name,
+			},
+			Subjects: []rbacv1.Subject{{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      name,
+				Namespace: k.namespace,
+			}},
+		})
+		if err != nil {
+			return environs.ScopedCredential{}, errors.Annotatef(err, "creating role binding for %q", appName)
+		}
+	} else if err != nil {
+		return environs.ScopedCredential{}, errors.Annotatef(err, "getting role binding for %q", appName)
+	}
+
+	expirySeconds := int64(duration / time.Second)
+	token, err := k.client().CoreV1().ServiceAccounts(k.namespace).CreateToken(
+		context.TODO(), name, &authenticationv1.TokenRequest{
+			Spec: authenticationv1.TokenRequestSpec{
+				ExpirationSeconds: &expirySeconds,
+			},
+		}, v1.CreateOptions{})
+	if err != nil {
+		return environs.ScopedCredential{}, errors.Annotatef(err, "requesting token for %q", appName)
+	}
+	return environs.ScopedCredential{
+		Credential: cloud.NewCredential(cloud.OAuth2AuthType, map[string]string{
+			CredAttrToken: token.Status.Token,
+		}),
+		Expiry: token.Status.ExpirationTimestamp.Time.UTC(),
+	}, nil
+}
+
+// RevokeScopedCredential is part of the environs.CredentialScoper
+// interface. Tokens are bound to their service account, so removing the
+// service account revokes them.
+func (k *kubernetesClient) RevokeScopedCredential(_ envcontext.ProviderCallContext, appName string) error {
+	name := scopedCredentialName(appName)
+	opts := v1.DeleteOptions{
+		PropagationPolicy: constants.DefaultPropagationPolicy(),
+	}
+	err := k.client().RbacV1().RoleBindings(k.namespace).Delete(context.TODO(), name, opts)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Annotatef(err, "deleting role binding for %q", appName)
+	}
+	err = k.client().RbacV1().Roles(k.namespace).Delete(context.TODO(), name, opts)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Annotatef(err, "deleting role for %q", appName)
+	}
+	err = k.client().CoreV1().ServiceAccounts(k.namespace).Delete(context.TODO(), name, opts)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Annotatef(err, "deleting service account for %q", appName)
+	}
+	return nil
+}
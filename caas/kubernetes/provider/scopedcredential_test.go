@@ -0,0 +1,162 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	core "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+)
+
+func (s *K8sBrokerSuite) scopedCredentialMeta() v1.ObjectMeta {
+	return v1.ObjectMeta{
+		Name:      "app-name.juju-trust",
+		Namespace: "test",
+		Labels:    map[string]string{"app.kubernetes.io/managed-by": "juju", "app.kubernetes.io/name": "app-name"},
+	}
+}
+
+func (s *K8sBrokerSuite) TestScopedCredential(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	meta := s.scopedCredentialMeta()
+	svcAccount := &core.ServiceAccount{ObjectMeta: meta}
+	// The role grants no access to secrets or RBAC resources, and
+	// write access only to the application's own workload and services.
+	role := &rbacv1.Role{
+		ObjectMeta: meta,
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"pods", "pods/log", "services", "endpoints", "configmaps", "persistentvolumeclaims", "events"},
+			Verbs:     []string{"get", "list", "watch"},
+		}, {
+			APIGroups: []string{"apps"},
+			Resources: []string{"statefulsets", "deployments", "daemonsets"},
+			Verbs:     []string{"get", "list", "watch"},
+		}, {
+			APIGroups:     []string{""},
+			Resources:     []string{"services"},
+			ResourceNames: []string{"app-name", "app-name-endpoints"},
+			Verbs:         []string{"update", "patch"},
+		}, {
+			APIGroups:     []string{"apps"},
+			Resources:     []string{"statefulsets", "deployments", "daemonsets"},
+			ResourceNames: []string{"app-name"},
+			Verbs:         []string{"update", "patch"},
+		}},
+	}
+	rb := &rbacv1.RoleBinding{
+		ObjectMeta: meta,
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     "app-name.juju-trust",
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      "app-name.juju-trust",
+			Namespace: "test",
+		}},
+	}
+	expirySeconds := int64(3600)
+	tokenRequest := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			ExpirationSeconds: &expirySeconds,
+		},
+	}
+	expiry := time.Date(2021, 6, 7, 8, 9, 10, 0, time.UTC)
+	tokenResult := &authenticationv1.TokenRequest{
+		Status: authenticationv1.TokenRequestStatus{
+			Token:               "scoped-token",
+			ExpirationTimestamp: v1.NewTime(expiry),
+		},
+	}
+
+	gomock.InOrder(
+		s.mockServiceAccounts.EXPECT().Create(gomock.Any(), svcAccount, v1.CreateOptions{}).Return(svcAccount, nil),
+		s.mockRoles.EXPECT().Create(gomock.Any(), role, v1.CreateOptions{}).Return(role, nil),
+		s.mockRoleBindings.EXPECT().Get(gomock.Any(), "app-name.juju-trust", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockRoleBindings.EXPECT().Create(gomock.Any(), rb, v1.CreateOptions{}).Return(rb, nil),
+		s.mockServiceAccounts.EXPECT().CreateToken(gomock.Any(), "app-name.juju-trust", tokenRequest, v1.CreateOptions{}).
+			Return(tokenResult, nil),
+	)
+
+	var scoper environs.CredentialScoper = s.broker
+	credential, err := scoper.ScopedCredential(context.NewCloudCallContext(), "app-name", time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(credential, jc.DeepEquals, environs.ScopedCredential{
+		Credential: cloud.NewCredential(cloud.OAuth2AuthType, map[string]string{
+			"Token": "scoped-token",
+		}),
+		Expiry: expiry,
+	})
+}
+
+func (s *K8sBrokerSuite) TestScopedCredentialRotation(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	meta := s.scopedCredentialMeta()
+	svcAccount := &core.ServiceAccount{ObjectMeta: meta}
+	rb := &rbacv1.RoleBinding{ObjectMeta: meta}
+	tokenResult := &authenticationv1.TokenRequest{
+		Status: authenticationv1.TokenRequestStatus{
+			Token:               "rotated-token",
+			ExpirationTimestamp: v1.NewTime(time.Date(2021, 6, 7, 8, 9, 10, 0, time.UTC)),
+		},
+	}
+
+	// The existing role binding is left alone, so that the tokens
+	// already issued remain valid.
+	gomock.InOrder(
+		s.mockServiceAccounts.EXPECT().Create(gomock.Any(), svcAccount, v1.CreateOptions{}).
+			Return(nil, s.k8sAlreadyExistsError()),
+		s.mockServiceAccounts.EXPECT().List(gomock.Any(), v1.ListOptions{
+			LabelSelector: "app.kubernetes.io/managed-by=juju,app.kubernetes.io/name=app-name",
+		}).Return(&core.ServiceAccountList{Items: []core.ServiceAccount{*svcAccount}}, nil),
+		s.mockServiceAccounts.EXPECT().Update(gomock.Any(), svcAccount, v1.UpdateOptions{}).Return(svcAccount, nil),
+		s.mockRoles.EXPECT().Create(gomock.Any(), gomock.Any(), v1.CreateOptions{}).
+			Return(nil, s.k8sAlreadyExistsError()),
+		s.mockRoles.EXPECT().List(gomock.Any(), v1.ListOptions{
+			LabelSelector: "app.kubernetes.io/managed-by=juju,app.kubernetes.io/name=app-name",
+		}).Return(&rbacv1.RoleList{Items: []rbacv1.Role{{ObjectMeta: meta}}}, nil),
+		s.mockRoles.EXPECT().Update(gomock.Any(), gomock.Any(), v1.UpdateOptions{}).Return(&rbacv1.Role{}, nil),
+		s.mockRoleBindings.EXPECT().Get(gomock.Any(), "app-name.juju-trust", v1.GetOptions{}).Return(rb, nil),
+		s.mockServiceAccounts.EXPECT().CreateToken(gomock.Any(), "app-name.juju-trust", gomock.Any(), v1.CreateOptions{}).
+			Return(tokenResult, nil),
+	)
+
+	credential, err := s.broker.ScopedCredential(context.NewCloudCallContext(), "app-name", time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(credential.Credential.Attributes(), jc.DeepEquals, map[string]string{
+		"Token": "rotated-token",
+	})
+}
+
+func (s *K8sBrokerSuite) TestRevokeScopedCredential(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	opts := s.deleteOptions(v1.DeletePropagationForeground, "")
+	gomock.InOrder(
+		s.mockRoleBindings.EXPECT().Delete(gomock.Any(), "app-name.juju-trust", opts).Return(nil),
+		s.mockRoles.EXPECT().Delete(gomock.Any(), "app-name.juju-trust", opts).Return(s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().Delete(gomock.Any(), "app-name.juju-trust", opts).Return(nil),
+	)
+
+	err := s.broker.RevokeScopedCredential(context.NewCloudCallContext(), "app-name")
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -0,0 +1,131 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloud
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"strings"
+	"time"
+)
+
+// expiringAttributes holds the names of the credential attributes
+// which hold something with a lifetime.
+type expiringAttributes struct {
+	// certificates holds the names of attributes holding PEM encoded
+	// client certificates.
+	certificates []string
+
+	// tokens holds the names of attributes holding JSON web tokens.
+	tokens []string
+}
+
+// credentialExpiringAttributes holds the attributes with a lifetime for
+// each auth type. Other attributes, such as a server's certificate, are
+// not the credential's own and do not affect its expiry.
+var credentialExpiringAttributes = map[AuthType]expiringAttributes{
+	CertificateAuthType: {
+		certificates: []string{"client-cert", "ClientCertificateData"},
+		tokens:       []string{"Token"},
+	},
+	OAuth2AuthType: {
+		tokens: []string{"Token"},
+	},
+	OAuth2WithCertAuthType: {
+		certificates: []string{"ClientCertificateData"},
+		tokens:       []string{"Token"},
+	},
+	UserPassWithCertAuthType: {
+		certificates: []string{"ClientCertificateData"},
+	},
+}
+
+// CredentialExpiry returns when the credential expires, and whether it
+// expires at all. The expiry is the earliest NotAfter of the client
+// certificates, and the earliest "exp" claim of the JSON web tokens
+// (such as OIDC tokens), held in the credential's auth type's known
+// attributes.
+func CredentialExpiry(cred Credential) (time.Time, bool) {
+	attrs, ok := credentialExpiringAttributes[cred.authType]
+	if !ok {
+		return time.Time{}, false
+	}
+	var expiries []time.Time
+	for _, name := range attrs.certificates {
+		expiries = append(expiries, certificateExpiries(cred.attributes[name])...)
+	}
+	for _, name := range attrs.tokens {
+		if t, ok := tokenExpiry(strings.TrimSpace(cred.attributes[name])); ok {
+			expiries = append(expiries, t)
+		}
+	}
+	if len(expiries) == 0 {
+		return time.Time{}, false
+	}
+	expiry := expiries[0]
+	for _, t := range expiries[1:] {
+		if t.Before(expiry) {
+			expiry = t
+		}
+	}
+	return expiry, true
+}
+
+// certificateExpiries returns the NotAfter times of the PEM encoded
+// certificates in a credential attribute value.
+func certificateExpiries(value string) []time.Time {
+	var expiries []time.Time
+	rest := []byte(strings.TrimSpace(value))
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			continue
+		}
+		expiries = append(expiries, cert.NotAfter)
+	}
+	return expiries
+}
+
+// tokenExpiry returns the "exp" claim of a JSON web token.
+func tokenExpiry(value string) (time.Time, bool) {
+	parts := strings.Split(value, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if !decodeTokenPart(parts[0], &header) || header.Alg == "" {
+		return time.Time{}, false
+	}
+	var claims struct {
+		Exp *json.Number `json:"exp"`
+	}
+	if !decodeTokenPart(parts[1], &claims) || claims.Exp == nil {
+		return time.Time{}, false
+	}
+	exp, err := claims.Exp.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(exp), 0).UTC(), true
+}
+
+func decodeTokenPart(part string, out interface{}) bool {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(part, "="))
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, out) == nil
+}
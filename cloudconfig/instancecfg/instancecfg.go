@@ -171,6 +171,10 @@ type InstanceConfig struct {
 	// directly contact the proxy to retrieve the assertions and store ID.
 	SnapStoreProxyURL string
 
+	// APIProxy holds the address of the HTTP CONNECT or SOCKS5 proxy
+	// through which the agent connects to the controller, if any.
+	APIProxy string
+
 	// The type of Simple Stream to download and deploy on this instance.
 	ImageStream string
 
@@ -476,6 +480,7 @@ func (cfg *InstanceConfig) AgentConfig(
 		Values:            cfg.AgentEnvironment,
 		Controller:        cfg.ControllerTag,
 		Model:             cfg.APIInfo.ModelTag,
+		APIProxy:          cfg.APIProxy,
 	}
 	if cfg.Bootstrap == nil {
 		return agent.NewAgentConfig(configParams)
@@ -843,6 +848,10 @@ type ProxyConfiguration struct {
 	// specified instead of the assertions/storeID settings above, juju can
 	// directly contact the proxy to retrieve the assertions and store ID.
 	SnapStoreProxyURL string
+
+	// APIProxy holds the address of the proxy through which agents
+	// connect to the controller.
+	APIProxy string
 }
 
 // proxyConfigurationFromEnv populates a ProxyConfiguration object from an
@@ -857,6 +866,7 @@ func proxyConfigurationFromEnv(cfg *config.Config) ProxyConfiguration {
 		SnapStoreAssertions: cfg.SnapStoreAssertions(),
 		SnapStoreProxyID:    cfg.SnapStoreProxy(),
 		SnapStoreProxyURL:   cfg.SnapStoreProxyURL(),
+		APIProxy:            cfg.AgentAPIProxy(),
 	}
 }
 
@@ -892,6 +902,7 @@ func PopulateInstanceConfig(icfg *InstanceConfig,
 	icfg.SnapStoreAssertions = proxyCfg.SnapStoreAssertions
 	icfg.SnapStoreProxyID = proxyCfg.SnapStoreProxyID
 	icfg.SnapStoreProxyURL = proxyCfg.SnapStoreProxyURL
+	icfg.APIProxy = proxyCfg.APIProxy
 	icfg.EnableOSRefreshUpdate = enableOSRefreshUpdates
 	icfg.EnableOSUpgrade = enableOSUpgrade
 	icfg.CloudInitUserData = cloudInitUserData
@@ -930,6 +941,13 @@ func FinishInstanceConfig(icfg *InstanceConfig, cfg *config.Config) (err error)
 		logger.Debugf("Setting numa ctl preference to %v", icfg.Controller.Config.NUMACtlPreference())
 		// Unfortunately, AgentEnvironment can only take strings as values
 		icfg.AgentEnvironment[agent.NUMACtlPreference] = fmt.Sprintf("%v", icfg.Controller.Config.NUMACtlPreference())
+
+		// Controllers using an externally managed replica set need its
+		// details before they can connect to state to read them.
+		if uri := icfg.Controller.Config.ExternalMongoURI(); uri != "" {
+			icfg.AgentEnvironment[agent.ExternalMongoURI] = uri
+			icfg.AgentEnvironment[agent.ExternalMongoCACert] = icfg.Controller.Config.ExternalMongoCACert()
+		}
 	}
 	return nil
 }
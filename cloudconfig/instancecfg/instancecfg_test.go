@@ -4,10 +4,12 @@
 package instancecfg_test
 
 import (
+	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/environs/config"
@@ -118,3 +120,29 @@ func (*instancecfgSuite) TestCharmDir(c *gc.C) {
 	}
 	c.Assert(icfg.CharmDir(), gc.Equals, "/path/to/datadir/charms")
 }
+
+func (*instancecfgSuite) TestAPIProxyPopulatedIntoAgentConfig(c *gc.C) {
+	icfg := &instancecfg.InstanceConfig{
+		DataDir:      "/path/to/datadir/",
+		LogDir:       "/path/to/logdir/",
+		MachineNonce: "nonce",
+		APIInfo: &api.Info{
+			Addrs:    []string{"10.0.0.1:17070"},
+			CACert:   testing.CACert,
+			Password: "secret",
+			ModelTag: testing.ModelTag,
+		},
+		ControllerTag: testing.ControllerTag,
+	}
+	err := instancecfg.PopulateInstanceConfig(icfg, "dummy", "", true,
+		instancecfg.ProxyConfiguration{APIProxy: "socks5://10.0.0.2:1080"},
+		false, false, nil, nil,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(icfg.APIProxy, gc.Equals, "socks5://10.0.0.2:1080")
+
+	agentConfig, err := icfg.AgentConfig(names.NewMachineTag("0"), version.MustParse("2.9.0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(agentConfig.APIProxy(), gc.NotNil)
+	c.Assert(agentConfig.APIProxy().String(), gc.Equals, "socks5://10.0.0.2:1080")
+}
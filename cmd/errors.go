@@ -0,0 +1,205 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// errorHint describes a remediation hint shown alongside errors with
+// a matching error code and, optionally, a matching message.
+type errorHint struct {
+	// Code is the API error code the hint applies to.
+	Code string
+
+	// Message, if set, must match the error message for the hint to
+	// apply. Its submatches are available to the template as .Match.
+	Message *regexp.Regexp
+
+	// Template is a text/template rendered to produce the hint.
+	Template string
+}
+
+// hintData is the data the hint templates are rendered with.
+type hintData struct {
+	Code    string
+	Message string
+	Match   []string
+}
+
+// errorHints holds the hints for each supported language, keyed on
+// the language code. Hints are tried in order, so those matching
+// on the error message must come before general hints for the same
+// code.
+var errorHints = map[string][]errorHint{
+	"en": {{
+		Code:     params.CodeNotFound,
+		Message:  regexp.MustCompile(`space "([^"]+)" not found`),
+		Template: `run "juju reload-spaces" to discover spaces added to the provider, then check that space "{{index .Match 1}}" is listed by "juju spaces"`,
+	}, {
+		Code:     params.CodeModelNotFound,
+		Template: `run "juju models" to list the models you have access to`,
+	}, {
+		Code:     params.CodeUnauthorized,
+		Template: `run "juju login" to log in to the controller again, or ask a controller administrator to grant you access`,
+	}, {
+		Code:     params.CodeLoginExpired,
+		Template: `run "juju login" to log in to the controller again`,
+	}, {
+		Code:     params.CodeUpgradeInProgress,
+		Template: `wait for the upgrade to complete and try again; "juju status" reports its progress`,
+	}, {
+		Code:     params.CodeMigrationInProgress,
+		Template: `wait for the model migration to complete and try again; "juju show-model" reports its progress`,
+	}, {
+		Code:     params.CodeIncompatibleClient,
+		Template: `use a juju client matching the controller version reported by "juju show-controller"`,
+	}, {
+		Code:     params.CodeNotSupported,
+		Template: `the controller may be running an older version of juju; check its version with "juju show-controller"`,
+	}, {
+		Code:     params.CodeQuotaLimitExceeded,
+		Template: `remove unused resources, or ask a controller administrator to raise the quota`,
+	}},
+}
+
+// defaultLanguage is the language used when no hints are available
+// for the language of the current locale.
+const defaultLanguage = "en"
+
+// localeLanguage returns the language code of the current locale, as
+// set by the LC_ALL, LC_MESSAGES or LANG environment variables.
+func localeLanguage() string {
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		locale := os.Getenv(key)
+		if locale == "" {
+			continue
+		}
+		// Locales look like "en_GB.UTF-8" or "pt_BR@euro".
+		parts := strings.FieldsFunc(locale, func(r rune) bool {
+			return r == '_' || r == '.' || r == '@'
+		})
+		if len(parts) > 0 {
+			return strings.ToLower(parts[0])
+		}
+	}
+	return defaultLanguage
+}
+
+// ErrorHint returns a hint describing how to remedy the specified
+// error, or an empty string if there is none. The hint is given in
+// the language of the current locale where possible.
+func ErrorHint(err error) string {
+	code := params.ErrCode(err)
+	if code == "" {
+		return ""
+	}
+	hints, ok := errorHints[localeLanguage()]
+	if !ok {
+		hints = errorHints[defaultLanguage]
+	}
+	message := err.Error()
+	for _, hint := range hints {
+		if hint.Code != code {
+			continue
+		}
+		data := hintData{Code: code, Message: message}
+		if hint.Message != nil {
+			if data.Match = hint.Message.FindStringSubmatch(message); data.Match == nil {
+				continue
+			}
+		}
+		tmpl, err := template.New("hint").Parse(hint.Template)
+		if err != nil {
+			logger.Warningf("parsing hint for %q: %v", code, err)
+			return ""
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			logger.Warningf("rendering hint for %q: %v", code, err)
+			return ""
+		}
+		return buf.String()
+	}
+	return ""
+}
+
+// renderedError is the machine readable form of an error written
+// when the command output is formatted as JSON.
+type renderedError struct {
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+// WriteError writes the specified error to the writer in the given
+// output format, along with any hint describing how to remedy it.
+// Errors written in the "json" format retain their error code.
+func WriteError(writer io.Writer, err error, format string) {
+	hint := ErrorHint(err)
+	if format == "json" {
+		out, jsonErr := json.Marshal(map[string]renderedError{
+			"error": {
+				Message: err.Error(),
+				Code:    params.ErrCode(err),
+				Hint:    hint,
+			},
+		})
+		if jsonErr == nil {
+			fmt.Fprintf(writer, "%s\n", out)
+			return
+		}
+		logger.Warningf("formatting error as json: %v", jsonErr)
+	}
+	cmd.WriteError(writer, err)
+	if hint != "" {
+		fmt.Fprintf(writer, "\nhint: %s\n", hint)
+	}
+}
+
+// NewErrorRenderingCommand wraps the specified command so that errors
+// returned when running it are written with WriteError, using the
+// output format selected with the command's --format option.
+func NewErrorRenderingCommand(c cmd.Command) cmd.Command {
+	return &errorRenderingCommand{Command: c}
+}
+
+type errorRenderingCommand struct {
+	cmd.Command
+	format *gnuflag.Flag
+}
+
+// SetFlags implements cmd.Command.
+func (c *errorRenderingCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.Command.SetFlags(f)
+	c.format = f.Lookup("format")
+}
+
+// Run implements cmd.Command.
+func (c *errorRenderingCommand) Run(ctx *cmd.Context) error {
+	err := c.Command.Run(ctx)
+	if err == nil || errors.Cause(err) == cmd.ErrSilent || cmd.IsRcPassthroughError(err) {
+		return err
+	}
+	var format string
+	if c.format != nil {
+		format = c.format.Value.String()
+	}
+	WriteError(ctx.Stderr, err, format)
+	logger.Debugf("error stack: \n%v", errors.ErrorStack(err))
+	return cmd.ErrSilent
+}
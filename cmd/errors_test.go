@@ -0,0 +1,126 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cmd_test
+
+import (
+	"bytes"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+type ErrorsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ErrorsSuite{})
+
+func (s *ErrorsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.PatchEnvironment("LANG", "en_GB.UTF-8")
+}
+
+func (s *ErrorsSuite) TestErrorHint(c *gc.C) {
+	err := errors.Annotate(&params.Error{
+		Code:    params.CodeModelNotFound,
+		Message: `model "foo" not found`,
+	}, "cannot get model")
+	c.Assert(jujucmd.ErrorHint(err), gc.Equals, `run "juju models" to list the models you have access to`)
+}
+
+func (s *ErrorsSuite) TestErrorHintTemplated(c *gc.C) {
+	err := &params.Error{
+		Code:    params.CodeNotFound,
+		Message: `space "db" not found`,
+	}
+	c.Assert(jujucmd.ErrorHint(err), gc.Equals,
+		`run "juju reload-spaces" to discover spaces added to the provider, then check that space "db" is listed by "juju spaces"`)
+}
+
+func (s *ErrorsSuite) TestErrorHintNoMatch(c *gc.C) {
+	c.Assert(jujucmd.ErrorHint(&params.Error{
+		Code:    params.CodeNotFound,
+		Message: `application "db" not found`,
+	}), gc.Equals, "")
+	c.Assert(jujucmd.ErrorHint(errors.New("boom")), gc.Equals, "")
+}
+
+func (s *ErrorsSuite) TestErrorHintUnknownLanguage(c *gc.C) {
+	s.PatchEnvironment("LC_ALL", "xx_XX.UTF-8")
+	err := &params.Error{Code: params.CodeLoginExpired, Message: "login expired"}
+	c.Assert(jujucmd.ErrorHint(err), gc.Equals, `run "juju login" to log in to the controller again`)
+}
+
+func (s *ErrorsSuite) TestWriteError(c *gc.C) {
+	var buf bytes.Buffer
+	jujucmd.WriteError(&buf, &params.Error{
+		Code:    params.CodeLoginExpired,
+		Message: "login expired",
+	}, "")
+	c.Assert(buf.String(), gc.Equals, `
+ERROR login expired
+
+hint: run "juju login" to log in to the controller again
+`[1:])
+}
+
+func (s *ErrorsSuite) TestWriteErrorNoHint(c *gc.C) {
+	var buf bytes.Buffer
+	jujucmd.WriteError(&buf, errors.New("boom"), "yaml")
+	c.Assert(buf.String(), gc.Equals, "ERROR boom\n")
+}
+
+func (s *ErrorsSuite) TestWriteErrorJSON(c *gc.C) {
+	var buf bytes.Buffer
+	jujucmd.WriteError(&buf, &params.Error{
+		Code:    params.CodeLoginExpired,
+		Message: "login expired",
+	}, "json")
+	c.Assert(buf.String(), gc.Equals,
+		`{"error":{"message":"login expired","code":"login expired","hint":"run \"juju login\" to log in to the controller again"}}`+"\n")
+}
+
+func (s *ErrorsSuite) TestErrorRenderingCommand(c *gc.C) {
+	inner := &failingCommand{err: &params.Error{
+		Code:    params.CodeLoginExpired,
+		Message: "login expired",
+	}}
+	ctx, err := cmdtesting.RunCommand(c, jujucmd.NewErrorRenderingCommand(inner), "--format", "json")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, `"code":"login expired"`)
+}
+
+func (s *ErrorsSuite) TestErrorRenderingCommandSilent(c *gc.C) {
+	inner := &failingCommand{err: cmd.ErrSilent}
+	ctx, err := cmdtesting.RunCommand(c, jujucmd.NewErrorRenderingCommand(inner))
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "")
+}
+
+type failingCommand struct {
+	cmd.CommandBase
+	fmt cmd.Output
+	err error
+}
+
+func (c *failingCommand) Info() *cmd.Info {
+	return &cmd.Info{Name: "fail"}
+}
+
+func (c *failingCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.fmt.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+func (c *failingCommand) Run(*cmd.Context) error {
+	return c.err
+}
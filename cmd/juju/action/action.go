@@ -10,6 +10,7 @@ import (
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/api/action"
+	"github.com/juju/juju/api/annotations"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/core/watcher"
@@ -53,6 +54,16 @@ type APIClient interface {
 	WatchActionProgress(actionId string) (watcher.StringsWatcher, error)
 }
 
+// AnnotationsAPI represents the annotations API functionality used to
+// select units by their annotations.
+type AnnotationsAPI interface {
+	io.Closer
+
+	// Query returns the annotations of the entities of the given kinds
+	// whose annotations match the selector.
+	Query(selector string, kinds ...string) ([]params.EntityAnnotations, error)
+}
+
 // ActionCommandBase is the base type for action sub-commands.
 type ActionCommandBase struct {
 	modelcmd.ModelCommandBase
@@ -70,3 +81,17 @@ var newAPIClient = func(c *ActionCommandBase) (APIClient, error) {
 	}
 	return action.NewClient(root), nil
 }
+
+// NewAnnotationsAPIClient returns a client for the annotations api
+// endpoint.
+func (c *ActionCommandBase) NewAnnotationsAPIClient() (AnnotationsAPI, error) {
+	return newAnnotationsAPIClient(c)
+}
+
+var newAnnotationsAPIClient = func(c *ActionCommandBase) (AnnotationsAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return annotations.NewClient(root), nil
+}
@@ -16,8 +16,9 @@ import (
 )
 
 var (
-	NewActionAPIClient = &newAPIClient
-	AddValueToMap      = addValueToMap
+	NewActionAPIClient      = &newAPIClient
+	NewAnnotationsAPIClient = &newAnnotationsAPIClient
+	AddValueToMap           = addValueToMap
 )
 
 type ShowOperationCommand struct {
@@ -17,6 +17,7 @@ import (
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/annotations"
 )
 
 func NewRunCommand() cmd.Command {
@@ -35,6 +36,7 @@ func NewRunCommand() cmd.Command {
 type runCommand struct {
 	runCommandBase
 	unitReceivers []string
+	selector      string
 	leaders       map[string]string
 	actionName    string
 	paramsYAML    cmd.FileVar
@@ -68,6 +70,11 @@ Valid unit identifiers are:
 If the leader syntax is used, the leader unit for the application will be
 resolved before the action is enqueued.
 
+Instead of naming units, the --selector option runs the action on the units
+whose annotations match a selector, a comma separated list of requirements
+such as env=prod,tier!=web. Each requirement is one of key=value, key!=value,
+key (the annotation is set) or !key (the annotation is not set).
+
 Params are validated according to the charm for the unit's application.  The
 valid params can be seen using "juju actions <application> --schema".
 Params may be in a yaml file which is passed with the --params option, or they
@@ -88,6 +95,7 @@ Examples:
     juju run mysql/3 backup --utc
     juju run mysql/3 backup
     juju run mysql/leader backup
+    juju run --selector env=prod,tier=db backup
     juju show-operation <ID>
     juju run mysql/3 backup --params parameters.yml
     juju run mysql/3 backup out=out.tar.bz2 file.kind=xz file.quality=high
@@ -108,12 +116,13 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 
 	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
 	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
+	f.StringVar(&c.selector, "selector", "", "Run the action on the units whose annotations match this selector")
 }
 
 func (c *runCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:    "run",
-		Args:    "<unit> [<unit> ...] <action-name> [<key>=<value> [<key>[.<key> ...]=<value>]]",
+		Args:    "(<unit> [<unit> ...] | --selector <selector>) <action-name> [<key>=<value> [<key>[.<key> ...]=<value>]]",
 		Purpose: "Run an action on a specified unit.",
 		Doc:     runDoc,
 	})
@@ -124,8 +133,16 @@ func (c *runCommand) Init(args []string) (err error) {
 	if err := c.runCommandBase.Init(args); err != nil {
 		return errors.Trace(err)
 	}
+	if c.selector != "" {
+		if _, err := annotations.ParseSelector(c.selector); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	for _, arg := range args {
 		if names.IsValidUnit(arg) || validLeader.MatchString(arg) {
+			if c.selector != "" {
+				return errors.New("cannot specify both units and --selector")
+			}
 			c.unitReceivers = append(c.unitReceivers, arg)
 		} else if nameRule.MatchString(arg) {
 			c.actionName = arg
@@ -134,7 +151,7 @@ func (c *runCommand) Init(args []string) (err error) {
 			return errors.Errorf("invalid unit or action name %q", arg)
 		}
 	}
-	if len(c.unitReceivers) == 0 {
+	if len(c.unitReceivers) == 0 && c.selector == "" {
 		return errors.New("no unit specified")
 	}
 	if c.actionName == "" {
@@ -167,6 +184,11 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	}
 	defer c.api.Close()
 
+	if c.selector != "" {
+		if err := c.selectUnits(); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	results, err := c.enqueueActions(ctx)
 	if err != nil {
 		return errors.Trace(err)
@@ -174,6 +196,26 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	return c.processOperationResults(ctx, results)
 }
 
+// selectUnits sets the units to run the action on to the units whose
+// annotations match the selector.
+func (c *runCommand) selectUnits() error {
+	api, err := c.NewAnnotationsAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	units, err := common.EntityIdsBySelector(api, c.selector, names.UnitTagKind)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(units) == 0 {
+		return errors.Errorf("no units match selector %q", c.selector)
+	}
+	c.unitReceivers = units
+	return nil
+}
+
 func (c *runCommand) enqueueActions(ctx *cmd.Context) (*params.EnqueuedActions, error) {
 	actionParams := map[string]interface{}{}
 	if c.paramsYAML.Path != "" {
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
+	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/v2"
 	gc "gopkg.in/check.v1"
@@ -216,6 +217,19 @@ func (s *RunSuite) TestInit(c *gc.C) {
 		expectUnits:  []string{"mysql/leader"},
 		expectAction: "valid-action-name",
 		expectKVArgs: [][]string{},
+	}, {
+		should:       "work with --selector",
+		args:         []string{"--selector", "env=prod", "valid-action-name", "foo=bar"},
+		expectAction: "valid-action-name",
+		expectKVArgs: [][]string{{"foo", "bar"}},
+	}, {
+		should:      "fail with both units and --selector",
+		args:        []string{"--selector", "env=prod", validUnitId, "valid-action-name"},
+		expectError: "cannot specify both units and --selector",
+	}, {
+		should:      "fail with invalid --selector",
+		args:        []string{"--selector", "env=", "valid-action-name"},
+		expectError: `selector "env=": requirement "env=" with no value not valid`,
 	}}
 
 	for i, t := range tests {
@@ -828,6 +842,66 @@ mysql/1:
 	}
 }
 
+type fakeAnnotationsAPI struct {
+	jujutesting.Stub
+	results []params.EntityAnnotations
+}
+
+func (f *fakeAnnotationsAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeAnnotationsAPI) Query(selector string, kinds ...string) ([]params.EntityAnnotations, error) {
+	f.MethodCall(f, "Query", selector, kinds)
+	return f.results, f.NextErr()
+}
+
+func (s *RunSuite) patchAnnotationsAPIClient(api *fakeAnnotationsAPI) {
+	s.PatchValue(action.NewAnnotationsAPIClient,
+		func(c *action.ActionCommandBase) (action.AnnotationsAPI, error) {
+			return api, nil
+		},
+	)
+}
+
+func (s *RunSuite) TestRunWithSelector(c *gc.C) {
+	annotationsAPI := &fakeAnnotationsAPI{results: []params.EntityAnnotations{{
+		EntityTag:   names.NewUnitTag(validUnitId).String(),
+		Annotations: map[string]string{"env": "prod"},
+	}}}
+	s.patchAnnotationsAPIClient(annotationsAPI)
+	s.clock = testClock()
+	client := &fakeAPIClient{
+		actionResults: []params.ActionResult{{
+			Action: &params.Action{
+				Tag:      validActionTagString,
+				Receiver: names.NewUnitTag(validUnitId).String(),
+			},
+		}},
+	}
+	s.testRunHelper(c, client, "", "", s.modelFlags[0],
+		[]string{"--selector", "env=prod", "some-action", "--background"},
+		0, 1, []params.Action{{
+			Name:       "some-action",
+			Parameters: map[string]interface{}{},
+			Receiver:   names.NewUnitTag(validUnitId).String(),
+		}}, nil)
+	annotationsAPI.CheckCalls(c, []jujutesting.StubCall{
+		{FuncName: "Query", Args: []interface{}{"env=prod", []string{names.UnitTagKind}}},
+		{FuncName: "Close"},
+	})
+}
+
+func (s *RunSuite) TestRunWithSelectorNoMatches(c *gc.C) {
+	s.patchAnnotationsAPIClient(&fakeAnnotationsAPI{})
+	restore := s.patchAPIClient(&fakeAPIClient{})
+	defer restore()
+	runCmd, _ := action.NewRunCommandForTest(s.store, s.clock, nil)
+	_, err := cmdtesting.RunCommand(c, runCmd, s.modelFlags[0], "admin", "--selector", "env=prod", "some-action")
+	c.Assert(err, gc.ErrorMatches, `no units match selector "env=prod"`)
+}
+
 func (s *RunSuite) testRunHelper(c *gc.C, client *fakeAPIClient,
 	expectedErr, expectedOutput, modelFlag string, withArgs []string,
 	numTicks int, numExpectedTimers int,
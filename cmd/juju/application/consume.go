@@ -6,6 +6,7 @@ package application
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/api/application"
@@ -30,10 +31,15 @@ If the controller name is omitted, Juju will use the currently active
 controller. Similarly, if the model owner is omitted, Juju will use the user
 that is currently logged in to the controller providing the offer.
 
+The --encrypt-relation-data option encrypts the settings exchanged over
+relations to the offer while they are passed between the controllers
+hosting the two models.
+
 Examples:
     $ juju consume othermodel.mysql
     $ juju consume owner/othermodel.mysql
     $ juju consume anothercontroller:owner/othermodel.mysql
+    $ juju consume --encrypt-relation-data anothercontroller:owner/othermodel.mysql
 
 See also:
     add-relation
@@ -53,6 +59,8 @@ type consumeCommand struct {
 	targetAPI         applicationConsumeAPI
 	remoteApplication string
 	applicationAlias  string
+
+	encryptRelationData bool
 }
 
 // Info implements cmd.Command.
@@ -65,6 +73,12 @@ func (c *consumeCommand) Info() *cmd.Info {
 	})
 }
 
+// SetFlags implements cmd.Command.
+func (c *consumeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.encryptRelationData, "encrypt-relation-data", false, "Encrypt the settings exchanged over relations to the offer")
+}
+
 // Init implements cmd.Command.
 func (c *consumeCommand) Init(args []string) error {
 	if len(args) == 0 {
@@ -156,9 +170,10 @@ func (c *consumeCommand) Run(ctx *cmd.Context) error {
 	defer targetClient.Close()
 
 	arg := crossmodel.ConsumeApplicationArgs{
-		Offer:            *consumeDetails.Offer,
-		ApplicationAlias: c.applicationAlias,
-		Macaroon:         consumeDetails.Macaroon,
+		Offer:               *consumeDetails.Offer,
+		ApplicationAlias:    c.applicationAlias,
+		Macaroon:            consumeDetails.Macaroon,
+		EncryptRelationData: c.encryptRelationData,
 	}
 	if consumeDetails.ControllerInfo != nil {
 		controllerTag, err := names.ParseControllerTag(consumeDetails.ControllerInfo.ControllerTag)
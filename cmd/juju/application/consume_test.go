@@ -92,17 +92,16 @@ func (s *ConsumeSuite) TestConsumeBlocked(c *gc.C) {
 	c.Assert(err.Error(), jc.Contains, `All operations that change model have been disabled for the current model.`)
 }
 
-func (s *ConsumeSuite) assertSuccessModelDotApplication(c *gc.C, alias string) {
+func (s *ConsumeSuite) assertSuccessModelDotApplication(c *gc.C, alias string, encrypt bool) {
 	s.mockAPI.localName = "mary-weep"
-	var (
-		ctx *cmd.Context
-		err error
-	)
+	args := []string{"ctrl:booster.uke"}
 	if alias != "" {
-		ctx, err = s.runConsume(c, "ctrl:booster.uke", alias)
-	} else {
-		ctx, err = s.runConsume(c, "ctrl:booster.uke")
+		args = append(args, alias)
 	}
+	if encrypt {
+		args = append(args, "--encrypt-relation-data")
+	}
+	ctx, err := s.runConsume(c, args...)
 	c.Assert(err, jc.ErrorIsNil)
 	mac, err := apitesting.NewMacaroon("id")
 	c.Assert(err, jc.ErrorIsNil)
@@ -118,6 +117,7 @@ func (s *ConsumeSuite) assertSuccessModelDotApplication(c *gc.C, alias string) {
 				Addrs:         []string{"192.168.1:1234"},
 				CACert:        coretesting.CACert,
 			},
+			EncryptRelationData: encrypt,
 		},
 		}},
 		{"Close", nil},
@@ -127,11 +127,15 @@ func (s *ConsumeSuite) assertSuccessModelDotApplication(c *gc.C, alias string) {
 }
 
 func (s *ConsumeSuite) TestSuccessModelDotApplication(c *gc.C) {
-	s.assertSuccessModelDotApplication(c, "")
+	s.assertSuccessModelDotApplication(c, "", false)
 }
 
 func (s *ConsumeSuite) TestSuccessModelDotApplicationWithAlias(c *gc.C) {
-	s.assertSuccessModelDotApplication(c, "alias")
+	s.assertSuccessModelDotApplication(c, "alias", false)
+}
+
+func (s *ConsumeSuite) TestSuccessModelDotApplicationEncryptRelationData(c *gc.C) {
+	s.assertSuccessModelDotApplication(c, "", true)
 }
 
 type mockConsumeAPI struct {
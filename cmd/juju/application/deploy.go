@@ -272,6 +272,10 @@ type DeployCommand struct {
 	// deployed but just output the changes.
 	DryRun bool
 
+	// ViaController is used to specify that the controller, rather than
+	// the client, should download charms and bundles from CharmHub.
+	ViaController bool
+
 	ApplicationName string
 	ConfigOptions   common.ConfigFlag
 	ConstraintsStr  string
@@ -377,6 +381,12 @@ A local charm may be deployed by giving the path to its directory:
   juju deploy /path/to/charm
   juju deploy /path/to/charm --series bionic
 
+When deploying a local charm archive, a detached PGP or cosign signature in a
+file of the same name with a ".sig" or ".asc" extension is uploaded with it, to
+be verified against the controller's "charm-signing-keys":
+
+  juju deploy /path/to/charm.charm
+
 You will need to be explicit if there is an ambiguity between a local and a
 remote charm:
 
@@ -574,6 +584,11 @@ Deploy to a machine that is in the 'dmz' network space but not in either the
 
     juju deploy haproxy -n 2 --constraints spaces=dmz,^cms,^database
 
+Deploy a bundle from charm hub when the client cannot reach charm hub itself,
+leaving the controller to resolve and download everything:
+
+    juju deploy kubeflow --via-controller
+
 Deploy a k8s charm that requires a single Nvidia GPU:
 
     juju deploy mycharm --device miner=1,nvidia.com/gpu
@@ -620,6 +635,7 @@ func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.Series, "series", "", "The series on which to deploy")
 	f.BoolVar(&c.DryRun, "dry-run", false, "Just show what the bundle deploy would do")
 	f.BoolVar(&c.Force, "force", false, "Allow a charm/bundle to be deployed which bypasses checks such as supported series or LXD profile allow list")
+	f.BoolVar(&c.ViaController, "via-controller", false, "Leave all charm hub interaction to the controller, for clients which cannot reach charm hub")
 	f.Var(storageFlag{&c.Storage, &c.BundleStorage}, "storage", "Charm storage constraints")
 	f.Var(devicesFlag{&c.Devices, &c.BundleDevices}, "device", "Charm device constraints")
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
@@ -785,8 +801,13 @@ func (c *DeployCommand) Run(ctx *cmd.Context) error {
 	csRepoFn := func() (store.CharmrepoForDeploy, error) {
 		return cstoreAPI, nil
 	}
-	downloadClientFn := func() (store.DownloadBundleClient, error) {
-		return c.NewDownloadClient()
+	// A nil download client func has bundles downloaded by the
+	// controller, so that the client never talks to CharmHub itself.
+	var downloadClientFn store.DownloadBundleClientFunc
+	if !c.ViaController {
+		downloadClientFn = func() (store.DownloadBundleClient, error) {
+			return c.NewDownloadClient()
+		}
 	}
 
 	charmAdapter := c.NewResolver(apicharms.NewClient(apiRoot), csRepoFn, downloadClientFn)
@@ -51,6 +51,7 @@ import (
 	"github.com/juju/juju/cmd/juju/application/deployer"
 	"github.com/juju/juju/cmd/juju/application/mocks"
 	"github.com/juju/juju/cmd/juju/application/store"
+	storemocks "github.com/juju/juju/cmd/juju/application/store/mocks"
 	apputils "github.com/juju/juju/cmd/juju/application/utils"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -2410,6 +2411,45 @@ func (s *DeployUnitTestSuite) TestDeployAttachStorageContainer(c *gc.C) {
 
 }
 
+func (s *DeployUnitTestSuite) TestDeployBundleViaController(c *gc.C) {
+	ctrl := s.setupMocks(c)
+	defer ctrl.Finish()
+
+	curl := charm.MustParseURL("ch:wordpress")
+	origin := commoncharm.Origin{
+		Source: commoncharm.OriginCharmHub,
+		Type:   "bundle",
+		Risk:   "stable",
+	}
+	charmsAPI := storemocks.NewMockCharmsAPI(ctrl)
+	bundle := storemocks.NewMockBundle(ctrl)
+	charmsAPI.EXPECT().DownloadBundle(curl, origin, "/tmp/wordpress.bundle").Return(bundle, nil)
+
+	s.factory.EXPECT().GetDeployer(gomock.Any(), gomock.Any(), gomock.Any()).Return(s.deployer, nil)
+	s.deployer.EXPECT().PrepareAndDeploy(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ *cmd.Context, _ deployer.DeployerAPI, resolver deployer.Resolver, _ store.MacaroonGetter) error {
+			got, err := resolver.GetBundle(curl, origin, "/tmp/wordpress.bundle")
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(got, gc.Equals, bundle)
+			return nil
+		},
+	)
+
+	fakeAPI := s.fakeAPI()
+	deployCmd := newDeployCommandForTest(fakeAPI)
+	deployCmd.NewResolver = func(_ store.CharmsAPI, charmRepoFn store.CharmStoreRepoFunc, downloadClientFn store.DownloadBundleClientFunc) deployer.Resolver {
+		return store.NewCharmAdaptor(charmsAPI, charmRepoFn, downloadClientFn)
+	}
+	deployCmd.NewDownloadClient = func() (store.DownloadBundleClient, error) {
+		c.Fatalf("bundle downloaded by the client")
+		return nil, nil
+	}
+	wrapped := modelcmd.Wrap(deployCmd)
+	wrapped.SetClientStore(jujuclienttesting.MinimalStore())
+	_, err := cmdtesting.RunCommand(c, wrapped, curl.String(), "--via-controller")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func basicDeployerConfig(charmOrBundle string) deployer.DeployerConfig {
 	cfgOps := common.ConfigFlag{}
 	cfgOps.SetPreserveStringValue(true)
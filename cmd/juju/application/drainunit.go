@@ -0,0 +1,218 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const (
+	defaultDrainTimeout = 10 * time.Minute
+	drainPollInterval   = 2 * time.Second
+)
+
+const drainUnitDoc = `
+Drain application units ahead of removing them from the model.
+
+Each unit is asked to run its charm's pre-remove hook, giving stateful
+workloads the opportunity to hand off their data before the unit goes
+away. Once every unit has completed the hook, the units are removed as
+if by "juju remove-unit".
+
+If the units have not all drained before the timeout expires, the
+command fails and no units are removed. Units that did drain remain
+drained, and may be removed with "juju remove-unit".
+
+Charms without a pre-remove hook are considered drained as soon as the
+unit agent notices the request.
+
+Examples:
+
+    juju drain-unit mysql/2
+
+    juju drain-unit mysql/2 mysql/3 --timeout 30m
+
+    juju drain-unit mysql/2 --destroy-storage
+
+See also:
+    remove-unit
+`
+
+// DrainUnitAPI defines the API methods that the drain-unit command uses.
+type DrainUnitAPI interface {
+	Close() error
+	BestAPIVersion() int
+	DrainUnits([]names.UnitTag) ([]params.ErrorResult, error)
+	UnitsDrained([]names.UnitTag) ([]params.BoolResult, error)
+	DestroyUnits(application.DestroyUnitsParams) ([]params.DestroyUnitResult, error)
+}
+
+// NewDrainUnitCommand returns a command which drains and then removes
+// application units.
+func NewDrainUnitCommand() modelcmd.ModelCommand {
+	c := &drainUnitCommand{clock: clock.WallClock}
+	c.newAPIFunc = func() (DrainUnitAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(c)
+}
+
+// drainUnitCommand is responsible for draining and then destroying
+// application units.
+type drainUnitCommand struct {
+	modelcmd.ModelCommandBase
+
+	unitNames      []string
+	timeout        time.Duration
+	destroyStorage bool
+
+	clock      clock.Clock
+	newAPIFunc func() (DrainUnitAPI, error)
+}
+
+// Info implements Command.Info.
+func (c *drainUnitCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "drain-unit",
+		Args:    "<unit> [...]",
+		Purpose: "Drain application units and then remove them from the model.",
+		Doc:     drainUnitDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *drainUnitCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.DurationVar(&c.timeout, "timeout", defaultDrainTimeout, "Maximum time to wait for the units to drain")
+	f.BoolVar(&c.destroyStorage, "destroy-storage", false, "Destroy storage attached to the units")
+}
+
+// Init implements Command.Init.
+func (c *drainUnitCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no units specified")
+	}
+	for _, name := range args {
+		if !names.IsValidUnit(name) {
+			return errors.Errorf("invalid unit name %q", name)
+		}
+	}
+	if c.timeout <= 0 {
+		return errors.NotValidf("non-positive timeout %v", c.timeout)
+	}
+	c.unitNames = args
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *drainUnitCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if client.BestAPIVersion() < 14 {
+		return errors.New("drain-unit is not supported by this controller")
+	}
+
+	tags := make([]names.UnitTag, len(c.unitNames))
+	for i, name := range c.unitNames {
+		tags[i] = names.NewUnitTag(name)
+	}
+	results, err := client.DrainUnits(tags)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockRemove)
+	}
+	anyFailed := false
+	for i, result := range results {
+		if result.Error != nil {
+			anyFailed = true
+			ctx.Infof("draining unit %s failed: %s", c.unitNames[i], result.Error)
+			continue
+		}
+		ctx.Infof("draining unit %s", c.unitNames[i])
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+
+	if err := c.waitForDrain(ctx, client, tags); err != nil {
+		return errors.Trace(err)
+	}
+
+	destroyResults, err := client.DestroyUnits(application.DestroyUnitsParams{
+		Units:          c.unitNames,
+		DestroyStorage: c.destroyStorage,
+	})
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockRemove)
+	}
+	for i, result := range destroyResults {
+		if result.Error != nil {
+			anyFailed = true
+			ctx.Infof("removing unit %s failed: %s", c.unitNames[i], result.Error)
+			continue
+		}
+		ctx.Infof("removing unit %s", c.unitNames[i])
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// waitForDrain polls the controller until each of the given units has
+// drained, or the timeout expires.
+func (c *drainUnitCommand) waitForDrain(ctx *cmd.Context, client DrainUnitAPI, tags []names.UnitTag) error {
+	timeout := c.clock.After(c.timeout)
+	pending := tags
+	for {
+		results, err := client.UnitsDrained(pending)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		var stillPending []names.UnitTag
+		for i, result := range results {
+			if result.Error != nil {
+				return errors.Annotatef(result.Error, "checking drain of unit %s", pending[i].Id())
+			}
+			if result.Result {
+				ctx.Infof("unit %s drained", pending[i].Id())
+				continue
+			}
+			stillPending = append(stillPending, pending[i])
+		}
+		if len(stillPending) == 0 {
+			return nil
+		}
+		pending = stillPending
+
+		select {
+		case <-c.clock.After(drainPollInterval):
+		case <-timeout:
+			unitNames := make([]string, len(pending))
+			for i, tag := range pending {
+				unitNames[i] = tag.Id()
+			}
+			return errors.Errorf("timed out waiting for units %v to drain; no units removed", unitNames)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/names/v4"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apiapplication "github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient"
+	jujutesting "github.com/juju/juju/testing"
+)
+
+type DrainUnitSuite struct {
+	jujutesting.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+	clock *testclock.Clock
+	api   *mockDrainUnitAPI
+}
+
+var _ = gc.Suite(&DrainUnitSuite{})
+
+func (s *DrainUnitSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Models["testing"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/controller": {},
+		},
+		CurrentModel: "admin/controller",
+	}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	s.clock = testclock.NewClock(time.Now())
+	s.api = &mockDrainUnitAPI{version: 14}
+}
+
+func (s *DrainUnitSuite) runDrainUnit(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, application.NewDrainUnitCommandForTest(s.api, s.clock, s.store), args...)
+}
+
+func (s *DrainUnitSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no units specified",
+	}, {
+		args: []string{"mysql"},
+		err:  `invalid unit name "mysql"`,
+	}, {
+		args: []string{"mysql/0", "--timeout", "0s"},
+		err:  "non-positive timeout 0s not valid",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := cmdtesting.InitCommand(application.NewDrainUnitCommandForTest(s.api, s.clock, s.store), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *DrainUnitSuite) TestDrainUnit(c *gc.C) {
+	s.api.drainedAfter = map[string]int{"mysql/0": 1, "mysql/1": 1}
+
+	ctx, err := s.runDrainUnit(c, "mysql/0", "mysql/1", "--destroy-storage")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "BestAPIVersion", "DrainUnits", "UnitsDrained", "DestroyUnits", "Close")
+	tags := []names.UnitTag{names.NewUnitTag("mysql/0"), names.NewUnitTag("mysql/1")}
+	s.api.CheckCall(c, 1, "DrainUnits", tags)
+	s.api.CheckCall(c, 2, "UnitsDrained", tags)
+	s.api.CheckCall(c, 3, "DestroyUnits", apiapplication.DestroyUnitsParams{
+		Units:          []string{"mysql/0", "mysql/1"},
+		DestroyStorage: true,
+	})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+draining unit mysql/0
+draining unit mysql/1
+unit mysql/0 drained
+unit mysql/1 drained
+removing unit mysql/0
+removing unit mysql/1
+`[1:])
+}
+
+func (s *DrainUnitSuite) TestDrainUnitWaits(c *gc.C) {
+	// mysql/1 reports drained on the third poll.
+	s.api.drainedAfter = map[string]int{"mysql/0": 1, "mysql/1": 3}
+
+	done := make(chan error)
+	go func() {
+		_, err := s.runDrainUnit(c, "mysql/0", "mysql/1")
+		done <- err
+	}()
+
+	// Wait for the timeout and each poll.
+	err := s.clock.WaitAdvance(2*time.Second, jujutesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.clock.WaitAdvance(2*time.Second, jujutesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case err := <-done:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(jujutesting.LongWait):
+		c.Fatalf("timed out waiting for command")
+	}
+	s.api.CheckCallNames(c, "BestAPIVersion", "DrainUnits", "UnitsDrained", "UnitsDrained", "UnitsDrained", "DestroyUnits", "Close")
+	s.api.CheckCall(c, 3, "UnitsDrained", []names.UnitTag{names.NewUnitTag("mysql/1")})
+}
+
+func (s *DrainUnitSuite) TestDrainUnitTimeout(c *gc.C) {
+	done := make(chan error)
+	go func() {
+		_, err := s.runDrainUnit(c, "mysql/0", "--timeout", "1s")
+		done <- err
+	}()
+
+	err := s.clock.WaitAdvance(time.Second, jujutesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case err := <-done:
+		c.Assert(err, gc.ErrorMatches, `timed out waiting for units \[mysql/0\] to drain; no units removed`)
+	case <-time.After(jujutesting.LongWait):
+		c.Fatalf("timed out waiting for command")
+	}
+	s.api.CheckCallNames(c, "BestAPIVersion", "DrainUnits", "UnitsDrained", "Close")
+}
+
+func (s *DrainUnitSuite) TestDrainUnitFailed(c *gc.C) {
+	s.api.drainErrors = map[string]*params.Error{
+		"mysql/1": {Message: "unit \"mysql/1\" does not exist"},
+	}
+
+	ctx, err := s.runDrainUnit(c, "mysql/0", "mysql/1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	s.api.CheckCallNames(c, "BestAPIVersion", "DrainUnits", "Close")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+draining unit mysql/0
+draining unit mysql/1 failed: unit "mysql/1" does not exist
+`[1:])
+}
+
+func (s *DrainUnitSuite) TestDrainUnitNotSupported(c *gc.C) {
+	s.api.version = 13
+
+	_, err := s.runDrainUnit(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "drain-unit is not supported by this controller")
+	s.api.CheckCallNames(c, "BestAPIVersion", "Close")
+}
+
+type mockDrainUnitAPI struct {
+	gitjujutesting.Stub
+	version     int
+	drainErrors map[string]*params.Error
+
+	// drainedAfter holds the number of polls after which each unit
+	// reports itself drained.
+	drainedAfter map[string]int
+	polls        int
+}
+
+func (m *mockDrainUnitAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockDrainUnitAPI) BestAPIVersion() int {
+	m.MethodCall(m, "BestAPIVersion")
+	return m.version
+}
+
+func (m *mockDrainUnitAPI) DrainUnits(units []names.UnitTag) ([]params.ErrorResult, error) {
+	m.MethodCall(m, "DrainUnits", units)
+	results := make([]params.ErrorResult, len(units))
+	for i, unit := range units {
+		results[i].Error = m.drainErrors[unit.Id()]
+	}
+	return results, m.NextErr()
+}
+
+func (m *mockDrainUnitAPI) UnitsDrained(units []names.UnitTag) ([]params.BoolResult, error) {
+	m.MethodCall(m, "UnitsDrained", units)
+	m.polls++
+	results := make([]params.BoolResult, len(units))
+	for i, unit := range units {
+		after, ok := m.drainedAfter[unit.Id()]
+		results[i].Result = ok && m.polls >= after
+	}
+	return results, m.NextErr()
+}
+
+func (m *mockDrainUnitAPI) DestroyUnits(args apiapplication.DestroyUnitsParams) ([]params.DestroyUnitResult, error) {
+	m.MethodCall(m, "DestroyUnits", args)
+	return make([]params.DestroyUnitResult, len(args.Units)), m.NextErr()
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/juju/charm/v9"
+	"github.com/juju/clock"
 	"github.com/juju/cmd"
 	"github.com/juju/collections/set"
 	gc "gopkg.in/check.v1"
@@ -121,6 +122,14 @@ func NewRemoveUnitCommandForTest(api RemoveApplicationAPI, store jujuclient.Clie
 	return modelcmd.Wrap(cmd)
 }
 
+// NewRemoveUnitCommandWithSelectorForTest returns a RemoveUnitCommand
+// with the application and annotations apis provided as specified.
+func NewRemoveUnitCommandWithSelectorForTest(api RemoveApplicationAPI, annotationsAPI annotationsQueryAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &removeUnitCommand{api: api, annotationsAPI: annotationsAPI}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 type removeAPIFunc func() (RemoveApplicationAPI, int, error)
 
 // NewRemoveApplicationCommandForTest returns a RemoveApplicationCommand.
@@ -231,10 +240,56 @@ func NewShowCommandForTest(api ApplicationsInfoAPI, store jujuclient.ClientStore
 	return modelcmd.Wrap(cmd)
 }
 
-func NewShowUnitCommandForTest(api UnitsInfoAPI, store jujuclient.ClientStore) cmd.Command {
-	cmd := &showUnitCommand{newAPIFunc: func() (UnitsInfoAPI, error) {
-		return api, nil
-	}}
+func NewDrainUnitCommandForTest(api DrainUnitAPI, clock clock.Clock, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &drainUnitCommand{
+		clock: clock,
+		newAPIFunc: func() (DrainUnitAPI, error) {
+			return api, nil
+		},
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewSetDefaultStorageCommandForTest(api SetDefaultStorageAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &setDefaultStorageCommand{
+		newAPIFunc: func() (SetDefaultStorageAPI, error) {
+			return api, nil
+		},
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewShowRelationCommandForTest(api RelationSnapshotAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &showRelationCommand{
+		newAPIFunc: func() (RelationSnapshotAPI, error) {
+			return api, nil
+		},
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewRelationsCommandForTest(api RelationMatrixAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &relationsCommand{
+		newAPIFunc: func() (RelationMatrixAPI, error) {
+			return api, nil
+		},
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewShowUnitCommandForTest(api UnitsInfoAPI, historyAPI WorkloadVersionHistoryAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &showUnitCommand{
+		newAPIFunc: func() (UnitsInfoAPI, error) {
+			return api, nil
+		},
+		newHistoryAPIFunc: func() (WorkloadVersionHistoryAPI, error) {
+			return historyAPI, nil
+		},
+	}
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
 }
@@ -0,0 +1,222 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/juju/charm/v9"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/relationsettings"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const relationsDoc = `
+The command displays the relations in the model as a matrix. Each
+application endpoint taking part in a relation is both a row and a
+column of the matrix, and the relation ID is shown where two endpoints
+are related. Peer relations are shown on the diagonal.
+
+In the tabular output, the relation IDs of cross-model relations are
+marked with "*", and those of container scoped relations with "c".
+
+The yaml and json formats list the endpoints each endpoint is related
+to, with the relation ID, scope and whether it is a cross-model
+relation.
+
+Examples:
+    juju relations
+    juju relations --format yaml
+
+See also:
+    add-relation
+    remove-relation
+    show-relation
+    status
+`
+
+// NewRelationsCommand returns a command that displays the relations in
+// a model as a matrix of application endpoints.
+func NewRelationsCommand() cmd.Command {
+	c := &relationsCommand{}
+	c.newAPIFunc = func() (RelationMatrixAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return relationsettings.NewClient(root), nil
+	}
+	return modelcmd.Wrap(c)
+}
+
+// RelationMatrixAPI defines the API methods that the relations command
+// uses.
+type RelationMatrixAPI interface {
+	Close() error
+	RelationMatrix() (params.RelationMatrix, error)
+}
+
+type relationsCommand struct {
+	modelcmd.ModelCommandBase
+
+	out cmd.Output
+
+	newAPIFunc func() (RelationMatrixAPI, error)
+}
+
+// RelationMatrixEndpoint holds an application endpoint and the
+// endpoints it is related to, keyed by "<application>:<endpoint>".
+type RelationMatrixEndpoint struct {
+	Role      string                         `yaml:"role" json:"role"`
+	Interface string                         `yaml:"interface" json:"interface"`
+	Remote    bool                           `yaml:"remote,omitempty" json:"remote,omitempty"`
+	Relations map[string]RelationMatrixEntry `yaml:"relations" json:"relations"`
+}
+
+// RelationMatrixEntry describes the relation between two endpoints.
+type RelationMatrixEntry struct {
+	RelationId int    `yaml:"relation-id" json:"relation-id"`
+	Scope      string `yaml:"scope" json:"scope"`
+	CrossModel bool   `yaml:"cross-model,omitempty" json:"cross-model,omitempty"`
+}
+
+// Info implements Command.Info.
+func (c *relationsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "relations",
+		Purpose: "Displays the relations in a model as a matrix of endpoints.",
+		Doc:     relationsDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *relationsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatRelationMatrixTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *relationsCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *relationsCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	matrix, err := client.RelationMatrix()
+	if err != nil {
+		if errors.IsNotSupported(err) {
+			return errors.New("displaying the relation matrix is not supported by this controller")
+		}
+		return errors.Trace(err)
+	}
+	if c.out.Name() == "tabular" {
+		if len(matrix.Endpoints) == 0 {
+			ctx.Infof("No relations to display.")
+			return nil
+		}
+		return c.out.Write(ctx, matrix)
+	}
+	return c.out.Write(ctx, formatRelationMatrix(matrix))
+}
+
+func endpointName(ep params.RelationMatrixEndpoint) string {
+	return ep.ApplicationName + ":" + ep.Name
+}
+
+// formatRelationMatrix converts the matrix into a map of endpoints,
+// each holding the endpoints it is related to.
+func formatRelationMatrix(matrix params.RelationMatrix) map[string]RelationMatrixEndpoint {
+	result := make(map[string]RelationMatrixEndpoint)
+	for i, ep := range matrix.Endpoints {
+		formatted := RelationMatrixEndpoint{
+			Role:      ep.Role,
+			Interface: ep.Interface,
+			Remote:    ep.Remote,
+			Relations: make(map[string]RelationMatrixEntry),
+		}
+		for j, entry := range matrix.Relations[i] {
+			if entry == nil {
+				continue
+			}
+			formatted.Relations[endpointName(matrix.Endpoints[j])] = RelationMatrixEntry{
+				RelationId: entry.RelationId,
+				Scope:      entry.Scope,
+				CrossModel: entry.CrossModel,
+			}
+		}
+		result[endpointName(ep)] = formatted
+	}
+	return result
+}
+
+// formatRelationMatrixTabular writes the matrix with a numbered row for
+// each endpoint, and a column for each row number.
+func formatRelationMatrixTabular(writer io.Writer, value interface{}) error {
+	matrix, ok := value.(params.RelationMatrix)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", matrix, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+
+	header := []interface{}{"", "Endpoint", "Interface"}
+	for i := range matrix.Endpoints {
+		header = append(header, strconv.Itoa(i+1))
+	}
+	w.Println(header...)
+
+	var crossModel, container bool
+	for i, ep := range matrix.Endpoints {
+		row := []interface{}{strconv.Itoa(i + 1), endpointName(ep), ep.Interface}
+		for _, entry := range matrix.Relations[i] {
+			if entry == nil {
+				row = append(row, "-")
+				continue
+			}
+			cell := strconv.Itoa(entry.RelationId)
+			if entry.Scope == string(charm.ScopeContainer) {
+				cell += "c"
+				container = true
+			}
+			if entry.CrossModel {
+				cell += "*"
+				crossModel = true
+			}
+			row = append(row, cell)
+		}
+		w.Println(row...)
+	}
+	if err := tw.Flush(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if crossModel || container {
+		fmt.Fprintln(writer)
+	}
+	if crossModel {
+		fmt.Fprintln(writer, "* cross-model relation")
+	}
+	if container {
+		fmt.Fprintln(writer, "c container scoped relation")
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient"
+	jujutesting "github.com/juju/juju/testing"
+)
+
+type RelationsSuite struct {
+	jujutesting.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+	api   *mockRelationMatrixAPI
+}
+
+var _ = gc.Suite(&RelationsSuite{})
+
+func (s *RelationsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Models["testing"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/controller": {},
+		},
+		CurrentModel: "admin/controller",
+	}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+
+	rel1 := &params.RelationMatrixEntry{RelationId: 1, Key: "wordpress:db mysql:server", Scope: "global"}
+	rel2 := &params.RelationMatrixEntry{RelationId: 2, Key: "wordpress:juju-info logging:info", Scope: "container"}
+	rel3 := &params.RelationMatrixEntry{RelationId: 3, Key: "mysql:cluster", Scope: "global"}
+	rel4 := &params.RelationMatrixEntry{RelationId: 4, Key: "wordpress:db backup:db", Scope: "global", CrossModel: true}
+	s.api = &mockRelationMatrixAPI{
+		matrix: params.RelationMatrix{
+			Endpoints: []params.RelationMatrixEndpoint{
+				{ApplicationName: "backup", Name: "db", Role: "provider", Interface: "mysql", Remote: true},
+				{ApplicationName: "logging", Name: "info", Role: "requirer", Interface: "juju-info"},
+				{ApplicationName: "mysql", Name: "cluster", Role: "peer", Interface: "mysql-ha"},
+				{ApplicationName: "mysql", Name: "server", Role: "provider", Interface: "mysql"},
+				{ApplicationName: "wordpress", Name: "db", Role: "requirer", Interface: "mysql"},
+				{ApplicationName: "wordpress", Name: "juju-info", Role: "provider", Interface: "juju-info"},
+			},
+			Relations: [][]*params.RelationMatrixEntry{
+				{nil, nil, nil, nil, rel4, nil},
+				{nil, nil, nil, nil, nil, rel2},
+				{nil, nil, rel3, nil, nil, nil},
+				{nil, nil, nil, nil, rel1, nil},
+				{rel4, nil, nil, rel1, nil, nil},
+				{nil, rel2, nil, nil, nil, nil},
+			},
+		},
+	}
+}
+
+func (s *RelationsSuite) runRelations(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, application.NewRelationsCommandForTest(s.api, s.store), args...)
+}
+
+func (s *RelationsSuite) TestInit(c *gc.C) {
+	_, err := s.runRelations(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["mysql"\]`)
+}
+
+func (s *RelationsSuite) TestRelationsTabular(c *gc.C) {
+	ctx, err := s.runRelations(c)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "RelationMatrix", "Close")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+   Endpoint             Interface  1   2   3  4  5   6
+1  backup:db            mysql      -   -   -  -  4*  -
+2  logging:info         juju-info  -   -   -  -  -   2c
+3  mysql:cluster        mysql-ha   -   -   3  -  -   -
+4  mysql:server         mysql      -   -   -  -  1   -
+5  wordpress:db         mysql      4*  -   -  1  -   -
+6  wordpress:juju-info  juju-info  -   2c  -  -  -   -
+
+* cross-model relation
+c container scoped relation
+`[1:])
+}
+
+func (s *RelationsSuite) TestRelationsYAML(c *gc.C) {
+	ctx, err := s.runRelations(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+backup:db:
+  role: provider
+  interface: mysql
+  remote: true
+  relations:
+    wordpress:db:
+      relation-id: 4
+      scope: global
+      cross-model: true
+logging:info:
+  role: requirer
+  interface: juju-info
+  relations:
+    wordpress:juju-info:
+      relation-id: 2
+      scope: container
+mysql:cluster:
+  role: peer
+  interface: mysql-ha
+  relations:
+    mysql:cluster:
+      relation-id: 3
+      scope: global
+mysql:server:
+  role: provider
+  interface: mysql
+  relations:
+    wordpress:db:
+      relation-id: 1
+      scope: global
+wordpress:db:
+  role: requirer
+  interface: mysql
+  relations:
+    backup:db:
+      relation-id: 4
+      scope: global
+      cross-model: true
+    mysql:server:
+      relation-id: 1
+      scope: global
+wordpress:juju-info:
+  role: provider
+  interface: juju-info
+  relations:
+    logging:info:
+      relation-id: 2
+      scope: container
+`[1:])
+}
+
+func (s *RelationsSuite) TestRelationsNone(c *gc.C) {
+	s.api.matrix = params.RelationMatrix{}
+	ctx, err := s.runRelations(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No relations to display.\n")
+}
+
+func (s *RelationsSuite) TestRelationsNoneJSON(c *gc.C) {
+	s.api.matrix = params.RelationMatrix{}
+	ctx, err := s.runRelations(c, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "{}\n")
+}
+
+func (s *RelationsSuite) TestRelationsNotSupported(c *gc.C) {
+	s.api.SetErrors(errors.NotSupportedf("relation matrix"))
+	_, err := s.runRelations(c)
+	c.Assert(err, gc.ErrorMatches, "displaying the relation matrix is not supported by this controller")
+}
+
+func (s *RelationsSuite) TestRelationsAPIError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.runRelations(c)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockRelationMatrixAPI struct {
+	gitjujutesting.Stub
+	matrix params.RelationMatrix
+}
+
+func (m *mockRelationMatrixAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockRelationMatrixAPI) RelationMatrix() (params.RelationMatrix, error) {
+	m.MethodCall(m, "RelationMatrix")
+	if err := m.NextErr(); err != nil {
+		return params.RelationMatrix{}, err
+	}
+	return m.matrix, nil
+}
@@ -12,12 +12,15 @@ import (
 	"github.com/juju/gnuflag"
 	"github.com/juju/names/v4"
 
+	"github.com/juju/juju/api/annotations"
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/api/storage"
 	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	coreannotations "github.com/juju/juju/core/annotations"
 	"github.com/juju/juju/core/model"
 )
 
@@ -26,13 +29,22 @@ func NewRemoveUnitCommand() modelcmd.ModelCommand {
 	return modelcmd.Wrap(&removeUnitCommand{})
 }
 
+// annotationsQueryAPI is the annotations API used to select the units
+// to remove.
+type annotationsQueryAPI interface {
+	Query(selector string, kinds ...string) ([]params.EntityAnnotations, error)
+	Close() error
+}
+
 // removeUnitCommand is responsible for destroying application units.
 type removeUnitCommand struct {
 	modelcmd.ModelCommandBase
 	DestroyStorage bool
 	NumUnits       int
 	EntityNames    []string
+	Selector       string
 	api            RemoveApplicationAPI
+	annotationsAPI annotationsQueryAPI
 
 	unknownModel bool
 	Force        bool
@@ -62,7 +74,10 @@ For cloud models specific units can be targeted for removal.
 Units of a application are numbered in sequence upon creation. For example, the
 fourth unit of wordpress will be designated "wordpress/3". These identifiers
 can be supplied in a space delimited list to remove unwanted units from the
-model.
+model. Alternatively, the --selector option removes the units whose
+annotations match a selector, a comma separated list of requirements such
+as env=staging,tier!=db. Each requirement is one of key=value, key!=value,
+key (the annotation is set) or !key (the annotation is not set).
 
 Juju will also remove the machine if the removed unit was the only unit left
 on that machine (including units in containers).
@@ -90,6 +105,8 @@ Examples:
 
     juju remove-unit wordpress/2 --force --no-wait
 
+    juju remove-unit --selector env=staging
+
 See also:
     remove-application
     scale-application
@@ -98,7 +115,7 @@ See also:
 func (c *removeUnitCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:    "remove-unit",
-		Args:    "<unit> [...] | <application>",
+		Args:    "<unit> [...] | <application> | --selector <selector>",
 		Purpose: "Remove application units from the model.",
 		Doc:     removeUnitDoc,
 	})
@@ -110,11 +127,20 @@ func (c *removeUnitCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.DestroyStorage, "destroy-storage", false, "Destroy storage attached to the unit")
 	f.BoolVar(&c.Force, "force", false, "Completely remove an application and all its dependencies")
 	f.BoolVar(&c.NoWait, "no-wait", false, "Rush through application removal without waiting for each individual step to complete")
+	f.StringVar(&c.Selector, "selector", "", "Remove the units whose annotations match this selector (non-k8s models only)")
 	c.fs = f
 }
 
 func (c *removeUnitCommand) Init(args []string) error {
 	c.EntityNames = args
+	if c.Selector != "" {
+		if len(args) > 0 {
+			return errors.New("cannot specify both units and --selector")
+		}
+		if _, err := coreannotations.ParseSelector(c.Selector); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	if err := c.validateArgsByModelType(); err != nil {
 		if !errors.IsNotFound(err) {
 			return errors.Trace(err)
@@ -138,6 +164,9 @@ func (c *removeUnitCommand) validateArgsByModelType() error {
 }
 
 func (c *removeUnitCommand) validateCAASRemoval() error {
+	if c.Selector != "" {
+		return errors.New("k8s models do not support --selector")
+	}
 	if c.DestroyStorage {
 		// TODO(caas): enable --destroy-storage for caas model.
 		return errors.New("k8s models only support --num-units")
@@ -165,7 +194,7 @@ func (c *removeUnitCommand) validateIAASRemoval() error {
 	if c.NumUnits != 0 {
 		return errors.NotValidf("--num-units for non k8s models")
 	}
-	if len(c.EntityNames) == 0 {
+	if len(c.EntityNames) == 0 && c.Selector == "" {
 		return errors.Errorf("no units specified")
 	}
 	for _, name := range c.EntityNames {
@@ -189,6 +218,37 @@ func (c *removeUnitCommand) getAPI() (RemoveApplicationAPI, int, error) {
 	return api, api.BestAPIVersion(), nil
 }
 
+func (c *removeUnitCommand) getAnnotationsAPI() (annotationsQueryAPI, error) {
+	if c.annotationsAPI != nil {
+		return c.annotationsAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return annotations.NewClient(root), nil
+}
+
+// selectUnits sets the units to remove to the units whose annotations
+// match the selector.
+func (c *removeUnitCommand) selectUnits() error {
+	client, err := c.getAnnotationsAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	units, err := common.EntityIdsBySelector(client, c.Selector, names.UnitTagKind)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(units) == 0 {
+		return errors.Errorf("no units match selector %q", c.Selector)
+	}
+	c.EntityNames = units
+	return nil
+}
+
 func (c *removeUnitCommand) getStorageAPI() (storageAPI, error) {
 	root, err := c.NewAPIRoot()
 	if err != nil {
@@ -247,6 +307,9 @@ func (c *removeUnitCommand) Run(ctx *cmd.Context) error {
 	defer client.Close()
 
 	if apiVersion < 4 {
+		if c.Selector != "" {
+			return errors.New("--selector is not supported by this controller")
+		}
 		return c.removeUnitsDeprecated(ctx, client)
 	}
 
@@ -262,6 +325,11 @@ func (c *removeUnitCommand) Run(ctx *cmd.Context) error {
 		return c.removeCaasUnits(ctx, client)
 	}
 
+	if c.Selector != "" {
+		if err := c.selectUnits(); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	if c.DestroyStorage && apiVersion < 5 {
 		return errors.New("--destroy-storage is not supported by this controller")
 	}
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
+	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -163,6 +164,52 @@ func (s *RemoveUnitSuite) TestBlockRemoveUnit(c *gc.C) {
 	c.Check(stripped, gc.Matches, ".*TestBlockRemoveUnit.*")
 }
 
+type fakeAnnotationsQueryAPI struct {
+	units    []string
+	selector string
+	kinds    []string
+}
+
+func (f *fakeAnnotationsQueryAPI) Query(selector string, kinds ...string) ([]params.EntityAnnotations, error) {
+	f.selector = selector
+	f.kinds = kinds
+	var results []params.EntityAnnotations
+	for _, unit := range f.units {
+		results = append(results, params.EntityAnnotations{
+			EntityTag:   names.NewUnitTag(unit).String(),
+			Annotations: map[string]string{"env": "staging"},
+		})
+	}
+	return results, nil
+}
+
+func (f *fakeAnnotationsQueryAPI) Close() error {
+	return nil
+}
+
+func (s *RemoveUnitSuite) TestRemoveUnitSelector(c *gc.C) {
+	annotationsAPI := &fakeAnnotationsQueryAPI{units: []string{"unit/0", "unit/1"}}
+	_, err := cmdtesting.RunCommand(c, application.NewRemoveUnitCommandWithSelectorForTest(s.fake, annotationsAPI, s.store), "--selector", "env=staging")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(annotationsAPI.selector, gc.Equals, "env=staging")
+	c.Assert(annotationsAPI.kinds, jc.DeepEquals, []string{"unit"})
+	c.Assert(s.fake.units, jc.DeepEquals, []string{"unit/0", "unit/1"})
+}
+
+func (s *RemoveUnitSuite) TestRemoveUnitSelectorNoMatches(c *gc.C) {
+	annotationsAPI := &fakeAnnotationsQueryAPI{}
+	_, err := cmdtesting.RunCommand(c, application.NewRemoveUnitCommandWithSelectorForTest(s.fake, annotationsAPI, s.store), "--selector", "env=staging")
+	c.Assert(err, gc.ErrorMatches, `no units match selector "env=staging"`)
+	c.Assert(s.fake.units, gc.HasLen, 0)
+}
+
+func (s *RemoveUnitSuite) TestRemoveUnitSelectorInvalid(c *gc.C) {
+	_, err := s.runRemoveUnit(c, "--selector", "env=staging", "unit/0")
+	c.Assert(err, gc.ErrorMatches, "cannot specify both units and --selector")
+	_, err = s.runRemoveUnit(c, "--selector", "env=")
+	c.Assert(err, gc.ErrorMatches, `selector "env=": requirement "env=" with no value not valid`)
+}
+
 func (s *RemoveUnitSuite) TestCAASRemoveUnit(c *gc.C) {
 	m := s.store.Models["arthur"].Models["king/sword"]
 	m.ModelType = model.CAAS
@@ -210,6 +257,9 @@ func (s *RemoveUnitSuite) TestCAASAllowsNumUnitsOnly(c *gc.C) {
 	_, err = s.runRemoveUnit(c, "some-application-name", "another-application", "--num-units", "2")
 	c.Assert(err, gc.ErrorMatches, "only single application supported")
 
+	_, err = s.runRemoveUnit(c, "--selector", "env=staging")
+	c.Assert(err, gc.ErrorMatches, "k8s models do not support --selector")
+
 	_, err = s.runRemoveUnit(c, "some-application-name", "--num-units", "2")
 	c.Assert(err, jc.ErrorIsNil)
 }
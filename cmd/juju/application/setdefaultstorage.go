@@ -0,0 +1,148 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/application"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/storage"
+)
+
+const setDefaultStorageDoc = `
+Set the storage pool, size and count used for an application's storage
+when new units are added, without having to pass --storage to every
+"juju add-unit".
+
+Storage constraints are specified per storage name, in the same format
+as the --storage option of "juju deploy": a comma separated sequence of
+pool, size and count. Any of them left out keeps its current value. The
+storage of existing units is not affected.
+
+Examples:
+
+Use the ebs-ssd pool and 100GiB volumes for new units of postgresql:
+
+    juju set-default-storage postgresql pgdata=ebs-ssd,100G
+
+Increase the size of the data storage of new units of mysql:
+
+    juju set-default-storage mysql data=200G
+
+See also:
+    add-unit
+    deploy
+    storage
+`
+
+// SetDefaultStorageAPI defines the API methods that the set-default-storage
+// command uses.
+type SetDefaultStorageAPI interface {
+	Close() error
+	BestAPIVersion() int
+	UpdateApplicationStorage(string, map[string]storage.Constraints) error
+}
+
+// NewSetDefaultStorageCommand returns a command which sets the storage
+// constraints used for an application's future units.
+func NewSetDefaultStorageCommand() modelcmd.ModelCommand {
+	c := &setDefaultStorageCommand{}
+	c.newAPIFunc = func() (SetDefaultStorageAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(c)
+}
+
+// setDefaultStorageCommand is responsible for updating the storage
+// constraints of an application.
+type setDefaultStorageCommand struct {
+	modelcmd.ModelCommandBase
+
+	applicationName string
+	storage         map[string]storage.Constraints
+
+	newAPIFunc func() (SetDefaultStorageAPI, error)
+}
+
+// Info implements Command.Info.
+func (c *setDefaultStorageCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "set-default-storage",
+		Args:    "<application> <storage-name>=<constraints> [...]",
+		Purpose: "Set the storage constraints used for an application's new units.",
+		Doc:     setDefaultStorageDoc,
+	})
+}
+
+// Init implements Command.Init.
+func (c *setDefaultStorageCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no application specified")
+	case 1:
+		return errors.New("no storage constraints specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.NotValidf("application name %q", args[0])
+	}
+	c.applicationName = args[0]
+
+	cons, err := storage.ParseConstraintsMap(args[1:], true)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// ParseConstraints defaults the count to 1, but a count which isn't
+	// specified should keep its current value.
+	for _, arg := range args[1:] {
+		name, value := splitStorageArg(arg)
+		if !hasStorageCount(value) {
+			storageCons := cons[name]
+			storageCons.Count = 0
+			cons[name] = storageCons
+		}
+	}
+	c.storage = cons
+	return nil
+}
+
+func splitStorageArg(arg string) (string, string) {
+	parts := strings.SplitN(arg, "=", 2)
+	return parts[0], parts[1]
+}
+
+func hasStorageCount(value string) bool {
+	for _, field := range strings.Split(value, ",") {
+		if _, err := strconv.ParseUint(field, 10, 64); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Run implements Command.Run.
+func (c *setDefaultStorageCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if client.BestAPIVersion() < 15 {
+		return errors.New("set-default-storage is not supported by this controller")
+	}
+	err = client.UpdateApplicationStorage(c.applicationName, c.storage)
+	return block.ProcessBlockedError(errors.Annotatef(err, "cannot set default storage for application %q", c.applicationName), block.BlockChange)
+}
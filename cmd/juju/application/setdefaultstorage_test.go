@@ -0,0 +1,118 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/storage"
+	jujutesting "github.com/juju/juju/testing"
+)
+
+type SetDefaultStorageSuite struct {
+	jujutesting.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+	api   *mockSetDefaultStorageAPI
+}
+
+var _ = gc.Suite(&SetDefaultStorageSuite{})
+
+func (s *SetDefaultStorageSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Models["testing"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/controller": {},
+		},
+		CurrentModel: "admin/controller",
+	}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	s.api = &mockSetDefaultStorageAPI{version: 15}
+}
+
+func (s *SetDefaultStorageSuite) runSetDefaultStorage(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, application.NewSetDefaultStorageCommandForTest(s.api, s.store), args...)
+}
+
+func (s *SetDefaultStorageSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application specified",
+	}, {
+		args: []string{"mysql"},
+		err:  "no storage constraints specified",
+	}, {
+		args: []string{"mysql/0", "data=ebs"},
+		err:  `application name "mysql/0" not valid`,
+	}, {
+		args: []string{"mysql", "data"},
+		err:  `expected "name=constraints" where "constraints" must be specified, got "data"`,
+	}, {
+		args: []string{"mysql", "data=ebs", "data=10G"},
+		err:  `storage "data" specified more than once`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := cmdtesting.InitCommand(application.NewSetDefaultStorageCommandForTest(s.api, s.store), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *SetDefaultStorageSuite) TestSetDefaultStorage(c *gc.C) {
+	_, err := s.runSetDefaultStorage(c, "mysql", "data=ebs,100G", "logs=3")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "BestAPIVersion", "UpdateApplicationStorage", "Close")
+	s.api.CheckCall(c, 1, "UpdateApplicationStorage", "mysql", map[string]storage.Constraints{
+		// The count of the data storage is left unchanged.
+		"data": {Pool: "ebs", Size: 100 * 1024},
+		"logs": {Count: 3},
+	})
+}
+
+func (s *SetDefaultStorageSuite) TestSetDefaultStorageError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.runSetDefaultStorage(c, "mysql", "data=ebs")
+	c.Assert(err, gc.ErrorMatches, `cannot set default storage for application "mysql": boom`)
+}
+
+func (s *SetDefaultStorageSuite) TestSetDefaultStorageNotSupported(c *gc.C) {
+	s.api.version = 14
+	_, err := s.runSetDefaultStorage(c, "mysql", "data=ebs")
+	c.Assert(err, gc.ErrorMatches, "set-default-storage is not supported by this controller")
+	s.api.CheckCallNames(c, "BestAPIVersion", "Close")
+}
+
+type mockSetDefaultStorageAPI struct {
+	gitjujutesting.Stub
+	version int
+}
+
+func (m *mockSetDefaultStorageAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockSetDefaultStorageAPI) BestAPIVersion() int {
+	m.MethodCall(m, "BestAPIVersion")
+	return m.version
+}
+
+func (m *mockSetDefaultStorageAPI) UpdateApplicationStorage(appName string, cons map[string]storage.Constraints) error {
+	m.MethodCall(m, "UpdateApplicationStorage", appName, cons)
+	return m.NextErr()
+}
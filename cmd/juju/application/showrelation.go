@@ -0,0 +1,147 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strconv"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/relationsettings"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const showRelationDoc = `
+The command takes a relation ID as an argument, as shown by
+"juju status --relations", and displays the application settings
+and the settings of each unit in scope, on both sides of the relation.
+
+Relation settings often hold credentials, such as database
+passwords, so take care when sharing the output.
+
+Examples:
+    juju show-relation 4
+    juju show-relation 4 --format json
+
+See also:
+    add-relation
+    remove-relation
+    show-unit
+`
+
+// NewShowRelationCommand returns a command that displays the settings
+// of a relation.
+func NewShowRelationCommand() cmd.Command {
+	s := &showRelationCommand{}
+	s.newAPIFunc = func() (RelationSnapshotAPI, error) {
+		root, err := s.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return relationsettings.NewClient(root), nil
+	}
+	return modelcmd.Wrap(s)
+}
+
+// RelationSnapshotAPI defines the API methods that the show-relation
+// command uses.
+type RelationSnapshotAPI interface {
+	Close() error
+	Snapshot(relationIds []int) ([]params.RelationSnapshotResult, error)
+}
+
+type showRelationCommand struct {
+	modelcmd.ModelCommandBase
+
+	out        cmd.Output
+	relationId int
+
+	newAPIFunc func() (RelationSnapshotAPI, error)
+}
+
+// RelationSnapshot holds the settings on each side of a relation,
+// keyed by application name.
+type RelationSnapshot struct {
+	RelationId   int                                 `yaml:"relation-id" json:"relation-id"`
+	Key          string                              `yaml:"key" json:"key"`
+	Applications map[string]RelationEndpointSettings `yaml:"applications" json:"applications"`
+}
+
+// RelationEndpointSettings holds the settings of the application and
+// units on one side of a relation.
+type RelationEndpointSettings struct {
+	Endpoint        string                            `yaml:"endpoint" json:"endpoint"`
+	Role            string                            `yaml:"role" json:"role"`
+	ApplicationData map[string]interface{}            `yaml:"application-data" json:"application-data"`
+	UnitData        map[string]map[string]interface{} `yaml:"unit-data,omitempty" json:"unit-data,omitempty"`
+}
+
+// Info implements Command.Info.
+func (c *showRelationCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show-relation",
+		Args:    "<relation id>",
+		Purpose: "Displays the settings on each side of a relation.",
+		Doc:     showRelationDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showRelationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters.Formatters())
+}
+
+// Init implements Command.Init.
+func (c *showRelationCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no relation ID specified")
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil || id < 0 {
+		return errors.NotValidf("relation ID %q", args[0])
+	}
+	c.relationId = id
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *showRelationCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	results, err := client.Snapshot([]int{c.relationId})
+	if err != nil {
+		if params.IsCodeNotImplemented(err) {
+			return errors.New("showing relation settings is not supported by this controller")
+		}
+		return errors.Trace(err)
+	}
+	result := results[0]
+	if result.Error != nil {
+		return result.Error
+	}
+
+	output := RelationSnapshot{
+		RelationId:   result.Id,
+		Key:          result.Key,
+		Applications: make(map[string]RelationEndpointSettings),
+	}
+	for _, ep := range result.Endpoints {
+		output.Applications[ep.ApplicationName] = RelationEndpointSettings{
+			Endpoint:        ep.Name,
+			Role:            ep.Role,
+			ApplicationData: ep.ApplicationSettings,
+			UnitData:        ep.UnitSettings,
+		}
+	}
+	return c.out.Write(ctx, output)
+}
@@ -0,0 +1,166 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient"
+	jujutesting "github.com/juju/juju/testing"
+)
+
+type ShowRelationSuite struct {
+	jujutesting.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+	api   *mockRelationSnapshotAPI
+}
+
+var _ = gc.Suite(&ShowRelationSuite{})
+
+func (s *ShowRelationSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Models["testing"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/controller": {},
+		},
+		CurrentModel: "admin/controller",
+	}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+
+	s.api = &mockRelationSnapshotAPI{
+		results: []params.RelationSnapshotResult{{
+			Id:  4,
+			Key: "wordpress:db mysql:server",
+			Endpoints: []params.RelationEndpointSnapshot{{
+				ApplicationName:     "mysql",
+				Name:                "server",
+				Role:                "provider",
+				ApplicationSettings: map[string]interface{}{"database": "wordpress"},
+				UnitSettings: map[string]map[string]interface{}{
+					"mysql/0": {"host": "10.0.0.1"},
+				},
+			}, {
+				ApplicationName:     "wordpress",
+				Name:                "db",
+				Role:                "requirer",
+				ApplicationSettings: map[string]interface{}{},
+			}},
+		}},
+	}
+}
+
+func (s *ShowRelationSuite) runShow(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, application.NewShowRelationCommandForTest(s.api, s.store), args...)
+}
+
+func (s *ShowRelationSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no relation ID specified",
+	}, {
+		args: []string{"mysql"},
+		err:  `relation ID "mysql" not valid`,
+	}, {
+		args: []string{"--", "-1"},
+		err:  `relation ID "-1" not valid`,
+	}, {
+		args: []string{"4", "5"},
+		err:  `unrecognized args: \["5"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runShow(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ShowRelationSuite) TestShowRelation(c *gc.C) {
+	ctx, err := s.runShow(c, "4")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []gitjujutesting.StubCall{
+		{"Snapshot", []interface{}{[]int{4}}},
+		{"Close", nil},
+	})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+relation-id: 4
+key: wordpress:db mysql:server
+applications:
+  mysql:
+    endpoint: server
+    role: provider
+    application-data:
+      database: wordpress
+    unit-data:
+      mysql/0:
+        host: 10.0.0.1
+  wordpress:
+    endpoint: db
+    role: requirer
+    application-data: {}
+`[1:])
+}
+
+func (s *ShowRelationSuite) TestShowRelationJSON(c *gc.C) {
+	ctx, err := s.runShow(c, "4", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		`{"relation-id":4,"key":"wordpress:db mysql:server","applications":{`+
+		`"mysql":{"endpoint":"server","role":"provider","application-data":{"database":"wordpress"},`+
+		`"unit-data":{"mysql/0":{"host":"10.0.0.1"}}},`+
+		`"wordpress":{"endpoint":"db","role":"requirer","application-data":{}}}}`+"\n")
+}
+
+func (s *ShowRelationSuite) TestShowRelationNotFound(c *gc.C) {
+	s.api.results = []params.RelationSnapshotResult{{
+		Id:    5,
+		Error: &params.Error{Message: "relation 5 not found", Code: params.CodeNotFound},
+	}}
+	_, err := s.runShow(c, "5")
+	c.Assert(err, gc.ErrorMatches, "relation 5 not found")
+}
+
+func (s *ShowRelationSuite) TestShowRelationNotSupported(c *gc.C) {
+	s.api.SetErrors(&params.Error{Code: params.CodeNotImplemented})
+	_, err := s.runShow(c, "4")
+	c.Assert(err, gc.ErrorMatches, "showing relation settings is not supported by this controller")
+}
+
+func (s *ShowRelationSuite) TestShowRelationAPIError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.runShow(c, "4")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockRelationSnapshotAPI struct {
+	gitjujutesting.Stub
+	results []params.RelationSnapshotResult
+}
+
+func (m *mockRelationSnapshotAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockRelationSnapshotAPI) Snapshot(relationIds []int) ([]params.RelationSnapshotResult, error) {
+	m.MethodCall(m, "Snapshot", relationIds)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.results, nil
+}
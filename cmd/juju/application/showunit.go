@@ -13,7 +13,10 @@ import (
 	"github.com/juju/naturalsort"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/api/workloadversions"
+	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -23,12 +26,17 @@ The command takes deployed unit names as an argument.
 Optionally, relation data for only a specified endpoint
 or related unit may be shown, or just the application data. 
 
+The --version-history option includes the workload versions
+previously reported by the unit, most recent first, along with
+the time each version was first reported.
+
 Examples:
     juju show-unit mysql/0
     juju show-unit mysql/0 wordpress/1
     juju show-unit mysql/0 --app
     juju show-unit mysql/0 --endpoint db
     juju show-unit mysql/0 --related-unit wordpress/2
+    juju show-unit mysql/0 --version-history
 `
 
 // NewShowUnitCommand returns a command that displays unit info.
@@ -37,6 +45,9 @@ func NewShowUnitCommand() cmd.Command {
 	s.newAPIFunc = func() (UnitsInfoAPI, error) {
 		return s.newUnitAPI()
 	}
+	s.newHistoryAPIFunc = func() (WorkloadVersionHistoryAPI, error) {
+		return s.newHistoryAPI()
+	}
 	return modelcmd.Wrap(s)
 }
 
@@ -48,8 +59,10 @@ type showUnitCommand struct {
 	endpoint    string
 	relatedUnit string
 	appOnly     bool
+	history     bool
 
-	newAPIFunc func() (UnitsInfoAPI, error)
+	newAPIFunc        func() (UnitsInfoAPI, error)
+	newHistoryAPIFunc func() (WorkloadVersionHistoryAPI, error)
 }
 
 // Info implements Command.Info.
@@ -95,6 +108,7 @@ func (c *showUnitCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.endpoint, "endpoint", "", "only show relation data for the specified endpoint")
 	f.StringVar(&c.relatedUnit, "related-unit", "", "only show relation data for the specified unit")
 	f.BoolVar(&c.appOnly, "app", false, "only show application relation data")
+	f.BoolVar(&c.history, "version-history", false, "include the unit's workload version history")
 }
 
 // UnitsInfoAPI defines the API methods that show-unit command uses.
@@ -103,6 +117,13 @@ type UnitsInfoAPI interface {
 	UnitsInfo([]names.UnitTag) ([]application.UnitInfo, error)
 }
 
+// WorkloadVersionHistoryAPI defines the API methods that show-unit
+// command uses to display workload version history.
+type WorkloadVersionHistoryAPI interface {
+	Close() error
+	VersionHistory([]names.UnitTag) ([]params.WorkloadVersionHistoryResult, error)
+}
+
 func (c *showUnitCommand) newUnitAPI() (UnitsInfoAPI, error) {
 	root, err := c.NewAPIRoot()
 	if err != nil {
@@ -111,6 +132,14 @@ func (c *showUnitCommand) newUnitAPI() (UnitsInfoAPI, error) {
 	return application.NewClient(root), nil
 }
 
+func (c *showUnitCommand) newHistoryAPI() (WorkloadVersionHistoryAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return workloadversions.NewClient(root), nil
+}
+
 // Info implements Command.Run.
 func (c *showUnitCommand) Run(ctx *cmd.Context) error {
 	client, err := c.newAPIFunc()
@@ -150,9 +179,47 @@ func (c *showUnitCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.history && len(output) > 0 {
+		if err := c.addVersionHistory(output, tags); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return c.out.Write(ctx, output)
 }
 
+// addVersionHistory adds the workload version history of each of the
+// units to the output.
+func (c *showUnitCommand) addVersionHistory(output map[string]UnitInfo, tags []names.UnitTag) error {
+	client, err := c.newHistoryAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	results, err := client.VersionHistory(tags)
+	if err != nil {
+		if params.IsCodeNotImplemented(err) {
+			return errors.New("workload version history is not supported by this controller")
+		}
+		return errors.Trace(err)
+	}
+	for i, result := range results {
+		if result.Error != nil {
+			return errors.Annotatef(result.Error, "getting workload version history for %q", tags[i].Id())
+		}
+		info := output[tags[i].Id()]
+		for _, version := range result.History {
+			entry := WorkloadVersionInfo{Version: version.Version}
+			if version.Since != nil {
+				entry.Since = common.FormatTime(version.Since, true)
+			}
+			info.WorkloadVersionHistory = append(info.WorkloadVersionHistory, entry)
+		}
+		output[tags[i].Id()] = info
+	}
+	return nil
+}
+
 func (c *showUnitCommand) getUnitTags() ([]names.UnitTag, error) {
 	tags := make([]names.UnitTag, len(c.units))
 	for i, one := range c.units {
@@ -193,15 +260,23 @@ type RelationData struct {
 	Data                    map[string]UnitRelationData `yaml:"related-units,omitempty" json:"related-units,omitempty"`
 }
 
+// WorkloadVersionInfo defines the serialization behaviour of a workload
+// version previously reported by a unit.
+type WorkloadVersionInfo struct {
+	Version string `yaml:"version" json:"version"`
+	Since   string `yaml:"since,omitempty" json:"since,omitempty"`
+}
+
 // ApplicationInfo defines the serialization behaviour of the application information.
 type UnitInfo struct {
-	WorkloadVersion string         `yaml:"workload-version,omitempty" json:"workload-version,omitempty"`
-	Machine         string         `yaml:"machine,omitempty" json:"machine,omitempty"`
-	OpenedPorts     []string       `yaml:"opened-ports" json:"opened-ports"`
-	PublicAddress   string         `yaml:"public-address,omitempty" json:"public-address,omitempty"`
-	Charm           string         `yaml:"charm" json:"charm"`
-	Leader          bool           `yaml:"leader" json:"leader"`
-	RelationData    []RelationData `yaml:"relation-info,omitempty" json:"relation-info,omitempty"`
+	WorkloadVersion        string                `yaml:"workload-version,omitempty" json:"workload-version,omitempty"`
+	WorkloadVersionHistory []WorkloadVersionInfo `yaml:"workload-version-history,omitempty" json:"workload-version-history,omitempty"`
+	Machine                string                `yaml:"machine,omitempty" json:"machine,omitempty"`
+	OpenedPorts            []string              `yaml:"opened-ports" json:"opened-ports"`
+	PublicAddress          string                `yaml:"public-address,omitempty" json:"public-address,omitempty"`
+	Charm                  string                `yaml:"charm" json:"charm"`
+	Leader                 bool                  `yaml:"leader" json:"leader"`
+	RelationData           []RelationData        `yaml:"relation-info,omitempty" json:"relation-info,omitempty"`
 
 	// The following are for CAAS models.
 	ProviderId string `yaml:"provider-id,omitempty" json:"provider-id,omitempty"`
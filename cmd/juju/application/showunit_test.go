@@ -6,6 +6,7 @@ package application_test
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -14,6 +15,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	apiapplication "github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/application"
 	"github.com/juju/juju/jujuclient"
 	_ "github.com/juju/juju/provider/dummy"
@@ -24,7 +26,8 @@ type ShowUnitSuite struct {
 	jujutesting.FakeJujuXDGDataHomeSuite
 	store *jujuclient.MemStore
 
-	mockAPI *mockShowUnitAPI
+	mockAPI    *mockShowUnitAPI
+	historyAPI *mockVersionHistoryAPI
 }
 
 var _ = gc.Suite(&ShowUnitSuite{})
@@ -48,10 +51,11 @@ func (s *ShowUnitSuite) SetUpTest(c *gc.C) {
 	s.mockAPI = &mockShowUnitAPI{
 		unitsInfoFunc: func([]names.UnitTag) ([]apiapplication.UnitInfo, error) { return nil, nil },
 	}
+	s.historyAPI = &mockVersionHistoryAPI{}
 }
 
 func (s *ShowUnitSuite) runShow(c *gc.C, args ...string) (*cmd.Context, error) {
-	return cmdtesting.RunCommand(c, application.NewShowUnitCommandForTest(s.mockAPI, s.store), args...)
+	return cmdtesting.RunCommand(c, application.NewShowUnitCommandForTest(s.mockAPI, s.historyAPI, s.store), args...)
 }
 
 type showUnitTest struct {
@@ -380,6 +384,60 @@ wordpress/0:
 	})
 }
 
+func (s *ShowUnitSuite) TestShowVersionHistory(c *gc.C) {
+	s.mockAPI.unitsInfoFunc = func([]names.UnitTag) ([]apiapplication.UnitInfo, error) {
+		return []apiapplication.UnitInfo{{
+			Tag:             "unit-wordpress-0",
+			WorkloadVersion: "5.7",
+			Charm:           "charm-wordpress",
+		}}, nil
+	}
+	first := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+	s.historyAPI.results = []params.WorkloadVersionHistoryResult{{
+		History: []params.WorkloadVersion{
+			{Version: "5.7", Since: &second},
+			{Version: "5.6", Since: &first},
+		},
+	}}
+	s.assertRunShow(c, showUnitTest{
+		args: []string{"wordpress/0", "--version-history"},
+		stdout: `
+wordpress/0:
+  workload-version: "5.7"
+  workload-version-history:
+  - version: "5.7"
+    since: 2021-03-01 13:00:00Z
+  - version: "5.6"
+    since: 2021-03-01 12:00:00Z
+  opened-ports: []
+  charm: charm-wordpress
+  leader: false
+`[1:],
+	})
+	c.Assert(s.historyAPI.tags, jc.DeepEquals, []names.UnitTag{names.NewUnitTag("wordpress/0")})
+}
+
+func (s *ShowUnitSuite) TestShowVersionHistoryError(c *gc.C) {
+	s.mockAPI.unitsInfoFunc = func([]names.UnitTag) ([]apiapplication.UnitInfo, error) {
+		return []apiapplication.UnitInfo{s.createTestUnitInfo("wordpress", "")}, nil
+	}
+	s.historyAPI.results = []params.WorkloadVersionHistoryResult{{
+		Error: &params.Error{Message: "boom"},
+	}}
+	_, err := s.runShow(c, "wordpress/0", "--version-history")
+	c.Assert(err, gc.ErrorMatches, `getting workload version history for "wordpress/0": boom`)
+}
+
+func (s *ShowUnitSuite) TestShowVersionHistoryNotSupported(c *gc.C) {
+	s.mockAPI.unitsInfoFunc = func([]names.UnitTag) ([]apiapplication.UnitInfo, error) {
+		return []apiapplication.UnitInfo{s.createTestUnitInfo("wordpress", "")}, nil
+	}
+	s.historyAPI.err = &params.Error{Code: params.CodeNotImplemented, Message: "unknown facade"}
+	_, err := s.runShow(c, "wordpress/0", "--version-history")
+	c.Assert(err, gc.ErrorMatches, "workload version history is not supported by this controller")
+}
+
 type mockShowUnitAPI struct {
 	unitsInfoFunc func([]names.UnitTag) ([]apiapplication.UnitInfo, error)
 }
@@ -391,3 +449,18 @@ func (s mockShowUnitAPI) Close() error {
 func (s mockShowUnitAPI) UnitsInfo(tags []names.UnitTag) ([]apiapplication.UnitInfo, error) {
 	return s.unitsInfoFunc(tags)
 }
+
+type mockVersionHistoryAPI struct {
+	tags    []names.UnitTag
+	results []params.WorkloadVersionHistoryResult
+	err     error
+}
+
+func (m *mockVersionHistoryAPI) Close() error {
+	return nil
+}
+
+func (m *mockVersionHistoryAPI) VersionHistory(tags []names.UnitTag) ([]params.WorkloadVersionHistoryResult, error) {
+	m.tags = tags
+	return m.results, m.err
+}
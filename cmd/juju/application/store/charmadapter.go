@@ -45,7 +45,9 @@ type CharmAdaptor struct {
 	bundleRepoFn       BundleRepoFunc
 }
 
-// NewCharmAdaptor returns a CharmAdaptor.
+// NewCharmAdaptor returns a CharmAdaptor. If downloadBundleClientFunc is
+// nil, CharmHub bundles are downloaded by the controller rather than by
+// the client.
 func NewCharmAdaptor(charmsAPI CharmsAPI, charmStoreRepoFunc CharmStoreRepoFunc, downloadBundleClientFunc DownloadBundleClientFunc) *CharmAdaptor {
 	return &CharmAdaptor{
 		charmsAPI:          charmsAPI,
@@ -152,6 +154,11 @@ type chBundleFactory struct {
 }
 
 func (ch chBundleFactory) GetBundle(curl *charm.URL, origin commoncharm.Origin, path string) (charm.Bundle, error) {
+	if ch.downloadBundleClientFunc == nil {
+		bundle, err := ch.charmsAPI.DownloadBundle(curl, origin, path)
+		return bundle, errors.Trace(err)
+	}
+
 	client, err := ch.downloadBundleClientFunc()
 	if err != nil {
 		return nil, errors.Trace(err)
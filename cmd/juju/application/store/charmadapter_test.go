@@ -267,6 +267,27 @@ func (s *resolveSuite) TestCharmHubGetBundle(c *gc.C) {
 	c.Assert(bundle, gc.DeepEquals, s.bundle)
 }
 
+func (s *resolveSuite) TestCharmHubGetBundleViaController(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	curl, err := charm.ParseURL("ch:testme-1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	origin := commoncharm.Origin{
+		Source: commoncharm.OriginCharmHub,
+		Type:   "bundle",
+		Risk:   "edge",
+	}
+	s.charmsAPI.EXPECT().DownloadBundle(curl, origin, "/tmp/").Return(s.bundle, nil)
+
+	charmAdapter := store.NewCharmAdaptor(s.charmsAPI, func() (store.CharmrepoForDeploy, error) {
+		return s.charmRepo, nil
+	}, nil)
+	bundle, err := charmAdapter.GetBundle(curl, origin, "/tmp/")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bundle, gc.DeepEquals, s.bundle)
+}
+
 func (s *resolveSuite) setupMocks(c *gc.C) *gomock.Controller {
 	ctrl := gomock.NewController(c)
 	s.charmRepo = mocks.NewMockCharmrepoForDeploy(ctrl)
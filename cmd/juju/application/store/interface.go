@@ -39,4 +39,5 @@ type MacaroonGetter interface {
 type CharmsAPI interface {
 	ResolveCharms(charms []apicharm.CharmToResolve) ([]apicharm.ResolvedCharm, error)
 	GetDownloadInfo(curl *charm.URL, origin commoncharm.Origin, mac *macaroon.Macaroon) (apicharm.DownloadInfo, error)
+	DownloadBundle(curl *charm.URL, origin commoncharm.Origin, archivePath string) (charm.Bundle, error)
 }
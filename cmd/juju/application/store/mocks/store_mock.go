@@ -214,6 +214,21 @@ func (m *MockCharmsAPI) EXPECT() *MockCharmsAPIMockRecorder {
 	return m.recorder
 }
 
+// DownloadBundle mocks base method
+func (m *MockCharmsAPI) DownloadBundle(arg0 *charm.URL, arg1 charm0.Origin, arg2 string) (charm.Bundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadBundle", arg0, arg1, arg2)
+	ret0, _ := ret[0].(charm.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadBundle indicates an expected call of DownloadBundle
+func (mr *MockCharmsAPIMockRecorder) DownloadBundle(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadBundle", reflect.TypeOf((*MockCharmsAPI)(nil).DownloadBundle), arg0, arg1, arg2)
+}
+
 // GetDownloadInfo mocks base method
 func (m *MockCharmsAPI) GetDownloadInfo(arg0 *charm.URL, arg1 charm0.Origin, arg2 *macaroon.Macaroon) (charms.DownloadInfo, error) {
 	m.ctrl.T.Helper()
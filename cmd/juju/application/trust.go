@@ -13,14 +13,23 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/application"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/modelcmd"
+	coreapplication "github.com/juju/juju/core/application"
 )
 
 const (
 	trustSummary = `Sets the trust status of a deployed application to true.`
 	trustDetails = `Sets the trust configuration value to true.
 
+By default a trusted application is given the model's credential. With
+--scope=application, the application is instead given a credential
+issued by the cloud for its use alone, with access limited to what the
+application needs. The credential expires, and is rotated by the
+controller before it does. Scoped credentials are only available on
+Kubernetes models.
+
 Examples:
     juju trust media-wiki
+    juju trust --scope=application media-wiki
 
 See also:
     config
@@ -30,6 +39,7 @@ See also:
 type trustCommand struct {
 	configCommand
 	removeTrust bool
+	scope       string
 }
 
 func NewTrustCommand() cmd.Command {
@@ -50,6 +60,7 @@ func (c *trustCommand) Info() *cmd.Info {
 func (c *trustCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.removeTrust, "remove", false, "Remove trusted access from a trusted application")
+	f.StringVar(&c.scope, "scope", "", "The credential given to the application: model or application")
 }
 
 // Init is part of the cmd.Command interface.
@@ -60,5 +71,17 @@ func (c *trustCommand) Init(args []string) error {
 	c.applicationName = args[0]
 	var trustOptionPair string
 	trustOptionPair = fmt.Sprintf("%s=%t", application.TrustConfigOptionName, !c.removeTrust)
-	return c.parseSet([]string{trustOptionPair})
+	options := []string{trustOptionPair}
+	if c.scope != "" {
+		if c.removeTrust {
+			return errors.New("cannot specify --scope with --remove")
+		}
+		if _, err := (coreapplication.ConfigAttributes{
+			coreapplication.TrustScopeConfigKey: c.scope,
+		}).TrustScope(); err != nil {
+			return errors.Trace(err)
+		}
+		options = append(options, fmt.Sprintf("%s=%s", coreapplication.TrustScopeConfigKey, c.scope))
+	}
+	return c.parseSet(options)
 }
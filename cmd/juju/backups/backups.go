@@ -43,6 +43,8 @@ type APIClient interface {
 	Upload(ar io.ReadSeeker, meta params.BackupsMetadataResult) (string, error)
 	// Remove removes the stored backups.
 	Remove(ids ...string) ([]params.ErrorResult, error)
+	// Verify checks that a stored backup can be restored.
+	Verify(id string) (*params.BackupsVerifyResult, error)
 }
 
 // CommandBase is the base type for backups sub-commands.
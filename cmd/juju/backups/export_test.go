@@ -63,3 +63,9 @@ func NewRemoveCommandForTest(store jujuclient.ClientStore) cmd.Command {
 	c.SetClientStore(store)
 	return modelcmd.Wrap(c)
 }
+
+func NewVerifyCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &verifyCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
@@ -178,6 +178,19 @@ func (mr *MockAPIClientMockRecorder) Remove(arg0 ...interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockAPIClient)(nil).Remove), arg0...)
 }
 
+// Verify mocks base method
+func (m *MockAPIClient) Verify(arg0 string) (*params.BackupsVerifyResult, error) {
+	ret := m.ctrl.Call(m, "Verify", arg0)
+	ret0, _ := ret[0].(*params.BackupsVerifyResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify
+func (mr *MockAPIClientMockRecorder) Verify(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockAPIClient)(nil).Verify), arg0)
+}
+
 // Upload mocks base method
 func (m *MockAPIClient) Upload(arg0 io.ReadSeeker, arg1 params.BackupsMetadataResult) (string, error) {
 	ret := m.ctrl.Call(m, "Upload", arg0, arg1)
@@ -0,0 +1,115 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const verifyDoc = `
+verify-backup restores a stored backup into a throwaway database on the
+controller machine and checks that every database and collection in it
+is intact, and that it records the controller model and all of the
+controller's HA nodes. The controller's own database is not touched.
+
+If no ID is given, the most recent stored backup is verified.
+
+The command fails if the backup could not be restored cleanly, so it
+can be run regularly to catch corrupt backups before they are needed.
+
+Examples:
+    juju verify-backup
+    juju verify-backup 20210601-101418.49db53ac-a42f-4ab2-86e1-0c6fa0fec762
+
+See also:
+    create-backup
+    backups
+`
+
+// NewVerifyCommand returns a command used to verify that a backup can
+// be restored.
+func NewVerifyCommand() cmd.Command {
+	return modelcmd.Wrap(&verifyCommand{})
+}
+
+// verifyCommand is the sub-command for verifying a backup.
+type verifyCommand struct {
+	CommandBase
+	// ID is the backup ID to verify.
+	ID string
+}
+
+// Info implements Command.Info.
+func (c *verifyCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "verify-backup",
+		Args:    "[<ID>]",
+		Purpose: "Check that a stored backup can be restored.",
+		Doc:     verifyDoc,
+	})
+}
+
+// Init implements Command.Init.
+func (c *verifyCommand) Init(args []string) error {
+	if len(args) > 0 {
+		c.ID, args = args[0], args[1:]
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *verifyCommand) Run(ctx *cmd.Context) error {
+	if err := c.validateIaasController(c.Info().Name); err != nil {
+		return errors.Trace(err)
+	}
+
+	client, apiVersion, err := c.NewGetAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if apiVersion < 4 {
+		return errors.New("verifying backups is not supported by this controller")
+	}
+
+	id := c.ID
+	if id == "" {
+		list, err := client.List()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		_, id, err = parseList(list.List)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if id == "" {
+			return errors.New("no stored backups to verify")
+		}
+		ctx.Infof("verifying latest backup %s", id)
+	}
+
+	result, err := client.Verify(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !result.Restorable {
+		fmt.Fprintf(ctx.Stdout, "backup %s is not restorable:\n", result.ID)
+		for _, problem := range result.Problems {
+			fmt.Fprintf(ctx.Stdout, "  %s\n", problem)
+		}
+		return cmd.ErrSilent
+	}
+	fmt.Fprintf(ctx.Stdout, "backup %s is restorable\n", result.ID)
+	fmt.Fprintf(ctx.Stdout, "restored databases: %s\n", strings.Join(result.Databases, ", "))
+	fmt.Fprintf(ctx.Stdout, "validated collections: %d\n", result.Collections)
+	return nil
+}
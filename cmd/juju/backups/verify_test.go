@@ -0,0 +1,139 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/backups"
+)
+
+type verifySuite struct {
+	BaseBackupsSuite
+
+	command cmd.Command
+}
+
+var _ = gc.Suite(&verifySuite{})
+
+func (s *verifySuite) SetUpTest(c *gc.C) {
+	s.BaseBackupsSuite.SetUpTest(c)
+
+	s.command = backups.NewVerifyCommandForTest(s.store)
+}
+
+func (s *verifySuite) patch(c *gc.C, apiVersion int) (*gomock.Controller, *MockAPIClient) {
+	ctrl := gomock.NewController(c)
+	client := NewMockAPIClient(ctrl)
+	s.PatchValue(backups.NewGetAPI,
+		func(c *backups.CommandBase) (backups.APIClient, int, error) {
+			return client, apiVersion, nil
+		},
+	)
+	return ctrl, client
+}
+
+func (s *verifySuite) TestVerifyWithID(c *gc.C) {
+	ctrl, client := s.patch(c, 4)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		client.EXPECT().Verify("one").Return(&params.BackupsVerifyResult{
+			ID:          "one",
+			Restorable:  true,
+			Databases:   []string{"juju", "logs"},
+			Collections: 42,
+		}, nil),
+		client.EXPECT().Close(),
+	)
+	ctx, err := cmdtesting.RunCommand(c, s.command, "one")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+backup one is restorable
+restored databases: juju, logs
+validated collections: 42
+`[1:])
+}
+
+func (s *verifySuite) TestVerifyLatest(c *gc.C) {
+	ctrl, client := s.patch(c, 4)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	gomock.InOrder(
+		client.EXPECT().List().Return(&params.BackupsListResult{
+			List: []params.BackupsMetadataResult{
+				{ID: "one", Started: now.Add(-time.Hour)},
+				{ID: "two", Started: now},
+				{ID: "three", Started: now.Add(-2 * time.Hour)},
+			},
+		}, nil),
+		client.EXPECT().Verify("two").Return(&params.BackupsVerifyResult{
+			ID:         "two",
+			Restorable: true,
+			Databases:  []string{"juju"},
+		}, nil),
+		client.EXPECT().Close(),
+	)
+	ctx, err := cmdtesting.RunCommand(c, s.command)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "verifying latest backup two\n")
+	c.Assert(cmdtesting.Stdout(ctx), jc.HasPrefix, "backup two is restorable\n")
+}
+
+func (s *verifySuite) TestVerifyNoBackups(c *gc.C) {
+	ctrl, client := s.patch(c, 4)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		client.EXPECT().List().Return(&params.BackupsListResult{}, nil),
+		client.EXPECT().Close(),
+	)
+	_, err := cmdtesting.RunCommand(c, s.command)
+	c.Assert(err, gc.ErrorMatches, "no stored backups to verify")
+}
+
+func (s *verifySuite) TestVerifyNotRestorable(c *gc.C) {
+	ctrl, client := s.patch(c, 4)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		client.EXPECT().Verify("one").Return(&params.BackupsVerifyResult{
+			ID: "one",
+			Problems: []string{
+				"collection juju.machines is not valid: bad BSON object",
+				"backup was taken with 3 controller nodes but records 1",
+			},
+		}, nil),
+		client.EXPECT().Close(),
+	)
+	ctx, err := cmdtesting.RunCommand(c, s.command, "one")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+backup one is not restorable:
+  collection juju.machines is not valid: bad BSON object
+  backup was taken with 3 controller nodes but records 1
+`[1:])
+}
+
+func (s *verifySuite) TestVerifyNotSupported(c *gc.C) {
+	ctrl, client := s.patch(c, 3)
+	defer ctrl.Finish()
+
+	client.EXPECT().Close()
+	_, err := cmdtesting.RunCommand(c, s.command, "one")
+	c.Assert(err, gc.ErrorMatches, "verifying backups is not supported by this controller")
+}
+
+func (s *verifySuite) TestVerifyTooManyArgs(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.command, "one", "two")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["two"\]`)
+}
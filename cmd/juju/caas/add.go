@@ -88,6 +88,10 @@ or
 Region is strictly necessary only when adding a k8s cluster to a JAAS controller.
 When using a standalone Juju controller, usually just --cloud is required.
 
+Before adding the cluster, Juju reports the storage classes and ingress classes
+available on it, and checks that the credential has the cluster permissions that
+Juju needs, so that problems are found before bootstrap or deploy.
+
 Once Juju is aware of the underlying cloud type, it looks for a suitably configured
 storage class to provide operator and workload storage. If none is found, use
 of the --storage option is required so that Juju will create a storage class
@@ -523,6 +527,10 @@ func (c *AddCAASCommand) Run(ctx *cmd.Context) (err error) {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	var readiness *k8s.ClusterReadiness
+	if checker, ok := broker.(k8s.ClusterReadinessChecker); ok {
+		readiness = reportClusterReadiness(ctx, checker)
+	}
 	var storageMsg string
 	if c.skipStorage {
 		storageMsg = " with no configured storage provisioning capability."
@@ -548,7 +556,11 @@ func (c *AddCAASCommand) Run(ctx *cmd.Context) (err error) {
 				return errors.Annotatef(err, clusterQueryErrMsg, cloudArg)
 			}
 			if provider.IsNoRecommendedStorageError(err) {
-				return errors.Errorf(noRecommendedStorageErrMsg, err.(provider.NoRecommendedStorageError).StorageProvider())
+				msg := fmt.Sprintf(noRecommendedStorageErrMsg, err.(provider.NoRecommendedStorageError).StorageProvider())
+				if readiness != nil && len(readiness.StorageClasses) > 0 {
+					msg += fmt.Sprintf("\tThe cluster has these storage classes: %s.\n", strings.Join(readiness.StorageClasses, ", "))
+				}
+				return errors.New(msg)
 			}
 			if provider.IsUnknownClusterError(err) {
 				cloudName := err.(provider.UnknownClusterError).CloudName
@@ -621,6 +633,57 @@ func (c *AddCAASCommand) Run(ctx *cmd.Context) (err error) {
 	return nil
 }
 
+// reportClusterReadiness reports the storage classes, ingress classes and
+// permissions that the cluster has for Juju, so that anything missing is
+// found now rather than at bootstrap or deploy time. Failing to check
+// the cluster is not fatal, as Juju may still be able to use it.
+func reportClusterReadiness(ctx *cmd.Context, checker k8s.ClusterReadinessChecker) *k8s.ClusterReadiness {
+	readiness, err := checker.CheckClusterReadiness()
+	if err != nil {
+		ctx.Warningf("Could not check that the cluster is ready for Juju: %v", err)
+		return nil
+	}
+	ctx.Infof("%s", formatClusterReadiness(readiness))
+	if len(readiness.MissingPermissions) > 0 {
+		ctx.Warningf(
+			"The credential does not have permission to %s. Juju needs these to bootstrap to the cluster or add models to it.",
+			strings.Join(readiness.MissingPermissions, ", "),
+		)
+	}
+	return readiness
+}
+
+// formatClusterReadiness returns the cluster readiness report.
+func formatClusterReadiness(readiness *k8s.ClusterReadiness) string {
+	classes := func(names []string, defaultName, none string) string {
+		if len(names) == 0 {
+			return none
+		}
+		out := make([]string, len(names))
+		for i, name := range names {
+			out[i] = name
+			if name == defaultName {
+				out[i] += " (default)"
+			}
+		}
+		return strings.Join(out, ", ")
+	}
+	permissions := "ok"
+	if len(readiness.MissingPermissions) > 0 {
+		permissions = "missing " + strings.Join(readiness.MissingPermissions, ", ")
+	}
+	return fmt.Sprintf(`Cluster readiness:
+  storage classes: %s
+  ingress classes: %s
+  permissions: %s`,
+		classes(readiness.StorageClasses, readiness.DefaultStorageClass,
+			"none, use --storage to have Juju create one or --skip-storage"),
+		classes(readiness.IngressClasses, readiness.DefaultIngressClass,
+			"none, applications can't be exposed by ingress until an ingress controller is installed"),
+		permissions,
+	)
+}
+
 func checkCloudRegion(given, detected string) error {
 	if given == "" {
 		// User provided no host cloud/region information.
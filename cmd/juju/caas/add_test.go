@@ -40,6 +40,7 @@ type addCAASSuite struct {
 	initialCloudMap               map[string]cloud.Cloud
 	fakeCloudAPI                  *fakeAddCloudAPI
 	fakeK8sClusterMetadataChecker *fakeK8sClusterMetadataChecker
+	clusterReadiness              *k8s.ClusterReadiness
 	cloudMetadataStore            *fakeCloudMetadataStore
 	credentialStoreAPI            *mocks.MockCredentialStoreAPI
 	fakeK8SConfigFunc             *clientconfig.ClientConfigFunc
@@ -169,6 +170,15 @@ func (api *fakeK8sClusterMetadataChecker) EnsureStorageProvisioner(cfg k8s.Stora
 	return results[0].(*k8s.StorageProvisioner), api.existingSC, jujutesting.TypeAssertError(results[1])
 }
 
+type fakeK8sClusterReadinessChecker struct {
+	*fakeK8sClusterMetadataChecker
+	readiness *k8s.ClusterReadiness
+}
+
+func (api *fakeK8sClusterReadinessChecker) CheckClusterReadiness() (*k8s.ClusterReadiness, error) {
+	return api.readiness, nil
+}
+
 func fakeNewK8sClientConfig(_ string, _ io.Reader, contextName, clusterName string, _ clientconfig.K8sCredentialResolver) (*clientconfig.ClientConfig, error) {
 	cCfg := &clientconfig.ClientConfig{
 		CurrentContext: "key1",
@@ -324,6 +334,12 @@ func (s *addCAASSuite) makeCommand(c *gc.C, cloudTypeExists, emptyClientConfig,
 			return s.fakeCloudAPI, nil
 		},
 		func(cloud jujucloud.Cloud, credential jujucloud.Credential) (k8s.ClusterMetadataChecker, error) {
+			if s.clusterReadiness != nil {
+				return &fakeK8sClusterReadinessChecker{
+					fakeK8sClusterMetadataChecker: s.fakeK8sClusterMetadataChecker,
+					readiness:                     s.clusterReadiness,
+				}, nil
+			}
 			return s.fakeK8sClusterMetadataChecker, nil
 		},
 		caas.FakeCluster(kubeConfigStr),
@@ -917,6 +933,37 @@ func (s *addCAASSuite) TestGatherClusterMetadataNoRecommendedStorageError(c *gc.
 	c.Assert(err, gc.ErrorMatches, expectedErr)
 }
 
+func (s *addCAASSuite) TestGatherClusterMetadataNoRecommendedStorageListsStorageClasses(c *gc.C) {
+	s.fakeK8sClusterMetadataChecker.Call("CheckDefaultWorkloadStorage").Returns(
+		&k8s.NonPreferredStorageError{PreferredStorage: k8s.PreferredStorage{Name: "disk"}})
+	s.clusterReadiness = &k8s.ClusterReadiness{StorageClasses: []string{"fast", "slow"}}
+
+	command := s.makeCommand(c, true, false, true)
+	_, err := s.runCommand(c, nil, command, "myk8s", "--cluster-name", "mrcloud2", "-c", "foo")
+	c.Assert(err, gc.ErrorMatches, `(?s).*"disk" provisioner.
+	The cluster has these storage classes: fast, slow.
+`)
+}
+
+func (s *addCAASSuite) TestClusterReadinessReport(c *gc.C) {
+	s.clusterReadiness = &k8s.ClusterReadiness{
+		StorageClasses:      []string{"fast", "slow"},
+		DefaultStorageClass: "fast",
+		MissingPermissions:  []string{"create namespaces"},
+	}
+
+	command := s.makeCommand(c, true, false, true)
+	ctx, err := s.runCommand(c, nil, command, "myk8s", "--cluster-name", "mrcloud2", "-c", "foo", "--skip-storage")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+Cluster readiness:
+  storage classes: fast (default), slow
+  ingress classes: none, applications can't be exposed by ingress until an ingress controller is installed
+  permissions: missing create namespaces
+`[1:])
+	c.Assert(c.GetTestLog(), jc.Contains, "The credential does not have permission to create namespaces.")
+}
+
 func (s *addCAASSuite) TestUnknownClusterExistingStorageClass(c *gc.C) {
 	s.fakeCloudAPI.isCloudRegionRequired = true
 	cloudRegion := "gce/us-east1"
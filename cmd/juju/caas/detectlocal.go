@@ -0,0 +1,332 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caas
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+
+	jujuclock "github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	k8s "github.com/juju/juju/caas/kubernetes"
+	"github.com/juju/juju/caas/kubernetes/clientconfig"
+	"github.com/juju/juju/caas/kubernetes/provider"
+	k8sconstants "github.com/juju/juju/caas/kubernetes/provider/constants"
+	jujucloud "github.com/juju/juju/cloud"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+// localClusterStorageClass is the storage class which kind and
+// minikube clusters provide by default.
+const localClusterStorageClass = "standard"
+
+var usageDetectLocalK8sSummary = `
+Detects local k8s clusters and registers them as clouds.`[1:]
+
+var usageDetectLocalK8sDetails = `
+Looks for Kubernetes clusters running on this machine and offers to
+register each one as a k8s cloud on this client, with the endpoint and
+credential needed to use it.
+
+Clusters created by kind and minikube are found in the kubeconfig file
+($KUBECONFIG or $HOME/.kube/config), and are registered using the name
+of their kubeconfig context. MicroK8s is always available as the
+built-in "microk8s" cloud, so only its credential is checked.
+
+Local clusters get new certificates when they are recreated or their
+certificates are rotated. Running this command again detects when a
+registered cluster's endpoint, certificates or credential no longer
+match, and offers to refresh them.
+
+Use --yes to register and refresh clusters without prompting.
+
+Examples:
+    juju detect-local-k8s
+    juju detect-local-k8s --yes
+
+See also:
+    add-k8s
+    update-k8s
+    remove-k8s
+`
+
+// DetectLocalK8sCommand is the command that registers local k8s
+// clusters as clouds.
+type DetectLocalK8sCommand struct {
+	modelcmd.CommandBase
+
+	// assumeYes registers and refreshes clusters without prompting.
+	assumeYes bool
+
+	clock jujuclock.Clock
+
+	// readKubeConfig returns the content of the kubeconfig file.
+	readKubeConfig func() ([]byte, error)
+
+	// builtInCloudsFunc is used to provide any built in clouds and their credential.
+	builtInCloudsFunc func(string) (jujucloud.Cloud, *jujucloud.Credential, string, error)
+
+	store                 credentialGetter
+	cloudMetadataStore    CloudMetadataStore
+	credentialStoreAPI    CredentialStoreAPI
+	newClientConfigReader func(string) (clientconfig.ClientConfigFunc, error)
+	credentialUIDGetter   func(credentialGetter, string, string) (string, error)
+}
+
+// NewDetectLocalK8sCommand returns a command to register local k8s
+// clusters as clouds.
+func NewDetectLocalK8sCommand(cloudMetadataStore CloudMetadataStore) cmd.Command {
+	store := jujuclient.NewFileClientStore()
+	command := &DetectLocalK8sCommand{
+		clock: jujuclock.WallClock,
+		readKubeConfig: func() ([]byte, error) {
+			return ioutil.ReadFile(clientconfig.GetKubeConfigPath())
+		},
+		builtInCloudsFunc:  maybeBuiltInCloud,
+		store:              store,
+		cloudMetadataStore: cloudMetadataStore,
+		credentialStoreAPI: store,
+		newClientConfigReader: func(caasType string) (clientconfig.ClientConfigFunc, error) {
+			return clientconfig.NewClientConfigReader(caasType)
+		},
+		credentialUIDGetter: decideCredentialUID,
+	}
+	return modelcmd.WrapBase(command)
+}
+
+// Info returns help information about the command.
+func (c *DetectLocalK8sCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "detect-local-k8s",
+		Purpose: usageDetectLocalK8sSummary,
+		Doc:     usageDetectLocalK8sDetails,
+	})
+}
+
+// SetFlags initializes the flags supported by the command.
+func (c *DetectLocalK8sCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.BoolVar(&c.assumeYes, "y", false, "Do not prompt for confirmation")
+	f.BoolVar(&c.assumeYes, "yes", false, "")
+}
+
+// Init populates the command with the args from the command line.
+func (c *DetectLocalK8sCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run is defined on the Command interface.
+func (c *DetectLocalK8sCommand) Run(ctx *cmd.Context) error {
+	scanner := bufio.NewScanner(ctx.Stdin)
+	confirm := func(format string, args ...interface{}) (bool, error) {
+		if c.assumeYes {
+			return true, nil
+		}
+		fmt.Fprintf(ctx.Stdout, format+" (y/N): ", args...)
+		if !scanner.Scan() {
+			return false, errors.Trace(scanner.Err())
+		}
+		answer := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		return answer == "y" || answer == "yes", nil
+	}
+
+	found, err := c.checkMicroK8s(ctx, confirm)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	content, err := c.readKubeConfig()
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Annotate(err, "reading Kubernetes config")
+	}
+	var clusters []clientconfig.LocalCluster
+	if len(content) > 0 {
+		if clusters, err = clientconfig.LocalClusters(content); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if len(clusters) == 0 && !found {
+		ctx.Infof("No local k8s clusters found.")
+		return nil
+	}
+
+	personalClouds, err := c.cloudMetadataStore.PersonalCloudMetadata()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, cluster := range clusters {
+		if err := c.registerCluster(ctx, confirm, content, cluster, personalClouds); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// checkMicroK8s reports whether MicroK8s is available as a built-in
+// cloud, and offers to refresh its credential on this client if the
+// stored one no longer matches.
+func (c *DetectLocalK8sCommand) checkMicroK8s(
+	ctx *cmd.Context, confirm func(string, ...interface{}) (bool, error),
+) (bool, error) {
+	cloudName := k8s.K8sCloudMicrok8s
+	_, credential, credentialName, err := c.builtInCloudsFunc(cloudName)
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	ctx.Infof("Found microk8s cluster, available as built-in k8s cloud %q.", cloudName)
+	if credential == nil {
+		return true, nil
+	}
+	existing, err := getExistingLocalCredential(c.store, cloudName, credentialName)
+	if errors.IsNotFound(err) {
+		// The credential is detected whenever it is needed.
+		return true, nil
+	}
+	if err != nil {
+		return true, errors.Trace(err)
+	}
+	if sameCredential(existing, *credential) {
+		return true, nil
+	}
+	ok, err := confirm("The microk8s credentials have changed. Refresh credential %q?", credentialName)
+	if err != nil || !ok {
+		return true, errors.Trace(err)
+	}
+	if err := c.updateCredential(cloudName, credentialName, *credential); err != nil {
+		return true, errors.Trace(err)
+	}
+	ctx.Infof("Refreshed credential %q for k8s cloud %q.", credentialName, cloudName)
+	return true, nil
+}
+
+// registerCluster registers the cluster as a k8s cloud, or refreshes
+// the cloud and its credential if they no longer match the cluster.
+func (c *DetectLocalK8sCommand) registerCluster(
+	ctx *cmd.Context, confirm func(string, ...interface{}) (bool, error),
+	content []byte, cluster clientconfig.LocalCluster, personalClouds map[string]jujucloud.Cloud,
+) error {
+	cloudName := cluster.ContextName
+	if !names.IsValidCloud(cloudName) {
+		ctx.Warningf("skipping %s cluster %q: not a valid cloud name", cluster.Tool, cloudName)
+		return nil
+	}
+	existingCloud, registered := personalClouds[cloudName]
+	if registered && existingCloud.Type != k8sconstants.CAASProviderType {
+		ctx.Warningf("skipping %s cluster %q: a %s cloud with that name already exists", cluster.Tool, cloudName, existingCloud.Type)
+		return nil
+	}
+
+	newCloud, credential, err := c.detectCluster(content, cloudName)
+	if err != nil {
+		ctx.Warningf("skipping %s cluster %q: %v", cluster.Tool, cloudName, err)
+		return nil
+	}
+
+	if !registered {
+		ok, err := confirm("Found %s cluster %q. Register it as k8s cloud %q?", cluster.Tool, cloudName, cloudName)
+		if err != nil || !ok {
+			return errors.Trace(err)
+		}
+		newCloud.Description = jujucloud.DefaultCloudDescription(newCloud.Type)
+		newCloud.Config = map[string]interface{}{
+			provider.WorkloadStorageKey: localClusterStorageClass,
+			provider.OperatorStorageKey: localClusterStorageClass,
+		}
+		if err := addCloudToLocal(c.cloudMetadataStore, newCloud); err != nil {
+			return errors.Trace(err)
+		}
+		if err := c.updateCredential(cloudName, cloudName, credential); err != nil {
+			return errors.Trace(err)
+		}
+		ctx.Infof("k8s cloud %q added to this client.", cloudName)
+		return nil
+	}
+
+	existingCredential, err := getExistingLocalCredential(c.store, cloudName, cloudName)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	cloudChanged := !sameClusterEndpoint(existingCloud, newCloud)
+	credentialChanged := err != nil || !sameCredential(existingCredential, credential)
+	if !cloudChanged && !credentialChanged {
+		ctx.Infof("%s cluster %q is already registered as k8s cloud %q.", cluster.Tool, cloudName, cloudName)
+		return nil
+	}
+	ok, err := confirm("The endpoint or certificates of %s cluster %q have changed. Refresh k8s cloud %q?", cluster.Tool, cloudName, cloudName)
+	if err != nil || !ok {
+		return errors.Trace(err)
+	}
+	if cloudChanged {
+		existingCloud.Endpoint = newCloud.Endpoint
+		existingCloud.CACertificates = newCloud.CACertificates
+		existingCloud.SkipTLSVerify = newCloud.SkipTLSVerify
+		existingCloud.AuthTypes = newCloud.AuthTypes
+		if err := addCloudToLocal(c.cloudMetadataStore, existingCloud); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if credentialChanged {
+		if err := c.updateCredential(cloudName, cloudName, credential); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	ctx.Infof("k8s cloud %q refreshed on this client.", cloudName)
+	return nil
+}
+
+// detectCluster returns the cloud and credential to use for the
+// cluster of the named kubeconfig context.
+func (c *DetectLocalK8sCommand) detectCluster(content []byte, contextName string) (jujucloud.Cloud, jujucloud.Credential, error) {
+	credentialUID, err := c.credentialUIDGetter(c.store, contextName, contextName)
+	if err != nil {
+		return jujucloud.Cloud{}, jujucloud.Credential{}, errors.Trace(err)
+	}
+	newCloud, credential, err := provider.CloudFromKubeConfig(bytes.NewReader(content), provider.KubeCloudParams{
+		ContextName:        contextName,
+		CloudName:          contextName,
+		CredentialUID:      credentialUID,
+		HostCloudRegion:    k8s.K8sCloudOther,
+		CaasType:           k8sconstants.CAASProviderType,
+		ClientConfigGetter: c.newClientConfigReader,
+		Clock:              c.clock,
+	})
+	if err != nil {
+		return jujucloud.Cloud{}, jujucloud.Credential{}, errors.Trace(err)
+	}
+	credential, err = ensureCredentialUID(contextName, credentialUID, credential)
+	if err != nil {
+		return jujucloud.Cloud{}, jujucloud.Credential{}, errors.Trace(err)
+	}
+	return newCloud, credential, nil
+}
+
+func (c *DetectLocalK8sCommand) updateCredential(cloudName, credentialName string, credential jujucloud.Credential) error {
+	return c.credentialStoreAPI.UpdateCredential(cloudName, jujucloud.CloudCredential{
+		AuthCredentials: map[string]jujucloud.Credential{credentialName: credential},
+	})
+}
+
+func sameClusterEndpoint(a, b jujucloud.Cloud) bool {
+	return a.Endpoint == b.Endpoint &&
+		a.SkipTLSVerify == b.SkipTLSVerify &&
+		reflect.DeepEqual(a.CACertificates, b.CACertificates)
+}
+
+func sameCredential(a, b jujucloud.Credential) bool {
+	return a.AuthType() == b.AuthType() && reflect.DeepEqual(a.Attributes(), b.Attributes())
+}
@@ -0,0 +1,233 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caas_test
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/cmd/juju/caas"
+	"github.com/juju/juju/jujuclient"
+)
+
+type detectLocalK8sSuite struct {
+	jujutesting.IsolationSuite
+
+	cloudStore      *fakePersonalCloudStore
+	credentialStore *fakeCredentialStore
+	store           *jujuclient.MemStore
+	microk8s        *cloud.Credential
+}
+
+var _ = gc.Suite(&detectLocalK8sSuite{})
+
+var localKubeConfigStr = `
+apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://127.0.0.1:40123
+    certificate-authority-data: QQ==
+  name: kind-dev
+- cluster:
+    server: https://1.1.1.1:8888
+    certificate-authority-data: QQ==
+  name: the-cluster
+contexts:
+- context:
+    cluster: kind-dev
+    user: kind-dev
+  name: kind-dev
+- context:
+    cluster: the-cluster
+    user: the-user
+  name: the-context
+current-context: the-context
+preferences: {}
+users:
+- name: kind-dev
+  user:
+    token: kind-token
+- name: the-user
+  user:
+    token: the-token
+`
+
+type fakePersonalCloudStore struct {
+	caas.CloudMetadataStore
+	clouds map[string]cloud.Cloud
+	writes int
+}
+
+func (f *fakePersonalCloudStore) PersonalCloudMetadata() (map[string]cloud.Cloud, error) {
+	clouds := make(map[string]cloud.Cloud)
+	for name, c := range f.clouds {
+		clouds[name] = c
+	}
+	return clouds, nil
+}
+
+func (f *fakePersonalCloudStore) WritePersonalCloudMetadata(clouds map[string]cloud.Cloud) error {
+	f.clouds = clouds
+	f.writes++
+	return nil
+}
+
+type fakeCredentialStore struct {
+	credentials map[string]cloud.CloudCredential
+}
+
+func (f *fakeCredentialStore) UpdateCredential(cloudName string, details cloud.CloudCredential) error {
+	f.credentials[cloudName] = details
+	return nil
+}
+
+func (s *detectLocalK8sSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.cloudStore = &fakePersonalCloudStore{clouds: make(map[string]cloud.Cloud)}
+	s.credentialStore = &fakeCredentialStore{credentials: make(map[string]cloud.CloudCredential)}
+	s.store = jujuclient.NewMemStore()
+	s.microk8s = nil
+}
+
+func (s *detectLocalK8sSuite) builtInClouds(cloudName string) (cloud.Cloud, *cloud.Credential, string, error) {
+	if s.microk8s == nil {
+		return cloud.Cloud{}, nil, "", errors.NotFoundf("built in cloud %q", cloudName)
+	}
+	return cloud.Cloud{Name: cloudName, Type: "kubernetes"}, s.microk8s, "microk8s", nil
+}
+
+func (s *detectLocalK8sSuite) runCommand(c *gc.C, kubeConfig, stdin string, args ...string) (*cmd.Context, error) {
+	command := caas.NewDetectLocalK8sCommandForTest(s.cloudStore, s.credentialStore, s.store, kubeConfig, s.builtInClouds)
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = strings.NewReader(stdin)
+	if err := cmdtesting.InitCommand(command, args); err != nil {
+		return ctx, err
+	}
+	return ctx, command.Run(ctx)
+}
+
+func (s *detectLocalK8sSuite) expectedCloud() cloud.Cloud {
+	return cloud.Cloud{
+		Name:            "kind-dev",
+		Type:            "kubernetes",
+		Description:     cloud.DefaultCloudDescription("kubernetes"),
+		HostCloudRegion: "other",
+		AuthTypes:       cloud.AuthTypes{cloud.OAuth2AuthType},
+		Endpoint:        "https://127.0.0.1:40123",
+		CACertificates:  []string{"A"},
+		Config: map[string]interface{}{
+			"workload-storage": "standard",
+			"operator-storage": "standard",
+		},
+	}
+}
+
+func (s *detectLocalK8sSuite) expectedCredential(token string) cloud.Credential {
+	return cloud.NewNamedCredential("kind-dev", cloud.OAuth2AuthType, map[string]string{
+		"Token":   token,
+		"rbac-id": "9baa5e46",
+	}, false)
+}
+
+func (s *detectLocalK8sSuite) TestExtraArg(c *gc.C) {
+	_, err := s.runCommand(c, localKubeConfigStr, "", "kind-dev")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["kind-dev"\]`)
+}
+
+func (s *detectLocalK8sSuite) TestNoClusters(c *gc.C) {
+	ctx, err := s.runCommand(c, "", "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No local k8s clusters found.\n")
+	c.Assert(s.cloudStore.writes, gc.Equals, 0)
+}
+
+func (s *detectLocalK8sSuite) TestRegisterCluster(c *gc.C) {
+	ctx, err := s.runCommand(c, localKubeConfigStr, "y\n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals,
+		`Found kind cluster "kind-dev". Register it as k8s cloud "kind-dev"? (y/N): `)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "k8s cloud \"kind-dev\" added to this client.\n")
+	c.Assert(s.cloudStore.clouds, jc.DeepEquals, map[string]cloud.Cloud{"kind-dev": s.expectedCloud()})
+	c.Assert(s.credentialStore.credentials, jc.DeepEquals, map[string]cloud.CloudCredential{
+		"kind-dev": {AuthCredentials: map[string]cloud.Credential{"kind-dev": s.expectedCredential("kind-token")}},
+	})
+}
+
+func (s *detectLocalK8sSuite) TestRegisterClusterDeclined(c *gc.C) {
+	_, err := s.runCommand(c, localKubeConfigStr, "n\n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.cloudStore.writes, gc.Equals, 0)
+	c.Assert(s.credentialStore.credentials, gc.HasLen, 0)
+}
+
+func (s *detectLocalK8sSuite) TestAlreadyRegistered(c *gc.C) {
+	s.cloudStore.clouds["kind-dev"] = s.expectedCloud()
+	s.store.Credentials["kind-dev"] = cloud.CloudCredential{
+		AuthCredentials: map[string]cloud.Credential{"kind-dev": s.expectedCredential("kind-token")},
+	}
+	ctx, err := s.runCommand(c, localKubeConfigStr, "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "kind cluster \"kind-dev\" is already registered as k8s cloud \"kind-dev\".\n")
+	c.Assert(s.cloudStore.writes, gc.Equals, 0)
+	c.Assert(s.credentialStore.credentials, gc.HasLen, 0)
+}
+
+func (s *detectLocalK8sSuite) TestRefreshRotatedCertificates(c *gc.C) {
+	existing := s.expectedCloud()
+	existing.CACertificates = []string{"old-ca"}
+	existing.Config = map[string]interface{}{"workload-storage": "custom"}
+	s.cloudStore.clouds["kind-dev"] = existing
+	s.store.Credentials["kind-dev"] = cloud.CloudCredential{
+		AuthCredentials: map[string]cloud.Credential{"kind-dev": s.expectedCredential("old-token")},
+	}
+	ctx, err := s.runCommand(c, localKubeConfigStr, "", "--yes")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "k8s cloud \"kind-dev\" refreshed on this client.\n")
+
+	// The user's cloud config is kept.
+	expected := s.expectedCloud()
+	expected.Config = map[string]interface{}{"workload-storage": "custom"}
+	c.Assert(s.cloudStore.clouds, jc.DeepEquals, map[string]cloud.Cloud{"kind-dev": expected})
+	c.Assert(s.credentialStore.credentials, jc.DeepEquals, map[string]cloud.CloudCredential{
+		"kind-dev": {AuthCredentials: map[string]cloud.Credential{"kind-dev": s.expectedCredential("kind-token")}},
+	})
+}
+
+func (s *detectLocalK8sSuite) TestNameClash(c *gc.C) {
+	s.cloudStore.clouds["kind-dev"] = cloud.Cloud{Name: "kind-dev", Type: "openstack"}
+	_, err := s.runCommand(c, localKubeConfigStr, "", "--yes")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(c.GetTestLog(), jc.Contains, `skipping kind cluster "kind-dev": a openstack cloud with that name already exists`)
+	c.Assert(s.cloudStore.writes, gc.Equals, 0)
+}
+
+func (s *detectLocalK8sSuite) TestMicroK8sCredentialRefreshed(c *gc.C) {
+	cred := cloud.NewNamedCredential("microk8s", cloud.OAuth2AuthType, map[string]string{"Token": "new-token"}, false)
+	s.microk8s = &cred
+	s.store.Credentials["microk8s"] = cloud.CloudCredential{
+		AuthCredentials: map[string]cloud.Credential{
+			"microk8s": cloud.NewNamedCredential("microk8s", cloud.OAuth2AuthType, map[string]string{"Token": "old-token"}, false),
+		},
+	}
+	ctx, err := s.runCommand(c, "", "yes\n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals,
+		`The microk8s credentials have changed. Refresh credential "microk8s"? (y/N): `)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, ""+
+		"Found microk8s cluster, available as built-in k8s cloud \"microk8s\".\n"+
+		"Refreshed credential \"microk8s\" for k8s cloud \"microk8s\".\n")
+	c.Assert(s.credentialStore.credentials, jc.DeepEquals, map[string]cloud.CloudCredential{
+		"microk8s": {AuthCredentials: map[string]cloud.Credential{"microk8s": cred}},
+	})
+}
@@ -6,6 +6,7 @@ package caas
 import (
 	"bytes"
 	"io"
+	"os"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -73,6 +74,43 @@ func NewUpdateCAASCommandForTest(
 	return command
 }
 
+func NewDetectLocalK8sCommandForTest(
+	cloudMetadataStore CloudMetadataStore,
+	credentialStoreAPI CredentialStoreAPI,
+	store jujuclient.ClientStore,
+	kubeConfig string,
+	builtInCloudsFunc func(string) (cloud.Cloud, *cloud.Credential, string, error),
+) cmd.Command {
+	command := &DetectLocalK8sCommand{
+		readKubeConfig: func() ([]byte, error) {
+			if kubeConfig == "" {
+				return nil, &os.PathError{Op: "open", Path: "config", Err: os.ErrNotExist}
+			}
+			return []byte(kubeConfig), nil
+		},
+		builtInCloudsFunc:  builtInCloudsFunc,
+		store:              store,
+		cloudMetadataStore: cloudMetadataStore,
+		credentialStoreAPI: credentialStoreAPI,
+		newClientConfigReader: func(caasType string) (clientconfig.ClientConfigFunc, error) {
+			readerFunc, err := clientconfig.NewClientConfigReader(caasType)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			// Don't try to set up a service account in the cluster.
+			return func(
+				credentialUID string, reader io.Reader,
+				contextName, clusterName string,
+				_ clientconfig.K8sCredentialResolver,
+			) (*clientconfig.ClientConfig, error) {
+				return readerFunc(credentialUID, reader, contextName, clusterName, nil)
+			}, nil
+		},
+		credentialUIDGetter: func(credentialGetter, string, string) (string, error) { return "9baa5e46", nil },
+	}
+	return modelcmd.WrapBase(command)
+}
+
 func NewRemoveCAASCommandForTest(
 	cloudMetadataStore CloudMetadataStore,
 	credentialStoreAPI credentialGetter,
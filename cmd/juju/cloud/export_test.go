@@ -29,6 +29,7 @@ type (
 var (
 	CredentialsFromLocalCache = credentialsFromLocalCache
 	CredentialsFromFile       = credentialsFromFile
+	CredentialsFromExec       = credentialsFromExec
 )
 
 func NewAddCloudCommandForTest(
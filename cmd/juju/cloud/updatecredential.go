@@ -4,8 +4,10 @@
 package cloud
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
+	"os/exec"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -50,6 +52,11 @@ Use --client to update a credential definition on this client.
 If a user will use a different client, say a different laptop, 
 the update will not affect that client's (laptop's) copy.
 
+Use --from-exec to get the credential details from a command rather than
+a file. The command is run by the shell and must print the same YAML that
+--file accepts. This allows credentials that are rotated by an external
+tool, such as short-lived tokens or certificates, to be updated by a script.
+
 Before credential is updated, the new content is validated. For some providers, 
 cloud credentials are region specific. To validate the credential for a non-default region, 
 use --region.
@@ -61,6 +68,7 @@ Examples:
     juju update-credential aws -f mine.yaml
     juju update-credential azure --region brazilsouth -f mine.yaml
     juju update-credential -f mine.yaml --controller mycontroller --force
+    juju update-credential aws --from-exec "vault-creds aws" --controller mycontroller
 
 See also: 
     add-credential
@@ -79,6 +87,9 @@ type updateCredentialCommand struct {
 	// CredentialsFile is the name of the file that contains credentials to update.
 	CredentialsFile string
 
+	// FromExec is a command whose output contains credentials to update.
+	FromExec string
+
 	// Region is the region that credentials will be validated for before an update.
 	Region string
 
@@ -103,6 +114,9 @@ func (c *updateCredentialCommand) Init(args []string) error {
 	if err := c.OptionalControllerCommand.Init(args); err != nil {
 		return err
 	}
+	if c.CredentialsFile != "" && c.FromExec != "" {
+		return errors.New("only one of --file and --from-exec can be specified")
+	}
 	argsCount := len(args)
 	if argsCount == 0 {
 		// We are either in the interactive mode or updating from a file.
@@ -136,6 +150,7 @@ func (c *updateCredentialCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.OptionalControllerCommand.SetFlags(f)
 	f.StringVar(&c.CredentialsFile, "f", "", "The YAML file containing credential details to update")
 	f.StringVar(&c.CredentialsFile, "file", "", "The YAML file containing credential details to update")
+	f.StringVar(&c.FromExec, "from-exec", "", "A command which prints YAML containing credential details to update")
 	f.StringVar(&c.Region, "region", "", "Cloud region that credential is valid for")
 	f.BoolVar(&c.Force, "force", false, "Force update controller side credential, ignore validation errors")
 }
@@ -159,7 +174,7 @@ func (c *updateCredentialCommand) getAPI() (CredentialAPI, error) {
 // Run implements Command.Run
 func (c *updateCredentialCommand) Run(ctx *cmd.Context) error {
 	// If no file and no cloud is provided, switch to interactive mode.
-	if c.CredentialsFile == "" && c.cloud == "" {
+	if c.CredentialsFile == "" && c.FromExec == "" && c.cloud == "" {
 		// TODO (anastasiamac 2019-03-22) interactive mode
 		return errors.New("Usage: juju update-credential [options] [<cloud-name> [<credential-name>]]")
 	}
@@ -170,6 +185,11 @@ func (c *updateCredentialCommand) Run(ctx *cmd.Context) error {
 		if err != nil {
 			return errors.Annotatef(err, "could not get credentials from file")
 		}
+	} else if c.FromExec != "" {
+		credentials, err = credentialsFromExec(ctx, c.FromExec, c.cloud, c.credential)
+		if err != nil {
+			return errors.Annotatef(err, "could not get credentials from command")
+		}
 	} else {
 		credentials, err = credentialsFromLocalCache(c.Store, c.cloud, c.credential)
 		if err != nil {
@@ -202,7 +222,33 @@ func credentialsFromFile(credentialsFile, cloudName, credentialName string) (map
 	if err != nil {
 		return nil, errors.Annotate(err, "parsing credentials file")
 	}
+	return filterCredentials(specifiedCredentials, cloudName, credentialName, fmt.Sprintf("file %q", credentialsFile))
+}
+
+// credentialsFromExec runs the command with the shell and parses its
+// output as a credentials file. The command's stderr is passed through.
+func credentialsFromExec(ctx *cmd.Context, command, cloudName, credentialName string) (map[string]jujucloud.CloudCredential, error) {
+	var stdout bytes.Buffer
+	execCmd := exec.Command("/bin/sh", "-c", command)
+	execCmd.Dir = ctx.Dir
+	execCmd.Stdout = &stdout
+	execCmd.Stderr = ctx.Stderr
+	if err := execCmd.Run(); err != nil {
+		return nil, errors.Annotatef(err, "running %q", command)
+	}
+	specifiedCredentials, err := jujucloud.ParseCredentials(stdout.Bytes())
+	if err != nil {
+		return nil, errors.Annotatef(err, "parsing output of %q", command)
+	}
+	return filterCredentials(specifiedCredentials, cloudName, credentialName, fmt.Sprintf("output of %q", command))
+}
 
+// filterCredentials returns the credentials for the given cloud and
+// credential name, if specified. The source describes where the
+// credentials came from, for errors.
+func filterCredentials(
+	specifiedCredentials map[string]jujucloud.CloudCredential, cloudName, credentialName, source string,
+) (map[string]jujucloud.CloudCredential, error) {
 	if cloudName == "" && credentialName == "" {
 		return specifiedCredentials, nil
 	}
@@ -211,7 +257,7 @@ func credentialsFromFile(credentialsFile, cloudName, credentialName string) (map
 	if cloudName != "" {
 		cloudCredentials, ok := specifiedCredentials[cloudName]
 		if !ok {
-			return nil, errors.NotFoundf("credentials for cloud %q in file %q", cloudName, credentialsFile)
+			return nil, errors.NotFoundf("credentials for cloud %q in %s", cloudName, source)
 		}
 		filteredByCloud[cloudName] = cloudCredentials
 	} else {
@@ -234,7 +280,7 @@ func credentialsFromFile(credentialsFile, cloudName, credentialName string) (map
 	}
 
 	if len(filteredByName) == 0 {
-		return nil, errors.NotFoundf("credential %q for cloud %q in file %s", credentialName, cloudName, credentialsFile)
+		return nil, errors.NotFoundf("credential %q for cloud %q in %s", credentialName, cloudName, source)
 	}
 	return filteredByName, nil
 }
@@ -207,6 +207,47 @@ credentials:
 	c.Assert(result, gc.IsNil)
 }
 
+func (s *updateCredentialSuite) TestFromExecWithCloudAndCredential(c *gc.C) {
+	testFile := s.makeCredentialsTestFile(c, `
+credentials:
+  somecloud:
+    its-credential:
+      auth-type: interactive
+      trust-password: "123"
+    its-other-credential:
+      auth-type: interactive
+      trust-password: "456"
+`)
+	ctx := cmdtesting.Context(c)
+	result, err := cloud.CredentialsFromExec(ctx, "cat "+testFile, "somecloud", "its-credential")
+	c.Assert(err, jc.ErrorIsNil)
+
+	one := jujucloud.NewCredential(jujucloud.InteractiveAuthType, map[string]string{"trust-password": "123"})
+	assertFoundCredentials(c, result, map[string]map[string]jujucloud.Credential{
+		"somecloud": {"its-credential": one},
+	})
+}
+
+func (s *updateCredentialSuite) TestFromExecNoDesiredCloud(c *gc.C) {
+	ctx := cmdtesting.Context(c)
+	result, err := cloud.CredentialsFromExec(ctx, `printf 'credentials:\n  anothercloud:\n    cred:\n      auth-type: empty\n'`, "somecloud", "")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `credentials for cloud "somecloud" in output of .* not found`)
+	c.Assert(result, gc.IsNil)
+}
+
+func (s *updateCredentialSuite) TestFromExecFails(c *gc.C) {
+	ctx := cmdtesting.Context(c)
+	_, err := cloud.CredentialsFromExec(ctx, "echo oops >&2; exit 3", "", "")
+	c.Assert(err, gc.ErrorMatches, `running "echo oops >&2; exit 3": exit status 3`)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "oops\n")
+}
+
+func (s *updateCredentialSuite) TestFileAndFromExec(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.testCommand, "-f", "somefile.yaml", "--from-exec", "cat somefile.yaml")
+	c.Assert(err, gc.ErrorMatches, `only one of --file and --from-exec can be specified`)
+}
+
 func (s *updateCredentialSuite) TestCloudCredentialFromLocalCacheNoneFound(c *gc.C) {
 	result, err := cloud.CredentialsFromLocalCache(s.store, "anothercloud", "its-credential")
 	c.Assert(err, gc.ErrorMatches, `loading credentials: credentials for cloud anothercloud not found`)
@@ -7,6 +7,7 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path"
 	"sort"
@@ -43,6 +44,7 @@ import (
 	"github.com/juju/juju/core/series"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/bootstrap"
+	"github.com/juju/juju/environs/bootstrapbundle"
 	environscloudspec "github.com/juju/juju/environs/cloudspec"
 	"github.com/juju/juju/environs/config"
 	envcontext "github.com/juju/juju/environs/context"
@@ -114,9 +116,40 @@ An error is emitted if the determined series is not supported. Using the
 
 	juju bootstrap --bootstrap-series=focal --force
 
+Labs without a cloud API can bootstrap onto an existing machine, reachable
+over SSH, by using a manual cloud. Supplying '--adopt' first checks that the
+machine is suitable for hosting a controller: it must use systemd, must not
+already be running a mongod, and must have the controller's API and state
+ports free. All problems found are reported before anything is installed:
+
+	juju bootstrap manual/ubuntu@10.0.0.1 --adopt
+
+Further existing machines may then be added to the controller model and
+made controllers, to make the controller highly available:
+
+	juju add-machine -m controller ssh:ubuntu@10.0.0.2
+	juju add-machine -m controller ssh:ubuntu@10.0.0.3
+	juju enable-ha --to 1,2
+
 Private clouds may need to specify their own custom image metadata and
 tools/agent. Use '--metadata-source' whose value is a local directory.
 
+Controllers without access to the internet can be bootstrapped from a
+bootstrap bundle made with create-bootstrap-bundle, which holds the agent
+binaries, the Juju Dashboard and optionally the juju-db snap, controller
+charm and container images. Use '--bootstrap-bundle' whose value is the
+bundle's path. LXD images in the bundle are added to the LXD image cache of
+LXD clouds, and OCI images are pushed to the registry set with the
+caas-image-repo controller config of k8s clouds.
+
+Site-specific setup, such as installing a monitoring agent or setting kernel
+parameters, can be applied to each controller machine with
+'--controller-customization', whose value is the path of a script. The script
+is run once on each controller machine after it comes up, including those
+added later by enable-ha, and again whenever the controller's
+controller-customization-script config changes. Its progress is shown by
+show-controller. Customization scripts are not run on k8s controllers.
+
 By default, the Juju version of the agent binary that is downloaded and
 installed on all models for the new controller will be the same as that
 of the Juju client used to perform the bootstrap.
@@ -177,6 +210,8 @@ Examples:
     juju bootstrap --agent-version=2.2.4 aws joe-us-east-1
     juju bootstrap --config bootstrap-timeout=1200 azure joe-eastus
     juju bootstrap aws --storage-pool name=secret --storage-pool type=ebs --storage-pool encrypted=true
+    juju bootstrap --bootstrap-bundle=./bootstrap-bundle.tar.gz maas
+    juju bootstrap --controller-customization=./setup-monitoring.sh aws
 
     # For a bootstrap on k8s, setting the service type of the Juju controller service to LoadBalancer
     juju bootstrap --config controller-service-type=loadbalancer
@@ -188,6 +223,7 @@ See also:
     add-credentials
     add-model
     controller-config
+    create-bootstrap-bundle
     model-config
     set-constraints
     show-cloud`
@@ -225,6 +261,7 @@ type bootstrapCommand struct {
 	JujuDbSnapPath           string
 	JujuDbSnapAssertionsPath string
 	MetadataSource           string
+	BootstrapBundle          string
 	Placement                string
 	KeepBrokenEnvironment    bool
 	AutoUpgrade              bool
@@ -249,8 +286,26 @@ type bootstrapCommand struct {
 
 	ControllerCharmPath string
 
+	// ControllerCustomizationPath is the path of a script to be run on
+	// each controller machine.
+	ControllerCustomizationPath string
+
+	// dashboardArchivePath is the path of the Juju Dashboard archive
+	// extracted from the bootstrap bundle, if any.
+	dashboardArchivePath string
+
+	// lxdImagePaths and ociImagePaths are the paths of the LXD and OCI
+	// images extracted from the bootstrap bundle, if any.
+	lxdImagePaths map[string]string
+	ociImagePaths map[string]string
+
 	// Force is used to allow a bootstrap to be run on unsupported series.
 	Force bool
+
+	// Adopt indicates that the controller is being bootstrapped onto an
+	// existing machine of a manual cloud, which should first be checked
+	// for its suitability.
+	Adopt bool
 }
 
 func (c *bootstrapCommand) Info() *cmd.Info {
@@ -312,6 +367,7 @@ func (c *bootstrapCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.JujuDbSnapPath, "db-snap", "", "Path to a locally built .snap to use as the internal juju-db service.")
 	f.StringVar(&c.JujuDbSnapAssertionsPath, "db-snap-asserts", "", "Path to a local .assert file. Requires --db-snap")
 	f.StringVar(&c.MetadataSource, "metadata-source", "", "Local path to use as agent and/or image metadata source")
+	f.StringVar(&c.BootstrapBundle, "bootstrap-bundle", "", "Path to a bootstrap bundle holding the agent binaries and other assets to bootstrap with")
 	f.StringVar(&c.Placement, "to", "", "Placement directive indicating an instance to bootstrap")
 	f.BoolVar(&c.KeepBrokenEnvironment, "keep-broken", false, "Do not destroy the model if bootstrap fails")
 	f.BoolVar(&c.AutoUpgrade, "auto-upgrade", false, "After bootstrap, upgrade to the latest patch release")
@@ -327,11 +383,23 @@ func (c *bootstrapCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.noDashboard, "no-dashboard", false, "Do not install the Juju Dashboard in the controller when bootstrapping")
 	f.BoolVar(&c.noSwitch, "no-switch", false, "Do not switch to the newly created controller")
 	f.BoolVar(&c.Force, "force", false, "Allow the bypassing of checks such as supported series")
+	f.BoolVar(&c.Adopt, "adopt", false, "Check that an existing machine of a manual cloud is suitable for hosting the controller before using it")
 	f.BoolVar(&c.noHostedModel, "no-default-model", false, "Do not create a default model")
 	f.StringVar(&c.ControllerCharmPath, "controller-charm", "", "Path to a locally built controller charm")
+	f.StringVar(&c.ControllerCustomizationPath, "controller-customization", "", "Path to a script to run on each controller machine")
 }
 
 func (c *bootstrapCommand) Init(args []string) (err error) {
+	if c.BootstrapBundle != "" {
+		if c.MetadataSource != "" || c.JujuDbSnapPath != "" || c.ControllerCharmPath != "" || c.BuildAgent {
+			return errors.New("--bootstrap-bundle can't be used with --metadata-source, --db-snap, --controller-charm or --build-agent")
+		}
+		_, err := c.Filesystem().Stat(c.BootstrapBundle)
+		if err != nil {
+			return errors.Annotatef(err, "problem with --bootstrap-bundle")
+		}
+	}
+
 	if c.JujuDbSnapPath != "" {
 		_, err := c.Filesystem().Stat(c.JujuDbSnapPath)
 		if err != nil {
@@ -371,6 +439,13 @@ func (c *bootstrapCommand) Init(args []string) (err error) {
 		}
 	}
 
+	if c.ControllerCustomizationPath != "" {
+		_, err := c.Filesystem().Stat(c.ControllerCustomizationPath)
+		if err != nil {
+			return errors.Annotatef(err, "problem with --controller-customization")
+		}
+	}
+
 	if c.showClouds && c.showRegionsForCloud != "" {
 		return errors.New("--clouds and --regions can't be used together")
 	}
@@ -632,10 +707,21 @@ to create a new model to deploy %sworkloads.
 		// now run normal bootstrap using info gained above.
 	}
 
+	if c.BootstrapBundle != "" {
+		cleanup, err := c.extractBootstrapBundle(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer cleanup()
+	}
+
 	cloud, provider, err := c.cloud(ctx)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if c.Adopt && cloud.Type != "manual" {
+		return errors.Errorf("--adopt is only supported for manual clouds, not %q", cloud.Type)
+	}
 
 	// If region is specified by the user, validate it here.
 	// lp#1632735
@@ -721,6 +807,10 @@ to create a new model to deploy %sworkloads.
 		return errors.NewNotValid(nil, "controller name cannot be set via config, please use cmd args")
 	}
 
+	if err := c.checkExternalMongo(bootstrapCfg.controller, isCAASController); err != nil {
+		return errors.Trace(err)
+	}
+
 	// Read existing current controller so we can clean up on error.
 	var oldCurrentController string
 	store := c.ClientStore()
@@ -847,12 +937,15 @@ to create a new model to deploy %sworkloads.
 		JujuDbSnapAssertionsPath:  c.JujuDbSnapAssertionsPath,
 		StoragePools:              bootstrapCfg.storagePools,
 		ControllerCharmPath:       c.ControllerCharmPath,
+		LXDImagePaths:             c.lxdImagePaths,
+		OCIImagePaths:             c.ociImagePaths,
 		DialOpts: environs.BootstrapDialOpts{
 			Timeout:        bootstrapCfg.bootstrap.BootstrapTimeout,
 			RetryDelay:     bootstrapCfg.bootstrap.BootstrapRetryDelay,
 			AddressesDelay: bootstrapCfg.bootstrap.BootstrapAddressesDelay,
 		},
-		Force: c.Force,
+		Force:                c.Force,
+		AdoptExistingMachine: c.Adopt,
 	}
 
 	hostedModel, err = c.initializeHostedModel(
@@ -944,8 +1037,13 @@ See `[1:] + "`juju kill-controller`" + `.`)
 
 	// Check whether the Juju Dashboard must be installed in the controller.
 	// Leaving this value empty means no Dashboard will be installed.
+	// A bootstrap bundle is used where there is no access to simplestreams,
+	// so the Dashboard is only installed if the bundle has one.
 	if !c.noDashboard {
-		bootstrapParams.DashboardDataSourceBaseURL = common.DashboardDataSourceBaseURL()
+		if c.BootstrapBundle == "" {
+			bootstrapParams.DashboardDataSourceBaseURL = common.DashboardDataSourceBaseURL()
+		}
+		bootstrapParams.DashboardArchivePath = c.dashboardArchivePath
 	}
 
 	if credentials.name == "" {
@@ -1015,6 +1113,49 @@ See `[1:] + "`juju kill-controller`" + `.`)
 	)
 }
 
+// extractBootstrapBundle extracts the bootstrap bundle to a temporary
+// directory, and uses its contents in place of the --metadata-source,
+// --db-snap and --controller-charm arguments. The returned function
+// removes the directory.
+func (c *bootstrapCommand) extractBootstrapBundle(ctx *cmd.Context) (func(), error) {
+	f, err := os.Open(ctx.AbsPath(c.BootstrapBundle))
+	if err != nil {
+		return nil, errors.Annotate(err, "opening bootstrap bundle")
+	}
+	defer func() { _ = f.Close() }()
+
+	dir, err := ioutil.TempDir("", "juju-bootstrap-bundle")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cleanup := func() {
+		if err := os.RemoveAll(dir); err != nil {
+			logger.Warningf("cannot remove bootstrap bundle directory %q: %v", dir, err)
+		}
+	}
+
+	ctx.Infof("Extracting bootstrap bundle %s", c.BootstrapBundle)
+	bundle, err := bootstrapbundle.Extract(f, dir)
+	if err != nil {
+		cleanup()
+		return nil, errors.Annotate(err, "extracting bootstrap bundle")
+	}
+	bundleVersion := bundle.Manifest.AgentVersion
+	if c.AgentVersionParam != "" && c.AgentVersion.Compare(bundleVersion) != 0 {
+		cleanup()
+		return nil, errors.Errorf("--agent-version %s does not match the bootstrap bundle's agent version %s", c.AgentVersion, bundleVersion)
+	}
+	c.AgentVersion = &bundleVersion
+	c.MetadataSource = bundle.MetadataDir()
+	c.JujuDbSnapPath = bundle.DBSnapPath()
+	c.JujuDbSnapAssertionsPath = bundle.DBSnapAssertionsPath()
+	c.ControllerCharmPath = bundle.ControllerCharmPath()
+	c.dashboardArchivePath = bundle.DashboardPath()
+	c.lxdImagePaths = bundle.LXDImagePaths()
+	c.ociImagePaths = bundle.OCIImagePaths()
+	return cleanup, nil
+}
+
 func (c *bootstrapCommand) controllerDataRefresher(
 	environ environs.BootstrapEnviron,
 	cloudCallCtx *envcontext.CloudCallContext,
@@ -1485,6 +1626,18 @@ func (c *bootstrapCommand) bootstrapConfigs(
 		}
 	}
 
+	if c.ControllerCustomizationPath != "" {
+		if _, ok := controllerConfigAttrs[controller.ControllerCustomizationScript]; ok {
+			return bootstrapConfigs{}, errors.Errorf(
+				"--controller-customization can't be used with %q config", controller.ControllerCustomizationScript)
+		}
+		script, err := ioutil.ReadFile(ctx.AbsPath(c.ControllerCustomizationPath))
+		if err != nil {
+			return bootstrapConfigs{}, errors.Annotate(err, "reading controller customization script")
+		}
+		controllerConfigAttrs[controller.ControllerCustomizationScript] = string(script)
+	}
+
 	bootstrapConfig, err := bootstrap.NewConfig(bootstrapConfigAttrs)
 	if err != nil {
 		return bootstrapConfigs{}, errors.Annotate(err, "constructing bootstrap config")
@@ -1619,6 +1772,27 @@ func checkProviderType(envType string) error {
 	return nil
 }
 
+// checkExternalMongo ensures that a controller is only bootstrapped
+// against an externally managed replica set when the feature is enabled,
+// and the controller would otherwise run its own juju-db.
+func (c *bootstrapCommand) checkExternalMongo(cfg controller.Config, isCAAS bool) error {
+	if cfg.ExternalMongoURI() == "" {
+		return nil
+	}
+	featureflag.SetFlagsFromEnvironment(osenv.JujuFeatureFlagEnvKey)
+	if !featureflag.Enabled(feature.ExternalMongo) {
+		msg := `%s is experimental in this version of Juju. To use it anyway, set JUJU_DEV_FEATURE_FLAGS="%s" in your shell model`
+		return errors.Errorf(msg, controller.ExternalMongoURI, feature.ExternalMongo)
+	}
+	if isCAAS {
+		return errors.NotSupportedf("%s for kubernetes controllers", controller.ExternalMongoURI)
+	}
+	if c.JujuDbSnapPath != "" {
+		return errors.Errorf("--db-snap cannot be used with %s", controller.ExternalMongoURI)
+	}
+	return nil
+}
+
 // handleBootstrapError is called to clean up if bootstrap fails.
 func handleBootstrapError(ctx *cmd.Context, cleanup func() error) {
 	ch := make(chan os.Signal, 1)
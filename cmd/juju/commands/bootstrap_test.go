@@ -34,11 +34,13 @@ import (
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/cmd/cmdtest"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/bootstrap"
+	"github.com/juju/juju/environs/bootstrapbundle"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/environs/dashboard"
@@ -51,6 +53,7 @@ import (
 	envtesting "github.com/juju/juju/environs/testing"
 	envtools "github.com/juju/juju/environs/tools"
 	toolstesting "github.com/juju/juju/environs/tools/testing"
+	"github.com/juju/juju/feature"
 	"github.com/juju/juju/juju/keys"
 	"github.com/juju/juju/juju/osenv"
 	jujutesting "github.com/juju/juju/juju/testing"
@@ -511,6 +514,29 @@ func (s *BootstrapSuite) TestCheckProviderProvisional(c *gc.C) {
 	}
 }
 
+func (s *BootstrapSuite) TestCheckExternalMongo(c *gc.C) {
+	command := &bootstrapCommand{}
+	err := command.checkExternalMongo(controller.Config{}, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg := controller.Config{
+		controller.ExternalMongoURI: "mongodb://db.example.com/?replicaSet=rs0",
+	}
+	s.PatchEnvironment(osenv.JujuFeatureFlagEnvKey, "")
+	err = command.checkExternalMongo(cfg, false)
+	c.Assert(err, gc.ErrorMatches, `external-mongo-uri is experimental .* set JUJU_DEV_FEATURE_FLAGS="external-mongo".*`)
+
+	s.PatchEnvironment(osenv.JujuFeatureFlagEnvKey, feature.ExternalMongo)
+	err = command.checkExternalMongo(cfg, false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = command.checkExternalMongo(cfg, true)
+	c.Assert(err, gc.ErrorMatches, `external-mongo-uri for kubernetes controllers not supported`)
+
+	command.JujuDbSnapPath = "/path/to/juju-db.snap"
+	err = command.checkExternalMongo(cfg, false)
+	c.Assert(err, gc.ErrorMatches, `--db-snap cannot be used with external-mongo-uri`)
+}
+
 func (s *BootstrapSuite) TestBootstrapTwice(c *gc.C) {
 	const controllerName = "dev"
 	s.setupAutoUploadTest(c, "1.8.3", "raring")
@@ -662,6 +688,19 @@ func (s *BootstrapSuite) TestBootstrapTimeout(c *gc.C) {
 	c.Assert(bootstrapFuncs.args.DialOpts.Timeout, gc.Equals, 99*time.Second)
 }
 
+func (s *BootstrapSuite) TestBootstrapAdoptRequiresManualCloud(c *gc.C) {
+	s.patchVersionAndSeries(c, "raring")
+
+	var bootstrapFuncs fakeBootstrapFuncs
+	s.PatchValue(&getBootstrapFuncs, func() BootstrapInterface {
+		return &bootstrapFuncs
+	})
+	_, err := cmdtesting.RunCommand(
+		c, s.newBootstrapCommand(), "dummy", "devcontroller", "--adopt",
+	)
+	c.Assert(err, gc.ErrorMatches, `--adopt is only supported for manual clouds, not "dummy"`)
+}
+
 func (s *BootstrapSuite) TestBootstrapAllSpacesAsConstraintsMerged(c *gc.C) {
 	s.patchVersionAndSeries(c, "raring")
 
@@ -2175,6 +2214,106 @@ func (s *BootstrapSuite) TestBootstrapWithControllerCharm(c *gc.C) {
 	}
 }
 
+func (s *BootstrapSuite) TestBootstrapWithBootstrapBundle(c *gc.C) {
+	src := c.MkDir()
+	for name, content := range map[string]string{
+		"metadata/tools/released/agent.tgz":  "agent",
+		bootstrapbundle.DashboardFile:        "dashboard",
+		bootstrapbundle.DBSnapFile:           "snap",
+		bootstrapbundle.DBSnapAssertionsFile: "assert",
+	} {
+		filename := filepath.Join(src, filepath.FromSlash(name))
+		err := os.MkdirAll(filepath.Dir(filename), 0755)
+		c.Assert(err, jc.ErrorIsNil)
+		err = ioutil.WriteFile(filename, []byte(content), 0644)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	bundlePath := filepath.Join(c.MkDir(), "bundle.tar.gz")
+	f, err := os.Create(bundlePath)
+	c.Assert(err, jc.ErrorIsNil)
+	err = bootstrapbundle.Create(f, src, bootstrapbundle.Manifest{AgentVersion: jujuversion.Current})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(f.Close(), jc.ErrorIsNil)
+
+	var gotArgs bootstrap.BootstrapParams
+	bootstrapFuncs := &fakeBootstrapFuncs{
+		bootstrapF: func(_ environs.BootstrapContext, _ environs.BootstrapEnviron, callCtx context.ProviderCallContext, args bootstrap.BootstrapParams) error {
+			gotArgs = args
+			data, err := ioutil.ReadFile(args.JujuDbSnapPath)
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(string(data), gc.Equals, "snap")
+			_, err = os.Stat(filepath.Join(args.MetadataDir, "tools", "released", "agent.tgz"))
+			c.Check(err, jc.ErrorIsNil)
+			return errors.New("test error")
+		},
+	}
+	s.PatchValue(&getBootstrapFuncs, func() BootstrapInterface {
+		return bootstrapFuncs
+	})
+	_, err = cmdtesting.RunCommand(c, s.newBootstrapCommand(),
+		"dummy", "devcontroller", "--bootstrap-bundle", bundlePath,
+	)
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(filepath.Base(gotArgs.MetadataDir), gc.Equals, bootstrapbundle.MetadataDir)
+	c.Assert(filepath.Base(gotArgs.JujuDbSnapAssertionsPath), gc.Equals, bootstrapbundle.DBSnapAssertionsFile)
+	c.Assert(filepath.Base(gotArgs.DashboardArchivePath), gc.Equals, bootstrapbundle.DashboardFile)
+	c.Assert(gotArgs.DashboardDataSourceBaseURL, gc.Equals, "")
+	c.Assert(gotArgs.ControllerCharmPath, gc.Equals, "")
+	c.Assert(*gotArgs.AgentVersion, gc.Equals, jujuversion.Current)
+
+	// The extracted bundle is removed once bootstrap completes.
+	_, err = os.Stat(gotArgs.MetadataDir)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *BootstrapSuite) TestBootstrapBundleConflicts(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newBootstrapCommand(),
+		"dummy", "devcontroller", "--bootstrap-bundle", "bundle.tar.gz", "--metadata-source", "/foo",
+	)
+	c.Assert(err, gc.ErrorMatches, "--bootstrap-bundle can't be used with --metadata-source, --db-snap, --controller-charm or --build-agent")
+}
+
+func (s *BootstrapSuite) TestBootstrapControllerCustomization(c *gc.C) {
+	s.patchVersionAndSeries(c, "raring")
+	var bootstrapFuncs fakeBootstrapFuncs
+	s.PatchValue(&getBootstrapFuncs, func() BootstrapInterface {
+		return &bootstrapFuncs
+	})
+	script := "#!/bin/sh\nsysctl -w vm.swappiness=10\n"
+	scriptPath := filepath.Join(c.MkDir(), "customize.sh")
+	err := ioutil.WriteFile(scriptPath, []byte(script), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = cmdtesting.RunCommand(
+		c, s.newBootstrapCommand(), "dummy", "ctrl",
+		"--controller-customization", scriptPath,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bootstrapFuncs.args.ControllerConfig.ControllerCustomizationScript(), gc.Equals, script)
+}
+
+func (s *BootstrapSuite) TestBootstrapControllerCustomizationConflicts(c *gc.C) {
+	s.patchVersionAndSeries(c, "raring")
+	scriptPath := filepath.Join(c.MkDir(), "customize.sh")
+	err := ioutil.WriteFile(scriptPath, []byte("true"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = cmdtesting.RunCommand(
+		c, s.newBootstrapCommand(), "dummy", "ctrl",
+		"--controller-customization", scriptPath,
+		"--config", "controller-customization-script=false",
+	)
+	c.Assert(err, gc.ErrorMatches, `--controller-customization can't be used with "controller-customization-script" config`)
+}
+
+func (s *BootstrapSuite) TestBootstrapControllerCustomizationMissing(c *gc.C) {
+	_, err := cmdtesting.RunCommand(
+		c, s.newBootstrapCommand(), "dummy", "ctrl",
+		"--controller-customization", "/no/such/customize.sh",
+	)
+	c.Assert(err, gc.ErrorMatches, "problem with --controller-customization: .*")
+}
+
 func (s *BootstrapSuite) TestBootstrapSetsControllerOnBase(c *gc.C) {
 	// This test ensures that the controller name is correctly set on
 	// on the bootstrap commands embedded ModelCommandBase. Without
@@ -0,0 +1,434 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/charm/v9"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/v2"
+	"github.com/juju/utils/v2/arch"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/cloudconfig/podcfg"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/environs/bootstrap"
+	"github.com/juju/juju/environs/bootstrapbundle"
+	"github.com/juju/juju/environs/dashboard"
+	"github.com/juju/juju/environs/filestorage"
+	"github.com/juju/juju/environs/storage"
+	"github.com/juju/juju/environs/sync"
+	envtools "github.com/juju/juju/environs/tools"
+	jujuversion "github.com/juju/juju/version"
+)
+
+// dashboardFetchMetadata is defined for testing purposes.
+var dashboardFetchMetadata = dashboard.FetchMetadata
+
+func newCreateBootstrapBundleCommand() cmd.Command {
+	return &createBootstrapBundleCommand{}
+}
+
+// createBootstrapBundleCommand writes a bootstrap bundle, holding
+// everything needed to bootstrap a controller without access to the
+// internet.
+type createBootstrapBundleCommand struct {
+	cmd.CommandBase
+
+	filename         string
+	versionStr       string
+	majorVersion     int
+	minorVersion     int
+	stream           string
+	source           string
+	imageMetadataDir string
+	dashboardPath    string
+	dashboardStream  string
+	noDashboard      bool
+	dbSnapPath       string
+	dbSnapAssertions string
+	controllerCharm  string
+	lxdImageArgs     []string
+	ociImageArgs     []string
+
+	// lxdImages and ociImages map the series/arch of each LXD image,
+	// and the name of each OCI image, to its local path.
+	lxdImages map[string]string
+	ociImages map[string]string
+}
+
+var _ cmd.Command = (*createBootstrapBundleCommand)(nil)
+
+const createBootstrapBundleDoc = `
+Writes a bootstrap bundle to the given file. A bootstrap bundle holds the
+agent binaries and other assets needed to bootstrap a controller without
+access to the internet, and is used with:
+
+    juju bootstrap --bootstrap-bundle=<file> ...
+
+The bundle holds the most recent agent binaries of the client's major.minor
+version, or of the version given with --version, and the most recent Juju
+Dashboard for that version. These are downloaded from the official stores,
+so the command must be run with access to the internet, or with the agent
+binaries and Dashboard given as local files.
+
+Custom image metadata, the juju-db snap and a controller charm may also be
+added to the bundle; bootstrap uses them as if given with --metadata-source,
+--db-snap and --controller-charm.
+
+LXD images for the controller machine may be added with --lxd-image, given
+as <series>/<arch>=<file> where the file is a unified image tarball written by
+"lxc image export". When bootstrapping to an LXD cloud they are added to the
+LXD image cache, so the controller machine's image need not be downloaded.
+
+OCI images for a k8s controller may be added with --oci-image, given as
+<name>=<file> where the name is jujud-operator or juju-db and the file is an
+OCI image layout archive, such as one written by
+"skopeo copy docker://<image> oci-archive:<file>". When bootstrapping to a k8s
+cloud they are pushed to the registry set with the caas-image-repo controller
+config, from which the controller's pods are run.
+
+Examples:
+    juju create-bootstrap-bundle bootstrap-bundle.tar.gz
+    juju create-bootstrap-bundle --version 2.9 --no-dashboard bootstrap-bundle.tar.gz
+    juju create-bootstrap-bundle --source=/home/ubuntu/agent-binaries --dashboard=./dashboard.tar.bz2 bootstrap-bundle.tar.gz
+    juju create-bootstrap-bundle --lxd-image focal/amd64=./focal.tar.gz bootstrap-bundle.tar.gz
+    juju create-bootstrap-bundle --oci-image jujud-operator=./jujud-operator.tar --oci-image juju-db=./juju-db.tar bootstrap-bundle.tar.gz
+
+See also:
+    bootstrap
+    sync-agent-binaries
+`
+
+func (c *createBootstrapBundleCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "create-bootstrap-bundle",
+		Args:    "<file>",
+		Purpose: "Write a bundle of the assets needed to bootstrap without internet access.",
+		Doc:     createBootstrapBundleDoc,
+	})
+}
+
+func (c *createBootstrapBundleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.versionStr, "version", "", "Include agent binaries of a specific major[.minor] version")
+	f.StringVar(&c.stream, "stream", "", "Simplestreams stream of the agent binaries")
+	f.StringVar(&c.source, "source", "", "Local source directory of the agent binaries")
+	f.StringVar(&c.imageMetadataDir, "image-metadata-dir", "", "Local directory of custom image metadata to include")
+	f.StringVar(&c.dashboardPath, "dashboard", "", "Path to a local Juju Dashboard archive to include")
+	f.StringVar(&c.dashboardStream, "dashboard-stream", "released", "Simplestreams stream of the Juju Dashboard")
+	f.BoolVar(&c.noDashboard, "no-dashboard", false, "Do not include the Juju Dashboard")
+	f.StringVar(&c.dbSnapPath, "db-snap", "", "Path to a locally built .snap to use as the internal juju-db service")
+	f.StringVar(&c.dbSnapAssertions, "db-snap-asserts", "", "Path to a local .assert file. Requires --db-snap")
+	f.StringVar(&c.controllerCharm, "controller-charm", "", "Path to a locally built controller charm")
+	f.Var(cmd.NewAppendStringsValue(&c.lxdImageArgs), "lxd-image", "LXD images to include, as <series>/<arch>=<file>")
+	f.Var(cmd.NewAppendStringsValue(&c.ociImageArgs), "oci-image", "OCI images to include, as jujud-operator=<file> or juju-db=<file>")
+}
+
+func (c *createBootstrapBundleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no bootstrap bundle file specified")
+	}
+	c.filename = args[0]
+	if c.versionStr != "" {
+		var err error
+		if c.majorVersion, c.minorVersion, err = version.ParseMajorMinor(c.versionStr); err != nil {
+			return err
+		}
+	} else {
+		c.majorVersion = jujuversion.Current.Major
+		c.minorVersion = jujuversion.Current.Minor
+	}
+	if c.dashboardPath != "" && c.noDashboard {
+		return errors.New("--dashboard and --no-dashboard can't be used together")
+	}
+	if c.dbSnapAssertions != "" && c.dbSnapPath == "" {
+		return errors.New("--db-snap-asserts requires --db-snap")
+	}
+	// As with bootstrap, the assertions are assumed to be alongside the snap.
+	if c.dbSnapAssertions == "" && c.dbSnapPath != "" {
+		c.dbSnapAssertions = strings.Replace(c.dbSnapPath, path.Ext(c.dbSnapPath), ".assert", -1)
+	}
+	var err error
+	if c.lxdImages, err = parseImageArgs("--lxd-image", c.lxdImageArgs, validateLXDImageName); err != nil {
+		return errors.Trace(err)
+	}
+	if c.ociImages, err = parseImageArgs("--oci-image", c.ociImageArgs, validateOCIImageName); err != nil {
+		return errors.Trace(err)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// parseImageArgs parses <name>=<file> image arguments into a map of
+// file keyed by name.
+func parseImageArgs(flag string, args []string, validateName func(string) error) (map[string]string, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+	images := make(map[string]string, len(args))
+	for _, arg := range args {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return nil, errors.Errorf("%s %q: expected <name>=<file>", flag, arg)
+		}
+		if err := validateName(parts[0]); err != nil {
+			return nil, errors.Annotatef(err, "%s %q", flag, arg)
+		}
+		if _, ok := images[parts[0]]; ok {
+			return nil, errors.Errorf("%s %q given more than once", flag, parts[0])
+		}
+		images[parts[0]] = parts[1]
+	}
+	return images, nil
+}
+
+func validateLXDImageName(name string) error {
+	parts := strings.Split(name, "/")
+	if len(parts) != 2 || parts[0] == "" {
+		return errors.NotValidf("image name %q, expected <series>/<arch>", name)
+	}
+	if !arch.IsSupportedArch(parts[1]) {
+		return errors.NotSupportedf("architecture %q", parts[1])
+	}
+	return nil
+}
+
+func validateOCIImageName(name string) error {
+	if name != podcfg.JujudOCIName && name != podcfg.JujudbOCIName {
+		return errors.NotValidf("image name %q, expected %s or %s", name, podcfg.JujudOCIName, podcfg.JujudbOCIName)
+	}
+	return nil
+}
+
+func (c *createBootstrapBundleCommand) Run(ctx *cmd.Context) error {
+	// Register writer for output on screen.
+	writer := loggo.NewMinimumLevelWriter(
+		cmd.NewCommandLogWriter("juju.environs.sync", ctx.Stdout, ctx.Stderr),
+		loggo.INFO)
+	_ = loggo.RegisterWriter("createbootstrapbundle", writer)
+	defer loggo.RemoveWriter("createbootstrapbundle")
+
+	filename := ctx.AbsPath(c.filename)
+	if _, err := os.Stat(filename); err == nil {
+		return errors.AlreadyExistsf("bootstrap bundle %q", c.filename)
+	}
+
+	dir, err := ioutil.TempDir("", "juju-bootstrap-bundle")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	var manifest bootstrapbundle.Manifest
+	manifest.AgentVersion, err = c.addAgentBinaries(filepath.Join(dir, bootstrapbundle.MetadataDir))
+	if err != nil {
+		return errors.Annotate(err, "adding agent binaries")
+	}
+	ctx.Infof("Added agent binaries %s", manifest.AgentVersion)
+
+	if c.imageMetadataDir != "" {
+		imagesDir := filepath.Join(dir, bootstrapbundle.MetadataDir, storage.BaseImagesPath)
+		if err := copyDir(imagesDir, ctx.AbsPath(c.imageMetadataDir)); err != nil {
+			return errors.Annotate(err, "adding image metadata")
+		}
+	}
+
+	if !c.noDashboard {
+		dashboardVersion, err := c.addDashboard(ctx, manifest.AgentVersion, filepath.Join(dir, bootstrapbundle.DashboardFile))
+		if err != nil {
+			return errors.Annotate(err, "adding Juju Dashboard")
+		}
+		ctx.Infof("Added Juju Dashboard %s", dashboardVersion)
+		manifest.DashboardVersion = dashboardVersion.String()
+	}
+
+	if c.dbSnapPath != "" {
+		if err := utils.CopyFile(filepath.Join(dir, bootstrapbundle.DBSnapFile), ctx.AbsPath(c.dbSnapPath)); err != nil {
+			return errors.Annotate(err, "adding juju-db snap")
+		}
+	}
+	if c.dbSnapAssertions != "" {
+		if err := utils.CopyFile(filepath.Join(dir, bootstrapbundle.DBSnapAssertionsFile), ctx.AbsPath(c.dbSnapAssertions)); err != nil {
+			return errors.Annotate(err, "adding juju-db snap assertions")
+		}
+	}
+
+	if c.controllerCharm != "" {
+		charmPath := ctx.AbsPath(c.controllerCharm)
+		ch, err := charm.ReadCharmArchive(charmPath)
+		if err != nil {
+			return errors.Errorf("--controller-charm %q is not a valid charm archive", c.controllerCharm)
+		}
+		if ch.Meta().Name != bootstrap.ControllerCharmName {
+			return errors.Errorf("--controller-charm %q is not a %q charm", c.controllerCharm, bootstrap.ControllerCharmName)
+		}
+		if err := utils.CopyFile(filepath.Join(dir, bootstrapbundle.ControllerCharmFile), charmPath); err != nil {
+			return errors.Annotate(err, "adding controller charm")
+		}
+	}
+
+	for name, imagePath := range c.lxdImages {
+		parts := strings.Split(name, "/")
+		file := bootstrapbundle.LXDImageFile(parts[0], parts[1])
+		if err := copyFile(filepath.Join(dir, filepath.FromSlash(file)), ctx.AbsPath(imagePath)); err != nil {
+			return errors.Annotatef(err, "adding LXD image %s", name)
+		}
+		if manifest.LXDImages == nil {
+			manifest.LXDImages = make(map[string]string)
+		}
+		manifest.LXDImages[name] = file
+		ctx.Infof("Added LXD image %s", name)
+	}
+	for name, imagePath := range c.ociImages {
+		file := bootstrapbundle.OCIImageFile(name)
+		if err := copyFile(filepath.Join(dir, filepath.FromSlash(file)), ctx.AbsPath(imagePath)); err != nil {
+			return errors.Annotatef(err, "adding OCI image %s", name)
+		}
+		if manifest.OCIImages == nil {
+			manifest.OCIImages = make(map[string]string)
+		}
+		manifest.OCIImages[name] = file
+		ctx.Infof("Added OCI image %s", name)
+	}
+
+	f, err := os.OpenFile(filename, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := bootstrapbundle.Create(f, dir, manifest); err != nil {
+		_ = f.Close()
+		_ = os.Remove(filename)
+		return errors.Annotate(err, "writing bootstrap bundle")
+	}
+	if err := f.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Bootstrap bundle written to %s", c.filename)
+	return nil
+}
+
+// addAgentBinaries copies the most recent agent binaries of the chosen
+// version, and their metadata, into dir, and returns their version.
+func (c *createBootstrapBundleCommand) addAgentBinaries(dir string) (version.Number, error) {
+	stor, err := filestorage.NewFileStorageWriter(dir)
+	if err != nil {
+		return version.Zero, errors.Trace(err)
+	}
+	sctx := &sync.SyncContext{
+		MajorVersion: c.majorVersion,
+		MinorVersion: c.minorVersion,
+		Stream:       c.stream,
+		Source:       c.source,
+		TargetToolsFinder: sync.StorageToolsFinder{
+			Storage: stor,
+		},
+		TargetToolsUploader: sync.StorageToolsUploader{
+			Storage:       stor,
+			WriteMetadata: true,
+			WriteMirrors:  envtools.DoNotWriteMirrors,
+		},
+	}
+	if err := syncTools(sctx); err != nil {
+		return version.Zero, errors.Trace(err)
+	}
+
+	toolsDir := c.stream
+	if toolsDir == "" {
+		toolsDir = envtools.ReleasedStream
+	}
+	list, err := envtools.ReadList(stor, toolsDir, c.majorVersion, c.minorVersion)
+	if err != nil {
+		return version.Zero, errors.Trace(err)
+	}
+	newest, _ := list.Newest()
+	return newest, nil
+}
+
+// addDashboard writes the Juju Dashboard archive to filename, either
+// copying the local archive or downloading the most recent one for the
+// agent version, and returns its version.
+func (c *createBootstrapBundleCommand) addDashboard(ctx *cmd.Context, agentVersion version.Number, filename string) (version.Number, error) {
+	if c.dashboardPath != "" {
+		archivePath := ctx.AbsPath(c.dashboardPath)
+		f, err := os.Open(archivePath)
+		if err != nil {
+			return version.Zero, errors.Trace(err)
+		}
+		defer func() { _ = f.Close() }()
+		vers, err := dashboard.DashboardArchiveVersion(f)
+		if err != nil {
+			return version.Zero, errors.Trace(err)
+		}
+		return vers, errors.Trace(utils.CopyFile(filename, archivePath))
+	}
+
+	source := dashboard.NewDataSource(common.DashboardDataSourceBaseURL())
+	allMeta, err := dashboardFetchMetadata(c.dashboardStream, agentVersion.Major, agentVersion.Minor, source)
+	if err != nil {
+		return version.Zero, errors.Annotate(err, "fetching Juju Dashboard metadata")
+	}
+	if len(allMeta) == 0 {
+		return version.Zero, errors.NotFoundf("Juju Dashboard archive for %d.%d", agentVersion.Major, agentVersion.Minor)
+	}
+	// Metadata info are returned in descending version order.
+	meta := allMeta[0]
+	r, _, err := meta.Source.Fetch(meta.Path)
+	if err != nil {
+		return version.Zero, errors.Annotatef(err, "downloading Juju Dashboard %s", meta.Version)
+	}
+	defer func() { _ = r.Close() }()
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return version.Zero, errors.Trace(err)
+	}
+	defer func() { _ = f.Close() }()
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(f, h), r)
+	if err != nil {
+		return version.Zero, errors.Annotatef(err, "downloading Juju Dashboard %s", meta.Version)
+	}
+	if hash := fmt.Sprintf("%x", h.Sum(nil)); hash != meta.SHA256 || size != meta.Size {
+		return version.Zero, errors.Errorf("downloaded Juju Dashboard %s does not match its metadata", meta.Version)
+	}
+	return meta.Version, errors.Trace(f.Close())
+}
+
+// copyDir copies the regular files under src to the same paths under
+// dest.
+func copyDir(dest, src string) error {
+	return filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(copyFile(filepath.Join(dest, rel), p))
+	})
+}
+
+// copyFile copies src to dest, creating dest's directory if needed.
+func copyFile(dest, src string) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(utils.CopyFile(dest, src))
+}
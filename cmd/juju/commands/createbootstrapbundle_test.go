@@ -0,0 +1,233 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/bootstrapbundle"
+	"github.com/juju/juju/environs/dashboard"
+	"github.com/juju/juju/environs/simplestreams"
+	"github.com/juju/juju/environs/sync"
+	coretesting "github.com/juju/juju/testing"
+	coretools "github.com/juju/juju/tools"
+)
+
+type createBootstrapBundleSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+
+	syncContext *sync.SyncContext
+}
+
+var _ = gc.Suite(&createBootstrapBundleSuite{})
+
+const fakeDashboardArchive = "dashboard archive"
+
+func (s *createBootstrapBundleSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.syncContext = nil
+	s.PatchValue(&syncTools, func(sctx *sync.SyncContext) error {
+		s.syncContext = sctx
+		data := []byte("agent binary")
+		return sctx.TargetToolsUploader.UploadTools("released", "released", &coretools.Tools{
+			Version: version.MustParseBinary("2.9.4-ubuntu-amd64"),
+			Size:    int64(len(data)),
+			SHA256:  fmt.Sprintf("%x", sha256.Sum256(data)),
+		}, data)
+	})
+	s.PatchValue(&dashboardFetchMetadata, func(stream string, major, minor int, sources ...simplestreams.DataSource) ([]*dashboard.Metadata, error) {
+		c.Check(stream, gc.Equals, "released")
+		c.Check(major, gc.Equals, 2)
+		c.Check(minor, gc.Equals, 9)
+		return []*dashboard.Metadata{{
+			Version: version.MustParse("0.8.1"),
+			Path:    "juju-dashboard-0.8.1.tar.bz2",
+			Size:    int64(len(fakeDashboardArchive)),
+			SHA256:  fmt.Sprintf("%x", sha256.Sum256([]byte(fakeDashboardArchive))),
+			Source:  fakeDashboardSource{},
+		}}, nil
+	})
+}
+
+// fakeDashboardSource is a simplestreams.DataSource which serves a
+// fake Juju Dashboard archive.
+type fakeDashboardSource struct {
+	simplestreams.DataSource
+}
+
+func (fakeDashboardSource) Fetch(path string) (io.ReadCloser, string, error) {
+	return ioutil.NopCloser(bytes.NewReader([]byte(fakeDashboardArchive))), path, nil
+}
+
+func (s *createBootstrapBundleSuite) extract(c *gc.C, filename string) *bootstrapbundle.Bundle {
+	f, err := os.Open(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	bundle, err := bootstrapbundle.Extract(f, c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	return bundle
+}
+
+func (s *createBootstrapBundleSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no bootstrap bundle file specified",
+	}, {
+		args: []string{"bundle.tar.gz", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"--version", "foo", "bundle.tar.gz"},
+		err:  `invalid major version number foo.*`,
+	}, {
+		args: []string{"--dashboard", "dashboard.tar.bz2", "--no-dashboard", "bundle.tar.gz"},
+		err:  "--dashboard and --no-dashboard can't be used together",
+	}, {
+		args: []string{"--db-snap-asserts", "juju-db.assert", "bundle.tar.gz"},
+		err:  "--db-snap-asserts requires --db-snap",
+	}, {
+		args: []string{"--lxd-image", "focal.tar.gz", "bundle.tar.gz"},
+		err:  `--lxd-image "focal.tar.gz": expected <name>=<file>`,
+	}, {
+		args: []string{"--lxd-image", "focal=focal.tar.gz", "bundle.tar.gz"},
+		err:  `--lxd-image "focal=focal.tar.gz": image name "focal", expected <series>/<arch> not valid`,
+	}, {
+		args: []string{"--lxd-image", "focal/z80=focal.tar.gz", "bundle.tar.gz"},
+		err:  `--lxd-image "focal/z80=focal.tar.gz": architecture "z80" not supported`,
+	}, {
+		args: []string{"--lxd-image", "focal/amd64=a.tar.gz", "--lxd-image", "focal/amd64=b.tar.gz", "bundle.tar.gz"},
+		err:  `--lxd-image "focal/amd64" given more than once`,
+	}, {
+		args: []string{"--oci-image", "mariadb=mariadb.tar", "bundle.tar.gz"},
+		err:  `--oci-image "mariadb=mariadb.tar": image name "mariadb", expected jujud-operator or juju-db not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := cmdtesting.InitCommand(newCreateBootstrapBundleCommand(), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *createBootstrapBundleSuite) TestCreate(c *gc.C) {
+	dir := c.MkDir()
+	snap := filepath.Join(dir, "juju-db.snap")
+	err := ioutil.WriteFile(snap, []byte("snap"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(dir, "juju-db.assert"), []byte("assert"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	filename := filepath.Join(dir, "bundle.tar.gz")
+	ctx, err := cmdtesting.RunCommand(c, newCreateBootstrapBundleCommand(),
+		"--version", "2.9", "--db-snap", snap, filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, "Added agent binaries 2.9.4\n")
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, "Added Juju Dashboard 0.8.1\n")
+
+	c.Assert(s.syncContext, gc.NotNil)
+	c.Assert(s.syncContext.MajorVersion, gc.Equals, 2)
+	c.Assert(s.syncContext.MinorVersion, gc.Equals, 9)
+
+	bundle := s.extract(c, filename)
+	c.Assert(bundle.Manifest.AgentVersion, gc.Equals, version.MustParse("2.9.4"))
+	c.Assert(bundle.Manifest.DashboardVersion, gc.Equals, "0.8.1")
+	c.Assert(bundle.Manifest.Files, jc.HasKey, "metadata/tools/released/juju-2.9.4-ubuntu-amd64.tgz")
+	c.Assert(bundle.ControllerCharmPath(), gc.Equals, "")
+
+	data, err := ioutil.ReadFile(bundle.DashboardPath())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, fakeDashboardArchive)
+	data, err = ioutil.ReadFile(bundle.DBSnapPath())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "snap")
+	data, err = ioutil.ReadFile(bundle.DBSnapAssertionsPath())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "assert")
+}
+
+func (s *createBootstrapBundleSuite) TestCreateNoDashboard(c *gc.C) {
+	s.PatchValue(&dashboardFetchMetadata, func(string, int, int, ...simplestreams.DataSource) ([]*dashboard.Metadata, error) {
+		return nil, errors.New("unexpected fetch")
+	})
+	filename := filepath.Join(c.MkDir(), "bundle.tar.gz")
+	_, err := cmdtesting.RunCommand(c, newCreateBootstrapBundleCommand(),
+		"--version", "2.9", "--no-dashboard", filename)
+	c.Assert(err, jc.ErrorIsNil)
+
+	bundle := s.extract(c, filename)
+	c.Assert(bundle.Manifest.DashboardVersion, gc.Equals, "")
+	c.Assert(bundle.DashboardPath(), gc.Equals, "")
+}
+
+func (s *createBootstrapBundleSuite) TestCreateDashboardMismatch(c *gc.C) {
+	s.PatchValue(&dashboardFetchMetadata, func(string, int, int, ...simplestreams.DataSource) ([]*dashboard.Metadata, error) {
+		return []*dashboard.Metadata{{
+			Version: version.MustParse("0.8.1"),
+			Path:    "juju-dashboard-0.8.1.tar.bz2",
+			Size:    int64(len(fakeDashboardArchive)),
+			SHA256:  "bad-hash",
+			Source:  fakeDashboardSource{},
+		}}, nil
+	})
+	filename := filepath.Join(c.MkDir(), "bundle.tar.gz")
+	_, err := cmdtesting.RunCommand(c, newCreateBootstrapBundleCommand(), "--version", "2.9", filename)
+	c.Assert(err, gc.ErrorMatches, "adding Juju Dashboard: downloaded Juju Dashboard 0.8.1 does not match its metadata")
+	_, err = os.Stat(filename)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *createBootstrapBundleSuite) TestCreateImages(c *gc.C) {
+	dir := c.MkDir()
+	lxdImage := filepath.Join(dir, "focal.tar.gz")
+	err := ioutil.WriteFile(lxdImage, []byte("lxd"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	ociImage := filepath.Join(dir, "jujud-operator.tar")
+	err = ioutil.WriteFile(ociImage, []byte("oci"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	filename := filepath.Join(dir, "bundle.tar.gz")
+	ctx, err := cmdtesting.RunCommand(c, newCreateBootstrapBundleCommand(),
+		"--version", "2.9", "--no-dashboard",
+		"--lxd-image", "focal/amd64="+lxdImage,
+		"--oci-image", "jujud-operator="+ociImage,
+		filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, "Added LXD image focal/amd64\n")
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, "Added OCI image jujud-operator\n")
+
+	bundle := s.extract(c, filename)
+	c.Assert(bundle.Manifest.LXDImages, jc.DeepEquals, map[string]string{
+		"focal/amd64": "container-images/lxd/focal-amd64",
+	})
+	c.Assert(bundle.Manifest.OCIImages, jc.DeepEquals, map[string]string{
+		"jujud-operator": "container-images/oci/jujud-operator.tar",
+	})
+	data, err := ioutil.ReadFile(bundle.LXDImagePaths()["focal/amd64"])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "lxd")
+	data, err = ioutil.ReadFile(bundle.OCIImagePaths()["jujud-operator"])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "oci")
+}
+
+func (s *createBootstrapBundleSuite) TestCreateExists(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "bundle.tar.gz")
+	err := ioutil.WriteFile(filename, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cmdtesting.RunCommand(c, newCreateBootstrapBundleCommand(), filename)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	c.Assert(s.syncContext, gc.IsNil)
+}
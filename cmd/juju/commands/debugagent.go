@@ -0,0 +1,197 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"io/ioutil"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/agentintrospection"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const (
+	// defaultIntrospectionPath is the introspection path queried when
+	// none is given: a dump of the agent's goroutines.
+	defaultIntrospectionPath = "/debug/pprof/goroutine?debug=1"
+
+	// introspectionPollInterval is how often the controller is asked
+	// whether the agent has responded.
+	introspectionPollInterval = time.Second
+)
+
+const usageDebugAgentSummary = "Queries the introspection socket of a machine or unit agent."
+
+var usageDebugAgentDetails = `
+The agents of a model serve introspection data, such as a dump of their
+goroutines, a report on their dependency engine and pprof profiles, on
+a local socket. This command asks the controller to have the agent of
+the given machine, or of the machine the given unit is assigned to,
+query that socket, and displays the response. Unlike running
+juju_engine_report and friends over juju ssh, it needs no SSH access to
+the machine, but requires admin access to the model.
+
+The path defaults to "` + defaultIntrospectionPath + `". Other
+paths include:
+
+    /depengine                     the dependency engine report
+    /debug/pprof/heap              a heap profile
+    /debug/pprof/profile?seconds=5 a CPU profile
+    /machinelock                   the machine lock history
+    /metrics                       the agent's Prometheus metrics
+    /units                         the units the agent is running
+
+Binary profiles should be written to a file with --output, for use with
+"go tool pprof".
+
+Examples:
+
+    juju debug-agent mysql/0
+    juju debug-agent 0 /depengine
+    juju debug-agent 0 /debug/pprof/heap --output heap.pprof
+
+See also:
+    debug-log
+    ssh`[1:]
+
+// agentIntrospectionAPI defines the API methods that the debug-agent
+// command uses.
+type agentIntrospectionAPI interface {
+	Close() error
+	BestAPIVersion() int
+	Introspect(tag names.Tag, path string) (string, error)
+	Result(id string) (params.IntrospectionResult, error)
+}
+
+// newDebugAgentCommand returns a command which queries an agent's
+// introspection socket through the controller.
+func newDebugAgentCommand() cmd.Command {
+	c := &debugAgentCommand{clock: clock.WallClock}
+	c.newAPIFunc = func() (agentIntrospectionAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return agentintrospection.NewClient(root), nil
+	}
+	return modelcmd.Wrap(c)
+}
+
+type debugAgentCommand struct {
+	modelcmd.ModelCommandBase
+
+	newAPIFunc func() (agentIntrospectionAPI, error)
+	clock      clock.Clock
+
+	tag    names.Tag
+	path   string
+	wait   time.Duration
+	output string
+}
+
+// Info implements Command.Info.
+func (c *debugAgentCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "debug-agent",
+		Args:    "<machine>|<unit> [<path>]",
+		Purpose: usageDebugAgentSummary,
+		Doc:     usageDebugAgentDetails,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *debugAgentCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.DurationVar(&c.wait, "wait", 30*time.Second, "Maximum time to wait for the agent to respond")
+	f.StringVar(&c.output, "o", "", "Write the response to the named file")
+	f.StringVar(&c.output, "output", "", "")
+}
+
+// Init implements Command.Init.
+func (c *debugAgentCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no machine or unit specified")
+	}
+	target, args := args[0], args[1:]
+	switch {
+	case names.IsValidMachine(target):
+		c.tag = names.NewMachineTag(target)
+	case names.IsValidUnit(target):
+		c.tag = names.NewUnitTag(target)
+	default:
+		return errors.NotValidf("machine or unit %q", target)
+	}
+	c.path = defaultIntrospectionPath
+	if len(args) > 0 {
+		c.path, args = args[0], args[1:]
+	}
+	if c.wait <= 0 {
+		return errors.New("--wait must be positive")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *debugAgentCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if client.BestAPIVersion() < 1 {
+		return errors.NotSupportedf("querying agents through the controller")
+	}
+	id, err := client.Introspect(c.tag, c.path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Verbosef("waiting for %s to respond to request %q", names.ReadableString(c.tag), id)
+
+	timeout := c.clock.After(c.wait)
+	for {
+		result, err := client.Result(id)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if result.Error != nil && !result.Completed {
+			return errors.Trace(result.Error)
+		}
+		if result.Completed {
+			if err := c.writeOutput(ctx, result.Output); err != nil {
+				return errors.Trace(err)
+			}
+			if result.Error != nil {
+				return errors.Trace(result.Error)
+			}
+			return nil
+		}
+		select {
+		case <-c.clock.After(introspectionPollInterval):
+		case <-timeout:
+			return errors.Errorf("timed out waiting for %s to respond", names.ReadableString(c.tag))
+		}
+	}
+}
+
+func (c *debugAgentCommand) writeOutput(ctx *cmd.Context, output []byte) error {
+	if c.output == "" {
+		_, err := ctx.Stdout.Write(output)
+		return errors.Trace(err)
+	}
+	path := ctx.AbsPath(c.output)
+	if err := ioutil.WriteFile(path, output, 0644); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Response written to %s", c.output)
+	return nil
+}
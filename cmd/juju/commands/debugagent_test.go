@@ -0,0 +1,191 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/names/v4"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type debugAgentSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+
+	api   *fakeAgentIntrospectionAPI
+	clock *testclock.Clock
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&debugAgentSuite{})
+
+func (s *debugAgentSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeAgentIntrospectionAPI{version: 1}
+	s.clock = testclock.NewClock(time.Now())
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Models["testing"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/default": {},
+		},
+		CurrentModel: "admin/default",
+	}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+}
+
+func (s *debugAgentSuite) newCommand() cmd.Command {
+	c := &debugAgentCommand{
+		newAPIFunc: func() (agentIntrospectionAPI, error) {
+			return s.api, nil
+		},
+		clock: s.clock,
+	}
+	c.SetClientStore(s.store)
+	return modelcmd.Wrap(c)
+}
+
+func (s *debugAgentSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no machine or unit specified",
+	}, {
+		args: []string{"mysql"},
+		err:  `machine or unit "mysql" not valid`,
+	}, {
+		args: []string{"0", "/depengine", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"0", "--wait", "0s"},
+		err:  "--wait must be positive",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := cmdtesting.RunCommand(c, s.newCommand(), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *debugAgentSuite) TestUnitDefaultPath(c *gc.C) {
+	s.api.results = []params.IntrospectionResult{{
+		Id:        "machine-1#0",
+		Completed: true,
+		Output:    []byte("goroutine profile: total 3\n"),
+	}}
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "goroutine profile: total 3\n")
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"Introspect", []interface{}{names.NewUnitTag("mysql/0"), "/debug/pprof/goroutine?debug=1"}},
+		{"Result", []interface{}{"machine-1#0"}},
+		{"Close", nil},
+	})
+}
+
+func (s *debugAgentSuite) TestOutputFile(c *gc.C) {
+	s.api.results = []params.IntrospectionResult{{
+		Id:        "machine-0#0",
+		Completed: true,
+		Output:    []byte("heap profile"),
+	}}
+	path := filepath.Join(c.MkDir(), "heap.pprof")
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "0", "/debug/pprof/heap", "--output", path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "heap profile")
+}
+
+func (s *debugAgentSuite) TestAgentError(c *gc.C) {
+	s.api.results = []params.IntrospectionResult{{
+		Id:        "machine-0#0",
+		Completed: true,
+		Error:     &params.Error{Message: "response returned 404 (Not Found)"},
+	}}
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "0", "/units")
+	c.Assert(err, gc.ErrorMatches, `response returned 404 \(Not Found\)`)
+}
+
+func (s *debugAgentSuite) TestWaitsForResponse(c *gc.C) {
+	s.api.results = []params.IntrospectionResult{
+		{Id: "machine-0#0"},
+		{Id: "machine-0#0", Completed: true, Output: []byte("ok")},
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		err := s.clock.WaitAdvance(introspectionPollInterval, coretesting.LongWait, 2)
+		c.Check(err, jc.ErrorIsNil)
+	}()
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "ok")
+	<-done
+}
+
+func (s *debugAgentSuite) TestTimeout(c *gc.C) {
+	s.api.results = []params.IntrospectionResult{{Id: "machine-0#0"}}
+	go func() {
+		err := s.clock.WaitAdvance(5*time.Second, coretesting.LongWait, 2)
+		c.Check(err, jc.ErrorIsNil)
+	}()
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "0", "--wait", "5s")
+	c.Assert(err, gc.ErrorMatches, "timed out waiting for machine 0 to respond")
+}
+
+func (s *debugAgentSuite) TestNotSupported(c *gc.C) {
+	s.api.version = 0
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "0")
+	c.Assert(err, gc.ErrorMatches, "querying agents through the controller not supported")
+}
+
+type fakeAgentIntrospectionAPI struct {
+	jujutesting.Stub
+
+	version int
+	results []params.IntrospectionResult
+}
+
+func (f *fakeAgentIntrospectionAPI) Close() error {
+	f.AddCall("Close")
+	return nil
+}
+
+func (f *fakeAgentIntrospectionAPI) BestAPIVersion() int {
+	return f.version
+}
+
+func (f *fakeAgentIntrospectionAPI) Introspect(tag names.Tag, path string) (string, error) {
+	f.AddCall("Introspect", tag, path)
+	if tag.Kind() == names.UnitTagKind {
+		return "machine-1#0", f.NextErr()
+	}
+	return "machine-0#0", f.NextErr()
+}
+
+func (f *fakeAgentIntrospectionAPI) Result(id string) (params.IntrospectionResult, error) {
+	f.AddCall("Result", id)
+	result := f.results[0]
+	if len(f.results) > 1 {
+		f.results = f.results[1:]
+	}
+	return result, f.NextErr()
+}
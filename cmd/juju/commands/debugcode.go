@@ -5,6 +5,7 @@ package commands
 
 import (
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 
 	jujucmd "github.com/juju/juju/cmd"
@@ -48,11 +49,18 @@ func (c *debugCodeCommand) Info() *cmd.Info {
 }
 
 func (c *debugCodeCommand) Init(args []string) error {
-	return c.debugHooksCommand.Init(args)
+	if err := c.debugHooksCommand.Init(args); err != nil {
+		return err
+	}
+	if len(c.units) > 1 {
+		return errors.Errorf("debug-code supports a single unit, got %q", c.units)
+	}
+	return nil
 }
 
 func (c *debugCodeCommand) SetFlags(f *gnuflag.FlagSet) {
-	c.debugHooksCommand.SetFlags(f)
+	// The --script flag of debug-hooks doesn't apply here.
+	c.sshCommand.SetFlags(f)
 	f.StringVar(&c.debugAt, "at", "all",
 		"interpreted by the charm for where you want to stop, defaults to 'all'")
 }
@@ -4,14 +4,22 @@
 package commands
 
 import (
+	"bytes"
 	"encoding/base64"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"sync"
 
 	"github.com/juju/charm/v9/hooks"
 	"github.com/juju/cmd"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/names/v4"
+	"github.com/juju/utils/v2"
 
 	"github.com/juju/juju/api/action"
 	"github.com/juju/juju/api/application"
@@ -32,8 +40,12 @@ func newDebugHooksCommand(hostChecker ssh.ReachableChecker) cmd.Command {
 // debugHooksCommand is responsible for launching a ssh shell on a given unit or machine.
 type debugHooksCommand struct {
 	sshCommand
+	units []string
 	hooks []string
 
+	scriptPath string
+	script     string
+
 	actionsAPI
 	charmRelationsAPI
 }
@@ -41,20 +53,45 @@ type debugHooksCommand struct {
 const debugHooksDoc = `
 Interactively debug hooks or actions remotely on an application unit.
 
+More than one unit may be specified, in which case a local tmux session
+is started with a window debugging each unit. This requires tmux to be
+installed on the client.
+
+With --script, no interactive session is started. Instead the given local
+script is run in the hook context on the unit whenever a matching hook or
+action fires, followed by the hook or action itself, and the output of
+both is written to the terminal. The hook runs even if the script fails,
+and its exit status is the one reported to Juju. This is intended for
+automated hook debugging, e.g. in CI. Interrupt the command to end the
+session.
+
 See the "juju help ssh" for information about SSH related options
 accepted by the debug-hooks command.
+
+Examples:
+
+    # Debug all hooks on mysql/0 and mysql/1, each in its own tmux window:
+    juju debug-hooks mysql/0 mysql/1
+
+    # Run ./dump-relation.sh whenever db-relation-changed fires on mysql/0:
+    juju debug-hooks --script ./dump-relation.sh mysql/0 db-relation-changed
 `
 
 func (c *debugHooksCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:    "debug-hooks",
-		Args:    "<unit name> [hook or action names]",
+		Args:    "<unit name> [<unit name> ...] [hook or action names]",
 		Purpose: "Launch a tmux session to debug hooks and/or actions.",
 		Doc:     debugHooksDoc,
 		Aliases: []string{"debug-hook"},
 	})
 }
 
+func (c *debugHooksCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.sshCommand.SetFlags(f)
+	f.StringVar(&c.scriptPath, "script", "", "Run the given local script before each matching hook, instead of an interactive shell")
+}
+
 func (c *debugHooksCommand) Init(args []string) error {
 	if len(args) < 1 {
 		return errors.Errorf("no unit name specified")
@@ -68,8 +105,17 @@ func (c *debugHooksCommand) Init(args []string) error {
 		return errors.Errorf("%q is not a valid unit name", c.provider.getTarget())
 	}
 
+	// Hook and action names never contain a "/", so all leading
+	// arguments which are valid unit names are units to debug.
+	c.units = []string{args[0]}
+	args = args[1:]
+	for len(args) > 0 && names.IsValidUnit(args[0]) {
+		c.units = append(c.units, args[0])
+		args = args[1:]
+	}
+
 	// If any of the hooks is "*", then debug all hooks.
-	c.hooks = append([]string{}, args[1:]...)
+	c.hooks = append([]string{}, args...)
 	for _, h := range c.hooks {
 		if h == "*" {
 			c.hooks = nil
@@ -212,6 +258,9 @@ func (c *debugHooksCommand) commonRun(
 	}
 	debugctx := unitdebug.NewHooksContext(target)
 	clientScript := unitdebug.ClientScript(debugctx, hooks, debugAt)
+	if c.script != "" {
+		clientScript = unitdebug.ScriptClientScript(debugctx, hooks, c.script)
+	}
 	b64Script := base64.StdEncoding.EncodeToString([]byte(clientScript))
 	innercmd := fmt.Sprintf(`F=$(mktemp); echo %s | base64 -d > $F; . $F`, b64Script)
 	args := []string{fmt.Sprintf(c.decideEntryPoint(ctx), innercmd)}
@@ -223,9 +272,171 @@ func (c *debugHooksCommand) commonRun(
 // and connects to it via SSH to execute the debug-hooks
 // script.
 func (c *debugHooksCommand) Run(ctx *cmd.Context) error {
+	if len(c.units) > 1 {
+		return c.runMultiple(ctx)
+	}
+	if c.scriptPath != "" {
+		script, err := ioutil.ReadFile(ctx.AbsPath(c.scriptPath))
+		if err != nil {
+			return errors.Annotate(err, "reading debug script")
+		}
+		c.script = string(script)
+	}
 	if err := c.initAPIs(); err != nil {
 		return err
 	}
 	defer c.closeAPIs()
 	return c.commonRun(ctx, c.provider.getTarget(), c.hooks, "")
 }
+
+// runMultiple debugs each of the units with a separate juju debug-hooks
+// process. Interactive sessions are multiplexed into a local tmux session
+// with a window per unit; script sessions are run concurrently, with each
+// line of output prefixed by the unit name.
+func (c *debugHooksCommand) runMultiple(ctx *cmd.Context) error {
+	juju, err := getJujuExecutable()
+	if err != nil {
+		return errors.Annotate(err, "getting juju executable path")
+	}
+	modelName, err := c.ModelIdentifier()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	unitArgs := func(unit string) []string {
+		args := []string{"debug-hooks", "--model=" + modelName}
+		if c.sshMachine.proxy {
+			args = append(args, "--proxy")
+		}
+		if c.sshMachine.noHostKeyChecks {
+			args = append(args, "--no-host-key-checks")
+		}
+		if c.scriptPath != "" {
+			args = append(args, "--script="+ctx.AbsPath(c.scriptPath))
+		}
+		args = append(args, unit)
+		return append(args, c.hooks...)
+	}
+	if c.scriptPath != "" {
+		return runDebugScripts(ctx, juju, c.units, unitArgs)
+	}
+	return runDebugTmux(ctx, juju, c.units, unitArgs)
+}
+
+// runDebugTmux starts a local tmux session with a window running
+// juju debug-hooks for each unit, and attaches to it.
+func runDebugTmux(ctx *cmd.Context, juju string, units []string, unitArgs func(string) []string) error {
+	tmux, err := exec.LookPath("tmux")
+	if err != nil {
+		return errors.New("debugging multiple units requires tmux to be installed")
+	}
+	session := fmt.Sprintf("juju-debug-hooks-%d", os.Getpid())
+	for i, unit := range units {
+		command := utils.CommandString(append([]string{juju}, unitArgs(unit)...)...)
+		args := []string{"new-window", "-t", session, "-n", unit, command}
+		if i == 0 {
+			args = []string{"new-session", "-d", "-s", session, "-n", unit, command}
+		}
+		if out, err := exec.Command(tmux, args...).CombinedOutput(); err != nil {
+			return errors.Annotatef(err, "starting tmux window for %q: %s", unit, out)
+		}
+	}
+	attach := "attach-session"
+	if os.Getenv("TMUX") != "" {
+		// Already inside tmux, so switch rather than nest.
+		attach = "switch-client"
+	}
+	cmd := exec.Command(tmux, attach, "-t", session)
+	cmd.Stdin = ctx.Stdin
+	cmd.Stdout = ctx.Stdout
+	cmd.Stderr = ctx.Stderr
+	return cmd.Run()
+}
+
+// runDebugScripts runs juju debug-hooks --script concurrently for each
+// unit, until all of them have exited. If one of them can't be started,
+// those already started are killed.
+func runDebugScripts(ctx *cmd.Context, juju string, units []string, unitArgs func(string) []string) error {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		errMu    sync.Mutex
+		firstErr error
+		started  []*exec.Cmd
+	)
+	for _, unit := range units {
+		stdout := newPrefixWriter(ctx.Stdout, &mu, unit+": ")
+		stderr := newPrefixWriter(ctx.Stderr, &mu, unit+": ")
+		cmd := exec.Command(juju, unitArgs(unit)...)
+		cmd.Stdout = stdout
+		cmd.Stderr = stderr
+		if err := cmd.Start(); err != nil {
+			for _, cmd := range started {
+				_ = cmd.Process.Kill()
+			}
+			// Wait for the killed commands to be reaped.
+			wg.Wait()
+			return errors.Annotatef(err, "debugging %q", unit)
+		}
+		started = append(started, cmd)
+		wg.Add(1)
+		go func(unit string) {
+			defer wg.Done()
+			err := cmd.Wait()
+			_ = stdout.Flush()
+			_ = stderr.Flush()
+			errMu.Lock()
+			defer errMu.Unlock()
+			if err != nil && firstErr == nil {
+				firstErr = errors.Annotatef(err, "debugging %q", unit)
+			}
+		}(unit)
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// prefixWriter writes each complete line written to it to out, prefixed
+// with the given string. The mutex serialises writes to out between
+// multiple prefixWriters.
+type prefixWriter struct {
+	out    io.Writer
+	mu     *sync.Mutex
+	prefix string
+	buf    []byte
+}
+
+func newPrefixWriter(out io.Writer, mu *sync.Mutex, prefix string) *prefixWriter {
+	return &prefixWriter{out: out, mu: mu, prefix: prefix}
+}
+
+// Write is part of the io.Writer interface.
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		if err := w.writeLine(w.buf[:i+1]); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[i+1:]
+	}
+}
+
+// Flush writes any remaining partial line.
+func (w *prefixWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	err := w.writeLine(append(w.buf, '\n'))
+	w.buf = nil
+	return err
+}
+
+func (w *prefixWriter) writeLine(line []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err := fmt.Fprintf(w.out, "%s%s", w.prefix, line)
+	return err
+}
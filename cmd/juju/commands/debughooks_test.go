@@ -4,12 +4,20 @@
 package commands
 
 import (
+	"bytes"
 	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"regexp"
 	"runtime"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	goyaml "gopkg.in/yaml.v2"
@@ -192,3 +200,198 @@ func (s *DebugHooksSuite) TestDebugHooksArgFormatting(c *gc.C) {
 		"hooks": []interface{}{"install", "start"},
 	})
 }
+
+func (s *DebugHooksSuite) TestDebugHooksScript(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Skipping on windows for now")
+	}
+	s.setupModel(c)
+	s.setHostChecker(validAddresses("0.public"))
+	scriptPath := filepath.Join(c.MkDir(), "debug.sh")
+	err := ioutil.WriteFile(scriptPath, []byte("env | sort\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	ctx, err := cmdtesting.RunCommand(c, newDebugHooksCommand(s.hostChecker),
+		"--script", scriptPath, "mysql/0", "install")
+	c.Assert(err, jc.ErrorIsNil)
+	base64Regex := regexp.MustCompile("echo ([A-Za-z0-9+/]+=*) \\| base64")
+	rawContent := base64Regex.FindStringSubmatch(cmdtesting.Stdout(ctx))
+	c.Assert(rawContent, gc.HasLen, 2)
+	scriptContent, err := base64.StdEncoding.DecodeString(rawContent[1])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(scriptContent), gc.Not(jc.Contains), "tmux attach-session")
+	debugArgsRegex := regexp.MustCompile(`echo "([A-Z-a-z0-9+/]+=*)" \| base64`)
+	debugArgs := debugArgsRegex.FindStringSubmatch(string(scriptContent))
+	c.Assert(debugArgs, gc.HasLen, 2)
+	yamlContent, err := base64.StdEncoding.DecodeString(debugArgs[1])
+	c.Assert(err, jc.ErrorIsNil)
+	var args map[string]interface{}
+	err = goyaml.Unmarshal(yamlContent, &args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(args, gc.DeepEquals, map[string]interface{}{
+		"hooks":  []interface{}{"install"},
+		"script": "env | sort\n",
+	})
+}
+
+func (s *DebugHooksSuite) TestRunDebugTmux(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("debugging multiple units is not supported on windows")
+	}
+	testing.PatchExecutableAsEchoArgs(c, s, "tmux")
+	s.PatchEnvironment("TMUX", "")
+	unitArgs := func(unit string) []string {
+		return []string{"debug-hooks", "--model=admin/controller", unit, "install"}
+	}
+	ctx := cmdtesting.Context(c)
+	err := runDebugTmux(ctx, "/path/to/juju", []string{"mysql/0", "mysql/1", "mysql/2"}, unitArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	session := fmt.Sprintf("juju-debug-hooks-%d", os.Getpid())
+	command := func(unit string) string {
+		return "/path/to/juju debug-hooks --model=admin/controller " + unit + " install"
+	}
+	testing.AssertEchoArgs(c, "tmux", "new-session", "-d", "-s", session, "-n", "mysql/0", command("mysql/0"))
+	testing.AssertEchoArgs(c, "tmux", "new-window", "-t", session, "-n", "mysql/1", command("mysql/1"))
+	testing.AssertEchoArgs(c, "tmux", "new-window", "-t", session, "-n", "mysql/2", command("mysql/2"))
+	testing.AssertEchoArgs(c, "tmux", "attach-session", "-t", session)
+}
+
+func (s *DebugHooksSuite) TestRunDebugTmuxInsideTmux(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("debugging multiple units is not supported on windows")
+	}
+	testing.PatchExecutableAsEchoArgs(c, s, "tmux")
+	s.PatchEnvironment("TMUX", "/tmp/tmux-1000/default,1234,0")
+	unitArgs := func(unit string) []string {
+		return []string{"debug-hooks", unit}
+	}
+	ctx := cmdtesting.Context(c)
+	err := runDebugTmux(ctx, "juju", []string{"mysql/0", "mysql/1"}, unitArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	session := fmt.Sprintf("juju-debug-hooks-%d", os.Getpid())
+	testing.AssertEchoArgs(c, "tmux", "new-session", "-d", "-s", session, "-n", "mysql/0", "juju debug-hooks mysql/0")
+	testing.AssertEchoArgs(c, "tmux", "new-window", "-t", session, "-n", "mysql/1", "juju debug-hooks mysql/1")
+	testing.AssertEchoArgs(c, "tmux", "switch-client", "-t", session)
+}
+
+func (s *DebugHooksSuite) TestRunDebugScripts(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("debugging multiple units is not supported on windows")
+	}
+	juju := filepath.Join(c.MkDir(), "juju")
+	err := ioutil.WriteFile(juju, []byte(`#!/bin/bash --norc
+echo "=== $2: hooks/install"
+echo "installing on $2"
+printf "no newline" >&2
+`), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	unitArgs := func(unit string) []string {
+		return []string{"debug-hooks", unit}
+	}
+	ctx := cmdtesting.Context(c)
+	err = runDebugScripts(ctx, juju, []string{"mysql/0", "mysql/1"}, unitArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The units run concurrently, so their lines may be interleaved,
+	// but each line is whole and prefixed by its unit.
+	sortedLines := func(s string) []string {
+		lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+		sort.Strings(lines)
+		return lines
+	}
+	c.Check(sortedLines(cmdtesting.Stdout(ctx)), jc.DeepEquals, []string{
+		"mysql/0: === mysql/0: hooks/install",
+		"mysql/0: installing on mysql/0",
+		"mysql/1: === mysql/1: hooks/install",
+		"mysql/1: installing on mysql/1",
+	})
+	c.Check(sortedLines(cmdtesting.Stderr(ctx)), jc.DeepEquals, []string{
+		"mysql/0: no newline",
+		"mysql/1: no newline",
+	})
+}
+
+func (s *DebugHooksSuite) TestRunDebugScriptsError(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("debugging multiple units is not supported on windows")
+	}
+	juju := filepath.Join(c.MkDir(), "juju")
+	err := ioutil.WriteFile(juju, []byte(`#!/bin/bash --norc
+if [ "$2" = "mysql/1" ]; then
+	echo "cannot connect" >&2
+	exit 1
+fi
+echo "ok"
+`), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	unitArgs := func(unit string) []string {
+		return []string{"debug-hooks", unit}
+	}
+	ctx := cmdtesting.Context(c)
+	err = runDebugScripts(ctx, juju, []string{"mysql/0", "mysql/1"}, unitArgs)
+	c.Assert(err, gc.ErrorMatches, `debugging "mysql/1": exit status 1`)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "mysql/0: ok\n")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "mysql/1: cannot connect\n")
+}
+
+func (s *DebugHooksSuite) TestRunDebugScriptsStartError(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("debugging multiple units is not supported on windows")
+	}
+	dir := c.MkDir()
+	juju := filepath.Join(dir, "juju")
+	pidFile := filepath.Join(dir, "pid")
+	err := ioutil.WriteFile(juju, []byte(`#!/bin/bash --norc
+echo $$ > `+pidFile+`
+exec sleep 600
+`), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	unitArgs := func(unit string) []string {
+		if unit == "mysql/1" {
+			// An argument longer than the kernel allows fails
+			// the start.
+			return []string{"debug-hooks", strings.Repeat("x", 1<<20)}
+		}
+		return []string{"debug-hooks", unit}
+	}
+	ctx := cmdtesting.Context(c)
+	err = runDebugScripts(ctx, juju, []string{"mysql/0", "mysql/1"}, unitArgs)
+	c.Assert(err, gc.ErrorMatches, `debugging "mysql/1": .*`)
+
+	// The first unit's script was started, then killed and reaped
+	// before runDebugScripts returned.
+	data, err := ioutil.ReadFile(pidFile)
+	if os.IsNotExist(err) {
+		// It was killed before it could record its pid.
+		return
+	}
+	c.Assert(err, jc.ErrorIsNil)
+	pid := strings.TrimSpace(string(data))
+	if pid == "" {
+		return
+	}
+	_, err = os.Stat(filepath.Join("/proc", pid))
+	c.Assert(os.IsNotExist(err), jc.IsTrue)
+}
+
+type PrefixWriterSuite struct{}
+
+var _ = gc.Suite(&PrefixWriterSuite{})
+
+func (s *PrefixWriterSuite) TestWrite(c *gc.C) {
+	var (
+		buf bytes.Buffer
+		mu  sync.Mutex
+	)
+	w := newPrefixWriter(&buf, &mu, "mysql/0: ")
+	_, err := w.Write([]byte("one\ntw"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(buf.String(), gc.Equals, "mysql/0: one\n")
+	_, err = w.Write([]byte("o\nthree"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(buf.String(), gc.Equals, "mysql/0: one\nmysql/0: two\n")
+	err = w.Flush()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(buf.String(), gc.Equals, "mysql/0: one\nmysql/0: two\nmysql/0: three\n")
+}
@@ -91,6 +91,11 @@ Examples:
     # server2 used first, and if necessary, newly created controller
     # machines having at least 8GB RAM.
     juju enable-ha -n 7 --to server1,server2 --constraints mem=8G
+
+    # On a manual cloud, where new machines cannot be created, make the
+    # existing machines previously added to the controller model with
+    # "juju add-machine ssh:<host>" into controllers.
+    juju enable-ha --to 1,2
 `
 
 // formatSimple marshals value to a yaml-formatted []byte, unless value is nil.
@@ -76,6 +76,9 @@ func (dummyHookContext) Relation(id int) (jujuc.ContextRelation, error) {
 func (dummyHookContext) RelationIds() ([]int, error) {
 	return []int{}, errors.NotFoundf("RelationIds")
 }
+func (dummyHookContext) SetRelationSchema(string, bool, string) error {
+	return nil
+}
 
 func (dummyHookContext) RequestReboot(prio jujuc.RebootPriority) error {
 	return nil
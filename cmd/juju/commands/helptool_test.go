@@ -54,6 +54,7 @@ Currently available charm hook tools are:
     close-port               register a request to close a port or port range
     config-get               print application configuration
     credential-get           access cloud credentials
+    device-list              list devices allocated to the unit's machine
     goal-state               print the status of the charm's peers and related units
     is-leader                print application leadership status
     juju-log                 write a message to the juju log
@@ -72,7 +73,9 @@ Currently available charm hook tools are:
     relation-get             get relation settings
     relation-ids             list all relation ids with the given relation name
     relation-list            list relation units
+    relation-schema-set      register a schema for relation data
     relation-set             set relation settings
+    secret-config-get        print the value of a secret config option
     state-delete             delete server-side-state key value pair
     state-get                print server-side-state value
     state-set                set server-side-state values
@@ -81,6 +84,7 @@ Currently available charm hook tools are:
     storage-add              add storage instances
     storage-get              print information for storage instance with specified id
     storage-list             list storage attached to the unit
+    storage-snapshot         take a snapshot of a storage instance
     unit-get                 print public-address or private-address
 
 Examples:
@@ -103,6 +107,7 @@ var expectedCommands = []string{
 	"close-port",
 	"config-get",
 	"credential-get",
+	"device-list",
 	"goal-state",
 	"is-leader",
 	"juju-log",
@@ -124,8 +129,11 @@ var expectedCommands = []string{
 	"relation-get",
 	"relation-ids",
 	"relation-list",
+	"relation-schema-set",
 	"relation-set",
 	"resource-get",
+	"resource-share",
+	"secret-config-get",
 	"state-delete",
 	"state-get",
 	"state-set",
@@ -134,6 +142,7 @@ var expectedCommands = []string{
 	"storage-add",
 	"storage-get",
 	"storage-list",
+	"storage-snapshot",
 	"unit-get",
 }
 
@@ -283,7 +283,7 @@ func (r jujuCommandRegistry) Register(c cmd.Command) {
 	if csc, ok := c.(hasClientStore); ok {
 		csc.SetClientStore(r.store)
 	}
-	r.commandRegistry.Register(c)
+	r.commandRegistry.Register(jujucmd.NewErrorRenderingCommand(c))
 }
 
 type commandRegistry interface {
@@ -305,6 +305,7 @@ func registerCommands(r commandRegistry) {
 	r.Register(newVersionCommand())
 	// Creation commands.
 	r.Register(newBootstrapCommand())
+	r.Register(newCreateBootstrapBundleCommand())
 	r.Register(application.NewAddRelationCommand())
 
 	// Cross model relations commands.
@@ -320,17 +321,20 @@ func registerCommands(r commandRegistry) {
 	// Firewall rule commands.
 	r.Register(firewall.NewSetFirewallRuleCommand())
 	r.Register(firewall.NewListFirewallRulesCommand())
+	r.Register(firewall.NewFirewallAuditCommand())
 
 	// Destruction commands.
 	r.Register(application.NewRemoveRelationCommand())
 	r.Register(application.NewRemoveApplicationCommand())
 	r.Register(application.NewRemoveUnitCommand())
+	r.Register(application.NewDrainUnitCommand())
 	r.Register(application.NewRemoveSaasCommand())
 
 	// Reporting commands.
 	r.Register(status.NewStatusCommand())
 	r.Register(newSwitchCommand())
 	r.Register(status.NewStatusHistoryCommand())
+	r.Register(status.NewAgentsCommand())
 
 	// Error resolution and debugging commands.
 	r.Register(action.NewExecCommand(nil))
@@ -340,6 +344,7 @@ func registerCommands(r commandRegistry) {
 	r.Register(newDebugLogCommand(nil))
 	r.Register(newDebugHooksCommand(nil))
 	r.Register(newDebugCodeCommand(nil))
+	r.Register(newDebugAgentCommand())
 
 	// Configuration commands.
 	r.Register(model.NewModelGetConstraintsCommand())
@@ -349,6 +354,7 @@ func registerCommands(r commandRegistry) {
 	r.Register(newUpgradeControllerCommand())
 	r.Register(application.NewRefreshCommand())
 	r.Register(application.NewSetSeriesCommand())
+	r.Register(application.NewSetDefaultStorageCommand())
 	r.Register(application.NewBindCommand())
 
 	// Charm tool commands.
@@ -364,6 +370,7 @@ func registerCommands(r commandRegistry) {
 	r.Register(backups.NewListCommand())
 	r.Register(backups.NewRemoveCommand())
 	r.Register(backups.NewUploadCommand())
+	r.Register(backups.NewVerifyCommand())
 
 	// Manage authorized ssh keys.
 	r.Register(NewAddKeysCommand())
@@ -393,6 +400,10 @@ func registerCommands(r commandRegistry) {
 	r.Register(machine.NewListMachinesCommand())
 	r.Register(machine.NewShowMachineCommand())
 	r.Register(machine.NewUpgradeSeriesCommand())
+	r.Register(machine.NewSetEgressNATAddressCommand())
+	r.Register(machine.NewRefreshMachineHardwareCommand())
+	r.Register(machine.NewResizeMachineCommand())
+	r.Register(machine.NewReplaceMachineCommand())
 
 	// Manage model
 	r.Register(model.NewConfigCommand())
@@ -403,6 +414,8 @@ func registerCommands(r commandRegistry) {
 	r.Register(model.NewRevokeCommand())
 	r.Register(model.NewShowCommand())
 	r.Register(model.NewModelCredentialCommand())
+	r.Register(model.NewTransferOwnershipCommand())
+	r.Register(model.NewCloneCommand(application.NewDeployCommand))
 	if featureflag.Enabled(feature.Branches) || featureflag.Enabled(feature.Generations) {
 		r.Register(model.NewAddBranchCommand())
 		r.Register(model.NewCommitCommand())
@@ -421,6 +434,11 @@ func registerCommands(r commandRegistry) {
 		r.Register(model.NewDumpCommand())
 		r.Register(model.NewDumpDBCommand())
 	}
+	if featureflag.Enabled(feature.ModelSpec) {
+		r.Register(model.NewApplyCommand())
+		r.Register(model.NewShowModelSpecCommand())
+		r.Register(model.NewRemoveModelSpecCommand())
+	}
 
 	// Manage and control actions
 	r.Register(action.NewListCommand())
@@ -445,6 +463,8 @@ func registerCommands(r commandRegistry) {
 	r.Register(application.NewDiffBundleCommand())
 	r.Register(application.NewShowApplicationCommand())
 	r.Register(application.NewShowUnitCommand())
+	r.Register(application.NewShowRelationCommand())
+	r.Register(application.NewRelationsCommand())
 
 	// Operation protection commands
 	r.Register(block.NewDisableCommand())
@@ -463,10 +483,15 @@ func registerCommands(r commandRegistry) {
 	r.Register(storage.NewDetachStorageCommandWithAPI())
 	r.Register(storage.NewAttachStorageCommandWithAPI())
 	r.Register(storage.NewImportFilesystemCommand(storage.NewStorageImporter, nil))
+	r.Register(storage.NewSnapshotCreateCommand())
+	r.Register(storage.NewSnapshotListCommand())
+	r.Register(storage.NewSnapshotRestoreCommand())
 
 	// Manage spaces
 	r.Register(space.NewAddCommand())
+	r.Register(space.NewCheckCommand())
 	r.Register(space.NewListCommand())
+	r.Register(space.NewMergeCommand())
 	r.Register(space.NewMoveCommand())
 	r.Register(space.NewReloadCommand())
 	r.Register(space.NewShowSpaceCommand())
@@ -476,6 +501,7 @@ func registerCommands(r commandRegistry) {
 	// Manage subnets
 	r.Register(subnet.NewAddCommand())
 	r.Register(subnet.NewListCommand())
+	r.Register(subnet.NewReloadCommand())
 
 	// Manage controllers
 	r.Register(controller.NewAddModelCommand())
@@ -488,6 +514,10 @@ func registerCommands(r commandRegistry) {
 	r.Register(controller.NewEnableDestroyControllerCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewConfigCommand())
+	r.Register(controller.NewInventoryReportCommand())
+	r.Register(controller.NewCheckOrphansCommand())
+	r.Register(controller.NewVerifyControllerCommand())
+	r.Register(controller.NewDrainControllerCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
@@ -517,6 +547,7 @@ func registerCommands(r commandRegistry) {
 	r.Register(caas.NewAddCAASCommand(&cloudToCommandAdapter{}))
 	r.Register(caas.NewUpdateCAASCommand(&cloudToCommandAdapter{}))
 	r.Register(caas.NewRemoveCAASCommand(&cloudToCommandAdapter{}))
+	r.Register(caas.NewDetectLocalK8sCommand(&cloudToCommandAdapter{}))
 	r.Register(application.NewScaleApplicationCommand())
 
 	// Manage Application Credential Access
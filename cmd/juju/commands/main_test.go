@@ -298,6 +298,7 @@ var commandNames = []string{
 	"add-subnet",
 	"add-unit",
 	"add-user",
+	"agents",
 	"agree",
 	"agreements",
 	"attach",
@@ -313,6 +314,9 @@ var commandNames = []string{
 	"change-user-password",
 	"charm",
 	"charm-resources",
+	"check-orphans",
+	"check-spaces",
+	"clone-model",
 	"clouds",
 	"collect-metrics",
 	"config",
@@ -320,10 +324,13 @@ var commandNames = []string{
 	"controller-config",
 	"controllers",
 	"create-backup",
+	"create-bootstrap-bundle",
 	"create-storage-pool",
+	"create-storage-snapshot",
 	"create-wallet",
 	"credentials",
 	"dashboard",
+	"debug-agent",
 	"debug-code",
 	"debug-hook",
 	"debug-hooks",
@@ -334,12 +341,15 @@ var commandNames = []string{
 	"destroy-controller",
 	"destroy-model",
 	"detach-storage",
+	"detect-local-k8s",
 	"diff-bundle",
 	"disable-command",
 	"disable-user",
 	"disabled-commands",
 	"download",
 	"download-backup",
+	"drain-controller",
+	"drain-unit",
 	"enable-command",
 	"enable-destroy-controller",
 	"enable-ha",
@@ -349,6 +359,7 @@ var commandNames = []string{
 	"expose",
 	"find",
 	"find-offers",
+	"firewall-audit",
 	"firewall-rules",
 	"get-constraints",
 	"get-model-constraints",
@@ -361,6 +372,7 @@ var commandNames = []string{
 	"import-filesystem",
 	"import-ssh-key",
 	"info",
+	"inventory-report",
 	"kill-controller",
 	"list-actions",
 	"list-agreements",
@@ -390,12 +402,14 @@ var commandNames = []string{
 	"login",
 	"logout",
 	"machines",
+	"merge-spaces",
 	"metrics",
 	"migrate",
 	"model-config",
 	"model-default",
 	"model-defaults",
 	"models",
+	"move-subnet",
 	"move-to-space",
 	"offer",
 	"offers",
@@ -403,10 +417,13 @@ var commandNames = []string{
 	"payloads",
 	"plans",
 	"refresh",
+	"refresh-machine-hardware",
 	"regions",
 	"register",
 	"relate", //alias for add-relation
+	"relations",
 	"reload-spaces",
+	"reload-subnets",
 	"remove-application",
 	"remove-backup",
 	"remove-cached-images",
@@ -425,9 +442,12 @@ var commandNames = []string{
 	"remove-unit",
 	"remove-user",
 	"rename-space",
+	"replace-machine",
 	"resolved",
 	"resolve",
+	"resize-machine",
 	"resources",
+	"restore-storage-snapshot",
 	"resume-relation",
 	"retry-provisioning",
 	"revoke",
@@ -439,6 +459,8 @@ var commandNames = []string{
 	"set-constraints",
 	"set-default-credential",
 	"set-default-region",
+	"set-default-storage",
+	"set-egress-nat-address",
 	"set-firewall-rule",
 	"set-meter-status",
 	"set-model-constraints",
@@ -456,6 +478,7 @@ var commandNames = []string{
 	"show-model",
 	"show-offer",
 	"show-operation",
+	"show-relation",
 	"show-status",
 	"show-status-log",
 	"show-storage",
@@ -471,11 +494,13 @@ var commandNames = []string{
 	"status",
 	"storage",
 	"storage-pools",
+	"storage-snapshots",
 	"subnets",
 	"suspend-relation",
 	"switch",
 	"sync-agent-binaries",
 	"sync-tools",
+	"transfer-model-ownership",
 	"trust",
 	"unexpose",
 	"unregister",
@@ -493,6 +518,8 @@ var commandNames = []string{
 	"upgrade-series",
 	"upload-backup",
 	"users",
+	"verify-backup",
+	"verify-controller",
 	"version",
 	"wallets",
 	"whoami",
@@ -501,11 +528,13 @@ var commandNames = []string{
 // optionalFeatures are feature flags that impact registration of commands.
 var optionalFeatures = []string{
 	feature.ActionsV2,
+	feature.ModelSpec,
 }
 
 // These are the commands that are behind the `devFeatures`.
 var commandNamesBehindFlags = set.NewStrings(
 	"run", "show-task", "operations", "list-operations", "show-operation",
+	"apply", "show-model-spec", "remove-model-spec",
 )
 
 func (s *MainSuite) TestHelpCommands(c *gc.C) {
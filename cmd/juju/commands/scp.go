@@ -64,6 +64,25 @@ files between machines, you can use the -3 option to scp, e.g. add "-- -3"
 to the command-line arguments.
 
 
+Synchronising directories
+
+The --sync option copies the contents of a local directory to a directory on
+a machine or unit, or vice versa, transferring only those files whose SHA-256
+checksums differ between the two sides. Files which already exist at the
+destination are updated by sending only their changed 1MiB blocks. Each
+transferred file is verified once copied, and failed transfers are retried
+in full (see --retries). This makes it practical to repeatedly push large
+charm payloads, or collect logs from many units, without copying unchanged
+content. Exactly one of <source> and <destination> must be remote, and extra
+scp arguments cannot be passed.
+
+The remote side may name several machines or units separated by commas.
+Uploads copy the same directory to each of them; downloads copy each
+target's directory into a subdirectory of <destination> named after the
+target, such as "mysql-0" for unit mysql/0. Remote paths starting with ~
+are relative to the home directory of the remote user.
+
+
 Security considerations
 
 To enable transfers to/from machines that do not have internet access, you can use
@@ -103,6 +122,14 @@ Examples:
     # (-- -3):
     juju scp -- -3 0:file.dat foo/0:
 
+    # Synchronise the local payload directory with /srv/payload on the
+    # mysql/0 unit, copying only files that have changed:
+    juju scp --sync payload mysql/0:/srv/payload
+
+    # Collect the charm logs directory from two units into logs/mysql-0
+    # and logs/mysql-1:
+    juju scp --sync mysql/0,mysql/1:~/charm-logs logs
+
 See also: 
 	ssh
 `
@@ -125,11 +152,20 @@ type scpCommand struct {
 	provider sshProvider
 
 	hostChecker jujussh.ReachableChecker
+
+	sync    bool
+	retries int
 }
 
+// defaultSyncRetries is the number of times a file transfer is retried
+// in --sync mode before giving up.
+const defaultSyncRetries = 3
+
 func (c *scpCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.sshMachine.SetFlags(f)
 	c.sshContainer.SetFlags(f)
+	f.BoolVar(&c.sync, "sync", false, "Only transfer files whose checksums differ, verifying each transfer")
+	f.IntVar(&c.retries, "retries", defaultSyncRetries, "Number of times to retry a failed transfer in --sync mode")
 }
 
 func (c *scpCommand) Info() *cmd.Info {
@@ -148,7 +184,13 @@ func (c *scpCommand) Init(args []string) (err error) {
 	if c.modelType, err = c.ModelType(); err != nil {
 		return err
 	}
+	if c.retries < 0 {
+		return errors.Errorf("--retries must not be negative")
+	}
 	if c.modelType == model.CAAS {
+		if c.sync {
+			return errors.NotSupportedf("--sync on a container model")
+		}
 		c.provider = &c.sshContainer
 	} else {
 		c.provider = &c.sshMachine
@@ -166,5 +208,8 @@ func (c *scpCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 	defer c.provider.cleanupRun()
+	if c.sync {
+		return c.sshMachine.syncCopy(ctx, c.retries)
+	}
 	return c.provider.copy(ctx)
 }
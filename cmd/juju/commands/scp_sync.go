@@ -0,0 +1,606 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/utils/v2"
+	"github.com/juju/utils/v2/ssh"
+)
+
+// syncEndpoint is one side of a synchronised copy: either a local
+// directory (targets is empty) or the same directory on one or more
+// machines or units.
+type syncEndpoint struct {
+	targets []string
+	path    string
+}
+
+func (e syncEndpoint) isRemote() bool {
+	return len(e.targets) > 0
+}
+
+// parseSyncArgs splits the scp arguments into a source and destination
+// endpoint. Exactly one of the endpoints must be remote, and passing
+// additional arguments through to scp is not supported. The remote
+// endpoint may name several targets separated by commas.
+func parseSyncArgs(args []string) (syncEndpoint, syncEndpoint, error) {
+	var endpoints []syncEndpoint
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			return syncEndpoint{}, syncEndpoint{}, errors.Errorf("--sync does not support extra scp arguments (got %q)", arg)
+		}
+		v := strings.SplitN(arg, ":", 2)
+		if len(v) <= 1 {
+			endpoints = append(endpoints, syncEndpoint{path: arg})
+			continue
+		}
+		targets := strings.Split(v[0], ",")
+		seen := set.NewStrings()
+		for _, target := range targets {
+			if target == "" {
+				return syncEndpoint{}, syncEndpoint{}, errors.Errorf("--sync target list %q contains an empty target", v[0])
+			}
+			if seen.Contains(target) {
+				return syncEndpoint{}, syncEndpoint{}, errors.Errorf("--sync target %q repeated", target)
+			}
+			seen.Add(target)
+		}
+		endpoints = append(endpoints, syncEndpoint{targets: targets, path: v[1]})
+	}
+	if len(endpoints) != 2 {
+		return syncEndpoint{}, syncEndpoint{}, errors.Errorf("--sync requires exactly one source and one destination")
+	}
+	src, dst := endpoints[0], endpoints[1]
+	if src.isRemote() == dst.isRemote() {
+		return syncEndpoint{}, syncEndpoint{}, errors.Errorf("--sync requires exactly one of source and destination to be remote")
+	}
+	if src.path == "" || dst.path == "" {
+		// An empty remote path refers to the home directory of the
+		// remote user, as with scp.
+		if src.isRemote() && src.path == "" {
+			src.path = "."
+		} else if dst.isRemote() && dst.path == "" {
+			dst.path = "."
+		} else {
+			return syncEndpoint{}, syncEndpoint{}, errors.Errorf("--sync requires a local directory")
+		}
+	}
+	return src, dst, nil
+}
+
+// targetDirName returns the name of the local directory that files
+// from the given target are copied into, when copying from several
+// targets at once. A unit "mysql/0" is copied into "mysql-0".
+func targetDirName(target string) string {
+	_, entity := splitUserTarget(target)
+	return strings.Replace(entity, "/", "-", -1)
+}
+
+// homePrefix matches the tilde prefix of a remote path, which the
+// remote shell expands to a home directory.
+var homePrefix = regexp.MustCompile(`^~[a-zA-Z0-9._-]*(/|$)`)
+
+// shellPath quotes a remote path for use in a shell script. A leading
+// tilde prefix is left unquoted so that the remote shell still expands
+// it.
+func shellPath(p string) string {
+	home := homePrefix.FindString(p)
+	if home == "" {
+		return utils.ShQuote(p)
+	}
+	if rest := p[len(home):]; rest != "" {
+		return home + utils.ShQuote(rest)
+	}
+	return home
+}
+
+// fileChecksums maps a slash separated path, relative to the root of a
+// synchronised directory, to the hex encoded SHA-256 of its content.
+type fileChecksums map[string]string
+
+// parseChecksums parses the output of sha256sum run over a directory
+// tree, as produced by remoteChecksumScript.
+func parseChecksums(r io.Reader) (fileChecksums, error) {
+	sums := make(fileChecksums)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		// sha256sum prefixes the line with a backslash when it has
+		// escaped backslashes and newlines in the file name.
+		escaped := strings.HasPrefix(line, `\`)
+		if escaped {
+			line = line[1:]
+		}
+		// sha256sum separates the digest and file name with two
+		// characters: a space and a mode indicator.
+		if len(line) < 67 || line[64] != ' ' {
+			return nil, errors.Errorf("unexpected checksum line %q", line)
+		}
+		name := line[66:]
+		if escaped {
+			var err error
+			if name, err = unescapeChecksumName(name); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		sums[strings.TrimPrefix(name, "./")] = line[:64]
+	}
+	return sums, errors.Trace(scanner.Err())
+}
+
+// unescapeChecksumName reverses the escaping of a file name by
+// sha256sum, which writes backslashes as "\\", newlines as "\n" and
+// carriage returns as "\r".
+func unescapeChecksumName(name string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		if name[i] != '\\' {
+			b.WriteByte(name[i])
+			continue
+		}
+		i++
+		if i == len(name) {
+			return "", errors.Errorf("unexpected escape at end of file name %q", name)
+		}
+		switch name[i] {
+		case '\\':
+			b.WriteByte('\\')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		default:
+			return "", errors.Errorf("unexpected escape %q in file name %q", name[i-1:i+1], name)
+		}
+	}
+	return b.String(), nil
+}
+
+// localChecksums walks the directory at root, returning the checksums
+// of every regular file found.
+func localChecksums(root string) (fileChecksums, error) {
+	sums := make(fileChecksums)
+	err := filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if os.IsNotExist(err) && p == root {
+			return filepath.SkipDir
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		sum, err := localChecksum(p)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		sums[filepath.ToSlash(rel)] = sum
+		return nil
+	})
+	return sums, errors.Trace(err)
+}
+
+func localChecksum(p string) (string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", errors.Trace(err)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// changedFiles returns the sorted paths of the source files which are
+// missing from, or differ in, the destination.
+func changedFiles(src, dst fileChecksums) []string {
+	var changed []string
+	for name, sum := range src {
+		if dst[name] != sum {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// remoteChecksumScript returns a shell script which prints the
+// checksums of all files below dir. A missing directory results in
+// no output rather than an error, so that the first sync to a new
+// location transfers everything.
+func remoteChecksumScript(dir string) string {
+	return fmt.Sprintf("cd %s 2>/dev/null || exit 0; find . -type f -exec sha256sum {} +", shellPath(dir))
+}
+
+// deltaBlockSize is the size of the blocks compared when transferring
+// only the changed parts of a file.
+const deltaBlockSize = 1 << 20
+
+// blockRange is a run of consecutive blocks of a file.
+type blockRange struct {
+	start, count int64
+}
+
+// remoteBlockChecksumScript returns a shell script which prints the size
+// of file, followed by the checksum of each of its blocks.
+func remoteBlockChecksumScript(file string) string {
+	return fmt.Sprintf(
+		`f=%s; size=$(stat -c %%s "$f") || exit 1; echo "$size"; i=0; `+
+			`while [ $((i * %d)) -lt "$size" ]; do `+
+			`dd if="$f" bs=%d skip=$i count=1 2>/dev/null | sha256sum | cut -d' ' -f1; i=$((i + 1)); done`,
+		shellPath(file), deltaBlockSize, deltaBlockSize)
+}
+
+// parseBlockChecksums parses the output of remoteBlockChecksumScript.
+func parseBlockChecksums(r io.Reader) (int64, []string, error) {
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return 0, nil, errors.Trace(err)
+		}
+		return 0, nil, errors.New("missing file size")
+	}
+	size, err := strconv.ParseInt(strings.TrimSpace(scanner.Text()), 10, 64)
+	if err != nil {
+		return 0, nil, errors.Annotate(err, "parsing file size")
+	}
+	var sums []string
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) != 64 {
+			return 0, nil, errors.Errorf("unexpected block checksum %q", line)
+		}
+		sums = append(sums, line)
+	}
+	return size, sums, errors.Trace(scanner.Err())
+}
+
+// localBlockChecksums returns the size of the file at p, and the
+// checksum of each of its blocks.
+func localBlockChecksums(p string) (int64, []string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return 0, nil, errors.Trace(err)
+	}
+	defer f.Close()
+	var (
+		size int64
+		sums []string
+	)
+	for {
+		h := sha256.New()
+		n, err := io.CopyN(h, f, deltaBlockSize)
+		if n > 0 {
+			size += n
+			sums = append(sums, fmt.Sprintf("%x", h.Sum(nil)))
+		}
+		if err == io.EOF {
+			return size, sums, nil
+		} else if err != nil {
+			return 0, nil, errors.Trace(err)
+		}
+	}
+}
+
+// changedBlocks returns the runs of source blocks which are missing
+// from, or differ in, the destination.
+func changedBlocks(src, dst []string) []blockRange {
+	var ranges []blockRange
+	for i := range src {
+		if i < len(dst) && dst[i] == src[i] {
+			continue
+		}
+		if n := len(ranges); n > 0 && ranges[n-1].start+ranges[n-1].count == int64(i) {
+			ranges[n-1].count++
+			continue
+		}
+		ranges = append(ranges, blockRange{start: int64(i), count: 1})
+	}
+	return ranges
+}
+
+// offsetWriter writes to a file sequentially from an offset.
+type offsetWriter struct {
+	f      *os.File
+	offset int64
+}
+
+func (w *offsetWriter) Write(p []byte) (int, error) {
+	n, err := w.f.WriteAt(p, w.offset)
+	w.offset += int64(n)
+	return n, err
+}
+
+// syncCopy copies the directory tree at the source to the destination,
+// transferring only the files whose checksums differ. Files which
+// already exist at the destination are first updated by sending only
+// their changed blocks. Each transferred file is verified after the
+// copy, and retried in full up to the given number of times on failure.
+// When copying from several targets, each target's files are copied
+// into a subdirectory of the destination named after the target.
+func (c *sshMachine) syncCopy(ctx Context, retries int) error {
+	src, dst, err := parseSyncArgs(c.getArgs())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	remote := src
+	if dst.isRemote() {
+		remote = dst
+	}
+	if len(remote.targets) == 1 {
+		return errors.Trace(c.syncTarget(ctx, remote.targets[0], src, dst, retries))
+	}
+
+	var failed []string
+	for _, target := range remote.targets {
+		targetSrc, targetDst := src, dst
+		if src.isRemote() {
+			targetDst.path = filepath.Join(dst.path, targetDirName(target))
+		}
+		fmt.Fprintf(ctx.GetStderr(), "%s: ", target)
+		if err := c.syncTarget(ctx, target, targetSrc, targetDst, retries); err != nil {
+			fmt.Fprintf(ctx.GetStderr(), "%v\n", err)
+			failed = append(failed, target)
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("--sync failed for %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// syncTarget synchronises the source and destination directories, one
+// of which is on the given target.
+func (c *sshMachine) syncTarget(ctx Context, target string, src, dst syncEndpoint, retries int) error {
+	resolved, err := c.resolveTarget(target)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	options, err := c.getSSHOptions(false, resolved)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	s := &syncer{
+		target:  resolved,
+		options: options,
+	}
+
+	remotePath := src.path
+	if dst.isRemote() {
+		remotePath = dst.path
+	}
+	remoteSums, err := s.remoteChecksums(remotePath)
+	if err != nil {
+		return errors.Annotatef(err, "reading checksums from %q", target)
+	}
+	var srcSums, dstSums fileChecksums
+	if src.isRemote() {
+		if dstSums, err = localChecksums(dst.path); err != nil {
+			return errors.Trace(err)
+		}
+		srcSums = remoteSums
+	} else {
+		if srcSums, err = localChecksums(src.path); err != nil {
+			return errors.Trace(err)
+		}
+		dstSums = remoteSums
+	}
+
+	changed := changedFiles(srcSums, dstSums)
+	if dst.isRemote() {
+		if err := s.makeRemoteDirs(dst.path, changed); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for _, name := range changed {
+		_, exists := dstSums[name]
+		var err error
+		for attempt := 0; attempt <= retries; attempt++ {
+			if attempt > 0 {
+				logger.Debugf("retrying transfer of %q (attempt %d): %v", name, attempt+1, err)
+			}
+			// Only the first attempt sends changed blocks; a retry
+			// copies the whole file in case the delta was the problem.
+			delta := exists && attempt == 0
+			if src.isRemote() {
+				err = s.download(path.Join(src.path, name), filepath.Join(dst.path, filepath.FromSlash(name)), srcSums[name], delta)
+			} else {
+				err = s.upload(filepath.Join(src.path, filepath.FromSlash(name)), path.Join(dst.path, name), srcSums[name], delta)
+			}
+			if err == nil {
+				break
+			}
+		}
+		if err != nil {
+			return errors.Annotatef(err, "transferring %q", name)
+		}
+	}
+	fmt.Fprintf(ctx.GetStderr(), "%d file(s) transferred, %d unchanged\n", len(changed), len(srcSums)-len(changed))
+	return nil
+}
+
+// syncer performs the individual ssh operations of a synchronised copy.
+type syncer struct {
+	target  *resolvedTarget
+	options *ssh.Options
+}
+
+func (s *syncer) command(script string) *ssh.Cmd {
+	return ssh.Command(s.target.userHost(), []string{"bash", "-c", utils.ShQuote(script)}, s.options)
+}
+
+func (s *syncer) run(script string) ([]byte, error) {
+	return s.command(script).Output()
+}
+
+func (s *syncer) remoteChecksums(dir string) (fileChecksums, error) {
+	out, err := s.run(remoteChecksumScript(dir))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return parseChecksums(strings.NewReader(string(out)))
+}
+
+func (s *syncer) remoteBlockChecksums(file string) (int64, []string, error) {
+	out, err := s.run(remoteBlockChecksumScript(file))
+	if err != nil {
+		return 0, nil, errors.Trace(err)
+	}
+	return parseBlockChecksums(strings.NewReader(string(out)))
+}
+
+func (s *syncer) makeRemoteDirs(root string, names []string) error {
+	dirs := make(map[string]bool)
+	for _, name := range names {
+		dirs[path.Join(root, path.Dir(name))] = true
+	}
+	if len(dirs) == 0 {
+		return nil
+	}
+	quoted := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		quoted = append(quoted, shellPath(dir))
+	}
+	sort.Strings(quoted)
+	_, err := s.run("mkdir -p " + strings.Join(quoted, " "))
+	return errors.Annotate(err, "creating remote directories")
+}
+
+func (s *syncer) remoteArg(p string) string {
+	arg := net.JoinHostPort(s.target.host, p)
+	if s.target.user != "" {
+		arg = s.target.user + "@" + arg
+	}
+	return arg
+}
+
+func (s *syncer) upload(local, remote, sum string, delta bool) error {
+	var err error
+	if delta {
+		err = s.uploadDelta(local, remote)
+	} else {
+		err = ssh.Copy([]string{local, s.remoteArg(remote)}, s.options)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The file is read from stdin so that its name, which sha256sum
+	// might escape, is not in the output.
+	out, err := s.run("sha256sum < " + shellPath(remote))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if got := strings.TrimSpace(string(out)); !strings.HasPrefix(got, sum+" ") {
+		return errors.Errorf("checksum mismatch: expected %s, got %q", sum, got)
+	}
+	return nil
+}
+
+// uploadDelta updates the existing remote file with the blocks of the
+// local file that differ from it.
+func (s *syncer) uploadDelta(local, remote string) error {
+	remoteSize, remoteSums, err := s.remoteBlockChecksums(remote)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	localSize, localSums, err := localBlockChecksums(local)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	f, err := os.Open(local)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	for _, blocks := range changedBlocks(localSums, remoteSums) {
+		cmd := s.command(fmt.Sprintf(
+			"dd of=%s bs=%d seek=%d conv=notrunc iflag=fullblock 2>/dev/null",
+			shellPath(remote), deltaBlockSize, blocks.start))
+		cmd.Stdin = io.NewSectionReader(f, blocks.start*deltaBlockSize, blocks.count*deltaBlockSize)
+		if err := cmd.Run(); err != nil {
+			return errors.Annotatef(err, "sending blocks %d-%d", blocks.start, blocks.start+blocks.count-1)
+		}
+	}
+	if localSize != remoteSize {
+		if _, err := s.run(fmt.Sprintf("truncate -s %d %s", localSize, shellPath(remote))); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (s *syncer) download(remote, local, sum string, delta bool) error {
+	if err := os.MkdirAll(filepath.Dir(local), 0755); err != nil {
+		return errors.Trace(err)
+	}
+	var err error
+	if delta {
+		err = s.downloadDelta(remote, local)
+	} else {
+		err = ssh.Copy([]string{s.remoteArg(remote), local}, s.options)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	got, err := localChecksum(local)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if got != sum {
+		return errors.Errorf("checksum mismatch: expected %s, got %s", sum, got)
+	}
+	return nil
+}
+
+// downloadDelta updates the existing local file with the blocks of the
+// remote file that differ from it.
+func (s *syncer) downloadDelta(remote, local string) error {
+	remoteSize, remoteSums, err := s.remoteBlockChecksums(remote)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, localSums, err := localBlockChecksums(local)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	f, err := os.OpenFile(local, os.O_WRONLY, 0)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	for _, blocks := range changedBlocks(remoteSums, localSums) {
+		cmd := s.command(fmt.Sprintf(
+			"dd if=%s bs=%d skip=%d count=%d 2>/dev/null",
+			shellPath(remote), deltaBlockSize, blocks.start, blocks.count))
+		cmd.Stdout = &offsetWriter{f: f, offset: blocks.start * deltaBlockSize}
+		if err := cmd.Run(); err != nil {
+			return errors.Annotatef(err, "receiving blocks %d-%d", blocks.start, blocks.start+blocks.count-1)
+		}
+	}
+	return errors.Trace(f.Truncate(remoteSize))
+}
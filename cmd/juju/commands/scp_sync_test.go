@@ -0,0 +1,211 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type SCPSyncSuite struct{}
+
+var _ = gc.Suite(&SCPSyncSuite{})
+
+func (s *SCPSyncSuite) TestParseSyncArgs(c *gc.C) {
+	src, dst, err := parseSyncArgs([]string{"payload", "mysql/0:/srv/payload"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(src, jc.DeepEquals, syncEndpoint{path: "payload"})
+	c.Check(dst, jc.DeepEquals, syncEndpoint{targets: []string{"mysql/0"}, path: "/srv/payload"})
+
+	src, dst, err = parseSyncArgs([]string{"0:", "logs"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(src, jc.DeepEquals, syncEndpoint{targets: []string{"0"}, path: "."})
+	c.Check(dst, jc.DeepEquals, syncEndpoint{path: "logs"})
+
+	src, dst, err = parseSyncArgs([]string{"mysql/0,ubuntu@mysql/1:~/logs", "logs"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(src, jc.DeepEquals, syncEndpoint{targets: []string{"mysql/0", "ubuntu@mysql/1"}, path: "~/logs"})
+	c.Check(dst, jc.DeepEquals, syncEndpoint{path: "logs"})
+}
+
+func (s *SCPSyncSuite) TestParseSyncArgsErrors(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"a", "0:b", "-r"},
+		err:  `--sync does not support extra scp arguments \(got "-r"\)`,
+	}, {
+		args: []string{"a", "b", "0:c"},
+		err:  "--sync requires exactly one source and one destination",
+	}, {
+		args: []string{"0:a", "1:b"},
+		err:  "--sync requires exactly one of source and destination to be remote",
+	}, {
+		args: []string{"a", "b"},
+		err:  "--sync requires exactly one of source and destination to be remote",
+	}, {
+		args: []string{"a", "0,,1:b"},
+		err:  `--sync target list "0,,1" contains an empty target`,
+	}, {
+		args: []string{"a", "0,0:b"},
+		err:  `--sync target "0" repeated`,
+	}} {
+		_, _, err := parseSyncArgs(test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *SCPSyncSuite) TestTargetDirName(c *gc.C) {
+	c.Check(targetDirName("mysql/0"), gc.Equals, "mysql-0")
+	c.Check(targetDirName("ubuntu@mysql/1"), gc.Equals, "mysql-1")
+	c.Check(targetDirName("2"), gc.Equals, "2")
+}
+
+func (s *SCPSyncSuite) TestShellPath(c *gc.C) {
+	for _, test := range []struct {
+		path, quoted string
+	}{
+		{"/srv/payload", "'/srv/payload'"},
+		{"it's", `'it'"'"'s'`},
+		{"~", "~"},
+		{"~/", "~/"},
+		{"~/my dir", "~/'my dir'"},
+		{"~ubuntu/logs", "~ubuntu/'logs'"},
+		{"~$(id)/x", `'~$(id)/x'`},
+		{"a/~/b", "'a/~/b'"},
+	} {
+		c.Check(shellPath(test.path), gc.Equals, test.quoted, gc.Commentf("path %q", test.path))
+	}
+}
+
+func (s *SCPSyncSuite) TestParseChecksums(c *gc.C) {
+	sumA := strings.Repeat("a", 64)
+	sumB := strings.Repeat("b", 64)
+	sums, err := parseChecksums(strings.NewReader(sumA + "  ./foo\n" + sumB + " *./dir/bar baz\n"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sums, jc.DeepEquals, fileChecksums{
+		"foo":         sumA,
+		"dir/bar baz": sumB,
+	})
+
+	_, err = parseChecksums(strings.NewReader("garbage\n"))
+	c.Assert(err, gc.ErrorMatches, `unexpected checksum line "garbage"`)
+}
+
+func (s *SCPSyncSuite) TestParseChecksumsEscapedNames(c *gc.C) {
+	sumA := strings.Repeat("a", 64)
+	sumB := strings.Repeat("b", 64)
+	sumC := strings.Repeat("c", 64)
+	sums, err := parseChecksums(strings.NewReader(
+		`\` + sumA + `  ./back\\slash` + "\n" +
+			`\` + sumB + `  ./new\nline\\n` + "\n" +
+			sumC + `  ./plain` + "\n",
+	))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sums, jc.DeepEquals, fileChecksums{
+		`back\slash`:   sumA,
+		"new\nline\\n": sumB,
+		"plain":        sumC,
+	})
+
+	_, err = parseChecksums(strings.NewReader(`\` + sumA + `  ./bad\x` + "\n"))
+	c.Assert(err, gc.ErrorMatches, `unexpected escape "\\\\x" in file name .*`)
+}
+
+func (s *SCPSyncSuite) TestLocalChecksums(c *gc.C) {
+	dir := c.MkDir()
+	err := os.MkdirAll(filepath.Join(dir, "sub"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(dir, "sub", "hello"), []byte("hello\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	sums, err := localChecksums(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sums, jc.DeepEquals, fileChecksums{
+		"sub/hello": "5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03",
+	})
+
+	sums, err = localChecksums(filepath.Join(dir, "missing"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sums, gc.HasLen, 0)
+}
+
+func (s *SCPSyncSuite) TestChangedFiles(c *gc.C) {
+	changed := changedFiles(
+		fileChecksums{"a": "1", "b": "2", "c": "3"},
+		fileChecksums{"a": "1", "b": "x", "d": "4"},
+	)
+	c.Assert(changed, jc.DeepEquals, []string{"b", "c"})
+}
+
+func (s *SCPSyncSuite) TestParseBlockChecksums(c *gc.C) {
+	sumA := strings.Repeat("a", 64)
+	sumB := strings.Repeat("b", 64)
+	size, sums, err := parseBlockChecksums(strings.NewReader("1048577\n" + sumA + "\n" + sumB + "\n"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(size, gc.Equals, int64(1048577))
+	c.Check(sums, jc.DeepEquals, []string{sumA, sumB})
+
+	_, _, err = parseBlockChecksums(strings.NewReader(""))
+	c.Check(err, gc.ErrorMatches, "missing file size")
+	_, _, err = parseBlockChecksums(strings.NewReader("12\nshort\n"))
+	c.Check(err, gc.ErrorMatches, `unexpected block checksum "short"`)
+}
+
+func (s *SCPSyncSuite) TestLocalBlockChecksums(c *gc.C) {
+	p := filepath.Join(c.MkDir(), "blocks")
+	data := append(bytes.Repeat([]byte{'x'}, deltaBlockSize), "hello\n"...)
+	err := ioutil.WriteFile(p, data, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	size, sums, err := localBlockChecksums(p)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(size, gc.Equals, int64(len(data)))
+	c.Assert(sums, gc.HasLen, 2)
+	c.Check(sums[1], gc.Equals, "5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03")
+
+	err = ioutil.WriteFile(p, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	size, sums, err = localBlockChecksums(p)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(size, gc.Equals, int64(0))
+	c.Check(sums, gc.HasLen, 0)
+}
+
+func (s *SCPSyncSuite) TestChangedBlocks(c *gc.C) {
+	changed := changedBlocks(
+		[]string{"a", "b", "c", "d", "e", "f"},
+		[]string{"a", "x", "y", "d", "e"},
+	)
+	c.Assert(changed, jc.DeepEquals, []blockRange{
+		{start: 1, count: 2},
+		{start: 5, count: 1},
+	})
+	c.Assert(changedBlocks([]string{"a"}, []string{"a", "b"}), gc.HasLen, 0)
+}
+
+func (s *SCPSyncSuite) TestOffsetWriter(c *gc.C) {
+	p := filepath.Join(c.MkDir(), "file")
+	err := ioutil.WriteFile(p, []byte("0123456789"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	f, err := os.OpenFile(p, os.O_WRONLY, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	w := &offsetWriter{f: f, offset: 3}
+	_, err = w.Write([]byte("ab"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = w.Write([]byte("cd"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(f.Close(), jc.ErrorIsNil)
+
+	data, err := ioutil.ReadFile(p)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "012abcd789")
+}
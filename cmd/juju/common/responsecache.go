@@ -0,0 +1,158 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/utils/v2"
+
+	"github.com/juju/juju/juju/osenv"
+)
+
+// ResponseCache caches the responses to read-only API calls on disk, so
+// that scripts running commands such as status every second don't each
+// cost the controller a full response. Responses younger than the TTL
+// are reused without contacting the controller. Older responses are
+// revalidated with the controller, for calls which support revnos.
+type ResponseCache struct {
+	dir   string
+	ttl   time.Duration
+	clock clock.Clock
+}
+
+// NewResponseCache returns a ResponseCache which stores responses in
+// the specified directory and reuses them for the TTL given.
+func NewResponseCache(dir string, ttl time.Duration, clock clock.Clock) *ResponseCache {
+	return &ResponseCache{
+		dir:   dir,
+		ttl:   ttl,
+		clock: clock,
+	}
+}
+
+// ResponseCacheFromEnvironment returns the ResponseCache configured by
+// the JUJU_API_CACHE_TTL environment variable, or nil if it isn't set,
+// in which case responses are not cached.
+func ResponseCacheFromEnvironment() (*ResponseCache, error) {
+	value := os.Getenv(osenv.JujuAPICacheTTLEnvKey)
+	if value == "" {
+		return nil, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl < 0 {
+		return nil, errors.Errorf("invalid %s env var %q, expected a duration such as 2s", osenv.JujuAPICacheTTLEnvKey, value)
+	}
+	if ttl == 0 {
+		return nil, nil
+	}
+	return NewResponseCache(osenv.JujuXDGDataHomePath("cache", "responses"), ttl, clock.WallClock), nil
+}
+
+// CachedCall makes an API call on behalf of a ResponseCache. It is
+// passed the revno of the cached response, or zero if there is none.
+// It either fills in the response and returns its revno, or returns
+// notModified, without filling in the response, if the controller
+// reports that the cached response is still current. Calls which don't
+// support revnos always return a zero revno.
+type CachedCall func(knownRevno int64) (revno int64, notModified bool, err error)
+
+// cachedResponse is the form in which responses are cached on disk.
+type cachedResponse struct {
+	Revno    int64           `json:"revno,omitempty"`
+	Fetched  time.Time       `json:"fetched"`
+	Response json.RawMessage `json:"response"`
+}
+
+// Call fills in the response to the API call identified by key, which
+// must identify the controller, user, model and call arguments as well
+// as the call itself. A cached response younger than the TTL is used as
+// is; otherwise the call is made. A nil ResponseCache always makes the
+// call.
+func (c *ResponseCache) Call(key string, response interface{}, call CachedCall) error {
+	if c == nil {
+		_, _, err := call(0)
+		return errors.Trace(err)
+	}
+
+	path := c.path(key)
+	entry, found := c.read(path)
+	if found && c.clock.Now().Sub(entry.Fetched) < c.ttl {
+		if err := json.Unmarshal(entry.Response, response); err == nil {
+			return nil
+		}
+		found = false
+	}
+
+	var knownRevno int64
+	if found {
+		knownRevno = entry.Revno
+	}
+	revno, notModified, err := call(knownRevno)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if notModified {
+		if !found {
+			return errors.New("controller reported a response as not modified without a cached response")
+		}
+		if err := json.Unmarshal(entry.Response, response); err != nil {
+			return errors.Annotate(err, "reading cached response")
+		}
+	}
+	// Failing to cache the response doesn't fail the call.
+	if err := c.write(path, revno, response); err != nil {
+		logger.Warningf("caching response: %v", err)
+	}
+	return nil
+}
+
+func (c *ResponseCache) path(key string) string {
+	return filepath.Join(c.dir, fmt.Sprintf("%x.json", sha256.Sum256([]byte(key))))
+}
+
+// read returns the cached response at the specified path, if there is
+// a readable one.
+func (c *ResponseCache) read(path string) (cachedResponse, bool) {
+	var entry cachedResponse
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logger.Debugf("reading cached response: %v", err)
+		}
+		return entry, false
+	}
+	if err := json.Unmarshal(data, &entry); err != nil {
+		logger.Debugf("reading cached response %q: %v", path, err)
+		return entry, false
+	}
+	return entry, true
+}
+
+func (c *ResponseCache) write(path string, revno int64, response interface{}) error {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	data, err = json.Marshal(cachedResponse{
+		Revno:    revno,
+		Fetched:  c.clock.Now(),
+		Response: data,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(utils.AtomicWriteFile(path, data, 0600))
+}
@@ -0,0 +1,155 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/juju/osenv"
+)
+
+type responseCacheSuite struct {
+	testing.IsolationSuite
+
+	clock *testclock.Clock
+	cache *common.ResponseCache
+}
+
+var _ = gc.Suite(&responseCacheSuite{})
+
+type cachedThing struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func (s *responseCacheSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Now())
+	s.cache = common.NewResponseCache(c.MkDir(), 2*time.Second, s.clock)
+}
+
+// fetch returns a CachedCall which fills in the response with the
+// thing given, unless the known revno matches the revno given.
+func fetch(response *cachedThing, thing cachedThing, revno int64, calls *[]int64) common.CachedCall {
+	return func(knownRevno int64) (int64, bool, error) {
+		*calls = append(*calls, knownRevno)
+		if knownRevno != 0 && knownRevno == revno {
+			return revno, true, nil
+		}
+		*response = thing
+		return revno, false, nil
+	}
+}
+
+func (s *responseCacheSuite) TestCallCachesResponse(c *gc.C) {
+	var calls []int64
+	var result cachedThing
+	thing := cachedThing{Name: "foo", Count: 1}
+	err := s.cache.Call("key", &result, fetch(&result, thing, 42, &calls))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, thing)
+
+	s.clock.Advance(time.Second)
+	result = cachedThing{}
+	err = s.cache.Call("key", &result, fetch(&result, cachedThing{Name: "bar"}, 43, &calls))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, thing)
+	c.Assert(calls, jc.DeepEquals, []int64{0})
+}
+
+func (s *responseCacheSuite) TestCallRevalidatesExpiredResponse(c *gc.C) {
+	var calls []int64
+	var result cachedThing
+	thing := cachedThing{Name: "foo", Count: 1}
+	err := s.cache.Call("key", &result, fetch(&result, thing, 42, &calls))
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.clock.Advance(3 * time.Second)
+	result = cachedThing{}
+	err = s.cache.Call("key", &result, fetch(&result, cachedThing{Name: "bar"}, 42, &calls))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, thing)
+	c.Assert(calls, jc.DeepEquals, []int64{0, 42})
+
+	// The revalidated response is fresh again.
+	s.clock.Advance(time.Second)
+	err = s.cache.Call("key", &result, fetch(&result, cachedThing{Name: "bar"}, 43, &calls))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, thing)
+	c.Assert(calls, gc.HasLen, 2)
+}
+
+func (s *responseCacheSuite) TestCallRefetchesModifiedResponse(c *gc.C) {
+	var calls []int64
+	var result cachedThing
+	err := s.cache.Call("key", &result, fetch(&result, cachedThing{Name: "foo"}, 42, &calls))
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.clock.Advance(3 * time.Second)
+	changed := cachedThing{Name: "foo", Count: 2}
+	err = s.cache.Call("key", &result, fetch(&result, changed, 43, &calls))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, changed)
+	c.Assert(calls, jc.DeepEquals, []int64{0, 42})
+}
+
+func (s *responseCacheSuite) TestCallKeysResponses(c *gc.C) {
+	var calls []int64
+	var result cachedThing
+	err := s.cache.Call("key", &result, fetch(&result, cachedThing{Name: "foo"}, 42, &calls))
+	c.Assert(err, jc.ErrorIsNil)
+
+	other := cachedThing{Name: "bar"}
+	err = s.cache.Call("other", &result, fetch(&result, other, 42, &calls))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, other)
+	c.Assert(calls, jc.DeepEquals, []int64{0, 0})
+}
+
+func (s *responseCacheSuite) TestCallErrorNotCached(c *gc.C) {
+	var result cachedThing
+	err := s.cache.Call("key", &result, func(int64) (int64, bool, error) {
+		return 0, false, errors.New("boom")
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	var calls []int64
+	err = s.cache.Call("key", &result, fetch(&result, cachedThing{Name: "foo"}, 42, &calls))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(calls, jc.DeepEquals, []int64{0})
+}
+
+func (s *responseCacheSuite) TestNilCacheAlwaysCalls(c *gc.C) {
+	var cache *common.ResponseCache
+	var calls []int64
+	var result cachedThing
+	for i := 0; i < 2; i++ {
+		err := cache.Call("key", &result, fetch(&result, cachedThing{Name: "foo"}, 42, &calls))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(calls, jc.DeepEquals, []int64{0, 0})
+}
+
+func (s *responseCacheSuite) TestResponseCacheFromEnvironment(c *gc.C) {
+	s.PatchEnvironment(osenv.JujuAPICacheTTLEnvKey, "")
+	cache, err := common.ResponseCacheFromEnvironment()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cache, gc.IsNil)
+
+	s.PatchEnvironment(osenv.JujuAPICacheTTLEnvKey, "2s")
+	cache, err = common.ResponseCacheFromEnvironment()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cache, gc.NotNil)
+
+	s.PatchEnvironment(osenv.JujuAPICacheTTLEnvKey, "soon")
+	_, err = common.ResponseCacheFromEnvironment()
+	c.Assert(err, gc.ErrorMatches, `invalid JUJU_API_CACHE_TTL env var "soon", expected a duration such as 2s`)
+}
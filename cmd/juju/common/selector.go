@@ -0,0 +1,35 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// AnnotationsQueryAPI is the annotations API used to select entities
+// by their annotations.
+type AnnotationsQueryAPI interface {
+	Query(selector string, kinds ...string) ([]params.EntityAnnotations, error)
+}
+
+// EntityIdsBySelector returns the ids of the entities of the given tag
+// kinds, such as "unit", whose annotations match the selector.
+func EntityIdsBySelector(api AnnotationsQueryAPI, selector string, kinds ...string) ([]string, error) {
+	results, err := api.Query(selector, kinds...)
+	if err != nil {
+		return nil, errors.Annotatef(err, "selecting entities matching %q", selector)
+	}
+	ids := make([]string, len(results))
+	for i, result := range results {
+		tag, err := names.ParseTag(result.EntityTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ids[i] = tag.Id()
+	}
+	return ids, nil
+}
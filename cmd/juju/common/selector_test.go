@@ -0,0 +1,48 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+)
+
+type selectorSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&selectorSuite{})
+
+type fakeAnnotationsQueryAPI struct {
+	testing.Stub
+	results []params.EntityAnnotations
+}
+
+func (f *fakeAnnotationsQueryAPI) Query(selector string, kinds ...string) ([]params.EntityAnnotations, error) {
+	f.MethodCall(f, "Query", selector, kinds)
+	return f.results, f.NextErr()
+}
+
+func (s *selectorSuite) TestEntityIdsBySelector(c *gc.C) {
+	api := &fakeAnnotationsQueryAPI{results: []params.EntityAnnotations{
+		{EntityTag: "unit-mysql-0", Annotations: map[string]string{"env": "prod"}},
+		{EntityTag: "unit-mysql-1", Annotations: map[string]string{"env": "prod"}},
+	}}
+	ids, err := common.EntityIdsBySelector(api, "env=prod", "unit")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, jc.DeepEquals, []string{"mysql/0", "mysql/1"})
+	api.CheckCall(c, 0, "Query", "env=prod", []string{"unit"})
+}
+
+func (s *selectorSuite) TestEntityIdsBySelectorError(c *gc.C) {
+	api := &fakeAnnotationsQueryAPI{}
+	api.SetErrors(errors.New("boom"))
+	_, err := common.EntityIdsBySelector(api, "env=prod")
+	c.Assert(err, gc.ErrorMatches, `selecting entities matching "env=prod": boom`)
+}
@@ -0,0 +1,157 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewCheckOrphansCommand returns a command that reports, and optionally
+// removes, the orphaned documents in the models on a controller.
+func NewCheckOrphansCommand() cmd.Command {
+	return modelcmd.WrapController(&checkOrphansCommand{})
+}
+
+type checkOrphansCommand struct {
+	modelcmd.ControllerCommandBase
+	out    cmd.Output
+	api    CheckOrphansAPI
+	remove bool
+}
+
+// CheckOrphansAPI defines the methods of the Controller facade used by
+// check-orphans.
+type CheckOrphansAPI interface {
+	Close() error
+	FindOrphanedDocuments(remove bool) (params.OrphanedDocumentsResult, error)
+}
+
+const checkOrphansDoc = `
+Looks for documents in the controller's database which refer to entities
+that no longer exist, in every model on the controller. These are units
+of applications which have been removed, link-layer devices of machines
+which are dead or have been removed, and relations with an endpoint on an
+application which has been removed. Such documents are left behind by
+failed or interrupted removals, and are never cleaned up by Juju.
+
+With --remove, the orphaned documents found are also removed. Each
+document is removed only if it is still orphaned at the time.
+
+The controller also checks for orphaned documents periodically, logging
+any it finds, and removes them if the "remove-orphaned-documents"
+controller configuration is true.
+
+Only controller superusers may run this command.
+
+Examples:
+
+    juju check-orphans
+    juju check-orphans --remove
+    juju check-orphans -c prod-controller --format yaml
+
+See also:
+    controller-config
+`
+
+// Info implements Command.Info.
+func (c *checkOrphansCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "check-orphans",
+		Purpose: "Reports, and optionally removes, orphaned documents on a controller.",
+		Doc:     checkOrphansDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *checkOrphansCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.remove, "remove", false, "Remove the orphaned documents found")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatOrphansTabular,
+	})
+}
+
+func (c *checkOrphansCommand) getAPI() (CheckOrphansAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return controller.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *checkOrphansCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	result, err := client.FindOrphanedDocuments(c.remove)
+	if err != nil {
+		return errors.Annotate(err, "cannot check for orphaned documents")
+	}
+	if len(result.Documents) == 0 {
+		ctx.Infof("No orphaned documents found.")
+		return nil
+	}
+	orphans := make([]OrphanedDocument, len(result.Documents))
+	for i, doc := range result.Documents {
+		orphans[i] = OrphanedDocument{
+			Collection: doc.Collection,
+			Id:         doc.Id,
+			Reason:     doc.Reason,
+		}
+		if tag, err := names.ParseModelTag(doc.ModelTag); err == nil {
+			orphans[i].ModelUUID = tag.Id()
+		}
+	}
+	if err := c.out.Write(ctx, orphans); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Removed {
+		ctx.Infof("Removed %d orphaned documents.", len(orphans))
+	}
+	return nil
+}
+
+// OrphanedDocument describes an orphaned document, as written by
+// check-orphans.
+type OrphanedDocument struct {
+	ModelUUID  string `yaml:"model-uuid" json:"model-uuid"`
+	Collection string `yaml:"collection" json:"collection"`
+	Id         string `yaml:"id" json:"id"`
+	Reason     string `yaml:"reason" json:"reason"`
+}
+
+func formatOrphansTabular(writer io.Writer, value interface{}) error {
+	orphans, ok := value.([]OrphanedDocument)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", orphans, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Model", "Collection", "Id", "Reason")
+	for _, orphan := range orphans {
+		w.Println(orphan.ModelUUID, orphan.Collection, fmt.Sprintf("%q", orphan.Id), orphan.Reason)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,118 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type checkOrphansSuite struct {
+	baseControllerSuite
+	api   *fakeCheckOrphansAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&checkOrphansSuite{})
+
+func (s *checkOrphansSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.api = &fakeCheckOrphansAPI{
+		result: params.OrphanedDocumentsResult{
+			Documents: []params.OrphanedDocument{{
+				Collection: "relations",
+				ModelTag:   coretesting.ModelTag.String(),
+				Id:         "ghost:db wordpress:db",
+				Reason:     `application "ghost" not found`,
+			}, {
+				Collection: "units",
+				ModelTag:   coretesting.ModelTag.String(),
+				Id:         "ghost/0",
+				Reason:     `application "ghost" not found`,
+			}},
+		},
+	}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+}
+
+func (s *checkOrphansSuite) newCommand() cmd.Command {
+	return controller.NewCheckOrphansCommandForTest(s.api, s.store)
+}
+
+func (s *checkOrphansSuite) TestTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	uuid := coretesting.ModelTag.Id()
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Model                                 Collection  Id                       Reason\n"+
+		uuid+"  relations   \"ghost:db wordpress:db\"  application \"ghost\" not found\n"+
+		uuid+"  units       \"ghost/0\"                application \"ghost\" not found\n")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "")
+	c.Assert(s.api.remove, jc.IsFalse)
+	c.Assert(s.api.closed, jc.IsTrue)
+}
+
+func (s *checkOrphansSuite) TestYAML(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- model-uuid: `+coretesting.ModelTag.Id()+`
+  collection: relations
+  id: ghost:db wordpress:db
+  reason: application "ghost" not found
+- model-uuid: `+coretesting.ModelTag.Id()+`
+  collection: units
+  id: ghost/0
+  reason: application "ghost" not found
+`[1:])
+}
+
+func (s *checkOrphansSuite) TestRemove(c *gc.C) {
+	s.api.result.Removed = true
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--remove")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.remove, jc.IsTrue)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Removed 2 orphaned documents.\n")
+}
+
+func (s *checkOrphansSuite) TestNoOrphans(c *gc.C) {
+	s.api.result = params.OrphanedDocumentsResult{}
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No orphaned documents found.\n")
+}
+
+func (s *checkOrphansSuite) TestError(c *gc.C) {
+	s.api.err = errors.New("permission denied")
+	_, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, gc.ErrorMatches, "cannot check for orphaned documents: permission denied")
+}
+
+type fakeCheckOrphansAPI struct {
+	result params.OrphanedDocumentsResult
+	err    error
+	remove bool
+	closed bool
+}
+
+func (f *fakeCheckOrphansAPI) Close() error {
+	f.closed = true
+	return nil
+}
+
+func (f *fakeCheckOrphansAPI) FindOrphanedDocuments(remove bool) (params.OrphanedDocumentsResult, error) {
+	f.remove = remove
+	return f.result, f.err
+}
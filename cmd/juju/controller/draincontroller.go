@@ -0,0 +1,109 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/controller"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewDrainControllerCommand returns a command that drains the API
+// server on a controller machine.
+func NewDrainControllerCommand() cmd.Command {
+	return modelcmd.WrapController(&drainControllerCommand{})
+}
+
+type drainControllerCommand struct {
+	modelcmd.ControllerCommandBase
+	api     DrainControllerAPI
+	machine names.MachineTag
+}
+
+// DrainControllerAPI defines the methods of the Controller facade used
+// by drain-controller.
+type DrainControllerAPI interface {
+	Close() error
+	DrainController(machine names.MachineTag) error
+}
+
+const drainControllerDoc = `
+Drains the API server on the given controller machine ahead of
+maintenance. The API server stops accepting connections, closes its
+existing connections once their outstanding requests have completed,
+and then restarts the controller agent. Agents and clients reconnect
+to the other controllers in the meantime.
+
+The machine is given by its ID in the controller model, as shown by
+"juju show-controller". Drain one controller at a time, waiting for it
+to come back before draining the next, so that agents always have a
+controller to reconnect to.
+
+Only controller superusers may run this command.
+
+Examples:
+
+    juju drain-controller 1
+    juju drain-controller 2 -c prod-controller
+
+See also:
+    enable-ha
+    show-controller
+`
+
+// Info implements Command.Info.
+func (c *drainControllerCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "drain-controller",
+		Args:    "<machine>",
+		Purpose: "Drains the API server on a controller machine and restarts it.",
+		Doc:     drainControllerDoc,
+	})
+}
+
+// Init implements Command.Init.
+func (c *drainControllerCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no controller machine specified")
+	}
+	id, err := cmd.ZeroOrOneArgs(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !names.IsValidMachine(id) {
+		return errors.NotValidf("machine ID %q", id)
+	}
+	c.machine = names.NewMachineTag(id)
+	return nil
+}
+
+func (c *drainControllerCommand) getAPI() (DrainControllerAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return controller.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *drainControllerCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if err := client.DrainController(c.machine); err != nil {
+		return errors.Annotatef(err, "cannot drain controller machine %s", c.machine.Id())
+	}
+	ctx.Infof("Draining controller machine %s.", c.machine.Id())
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+)
+
+type drainControllerSuite struct {
+	baseControllerSuite
+	api   *fakeDrainControllerAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&drainControllerSuite{})
+
+func (s *drainControllerSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.api = &fakeDrainControllerAPI{}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+}
+
+func (s *drainControllerSuite) newCommand() cmd.Command {
+	return controller.NewDrainControllerCommandForTest(s.api, s.store)
+}
+
+func (s *drainControllerSuite) TestDrain(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.machine, gc.Equals, names.NewMachineTag("1"))
+	c.Assert(s.api.closed, jc.IsTrue)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Draining controller machine 1.\n")
+}
+
+func (s *drainControllerSuite) TestInit(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, gc.ErrorMatches, "no controller machine specified")
+	_, err = cmdtesting.RunCommand(c, s.newCommand(), "1", "2")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["2"\]`)
+	_, err = cmdtesting.RunCommand(c, s.newCommand(), "mysql/0")
+	c.Assert(err, gc.ErrorMatches, `machine ID "mysql/0" not valid`)
+}
+
+func (s *drainControllerSuite) TestError(c *gc.C) {
+	s.api.err = errors.New(`non-controller machine "1" not valid`)
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "1")
+	c.Assert(err, gc.ErrorMatches, `cannot drain controller machine 1: non-controller machine "1" not valid`)
+}
+
+type fakeDrainControllerAPI struct {
+	machine names.MachineTag
+	err     error
+	closed  bool
+}
+
+func (f *fakeDrainControllerAPI) Close() error {
+	f.closed = true
+	return nil
+}
+
+func (f *fakeDrainControllerAPI) DrainController(machine names.MachineTag) error {
+	f.machine = machine
+	return f.err
+}
@@ -93,6 +93,46 @@ func NewEnableDestroyControllerCommandForTest(api removeBlocksAPI, store jujucli
 	return modelcmd.WrapController(c)
 }
 
+// NewInventoryReportCommandForTest returns an inventoryReportCommand
+// with the api provided as specified.
+func NewInventoryReportCommandForTest(api InventoryAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &inventoryReportCommand{
+		api: api,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewCheckOrphansCommandForTest returns a checkOrphansCommand
+// with the api provided as specified.
+func NewCheckOrphansCommandForTest(api CheckOrphansAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &checkOrphansCommand{
+		api: api,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewVerifyControllerCommandForTest returns a verifyControllerCommand
+// with the api provided as specified.
+func NewVerifyControllerCommandForTest(api VerifyControllerAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &verifyControllerCommand{
+		api: api,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewDrainControllerCommandForTest returns a drainControllerCommand
+// with the api provided as specified.
+func NewDrainControllerCommandForTest(api DrainControllerAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &drainControllerCommand{
+		api: api,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 // NewDestroyCommandForTest returns a DestroyCommand with the controller and
 // client endpoints mocked out.
 func NewDestroyCommandForTest(
@@ -0,0 +1,350 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/inventory"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewInventoryReportCommand returns a command that reports the
+// machines, volumes and applications of all the models on a controller.
+func NewInventoryReportCommand() cmd.Command {
+	return modelcmd.WrapController(&inventoryReportCommand{})
+}
+
+type inventoryReportCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+	api InventoryAPI
+}
+
+// InventoryAPI defines the methods of the Inventory facade used by
+// inventory-report.
+type InventoryAPI interface {
+	Close() error
+	Report() (params.InventoryReport, error)
+}
+
+const inventoryReportDoc = `
+Reports the machines, volumes and applications of every model on the
+controller, for license and capacity reporting. Machines are listed with
+their instance, instance type constraint and hardware; volumes with their
+pool and size; and applications with their charm, charm version and unit
+count.
+
+The default tabular format summarises each model. The yaml and json
+formats include every entity, and the csv format has one row per
+machine, volume or application, for loading into a spreadsheet.
+
+Only controller superusers may run this command.
+
+Examples:
+
+    juju inventory-report
+    juju inventory-report --format csv -o inventory.csv
+    juju inventory-report -c prod-controller --format json
+
+See also:
+    models
+    show-controller
+`
+
+// Info implements Command.Info.
+func (c *inventoryReportCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "inventory-report",
+		Purpose: "Reports the inventory of all the models on a controller.",
+		Doc:     inventoryReportDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *inventoryReportCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"csv":     formatInventoryCSV,
+		"tabular": formatInventoryTabular,
+	})
+}
+
+func (c *inventoryReportCommand) getAPI() (InventoryAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return inventory.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *inventoryReportCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	report, err := client.Report()
+	if err != nil {
+		return errors.Annotate(err, "cannot get inventory report")
+	}
+	result := InventoryReport{Models: make([]ModelInventory, len(report.Models))}
+	for i, model := range report.Models {
+		result.Models[i] = convertModelInventory(model)
+		if model.Error != nil {
+			ctx.Warningf("cannot report inventory of model %q: %v", result.Models[i].Name, model.Error)
+		}
+	}
+	return c.out.Write(ctx, result)
+}
+
+// InventoryReport is the inventory of the models on a controller, as
+// written by inventory-report.
+type InventoryReport struct {
+	Models []ModelInventory `yaml:"models" json:"models"`
+}
+
+// ModelInventory is the inventory of a single model.
+type ModelInventory struct {
+	Name         string                 `yaml:"name" json:"name"`
+	UUID         string                 `yaml:"model-uuid" json:"model-uuid"`
+	Type         string                 `yaml:"type,omitempty" json:"type,omitempty"`
+	Cloud        string                 `yaml:"cloud,omitempty" json:"cloud,omitempty"`
+	CloudRegion  string                 `yaml:"region,omitempty" json:"region,omitempty"`
+	Machines     []MachineInventory     `yaml:"machines,omitempty" json:"machines,omitempty"`
+	Volumes      []VolumeInventory      `yaml:"volumes,omitempty" json:"volumes,omitempty"`
+	Applications []ApplicationInventory `yaml:"applications,omitempty" json:"applications,omitempty"`
+	Error        string                 `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+// MachineInventory describes a machine in a model inventory.
+type MachineInventory struct {
+	Id           string `yaml:"id" json:"id"`
+	Series       string `yaml:"series,omitempty" json:"series,omitempty"`
+	InstanceId   string `yaml:"instance-id,omitempty" json:"instance-id,omitempty"`
+	InstanceType string `yaml:"instance-type,omitempty" json:"instance-type,omitempty"`
+	Arch         string `yaml:"arch,omitempty" json:"arch,omitempty"`
+	Cores        uint64 `yaml:"cores,omitempty" json:"cores,omitempty"`
+	Mem          uint64 `yaml:"mem,omitempty" json:"mem,omitempty"`
+	RootDisk     uint64 `yaml:"root-disk,omitempty" json:"root-disk,omitempty"`
+	Zone         string `yaml:"availability-zone,omitempty" json:"availability-zone,omitempty"`
+}
+
+// VolumeInventory describes a volume in a model inventory. Size is
+// in MiB.
+type VolumeInventory struct {
+	Id         string `yaml:"id" json:"id"`
+	ProviderId string `yaml:"provider-id,omitempty" json:"provider-id,omitempty"`
+	Pool       string `yaml:"pool,omitempty" json:"pool,omitempty"`
+	Size       uint64 `yaml:"size" json:"size"`
+	Persistent bool   `yaml:"persistent" json:"persistent"`
+}
+
+// ApplicationInventory describes an application in a model inventory.
+type ApplicationInventory struct {
+	Name         string `yaml:"name" json:"name"`
+	Charm        string `yaml:"charm" json:"charm"`
+	CharmVersion string `yaml:"charm-version,omitempty" json:"charm-version,omitempty"`
+	Units        int    `yaml:"units" json:"units"`
+}
+
+func convertModelInventory(in params.ModelInventory) ModelInventory {
+	out := ModelInventory{
+		Name:        in.Name,
+		Type:        in.Type,
+		Cloud:       in.Cloud,
+		CloudRegion: in.CloudRegion,
+	}
+	if tag, err := names.ParseModelTag(in.ModelTag); err == nil {
+		out.UUID = tag.Id()
+	}
+	if owner, err := names.ParseUserTag(in.OwnerTag); err == nil {
+		out.Name = owner.Id() + "/" + in.Name
+	}
+	if out.Name == "" {
+		// The model couldn't be read, so all we have is its UUID.
+		out.Name = out.UUID
+	}
+	if in.Error != nil {
+		out.Error = in.Error.Error()
+	}
+	for _, m := range in.Machines {
+		machine := MachineInventory{
+			Id:           m.Id,
+			Series:       m.Series,
+			InstanceId:   m.InstanceId,
+			InstanceType: m.InstanceType,
+		}
+		if hw := m.Hardware; hw != nil {
+			if hw.Arch != nil {
+				machine.Arch = *hw.Arch
+			}
+			if hw.Cores != nil {
+				machine.Cores = *hw.Cores
+			}
+			if hw.Mem != nil {
+				machine.Mem = *hw.Mem
+			}
+			if hw.RootDisk != nil {
+				machine.RootDisk = *hw.RootDisk
+			}
+			if hw.AvailabilityZone != nil {
+				machine.Zone = *hw.AvailabilityZone
+			}
+		}
+		out.Machines = append(out.Machines, machine)
+	}
+	for _, v := range in.Volumes {
+		out.Volumes = append(out.Volumes, VolumeInventory{
+			Id:         v.Id,
+			ProviderId: v.ProviderId,
+			Pool:       v.Pool,
+			Size:       v.Size,
+			Persistent: v.Persistent,
+		})
+	}
+	for _, app := range in.Applications {
+		out.Applications = append(out.Applications, ApplicationInventory{
+			Name:         app.Name,
+			Charm:        app.CharmURL,
+			CharmVersion: app.CharmVersion,
+			Units:        app.UnitCount,
+		})
+	}
+	return out
+}
+
+func formatInventoryTabular(writer io.Writer, value interface{}) error {
+	report, ok := value.(InventoryReport)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", report, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Model", "Cloud/Region", "Machines", "Cores", "Memory", "Volumes", "Storage", "Apps", "Units")
+	for col := 2; col <= 8; col++ {
+		tw.SetColumnAlignRight(col)
+	}
+	for _, model := range report.Models {
+		cloudRegion := model.Cloud
+		if model.CloudRegion != "" {
+			cloudRegion += "/" + model.CloudRegion
+		}
+		if model.Error != "" {
+			w.Println(model.Name, cloudRegion, noValueDisplay, noValueDisplay, noValueDisplay,
+				noValueDisplay, noValueDisplay, noValueDisplay, noValueDisplay)
+			continue
+		}
+		var cores, mem, storage uint64
+		for _, m := range model.Machines {
+			cores += m.Cores
+			mem += m.Mem
+		}
+		for _, v := range model.Volumes {
+			storage += v.Size
+		}
+		var units int
+		for _, app := range model.Applications {
+			units += app.Units
+		}
+		w.Println(
+			model.Name, cloudRegion,
+			len(model.Machines), cores, humanizeMiB(mem),
+			len(model.Volumes), humanizeMiB(storage),
+			len(model.Applications), units,
+		)
+	}
+	return tw.Flush()
+}
+
+// inventoryCSVHeader holds the columns written by the csv format. Each
+// row describes a machine, a volume or an application, as given in the
+// kind column; columns which don't apply to the kind are left empty.
+var inventoryCSVHeader = []string{
+	"model", "model-uuid", "cloud", "region", "kind", "id",
+	"series", "instance-id", "instance-type", "arch", "cores", "mem", "root-disk", "availability-zone",
+	"provider-id", "pool", "size", "persistent",
+	"charm", "charm-version", "units",
+}
+
+func formatInventoryCSV(writer io.Writer, value interface{}) error {
+	report, ok := value.(InventoryReport)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", report, value)
+	}
+	w := csv.NewWriter(writer)
+	if err := w.Write(inventoryCSVHeader); err != nil {
+		return errors.Trace(err)
+	}
+	for _, model := range report.Models {
+		row := func(kind, id string) []string {
+			r := make([]string, len(inventoryCSVHeader))
+			copy(r, []string{model.Name, model.UUID, model.Cloud, model.CloudRegion, kind, id})
+			return r
+		}
+		for _, m := range model.Machines {
+			r := row("machine", m.Id)
+			copy(r[6:], []string{
+				m.Series, m.InstanceId, m.InstanceType, m.Arch,
+				formatCount(m.Cores), formatCount(m.Mem), formatCount(m.RootDisk), m.Zone,
+			})
+			if err := w.Write(r); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		for _, v := range model.Volumes {
+			r := row("volume", v.Id)
+			copy(r[14:], []string{
+				v.ProviderId, v.Pool, strconv.FormatUint(v.Size, 10), strconv.FormatBool(v.Persistent),
+			})
+			if err := w.Write(r); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		for _, app := range model.Applications {
+			r := row("application", app.Name)
+			copy(r[18:], []string{app.Charm, app.CharmVersion, strconv.Itoa(app.Units)})
+			if err := w.Write(r); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	w.Flush()
+	return errors.Trace(w.Error())
+}
+
+// formatCount formats an optional hardware count, leaving it empty
+// when unknown.
+func formatCount(n uint64) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.FormatUint(n, 10)
+}
+
+// humanizeMiB formats a size in MiB for display.
+func humanizeMiB(size uint64) string {
+	if size == 0 {
+		return noValueDisplay
+	}
+	return humanize.IBytes(size * humanize.MiByte)
+}
@@ -0,0 +1,163 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type inventoryReportSuite struct {
+	baseControllerSuite
+	api   *fakeInventoryAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&inventoryReportSuite{})
+
+func (s *inventoryReportSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	arch := "amd64"
+	mem := uint64(8192)
+	cores := uint64(4)
+	s.api = &fakeInventoryAPI{
+		report: params.InventoryReport{
+			Models: []params.ModelInventory{{
+				ModelTag:    coretesting.ModelTag.String(),
+				Name:        "prod",
+				OwnerTag:    "user-bob",
+				Type:        "iaas",
+				Cloud:       "aws",
+				CloudRegion: "us-east-1",
+				Machines: []params.MachineInventory{{
+					Id:           "0",
+					Series:       "focal",
+					InstanceId:   "i-0123",
+					InstanceType: "m5.large",
+					Hardware: &params.MachineHardware{
+						Arch:  &arch,
+						Mem:   &mem,
+						Cores: &cores,
+					},
+				}},
+				Volumes: []params.VolumeInventory{{
+					Id:         "0",
+					ProviderId: "vol-0123",
+					Pool:       "ebs",
+					Size:       10240,
+					Persistent: true,
+				}},
+				Applications: []params.ApplicationInventory{{
+					Name:         "postgresql",
+					CharmURL:     "ch:amd64/focal/postgresql-42",
+					CharmVersion: "v1.2.3",
+					UnitCount:    3,
+				}},
+			}},
+		},
+	}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+}
+
+func (s *inventoryReportSuite) newCommand() cmd.Command {
+	return controller.NewInventoryReportCommandForTest(s.api, s.store)
+}
+
+func (s *inventoryReportSuite) TestTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Matches, ""+
+		`Model +Cloud/Region +Machines +Cores +Memory +Volumes +Storage +Apps +Units\n`+
+		`bob/prod +aws/us-east-1 +1 +4 +8.0 GiB +1 +10 GiB +1 +3\n`)
+	c.Assert(s.api.closed, jc.IsTrue)
+}
+
+func (s *inventoryReportSuite) TestCSV(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--format", "csv")
+	c.Assert(err, jc.ErrorIsNil)
+	uuid := coretesting.ModelTag.Id()
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"model,model-uuid,cloud,region,kind,id,series,instance-id,instance-type,arch,cores,mem,root-disk,availability-zone,provider-id,pool,size,persistent,charm,charm-version,units\n"+
+		"bob/prod,"+uuid+",aws,us-east-1,machine,0,focal,i-0123,m5.large,amd64,4,8192,,,,,,,,,\n"+
+		"bob/prod,"+uuid+",aws,us-east-1,volume,0,,,,,,,,,vol-0123,ebs,10240,true,,,\n"+
+		"bob/prod,"+uuid+",aws,us-east-1,application,postgresql,,,,,,,,,,,,,ch:amd64/focal/postgresql-42,v1.2.3,3\n",
+	)
+}
+
+func (s *inventoryReportSuite) TestYAML(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+models:
+- name: bob/prod
+  model-uuid: `+coretesting.ModelTag.Id()+`
+  type: iaas
+  cloud: aws
+  region: us-east-1
+  machines:
+  - id: "0"
+    series: focal
+    instance-id: i-0123
+    instance-type: m5.large
+    arch: amd64
+    cores: 4
+    mem: 8192
+  volumes:
+  - id: "0"
+    provider-id: vol-0123
+    pool: ebs
+    size: 10240
+    persistent: true
+  applications:
+  - name: postgresql
+    charm: ch:amd64/focal/postgresql-42
+    charm-version: v1.2.3
+    units: 3
+`[1:])
+}
+
+func (s *inventoryReportSuite) TestModelError(c *gc.C) {
+	s.api.report.Models = append(s.api.report.Models, params.ModelInventory{
+		ModelTag: "model-deadbeef-0bad-400d-8000-4b1d0d06f00e",
+		Error:    &params.Error{Message: "boom"},
+	})
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Matches,
+		`(?s).*cannot report inventory of model "deadbeef-0bad-400d-8000-4b1d0d06f00e": boom.*`)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Matches,
+		`(?s).*\ndeadbeef-0bad-400d-8000-4b1d0d06f00e +(- +){6}-\n`)
+}
+
+func (s *inventoryReportSuite) TestError(c *gc.C) {
+	s.api.err = errors.New("permission denied")
+	_, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, gc.ErrorMatches, "cannot get inventory report: permission denied")
+}
+
+type fakeInventoryAPI struct {
+	report params.InventoryReport
+	err    error
+	closed bool
+}
+
+func (f *fakeInventoryAPI) Close() error {
+	f.closed = true
+	return nil
+}
+
+func (f *fakeInventoryAPI) Report() (params.InventoryReport, error) {
+	return f.report, f.err
+}
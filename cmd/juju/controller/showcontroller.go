@@ -358,6 +358,10 @@ type MachineDetails struct {
 
 	// HAPrimary is set to true for a primary controller machine in HA.
 	HAPrimary bool `yaml:"ha-primary,omitempty" json:"ha-primary,omitempty"`
+
+	// Customization holds the progress of the controller customization
+	// script on the machine, along with the reason it failed, if it did.
+	Customization string `yaml:"customization,omitempty" json:"customization,omitempty"`
 }
 
 // ModelDetails holds details of a model to show.
@@ -545,6 +549,10 @@ func (c *showControllerCommand) convertMachinesForShow(
 				details.HAPrimary = *m.HAPrimary
 			}
 		}
+		details.Customization = m.CustomizationStatus
+		if m.CustomizationMessage != "" {
+			details.Customization += ": " + m.CustomizationMessage
+		}
 		nodes[m.Id] = details
 	}
 }
@@ -659,6 +659,52 @@ aws-test:
 	s.assertShowController(c, "aws-test")
 }
 
+func (s *ShowControllerSuite) TestShowControllerCustomization(c *gc.C) {
+	_ = s.createTestClientStore(c)
+	s.expectedOutput = `
+aws-test:
+  details:
+    uuid: this-is-the-aws-test-uuid
+    controller-uuid: this-is-the-aws-test-uuid
+    api-endpoints: [this-is-aws-test-of-many-api-endpoints]
+    cloud: aws
+    region: us-east-1
+    agent-version: 999.99.99
+    agent-git-commit: badf00d0badf00d0badf00d0badf00d0badf00d0
+    controller-model-version: 999.99.99
+    mongo-version: 3.5.12
+    ca-cert: this-is-aws-test-ca-cert
+  controller-machines:
+    "0":
+      instance-id: id-0
+      ha-status: ha-pending
+      customization: completed
+    "1":
+      instance-id: id-1
+      ha-status: down, lost connection
+      customization: 'failed: exit status 1: no such package'
+    "2":
+      instance-id: id-2
+      ha-status: ha-enabled
+  models:
+    controller:
+      uuid: ghi
+      model-uuid: ghi
+      machine-count: 2
+      core-count: 4
+  current-model: admin/controller
+  account:
+    user: admin
+    access: superuser
+`[1:]
+
+	s.fakeController.machines["ghi"][0].CustomizationStatus = "completed"
+	s.fakeController.machines["ghi"][1].CustomizationStatus = "failed"
+	s.fakeController.machines["ghi"][1].CustomizationMessage = "exit status 1: no such package"
+
+	s.assertShowController(c, "aws-test")
+}
+
 type fakeController struct {
 	controllerName    string
 	machines          map[string][]base.Machine
@@ -0,0 +1,189 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewVerifyControllerCommand returns a command that checks the
+// consistency of the controller's database.
+func NewVerifyControllerCommand() cmd.Command {
+	return modelcmd.WrapController(&verifyControllerCommand{})
+}
+
+type verifyControllerCommand struct {
+	modelcmd.ControllerCommandBase
+	out    cmd.Output
+	api    VerifyControllerAPI
+	repair bool
+}
+
+// VerifyControllerAPI defines the methods of the Controller facade used
+// by verify-controller.
+type VerifyControllerAPI interface {
+	Close() error
+	VerifyIntegrity(repair bool) (params.IntegrityReport, error)
+}
+
+const verifyControllerDoc = `
+Runs consistency checks across the collections of every model on the
+controller's database, and reports any inconsistencies found. The checks
+are:
+
+    refcounts  the unit and relation counts of each application match
+               the units and relations which exist
+    life       no unit or relation is alive while an application or
+               machine it depends on is dead
+    bindings   endpoint bindings and subnets refer to spaces which exist
+
+The report is written in yaml by default, for consumption by scripts and
+monitoring. Use --format tabular for a summary.
+
+With --repair, the inconsistencies which can be repaired safely are
+repaired, and are reported as such. Currently only application unit and
+relation counts are repaired. A repair is skipped if the application
+changes while it is being checked; run the command again to retry it.
+
+Only controller superusers may run this command.
+
+Examples:
+
+    juju verify-controller
+    juju verify-controller --format tabular
+    juju verify-controller --repair -c prod-controller
+
+See also:
+    check-orphans
+`
+
+// Info implements Command.Info.
+func (c *verifyControllerCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "verify-controller",
+		Purpose: "Checks the consistency of a controller's database.",
+		Doc:     verifyControllerDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *verifyControllerCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.repair, "repair", false, "Repair the inconsistencies which can be repaired safely")
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatIntegrityTabular,
+	})
+}
+
+func (c *verifyControllerCommand) getAPI() (VerifyControllerAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return controller.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *verifyControllerCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	result, err := client.VerifyIntegrity(c.repair)
+	if err != nil {
+		return errors.Annotate(err, "cannot verify controller")
+	}
+	report := IntegrityReport{Checks: result.Checks}
+	var repaired int
+	for _, problem := range result.Problems {
+		p := IntegrityProblem{
+			Check:      problem.Check,
+			Collection: problem.Collection,
+			Id:         problem.Id,
+			Detail:     problem.Detail,
+			Repairable: problem.Repairable,
+			Repaired:   problem.Repaired,
+		}
+		if tag, err := names.ParseModelTag(problem.ModelTag); err == nil {
+			p.ModelUUID = tag.Id()
+		}
+		if p.Repaired {
+			repaired++
+		}
+		report.Problems = append(report.Problems, p)
+	}
+	if err := c.out.Write(ctx, report); err != nil {
+		return errors.Trace(err)
+	}
+	switch {
+	case len(report.Problems) == 0:
+		ctx.Infof("No problems found.")
+	case c.repair:
+		ctx.Infof("%d problems found, %d repaired.", len(report.Problems), repaired)
+	default:
+		ctx.Infof("%d problems found.", len(report.Problems))
+	}
+	return nil
+}
+
+// IntegrityReport is the report written by verify-controller.
+type IntegrityReport struct {
+	Checks   []string           `yaml:"checks" json:"checks"`
+	Problems []IntegrityProblem `yaml:"problems,omitempty" json:"problems,omitempty"`
+}
+
+// IntegrityProblem describes an inconsistency found by verify-controller.
+type IntegrityProblem struct {
+	Check      string `yaml:"check" json:"check"`
+	ModelUUID  string `yaml:"model-uuid" json:"model-uuid"`
+	Collection string `yaml:"collection" json:"collection"`
+	Id         string `yaml:"id" json:"id"`
+	Detail     string `yaml:"detail" json:"detail"`
+	Repairable bool   `yaml:"repairable" json:"repairable"`
+	Repaired   bool   `yaml:"repaired,omitempty" json:"repaired,omitempty"`
+}
+
+func formatIntegrityTabular(writer io.Writer, value interface{}) error {
+	report, ok := value.(IntegrityReport)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", report, value)
+	}
+	if len(report.Problems) == 0 {
+		return nil
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Check", "Model", "Collection", "Id", "Repair", "Detail")
+	for _, problem := range report.Problems {
+		repair := noValueDisplay
+		switch {
+		case problem.Repaired:
+			repair = "repaired"
+		case problem.Repairable:
+			repair = "repairable"
+		}
+		w.Println(problem.Check, problem.ModelUUID, problem.Collection,
+			fmt.Sprintf("%q", problem.Id), repair, problem.Detail)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,137 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type verifyControllerSuite struct {
+	baseControllerSuite
+	api   *fakeVerifyControllerAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&verifyControllerSuite{})
+
+func (s *verifyControllerSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.api = &fakeVerifyControllerAPI{
+		report: params.IntegrityReport{
+			Checks: []string{"refcounts", "life", "bindings"},
+			Problems: []params.IntegrityProblem{{
+				Check:      "refcounts",
+				Collection: "applications",
+				ModelTag:   coretesting.ModelTag.String(),
+				Id:         "mysql",
+				Detail:     "unit count is 2 but 1 units found",
+				Repairable: true,
+			}, {
+				Check:      "life",
+				Collection: "units",
+				ModelTag:   coretesting.ModelTag.String(),
+				Id:         "mysql/0",
+				Detail:     `unit is alive but machine "0" is dead`,
+			}},
+		},
+	}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+}
+
+func (s *verifyControllerSuite) newCommand() cmd.Command {
+	return controller.NewVerifyControllerCommandForTest(s.api, s.store)
+}
+
+func (s *verifyControllerSuite) TestYAML(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	uuid := coretesting.ModelTag.Id()
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+checks:
+- refcounts
+- life
+- bindings
+problems:
+- check: refcounts
+  model-uuid: `+uuid+`
+  collection: applications
+  id: mysql
+  detail: unit count is 2 but 1 units found
+  repairable: true
+- check: life
+  model-uuid: `+uuid+`
+  collection: units
+  id: mysql/0
+  detail: unit is alive but machine "0" is dead
+  repairable: false
+`[1:])
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "2 problems found.\n")
+	c.Assert(s.api.repair, jc.IsFalse)
+	c.Assert(s.api.closed, jc.IsTrue)
+}
+
+func (s *verifyControllerSuite) TestTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--format", "tabular")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Matches, ""+
+		`Check +Model +Collection +Id +Repair +Detail\n`+
+		`refcounts +`+coretesting.ModelTag.Id()+` +applications +"mysql" +repairable +unit count is 2 but 1 units found\n`+
+		`life +`+coretesting.ModelTag.Id()+` +units +"mysql/0" +- +unit is alive but machine "0" is dead\n`)
+}
+
+func (s *verifyControllerSuite) TestRepair(c *gc.C) {
+	s.api.report.Problems[0].Repaired = true
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--repair", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.repair, jc.IsTrue)
+	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, `"repairable":true,"repaired":true`)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "2 problems found, 1 repaired.\n")
+}
+
+func (s *verifyControllerSuite) TestNoProblems(c *gc.C) {
+	s.api.report.Problems = nil
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+checks:
+- refcounts
+- life
+- bindings
+`[1:])
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No problems found.\n")
+}
+
+func (s *verifyControllerSuite) TestError(c *gc.C) {
+	s.api.err = errors.New("permission denied")
+	_, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, gc.ErrorMatches, "cannot verify controller: permission denied")
+}
+
+type fakeVerifyControllerAPI struct {
+	report params.IntegrityReport
+	err    error
+	repair bool
+	closed bool
+}
+
+func (f *fakeVerifyControllerAPI) Close() error {
+	f.closed = true
+	return nil
+}
+
+func (f *fakeVerifyControllerAPI) VerifyIntegrity(repair bool) (params.IntegrityReport, error) {
+	f.repair = repair
+	return f.report, f.err
+}
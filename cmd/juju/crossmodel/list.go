@@ -4,6 +4,7 @@
 package crossmodel
 
 import (
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"time"
@@ -49,6 +50,10 @@ Examples:
     $ juju offers hosted-mysql
     $ juju offers hosted-mysql --active-only
 
+If the JUJU_API_CACHE_TTL environment variable is set to a duration such
+as 2s, the offers reported are cached and reused by later calls with the
+same arguments until that duration has passed.
+
 See also:
    find-offers   
    show-offer
@@ -70,6 +75,9 @@ type listCommand struct {
 	consumerName      string
 	offerName         string
 	filters           []crossmodel.ApplicationOfferFilter
+
+	// responseCache, if set, caches the offers reported.
+	responseCache *common.ResponseCache
 }
 
 // NewListEndpointsCommand constructs new list endpoint command.
@@ -99,6 +107,9 @@ func (c *listCommand) Init(args []string) (err error) {
 		return errors.Trace(err)
 	}
 	c.offerName = offerName
+	if c.responseCache, err = common.ResponseCacheFromEnvironment(); err != nil {
+		return errors.Trace(err)
+	}
 	return nil
 }
 
@@ -131,12 +142,6 @@ func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
 
 // Run implements Command.Run.
 func (c *listCommand) Run(ctx *cmd.Context) (err error) {
-	api, err := c.newAPIFunc()
-	if err != nil {
-		return err
-	}
-	defer api.Close()
-
 	controllerName, err := c.ControllerName()
 	if err != nil {
 		return errors.Trace(err)
@@ -179,7 +184,24 @@ func (c *listCommand) Run(ctx *cmd.Context) (err error) {
 		c.filters[0].AllowedConsumers = []string{c.consumerName}
 	}
 
-	offeredApplications, err := api.ListOffers(c.filters...)
+	var key string
+	if c.responseCache != nil {
+		if key, err = c.offersCacheKey(controllerName, modelName); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	var offeredApplications []*crossmodel.ApplicationOfferDetails
+	// Offer access is not tracked by the model revno, so cached
+	// offers are only reused until they expire.
+	err = c.responseCache.Call(key, &offeredApplications, func(int64) (int64, bool, error) {
+		api, err := c.newAPIFunc()
+		if err != nil {
+			return 0, false, err
+		}
+		defer api.Close()
+		offeredApplications, err = api.ListOffers(c.filters...)
+		return 0, false, err
+	})
 	if err != nil {
 		return err
 	}
@@ -193,6 +215,21 @@ func (c *listCommand) Run(ctx *cmd.Context) (err error) {
 	return c.out.Write(ctx, data)
 }
 
+// offersCacheKey returns the key identifying the offers requested in
+// the response cache.
+func (c *listCommand) offersCacheKey(controllerName, modelName string) (string, error) {
+	account, err := c.CurrentAccountDetails()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	filters, err := json.Marshal(c.filters)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return fmt.Sprintf("offers %q %q %q %s",
+		controllerName, account.User, modelName, filters), nil
+}
+
 // ListAPI defines the API methods that list endpoints command use.
 type ListAPI interface {
 	Close() error
@@ -0,0 +1,155 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall
+
+import (
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/firewallrules"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+var auditHelpSummary = `
+Reports cloud firewall rules not backed by any opened port.`[1:]
+
+var auditHelpDetails = `
+Compares the ingress rules in the cloud with the ports opened by units in
+the model, and lists any rules that no unit has opened. Such orphan rules
+can be left behind when units or machines go away while the firewaller
+isn't running; the firewaller removes them when it next reconciles the
+model's rules.
+
+Examples:
+    juju firewall-audit
+    juju firewall-audit --format yaml
+
+See also:
+    list-firewall-rules`
+
+// NewFirewallAuditCommand returns a command to report orphan firewall rules.
+func NewFirewallAuditCommand() cmd.Command {
+	cmd := &firewallAuditCommand{}
+	cmd.newAPIFunc = func() (FirewallAuditAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return firewallrules.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type firewallAuditCommand struct {
+	modelcmd.ModelCommandBase
+	modelcmd.IAASOnlyCommand
+	out cmd.Output
+
+	newAPIFunc func() (FirewallAuditAPI, error)
+}
+
+// Info implements cmd.Command.
+func (c *firewallAuditCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "firewall-audit",
+		Purpose: auditHelpSummary,
+		Doc:     auditHelpDetails,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *firewallAuditCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatAuditTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *firewallAuditCommand) Init(args []string) (err error) {
+	return cmd.CheckEmpty(args)
+}
+
+// FirewallAuditAPI defines the API methods that the firewall audit command uses.
+type FirewallAuditAPI interface {
+	Close() error
+	AuditFirewall() (params.FirewallAuditResults, error)
+}
+
+// Run implements cmd.Command.
+func (c *firewallAuditCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	result, err := client.AuditFirewall()
+	if err != nil {
+		return err
+	}
+
+	audit := firewallAudit{
+		FirewallMode: result.FirewallMode,
+		Orphans:      make([]orphanRule, len(result.Orphans)),
+	}
+	for i, o := range result.Orphans {
+		audit.Orphans[i] = orphanRule{
+			Machine:     o.MachineId,
+			PortRange:   o.PortRange.NetworkPortRange().String(),
+			SourceCIDRs: o.SourceCIDRs,
+		}
+	}
+	if len(audit.Orphans) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No orphan firewall rules found.")
+		return nil
+	}
+	return c.out.Write(ctx, audit)
+}
+
+type firewallAudit struct {
+	FirewallMode string       `yaml:"firewall-mode" json:"firewall-mode"`
+	Orphans      []orphanRule `yaml:"orphans" json:"orphans"`
+}
+
+type orphanRule struct {
+	Machine     string   `yaml:"machine,omitempty" json:"machine,omitempty"`
+	PortRange   string   `yaml:"port-range" json:"port-range"`
+	SourceCIDRs []string `yaml:"source-subnets,omitempty" json:"source-subnets,omitempty"`
+}
+
+func formatAuditTabular(writer io.Writer, value interface{}) error {
+	audit, ok := value.(firewallAudit)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", audit, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+
+	sort.Slice(audit.Orphans, func(i, j int) bool {
+		if audit.Orphans[i].Machine != audit.Orphans[j].Machine {
+			return audit.Orphans[i].Machine < audit.Orphans[j].Machine
+		}
+		return audit.Orphans[i].PortRange < audit.Orphans[j].PortRange
+	})
+
+	w.Println("Machine", "Port range", "Source subnets")
+	for _, rule := range audit.Orphans {
+		machine := rule.Machine
+		if machine == "" {
+			machine = "-"
+		}
+		w.Println(machine, rule.PortRange, strings.Join(rule.SourceCIDRs, ","))
+	}
+	tw.Flush()
+	return nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/firewall"
+	"github.com/juju/juju/testing"
+)
+
+type AuditSuite struct {
+	testing.BaseSuite
+
+	mockAPI *mockAuditAPI
+}
+
+var _ = gc.Suite(&AuditSuite{})
+
+func (s *AuditSuite) SetUpTest(c *gc.C) {
+	s.mockAPI = &mockAuditAPI{
+		result: params.FirewallAuditResults{
+			FirewallMode: "instance",
+			Orphans: []params.OrphanFirewallRule{{
+				MachineId:   "1",
+				PortRange:   params.PortRange{FromPort: 8080, ToPort: 8080, Protocol: "tcp"},
+				SourceCIDRs: []string{"0.0.0.0/0"},
+			}, {
+				MachineId:   "0",
+				PortRange:   params.PortRange{FromPort: 1000, ToPort: 2000, Protocol: "udp"},
+				SourceCIDRs: []string{"10.0.0.0/8", "192.168.0.0/16"},
+			}},
+		},
+	}
+}
+
+func (s *AuditSuite) runAudit(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, firewall.NewFirewallAuditCommandForTest(s.mockAPI), args...)
+}
+
+func (s *AuditSuite) TestAuditError(c *gc.C) {
+	s.mockAPI.err = errors.New("fail")
+	_, err := s.runAudit(c)
+	c.Assert(err, gc.ErrorMatches, ".*fail.*")
+}
+
+func (s *AuditSuite) TestAuditTabular(c *gc.C) {
+	ctx, err := s.runAudit(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Machine  Port range     Source subnets
+0        1000-2000/udp  10.0.0.0/8,192.168.0.0/16
+1        8080/tcp       0.0.0.0/0
+
+`[1:])
+}
+
+func (s *AuditSuite) TestAuditYAML(c *gc.C) {
+	ctx, err := s.runAudit(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+firewall-mode: instance
+orphans:
+- machine: "1"
+  port-range: 8080/tcp
+  source-subnets:
+  - 0.0.0.0/0
+- machine: "0"
+  port-range: 1000-2000/udp
+  source-subnets:
+  - 10.0.0.0/8
+  - 192.168.0.0/16
+`[1:])
+}
+
+func (s *AuditSuite) TestAuditNoOrphans(c *gc.C) {
+	s.mockAPI.result.Orphans = nil
+	ctx, err := s.runAudit(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No orphan firewall rules found.\n")
+}
+
+func (s *AuditSuite) TestAuditArgs(c *gc.C) {
+	_, err := s.runAudit(c, "foo")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+type mockAuditAPI struct {
+	result params.FirewallAuditResults
+	err    error
+}
+
+func (s *mockAuditAPI) Close() error {
+	return nil
+}
+
+func (s *mockAuditAPI) AuditFirewall() (params.FirewallAuditResults, error) {
+	return s.result, s.err
+}
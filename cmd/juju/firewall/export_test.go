@@ -33,3 +33,15 @@ func NewSetRulesCommandForTest(
 	aCmd.SetClientStore(jujuclienttesting.MinimalStore())
 	return modelcmd.Wrap(aCmd)
 }
+
+func NewFirewallAuditCommandForTest(
+	api FirewallAuditAPI,
+) cmd.Command {
+	aCmd := &firewallAuditCommand{
+		newAPIFunc: func() (FirewallAuditAPI, error) {
+			return api, nil
+		},
+	}
+	aCmd.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(aCmd)
+}
@@ -77,6 +77,11 @@ Manual provisioning is the process of installing Juju on an existing machine
 and bringing it under Juju's management. The Juju controller must be able to
 access the new machine over the network.
 
+When provisioning over WinRM without client certificate authentication, the
+password is only sent over https if the listener's certificate can be
+verified against the CA cert in $JUJU_DATA/x509/winrmcacert.crt. Use
+--winrm-insecure to send it without verifying the certificate.
+
 
 Container creation
 
@@ -162,6 +167,9 @@ type addCommand struct {
 	NumMachines int
 	// Disks describes disks that are to be attached to the machine.
 	Disks []storage.Constraints
+	// WinRMInsecure allows the password to be sent to a WinRM https
+	// listener whose certificate cannot be verified.
+	WinRMInsecure bool
 }
 
 func (c *addCommand) Info() *cmd.Info {
@@ -179,6 +187,7 @@ func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.NumMachines, "n", 1, "The number of machines to add")
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Machine constraints that overwrite those available from 'juju get-model-constraints' and provider's defaults")
 	f.Var(disksFlag{&c.Disks}, "disks", "Storage constraints for disks to attach to the machine(s)")
+	f.BoolVar(&c.WinRMInsecure, "winrm-insecure", false, "Allow sending the password to a WinRM https listener without verifying its certificate")
 }
 
 func (c *addCommand) Init(args []string) error {
@@ -460,8 +469,9 @@ func (c *addCommand) provisionWinRM(args manual.ProvisionMachineArgs) (string, e
 		return "", errors.Annotatef(err, "cannot create WinRM client connection")
 	}
 	args.WinRM = manual.WinRMArgs{
-		Keys:   cert,
-		Client: client,
+		Keys:     cert,
+		Client:   client,
+		Insecure: c.WinRMInsecure,
 	}
 	return winrmProvisioner(args)
 }
@@ -39,12 +39,13 @@ func (s *AddMachineSuite) SetUpTest(c *gc.C) {
 
 func (s *AddMachineSuite) TestInit(c *gc.C) {
 	for i, test := range []struct {
-		args        []string
-		series      string
-		constraints string
-		placement   string
-		count       int
-		errorString string
+		args          []string
+		series        string
+		constraints   string
+		placement     string
+		count         int
+		winrmInsecure bool
+		errorString   string
 	}{
 		{
 			count: 1,
@@ -75,6 +76,11 @@ func (s *AddMachineSuite) TestInit(c *gc.C) {
 			args:      []string{"winrm:user@10.10.0.3"},
 			count:     1,
 			placement: "winrm:user@10.10.0.3",
+		}, {
+			args:          []string{"winrm:user@10.10.0.3", "--winrm-insecure"},
+			count:         1,
+			placement:     "winrm:user@10.10.0.3",
+			winrmInsecure: true,
 		}, {
 			args:      []string{"zone=us-east-1a"},
 			count:     1,
@@ -105,6 +111,7 @@ func (s *AddMachineSuite) TestInit(c *gc.C) {
 				c.Check("", gc.Equals, test.placement)
 			}
 			c.Check(addCmd.NumMachines, gc.Equals, test.count)
+			c.Check(addCmd.WinRMInsecure, gc.Equals, test.winrmInsecure)
 		} else {
 			c.Check(err, gc.ErrorMatches, test.errorString)
 		}
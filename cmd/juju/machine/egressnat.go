@@ -0,0 +1,123 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"net"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/machinemanager"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewSetEgressNATAddressCommand returns a command used to declare the
+// address of the NAT gateway through which a machine's traffic egresses.
+func NewSetEgressNATAddressCommand() cmd.Command {
+	return modelcmd.Wrap(&setEgressNATAddressCommand{})
+}
+
+// EgressNATAddressAPI defines the API methods used by the
+// set-egress-nat-address command.
+type EgressNATAddressAPI interface {
+	SetEgressNATAddress(machineName, address string) error
+	Close() error
+}
+
+// setEgressNATAddressCommand declares or clears the egress NAT
+// address of a machine.
+type setEgressNATAddressCommand struct {
+	baseMachinesCommand
+	api EgressNATAddressAPI
+
+	machineId string
+	address   string
+	reset     bool
+}
+
+const setEgressNATAddressDoc = `
+Declares the address of the NAT gateway through which traffic from a
+machine egresses.
+
+Cross-model relations of units on the machine report this address as
+their egress subnet, so that offering models can allow traffic from it.
+A relation's explicitly set egress subnets still take precedence.
+An address declared for a machine overrides the model's egress-nat-address
+setting.
+
+Examples:
+
+    juju set-egress-nat-address 3 203.0.113.10
+    juju set-egress-nat-address 3 --reset
+
+See also:
+    model-config
+`
+
+// Info implements Command.Info.
+func (c *setEgressNATAddressCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "set-egress-nat-address",
+		Args:    "<machine> [<address>]",
+		Purpose: "Declares the NAT gateway address for traffic from a machine.",
+		Doc:     setEgressNATAddressDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setEgressNATAddressCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.reset, "reset", false, "Clear the declared address")
+}
+
+// Init implements Command.Init.
+func (c *setEgressNATAddressCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no machine specified")
+	}
+	c.machineId, args = args[0], args[1:]
+	if !names.IsValidMachine(c.machineId) {
+		return errors.Errorf("invalid machine id %q", c.machineId)
+	}
+
+	if c.reset {
+		return cmd.CheckEmpty(args)
+	}
+	if len(args) == 0 {
+		return errors.New("no address specified")
+	}
+	c.address, args = args[0], args[1:]
+	if net.ParseIP(c.address) == nil {
+		return errors.Errorf("invalid address %q", c.address)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *setEgressNATAddressCommand) getAPI() (EgressNATAddressAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *setEgressNATAddressCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	err = client.SetEgressNATAddress(c.machineId, c.address)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,94 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/testing"
+)
+
+type SetEgressNATAddressSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api *fakeEgressNATAddressAPI
+}
+
+var _ = gc.Suite(&SetEgressNATAddressSuite{})
+
+func (s *SetEgressNATAddressSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeEgressNATAddressAPI{}
+}
+
+func (s *SetEgressNATAddressSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no machine specified",
+	}, {
+		args: []string{"foo", "203.0.113.10"},
+		err:  `invalid machine id "foo"`,
+	}, {
+		args: []string{"3"},
+		err:  "no address specified",
+	}, {
+		args: []string{"3", "bad"},
+		err:  `invalid address "bad"`,
+	}, {
+		args: []string{"3", "203.0.113.10", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"3", "--reset", "203.0.113.10"},
+		err:  `unrecognized args: \["203.0.113.10"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := cmdtesting.RunCommand(c, machine.NewSetEgressNATAddressCommandForTest(s.api), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *SetEgressNATAddressSuite) TestSet(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, machine.NewSetEgressNATAddressCommandForTest(s.api), "0/lxd/1", "203.0.113.10")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"SetEgressNATAddress", []interface{}{"0/lxd/1", "203.0.113.10"}},
+		{"Close", nil},
+	})
+}
+
+func (s *SetEgressNATAddressSuite) TestReset(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, machine.NewSetEgressNATAddressCommandForTest(s.api), "3", "--reset")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"SetEgressNATAddress", []interface{}{"3", ""}},
+		{"Close", nil},
+	})
+}
+
+func (s *SetEgressNATAddressSuite) TestError(c *gc.C) {
+	s.api.SetErrors(errors.NotSupportedf("setting egress NAT addresses"))
+	_, err := cmdtesting.RunCommand(c, machine.NewSetEgressNATAddressCommandForTest(s.api), "3", "203.0.113.10")
+	c.Assert(err, gc.ErrorMatches, "setting egress NAT addresses not supported")
+}
+
+type fakeEgressNATAddressAPI struct {
+	jujutesting.Stub
+}
+
+func (f *fakeEgressNATAddressAPI) SetEgressNATAddress(machineName, address string) error {
+	f.MethodCall(f, "SetEgressNATAddress", machineName, address)
+	return f.NextErr()
+}
+
+func (f *fakeEgressNATAddressAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
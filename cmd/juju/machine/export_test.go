@@ -4,6 +4,7 @@
 package machine
 
 import (
+	"github.com/juju/clock"
 	"github.com/juju/cmd"
 	"github.com/juju/worker/v2/catacomb"
 
@@ -76,3 +77,35 @@ func NewUpgradeSeriesCommandForTest(upgradeAPI UpgradeMachineSeriesAPI) cmd.Comm
 func NewDisksFlag(disks *[]storage.Constraints) *disksFlag {
 	return &disksFlag{disks}
 }
+
+// NewSetEgressNATAddressCommandForTest returns a set-egress-nat-address
+// command with the api provided as specified.
+func NewSetEgressNATAddressCommandForTest(api EgressNATAddressAPI) cmd.Command {
+	command := &setEgressNATAddressCommand{api: api}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(command)
+}
+
+// NewRefreshMachineHardwareCommandForTest returns a refresh-machine-hardware
+// command with the api provided as specified.
+func NewRefreshMachineHardwareCommandForTest(api RefreshMachineHardwareAPI) cmd.Command {
+	command := &refreshMachineHardwareCommand{api: api}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(command)
+}
+
+// NewResizeMachineCommandForTest returns a resize-machine command with
+// the api provided as specified.
+func NewResizeMachineCommandForTest(api ResizeMachineAPI) cmd.Command {
+	command := &resizeMachineCommand{api: api}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(command)
+}
+
+// NewReplaceMachineCommandForTest returns a replace-machine command with
+// the api and clock provided as specified.
+func NewReplaceMachineCommandForTest(api ReplaceMachineAPI, clock clock.Clock) cmd.Command {
+	command := &replaceMachineCommand{api: api, clock: clock}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(command)
+}
@@ -0,0 +1,140 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/instance"
+)
+
+// NewRefreshMachineHardwareCommand returns a command used to re-read the
+// hardware characteristics of machines from the cloud.
+func NewRefreshMachineHardwareCommand() cmd.Command {
+	return modelcmd.Wrap(&refreshMachineHardwareCommand{})
+}
+
+// RefreshMachineHardwareAPI defines the API methods used by the
+// refresh-machine-hardware command.
+type RefreshMachineHardwareAPI interface {
+	RefreshMachineHardware(machines ...string) ([]params.MachineHardwareResult, error)
+	Close() error
+}
+
+// refreshMachineHardwareCommand updates the recorded hardware of
+// machines from their cloud instances.
+type refreshMachineHardwareCommand struct {
+	baseMachinesCommand
+	api RefreshMachineHardwareAPI
+
+	machineIds []string
+}
+
+const refreshMachineHardwareDoc = `
+Re-reads the hardware characteristics of the instances of the given
+machines from the cloud, and records them in the model.
+
+The hardware of a machine is recorded when it is provisioned. If its
+instance is later resized outside of Juju, such as through the cloud's
+console, the recorded hardware shown by status and show-machine is out
+of date until it is refreshed with this command.
+
+Not all clouds support reading the hardware of running instances.
+
+Examples:
+
+    juju refresh-machine-hardware 3
+    juju refresh-machine-hardware 3 4 5
+
+See also:
+    show-machine
+    set-constraints
+`
+
+// Info implements Command.Info.
+func (c *refreshMachineHardwareCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "refresh-machine-hardware",
+		Args:    "<machine> ...",
+		Purpose: "Refreshes the recorded hardware of machines from the cloud.",
+		Doc:     refreshMachineHardwareDoc,
+	})
+}
+
+// Init implements Command.Init.
+func (c *refreshMachineHardwareCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no machines specified")
+	}
+	for _, id := range args {
+		if !names.IsValidMachine(id) {
+			return errors.Errorf("invalid machine id %q", id)
+		}
+	}
+	c.machineIds = args
+	return nil
+}
+
+func (c *refreshMachineHardwareCommand) getAPI() (RefreshMachineHardwareAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *refreshMachineHardwareCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	results, err := client.RefreshMachineHardware(c.machineIds...)
+	if err := block.ProcessBlockedError(err, block.BlockChange); err != nil {
+		return err
+	}
+
+	anyFailed := false
+	for i, id := range c.machineIds {
+		result := results[i]
+		if result.Error != nil {
+			anyFailed = true
+			ctx.Infof("refreshing hardware of machine %s failed: %s", id, result.Error)
+			continue
+		}
+		ctx.Infof("machine %s: %s", id, formatHardware(result.Hardware))
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+func formatHardware(hw *params.MachineHardware) string {
+	if hw == nil {
+		return "hardware unknown"
+	}
+	hc := instance.HardwareCharacteristics{
+		Arch:             hw.Arch,
+		Mem:              hw.Mem,
+		RootDisk:         hw.RootDisk,
+		CpuCores:         hw.Cores,
+		CpuPower:         hw.CpuPower,
+		Tags:             hw.Tags,
+		AvailabilityZone: hw.AvailabilityZone,
+	}
+	return hc.String()
+}
@@ -0,0 +1,94 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/testing"
+)
+
+type RefreshMachineHardwareSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api *fakeRefreshMachineHardwareAPI
+}
+
+var _ = gc.Suite(&RefreshMachineHardwareSuite{})
+
+func (s *RefreshMachineHardwareSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeRefreshMachineHardwareAPI{}
+}
+
+func (s *RefreshMachineHardwareSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no machines specified",
+	}, {
+		args: []string{"3", "foo"},
+		err:  `invalid machine id "foo"`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := cmdtesting.RunCommand(c, machine.NewRefreshMachineHardwareCommandForTest(s.api), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *RefreshMachineHardwareSuite) TestRefresh(c *gc.C) {
+	mem, cores := uint64(16384), uint64(8)
+	s.api.results = []params.MachineHardwareResult{
+		{Hardware: &params.MachineHardware{Mem: &mem, Cores: &cores}},
+	}
+	ctx, err := cmdtesting.RunCommand(c, machine.NewRefreshMachineHardwareCommandForTest(s.api), "3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "machine 3: cores=8 mem=16384M\n")
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"RefreshMachineHardware", []interface{}{[]string{"3"}}},
+		{"Close", nil},
+	})
+}
+
+func (s *RefreshMachineHardwareSuite) TestRefreshPartialFailure(c *gc.C) {
+	mem := uint64(16384)
+	s.api.results = []params.MachineHardwareResult{
+		{Hardware: &params.MachineHardware{Mem: &mem}},
+		{Error: &params.Error{Message: "machine 4 not provisioned"}},
+	}
+	ctx, err := cmdtesting.RunCommand(c, machine.NewRefreshMachineHardwareCommandForTest(s.api), "3", "4")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, ""+
+		"machine 3: mem=16384M\n"+
+		"refreshing hardware of machine 4 failed: machine 4 not provisioned\n")
+}
+
+func (s *RefreshMachineHardwareSuite) TestError(c *gc.C) {
+	s.api.SetErrors(errors.NotSupportedf("refreshing machine hardware"))
+	_, err := cmdtesting.RunCommand(c, machine.NewRefreshMachineHardwareCommandForTest(s.api), "3")
+	c.Assert(err, gc.ErrorMatches, "refreshing machine hardware not supported")
+}
+
+type fakeRefreshMachineHardwareAPI struct {
+	jujutesting.Stub
+	results []params.MachineHardwareResult
+}
+
+func (f *fakeRefreshMachineHardwareAPI) RefreshMachineHardware(machines ...string) ([]params.MachineHardwareResult, error) {
+	f.MethodCall(f, "RefreshMachineHardware", machines)
+	return f.results, f.NextErr()
+}
+
+func (f *fakeRefreshMachineHardwareAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"os"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/constraints"
+)
+
+// replacePollInterval is how often the replace-machine command advances
+// the replacement of a machine.
+const replacePollInterval = 5 * time.Second
+
+// NewReplaceMachineCommand returns a command used to replace a machine
+// with a new one.
+func NewReplaceMachineCommand() cmd.Command {
+	return modelcmd.Wrap(&replaceMachineCommand{clock: clock.WallClock})
+}
+
+// ReplaceMachineAPI defines the API methods used by the replace-machine
+// command.
+type ReplaceMachineAPI interface {
+	ReplaceMachine(machine string, cons *constraints.Value) (params.ReplaceMachineResult, error)
+	Close() error
+}
+
+// replaceMachineCommand moves the units of a machine, and their storage,
+// to a new machine and removes the old one.
+type replaceMachineCommand struct {
+	baseMachinesCommand
+	api   ReplaceMachineAPI
+	clock clock.Clock
+
+	machineId   string
+	constraints string
+	cons        *constraints.Value
+}
+
+const replaceMachineDoc = `
+Replaces a machine with a new machine, such as to move its workloads to
+new hardware or to recover from a failing instance.
+
+A replacement machine is provisioned with the same series as the machine
+being replaced, and with the given constraints or, if none are given,
+the constraints of the machine being replaced. Once it has started, the
+principal units of the old machine are removed, leaving their detachable
+storage behind, and redeployed to the replacement machine with that
+storage attached. Their subordinates follow them. Finally, the old
+machine is removed.
+
+Units are redeployed with new unit numbers, and storage which cannot be
+detached, such as the machine's root disk, is not moved.
+
+The progress of the replacement is shown as the command runs, and is
+recorded in the modification status of the machine being replaced. If
+the command is interrupted, or a step fails and the problem is
+resolved, running it again resumes the replacement where it left off.
+
+Controller machines, and machines hosting containers, cannot be
+replaced.
+
+Examples:
+
+    juju replace-machine 3
+    juju replace-machine 3 --constraints "mem=16G"
+
+See also:
+    add-machine
+    remove-machine
+    resize-machine
+`
+
+// Info implements Command.Info.
+func (c *replaceMachineCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "replace-machine",
+		Args:    "<machine>",
+		Purpose: "Replaces a machine, moving its units and storage to a new machine.",
+		Doc:     replaceMachineDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *replaceMachineCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.baseMachinesCommand.SetFlags(f)
+	f.StringVar(&c.constraints, "constraints", "", "Constraints for the replacement machine")
+}
+
+// Init implements Command.Init.
+func (c *replaceMachineCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no machine specified")
+	case 1:
+	default:
+		return errors.New("only one machine may be replaced at a time")
+	}
+	if !names.IsValidMachine(args[0]) {
+		return errors.Errorf("invalid machine id %q", args[0])
+	}
+	c.machineId = args[0]
+	if c.constraints != "" {
+		cons, err := constraints.Parse(c.constraints)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.cons = &cons
+	}
+	return nil
+}
+
+func (c *replaceMachineCommand) getAPI() (ReplaceMachineAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *replaceMachineCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	interrupted := make(chan os.Signal, 1)
+	defer close(interrupted)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+
+	var lastMessage string
+	for {
+		result, err := client.ReplaceMachine(c.machineId, c.cons)
+		if err := block.ProcessBlockedError(err, block.BlockRemove); err != nil {
+			return err
+		}
+		if result.Message != lastMessage {
+			ctx.Infof("%s", result.Message)
+			lastMessage = result.Message
+		}
+		if result.Done {
+			return nil
+		}
+		select {
+		case <-c.clock.After(replacePollInterval):
+		case <-interrupted:
+			ctx.Infof("ctrl+c detected; run replace-machine again to resume the replacement")
+			return cmd.ErrSilent
+		}
+	}
+}
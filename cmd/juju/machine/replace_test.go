@@ -0,0 +1,131 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/testing"
+)
+
+type ReplaceMachineSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api   *fakeReplaceMachineAPI
+	clock *testclock.Clock
+}
+
+var _ = gc.Suite(&ReplaceMachineSuite{})
+
+func (s *ReplaceMachineSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeReplaceMachineAPI{}
+	s.clock = testclock.NewClock(time.Now())
+}
+
+func (s *ReplaceMachineSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no machine specified",
+	}, {
+		args: []string{"3", "4"},
+		err:  "only one machine may be replaced at a time",
+	}, {
+		args: []string{"foo"},
+		err:  `invalid machine id "foo"`,
+	}, {
+		args: []string{"3", "--constraints", "mem=lots"},
+		err:  `bad "mem" constraint: must be a non-negative float with optional M/G/T/P suffix`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := cmdtesting.RunCommand(c, machine.NewReplaceMachineCommandForTest(s.api, s.clock), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *ReplaceMachineSuite) TestReplace(c *gc.C) {
+	s.api.results = []params.ReplaceMachineResult{{
+		Replacement: "machine-4",
+		Message:     "provisioning replacement machine 4",
+	}, {
+		Replacement: "machine-4",
+		Message:     "provisioning replacement machine 4",
+	}, {
+		Replacement: "machine-4",
+		Message:     "replaced by machine 4",
+		Done:        true,
+	}}
+
+	done := make(chan struct{})
+	var stderr string
+	go func() {
+		defer close(done)
+		ctx, err := cmdtesting.RunCommand(c,
+			machine.NewReplaceMachineCommandForTest(s.api, s.clock), "3", "--constraints", "mem=16G")
+		c.Check(err, jc.ErrorIsNil)
+		stderr = cmdtesting.Stderr(ctx)
+	}()
+
+	for i := 0; i < 2; i++ {
+		err := s.clock.WaitAdvance(5*time.Second, jujutesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	select {
+	case <-done:
+	case <-time.After(jujutesting.LongWait):
+		c.Fatalf("timed out waiting for command")
+	}
+
+	// Repeated messages are only shown once.
+	c.Assert(stderr, gc.Equals, ""+
+		"provisioning replacement machine 4\n"+
+		"replaced by machine 4\n")
+	cons := constraints.MustParse("mem=16G")
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"ReplaceMachine", []interface{}{"3", &cons}},
+		{"ReplaceMachine", []interface{}{"3", &cons}},
+		{"ReplaceMachine", []interface{}{"3", &cons}},
+		{"Close", nil},
+	})
+}
+
+func (s *ReplaceMachineSuite) TestReplaceError(c *gc.C) {
+	s.api.results = []params.ReplaceMachineResult{{}}
+	s.api.SetErrors(errors.New("machine 3 is a controller and cannot be replaced"))
+	_, err := cmdtesting.RunCommand(c, machine.NewReplaceMachineCommandForTest(s.api, s.clock), "3")
+	c.Assert(err, gc.ErrorMatches, "machine 3 is a controller and cannot be replaced")
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"ReplaceMachine", []interface{}{"3", (*constraints.Value)(nil)}},
+		{"Close", nil},
+	})
+}
+
+type fakeReplaceMachineAPI struct {
+	jujutesting.Stub
+	results []params.ReplaceMachineResult
+}
+
+func (f *fakeReplaceMachineAPI) ReplaceMachine(machine string, cons *constraints.Value) (params.ReplaceMachineResult, error) {
+	f.MethodCall(f, "ReplaceMachine", machine, cons)
+	result := f.results[0]
+	f.results = f.results[1:]
+	return result, f.NextErr()
+}
+
+func (f *fakeReplaceMachineAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
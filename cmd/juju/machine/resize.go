@@ -0,0 +1,187 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/watcher"
+)
+
+// NewResizeMachineCommand returns a command used to resize the instance
+// of a machine.
+func NewResizeMachineCommand() cmd.Command {
+	return modelcmd.Wrap(&resizeMachineCommand{})
+}
+
+// ResizeMachineAPI defines the API methods used by the resize-machine
+// command.
+type ResizeMachineAPI interface {
+	PrepareResizeMachine(machine string, cons constraints.Value) error
+	ResizeMachine(machine string, cons constraints.Value, force bool) (*params.MachineHardware, error)
+	WatchUpgradeSeriesNotifications(string) (watcher.NotifyWatcher, string, error)
+	GetUpgradeSeriesMessages(string, string) ([]string, error)
+	Close() error
+}
+
+// resizeMachineCommand resizes the instance of a machine to hardware
+// matching the given constraints.
+type resizeMachineCommand struct {
+	baseMachinesCommand
+	api ResizeMachineAPI
+
+	machineId   string
+	constraints string
+	force       bool
+	cons        constraints.Value
+}
+
+const resizeMachineDoc = `
+Resizes the instance of a machine to the cheapest instance type on the
+cloud which satisfies the given constraints, such as to give it more
+memory or cores, and records its new hardware in the model.
+
+The workloads on the machine are stopped before its instance is resized,
+and started again afterwards. They are stopped and started by running
+the pre-series-upgrade and post-series-upgrade hooks of the machine's
+units, so charms which handle series upgrades handle resizes too. The
+progress of the hooks is shown as the command runs, and the progress of
+the resize is shown in the machine's modification status.
+
+Use --force to resize the instance without stopping the workloads
+first. Cloud instances are generally stopped in order to be resized, so
+the workloads will be interrupted without warning.
+
+Not all clouds support resizing instances, and controller machines
+cannot be resized.
+
+Examples:
+
+    juju resize-machine 3 --constraints "mem=16G"
+    juju resize-machine 3 --constraints "cores=8 mem=32G" --force
+
+See also:
+    refresh-machine-hardware
+    set-constraints
+    upgrade-series
+`
+
+// Info implements Command.Info.
+func (c *resizeMachineCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "resize-machine",
+		Args:    "<machine>",
+		Purpose: "Resizes the instance of a machine.",
+		Doc:     resizeMachineDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *resizeMachineCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.baseMachinesCommand.SetFlags(f)
+	f.StringVar(&c.constraints, "constraints", "", "Constraints the resized instance must satisfy")
+	f.BoolVar(&c.force, "force", false, "Resize the instance without first stopping its workloads")
+}
+
+// Init implements Command.Init.
+func (c *resizeMachineCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no machine specified")
+	case 1:
+	default:
+		return errors.New("only one machine may be resized at a time")
+	}
+	if !names.IsValidMachine(args[0]) {
+		return errors.Errorf("invalid machine id %q", args[0])
+	}
+	c.machineId = args[0]
+	if c.constraints == "" {
+		return errors.New("no constraints specified")
+	}
+	cons, err := constraints.Parse(c.constraints)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.cons = cons
+	return nil
+}
+
+func (c *resizeMachineCommand) getAPI() (ResizeMachineAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *resizeMachineCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if !c.force {
+		ctx.Infof("stopping workloads on machine %s", c.machineId)
+		err := client.PrepareResizeMachine(c.machineId, c.cons)
+		if err := block.ProcessBlockedError(err, block.BlockChange); err != nil {
+			return err
+		}
+		if err := c.waitForWorkloads(ctx, client); err != nil {
+			return errors.Annotate(err, "stopping workloads")
+		}
+	}
+
+	ctx.Infof("resizing machine %s", c.machineId)
+	hw, err := client.ResizeMachine(c.machineId, c.cons, c.force)
+	if err := block.ProcessBlockedError(err, block.BlockChange); err != nil {
+		return err
+	}
+	if !c.force {
+		if err := c.waitForWorkloads(ctx, client); err != nil {
+			return errors.Annotate(err, "starting workloads")
+		}
+	}
+	ctx.Infof("machine %s resized: %s", c.machineId, formatHardware(hw))
+	return nil
+}
+
+// waitForWorkloads shows the progress of the machine's workloads being
+// stopped or started, until the controller stops the notifications.
+func (c *resizeMachineCommand) waitForWorkloads(ctx *cmd.Context, client ResizeMachineAPI) error {
+	w, wid, err := client.WatchUpgradeSeriesNotifications(c.machineId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer w.Kill()
+	for range w.Changes() {
+		messages, err := client.GetUpgradeSeriesMessages(c.machineId, wid)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(messages) > 0 {
+			ctx.Infof(strings.Join(messages, "\n"))
+		}
+	}
+	if err := w.Wait(); err != nil && !params.IsCodeStopped(err) {
+		return errors.Trace(err)
+	}
+	return nil
+}
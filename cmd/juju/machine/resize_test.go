@@ -0,0 +1,139 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/testing"
+)
+
+type ResizeMachineSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api *fakeResizeMachineAPI
+}
+
+var _ = gc.Suite(&ResizeMachineSuite{})
+
+func (s *ResizeMachineSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	mem, cores := uint64(16384), uint64(4)
+	s.api = &fakeResizeMachineAPI{
+		hardware: &params.MachineHardware{Mem: &mem, Cores: &cores},
+		messages: []string{"workloads done"},
+	}
+}
+
+func (s *ResizeMachineSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no machine specified",
+	}, {
+		args: []string{"3", "4", "--constraints", "mem=16G"},
+		err:  "only one machine may be resized at a time",
+	}, {
+		args: []string{"foo", "--constraints", "mem=16G"},
+		err:  `invalid machine id "foo"`,
+	}, {
+		args: []string{"3"},
+		err:  "no constraints specified",
+	}, {
+		args: []string{"3", "--constraints", "mem=lots"},
+		err:  `bad "mem" constraint: must be a non-negative float with optional M/G/T/P suffix`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := cmdtesting.RunCommand(c, machine.NewResizeMachineCommandForTest(s.api), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *ResizeMachineSuite) TestResize(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, machine.NewResizeMachineCommandForTest(s.api), "3", "--constraints", "mem=16G")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, ""+
+		"stopping workloads on machine 3\n"+
+		"workloads done\n"+
+		"resizing machine 3\n"+
+		"workloads done\n"+
+		"machine 3 resized: cores=4 mem=16384M\n")
+	cons := constraints.MustParse("mem=16G")
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"PrepareResizeMachine", []interface{}{"3", cons}},
+		{"WatchUpgradeSeriesNotifications", []interface{}{"3"}},
+		{"GetUpgradeSeriesMessages", []interface{}{"3", "watcher-id"}},
+		{"ResizeMachine", []interface{}{"3", cons, false}},
+		{"WatchUpgradeSeriesNotifications", []interface{}{"3"}},
+		{"GetUpgradeSeriesMessages", []interface{}{"3", "watcher-id"}},
+		{"Close", nil},
+	})
+}
+
+func (s *ResizeMachineSuite) TestResizeForce(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, machine.NewResizeMachineCommandForTest(s.api), "3", "--constraints", "mem=16G", "--force")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, ""+
+		"resizing machine 3\n"+
+		"machine 3 resized: cores=4 mem=16384M\n")
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"ResizeMachine", []interface{}{"3", constraints.MustParse("mem=16G"), true}},
+		{"Close", nil},
+	})
+}
+
+func (s *ResizeMachineSuite) TestPrepareError(c *gc.C) {
+	s.api.SetErrors(errors.New("machine 3 is a controller and cannot be resized"))
+	_, err := cmdtesting.RunCommand(c, machine.NewResizeMachineCommandForTest(s.api), "3", "--constraints", "mem=16G")
+	c.Assert(err, gc.ErrorMatches, "machine 3 is a controller and cannot be resized")
+	s.api.CheckCallNames(c, "PrepareResizeMachine", "Close")
+}
+
+type fakeResizeMachineAPI struct {
+	jujutesting.Stub
+	hardware *params.MachineHardware
+	messages []string
+}
+
+func (f *fakeResizeMachineAPI) PrepareResizeMachine(machine string, cons constraints.Value) error {
+	f.MethodCall(f, "PrepareResizeMachine", machine, cons)
+	return f.NextErr()
+}
+
+func (f *fakeResizeMachineAPI) ResizeMachine(machine string, cons constraints.Value, force bool) (*params.MachineHardware, error) {
+	f.MethodCall(f, "ResizeMachine", machine, cons, force)
+	return f.hardware, f.NextErr()
+}
+
+func (f *fakeResizeMachineAPI) WatchUpgradeSeriesNotifications(machine string) (watcher.NotifyWatcher, string, error) {
+	f.MethodCall(f, "WatchUpgradeSeriesNotifications", machine)
+	// The controller sends a single change, then stops the watcher
+	// once the workloads have finished.
+	ch := make(chan struct{}, 1)
+	ch <- struct{}{}
+	close(ch)
+	w := watchertest.NewMockNotifyWatcher(ch)
+	w.KillErr(&params.Error{Code: params.CodeStopped})
+	return w, "watcher-id", f.NextErr()
+}
+
+func (f *fakeResizeMachineAPI) GetUpgradeSeriesMessages(machine, watcherId string) ([]string, error) {
+	f.MethodCall(f, "GetUpgradeSeriesMessages", machine, watcherId)
+	return f.messages, f.NextErr()
+}
+
+func (f *fakeResizeMachineAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
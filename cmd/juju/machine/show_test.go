@@ -4,11 +4,14 @@
 package machine_test
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/machine"
 	"github.com/juju/juju/testing"
 )
@@ -124,6 +127,71 @@ func (s *MachineShowCommandSuite) TestShowSingleMachine(c *gc.C) {
 		"    hardware: availability-zone=us-east-1\n")
 }
 
+type engineHealthStatusAPI struct {
+	fakeStatusAPI
+}
+
+func (api *engineHealthStatusAPI) Status(c []string) (*params.FullStatus, error) {
+	result, err := api.fakeStatusAPI.Status(c)
+	if err != nil {
+		return nil, err
+	}
+	m := result.Machines["0"]
+	m.EngineHealth = &params.EngineHealth{
+		Workers: []params.EngineWorkerHealth{{
+			Name:     "api-address-updater",
+			Health:   "bouncing",
+			State:    "started",
+			Restarts: 5,
+		}, {
+			Name:   "machiner",
+			Health: "failing",
+			State:  "stopped",
+			Error:  "boom",
+		}},
+		Updated: time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	result.Machines["0"] = m
+	return result, nil
+}
+
+func (s *MachineShowCommandSuite) TestShowMachineEngineHealth(c *gc.C) {
+	context, err := cmdtesting.RunCommand(c, machine.NewShowCommandForTest(&engineHealthStatusAPI{}), "0", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, ""+
+		"model: dummyenv\n"+
+		"machines:\n"+
+		"  \"0\":\n"+
+		"    juju-status:\n"+
+		"      current: started\n"+
+		"    dns-name: 10.0.0.1\n"+
+		"    ip-addresses:\n"+
+		"    - 10.0.0.1\n"+
+		"    - 10.0.1.1\n"+
+		"    instance-id: juju-badd06-0\n"+
+		"    series: trusty\n"+
+		"    network-interfaces:\n"+
+		"      eth0:\n"+
+		"        ip-addresses:\n"+
+		"        - 10.0.0.1\n"+
+		"        - 10.0.1.1\n"+
+		"        mac-address: aa:bb:cc:dd:ee:ff\n"+
+		"        is-up: true\n"+
+		"    constraints: mem=3584M\n"+
+		"    hardware: availability-zone=us-east-1\n"+
+		"    engine-health:\n"+
+		"      updated: 2021-01-02 03:04:05Z\n"+
+		"      workers:\n"+
+		"        api-address-updater:\n"+
+		"          health: bouncing\n"+
+		"          state: started\n"+
+		"          restarts: 5\n"+
+		"        machiner:\n"+
+		"          health: failing\n"+
+		"          state: stopped\n"+
+		"          error: boom\n")
+}
+
 func (s *MachineShowCommandSuite) TestShowTabularMachine(c *gc.C) {
 	context, err := cmdtesting.RunCommand(c, newMachineShowCommand(), "--format", "tabular", "0", "1")
 	c.Assert(err, jc.ErrorIsNil)
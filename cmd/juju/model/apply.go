@@ -0,0 +1,151 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"bytes"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/modelspec"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	coremodelspec "github.com/juju/juju/core/modelspec"
+)
+
+const applyHelpDoc = `
+Sets the declarative spec of a model. The model is then continuously
+reconciled towards the spec: the number of units, the charm config,
+relations and offers of the applications named in the spec are changed
+to match it. Applications, relations and offers not named in the spec
+are left alone.
+
+Applications are not deployed, and their charms are not refreshed; use
+the deploy and refresh commands for that. Differences which cannot be
+reconciled are reported by the show-model-spec command.
+
+The spec is read from the file given with --file, or from standard
+input if the file is "-". For example:
+
+    applications:
+      mysql:
+        charm: cs:mysql
+        units: 2
+        config:
+          max-connections: 200
+      wordpress:
+        charm: cs:wordpress
+        units: 3
+    relations:
+    - [wordpress, mysql]
+    offers:
+      hosted-mysql:
+        application: mysql
+        endpoints: [db]
+
+This command is experimental, and is only available with the
+"model-spec" feature flag enabled.
+
+Examples:
+
+    juju apply -f model.yaml
+    cat model.yaml | juju apply -f -
+
+See also:
+    show-model-spec
+    remove-model-spec
+`
+
+// NewApplyCommand returns a command to set the declarative spec of a
+// model.
+func NewApplyCommand() cmd.Command {
+	return modelcmd.Wrap(&applyCommand{})
+}
+
+type applyCommand struct {
+	modelcmd.ModelCommandBase
+	api ApplyAPI
+
+	specFile cmd.FileVar
+}
+
+// ApplyAPI defines the API methods used by the apply command.
+type ApplyAPI interface {
+	Close() error
+	SetModelSpec(spec string) (int, error)
+}
+
+// Info implements Command.
+func (c *applyCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "apply",
+		Purpose: "Sets the declarative spec a model is reconciled towards.",
+		Doc:     applyHelpDoc,
+	})
+}
+
+// SetFlags implements Command.
+func (c *applyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.Var(&c.specFile, "f", "path to the yaml-formatted model spec, or - for standard input")
+	f.Var(&c.specFile, "file", "")
+}
+
+// Init implements Command.
+func (c *applyCommand) Init(args []string) error {
+	if c.specFile.Path == "" {
+		return errors.New("no model spec specified")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *applyCommand) getAPI() (ApplyAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelspec.NewClient(root), nil
+}
+
+// Run implements Command.
+func (c *applyCommand) Run(ctx *cmd.Context) error {
+	spec, err := c.readSpec(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Check the spec here to report mistakes before connecting.
+	if _, err := coremodelspec.Parse(spec); err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	revision, err := client.SetModelSpec(string(spec))
+	if err != nil {
+		return block.ProcessBlockedError(errors.Annotate(err, "cannot apply model spec"), block.BlockChange)
+	}
+	ctx.Infof("Applied model spec revision %d.", revision)
+	return nil
+}
+
+func (c *applyCommand) readSpec(ctx *cmd.Context) ([]byte, error) {
+	if c.specFile.Path != "-" {
+		return c.specFile.Read(ctx)
+	}
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(ctx.Stdin); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+const applySpec = `
+applications:
+  mysql:
+    charm: cs:mysql
+    units: 2
+`
+
+type ApplySuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  fakeApplyClient
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&ApplySuite{})
+
+type fakeApplyClient struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeApplyClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeApplyClient) SetModelSpec(spec string) (int, error) {
+	f.MethodCall(f, "SetModelSpec", spec)
+	return 2, f.NextErr()
+}
+
+func (s *ApplySuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake.ResetCalls()
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		ModelUUID: testing.ModelTag.Id(),
+		ModelType: coremodel.IAAS,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *ApplySuite) writeSpec(c *gc.C, spec string) string {
+	path := filepath.Join(c.MkDir(), "model.yaml")
+	err := ioutil.WriteFile(path, []byte(spec), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *ApplySuite) TestInit(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, model.NewApplyCommandForTest(&s.fake, s.store))
+	c.Assert(err, gc.ErrorMatches, "no model spec specified")
+	_, err = cmdtesting.RunCommand(c, model.NewApplyCommandForTest(&s.fake, s.store), "-f", "model.yaml", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *ApplySuite) TestApply(c *gc.C) {
+	path := s.writeSpec(c, applySpec)
+	ctx, err := cmdtesting.RunCommand(c, model.NewApplyCommandForTest(&s.fake, s.store), "-f", path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Applied model spec revision 2.\n")
+	s.fake.CheckCallNames(c, "SetModelSpec", "Close")
+	s.fake.CheckCall(c, 0, "SetModelSpec", applySpec)
+}
+
+func (s *ApplySuite) TestApplyStdin(c *gc.C) {
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = strings.NewReader(applySpec)
+	command := model.NewApplyCommandForTest(&s.fake, s.store)
+	err := cmdtesting.InitCommand(command, []string{"-f", "-"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = command.Run(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCall(c, 0, "SetModelSpec", applySpec)
+}
+
+func (s *ApplySuite) TestApplyInvalidSpec(c *gc.C) {
+	path := s.writeSpec(c, "applications: {mysql: {units: 1}}\n")
+	_, err := cmdtesting.RunCommand(c, model.NewApplyCommandForTest(&s.fake, s.store), "-f", path)
+	c.Assert(err, gc.ErrorMatches, `application "mysql" with no charm not valid`)
+	s.fake.CheckNoCalls(c)
+}
+
+func (s *ApplySuite) TestApplyError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	path := s.writeSpec(c, applySpec)
+	_, err := cmdtesting.RunCommand(c, model.NewApplyCommandForTest(&s.fake, s.store), "-f", path)
+	c.Assert(err, gc.ErrorMatches, "cannot apply model spec: boom")
+}
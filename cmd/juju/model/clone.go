@@ -0,0 +1,186 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/modelcloner"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+// NewCloneCommand returns a fully constructed clone-model command.
+// The deploy command is used to deploy the source model's applications
+// to the new model, when they are requested.
+func NewCloneCommand(newDeployCommand func() modelcmd.ModelCommand) cmd.Command {
+	return modelcmd.Wrap(&cloneCommand{
+		newDeployCommand: newDeployCommand,
+	})
+}
+
+type cloneCommand struct {
+	modelcmd.ModelCommandBase
+	api              CloneAPI
+	newDeployCommand func() modelcmd.ModelCommand
+
+	name                string
+	owner               string
+	includeApplications bool
+}
+
+const cloneHelpDoc = `
+Creates a new model based on an existing one. The new model uses the
+same cloud, region and cloud credential as the source model, and has the
+same model config and model constraints; config inherited from the
+controller or cloud defaults is left to be inherited afresh.
+
+With --include-applications, the source model's applications, machines
+and relations are exported as a bundle and deployed to the new model.
+No workload data (storage contents, databases and so on) is copied.
+
+The new model is not made the current model; use the switch command to
+start working with it.
+
+Examples:
+
+    juju clone-model staging
+    juju clone-model -m production staging --include-applications
+    juju clone-model staging --owner bob
+
+See also:
+    add-model
+    export-bundle
+    switch
+`
+
+// Info implements Command.
+func (c *cloneCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "clone-model",
+		Args:    "<new model name>",
+		Purpose: "Creates a new model based on an existing one.",
+		Doc:     cloneHelpDoc,
+	})
+}
+
+// SetFlags implements Command.
+func (c *cloneCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.owner, "owner", "", "The owner of the new model if not the current user")
+	f.BoolVar(&c.includeApplications, "include-applications", false, "Deploy the source model's applications to the new model")
+}
+
+// Init implements Command.
+func (c *cloneCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no model name specified")
+	}
+	c.name, args = args[0], args[1:]
+	if !names.IsValidModelName(c.name) {
+		return errors.NotValidf("model name %q", c.name)
+	}
+	if c.owner != "" && !names.IsValidUser(c.owner) {
+		return errors.NotValidf("user name %q", c.owner)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// CloneAPI specifies the used function calls of the ModelCloner.
+type CloneAPI interface {
+	Close() error
+	CloneModel(args modelcloner.CloneModelArgs) (modelcloner.CloneModelResult, error)
+}
+
+func (c *cloneCommand) getAPI() (CloneAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewControllerAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelcloner.NewClient(root), nil
+}
+
+// Run implements Command.
+func (c *cloneCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	sourceName, sourceDetails, err := c.ModelCommandBase.ModelDetails()
+	if err != nil {
+		return errors.Annotate(err, "getting model details")
+	}
+	args := modelcloner.CloneModelArgs{
+		Source:              names.NewModelTag(sourceDetails.ModelUUID),
+		Name:                c.name,
+		IncludeApplications: c.includeApplications,
+	}
+	if c.owner != "" {
+		args.Owner = names.NewUserTag(c.owner)
+	}
+	result, err := client.CloneModel(args)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "clone a model")
+		}
+		return block.ProcessBlockedError(errors.Annotate(err, "cannot clone model"), block.BlockChange)
+	}
+
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	modelName := jujuclient.JoinOwnerModelName(result.Owner, result.Name)
+	store := modelcmd.QualifyingClientStore{ClientStore: c.ClientStore()}
+	if err := store.UpdateModel(controllerName, modelName, jujuclient.ModelDetails{
+		ModelUUID: result.ModelUUID,
+		ModelType: result.Type,
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Cloned model %q as %q.", sourceName, modelName)
+
+	if result.Bundle == "" {
+		return nil
+	}
+	return c.deployBundle(ctx, controllerName+":"+modelName, result.Bundle)
+}
+
+// deployBundle deploys the exported applications to the new model,
+// using the deploy command so that charms are resolved and added
+// exactly as they would be for any other bundle.
+func (c *cloneCommand) deployBundle(ctx *cmd.Context, modelName, bundle string) error {
+	dir, err := ioutil.TempDir("", "clone-model")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.RemoveAll(dir)
+
+	bundlePath := filepath.Join(dir, "bundle.yaml")
+	if err := ioutil.WriteFile(bundlePath, []byte(bundle), 0600); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Deploying applications to %q.", modelName)
+	code := cmd.Main(c.newDeployCommand(), ctx, []string{"-m", modelName, bundlePath})
+	if code == 0 {
+		return nil
+	}
+	return cmd.ErrSilent
+}
@@ -0,0 +1,190 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for info.
+
+package model_test
+
+import (
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/modelcloner"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/cmd/modelcmd"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+const cloneModelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00e"
+
+type CloneCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake   fakeCloneClient
+	store  *jujuclient.MemStore
+	deploy *fakeDeployCommand
+}
+
+var _ = gc.Suite(&CloneCommandSuite{})
+
+type fakeCloneClient struct {
+	gitjujutesting.Stub
+	result modelcloner.CloneModelResult
+}
+
+func (f *fakeCloneClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeCloneClient) CloneModel(args modelcloner.CloneModelArgs) (modelcloner.CloneModelResult, error) {
+	f.MethodCall(f, "CloneModel", args)
+	return f.result, f.NextErr()
+}
+
+type fakeDeployCommand struct {
+	modelcmd.ModelCommandBase
+	modelName string
+	bundle    string
+	err       error
+}
+
+func (c *fakeDeployCommand) Info() *cmd.Info {
+	return &cmd.Info{Name: "deploy"}
+}
+
+func (c *fakeDeployCommand) Init(args []string) error {
+	bundle, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+	c.bundle = string(bundle)
+	return nil
+}
+
+func (c *fakeDeployCommand) Run(ctx *cmd.Context) error {
+	var err error
+	c.modelName, err = c.ModelIdentifier()
+	if err != nil {
+		return err
+	}
+	return c.err
+}
+
+func (s *CloneCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = fakeCloneClient{
+		result: modelcloner.CloneModelResult{
+			ModelUUID: cloneModelUUID,
+			Name:      "clone",
+			Owner:     names.NewUserTag("admin"),
+			Type:      coremodel.IAAS,
+		},
+	}
+	s.deploy = &fakeDeployCommand{}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		ModelUUID: testing.ModelTag.Id(),
+		ModelType: coremodel.IAAS,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *CloneCommandSuite) newCommand() cmd.Command {
+	return model.NewCloneCommandForTest(&s.fake, func() modelcmd.ModelCommand {
+		s.deploy.SetClientStore(s.store)
+		return modelcmd.Wrap(s.deploy)
+	}, s.store)
+}
+
+func (s *CloneCommandSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no model name specified",
+	}, {
+		args: []string{"Bad_Name"},
+		err:  `model name "Bad_Name" not valid`,
+	}, {
+		args: []string{"clone", "--owner", "not/a/user"},
+		err:  `user name "not/a/user" not valid`,
+	}, {
+		args: []string{"clone", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := cmdtesting.RunCommand(c, s.newCommand(), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *CloneCommandSuite) TestClone(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "clone")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"CloneModel", []interface{}{modelcloner.CloneModelArgs{
+			Source: testing.ModelTag,
+			Name:   "clone",
+		}}},
+		{"Close", nil},
+	})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Cloned model \"admin/mymodel\" as \"admin/clone\".\n")
+
+	// The new model is known locally, but is not made current.
+	c.Assert(s.store.Models["testing"].Models["admin/clone"], jc.DeepEquals, jujuclient.ModelDetails{
+		ModelUUID: cloneModelUUID,
+		ModelType: coremodel.IAAS,
+	})
+	c.Assert(s.store.Models["testing"].CurrentModel, gc.Equals, "admin/mymodel")
+	c.Assert(s.deploy.modelName, gc.Equals, "")
+}
+
+func (s *CloneCommandSuite) TestCloneWithApplications(c *gc.C) {
+	s.fake.result.Owner = names.NewUserTag("bob")
+	s.fake.result.Bundle = "applications: {}\n"
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "clone", "--owner", "bob", "--include-applications")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCall(c, 0, "CloneModel", modelcloner.CloneModelArgs{
+		Source:              testing.ModelTag,
+		Name:                "clone",
+		Owner:               names.NewUserTag("bob"),
+		IncludeApplications: true,
+	})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, ""+
+		"Cloned model \"admin/mymodel\" as \"bob/clone\".\n"+
+		"Deploying applications to \"testing:bob/clone\".\n")
+	c.Assert(s.store.Models["testing"].Models["bob/clone"].ModelUUID, gc.Equals, cloneModelUUID)
+	c.Assert(s.deploy.modelName, gc.Equals, "bob/clone")
+	c.Assert(s.deploy.bundle, gc.Equals, "applications: {}\n")
+}
+
+func (s *CloneCommandSuite) TestCloneDeployFailed(c *gc.C) {
+	s.fake.result.Bundle = "applications: {}\n"
+	s.deploy.err = errors.New("boom")
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "clone", "--include-applications")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	// The model was still created.
+	c.Assert(s.store.Models["testing"].Models["admin/clone"].ModelUUID, gc.Equals, cloneModelUUID)
+}
+
+func (s *CloneCommandSuite) TestCloneFailed(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "clone")
+	c.Assert(err, gc.ErrorMatches, "cannot clone model: boom")
+	_, ok := s.store.Models["testing"].Models["admin/clone"]
+	c.Assert(ok, jc.IsFalse)
+}
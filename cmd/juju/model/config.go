@@ -44,6 +44,12 @@ the fields when they're encountered.
 
 The reset flag will set the provided key(s) to the model default for those key(s).
 Any key not in the default model config, will be deleted.
+
+The trace flag shows how the value of a single key is resolved: the value set
+by each config source (Juju defaults, controller defaults, region defaults and
+the model itself) is listed in order, later sources overriding earlier ones.
+The source providing the value in use is marked. Keys that are not set on the
+model follow any later changes to their defaults, see model-defaults.
 `
 	modelConfigHelpDocKeys = `
 The following keys are available:
@@ -73,6 +79,9 @@ Set model config values of a specific model:
 Reset the values of the provided keys to model defaults:
     juju model-config --reset default-series,test-mode
 
+Show where the value of ftp-proxy comes from:
+    juju model-config --trace ftp-proxy
+
 See also:
     models
     model-defaults
@@ -176,6 +185,7 @@ type configCommand struct {
 	setOptions           common.ConfigFlag
 	ignoreAgentVersion   bool
 	ignoreReadOnlyFields bool
+	trace                bool
 }
 
 // configCommandAPI defines an API interface to be used during testing.
@@ -185,6 +195,7 @@ type configCommandAPI interface {
 	ModelGetWithMetadata() (config.ConfigValues, error)
 	ModelSet(config map[string]interface{}) error
 	ModelUnset(keys ...string) error
+	ModelConfigTrace(key string) (config.ConfigValueTrace, error)
 }
 
 // Info implements part of the cmd.Command interface.
@@ -222,6 +233,7 @@ func (c *configCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.reset), "reset", "Reset the provided comma delimited keys, deletes keys not in the model config")
 	f.BoolVar(&c.ignoreAgentVersion, "ignore-agent-version", false, "Skip the error when passing in the agent version configuration (deprecated)")
 	f.BoolVar(&c.ignoreReadOnlyFields, "ignore-read-only-fields", false, "Ignore read only fields that might cause errors to be emitted while processing yaml documents")
+	f.BoolVar(&c.trace, "trace", false, "Show the value set for a key by each config source")
 }
 
 // Init implements part of the cmd.Command interface.
@@ -233,6 +245,9 @@ func (c *configCommand) Init(args []string) error {
 	if err := c.parseResetKeys(); err != nil {
 		return errors.Trace(err)
 	}
+	if c.trace {
+		return c.handleTraceArgs(args)
+	}
 
 	switch len(args) {
 	case 0:
@@ -254,6 +269,19 @@ func (c *configCommand) handleZeroArgs() error {
 	return nil
 }
 
+// handleTraceArgs handles the positional args when tracing a key.
+func (c *configCommand) handleTraceArgs(args []string) error {
+	if len(c.reset) > 0 {
+		return errors.New("cannot trace and reset model values simultaneously")
+	}
+	if len(args) != 1 || strings.Contains(args[0], "=") {
+		return errors.New("--trace requires a single key")
+	}
+	c.keys = args
+	c.action = c.traceConfig
+	return nil
+}
+
 // handleOneArg handles the case where there is one positional arg.
 func (c *configCommand) handleOneArg(arg string) error {
 	if arg == "-" {
@@ -471,6 +499,40 @@ func (c *configCommand) getConfig(client configCommandAPI, ctx *cmd.Context) err
 	return nil
 }
 
+// configSourceValue holds the value of a key in one config source.
+type configSourceValue struct {
+	Source string      `json:"source" yaml:"source"`
+	Value  interface{} `json:"value" yaml:"value"`
+}
+
+// configTrace holds the result of tracing a key's value.
+type configTrace struct {
+	Value   interface{}         `json:"value" yaml:"value"`
+	Source  string              `json:"source" yaml:"source"`
+	Sources []configSourceValue `json:"sources" yaml:"sources"`
+}
+
+// traceConfig writes the value of a single key as set by each config
+// source to the cmd.Context.
+func (c *configCommand) traceConfig(client configCommandAPI, ctx *cmd.Context) error {
+	trace, err := client.ModelConfigTrace(c.keys[0])
+	if errors.IsNotSupported(err) {
+		return errors.New("tracing model config is not supported by this controller")
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	result := configTrace{
+		Value:   trace.Value,
+		Source:  trace.Source,
+		Sources: make([]configSourceValue, len(trace.Sources)),
+	}
+	for i, value := range trace.Sources {
+		result.Sources[i] = configSourceValue{Source: value.Source, Value: value.Value}
+	}
+	return c.out.Write(ctx, result)
+}
+
 func (c *configCommand) getFilteredModel(client configCommandAPI) (config.ConfigValues, error) {
 	attrs, err := client.ModelGetWithMetadata()
 	if err != nil {
@@ -558,6 +620,9 @@ func isModelAttribute(attr string) bool {
 
 // formatConfigTabular writes a tabular summary of config information.
 func formatConfigTabular(writer io.Writer, value interface{}) error {
+	if trace, ok := value.(configTrace); ok {
+		return formatConfigTraceTabular(writer, trace)
+	}
 	configValues, ok := value.(config.ConfigValues)
 	if !ok {
 		return errors.Errorf("expected value of type %T, got %T", configValues, value)
@@ -592,6 +657,29 @@ func formatConfigTabular(writer io.Writer, value interface{}) error {
 	return nil
 }
 
+// formatConfigTraceTabular writes a tabular summary of a key's value
+// in each config source, marking the source of the value in use.
+func formatConfigTraceTabular(writer io.Writer, trace configTrace) error {
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{
+		TabWriter: tw,
+	}
+	w.Println("From", "Value", "In use")
+	for _, value := range trace.Sources {
+		out := &bytes.Buffer{}
+		if err := cmd.FormatYaml(out, value.Value); err != nil {
+			return errors.Annotatef(err, "formatting %s value", value.Source)
+		}
+		var inUse string
+		if value.Source == trace.Source {
+			inUse = "*"
+		}
+		w.Println(value.Source, strings.TrimSuffix(out.String(), "\n"), inUse)
+	}
+	tw.Flush()
+	return nil
+}
+
 // ConfigDetails gets ModelDetails when a model is not available
 // to use.
 func ConfigDetails() (map[string]interface{}, error) {
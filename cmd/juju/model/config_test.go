@@ -8,11 +8,13 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/testing"
 )
 
@@ -64,6 +66,23 @@ func (s *ConfigCommandSuite) TestInit(c *gc.C) {
 			desc:       "get multiple fails",
 			args:       []string{"one", "two"},
 			errorMatch: "can only retrieve a single value, or all values",
+		}, {
+			// Test trace
+			desc:   "trace one succeeds",
+			args:   []string{"--trace", "one"},
+			nilErr: true,
+		}, {
+			desc:       "trace requires a key",
+			args:       []string{"--trace"},
+			errorMatch: "--trace requires a single key",
+		}, {
+			desc:       "trace cannot have k=v pairs",
+			args:       []string{"--trace", "one=two"},
+			errorMatch: "--trace requires a single key",
+		}, {
+			desc:       "cannot trace and reset at the same time",
+			args:       []string{"--trace", "--reset", "one", "two"},
+			errorMatch: "cannot trace and reset model values simultaneously",
 		}, {
 			// test variations
 			desc:   "test reset interspersed",
@@ -215,6 +234,55 @@ func (s *ConfigCommandSuite) TestAllValuesTabular(c *gc.C) {
 	c.Assert(output, gc.Equals, expected)
 }
 
+func (s *ConfigCommandSuite) setTrace() {
+	s.fake.trace = config.ConfigValueTrace{
+		Value:  "http://proxy",
+		Source: "controller",
+		Sources: []config.ConfigValue{
+			{Value: "", Source: "default"},
+			{Value: "http://proxy", Source: "controller"},
+		},
+	}
+}
+
+func (s *ConfigCommandSuite) TestTraceTabular(c *gc.C) {
+	s.setTrace()
+	context, err := s.run(c, "--trace", "ftp-proxy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.keys, jc.DeepEquals, []string{"ftp-proxy"})
+
+	output := cmdtesting.Stdout(context)
+	expected := "" +
+		"From        Value         In use\n" +
+		"default     \"\"            \n" +
+		"controller  http://proxy  *\n" +
+		"\n"
+	c.Assert(output, gc.Equals, expected)
+}
+
+func (s *ConfigCommandSuite) TestTraceYAML(c *gc.C) {
+	s.setTrace()
+	context, err := s.run(c, "--trace", "ftp-proxy", "--format=yaml")
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := cmdtesting.Stdout(context)
+	expected := "" +
+		"value: http://proxy\n" +
+		"source: controller\n" +
+		"sources:\n" +
+		"- source: default\n" +
+		"  value: \"\"\n" +
+		"- source: controller\n" +
+		"  value: http://proxy\n"
+	c.Assert(output, gc.Equals, expected)
+}
+
+func (s *ConfigCommandSuite) TestTraceNotSupported(c *gc.C) {
+	s.fake.err = errors.NotSupportedf("ModelConfigTrace on v2 facade")
+	_, err := s.run(c, "--trace", "ftp-proxy")
+	c.Assert(err, gc.ErrorMatches, "tracing model config is not supported by this controller")
+}
+
 func (s *ConfigCommandSuite) TestSetAgentVersion(c *gc.C) {
 	_, err := s.run(c, "agent-version=2.0.0")
 	c.Assert(err, gc.ErrorMatches, `"agent-version" must be set via "upgrade-model"`)
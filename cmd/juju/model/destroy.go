@@ -21,11 +21,13 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/modelconfig"
+	"github.com/juju/juju/api/modeldestruction"
 	"github.com/juju/juju/api/modelmanager"
 	"github.com/juju/juju/api/storage"
 	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/common"
 	rcmd "github.com/juju/juju/cmd/juju/romulus"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/cmd/output"
@@ -35,6 +37,11 @@ import (
 
 const (
 	slaUnsupported = "unsupported"
+
+	// watchRefreshInterval is how often destroy-model --watch shows
+	// what the model's destruction is waiting on when that has not
+	// changed, so that the ages shown stay current.
+	watchRefreshInterval = time.Minute
 )
 
 var logger = loggo.GetLogger("juju.cmd.juju.model")
@@ -62,9 +69,11 @@ type destroyCommand struct {
 	timeout        time.Duration
 	destroyStorage bool
 	releaseStorage bool
+	watch          bool
 	api            DestroyModelAPI
 	configAPI      ModelConfigAPI
 	storageAPI     StorageAPI
+	progressAPI    DestructionProgressAPI
 
 	Force  bool
 	NoWait bool
@@ -94,6 +103,10 @@ proceed to a next step until the current step has finished.
 However, when using --force, users can also specify --no-wait to progress through steps 
 without delay waiting for each step to complete.
 
+While waiting for the model to be removed, use --watch to show which machines,
+applications, storage and cross-model relations remain, along with their
+status and how long they have had it.
+
 Examples:
 
     juju destroy-model test
@@ -102,6 +115,7 @@ Examples:
     juju destroy-model -y mymodel --release-storage
     juju destroy-model -y mymodel --force
     juju destroy-model -y mymodel --force --no-wait
+    juju destroy-model -y mymodel --watch
 
 See also:
     destroy-controller
@@ -127,6 +141,14 @@ type DestroyModelAPI interface {
 	ModelStatus(models ...names.ModelTag) ([]base.ModelStatus, error)
 }
 
+// DestructionProgressAPI defines the methods on the ModelDestruction
+// API that the destroy command calls when watching. It is exported for
+// mocking in tests.
+type DestructionProgressAPI interface {
+	Close() error
+	Progress() (params.ModelDestructionProgress, error)
+}
+
 // ModelConfigAPI defines the methods on the modelconfig
 // API that the destroy command calls. It is exported for mocking in tests.
 type ModelConfigAPI interface {
@@ -155,6 +177,7 @@ func (c *destroyCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.releaseStorage, "release-storage", false, "Release all storage instances from the model, and management of the controller, without destroying them")
 	f.BoolVar(&c.Force, "force", false, "Force destroy model ignoring any errors")
 	f.BoolVar(&c.NoWait, "no-wait", false, "Rush through model destruction without waiting for each individual step to complete")
+	f.BoolVar(&c.watch, "watch", false, "Show what model destruction is waiting on until it completes")
 	c.fs = f
 }
 
@@ -207,6 +230,21 @@ func (c *destroyCommand) getStorageAPI() (StorageAPI, error) {
 	return storage.NewClient(root), nil
 }
 
+func (c *destroyCommand) getProgressAPI() (DestructionProgressAPI, error) {
+	if c.progressAPI != nil {
+		return c.progressAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if root.BestFacadeVersion("ModelDestruction") == 0 {
+		_ = root.Close()
+		return nil, errors.NotSupportedf("watching model destruction with this controller")
+	}
+	return modeldestruction.NewClient(root), nil
+}
+
 // Run implements Command.Run
 func (c *destroyCommand) Run(ctx *cmd.Context) error {
 	noWaitSet := false
@@ -316,6 +354,15 @@ upgrade the controller to version 2.3 or greater.
 		}
 	}
 
+	var progressAPI DestructionProgressAPI
+	if c.watch {
+		progressAPI, err = c.getProgressAPI()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer progressAPI.Close()
+	}
+
 	// Attempt to destroy the model.
 	fmt.Fprint(ctx.Stderr, "Destroying model")
 	var destroyStorage *bool
@@ -341,7 +388,7 @@ upgrade the controller to version 2.3 or greater.
 
 	// Wait for model to be destroyed.
 	if err := waitForModelDestroyed(
-		ctx, api,
+		ctx, api, progressAPI,
 		names.NewModelTag(modelDetails.ModelUUID),
 		c.timeout,
 		c.clock,
@@ -402,9 +449,13 @@ func (data *modelData) isEmpty() bool {
 		data.filesystemCount == 0
 }
 
+// waitForModelDestroyed waits for the model to be removed, reporting
+// what remains as it goes. If progressAPI is not nil, what the model's
+// destruction is waiting on is shown in detail.
 func waitForModelDestroyed(
 	ctx *cmd.Context,
 	api DestroyModelAPI,
+	progressAPI DestructionProgressAPI,
 	tag names.ModelTag,
 	timeout time.Duration,
 	clock jujuclock.Clock,
@@ -428,6 +479,7 @@ func waitForModelDestroyed(
 	reported := ""
 	lineLength := 0
 	const perLineLength = 80
+	watcher := destructionWatcher{api: progressAPI}
 	for {
 		select {
 		case <-interrupted:
@@ -443,6 +495,14 @@ func waitForModelDestroyed(
 				// model has been destroyed successfully.
 				return nil
 			}
+			intervalSeconds = 2 * time.Second
+			if progressAPI != nil {
+				err := watcher.update(ctx.Stderr, clock.Now())
+				if err == nil {
+					continue
+				}
+				logger.Debugf("cannot get model destruction progress: %v", err)
+			}
 			msg := formatDestroyModelInfo(data)
 			if reported == msg {
 				if lineLength == perLineLength {
@@ -457,11 +517,71 @@ func waitForModelDestroyed(
 				reported = msg
 				lineLength = len(msg) + 3
 			}
-			intervalSeconds = 2 * time.Second
 		}
 	}
 }
 
+// destructionWatcher shows what the destruction of a model is waiting
+// on whenever that changes.
+type destructionWatcher struct {
+	api        DestructionProgressAPI
+	reported   string
+	reportedAt time.Time
+}
+
+// update gets the progress of the model's destruction and writes it to
+// the output if it has changed since last written, or if it was last
+// written longer ago than watchRefreshInterval.
+func (w *destructionWatcher) update(out io.Writer, now time.Time) error {
+	progress, err := w.api.Progress()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Ages are left out when checking for changes, since they always do.
+	var buf bytes.Buffer
+	formatDestructionProgress(&buf, progress, time.Time{})
+	summary := buf.String()
+	if summary == w.reported && now.Sub(w.reportedAt) < watchRefreshInterval {
+		return nil
+	}
+	w.reported = summary
+	w.reportedAt = now
+
+	fmt.Fprintln(out)
+	formatDestructionProgress(out, progress, now)
+	return nil
+}
+
+// formatDestructionProgress writes a table of the entities the model's
+// destruction is waiting on. Ages relative to now are included unless
+// now is zero.
+func formatDestructionProgress(writer io.Writer, progress params.ModelDestructionProgress, now time.Time) {
+	age := func(since *time.Time) string {
+		if since == nil || now.IsZero() {
+			return ""
+		}
+		return common.UserFriendlyDuration(*since, now)
+	}
+
+	heading := "Waiting for model to be removed"
+	if progress.DyingSince != nil && !now.IsZero() {
+		heading += fmt.Sprintf(" (destroyed %s)", age(progress.DyingSince))
+	}
+	if len(progress.Blockers) == 0 {
+		fmt.Fprintln(writer, heading)
+		return
+	}
+	fmt.Fprintln(writer, heading+":")
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Kind", "Id", "Life", "Status", "Since", "Message")
+	for _, b := range progress.Blockers {
+		w.Println(b.Kind, b.Id, b.Life, b.Status, age(b.Since), b.Message)
+	}
+	tw.Flush()
+}
+
 type modelResourceErrorStatus struct {
 	ID, Message string
 }
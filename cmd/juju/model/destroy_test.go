@@ -24,6 +24,7 @@ import (
 	"github.com/juju/juju/cmd/juju/model"
 	rcmd "github.com/juju/juju/cmd/juju/romulus"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/life"
 	coremodel "github.com/juju/juju/core/model"
 	"github.com/juju/juju/jujuclient"
 	_ "github.com/juju/juju/provider/dummy"
@@ -35,6 +36,7 @@ type DestroySuite struct {
 	api             *fakeAPI
 	configAPI       *fakeConfigAPI
 	storageAPI      *mockStorageAPI
+	progressAPI     *fakeProgressAPI
 	stub            *jutesting.Stub
 	budgetAPIClient *mockBudgetAPIClient
 	store           *jujuclient.MemStore
@@ -111,6 +113,7 @@ func (s *DestroySuite) SetUpTest(c *gc.C) {
 	}
 	s.configAPI = &fakeConfigAPI{}
 	s.storageAPI = &mockStorageAPI{Stub: s.stub}
+	s.progressAPI = &fakeProgressAPI{}
 	s.clock = testclock.NewClock(time.Now())
 
 	s.store = jujuclient.NewMemStore()
@@ -134,12 +137,12 @@ func (s *DestroySuite) SetUpTest(c *gc.C) {
 }
 
 func (s *DestroySuite) runDestroyCommand(c *gc.C, args ...string) (*cmd.Context, error) {
-	command := model.NewDestroyCommandForTest(s.api, s.configAPI, s.storageAPI, s.clock, noOpRefresh, s.store)
+	command := model.NewDestroyCommandForTest(s.api, s.configAPI, s.storageAPI, s.progressAPI, s.clock, noOpRefresh, s.store)
 	return cmdtesting.RunCommand(c, command, args...)
 }
 
 func (s *DestroySuite) NewDestroyCommand() cmd.Command {
-	return model.NewDestroyCommandForTest(s.api, s.configAPI, s.storageAPI, s.clock, noOpRefresh, s.store)
+	return model.NewDestroyCommandForTest(s.api, s.configAPI, s.storageAPI, s.progressAPI, s.clock, noOpRefresh, s.store)
 }
 
 func checkModelExistsInStore(c *gc.C, name string, store jujuclient.ClientStore) {
@@ -181,7 +184,7 @@ func (s *DestroySuite) TestDestroyUnknownModelCallsRefresh(c *gc.C) {
 		return nil
 	}
 
-	command := model.NewDestroyCommandForTest(s.api, s.configAPI, s.storageAPI, s.clock, refresh, s.store)
+	command := model.NewDestroyCommandForTest(s.api, s.configAPI, s.storageAPI, s.progressAPI, s.clock, refresh, s.store)
 	_, err := cmdtesting.RunCommand(c, command, "foo")
 	c.Check(called, jc.IsTrue)
 	c.Check(err, gc.ErrorMatches, `model test1:admin/foo not found`)
@@ -519,12 +522,120 @@ Waiting for model to be removed....`[1:])
 	}
 }
 
+func (s *DestroySuite) TestDestroyCommandWatch(c *gc.C) {
+	checkModelExistsInStore(c, "test1:admin/test2", s.store)
+
+	s.api.modelInfoErr = []*params.Error{nil, nil}
+	s.api.modelStatusPayload = []base.ModelStatus{{
+		HostedMachineCount: 1,
+		Volumes:            []base.Volume{{Id: "1"}},
+		Filesystems:        []base.Filesystem{},
+	}}
+	fiveMinutesAgo := s.clock.Now().Add(-5 * time.Minute)
+	twoMinutesAgo := s.clock.Now().Add(-2 * time.Minute)
+	s.progressAPI.progress = params.ModelDestructionProgress{
+		Life:       life.Dying,
+		DyingSince: &fiveMinutesAgo,
+		Blockers: []params.ModelDestructionBlocker{{
+			Kind:    "machine",
+			Id:      "0",
+			Life:    life.Dying,
+			Status:  "provisioning error",
+			Message: "instance not terminated",
+			Since:   &fiveMinutesAgo,
+		}, {
+			Kind:    "volume",
+			Id:      "1",
+			Life:    life.Dying,
+			Status:  "error",
+			Message: "failed to destroy volume",
+			Since:   &twoMinutesAgo,
+		}},
+	}
+
+	done := make(chan struct{}, 1)
+	outErr := make(chan error, 1)
+	outStdErr := make(chan string, 1)
+
+	go func() {
+		// run destroy model cmd, and timeout in 3s.
+		ctx, err := s.runDestroyCommand(c, "test2", "-y", "-t", "3s", "--watch")
+		outStdErr <- cmdtesting.Stderr(ctx)
+		outErr <- err
+		done <- struct{}{}
+	}()
+
+	c.Assert(s.clock.WaitAdvance(5*time.Second, testing.LongWait, 2), jc.ErrorIsNil)
+
+	select {
+	case <-done:
+		// Unchanged progress is not shown again within a minute.
+		c.Assert(<-outStdErr, gc.Equals, `
+Destroying model
+Waiting for model to be removed (destroyed 5 minutes ago):
+Kind     Id  Life   Status              Since          Message
+machine  0   dying  provisioning error  5 minutes ago  instance not terminated
+volume   1   dying  error               2 minutes ago  failed to destroy volume
+`[1:])
+		c.Assert(<-outErr, jc.Satisfies, errors.IsTimeout)
+		checkModelExistsInStore(c, "test1:admin/test2", s.store)
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for destroy cmd.")
+	}
+}
+
+func (s *DestroySuite) TestDestroyCommandWatchProgressError(c *gc.C) {
+	s.api.modelInfoErr = []*params.Error{nil, nil}
+	s.api.modelStatusPayload = []base.ModelStatus{{
+		HostedMachineCount: 1,
+		Volumes:            []base.Volume{},
+		Filesystems:        []base.Filesystem{},
+	}}
+	s.progressAPI.err = errors.New("boom")
+
+	done := make(chan struct{}, 1)
+	outErr := make(chan error, 1)
+	outStdErr := make(chan string, 1)
+
+	go func() {
+		ctx, err := s.runDestroyCommand(c, "test2", "-y", "-t", "3s", "--watch")
+		outStdErr <- cmdtesting.Stderr(ctx)
+		outErr <- err
+		done <- struct{}{}
+	}()
+
+	c.Assert(s.clock.WaitAdvance(5*time.Second, testing.LongWait, 2), jc.ErrorIsNil)
+
+	select {
+	case <-done:
+		// The summary is shown instead.
+		c.Assert(<-outStdErr, gc.Equals, `
+Destroying model
+Waiting for model to be removed, 1 machine(s)....`[1:])
+		c.Assert(<-outErr, jc.Satisfies, errors.IsTimeout)
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for destroy cmd.")
+	}
+}
+
 func (s *DestroySuite) TestBlockedDestroy(c *gc.C) {
 	s.stub.SetErrors(apiservererrors.OperationBlockedError("TestBlockedDestroy"))
 	_, err := s.runDestroyCommand(c, "test2", "-y")
 	testing.AssertOperationWasBlocked(c, err, ".*TestBlockedDestroy.*")
 }
 
+// fakeProgressAPI mocks out the DestructionProgressAPI.
+type fakeProgressAPI struct {
+	progress params.ModelDestructionProgress
+	err      error
+}
+
+func (f *fakeProgressAPI) Close() error { return nil }
+
+func (f *fakeProgressAPI) Progress() (params.ModelDestructionProgress, error) {
+	return f.progress, f.err
+}
+
 // mockBudgetAPIClient implements the budgetAPIClient interface.
 type mockBudgetAPIClient struct {
 	*jutesting.Stub
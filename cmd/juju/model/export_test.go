@@ -61,6 +61,14 @@ func NewDumpCommandForTest(api DumpModelAPI, store jujuclient.ClientStore) cmd.C
 	return modelcmd.Wrap(cmd)
 }
 
+// NewTransferOwnershipCommandForTest returns a transferOwnershipCommand
+// with the api provided as specified.
+func NewTransferOwnershipCommandForTest(api TransferOwnershipAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &transferOwnershipCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 // NewDumpDBCommandForTest returns a DumpDBCommand with the api provided as specified.
 func NewDumpDBCommandForTest(api DumpDBAPI, store jujuclient.ClientStore) cmd.Command {
 	cmd := &dumpDBCommand{api: api}
@@ -82,14 +90,16 @@ func NewDestroyCommandForTest(
 	api DestroyModelAPI,
 	configAPI ModelConfigAPI,
 	storageAPI StorageAPI,
+	progressAPI DestructionProgressAPI,
 	clk jujuclock.Clock,
 	refreshFunc func(jujuclient.ClientStore, string) error, store jujuclient.ClientStore,
 ) cmd.Command {
 	cmd := &destroyCommand{
-		api:        api,
-		clock:      clk,
-		configAPI:  configAPI,
-		storageAPI: storageAPI,
+		api:         api,
+		clock:       clk,
+		configAPI:   configAPI,
+		storageAPI:  storageAPI,
+		progressAPI: progressAPI,
 	}
 	cmd.SetClientStore(store)
 	cmd.SetModelRefresh(refreshFunc)
@@ -246,3 +256,35 @@ func NewShowCommitCommandForTest(api ShowCommitCommandAPI, store jujuclient.Clie
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
 }
+
+// NewCloneCommandForTest returns a cloneCommand with the api and
+// deploy command provided as specified.
+func NewCloneCommandForTest(api CloneAPI, newDeployCommand func() modelcmd.ModelCommand, store jujuclient.ClientStore) cmd.Command {
+	cmd := &cloneCommand{api: api, newDeployCommand: newDeployCommand}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewApplyCommandForTest returns an applyCommand with the api provided
+// as specified.
+func NewApplyCommandForTest(api ApplyAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &applyCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewShowModelSpecCommandForTest returns a showModelSpecCommand with the
+// api provided as specified.
+func NewShowModelSpecCommandForTest(api ShowModelSpecAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &showModelSpecCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewRemoveModelSpecCommandForTest returns a removeModelSpecCommand with
+// the api provided as specified.
+func NewRemoveModelSpecCommandForTest(api RemoveModelSpecAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &removeModelSpecCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
@@ -44,6 +44,7 @@ type fakeEnvAPI struct {
 	err           error
 	keys          []string
 	resetKeys     []string
+	trace         config.ConfigValueTrace
 }
 
 func (f *fakeEnvAPI) Close() error {
@@ -72,6 +73,11 @@ func (f *fakeEnvAPI) ModelUnset(keys ...string) error {
 	return f.err
 }
 
+func (f *fakeEnvAPI) ModelConfigTrace(key string) (config.ConfigValueTrace, error) {
+	f.keys = []string{key}
+	return f.trace, f.err
+}
+
 // ModelDefaults related fake environment for testing.
 
 type fakeModelDefaultEnvSuite struct {
@@ -0,0 +1,88 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/modelspec"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const removeModelSpecHelpDoc = `
+Removes the declarative spec of a model, as set with the apply command.
+The model is left as it is, and is no longer reconciled.
+
+This command is experimental, and is only available with the
+"model-spec" feature flag enabled.
+
+Examples:
+
+    juju remove-model-spec
+
+See also:
+    apply
+    show-model-spec
+`
+
+// NewRemoveModelSpecCommand returns a command to remove the declarative
+// spec of a model.
+func NewRemoveModelSpecCommand() cmd.Command {
+	return modelcmd.Wrap(&removeModelSpecCommand{})
+}
+
+type removeModelSpecCommand struct {
+	modelcmd.ModelCommandBase
+	api RemoveModelSpecAPI
+}
+
+// RemoveModelSpecAPI defines the API methods used by the
+// remove-model-spec command.
+type RemoveModelSpecAPI interface {
+	Close() error
+	RemoveModelSpec() error
+}
+
+// Info implements Command.
+func (c *removeModelSpecCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "remove-model-spec",
+		Purpose: "Removes the declarative spec of a model.",
+		Doc:     removeModelSpecHelpDoc,
+	})
+}
+
+// Init implements Command.
+func (c *removeModelSpecCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *removeModelSpecCommand) getAPI() (RemoveModelSpecAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelspec.NewClient(root), nil
+}
+
+// Run implements Command.
+func (c *removeModelSpecCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.RemoveModelSpec(); err != nil {
+		return block.ProcessBlockedError(errors.Annotate(err, "cannot remove model spec"), block.BlockChange)
+	}
+	ctx.Infof("Removed model spec; the model is no longer reconciled.")
+	return nil
+}
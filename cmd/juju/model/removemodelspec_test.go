@@ -0,0 +1,74 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type RemoveModelSpecSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  fakeRemoveModelSpecClient
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&RemoveModelSpecSuite{})
+
+type fakeRemoveModelSpecClient struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeRemoveModelSpecClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeRemoveModelSpecClient) RemoveModelSpec() error {
+	f.MethodCall(f, "RemoveModelSpec")
+	return f.NextErr()
+}
+
+func (s *RemoveModelSpecSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake.ResetCalls()
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		ModelUUID: testing.ModelTag.Id(),
+		ModelType: coremodel.IAAS,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *RemoveModelSpecSuite) TestRemove(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, model.NewRemoveModelSpecCommandForTest(&s.fake, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Removed model spec; the model is no longer reconciled.\n")
+	s.fake.CheckCallNames(c, "RemoveModelSpec", "Close")
+}
+
+func (s *RemoveModelSpecSuite) TestRemoveError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	_, err := cmdtesting.RunCommand(c, model.NewRemoveModelSpecCommandForTest(&s.fake, s.store))
+	c.Assert(err, gc.ErrorMatches, "cannot remove model spec: boom")
+}
+
+func (s *RemoveModelSpecSuite) TestRemoveExtraArgs(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, model.NewRemoveModelSpecCommandForTest(&s.fake, s.store), "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
@@ -0,0 +1,127 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/modelspec"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const showModelSpecHelpDoc = `
+Shows the declarative spec of a model, as set with the apply command,
+along with the status of reconciling the model towards it. The status
+lists the differences between the model and the spec which could not
+be reconciled, such as applications which are not deployed.
+
+This command is experimental, and is only available with the
+"model-spec" feature flag enabled.
+
+Examples:
+
+    juju show-model-spec
+    juju show-model-spec --format json
+
+See also:
+    apply
+    remove-model-spec
+`
+
+// NewShowModelSpecCommand returns a command to show the declarative
+// spec of a model.
+func NewShowModelSpecCommand() cmd.Command {
+	return modelcmd.Wrap(&showModelSpecCommand{})
+}
+
+type showModelSpecCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	api ShowModelSpecAPI
+}
+
+// ShowModelSpecAPI defines the API methods used by the show-model-spec
+// command.
+type ShowModelSpecAPI interface {
+	Close() error
+	ModelSpec() (params.ModelSpecResult, error)
+}
+
+// Info implements Command.
+func (c *showModelSpecCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show-model-spec",
+		Purpose: "Shows the declarative spec of a model and its reconciliation status.",
+		Doc:     showModelSpecHelpDoc,
+	})
+}
+
+// SetFlags implements Command.
+func (c *showModelSpecCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+// Init implements Command.
+func (c *showModelSpecCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *showModelSpecCommand) getAPI() (ShowModelSpecAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelspec.NewClient(root), nil
+}
+
+type modelSpecOutput struct {
+	Revision int                    `yaml:"revision" json:"revision"`
+	Spec     string                 `yaml:"spec" json:"spec"`
+	Status   *modelSpecStatusOutput `yaml:"status,omitempty" json:"status,omitempty"`
+}
+
+type modelSpecStatusOutput struct {
+	Revision   int       `yaml:"revision" json:"revision"`
+	Reconciled time.Time `yaml:"reconciled" json:"reconciled"`
+	Drift      []string  `yaml:"drift,omitempty" json:"drift,omitempty"`
+}
+
+// Run implements Command.
+func (c *showModelSpecCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	result, err := client.ModelSpec()
+	if params.IsCodeNotFound(err) {
+		return errors.New("model has no spec; set one with juju apply")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	out := modelSpecOutput{
+		Revision: result.Revision,
+		Spec:     result.Spec,
+	}
+	if result.Status != nil {
+		out.Status = &modelSpecStatusOutput{
+			Revision:   result.Status.Revision,
+			Reconciled: result.Status.Reconciled,
+			Drift:      result.Status.Drift,
+		}
+	}
+	return c.out.Write(ctx, out)
+}
@@ -0,0 +1,101 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type ShowModelSpecSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  fakeShowModelSpecClient
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&ShowModelSpecSuite{})
+
+type fakeShowModelSpecClient struct {
+	gitjujutesting.Stub
+	result params.ModelSpecResult
+}
+
+func (f *fakeShowModelSpecClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeShowModelSpecClient) ModelSpec() (params.ModelSpecResult, error) {
+	f.MethodCall(f, "ModelSpec")
+	return f.result, f.NextErr()
+}
+
+func (s *ShowModelSpecSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = fakeShowModelSpecClient{
+		result: params.ModelSpecResult{
+			Spec:     "applications:\n  mysql:\n    charm: cs:mysql\n",
+			Revision: 2,
+			Status: &params.ModelSpecStatus{
+				Revision:   2,
+				Reconciled: time.Date(2021, 5, 4, 3, 2, 1, 0, time.UTC),
+				Drift:      []string{`application "mysql" is not deployed`},
+			},
+		},
+	}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		ModelUUID: testing.ModelTag.Id(),
+		ModelType: coremodel.IAAS,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *ShowModelSpecSuite) TestShowYAML(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, model.NewShowModelSpecCommandForTest(&s.fake, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+revision: 2
+spec: |
+  applications:
+    mysql:
+      charm: cs:mysql
+status:
+  revision: 2
+  reconciled: 2021-05-04T03:02:01Z
+  drift:
+  - application "mysql" is not deployed
+`[1:])
+	s.fake.CheckCallNames(c, "ModelSpec", "Close")
+}
+
+func (s *ShowModelSpecSuite) TestShowJSON(c *gc.C) {
+	s.fake.result.Status = nil
+	ctx, err := cmdtesting.RunCommand(c, model.NewShowModelSpecCommandForTest(&s.fake, s.store), "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals,
+		`{"revision":2,"spec":"applications:\n  mysql:\n    charm: cs:mysql\n"}`+"\n")
+}
+
+func (s *ShowModelSpecSuite) TestShowNoSpec(c *gc.C) {
+	s.fake.SetErrors(&params.Error{Code: params.CodeNotFound, Message: "model spec not found"})
+	_, err := cmdtesting.RunCommand(c, model.NewShowModelSpecCommandForTest(&s.fake, s.store))
+	c.Assert(err, gc.ErrorMatches, "model has no spec; set one with juju apply")
+}
@@ -0,0 +1,146 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+// NewTransferOwnershipCommand returns a fully constructed
+// transfer-model-ownership command.
+func NewTransferOwnershipCommand() cmd.Command {
+	return modelcmd.Wrap(&transferOwnershipCommand{})
+}
+
+type transferOwnershipCommand struct {
+	modelcmd.ModelCommandBase
+	api TransferOwnershipAPI
+
+	owner names.UserTag
+}
+
+const transferOwnershipHelpDoc = `
+Makes another user the owner of a model. The new owner is given admin
+access to the model and to all of the application offers it hosts.
+
+Access held by the previous owner is not changed; use the revoke command
+to remove it once the transfer is complete. The model is afterwards
+known by the new owner's name, e.g. "bob/mymodel".
+
+Only controller superusers may transfer model ownership.
+
+Examples:
+
+    juju transfer-model-ownership bob
+    juju transfer-model-ownership -m alice/mymodel bob
+
+See also:
+    grant
+    revoke
+    models
+`
+
+// Info implements Command.
+func (c *transferOwnershipCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "transfer-model-ownership",
+		Args:    "<user>",
+		Purpose: "Transfers ownership of a model to another user.",
+		Doc:     transferOwnershipHelpDoc,
+	})
+}
+
+// Init implements Command.
+func (c *transferOwnershipCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no user specified")
+	}
+	user, args := args[0], args[1:]
+	if !names.IsValidUser(user) {
+		return errors.NotValidf("user name %q", user)
+	}
+	c.owner = names.NewUserTag(user)
+	return cmd.CheckEmpty(args)
+}
+
+// TransferOwnershipAPI specifies the used function calls of the
+// ModelManager.
+type TransferOwnershipAPI interface {
+	Close() error
+	TransferModelOwnership(model names.ModelTag, owner names.UserTag) error
+}
+
+func (c *transferOwnershipCommand) getAPI() (TransferOwnershipAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.ModelCommandBase.NewModelManagerAPIClient()
+}
+
+// Run implements Command.
+func (c *transferOwnershipCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	modelName, modelDetails, err := c.ModelCommandBase.ModelDetails()
+	if err != nil {
+		return errors.Annotate(err, "getting model details")
+	}
+
+	modelTag := names.NewModelTag(modelDetails.ModelUUID)
+	if err := client.TransferModelOwnership(modelTag, c.owner); err != nil {
+		return block.ProcessBlockedError(errors.Annotate(err, "could not transfer model ownership"), block.BlockChange)
+	}
+	ctx.Infof("Transferred ownership of model %q to %q.", modelName, c.owner.Id())
+
+	if err := c.renameLocalModel(modelName, *modelDetails); err != nil {
+		ctx.Warningf("cannot update local model details: %v", err)
+	}
+	return nil
+}
+
+// renameLocalModel records the model in the client store under the
+// new owner's qualified name, keeping it current if it was before.
+func (c *transferOwnershipCommand) renameLocalModel(modelName string, details jujuclient.ModelDetails) error {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	store := modelcmd.QualifyingClientStore{ClientStore: c.ClientStore()}
+	oldModelName, err := store.QualifiedModelName(controllerName, modelName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	name, _, err := jujuclient.SplitModelName(oldModelName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newModelName := jujuclient.JoinOwnerModelName(c.owner, name)
+	if newModelName == oldModelName {
+		return nil
+	}
+	currentModel, err := store.CurrentModel(controllerName)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if err := store.UpdateModel(controllerName, newModelName, details); err != nil {
+		return errors.Trace(err)
+	}
+	if currentModel == oldModelName {
+		if err := store.SetCurrentModel(controllerName, newModelName); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(store.RemoveModel(controllerName, oldModelName))
+}
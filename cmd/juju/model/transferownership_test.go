@@ -0,0 +1,103 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for info.
+
+package model_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type TransferOwnershipCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  fakeTransferOwnershipClient
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&TransferOwnershipCommandSuite{})
+
+type fakeTransferOwnershipClient struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeTransferOwnershipClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeTransferOwnershipClient) TransferModelOwnership(model names.ModelTag, owner names.UserTag) error {
+	f.MethodCall(f, "TransferModelOwnership", model, owner)
+	return f.NextErr()
+}
+
+func (s *TransferOwnershipCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake.ResetCalls()
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		ModelUUID: testing.ModelTag.Id(),
+		ModelType: coremodel.IAAS,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *TransferOwnershipCommandSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no user specified",
+	}, {
+		args: []string{"not/a/user"},
+		err:  `user name "not/a/user" not valid`,
+	}, {
+		args: []string{"bob", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := cmdtesting.RunCommand(c, model.NewTransferOwnershipCommandForTest(&s.fake, s.store), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *TransferOwnershipCommandSuite) TestTransfer(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, model.NewTransferOwnershipCommandForTest(&s.fake, s.store), "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"TransferModelOwnership", []interface{}{testing.ModelTag, names.NewUserTag("bob")}},
+		{"Close", nil},
+	})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Transferred ownership of model \"admin/mymodel\" to \"bob\".\n")
+
+	// The model is now known locally by the new owner's name.
+	c.Assert(s.store.Models["testing"].Models, jc.DeepEquals, map[string]jujuclient.ModelDetails{
+		"bob/mymodel": {
+			ModelUUID: testing.ModelTag.Id(),
+			ModelType: coremodel.IAAS,
+		},
+	})
+	c.Assert(s.store.Models["testing"].CurrentModel, gc.Equals, "bob/mymodel")
+}
+
+func (s *TransferOwnershipCommandSuite) TestTransferFailed(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	_, err := cmdtesting.RunCommand(c, model.NewTransferOwnershipCommandForTest(&s.fake, s.store), "bob")
+	c.Assert(err, gc.ErrorMatches, "could not transfer model ownership: boom")
+	c.Assert(s.store.Models["testing"].CurrentModel, gc.Equals, "admin/mymodel")
+}
@@ -0,0 +1,123 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package space
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewCheckCommand returns a command used to check the model's spaces.
+func NewCheckCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&CheckCommand{})
+}
+
+// CheckCommand calls the API to report problems with the spaces used by
+// the model.
+type CheckCommand struct {
+	SpaceCommandBase
+
+	out cmd.Output
+}
+
+const checkCommandDoc = `
+Checks the spaces referred to by the model's default-space, the endpoint
+bindings of its applications and the space constraints of the model and
+its applications. Spaces that do not exist, or have no subnets to deploy
+units to, are reported along with suggestions for fixing them. Spaces
+without subnets which nothing refers to yet are reported as warnings.
+
+Examples:
+
+	juju check-spaces
+	juju check-spaces --format yaml
+
+See also:
+	move-to-space
+	reload-spaces
+	remove-space
+	show-space
+	spaces
+`
+
+// Info is defined on the cmd.Command interface.
+func (c *CheckCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "check-spaces",
+		Purpose: "Check the model's spaces for problems.",
+		Doc:     strings.TrimSpace(checkCommandDoc),
+	})
+}
+
+// SetFlags is defined on the cmd.Command interface.
+func (c *CheckCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.SpaceCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": printIssuesTabular,
+	})
+}
+
+// Init is defined on the cmd.Command interface.
+func (c *CheckCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *CheckCommand) Run(ctx *cmd.Context) error {
+	return c.RunWithSpaceAPI(ctx, func(api SpaceAPI, ctx *cmd.Context) error {
+		issues, err := api.CheckSpaces()
+		if err != nil {
+			if params.IsCodeUnauthorized(err) {
+				common.PermissionsMessage(ctx.Stderr, "checking spaces")
+			}
+			return errors.Annotate(err, "cannot check spaces")
+		}
+		if len(issues) == 0 && c.out.Name() == "tabular" {
+			ctx.Infof("No problems found with spaces.")
+			return nil
+		}
+
+		formatted := make([]SpaceIssue, len(issues))
+		for i, issue := range issues {
+			formatted[i] = SpaceIssue(issue)
+		}
+		return errors.Trace(c.out.Write(ctx, formatted))
+	})
+}
+
+// printIssuesTabular prints each issue with its suggestion indented
+// below it, as suggestions are too long to fit in a table column.
+func printIssuesTabular(writer io.Writer, value interface{}) error {
+	issues, ok := value.([]SpaceIssue)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", issues, value)
+	}
+	for _, issue := range issues {
+		_, _ = fmt.Fprintf(writer, "%s: %s: %s\n", issue.Severity, issue.Entity, issue.Message)
+		if issue.Suggestion != "" {
+			_, _ = fmt.Fprintf(writer, "  suggestion: %s\n", issue.Suggestion)
+		}
+	}
+	return nil
+}
+
+// SpaceIssue represents a problem with a space output by the CLI client.
+type SpaceIssue struct {
+	Severity   string `json:"severity" yaml:"severity"`
+	Entity     string `json:"entity" yaml:"entity"`
+	Message    string `json:"message" yaml:"message"`
+	Suggestion string `json:"suggestion,omitempty" yaml:"suggestion,omitempty"`
+}
@@ -0,0 +1,71 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package space_test
+
+import (
+	"github.com/juju/errors"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/space"
+)
+
+type CheckSuite struct {
+	BaseSpaceSuite
+}
+
+var _ = gc.Suite(&CheckSuite{})
+
+func (s *CheckSuite) SetUpTest(c *gc.C) {
+	s.BaseSpaceSuite.SetUpTest(c)
+	s.newCommand = space.NewCheckCommand
+	s.api.CheckSpacesResp = []params.SpaceIssue{{
+		Severity:   "error",
+		Entity:     "application-mysql",
+		Message:    `endpoint "db" is bound to space "empty", which has no subnets`,
+		Suggestion: `use another space with "juju bind mysql db=<space>"`,
+	}, {
+		Severity: "warning",
+		Entity:   "space-unused",
+		Message:  `space "unused" has no subnets`,
+	}}
+}
+
+func (s *CheckSuite) TestInit(c *gc.C) {
+	_, err := s.InitCommand(c, "foo")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+	s.api.CheckCallNames(c)
+}
+
+func (s *CheckSuite) TestRunTabular(c *gc.C) {
+	s.AssertRunSucceeds(c, "", ""+
+		`error: application-mysql: endpoint "db" is bound to space "empty", which has no subnets`+"\n"+
+		`  suggestion: use another space with "juju bind mysql db=<space>"`+"\n"+
+		`warning: space-unused: space "unused" has no subnets`+"\n",
+	)
+	s.api.CheckCallNames(c, "CheckSpaces", "Close")
+}
+
+func (s *CheckSuite) TestRunYAML(c *gc.C) {
+	s.AssertRunSucceeds(c, "", `
+- severity: error
+  entity: application-mysql
+  message: endpoint "db" is bound to space "empty", which has no subnets
+  suggestion: use another space with "juju bind mysql db=<space>"
+- severity: warning
+  entity: space-unused
+  message: space "unused" has no subnets
+`[1:], "--format", "yaml")
+}
+
+func (s *CheckSuite) TestRunNoIssues(c *gc.C) {
+	s.api.CheckSpacesResp = nil
+	s.AssertRunSucceeds(c, "No problems found with spaces.\n", "")
+}
+
+func (s *CheckSuite) TestRunWhenSpacesAPIFails(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_ = s.AssertRunFails(c, "cannot check spaces: boom")
+	s.api.CheckCallNames(c, "CheckSpaces", "Close")
+}
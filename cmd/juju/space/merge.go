@@ -0,0 +1,123 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package space
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewMergeCommand returns a command used to merge spaces into another.
+func NewMergeCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&MergeCommand{})
+}
+
+// MergeCommand calls the API to merge one or more network spaces into
+// another existing space.
+type MergeCommand struct {
+	SpaceCommandBase
+
+	Name   string
+	Spaces []string
+
+	Force bool
+}
+
+const mergeCommandDoc = `
+Merges one or more spaces into an existing space, then removes them. The
+subnets of the merged spaces move to the target space, and any endpoint
+bindings, constraints and controller settings (juju-mgmt-space and
+juju-ha-space) that refer to a merged space are updated to refer to the
+target space instead. Each space is merged in a single transaction.
+
+Merging is refused when an application or machine has space constraints
+that would contradict each other afterwards, such as requiring one of the
+spaces while excluding the other. Use --force to merge regardless.
+
+Examples:
+
+Merge the db-a and db-b spaces into the db space:
+	juju merge-spaces db db-a db-b
+
+See also:
+	add-space
+	list-spaces
+	move-to-space
+	remove-space
+	rename-space
+	show-space
+`
+
+// Info is defined on the cmd.Command interface.
+func (c *MergeCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "merge-spaces",
+		Args:    "<name> <space1> [ <space2> ...]",
+		Purpose: "Merge network spaces into another space.",
+		Doc:     strings.TrimSpace(mergeCommandDoc),
+	})
+}
+
+// SetFlags defines the merge command flags it wants to offer.
+func (c *MergeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.SpaceCommandBase.SetFlags(f)
+	f.BoolVar(&c.Force, "force", false, "Merge the spaces even if space constraints would contradict each other")
+}
+
+// Init is defined on the cmd.Command interface. It checks the
+// arguments for sanity and sets up the command to run.
+func (c *MergeCommand) Init(args []string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "invalid arguments specified")
+
+	switch len(args) {
+	case 0:
+		return errors.New("space name is required")
+	case 1:
+		return errors.New("at least one space to merge is required")
+	}
+	if c.Name, err = CheckName(args[0]); err != nil {
+		return errors.Trace(err)
+	}
+
+	seen := set.NewStrings()
+	for _, arg := range args[1:] {
+		name, err := CheckName(arg)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if name == c.Name {
+			return errors.Errorf("cannot merge space %q into itself", name)
+		}
+		if seen.Contains(name) {
+			return errors.Errorf("duplicate space %q specified", name)
+		}
+		seen.Add(name)
+		c.Spaces = append(c.Spaces, name)
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *MergeCommand) Run(ctx *cmd.Context) error {
+	return c.RunWithSpaceAPI(ctx, func(api SpaceAPI, ctx *cmd.Context) error {
+		if err := api.MergeSpaces(c.Name, c.Spaces, c.Force); err != nil {
+			return errors.Annotatef(err, "cannot merge spaces into %q", c.Name)
+		}
+
+		quoted := make([]string, len(c.Spaces))
+		for i, name := range c.Spaces {
+			quoted[i] = fmt.Sprintf("%q", name)
+		}
+		ctx.Infof("merged %s into space %q", strings.Join(quoted, ", "), c.Name)
+		return nil
+	})
+}
@@ -0,0 +1,97 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package space_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/space"
+)
+
+type MergeSuite struct {
+	BaseSpaceSuite
+}
+
+var _ = gc.Suite(&MergeSuite{})
+
+func (s *MergeSuite) SetUpTest(c *gc.C) {
+	s.BaseSpaceSuite.SetUpTest(c)
+	s.newCommand = space.NewMergeCommand
+}
+
+func (s *MergeSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		about        string
+		args         []string
+		expectName   string
+		expectSpaces []string
+		expectErr    string
+	}{{
+		about:     "no arguments",
+		expectErr: "space name is required",
+	}, {
+		about:     "no spaces to merge",
+		args:      s.Strings("db"),
+		expectErr: "at least one space to merge is required",
+	}, {
+		about:     "invalid target space name",
+		args:      s.Strings("%inv$alid", "db-a"),
+		expectErr: `"%inv\$alid" is not a valid space name`,
+	}, {
+		about:     "invalid space name to merge",
+		args:      s.Strings("db", "db_a"),
+		expectErr: `"db_a" is not a valid space name`,
+	}, {
+		about:     "merging a space into itself",
+		args:      s.Strings("db", "db-a", "db"),
+		expectErr: `cannot merge space "db" into itself`,
+	}, {
+		about:     "duplicate space to merge",
+		args:      s.Strings("db", "db-a", "db-a"),
+		expectErr: `duplicate space "db-a" specified`,
+	}, {
+		about:        "all ok",
+		args:         s.Strings("db", "db-a", "db-b"),
+		expectName:   "db",
+		expectSpaces: s.Strings("db-a", "db-b"),
+	}} {
+		c.Logf("test #%d: %s", i, test.about)
+		command, err := s.InitCommand(c, test.args...)
+		if test.expectErr != "" {
+			c.Check(err, gc.ErrorMatches, "invalid arguments specified: "+test.expectErr)
+		} else {
+			c.Check(err, jc.ErrorIsNil)
+			command := command.(*space.MergeCommand)
+			c.Check(command.Name, gc.Equals, test.expectName)
+			c.Check(command.Spaces, jc.DeepEquals, test.expectSpaces)
+		}
+		// No API calls should be recorded at this stage.
+		s.api.CheckCallNames(c)
+	}
+}
+
+func (s *MergeSuite) TestRunSucceeds(c *gc.C) {
+	s.AssertRunSucceeds(c,
+		`merged "db-a", "db-b" into space "db"\n`,
+		"", // no stdout, just stderr
+		"db", "db-a", "db-b", "--force",
+	)
+
+	s.api.CheckCallNames(c, "MergeSpaces", "Close")
+	s.api.CheckCall(c, 0, "MergeSpaces", "db", []string{"db-a", "db-b"}, true)
+}
+
+func (s *MergeSuite) TestRunWhenSpacesAPIFails(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+
+	_ = s.AssertRunFails(c,
+		`cannot merge spaces into "db": boom`,
+		"db", "db-a",
+	)
+
+	s.api.CheckCallNames(c, "MergeSpaces", "Close")
+	s.api.CheckCall(c, 0, "MergeSpaces", "db", []string{"db-a"}, false)
+}
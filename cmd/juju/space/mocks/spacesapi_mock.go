@@ -49,6 +49,21 @@ func (mr *MockSpaceAPIMockRecorder) AddSpace(arg0, arg1, arg2 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSpace", reflect.TypeOf((*MockSpaceAPI)(nil).AddSpace), arg0, arg1, arg2)
 }
 
+// CheckSpaces mocks base method
+func (m *MockSpaceAPI) CheckSpaces() ([]params.SpaceIssue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSpaces")
+	ret0, _ := ret[0].([]params.SpaceIssue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckSpaces indicates an expected call of CheckSpaces
+func (mr *MockSpaceAPIMockRecorder) CheckSpaces() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSpaces", reflect.TypeOf((*MockSpaceAPI)(nil).CheckSpaces))
+}
+
 // ListSpaces mocks base method
 func (m *MockSpaceAPI) ListSpaces() ([]params.Space, error) {
 	m.ctrl.T.Helper()
@@ -64,6 +79,20 @@ func (mr *MockSpaceAPIMockRecorder) ListSpaces() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSpaces", reflect.TypeOf((*MockSpaceAPI)(nil).ListSpaces))
 }
 
+// MergeSpaces mocks base method
+func (m *MockSpaceAPI) MergeSpaces(arg0 string, arg1 []string, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeSpaces", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeSpaces indicates an expected call of MergeSpaces
+func (mr *MockSpaceAPIMockRecorder) MergeSpaces(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeSpaces", reflect.TypeOf((*MockSpaceAPI)(nil).MergeSpaces), arg0, arg1, arg2)
+}
+
 // MoveSubnets mocks base method
 func (m *MockSpaceAPI) MoveSubnets(arg0 names_v3.SpaceTag, arg1 []names_v3.SubnetTag, arg2 bool) (params.MoveSubnetsResult, error) {
 	m.ctrl.T.Helper()
@@ -212,6 +241,21 @@ func (mr *MockAPIMockRecorder) AddSpace(arg0, arg1, arg2 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSpace", reflect.TypeOf((*MockAPI)(nil).AddSpace), arg0, arg1, arg2)
 }
 
+// CheckSpaces mocks base method
+func (m *MockAPI) CheckSpaces() ([]params.SpaceIssue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSpaces")
+	ret0, _ := ret[0].([]params.SpaceIssue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckSpaces indicates an expected call of CheckSpaces
+func (mr *MockAPIMockRecorder) CheckSpaces() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSpaces", reflect.TypeOf((*MockAPI)(nil).CheckSpaces))
+}
+
 // Close mocks base method
 func (m *MockAPI) Close() error {
 	m.ctrl.T.Helper()
@@ -241,6 +285,20 @@ func (mr *MockAPIMockRecorder) ListSpaces() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSpaces", reflect.TypeOf((*MockAPI)(nil).ListSpaces))
 }
 
+// MergeSpaces mocks base method
+func (m *MockAPI) MergeSpaces(arg0 string, arg1 []string, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeSpaces", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeSpaces indicates an expected call of MergeSpaces
+func (mr *MockAPIMockRecorder) MergeSpaces(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeSpaces", reflect.TypeOf((*MockAPI)(nil).MergeSpaces), arg0, arg1, arg2)
+}
+
 // MoveSubnets mocks base method
 func (m *MockAPI) MoveSubnets(arg0 names_v3.SpaceTag, arg1 []names_v3.SubnetTag, arg2 bool) (params.MoveSubnetsResult, error) {
 	m.ctrl.T.Helper()
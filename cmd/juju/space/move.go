@@ -49,9 +49,13 @@ Examples:
 Move a list of CIDRs from their space to a new space:
 	juju move-to-space db-space 172.31.1.0/28 172.31.16.0/20
 
+Move a single subnet into the db-space space:
+	juju move-subnet db-space 172.31.1.0/28
+
 See also:
 	add-space
 	list-spaces
+	merge-spaces
 	reload-spaces
 	rename-space
 	show-space
@@ -65,6 +69,7 @@ func (c *MoveCommand) Info() *cmd.Info {
 		Args:    "[--format yaml|json] <name> <CIDR1> [ <CIDR2> ...]",
 		Purpose: "Update a network space's CIDR.",
 		Doc:     strings.TrimSpace(moveCommandDoc),
+		Aliases: []string{"move-subnet"},
 	})
 }
 
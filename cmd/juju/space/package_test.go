@@ -151,6 +151,7 @@ type StubAPI struct {
 	Subnets []params.Subnet
 
 	ShowSpaceResp     params.ShowSpaceResult
+	CheckSpacesResp   []params.SpaceIssue
 	MoveSubnetsResp   params.MoveSubnetsResult
 	SubnetsByCIDRResp []params.SubnetsResult
 }
@@ -267,6 +268,11 @@ func (sa *StubAPI) RenameSpace(name, newName string) error {
 	return sa.NextErr()
 }
 
+func (sa *StubAPI) MergeSpaces(name string, spaceNames []string, force bool) error {
+	sa.MethodCall(sa, "MergeSpaces", name, spaceNames, force)
+	return sa.NextErr()
+}
+
 func (sa *StubAPI) ReloadSpaces() error {
 	sa.MethodCall(sa, "ReloadSpaces")
 	return sa.NextErr()
@@ -280,6 +286,14 @@ func (sa *StubAPI) ShowSpace(name string) (params.ShowSpaceResult, error) {
 	return sa.ShowSpaceResp, nil
 }
 
+func (sa *StubAPI) CheckSpaces() ([]params.SpaceIssue, error) {
+	sa.MethodCall(sa, "CheckSpaces")
+	if err := sa.NextErr(); err != nil {
+		return nil, err
+	}
+	return sa.CheckSpacesResp, nil
+}
+
 func (sa *StubAPI) MoveSubnets(name names.SpaceTag, tags []names.SubnetTag, force bool) (params.MoveSubnetsResult, error) {
 	sa.MethodCall(sa, "MoveSubnets", name, tags, force)
 	return sa.MoveSubnetsResp, sa.NextErr()
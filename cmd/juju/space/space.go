@@ -44,12 +44,19 @@ type SpaceAPI interface {
 	// RenameSpace changes the name of the space.
 	RenameSpace(name, newName string) error
 
+	// MergeSpaces merges the named spaces into the space called name.
+	MergeSpaces(name string, spaceNames []string, force bool) error
+
 	// ReloadSpaces fetches spaces and subnets from substrate
 	ReloadSpaces() error
 
 	// ShowSpace fetches space information.
 	ShowSpace(name string) (params.ShowSpaceResult, error)
 
+	// CheckSpaces reports problems with the spaces referred to by the
+	// model's configuration, endpoint bindings and constraints.
+	CheckSpaces() ([]params.SpaceIssue, error)
+
 	// MoveSubnets ensures that the input subnets are in the input space.
 	MoveSubnets(names.SpaceTag, []names.SubnetTag, bool) (params.MoveSubnetsResult, error)
 }
@@ -178,11 +185,22 @@ func (m *APIShim) RenameSpace(oldName, newName string) error {
 	return m.spaceAPI.RenameSpace(oldName, newName)
 }
 
+// MergeSpaces merges the named spaces into the space called name.
+func (m *APIShim) MergeSpaces(name string, spaceNames []string, force bool) error {
+	return m.spaceAPI.MergeSpaces(name, spaceNames, force)
+}
+
 // ShowSpace fetches space information.
 func (m *APIShim) ShowSpace(name string) (params.ShowSpaceResult, error) {
 	return m.spaceAPI.ShowSpace(name)
 }
 
+// CheckSpaces reports problems with the spaces referred to by the
+// model's configuration, endpoint bindings and constraints.
+func (m *APIShim) CheckSpaces() ([]params.SpaceIssue, error) {
+	return m.spaceAPI.CheckSpaces()
+}
+
 // MoveSubnets ensures that the input subnets are in the input space.
 func (m *APIShim) MoveSubnets(space names.SpaceTag, subnets []names.SubnetTag, force bool) (params.MoveSubnetsResult, error) {
 	return m.spaceAPI.MoveSubnets(space, subnets, force)
@@ -0,0 +1,195 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/agentpresence"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/juju/osenv"
+)
+
+var agentsDoc = `
+Shows the connections of the model's agents to the controller: the
+controller machine whose API server each agent is connected to, the
+address the agent connected from, and when the agent was last heard
+from.
+
+An agent is "missing" when the API server it was connected to has gone
+away and not yet come back. Agents ping the API server once a minute;
+an agent that has not pinged within the controller's
+"agent-ping-timeout" is disconnected. Raise the timeout for agents on
+high-latency links:
+
+    juju controller-config agent-ping-timeout=5m
+
+Examples:
+
+    juju agents
+    juju agents --format yaml
+`
+
+// NewAgentsCommand returns a command that shows the connections of
+// a model's agents to the controller.
+func NewAgentsCommand() cmd.Command {
+	return modelcmd.Wrap(&agentsCommand{})
+}
+
+// AgentsAPI is the API surface for the agents command.
+type AgentsAPI interface {
+	Agents() ([]params.AgentConnection, error)
+	Close() error
+}
+
+type agentsCommand struct {
+	modelcmd.ModelCommandBase
+	api     AgentsAPI
+	out     cmd.Output
+	isoTime bool
+}
+
+// Info implements cmd.Command.
+func (c *agentsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "agents",
+		Purpose: "Show the connections of the model's agents to the controller.",
+		Doc:     agentsDoc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *agentsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.formatTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *agentsCommand) Init(args []string) error {
+	// If use of ISO time not specified on command line,
+	// check env var.
+	if !c.isoTime {
+		var err error
+		envVarValue := os.Getenv(osenv.JujuStatusIsoTimeEnvKey)
+		if envVarValue != "" {
+			if c.isoTime, err = strconv.ParseBool(envVarValue); err != nil {
+				return errors.Annotatef(err, "invalid %s env var, expected true|false", osenv.JujuStatusIsoTimeEnvKey)
+			}
+		}
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *agentsCommand) getAPI() (AgentsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return agentpresence.NewClient(root), nil
+}
+
+// agentConnection is the formatted connection of an agent.
+type agentConnection struct {
+	Agent         string `json:"agent" yaml:"agent"`
+	Controller    string `json:"controller" yaml:"controller"`
+	ConnectionID  uint64 `json:"connection-id" yaml:"connection-id"`
+	Status        string `json:"status" yaml:"status"`
+	RemoteAddress string `json:"remote-address,omitempty" yaml:"remote-address,omitempty"`
+	LastSeen      string `json:"last-seen" yaml:"last-seen"`
+}
+
+// Run implements cmd.Command.
+func (c *agentsCommand) Run(ctx *cmd.Context) error {
+	apiclient, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer apiclient.Close()
+
+	connections, err := apiclient.Agents()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(connections) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No agents are connected.")
+		return nil
+	}
+	formatted := make([]agentConnection, len(connections))
+	for i, conn := range connections {
+		formatted[i] = agentConnection{
+			Agent:         entityName(conn.Tag),
+			Controller:    tagId(conn.Server),
+			ConnectionID:  conn.ConnectionID,
+			Status:        conn.Status,
+			RemoteAddress: conn.RemoteAddress,
+			LastSeen:      c.formatTime(conn.LastSeen),
+		}
+	}
+	return c.out.Write(ctx, formatted)
+}
+
+func (c *agentsCommand) formatTime(t time.Time) string {
+	if c.isoTime {
+		return t.UTC().Format(time.RFC3339)
+	}
+	return common.FormatTime(&t, false)
+}
+
+func (c *agentsCommand) formatTabular(writer io.Writer, value interface{}) error {
+	connections, ok := value.([]agentConnection)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", connections, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Agent", "Controller", "Status", "Address", "Last seen")
+	for _, conn := range connections {
+		address := conn.RemoteAddress
+		if address == "" {
+			address = "-"
+		}
+		w.Println(conn.Agent, conn.Controller, conn.Status, address, conn.LastSeen)
+	}
+	return tw.Flush()
+}
+
+// entityName returns the kind and id of the entity identified by the
+// given tag, or the tag itself if it cannot be parsed.
+func entityName(tag string) string {
+	t, err := names.ParseTag(tag)
+	if err != nil {
+		return tag
+	}
+	return names.ReadableString(t)
+}
+
+// tagId returns the id of the entity identified by the given tag, or
+// the tag itself if it cannot be parsed.
+func tagId(tag string) string {
+	t, err := names.ParseTag(tag)
+	if err != nil {
+		return tag
+	}
+	return t.Id()
+}
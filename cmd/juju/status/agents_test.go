@@ -0,0 +1,109 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	statuscmd "github.com/juju/juju/cmd/juju/status"
+)
+
+type AgentsSuite struct {
+	testing.IsolationSuite
+	api *fakeAgentsAPI
+}
+
+var _ = gc.Suite(&AgentsSuite{})
+
+func (s *AgentsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	lastSeen := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	s.api = &fakeAgentsAPI{
+		connections: []params.AgentConnection{{
+			Tag:           "machine-1",
+			Server:        "machine-0",
+			ConnectionID:  4,
+			Status:        "alive",
+			RemoteAddress: "10.0.0.2:50000",
+			LastSeen:      lastSeen,
+		}, {
+			Tag:          "unit-mysql-0",
+			Server:       "machine-2",
+			ConnectionID: 7,
+			Status:       "missing",
+			LastSeen:     lastSeen.Add(-time.Minute),
+		}},
+	}
+}
+
+func (s *AgentsSuite) TestInitArgs(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, statuscmd.NewTestAgentsCommand(s.api), "mysql/0")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["mysql/0"\]`)
+}
+
+func (s *AgentsSuite) TestTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, statuscmd.NewTestAgentsCommand(s.api), "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Agent         Controller  Status   Address         Last seen
+machine 1     0           alive    10.0.0.2:50000  2021-03-01T12:00:00Z
+unit mysql/0  2           missing  -               2021-03-01T11:59:00Z
+
+`[1:])
+	c.Assert(s.api.closed, jc.IsTrue)
+}
+
+func (s *AgentsSuite) TestTabularNoAgents(c *gc.C) {
+	s.api.connections = nil
+	ctx, err := cmdtesting.RunCommand(c, statuscmd.NewTestAgentsCommand(s.api))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No agents are connected.\n")
+}
+
+func (s *AgentsSuite) TestYAML(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, statuscmd.NewTestAgentsCommand(s.api), "--utc", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- agent: machine 1
+  controller: "0"
+  connection-id: 4
+  status: alive
+  remote-address: 10.0.0.2:50000
+  last-seen: "2021-03-01T12:00:00Z"
+- agent: unit mysql/0
+  controller: "2"
+  connection-id: 7
+  status: missing
+  last-seen: "2021-03-01T11:59:00Z"
+`[1:])
+}
+
+func (s *AgentsSuite) TestAPIError(c *gc.C) {
+	s.api.err = errors.New("boom")
+	_, err := cmdtesting.RunCommand(c, statuscmd.NewTestAgentsCommand(s.api))
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeAgentsAPI struct {
+	connections []params.AgentConnection
+	err         error
+	closed      bool
+}
+
+func (f *fakeAgentsAPI) Agents() ([]params.AgentConnection, error) {
+	return f.connections, f.err
+}
+
+func (f *fakeAgentsAPI) Close() error {
+	f.closed = true
+	return nil
+}
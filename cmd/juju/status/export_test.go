@@ -18,3 +18,15 @@ func NewTestStatusCommand(statusapi statusAPI, storageapi storage.StorageListAPI
 	return modelcmd.Wrap(
 		&statusCommand{statusAPI: statusapi, storageAPI: storageapi, clock: clock})
 }
+
+func NewTestHealthStatusCommand(healthapi healthAPI, clock Clock) cmd.Command {
+	return modelcmd.Wrap(&statusCommand{healthAPI: healthapi, clock: clock})
+}
+
+func NewTestSelectorStatusCommand(statusapi statusAPI, annotationsapi annotationsAPI, clock Clock) cmd.Command {
+	return modelcmd.Wrap(&statusCommand{statusAPI: statusapi, annotationsAPI: annotationsapi, clock: clock})
+}
+
+func NewTestAgentsCommand(api AgentsAPI) cmd.Command {
+	return &agentsCommand{api: api}
+}
@@ -78,6 +78,7 @@ type machineStatus struct {
 	HAStatus           string                        `json:"controller-member-status,omitempty" yaml:"controller-member-status,omitempty"`
 	HAPrimary          bool                          `json:"ha-primary,omitempty" yaml:"ha-primary,omitempty"`
 	LXDProfiles        map[string]lxdProfileContents `json:"lxd-profiles,omitempty" yaml:"lxd-profiles,omitempty"`
+	EngineHealth       *engineHealthContents         `json:"engine-health,omitempty" yaml:"engine-health,omitempty"`
 }
 
 // A goyaml bug means we can't declare these types
@@ -106,6 +107,20 @@ func (s machineStatus) machineName() string {
 	return s.DisplayName
 }
 
+// engineHealthContents holds the unhealthy workers in a machine
+// agent's dependency engine, keyed by name.
+type engineHealthContents struct {
+	Updated string                                `json:"updated,omitempty" yaml:"updated,omitempty"`
+	Workers map[string]engineWorkerHealthContents `json:"workers,omitempty" yaml:"workers,omitempty"`
+}
+
+type engineWorkerHealthContents struct {
+	Health   string `json:"health" yaml:"health"`
+	State    string `json:"state" yaml:"state"`
+	Error    string `json:"error,omitempty" yaml:"error,omitempty"`
+	Restarts int    `json:"restarts,omitempty" yaml:"restarts,omitempty"`
+}
+
 // LXDProfile holds status info about a LXDProfile
 type lxdProfileContents struct {
 	Config      map[string]string            `json:"config" yaml:"config"`
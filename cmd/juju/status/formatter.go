@@ -226,6 +226,28 @@ func (sf *statusFormatter) formatMachine(machine params.MachineStatus) machineSt
 		}
 	}
 
+	if machine.EngineHealth != nil {
+		out.EngineHealth = sf.formatEngineHealth(*machine.EngineHealth)
+	}
+
+	return out
+}
+
+func (sf *statusFormatter) formatEngineHealth(health params.EngineHealth) *engineHealthContents {
+	out := &engineHealthContents{
+		Updated: common.FormatTime(&health.Updated, sf.isoTime),
+	}
+	if len(health.Workers) > 0 {
+		out.Workers = make(map[string]engineWorkerHealthContents)
+	}
+	for _, w := range health.Workers {
+		out.Workers[w.Name] = engineWorkerHealthContents{
+			Health:   w.Health,
+			State:    w.State,
+			Error:    w.Error,
+			Restarts: w.Restarts,
+		}
+	}
 	return out
 }
 
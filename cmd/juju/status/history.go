@@ -11,12 +11,15 @@ import (
 	"strings"
 	"time"
 
+	"github.com/juju/ansiterm"
 	"github.com/juju/cmd"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/names/v4"
 
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -36,6 +39,7 @@ func NewStatusHistoryCommand() cmd.Command {
 // HistoryAPI is the API surface for the show-status-log command.
 type HistoryAPI interface {
 	StatusHistory(kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter) (status.History, error)
+	WatchStatusHistory(unitName string, args api.StatusHistoryStreamParams) (<-chan params.StatusHistoryRecord, error)
 	Close() error
 }
 
@@ -51,6 +55,7 @@ type statusHistoryCommand struct {
 	entityName           string
 	date                 time.Time
 	includeStatusUpdates bool
+	follow               bool
 }
 
 var statusHistoryDoc = fmt.Sprintf(`
@@ -61,6 +66,14 @@ The statuses are available for the following types.
 %v
  and sorted by time of occurrence.
  The default is unit.
+
+For units, --follow continues to show status changes as they happen,
+along with how long each hook took to run.
+
+Examples:
+
+    juju show-status-log mysql/0
+    juju show-status-log mysql/0 --follow
 `, supportedHistoryKindDescs())
 
 func (c *statusHistoryCommand) Info() *cmd.Info {
@@ -100,6 +113,7 @@ func (c *statusHistoryCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.backlogSizeDays, "days", 0, "Returns the logs for the past <days> days (cannot be combined with -n or --date)")
 	f.StringVar(&c.backlogDate, "from-date", "", "Returns logs for any date after the passed one, the expected date format is YYYY-MM-DD (cannot be combined with -n or --days)")
 	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	f.BoolVar(&c.follow, "follow", false, "Show new status changes of a unit as they happen")
 	// TODO (anastasiamac 2018-04-11) Remove at the next major release, say Juju 2.5+ or Juju 3.x.
 	// the functionality is no longer there since a fix for lp#1530840
 	f.BoolVar(&c.includeStatusUpdates, "include-status-updates", false, "Deprecated, has no effect for 2.3+ controllers: Include update status hook messages in the returned logs")
@@ -143,10 +157,20 @@ func (c *statusHistoryCommand) Init(args []string) error {
 	}
 
 	kind := status.HistoryKind(c.outputContent)
-	if kind.Valid() {
-		return nil
+	if !kind.Valid() {
+		return errors.Errorf("unexpected status type %q", c.outputContent)
+	}
+	if c.follow {
+		switch kind {
+		case status.KindUnit, status.KindWorkload, status.KindUnitAgent:
+		default:
+			return errors.Errorf("--follow is only supported for units")
+		}
+		if !emptyDays || !emptyDate {
+			return errors.Errorf("--follow cannot be combined with --days or --from-date")
+		}
 	}
-	return errors.Errorf("unexpected status type %q", c.outputContent)
+	return nil
 }
 
 const runningHookMSG = "running update-status hook"
@@ -165,6 +189,9 @@ func (c *statusHistoryCommand) Run(ctx *cmd.Context) error {
 	}
 	defer apiclient.Close()
 	kind := status.HistoryKind(c.outputContent)
+	if c.follow {
+		return c.runFollow(ctx, apiclient, kind)
+	}
 	var delta *time.Duration
 
 	if c.backlogSizeDays != 0 {
@@ -225,3 +252,44 @@ func (c *statusHistoryCommand) writeTabular(writer io.Writer, statuses status.Hi
 	}
 	tw.Flush()
 }
+
+// runFollow writes the status changes of the unit as they happen, after
+// the most recent ones, until the connection to the controller closes.
+func (c *statusHistoryCommand) runFollow(ctx *cmd.Context, apiclient HistoryAPI, kind status.HistoryKind) error {
+	if !names.IsValidUnit(c.entityName) {
+		return errors.Errorf("%q is not a valid name for a %s", c.entityName, kind)
+	}
+	records, err := apiclient.WatchStatusHistory(c.entityName, api.StatusHistoryStreamParams{
+		Backlog: uint(c.backlogSize),
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// Each change is written as it arrives, so the columns are given a
+	// fixed width, wide enough for any time, kind or status.
+	now := time.Now()
+	const padding = 2
+	minWidth := len(common.FormatTime(&now, c.isoTime)) + padding
+	tw := ansiterm.NewTabWriter(ctx.Stdout, minWidth, 1, padding, ' ', 0)
+	w := output.Wrapper{tw}
+	w.Println("Time", "Type", "Status", "Message")
+	tw.Flush()
+	for record := range records {
+		if kind != status.KindUnit && record.Kind != string(kind) {
+			continue
+		}
+		message := record.Message
+		if record.CompletedHook != "" {
+			message = strings.TrimSpace(fmt.Sprintf(
+				"%s (%s hook took %v)", message, record.CompletedHook, record.HookDuration,
+			))
+		}
+		since := record.Since
+		w.Print(common.FormatTime(&since, c.isoTime), record.Kind)
+		w.PrintStatus(status.Status(record.Status))
+		w.Println(message)
+		tw.Flush()
+	}
+	return nil
+}
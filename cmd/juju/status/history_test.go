@@ -15,6 +15,8 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
 	statuscmd "github.com/juju/juju/cmd/juju/status"
 	"github.com/juju/juju/core/status"
 )
@@ -109,9 +111,96 @@ func (s *StatusHistorySuite) TestResults(c *gc.C) {
 	c.Check(cmdtesting.Stdout(ctx), gc.Equals, expected)
 }
 
+func (s *StatusHistorySuite) TestFollow(c *gc.C) {
+	records := make(chan params.StatusHistoryRecord, 3)
+	records <- params.StatusHistoryRecord{
+		Kind:    "juju-unit",
+		Status:  "executing",
+		Message: "running install hook",
+		Since:   *s.next(),
+		Hook:    "install",
+	}
+	records <- params.StatusHistoryRecord{
+		Kind:    "workload",
+		Status:  "active",
+		Message: "ready",
+		Since:   *s.next(),
+	}
+	records <- params.StatusHistoryRecord{
+		Kind:          "juju-unit",
+		Status:        "idle",
+		Since:         *s.next(),
+		CompletedHook: "install",
+		HookDuration:  2 * time.Minute,
+	}
+	close(records)
+	fakeAPI := &fakeHistoryAPI{records: records}
+	s.api = fakeAPI
+
+	expected := "" +
+		"Time                  Type                  Status                Message\n" +
+		"2017-11-28 12:34:56Z  juju-unit             executing             running install hook\n" +
+		"2017-11-28 12:35:56Z  workload              active                ready\n" +
+		"2017-11-28 12:36:56Z  juju-unit             idle                  (install hook took 2m0s)\n"
+
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "mysql/0", "--utc", "--follow", "-n", "5")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, expected)
+	c.Check(fakeAPI.unitName, gc.Equals, "mysql/0")
+	c.Check(fakeAPI.streamArgs, jc.DeepEquals, api.StatusHistoryStreamParams{Backlog: 5})
+}
+
+func (s *StatusHistorySuite) TestFollowWorkloadOnly(c *gc.C) {
+	records := make(chan params.StatusHistoryRecord, 2)
+	records <- params.StatusHistoryRecord{
+		Kind:    "juju-unit",
+		Status:  "executing",
+		Message: "running install hook",
+		Since:   *s.next(),
+	}
+	records <- params.StatusHistoryRecord{
+		Kind:    "workload",
+		Status:  "active",
+		Message: "ready",
+		Since:   *s.next(),
+	}
+	close(records)
+	s.api = &fakeHistoryAPI{records: records}
+
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "mysql/0", "--utc", "--follow", "--type", "workload")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Time                  Type                  Status                Message\n"+
+		"2017-11-28 12:35:56Z  workload              active                ready\n")
+}
+
+func (s *StatusHistorySuite) TestFollowInvalidArgs(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"0", "--type", "machine", "--follow"},
+		err:  "--follow is only supported for units",
+	}, {
+		args: []string{"mysql/0", "--follow", "--days", "2"},
+		err:  "--follow cannot be combined with --days or --from-date",
+	}, {
+		args: []string{"mysql/0", "--follow", "--from-date", "2021-03-01"},
+		err:  "--follow cannot be combined with --days or --from-date",
+	}} {
+		_, err := cmdtesting.RunCommand(c, s.newCommand(), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 type fakeHistoryAPI struct {
 	err     error
 	history status.History
+
+	records    chan params.StatusHistoryRecord
+	unitName   string
+	streamArgs api.StatusHistoryStreamParams
 }
 
 func (*fakeHistoryAPI) Close() error {
@@ -121,3 +210,9 @@ func (*fakeHistoryAPI) Close() error {
 func (f *fakeHistoryAPI) StatusHistory(kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter) (status.History, error) {
 	return f.history, f.err
 }
+
+func (f *fakeHistoryAPI) WatchStatusHistory(unitName string, args api.StatusHistoryStreamParams) (<-chan params.StatusHistoryRecord, error) {
+	f.unitName = unitName
+	f.streamArgs = args
+	return f.records, f.err
+}
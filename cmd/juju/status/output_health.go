@@ -0,0 +1,72 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/output"
+)
+
+type formattedHealth struct {
+	Severity string                   `json:"severity" yaml:"severity"`
+	Problems []formattedHealthProblem `json:"problems" yaml:"problems"`
+}
+
+type formattedHealthProblem struct {
+	Severity string `json:"severity" yaml:"severity"`
+	Kind     string `json:"kind" yaml:"kind"`
+	Entity   string `json:"entity" yaml:"entity"`
+	Message  string `json:"message" yaml:"message"`
+	Since    string `json:"since,omitempty" yaml:"since,omitempty"`
+}
+
+func formatHealth(summary params.ModelHealthSummary, isoTime bool) formattedHealth {
+	result := formattedHealth{
+		Severity: summary.Severity,
+		Problems: make([]formattedHealthProblem, len(summary.Problems)),
+	}
+	for i, p := range summary.Problems {
+		entity := p.Tag
+		if tag, err := names.ParseTag(p.Tag); err == nil {
+			entity = tag.Id()
+		}
+		result.Problems[i] = formattedHealthProblem{
+			Severity: p.Severity,
+			Kind:     p.Kind,
+			Entity:   entity,
+			Message:  p.Message,
+		}
+		if p.Since != nil {
+			result.Problems[i].Since = common.FormatTime(p.Since, isoTime)
+		}
+	}
+	return result
+}
+
+// FormatHealthTabular writes a tabular summary of a model's health.
+func FormatHealthTabular(writer io.Writer, value interface{}) error {
+	health, ok := value.(formattedHealth)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", health, value)
+	}
+	fmt.Fprintf(writer, "Model health: %s\n", health.Severity)
+	if len(health.Problems) == 0 {
+		return nil
+	}
+	fmt.Fprintln(writer)
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Severity", "Kind", "Entity", "Since", "Message")
+	for _, p := range health.Problems {
+		w.Println(p.Severity, p.Kind, p.Entity, p.Since, p.Message)
+	}
+	return tw.Flush()
+}
@@ -413,12 +413,17 @@ func printMachine(w output.Wrapper, m machineStatus) {
 // Rules:
 //  - if the modification-status is in error mode, then show that over the
 //    juju status and machine status message
+//  - if the machine is being replaced, then show the progress of the
+//    replacement over the machine status message
 func getStatusAndMessageFromMachineStatus(m machineStatus) (status.Status, string) {
 	currentStatus := m.JujuStatus.Current
 	currentMessage := m.MachineStatus.Message
-	if m.ModificationStatus.Current == status.Error {
+	switch m.ModificationStatus.Current {
+	case status.Error:
 		currentStatus = m.ModificationStatus.Current
 		currentMessage = m.ModificationStatus.Message
+	case status.Replacing:
+		currentMessage = m.ModificationStatus.Message
 	}
 
 	return currentStatus, currentMessage
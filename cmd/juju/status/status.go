@@ -17,19 +17,35 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/loggo"
+	"github.com/juju/names/v4"
 
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/annotations"
+	"github.com/juju/juju/api/modelhealth"
 	storageapi "github.com/juju/juju/api/storage"
 	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/juju/storage"
 	"github.com/juju/juju/cmd/modelcmd"
+	coreannotations "github.com/juju/juju/core/annotations"
 	"github.com/juju/juju/juju/osenv"
 )
 
 var logger = loggo.GetLogger("juju.cmd.juju.status")
 
 type statusAPI interface {
-	Status(patterns []string) (*params.FullStatus, error)
+	FilteredStatus(args params.StatusParams) (*params.FullStatus, error)
+	Close() error
+}
+
+type healthAPI interface {
+	Summary() (params.ModelHealthSummary, error)
+	Close() error
+}
+
+type annotationsAPI interface {
+	Query(selector string, kinds ...string) ([]params.EntityAnnotations, error)
 	Close() error
 }
 
@@ -50,11 +66,17 @@ type statusCommand struct {
 	modelcmd.ModelCommandBase
 	out        cmd.Output
 	patterns   []string
+	spaces     []string
+	subnets    []string
+	selector   string
 	isoTime    bool
 	statusAPI  statusAPI
 	storageAPI storage.StorageListAPI
+	healthAPI  healthAPI
 	clock      Clock
 
+	annotationsAPI annotationsAPI
+
 	retryCount int
 	retryDelay time.Duration
 
@@ -68,6 +90,13 @@ type statusCommand struct {
 
 	// storage indicates if 'storage' section is displayed
 	storage bool
+
+	// health indicates if a summary of the model's problems is
+	// displayed instead of its status.
+	health bool
+
+	// responseCache, if set, caches the status reported.
+	responseCache *common.ResponseCache
 }
 
 var usageSummary = `
@@ -117,6 +146,39 @@ The '--format' option allows you to specify how the status report is formatted.
                     Provide information in a JSON or YAML formats for 
                     programmatic use.
 
+
+Filtering by network
+
+The '--space' and '--subnet' options restrict the report to machines with an
+address in one of the given spaces or subnets, and to the units they host.
+Both options accept a comma separated list and may be combined with
+selectors, in which case an entity must match both to be displayed. This is
+useful when debugging endpoint bindings on models spanning several networks.
+
+Filtering by annotations
+
+The '--selector' option also reports on the machines, applications and units
+whose annotations match a selector, a comma separated list of requirements
+such as env=prod,tier!=web. Each requirement is one of key=value, key!=value,
+key (the annotation is set) or !key (the annotation is not set).
+
+Model health
+
+The '--health' option reports a summary of the problems found in the model
+in place of its status: units in error, units whose hooks are failing
+repeatedly, lost unit agents, machines that are down, and machine agents
+still to be upgraded. Each problem has a severity of "warning" or "critical",
+and the model's overall severity is that of its most severe problem, or "ok".
+Only the tabular, json and yaml formats are supported with '--health'.
+
+Caching status
+
+Scripts which report status frequently can set the JUJU_API_CACHE_TTL
+environment variable to a duration, such as 2s, to reuse the status for
+that long without contacting the controller. After that, the controller
+is asked whether the model has changed, and only reports the full status
+again if it has.
+
 Examples:
 
     # Report the status of units hosted on machine 0
@@ -131,9 +193,20 @@ Examples:
     # Include information about storage and relations in output
     juju status --storage --relations
 
+    # Report the status of machines with an address in the db-space
+    # space, and of the units they host
+    juju status --space db-space
+
+    # Report the status of the entities annotated with env=prod
+    juju status --selector env=prod
+
     # Provide output as valid JSON
     juju status --format=json
 
+    # Summarise the problems in the model, such as units in error,
+    # lost agents and machines that are down
+    juju status --health
+
 Further reading:
 
     https://juju.is/docs/command/status
@@ -164,6 +237,10 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.color, "color", false, "Use ANSI color codes in tabular output")
 	f.BoolVar(&c.relations, "relations", false, "Show 'relations' section in tabular output")
 	f.BoolVar(&c.storage, "storage", false, "Show 'storage' section in tabular output")
+	f.BoolVar(&c.health, "health", false, "Show a summary of problems in the model instead of its status")
+	f.Var(cmd.NewStringsValue(nil, &c.spaces), "space", "Only show machines with an address in one of these spaces (comma separated), and their units")
+	f.Var(cmd.NewStringsValue(nil, &c.subnets), "subnet", "Only show machines with an address in one of these subnets (comma separated CIDRs), and their units")
+	f.StringVar(&c.selector, "selector", "", "Show the machines, applications and units whose annotations match this selector")
 
 	f.IntVar(&c.retryCount, "retry-count", 3, "Number of times to retry API failures")
 	f.DurationVar(&c.retryDelay, "retry-delay", 100*time.Millisecond, "Time to wait between retry attempts")
@@ -198,6 +275,27 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 
 func (c *statusCommand) Init(args []string) error {
 	c.patterns = args
+	if c.health {
+		if len(args) > 0 {
+			return errors.New("selectors cannot be used with --health")
+		}
+		if len(c.spaces) > 0 || len(c.subnets) > 0 {
+			return errors.New("--space and --subnet cannot be used with --health")
+		}
+		if c.selector != "" {
+			return errors.New("--selector cannot be used with --health")
+		}
+		switch c.out.Name() {
+		case "tabular", "json", "yaml":
+		default:
+			return errors.Errorf("--health does not support the %q format", c.out.Name())
+		}
+	}
+	if c.selector != "" {
+		if _, err := coreannotations.ParseSelector(c.selector); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	// If use of ISO time not specified on command line,
 	// check env var.
 	if !c.isoTime {
@@ -212,23 +310,38 @@ func (c *statusCommand) Init(args []string) error {
 	if c.clock == nil {
 		c.clock = clock.WallClock
 	}
+	var err error
+	if c.responseCache, err = common.ResponseCacheFromEnvironment(); err != nil {
+		return errors.Trace(err)
+	}
 	return nil
 }
 
+// modelAPIRoot returns the connection to the model shared by the
+// API clients the command uses.
+func (c *statusCommand) modelAPIRoot() (api.Connection, error) {
+	modelName, _, err := c.ModelDetails()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	root, err := c.PooledAPIRoot(modelName)
+	return root, errors.Trace(err)
+}
+
 var newAPIClientForStatus = func(c *statusCommand) (statusAPI, error) {
 	if c.statusAPI == nil {
-		api, err := c.NewAPIClient()
+		root, err := c.modelAPIRoot()
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		c.statusAPI = api
+		c.statusAPI = root.Client()
 	}
 	return c.statusAPI, nil
 }
 
 var newAPIClientForStorage = func(c *statusCommand) (storage.StorageListAPI, error) {
 	if c.storageAPI == nil {
-		root, err := c.NewAPIRoot()
+		root, err := c.modelAPIRoot()
 		if err != nil {
 			return nil, err
 		}
@@ -237,6 +350,28 @@ var newAPIClientForStorage = func(c *statusCommand) (storage.StorageListAPI, err
 	return c.storageAPI, nil
 }
 
+var newAPIClientForHealth = func(c *statusCommand) (healthAPI, error) {
+	if c.healthAPI == nil {
+		root, err := c.modelAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		c.healthAPI = modelhealth.NewClient(root)
+	}
+	return c.healthAPI, nil
+}
+
+var newAPIClientForAnnotations = func(c *statusCommand) (annotationsAPI, error) {
+	if c.annotationsAPI == nil {
+		root, err := c.modelAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		c.annotationsAPI = annotations.NewClient(root)
+	}
+	return c.annotationsAPI, nil
+}
+
 func (c *statusCommand) close() {
 	// We really don't care what the errors are if there are some.
 	// The user can't do anything about it.  Just try.
@@ -246,15 +381,84 @@ func (c *statusCommand) close() {
 	if c.storageAPI != nil {
 		c.storageAPI.Close()
 	}
+	if c.healthAPI != nil {
+		c.healthAPI.Close()
+	}
+	if c.annotationsAPI != nil {
+		c.annotationsAPI.Close()
+	}
 	return
 }
 
 func (c *statusCommand) getStatus() (*params.FullStatus, error) {
-	apiclient, err := newAPIClientForStatus(c)
+	args := params.StatusParams{
+		Patterns: c.patterns,
+		Spaces:   c.spaces,
+		Subnets:  c.subnets,
+	}
+	var key string
+	if c.responseCache != nil {
+		var err error
+		if key, err = c.statusCacheKey(args); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	var status *params.FullStatus
+	err := c.responseCache.Call(key, &status, func(knownRevno int64) (int64, bool, error) {
+		apiclient, err := newAPIClientForStatus(c)
+		if err != nil {
+			return 0, false, errors.Trace(err)
+		}
+		args.KnownRevno = knownRevno
+		result, err := apiclient.FilteredStatus(args)
+		if err == nil && result.NotModified {
+			return result.Revno, true, nil
+		}
+		status = result
+		if err != nil {
+			return 0, false, err
+		}
+		return result.Revno, false, nil
+	})
+	return status, err
+}
+
+// statusCacheKey returns the key identifying the status requested in
+// the response cache.
+func (c *statusCommand) statusCacheKey(args params.StatusParams) (string, error) {
+	controllerName, err := c.ControllerName()
 	if err != nil {
-		return nil, errors.Trace(err)
+		return "", errors.Trace(err)
+	}
+	account, err := c.CurrentAccountDetails()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	modelName, err := c.ModelIdentifier()
+	if err != nil {
+		return "", errors.Trace(err)
 	}
-	return apiclient.Status(c.patterns)
+	return fmt.Sprintf("status %q %q %q %q %q %q",
+		controllerName, account.User, modelName, args.Patterns, args.Spaces, args.Subnets), nil
+}
+
+// selectEntities adds the machines, applications and units whose
+// annotations match the selector to the patterns to report on.
+func (c *statusCommand) selectEntities() error {
+	api, err := newAPIClientForAnnotations(c)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ids, err := common.EntityIdsBySelector(api, c.selector,
+		names.MachineTagKind, names.ApplicationTagKind, names.UnitTagKind)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(ids) == 0 {
+		return errors.Errorf("no machines, applications or units match selector %q", c.selector)
+	}
+	c.patterns = append(c.patterns, ids...)
+	return nil
 }
 
 func (c *statusCommand) getStorageInfo(ctx *cmd.Context) (*storage.CombinedStorage, error) {
@@ -275,6 +479,14 @@ func (c *statusCommand) getStorageInfo(ctx *cmd.Context) (*storage.CombinedStora
 
 func (c *statusCommand) Run(ctx *cmd.Context) error {
 	defer c.close()
+	if c.health {
+		return c.runHealth(ctx)
+	}
+	if c.selector != "" {
+		if err := c.selectEntities(); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	// Always attempt to get the status at least once, and retry if it fails.
 	status, err := c.getStatus()
@@ -380,6 +592,21 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+func (c *statusCommand) runHealth(ctx *cmd.Context) error {
+	apiclient, err := newAPIClientForHealth(c)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	summary, err := apiclient.Summary()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, formatHealth(summary, c.isoTime))
+}
+
 func (c *statusCommand) FormatTabular(writer io.Writer, value interface{}) error {
+	if _, ok := value.(formattedHealth); ok {
+		return FormatHealthTabular(writer, value)
+	}
 	return FormatTabular(writer, c.color, value)
 }
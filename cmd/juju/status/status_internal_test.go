@@ -4799,8 +4799,8 @@ type fakeAPIClient struct {
 	closeCalled  bool
 }
 
-func (a *fakeAPIClient) Status(patterns []string) (*params.FullStatus, error) {
-	a.patternsUsed = patterns
+func (a *fakeAPIClient) FilteredStatus(args params.StatusParams) (*params.FullStatus, error) {
+	a.patternsUsed = args.Patterns
 	return a.statusReturn, nil
 }
 
@@ -5267,8 +5267,8 @@ func (s *StatusSuite) TestStatusWithNilStatusAPI(c *gc.C) {
 	}
 
 	client := fakeAPIClient{}
-	var status = client.Status
-	s.PatchValue(&status, func(_ []string) (*params.FullStatus, error) {
+	var status = client.FilteredStatus
+	s.PatchValue(&status, func(_ params.StatusParams) (*params.FullStatus, error) {
 		return nil, nil
 	})
 	s.PatchValue(&newAPIClientForStatus, func(_ *statusCommand) (statusAPI, error) {
@@ -6131,3 +6131,23 @@ controller  kontroll    dummy/dummy-region  1.2.3    unsupported  15:04:05+07:00
 	_, _, stderr = runStatus(c, "cannot", "match", "me")
 	c.Check(string(stderr), gc.Equals, "Nothing matched specified filters.\n")
 }
+
+func (s *StatusSuite) TestMachineModificationStatusTabular(c *gc.C) {
+	m := machineStatus{
+		JujuStatus:    statusInfoContents{Current: status.Started},
+		MachineStatus: statusInfoContents{Current: status.Running, Message: "Running"},
+	}
+	current, message := getStatusAndMessageFromMachineStatus(m)
+	c.Check(current, gc.Equals, status.Started)
+	c.Check(message, gc.Equals, "Running")
+
+	m.ModificationStatus = statusInfoContents{Current: status.Replacing, Message: "waiting for foo/0 to be removed"}
+	current, message = getStatusAndMessageFromMachineStatus(m)
+	c.Check(current, gc.Equals, status.Started)
+	c.Check(message, gc.Equals, "waiting for foo/0 to be removed")
+
+	m.ModificationStatus = statusInfoContents{Current: status.Error, Message: "boom"}
+	current, message = getStatusAndMessageFromMachineStatus(m)
+	c.Check(current, gc.Equals, status.Error)
+	c.Check(message, gc.Equals, "boom")
+}
@@ -15,6 +15,8 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/status"
 	corestatus "github.com/juju/juju/core/status"
+	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/testing"
 )
 
@@ -54,6 +56,36 @@ func (s *MinimalStatusSuite) TestGoodCall(c *gc.C) {
 	c.Assert(s.clock.waits, gc.HasLen, 0)
 }
 
+func (s *MinimalStatusSuite) TestNetworkFilters(c *gc.C) {
+	_, err := s.runStatus(c, "--space", "db-space,public", "--subnet", "10.0.0.0/24", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.statusapi.args, jc.DeepEquals, params.StatusParams{
+		Patterns: []string{"mysql"},
+		Spaces:   []string{"db-space", "public"},
+		Subnets:  []string{"10.0.0.0/24"},
+	})
+}
+
+func (s *MinimalStatusSuite) TestCachedStatus(c *gc.C) {
+	s.PatchEnvironment(osenv.JujuAPICacheTTLEnvKey, "1h")
+	err := jujuclient.NewFileClientStore().UpdateAccount("test", jujuclient.AccountDetails{User: "admin"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.runStatus(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.statusapi.args = params.StatusParams{}
+	s.statusapi.errors = []error{errors.New("not expected")}
+	ctx, err := s.runStatus(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, "name: test")
+	c.Assert(s.statusapi.args, jc.DeepEquals, params.StatusParams{})
+
+	// Different filters are cached separately.
+	_, err = s.runStatus(c, "--format", "yaml", "--retry-count", "0", "mysql")
+	c.Assert(err, gc.ErrorMatches, "not expected")
+}
+
 func (s *MinimalStatusSuite) TestGoodCallWithStorage(c *gc.C) {
 	context, err := s.runStatus(c, "--storage")
 	c.Assert(err, jc.ErrorIsNil)
@@ -130,12 +162,138 @@ func (s *MinimalStatusSuite) TestRetryCountOfZero(c *gc.C) {
 	c.Assert(s.clock.waits, gc.HasLen, 0)
 }
 
+func (s *MinimalStatusSuite) runHealth(c *gc.C, api *fakeHealthAPI, args ...string) (*cmd.Context, error) {
+	statusCmd := status.NewTestHealthStatusCommand(api, s.clock)
+	return cmdtesting.RunCommand(c, statusCmd, append([]string{"--health"}, args...)...)
+}
+
+func (s *MinimalStatusSuite) TestHealth(c *gc.C) {
+	since := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	api := &fakeHealthAPI{result: params.ModelHealthSummary{
+		Severity: "critical",
+		Problems: []params.HealthProblem{{
+			Kind:     "hook-failing",
+			Severity: "critical",
+			Tag:      "unit-mysql-1",
+			Message:  `hook failed: "install" (failed 3 times recently)`,
+			Since:    &since,
+		}, {
+			Kind:     "upgrade-pending",
+			Severity: "warning",
+			Tag:      "machine-0",
+			Message:  "agent is running 2.9.0, model agent version is 2.9.1",
+		}},
+	}}
+	ctx, err := s.runHealth(c, api, "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Model health: critical
+
+Severity  Kind             Entity   Since                 Message
+critical  hook-failing     mysql/1  2021-03-01 12:00:00Z  hook failed: "install" (failed 3 times recently)
+warning   upgrade-pending  0                              agent is running 2.9.0, model agent version is 2.9.1
+
+`[1:])
+
+	ctx, err = s.runHealth(c, api, "--utc", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+severity: critical
+problems:
+- severity: critical
+  kind: hook-failing
+  entity: mysql/1
+  message: 'hook failed: "install" (failed 3 times recently)'
+  since: 2021-03-01 12:00:00Z
+- severity: warning
+  kind: upgrade-pending
+  entity: "0"
+  message: agent is running 2.9.0, model agent version is 2.9.1
+`[1:])
+}
+
+func (s *MinimalStatusSuite) TestHealthOK(c *gc.C) {
+	api := &fakeHealthAPI{result: params.ModelHealthSummary{Severity: "ok"}}
+	ctx, err := s.runHealth(c, api)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "Model health: ok\n\n")
+}
+
+func (s *MinimalStatusSuite) TestHealthInvalidArgs(c *gc.C) {
+	api := &fakeHealthAPI{}
+	_, err := s.runHealth(c, api, "mysql")
+	c.Assert(err, gc.ErrorMatches, "selectors cannot be used with --health")
+	_, err = s.runHealth(c, api, "--format", "oneline")
+	c.Assert(err, gc.ErrorMatches, `--health does not support the "oneline" format`)
+	_, err = s.runHealth(c, api, "--space", "db-space")
+	c.Assert(err, gc.ErrorMatches, "--space and --subnet cannot be used with --health")
+}
+
+type fakeHealthAPI struct {
+	result params.ModelHealthSummary
+}
+
+func (f *fakeHealthAPI) Summary() (params.ModelHealthSummary, error) {
+	return f.result, nil
+}
+
+func (*fakeHealthAPI) Close() error {
+	return nil
+}
+
+func (s *MinimalStatusSuite) TestSelector(c *gc.C) {
+	api := &fakeAnnotationsAPI{results: []params.EntityAnnotations{
+		{EntityTag: "application-mysql"},
+		{EntityTag: "machine-0"},
+		{EntityTag: "unit-wordpress-0"},
+	}}
+	statusCmd := status.NewTestSelectorStatusCommand(s.statusapi, api, s.clock)
+	_, err := cmdtesting.RunCommand(c, statusCmd, "--selector", "env=prod", "nova-*")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api.selector, gc.Equals, "env=prod")
+	c.Assert(api.kinds, jc.DeepEquals, []string{"machine", "application", "unit"})
+	c.Assert(s.statusapi.args, jc.DeepEquals, params.StatusParams{
+		Patterns: []string{"nova-*", "mysql", "0", "wordpress/0"},
+	})
+}
+
+func (s *MinimalStatusSuite) TestSelectorNoMatches(c *gc.C) {
+	statusCmd := status.NewTestSelectorStatusCommand(s.statusapi, &fakeAnnotationsAPI{}, s.clock)
+	_, err := cmdtesting.RunCommand(c, statusCmd, "--selector", "env=prod")
+	c.Assert(err, gc.ErrorMatches, `no machines, applications or units match selector "env=prod"`)
+}
+
+func (s *MinimalStatusSuite) TestSelectorInvalid(c *gc.C) {
+	_, err := s.runStatus(c, "--selector", "env=")
+	c.Assert(err, gc.ErrorMatches, `selector "env=": requirement "env=" with no value not valid`)
+	_, err = s.runStatus(c, "--selector", "env=prod", "--health")
+	c.Assert(err, gc.ErrorMatches, "--selector cannot be used with --health")
+}
+
+type fakeAnnotationsAPI struct {
+	results  []params.EntityAnnotations
+	selector string
+	kinds    []string
+}
+
+func (f *fakeAnnotationsAPI) Query(selector string, kinds ...string) ([]params.EntityAnnotations, error) {
+	f.selector = selector
+	f.kinds = kinds
+	return f.results, nil
+}
+
+func (*fakeAnnotationsAPI) Close() error {
+	return nil
+}
+
 type fakeStatusAPI struct {
 	result *params.FullStatus
 	errors []error
+	args   params.StatusParams
 }
 
-func (f *fakeStatusAPI) Status(patterns []string) (*params.FullStatus, error) {
+func (f *fakeStatusAPI) FilteredStatus(args params.StatusParams) (*params.FullStatus, error) {
+	f.args = args
 	if len(f.errors) > 0 {
 		err, rest := f.errors[0], f.errors[1:]
 		f.errors = rest
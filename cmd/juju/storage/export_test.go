@@ -91,3 +91,27 @@ func NewDetachStorageCommandForTest(new NewEntityDetacherCloserFunc, store jujuc
 	cmd.newEntityDetacherCloser = new
 	return modelcmd.Wrap(cmd)
 }
+
+func NewSnapshotCreateCommandForTest(api SnapshotCreateAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &snapshotCreateCommand{newAPIFunc: func() (SnapshotCreateAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewSnapshotListCommandForTest(api SnapshotListAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &snapshotListCommand{newAPIFunc: func() (SnapshotListAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewSnapshotRestoreCommandForTest(api SnapshotRestoreAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &snapshotRestoreCommand{newAPIFunc: func() (SnapshotRestoreAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
@@ -27,6 +27,10 @@ func NewListCommand() cmd.Command {
 
 const listCommandDoc = `
 List information about storage.
+
+When listing storage instances, the total size of the loop and tmpfs
+storage on each machine is also shown, along with the machine quota
+configured on the storage pools it came from, if any.
 `
 
 // listCommand returns storage instances.
@@ -102,6 +106,13 @@ func (c *listCommand) Run(ctx *cmd.Context) (err error) {
 	if err != nil {
 		return err
 	}
+	if poolAPI, ok := api.(StoragePoolListAPI); ok && params.WantStorage {
+		usage, err := generateMachineStorageUsage(poolAPI, combined)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		combined.MachineStorage = usage
+	}
 	if combined.Empty() {
 		if c.out.Name() == "tabular" {
 			ctx.Infof("No storage to display.")
@@ -171,6 +182,10 @@ type CombinedStorage struct {
 	StorageInstances map[string]StorageInfo    `yaml:"storage,omitempty" json:"storage,omitempty"`
 	Filesystems      map[string]FilesystemInfo `yaml:"filesystems,omitempty" json:"filesystems,omitempty"`
 	Volumes          map[string]VolumeInfo     `yaml:"volumes,omitempty" json:"volumes,omitempty"`
+
+	// MachineStorage holds the loop and tmpfs storage consumption of
+	// each machine, keyed by machine ID and then provider type.
+	MachineStorage map[string]map[string]MachineStorageUsage `yaml:"machine-storage,omitempty" json:"machine-storage,omitempty"`
 }
 
 // Empty checks if CombinedStorage is empty.
@@ -192,6 +207,12 @@ func formatListTabular(writer io.Writer, value interface{}, all bool) error {
 		if err := formatStorageInstancesListTabular(writer, combined); err != nil {
 			return errors.Trace(err)
 		}
+		if len(combined.MachineStorage) > 0 {
+			fmt.Fprintln(writer)
+			if err := formatMachineStorageListTabular(writer, combined.MachineStorage); err != nil {
+				return errors.Trace(err)
+			}
+		}
 		if !all {
 			return nil
 		}
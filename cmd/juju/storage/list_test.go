@@ -70,6 +70,44 @@ transcode/1   shared-fs/0   filesystem  1.0GiB  attached
 `[1:])
 }
 
+func (s *ListSuite) TestListMachineStorage(c *gc.C) {
+	api := &mockListPoolsAPI{
+		mockListAPI: s.mockAPI,
+		pools: []params.StoragePool{{
+			Name:     "radiance",
+			Provider: "loop",
+			Attrs:    map[string]interface{}{"machine-quota": "1G"},
+		}},
+	}
+	context, err := cmdtesting.RunCommand(c, storage.NewListCommandForTest(api, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, `
+Unit          Storage id    Type        Pool      Size    Status    Message
+              persistent/1  filesystem                    detached  
+postgresql/0  db-dir/1100   block                 3.0MiB  attached  
+transcode/0   db-dir/1000   block                         pending   creating volume
+transcode/0   shared-fs/0   filesystem  radiance  1.0GiB  attached  
+transcode/1   shared-fs/0   filesystem  radiance  1.0GiB  attached  
+
+Machine  Provider  Used    Quota
+0        loop      512MiB  1.0GiB
+
+`[1:])
+}
+
+func (s *ListSuite) TestListMachineStorageInvalidQuota(c *gc.C) {
+	api := &mockListPoolsAPI{
+		mockListAPI: s.mockAPI,
+		pools: []params.StoragePool{{
+			Name:     "radiance",
+			Provider: "loop",
+			Attrs:    map[string]interface{}{"machine-quota": "lots"},
+		}},
+	}
+	_, err := cmdtesting.RunCommand(c, storage.NewListCommandForTest(api, s.store))
+	c.Assert(err, gc.ErrorMatches, `parsing machine-quota of pool "radiance": .*`)
+}
+
 func (s *ListSuite) TestListYAML(c *gc.C) {
 	now := time.Now()
 	s.mockAPI.time = now
@@ -329,6 +367,15 @@ type mockListAPI struct {
 	time            time.Time
 }
 
+type mockListPoolsAPI struct {
+	*mockListAPI
+	pools []params.StoragePool
+}
+
+func (s *mockListPoolsAPI) ListPools(providers, names []string) ([]params.StoragePool, error) {
+	return s.pools, nil
+}
+
 func (s *mockListAPI) Close() error {
 	return nil
 }
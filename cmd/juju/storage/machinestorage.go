@@ -0,0 +1,143 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/naturalsort"
+	"github.com/juju/utils/v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/storage/provider"
+)
+
+// StoragePoolListAPI defines the API method that the storage command uses
+// to look up the machine quotas of storage pools.
+type StoragePoolListAPI interface {
+	ListPools(providers, names []string) ([]params.StoragePool, error)
+}
+
+// MachineStorageUsage holds the total size of the machine-local storage
+// of one storage provider type on a machine, and the quota on it.
+type MachineStorageUsage struct {
+	// Used is the total size, in MiB, of the storage on the machine.
+	Used uint64 `yaml:"used" json:"used"`
+
+	// Quota is the smallest machine quota, in MiB, of the pools that
+	// the storage came from, or zero if none of them have a quota.
+	Quota uint64 `yaml:"quota,omitempty" json:"quota,omitempty"`
+}
+
+// machineStorageProviders holds the storage provider types that consume
+// the resources of the machine they are provisioned on, and whose
+// storage is limited by the pools' machine quotas.
+var machineStorageProviders = map[string]bool{
+	string(provider.LoopProviderType):  true,
+	string(provider.TmpfsProviderType): true,
+}
+
+// generateMachineStorageUsage returns the consumption of loop and tmpfs
+// storage on each machine, keyed by machine ID and then provider type.
+func generateMachineStorageUsage(
+	api StoragePoolListAPI, combined *CombinedStorage,
+) (map[string]map[string]MachineStorageUsage, error) {
+	pools, err := api.ListPools(nil, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	providers := make(map[string]string)
+	quotas := make(map[string]uint64)
+	for _, pool := range pools {
+		providers[pool.Name] = pool.Provider
+		value, ok := pool.Attrs[provider.MachineQuota]
+		if !ok {
+			continue
+		}
+		quota, err := utils.ParseSize(fmt.Sprint(value))
+		if err != nil {
+			return nil, errors.Annotatef(err, "parsing %s of pool %q", provider.MachineQuota, pool.Name)
+		}
+		quotas[pool.Name] = quota
+	}
+
+	usage := make(map[string]map[string]MachineStorageUsage)
+	add := func(machineId, pool string, size uint64, providerType string) {
+		providerTypeForPool, ok := providers[pool]
+		if !ok {
+			// The default pools are named after
+			// their storage provider types.
+			providerTypeForPool = pool
+		}
+		if providerTypeForPool != providerType {
+			return
+		}
+		byProvider, ok := usage[machineId]
+		if !ok {
+			byProvider = make(map[string]MachineStorageUsage)
+			usage[machineId] = byProvider
+		}
+		u := byProvider[providerType]
+		u.Used += size
+		if quota := quotas[pool]; quota > 0 && (u.Quota == 0 || quota < u.Quota) {
+			u.Quota = quota
+		}
+		byProvider[providerType] = u
+	}
+	for _, v := range combined.Volumes {
+		if v.Attachments == nil {
+			continue
+		}
+		for machineId := range v.Attachments.Machines {
+			add(machineId, v.Pool, v.Size, string(provider.LoopProviderType))
+		}
+	}
+	for _, f := range combined.Filesystems {
+		if f.Attachments == nil {
+			continue
+		}
+		for machineId := range f.Attachments.Machines {
+			add(machineId, f.Pool, f.Size, string(provider.TmpfsProviderType))
+		}
+	}
+	if len(usage) == 0 {
+		return nil, nil
+	}
+	return usage, nil
+}
+
+// formatMachineStorageListTabular writes a tabular summary of the loop and
+// tmpfs storage consumed on each machine.
+func formatMachineStorageListTabular(writer io.Writer, usage map[string]map[string]MachineStorageUsage) error {
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Machine", "Provider", "Used", "Quota")
+
+	machineIds := make([]string, 0, len(usage))
+	for machineId := range usage {
+		machineIds = append(machineIds, machineId)
+	}
+	naturalsort.Sort(machineIds)
+	for _, machineId := range machineIds {
+		byProvider := usage[machineId]
+		providerTypes := make([]string, 0, len(byProvider))
+		for providerType := range byProvider {
+			providerTypes = append(providerTypes, providerType)
+		}
+		sort.Strings(providerTypes)
+		for _, providerType := range providerTypes {
+			u := byProvider[providerType]
+			quota := "-"
+			if u.Quota > 0 {
+				quota = humanizeStorageSize(u.Quota)
+			}
+			w.Println(machineId, providerType, humanizeStorageSize(u.Used), quota)
+		}
+	}
+	return tw.Flush()
+}
@@ -45,9 +45,14 @@ configuration as space-separated pairs, e.g. tags, size, path, etc.
 For Kubernetes models, the provider type defaults to "kubernetes"
 unless otherwise specified.
 
+Pools of the "loop" and "tmpfs" providers, which consume the root disk
+and memory of the machines they are created on, accept a "machine-quota"
+attribute limiting the total size of such storage on each machine.
+
 Examples:
 
     juju create-storage-pool ebsrotary ebs volume-type=standard
+    juju create-storage-pool scratch loop machine-quota=20G
     juju create-storage-pool gcepd storage-provisioner=kubernetes.io/gce-pd [storage-mode=RWX|RWO|ROX] parameters.type=pd-standard
 
 See also:
@@ -0,0 +1,110 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// SnapshotCreateAPI defines the API methods that the create storage
+// snapshot command uses.
+type SnapshotCreateAPI interface {
+	Close() error
+	CreateSnapshot(storageId, description string) (params.StorageSnapshot, error)
+}
+
+const snapshotCreateCommandDoc = `
+Take a snapshot of the volume backing a storage instance, using the
+storage provider's snapshot facility (e.g. EBS snapshots).
+
+The snapshot is taken of the volume as it is at the time the command
+is run, so it is only crash-consistent. For an application-consistent
+snapshot, the charm should quiesce its workload and take the snapshot
+itself with the "storage-snapshot" hook tool.
+
+Snapshots may be listed with "juju storage-snapshots", and restored
+as new storage with "juju restore-storage-snapshot".
+
+Examples:
+    juju create-storage-snapshot pgdata/0
+    juju create-storage-snapshot pgdata/0 --description "before upgrade"
+
+See also:
+    storage-snapshots
+    restore-storage-snapshot
+`
+
+// NewSnapshotCreateCommand returns a command that takes a snapshot
+// of a storage instance.
+func NewSnapshotCreateCommand() cmd.Command {
+	cmd := &snapshotCreateCommand{}
+	cmd.newAPIFunc = func() (SnapshotCreateAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// snapshotCreateCommand takes a snapshot of a storage instance.
+type snapshotCreateCommand struct {
+	StorageCommandBase
+	modelcmd.IAASOnlyCommand
+	newAPIFunc  func() (SnapshotCreateAPI, error)
+	storageId   string
+	description string
+}
+
+// Init implements Command.Init.
+func (c *snapshotCreateCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("create-storage-snapshot requires a storage ID")
+	}
+	c.storageId = args[0]
+	if !names.IsValidStorage(c.storageId) {
+		return errors.NotValidf("storage ID %q", c.storageId)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Info implements Command.Info.
+func (c *snapshotCreateCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "create-storage-snapshot",
+		Args:    "<storage ID>",
+		Purpose: "Takes a snapshot of a storage instance.",
+		Doc:     snapshotCreateCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *snapshotCreateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.StringVar(&c.description, "description", "", "A description of the snapshot")
+}
+
+// Run implements Command.Run.
+func (c *snapshotCreateCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	snapshot, err := api.CreateSnapshot(c.storageId, c.description)
+	if err != nil {
+		return block.ProcessBlockedError(
+			errors.Annotatef(err, "cannot create snapshot of storage %s", c.storageId),
+			block.BlockChange,
+		)
+	}
+	ctx.Infof("created snapshot %s of storage %s", snapshot.SnapshotId, c.storageId)
+	return nil
+}
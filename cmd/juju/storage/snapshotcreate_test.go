@@ -0,0 +1,92 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+)
+
+type SnapshotCreateSuite struct {
+	SubStorageSuite
+	mockAPI *mockSnapshotAPI
+}
+
+var _ = gc.Suite(&SnapshotCreateSuite{})
+
+func (s *SnapshotCreateSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockSnapshotAPI{}
+}
+
+func (s *SnapshotCreateSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, storage.NewSnapshotCreateCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *SnapshotCreateSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "create-storage-snapshot requires a storage ID",
+	}, {
+		args: []string{"pgdata"},
+		err:  `storage ID "pgdata" not valid`,
+	}, {
+		args: []string{"pgdata/0", "pgdata/1"},
+		err:  `unrecognized args: \["pgdata/1"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *SnapshotCreateSuite) TestCreate(c *gc.C) {
+	ctx, err := s.run(c, "pgdata/0", "--description", "before upgrade")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "created snapshot snap-0 of storage pgdata/0\n")
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"CreateSnapshot", []interface{}{"pgdata/0", "before upgrade"}},
+		{"Close", nil},
+	})
+}
+
+func (s *SnapshotCreateSuite) TestCreateError(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	_, err := s.run(c, "pgdata/0")
+	c.Assert(err, gc.ErrorMatches, "cannot create snapshot of storage pgdata/0: boom")
+}
+
+type mockSnapshotAPI struct {
+	testing.Stub
+	snapshots []params.StorageSnapshot
+}
+
+func (m *mockSnapshotAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockSnapshotAPI) CreateSnapshot(storageId, description string) (params.StorageSnapshot, error) {
+	m.MethodCall(m, "CreateSnapshot", storageId, description)
+	return params.StorageSnapshot{
+		StorageTag:  "storage-" + storageId,
+		SnapshotId:  "snap-0",
+		Description: description,
+	}, m.NextErr()
+}
+
+func (m *mockSnapshotAPI) ListSnapshots(storageId string) ([]params.StorageSnapshot, error) {
+	m.MethodCall(m, "ListSnapshots", storageId)
+	return m.snapshots, m.NextErr()
+}
@@ -0,0 +1,177 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// SnapshotListAPI defines the API methods that the list storage
+// snapshots command uses.
+type SnapshotListAPI interface {
+	Close() error
+	ListSnapshots(storageId string) ([]params.StorageSnapshot, error)
+}
+
+const snapshotListCommandDoc = `
+List the snapshots of the volume backing a storage instance.
+
+Examples:
+    juju storage-snapshots pgdata/0
+    juju storage-snapshots pgdata/0 --format yaml
+
+See also:
+    create-storage-snapshot
+    restore-storage-snapshot
+`
+
+// NewSnapshotListCommand returns a command that lists the snapshots
+// of a storage instance.
+func NewSnapshotListCommand() cmd.Command {
+	cmd := &snapshotListCommand{}
+	cmd.newAPIFunc = func() (SnapshotListAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// snapshotListCommand lists the snapshots of a storage instance.
+type snapshotListCommand struct {
+	StorageCommandBase
+	modelcmd.IAASOnlyCommand
+	newAPIFunc func() (SnapshotListAPI, error)
+	out        cmd.Output
+	storageId  string
+}
+
+// SnapshotInfo defines the serialization behaviour of a storage snapshot.
+type SnapshotInfo struct {
+	Volume      string     `yaml:"volume" json:"volume"`
+	Size        uint64     `yaml:"size" json:"size"`
+	Status      string     `yaml:"status" json:"status"`
+	Created     *time.Time `yaml:"created,omitempty" json:"created,omitempty"`
+	Description string     `yaml:"description,omitempty" json:"description,omitempty"`
+}
+
+// Init implements Command.Init.
+func (c *snapshotListCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("storage-snapshots requires a storage ID")
+	}
+	c.storageId = args[0]
+	if !names.IsValidStorage(c.storageId) {
+		return errors.NotValidf("storage ID %q", c.storageId)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Info implements Command.Info.
+func (c *snapshotListCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "storage-snapshots",
+		Args:    "<storage ID>",
+		Purpose: "Lists the snapshots of a storage instance.",
+		Doc:     snapshotListCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *snapshotListCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSnapshotListTabular,
+	})
+}
+
+// Run implements Command.Run.
+func (c *snapshotListCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	snapshots, err := api.ListSnapshots(c.storageId)
+	if err != nil {
+		return errors.Annotatef(err, "cannot list snapshots of storage %s", c.storageId)
+	}
+	if len(snapshots) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No snapshots of storage %s to display.", c.storageId)
+		return nil
+	}
+	return c.out.Write(ctx, formatSnapshotInfo(snapshots))
+}
+
+func formatSnapshotInfo(snapshots []params.StorageSnapshot) map[string]SnapshotInfo {
+	result := make(map[string]SnapshotInfo, len(snapshots))
+	for _, snap := range snapshots {
+		result[snap.SnapshotId] = SnapshotInfo{
+			Volume:      snap.VolumeId,
+			Size:        snap.Size,
+			Status:      snap.Status,
+			Created:     snap.Created,
+			Description: snap.Description,
+		}
+	}
+	return result
+}
+
+// formatSnapshotListTabular writes a tabular summary of storage snapshots,
+// ordered by creation time.
+func formatSnapshotListTabular(writer io.Writer, value interface{}) error {
+	snapshots, ok := value.(map[string]SnapshotInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", snapshots, value)
+	}
+	tw := output.TabWriter(writer)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	print("Snapshot", "Volume", "Size", "Status", "Created", "Description")
+	for _, id := range sortedSnapshotIds(snapshots) {
+		snap := snapshots[id]
+		var created string
+		if snap.Created != nil {
+			created = snap.Created.UTC().Format(time.RFC3339)
+		}
+		print(
+			id, snap.Volume,
+			humanize.IBytes(snap.Size*humanize.MiByte),
+			snap.Status, created, snap.Description,
+		)
+	}
+	return tw.Flush()
+}
+
+func sortedSnapshotIds(snapshots map[string]SnapshotInfo) []string {
+	ids := make([]string, 0, len(snapshots))
+	for id := range snapshots {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		ci, cj := snapshots[ids[i]].Created, snapshots[ids[j]].Created
+		if ci != nil && cj != nil && !ci.Equal(*cj) {
+			return ci.Before(*cj)
+		}
+		return ids[i] < ids[j]
+	})
+	return ids
+}
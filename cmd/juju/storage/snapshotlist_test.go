@@ -0,0 +1,103 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+)
+
+type SnapshotListSuite struct {
+	SubStorageSuite
+	mockAPI *mockSnapshotAPI
+}
+
+var _ = gc.Suite(&SnapshotListSuite{})
+
+func (s *SnapshotListSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	older := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	newer := older.Add(24 * time.Hour)
+	s.mockAPI = &mockSnapshotAPI{
+		snapshots: []params.StorageSnapshot{{
+			StorageTag:  "storage-pgdata-0",
+			SnapshotId:  "snap-b",
+			VolumeId:    "vol-0",
+			Size:        10240,
+			Status:      "pending",
+			Created:     &newer,
+			Description: "nightly",
+		}, {
+			StorageTag: "storage-pgdata-0",
+			SnapshotId: "snap-a",
+			VolumeId:   "vol-0",
+			Size:       10240,
+			Status:     "completed",
+			Created:    &older,
+		}},
+	}
+}
+
+func (s *SnapshotListSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, storage.NewSnapshotListCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *SnapshotListSuite) TestInitErrors(c *gc.C) {
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "storage-snapshots requires a storage ID")
+	_, err = s.run(c, "pgdata")
+	c.Assert(err, gc.ErrorMatches, `storage ID "pgdata" not valid`)
+}
+
+func (s *SnapshotListSuite) TestListTabular(c *gc.C) {
+	ctx, err := s.run(c, "pgdata/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Snapshot  Volume  Size    Status     Created               Description
+snap-a    vol-0   10 GiB  completed  2021-03-04T05:06:07Z  
+snap-b    vol-0   10 GiB  pending    2021-03-05T05:06:07Z  nightly
+`[1:])
+	s.mockAPI.CheckCallNames(c, "ListSnapshots", "Close")
+	s.mockAPI.CheckCall(c, 0, "ListSnapshots", "pgdata/0")
+}
+
+func (s *SnapshotListSuite) TestListYAML(c *gc.C) {
+	ctx, err := s.run(c, "pgdata/0", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+snap-a:
+  volume: vol-0
+  size: 10240
+  status: completed
+  created: 2021-03-04T05:06:07Z
+snap-b:
+  volume: vol-0
+  size: 10240
+  status: pending
+  created: 2021-03-05T05:06:07Z
+  description: nightly
+`[1:])
+}
+
+func (s *SnapshotListSuite) TestListNone(c *gc.C) {
+	s.mockAPI.snapshots = nil
+	ctx, err := s.run(c, "pgdata/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No snapshots of storage pgdata/0 to display.\n")
+}
+
+func (s *SnapshotListSuite) TestListError(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	_, err := s.run(c, "pgdata/0")
+	c.Assert(err, gc.ErrorMatches, "cannot list snapshots of storage pgdata/0: boom")
+}
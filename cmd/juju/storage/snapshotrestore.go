@@ -0,0 +1,113 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// SnapshotRestoreAPI defines the API methods that the restore storage
+// snapshot command uses.
+type SnapshotRestoreAPI interface {
+	Close() error
+	RestoreSnapshot(storageId, snapshotId, availabilityZone string) (names.StorageTag, error)
+}
+
+const snapshotRestoreCommandDoc = `
+Restore a snapshot of a storage instance as new storage in the model.
+
+A new volume is created from the snapshot and imported into the model
+as detached storage, with the same storage name as the original. The
+original storage is not affected. The restored storage may then be
+attached to a unit with "juju attach-storage".
+
+By default the volume is created in the same availability zone as the
+volume that the snapshot was taken of. Use --availability-zone to
+create it elsewhere, e.g. to attach it to a unit in another zone.
+
+Only snapshots of filesystem storage may currently be restored.
+
+Examples:
+    juju restore-storage-snapshot pgdata/0 snap-0123456789abcdef0
+    juju restore-storage-snapshot pgdata/0 snap-0123456789abcdef0 --availability-zone us-east-1b
+
+See also:
+    create-storage-snapshot
+    storage-snapshots
+    attach-storage
+`
+
+// NewSnapshotRestoreCommand returns a command that restores a snapshot
+// of a storage instance as new storage.
+func NewSnapshotRestoreCommand() cmd.Command {
+	cmd := &snapshotRestoreCommand{}
+	cmd.newAPIFunc = func() (SnapshotRestoreAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// snapshotRestoreCommand restores a snapshot of a storage instance.
+type snapshotRestoreCommand struct {
+	StorageCommandBase
+	modelcmd.IAASOnlyCommand
+	newAPIFunc       func() (SnapshotRestoreAPI, error)
+	storageId        string
+	snapshotId       string
+	availabilityZone string
+}
+
+// Init implements Command.Init.
+func (c *snapshotRestoreCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.New("restore-storage-snapshot requires a storage ID and a snapshot ID")
+	}
+	c.storageId, c.snapshotId = args[0], args[1]
+	if !names.IsValidStorage(c.storageId) {
+		return errors.NotValidf("storage ID %q", c.storageId)
+	}
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Info implements Command.Info.
+func (c *snapshotRestoreCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "restore-storage-snapshot",
+		Args:    "<storage ID> <snapshot ID>",
+		Purpose: "Restores a snapshot of a storage instance as new storage.",
+		Doc:     snapshotRestoreCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *snapshotRestoreCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.StringVar(&c.availabilityZone, "availability-zone", "", "The availability zone in which to create the restored volume")
+}
+
+// Run implements Command.Run.
+func (c *snapshotRestoreCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	storageTag, err := api.RestoreSnapshot(c.storageId, c.snapshotId, c.availabilityZone)
+	if err != nil {
+		return block.ProcessBlockedError(
+			errors.Annotatef(err, "cannot restore snapshot %s of storage %s", c.snapshotId, c.storageId),
+			block.BlockChange,
+		)
+	}
+	ctx.Infof("restored snapshot %s as storage %s", c.snapshotId, storageTag.Id())
+	return nil
+}
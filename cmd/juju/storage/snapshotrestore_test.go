@@ -0,0 +1,69 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/storage"
+)
+
+type SnapshotRestoreSuite struct {
+	SubStorageSuite
+	mockAPI *mockSnapshotRestoreAPI
+}
+
+var _ = gc.Suite(&SnapshotRestoreSuite{})
+
+func (s *SnapshotRestoreSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockSnapshotRestoreAPI{}
+}
+
+func (s *SnapshotRestoreSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, storage.NewSnapshotRestoreCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *SnapshotRestoreSuite) TestInitErrors(c *gc.C) {
+	_, err := s.run(c, "pgdata/0")
+	c.Assert(err, gc.ErrorMatches, "restore-storage-snapshot requires a storage ID and a snapshot ID")
+	_, err = s.run(c, "pgdata", "snap-0")
+	c.Assert(err, gc.ErrorMatches, `storage ID "pgdata" not valid`)
+}
+
+func (s *SnapshotRestoreSuite) TestRestore(c *gc.C) {
+	ctx, err := s.run(c, "pgdata/0", "snap-0", "--availability-zone", "zone-b")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "restored snapshot snap-0 as storage pgdata/1\n")
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"RestoreSnapshot", []interface{}{"pgdata/0", "snap-0", "zone-b"}},
+		{"Close", nil},
+	})
+}
+
+func (s *SnapshotRestoreSuite) TestRestoreError(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	_, err := s.run(c, "pgdata/0", "snap-0")
+	c.Assert(err, gc.ErrorMatches, "cannot restore snapshot snap-0 of storage pgdata/0: boom")
+}
+
+type mockSnapshotRestoreAPI struct {
+	testing.Stub
+}
+
+func (m *mockSnapshotRestoreAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockSnapshotRestoreAPI) RestoreSnapshot(storageId, snapshotId, zone string) (names.StorageTag, error) {
+	m.MethodCall(m, "RestoreSnapshot", storageId, snapshotId, zone)
+	return names.NewStorageTag("pgdata/1"), m.NextErr()
+}
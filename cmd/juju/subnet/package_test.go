@@ -120,6 +120,7 @@ type StubAPI struct {
 	Subnets []params.Subnet
 	Spaces  []names.Tag
 	Zones   []string
+	Missing []params.MissingSubnet
 }
 
 var _ subnet.SubnetAPI = (*StubAPI)(nil)
@@ -203,3 +204,11 @@ func (sa *StubAPI) ListSubnets(withSpace *names.SpaceTag, withZone string) ([]pa
 	}
 	return sa.Subnets, nil
 }
+
+func (sa *StubAPI) ReloadSubnets() ([]params.MissingSubnet, error) {
+	sa.MethodCall(sa, "ReloadSubnets")
+	if err := sa.NextErr(); err != nil {
+		return nil, err
+	}
+	return sa.Missing, nil
+}
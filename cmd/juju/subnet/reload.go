@@ -0,0 +1,91 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package subnet
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewReloadCommand returns a command used to reload subnets from the
+// provider.
+func NewReloadCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&ReloadCommand{})
+}
+
+// ReloadCommand calls the API to refresh the subnets and availability
+// zones known to Juju from the provider.
+type ReloadCommand struct {
+	SubnetCommandBase
+}
+
+const reloadCommandDoc = `
+Re-queries the provider for its subnets and availability zones. Subnets not
+yet known to Juju are added to the model and those already known are
+updated.
+
+Subnets that the provider no longer reports are not removed. Each is listed
+instead, along with any machines with addresses in it and any applications
+with endpoints bound to its space when no other subnet in that space remains.
+
+See also:
+    reload-spaces
+    subnets
+`
+
+// Info is defined on the cmd.Command interface.
+func (c *ReloadCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "reload-subnets",
+		Purpose: "Reloads subnets and availability zones from the provider.",
+		Doc:     strings.TrimSpace(reloadCommandDoc),
+	})
+}
+
+// Init is defined on the cmd.Command interface.
+func (c *ReloadCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *ReloadCommand) Run(ctx *cmd.Context) error {
+	return c.RunWithAPI(ctx, func(api SubnetAPI, ctx *cmd.Context) error {
+		missing, err := api.ReloadSubnets()
+		if err != nil {
+			if errors.IsNotSupported(err) {
+				ctx.Infof("cannot reload subnets: %v", err)
+			}
+			return block.ProcessBlockedError(errors.Annotate(err, "could not reload subnets"), block.BlockChange)
+		}
+
+		for _, subnet := range missing {
+			var space string
+			if tag, err := names.ParseSpaceTag(subnet.SpaceTag); err == nil {
+				space = tag.Id()
+			}
+			var users []string
+			for _, entity := range append(subnet.Machines, subnet.Bindings...) {
+				tag, err := names.ParseTag(entity.Tag)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				users = append(users, names.ReadableString(tag))
+			}
+			if len(users) == 0 {
+				ctx.Infof("subnet %q in space %q is no longer known to the provider", subnet.CIDR, space)
+				continue
+			}
+			ctx.Infof("WARNING: subnet %q in space %q is no longer known to the provider but is still used by: %s",
+				subnet.CIDR, space, strings.Join(users, ", "))
+		}
+		return nil
+	})
+}
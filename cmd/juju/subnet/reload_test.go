@@ -0,0 +1,65 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package subnet_test
+
+import (
+	"github.com/juju/errors"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/subnet"
+)
+
+type ReloadSuite struct {
+	BaseSubnetSuite
+}
+
+var _ = gc.Suite(&ReloadSuite{})
+
+func (s *ReloadSuite) SetUpTest(c *gc.C) {
+	s.BaseSubnetSuite.SetUpTest(c)
+	s.newCommand = subnet.NewReloadCommand
+}
+
+func (s *ReloadSuite) TestInitWithArgsFails(c *gc.C) {
+	_, err := s.InitCommand(c, "foo")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *ReloadSuite) TestRunNothingMissing(c *gc.C) {
+	s.AssertRunSucceeds(c, "", "")
+
+	s.api.CheckCallNames(c, "ReloadSubnets", "Close")
+}
+
+func (s *ReloadSuite) TestRunReportsMissingSubnets(c *gc.C) {
+	s.api.Missing = []params.MissingSubnet{{
+		CIDR:     "10.0.0.0/24",
+		SpaceTag: "space-db",
+		Machines: []params.Entity{{Tag: "machine-0"}, {Tag: "machine-1-lxd-2"}},
+		Bindings: []params.Entity{{Tag: "application-mysql"}},
+	}, {
+		CIDR:     "10.1.0.0/24",
+		SpaceTag: "space-alpha",
+	}}
+
+	s.AssertRunSucceeds(c,
+		`WARNING: subnet "10.0.0.0/24" in space "db" is no longer known to the provider `+
+			`but is still used by: machine 0, machine 1/lxd/2, application mysql\n`+
+			`subnet "10.1.0.0/24" in space "alpha" is no longer known to the provider\n`,
+		"",
+	)
+
+	s.api.CheckCallNames(c, "ReloadSubnets", "Close")
+}
+
+func (s *ReloadSuite) TestRunNotSupported(c *gc.C) {
+	s.api.SetErrors(errors.NotSupportedf("reloading subnets"))
+
+	_, stderr, err := s.RunCommand(c)
+	c.Assert(err, gc.ErrorMatches, "could not reload subnets: reloading subnets not supported")
+	c.Assert(stderr, gc.Equals, "cannot reload subnets: reloading subnets not supported\n")
+
+	s.api.CheckCallNames(c, "ReloadSubnets", "Close")
+}
@@ -36,6 +36,10 @@ type SubnetAPI interface {
 
 	// AllSpaces returns all Juju network spaces.
 	AllSpaces() ([]names.Tag, error)
+
+	// ReloadSubnets refreshes the subnets known to Juju from the
+	// provider, returning those the provider no longer reports.
+	ReloadSubnets() ([]params.MissingSubnet, error)
 }
 
 // mvpAPIShim forwards SubnetAPI methods to the real API facade for
@@ -59,6 +63,10 @@ func (m *mvpAPIShim) ListSubnets(withSpace *names.SpaceTag, withZone string) ([]
 	return m.facade.ListSubnets(withSpace, withZone)
 }
 
+func (m *mvpAPIShim) ReloadSubnets() ([]params.MissingSubnet, error) {
+	return m.facade.ReloadSubnets()
+}
+
 var logger = loggo.GetLogger("juju.cmd.juju.subnet")
 
 // SubnetCommandBase is the base type embedded into all subnet
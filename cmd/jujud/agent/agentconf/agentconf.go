@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/cmd/jujud/agent/config"
 	agenterrors "github.com/juju/juju/cmd/jujud/agent/errors"
+	"github.com/juju/juju/core/logsink"
 	"github.com/juju/juju/state/mgo"
 )
 
@@ -129,8 +130,13 @@ func SetupAgentLogging(context *loggo.Context, config agent.Config) {
 		logger.Infof("setting logging config to %q", loggingConfig)
 		// There should only be valid logging configuration strings saved
 		// in the logging config section in the agent.conf file.
+		// The log sink levels are set by the logger worker, once the
+		// agent has set up its sinks.
 		context.ResetLoggerLevels()
-		err := context.ConfigureLoggers(loggingConfig)
+		sinkConfig, err := logsink.ParseConfig(loggingConfig)
+		if err == nil {
+			err = context.ConfigureLoggers(sinkConfig.Modules)
+		}
 		if err != nil {
 			logger.Errorf("problem setting logging config %v", err)
 		}
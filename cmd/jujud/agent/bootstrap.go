@@ -59,10 +59,11 @@ import (
 )
 
 var (
-	initiateMongoServer  = peergrouper.InitiateMongoServer
-	agentInitializeState = agentbootstrap.InitializeState
-	sshGenerateKey       = ssh.GenerateKey
-	minSocketTimeout     = 1 * time.Minute
+	initiateMongoServer     = peergrouper.InitiateMongoServer
+	checkExternalReplicaSet = mongo.CheckExternalReplicaSet
+	agentInitializeState    = agentbootstrap.InitializeState
+	sshGenerateKey          = ssh.GenerateKey
+	minSocketTimeout        = 1 * time.Minute
 )
 
 const adminUserName = "admin"
@@ -230,6 +231,7 @@ func (c *BootstrapCommand) Run(_ *cmd.Context) error {
 				Arch:   arch.HostArch(),
 				Series: hostSeries,
 			}
+			envtools.SetAgentMetadataPublicKey(args.ControllerConfig.AgentMetadataPublicKey())
 			_, toolsErr := envtools.FindTools(env, -1, -1, streams, filter)
 			if toolsErr == nil {
 				logger.Infof("agent binaries are available, upgrade will occur after bootstrap")
@@ -317,8 +319,14 @@ func (c *BootstrapCommand) Run(_ *cmd.Context) error {
 			dialOpts.SocketTimeout = minSocketTimeout
 		}
 
-		// We shouldn't attempt to dial peers until we have some.
-		dialOpts.Direct = true
+		// We shouldn't attempt to dial peers until we have some,
+		// unless the replica set is externally managed and so
+		// already has them.
+		externalMongo, err := agent.ExternalMongo(agentConfig)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		dialOpts.Direct = externalMongo == nil
 
 		adminTag := names.NewLocalUserTag(adminUserName)
 		controller, stateErr = agentInitializeState(
@@ -575,6 +583,21 @@ func (c *BootstrapCommand) startMongo(isCAAS bool, addrs network.ProviderAddress
 	// to start as there's no retry loop in place.
 	// 5 minutes should suffice.
 	mongoDialOpts := mongo.DialOpts{Timeout: 5 * time.Minute}
+
+	// An externally managed replica set is already running, and is
+	// not ours to initiate; we only check that it is usable.
+	rs, err := agent.ExternalMongo(agentConfig)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if rs != nil {
+		logger.Infof("using external mongo replica set %q at %v", rs.ReplicaSet, rs.Addrs)
+		return errors.Annotate(
+			checkExternalReplicaSet(rs, agentConfig.Value(agent.ExternalMongoCACert), mongoDialOpts),
+			"checking external mongo replica set",
+		)
+	}
+
 	dialInfo, err := mongo.DialInfo(info.Info, mongoDialOpts)
 	if err != nil {
 		return err
@@ -36,6 +36,7 @@ var (
 		"application-scaler",     // tertiary dependency: will be inactive because migration workers will be inactive
 		"charm-revision-updater", // tertiary dependency: will be inactive because migration workers will be inactive
 		"compute-provisioner",
+		"credential-expiry", // tertiary dependency: will be inactive because migration workers will be inactive
 		"environ-tracker",
 		"firewaller",
 		"instance-mutater",
@@ -46,6 +47,7 @@ var (
 		"migration-fortress",      // secondary dependency: will be inactive because depends on model-upgrader
 		"migration-inactive-flag", // secondary dependency: will be inactive because depends on model-upgrader
 		"migration-master",        // secondary dependency: will be inactive because depends on model-upgrader
+		"model-reconciler",        // tertiary dependency: will be inactive because migration workers will be inactive
 		"model-upgrader",
 		"remote-relations",      // tertiary dependency: will be inactive because migration workers will be inactive
 		"state-cleaner",         // tertiary dependency: will be inactive because migration workers will be inactive
@@ -59,6 +61,7 @@ var (
 		"application-scaler",
 		"charm-revision-updater",
 		"compute-provisioner",
+		"credential-expiry",
 		"environ-tracker",
 		"firewaller",
 		"instance-mutater",
@@ -70,6 +73,7 @@ var (
 		"migration-fortress",
 		"migration-inactive-flag",
 		"migration-master",
+		"model-reconciler",
 		"remote-relations",
 		"state-cleaner",
 		"status-history-pruner",
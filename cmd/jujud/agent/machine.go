@@ -57,6 +57,7 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/logsink"
 	"github.com/juju/juju/core/machinelock"
 	coremodel "github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/paths"
@@ -464,6 +465,12 @@ func (a *MachineAgent) Run(ctx *cmd.Context) (err error) {
 
 	agentconf.SetupAgentLogging(loggo.DefaultContext(), a.CurrentConfig())
 
+	// Make sure the key used to encrypt the secrets in the agent configs
+	// on this machine exists; they're encrypted when next written.
+	if err := agent.EnsureConfigKey(a.CurrentConfig().DataDir()); err != nil {
+		return errors.Annotate(err, "creating agent config key")
+	}
+
 	if err := introspection.WriteProfileFunctions(introspection.ProfileDir); err != nil {
 		// This isn't fatal, just annoying.
 		logger.Errorf("failed to write profile funcs: %v", err)
@@ -487,6 +494,9 @@ func (a *MachineAgent) Run(ctx *cmd.Context) (err error) {
 	}
 
 	agentConfig := a.CurrentConfig()
+	if err := a.bufferedLogger.EnableSpool(agent.LogSpoolFilename(agentConfig), logsender.DefaultSpoolSize); err != nil {
+		logger.Warningf("cannot spool logs to disk: %v", err)
+	}
 	agentName := a.Tag().String()
 	machineLock, err := machinelock.New(machinelock.Config{
 		AgentName:   agentName,
@@ -589,6 +599,7 @@ func (a *MachineAgent) makeEngineCreator(
 			ControllerLeaseDuration:           time.Minute,
 			LogPruneInterval:                  5 * time.Minute,
 			TransactionPruneInterval:          time.Hour,
+			OrphanFinderInterval:              6 * time.Hour,
 			MachineLock:                       a.machineLock,
 			SetStatePool:                      statePoolReporter.Set,
 			RegisterIntrospectionHTTPHandlers: registerIntrospectionHandlers,
@@ -600,6 +611,8 @@ func (a *MachineAgent) makeEngineCreator(
 			UnitEngineConfig:                  engineConfigFunc,
 			SetupLogging:                      agentconf.SetupAgentLogging,
 			LeaseFSM:                          raftlease.NewFSM(),
+			IntrospectionSocketName:           a.newIntrospectionSocketName,
+			DependencyEngine:                  engine,
 		}
 		manifolds := iaasMachineManifolds(manifoldsCfg)
 		if a.isCaasAgent {
@@ -1077,29 +1090,41 @@ func (a *MachineAgent) startModelWorkers(cfg modelworkermanager.NewModelConfig)
 			Compress:   true,
 		}
 	}
-	if err := loggingContext.AddWriter(
-		"file", loggo.NewSimpleWriter(writer, loggo.DefaultFormatter)); err != nil {
+	// Each writer is a log sink, so that the model's logging config can
+	// give it its own level.
+	loggingSinks := logsink.Sinks{
+		logsink.File:       logsink.NewWriter(loggo.NewSimpleWriter(writer, loggo.DefaultFormatter), loggo.UNSPECIFIED),
+		logsink.Controller: logsink.NewWriter(cfg.ModelLogger, loggo.UNSPECIFIED),
+		logsink.Syslog:     logsink.NewWriter(logsink.NewSyslogWriter("juju-model-"+cfg.ModelUUID[:6]), logsink.Disabled),
+	}
+	if err := loggingContext.AddWriter("file", loggingSinks[logsink.File]); err != nil {
 		logger.Errorf("unable to configure file logging for model: %v", err)
 	}
 	// Use a standard state logger for the right model.
-	if err := loggingContext.AddWriter("db", cfg.ModelLogger); err != nil {
+	if err := loggingContext.AddWriter("db", loggingSinks[logsink.Controller]); err != nil {
 		logger.Errorf("unable to configure db logging for model: %v", err)
 	}
+	if err := loggingContext.AddWriter("syslog", loggingSinks[logsink.Syslog]); err != nil {
+		logger.Errorf("unable to configure syslog logging for model: %v", err)
+	}
 
 	manifoldsCfg := model.ManifoldsConfig{
-		Agent:                       modelAgent,
-		AgentConfigChanged:          a.configChangedVal,
-		Authority:                   cfg.Authority,
-		Clock:                       clock.WallClock,
-		LoggingContext:              loggingContext,
-		RunFlagDuration:             time.Minute,
-		CharmRevisionUpdateInterval: 24 * time.Hour,
-		StatusHistoryPrunerInterval: 5 * time.Minute,
-		ActionPrunerInterval:        24 * time.Hour,
-		Mux:                         cfg.Mux,
-		NewEnvironFunc:              newEnvirons,
-		NewContainerBrokerFunc:      newCAASBroker,
-		NewMigrationMaster:          migrationmaster.NewWorker,
+		Agent:                         modelAgent,
+		AgentConfigChanged:            a.configChangedVal,
+		Authority:                     cfg.Authority,
+		Clock:                         clock.WallClock,
+		LoggingContext:                loggingContext,
+		LoggingSinks:                  loggingSinks,
+		RunFlagDuration:               time.Minute,
+		CharmRevisionUpdateInterval:   24 * time.Hour,
+		CredentialExpiryCheckInterval: time.Hour,
+		StatusHistoryPrunerInterval:   5 * time.Minute,
+		ActionPrunerInterval:          24 * time.Hour,
+		FirewallReconcileInterval:     10 * time.Minute,
+		Mux:                           cfg.Mux,
+		NewEnvironFunc:                newEnvirons,
+		NewContainerBrokerFunc:        newCAASBroker,
+		NewMigrationMaster:            migrationmaster.NewWorker,
 	}
 	if wrench.IsActive("charmrevision", "shortinterval") {
 		interval := 10 * time.Second
@@ -1182,6 +1207,14 @@ func (a *MachineAgent) ensureMongoServer(agentConfig agent.Config) (err error) {
 	if a.isCaasAgent {
 		return nil
 	}
+	// Controllers using an externally managed replica set have no
+	// co-located mongod to install.
+	if rs, err := agent.ExternalMongo(agentConfig); err != nil {
+		return errors.Trace(err)
+	} else if rs != nil {
+		logger.Debugf("using external mongo replica set %q", rs.ReplicaSet)
+		return nil
+	}
 	// EnsureMongoServer installs/upgrades the init config as necessary.
 	ensureServerParams, err := cmdutil.NewEnsureServerParams(agentConfig)
 	if err != nil {
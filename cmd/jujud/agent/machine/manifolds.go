@@ -11,6 +11,7 @@ import (
 	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/names/v4"
 	"github.com/juju/proxy"
 	"github.com/juju/pubsub"
 	"github.com/juju/utils/v2/voyeur"
@@ -48,10 +49,12 @@ import (
 	"github.com/juju/juju/worker/certupdater"
 	"github.com/juju/juju/worker/common"
 	lxdbroker "github.com/juju/juju/worker/containerbroker"
+	"github.com/juju/juju/worker/controllercustomizer"
 	"github.com/juju/juju/worker/controllerport"
 	"github.com/juju/juju/worker/credentialvalidator"
 	"github.com/juju/juju/worker/deployer"
 	"github.com/juju/juju/worker/diskmanager"
+	"github.com/juju/juju/worker/enginehealthreporter"
 	"github.com/juju/juju/worker/externalcontrollerupdater"
 	"github.com/juju/juju/worker/fanconfigurer"
 	"github.com/juju/juju/worker/fortress"
@@ -62,9 +65,12 @@ import (
 	"github.com/juju/juju/worker/httpserverargs"
 	"github.com/juju/juju/worker/identityfilewriter"
 	"github.com/juju/juju/worker/instancemutater"
+	"github.com/juju/juju/worker/introspectionresponder"
 	leasemanager "github.com/juju/juju/worker/lease/manifold"
+	"github.com/juju/juju/worker/lifecyclewebhook"
 	"github.com/juju/juju/worker/logger"
 	"github.com/juju/juju/worker/logsender"
+	"github.com/juju/juju/worker/logsresizer"
 	"github.com/juju/juju/worker/machineactions"
 	"github.com/juju/juju/worker/machiner"
 	"github.com/juju/juju/worker/migrationflag"
@@ -72,6 +78,7 @@ import (
 	"github.com/juju/juju/worker/modelcache"
 	"github.com/juju/juju/worker/modelworkermanager"
 	"github.com/juju/juju/worker/multiwatcher"
+	"github.com/juju/juju/worker/orphanfinder"
 	"github.com/juju/juju/worker/peergrouper"
 	prworker "github.com/juju/juju/worker/presence"
 	"github.com/juju/juju/worker/proxyupdater"
@@ -84,6 +91,7 @@ import (
 	"github.com/juju/juju/worker/raft/rafttransport"
 	"github.com/juju/juju/worker/reboot"
 	"github.com/juju/juju/worker/resumer"
+	"github.com/juju/juju/worker/sandboxprofiler"
 	"github.com/juju/juju/worker/singular"
 	workerstate "github.com/juju/juju/worker/state"
 	"github.com/juju/juju/worker/stateconfigwatcher"
@@ -224,6 +232,10 @@ type ManifoldsConfig struct {
 	// are pruned from the database.
 	TransactionPruneInterval time.Duration
 
+	// OrphanFinderInterval defines how frequently the database is
+	// checked for orphaned documents.
+	OrphanFinderInterval time.Duration
+
 	// SetStatePool is used by the state worker for informing the agent of
 	// the StatePool that it creates, so we can pass it to the introspection
 	// worker running outside of the dependency engine.
@@ -269,6 +281,15 @@ type ManifoldsConfig struct {
 	// LeaseFSM represents the internal finite state machine for lease
 	// management.
 	LeaseFSM *raftlease.FSM
+
+	// IntrospectionSocketName returns the name of the abstract domain
+	// socket the agent's introspection worker listens on, so that the
+	// introspection responder can answer requests made through the API.
+	IntrospectionSocketName func(names.Tag) string
+
+	// DependencyEngine is the engine running the machine agent's
+	// manifolds, whose health is reported to the controller.
+	DependencyEngine enginehealthreporter.Reporter
 }
 
 // commonManifolds returns a set of co-configured manifolds covering the
@@ -638,10 +659,42 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 
 		txnPrunerName: ifNotMigrating(ifPrimaryController(txnpruner.Manifold(
 			txnpruner.ManifoldConfig{
-				ClockName:     clockName,
-				StateName:     stateName,
-				PruneInterval: config.TransactionPruneInterval,
-				NewWorker:     txnpruner.New,
+				ClockName:            clockName,
+				StateName:            stateName,
+				PruneInterval:        config.TransactionPruneInterval,
+				NewWorker:            txnpruner.New,
+				PrometheusRegisterer: config.PrometheusRegisterer,
+			},
+		))),
+
+		orphanFinderName: ifNotMigrating(ifPrimaryController(orphanfinder.Manifold(
+			orphanfinder.ManifoldConfig{
+				ClockName: clockName,
+				StateName: stateName,
+				Interval:  config.OrphanFinderInterval,
+				NewWorker: orphanfinder.New,
+			},
+		))),
+
+		// The logs resizer manifold resizes the models' capped logs
+		// collections when the controller's model-logs-size changes.
+		logsResizerName: ifNotMigrating(ifPrimaryController(logsresizer.Manifold(
+			logsresizer.ManifoldConfig{
+				StateName: stateName,
+				NewWorker: logsresizer.NewWorkerShim,
+			},
+		))),
+
+		// The lifecycle webhook manifold posts machine, unit and model
+		// lifecycle events from all models to the controller's
+		// lifecycle webhook, if one is configured.
+		lifecycleWebhookName: ifNotMigrating(ifPrimaryController(lifecyclewebhook.Manifold(
+			lifecyclewebhook.ManifoldConfig{
+				ClockName:        clockName,
+				StateName:        stateName,
+				MultiwatcherName: multiwatcherName,
+				RetryDelay:       5 * time.Second,
+				NewWorker:        lifecyclewebhook.NewWorkerShim,
 			},
 		))),
 
@@ -906,6 +959,44 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewWorker:     machineactions.NewMachineActionsWorker,
 		})),
 
+		// The introspection responder answers requests, made through
+		// the API, to query the agent's introspection socket. This
+		// lets operators debug an agent without SSH access.
+		introspectionResponderName: ifNotMigrating(introspectionresponder.Manifold(introspectionresponder.ManifoldConfig{
+			AgentName:               agentName,
+			APICallerName:           apiCallerName,
+			IntrospectionSocketName: config.IntrospectionSocketName,
+			NewFacade:               introspectionresponder.NewFacade,
+			NewWorker:               introspectionresponder.NewWorker,
+		})),
+
+		// The sandbox profiler sets the sysctls and loads the AppArmor
+		// profiles declared by the charms of the units on the machine,
+		// as allowed by the controller when they were deployed.
+		sandboxProfilerName: ifNotMigrating(sandboxprofiler.Manifold(sandboxprofiler.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			SysctlDir:     "/etc/sysctl.d",
+			AppArmorDir:   "/etc/apparmor.d",
+			RunCommand:    sandboxprofiler.RunCommand,
+			NewFacade:     sandboxprofiler.NewFacade,
+			NewWorker:     sandboxprofiler.NewWorker,
+		})),
+
+		// The engine health reporter summarises the workers in this
+		// engine which are failing or bouncing, so that they show up
+		// in machine status.
+		engineHealthReporterName: ifNotMigrating(enginehealthreporter.Manifold(enginehealthreporter.ManifoldConfig{
+			AgentName:       agentName,
+			APICallerName:   apiCallerName,
+			Engine:          config.DependencyEngine,
+			Clock:           config.Clock,
+			Period:          time.Minute,
+			BounceThreshold: 3,
+			NewFacade:       enginehealthreporter.NewFacade,
+			NewWorker:       enginehealthreporter.NewWorker,
+		})),
+
 		// The upgrader is a leaf worker that returns a specific error
 		// type recognised by the machine agent, causing other workers
 		// to be stopped and the agent to be restarted running the new
@@ -982,6 +1073,19 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewClient:     instancemutater.NewClient,
 			NewWorker:     instancemutater.NewContainerWorker,
 		})),
+
+		// The controller customizer runs the controller's
+		// controller-customization-script on each controller machine,
+		// and records the outcome against the controller node.
+		controllerCustomizerName: ifNotMigrating(ifController(controllercustomizer.Manifold(
+			controllercustomizer.ManifoldConfig{
+				AgentName: agentName,
+				ClockName: clockName,
+				StateName: stateName,
+				RunScript: controllercustomizer.RunScript,
+				NewWorker: controllercustomizer.NewWorkerShim,
+			},
+		))),
 	}
 
 	return mergeManifolds(config, manifolds)
@@ -1113,6 +1217,9 @@ const (
 	identityFileWriterName        = "ssh-identity-writer"
 	toolsVersionCheckerName       = "tools-version-checker"
 	machineActionName             = "machine-action-runner"
+	introspectionResponderName    = "introspection-responder"
+	sandboxProfilerName           = "sandbox-profiler"
+	engineHealthReporterName      = "engine-health-reporter"
 	hostKeyReporterName           = "host-key-reporter"
 	fanConfigurerName             = "fan-configurer"
 	externalControllerUpdaterName = "external-controller-updater"
@@ -1121,6 +1228,10 @@ const (
 	isControllerFlagName          = "is-controller-flag"
 	instanceMutaterName           = "instance-mutater"
 	txnPrunerName                 = "transaction-pruner"
+	orphanFinderName              = "orphan-finder"
+	lifecycleWebhookName          = "lifecycle-webhook"
+	logsResizerName               = "logs-resizer"
+	controllerCustomizerName      = "controller-customizer"
 	certificateWatcherName        = "certificate-watcher"
 	modelCacheName                = "model-cache"
 	modelCacheInitializedFlagName = "model-cache-initialized-flag"
@@ -70,21 +70,26 @@ func (ms *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 			"certificate-updater",
 			"certificate-watcher",
 			"clock",
+			"controller-customizer",
 			"controller-port",
 			"deployer",
 			"disk-manager",
+			"engine-health-reporter",
 			"external-controller-updater",
 			"fan-configurer",
 			"host-key-reporter",
 			"http-server",
 			"http-server-args",
 			"instance-mutater",
+			"introspection-responder",
 			"is-controller-flag",
 			"is-primary-controller-flag",
 			"lease-clock-updater",
 			"lease-manager",
+			"lifecycle-webhook",
 			"log-sender",
 			"logging-config-updater",
+			"logs-resizer",
 			"machine-action-runner",
 			"machiner",
 			"mgo-txn-resumer",
@@ -96,6 +101,7 @@ func (ms *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 			"model-cache-initialized-gate",
 			"model-worker-manager",
 			"multiwatcher",
+			"orphan-finder",
 			"peer-grouper",
 			"presence",
 			"proxy-config-updater",
@@ -107,6 +113,7 @@ func (ms *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 			"raft-leader-flag",
 			"raft-transport",
 			"reboot-executor",
+			"sandbox-profiler",
 			"ssh-authkeys-updater",
 			"ssh-identity-writer",
 			"state",
@@ -154,8 +161,10 @@ func (ms *ManifoldsSuite) TestManifoldNamesCAAS(c *gc.C) {
 			"is-primary-controller-flag",
 			"lease-clock-updater",
 			"lease-manager",
+			"lifecycle-webhook",
 			"log-sender",
 			"logging-config-updater",
+			"logs-resizer",
 			"mgo-txn-resumer",
 			"migration-fortress",
 			"migration-minion",
@@ -165,6 +174,7 @@ func (ms *ManifoldsSuite) TestManifoldNamesCAAS(c *gc.C) {
 			"model-cache-initialized-gate",
 			"model-worker-manager",
 			"multiwatcher",
+			"orphan-finder",
 			"peer-grouper",
 			"presence",
 			"proxy-config-updater",
@@ -289,6 +299,7 @@ func (*ManifoldsSuite) TestSingularGuardsUsed(c *gc.C) {
 	controllerWorkers := set.NewStrings(
 		"certificate-watcher",
 		"audit-config-updater",
+		"controller-customizer",
 		"is-primary-controller-flag",
 		"model-cache",
 		"model-cache-initialized-flag",
@@ -303,6 +314,9 @@ func (*ManifoldsSuite) TestSingularGuardsUsed(c *gc.C) {
 	)
 	primaryControllerWorkers := set.NewStrings(
 		"external-controller-updater",
+		"lifecycle-webhook",
+		"logs-resizer",
+		"orphan-finder",
 		"transaction-pruner",
 	)
 	for name, manifold := range manifolds {
@@ -528,6 +542,18 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"upgrade-steps-gate",
 	},
 
+	"engine-health-reporter": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"external-controller-updater": {
 		"agent",
 		"api-caller",
@@ -613,6 +639,18 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"upgrade-steps-gate",
 	},
 
+	"introspection-responder": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"is-controller-flag": {"agent", "state", "state-config-watcher"},
 
 	"is-primary-controller-flag": {
@@ -653,6 +691,26 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"state-config-watcher",
 	},
 
+	"lifecycle-webhook": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"clock",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"multiwatcher",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-database-flag",
+		"upgrade-database-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"log-sender": {
 		"agent",
 		"api-caller",
@@ -781,6 +839,22 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"upgrade-steps-gate",
 	},
 
+	"controller-customizer": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"clock",
+		"is-controller-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"multiwatcher": {
 		"agent",
 		"is-controller-flag",
@@ -790,6 +864,39 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"upgrade-database-gate",
 	},
 
+	"logs-resizer": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
+	"orphan-finder": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"clock",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"peer-grouper": {
 		"agent",
 		"central-hub",
@@ -932,6 +1039,18 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"upgrade-steps-gate",
 	},
 
+	"sandbox-profiler": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"ssh-authkeys-updater": {
 		"agent",
 		"api-caller",
@@ -22,6 +22,7 @@ import (
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/cmd/jujud/agent/engine"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/logsink"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/pki"
 	"github.com/juju/juju/worker/actionpruner"
@@ -40,6 +41,7 @@ import (
 	"github.com/juju/juju/worker/charmrevision"
 	"github.com/juju/juju/worker/cleaner"
 	"github.com/juju/juju/worker/common"
+	"github.com/juju/juju/worker/credentialexpiry"
 	"github.com/juju/juju/worker/credentialvalidator"
 	"github.com/juju/juju/worker/environ"
 	"github.com/juju/juju/worker/firewaller"
@@ -55,10 +57,12 @@ import (
 	"github.com/juju/juju/worker/metricworker"
 	"github.com/juju/juju/worker/migrationflag"
 	"github.com/juju/juju/worker/migrationmaster"
+	"github.com/juju/juju/worker/modelreconciler"
 	"github.com/juju/juju/worker/modelupgrader"
 	"github.com/juju/juju/worker/provisioner"
 	"github.com/juju/juju/worker/pruner"
 	"github.com/juju/juju/worker/remoterelations"
+	"github.com/juju/juju/worker/scopedcredentials"
 	"github.com/juju/juju/worker/singular"
 	"github.com/juju/juju/worker/statushistorypruner"
 	"github.com/juju/juju/worker/storageprovisioner"
@@ -93,6 +97,10 @@ type ManifoldsConfig struct {
 	// written into the model's logging collection rather than the controller's.
 	LoggingContext *loggo.Context
 
+	// LoggingSinks holds the model's log sinks, whose levels are set
+	// from the model's logging config.
+	LoggingSinks logsink.Sinks
+
 	// HTTP server mux for registering caas admission controllers
 	Mux *apiserverhttp.Mux
 
@@ -106,6 +114,11 @@ type ManifoldsConfig struct {
 	// revision worker will check for new revisions of known charms.
 	CharmRevisionUpdateInterval time.Duration
 
+	// CredentialExpiryCheckInterval determines how often the
+	// credential-expiry worker checks whether the model's cloud
+	// credential is about to expire.
+	CredentialExpiryCheckInterval time.Duration
+
 	// StatusHistoryPruner* values control status-history pruning
 	// behaviour.
 	StatusHistoryPrunerInterval time.Duration
@@ -114,6 +127,10 @@ type ManifoldsConfig struct {
 	// worker is run.
 	ActionPrunerInterval time.Duration
 
+	// FirewallReconcileInterval controls how often the firewaller
+	// removes provider rules that are no longer backed by opened ports.
+	FirewallReconcileInterval time.Duration
+
 	// NewEnvironFunc is a function opens a provider "environment"
 	// (typically environs.New).
 	NewEnvironFunc environs.NewEnvironFunc
@@ -159,6 +176,7 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			AgentName:      agentName,
 			APICallerName:  apiCallerName,
 			LoggingContext: config.LoggingContext,
+			Sinks:          config.LoggingSinks,
 			Logger:         config.LoggingContext.GetLogger("juju.worker.logger"),
 		})),
 
@@ -270,6 +288,15 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewWorker: charmrevision.NewWorker,
 			Logger:    config.LoggingContext.GetLogger("juju.worker.charmrevision"),
 		})),
+		credentialExpiryName: ifNotMigrating(ifCredentialValid(credentialexpiry.Manifold(credentialexpiry.ManifoldConfig{
+			APICallerName: apiCallerName,
+			Clock:         config.Clock,
+			Period:        config.CredentialExpiryCheckInterval,
+
+			NewFacade: credentialexpiry.NewAPIFacade,
+			NewWorker: credentialexpiry.NewWorker,
+			Logger:    config.LoggingContext.GetLogger("juju.worker.credentialexpiry"),
+		}))),
 		remoteRelationsName: ifNotMigrating(remoterelations.Manifold(remoterelations.ManifoldConfig{
 			AgentName:                agentName,
 			APICallerName:            apiCallerName,
@@ -283,6 +310,11 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			Clock:         config.Clock,
 			Logger:        config.LoggingContext.GetLogger("juju.worker.cleaner"),
 		})),
+		modelReconcilerName: ifNotMigrating(modelreconciler.Manifold(modelreconciler.ManifoldConfig{
+			APICallerName: apiCallerName,
+			Clock:         config.Clock,
+			Logger:        config.LoggingContext.GetLogger("juju.worker.modelreconciler"),
+		})),
 		statusHistoryPrunerName: ifNotMigrating(pruner.Manifold(pruner.ManifoldConfig{
 			APICallerName: apiCallerName,
 			Clock:         config.Clock,
@@ -400,6 +432,7 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFirewallerFacade:          firewaller.NewFirewallerFacade,
 			NewRemoteRelationsFacade:     firewaller.NewRemoteRelationsFacade,
 			NewCredentialValidatorFacade: common.NewCredentialInvalidatorFacade,
+			ReconcileInterval:            config.FirewallReconcileInterval,
 		}))),
 		unitAssignerName: ifNotMigrating(unitassigner.Manifold(unitassigner.ManifoldConfig{
 			APICallerName: apiCallerName,
@@ -479,7 +512,7 @@ func CAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			Logger:                 config.LoggingContext.GetLogger("juju.worker.caas"),
 		})),
 
-		caasFirewallerNameLegacy: ifNotMigrating(caasfirewaller.Manifold(
+		caasFirewallerNameLegacy: ifNotMigrating(ifCredentialValid(caasfirewaller.Manifold(
 			caasfirewaller.ManifoldConfig{
 				APICallerName:  apiCallerName,
 				BrokerName:     caasBrokerTrackerName,
@@ -491,9 +524,9 @@ func CAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 				NewWorker: caasfirewaller.NewWorker,
 				Logger:    config.LoggingContext.GetLogger("juju.worker.caasfirewallerlegacy"),
 			},
-		)),
+		))),
 
-		caasFirewallerNameEmbedded: ifNotMigrating(caasfirewallerembedded.Manifold(
+		caasFirewallerNameEmbedded: ifNotMigrating(ifCredentialValid(caasfirewallerembedded.Manifold(
 			caasfirewallerembedded.ManifoldConfig{
 				APICallerName:  apiCallerName,
 				BrokerName:     caasBrokerTrackerName,
@@ -505,7 +538,7 @@ func CAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 				NewWorker: caasfirewallerembedded.NewWorker,
 				Logger:    config.LoggingContext.GetLogger("juju.worker.caasfirewallerembedded"),
 			},
-		)),
+		))),
 
 		caasModelOperatorName: ifResponsible(caasmodeloperator.Manifold(caasmodeloperator.ManifoldConfig{
 			AgentName:     agentName,
@@ -515,7 +548,7 @@ func CAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			ModelUUID:     agentConfig.Model().Id(),
 		})),
 
-		caasOperatorProvisionerName: ifNotMigrating(caasoperatorprovisioner.Manifold(
+		caasOperatorProvisionerName: ifNotMigrating(ifCredentialValid(caasoperatorprovisioner.Manifold(
 			caasoperatorprovisioner.ManifoldConfig{
 				AgentName:     agentName,
 				APICallerName: apiCallerName,
@@ -524,9 +557,9 @@ func CAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 				NewWorker:     caasoperatorprovisioner.NewProvisionerWorker,
 				Logger:        config.LoggingContext.GetLogger("juju.worker.caasprovisioner"),
 			},
-		)),
+		))),
 
-		caasApplicationProvisionerName: ifNotMigrating(caasapplicationprovisioner.Manifold(
+		caasApplicationProvisionerName: ifNotMigrating(ifCredentialValid(caasapplicationprovisioner.Manifold(
 			caasapplicationprovisioner.ManifoldConfig{
 				APICallerName: apiCallerName,
 				BrokerName:    caasBrokerTrackerName,
@@ -534,9 +567,9 @@ func CAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 				NewWorker:     caasapplicationprovisioner.NewProvisionerWorker,
 				Logger:        config.LoggingContext.GetLogger("juju.worker.caasapplicationprovisioner"),
 			},
-		)),
+		))),
 
-		caasUnitProvisionerName: ifNotMigrating(caasunitprovisioner.Manifold(
+		caasUnitProvisionerName: ifNotMigrating(ifCredentialValid(caasunitprovisioner.Manifold(
 			caasunitprovisioner.ManifoldConfig{
 				APICallerName: apiCallerName,
 				BrokerName:    caasBrokerTrackerName,
@@ -546,7 +579,7 @@ func CAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 				NewWorker: caasunitprovisioner.NewWorker,
 				Logger:    config.LoggingContext.GetLogger("juju.worker.caasunitprovisioner"),
 			},
-		)),
+		))),
 		modelUpgraderName: caasenvironupgrader.Manifold(caasenvironupgrader.ManifoldConfig{
 			APICallerName: apiCallerName,
 			GateName:      modelUpgradeGateName,
@@ -564,6 +597,13 @@ func CAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewCredentialValidatorFacade: common.NewCredentialInvalidatorFacade,
 			NewWorker:                    storageprovisioner.NewCaasWorker,
 		}))),
+		scopedCredentialsName: ifNotMigrating(ifCredentialValid(scopedcredentials.Manifold(scopedcredentials.ManifoldConfig{
+			APICallerName:                apiCallerName,
+			EnvironName:                  caasBrokerTrackerName,
+			Clock:                        config.Clock,
+			Logger:                       config.LoggingContext.GetLogger("juju.worker.scopedcredentials"),
+			NewCredentialValidatorFacade: common.NewCredentialInvalidatorFacade,
+		}))),
 	}
 	result := commonManifolds(config)
 	for name, manifold := range manifolds {
@@ -676,8 +716,10 @@ const (
 	applicationScalerName    = "application-scaler"
 	instancePollerName       = "instance-poller"
 	charmRevisionUpdaterName = "charm-revision-updater"
+	credentialExpiryName     = "credential-expiry"
 	metricWorkerName         = "metric-worker"
 	stateCleanerName         = "state-cleaner"
+	modelReconcilerName      = "model-reconciler"
 	statusHistoryPrunerName  = "status-history-pruner"
 	actionPrunerName         = "action-pruner"
 	machineUndertakerName    = "machine-undertaker"
@@ -685,6 +727,7 @@ const (
 	logForwarderName         = "log-forwarder"
 	loggingConfigUpdaterName = "logging-config-updater"
 	instanceMutaterName      = "instance-mutater"
+	scopedCredentialsName    = "scoped-credentials"
 
 	caasAdmissionName              = "caas-admission"
 	caasFirewallerNameLegacy       = "caas-firewaller-legacy"
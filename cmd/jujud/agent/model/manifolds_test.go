@@ -42,6 +42,7 @@ func (s *ManifoldsSuite) TestIAASNames(c *gc.C) {
 		"charm-revision-updater",
 		"clock",
 		"compute-provisioner",
+		"credential-expiry",
 		"environ-tracker",
 		"firewaller",
 		"instance-mutater",
@@ -54,6 +55,7 @@ func (s *ManifoldsSuite) TestIAASNames(c *gc.C) {
 		"migration-fortress",
 		"migration-inactive-flag",
 		"migration-master",
+		"model-reconciler",
 		"model-upgrade-gate",
 		"model-upgraded-flag",
 		"model-upgrader",
@@ -95,18 +97,21 @@ func (s *ManifoldsSuite) TestCAASNames(c *gc.C) {
 		"caas-unit-provisioner",
 		"charm-revision-updater",
 		"clock",
+		"credential-expiry",
 		"is-responsible-flag",
 		"log-forwarder",
 		"logging-config-updater",
 		"migration-fortress",
 		"migration-inactive-flag",
 		"migration-master",
+		"model-reconciler",
 		"model-upgrade-gate",
 		"model-upgraded-flag",
 		"model-upgrader",
 		"not-alive-flag",
 		"not-dead-flag",
 		"remote-relations",
+		"scoped-credentials",
 		"state-cleaner",
 		"status-history-pruner",
 		"undertaker",
@@ -229,7 +234,8 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 		"migration-inactive-flag",
 		"model-upgrade-gate",
 		"model-upgraded-flag",
-		"not-dead-flag"},
+		"not-dead-flag",
+		"valid-credential-flag"},
 
 	"caas-firewaller-embedded": {
 		"agent",
@@ -240,7 +246,8 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 		"migration-inactive-flag",
 		"model-upgrade-gate",
 		"model-upgraded-flag",
-		"not-dead-flag"},
+		"not-dead-flag",
+		"valid-credential-flag"},
 
 	"caas-model-operator": {
 		"agent",
@@ -259,7 +266,8 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 		"migration-inactive-flag",
 		"model-upgrade-gate",
 		"model-upgraded-flag",
-		"not-dead-flag"},
+		"not-dead-flag",
+		"valid-credential-flag"},
 
 	"caas-application-provisioner": {
 		"agent",
@@ -271,7 +279,8 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 		"migration-inactive-flag",
 		"model-upgrade-gate",
 		"model-upgraded-flag",
-		"not-dead-flag"},
+		"not-dead-flag",
+		"valid-credential-flag"},
 
 	"caas-storage-provisioner": {
 		"agent",
@@ -294,7 +303,8 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 		"migration-inactive-flag",
 		"model-upgrade-gate",
 		"model-upgraded-flag",
-		"not-dead-flag"},
+		"not-dead-flag",
+		"valid-credential-flag"},
 
 	"charm-revision-updater": {
 		"agent",
@@ -308,6 +318,18 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 
 	"clock": {},
 
+	"credential-expiry": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag",
+		"valid-credential-flag",
+	},
+
 	"is-responsible-flag": {"agent", "api-caller"},
 
 	"log-forwarder": {
@@ -352,6 +374,16 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 		"model-upgraded-flag",
 		"not-dead-flag"},
 
+	"model-reconciler": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag"},
+
 	"model-upgrade-gate": {},
 
 	"model-upgraded-flag": {"model-upgrade-gate"},
@@ -372,6 +404,18 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 		"model-upgraded-flag",
 		"not-dead-flag"},
 
+	"scoped-credentials": {
+		"agent",
+		"api-caller",
+		"caas-broker-tracker",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag",
+		"valid-credential-flag"},
+
 	"state-cleaner": {
 		"agent",
 		"api-caller",
@@ -446,6 +490,18 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 
 	"clock": {},
 
+	"credential-expiry": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag",
+		"valid-credential-flag",
+	},
+
 	"compute-provisioner": {
 		"agent",
 		"api-caller",
@@ -574,6 +630,16 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 		"model-upgraded-flag",
 		"not-dead-flag"},
 
+	"model-reconciler": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag"},
+
 	"model-upgrade-gate": {},
 
 	"model-upgraded-flag": {"model-upgrade-gate"},
@@ -182,6 +182,9 @@ func (a *UnitAgent) APIWorkers() (worker.Worker, error) {
 	}
 
 	agentConfig := a.AgentConf.CurrentConfig()
+	if err := a.bufferedLogger.EnableSpool(agent.LogSpoolFilename(agentConfig), logsender.DefaultSpoolSize); err != nil {
+		logger.Warningf("cannot spool logs to disk: %v", err)
+	}
 	a.upgradeComplete = upgradesteps.NewLock(agentConfig)
 	machineLock, err := machinelock.New(machinelock.Config{
 		AgentName:   a.Tag().String(),
@@ -0,0 +1,93 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/version"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/upgrades"
+	jujuversion "github.com/juju/juju/version"
+)
+
+type upgradePlanCommand struct {
+	cmd.CommandBase
+	out     cmd.Output
+	from    string
+	targets []string
+
+	fromVersion version.Number
+}
+
+// NewUpgradePlanCommand returns a command which prints the upgrade
+// steps this version of jujud would run when upgrading from a given
+// version, without running them. The controller runs it from the agent
+// binaries being upgraded to, since only they know the steps added
+// since the controller's own version.
+func NewUpgradePlanCommand() cmd.Command {
+	return &upgradePlanCommand{}
+}
+
+// Info is part of cmd.Command.
+func (c *upgradePlanCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "upgrade-plan",
+		Purpose: "show the upgrade steps which would be run when upgrading to this version",
+	})
+}
+
+// SetFlags is part of cmd.Command.
+func (c *upgradePlanCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "json", map[string]cmd.Formatter{
+		"json": cmd.FormatJson,
+		"yaml": cmd.FormatYaml,
+	})
+	f.StringVar(&c.from, "from", "", "the version being upgraded from")
+	f.Var(cmd.NewAppendStringsValue(&c.targets), "targets", "only show steps for these comma delimited machine targets")
+}
+
+// Init is part of cmd.Command.
+func (c *upgradePlanCommand) Init(args []string) error {
+	if c.from == "" {
+		return errors.New("--from is required")
+	}
+	from, err := version.Parse(c.from)
+	if err != nil {
+		return errors.Annotate(err, "--from")
+	}
+	if from.Compare(jujuversion.Current) > 0 {
+		return errors.Errorf("cannot upgrade from %s to %s", from, jujuversion.Current)
+	}
+	c.fromVersion = from
+	for _, target := range c.targets {
+		if !validTarget(upgrades.Target(target)) {
+			return errors.NotValidf("upgrade target %q", target)
+		}
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func validTarget(target upgrades.Target) bool {
+	for _, valid := range upgrades.AllTargets {
+		if target == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// Run is part of cmd.Command.
+func (c *upgradePlanCommand) Run(ctx *cmd.Context) error {
+	targets := upgrades.AllTargets
+	if len(c.targets) > 0 {
+		targets = make([]upgrades.Target, len(c.targets))
+		for i, target := range c.targets {
+			targets[i] = upgrades.Target(target)
+		}
+	}
+	return c.out.Write(ctx, upgrades.PlanResult(c.fromVersion, jujuversion.Current, targets))
+}
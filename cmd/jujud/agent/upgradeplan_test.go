@@ -0,0 +1,66 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent_test
+
+import (
+	"encoding/json"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	agentcmd "github.com/juju/juju/cmd/jujud/agent"
+	jujuversion "github.com/juju/juju/version"
+)
+
+type upgradePlanSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&upgradePlanSuite{})
+
+func (s *upgradePlanSuite) TestInit(c *gc.C) {
+	s.PatchValue(&jujuversion.Current, version.MustParse("2.9.0"))
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "--from is required",
+	}, {
+		args: []string{"--from", "two"},
+		err:  `--from: invalid version "two"`,
+	}, {
+		args: []string{"--from", "2.9.1"},
+		err:  "cannot upgrade from 2.9.1 to 2.9.0",
+	}, {
+		args: []string{"--from", "2.8.0", "--targets", "controller,bogus"},
+		err:  `upgrade target "bogus" not valid`,
+	}, {
+		args: []string{"--from", "2.8.0", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		_, err := cmdtesting.RunCommand(c, agentcmd.NewUpgradePlanCommand(), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *upgradePlanSuite) TestRun(c *gc.C) {
+	s.PatchValue(&jujuversion.Current, version.MustParse("2.9.0"))
+	ctx, err := cmdtesting.RunCommand(c, agentcmd.NewUpgradePlanCommand(), "--from", "2.8.0", "--targets", "controller")
+	c.Assert(err, jc.ErrorIsNil)
+
+	var result params.UpgradePlanResult
+	err = json.Unmarshal([]byte(cmdtesting.Stdout(ctx)), &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.FromVersion, gc.Equals, version.MustParse("2.8.0"))
+	c.Assert(result.ToVersion, gc.Equals, version.MustParse("2.9.0"))
+	for _, step := range result.Steps {
+		c.Check(step.TargetVersion.Compare(result.FromVersion) > 0, jc.IsTrue)
+		c.Check(step.TargetVersion.Compare(result.ToVersion) <= 0, jc.IsTrue)
+	}
+}
@@ -103,8 +103,10 @@ func (c *configFromEnv) LoggingConfig() string {
 	panic("not implemented")
 }
 
+// Value implements agent.Config. The environment holds none of the
+// agent's config values, so the defaults apply.
 func (c *configFromEnv) Value(key string) string {
-	panic("not implemented")
+	return ""
 }
 
 func (c *configFromEnv) Model() names.ModelTag {
@@ -131,6 +133,12 @@ func (c *configFromEnv) NonSyncedWritesToRaftLog() bool {
 	panic("not implemented")
 }
 
+// APIProxy implements agent.Config. The k8s agent connects to the
+// controller directly.
+func (c *configFromEnv) APIProxy() *url.URL {
+	return nil
+}
+
 type configFunc func() agent.Config
 
 func defaultConfig() agent.Config {
@@ -11,12 +11,16 @@ import (
 	"net/http/httptest"
 	"os"
 
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/cmd/k8sagent/initialize"
+	"github.com/juju/juju/worker/apicaller"
 )
 
 func (s *initCommandSuit) TestConfigFromEnv(c *gc.C) {
@@ -41,7 +45,24 @@ func (s *initCommandSuit) TestConfigFromEnv(c *gc.C) {
 		Tag:      names.NewApplicationTag("gitlab"),
 		Password: `passwd`,
 	})
+	c.Assert(cfg.Value(agent.APIDialPreferredSubnets), gc.Equals, "")
+}
 
+func (s *initCommandSuit) TestConnectWithConfigFromEnv(c *gc.C) {
+	a := initialize.NewInitCommandForTest(nil, nil).(agent.Agent)
+	var (
+		openedInfo *api.Info
+		openedOpts api.DialOpts
+	)
+	apiOpen := func(info *api.Info, opts api.DialOpts) (api.Connection, error) {
+		openedInfo, openedOpts = info, opts
+		return nil, errors.New("no controller")
+	}
+	_, err := apicaller.OnlyConnect(a, apiOpen, loggo.GetLogger("test"))
+	c.Assert(err, gc.ErrorMatches, "no controller")
+	c.Assert(openedInfo.Addrs, gc.DeepEquals, []string{`1.1.1.1`, `2.2.2.2`})
+	c.Assert(openedOpts.Proxy, gc.IsNil)
+	c.Assert(openedOpts.AddressHealth, gc.NotNil)
 }
 
 func (s *initCommandSuit) TestDefaultIdentityOnK8S(c *gc.C) {
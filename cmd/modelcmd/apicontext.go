@@ -4,10 +4,12 @@
 package modelcmd
 
 import (
+	"crypto/tls"
 	"net/http"
 	"net/url"
 	"os"
 
+	"github.com/juju/clock"
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
@@ -15,6 +17,7 @@ import (
 	"gopkg.in/juju/idmclient.v1/ussologin"
 	"gopkg.in/macaroon-bakery.v2/httpbakery"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/jujuclient"
 )
 
@@ -25,6 +28,17 @@ type apiContext struct {
 	// methods that clients should not use, such as Save.
 	jar        *domainCookieJar
 	interactor httpbakery.Interactor
+
+	// health and sessions hold the dial state shared by all the
+	// connections made to the controller, so that later connections
+	// dial the address that worked before and resume its TLS session.
+	health   *api.AddressHealth
+	sessions tls.ClientSessionCache
+
+	// conns holds the pooled connections to the controller,
+	// keyed by model name; the controller connection has an
+	// empty model name.
+	conns map[string]pooledConnection
 }
 
 // AuthOpts holds flags relating to authentication.
@@ -87,6 +101,9 @@ func newAPIContext(ctxt *cmd.Context, opts *AuthOpts, store jujuclient.CookieSto
 	return &apiContext{
 		jar:        jar,
 		interactor: interactor,
+		health:     api.NewAddressHealth(clock.WallClock, nil),
+		sessions:   tls.NewLRUClientSessionCache(0),
+		conns:      make(map[string]pooledConnection),
 	}, nil
 }
 
@@ -107,15 +124,44 @@ func (ctx *apiContext) NewBakeryClient() *httpbakery.Client {
 	return client
 }
 
-// Close closes the API context, saving any cookies to the
-// persistent cookie jar.
+// setDialOpts sets the dial options which share the dial state
+// of the controller's connections, unless they are already set.
+func (ctx *apiContext) setDialOpts(opts *api.DialOpts) {
+	if opts.AddressHealth == nil {
+		opts.AddressHealth = ctx.health
+	}
+	if opts.TLSSessionCache == nil {
+		opts.TLSSessionCache = ctx.sessions
+	}
+}
+
+// Close closes the API context, closing any pooled connections
+// and saving any cookies to the persistent cookie jar.
 func (ctxt *apiContext) Close() error {
+	for modelName, conn := range ctxt.conns {
+		if err := conn.Connection.Close(); err != nil {
+			logger.Warningf("cannot close API connection: %v", err)
+		}
+		delete(ctxt.conns, modelName)
+	}
 	if err := ctxt.jar.Save(); err != nil {
 		return errors.Annotatef(err, "cannot save cookie jar")
 	}
 	return nil
 }
 
+// pooledConnection is an API connection held by an apiContext for
+// reuse. Closing it does nothing; the underlying connection is closed
+// along with the API context.
+type pooledConnection struct {
+	api.Connection
+}
+
+// Close implements api.Connection.Close.
+func (pooledConnection) Close() error {
+	return nil
+}
+
 const domainCookieName = "domain"
 
 // domainCookieJar implements a variant of CookieJar that
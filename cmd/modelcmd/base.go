@@ -269,6 +269,38 @@ func (c *CommandBase) NewAPIRootWithDialOpts(
 	return conn, errors.Trace(err)
 }
 
+// PooledAPIRoot returns a connection to the API server for the given
+// model or controller, reusing the connection opened by an earlier call
+// for the same model unless it has broken. Pooled connections are closed
+// when the command finishes; closing one before then does nothing.
+//
+// Commands that make several calls to the same model, or that touch
+// several models on the same controller, should use PooledAPIRoot so
+// that each model is dialled only once.
+func (c *CommandBase) PooledAPIRoot(
+	store jujuclient.ClientStore,
+	controllerName, modelName string,
+) (api.Connection, error) {
+	ctx, err := c.getAPIContext(store, controllerName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if conn, ok := ctx.conns[modelName]; ok {
+		if !conn.IsBroken() {
+			return conn, nil
+		}
+		_ = conn.Connection.Close()
+		delete(ctx.conns, modelName)
+	}
+	conn, err := c.NewAPIRoot(store, controllerName, modelName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	pooled := pooledConnection{conn}
+	ctx.conns[modelName] = pooled
+	return pooled, nil
+}
+
 // RemoveModelFromClientStore removes given model from client cache, store,
 // for a given controller.
 // If this model has also been cached as current, it will be reset if
@@ -328,7 +360,7 @@ func (c *CommandBase) NewAPIConnectionParams(
 		}
 	}
 
-	return newAPIConnectionParams(
+	param, err := newAPIConnectionParams(
 		store, controllerName, modelName,
 		accountDetails,
 		c.Embedded,
@@ -336,6 +368,15 @@ func (c *CommandBase) NewAPIConnectionParams(
 		c.apiOpen,
 		getPassword,
 	)
+	if err != nil {
+		return juju.NewAPIConnectionParams{}, errors.Trace(err)
+	}
+	ctx, err := c.getAPIContext(store, controllerName)
+	if err != nil {
+		return juju.NewAPIConnectionParams{}, errors.Trace(err)
+	}
+	ctx.setDialOpts(&param.DialOpts)
+	return param, nil
 }
 
 // HTTPClient returns an http.Client that contains the loaded
@@ -10,8 +10,10 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
+	"github.com/juju/names/v4"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/api"
@@ -182,6 +184,96 @@ To access it run 'juju switch bar:admin/badmodel'.`,
 	}
 }
 
+func (s *BaseCommandSuite) TestPooledAPIRoot(c *gc.C) {
+	var opened []*fakeConnection
+	var dialOpts []api.DialOpts
+	apiOpen := func(_ *api.Info, opts api.DialOpts) (api.Connection, error) {
+		conn := &fakeConnection{}
+		opened = append(opened, conn)
+		dialOpts = append(dialOpts, opts)
+		return conn, nil
+	}
+	baseCmd := new(modelcmd.ModelCommandBase)
+	baseCmd.SetClientStore(s.store)
+	baseCmd.SetAPIOpen(apiOpen)
+	modelcmd.InitContexts(&cmd.Context{Stderr: ioutil.Discard}, baseCmd)
+	modelcmd.SetRunStarted(baseCmd)
+	c.Assert(baseCmd.SetModelIdentifier("foo:admin/goodmodel", false), jc.ErrorIsNil)
+
+	conn, err := baseCmd.PooledAPIRoot("admin/goodmodel")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conn.Close(), jc.ErrorIsNil)
+	again, err := baseCmd.PooledAPIRoot("admin/goodmodel")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(again, gc.Equals, conn)
+	c.Assert(opened, gc.HasLen, 1)
+	c.Assert(opened[0].closed, jc.IsFalse)
+
+	// Connections to other models on the same controller
+	// share the controller's dial state.
+	_, err = baseCmd.PooledAPIRoot("admin/badmodel")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(opened, gc.HasLen, 2)
+	c.Assert(dialOpts[0].AddressHealth, gc.NotNil)
+	c.Assert(dialOpts[1].AddressHealth, gc.Equals, dialOpts[0].AddressHealth)
+	c.Assert(dialOpts[0].TLSSessionCache, gc.NotNil)
+	c.Assert(dialOpts[1].TLSSessionCache, gc.Equals, dialOpts[0].TLSSessionCache)
+
+	// Broken connections are replaced.
+	opened[0].broken = true
+	_, err = baseCmd.PooledAPIRoot("admin/goodmodel")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(opened, gc.HasLen, 3)
+	c.Assert(opened[0].closed, jc.IsTrue)
+
+	modelcmd.CloseAPIContexts(baseCmd)
+	c.Assert(opened[1].closed, jc.IsTrue)
+	c.Assert(opened[2].closed, jc.IsTrue)
+}
+
+type fakeConnection struct {
+	api.Connection
+	broken bool
+	closed bool
+}
+
+func (c *fakeConnection) APIHostPorts() []network.MachineHostPorts {
+	return nil
+}
+
+func (c *fakeConnection) ServerVersion() (version.Number, bool) {
+	return version.Zero, false
+}
+
+func (c *fakeConnection) Addr() string {
+	return "testing.invalid:1234"
+}
+
+func (c *fakeConnection) IPAddr() string {
+	return ""
+}
+
+func (c *fakeConnection) PublicDNSName() string {
+	return ""
+}
+
+func (c *fakeConnection) AuthTag() names.Tag {
+	return names.NewUserTag("bar")
+}
+
+func (c *fakeConnection) ControllerAccess() string {
+	return "superuser"
+}
+
+func (c *fakeConnection) IsBroken() bool {
+	return c.broken
+}
+
+func (c *fakeConnection) Close() error {
+	c.closed = true
+	return nil
+}
+
 type NewGetBootstrapConfigParamsFuncSuite struct {
 	testing.IsolationSuite
 }
@@ -428,6 +428,19 @@ func (c *ModelCommandBase) NewControllerAPIRoot() (api.Connection, error) {
 	return c.newAPIRoot("", nil)
 }
 
+// PooledAPIRoot returns a pooled connection to the API server for the
+// named model on the controller specified on the command line, or to
+// the controller itself if modelName is empty. See
+// CommandBase.PooledAPIRoot.
+func (c *ModelCommandBase) PooledAPIRoot(modelName string) (api.Connection, error) {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	conn, err := c.CommandBase.PooledAPIRoot(c.store, controllerName, modelName)
+	return conn, errors.Trace(err)
+}
+
 // newAPIRoot is the internal implementation of NewAPIRoot and NewControllerAPIRoot;
 // if modelName is empty, it makes a controller-only connection.
 func (c *ModelCommandBase) newAPIRoot(modelName string, dialOpts *api.DialOpts) (api.Connection, error) {
@@ -5,6 +5,8 @@ package main
 
 import (
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -36,6 +38,9 @@ type generateAgentsCommand struct {
 	clean           bool
 	public          bool
 	preventFallback bool
+	sign            bool
+	keyFile         string
+	passphrase      string
 }
 
 const generateAgentsDoc = `
@@ -70,6 +75,13 @@ Newly generated metadata will be merged with any existing metadata that is alrea
 To first remove metadata for the specified stream before generating new metadata,
 use the --clean option.
 
+To produce signed metadata, use the --sign option together with -k to specify a
+file containing an armored private signing key (and -p for its passphrase if the
+key is encrypted). A .sjson file is written next to each generated .json file.
+When the matching public key is set in the controller's
+agent-metadata-public-key config, controllers only accept agent metadata from
+agent-metadata-url that is signed with it.
+
 Examples:
 
 # generate metadata for "released":
@@ -83,6 +95,9 @@ juju metadata generate-agents -d <workingdir> --stream proposed
 
 # generate metadata for "proposed", first removing existing "proposed" metadata:
 juju metadata generate-agents -d <workingdir> --stream proposed --clean
+
+# generate signed metadata for "released":
+juju metadata generate-agents -d <workingdir> --sign -k <keyfile>
 `
 
 func (c *generateAgentsCommand) Info() *cmd.Info {
@@ -104,6 +119,19 @@ func (c *generateAgentsCommand) SetFlags(f *gnuflag.FlagSet) {
 		"agent binaries are for a public cloud, so generate mirror information")
 	f.BoolVar(&c.preventFallback, "prevent-fallback", false,
 		"prevent falling back to the public cloud if no binaries are found")
+	f.BoolVar(&c.sign, "sign", false, "sign the generated metadata")
+	f.StringVar(&c.keyFile, "k", "", "file containing the armored private signing key")
+	f.StringVar(&c.passphrase, "p", "", "passphrase used to decrypt the private key")
+}
+
+func (c *generateAgentsCommand) Init(args []string) error {
+	if c.sign && c.keyFile == "" {
+		return errors.Errorf("keyfile must be specified when signing metadata")
+	}
+	if !c.sign && (c.keyFile != "" || c.passphrase != "") {
+		return errors.Errorf("keyfile and passphrase are only valid with --sign")
+	}
+	return cmd.CheckEmpty(args)
 }
 
 func (c *generateAgentsCommand) Run(context *cmd.Context) error {
@@ -149,7 +177,19 @@ func (c *generateAgentsCommand) Run(context *cmd.Context) error {
 	if c.public {
 		writeMirrors = envtools.WriteMirrors
 	}
-	return errors.Trace(mergeAndWriteMetadata(targetStorage, c.stream, c.stream, c.clean, toolsList, writeMirrors))
+	if err := mergeAndWriteMetadata(targetStorage, c.stream, c.stream, c.clean, toolsList, writeMirrors); err != nil {
+		return errors.Trace(err)
+	}
+	if !c.sign {
+		return nil
+	}
+	keyData, err := ioutil.ReadFile(context.AbsPath(c.keyFile))
+	if err != nil {
+		return errors.Annotate(err, "reading signing key")
+	}
+	toolsDir := filepath.Join(c.metadataDir, storage.BaseToolsPath)
+	fmt.Fprintf(context.Stdout, "Signing agent metadata in %s.\n", toolsDir)
+	return errors.Trace(process(toolsDir, string(keyData), c.passphrase))
 }
 
 func makeDataSources(urls ...string) []simplestreams.DataSource {
@@ -168,6 +208,35 @@ func makeDataSources(urls ...string) []simplestreams.DataSource {
 	return dataSources
 }
 
+// makeSignedDataSources returns data sources for the given URLs which
+// only accept metadata signed with the given public key.
+func makeSignedDataSources(publicKey string, urls ...string) []simplestreams.DataSource {
+	dataSources := make([]simplestreams.DataSource, len(urls))
+	for i, url := range urls {
+		dataSources[i] = simplestreams.NewDataSource(
+			simplestreams.Config{
+				Description:          "signed local source",
+				BaseURL:              url,
+				PublicSigningKey:     publicKey,
+				HostnameVerification: utils.VerifySSLHostnames,
+				Priority:             simplestreams.CUSTOM_CLOUD_DATA,
+				RequireSigned:        true,
+			},
+		)
+	}
+	return dataSources
+}
+
+// readPublicKey returns the contents of the armored public key file
+// used to verify signed metadata.
+func readPublicKey(ctx *cmd.Context, keyFile string) (string, error) {
+	keyData, err := ioutil.ReadFile(ctx.AbsPath(keyFile))
+	if err != nil {
+		return "", errors.Annotate(err, "reading public key")
+	}
+	return string(keyData), nil
+}
+
 // This is essentially the same as tools.MergeAndWriteMetadata, but also
 // resolves metadata for existing agents by fetching them and computing
 // size/sha256 locally.
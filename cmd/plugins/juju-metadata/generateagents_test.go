@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -25,6 +26,8 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/bootstrap"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/simplestreams"
+	sstesting "github.com/juju/juju/environs/simplestreams/testing"
 	"github.com/juju/juju/environs/tools"
 	toolstesting "github.com/juju/juju/environs/tools/testing"
 	"github.com/juju/juju/juju/keys"
@@ -294,6 +297,40 @@ func (s *GenerateAgentsSuite) TestGenerateWithMirrors(c *gc.C) {
 	c.Assert(obtainedVersionStrings, gc.DeepEquals, versionStrings)
 }
 
+func (s *GenerateAgentsSuite) TestGenerateSigned(c *gc.C) {
+	metadataDir := c.MkDir()
+	toolstesting.MakeTools(c, metadataDir, "released", versionStrings)
+	keyFile := filepath.Join(c.MkDir(), "private.asc")
+	err := ioutil.WriteFile(keyFile, []byte(sstesting.SignedMetadataPrivateKey), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(newGenerateAgentsCommandForTests(), ctx, []string{
+		"-d", metadataDir, "--sign", "-k", keyFile, "-p", sstesting.PrivateKeyPassphrase,
+	})
+	c.Assert(code, gc.Equals, 0)
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, "Signing agent metadata in "+filepath.Join(metadataDir, "tools"))
+
+	streamsDir := filepath.Join(metadataDir, "tools", "streams", "v1")
+	for _, name := range []string{"index2", "index", "com.ubuntu.juju-released-tools"} {
+		f, err := os.Open(filepath.Join(streamsDir, name+simplestreams.SignedSuffix))
+		c.Assert(err, jc.ErrorIsNil)
+		signed, err := simplestreams.DecodeCheckSignature(f, sstesting.SignedMetadataPublicKey)
+		f.Close()
+		c.Assert(err, jc.ErrorIsNil)
+		unsigned, err := ioutil.ReadFile(filepath.Join(streamsDir, name+simplestreams.UnsignedSuffix))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(strings.TrimSpace(string(signed)), gc.Equals, strings.TrimSpace(string(unsigned)))
+	}
+}
+
+func (s *GenerateAgentsSuite) TestSignInitErrors(c *gc.C) {
+	err := cmdtesting.InitCommand(newGenerateAgentsCommandForTests(), []string{"--sign"})
+	c.Check(err, gc.ErrorMatches, "keyfile must be specified when signing metadata")
+	err = cmdtesting.InitCommand(newGenerateAgentsCommandForTests(), []string{"-k", "private.asc"})
+	c.Check(err, gc.ErrorMatches, "keyfile and passphrase are only valid with --sign")
+}
+
 func (s *GenerateAgentsSuite) TestNoTools(c *gc.C) {
 	if runtime.GOOS == "windows" {
 		c.Skip("Skipping on windows, test only set up for Linux tools")
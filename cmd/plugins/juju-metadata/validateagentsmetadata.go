@@ -42,6 +42,7 @@ type validateAgentsMetadataCommand struct {
 	partVersion  string
 	major        int
 	minor        int
+	publicKey    string
 }
 
 var validateAgentsMetadataDoc = `
@@ -92,6 +93,10 @@ Examples:
 
   juju metadata validate-agents --stream proposed
 
+ - validate signed metadata from a local directory against a site public key
+
+  juju metadata validate-agents -d <some directory> --public-key <keyfile>
+
 A key use case is to validate newly generated metadata prior to deployment to
 production. In this case, the metadata is placed in a local directory, a cloud
 provider type is specified (ec2, openstack etc), and the validation is performed
@@ -127,6 +132,7 @@ func (c *validateAgentsMetadataCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.exactVersion, "juju-version", "", "")
 	f.StringVar(&c.partVersion, "majorminor-version", "", "")
 	f.StringVar(&c.stream, "stream", tools.ReleasedStream, "simplestreams stream for which to generate the metadata")
+	f.StringVar(&c.publicKey, "public-key", "", "file containing the armored public key used to verify signed metadata")
 }
 
 func (c *validateAgentsMetadataCommand) Init(args []string) error {
@@ -138,6 +144,9 @@ func (c *validateAgentsMetadataCommand) Init(args []string) error {
 			return errors.Errorf("metadata directory required if provider type is specified")
 		}
 	}
+	if c.publicKey != "" && c.metadataDir == "" {
+		return errors.Errorf("metadata directory required if public key is specified")
+	}
 	if c.exactVersion == "current" {
 		c.exactVersion = jujuversion.Current.String()
 	}
@@ -214,7 +223,15 @@ func (c *validateAgentsMetadataCommand) Run(context *cmd.Context) error {
 		if err != nil {
 			return err
 		}
-		params.Sources = makeDataSources(toolsURL)
+		if c.publicKey == "" {
+			params.Sources = makeDataSources(toolsURL)
+		} else {
+			publicKey, err := readPublicKey(context, c.publicKey)
+			if err != nil {
+				return err
+			}
+			params.Sources = makeSignedDataSources(publicKey, toolsURL)
+		}
 	}
 	params.Stream = c.stream
 
@@ -4,6 +4,8 @@
 package main
 
 import (
+	"io/ioutil"
+	"path/filepath"
 	"strings"
 
 	"github.com/juju/cmd"
@@ -15,6 +17,7 @@ import (
 
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/environs/filestorage"
+	sstesting "github.com/juju/juju/environs/simplestreams/testing"
 	"github.com/juju/juju/environs/tools"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
@@ -55,6 +58,9 @@ var validateInitToolsErrorTests = []struct {
 	}, {
 		args: []string{"-s", "series", "-r", "region", "--majorminor-version", "2.2.1"},
 		err:  `invalid major.minor version number 2.2.1`,
+	}, {
+		args: []string{"-s", "series", "--public-key", "key.asc"},
+		err:  `metadata directory required if public key is specified`,
 	},
 }
 
@@ -249,3 +255,31 @@ func (s *ValidateToolsMetadataSuite) TestJustDirectory(c *gc.C) {
 	strippedOut := strings.Replace(errOut, "\n", "", -1)
 	c.Check(strippedOut, gc.Matches, `Matching Tools Versions:.*Resolve Metadata.*`)
 }
+
+func (s *ValidateToolsMetadataSuite) writePublicKey(c *gc.C) string {
+	keyFile := filepath.Join(c.MkDir(), "key.asc")
+	err := ioutil.WriteFile(keyFile, []byte(sstesting.SignedMetadataPublicKey), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return keyFile
+}
+
+func (s *ValidateToolsMetadataSuite) TestSignedMetadata(c *gc.C) {
+	s.makeLocalMetadata(c, "released", jujuversion.Current.String(), "region-2", "raring", "some-auth-url")
+	err := process(s.metadataDir, sstesting.SignedMetadataPrivateKey, sstesting.PrivateKeyPassphrase)
+	c.Assert(err, jc.ErrorIsNil)
+	ctx, err := runValidateAgentsMetadata(c, s.store,
+		"-s", "raring", "-d", s.metadataDir, "--public-key", s.writePublicKey(c),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	errOut := cmdtesting.Stdout(ctx)
+	strippedOut := strings.Replace(errOut, "\n", "", -1)
+	c.Check(strippedOut, gc.Matches, `Matching Tools Versions:.*Signed: true.*`)
+}
+
+func (s *ValidateToolsMetadataSuite) TestUnsignedMetadataWithPublicKey(c *gc.C) {
+	s.makeLocalMetadata(c, "released", jujuversion.Current.String(), "region-2", "raring", "some-auth-url")
+	_, err := runValidateAgentsMetadata(c, s.store,
+		"-s", "raring", "-d", s.metadataDir, "--public-key", s.writePublicKey(c),
+	)
+	c.Assert(err, gc.NotNil)
+}
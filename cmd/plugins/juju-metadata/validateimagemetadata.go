@@ -38,6 +38,7 @@ type validateImageMetadataCommand struct {
 	region       string
 	endpoint     string
 	stream       string
+	publicKey    string
 }
 
 var validateImagesMetadataDoc = `
@@ -64,6 +65,10 @@ Examples:
 
   juju metadata validate-images -s raring -d <some directory>
 
+ - validate signed metadata from a local directory against a site public key
+
+  juju metadata validate-images -s raring -d <some directory> --public-key <keyfile>
+
 A key use case is to validate newly generated metadata prior to deployment to
 production. In this case, the metadata is placed in a local directory, a cloud
 provider type is specified (ec2, openstack etc), and the validation is performed
@@ -95,6 +100,7 @@ func (c *validateImageMetadataCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.region, "r", "", "the region for which to validate (overrides env config region)")
 	f.StringVar(&c.endpoint, "u", "", "the cloud endpoint URL for which to validate (overrides env config endpoint)")
 	f.StringVar(&c.stream, "stream", "", "the images stream (defaults to released)")
+	f.StringVar(&c.publicKey, "public-key", "", "file containing the armored public key used to verify signed metadata")
 }
 
 func (c *validateImageMetadataCommand) Init(args []string) error {
@@ -109,6 +115,9 @@ func (c *validateImageMetadataCommand) Init(args []string) error {
 			return errors.Errorf("metadata directory required if provider type is specified")
 		}
 	}
+	if c.publicKey != "" && c.metadataDir == "" {
+		return errors.Errorf("metadata directory required if public key is specified")
+	}
 	return cmd.CheckEmpty(args)
 }
 
@@ -231,7 +240,15 @@ func (c *validateImageMetadataCommand) createLookupParams(context *cmd.Context)
 		if _, err := c.Filesystem().Stat(dir); err != nil {
 			return nil, err
 		}
-		params.Sources = imagesDataSources(dir)
+		if c.publicKey == "" {
+			params.Sources = imagesDataSources(dir)
+		} else {
+			publicKey, err := readPublicKey(context, c.publicKey)
+			if err != nil {
+				return nil, err
+			}
+			params.Sources = makeSignedDataSources(publicKey, "file://"+dir)
+		}
 	}
 	return params, nil
 }
@@ -51,6 +51,9 @@ var validateInitImageErrorTests = []struct {
 	}, {
 		args: []string{"-p", "ec2", "-s", "series", "-r", "region"},
 		err:  `metadata directory required if provider type is specified`,
+	}, {
+		args: []string{"-s", "series", "--public-key", "key.asc"},
+		err:  `metadata directory required if public key is specified`,
 	},
 }
 
@@ -92,11 +92,26 @@ func (r resources) registerHookContextCommands() {
 			return cmd, nil
 		},
 	)
+
+	jujuc.RegisterCommand(
+		contextcmd.ShareCmdName,
+		func(ctx jujuc.Context) (jujucmd.Command, error) {
+			compCtx, err := ctx.Component(resource.ComponentName)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			cmd, err := contextcmd.NewShareCmd(compCtx)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			return cmd, nil
+		},
+	)
 }
 
 func (r resources) newUnitFacadeClient(unitName string, caller base.APICaller) (context.APIClient, error) {
 
-	facadeCaller := base.NewFacadeCallerForVersion(caller, context.HookContextFacade, 1)
+	facadeCaller := base.NewFacadeCaller(caller, context.HookContextFacade)
 	httpClient, err := caller.HTTPClient()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -245,5 +245,6 @@ func proxyConfigurationFromContainerCfg(cfg params.ContainerConfig) instancecfg.
 		SnapStoreAssertions: cfg.SnapStoreAssertions,
 		SnapStoreProxyID:    cfg.SnapStoreProxyID,
 		SnapStoreProxyURL:   cfg.SnapStoreProxyURL,
+		APIProxy:            cfg.APIProxy,
 	}
 }
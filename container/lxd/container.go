@@ -18,6 +18,7 @@ import (
 	"github.com/lxc/lxd/shared/version"
 
 	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/devices"
 	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/network"
 )
@@ -96,10 +97,44 @@ func (c *ContainerSpec) ApplyConstraints(serverVersion string, cons constraints.
 			c.Devices["root"]["size"] = fmt.Sprintf(template, *cons.RootDisk)
 		}
 	}
+	if cons.HasGpus() || cons.HasGpuType() {
+		c.applyGPUConstraints(cons)
+	}
 
 	return nil
 }
 
+// applyGPUConstraints adds gpu devices to the container spec.
+// If a number of GPUs is requested, one device is added for each,
+// selecting host GPUs by their DRM card ID in order. Otherwise a single
+// device passing through every matching host GPU is added.
+func (c *ContainerSpec) applyGPUConstraints(cons constraints.Value) {
+	var vendorID string
+	if cons.HasGpuType() {
+		vendorID = devices.GPUVendorIDs[*cons.GpuType]
+	}
+	newDevice := func() device {
+		dev := map[string]string{"type": "gpu"}
+		if vendorID != "" {
+			dev["vendorid"] = vendorID
+		}
+		return dev
+	}
+
+	if c.Devices == nil {
+		c.Devices = map[string]device{}
+	}
+	if !cons.HasGpus() {
+		c.Devices["gpu"] = newDevice()
+		return
+	}
+	for i := uint64(0); i < *cons.Gpus; i++ {
+		dev := newDevice()
+		dev["id"] = fmt.Sprintf("%d", i)
+		c.Devices[fmt.Sprintf("gpu%d", i)] = dev
+	}
+}
+
 // Container extends the upstream LXD container type.
 type Container struct {
 	api.Container
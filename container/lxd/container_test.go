@@ -558,3 +558,20 @@ func (s *managerSuite) TestSpecApplyConstraints(c *gc.C) {
 	c.Check(spec.Config, gc.DeepEquals, exp)
 	c.Check(spec.InstanceType, gc.Equals, instType)
 }
+
+func (s *managerSuite) TestSpecApplyConstraintsGPUs(c *gc.C) {
+	spec := lxd.ContainerSpec{Config: map[string]string{}}
+	err := spec.ApplyConstraints("3.10.0", constraints.MustParse("gpus=2 gpu-type=nvidia"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(spec.Devices, gc.DeepEquals, map[string]map[string]string{
+		"gpu0": {"type": "gpu", "id": "0", "vendorid": "10de"},
+		"gpu1": {"type": "gpu", "id": "1", "vendorid": "10de"},
+	})
+
+	spec = lxd.ContainerSpec{Config: map[string]string{}}
+	err = spec.ApplyConstraints("3.10.0", constraints.MustParse("gpu-type=amd"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(spec.Devices, gc.DeepEquals, map[string]map[string]string{
+		"gpu": {"type": "gpu", "vendorid": "1002"},
+	})
+}
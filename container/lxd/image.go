@@ -5,6 +5,7 @@ package lxd
 
 import (
 	"fmt"
+	"io"
 	"path"
 
 	"github.com/juju/errors"
@@ -170,6 +171,31 @@ func (s *Server) CopyRemoteImage(
 	return nil
 }
 
+// ImportImage adds the unified LXD image tarball read from r to the local
+// cache, with the juju/series/arch alias that FindImage looks for first.
+// It does nothing if an image with that alias is already cached.
+func (s *Server) ImportImage(r io.Reader, series, arch string) error {
+	localAlias := seriesLocalAlias(series, arch)
+	entry, _, err := s.GetImageAlias(localAlias)
+	if err != nil && !IsLXDNotFound(err) {
+		return errors.Trace(err)
+	}
+	if entry != nil {
+		logger.Debugf("image %q already cached, not importing", localAlias)
+		return nil
+	}
+
+	req := api.ImagesPost{Aliases: []api.ImageAlias{{Name: localAlias}}}
+	op, err := s.CreateImage(req, &lxd.ImageCreateArgs{MetaFile: r})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := op.Wait(); err != nil {
+		return errors.Annotatef(err, "importing image %q", localAlias)
+	}
+	return nil
+}
+
 // seriesLocalAlias returns the alias to assign to images for the
 // specified series. The alias is juju-specific, to support the
 // user supplying a customised image (e.g. CentOS with cloud-init).
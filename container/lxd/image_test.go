@@ -5,6 +5,7 @@ package lxd_test
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/golang/mock/gomock"
 	jc "github.com/juju/testing/checkers"
@@ -184,6 +185,42 @@ func (s *imageSuite) TestFindImageRemoteServersNotFound(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, ".*failed to retrieve image.*")
 }
 
+func (s *imageSuite) TestImportImage(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	iSvr := s.NewMockServer(ctrl)
+
+	createOp := lxdtesting.NewMockOperation(ctrl)
+	createOp.EXPECT().Wait().Return(nil)
+
+	req := lxdapi.ImagesPost{Aliases: []lxdapi.ImageAlias{{Name: "juju/focal/" + s.Arch()}}}
+	gomock.InOrder(
+		iSvr.EXPECT().GetImageAlias("juju/focal/"+s.Arch()).Return(nil, lxdtesting.ETag, errors.New("not found")),
+		iSvr.EXPECT().CreateImage(req, gomock.Any()).Return(createOp, nil),
+	)
+
+	jujuSvr, err := lxd.NewServer(iSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = jujuSvr.ImportImage(strings.NewReader("image"), "focal", s.Arch())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *imageSuite) TestImportImageAlreadyCached(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	iSvr := s.NewMockServer(ctrl)
+
+	alias := &lxdapi.ImageAliasesEntry{ImageAliasesEntryPut: lxdapi.ImageAliasesEntryPut{Target: "foo-target"}}
+	iSvr.EXPECT().GetImageAlias("juju/focal/"+s.Arch()).Return(alias, lxdtesting.ETag, nil)
+
+	jujuSvr, err := lxd.NewServer(iSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = jujuSvr.ImportImage(strings.NewReader("image"), "focal", s.Arch())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *imageSuite) TestSeriesRemoteAliasesNotSupported(c *gc.C) {
 	_, err := lxd.SeriesRemoteAliases("centos7", "arm64")
 	c.Assert(err, gc.ErrorMatches, `series "centos7" not supported`)
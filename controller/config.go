@@ -19,7 +19,9 @@ import (
 	"gopkg.in/juju/environschema.v1"
 	"gopkg.in/macaroon-bakery.v2/bakery"
 
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/resources"
+	mongoutils "github.com/juju/juju/mongo/utils"
 	"github.com/juju/juju/pki"
 )
 
@@ -53,6 +55,12 @@ const (
 	// This effectively says that we can have a new agent connect per duration specified.
 	AgentRateLimitRate = "agent-ratelimit-rate"
 
+	// AgentPingTimeout is the time the API server waits for a ping from
+	// a connected agent before it closes the agent's connection. Agents
+	// ping every minute; the timeout can be raised for agents on links
+	// with high latency or frequent stalls.
+	AgentPingTimeout = "agent-ping-timeout"
+
 	// APIPortOpenDelay is a duration that the controller will wait
 	// between when the controller has been deemed to be ready to open
 	// the api-port and when the api-port is actually opened. This value
@@ -99,6 +107,63 @@ const (
 	// CharmStoreURL is the key for the url to use for charmstore API calls
 	CharmStoreURL = "charmstore-url"
 
+	// CharmVettingWebhookURL is the url of a webhook which is consulted
+	// before a charm is deployed or refreshed, and which may reject it.
+	CharmVettingWebhookURL = "charm-vetting-webhook-url"
+
+	// CharmSignaturePolicy determines whether detached signatures
+	// uploaded with charms are verified, and whether charms without a
+	// verified signature may be deployed.
+	CharmSignaturePolicy = "charm-signature-policy"
+
+	// CredentialExpiryWarningPeriod is how long before a cloud
+	// credential expires that models using it are warned.
+	CredentialExpiryWarningPeriod = "credential-expiry-warning-period"
+
+	// CredentialExpiryWebhookURL is the url of a webhook which is
+	// notified when a cloud credential is about to expire, or has
+	// expired.
+	CredentialExpiryWebhookURL = "credential-expiry-webhook-url"
+
+	// LifecycleWebhookURL is the url of a webhook which is notified of
+	// machine, unit and model lifecycle events.
+	LifecycleWebhookURL = "lifecycle-webhook-url"
+
+	// LifecycleWebhookSecret is the key used to sign the notifications
+	// posted to the lifecycle webhook.
+	LifecycleWebhookSecret = "lifecycle-webhook-secret"
+
+	// LifecycleWebhookEvents is the list of lifecycle events which are
+	// posted to the lifecycle webhook.
+	LifecycleWebhookEvents = "lifecycle-webhook-events"
+
+	// APIGatewayToken is the bearer token accepted by the read-only
+	// API gateway. The gateway is disabled when it is empty.
+	APIGatewayToken = "api-gateway-token"
+
+	// ControllerCustomizationScript is a script run once on each
+	// controller machine after it comes up, for site-specific setup such
+	// as installing a monitoring agent. It is run again whenever it
+	// changes.
+	ControllerCustomizationScript = "controller-customization-script"
+
+	// CharmSigningKeys holds the armored PGP public keys and PEM encoded
+	// cosign public keys trusted to sign charms.
+	CharmSigningKeys = "charm-signing-keys"
+
+	// CharmSandboxSysctls lists the kernel parameters charms may set on
+	// their machines in their sandbox profiles. An entry ending in "*"
+	// allows every parameter starting with what precedes it.
+	CharmSandboxSysctls = "charm-sandbox-sysctls"
+
+	// CharmSandboxAppArmor determines whether charms may load AppArmor
+	// profiles on their machines in their sandbox profiles.
+	CharmSandboxAppArmor = "charm-sandbox-apparmor"
+
+	// AgentMetadataPublicKey is the armored PGP public key that agent
+	// metadata from a model's agent-metadata-url must be signed with.
+	AgentMetadataPublicKey = "agent-metadata-public-key"
+
 	// ControllerUUIDKey is the key for the controller UUID attribute.
 	ControllerUUIDKey = "controller-uuid"
 
@@ -137,6 +202,60 @@ const (
 	// snaps for focal or later. The value is ignored for older releases.
 	JujuDBSnapChannel = "juju-db-snap-channel"
 
+	// ExternalMongoURI is a MongoDB connection string for an externally
+	// managed replica set, used by the controller in place of its
+	// co-located juju-db. It requires the "external-mongo" feature flag.
+	// The URI may hold the replica set's credentials, so it is one of the
+	// SecretConfigAttributes.
+	ExternalMongoURI = "external-mongo-uri"
+
+	// ExternalMongoCACert holds the CA certificate, in PEM format, that
+	// signed the certificates of the external replica set's members.
+	ExternalMongoCACert = "external-mongo-ca-cert"
+
+	// BlobstoreBackend selects where the controller stores blob data
+	// such as charm archives and agent binaries: "mongo" (the default)
+	// keeps it in GridFS, "s3" in an S3-compatible object store.
+	BlobstoreBackend = "blobstore-backend"
+
+	// BlobstoreS3Endpoint is the URL of the S3-compatible object store
+	// used when blobstore-backend is "s3". If unset, AWS S3 is used.
+	BlobstoreS3Endpoint = "blobstore-s3-endpoint"
+
+	// BlobstoreS3Region is the region of the S3 object store.
+	BlobstoreS3Region = "blobstore-s3-region"
+
+	// BlobstoreS3Bucket is the bucket holding the controller's blobs.
+	BlobstoreS3Bucket = "blobstore-s3-bucket"
+
+	// BlobstoreS3AccessKey and BlobstoreS3SecretKey hold the credentials
+	// used to access the S3 bucket.
+	BlobstoreS3AccessKey = "blobstore-s3-access-key"
+	BlobstoreS3SecretKey = "blobstore-s3-secret-key"
+
+	// SecretBackend selects where the keys protecting controller-side
+	// secrets, such as cloud credentials and the CA private key, are
+	// held: "internal" (the default) stores the secrets in mongo as
+	// they are, "vault" encrypts them with data keys wrapped by a
+	// HashiCorp Vault transit key.
+	SecretBackend = "secret-backend"
+
+	// SecretBackendVaultAddress is the URL of the Vault server used when
+	// secret-backend is "vault".
+	SecretBackendVaultAddress = "secret-backend-vault-address"
+
+	// SecretBackendVaultToken is the token used to authenticate to Vault.
+	SecretBackendVaultToken = "secret-backend-vault-token"
+
+	// SecretBackendVaultTransitKey is the name of the Vault transit key,
+	// optionally prefixed by the mount path of the transit secrets
+	// engine, which wraps the data keys.
+	SecretBackendVaultTransitKey = "secret-backend-vault-transit-key"
+
+	// SecretBackendVaultCACert holds the CA certificate, in PEM format,
+	// that signed the Vault server's certificate.
+	SecretBackendVaultCACert = "secret-backend-vault-ca-cert"
+
 	// MaxDebugLogDuration is used to provide a backstop to the execution of a debug-log
 	// command. If someone starts a debug-log session in a remote screen for example, it
 	// is very easy to disconnect from the screen while leaving the debug-log process
@@ -183,6 +302,16 @@ const (
 	// to not sleep at all.
 	PruneTxnSleepTime = "prune-txn-sleep-time"
 
+	// PruneTxnMaxSize is the size of the txns collection, eg "1G", above
+	// which transactions are pruned regardless of how much their number
+	// has grown since the last prune. A value of 0 disables the check.
+	PruneTxnMaxSize = "prune-txn-max-size"
+
+	// RemoveOrphanedDocuments sets whether the controller's orphan finder
+	// removes the orphaned documents it finds, rather than only reporting
+	// them.
+	RemoveOrphanedDocuments = "remove-orphaned-documents"
+
 	// MaxCharmStateSize is the maximum allowed size of charm-specific
 	// per-unit state data that charms can store to the controller in
 	// bytes. A value of 0 disables the quota checks although in
@@ -195,6 +324,14 @@ const (
 	// hard (but configurable) limit of 16M.
 	MaxAgentStateSize = "max-agent-state-size"
 
+	// MaxModelsPerUser is the maximum number of models a single user may
+	// own on the controller. A value of 0 disables the check.
+	MaxModelsPerUser = "max-models-per-user"
+
+	// MaxUnitsPerModel is the maximum number of units that may be
+	// deployed to a single model. A value of 0 disables the check.
+	MaxUnitsPerModel = "max-units-per-model"
+
 	// NonSyncedWritesToRaftLog allows the operator to disable fsync calls
 	// when writing to the raft log by setting this value to true.
 	NonSyncedWritesToRaftLog = "non-synced-writes-to-raft-log"
@@ -209,6 +346,15 @@ const (
 	// A token is added to the ratelimit token bucket every 250ms.
 	DefaultAgentRateLimitRate = 250 * time.Millisecond
 
+	// DefaultAgentPingTimeout is the default time the API server waits
+	// for a ping from an agent before closing its connection.
+	DefaultAgentPingTimeout = 3 * time.Minute
+
+	// agentPingPeriod is the interval at which agents ping the API
+	// server, which the agent ping timeout must exceed. It matches
+	// api.PingPeriod.
+	agentPingPeriod = time.Minute
+
 	// DefaultAuditingEnabled contains the default value for the
 	// AuditingEnabled config value.
 	DefaultAuditingEnabled = true
@@ -239,6 +385,41 @@ const (
 	// It is a string representation of a time.Duration.
 	DefaultAPIPortOpenDelay = "2s"
 
+	// BlobstoreBackendMongo and BlobstoreBackendS3 are the supported
+	// values of blobstore-backend.
+	BlobstoreBackendMongo = "mongo"
+	BlobstoreBackendS3    = "s3"
+
+	// SecretBackendInternal and SecretBackendVault are the supported
+	// values of secret-backend.
+	SecretBackendInternal = "internal"
+	SecretBackendVault    = "vault"
+
+	// DefaultSecretBackendVaultTransitKey is the Vault transit key used
+	// if none is configured.
+	DefaultSecretBackendVaultTransitKey = "transit/juju"
+
+	// CharmSignaturePolicyNone, CharmSignaturePolicyVerify and
+	// CharmSignaturePolicyRequire are the supported values of
+	// charm-signature-policy. "verify" checks any signature uploaded
+	// with a charm, "require" also rejects charms with no verified
+	// signature.
+	CharmSignaturePolicyNone    = "none"
+	CharmSignaturePolicyVerify  = "verify"
+	CharmSignaturePolicyRequire = "require"
+
+	// LifecycleEventMachineProvisioned, LifecycleEventUnitStarted,
+	// LifecycleEventUnitRemoved and LifecycleEventModelDestroyed are
+	// the events which may be posted to the lifecycle webhook.
+	LifecycleEventMachineProvisioned = "machine-provisioned"
+	LifecycleEventUnitStarted        = "unit-started"
+	LifecycleEventUnitRemoved        = "unit-removed"
+	LifecycleEventModelDestroyed     = "model-destroyed"
+
+	// DefaultBlobstoreS3Region is the region used for the blobstore S3
+	// bucket if none is configured.
+	DefaultBlobstoreS3Region = "us-east-1"
+
 	// DefaultMongoMemoryProfile is the default profile used by mongo.
 	DefaultMongoMemoryProfile = MongoProfDefault
 
@@ -250,6 +431,10 @@ const (
 	// can run before being terminated by the API server.
 	DefaultMaxDebugLogDuration = 24 * time.Hour
 
+	// DefaultCredentialExpiryWarningPeriod is the default time before a
+	// cloud credential expires that models using it are warned.
+	DefaultCredentialExpiryWarningPeriod = 7 * 24 * time.Hour
+
 	// DefaultMaxTxnLogCollectionMB is the maximum size the txn log collection.
 	DefaultMaxTxnLogCollectionMB = 10 // 10 MB
 
@@ -280,6 +465,14 @@ const (
 	// other systems to operate concurrently.
 	DefaultPruneTxnSleepTime = "10ms"
 
+	// DefaultPruneTxnMaxSizeMB is the size in MiB of the txns collection
+	// above which transactions are pruned regardless of their growth.
+	DefaultPruneTxnMaxSizeMB = 1024
+
+	// DefaultRemoveOrphanedDocuments is the default for whether orphaned
+	// documents are removed when they are found.
+	DefaultRemoveOrphanedDocuments = false
+
 	// DefaultMaxCharmStateSize is the maximum size (in bytes) of charm
 	// state data that each unit can store to the controller.
 	DefaultMaxCharmStateSize = 2 * 1024 * 1024
@@ -292,6 +485,10 @@ const (
 	// non-synced-writes-to-raft-log value. It is set to false by default.
 	DefaultNonSyncedWritesToRaftLog = false
 
+	// MinAPIGatewayTokenLength is the minimum length of the API
+	// gateway token.
+	MinAPIGatewayTokenLength = 16
+
 	// JujuHASpace is the network space within which the MongoDB replica-set
 	// should communicate.
 	JujuHASpace = "juju-ha-space"
@@ -300,6 +497,15 @@ const (
 	// communicate with controllers.
 	JujuManagementSpace = "juju-mgmt-space"
 
+	// APIAdvertiseCIDRs is a list of CIDRs. When set, only controller API
+	// addresses within one of them are advertised to agents and clients.
+	APIAdvertiseCIDRs = "api-advertise-cidrs"
+
+	// APIAdvertiseScopes is a list of network address scopes (such as
+	// "public" or "local-cloud"). When set, only controller API addresses
+	// with one of these scopes are advertised to agents and clients.
+	APIAdvertiseScopes = "api-advertise-scopes"
+
 	// CAASOperatorImagePath sets the url of the docker image
 	// used for the application operator.
 	// Deprecated: use CAASImageRepo
@@ -326,12 +532,26 @@ var (
 		AllowModelAccessKey,
 		AgentRateLimitMax,
 		AgentRateLimitRate,
+		AgentPingTimeout,
 		APIPort,
 		APIPortOpenDelay,
 		AutocertDNSNameKey,
 		AutocertURLKey,
 		CACertKey,
 		CharmStoreURL,
+		CharmVettingWebhookURL,
+		CharmSignaturePolicy,
+		CharmSigningKeys,
+		CharmSandboxSysctls,
+		CharmSandboxAppArmor,
+		AgentMetadataPublicKey,
+		CredentialExpiryWarningPeriod,
+		CredentialExpiryWebhookURL,
+		LifecycleWebhookURL,
+		LifecycleWebhookSecret,
+		LifecycleWebhookEvents,
+		APIGatewayToken,
+		ControllerCustomizationScript,
 		ControllerAPIPort,
 		ControllerName,
 		ControllerUUIDKey,
@@ -341,6 +561,19 @@ var (
 		StatePort,
 		MongoMemoryProfile,
 		JujuDBSnapChannel,
+		ExternalMongoURI,
+		ExternalMongoCACert,
+		BlobstoreBackend,
+		BlobstoreS3Endpoint,
+		BlobstoreS3Region,
+		BlobstoreS3Bucket,
+		BlobstoreS3AccessKey,
+		BlobstoreS3SecretKey,
+		SecretBackend,
+		SecretBackendVaultAddress,
+		SecretBackendVaultToken,
+		SecretBackendVaultTransitKey,
+		SecretBackendVaultCACert,
 		MaxDebugLogDuration,
 		MaxTxnLogSize,
 		MaxPruneTxnBatchSize,
@@ -350,9 +583,13 @@ var (
 		ModelLogsSize,
 		PruneTxnQueryCount,
 		PruneTxnSleepTime,
+		PruneTxnMaxSize,
+		RemoveOrphanedDocuments,
 		PublicDNSAddress,
 		JujuHASpace,
 		JujuManagementSpace,
+		APIAdvertiseCIDRs,
+		APIAdvertiseScopes,
 		AuditingEnabled,
 		AuditLogCaptureArgs,
 		AuditLogMaxSize,
@@ -364,6 +601,8 @@ var (
 		MeteringURL,
 		MaxCharmStateSize,
 		MaxAgentStateSize,
+		MaxModelsPerUser,
+		MaxUnitsPerModel,
 		NonSyncedWritesToRaftLog,
 	}
 
@@ -385,6 +624,7 @@ var (
 	AllowedUpdateConfigAttributes = set.NewStrings(
 		AgentRateLimitMax,
 		AgentRateLimitRate,
+		AgentPingTimeout,
 		APIPortOpenDelay,
 		AuditingEnabled,
 		AuditLogCaptureArgs,
@@ -402,17 +642,80 @@ var (
 		MongoMemoryProfile,
 		PruneTxnQueryCount,
 		PruneTxnSleepTime,
+		PruneTxnMaxSize,
+		RemoveOrphanedDocuments,
 		PublicDNSAddress,
 		JujuHASpace,
 		JujuManagementSpace,
+		APIAdvertiseCIDRs,
+		APIAdvertiseScopes,
 		CAASOperatorImagePath,
 		CAASImageRepo,
 		Features,
 		MaxCharmStateSize,
 		MaxAgentStateSize,
+		MaxModelsPerUser,
+		MaxUnitsPerModel,
+		CharmVettingWebhookURL,
+		CharmSignaturePolicy,
+		CharmSigningKeys,
+		CharmSandboxSysctls,
+		CharmSandboxAppArmor,
+		AgentMetadataPublicKey,
+		CredentialExpiryWarningPeriod,
+		CredentialExpiryWebhookURL,
+		LifecycleWebhookURL,
+		LifecycleWebhookSecret,
+		LifecycleWebhookEvents,
+		APIGatewayToken,
+		ControllerCustomizationScript,
 		NonSyncedWritesToRaftLog,
+		BlobstoreBackend,
+		BlobstoreS3Endpoint,
+		BlobstoreS3Region,
+		BlobstoreS3Bucket,
+		BlobstoreS3AccessKey,
+		BlobstoreS3SecretKey,
+		SecretBackend,
+		SecretBackendVaultAddress,
+		SecretBackendVaultToken,
+		SecretBackendVaultTransitKey,
+		SecretBackendVaultCACert,
+	)
+
+	// BlobstoreConfigAttributes contains the controller config
+	// attributes that determine where blob data is stored. They may
+	// only be updated while blob data is held in mongo; blob data
+	// already stored is moved to S3 by an upgrade step.
+	BlobstoreConfigAttributes = set.NewStrings(
+		BlobstoreBackend,
+		BlobstoreS3Endpoint,
+		BlobstoreS3Region,
+		BlobstoreS3Bucket,
+		BlobstoreS3AccessKey,
+		BlobstoreS3SecretKey,
+	)
+
+	// SecretConfigAttributes contains the controller config attributes
+	// holding credentials that only the controller itself uses. They are
+	// never returned over the API.
+	SecretConfigAttributes = set.NewStrings(
+		BlobstoreS3SecretKey,
+		SecretBackendVaultToken,
+		LifecycleWebhookSecret,
+		APIGatewayToken,
+		ExternalMongoURI,
 	)
 
+	// LifecycleEvents holds all of the events which may be posted to
+	// the lifecycle webhook.
+	LifecycleEvents = []string{
+		LifecycleEventMachineProvisioned,
+		LifecycleEventUnitStarted,
+		LifecycleEventUnitRemoved,
+		LifecycleEventModelDestroyed,
+	}
+
 	// DefaultAuditLogExcludeMethods is the default list of methods to
 	// exclude from the audit log.
 	DefaultAuditLogExcludeMethods = []string{
@@ -421,6 +724,15 @@ var (
 		ReadOnlyMethodsWildcard,
 	}
 
+	// advertisableScopes holds the address scopes accepted by
+	// api-advertise-scopes.
+	advertisableScopes = set.NewStrings(
+		string(network.ScopePublic),
+		string(network.ScopeCloudLocal),
+		string(network.ScopeFanLocal),
+		string(network.ScopeMachineLocal),
+	)
+
 	methodNameRE = regexp.MustCompile(`[[:alpha:]][[:alnum:]]*\.[[:alpha:]][[:alnum:]]*`)
 )
 
@@ -581,6 +893,12 @@ func (c Config) AgentRateLimitRate() time.Duration {
 	return c.durationOrDefault(AgentRateLimitRate, DefaultAgentRateLimitRate)
 }
 
+// AgentPingTimeout is the time the API server waits for a ping from a
+// connected agent before closing its connection.
+func (c Config) AgentPingTimeout() time.Duration {
+	return c.durationOrDefault(AgentPingTimeout, DefaultAgentPingTimeout)
+}
+
 // AuditingEnabled returns whether or not auditing has been enabled
 // for the environment. The default is false.
 func (c Config) AuditingEnabled() bool {
@@ -647,6 +965,106 @@ func (c Config) CharmStoreURL() string {
 	return url
 }
 
+// CharmVettingWebhookURL returns the url of the webhook to consult before
+// deploying or refreshing a charm, or an empty string if charms are not
+// vetted.
+func (c Config) CharmVettingWebhookURL() string {
+	return c.asString(CharmVettingWebhookURL)
+}
+
+// CredentialExpiryWarningPeriod returns how long before a cloud
+// credential expires that models using it are warned.
+func (c Config) CredentialExpiryWarningPeriod() time.Duration {
+	period, ok := c[CredentialExpiryWarningPeriod].(time.Duration)
+	if !ok {
+		period = DefaultCredentialExpiryWarningPeriod
+	}
+	return period
+}
+
+// CredentialExpiryWebhookURL returns the url of the webhook to notify
+// of expiring cloud credentials, or an empty string if there is none.
+func (c Config) CredentialExpiryWebhookURL() string {
+	return c.asString(CredentialExpiryWebhookURL)
+}
+
+// LifecycleWebhookURL returns the url of the webhook to notify of
+// lifecycle events, or an empty string if there is none.
+func (c Config) LifecycleWebhookURL() string {
+	return c.asString(LifecycleWebhookURL)
+}
+
+// LifecycleWebhookSecret returns the key used to sign lifecycle webhook
+// notifications, or an empty string if they are not signed.
+func (c Config) LifecycleWebhookSecret() string {
+	return c.asString(LifecycleWebhookSecret)
+}
+
+// LifecycleWebhookEvents returns the set of lifecycle events posted to
+// the lifecycle webhook. All events are posted unless configured
+// otherwise.
+func (c Config) LifecycleWebhookEvents() set.Strings {
+	if value, ok := c[LifecycleWebhookEvents]; ok {
+		value := value.([]interface{})
+		events := set.NewStrings()
+		for _, item := range value {
+			events.Add(item.(string))
+		}
+		return events
+	}
+	return set.NewStrings(LifecycleEvents...)
+}
+
+// APIGatewayToken returns the bearer token accepted by the read-only
+// API gateway, or an empty string if the gateway is disabled.
+func (c Config) APIGatewayToken() string {
+	return c.asString(APIGatewayToken)
+}
+
+// ControllerCustomizationScript returns the script run on each
+// controller machine after it comes up, or an empty string if there is
+// none.
+func (c Config) ControllerCustomizationScript() string {
+	return c.asString(ControllerCustomizationScript)
+}
+
+// CharmSignaturePolicy returns the policy applied to charm signatures,
+// one of CharmSignaturePolicyNone, CharmSignaturePolicyVerify or
+// CharmSignaturePolicyRequire.
+func (c Config) CharmSignaturePolicy() string {
+	if policy := c.asString(CharmSignaturePolicy); policy != "" {
+		return policy
+	}
+	return CharmSignaturePolicyNone
+}
+
+// CharmSigningKeys returns the public keys trusted to sign charms.
+func (c Config) CharmSigningKeys() string {
+	return c.asString(CharmSigningKeys)
+}
+
+// CharmSandboxSysctls returns the kernel parameters charms may set on
+// their machines.
+func (c Config) CharmSandboxSysctls() []string {
+	return c.asStringList(CharmSandboxSysctls)
+}
+
+// CharmSandboxAppArmor returns whether charms may load AppArmor profiles
+// on their machines. The default is false.
+func (c Config) CharmSandboxAppArmor() bool {
+	if v, ok := c[CharmSandboxAppArmor]; ok {
+		return v.(bool)
+	}
+	return false
+}
+
+// AgentMetadataPublicKey returns the public key that agent metadata
+// from agent-metadata-url must be signed with, or an empty string if
+// the Juju key is used.
+func (c Config) AgentMetadataPublicKey() string {
+	return c.asString(AgentMetadataPublicKey)
+}
+
 // ControllerName returns the name for the controller
 func (c Config) ControllerName() string {
 	return c.asString(ControllerName)
@@ -716,6 +1134,97 @@ func (c Config) JujuDBSnapChannel() string {
 	return c.asString(JujuDBSnapChannel)
 }
 
+// ExternalMongoURI returns the connection string of the externally
+// managed replica set used by the controller, or "" if the controller
+// uses its own juju-db.
+func (c Config) ExternalMongoURI() string {
+	return c.asString(ExternalMongoURI)
+}
+
+// ExternalMongoCACert returns the CA certificate, in PEM format, of the
+// externally managed replica set used by the controller.
+func (c Config) ExternalMongoCACert() string {
+	return c.asString(ExternalMongoCACert)
+}
+
+// BlobstoreBackend returns where the controller stores blob data,
+// either BlobstoreBackendMongo or BlobstoreBackendS3.
+func (c Config) BlobstoreBackend() string {
+	if backend := c.asString(BlobstoreBackend); backend != "" {
+		return backend
+	}
+	return BlobstoreBackendMongo
+}
+
+// BlobstoreS3Endpoint returns the URL of the S3-compatible object store
+// holding blob data, or "" to use AWS S3.
+func (c Config) BlobstoreS3Endpoint() string {
+	return c.asString(BlobstoreS3Endpoint)
+}
+
+// BlobstoreS3Region returns the region of the S3 object store.
+func (c Config) BlobstoreS3Region() string {
+	if region := c.asString(BlobstoreS3Region); region != "" {
+		return region
+	}
+	return DefaultBlobstoreS3Region
+}
+
+// BlobstoreS3Bucket returns the name of the S3 bucket holding blob data.
+func (c Config) BlobstoreS3Bucket() string {
+	return c.asString(BlobstoreS3Bucket)
+}
+
+// BlobstoreS3AccessKey returns the access key used to access the S3
+// bucket.
+func (c Config) BlobstoreS3AccessKey() string {
+	return c.asString(BlobstoreS3AccessKey)
+}
+
+// BlobstoreS3SecretKey returns the secret key used to access the S3
+// bucket.
+func (c Config) BlobstoreS3SecretKey() string {
+	return c.asString(BlobstoreS3SecretKey)
+}
+
+// SecretBackend returns where the keys protecting controller-side
+// secrets are held, either SecretBackendInternal or SecretBackendVault.
+func (c Config) SecretBackend() string {
+	if backend := c.asString(SecretBackend); backend != "" {
+		return backend
+	}
+	return SecretBackendInternal
+}
+
+// SecretBackendVaultAddress returns the URL of the Vault server.
+func (c Config) SecretBackendVaultAddress() string {
+	return c.asString(SecretBackendVaultAddress)
+}
+
+// SecretBackendVaultToken returns the token used to authenticate to
+// Vault.
+func (c Config) SecretBackendVaultToken() string {
+	return c.asString(SecretBackendVaultToken)
+}
+
+// SecretBackendVaultTransitKey returns the path of the Vault transit key
+// which wraps the data keys, as "<mount>/<key>".
+func (c Config) SecretBackendVaultTransitKey() string {
+	if key := c.asString(SecretBackendVaultTransitKey); key != "" {
+		if !strings.Contains(key, "/") {
+			return "transit/" + key
+		}
+		return key
+	}
+	return DefaultSecretBackendVaultTransitKey
+}
+
+// SecretBackendVaultCACert returns the CA certificate that signed the
+// Vault server's certificate, or "" to use the system roots.
+func (c Config) SecretBackendVaultCACert() string {
+	return c.asString(SecretBackendVaultCACert)
+}
+
 // NUMACtlPreference returns if numactl is preferred.
 func (c Config) NUMACtlPreference() bool {
 	if numa, ok := c[SetNUMAControlPolicyKey]; ok {
@@ -793,6 +1302,22 @@ func (c Config) PruneTxnSleepTime() time.Duration {
 	return val
 }
 
+// PruneTxnMaxSizeMB is the size in MiB of the txns collection above which
+// transactions are pruned regardless of their growth. Zero disables
+// pruning on size.
+func (c Config) PruneTxnMaxSizeMB() int {
+	return c.sizeMBOrDefault(PruneTxnMaxSize, DefaultPruneTxnMaxSizeMB)
+}
+
+// RemoveOrphanedDocuments returns whether orphaned documents found by the
+// controller are removed, rather than only reported.
+func (c Config) RemoveOrphanedDocuments() bool {
+	if v, ok := c[RemoveOrphanedDocuments].(bool); ok {
+		return v
+	}
+	return DefaultRemoveOrphanedDocuments
+}
+
 // PublicDNSAddress returns the DNS name of the controller.
 func (c Config) PublicDNSAddress() string {
 	return c.asString(PublicDNSAddress)
@@ -810,6 +1335,38 @@ func (c Config) JujuManagementSpace() string {
 	return c.asString(JujuManagementSpace)
 }
 
+// APIAdvertiseCIDRs returns the CIDRs within which controller API
+// addresses must fall to be advertised to agents and clients.
+func (c Config) APIAdvertiseCIDRs() []string {
+	return c.asStringList(APIAdvertiseCIDRs)
+}
+
+// APIAdvertiseScopes returns the network scopes that controller API
+// addresses must have to be advertised to agents and clients.
+func (c Config) APIAdvertiseScopes() []network.Scope {
+	values := c.asStringList(APIAdvertiseScopes)
+	if values == nil {
+		return nil
+	}
+	scopes := make([]network.Scope, len(values))
+	for i, value := range values {
+		scopes[i] = network.Scope(value)
+	}
+	return scopes
+}
+
+func (c Config) asStringList(key string) []string {
+	value, ok := c[key].([]interface{})
+	if !ok {
+		return nil
+	}
+	items := make([]string, len(value))
+	for i, item := range value {
+		items[i] = item.(string)
+	}
+	return items
+}
+
 // CAASOperatorImagePath sets the url of the docker image
 // used for the application operator.
 func (c Config) CAASOperatorImagePath() string {
@@ -844,6 +1401,18 @@ func (c Config) MaxAgentStateSize() int {
 	return c.intOrDefault(MaxAgentStateSize, DefaultMaxAgentStateSize)
 }
 
+// MaxModelsPerUser returns the maximum number of models a single user may
+// own on the controller. A value of zero indicates no limit.
+func (c Config) MaxModelsPerUser() int {
+	return c.intOrDefault(MaxModelsPerUser, 0)
+}
+
+// MaxUnitsPerModel returns the maximum number of units that may be deployed
+// to a single model. A value of zero indicates no limit.
+func (c Config) MaxUnitsPerModel() int {
+	return c.intOrDefault(MaxUnitsPerModel, 0)
+}
+
 // NonSyncedWritesToRaftLog returns true if fsync calls should be skipped
 // after each write to the raft log.
 func (c Config) NonSyncedWritesToRaftLog() bool {
@@ -906,6 +1475,11 @@ func Validate(c Config) error {
 			return errors.Errorf("%s must be between 0..1m", AgentRateLimitRate)
 		}
 	}
+	if v, ok := c[AgentPingTimeout].(time.Duration); ok {
+		if v <= agentPingPeriod {
+			return errors.Errorf("%s must be greater than %v, the interval between agent pings", AgentPingTimeout, agentPingPeriod)
+		}
+	}
 
 	if mgoMemProfile, ok := c[MongoMemoryProfile].(string); ok {
 		if mgoMemProfile != MongoProfLow && mgoMemProfile != MongoProfDefault {
@@ -913,6 +1487,80 @@ func Validate(c Config) error {
 		}
 	}
 
+	if uri := c.ExternalMongoURI(); uri != "" {
+		rs, err := mongoutils.ParseExternalURI(uri)
+		if err != nil {
+			return errors.Annotatef(err, "invalid %s", ExternalMongoURI)
+		}
+		if rs.TLS && c.ExternalMongoCACert() == "" {
+			return errors.Errorf("%s must be set when %s uses TLS", ExternalMongoCACert, ExternalMongoURI)
+		}
+	}
+	if caCert := c.ExternalMongoCACert(); caCert != "" {
+		if c.ExternalMongoURI() == "" {
+			return errors.Errorf("%s requires %s", ExternalMongoCACert, ExternalMongoURI)
+		}
+		if ok, err := pki.IsPemCA([]byte(caCert)); err != nil {
+			return errors.Annotatef(err, "bad %s in configuration", ExternalMongoCACert)
+		} else if !ok {
+			return errors.Errorf("%s in configuration is not a CA", ExternalMongoCACert)
+		}
+	}
+
+	switch backend := c.BlobstoreBackend(); backend {
+	case BlobstoreBackendMongo:
+		for _, key := range []string{BlobstoreS3Endpoint, BlobstoreS3Bucket, BlobstoreS3AccessKey, BlobstoreS3SecretKey} {
+			if c.asString(key) != "" {
+				return errors.Errorf("%s requires %s %q", key, BlobstoreBackend, BlobstoreBackendS3)
+			}
+		}
+	case BlobstoreBackendS3:
+		if c.BlobstoreS3Bucket() == "" {
+			return errors.Errorf("%s must be set when %s is %q", BlobstoreS3Bucket, BlobstoreBackend, BlobstoreBackendS3)
+		}
+		if (c.BlobstoreS3AccessKey() == "") != (c.BlobstoreS3SecretKey() == "") {
+			return errors.Errorf("%s and %s must be set together", BlobstoreS3AccessKey, BlobstoreS3SecretKey)
+		}
+		if endpoint := c.BlobstoreS3Endpoint(); endpoint != "" {
+			u, err := url.Parse(endpoint)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return errors.Errorf("%s %q is not a valid http or https URL", BlobstoreS3Endpoint, endpoint)
+			}
+		}
+	default:
+		return errors.Errorf("%s: expected one of %q or %q got %q", BlobstoreBackend, BlobstoreBackendMongo, BlobstoreBackendS3, backend)
+	}
+
+	switch backend := c.SecretBackend(); backend {
+	case SecretBackendInternal:
+		for _, key := range []string{SecretBackendVaultAddress, SecretBackendVaultToken, SecretBackendVaultTransitKey, SecretBackendVaultCACert} {
+			if c.asString(key) != "" {
+				return errors.Errorf("%s requires %s %q", key, SecretBackend, SecretBackendVault)
+			}
+		}
+	case SecretBackendVault:
+		address := c.SecretBackendVaultAddress()
+		if address == "" {
+			return errors.Errorf("%s must be set when %s is %q", SecretBackendVaultAddress, SecretBackend, SecretBackendVault)
+		}
+		u, err := url.Parse(address)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.Errorf("%s %q is not a valid http or https URL", SecretBackendVaultAddress, address)
+		}
+		if c.SecretBackendVaultToken() == "" {
+			return errors.Errorf("%s must be set when %s is %q", SecretBackendVaultToken, SecretBackend, SecretBackendVault)
+		}
+		if caCert := c.SecretBackendVaultCACert(); caCert != "" {
+			if ok, err := pki.IsPemCA([]byte(caCert)); err != nil {
+				return errors.Annotatef(err, "bad %s in configuration", SecretBackendVaultCACert)
+			} else if !ok {
+				return errors.Errorf("%s in configuration is not a CA", SecretBackendVaultCACert)
+			}
+		}
+	default:
+		return errors.Errorf("%s: expected one of %q or %q got %q", SecretBackend, SecretBackendInternal, SecretBackendVault, backend)
+	}
+
 	if v, ok := c[MaxDebugLogDuration].(time.Duration); ok {
 		if v == 0 {
 			return errors.Errorf("%s cannot be zero", MaxDebugLogDuration)
@@ -956,6 +1604,12 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[PruneTxnMaxSize].(string); ok {
+		if _, err := utils.ParseSize(v); err != nil {
+			return errors.Annotatef(err, `%s must be a valid size (eg "1G")`, PruneTxnMaxSize)
+		}
+	}
+
 	if err := c.validateSpaceConfig(JujuHASpace, "juju HA"); err != nil {
 		return errors.Trace(err)
 	}
@@ -964,6 +1618,18 @@ func Validate(c Config) error {
 		return errors.Trace(err)
 	}
 
+	for _, cidr := range c.APIAdvertiseCIDRs() {
+		if !network.IsValidCIDR(cidr) {
+			return errors.NotValidf("%s value %q", APIAdvertiseCIDRs, cidr)
+		}
+	}
+
+	for _, scope := range c.APIAdvertiseScopes() {
+		if !advertisableScopes.Contains(string(scope)) {
+			return errors.NotValidf("%s value %q", APIAdvertiseScopes, scope)
+		}
+	}
+
 	if v, ok := c[CAASOperatorImagePath].(string); ok && v != "" {
 		if err := resources.ValidateDockerRegistryPath(v); err != nil {
 			return errors.Trace(err)
@@ -1056,6 +1722,49 @@ func Validate(c Config) error {
 		return errors.Errorf("invalid max charm/agent state sizes: combined value should not exceed mongo's 16M per-document limit, got %d", maxUnitStateSize)
 	}
 
+	for _, key := range []string{MaxModelsPerUser, MaxUnitsPerModel} {
+		if v, ok := c[key].(int); ok && v < 0 {
+			return errors.Errorf("invalid %s: should be a positive number (or 0 to disable limit), got %d", key, v)
+		}
+	}
+
+	for _, key := range []string{CharmVettingWebhookURL, CredentialExpiryWebhookURL, LifecycleWebhookURL} {
+		if v, ok := c[key].(string); ok && v != "" {
+			u, err := url.Parse(v)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return errors.Errorf("%s %q is not a valid http or https URL", key, v)
+			}
+		}
+	}
+
+	if v, ok := c[CredentialExpiryWarningPeriod].(time.Duration); ok && v < 0 {
+		return errors.Errorf("%s cannot be negative", CredentialExpiryWarningPeriod)
+	}
+
+	if v, ok := c[LifecycleWebhookEvents].([]interface{}); ok {
+		known := set.NewStrings(LifecycleEvents...)
+		for _, event := range v {
+			if event := event.(string); !known.Contains(event) {
+				return errors.Errorf("invalid %s: unknown event %q", LifecycleWebhookEvents, event)
+			}
+		}
+	}
+
+	if v, ok := c[APIGatewayToken].(string); ok && v != "" && len(v) < MinAPIGatewayTokenLength {
+		return errors.Errorf("%s must be at least %d characters", APIGatewayToken, MinAPIGatewayTokenLength)
+	}
+
+	switch policy := c.CharmSignaturePolicy(); policy {
+	case CharmSignaturePolicyNone:
+	case CharmSignaturePolicyVerify, CharmSignaturePolicyRequire:
+		if c.CharmSigningKeys() == "" {
+			return errors.Errorf("%s must be set when %s is %q", CharmSigningKeys, CharmSignaturePolicy, policy)
+		}
+	default:
+		return errors.Errorf("invalid %s %q: expected one of %q, %q or %q",
+			CharmSignaturePolicy, policy, CharmSignaturePolicyNone, CharmSignaturePolicyVerify, CharmSignaturePolicyRequire)
+	}
+
 	return nil
 }
 
@@ -1108,88 +1817,188 @@ func (c Config) AsSpaceConstraints(spaces *[]string) *[]string {
 	return &ns
 }
 
+// FilterAdvertisedHostPorts returns the API host/ports of each
+// controller that should be advertised according to the configured
+// api-advertise-cidrs and api-advertise-scopes. If filtering would leave
+// a controller with no addresses, all of its addresses are retained so
+// that misconfiguration cannot cut off access to it.
+func (c Config) FilterAdvertisedHostPorts(apiHostPorts []network.SpaceHostPorts) []network.SpaceHostPorts {
+	cidrs := c.APIAdvertiseCIDRs()
+	scopes := c.APIAdvertiseScopes()
+	if len(cidrs) == 0 && len(scopes) == 0 {
+		return apiHostPorts
+	}
+
+	filtered := make([]network.SpaceHostPorts, len(apiHostPorts))
+	for i, hps := range apiHostPorts {
+		selected := hps
+		if len(cidrs) > 0 {
+			var ok bool
+			if selected, ok = selected.InCIDRs(cidrs...); !ok {
+				filtered[i] = hps
+				continue
+			}
+		}
+		if len(scopes) > 0 {
+			var ok bool
+			if selected, ok = selected.InScopes(scopes...); !ok {
+				filtered[i] = hps
+				continue
+			}
+		}
+		filtered[i] = selected
+	}
+	return filtered
+}
+
 var configChecker = schema.FieldMap(schema.Fields{
-	AgentRateLimitMax:        schema.ForceInt(),
-	AgentRateLimitRate:       schema.TimeDuration(),
-	AuditingEnabled:          schema.Bool(),
-	AuditLogCaptureArgs:      schema.Bool(),
-	AuditLogMaxSize:          schema.String(),
-	AuditLogMaxBackups:       schema.ForceInt(),
-	AuditLogExcludeMethods:   schema.List(schema.String()),
-	APIPort:                  schema.ForceInt(),
-	APIPortOpenDelay:         schema.String(),
-	ControllerAPIPort:        schema.ForceInt(),
-	ControllerName:           schema.String(),
-	StatePort:                schema.ForceInt(),
-	IdentityURL:              schema.String(),
-	IdentityPublicKey:        schema.String(),
-	SetNUMAControlPolicyKey:  schema.Bool(),
-	AutocertURLKey:           schema.String(),
-	AutocertDNSNameKey:       schema.String(),
-	AllowModelAccessKey:      schema.Bool(),
-	MongoMemoryProfile:       schema.String(),
-	JujuDBSnapChannel:        schema.String(),
-	MaxDebugLogDuration:      schema.TimeDuration(),
-	MaxTxnLogSize:            schema.String(),
-	MaxPruneTxnBatchSize:     schema.ForceInt(),
-	MaxPruneTxnPasses:        schema.ForceInt(),
-	ModelLogfileMaxBackups:   schema.ForceInt(),
-	ModelLogfileMaxSize:      schema.String(),
-	ModelLogsSize:            schema.String(),
-	PruneTxnQueryCount:       schema.ForceInt(),
-	PruneTxnSleepTime:        schema.String(),
-	PublicDNSAddress:         schema.String(),
-	JujuHASpace:              schema.String(),
-	JujuManagementSpace:      schema.String(),
-	CAASOperatorImagePath:    schema.String(),
-	CAASImageRepo:            schema.String(),
-	Features:                 schema.List(schema.String()),
-	CharmStoreURL:            schema.String(),
-	MeteringURL:              schema.String(),
-	MaxCharmStateSize:        schema.ForceInt(),
-	MaxAgentStateSize:        schema.ForceInt(),
-	NonSyncedWritesToRaftLog: schema.Bool(),
+	AgentRateLimitMax:             schema.ForceInt(),
+	AgentRateLimitRate:            schema.TimeDuration(),
+	AgentPingTimeout:              schema.TimeDuration(),
+	AuditingEnabled:               schema.Bool(),
+	AuditLogCaptureArgs:           schema.Bool(),
+	AuditLogMaxSize:               schema.String(),
+	AuditLogMaxBackups:            schema.ForceInt(),
+	AuditLogExcludeMethods:        schema.List(schema.String()),
+	APIPort:                       schema.ForceInt(),
+	APIPortOpenDelay:              schema.String(),
+	ControllerAPIPort:             schema.ForceInt(),
+	ControllerName:                schema.String(),
+	StatePort:                     schema.ForceInt(),
+	IdentityURL:                   schema.String(),
+	IdentityPublicKey:             schema.String(),
+	SetNUMAControlPolicyKey:       schema.Bool(),
+	AutocertURLKey:                schema.String(),
+	AutocertDNSNameKey:            schema.String(),
+	AllowModelAccessKey:           schema.Bool(),
+	MongoMemoryProfile:            schema.String(),
+	JujuDBSnapChannel:             schema.String(),
+	ExternalMongoURI:              schema.String(),
+	ExternalMongoCACert:           schema.String(),
+	BlobstoreBackend:              schema.String(),
+	BlobstoreS3Endpoint:           schema.String(),
+	BlobstoreS3Region:             schema.String(),
+	BlobstoreS3Bucket:             schema.String(),
+	BlobstoreS3AccessKey:          schema.String(),
+	BlobstoreS3SecretKey:          schema.String(),
+	SecretBackend:                 schema.String(),
+	SecretBackendVaultAddress:     schema.String(),
+	SecretBackendVaultToken:       schema.String(),
+	SecretBackendVaultTransitKey:  schema.String(),
+	SecretBackendVaultCACert:      schema.String(),
+	MaxDebugLogDuration:           schema.TimeDuration(),
+	MaxTxnLogSize:                 schema.String(),
+	MaxPruneTxnBatchSize:          schema.ForceInt(),
+	MaxPruneTxnPasses:             schema.ForceInt(),
+	ModelLogfileMaxBackups:        schema.ForceInt(),
+	ModelLogfileMaxSize:           schema.String(),
+	ModelLogsSize:                 schema.String(),
+	PruneTxnQueryCount:            schema.ForceInt(),
+	PruneTxnSleepTime:             schema.String(),
+	PruneTxnMaxSize:               schema.String(),
+	RemoveOrphanedDocuments:       schema.Bool(),
+	PublicDNSAddress:              schema.String(),
+	JujuHASpace:                   schema.String(),
+	JujuManagementSpace:           schema.String(),
+	APIAdvertiseCIDRs:             schema.List(schema.String()),
+	APIAdvertiseScopes:            schema.List(schema.String()),
+	CAASOperatorImagePath:         schema.String(),
+	CAASImageRepo:                 schema.String(),
+	Features:                      schema.List(schema.String()),
+	CharmStoreURL:                 schema.String(),
+	CharmVettingWebhookURL:        schema.String(),
+	CharmSignaturePolicy:          schema.String(),
+	CharmSigningKeys:              schema.String(),
+	CharmSandboxSysctls:           schema.List(schema.String()),
+	CharmSandboxAppArmor:          schema.Bool(),
+	AgentMetadataPublicKey:        schema.String(),
+	CredentialExpiryWarningPeriod: schema.TimeDuration(),
+	CredentialExpiryWebhookURL:    schema.String(),
+	LifecycleWebhookURL:           schema.String(),
+	LifecycleWebhookSecret:        schema.String(),
+	LifecycleWebhookEvents:        schema.List(schema.String()),
+	APIGatewayToken:               schema.String(),
+	ControllerCustomizationScript: schema.String(),
+	MeteringURL:                   schema.String(),
+	MaxCharmStateSize:             schema.ForceInt(),
+	MaxAgentStateSize:             schema.ForceInt(),
+	MaxModelsPerUser:              schema.ForceInt(),
+	MaxUnitsPerModel:              schema.ForceInt(),
+	NonSyncedWritesToRaftLog:      schema.Bool(),
 }, schema.Defaults{
-	AgentRateLimitMax:        schema.Omit,
-	AgentRateLimitRate:       schema.Omit,
-	APIPort:                  DefaultAPIPort,
-	APIPortOpenDelay:         DefaultAPIPortOpenDelay,
-	ControllerAPIPort:        schema.Omit,
-	ControllerName:           schema.Omit,
-	AuditingEnabled:          DefaultAuditingEnabled,
-	AuditLogCaptureArgs:      DefaultAuditLogCaptureArgs,
-	AuditLogMaxSize:          fmt.Sprintf("%vM", DefaultAuditLogMaxSizeMB),
-	AuditLogMaxBackups:       DefaultAuditLogMaxBackups,
-	AuditLogExcludeMethods:   DefaultAuditLogExcludeMethods,
-	StatePort:                DefaultStatePort,
-	IdentityURL:              schema.Omit,
-	IdentityPublicKey:        schema.Omit,
-	SetNUMAControlPolicyKey:  DefaultNUMAControlPolicy,
-	AutocertURLKey:           schema.Omit,
-	AutocertDNSNameKey:       schema.Omit,
-	AllowModelAccessKey:      schema.Omit,
-	MongoMemoryProfile:       DefaultMongoMemoryProfile,
-	JujuDBSnapChannel:        DefaultJujuDBSnapChannel,
-	MaxDebugLogDuration:      DefaultMaxDebugLogDuration,
-	MaxTxnLogSize:            fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
-	MaxPruneTxnBatchSize:     DefaultMaxPruneTxnBatchSize,
-	MaxPruneTxnPasses:        DefaultMaxPruneTxnPasses,
-	ModelLogfileMaxBackups:   DefaultModelLogfileMaxBackups,
-	ModelLogfileMaxSize:      fmt.Sprintf("%vM", DefaultModelLogfileMaxSize),
-	ModelLogsSize:            fmt.Sprintf("%vM", DefaultModelLogsSizeMB),
-	PruneTxnQueryCount:       DefaultPruneTxnQueryCount,
-	PruneTxnSleepTime:        DefaultPruneTxnSleepTime,
-	PublicDNSAddress:         schema.Omit,
-	JujuHASpace:              schema.Omit,
-	JujuManagementSpace:      schema.Omit,
-	CAASOperatorImagePath:    schema.Omit,
-	CAASImageRepo:            schema.Omit,
-	Features:                 schema.Omit,
-	CharmStoreURL:            csclient.ServerURL,
-	MeteringURL:              romulus.DefaultAPIRoot,
-	MaxCharmStateSize:        DefaultMaxCharmStateSize,
-	MaxAgentStateSize:        DefaultMaxAgentStateSize,
-	NonSyncedWritesToRaftLog: DefaultNonSyncedWritesToRaftLog,
+	AgentRateLimitMax:             schema.Omit,
+	AgentRateLimitRate:            schema.Omit,
+	AgentPingTimeout:              schema.Omit,
+	APIPort:                       DefaultAPIPort,
+	APIPortOpenDelay:              DefaultAPIPortOpenDelay,
+	ControllerAPIPort:             schema.Omit,
+	ControllerName:                schema.Omit,
+	AuditingEnabled:               DefaultAuditingEnabled,
+	AuditLogCaptureArgs:           DefaultAuditLogCaptureArgs,
+	AuditLogMaxSize:               fmt.Sprintf("%vM", DefaultAuditLogMaxSizeMB),
+	AuditLogMaxBackups:            DefaultAuditLogMaxBackups,
+	AuditLogExcludeMethods:        DefaultAuditLogExcludeMethods,
+	StatePort:                     DefaultStatePort,
+	IdentityURL:                   schema.Omit,
+	IdentityPublicKey:             schema.Omit,
+	SetNUMAControlPolicyKey:       DefaultNUMAControlPolicy,
+	AutocertURLKey:                schema.Omit,
+	AutocertDNSNameKey:            schema.Omit,
+	AllowModelAccessKey:           schema.Omit,
+	MongoMemoryProfile:            DefaultMongoMemoryProfile,
+	JujuDBSnapChannel:             DefaultJujuDBSnapChannel,
+	ExternalMongoURI:              schema.Omit,
+	ExternalMongoCACert:           schema.Omit,
+	BlobstoreBackend:              schema.Omit,
+	BlobstoreS3Endpoint:           schema.Omit,
+	BlobstoreS3Region:             schema.Omit,
+	BlobstoreS3Bucket:             schema.Omit,
+	BlobstoreS3AccessKey:          schema.Omit,
+	BlobstoreS3SecretKey:          schema.Omit,
+	SecretBackend:                 schema.Omit,
+	SecretBackendVaultAddress:     schema.Omit,
+	SecretBackendVaultToken:       schema.Omit,
+	SecretBackendVaultTransitKey:  schema.Omit,
+	SecretBackendVaultCACert:      schema.Omit,
+	MaxDebugLogDuration:           DefaultMaxDebugLogDuration,
+	MaxTxnLogSize:                 fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
+	MaxPruneTxnBatchSize:          DefaultMaxPruneTxnBatchSize,
+	MaxPruneTxnPasses:             DefaultMaxPruneTxnPasses,
+	ModelLogfileMaxBackups:        DefaultModelLogfileMaxBackups,
+	ModelLogfileMaxSize:           fmt.Sprintf("%vM", DefaultModelLogfileMaxSize),
+	ModelLogsSize:                 fmt.Sprintf("%vM", DefaultModelLogsSizeMB),
+	PruneTxnQueryCount:            DefaultPruneTxnQueryCount,
+	PruneTxnSleepTime:             DefaultPruneTxnSleepTime,
+	PruneTxnMaxSize:               fmt.Sprintf("%vM", DefaultPruneTxnMaxSizeMB),
+	RemoveOrphanedDocuments:       DefaultRemoveOrphanedDocuments,
+	PublicDNSAddress:              schema.Omit,
+	JujuHASpace:                   schema.Omit,
+	JujuManagementSpace:           schema.Omit,
+	APIAdvertiseCIDRs:             schema.Omit,
+	APIAdvertiseScopes:            schema.Omit,
+	CAASOperatorImagePath:         schema.Omit,
+	CAASImageRepo:                 schema.Omit,
+	Features:                      schema.Omit,
+	CharmStoreURL:                 csclient.ServerURL,
+	CharmVettingWebhookURL:        schema.Omit,
+	CharmSignaturePolicy:          schema.Omit,
+	CharmSigningKeys:              schema.Omit,
+	CharmSandboxSysctls:           schema.Omit,
+	CharmSandboxAppArmor:          schema.Omit,
+	AgentMetadataPublicKey:        schema.Omit,
+	CredentialExpiryWarningPeriod: DefaultCredentialExpiryWarningPeriod,
+	CredentialExpiryWebhookURL:    schema.Omit,
+	LifecycleWebhookURL:           schema.Omit,
+	LifecycleWebhookSecret:        schema.Omit,
+	LifecycleWebhookEvents:        schema.Omit,
+	APIGatewayToken:               schema.Omit,
+	ControllerCustomizationScript: schema.Omit,
+	MeteringURL:                   romulus.DefaultAPIRoot,
+	MaxCharmStateSize:             DefaultMaxCharmStateSize,
+	MaxAgentStateSize:             DefaultMaxAgentStateSize,
+	MaxModelsPerUser:              schema.Omit,
+	MaxUnitsPerModel:              schema.Omit,
+	NonSyncedWritesToRaftLog:      DefaultNonSyncedWritesToRaftLog,
 })
 
 // ConfigSchema holds information on all the fields defined by
@@ -1204,6 +2013,10 @@ var ConfigSchema = environschema.Fields{
 		Description: "The time taken to add a new token to the ratelimit bucket",
 		Type:        environschema.Tstring,
 	},
+	AgentPingTimeout: {
+		Description: "The time the controller waits for a ping from a connected agent before closing its connection",
+		Type:        environschema.Tstring,
+	},
 	AuditingEnabled: {
 		Description: "Determines if the controller records auditing information",
 		Type:        environschema.Tbool,
@@ -1281,6 +2094,60 @@ they don't have any access rights to the controller itself`,
 		Type:        environschema.Tstring,
 		Description: `Sets channel for installing mongo snaps when bootstrapping on focal or later`,
 	},
+	ExternalMongoURI: {
+		Type: environschema.Tstring,
+		Description: `A mongodb:// connection string for an externally managed replica set
+to use instead of the controller's own juju-db; requires the "replicaSet"
+option and the external-mongo feature flag`,
+	},
+	ExternalMongoCACert: {
+		Type:        environschema.Tstring,
+		Description: `The CA certificate that signed the external replica set members' certificates`,
+	},
+	BlobstoreBackend: {
+		Type:        environschema.Tstring,
+		Description: `Where the controller stores blob data such as charms and agent binaries: "mongo" or "s3"`,
+	},
+	BlobstoreS3Endpoint: {
+		Type:        environschema.Tstring,
+		Description: `The URL of the S3-compatible object store used for blob data (defaults to AWS S3)`,
+	},
+	BlobstoreS3Region: {
+		Type:        environschema.Tstring,
+		Description: `The region of the S3 object store used for blob data`,
+	},
+	BlobstoreS3Bucket: {
+		Type:        environschema.Tstring,
+		Description: `The S3 bucket in which blob data is stored`,
+	},
+	BlobstoreS3AccessKey: {
+		Type:        environschema.Tstring,
+		Description: `The access key used to access the blob data S3 bucket`,
+	},
+	BlobstoreS3SecretKey: {
+		Type:        environschema.Tstring,
+		Description: `The secret key used to access the blob data S3 bucket`,
+	},
+	SecretBackend: {
+		Type:        environschema.Tstring,
+		Description: `Where the keys protecting controller secrets such as cloud credentials are held: "internal", or "vault" to encrypt them with keys wrapped by a Vault transit key`,
+	},
+	SecretBackendVaultAddress: {
+		Type:        environschema.Tstring,
+		Description: `The URL of the Vault server used to wrap the keys protecting controller secrets`,
+	},
+	SecretBackendVaultToken: {
+		Type:        environschema.Tstring,
+		Description: `The token used to authenticate to the Vault server`,
+	},
+	SecretBackendVaultTransitKey: {
+		Type:        environschema.Tstring,
+		Description: `The Vault transit key which wraps the keys protecting controller secrets, as "<mount>/<key>" (defaults to "transit/juju")`,
+	},
+	SecretBackendVaultCACert: {
+		Type:        environschema.Tstring,
+		Description: `The CA certificate that signed the Vault server's certificate`,
+	},
 	MaxDebugLogDuration: {
 		Type:        environschema.Tstring,
 		Description: `The maximum amout of time a debug-log session is allowed to run`,
@@ -1307,7 +2174,7 @@ they don't have any access rights to the controller itself`,
 	},
 	ModelLogsSize: {
 		Type:        environschema.Tstring,
-		Description: `The size of the capped collections used to hold the logs for the models`,
+		Description: `The size of the capped collections used to hold the logs for the models; changes are applied to existing models without a restart`,
 	},
 	PruneTxnQueryCount: {
 		Type:        environschema.Tint,
@@ -1317,6 +2184,14 @@ they don't have any access rights to the controller itself`,
 		Type:        environschema.Tstring,
 		Description: `The amount of time to sleep between processing each batch query`,
 	},
+	PruneTxnMaxSize: {
+		Type:        environschema.Tstring,
+		Description: `The size of the txns collection above which transactions are pruned regardless of their growth (0 disables)`,
+	},
+	RemoveOrphanedDocuments: {
+		Type:        environschema.Tbool,
+		Description: `Whether orphaned documents found by the controller are removed rather than only reported`,
+	},
 	PublicDNSAddress: {
 		Type:        environschema.Tstring,
 		Description: `Public DNS address (with port) of the controller.`,
@@ -1329,6 +2204,14 @@ they don't have any access rights to the controller itself`,
 		Type:        environschema.Tstring,
 		Description: `The network space that agents should use to communicate with controllers`,
 	},
+	APIAdvertiseCIDRs: {
+		Type:        environschema.FieldType("list of strings"),
+		Description: `If set, only controller API addresses within these CIDRs are advertised to agents and clients`,
+	},
+	APIAdvertiseScopes: {
+		Type:        environschema.FieldType("list of strings"),
+		Description: `If set, only controller API addresses with these scopes (public, local-cloud, local-fan, local-machine) are advertised to agents and clients`,
+	},
 	CAASOperatorImagePath: {
 		Type: environschema.Tstring,
 		Description: `(deprected) The url of the docker image used for the application operator.
@@ -1346,6 +2229,58 @@ Use "caas-image-repo" instead.`,
 		Type:        environschema.Tstring,
 		Description: `The url for charmstore API calls`,
 	},
+	CharmVettingWebhookURL: {
+		Type:        environschema.Tstring,
+		Description: `The url of a webhook which is sent the metadata and hash of each charm before it is deployed, and which may reject the deploy`,
+	},
+	CharmSignaturePolicy: {
+		Type:        environschema.Tstring,
+		Description: `Whether charm signatures are checked: "none", "verify" to check any signature uploaded with a charm, or "require" to also reject charms without a verified signature`,
+	},
+	CharmSigningKeys: {
+		Type:        environschema.Tstring,
+		Description: `Armored PGP public keys and PEM encoded cosign public keys trusted to sign charms`,
+	},
+	CharmSandboxSysctls: {
+		Type:        environschema.FieldType("list of strings"),
+		Description: `The kernel parameters charms may set on their machines in sandbox-profile.yaml; an entry ending in "*" allows every parameter starting with what precedes it`,
+	},
+	CharmSandboxAppArmor: {
+		Type:        environschema.Tbool,
+		Description: `Whether charms may load AppArmor profiles on their machines in sandbox-profile.yaml`,
+	},
+	AgentMetadataPublicKey: {
+		Type:        environschema.Tstring,
+		Description: `An armored PGP public key; if set, agent metadata from a model's agent-metadata-url must be signed with it instead of the Juju key`,
+	},
+	CredentialExpiryWarningPeriod: {
+		Type:        environschema.Tstring,
+		Description: `How long before a cloud credential expires that models using it are warned (0 disables the warning)`,
+	},
+	CredentialExpiryWebhookURL: {
+		Type:        environschema.Tstring,
+		Description: `The url of a webhook which is notified when a cloud credential is about to expire or has expired`,
+	},
+	LifecycleWebhookURL: {
+		Type:        environschema.Tstring,
+		Description: `The url of a webhook which is notified when machines are provisioned, units are started or removed, and models are destroyed`,
+	},
+	LifecycleWebhookSecret: {
+		Type:        environschema.Tstring,
+		Description: `The key used to sign lifecycle webhook notifications with HMAC-SHA256; notifications are not signed if it is empty`,
+	},
+	LifecycleWebhookEvents: {
+		Type:        environschema.FieldType("list of strings"),
+		Description: `The lifecycle events posted to the lifecycle webhook: any of "machine-provisioned", "unit-started", "unit-removed" and "model-destroyed" (defaults to all of them)`,
+	},
+	APIGatewayToken: {
+		Type:        environschema.Tstring,
+		Description: `The bearer token accepted by the read-only API gateway, served as REST at /gateway/v1 and as the gRPC service juju.gateway.v1.Gateway; the gateway is disabled if it is empty`,
+	},
+	ControllerCustomizationScript: {
+		Type:        environschema.Tstring,
+		Description: `A script run once on each controller machine after it comes up, and again whenever it changes; its progress is shown by show-controller`,
+	},
 	MeteringURL: {
 		Type:        environschema.Tstring,
 		Description: `The url for metrics`,
@@ -1358,6 +2293,14 @@ Use "caas-image-repo" instead.`,
 		Type:        environschema.Tint,
 		Description: `The maximum size (in bytes) of internal state data that agents can store to the controller`,
 	},
+	MaxModelsPerUser: {
+		Type:        environschema.Tint,
+		Description: `The maximum number of models a single user may own on the controller (0 means no limit)`,
+	},
+	MaxUnitsPerModel: {
+		Type:        environschema.Tint,
+		Description: `The maximum number of units that may be deployed to a single model (0 means no limit)`,
+	},
 	NonSyncedWritesToRaftLog: {
 		Type:        environschema.Tbool,
 		Description: `Do not perform fsync calls after appending entries to the raft log. Disabling sync improves performance at the cost of reliability`,
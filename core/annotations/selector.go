@@ -0,0 +1,137 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package annotations
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// Operator is the operator of a selector requirement.
+type Operator string
+
+const (
+	// Equals requires the annotation to have the given value.
+	Equals Operator = "="
+
+	// NotEquals requires the annotation not to have the given value.
+	// Entities without the annotation meet the requirement.
+	NotEquals Operator = "!="
+
+	// Exists requires the annotation to be set.
+	Exists Operator = "exists"
+
+	// DoesNotExist requires the annotation not to be set.
+	DoesNotExist Operator = "!"
+)
+
+// Requirement is a single requirement of a selector on the
+// annotation with the given key.
+type Requirement struct {
+	Key      string
+	Operator Operator
+	Value    string
+}
+
+// Matches returns whether the annotations meet the requirement.
+func (r Requirement) Matches(annotations map[string]string) bool {
+	value, ok := annotations[r.Key]
+	switch r.Operator {
+	case Equals:
+		return ok && value == r.Value
+	case NotEquals:
+		return !ok || value != r.Value
+	case Exists:
+		return ok
+	case DoesNotExist:
+		return !ok
+	}
+	return false
+}
+
+// String returns the requirement in the form it is parsed from.
+func (r Requirement) String() string {
+	switch r.Operator {
+	case Exists:
+		return r.Key
+	case DoesNotExist:
+		return "!" + r.Key
+	}
+	return r.Key + string(r.Operator) + r.Value
+}
+
+// Selector selects entities by their annotations, in the style of
+// label selectors, e.g. "env=prod,tier!=web". An entity is selected
+// if its annotations meet all the requirements of the selector.
+type Selector []Requirement
+
+// ParseSelector parses a selector from a comma separated list of
+// requirements, each of which is one of:
+//
+//	key=value, key==value  the annotation has the value
+//	key!=value             the annotation does not have the value
+//	key                    the annotation is set
+//	!key                   the annotation is not set
+func ParseSelector(s string) (Selector, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, errors.NotValidf("empty selector")
+	}
+	var selector Selector
+	for _, term := range strings.Split(s, ",") {
+		r, err := parseRequirement(strings.TrimSpace(term))
+		if err != nil {
+			return nil, errors.Annotatef(err, "selector %q", s)
+		}
+		selector = append(selector, r)
+	}
+	return selector, nil
+}
+
+func parseRequirement(term string) (Requirement, error) {
+	var r Requirement
+	switch {
+	case term == "":
+		return r, errors.NotValidf("empty requirement")
+	case strings.HasPrefix(term, "!") && !strings.Contains(term, "="):
+		r = Requirement{Key: strings.TrimSpace(term[1:]), Operator: DoesNotExist}
+	case strings.Contains(term, "!="):
+		parts := strings.SplitN(term, "!=", 2)
+		r = Requirement{Key: parts[0], Operator: NotEquals, Value: parts[1]}
+	case strings.Contains(term, "="):
+		parts := strings.SplitN(term, "=", 2)
+		r = Requirement{Key: parts[0], Operator: Equals, Value: strings.TrimPrefix(parts[1], "=")}
+	default:
+		r = Requirement{Key: term, Operator: Exists}
+	}
+	r.Key = strings.TrimSpace(r.Key)
+	r.Value = strings.TrimSpace(r.Value)
+	if r.Key == "" || strings.ContainsAny(r.Key, " \t=!") {
+		return r, errors.NotValidf("requirement %q", term)
+	}
+	if (r.Operator == Equals || r.Operator == NotEquals) && r.Value == "" {
+		return r, errors.NotValidf("requirement %q with no value", term)
+	}
+	return r, nil
+}
+
+// Matches returns whether the annotations meet all the requirements
+// of the selector.
+func (s Selector) Matches(annotations map[string]string) bool {
+	for _, r := range s {
+		if !r.Matches(annotations) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the selector in the form it is parsed from.
+func (s Selector) String() string {
+	terms := make([]string, len(s))
+	for i, r := range s {
+		terms[i] = r.String()
+	}
+	return strings.Join(terms, ",")
+}
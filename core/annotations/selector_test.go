@@ -0,0 +1,84 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package annotations_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	jujuannotations "github.com/juju/juju/core/annotations"
+	"github.com/juju/juju/testing"
+)
+
+type selectorSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&selectorSuite{})
+
+func (s *selectorSuite) TestParseSelector(c *gc.C) {
+	selector, err := jujuannotations.ParseSelector(" env=prod, tier != web,tier==db,backup,!deprecated ")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(selector, jc.DeepEquals, jujuannotations.Selector{
+		{Key: "env", Operator: jujuannotations.Equals, Value: "prod"},
+		{Key: "tier", Operator: jujuannotations.NotEquals, Value: "web"},
+		{Key: "tier", Operator: jujuannotations.Equals, Value: "db"},
+		{Key: "backup", Operator: jujuannotations.Exists},
+		{Key: "deprecated", Operator: jujuannotations.DoesNotExist},
+	})
+	c.Assert(selector.String(), gc.Equals, "env=prod,tier!=web,tier=db,backup,!deprecated")
+}
+
+func (s *selectorSuite) TestParseSelectorErrors(c *gc.C) {
+	for i, test := range []struct {
+		selector string
+		err      string
+	}{{
+		selector: "",
+		err:      "empty selector not valid",
+	}, {
+		selector: "env=prod,",
+		err:      `selector "env=prod,": empty requirement not valid`,
+	}, {
+		selector: "env=",
+		err:      `selector "env=": requirement "env=" with no value not valid`,
+	}, {
+		selector: "=prod",
+		err:      `selector "=prod": requirement "=prod" not valid`,
+	}, {
+		selector: "!env=prod",
+		err:      `selector "!env=prod": requirement "!env=prod" not valid`,
+	}, {
+		selector: "my env",
+		err:      `selector "my env": requirement "my env" not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.selector)
+		_, err := jujuannotations.ParseSelector(test.selector)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *selectorSuite) TestMatches(c *gc.C) {
+	annotations := map[string]string{"env": "prod", "tier": "web"}
+	for i, test := range []struct {
+		selector string
+		matches  bool
+	}{
+		{"env=prod", true},
+		{"env=staging", false},
+		{"env=prod,tier=web", true},
+		{"env=prod,tier!=web", false},
+		{"env!=staging", true},
+		{"owner!=bob", true},
+		{"tier", true},
+		{"owner", false},
+		{"!owner", true},
+		{"!env", false},
+	} {
+		c.Logf("test %d: %q", i, test.selector)
+		selector, err := jujuannotations.ParseSelector(test.selector)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(selector.Matches(annotations), gc.Equals, test.matches)
+	}
+}
@@ -0,0 +1,19 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+// CrossModelIngressAddressesConfigKey is the application config key that
+// pins the ingress address advertised for endpoints in cross-model
+// relations, such as the public address of a NAT gateway in front of the
+// units. Its value is a comma-separated list of endpoint=address pairs.
+// The address replaces the public or private unit address that would
+// otherwise be selected for relations to applications in other models.
+const CrossModelIngressAddressesConfigKey = "cross-model-ingress-addresses"
+
+// CrossModelIngressAddresses returns the pinned cross-model ingress
+// addresses held in the application config, keyed by endpoint name.
+// An empty map is returned if no addresses are pinned.
+func (c ConfigAttributes) CrossModelIngressAddresses() (map[string]string, error) {
+	return c.endpointAddresses(CrossModelIngressAddressesConfigKey)
+}
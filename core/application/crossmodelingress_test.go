@@ -0,0 +1,44 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type CrossModelIngressAddressesSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&CrossModelIngressAddressesSuite{})
+
+func (s *CrossModelIngressAddressesSuite) TestDefault(c *gc.C) {
+	addrs, err := application.ConfigAttributes(nil).CrossModelIngressAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addrs, gc.HasLen, 0)
+}
+
+func (s *CrossModelIngressAddressesSuite) TestAddresses(c *gc.C) {
+	addrs, err := application.ConfigAttributes{
+		"cross-model-ingress-addresses": "db=203.0.113.10, admin=2001:db8::10",
+	}.CrossModelIngressAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addrs, jc.DeepEquals, map[string]string{
+		"db":    "203.0.113.10",
+		"admin": "2001:db8::10",
+	})
+}
+
+func (s *CrossModelIngressAddressesSuite) TestInvalid(c *gc.C) {
+	_, err := application.ConfigAttributes{
+		"cross-model-ingress-addresses": "db=nat.example.com",
+	}.CrossModelIngressAddresses()
+	c.Assert(err, gc.ErrorMatches, `cross-model-ingress-addresses address "nat.example.com" for endpoint "db" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
@@ -0,0 +1,62 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"net"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// ExternalLBAddressesConfigKey is the application config key that declares
+// endpoints fronted by an external load balancer, such as an HAProxy or
+// keepalived virtual IP. Its value is a comma-separated list of
+// endpoint=address pairs. The address is advertised as the ingress
+// address for the endpoint instead of the addresses of the units.
+const ExternalLBAddressesConfigKey = "external-lb-addresses"
+
+// ExternalLBAddresses returns the virtual addresses held in the application
+// config, keyed by endpoint name. An empty map is returned if no endpoints
+// are behind an external load balancer.
+func (c ConfigAttributes) ExternalLBAddresses() (map[string]string, error) {
+	return c.endpointAddresses(ExternalLBAddressesConfigKey)
+}
+
+// endpointAddresses returns the IP addresses held in the application
+// config under the input key as a comma-separated list of
+// endpoint=address pairs, keyed by endpoint name.
+func (c ConfigAttributes) endpointAddresses(key string) (map[string]string, error) {
+	addresses := make(map[string]string)
+	val, ok := c[key]
+	if !ok {
+		return addresses, nil
+	}
+	str, ok := val.(string)
+	if !ok {
+		return nil, errors.NotValidf("%s value %v", key, val)
+	}
+	for _, pair := range strings.Split(str, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.NotValidf("%s entry %q", key, pair)
+		}
+		endpoint, address := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if endpoint == "" {
+			return nil, errors.NotValidf("%s entry %q with no endpoint", key, pair)
+		}
+		if net.ParseIP(address) == nil {
+			return nil, errors.NotValidf("%s address %q for endpoint %q", key, address, endpoint)
+		}
+		if _, ok := addresses[endpoint]; ok {
+			return nil, errors.NotValidf("%s duplicate endpoint %q", key, endpoint)
+		}
+		addresses[endpoint] = address
+	}
+	return addresses, nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ExternalLBAddressesSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&ExternalLBAddressesSuite{})
+
+func (s *ExternalLBAddressesSuite) TestDefault(c *gc.C) {
+	addrs, err := application.ConfigAttributes(nil).ExternalLBAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addrs, gc.HasLen, 0)
+
+	addrs, err = application.ConfigAttributes{
+		"external-lb-addresses": "",
+	}.ExternalLBAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addrs, gc.HasLen, 0)
+}
+
+func (s *ExternalLBAddressesSuite) TestAddresses(c *gc.C) {
+	addrs, err := application.ConfigAttributes{
+		"external-lb-addresses": "website=10.0.0.100, admin = 2001:db8::10",
+	}.ExternalLBAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addrs, jc.DeepEquals, map[string]string{
+		"website": "10.0.0.100",
+		"admin":   "2001:db8::10",
+	})
+}
+
+func (s *ExternalLBAddressesSuite) TestInvalid(c *gc.C) {
+	for _, test := range []struct {
+		value interface{}
+		err   string
+	}{{
+		value: true,
+		err:   `external-lb-addresses value true not valid`,
+	}, {
+		value: "website",
+		err:   `external-lb-addresses entry "website" not valid`,
+	}, {
+		value: "=10.0.0.100",
+		err:   `external-lb-addresses entry "=10.0.0.100" with no endpoint not valid`,
+	}, {
+		value: "website=lb.example.com",
+		err:   `external-lb-addresses address "lb.example.com" for endpoint "website" not valid`,
+	}, {
+		value: "website=10.0.0.100,website=10.0.0.101",
+		err:   `external-lb-addresses duplicate endpoint "website" not valid`,
+	}} {
+		_, err := application.ConfigAttributes{
+			"external-lb-addresses": test.value,
+		}.ExternalLBAddresses()
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+)
+
+// FirewallModeConfigKey is the application config key that overrides the
+// model's firewall-mode for the application's units. When unset, the
+// model's firewall-mode applies.
+const FirewallModeConfigKey = "firewall-mode"
+
+// The firewall modes an application may override the model's
+// firewall-mode with. They match the values of the model setting.
+const (
+	FirewallModeInstance = "instance"
+	FirewallModeGlobal   = "global"
+	FirewallModeNone     = "none"
+)
+
+// FirewallMode returns the firewall mode held in the application config,
+// or an empty string if the model's firewall-mode applies.
+func (c ConfigAttributes) FirewallMode() (string, error) {
+	val, ok := c[FirewallModeConfigKey]
+	if !ok {
+		return "", nil
+	}
+	mode, ok := val.(string)
+	if !ok {
+		return "", errors.NotValidf("%s value %v", FirewallModeConfigKey, val)
+	}
+	switch mode {
+	case "", FirewallModeInstance, FirewallModeGlobal, FirewallModeNone:
+		return mode, nil
+	}
+	return "", errors.NotValidf("%s %q", FirewallModeConfigKey, mode)
+}
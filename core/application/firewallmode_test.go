@@ -0,0 +1,48 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type FirewallModeSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&FirewallModeSuite{})
+
+func (s *FirewallModeSuite) TestDefault(c *gc.C) {
+	mode, err := application.ConfigAttributes(nil).FirewallMode()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mode, gc.Equals, "")
+}
+
+func (s *FirewallModeSuite) TestModes(c *gc.C) {
+	for _, expected := range []string{"", "instance", "global", "none"} {
+		mode, err := application.ConfigAttributes{
+			"firewall-mode": expected,
+		}.FirewallMode()
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(mode, gc.Equals, expected)
+	}
+}
+
+func (s *FirewallModeSuite) TestInvalid(c *gc.C) {
+	_, err := application.ConfigAttributes{
+		"firewall-mode": "sometimes",
+	}.FirewallMode()
+	c.Assert(err, gc.ErrorMatches, `firewall-mode "sometimes" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	_, err = application.ConfigAttributes{
+		"firewall-mode": true,
+	}.FirewallMode()
+	c.Assert(err, gc.ErrorMatches, `firewall-mode value true not valid`)
+}
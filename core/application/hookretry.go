@@ -0,0 +1,111 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/charm/v9/hooks"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+)
+
+const (
+	// HookRetryConfigKey is the application config key that enables or
+	// disables automatic retries of failed hooks. When unset, the model's
+	// automatically-retry-hooks setting applies.
+	HookRetryConfigKey = "hook-retry"
+
+	// HookRetryMaxConfigKey is the application config key holding the
+	// maximum number of automatic retries of a failed hook. Zero means
+	// there is no limit.
+	HookRetryMaxConfigKey = "hook-retry-max"
+
+	// HookRetryMaxDelayConfigKey is the application config key holding the
+	// longest delay between automatic retries of a failed hook.
+	HookRetryMaxDelayConfigKey = "hook-retry-max-delay"
+
+	// HookRetryHooksConfigKey is the application config key holding the
+	// space separated kinds of hook that are retried automatically. When
+	// empty, all failed hooks are retried.
+	HookRetryHooksConfigKey = "hook-retry-hooks"
+)
+
+// HookRetryPolicy describes how the units of an application retry failed
+// hooks automatically.
+type HookRetryPolicy struct {
+	// Enabled overrides the model's automatically-retry-hooks setting
+	// when not nil.
+	Enabled *bool
+
+	// MaxRetries is the maximum number of automatic retries of a failed
+	// hook. Zero means there is no limit.
+	MaxRetries int
+
+	// MaxDelay is the longest delay between automatic retries. Zero
+	// means the default delay applies.
+	MaxDelay time.Duration
+
+	// Hooks holds the kinds of hook that are retried automatically.
+	// When empty, all failed hooks are retried.
+	Hooks []string
+}
+
+// HookRetryPolicy returns the hook retry policy held in the application
+// config, or an error if any of the values are not valid.
+func (c ConfigAttributes) HookRetryPolicy() (HookRetryPolicy, error) {
+	var policy HookRetryPolicy
+	if val, ok := c[HookRetryConfigKey]; ok {
+		enabled, ok := val.(bool)
+		if !ok {
+			return HookRetryPolicy{}, errors.NotValidf("%s value %v", HookRetryConfigKey, val)
+		}
+		policy.Enabled = &enabled
+	}
+	if val, ok := c[HookRetryMaxConfigKey]; ok {
+		var maxRetries int
+		switch v := val.(type) {
+		case int:
+			maxRetries = v
+		case int64:
+			maxRetries = int(v)
+		case float64:
+			maxRetries = int(v)
+		default:
+			return HookRetryPolicy{}, errors.NotValidf("%s value %v", HookRetryMaxConfigKey, val)
+		}
+		if maxRetries < 0 {
+			return HookRetryPolicy{}, errors.NotValidf("negative %s %d", HookRetryMaxConfigKey, maxRetries)
+		}
+		policy.MaxRetries = maxRetries
+	}
+	if val := c.GetString(HookRetryMaxDelayConfigKey, ""); val != "" {
+		delay, err := time.ParseDuration(val)
+		if err != nil {
+			return HookRetryPolicy{}, errors.NotValidf("%s value %q", HookRetryMaxDelayConfigKey, val)
+		}
+		if delay <= 0 {
+			return HookRetryPolicy{}, errors.NotValidf("non-positive %s %q", HookRetryMaxDelayConfigKey, val)
+		}
+		policy.MaxDelay = delay
+	}
+	if val := c.GetString(HookRetryHooksConfigKey, ""); val != "" {
+		known := set.NewStrings()
+		for _, kinds := range [][]hooks.Kind{
+			hooks.UnitHooks(), hooks.RelationHooks(), hooks.StorageHooks(), hooks.WorkloadHooks(),
+		} {
+			for _, kind := range kinds {
+				known.Add(string(kind))
+			}
+		}
+		for _, name := range strings.Fields(val) {
+			if !known.Contains(name) {
+				return HookRetryPolicy{}, errors.NotValidf("%s hook %q", HookRetryHooksConfigKey, name)
+			}
+			policy.Hooks = append(policy.Hooks, name)
+		}
+	}
+	return policy, nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type HookRetryPolicySuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&HookRetryPolicySuite{})
+
+func (s *HookRetryPolicySuite) TestDefaults(c *gc.C) {
+	policy, err := application.ConfigAttributes(nil).HookRetryPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, application.HookRetryPolicy{})
+}
+
+func (s *HookRetryPolicySuite) TestPolicy(c *gc.C) {
+	policy, err := application.ConfigAttributes{
+		"hook-retry":           false,
+		"hook-retry-max":       int64(3),
+		"hook-retry-max-delay": "30s",
+		"hook-retry-hooks":     "install  config-changed relation-changed",
+	}.HookRetryPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	enabled := false
+	c.Assert(policy, jc.DeepEquals, application.HookRetryPolicy{
+		Enabled:    &enabled,
+		MaxRetries: 3,
+		MaxDelay:   30 * time.Second,
+		Hooks:      []string{"install", "config-changed", "relation-changed"},
+	})
+}
+
+func (s *HookRetryPolicySuite) TestInvalid(c *gc.C) {
+	for i, test := range []struct {
+		attrs application.ConfigAttributes
+		err   string
+	}{{
+		attrs: application.ConfigAttributes{"hook-retry-max": -1},
+		err:   "negative hook-retry-max -1 not valid",
+	}, {
+		attrs: application.ConfigAttributes{"hook-retry-max-delay": "soon"},
+		err:   `hook-retry-max-delay value "soon" not valid`,
+	}, {
+		attrs: application.ConfigAttributes{"hook-retry-max-delay": "0s"},
+		err:   `non-positive hook-retry-max-delay "0s" not valid`,
+	}, {
+		attrs: application.ConfigAttributes{"hook-retry-hooks": "install db-relation-changed"},
+		err:   `hook-retry-hooks hook "db-relation-changed" not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.attrs)
+		_, err := test.attrs.HookRetryPolicy()
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/proxy"
+)
+
+// The following application config keys override the model's proxy
+// settings for the units of a single application. They take the same
+// values as the model config keys of the same names.
+const (
+	JujuHTTPProxyConfigKey  = "juju-http-proxy"
+	JujuHTTPSProxyConfigKey = "juju-https-proxy"
+	JujuNoProxyConfigKey    = "juju-no-proxy"
+	AptHTTPProxyConfigKey   = "apt-http-proxy"
+	AptHTTPSProxyConfigKey  = "apt-https-proxy"
+	SnapHTTPProxyConfigKey  = "snap-http-proxy"
+	SnapHTTPSProxyConfigKey = "snap-https-proxy"
+)
+
+// ProxyConfigKeys lists the application config keys that override
+// the model's proxy settings.
+var ProxyConfigKeys = []string{
+	JujuHTTPProxyConfigKey,
+	JujuHTTPSProxyConfigKey,
+	JujuNoProxyConfigKey,
+	AptHTTPProxyConfigKey,
+	AptHTTPSProxyConfigKey,
+	SnapHTTPProxyConfigKey,
+	SnapHTTPSProxyConfigKey,
+}
+
+// HasProxyOverrides returns true if any of the application's proxy
+// config keys are set.
+func (c ConfigAttributes) HasProxyOverrides() bool {
+	for _, key := range ProxyConfigKeys {
+		if c.proxyOverride(key, "") != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// JujuProxySettings returns the model's juju proxy settings with any
+// application overrides applied.
+func (c ConfigAttributes) JujuProxySettings(model proxy.Settings) proxy.Settings {
+	model.Http = c.proxyOverride(JujuHTTPProxyConfigKey, model.Http)
+	model.Https = c.proxyOverride(JujuHTTPSProxyConfigKey, model.Https)
+	model.NoProxy = c.proxyOverride(JujuNoProxyConfigKey, model.NoProxy)
+	return model
+}
+
+// AptProxySettings returns the model's apt proxy settings with any
+// application overrides applied.
+func (c ConfigAttributes) AptProxySettings(model proxy.Settings) proxy.Settings {
+	model.Http = c.proxyOverride(AptHTTPProxyConfigKey, model.Http)
+	model.Https = c.proxyOverride(AptHTTPSProxyConfigKey, model.Https)
+	return model
+}
+
+// SnapProxySettings returns the model's snap proxy settings with any
+// application overrides applied.
+func (c ConfigAttributes) SnapProxySettings(model proxy.Settings) proxy.Settings {
+	model.Http = c.proxyOverride(SnapHTTPProxyConfigKey, model.Http)
+	model.Https = c.proxyOverride(SnapHTTPSProxyConfigKey, model.Https)
+	return model
+}
+
+// proxyOverride returns the value of the given proxy config key, or
+// the supplied model value if the key is unset or empty.
+func (c ConfigAttributes) proxyOverride(key, modelValue string) string {
+	if value, _ := c[key].(string); value != "" {
+		return value
+	}
+	return modelValue
+}
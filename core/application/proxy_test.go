@@ -0,0 +1,67 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/proxy"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ProxyOverridesSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&ProxyOverridesSuite{})
+
+var modelProxy = proxy.Settings{
+	Http:    "http://model.proxy:3128",
+	Https:   "https://model.proxy:3128",
+	Ftp:     "ftp://model.proxy:21",
+	NoProxy: "10.0.0.1",
+}
+
+func (s *ProxyOverridesSuite) TestNoOverrides(c *gc.C) {
+	cfg := application.ConfigAttributes{
+		"juju-http-proxy": "",
+		"trust":           true,
+	}
+	c.Assert(cfg.HasProxyOverrides(), jc.IsFalse)
+	c.Assert(cfg.JujuProxySettings(modelProxy), jc.DeepEquals, modelProxy)
+	c.Assert(cfg.AptProxySettings(modelProxy), jc.DeepEquals, modelProxy)
+	c.Assert(cfg.SnapProxySettings(modelProxy), jc.DeepEquals, modelProxy)
+	c.Assert(application.ConfigAttributes(nil).JujuProxySettings(modelProxy), jc.DeepEquals, modelProxy)
+}
+
+func (s *ProxyOverridesSuite) TestOverrides(c *gc.C) {
+	cfg := application.ConfigAttributes{
+		"juju-http-proxy":  "http://corp.proxy:8080",
+		"juju-https-proxy": "https://corp.proxy:8443",
+		"juju-no-proxy":    "10.0.0.0/8",
+		"apt-http-proxy":   "http://apt.proxy:3142",
+		"snap-https-proxy": "https://snap.proxy:3128",
+	}
+	c.Assert(cfg.HasProxyOverrides(), jc.IsTrue)
+	c.Assert(cfg.JujuProxySettings(modelProxy), jc.DeepEquals, proxy.Settings{
+		Http:    "http://corp.proxy:8080",
+		Https:   "https://corp.proxy:8443",
+		Ftp:     "ftp://model.proxy:21",
+		NoProxy: "10.0.0.0/8",
+	})
+	c.Assert(cfg.AptProxySettings(modelProxy), jc.DeepEquals, proxy.Settings{
+		Http:    "http://apt.proxy:3142",
+		Https:   "https://model.proxy:3128",
+		Ftp:     "ftp://model.proxy:21",
+		NoProxy: "10.0.0.1",
+	})
+	c.Assert(cfg.SnapProxySettings(modelProxy), jc.DeepEquals, proxy.Settings{
+		Http:    "http://model.proxy:3128",
+		Https:   "https://snap.proxy:3128",
+		Ftp:     "ftp://model.proxy:21",
+		NoProxy: "10.0.0.1",
+	})
+}
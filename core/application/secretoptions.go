@@ -0,0 +1,45 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// SecretOptionsConfigKey is the application config key that declares
+// which of the charm's string config options hold secrets. Its value is a
+// comma-separated list of option names. The values of secret options are
+// stored sealed, are never returned to clients, and are only delivered to
+// units on request through the secret-config-get hook tool.
+const SecretOptionsConfigKey = "secret-options"
+
+// SecretOptionType is the type reported for charm config options
+// declared as secret.
+const SecretOptionType = "secret"
+
+// SecretOptions returns the names of the charm config options declared as
+// secret in the application config, in the order they were declared.
+func (c ConfigAttributes) SecretOptions() ([]string, error) {
+	val, ok := c[SecretOptionsConfigKey]
+	if !ok {
+		return nil, nil
+	}
+	str, ok := val.(string)
+	if !ok {
+		return nil, errors.NotValidf("%s value %v", SecretOptionsConfigKey, val)
+	}
+	var names []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(str, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names, nil
+}
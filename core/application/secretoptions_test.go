@@ -0,0 +1,45 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SecretOptionsSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&SecretOptionsSuite{})
+
+func (s *SecretOptionsSuite) TestDefault(c *gc.C) {
+	names, err := application.ConfigAttributes(nil).SecretOptions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(names, gc.HasLen, 0)
+
+	names, err = application.ConfigAttributes{
+		"secret-options": "",
+	}.SecretOptions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(names, gc.HasLen, 0)
+}
+
+func (s *SecretOptionsSuite) TestSecretOptions(c *gc.C) {
+	names, err := application.ConfigAttributes{
+		"secret-options": "password, api-key,,password",
+	}.SecretOptions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(names, jc.DeepEquals, []string{"password", "api-key"})
+}
+
+func (s *SecretOptionsSuite) TestInvalid(c *gc.C) {
+	_, err := application.ConfigAttributes{
+		"secret-options": true,
+	}.SecretOptions()
+	c.Assert(err, gc.ErrorMatches, `secret-options value true not valid`)
+}
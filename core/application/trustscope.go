@@ -0,0 +1,45 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+)
+
+// TrustScopeConfigKey is the application config key that determines
+// which credential a trusted application is given. When unset, the
+// application is given the model's credential.
+const TrustScopeConfigKey = "trust-scope"
+
+// The scopes of the credential given to a trusted application.
+const (
+	// TrustScopeModel gives the application the model's credential.
+	TrustScopeModel = "model"
+
+	// TrustScopeApplication gives the application a credential
+	// issued by the cloud provider for its use alone, with access
+	// limited to what the application needs, and which expires and
+	// is rotated by the controller.
+	TrustScopeApplication = "application"
+)
+
+// TrustScope returns the scope of the credential given to the
+// application when it is trusted.
+func (c ConfigAttributes) TrustScope() (string, error) {
+	val, ok := c[TrustScopeConfigKey]
+	if !ok {
+		return TrustScopeModel, nil
+	}
+	scope, ok := val.(string)
+	if !ok {
+		return "", errors.NotValidf("%s value %v", TrustScopeConfigKey, val)
+	}
+	switch scope {
+	case "":
+		return TrustScopeModel, nil
+	case TrustScopeModel, TrustScopeApplication:
+		return scope, nil
+	}
+	return "", errors.NotValidf("%s %q", TrustScopeConfigKey, scope)
+}
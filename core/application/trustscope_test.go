@@ -0,0 +1,54 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type TrustScopeSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&TrustScopeSuite{})
+
+func (s *TrustScopeSuite) TestDefault(c *gc.C) {
+	scope, err := application.ConfigAttributes(nil).TrustScope()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scope, gc.Equals, "model")
+
+	scope, err = application.ConfigAttributes{
+		"trust-scope": "",
+	}.TrustScope()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scope, gc.Equals, "model")
+}
+
+func (s *TrustScopeSuite) TestScopes(c *gc.C) {
+	for _, expected := range []string{"model", "application"} {
+		scope, err := application.ConfigAttributes{
+			"trust-scope": expected,
+		}.TrustScope()
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(scope, gc.Equals, expected)
+	}
+}
+
+func (s *TrustScopeSuite) TestInvalid(c *gc.C) {
+	_, err := application.ConfigAttributes{
+		"trust-scope": "cloud",
+	}.TrustScope()
+	c.Assert(err, gc.ErrorMatches, `trust-scope "cloud" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	_, err = application.ConfigAttributes{
+		"trust-scope": true,
+	}.TrustScope()
+	c.Assert(err, gc.ErrorMatches, `trust-scope value true not valid`)
+}
@@ -72,3 +72,11 @@ func BranchEvents(change interface{}) bool {
 	}
 	return false
 }
+
+func ModelEntityEvents(change interface{}) bool {
+	switch change.(type) {
+	case cache.ModelEntityChange:
+		return true
+	}
+	return false
+}
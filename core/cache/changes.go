@@ -358,6 +358,13 @@ type RemoveBranch struct {
 	Id        string
 }
 
+// ModelEntityChange represents a change to an entity of a model which
+// is not otherwise held in the cache, such as an application offer or
+// a remote application. It only advances the model's revno.
+type ModelEntityChange struct {
+	ModelUUID string
+}
+
 func copyStatusInfo(info status.StatusInfo) status.StatusInfo {
 	var cSince *time.Time
 	if info.Since != nil {
@@ -186,6 +186,8 @@ func (c *Controller) loop() error {
 				c.updateBranch(ch)
 			case RemoveBranch:
 				err = c.removeBranch(ch)
+			case ModelEntityChange:
+				c.touchModel(ch)
 			}
 			if c.notify != nil {
 				c.notify(change)
@@ -404,6 +406,18 @@ func (c *Controller) removeBranch(ch RemoveBranch) error {
 	return errors.Trace(c.removeResident(ch.ModelUUID, func(m *Model) error { return m.removeBranch(ch) }))
 }
 
+// touchModel advances the revno of the specified model. Unlike other
+// changes, it doesn't add the model if it is not yet cached; the model's
+// revno is advanced by its own changes once it is.
+func (c *Controller) touchModel(ch ModelEntityChange) {
+	c.modelsMu.Lock()
+	model, ok := c.models[ch.ModelUUID]
+	c.modelsMu.Unlock()
+	if ok {
+		model.touch()
+	}
+}
+
 // removeResident uses the input removal function to remove a cache resident,
 // including cleaning up resources it was responsible for creating.
 // If the cache does not have the model loaded for the resident yet,
@@ -83,6 +83,18 @@ func (s *ControllerSuite) TestRemoveModel(c *gc.C) {
 	s.AssertResident(c, mod.CacheId(), false)
 }
 
+func (s *ControllerSuite) TestModelEntityChangeAdvancesRevno(c *gc.C) {
+	controller, events := s.New(c)
+	s.ProcessChange(c, modelChange, events)
+
+	mod, err := controller.Model(modelChange.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	revno := mod.Revno()
+
+	s.ProcessChange(c, cache.ModelEntityChange{ModelUUID: modelChange.ModelUUID}, events)
+	c.Check(mod.Revno(), gc.Equals, revno+1)
+}
+
 func (s *ControllerSuite) TestWaitForModelExists(c *gc.C) {
 	controller, events := s.New(c)
 	clock := testclock.NewClock(time.Now())
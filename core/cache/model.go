@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
@@ -53,6 +54,10 @@ func newModel(config modelConfig) *Model {
 		units:         make(map[string]*Unit),
 		relations:     make(map[string]*Relation),
 		branches:      make(map[string]*Branch),
+		// The revno starts from the time the model is cached, so
+		// that revnos from a restarted controller, or another
+		// controller in HA, don't match those already handed out.
+		revno: time.Now().UnixNano(),
 	}
 	return m
 }
@@ -83,6 +88,10 @@ type Model struct {
 	relations    map[string]*Relation
 	branches     map[string]*Branch
 
+	// revno is advanced each time the model or any of its
+	// entities change.
+	revno int64
+
 	// lastSummaryPublish is here for testing purposes to ensure
 	// synchronisation between the test and the handling of the
 	// published summary event. This channel is returned by the pubsub
@@ -123,6 +132,21 @@ func (m *Model) Name() string {
 	return m.details.Name
 }
 
+// Revno returns a number which is advanced each time the model, or any
+// entity in it, changes. This includes entities which are not otherwise
+// cached, such as application offers and remote applications.
+func (m *Model) Revno() int64 {
+	defer m.doLocked()()
+	return m.revno
+}
+
+// touch records a change to an entity of the model which is not
+// otherwise cached.
+func (m *Model) touch() {
+	defer m.doLocked()()
+	m.revno++
+}
+
 // Summary returns a copy of the current summary, and its hash.
 func (m *Model) Summary() (ModelSummary, string) {
 	defer m.doLocked()()
@@ -355,6 +379,7 @@ func (m *Model) WatchMachines() (*PredicateStringsWatcher, error) {
 // updateApplication adds or updates the application in the model.
 func (m *Model) updateApplication(ch ApplicationChange, rm *residentManager) {
 	m.mu.Lock()
+	m.revno++
 
 	app, found := m.applications[ch.Name]
 	if !found {
@@ -369,6 +394,7 @@ func (m *Model) updateApplication(ch ApplicationChange, rm *residentManager) {
 // removeApplication removes the application from the model.
 func (m *Model) removeApplication(ch RemoveApplication) error {
 	defer m.doLocked()()
+	m.revno++
 
 	app, ok := m.applications[ch.Name]
 	if ok {
@@ -384,6 +410,7 @@ func (m *Model) removeApplication(ch RemoveApplication) error {
 // updateCharm adds or updates the charm in the model.
 func (m *Model) updateCharm(ch CharmChange, rm *residentManager) {
 	m.mu.Lock()
+	m.revno++
 
 	charm, found := m.charms[ch.CharmURL]
 	if !found {
@@ -398,6 +425,7 @@ func (m *Model) updateCharm(ch CharmChange, rm *residentManager) {
 // removeCharm removes the charm from the model.
 func (m *Model) removeCharm(ch RemoveCharm) error {
 	defer m.doLocked()()
+	m.revno++
 
 	charm, ok := m.charms[ch.CharmURL]
 	if ok {
@@ -412,6 +440,7 @@ func (m *Model) removeCharm(ch RemoveCharm) error {
 // updateUnit adds or updates the unit in the model.
 func (m *Model) updateUnit(ch UnitChange, rm *residentManager) {
 	m.mu.Lock()
+	m.revno++
 
 	unit, found := m.units[ch.Name]
 	if !found {
@@ -427,6 +456,7 @@ func (m *Model) updateUnit(ch UnitChange, rm *residentManager) {
 // removeUnit removes the unit from the model.
 func (m *Model) removeUnit(ch RemoveUnit) error {
 	defer m.doLocked()()
+	m.revno++
 
 	unit, ok := m.units[ch.Name]
 	if ok {
@@ -468,6 +498,7 @@ func (m *Model) Relations() map[string]Relation {
 // updateRelation adds or updates the relation in the model.
 func (m *Model) updateRelation(ch RelationChange, rm *residentManager) {
 	m.mu.Lock()
+	m.revno++
 
 	relation, found := m.relations[ch.Key]
 	if !found {
@@ -482,6 +513,7 @@ func (m *Model) updateRelation(ch RelationChange, rm *residentManager) {
 // removeRelation removes the relation from the model.
 func (m *Model) removeRelation(ch RemoveRelation) error {
 	defer m.doLocked()()
+	m.revno++
 
 	relation, ok := m.relations[ch.Key]
 	if ok {
@@ -496,6 +528,7 @@ func (m *Model) removeRelation(ch RemoveRelation) error {
 // updateMachine adds or updates the machine in the model.
 func (m *Model) updateMachine(ch MachineChange, rm *residentManager) {
 	m.mu.Lock()
+	m.revno++
 
 	machine, found := m.machines[ch.Id]
 	if !found {
@@ -512,6 +545,7 @@ func (m *Model) updateMachine(ch MachineChange, rm *residentManager) {
 // removeMachine removes the machine from the model.
 func (m *Model) removeMachine(ch RemoveMachine) error {
 	defer m.doLocked()()
+	m.revno++
 
 	machine, ok := m.machines[ch.Id]
 	if ok {
@@ -531,6 +565,7 @@ func (m *Model) removeMachine(ch RemoveMachine) error {
 // should be passed through by the cache worker as a deletion.
 func (m *Model) updateBranch(ch BranchChange, rm *residentManager) {
 	m.mu.Lock()
+	m.revno++
 
 	branch, found := m.branches[ch.Id]
 	if !found {
@@ -545,6 +580,7 @@ func (m *Model) updateBranch(ch BranchChange, rm *residentManager) {
 // removeBranch removes the branch from the model.
 func (m *Model) removeBranch(ch RemoveBranch) error {
 	defer m.doLocked()()
+	m.revno++
 
 	branch, ok := m.branches[ch.Id]
 	if ok {
@@ -559,6 +595,7 @@ func (m *Model) removeBranch(ch RemoveBranch) error {
 
 func (m *Model) setDetails(details ModelChange) {
 	m.mu.Lock()
+	m.revno++
 
 	m.setRemovalMessage(RemoveModel{
 		ModelUUID: details.ModelUUID,
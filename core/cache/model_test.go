@@ -164,6 +164,28 @@ func (s *ModelSuite) TestConfigWatcherSameValuesCacheHit(c *gc.C) {
 	c.Check(testutil.ToFloat64(s.Gauges.ModelHashCacheHit), gc.Equals, float64(1))
 }
 
+func (s *ModelSuite) TestRevnoAdvancesOnChange(c *gc.C) {
+	m := s.NewModel(modelChange)
+	revno := m.Revno()
+
+	m.UpdateApplication(appChange, s.Manager)
+	c.Assert(m.Revno() > revno, jc.IsTrue)
+	revno = m.Revno()
+
+	m.UpdateUnit(unitChange, s.Manager)
+	c.Assert(m.Revno() > revno, jc.IsTrue)
+	revno = m.Revno()
+
+	err := m.RemoveUnit(cache.RemoveUnit{ModelUUID: unitChange.ModelUUID, Name: unitChange.Name})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Revno() > revno, jc.IsTrue)
+
+	// Reading the model doesn't advance the revno.
+	revno = m.Revno()
+	_ = m.Units()
+	c.Assert(m.Revno(), gc.Equals, revno)
+}
+
 func (s *ModelSuite) TestApplicationNotFoundError(c *gc.C) {
 	m := s.NewModel(modelChange)
 	_, err := m.Application("nope")
@@ -0,0 +1,169 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charm
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/juju/charm/v9"
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// SandboxProfileFile is the name of the file in which a charm declares
+// the sandboxing its units need on their machines, alongside
+// lxd-profile.yaml.
+const SandboxProfileFile = "sandbox-profile.yaml"
+
+var (
+	sysctlNamePattern      = regexp.MustCompile(`^[a-z0-9_]+([./][a-zA-Z0-9_-]+)*$`)
+	appArmorProfilePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+)
+
+// SandboxProfile holds the sandboxing a charm declares its units need on
+// their machines: kernel parameters to set, and AppArmor profiles to
+// load. Like LXD profiles, it is applied to the machine as a whole.
+type SandboxProfile struct {
+	// Sysctls holds the values of the kernel parameters to set, keyed
+	// by name, eg "net.core.somaxconn".
+	Sysctls map[string]string `yaml:"sysctls,omitempty"`
+
+	// AppArmorProfiles holds the text of the AppArmor profiles to
+	// load, keyed by a name unique within the charm.
+	AppArmorProfiles map[string]string `yaml:"apparmor-profiles,omitempty"`
+}
+
+// Empty returns true if the profile asks for nothing.
+func (p *SandboxProfile) Empty() bool {
+	return p == nil || (len(p.Sysctls) == 0 && len(p.AppArmorProfiles) == 0)
+}
+
+// Validate returns an error if the profile's sysctl or AppArmor profile
+// names, or their values, can't be applied.
+func (p *SandboxProfile) Validate() error {
+	for name, value := range p.Sysctls {
+		if !sysctlNamePattern.MatchString(name) {
+			return errors.NotValidf("sysctl name %q", name)
+		}
+		if value == "" || strings.ContainsAny(value, "\r\n") {
+			return errors.NotValidf("value %q for sysctl %q", value, name)
+		}
+	}
+	for name, profile := range p.AppArmorProfiles {
+		if !appArmorProfilePattern.MatchString(name) {
+			return errors.NotValidf("AppArmor profile name %q", name)
+		}
+		if strings.TrimSpace(profile) == "" {
+			return errors.NotValidf("empty AppArmor profile %q", name)
+		}
+	}
+	return nil
+}
+
+// CheckAllowed returns an error satisfying errors.IsForbidden if the
+// profile sets a sysctl not in allowedSysctls, or loads AppArmor profiles
+// when allowAppArmor is false. An allowed sysctl ending in "*" allows
+// every sysctl starting with what precedes it.
+func (p *SandboxProfile) CheckAllowed(allowedSysctls []string, allowAppArmor bool) error {
+	if p.Empty() {
+		return nil
+	}
+	names := make([]string, 0, len(p.Sysctls))
+	for name := range p.Sysctls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !sysctlAllowed(name, allowedSysctls) {
+			return errors.Forbiddenf("sysctl %q is not allowed by the controller", name)
+		}
+	}
+	if len(p.AppArmorProfiles) > 0 && !allowAppArmor {
+		return errors.Forbiddenf("AppArmor profiles are not allowed by the controller")
+	}
+	return nil
+}
+
+func sysctlAllowed(name string, allowed []string) bool {
+	for _, pattern := range allowed {
+		if strings.HasSuffix(pattern, "*") {
+			if strings.HasPrefix(name, strings.TrimSuffix(pattern, "*")) {
+				return true
+			}
+		} else if name == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseSandboxProfile parses and validates the contents of a charm's
+// sandbox profile file.
+func ParseSandboxProfile(data []byte) (*SandboxProfile, error) {
+	var profile SandboxProfile
+	if err := yaml.UnmarshalStrict(data, &profile); err != nil {
+		return nil, errors.Annotatef(err, "parsing %s", SandboxProfileFile)
+	}
+	if err := profile.Validate(); err != nil {
+		return nil, errors.Annotatef(err, "invalid %s", SandboxProfileFile)
+	}
+	return &profile, nil
+}
+
+// ReadSandboxProfile returns the sandbox profile declared by the charm
+// directory or archive, or nil if it declares none. Charms of other
+// kinds, which have no files, never declare one.
+func ReadSandboxProfile(ch charm.Charm) (*SandboxProfile, error) {
+	var (
+		data []byte
+		err  error
+	)
+	switch ch := ch.(type) {
+	case *charm.CharmDir:
+		data, err = ioutil.ReadFile(filepath.Join(ch.Path, SandboxProfileFile))
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+	case *charm.CharmArchive:
+		if ch.Path == "" {
+			return nil, nil
+		}
+		data, err = readArchiveFile(ch.Path, SandboxProfileFile)
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading %s", SandboxProfileFile)
+	}
+	return ParseSandboxProfile(data)
+}
+
+func readArchiveFile(archivePath, name string) ([]byte, error) {
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() { _ = zr.Close() }()
+	for _, f := range zr.File {
+		if filepath.Clean(f.Name) != name {
+			continue
+		}
+		r, err := f.Open()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer func() { _ = r.Close() }()
+		return ioutil.ReadAll(r)
+	}
+	return nil, errors.NotFoundf("%s in charm archive", name)
+}
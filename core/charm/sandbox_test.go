@@ -0,0 +1,124 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charm_test
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	charmv9 "github.com/juju/charm/v9"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/charm"
+)
+
+type sandboxSuite struct{}
+
+var _ = gc.Suite(&sandboxSuite{})
+
+const sandboxProfileYAML = `
+sysctls:
+  net.core.somaxconn: "4096"
+  vm.max_map_count: "262144"
+apparmor-profiles:
+  worker: |
+    profile worker flags=(attach_disconnected) {}
+`
+
+func (s *sandboxSuite) TestParseSandboxProfile(c *gc.C) {
+	profile, err := charm.ParseSandboxProfile([]byte(sandboxProfileYAML))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile, jc.DeepEquals, &charm.SandboxProfile{
+		Sysctls: map[string]string{
+			"net.core.somaxconn": "4096",
+			"vm.max_map_count":   "262144",
+		},
+		AppArmorProfiles: map[string]string{
+			"worker": "profile worker flags=(attach_disconnected) {}\n",
+		},
+	})
+	c.Assert(profile.Empty(), jc.IsFalse)
+}
+
+func (s *sandboxSuite) TestParseSandboxProfileInvalid(c *gc.C) {
+	for i, t := range []struct {
+		yaml string
+		err  string
+	}{{
+		yaml: "devices: {}",
+		err:  `parsing sandbox-profile.yaml: .*field devices not found.*`,
+	}, {
+		yaml: "sysctls: {\"../kernel\": \"1\"}",
+		err:  `invalid sandbox-profile.yaml: sysctl name "../kernel" not valid`,
+	}, {
+		yaml: "sysctls: {kernel.panic: \"1\\nvm.swappiness = 0\"}",
+		err:  `invalid sandbox-profile.yaml: value .* for sysctl "kernel.panic" not valid`,
+	}, {
+		yaml: "apparmor-profiles: {\"../usr.bin\": \"profile x {}\"}",
+		err:  `invalid sandbox-profile.yaml: AppArmor profile name "../usr.bin" not valid`,
+	}, {
+		yaml: "apparmor-profiles: {worker: \"\"}",
+		err:  `invalid sandbox-profile.yaml: empty AppArmor profile "worker" not valid`,
+	}} {
+		c.Logf("test %d: %s", i, t.yaml)
+		_, err := charm.ParseSandboxProfile([]byte(t.yaml))
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *sandboxSuite) TestCheckAllowed(c *gc.C) {
+	profile, err := charm.ParseSandboxProfile([]byte(sandboxProfileYAML))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = profile.CheckAllowed([]string{"net.core.*", "vm.max_map_count"}, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = profile.CheckAllowed([]string{"net.core.*"}, true)
+	c.Assert(err, jc.Satisfies, errors.IsForbidden)
+	c.Assert(err, gc.ErrorMatches, `sysctl "vm.max_map_count" is not allowed by the controller`)
+
+	err = profile.CheckAllowed([]string{"*"}, false)
+	c.Assert(err, jc.Satisfies, errors.IsForbidden)
+	c.Assert(err, gc.ErrorMatches, `AppArmor profiles are not allowed by the controller`)
+
+	var none *charm.SandboxProfile
+	c.Assert(none.Empty(), jc.IsTrue)
+	c.Assert(none.CheckAllowed(nil, false), jc.ErrorIsNil)
+}
+
+func (s *sandboxSuite) TestReadSandboxProfileFromDir(c *gc.C) {
+	dir := c.MkDir()
+	ch := &charmv9.CharmDir{Path: dir}
+	profile, err := charm.ReadSandboxProfile(ch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile, gc.IsNil)
+
+	err = ioutil.WriteFile(filepath.Join(dir, charm.SandboxProfileFile), []byte(sandboxProfileYAML), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	profile, err = charm.ReadSandboxProfile(ch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile.Sysctls, gc.HasLen, 2)
+	c.Assert(profile.AppArmorProfiles, gc.HasLen, 1)
+}
+
+func (s *sandboxSuite) TestReadSandboxProfileFromArchive(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "charm.zip")
+	f, err := os.Create(path)
+	c.Assert(err, jc.ErrorIsNil)
+	zw := zip.NewWriter(f)
+	w, err := zw.Create(charm.SandboxProfileFile)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = w.Write([]byte(sandboxProfileYAML))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zw.Close(), jc.ErrorIsNil)
+	c.Assert(f.Close(), jc.ErrorIsNil)
+
+	profile, err := charm.ReadSandboxProfile(&charmv9.CharmArchive{Path: path})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile.Sysctls["vm.max_map_count"], gc.Equals, "262144")
+}
@@ -0,0 +1,203 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charm
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"strings"
+
+	"github.com/juju/errors"
+	"golang.org/x/crypto/openpgp"
+)
+
+// Signature formats understood by VerifySignature.
+const (
+	// SignatureFormatPGP is a detached OpenPGP signature, armored or
+	// binary.
+	SignatureFormatPGP = "pgp"
+
+	// SignatureFormatCosign is a base64 encoded cosign blob signature;
+	// an ECDSA or RSA signature over the SHA256 digest of the archive.
+	SignatureFormatCosign = "cosign"
+)
+
+const (
+	pgpPublicKeyBlock = "PGP PUBLIC KEY BLOCK"
+	pemPublicKeyBlock = "PUBLIC KEY"
+	pgpSignatureBlock = "-----BEGIN PGP SIGNATURE-----"
+)
+
+// VerifiedSignature describes a charm signature that was successfully
+// verified against one of the trusted keys.
+type VerifiedSignature struct {
+	// Format is the format of the signature, either SignatureFormatPGP
+	// or SignatureFormatCosign.
+	Format string
+
+	// KeyID identifies the trusted key that made the signature. For PGP
+	// keys this is the key id, for cosign keys it is the hex encoded
+	// SHA256 hash of the DER encoded public key.
+	KeyID string
+}
+
+// VerifySignature checks the detached signature of the charm archive
+// read from archive against the trusted keys. The trusted keys are a
+// concatenation of armored PGP public key blocks and PEM encoded
+// cosign public keys. An errors.Unauthorized error is returned if the
+// signature was not made by any of the trusted keys.
+func VerifySignature(archive io.Reader, signature []byte, trustedKeys string) (VerifiedSignature, error) {
+	pgpKeys, cosignKeys, err := parseTrustedKeys(trustedKeys)
+	if err != nil {
+		return VerifiedSignature{}, errors.Trace(err)
+	}
+	data, err := ioutil.ReadAll(archive)
+	if err != nil {
+		return VerifiedSignature{}, errors.Annotate(err, "reading charm archive")
+	}
+
+	trimmed := bytes.TrimSpace(signature)
+	if len(trimmed) == 0 {
+		return VerifiedSignature{}, errors.NotValidf("empty charm signature")
+	}
+	if bytes.HasPrefix(trimmed, []byte(pgpSignatureBlock)) {
+		return verifyPGP(pgpKeys, data, trimmed, true)
+	}
+	if raw, err := base64.StdEncoding.DecodeString(string(trimmed)); err == nil {
+		return verifyCosign(cosignKeys, data, raw)
+	}
+	return verifyPGP(pgpKeys, data, signature, false)
+}
+
+func verifyPGP(keys openpgp.EntityList, data, signature []byte, armored bool) (VerifiedSignature, error) {
+	if len(keys) == 0 {
+		return VerifiedSignature{}, errors.Unauthorizedf("no trusted PGP keys to verify charm signature")
+	}
+	check := openpgp.CheckDetachedSignature
+	if armored {
+		check = openpgp.CheckArmoredDetachedSignature
+	}
+	signer, err := check(keys, bytes.NewReader(data), bytes.NewReader(signature))
+	if err != nil {
+		return VerifiedSignature{}, errors.Unauthorizedf("charm signature not made by a trusted key: %v", err)
+	}
+	return VerifiedSignature{
+		Format: SignatureFormatPGP,
+		KeyID:  signer.PrimaryKey.KeyIdString(),
+	}, nil
+}
+
+type cosignKey struct {
+	id  string
+	key crypto.PublicKey
+}
+
+func verifyCosign(keys []cosignKey, data, signature []byte) (VerifiedSignature, error) {
+	if len(keys) == 0 {
+		return VerifiedSignature{}, errors.Unauthorizedf("no trusted cosign keys to verify charm signature")
+	}
+	digest := sha256.Sum256(data)
+	for _, k := range keys {
+		if verifyDigest(k.key, digest[:], signature) {
+			return VerifiedSignature{
+				Format: SignatureFormatCosign,
+				KeyID:  k.id,
+			}, nil
+		}
+	}
+	return VerifiedSignature{}, errors.Unauthorizedf("charm signature not made by a trusted key")
+}
+
+func verifyDigest(key crypto.PublicKey, digest, signature []byte) bool {
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		var sig struct {
+			R, S *big.Int
+		}
+		if rest, err := asn1.Unmarshal(signature, &sig); err != nil || len(rest) != 0 {
+			return false
+		}
+		return ecdsa.Verify(key, digest, sig.R, sig.S)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, signature) == nil
+	}
+	return false
+}
+
+// ValidateTrustedKeys checks that the trusted keys can be parsed.
+func ValidateTrustedKeys(trustedKeys string) error {
+	_, _, err := parseTrustedKeys(trustedKeys)
+	return errors.Trace(err)
+}
+
+// parseTrustedKeys splits the trusted keys into PGP key rings and PEM
+// encoded public keys, parsing each of them.
+func parseTrustedKeys(trustedKeys string) (openpgp.EntityList, []cosignKey, error) {
+	var (
+		pgpKeys    openpgp.EntityList
+		cosignKeys []cosignKey
+	)
+	rest := trustedKeys
+	for {
+		start := strings.Index(rest, "-----BEGIN ")
+		if start < 0 {
+			break
+		}
+		rest = rest[start:]
+		typeEnd := strings.Index(rest, "-----\n")
+		if typeEnd < 0 {
+			return nil, nil, errors.NotValidf("trusted key block")
+		}
+		blockType := strings.TrimPrefix(rest[:typeEnd], "-----BEGIN ")
+		endMarker := "-----END " + blockType + "-----"
+		end := strings.Index(rest, endMarker)
+		if end < 0 {
+			return nil, nil, errors.NotValidf("unterminated %q trusted key block", blockType)
+		}
+		block := rest[:end+len(endMarker)]
+		rest = rest[end+len(endMarker):]
+
+		switch blockType {
+		case pgpPublicKeyBlock:
+			keys, err := openpgp.ReadArmoredKeyRing(strings.NewReader(block))
+			if err != nil {
+				return nil, nil, errors.Annotate(err, "parsing trusted PGP key")
+			}
+			pgpKeys = append(pgpKeys, keys...)
+		case pemPublicKeyBlock:
+			p, _ := pem.Decode([]byte(block))
+			if p == nil {
+				return nil, nil, errors.NotValidf("trusted public key")
+			}
+			key, err := x509.ParsePKIXPublicKey(p.Bytes)
+			if err != nil {
+				return nil, nil, errors.Annotate(err, "parsing trusted public key")
+			}
+			switch key.(type) {
+			case *ecdsa.PublicKey, *rsa.PublicKey:
+			default:
+				return nil, nil, errors.NotSupportedf("trusted public key type %T", key)
+			}
+			sum := sha256.Sum256(p.Bytes)
+			cosignKeys = append(cosignKeys, cosignKey{
+				id:  hex.EncodeToString(sum[:]),
+				key: key,
+			})
+		default:
+			return nil, nil, errors.NotSupportedf("trusted key block %q", blockType)
+		}
+	}
+	return pgpKeys, cosignKeys, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charm_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/charm"
+)
+
+type signatureSuite struct{}
+
+var _ = gc.Suite(&signatureSuite{})
+
+var archiveData = []byte("not really a charm archive")
+
+func (s *signatureSuite) newPGPKey(c *gc.C) (*openpgp.Entity, string) {
+	entity, err := openpgp.NewEntity("charm-signer", "", "signer@example.com", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entity.Serialize(w), jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+	return entity, buf.String()
+}
+
+func (s *signatureSuite) newCosignKey(c *gc.C) (*ecdsa.PrivateKey, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	c.Assert(err, jc.ErrorIsNil)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	c.Assert(err, jc.ErrorIsNil)
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func (s *signatureSuite) cosignSign(c *gc.C, key *ecdsa.PrivateKey, digest []byte) string {
+	r, sv, err := ecdsa.Sign(rand.Reader, key, digest)
+	c.Assert(err, jc.ErrorIsNil)
+	raw, err := asn1.Marshal(struct{ R, S *big.Int }{r, sv})
+	c.Assert(err, jc.ErrorIsNil)
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+func (s *signatureSuite) TestVerifyArmoredPGPSignature(c *gc.C) {
+	entity, pub := s.newPGPKey(c)
+	var sig bytes.Buffer
+	err := openpgp.ArmoredDetachSign(&sig, entity, bytes.NewReader(archiveData), nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	verified, err := charm.VerifySignature(bytes.NewReader(archiveData), sig.Bytes(), pub)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(verified, jc.DeepEquals, charm.VerifiedSignature{
+		Format: charm.SignatureFormatPGP,
+		KeyID:  entity.PrimaryKey.KeyIdString(),
+	})
+}
+
+func (s *signatureSuite) TestVerifyBinaryPGPSignature(c *gc.C) {
+	entity, pub := s.newPGPKey(c)
+	var sig bytes.Buffer
+	err := openpgp.DetachSign(&sig, entity, bytes.NewReader(archiveData), nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	verified, err := charm.VerifySignature(bytes.NewReader(archiveData), sig.Bytes(), pub)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(verified.Format, gc.Equals, charm.SignatureFormatPGP)
+}
+
+func (s *signatureSuite) TestVerifyPGPSignatureUntrustedKey(c *gc.C) {
+	entity, _ := s.newPGPKey(c)
+	_, otherPub := s.newPGPKey(c)
+	var sig bytes.Buffer
+	err := openpgp.ArmoredDetachSign(&sig, entity, bytes.NewReader(archiveData), nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = charm.VerifySignature(bytes.NewReader(archiveData), sig.Bytes(), otherPub)
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+}
+
+func (s *signatureSuite) TestVerifyCosignSignature(c *gc.C) {
+	key, pub := s.newCosignKey(c)
+	digest := sha256.Sum256(archiveData)
+	sig := s.cosignSign(c, key, digest[:])
+
+	_, pgpPub := s.newPGPKey(c)
+	verified, err := charm.VerifySignature(bytes.NewReader(archiveData), []byte(sig+"\n"), pgpPub+pub)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(verified.Format, gc.Equals, charm.SignatureFormatCosign)
+	c.Assert(verified.KeyID, gc.HasLen, 64)
+}
+
+func (s *signatureSuite) TestVerifyCosignSignatureTamperedArchive(c *gc.C) {
+	key, pub := s.newCosignKey(c)
+	digest := sha256.Sum256(archiveData)
+	sig := s.cosignSign(c, key, digest[:])
+
+	_, err := charm.VerifySignature(bytes.NewReader([]byte("tampered")), []byte(sig), pub)
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+}
+
+func (s *signatureSuite) TestVerifyNoTrustedKeys(c *gc.C) {
+	_, err := charm.VerifySignature(bytes.NewReader(archiveData), []byte("c2lnbmF0dXJl"), "")
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+}
+
+func (s *signatureSuite) TestVerifyEmptySignature(c *gc.C) {
+	_, err := charm.VerifySignature(bytes.NewReader(archiveData), nil, "")
+	c.Assert(err, gc.ErrorMatches, "empty charm signature not valid")
+}
+
+func (s *signatureSuite) TestValidateTrustedKeys(c *gc.C) {
+	_, pgpPub := s.newPGPKey(c)
+	_, cosignPub := s.newCosignKey(c)
+	c.Assert(charm.ValidateTrustedKeys(pgpPub+cosignPub), jc.ErrorIsNil)
+	c.Assert(charm.ValidateTrustedKeys(""), jc.ErrorIsNil)
+	err := charm.ValidateTrustedKeys("-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n")
+	c.Assert(err, gc.ErrorMatches, `trusted key block "CERTIFICATE" not supported`)
+}
@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	"github.com/juju/utils/v2/arch"
@@ -35,8 +36,16 @@ const (
 	VirtType         = "virt-type"
 	Zones            = "zones"
 	AllocatePublicIP = "allocate-public-ip"
+	Gpus             = "gpus"
+	GpuType          = "gpu-type"
+	CpuQuota         = "cpu-quota"
+	MemoryLimit      = "memory-limit"
 )
 
+// GpuTypes holds the values recognised by the gpu-type constraint, each
+// naming a GPU vendor.
+var GpuTypes = []string{"amd", "intel", "nvidia"}
+
 // Value describes a user's requirements of the hardware on which units
 // of an application will run. Constraints are used to choose an existing machine
 // onto which a unit will be deployed, or to provision a new machine if no
@@ -59,6 +68,26 @@ type Value struct {
 	// equivalent to 1 Amazon ECU (or, roughly, a single 2007-era Xeon).
 	CpuPower *uint64 `json:"cpu-power,omitempty" yaml:"cpu-power,omitempty"`
 
+	// Gpus, if not nil, indicates that a machine must have at least that
+	// number of GPUs or other accelerators available.
+	Gpus *uint64 `json:"gpus,omitempty" yaml:"gpus,omitempty"`
+
+	// GpuType, if not nil or empty, indicates that the GPUs available to a
+	// machine must be made by the named vendor, one of GpuTypes.
+	GpuType *string `json:"gpu-type,omitempty" yaml:"gpu-type,omitempty"`
+
+	// CpuQuota, if not nil, limits the CPU time available to the workload
+	// processes of each unit, as a percentage of a single CPU; 150 allows
+	// a unit one and a half CPUs. Unlike the other constraints it is not
+	// used to select a machine, but is enforced on the machine by the
+	// unit agent.
+	CpuQuota *uint64 `json:"cpu-quota,omitempty" yaml:"cpu-quota,omitempty"`
+
+	// MemoryLimit, if not nil, limits the memory available to the workload
+	// processes of each unit to that many megabytes. Like CpuQuota it is
+	// enforced on the machine by the unit agent.
+	MemoryLimit *uint64 `json:"memory-limit,omitempty" yaml:"memory-limit,omitempty"`
+
 	// Mem, if not nil, indicates that a machine must have at least that many
 	// megabytes of RAM.
 	Mem *uint64 `json:"mem,omitempty" yaml:"mem,omitempty"`
@@ -147,6 +176,28 @@ func (v *Value) HasCpuCores() bool {
 	return v.CpuCores != nil && *v.CpuCores > 0
 }
 
+// HasGpus returns true if the constraints.Value specifies a minimum number
+// of GPUs.
+func (v *Value) HasGpus() bool {
+	return v.Gpus != nil && *v.Gpus > 0
+}
+
+// HasGpuType returns true if the constraints.Value specifies a GPU type.
+func (v *Value) HasGpuType() bool {
+	return v.GpuType != nil && *v.GpuType != ""
+}
+
+// HasCpuQuota returns true if the constraints.Value specifies a CPU quota.
+func (v *Value) HasCpuQuota() bool {
+	return v.CpuQuota != nil && *v.CpuQuota > 0
+}
+
+// HasMemoryLimit returns true if the constraints.Value specifies a memory
+// limit.
+func (v *Value) HasMemoryLimit() bool {
+	return v.MemoryLimit != nil && *v.MemoryLimit > 0
+}
+
 // HasRootDisk returns true if the constraints.Value specifies a RootDisk size.
 func (v *Value) HasRootDisk() bool {
 	return v.RootDisk != nil && *v.RootDisk > 0
@@ -235,6 +286,15 @@ func (v Value) String() string {
 	if v.CpuPower != nil {
 		strs = append(strs, "cpu-power="+uintStr(*v.CpuPower))
 	}
+	if v.Gpus != nil {
+		strs = append(strs, "gpus="+uintStr(*v.Gpus))
+	}
+	if v.GpuType != nil {
+		strs = append(strs, "gpu-type="+(*v.GpuType))
+	}
+	if v.CpuQuota != nil {
+		strs = append(strs, "cpu-quota="+uintStr(*v.CpuQuota))
+	}
 	if v.InstanceType != nil {
 		strs = append(strs, "instance-type="+(*v.InstanceType))
 	}
@@ -245,6 +305,13 @@ func (v Value) String() string {
 		}
 		strs = append(strs, "mem="+s)
 	}
+	if v.MemoryLimit != nil {
+		s := uintStr(*v.MemoryLimit)
+		if s != "" {
+			s += "M"
+		}
+		strs = append(strs, "memory-limit="+s)
+	}
 	if v.RootDisk != nil {
 		s := uintStr(*v.RootDisk)
 		if s != "" {
@@ -296,9 +363,21 @@ func (v Value) GoString() string {
 	if v.CpuPower != nil {
 		values = append(values, fmt.Sprintf("CpuPower: %v", *v.CpuPower))
 	}
+	if v.Gpus != nil {
+		values = append(values, fmt.Sprintf("Gpus: %v", *v.Gpus))
+	}
+	if v.GpuType != nil {
+		values = append(values, fmt.Sprintf("GpuType: %q", *v.GpuType))
+	}
+	if v.CpuQuota != nil {
+		values = append(values, fmt.Sprintf("CpuQuota: %v", *v.CpuQuota))
+	}
 	if v.Mem != nil {
 		values = append(values, fmt.Sprintf("Mem: %v", *v.Mem))
 	}
+	if v.MemoryLimit != nil {
+		values = append(values, fmt.Sprintf("MemoryLimit: %v", *v.MemoryLimit))
+	}
 	if v.RootDisk != nil {
 		values = append(values, fmt.Sprintf("RootDisk: %v", *v.RootDisk))
 	}
@@ -482,6 +561,14 @@ func (v *Value) setRaw(name, str string) error {
 		err = v.setCpuCores(str)
 	case CpuPower:
 		err = v.setCpuPower(str)
+	case Gpus:
+		err = v.setGpus(str)
+	case GpuType:
+		err = v.setGpuType(str)
+	case CpuQuota:
+		err = v.setCpuQuota(str)
+	case MemoryLimit:
+		err = v.setMemoryLimit(str)
 	case Mem:
 		err = v.setMem(str)
 	case RootDisk:
@@ -545,6 +632,14 @@ func (v *Value) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			v.CpuCores, err = parseUint64(vstr)
 		case CpuPower:
 			v.CpuPower, err = parseUint64(vstr)
+		case Gpus:
+			v.Gpus, err = parseUint64(vstr)
+		case GpuType:
+			err = v.setGpuType(vstr)
+		case CpuQuota:
+			v.CpuQuota, err = parseUint64(vstr)
+		case MemoryLimit:
+			v.MemoryLimit, err = parseUint64(vstr)
 		case Mem:
 			v.Mem, err = parseUint64(vstr)
 		case RootDisk:
@@ -628,6 +723,41 @@ func (v *Value) setCpuPower(str string) (err error) {
 	return
 }
 
+func (v *Value) setGpus(str string) (err error) {
+	if v.Gpus != nil {
+		return errors.Errorf("already set")
+	}
+	v.Gpus, err = parseUint64(str)
+	return
+}
+
+func (v *Value) setGpuType(str string) error {
+	if v.GpuType != nil {
+		return errors.Errorf("already set")
+	}
+	if str != "" && !set.NewStrings(GpuTypes...).Contains(str) {
+		return errors.Errorf("%q not recognized", str)
+	}
+	v.GpuType = &str
+	return nil
+}
+
+func (v *Value) setCpuQuota(str string) (err error) {
+	if v.CpuQuota != nil {
+		return errors.Errorf("already set")
+	}
+	v.CpuQuota, err = parseUint64(str)
+	return
+}
+
+func (v *Value) setMemoryLimit(str string) (err error) {
+	if v.MemoryLimit != nil {
+		return errors.Errorf("already set")
+	}
+	v.MemoryLimit, err = parseSize(str)
+	return
+}
+
 func (v *Value) setInstanceType(str string) error {
 	if v.InstanceType != nil {
 		return errors.Errorf("already set")
@@ -314,6 +314,80 @@ var parseConstraintsTests = []struct {
 		args:    []string{`instance-type=something\ with\ spaces`},
 	},
 
+	// "gpus" in detail.
+	{
+		summary: "set gpus empty",
+		args:    []string{"gpus="},
+	}, {
+		summary: "set gpus zero",
+		args:    []string{"gpus=0"},
+	}, {
+		summary: "set gpus",
+		args:    []string{"gpus=2"},
+	}, {
+		summary: "set nonsense gpus",
+		args:    []string{"gpus=lots"},
+		err:     `bad "gpus" constraint: must be a non-negative integer`,
+	}, {
+		summary: "double set gpus together",
+		args:    []string{"gpus=1 gpus=2"},
+		err:     `bad "gpus" constraint: already set`,
+	},
+
+	// "gpu-type" in detail.
+	{
+		summary: "set gpu-type empty",
+		args:    []string{"gpu-type="},
+	}, {
+		summary: "set gpu-type",
+		args:    []string{"gpu-type=nvidia"},
+	}, {
+		summary: "set unknown gpu-type",
+		args:    []string{"gpu-type=nvidia-tesla-t4"},
+		err:     `bad "gpu-type" constraint: "nvidia-tesla-t4" not recognized`,
+	}, {
+		summary: "double set gpu-type together",
+		args:    []string{"gpu-type=amd gpu-type=intel"},
+		err:     `bad "gpu-type" constraint: already set`,
+	},
+
+	// "cpu-quota" in detail.
+	{
+		summary: "set cpu-quota empty",
+		args:    []string{"cpu-quota="},
+	}, {
+		summary: "set cpu-quota",
+		args:    []string{"cpu-quota=150"},
+	}, {
+		summary: "set nonsense cpu-quota",
+		args:    []string{"cpu-quota=lots"},
+		err:     `bad "cpu-quota" constraint: must be a non-negative integer`,
+	}, {
+		summary: "double set cpu-quota together",
+		args:    []string{"cpu-quota=50 cpu-quota=100"},
+		err:     `bad "cpu-quota" constraint: already set`,
+	},
+
+	// "memory-limit" in detail.
+	{
+		summary: "set memory-limit empty",
+		args:    []string{"memory-limit="},
+	}, {
+		summary: "set memory-limit with suffix",
+		args:    []string{"memory-limit=512M"},
+	}, {
+		summary: "set memory-limit with G suffix",
+		args:    []string{"memory-limit=2G"},
+	}, {
+		summary: "set nonsense memory-limit",
+		args:    []string{"memory-limit=lots"},
+		err:     `bad "memory-limit" constraint: must be a non-negative float with optional M/G/T/P suffix`,
+	}, {
+		summary: "double set memory-limit together",
+		args:    []string{"memory-limit=1G memory-limit=2G"},
+		err:     `bad "memory-limit" constraint: already set`,
+	},
+
 	// "virt-type" in detail.
 	{
 		summary: "set virt-type empty",
@@ -663,6 +737,17 @@ var constraintsRoundtripTests = []roundTrip{
 	{"CpuPower1", constraints.Value{CpuPower: nil}},
 	{"CpuPower2", constraints.Value{CpuPower: uint64p(0)}},
 	{"CpuPower3", constraints.Value{CpuPower: uint64p(250)}},
+	{"Gpus1", constraints.Value{Gpus: nil}},
+	{"Gpus2", constraints.Value{Gpus: uint64p(0)}},
+	{"Gpus3", constraints.Value{Gpus: uint64p(4)}},
+	{"GpuType1", constraints.Value{GpuType: strp("")}},
+	{"GpuType2", constraints.Value{GpuType: strp("nvidia")}},
+	{"CpuQuota1", constraints.Value{CpuQuota: nil}},
+	{"CpuQuota2", constraints.Value{CpuQuota: uint64p(0)}},
+	{"CpuQuota3", constraints.Value{CpuQuota: uint64p(150)}},
+	{"MemoryLimit1", constraints.Value{MemoryLimit: nil}},
+	{"MemoryLimit2", constraints.Value{MemoryLimit: uint64p(0)}},
+	{"MemoryLimit3", constraints.Value{MemoryLimit: uint64p(2048)}},
 	{"Mem1", constraints.Value{Mem: nil}},
 	{"Mem2", constraints.Value{Mem: uint64p(0)}},
 	{"Mem3", constraints.Value{Mem: uint64p(98765)}},
@@ -689,7 +774,11 @@ var constraintsRoundtripTests = []roundTrip{
 		Container:        ctypep("lxd"),
 		CpuCores:         uint64p(4096),
 		CpuPower:         uint64p(9001),
+		Gpus:             uint64p(2),
+		GpuType:          strp("nvidia"),
+		CpuQuota:         uint64p(150),
 		Mem:              uint64p(18000000000),
+		MemoryLimit:      uint64p(4096),
 		RootDisk:         uint64p(24000000000),
 		RootDiskSource:   strp("cave"),
 		Tags:             &[]string{"foo", "bar"},
@@ -72,6 +72,10 @@ type ConsumeApplicationArgs struct {
 
 	// ApplicationAlias is the name of the alias to use for the application name.
 	ApplicationAlias string
+
+	// EncryptRelationData is true if the settings exchanged over
+	// relations to the consumed application are to be encrypted.
+	EncryptRelationData bool
 }
 
 // String returns the offered application name.
@@ -0,0 +1,317 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"golang.org/x/crypto/nacl/box"
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+// The settings of a cross-model relation which encrypts its data are
+// encrypted by each unit with a settings key of its own. The unit
+// publishes its public key alongside them, and wraps the settings key
+// for each unit on the other side of the relation which has published
+// a public key. The leader also wraps the key with which it encrypts the
+// application settings for the other units of its application, so that
+// the next leader can read them. Only the units hold the keys; the
+// controllers relay the ciphertext.
+const (
+	// RelationPublicKeySetting is the relation setting holding the
+	// public key of the unit which wrote the settings.
+	RelationPublicKeySetting = "juju-encryption-public-key"
+
+	// relationSettingsKeyPrefix prefixes the relation settings which
+	// hold the writer's settings key wrapped for a reader, followed by
+	// the fingerprint of the reader's public key.
+	relationSettingsKeyPrefix = "juju-encryption-key-"
+
+	// encryptedValuePrefix prefixes encrypted setting values.
+	encryptedValuePrefix = "juju-encrypted:"
+
+	relationKeyLength   = 32
+	relationNonceLength = 24
+)
+
+// clearRelationSettings holds the relation settings which are never
+// encrypted, because the controllers act on them.
+var clearRelationSettings = set.NewStrings(
+	"private-address",
+	"ingress-address",
+	"egress-subnets",
+)
+
+// RelationKeys holds a unit's keys for one generation of the keys of
+// a cross-model relation which encrypts its data.
+type RelationKeys struct {
+	Generation  int    `yaml:"generation"`
+	PublicKey   string `yaml:"public-key"`
+	PrivateKey  string `yaml:"private-key"`
+	SettingsKey string `yaml:"settings-key"`
+}
+
+// NewRelationKeys returns new random relation keys for the given
+// key generation.
+func NewRelationKeys(generation int) (*RelationKeys, error) {
+	publicKey, privateKey, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, errors.Annotate(err, "generating relation key pair")
+	}
+	var settingsKey [relationKeyLength]byte
+	if _, err := rand.Read(settingsKey[:]); err != nil {
+		return nil, errors.Annotate(err, "generating relation settings key")
+	}
+	return &RelationKeys{
+		Generation:  generation,
+		PublicKey:   encodeRelationKey(publicKey),
+		PrivateKey:  encodeRelationKey(privateKey),
+		SettingsKey: encodeRelationKey(&settingsKey),
+	}, nil
+}
+
+// SealRelationSettings returns the changes which make a relation
+// settings bag, currently holding the stored settings, hold the
+// values encrypted with the keys, and the settings key wrapped for
+// each of the readers' public keys. The values must be complete:
+// stored values missing from them are deleted, as are those which
+// are empty. Values which are stored already are left alone, so the
+// result is empty when the bag needs no change.
+func SealRelationSettings(
+	keys *RelationKeys, stored, values map[string]string, readers []string,
+) (map[string]string, error) {
+	settingsKey, err := decodeRelationKey(keys.SettingsKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	privateKey, err := decodeRelationKey(keys.PrivateKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// When the bag was written with other keys, everything in it must
+	// be encrypted and wrapped again.
+	rekeyed := stored[RelationPublicKeySetting] != keys.PublicKey
+	changes := make(map[string]string)
+	if rekeyed {
+		changes[RelationPublicKeySetting] = keys.PublicKey
+	}
+
+	for name := range stored {
+		if _, ok := values[name]; !ok && !isRelationKeySetting(name) {
+			changes[name] = ""
+		}
+	}
+	for name, value := range values {
+		switch {
+		case isRelationKeySetting(name):
+			continue
+		case value == "" || clearRelationSettings.Contains(name):
+			if stored[name] != value {
+				changes[name] = value
+			}
+			continue
+		case !rekeyed:
+			current, err := openRelationValue(settingsKey, name, stored[name])
+			if err == nil && current == value {
+				continue
+			}
+		}
+		sealed, err := sealRelationValue(settingsKey, name, value)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		changes[name] = sealed
+	}
+
+	wrapped := set.NewStrings()
+	for _, reader := range readers {
+		readerKey, err := decodeRelationKey(reader)
+		if err != nil || reader == keys.PublicKey {
+			// Readers which publish no valid key can't be given
+			// the settings key, and the writer needs none.
+			continue
+		}
+		name := relationSettingsKeyName(reader)
+		wrapped.Add(name)
+		if !rekeyed && stored[name] != "" {
+			continue
+		}
+		if changes[name], err = wrapRelationSettingsKey(settingsKey, readerKey, privateKey); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	for name := range stored {
+		if strings.HasPrefix(name, relationSettingsKeyPrefix) && !wrapped.Contains(name) {
+			changes[name] = ""
+		}
+	}
+	return changes, nil
+}
+
+// OpenRelationSettings returns the values of a relation settings bag
+// which can be decrypted with any of the keys, whether the bag was
+// written with the keys themselves or by a unit which wrapped its
+// settings key for one of their public keys. The settings used to
+// exchange keys are omitted, as are values which cannot be decrypted
+// and unencrypted values other than those the controllers act on.
+func OpenRelationSettings(keys []*RelationKeys, stored map[string]string) map[string]string {
+	settingsKey := openRelationSettingsKey(keys, stored)
+	result := make(map[string]string)
+	for name, value := range stored {
+		switch {
+		case isRelationKeySetting(name):
+		case clearRelationSettings.Contains(name):
+			result[name] = value
+		case settingsKey != nil:
+			if value, err := openRelationValue(settingsKey, name, value); err == nil {
+				result[name] = value
+			}
+		}
+	}
+	return result
+}
+
+// CanOpenRelationSettings reports whether the keys can decrypt the stored
+// settings, or there are no encrypted settings stored. Resealing settings
+// which can't be opened would delete them.
+func CanOpenRelationSettings(keys []*RelationKeys, stored map[string]string) bool {
+	if stored[RelationPublicKeySetting] == "" {
+		return true
+	}
+	return openRelationSettingsKey(keys, stored) != nil
+}
+
+// openRelationSettingsKey returns the settings key with which the
+// stored settings were encrypted, or nil if none of the keys can
+// recover it.
+func openRelationSettingsKey(keys []*RelationKeys, stored map[string]string) *[relationKeyLength]byte {
+	writer := stored[RelationPublicKeySetting]
+	writerKey, err := decodeRelationKey(writer)
+	if err != nil {
+		return nil
+	}
+	for _, k := range keys {
+		if k.PublicKey == writer {
+			if settingsKey, err := decodeRelationKey(k.SettingsKey); err == nil {
+				return settingsKey
+			}
+			continue
+		}
+		wrapped, ok := stored[relationSettingsKeyName(k.PublicKey)]
+		if !ok {
+			continue
+		}
+		privateKey, err := decodeRelationKey(k.PrivateKey)
+		if err != nil {
+			continue
+		}
+		if settingsKey, err := unwrapRelationSettingsKey(wrapped, writerKey, privateKey); err == nil {
+			return settingsKey
+		}
+	}
+	return nil
+}
+
+// isRelationKeySetting reports whether the relation setting is used
+// to exchange keys, and so can't be set by charms.
+func isRelationKeySetting(name string) bool {
+	return name == RelationPublicKeySetting || strings.HasPrefix(name, relationSettingsKeyPrefix)
+}
+
+// relationSettingsKeyName returns the name of the relation setting
+// holding the settings key wrapped for the given public key. It uses
+// a fingerprint of the key, since unit names differ between models.
+func relationSettingsKeyName(publicKey string) string {
+	sum := sha256.Sum256([]byte(publicKey))
+	return relationSettingsKeyPrefix + hex.EncodeToString(sum[:8])
+}
+
+func encodeRelationKey(key *[relationKeyLength]byte) string {
+	return base64.StdEncoding.EncodeToString(key[:])
+}
+
+func decodeRelationKey(key string) (*[relationKeyLength]byte, error) {
+	keyBytes, err := base64.StdEncoding.DecodeString(key)
+	if err != nil || len(keyBytes) != relationKeyLength {
+		return nil, errors.NotValidf("relation key")
+	}
+	var result [relationKeyLength]byte
+	copy(result[:], keyBytes)
+	return &result, nil
+}
+
+func newRelationNonce() (*[relationNonceLength]byte, error) {
+	var nonce [relationNonceLength]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, errors.Annotate(err, "generating nonce")
+	}
+	return &nonce, nil
+}
+
+func splitRelationNonce(encoded string) (*[relationNonceLength]byte, []byte, error) {
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < relationNonceLength {
+		return nil, nil, errors.NotValidf("encrypted relation setting")
+	}
+	var nonce [relationNonceLength]byte
+	copy(nonce[:], sealed)
+	return &nonce, sealed[relationNonceLength:], nil
+}
+
+// sealRelationValue encrypts the value of the named setting. The name
+// is sealed with the value so that values can't be swapped between
+// settings.
+func sealRelationValue(settingsKey *[relationKeyLength]byte, name, value string) (string, error) {
+	nonce, err := newRelationNonce()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	sealed := secretbox.Seal(nonce[:], []byte(name+"\x00"+value), nonce, settingsKey)
+	return encryptedValuePrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func openRelationValue(settingsKey *[relationKeyLength]byte, name, sealed string) (string, error) {
+	if !strings.HasPrefix(sealed, encryptedValuePrefix) {
+		return "", errors.NotValidf("encrypted relation setting %q", name)
+	}
+	nonce, sealedValue, err := splitRelationNonce(strings.TrimPrefix(sealed, encryptedValuePrefix))
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	payload, ok := secretbox.Open(nil, sealedValue, nonce, settingsKey)
+	if !ok || !strings.HasPrefix(string(payload), name+"\x00") {
+		return "", errors.Errorf("cannot decrypt relation setting %q", name)
+	}
+	return strings.TrimPrefix(string(payload), name+"\x00"), nil
+}
+
+func wrapRelationSettingsKey(settingsKey, readerKey, privateKey *[relationKeyLength]byte) (string, error) {
+	nonce, err := newRelationNonce()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	sealed := box.Seal(nonce[:], settingsKey[:], nonce, readerKey, privateKey)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func unwrapRelationSettingsKey(wrapped string, writerKey, privateKey *[relationKeyLength]byte) (*[relationKeyLength]byte, error) {
+	nonce, sealed, err := splitRelationNonce(wrapped)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	keyBytes, ok := box.Open(nil, sealed, nonce, writerKey, privateKey)
+	if !ok || len(keyBytes) != relationKeyLength {
+		return nil, errors.New("cannot unwrap relation settings key")
+	}
+	var settingsKey [relationKeyLength]byte
+	copy(settingsKey[:], keyBytes)
+	return &settingsKey, nil
+}
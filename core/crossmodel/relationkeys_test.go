@@ -0,0 +1,208 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/crossmodel"
+)
+
+type RelationKeysSuite struct{}
+
+var _ = gc.Suite(&RelationKeysSuite{})
+
+func (s *RelationKeysSuite) newKeys(c *gc.C, generation int) *crossmodel.RelationKeys {
+	keys, err := crossmodel.NewRelationKeys(generation)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(keys.Generation, gc.Equals, generation)
+	return keys
+}
+
+// seal seals the values into the stored settings the way the
+// controller would store the changes.
+func (s *RelationKeysSuite) seal(
+	c *gc.C, keys *crossmodel.RelationKeys, stored, values map[string]string, readers ...string,
+) map[string]string {
+	changes, err := crossmodel.SealRelationSettings(keys, stored, values, readers)
+	c.Assert(err, jc.ErrorIsNil)
+	for name, value := range changes {
+		if value == "" {
+			delete(stored, name)
+		} else {
+			stored[name] = value
+		}
+	}
+	return changes
+}
+
+func (s *RelationKeysSuite) TestSealAndOpen(c *gc.C) {
+	writer := s.newKeys(c, 1)
+	reader := s.newKeys(c, 1)
+	stranger := s.newKeys(c, 1)
+	values := map[string]string{
+		"password":        "s3cret",
+		"private-address": "10.0.0.1",
+	}
+	stored := make(map[string]string)
+	s.seal(c, writer, stored, values, reader.PublicKey)
+
+	c.Assert(stored, gc.HasLen, 4)
+	c.Assert(stored[crossmodel.RelationPublicKeySetting], gc.Equals, writer.PublicKey)
+	c.Assert(stored["private-address"], gc.Equals, "10.0.0.1")
+	c.Assert(stored["password"], gc.Not(gc.Equals), "")
+	c.Assert(stored["password"], gc.Not(jc.Contains), "s3cret")
+
+	c.Assert(crossmodel.OpenRelationSettings([]*crossmodel.RelationKeys{reader}, stored), jc.DeepEquals, values)
+	c.Assert(crossmodel.OpenRelationSettings([]*crossmodel.RelationKeys{writer}, stored), jc.DeepEquals, values)
+	c.Assert(crossmodel.OpenRelationSettings([]*crossmodel.RelationKeys{stranger}, stored), jc.DeepEquals, map[string]string{
+		"private-address": "10.0.0.1",
+	})
+}
+
+func (s *RelationKeysSuite) TestSealUnchanged(c *gc.C) {
+	writer := s.newKeys(c, 1)
+	reader := s.newKeys(c, 1)
+	values := map[string]string{"password": "s3cret"}
+	stored := make(map[string]string)
+	s.seal(c, writer, stored, values, reader.PublicKey)
+
+	changes := s.seal(c, writer, stored, values, reader.PublicKey)
+	c.Assert(changes, gc.HasLen, 0)
+}
+
+func (s *RelationKeysSuite) TestSealChanges(c *gc.C) {
+	writer := s.newKeys(c, 1)
+	stored := make(map[string]string)
+	s.seal(c, writer, stored, map[string]string{
+		"user":     "admin",
+		"password": "s3cret",
+		"host":     "db",
+	})
+
+	changes := s.seal(c, writer, stored, map[string]string{
+		"user":     "admin",
+		"password": "",
+		"port":     "5432",
+	})
+	c.Assert(changes, gc.HasLen, 3)
+	c.Assert(changes["password"], gc.Equals, "")
+	c.Assert(changes["host"], gc.Equals, "")
+	c.Assert(changes["port"], gc.Not(gc.Equals), "")
+	c.Assert(crossmodel.OpenRelationSettings([]*crossmodel.RelationKeys{writer}, stored), jc.DeepEquals, map[string]string{
+		"user": "admin",
+		"port": "5432",
+	})
+}
+
+func (s *RelationKeysSuite) TestSealIgnoresKeySettings(c *gc.C) {
+	writer := s.newKeys(c, 1)
+	other := s.newKeys(c, 1)
+	stored := make(map[string]string)
+	s.seal(c, writer, stored, map[string]string{
+		crossmodel.RelationPublicKeySetting: other.PublicKey,
+		"password":                          "s3cret",
+	})
+	c.Assert(stored[crossmodel.RelationPublicKeySetting], gc.Equals, writer.PublicKey)
+	c.Assert(crossmodel.OpenRelationSettings([]*crossmodel.RelationKeys{writer}, stored), jc.DeepEquals, map[string]string{
+		"password": "s3cret",
+	})
+}
+
+func (s *RelationKeysSuite) TestOpenOmitsUnencryptedValues(c *gc.C) {
+	writer := s.newKeys(c, 1)
+	stored := make(map[string]string)
+	s.seal(c, writer, stored, map[string]string{"password": "s3cret"})
+	stored["injected"] = "value"
+
+	c.Assert(crossmodel.OpenRelationSettings([]*crossmodel.RelationKeys{writer}, stored), jc.DeepEquals, map[string]string{
+		"password": "s3cret",
+	})
+}
+
+func (s *RelationKeysSuite) TestOpenOmitsSwappedValues(c *gc.C) {
+	writer := s.newKeys(c, 1)
+	stored := make(map[string]string)
+	s.seal(c, writer, stored, map[string]string{
+		"user":     "admin",
+		"password": "s3cret",
+	})
+	stored["user"], stored["password"] = stored["password"], stored["user"]
+
+	c.Assert(crossmodel.OpenRelationSettings([]*crossmodel.RelationKeys{writer}, stored), gc.HasLen, 0)
+}
+
+func (s *RelationKeysSuite) TestSealWithRotatedWriterKeys(c *gc.C) {
+	writer := s.newKeys(c, 1)
+	reader := s.newKeys(c, 1)
+	values := map[string]string{"password": "s3cret"}
+	stored := make(map[string]string)
+	s.seal(c, writer, stored, values, reader.PublicKey)
+	oldPassword := stored["password"]
+
+	rotated := s.newKeys(c, 2)
+	changes := s.seal(c, rotated, stored, values, reader.PublicKey)
+	c.Assert(changes, gc.HasLen, 3)
+	c.Assert(stored["password"], gc.Not(gc.Equals), oldPassword)
+	c.Assert(crossmodel.OpenRelationSettings([]*crossmodel.RelationKeys{reader}, stored), jc.DeepEquals, values)
+	c.Assert(crossmodel.OpenRelationSettings([]*crossmodel.RelationKeys{writer}, stored), gc.HasLen, 0)
+}
+
+func (s *RelationKeysSuite) TestSealWithRotatedReaderKeys(c *gc.C) {
+	writer := s.newKeys(c, 1)
+	reader := s.newKeys(c, 1)
+	values := map[string]string{"password": "s3cret"}
+	stored := make(map[string]string)
+	s.seal(c, writer, stored, values, reader.PublicKey)
+
+	// The reader keeps its previous keys, so it can read settings
+	// which were wrapped for them until the writer catches up.
+	rotated := s.newKeys(c, 2)
+	readerKeys := []*crossmodel.RelationKeys{rotated, reader}
+	c.Assert(crossmodel.OpenRelationSettings(readerKeys, stored), jc.DeepEquals, values)
+
+	changes := s.seal(c, writer, stored, values, rotated.PublicKey)
+	c.Assert(changes, gc.HasLen, 2)
+	c.Assert(crossmodel.OpenRelationSettings(readerKeys[:1], stored), jc.DeepEquals, values)
+	c.Assert(crossmodel.OpenRelationSettings(readerKeys[1:], stored), gc.HasLen, 0)
+}
+
+func (s *RelationKeysSuite) TestSealSkipsInvalidReaders(c *gc.C) {
+	writer := s.newKeys(c, 1)
+	stored := make(map[string]string)
+	s.seal(c, writer, stored, map[string]string{"password": "s3cret"}, "", "invalid", writer.PublicKey)
+	c.Assert(stored, gc.HasLen, 2)
+}
+
+func (s *RelationKeysSuite) TestSealForNextWriter(c *gc.C) {
+	leader := s.newKeys(c, 1)
+	peer := s.newKeys(c, 1)
+	reader := s.newKeys(c, 1)
+	values := map[string]string{"database": "wordpress"}
+	stored := make(map[string]string)
+	s.seal(c, leader, stored, values, reader.PublicKey, peer.PublicKey)
+
+	// When the peer takes over writing the settings, it can read them,
+	// and seals them again with its own keys.
+	peerKeys := []*crossmodel.RelationKeys{peer}
+	c.Assert(crossmodel.CanOpenRelationSettings(peerKeys, stored), jc.IsTrue)
+	opened := crossmodel.OpenRelationSettings(peerKeys, stored)
+	c.Assert(opened, jc.DeepEquals, values)
+	s.seal(c, peer, stored, opened, reader.PublicKey, leader.PublicKey)
+	c.Assert(stored[crossmodel.RelationPublicKeySetting], gc.Equals, peer.PublicKey)
+	c.Assert(crossmodel.OpenRelationSettings([]*crossmodel.RelationKeys{reader}, stored), jc.DeepEquals, values)
+	c.Assert(crossmodel.OpenRelationSettings([]*crossmodel.RelationKeys{leader}, stored), jc.DeepEquals, values)
+}
+
+func (s *RelationKeysSuite) TestCanOpenRelationSettings(c *gc.C) {
+	writer := s.newKeys(c, 1)
+	stranger := s.newKeys(c, 1)
+	stored := make(map[string]string)
+	c.Assert(crossmodel.CanOpenRelationSettings([]*crossmodel.RelationKeys{stranger}, stored), jc.IsTrue)
+
+	s.seal(c, writer, stored, map[string]string{"password": "s3cret"})
+	c.Assert(crossmodel.CanOpenRelationSettings([]*crossmodel.RelationKeys{writer}, stored), jc.IsTrue)
+	c.Assert(crossmodel.CanOpenRelationSettings([]*crossmodel.RelationKeys{stranger}, stored), jc.IsFalse)
+}
@@ -0,0 +1,9 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package devices
+
+var (
+	DRIDevDir = &driDevDir
+	DRMSysDir = &drmSysDir
+)
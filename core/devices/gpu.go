@@ -0,0 +1,121 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package devices
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// GPU is the device type of graphics processors.
+const GPU DeviceType = "gpu"
+
+// GPUVendorIDs maps the values of the gpu-type constraint to the PCI
+// vendor IDs of the GPUs they select.
+var GPUVendorIDs = map[string]string{
+	"amd":    "1002",
+	"intel":  "8086",
+	"nvidia": "10de",
+}
+
+// Device describes a device present on the local machine.
+type Device struct {
+	// Name is the kernel name of the device, e.g. "card0".
+	Name string `json:"name" yaml:"name"`
+
+	// Type is the type of the device.
+	Type DeviceType `json:"type" yaml:"type"`
+
+	// Path is the path of the device node.
+	Path string `json:"path" yaml:"path"`
+
+	// Vendor is the gpu-type constraint value of the device's
+	// vendor, or empty if the vendor is not one Juju recognises.
+	Vendor string `json:"vendor,omitempty" yaml:"vendor,omitempty"`
+
+	// VendorID is the PCI vendor ID of the device.
+	VendorID string `json:"vendor-id,omitempty" yaml:"vendor-id,omitempty"`
+
+	// PCIAddress is the PCI slot of the device, e.g. "0000:01:00.0".
+	PCIAddress string `json:"pci-address,omitempty" yaml:"pci-address,omitempty"`
+}
+
+var (
+	driDevDir = "/dev/dri"
+	drmSysDir = "/sys/class/drm"
+)
+
+// LocalGPUs returns the GPUs with device nodes on the local machine.
+// Containers only have device nodes for the GPUs passed through to them,
+// so inside a container these are the GPUs allocated to it rather than
+// every GPU of the host.
+func LocalGPUs() ([]Device, error) {
+	paths, err := filepath.Glob(filepath.Join(driDevDir, "card[0-9]*"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cardNumber := func(path string) int {
+		n, _ := strconv.Atoi(strings.TrimPrefix(filepath.Base(path), "card"))
+		return n
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return cardNumber(paths[i]) < cardNumber(paths[j])
+	})
+
+	var gpus []Device
+	for _, path := range paths {
+		name := filepath.Base(path)
+		sysDir := filepath.Join(drmSysDir, name, "device")
+		vendor, err := ioutil.ReadFile(filepath.Join(sysDir, "vendor"))
+		if os.IsNotExist(err) {
+			// Not a PCI device, e.g. a virtual display.
+			logger.Debugf("skipping %s: no PCI vendor", path)
+			continue
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "reading vendor of %s", name)
+		}
+		gpu := Device{
+			Name:     name,
+			Type:     GPU,
+			Path:     path,
+			VendorID: strings.TrimPrefix(strings.TrimSpace(string(vendor)), "0x"),
+		}
+		for gpuType, vendorID := range GPUVendorIDs {
+			if vendorID == gpu.VendorID {
+				gpu.Vendor = gpuType
+			}
+		}
+		if gpu.PCIAddress, err = pciSlotName(filepath.Join(sysDir, "uevent")); err != nil {
+			return nil, errors.Annotatef(err, "reading PCI address of %s", name)
+		}
+		gpus = append(gpus, gpu)
+	}
+	return gpus, nil
+}
+
+// pciSlotName returns the PCI_SLOT_NAME recorded in the uevent file of
+// a PCI device.
+func pciSlotName(ueventPath string) (string, error) {
+	f, err := os.Open(ueventPath)
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if slot := strings.TrimPrefix(scanner.Text(), "PCI_SLOT_NAME="); slot != scanner.Text() {
+			return slot, nil
+		}
+	}
+	return "", errors.Trace(scanner.Err())
+}
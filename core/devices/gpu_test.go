@@ -0,0 +1,91 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package devices_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/devices"
+	"github.com/juju/juju/testing"
+)
+
+type GPUSuite struct {
+	testing.BaseSuite
+
+	devDir string
+	sysDir string
+}
+
+var _ = gc.Suite(&GPUSuite{})
+
+func (s *GPUSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.devDir = c.MkDir()
+	s.sysDir = c.MkDir()
+	s.PatchValue(devices.DRIDevDir, s.devDir)
+	s.PatchValue(devices.DRMSysDir, s.sysDir)
+}
+
+func (s *GPUSuite) addCard(c *gc.C, name, vendor, slot string) {
+	err := ioutil.WriteFile(filepath.Join(s.devDir, name), nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	if vendor == "" {
+		return
+	}
+	dir := filepath.Join(s.sysDir, name, "device")
+	err = os.MkdirAll(dir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(dir, "vendor"), []byte(vendor+"\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	uevent := "DRIVER=test\nPCI_SLOT_NAME=" + slot + "\n"
+	err = ioutil.WriteFile(filepath.Join(dir, "uevent"), []byte(uevent), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *GPUSuite) TestLocalGPUs(c *gc.C) {
+	s.addCard(c, "card10", "0x1002", "0000:0a:00.0")
+	s.addCard(c, "card2", "0x10de", "0000:02:00.0")
+	s.addCard(c, "card1", "0x1234", "0000:01:00.0")
+	// A virtual display has no PCI device.
+	s.addCard(c, "card0", "", "")
+	// Render nodes duplicate the cards.
+	err := ioutil.WriteFile(filepath.Join(s.devDir, "renderD128"), nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	gpus, err := devices.LocalGPUs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gpus, jc.DeepEquals, []devices.Device{{
+		Name:       "card1",
+		Type:       devices.GPU,
+		Path:       filepath.Join(s.devDir, "card1"),
+		VendorID:   "1234",
+		PCIAddress: "0000:01:00.0",
+	}, {
+		Name:       "card2",
+		Type:       devices.GPU,
+		Path:       filepath.Join(s.devDir, "card2"),
+		Vendor:     "nvidia",
+		VendorID:   "10de",
+		PCIAddress: "0000:02:00.0",
+	}, {
+		Name:       "card10",
+		Type:       devices.GPU,
+		Path:       filepath.Join(s.devDir, "card10"),
+		Vendor:     "amd",
+		VendorID:   "1002",
+		PCIAddress: "0000:0a:00.0",
+	}})
+}
+
+func (s *GPUSuite) TestLocalGPUsNone(c *gc.C) {
+	s.PatchValue(devices.DRIDevDir, filepath.Join(s.devDir, "missing"))
+	gpus, err := devices.LocalGPUs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gpus, gc.HasLen, 0)
+}
@@ -0,0 +1,112 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package facadeusage records which versions of each facade are being
+// called, and by which clients and agents, so that operators can find
+// out what will break before old facade versions are dropped.
+//
+// Usage is held in memory by each API server, and is lost when the API
+// server restarts.
+package facadeusage
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+)
+
+// Call identifies the callers of a facade version.
+type Call struct {
+	// Facade is the name of the facade that was called.
+	Facade string
+
+	// Version is the version of the facade that was called.
+	Version int
+
+	// Client identifies the software making the call, such as
+	// "juju/2.9.0", taken from the connection's request headers.
+	Client string
+
+	// EntityKind is the kind of entity that made the call, such as
+	// "user", "machine" or "unit". It is empty for calls made before
+	// login.
+	EntityKind string
+}
+
+// Usage holds how often, and when, a facade version has been called.
+type Usage struct {
+	Call
+
+	// Count is the number of calls made.
+	Count int64
+
+	// FirstSeen and LastSeen hold the times of the first and last
+	// calls made.
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+// Tracker records facade usage. It is safe for concurrent use.
+type Tracker struct {
+	clock clock.Clock
+	since time.Time
+
+	mu    sync.Mutex
+	usage map[Call]*Usage
+}
+
+// NewTracker returns a new Tracker that uses the clock to time calls.
+func NewTracker(clock clock.Clock) *Tracker {
+	return &Tracker{
+		clock: clock,
+		since: clock.Now(),
+		usage: make(map[Call]*Usage),
+	}
+}
+
+// Since returns the time the tracker started recording usage.
+func (t *Tracker) Since() time.Time {
+	return t.since
+}
+
+// Record records a call.
+func (t *Tracker) Record(call Call) {
+	now := t.clock.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	u, ok := t.usage[call]
+	if !ok {
+		u = &Usage{Call: call, FirstSeen: now}
+		t.usage[call] = u
+	}
+	u.Count++
+	u.LastSeen = now
+}
+
+// Usage returns the usage recorded so far, ordered by facade, version,
+// client and entity kind.
+func (t *Tracker) Usage() []Usage {
+	t.mu.Lock()
+	result := make([]Usage, 0, len(t.usage))
+	for _, u := range t.usage {
+		result = append(result, *u)
+	}
+	t.mu.Unlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Facade != b.Facade {
+			return a.Facade < b.Facade
+		}
+		if a.Version != b.Version {
+			return a.Version < b.Version
+		}
+		if a.Client != b.Client {
+			return a.Client < b.Client
+		}
+		return a.EntityKind < b.EntityKind
+	})
+	return result
+}
@@ -0,0 +1,71 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package facadeusage_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/facadeusage"
+)
+
+type trackerSuite struct{}
+
+var _ = gc.Suite(&trackerSuite{})
+
+func (*trackerSuite) TestEmpty(c *gc.C) {
+	start := time.Date(2021, 4, 1, 12, 0, 0, 0, time.UTC)
+	t := facadeusage.NewTracker(testclock.NewClock(start))
+	c.Assert(t.Usage(), gc.HasLen, 0)
+	c.Assert(t.Since(), gc.Equals, start)
+}
+
+func (*trackerSuite) TestRecord(c *gc.C) {
+	start := time.Date(2021, 4, 1, 12, 0, 0, 0, time.UTC)
+	clock := testclock.NewClock(start)
+	t := facadeusage.NewTracker(clock)
+
+	uniter14 := facadeusage.Call{Facade: "Uniter", Version: 14, Client: "juju/2.8.10", EntityKind: "unit"}
+	uniter18 := facadeusage.Call{Facade: "Uniter", Version: 18, Client: "juju/2.9.0", EntityKind: "unit"}
+	client := facadeusage.Call{Facade: "Client", Version: 3, Client: "juju/2.9.0", EntityKind: "user"}
+
+	t.Record(uniter18)
+	t.Record(uniter14)
+	clock.Advance(time.Minute)
+	t.Record(uniter14)
+	t.Record(client)
+
+	c.Assert(t.Usage(), jc.DeepEquals, []facadeusage.Usage{{
+		Call:      client,
+		Count:     1,
+		FirstSeen: start.Add(time.Minute),
+		LastSeen:  start.Add(time.Minute),
+	}, {
+		Call:      uniter14,
+		Count:     2,
+		FirstSeen: start,
+		LastSeen:  start.Add(time.Minute),
+	}, {
+		Call:      uniter18,
+		Count:     1,
+		FirstSeen: start,
+		LastSeen:  start,
+	}})
+}
+
+func (*trackerSuite) TestRecordDistinguishesCallers(c *gc.C) {
+	t := facadeusage.NewTracker(testclock.NewClock(time.Time{}))
+	t.Record(facadeusage.Call{Facade: "Uniter", Version: 14, Client: "juju/2.8.10", EntityKind: "unit"})
+	t.Record(facadeusage.Call{Facade: "Uniter", Version: 14, Client: "juju/2.8.9", EntityKind: "unit"})
+	t.Record(facadeusage.Call{Facade: "Uniter", Version: 14, Client: "juju/2.8.10", EntityKind: "machine"})
+
+	usage := t.Usage()
+	c.Assert(usage, gc.HasLen, 3)
+	for _, u := range usage {
+		c.Check(u.Count, gc.Equals, int64(1))
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package logsink supports logging configuration with separate levels
+// for each of the places, or sinks, that an agent writes its logs to.
+//
+// A model's logging-config holds the usual loggo module levels, which
+// decide what is logged at all, and may also hold entries of the form
+// "sink:<name>=<level>", which set the least severe level written to
+// the named sink. For example:
+//
+//	<root>=DEBUG;sink:controller=WARNING;sink:syslog=ERROR
+//
+// logs debug messages to the agent's log file, only warnings and
+// errors to the controller, and only errors to syslog.
+package logsink
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+)
+
+const (
+	// File is the sink for the agent's log file.
+	File = "file"
+
+	// Syslog is the sink for the machine's syslog. Nothing is written
+	// to syslog unless a level is configured for it.
+	Syslog = "syslog"
+
+	// Controller is the sink for the logs sent to the controller and
+	// stored in its database.
+	Controller = "controller"
+)
+
+// Disabled is the level of a sink which writes nothing.
+const Disabled = loggo.CRITICAL + 1
+
+// sinkPrefix starts the logging-config entries that configure sinks.
+const sinkPrefix = "sink:"
+
+// Config is a parsed logging-config string.
+type Config struct {
+	// Modules holds the loggo module levels.
+	Modules string
+
+	// Levels holds the configured level of each sink, by sink name.
+	Levels map[string]loggo.Level
+}
+
+// ParseConfig parses a logging-config string, separating the sink
+// levels from the module levels.
+func ParseConfig(config string) (Config, error) {
+	var modules []string
+	levels := make(map[string]loggo.Level)
+	for _, entry := range strings.Split(config, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.HasPrefix(entry, sinkPrefix) {
+			modules = append(modules, entry)
+			continue
+		}
+		parts := strings.SplitN(strings.TrimPrefix(entry, sinkPrefix), "=", 2)
+		if len(parts) != 2 {
+			return Config{}, errors.NotValidf("logging sink config %q", entry)
+		}
+		name := strings.TrimSpace(parts[0])
+		if !isSink(name) {
+			return Config{}, errors.NotValidf("logging sink %q", name)
+		}
+		level, ok := loggo.ParseLevel(strings.TrimSpace(parts[1]))
+		if !ok {
+			return Config{}, errors.NotValidf("level %q for logging sink %q", parts[1], name)
+		}
+		levels[name] = level
+	}
+	result := Config{
+		Modules: strings.Join(modules, ";"),
+		Levels:  levels,
+	}
+	if _, err := loggo.ParseConfigString(result.Modules); err != nil {
+		return Config{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
+func isSink(name string) bool {
+	switch name {
+	case File, Syslog, Controller:
+		return true
+	}
+	return false
+}
+
+// String returns the logging-config string for the config.
+func (c Config) String() string {
+	entries := []string{}
+	if c.Modules != "" {
+		entries = append(entries, c.Modules)
+	}
+	names := make([]string, 0, len(c.Levels))
+	for name := range c.Levels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		entries = append(entries, sinkPrefix+name+"="+c.Levels[name].String())
+	}
+	return strings.Join(entries, ";")
+}
+
+// Writer is a loggo.Writer which passes on the entries at or above its
+// level to the sink's underlying writer.
+type Writer struct {
+	writer       loggo.Writer
+	defaultLevel loggo.Level
+
+	mu    sync.Mutex
+	level loggo.Level
+}
+
+// NewWriter returns a Writer for a sink which writes to the given
+// writer, and which has the given level until the sink is configured.
+// Sinks with a level of loggo.UNSPECIFIED write everything, and those
+// with a level of Disabled write nothing.
+func NewWriter(writer loggo.Writer, defaultLevel loggo.Level) *Writer {
+	return &Writer{
+		writer:       writer,
+		defaultLevel: defaultLevel,
+		level:        defaultLevel,
+	}
+}
+
+// Write is part of the loggo.Writer interface.
+func (w *Writer) Write(entry loggo.Entry) {
+	if entry.Level < w.Level() {
+		return
+	}
+	w.writer.Write(entry)
+}
+
+// Level returns the sink's current level.
+func (w *Writer) Level() loggo.Level {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.level
+}
+
+// SetLevel sets the sink's level.
+func (w *Writer) SetLevel(level loggo.Level) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.level = level
+}
+
+// Sinks holds an agent's sink writers, by sink name.
+type Sinks map[string]*Writer
+
+// Configure sets the level of each of the sinks to the one in levels,
+// or back to its default level if levels doesn't have one.
+func (s Sinks) Configure(levels map[string]loggo.Level) {
+	for name, writer := range s {
+		level, ok := levels[name]
+		if !ok {
+			level = writer.defaultLevel
+		}
+		writer.SetLevel(level)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logsink_test
+
+import (
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/logsink"
+)
+
+type logsinkSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&logsinkSuite{})
+
+func (s *logsinkSuite) TestParseConfig(c *gc.C) {
+	cfg, err := logsink.ParseConfig("<root>=DEBUG; juju.worker=TRACE;sink:controller=WARNING; sink:syslog = ERROR;")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, logsink.Config{
+		Modules: "<root>=DEBUG;juju.worker=TRACE",
+		Levels: map[string]loggo.Level{
+			logsink.Controller: loggo.WARNING,
+			logsink.Syslog:     loggo.ERROR,
+		},
+	})
+	c.Assert(cfg.String(), gc.Equals, "<root>=DEBUG;juju.worker=TRACE;sink:controller=WARNING;sink:syslog=ERROR")
+}
+
+func (s *logsinkSuite) TestParseConfigModulesOnly(c *gc.C) {
+	cfg, err := logsink.ParseConfig("<root>=INFO")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.Modules, gc.Equals, "<root>=INFO")
+	c.Assert(cfg.Levels, gc.HasLen, 0)
+	c.Assert(cfg.String(), gc.Equals, "<root>=INFO")
+}
+
+func (s *logsinkSuite) TestParseConfigErrors(c *gc.C) {
+	for i, test := range []struct {
+		config string
+		err    string
+	}{{
+		config: "sink:file",
+		err:    `logging sink config "sink:file" not valid`,
+	}, {
+		config: "sink:mongo=INFO",
+		err:    `logging sink "mongo" not valid`,
+	}, {
+		config: "sink:file=LOUD",
+		err:    `level "LOUD" for logging sink "file" not valid`,
+	}, {
+		config: "<root>=LOUD;sink:file=INFO",
+		err:    `.*unknown severity level "LOUD"`,
+	}} {
+		c.Logf("test %d: %q", i, test.config)
+		_, err := logsink.ParseConfig(test.config)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+type recordingWriter struct {
+	entries []loggo.Entry
+}
+
+func (w *recordingWriter) Write(entry loggo.Entry) {
+	w.entries = append(w.entries, entry)
+}
+
+func (s *logsinkSuite) TestWriterLevel(c *gc.C) {
+	var recorder recordingWriter
+	w := logsink.NewWriter(&recorder, loggo.UNSPECIFIED)
+	w.Write(loggo.Entry{Level: loggo.DEBUG, Message: "debug"})
+
+	w.SetLevel(loggo.WARNING)
+	c.Assert(w.Level(), gc.Equals, loggo.WARNING)
+	w.Write(loggo.Entry{Level: loggo.INFO, Message: "info"})
+	w.Write(loggo.Entry{Level: loggo.ERROR, Message: "error"})
+
+	c.Assert(recorder.entries, jc.DeepEquals, []loggo.Entry{
+		{Level: loggo.DEBUG, Message: "debug"},
+		{Level: loggo.ERROR, Message: "error"},
+	})
+}
+
+func (s *logsinkSuite) TestSinksConfigure(c *gc.C) {
+	file := logsink.NewWriter(&recordingWriter{}, loggo.UNSPECIFIED)
+	syslog := logsink.NewWriter(&recordingWriter{}, logsink.Disabled)
+	sinks := logsink.Sinks{
+		logsink.File:   file,
+		logsink.Syslog: syslog,
+	}
+
+	sinks.Configure(map[string]loggo.Level{
+		logsink.File:   loggo.INFO,
+		logsink.Syslog: loggo.ERROR,
+	})
+	c.Assert(file.Level(), gc.Equals, loggo.INFO)
+	c.Assert(syslog.Level(), gc.Equals, loggo.ERROR)
+
+	// Sinks missing from the config go back to their defaults.
+	sinks.Configure(nil)
+	c.Assert(file.Level(), gc.Equals, loggo.UNSPECIFIED)
+	c.Assert(syslog.Level(), gc.Equals, logsink.Disabled)
+}
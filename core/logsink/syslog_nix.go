@@ -0,0 +1,54 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !windows
+
+package logsink
+
+import (
+	"log/syslog"
+	"sync"
+
+	"github.com/juju/loggo"
+)
+
+// NewSyslogWriter returns a loggo.Writer which writes to the local
+// syslog, tagging the messages with tag. It connects to syslog when
+// the first message is written; messages which can't be written are
+// discarded.
+func NewSyslogWriter(tag string) loggo.Writer {
+	return &syslogWriter{tag: tag}
+}
+
+type syslogWriter struct {
+	tag string
+
+	mu sync.Mutex
+	w  *syslog.Writer
+}
+
+// Write is part of the loggo.Writer interface.
+func (s *syslogWriter) Write(entry loggo.Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.w == nil {
+		w, err := syslog.New(syslog.LOG_INFO|syslog.LOG_DAEMON, s.tag)
+		if err != nil {
+			return
+		}
+		s.w = w
+	}
+	msg := entry.Module + " " + entry.Message
+	switch entry.Level {
+	case loggo.CRITICAL:
+		_ = s.w.Crit(msg)
+	case loggo.ERROR:
+		_ = s.w.Err(msg)
+	case loggo.WARNING:
+		_ = s.w.Warning(msg)
+	case loggo.INFO:
+		_ = s.w.Info(msg)
+	default:
+		_ = s.w.Debug(msg)
+	}
+}
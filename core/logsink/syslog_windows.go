@@ -0,0 +1,17 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logsink
+
+import "github.com/juju/loggo"
+
+// NewSyslogWriter returns a loggo.Writer which discards everything
+// written to it, as there is no syslog on Windows.
+func NewSyslogWriter(tag string) loggo.Writer {
+	return discardWriter{}
+}
+
+type discardWriter struct{}
+
+// Write is part of the loggo.Writer interface.
+func (discardWriter) Write(loggo.Entry) {}
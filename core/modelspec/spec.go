@@ -0,0 +1,134 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelspec defines the declarative spec of a model, which a
+// model is continuously reconciled towards once set with juju apply.
+package modelspec
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/charm/v9"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"gopkg.in/yaml.v2"
+)
+
+// Spec is the declarative spec of a model. Only the applications,
+// relations and offers named in the spec are reconciled; anything else
+// in the model is left alone.
+type Spec struct {
+	// Applications holds the applications of the model, keyed by
+	// application name.
+	Applications map[string]ApplicationSpec `yaml:"applications,omitempty"`
+
+	// Relations holds the relations of the model. Each relation is a
+	// pair of endpoints of the form <application>[:<endpoint>].
+	Relations [][]string `yaml:"relations,omitempty"`
+
+	// Offers holds the offers of the model, keyed by offer name.
+	Offers map[string]OfferSpec `yaml:"offers,omitempty"`
+}
+
+// ApplicationSpec is the spec of an application.
+type ApplicationSpec struct {
+	// Charm is the URL of the application's charm. If the URL has no
+	// revision, any revision of the charm is accepted.
+	Charm string `yaml:"charm"`
+
+	// Units is the number of units of the application. If it is nil,
+	// or the application is a subordinate, the number of units is not
+	// reconciled.
+	Units *int `yaml:"units,omitempty"`
+
+	// Config holds the charm config of the application. Only the
+	// options given are reconciled.
+	Config map[string]interface{} `yaml:"config,omitempty"`
+}
+
+// OfferSpec is the spec of an offer.
+type OfferSpec struct {
+	// Application is the name of the offered application.
+	Application string `yaml:"application"`
+
+	// Endpoints holds the names of the offered endpoints.
+	Endpoints []string `yaml:"endpoints"`
+}
+
+// Parse parses and validates a spec in YAML.
+func Parse(data []byte) (*Spec, error) {
+	var spec Spec
+	if err := yaml.UnmarshalStrict(data, &spec); err != nil {
+		return nil, errors.Annotate(err, "cannot parse model spec")
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &spec, nil
+}
+
+// Validate returns an error if the spec is not valid.
+func (s *Spec) Validate() error {
+	for _, name := range s.ApplicationNames() {
+		app := s.Applications[name]
+		if !names.IsValidApplication(name) {
+			return errors.NotValidf("application name %q", name)
+		}
+		if app.Charm == "" {
+			return errors.NotValidf("application %q with no charm", name)
+		}
+		if _, err := charm.ParseURL(app.Charm); err != nil {
+			return errors.Annotatef(err, "application %q", name)
+		}
+		if app.Units != nil && *app.Units < 0 {
+			return errors.NotValidf("application %q with %d units", name, *app.Units)
+		}
+	}
+	for _, relation := range s.Relations {
+		if len(relation) != 2 {
+			return errors.NotValidf("relation %q with %d endpoints", strings.Join(relation, " "), len(relation))
+		}
+		for _, endpoint := range relation {
+			appName := strings.SplitN(endpoint, ":", 2)[0]
+			if _, ok := s.Applications[appName]; !ok {
+				return errors.NotValidf("relation endpoint %q of unknown application", endpoint)
+			}
+		}
+	}
+	for _, name := range s.OfferNames() {
+		offer := s.Offers[name]
+		// Same rules for valid offer names apply as for applications.
+		if !names.IsValidApplication(name) {
+			return errors.NotValidf("offer name %q", name)
+		}
+		if _, ok := s.Applications[offer.Application]; !ok {
+			return errors.NotValidf("offer %q of unknown application %q", name, offer.Application)
+		}
+		if len(offer.Endpoints) == 0 {
+			return errors.NotValidf("offer %q with no endpoints", name)
+		}
+	}
+	return nil
+}
+
+// ApplicationNames returns the names of the applications in the spec,
+// sorted.
+func (s *Spec) ApplicationNames() []string {
+	result := make([]string, 0, len(s.Applications))
+	for name := range s.Applications {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// OfferNames returns the names of the offers in the spec, sorted.
+func (s *Spec) OfferNames() []string {
+	result := make([]string, 0, len(s.Offers))
+	for name := range s.Offers {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
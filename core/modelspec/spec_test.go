@@ -0,0 +1,97 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelspec_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/modelspec"
+)
+
+type specSuite struct{}
+
+var _ = gc.Suite(&specSuite{})
+
+func (s *specSuite) TestParse(c *gc.C) {
+	spec, err := modelspec.Parse([]byte(`
+applications:
+  wordpress:
+    charm: cs:wordpress
+    units: 2
+    config:
+      blog-title: My Blog
+  mysql:
+    charm: cs:mysql-58
+    units: 1
+relations:
+- [wordpress:db, mysql]
+offers:
+  db:
+    application: mysql
+    endpoints: [server]
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	one, two := 1, 2
+	c.Assert(spec, jc.DeepEquals, &modelspec.Spec{
+		Applications: map[string]modelspec.ApplicationSpec{
+			"wordpress": {
+				Charm:  "cs:wordpress",
+				Units:  &two,
+				Config: map[string]interface{}{"blog-title": "My Blog"},
+			},
+			"mysql": {
+				Charm: "cs:mysql-58",
+				Units: &one,
+			},
+		},
+		Relations: [][]string{{"wordpress:db", "mysql"}},
+		Offers: map[string]modelspec.OfferSpec{
+			"db": {Application: "mysql", Endpoints: []string{"server"}},
+		},
+	})
+	c.Assert(spec.ApplicationNames(), jc.DeepEquals, []string{"mysql", "wordpress"})
+	c.Assert(spec.OfferNames(), jc.DeepEquals, []string{"db"})
+}
+
+func (s *specSuite) TestParseErrors(c *gc.C) {
+	for i, test := range []struct {
+		spec string
+		err  string
+	}{{
+		spec: "applications: [",
+		err:  "cannot parse model spec: .*",
+	}, {
+		spec: "machines: {}",
+		err:  "cannot parse model spec: .*field machines not found.*",
+	}, {
+		spec: "applications: {Bad: {charm: cs:mysql}}",
+		err:  `application name "Bad" not valid`,
+	}, {
+		spec: "applications: {mysql: {}}",
+		err:  `application "mysql" with no charm not valid`,
+	}, {
+		spec: "applications: {mysql: {charm: 'bogus:mysql'}}",
+		err:  `application "mysql": .*`,
+	}, {
+		spec: "applications: {mysql: {charm: cs:mysql, units: -1}}",
+		err:  `application "mysql" with -1 units not valid`,
+	}, {
+		spec: "applications: {mysql: {charm: cs:mysql}}\nrelations: [[mysql]]",
+		err:  `relation "mysql" with 1 endpoints not valid`,
+	}, {
+		spec: "applications: {mysql: {charm: cs:mysql}}\nrelations: [[mysql, wordpress:db]]",
+		err:  `relation endpoint "wordpress:db" of unknown application not valid`,
+	}, {
+		spec: "applications: {mysql: {charm: cs:mysql}}\noffers: {db: {application: pgsql, endpoints: [db]}}",
+		err:  `offer "db" of unknown application "pgsql" not valid`,
+	}, {
+		spec: "applications: {mysql: {charm: cs:mysql}}\noffers: {db: {application: mysql}}",
+		err:  `offer "db" with no endpoints not valid`,
+	}} {
+		c.Logf("test %d: %s", i, test.spec)
+		_, err := modelspec.Parse([]byte(test.spec))
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -307,6 +307,72 @@ func (hps SpaceHostPorts) InSpaces(spaces ...SpaceInfo) (SpaceHostPorts, bool) {
 	return hps, false
 }
 
+// InCIDRs returns the SpaceHostPorts with IP addresses inside one of the
+// input CIDRs. If no CIDRs are given, or none of the host/ports match,
+// the original SpaceHostPorts are returned along with false.
+func (hps SpaceHostPorts) InCIDRs(cidrs ...string) (SpaceHostPorts, bool) {
+	if len(cidrs) == 0 {
+		return hps, false
+	}
+
+	var nets []*net.IPNet
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			logger.Errorf("ignoring invalid CIDR %q: %v", cidr, err)
+			continue
+		}
+		nets = append(nets, ipNet)
+	}
+
+	var selectedHostPorts SpaceHostPorts
+	for _, hp := range hps {
+		ip := hp.IP()
+		if ip == nil {
+			continue
+		}
+		for _, ipNet := range nets {
+			if ipNet.Contains(ip) {
+				selectedHostPorts = append(selectedHostPorts, hp)
+				break
+			}
+		}
+	}
+
+	if len(selectedHostPorts) > 0 {
+		return selectedHostPorts, true
+	}
+
+	logger.Errorf("no hostPorts found in CIDRs %v", cidrs)
+	return hps, false
+}
+
+// InScopes returns the SpaceHostPorts with one of the input address
+// scopes. If no scopes are given, or none of the host/ports match,
+// the original SpaceHostPorts are returned along with false.
+func (hps SpaceHostPorts) InScopes(scopes ...Scope) (SpaceHostPorts, bool) {
+	if len(scopes) == 0 {
+		return hps, false
+	}
+
+	var selectedHostPorts SpaceHostPorts
+	for _, hp := range hps {
+		for _, scope := range scopes {
+			if hp.Scope == scope {
+				selectedHostPorts = append(selectedHostPorts, hp)
+				break
+			}
+		}
+	}
+
+	if len(selectedHostPorts) > 0 {
+		return selectedHostPorts, true
+	}
+
+	logger.Errorf("no hostPorts found with scopes %v", scopes)
+	return hps, false
+}
+
 // AllMatchingScope returns the HostPorts that best satisfy the input scope
 // matching function, as strings usable as arguments to net.Dial.
 func (hps SpaceHostPorts) AllMatchingScope(getMatcher ScopeMatchFunc) []string {
@@ -539,3 +539,31 @@ func (s *HostPortSuite) TestSpaceHostPortsToProviderHostPorts(c *gc.C) {
 	_, err = hps.ToProviderHostPorts(stubLookup{})
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
+
+func (s *HostPortSuite) TestSpaceHostPortsInCIDRs(c *gc.C) {
+	hps := network.NewSpaceHostPorts(17070, "10.0.0.1", "192.168.1.1", "54.32.1.2")
+
+	filtered, ok := hps.InCIDRs("192.168.0.0/16", "54.0.0.0/8")
+	c.Assert(ok, jc.IsTrue)
+	c.Check(filtered, gc.DeepEquals, network.SpaceHostPorts{hps[1], hps[2]})
+
+	filtered, ok = hps.InCIDRs("172.16.0.0/12")
+	c.Assert(ok, jc.IsFalse)
+	c.Check(filtered, gc.DeepEquals, hps)
+
+	filtered, ok = hps.InCIDRs()
+	c.Assert(ok, jc.IsFalse)
+	c.Check(filtered, gc.DeepEquals, hps)
+}
+
+func (s *HostPortSuite) TestSpaceHostPortsInScopes(c *gc.C) {
+	hps := network.NewSpaceHostPorts(17070, "10.0.0.1", "54.32.1.2", "127.0.0.1")
+
+	filtered, ok := hps.InScopes(network.ScopePublic)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(filtered, gc.DeepEquals, network.SpaceHostPorts{hps[1]})
+
+	filtered, ok = hps.InScopes(network.ScopeFanLocal)
+	c.Assert(ok, jc.IsFalse)
+	c.Check(filtered, gc.DeepEquals, hps)
+}
@@ -115,12 +115,14 @@ func (s IDSet) SortedValues() []Id {
 // There can be situations (observed for CAAS) where the addresses can
 // contain a FQDN.
 // For these cases we log a warning and eschew subnet determination.
+// Any CIDRs in the input, such as an IPv6 address with a /64 prefix,
+// are returned as the network they denote.
 func SubnetsForAddresses(addrs []string) []string {
 	var subs []string
 	for _, a := range addrs {
 		// We don't expect this to be the case, but guard conservatively.
-		if _, _, err := net.ParseCIDR(a); err == nil {
-			subs = append(subs, a)
+		if _, ipNet, err := net.ParseCIDR(a); err == nil {
+			subs = append(subs, ipNet.String())
 			continue
 		}
 
@@ -115,3 +115,17 @@ func (s *NetworkSuite) TestSubnetsForAddresses(c *gc.C) {
 		"75ae:3af:968e:3a33:55e2:6379:fa67:d790/128",
 	})
 }
+
+func (s *NetworkSuite) TestSubnetsForAddressesCIDRs(c *gc.C) {
+	addrs := []string{
+		"10.10.10.10/24",
+		"2001:db8::1/64",
+		"2001:db8::1/128",
+	}
+
+	c.Check(network.SubnetsForAddresses(addrs), gc.DeepEquals, []string{
+		"10.10.10.0/24",
+		"2001:db8::/64",
+		"2001:db8::1/128",
+	})
+}
@@ -71,6 +71,10 @@ type PortRange struct {
 	Protocol string
 }
 
+// MaxICMPType is the largest ICMP message type that can be used to restrict
+// an "icmp" port range.
+const MaxICMPType = 255
+
 // IsValid determines if the port range is valid.
 func (p PortRange) Validate() error {
 	proto := strings.ToLower(p.Protocol)
@@ -78,10 +82,7 @@ func (p PortRange) Validate() error {
 		return errors.Errorf(`invalid protocol %q, expected "tcp", "udp", or "icmp"`, proto)
 	}
 	if proto == "icmp" {
-		if p.FromPort == p.ToPort && p.FromPort == -1 {
-			return nil
-		}
-		return errors.Errorf(`protocol "icmp" doesn't support any ports; got "%v"`, p.FromPort)
+		return p.validateICMP()
 	}
 	if p.FromPort > p.ToPort {
 		return errors.Errorf("invalid port range %s", p)
@@ -91,6 +92,27 @@ func (p PortRange) Validate() error {
 	return nil
 }
 
+// validateICMP checks that an "icmp" port range either covers all message
+// types (both bounds set to -1) or a single message type.
+func (p PortRange) validateICMP() error {
+	if p.FromPort == p.ToPort && p.FromPort == -1 {
+		return nil
+	}
+	if p.FromPort != p.ToPort {
+		return errors.Errorf(`protocol "icmp" doesn't support port ranges; got "%d-%d"`, p.FromPort, p.ToPort)
+	}
+	if p.FromPort < 0 || p.FromPort > MaxICMPType {
+		return errors.Errorf(`icmp type must be between 0 and %d, got %d`, MaxICMPType, p.FromPort)
+	}
+	return nil
+}
+
+// IsICMPType returns true if this is an "icmp" port range restricted to a
+// single ICMP message type, rather than one covering all ICMP traffic.
+func (p PortRange) IsICMPType() bool {
+	return strings.ToLower(p.Protocol) == "icmp" && p.FromPort >= 0
+}
+
 // Length returns the number of ports in the range.  If the range is not valid,
 // it returns 0. If this range uses ICMP as the protocol then a -1 is returned
 // instead.
@@ -134,7 +156,7 @@ func (p PortRange) SanitizeBounds() PortRange {
 // String returns a formatted representation of this port range.
 func (p PortRange) String() string {
 	protocol := strings.ToLower(p.Protocol)
-	if protocol == "icmp" {
+	if protocol == "icmp" && !p.IsICMPType() {
 		return protocol
 	}
 	if p.FromPort == p.ToPort {
@@ -188,15 +210,16 @@ func UniquePortRanges(portRanges []PortRange) []PortRange {
 // ParsePortRange builds a PortRange from the provided string. If the
 // string does not include a protocol then "tcp" is used. Validate()
 // gets called on the result before returning. If validation fails the
-// invalid PortRange is still returned.
-// Example strings: "80/tcp", "443", "12345-12349/udp", "icmp".
+// invalid PortRange is still returned. An "icmp" range may optionally be
+// restricted to a single ICMP message type by prefixing it with the type.
+// Example strings: "80/tcp", "443", "12345-12349/udp", "icmp", "8/icmp".
 func ParsePortRange(inPortRange string) (PortRange, error) {
 	// Extract the protocol.
 	protocol := "tcp"
 	parts := strings.SplitN(inPortRange, "/", 2)
 	if len(parts) == 2 {
 		inPortRange = parts[0]
-		protocol = parts[1]
+		protocol = strings.ToLower(parts[1])
 	}
 
 	// Parse the ports.
@@ -271,7 +294,9 @@ func CombinePortRanges(ranges ...PortRange) []PortRange {
 			current = &thispr
 			continue
 		}
-		if pr.Protocol == current.Protocol && pr.FromPort == current.ToPort+1 {
+		// ICMP types are distinct message kinds rather than contiguous
+		// ports, so they are never merged.
+		if pr.Protocol == current.Protocol && pr.Protocol != "icmp" && pr.FromPort == current.ToPort+1 {
 			current.ToPort = thispr.ToPort
 			continue
 		}
@@ -98,6 +98,11 @@ func (*PortRangeSuite) TestStrings(c *gc.C) {
 		gc.Equals,
 		"icmp",
 	)
+	c.Assert(
+		network.PortRange{8, 8, "icmp"}.String(),
+		gc.Equals,
+		"8/icmp",
+	)
 }
 
 func (*PortRangeSuite) TestValidate(c *gc.C) {
@@ -146,9 +151,21 @@ func (*PortRangeSuite) TestValidate(c *gc.C) {
 		network.PortRange{80, 80, "some protocol"},
 		`invalid protocol "some protocol", expected "tcp", "udp", or "icmp"`,
 	}, {
-		"invalid icmp port",
-		network.PortRange{1, 1, "icmp"},
-		`protocol "icmp" doesn't support any ports; got "1"`,
+		"all icmp types",
+		network.PortRange{-1, -1, "icmp"},
+		"",
+	}, {
+		"single icmp type",
+		network.PortRange{8, 8, "icmp"},
+		"",
+	}, {
+		"icmp type range",
+		network.PortRange{0, 8, "icmp"},
+		`protocol "icmp" doesn't support port ranges; got "0-8"`,
+	}, {
+		"icmp type too large",
+		network.PortRange{256, 256, "icmp"},
+		"icmp type must be between 0 and 255, got 256",
 	}}
 
 	for i, t := range testCases {
@@ -220,6 +237,23 @@ func (*PortRangeSuite) TestParseIcmpProtocolRoundTrip(c *gc.C) {
 	c.Check(portRangeStr, gc.Equals, "icmp")
 }
 
+func (*PortRangeSuite) TestParseIcmpType(c *gc.C) {
+	portRange, err := network.ParsePortRange("8/ICMP")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(portRange.Protocol, gc.Equals, "icmp")
+	c.Check(portRange.FromPort, gc.Equals, 8)
+	c.Check(portRange.ToPort, gc.Equals, 8)
+	c.Check(portRange.IsICMPType(), jc.IsTrue)
+	c.Check(portRange.String(), gc.Equals, "8/icmp")
+}
+
+func (*PortRangeSuite) TestParseIcmpTypeRange(c *gc.C) {
+	_, err := network.ParsePortRange("0-8/icmp")
+
+	c.Check(err, gc.ErrorMatches, `protocol "icmp" doesn't support port ranges; got "0-8"`)
+}
+
 func (*PortRangeSuite) TestParsePortRangeRoundTrip(c *gc.C) {
 	portRange, err := network.ParsePortRange("8000-8099/tcp")
 	c.Assert(err, jc.ErrorIsNil)
@@ -287,6 +321,9 @@ func (*PortRangeSuite) TestCombinePortRanges(c *gc.C) {
 	}, {
 		[]network.PortRange{{80, 82, "tcp"}, {81, 84, "udp"}, {84, 84, "tcp"}, {86, 87, "udp"}, {80, 80, "udp"}},
 		[]network.PortRange{{80, 82, "tcp"}, {84, 84, "tcp"}, {80, 84, "udp"}, {86, 87, "udp"}},
+	}, {
+		[]network.PortRange{{9, 9, "icmp"}, {8, 8, "icmp"}},
+		[]network.PortRange{{8, 8, "icmp"}, {9, 9, "icmp"}},
 	}}
 	for i, t := range testCases {
 		c.Logf("test %d", i)
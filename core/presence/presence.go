@@ -32,8 +32,9 @@ type Recorder interface {
 
 	// Connect adds an entry for the specified agent.
 	// The server and agent strings are the stringified machine and unit tags.
-	// The model is the UUID for the model.
-	Connect(server, model, agent string, id uint64, controllerAgent bool, userData string)
+	// The model is the UUID for the model. The remote address is the
+	// address the agent connected from.
+	Connect(server, model, agent string, id uint64, controllerAgent bool, userData, remoteAddress string)
 
 	// Disconnect removes the entry for the specified connection id.
 	Disconnect(server string, id uint64)
@@ -141,6 +142,9 @@ type Value struct {
 	// UserData is the user data provided with the Login API call.
 	UserData string
 
+	// RemoteAddress is the address the agent connected from.
+	RemoteAddress string
+
 	// LastSeen is the timestamp when the connection was added using
 	// Connect, or the last time Activity was called.
 	LastSeen time.Time
@@ -202,7 +206,7 @@ func (r *recorder) findIndex(server string, id uint64) int {
 }
 
 // Connect implements Recorder.
-func (r *recorder) Connect(server, model, agent string, id uint64, controllerAgent bool, userData string) {
+func (r *recorder) Connect(server, model, agent string, id uint64, controllerAgent bool, userData, remoteAddress string) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	if !r.enabled {
@@ -219,6 +223,7 @@ func (r *recorder) Connect(server, model, agent string, id uint64, controllerAge
 			ConnectionID:    id,
 			Status:          Alive,
 			UserData:        userData,
+			RemoteAddress:   remoteAddress,
 			LastSeen:        r.clock.Now(),
 		})
 	} else {
@@ -332,7 +332,7 @@ func lastSeen(v presence.Value, when time.Time) presence.Value {
 }
 
 func connect(r presence.Recorder, info presence.Value) {
-	r.Connect(info.Server, info.Model, info.Agent, info.ConnectionID, info.ControllerAgent, info.UserData)
+	r.Connect(info.Server, info.Model, info.Agent, info.ConnectionID, info.ControllerAgent, info.UserData, info.RemoteAddress)
 }
 
 const bootstrapUUID = "bootstrap-uuid"
@@ -358,10 +358,11 @@ var ha2 = presence.Value{
 }
 
 var modelMachine0 = presence.Value{
-	Model:        modelUUID,
-	Server:       "machine-1",
-	Agent:        "machine-0",
-	ConnectionID: 1237,
+	Model:         modelUUID,
+	Server:        "machine-1",
+	Agent:         "machine-0",
+	ConnectionID:  1237,
+	RemoteAddress: "10.0.0.5:49152",
 }
 var modelMachine1 = presence.Value{
 	Model:        modelUUID,
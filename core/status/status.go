@@ -235,6 +235,13 @@ const (
 // ModificationStatus
 const (
 	Applied Status = "applied"
+
+	// Resizing indicates that the machine's instance is being resized.
+	Resizing Status = "resizing"
+
+	// Replacing indicates that the machine's units are being moved
+	// to a replacement machine.
+	Replacing Status = "replacing"
 )
 
 const (
@@ -252,6 +259,8 @@ func (status Status) KnownModificationStatus() bool {
 	case
 		Idle,
 		Applied,
+		Resizing,
+		Replacing,
 		Error,
 		Unknown:
 		return true
@@ -26,6 +26,16 @@ func (s *StatusSuite) TestModification(c *gc.C) {
 			status: status.Applied,
 			valid:  true,
 		},
+		{
+			name:   "resizing",
+			status: status.Resizing,
+			valid:  true,
+		},
+		{
+			name:   "replacing",
+			status: status.Replacing,
+			valid:  true,
+		},
 		{
 			name:   "error",
 			status: status.Error,
@@ -89,6 +99,8 @@ func (s *StatusSuite) TestInvalidModelStatus(c *gc.C) {
 		status.Provisioning,
 		status.ProvisioningError,
 		status.Rebooting,
+		status.Replacing,
+		status.Resizing,
 		status.Running,
 		status.Suspending,
 		status.Started,
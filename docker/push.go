@@ -0,0 +1,264 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+const (
+	ociManifestMediaType    = "application/vnd.oci.image.manifest.v1+json"
+	dockerManifestMediaType = "application/vnd.docker.distribution.manifest.v2+json"
+)
+
+// descriptor describes content in an OCI image layout.
+type descriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+// imageIndex is the index.json of an OCI image layout.
+type imageIndex struct {
+	Manifests []descriptor `json:"manifests"`
+}
+
+// imageManifest is an OCI image manifest.
+type imageManifest struct {
+	Config descriptor   `json:"config"`
+	Layers []descriptor `json:"layers"`
+}
+
+// PushImageArchive pushes the image in the OCI image layout archive at
+// archivePath, as written by "skopeo copy ... oci-archive:<file>", to a
+// registry as imagePath, such as "registry.example.com/juju/jujud-operator:2.9.1".
+// The archive must hold a single image manifest, and the registry must
+// accept pushes without authentication.
+func PushImageArchive(archivePath, imagePath string) error {
+	baseURL, repo, tag, err := parseImagePath(imagePath)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	dir, err := ioutil.TempDir("", "juju-oci-image")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	if err := extractImageLayout(archivePath, dir); err != nil {
+		return errors.Annotatef(err, "reading OCI image archive %q", archivePath)
+	}
+
+	var index imageIndex
+	if err := readJSON(filepath.Join(dir, "index.json"), &index); err != nil {
+		return errors.Annotate(err, "reading OCI image index")
+	}
+	if len(index.Manifests) != 1 {
+		return errors.NotSupportedf("OCI image archive with %d manifests", len(index.Manifests))
+	}
+	manifestDesc := index.Manifests[0]
+	if manifestDesc.MediaType != ociManifestMediaType && manifestDesc.MediaType != dockerManifestMediaType {
+		return errors.NotSupportedf("OCI image manifest media type %q", manifestDesc.MediaType)
+	}
+	manifestPath, err := blobPath(dir, manifestDesc.Digest)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var manifest imageManifest
+	if err := readJSON(manifestPath, &manifest); err != nil {
+		return errors.Annotate(err, "reading OCI image manifest")
+	}
+
+	r := registry{baseURL: baseURL, repo: repo}
+	for _, desc := range append([]descriptor{manifest.Config}, manifest.Layers...) {
+		blob, err := blobPath(dir, desc.Digest)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := r.pushBlob(blob, desc.Digest); err != nil {
+			return errors.Annotatef(err, "pushing blob %s", desc.Digest)
+		}
+	}
+	return errors.Annotate(r.putManifest(manifestPath, manifestDesc.MediaType, tag), "pushing image manifest")
+}
+
+// parseImagePath splits an image path into the base URL of its
+// registry, its repository and its tag. Registries on the local host
+// are reached over plain HTTP, as the docker daemon does.
+func parseImagePath(imagePath string) (baseURL, repo, tag string, _ error) {
+	parts := strings.SplitN(imagePath, "/", 2)
+	if len(parts) != 2 || (!strings.ContainsAny(parts[0], ".:") && parts[0] != "localhost") {
+		return "", "", "", errors.NotValidf("image path %q without registry host", imagePath)
+	}
+	host, repo := parts[0], parts[1]
+	tag = "latest"
+	if i := strings.LastIndex(repo, ":"); i > strings.LastIndex(repo, "/") {
+		repo, tag = repo[:i], repo[i+1:]
+	}
+	scheme := "https"
+	hostname := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		hostname = h
+	}
+	if hostname == "localhost" || net.ParseIP(hostname).IsLoopback() {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s", scheme, host), repo, tag, nil
+}
+
+// extractImageLayout extracts the index and blobs of the OCI image
+// layout archive at archivePath into dir.
+func extractImageLayout(archivePath, dir string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = f.Close() }()
+	tr := tar.NewReader(f)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean(strings.TrimPrefix(hdr.Name, "./"))
+		if name != "index.json" && !strings.HasPrefix(name, "blobs/") {
+			continue
+		}
+		filename := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			return errors.Trace(err)
+		}
+		out, err := os.Create(filename)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		_, err = io.Copy(out, tr)
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// blobPath returns the path in an extracted image layout of the blob
+// with the given digest.
+func blobPath(dir, digest string) (string, error) {
+	parts := strings.SplitN(digest, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || strings.ContainsAny(parts[1], `/\.`) {
+		return "", errors.NotValidf("digest %q", digest)
+	}
+	return filepath.Join(dir, "blobs", parts[0], parts[1]), nil
+}
+
+func readJSON(filename string, v interface{}) error {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(json.Unmarshal(data, v))
+}
+
+// registry pushes content to a repository using the OCI distribution
+// API.
+type registry struct {
+	baseURL string
+	repo    string
+}
+
+func (r registry) url(format string, args ...interface{}) string {
+	return fmt.Sprintf("%s/v2/%s/%s", r.baseURL, r.repo, fmt.Sprintf(format, args...))
+}
+
+// pushBlob uploads the blob at filename in a single request, unless the
+// registry already has it.
+func (r registry) pushBlob(filename, digest string) error {
+	resp, err := http.Head(r.url("blobs/%s", digest))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	resp, err = http.Post(r.url("blobs/uploads/"), "", nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return errors.Errorf("starting upload: unexpected response from registry: %s", resp.Status)
+	}
+	location, err := resp.Location()
+	if err != nil {
+		return errors.Annotate(err, "starting upload")
+	}
+	query := location.Query()
+	query.Set("digest", digest)
+	location.RawQuery = query.Encode()
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(r.put(location, f, info.Size(), "application/octet-stream"))
+}
+
+// putManifest uploads the manifest at filename with the given tag.
+func (r registry) putManifest(filename, mediaType, tag string) error {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	location, err := url.Parse(r.url("manifests/%s", tag))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(r.put(location, bytes.NewReader(data), int64(len(data)), mediaType))
+}
+
+func (r registry) put(location *url.URL, body io.Reader, size int64, contentType string) error {
+	req, err := http.NewRequest(http.MethodPut, location.String(), body)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return errors.Errorf("unexpected response from registry: %s", resp.Status)
+	}
+	return nil
+}
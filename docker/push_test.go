@@ -0,0 +1,160 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package docker_test
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/docker"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type pushSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&pushSuite{})
+
+// fakeRegistry is a minimal OCI distribution API server, recording the
+// blobs and manifests pushed to it.
+type fakeRegistry struct {
+	mu        sync.Mutex
+	blobs     map[string]string
+	manifests map[string]string
+	types     map[string]string
+}
+
+func newFakeRegistry() *fakeRegistry {
+	return &fakeRegistry{
+		blobs:     make(map[string]string),
+		manifests: make(map[string]string),
+		types:     make(map[string]string),
+	}
+}
+
+func (r *fakeRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p := req.URL.Path
+	switch {
+	case req.Method == http.MethodHead && strings.Contains(p, "/blobs/"):
+		if _, ok := r.blobs[p[strings.LastIndex(p, "/")+1:]]; ok {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	case req.Method == http.MethodPost && strings.HasSuffix(p, "/blobs/uploads/"):
+		w.Header().Set("Location", p+"upload-id?state=x")
+		w.WriteHeader(http.StatusAccepted)
+	case req.Method == http.MethodPut && strings.Contains(p, "/blobs/uploads/"):
+		data, _ := ioutil.ReadAll(req.Body)
+		digest := req.URL.Query().Get("digest")
+		if digest != fmt.Sprintf("sha256:%x", sha256.Sum256(data)) || req.URL.Query().Get("state") != "x" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r.blobs[digest] = string(data)
+		w.WriteHeader(http.StatusCreated)
+	case req.Method == http.MethodPut && strings.Contains(p, "/manifests/"):
+		data, _ := ioutil.ReadAll(req.Body)
+		r.manifests[p] = string(data)
+		r.types[p] = req.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func digest(content string) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(content)))
+}
+
+// writeImageArchive writes an OCI image layout archive holding an image
+// with the given config and layer, and returns its path and manifest.
+func writeImageArchive(c *gc.C, config, layer string) (string, string) {
+	manifest := fmt.Sprintf(
+		`{"schemaVersion":2,"config":{"mediaType":"application/vnd.oci.image.config.v1+json","digest":%q,"size":%d},`+
+			`"layers":[{"mediaType":"application/vnd.oci.image.layer.v1.tar+gzip","digest":%q,"size":%d}]}`,
+		digest(config), len(config), digest(layer), len(layer))
+	index := fmt.Sprintf(
+		`{"schemaVersion":2,"manifests":[{"mediaType":"application/vnd.oci.image.manifest.v1+json","digest":%q,"size":%d}]}`,
+		digest(manifest), len(manifest))
+
+	filename := filepath.Join(c.MkDir(), "image.tar")
+	f, err := os.Create(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	tw := tar.NewWriter(f)
+	for _, entry := range []struct{ name, content string }{
+		{"oci-layout", `{"imageLayoutVersion":"1.0.0"}`},
+		{"index.json", index},
+		{"blobs/sha256/" + digest(manifest)[7:], manifest},
+		{"blobs/sha256/" + digest(config)[7:], config},
+		{"blobs/sha256/" + digest(layer)[7:], layer},
+	} {
+		err := tw.WriteHeader(&tar.Header{
+			Name: entry.name,
+			Mode: 0644,
+			Size: int64(len(entry.content)),
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = tw.Write([]byte(entry.content))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(tw.Close(), jc.ErrorIsNil)
+	return filename, manifest
+}
+
+func (s *pushSuite) TestPushImageArchive(c *gc.C) {
+	registry := newFakeRegistry()
+	srv := httptest.NewServer(registry)
+	defer srv.Close()
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	archive, manifest := writeImageArchive(c, "config", "layer")
+	err := docker.PushImageArchive(archive, host+"/juju/jujud-operator:2.9.1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(registry.blobs, jc.DeepEquals, map[string]string{
+		digest("config"): "config",
+		digest("layer"):  "layer",
+	})
+	c.Assert(registry.manifests, jc.DeepEquals, map[string]string{
+		"/v2/juju/jujud-operator/manifests/2.9.1": manifest,
+	})
+	c.Assert(registry.types["/v2/juju/jujud-operator/manifests/2.9.1"], gc.Equals, "application/vnd.oci.image.manifest.v1+json")
+}
+
+func (s *pushSuite) TestPushImageArchiveSkipsExistingBlobs(c *gc.C) {
+	registry := newFakeRegistry()
+	registry.blobs[digest("config")] = "existing"
+	srv := httptest.NewServer(registry)
+	defer srv.Close()
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	archive, _ := writeImageArchive(c, "config", "layer")
+	err := docker.PushImageArchive(archive, host+"/juju-db:4.0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(registry.blobs[digest("config")], gc.Equals, "existing")
+	c.Assert(registry.blobs[digest("layer")], gc.Equals, "layer")
+	c.Assert(registry.manifests, jc.HasKey, "/v2/juju-db/manifests/4.0")
+}
+
+func (s *pushSuite) TestPushImageArchiveNoRegistryHost(c *gc.C) {
+	archive, _ := writeImageArchive(c, "config", "layer")
+	err := docker.PushImageArchive(archive, "jujusolutions/jujud-operator:2.9.1")
+	c.Assert(err, gc.ErrorMatches, `image path "jujusolutions/jujud-operator:2.9.1" without registry host not valid`)
+}
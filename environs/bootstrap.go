@@ -78,6 +78,22 @@ type BootstrapParams struct {
 
 	// Force is used to allow a bootstrap to be run on unsupported series.
 	Force bool
+
+	// AdoptExistingMachine indicates that the controller is being
+	// bootstrapped onto an existing machine, which the provider should
+	// check is suitable for running a controller before using it.
+	AdoptExistingMachine bool
+
+	// LXDImagePaths maps series/arch to local unified LXD image
+	// tarballs. LXD providers add them to the image cache before
+	// starting the controller, so its image need not be downloaded.
+	LXDImagePaths map[string]string
+
+	// OCIImagePaths maps the names of the controller's OCI images, such
+	// as "jujud-operator", to local OCI image layout archives. k8s
+	// providers push them to the caas-image-repo registry before
+	// starting the controller.
+	OCIImagePaths map[string]string
 }
 
 // CloudBootstrapFinalizer is a function returned from Environ.Bootstrap.
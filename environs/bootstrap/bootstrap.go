@@ -142,6 +142,11 @@ type BootstrapParams struct {
 	// If not set, the Juju Dashboard is not installed from simplestreams.
 	DashboardDataSourceBaseURL string
 
+	// DashboardArchivePath, if set, is the path of a local Juju Dashboard
+	// archive to install in the controller instead of one retrieved from
+	// simplestreams. It is not supported for k8s controllers.
+	DashboardArchivePath string
+
 	// AdminSecret contains the administrator password.
 	AdminSecret string
 
@@ -175,9 +180,23 @@ type BootstrapParams struct {
 	// Force is used to allow a bootstrap to be run on unsupported series.
 	Force bool
 
+	// AdoptExistingMachine indicates that the controller is being
+	// bootstrapped onto an existing machine, which should be checked
+	// for its suitability before being used.
+	AdoptExistingMachine bool
+
 	// ControllerCharmPath is a local controller charm archive.
 	ControllerCharmPath string
 
+	// LXDImagePaths maps series/arch to local LXD images to add to the
+	// image cache of LXD clouds before the controller is started.
+	LXDImagePaths map[string]string
+
+	// OCIImagePaths maps the names of the controller's OCI images to
+	// local OCI image archives, pushed to the caas-image-repo registry
+	// of k8s clouds before the controller is started.
+	OCIImagePaths map[string]string
+
 	// ExtraAgentValuesForTesting are testing only values written to the agent config file.
 	ExtraAgentValuesForTesting map[string]string
 }
@@ -457,6 +476,7 @@ func bootstrapIAAS(
 			versionTxt = fmt.Sprintf("%v.%v", agentVersion.Major, agentVersion.Minor)
 		}
 		ctx.Infof("Looking for %vpackaged Juju agent version %s for %s", latestPatchTxt, versionTxt, bootstrapArch)
+		tools.SetAgentMetadataPublicKey(args.ControllerConfig.AgentMetadataPublicKey())
 		availableTools, err = findPackagedTools(environ, args.AgentVersion, &bootstrapArch, bootstrapSeries)
 		if err != nil && !errors.IsNotFound(err) {
 			return err
@@ -643,6 +663,9 @@ func Bootstrap(
 		SupportedBootstrapSeries:   args.SupportedBootstrapSeries,
 		Placement:                  args.Placement,
 		Force:                      args.Force,
+		AdoptExistingMachine:       args.AdoptExistingMachine,
+		LXDImagePaths:              args.LXDImagePaths,
+		OCIImagePaths:              args.OCIImagePaths,
 		ExtraAgentValuesForTesting: args.ExtraAgentValuesForTesting,
 	}
 	doBootstrap := bootstrapIAAS
@@ -723,7 +746,7 @@ func finalizeInstanceBootstrapConfig(
 	icfg.Bootstrap.HostedModelConfig = args.HostedModelConfig
 	icfg.Bootstrap.StoragePools = args.StoragePools
 	icfg.Bootstrap.Timeout = args.DialOpts.Timeout
-	icfg.Bootstrap.Dashboard = dashboardArchive(args.DashboardDataSourceBaseURL, cfg.DashboardStream(), vers.Major, vers.Minor, true, func(msg string) {
+	icfg.Bootstrap.Dashboard = dashboardArchive(args.DashboardArchivePath, args.DashboardDataSourceBaseURL, cfg.DashboardStream(), vers.Major, vers.Minor, true, func(msg string) {
 		ctx.Infof(msg)
 	})
 	icfg.Bootstrap.JujuDbSnapPath = args.JujuDbSnapPath
@@ -809,7 +832,7 @@ func finalizePodBootstrapConfig(
 	pcfg.Bootstrap.ControllerServiceType = args.ControllerServiceType
 	pcfg.Bootstrap.ControllerExternalName = args.ControllerExternalName
 	pcfg.Bootstrap.ControllerExternalIPs = append([]string(nil), args.ControllerExternalIPs...)
-	pcfg.Bootstrap.Dashboard = dashboardArchive(args.DashboardDataSourceBaseURL, cfg.DashboardStream(), vers.Major, vers.Minor, false, func(msg string) {
+	pcfg.Bootstrap.Dashboard = dashboardArchive(args.DashboardArchivePath, args.DashboardDataSourceBaseURL, cfg.DashboardStream(), vers.Major, vers.Minor, false, func(msg string) {
 		ctx.Infof(msg)
 	})
 	return nil
@@ -1072,13 +1095,17 @@ func setPrivateMetadataSources(metadataDir string) ([]*imagemetadata.ImageMetada
 
 // dashboardArchive returns information on the Dashboard archive that will be uploaded
 // to the controller. Possible errors in retrieving the Dashboard archive information
-// do not prevent the model to be bootstrapped. If dataSourceBaseURL is
+// do not prevent the model to be bootstrapped. If path is non-empty, or the
+// JUJU_DASHBOARD environment variable is set, the local archive at that path is
+// used. Otherwise, if dataSourceBaseURL is
 // non-empty, remote Dashboard archive info is retrieved from simplestreams using it
 // as the base URL. The given logProgress function is used to inform users
 // about errors or progress in setting up the Juju Dashboard.
-func dashboardArchive(dataSourceBaseURL, stream string, major, minor int, allowLocal bool, logProgress func(string)) *coretools.DashboardArchive {
-	// The environment variable is only used for development purposes.
-	path := os.Getenv("JUJU_DASHBOARD")
+func dashboardArchive(path, dataSourceBaseURL, stream string, major, minor int, allowLocal bool, logProgress func(string)) *coretools.DashboardArchive {
+	if path == "" {
+		// The environment variable is only used for development purposes.
+		path = os.Getenv("JUJU_DASHBOARD")
+	}
 	if path != "" && !allowLocal {
 		// TODO(wallyworld) - support local archive on k8s controllers at bootstrap
 		// It can't be passed the same way as on IAAS as it's too large.
@@ -966,6 +966,25 @@ func (s *bootstrapSuite) TestBootstrapDashboardSuccessLocal(c *gc.C) {
 	c.Assert(env.instanceConfig.Bootstrap.Dashboard.SHA256, gc.Equals, fmt.Sprintf("%x", h.Sum(nil)))
 }
 
+func (s *bootstrapSuite) TestBootstrapDashboardArchivePath(c *gc.C) {
+	path := makeDashboardArchive(c, "2.2.0")
+	env := newEnviron("foo", useDefaultKeys, nil)
+	ctx := cmdtesting.Context(c)
+	err := bootstrap.Bootstrap(modelcmd.BootstrapContext(context.Background(), ctx), env,
+		s.callContext, bootstrap.BootstrapParams{
+			ControllerConfig:           coretesting.FakeControllerConfig(),
+			AdminSecret:                "admin-secret",
+			CAPrivateKey:               coretesting.CAKey,
+			DashboardDataSourceBaseURL: "https://1.2.3.4/dashboard/sources",
+			DashboardArchivePath:       path,
+			SupportedBootstrapSeries:   supportedJujuSeries,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, "Fetching Juju Dashboard 2.2.0 from local archive\n")
+	c.Assert(env.instanceConfig.Bootstrap.Dashboard.URL, gc.Equals, "file://"+path)
+	c.Assert(env.instanceConfig.Bootstrap.Dashboard.Version.String(), gc.Equals, "2.2.0")
+}
+
 func (s *bootstrapSuite) TestBootstrapDashboardSuccessNoGUI(c *gc.C) {
 	env := newEnviron("foo", useDefaultKeys, nil)
 	ctx := cmdtesting.Context(c)
@@ -0,0 +1,343 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package bootstrapbundle reads and writes bootstrap bundles. A
+// bootstrap bundle is a gzipped tar archive holding everything needed
+// to bootstrap a controller without access to the internet: agent
+// binaries and their simplestreams metadata, and optionally image
+// metadata, a Juju Dashboard archive, a juju-db snap, a controller
+// charm, and LXD and OCI images for the controller.
+package bootstrapbundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// ManifestFile is the name of the file describing the bundle's
+	// contents. It is the first entry in the archive.
+	ManifestFile = "manifest.yaml"
+
+	// MetadataDir is the directory holding the simplestreams metadata,
+	// laid out as expected by bootstrap's --metadata-source: agent
+	// binaries under "tools" and image metadata under "images".
+	MetadataDir = "metadata"
+
+	// DashboardFile is the Juju Dashboard archive.
+	DashboardFile = "dashboard.tar.bz2"
+
+	// DBSnapFile and DBSnapAssertionsFile are the juju-db snap and its
+	// assertions.
+	DBSnapFile           = "juju-db.snap"
+	DBSnapAssertionsFile = "juju-db.assert"
+
+	// ControllerCharmFile is the controller charm.
+	ControllerCharmFile = "controller.charm"
+
+	// LXDImagesDir holds unified LXD image tarballs, as written by
+	// "lxc image export".
+	LXDImagesDir = "container-images/lxd"
+
+	// OCIImagesDir holds OCI image layout archives.
+	OCIImagesDir = "container-images/oci"
+)
+
+// Manifest describes the contents of a bootstrap bundle.
+type Manifest struct {
+	// AgentVersion is the version of the agent binaries in the bundle.
+	AgentVersion version.Number `yaml:"agent-version"`
+
+	// DashboardVersion is the version of the Juju Dashboard in the
+	// bundle, if there is one.
+	DashboardVersion string `yaml:"dashboard-version,omitempty"`
+
+	// Files holds the SHA256 hash of each file in the bundle, keyed by
+	// its slash separated path within the bundle.
+	Files map[string]string `yaml:"files"`
+
+	// LXDImages maps the series/arch of each LXD image in the bundle to
+	// its path within the bundle.
+	LXDImages map[string]string `yaml:"lxd-images,omitempty"`
+
+	// OCIImages maps the name of each OCI image in the bundle, such as
+	// "jujud-operator", to its path within the bundle.
+	OCIImages map[string]string `yaml:"oci-images,omitempty"`
+}
+
+// LXDImageFile returns the path within a bundle of the LXD image for
+// the given series and architecture.
+func LXDImageFile(series, arch string) string {
+	return path.Join(LXDImagesDir, series+"-"+arch)
+}
+
+// OCIImageFile returns the path within a bundle of the named OCI image.
+func OCIImageFile(name string) string {
+	return path.Join(OCIImagesDir, name+".tar")
+}
+
+// Create writes a bootstrap bundle holding the contents of dir to w.
+// The hashes of the files are added to the manifest, which is written
+// first.
+func Create(w io.Writer, dir string, manifest Manifest) error {
+	var paths []string
+	manifest.Files = make(map[string]string)
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		name := filepath.ToSlash(rel)
+		if name == ManifestFile {
+			return nil
+		}
+		hash, err := fileHash(p)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		manifest.Files[name] = hash
+		paths = append(paths, name)
+		return nil
+	})
+	if err != nil {
+		return errors.Annotate(err, "reading bundle contents")
+	}
+	sort.Strings(paths)
+
+	manifestData, err := yaml.Marshal(manifest)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+	if err := tw.WriteHeader(&tar.Header{
+		Name: ManifestFile,
+		Mode: 0644,
+		Size: int64(len(manifestData)),
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := tw.Write(manifestData); err != nil {
+		return errors.Trace(err)
+	}
+	for _, name := range paths {
+		if err := addFile(tw, filepath.Join(dir, filepath.FromSlash(name)), name); err != nil {
+			return errors.Annotatef(err, "adding %q", name)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(gzw.Close())
+}
+
+func addFile(tw *tar.Writer, filename, name string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    int64(info.Mode().Perm()),
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	_, err = io.Copy(tw, f)
+	return errors.Trace(err)
+}
+
+// Bundle is a bootstrap bundle extracted to a directory.
+type Bundle struct {
+	// Dir is the directory the bundle was extracted to.
+	Dir string
+
+	// Manifest describes the bundle's contents.
+	Manifest Manifest
+}
+
+// Extract extracts the bootstrap bundle read from r into dir, checking
+// each file against the hash recorded in the manifest. Entries which
+// are not in the manifest, or which would be written outside dir, are
+// rejected.
+func Extract(r io.Reader, dir string) (*Bundle, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading bootstrap bundle")
+	}
+	tr := tar.NewReader(gzr)
+
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, errors.Annotate(err, "reading bootstrap bundle")
+	}
+	if hdr.Name != ManifestFile {
+		return nil, errors.NotValidf("bootstrap bundle without %s", ManifestFile)
+	}
+	manifestData, err := ioutil.ReadAll(tr)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading bootstrap bundle manifest")
+	}
+	var manifest Manifest
+	if err := yaml.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, errors.Annotate(err, "parsing bootstrap bundle manifest")
+	}
+
+	extracted := make(map[string]bool)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Annotate(err, "reading bootstrap bundle")
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			return nil, errors.NotValidf("bootstrap bundle entry %q", hdr.Name)
+		}
+		name := path.Clean(hdr.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return nil, errors.NotValidf("bootstrap bundle entry %q", hdr.Name)
+		}
+		expected, ok := manifest.Files[name]
+		if !ok {
+			return nil, errors.NotValidf("bootstrap bundle entry %q not in manifest", hdr.Name)
+		}
+		hash, err := extractFile(tr, filepath.Join(dir, filepath.FromSlash(name)), os.FileMode(hdr.Mode).Perm())
+		if err != nil {
+			return nil, errors.Annotatef(err, "extracting %q", name)
+		}
+		if hash != expected {
+			return nil, errors.Errorf("bootstrap bundle entry %q is corrupt: hash %s, expected %s", name, hash, expected)
+		}
+		extracted[name] = true
+	}
+	for name := range manifest.Files {
+		if !extracted[name] {
+			return nil, errors.NotFoundf("bootstrap bundle entry %q", name)
+		}
+	}
+	for _, images := range []map[string]string{manifest.LXDImages, manifest.OCIImages} {
+		for image, name := range images {
+			if !extracted[name] {
+				return nil, errors.NotFoundf("bootstrap bundle image %q", image)
+			}
+		}
+	}
+	return &Bundle{Dir: dir, Manifest: manifest}, nil
+}
+
+func extractFile(r io.Reader, filename string, mode os.FileMode) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return "", errors.Trace(err)
+	}
+	f, err := os.OpenFile(filename, os.O_CREATE|os.O_EXCL|os.O_WRONLY, mode)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer func() { _ = f.Close() }()
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(f, h), r); err != nil {
+		return "", errors.Trace(err)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), errors.Trace(f.Close())
+}
+
+// MetadataDir returns the directory holding the bundle's simplestreams
+// metadata.
+func (b *Bundle) MetadataDir() string {
+	return filepath.Join(b.Dir, MetadataDir)
+}
+
+// DashboardPath returns the path of the bundle's Juju Dashboard
+// archive, or "" if it has none.
+func (b *Bundle) DashboardPath() string {
+	return b.path(DashboardFile)
+}
+
+// DBSnapPath returns the path of the bundle's juju-db snap, or "" if it
+// has none.
+func (b *Bundle) DBSnapPath() string {
+	return b.path(DBSnapFile)
+}
+
+// DBSnapAssertionsPath returns the path of the bundle's juju-db snap
+// assertions, or "" if it has none.
+func (b *Bundle) DBSnapAssertionsPath() string {
+	return b.path(DBSnapAssertionsFile)
+}
+
+// ControllerCharmPath returns the path of the bundle's controller
+// charm, or "" if it has none.
+func (b *Bundle) ControllerCharmPath() string {
+	return b.path(ControllerCharmFile)
+}
+
+// LXDImagePaths returns the paths of the bundle's LXD images, keyed by
+// series/arch.
+func (b *Bundle) LXDImagePaths() map[string]string {
+	return b.paths(b.Manifest.LXDImages)
+}
+
+// OCIImagePaths returns the paths of the bundle's OCI images, keyed by
+// image name.
+func (b *Bundle) OCIImagePaths() map[string]string {
+	return b.paths(b.Manifest.OCIImages)
+}
+
+func (b *Bundle) paths(images map[string]string) map[string]string {
+	if len(images) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(images))
+	for image, name := range images {
+		result[image] = filepath.Join(b.Dir, filepath.FromSlash(name))
+	}
+	return result
+}
+
+func (b *Bundle) path(name string) string {
+	if _, ok := b.Manifest.Files[name]; !ok {
+		return ""
+	}
+	return filepath.Join(b.Dir, name)
+}
+
+func fileHash(filename string) (string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer func() { _ = f.Close() }()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", errors.Trace(err)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
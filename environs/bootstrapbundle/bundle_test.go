@@ -0,0 +1,185 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bootstrapbundle_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/bootstrapbundle"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type bundleSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&bundleSuite{})
+
+func (s *bundleSuite) writeFiles(c *gc.C, dir string, files map[string]string) {
+	for name, content := range files {
+		filename := filepath.Join(dir, filepath.FromSlash(name))
+		err := os.MkdirAll(filepath.Dir(filename), 0755)
+		c.Assert(err, jc.ErrorIsNil)
+		err = ioutil.WriteFile(filename, []byte(content), 0644)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *bundleSuite) TestCreateExtract(c *gc.C) {
+	src := c.MkDir()
+	s.writeFiles(c, src, map[string]string{
+		"metadata/tools/streams/v1/index.json": "{}",
+		"metadata/tools/released/juju.tgz":     "agent",
+		bootstrapbundle.DashboardFile:          "dashboard",
+		bootstrapbundle.DBSnapFile:             "snap",
+	})
+
+	var buf bytes.Buffer
+	err := bootstrapbundle.Create(&buf, src, bootstrapbundle.Manifest{
+		AgentVersion:     version.MustParse("2.9.1"),
+		DashboardVersion: "0.8.1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	dest := c.MkDir()
+	bundle, err := bootstrapbundle.Extract(&buf, dest)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bundle.Dir, gc.Equals, dest)
+	c.Assert(bundle.Manifest.AgentVersion, gc.Equals, version.MustParse("2.9.1"))
+	c.Assert(bundle.Manifest.DashboardVersion, gc.Equals, "0.8.1")
+	c.Assert(bundle.Manifest.Files, gc.HasLen, 4)
+
+	c.Assert(bundle.MetadataDir(), gc.Equals, filepath.Join(dest, "metadata"))
+	c.Assert(bundle.DashboardPath(), gc.Equals, filepath.Join(dest, bootstrapbundle.DashboardFile))
+	c.Assert(bundle.DBSnapPath(), gc.Equals, filepath.Join(dest, bootstrapbundle.DBSnapFile))
+	c.Assert(bundle.DBSnapAssertionsPath(), gc.Equals, "")
+	c.Assert(bundle.ControllerCharmPath(), gc.Equals, "")
+
+	data, err := ioutil.ReadFile(filepath.Join(dest, "metadata", "tools", "released", "juju.tgz"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "agent")
+}
+
+type entry struct {
+	name    string
+	content string
+}
+
+func makeBundle(c *gc.C, entries ...entry) *bytes.Buffer {
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	for _, e := range entries {
+		err := tw.WriteHeader(&tar.Header{
+			Name: e.name,
+			Mode: 0644,
+			Size: int64(len(e.content)),
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = tw.Write([]byte(e.content))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(tw.Close(), jc.ErrorIsNil)
+	c.Assert(gzw.Close(), jc.ErrorIsNil)
+	return &buf
+}
+
+// The SHA256 hash of "agent".
+const agentHash = "d4f0bc5a29de06b510f9aa428f1eedba926012b591fef7a518e776a7c9bd1824"
+
+func (s *bundleSuite) TestExtractNoManifest(c *gc.C) {
+	buf := makeBundle(c, entry{"juju-db.snap", "snap"})
+	_, err := bootstrapbundle.Extract(buf, c.MkDir())
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "bootstrap bundle without manifest.yaml not valid")
+}
+
+func (s *bundleSuite) TestExtractPathTraversal(c *gc.C) {
+	buf := makeBundle(c,
+		entry{"manifest.yaml", "agent-version: 2.9.1\nfiles:\n  ../escape: " + agentHash + "\n"},
+		entry{"metadata/../../escape", "agent"},
+	)
+	dir := c.MkDir()
+	_, err := bootstrapbundle.Extract(buf, filepath.Join(dir, "bundle"))
+	c.Assert(err, gc.ErrorMatches, `bootstrap bundle entry "metadata/../../escape" not valid`)
+	_, err = os.Stat(filepath.Join(dir, "escape"))
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *bundleSuite) TestExtractNotInManifest(c *gc.C) {
+	buf := makeBundle(c,
+		entry{"manifest.yaml", "agent-version: 2.9.1\nfiles: {}\n"},
+		entry{"juju-db.snap", "snap"},
+	)
+	_, err := bootstrapbundle.Extract(buf, c.MkDir())
+	c.Assert(err, gc.ErrorMatches, `bootstrap bundle entry "juju-db.snap" not in manifest not valid`)
+}
+
+func (s *bundleSuite) TestExtractCorrupt(c *gc.C) {
+	buf := makeBundle(c,
+		entry{"manifest.yaml", "agent-version: 2.9.1\nfiles:\n  juju-db.snap: " + agentHash + "\n"},
+		entry{"juju-db.snap", "snap"},
+	)
+	_, err := bootstrapbundle.Extract(buf, c.MkDir())
+	c.Assert(err, gc.ErrorMatches, `bootstrap bundle entry "juju-db.snap" is corrupt: .*`)
+}
+
+func (s *bundleSuite) TestExtractMissing(c *gc.C) {
+	buf := makeBundle(c,
+		entry{"manifest.yaml", "agent-version: 2.9.1\nfiles:\n  juju-db.snap: " + agentHash + "\n"},
+	)
+	_, err := bootstrapbundle.Extract(buf, c.MkDir())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *bundleSuite) TestCreateExtractImages(c *gc.C) {
+	lxdImage := bootstrapbundle.LXDImageFile("focal", "amd64")
+	ociImage := bootstrapbundle.OCIImageFile("jujud-operator")
+	c.Assert(lxdImage, gc.Equals, "container-images/lxd/focal-amd64")
+	c.Assert(ociImage, gc.Equals, "container-images/oci/jujud-operator.tar")
+
+	src := c.MkDir()
+	s.writeFiles(c, src, map[string]string{
+		"metadata/tools/released/juju.tgz": "agent",
+		lxdImage:                           "lxd",
+		ociImage:                           "oci",
+	})
+
+	var buf bytes.Buffer
+	err := bootstrapbundle.Create(&buf, src, bootstrapbundle.Manifest{
+		AgentVersion: version.MustParse("2.9.1"),
+		LXDImages:    map[string]string{"focal/amd64": lxdImage},
+		OCIImages:    map[string]string{"jujud-operator": ociImage},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	dest := c.MkDir()
+	bundle, err := bootstrapbundle.Extract(&buf, dest)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bundle.LXDImagePaths(), jc.DeepEquals, map[string]string{
+		"focal/amd64": filepath.Join(dest, "container-images", "lxd", "focal-amd64"),
+	})
+	c.Assert(bundle.OCIImagePaths(), jc.DeepEquals, map[string]string{
+		"jujud-operator": filepath.Join(dest, "container-images", "oci", "jujud-operator.tar"),
+	})
+}
+
+func (s *bundleSuite) TestExtractImageMissing(c *gc.C) {
+	buf := makeBundle(c,
+		entry{"manifest.yaml", "agent-version: 2.9.1\nfiles: {}\nlxd-images:\n  focal/amd64: container-images/lxd/focal-amd64\n"},
+	)
+	_, err := bootstrapbundle.Extract(buf, c.MkDir())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `bootstrap bundle image "focal/amd64" not found`)
+}
@@ -24,10 +24,12 @@ import (
 
 	"github.com/juju/juju/charmhub"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/logsink"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/network"
+	jujuproxy "github.com/juju/juju/utils/proxy"
 	jujuversion "github.com/juju/juju/version"
 )
 
@@ -89,6 +91,11 @@ const (
 	// of OS image metadata for containers.
 	ContainerImageMetadataURLKey = "container-image-metadata-url"
 
+	// ImageMetadataServiceURLKey is the key used to specify the URL of a
+	// site-defined service that is queried for OS images when starting
+	// instances, ahead of simplestreams.
+	ImageMetadataServiceURLKey = "image-metadata-service-url"
+
 	// DashboardStreamKey stores the key used to specify the stream
 	// to used when fetching a dashboard tarball.
 	DashboardStreamKey = "dashboard-stream"
@@ -159,6 +166,11 @@ const (
 	// SnapStoreProxyURL is used to specify the URL to a snap store proxy.
 	SnapStoreProxyURLKey = "snap-store-proxy-url"
 
+	// AgentAPIProxyKey is used to set the HTTP CONNECT or SOCKS5 proxy
+	// through which agents connect to the controller. It is separate
+	// from the proxy settings used for other traffic.
+	AgentAPIProxyKey = "agent-api-proxy"
+
 	// NetBondReconfigureDelay is the key to pass when bridging
 	// the network for containers.
 	NetBondReconfigureDelayKey = "net-bond-reconfigure-delay"
@@ -230,6 +242,12 @@ const (
 	// originates if the model is deployed such that NAT or similar is in use.
 	EgressSubnets = "egress-subnets"
 
+	// EgressNATAddress is the address of the NAT gateway through which
+	// traffic from machines in this model egresses. It is used as the
+	// default egress for cross-model relations, and may be overridden
+	// for individual machines.
+	EgressNATAddress = "egress-nat-address"
+
 	// FanConfig defines the configuration for FAN network running in the model.
 	FanConfig = "fan-config"
 
@@ -238,6 +256,14 @@ const (
 	// provisioning machines.
 	CloudInitUserDataKey = "cloudinit-userdata"
 
+	// CloudInitPackagesKey is the key to specify a list of extra packages
+	// to install on new machines. The list will be comma separated.
+	CloudInitPackagesKey = "cloudinit-packages"
+
+	// CloudInitRunCmdsKey is the key to specify a YAML list of extra
+	// commands to run on new machines, after Juju's own commands.
+	CloudInitRunCmdsKey = "cloudinit-runcmds"
+
 	// BackupDirKey specifies the backup working directory.
 	BackupDirKey = "backup-dir"
 
@@ -490,8 +516,11 @@ var defaultConfigValues = map[string]interface{}{
 	TransmitVendorMetricsKey:      true,
 	UpdateStatusHookInterval:      DefaultUpdateStatusHookInterval,
 	EgressSubnets:                 "",
+	EgressNATAddress:              "",
 	FanConfig:                     "",
 	CloudInitUserDataKey:          "",
+	CloudInitPackagesKey:          "",
+	CloudInitRunCmdsKey:           "",
 	ContainerInheritPropertiesKey: "",
 	BackupDirKey:                  "",
 	LXDSnapChannel:                "latest/stable",
@@ -505,6 +534,7 @@ var defaultConfigValues = map[string]interface{}{
 	AgentMetadataURLKey:          "",
 	ContainerImageStreamKey:      "released",
 	ContainerImageMetadataURLKey: "",
+	ImageMetadataServiceURLKey:   "",
 
 	// Log forward settings.
 	LogForwardEnabled: false,
@@ -531,6 +561,8 @@ var defaultConfigValues = map[string]interface{}{
 	SnapStoreAssertionsKey: "",
 	SnapStoreProxyURLKey:   "",
 
+	AgentAPIProxyKey: "",
+
 	// Status history settings
 	MaxStatusHistoryAge:  DefaultStatusHistoryAge,
 	MaxStatusHistorySize: DefaultStatusHistorySize,
@@ -631,7 +663,7 @@ func Validate(cfg, old *Config) error {
 
 	// If the logging config is set, make sure it is valid.
 	if v, ok := cfg.defined["logging-config"].(string); ok {
-		if _, err := loggo.ParseConfigString(v); err != nil {
+		if _, err := logsink.ParseConfig(v); err != nil {
 			return err
 		}
 	}
@@ -700,6 +732,12 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[EgressNATAddress].(string); ok && v != "" {
+		if net.ParseIP(v) == nil {
+			return errors.NotValidf("%s %q", EgressNATAddress, v)
+		}
+	}
+
 	if v, ok := cfg.defined[FanConfig].(string); ok && v != "" {
 		_, err := network.ParseFanConfig(v)
 		if err != nil {
@@ -753,6 +791,21 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if raw, ok := cfg.defined[CloudInitPackagesKey].(string); ok && raw != "" {
+		for _, pkg := range strings.Split(raw, ",") {
+			pkg = strings.TrimSpace(pkg)
+			if pkg == "" || strings.ContainsAny(pkg, " \t") {
+				return errors.NotValidf("cloudinit-packages package %q", pkg)
+			}
+		}
+	}
+
+	if raw, ok := cfg.defined[CloudInitRunCmdsKey].(string); ok && raw != "" {
+		if _, err := parseRunCmds(raw); err != nil {
+			return errors.Annotate(err, "cloudinit-runcmds")
+		}
+	}
+
 	if raw, ok := cfg.defined[ContainerInheritPropertiesKey].(string); ok && raw != "" {
 		rawProperties := strings.Split(raw, ",")
 		propertySet := set.NewStrings()
@@ -771,6 +824,16 @@ func Validate(cfg, old *Config) error {
 		return errors.Trace(err)
 	}
 
+	if _, err := jujuproxy.ParseAPIProxy(cfg.AgentAPIProxy()); err != nil {
+		return errors.Annotate(err, AgentAPIProxyKey)
+	}
+
+	if v, ok := cfg.ImageMetadataServiceURL(); ok {
+		if u, err := url.ParseRequestURI(v); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return errors.NotValidf("image-metadata-service-url %q", v)
+		}
+	}
+
 	if err := cfg.validateDefaultSpace(); err != nil {
 		return errors.Trace(err)
 	}
@@ -825,6 +888,22 @@ func ensureStringMaps(in string) (map[string]interface{}, error) {
 	return out.(map[string]interface{}), nil
 }
 
+// parseRunCmds parses the given YAML list of commands.
+func parseRunCmds(in string) ([]string, error) {
+	var cmds []interface{}
+	if err := yaml.Unmarshal([]byte(in), &cmds); err != nil {
+		return nil, errors.Annotate(err, "must be a YAML list of commands")
+	}
+	result := make([]string, len(cmds))
+	for i, cmd := range cmds {
+		var ok bool
+		if result[i], ok = cmd.(string); !ok || result[i] == "" {
+			return nil, errors.NotValidf("command %v", cmd)
+		}
+	}
+	return result, nil
+}
+
 func isEmpty(val interface{}) bool {
 	switch val := val.(type) {
 	case nil:
@@ -1125,6 +1204,12 @@ func (c *Config) SnapStoreProxyURL() string {
 	return c.asString(SnapStoreProxyURLKey)
 }
 
+// AgentAPIProxy returns the proxy through which agents connect to the
+// controller, or an empty string if they connect directly.
+func (c *Config) AgentAPIProxy() string {
+	return c.asString(AgentAPIProxyKey)
+}
+
 // LogFwdSyslog returns the syslog forwarding config.
 func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	partial := false
@@ -1209,6 +1294,15 @@ func (c *Config) ContainerImageMetadataURL() (string, bool) {
 	return "", false
 }
 
+// ImageMetadataServiceURL returns the URL of the site-defined image
+// metadata service, and whether it has been set.
+func (c *Config) ImageMetadataServiceURL() (string, bool) {
+	if url := c.asString(ImageMetadataServiceURLKey); url != "" {
+		return url, true
+	}
+	return "", false
+}
+
 // Development returns whether the environment is in development mode.
 func (c *Config) Development() bool {
 	value, _ := c.defined["development"].(bool)
@@ -1500,6 +1594,15 @@ func (c *Config) EgressSubnets() []string {
 	return result
 }
 
+// EgressNATAddress returns the address of the NAT gateway through which
+// traffic from machines in this model egresses, if one is configured.
+func (c *Config) EgressNATAddress() (string, bool) {
+	if addr := c.asString(EgressNATAddress); addr != "" {
+		return addr, true
+	}
+	return "", false
+}
+
 // FanConfig is the configuration of FAN network running in the model.
 func (c *Config) FanConfig() (network.FanConfig, error) {
 	// At this point we are sure that the line is valid.
@@ -1507,15 +1610,62 @@ func (c *Config) FanConfig() (network.FanConfig, error) {
 }
 
 // CloudInitUserData returns a copy of the raw user data attributes
-// that were specified by the user.
+// that were specified by the user, with any packages and commands
+// from cloudinit-packages and cloudinit-runcmds appended to the
+// user data's packages and postruncmd respectively.
 func (c *Config) CloudInitUserData() map[string]interface{} {
-	raw := c.asString(CloudInitUserDataKey)
+	var userData map[string]interface{}
+	if raw := c.asString(CloudInitUserDataKey); raw != "" {
+		// The raw data has already passed Validate()
+		userData, _ = ensureStringMaps(raw)
+	}
+	packages := c.CloudInitPackages()
+	runCmds := c.CloudInitRunCmds()
+	if len(packages) == 0 && len(runCmds) == 0 {
+		return userData
+	}
+	if userData == nil {
+		userData = make(map[string]interface{})
+	}
+	appendStrings := func(key string, values []string) {
+		if len(values) == 0 {
+			return
+		}
+		existing, _ := userData[key].([]interface{})
+		for _, v := range values {
+			existing = append(existing, v)
+		}
+		userData[key] = existing
+	}
+	appendStrings("packages", packages)
+	appendStrings("postruncmd", runCmds)
+	return userData
+}
+
+// CloudInitPackages returns the extra packages to install on new
+// machines, as specified by cloudinit-packages.
+func (c *Config) CloudInitPackages() []string {
+	raw := c.asString(CloudInitPackagesKey)
+	if raw == "" {
+		return nil
+	}
+	var packages []string
+	for _, pkg := range strings.Split(raw, ",") {
+		packages = append(packages, strings.TrimSpace(pkg))
+	}
+	return packages
+}
+
+// CloudInitRunCmds returns the extra commands to run on new machines,
+// as specified by cloudinit-runcmds.
+func (c *Config) CloudInitRunCmds() []string {
+	raw := c.asString(CloudInitRunCmdsKey)
 	if raw == "" {
 		return nil
 	}
 	// The raw data has already passed Validate()
-	conformingUserDataMap, _ := ensureStringMaps(raw)
-	return conformingUserDataMap
+	cmds, _ := parseRunCmds(raw)
+	return cmds
 }
 
 // ContainerInheritProperties returns a copy of the raw user data keys
@@ -1625,6 +1775,7 @@ var alwaysOptional = schema.Defaults{
 	SnapStoreProxyKey:             schema.Omit,
 	SnapStoreAssertionsKey:        schema.Omit,
 	SnapStoreProxyURLKey:          schema.Omit,
+	AgentAPIProxyKey:              schema.Omit,
 	"apt-mirror":                  schema.Omit,
 	AgentStreamKey:                schema.Omit,
 	DashboardStreamKey:            schema.Omit,
@@ -1637,6 +1788,7 @@ var alwaysOptional = schema.Defaults{
 	AgentMetadataURLKey:           schema.Omit,
 	ContainerImageStreamKey:       schema.Omit,
 	ContainerImageMetadataURLKey:  schema.Omit,
+	ImageMetadataServiceURLKey:    schema.Omit,
 	"default-series":              schema.Omit,
 	"development":                 schema.Omit,
 	"ssl-hostname-verification":   schema.Omit,
@@ -1655,8 +1807,11 @@ var alwaysOptional = schema.Defaults{
 	MaxActionResultsSize:          schema.Omit,
 	UpdateStatusHookInterval:      schema.Omit,
 	EgressSubnets:                 schema.Omit,
+	EgressNATAddress:              schema.Omit,
 	FanConfig:                     schema.Omit,
 	CloudInitUserDataKey:          schema.Omit,
+	CloudInitPackagesKey:          schema.Omit,
+	CloudInitRunCmdsKey:           schema.Omit,
 	ContainerInheritPropertiesKey: schema.Omit,
 	BackupDirKey:                  schema.Omit,
 	DefaultSpace:                  schema.Omit,
@@ -1985,11 +2140,21 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	AgentAPIProxyKey: {
+		Description: "The HTTP CONNECT (http://) or SOCKS5 (socks5://) proxy through which agents connect to the controller",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	"image-metadata-url": {
 		Description: "The URL at which the metadata used to locate OS image ids is located",
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	ImageMetadataServiceURLKey: {
+		Description: "The URL of a service queried with the series, architecture, region and tags constraints of new machines for the OS image ids to start them with, ahead of simplestreams",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	"image-stream": {
 		Description: `The simplestreams stream used to identify which image ids to search when starting an instance.`,
 		Type:        environschema.Tstring,
@@ -2011,7 +2176,7 @@ global or per instance security groups.`,
 		Group:       environschema.EnvironGroup,
 	},
 	"logging-config": {
-		Description: `The configuration string to use when configuring Juju agent logging (see http://godoc.org/github.com/juju/loggo#ParseConfigurationString for details)`,
+		Description: `The configuration string to use when configuring Juju agent logging (see http://godoc.org/github.com/juju/loggo#ParseConfigurationString for details). Entries of the form sink:<name>=<level> set the least severe level written to the "file", "syslog" and "controller" log sinks`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
@@ -2158,6 +2323,11 @@ where possible. (default "")`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	EgressNATAddress: {
+		Description: "Address of the NAT gateway through which traffic from machines in this model egresses, used for cross-model relations",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	FanConfig: {
 		Description: "Configuration for fan networking for this model",
 		Type:        environschema.Tstring,
@@ -2168,6 +2338,16 @@ where possible. (default "")`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	CloudInitPackagesKey: {
+		Description: "List of extra packages to install on new machines created in this model (comma-separated)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	CloudInitRunCmdsKey: {
+		Description: "List of extra commands (in yaml format) to run on new machines created in this model, after Juju's own commands",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	ContainerInheritPropertiesKey: {
 		Description: "List of properties to be copied from the host machine to new containers created in this model (comma-separated)",
 		Type:        environschema.Tstring,
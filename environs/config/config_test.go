@@ -117,6 +117,12 @@ var configTests = []configTest{
 		attrs: minimalConfigAttrs.Merge(testing.Attrs{
 			"logging-config": "juju=INFO",
 		}),
+	}, {
+		about:       "Explicit logging with sink levels",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logging-config": "<root>=DEBUG;sink:controller=WARNING;sink:syslog=ERROR",
+		}),
 	}, {
 		about:       "Explicit authorized-keys",
 		useDefaults: config.UseDefaults,
@@ -377,6 +383,13 @@ var configTests = []configTest{
 			"logging-config": "foo=bar",
 		}),
 		err: `unknown severity level "bar"`,
+	}, {
+		about:       "Invalid logging sink",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logging-config": "<root>=INFO;sink:mongo=INFO",
+		}),
+		err: `logging sink "mongo" not valid`,
 	}, {
 		about:       "Sample configuration",
 		useDefaults: config.UseDefaults,
@@ -577,6 +590,73 @@ var configTests = []configTest{
 			"container-inherit-properties": "apt-security, write_files,users,apt-sources",
 		}),
 		err: `container-inherit-properties: users, write_files not allowed`,
+	}, {
+		about:       "Valid image-metadata-service-url",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"image-metadata-service-url": "https://images.example.com/lookup",
+		}),
+	}, {
+		about:       "Invalid image-metadata-service-url",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"image-metadata-service-url": "file:///images",
+		}),
+		err: `image-metadata-service-url "file:///images" not valid`,
+	}, {
+		about:       "Valid egress-nat-address",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"egress-nat-address": "203.0.113.10",
+		}),
+	}, {
+		about:       "Invalid egress-nat-address",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"egress-nat-address": "10.0.0.0/24",
+		}),
+		err: `egress-nat-address "10.0.0.0/24" not valid`,
+	}, {
+		about:       "Valid agent-api-proxy",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"agent-api-proxy": "socks5://10.0.0.1:1080",
+		}),
+	}, {
+		about:       "Invalid agent-api-proxy",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"agent-api-proxy": "https://proxy.example.com",
+		}),
+		err: `agent-api-proxy: API proxy address "https://proxy.example.com" has unsupported scheme "https", expected http or socks5`,
+	}, {
+		about:       "Valid cloudinit-packages and cloudinit-runcmds",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"cloudinit-packages": "htop, nfs-common",
+			"cloudinit-runcmds":  "['mkdir /srv/data', 'touch /srv/data/ready']",
+		}),
+	}, {
+		about:       "Invalid cloudinit-packages",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"cloudinit-packages": "htop,,nfs-common",
+		}),
+		err: `cloudinit-packages package "" not valid`,
+	}, {
+		about:       "Invalid cloudinit-runcmds: not a list",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"cloudinit-runcmds": "cmd: mkdir /srv/data",
+		}),
+		err: `cloudinit-runcmds: must be a YAML list of commands: yaml: unmarshal errors:\n.*`,
+	}, {
+		about:       "Invalid cloudinit-runcmds: not a command",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"cloudinit-runcmds": "[42]",
+		}),
+		err: `cloudinit-runcmds: command 42 not valid`,
 	}, {
 		about:       "String as valid value",
 		useDefaults: config.UseDefaults,
@@ -1430,6 +1510,19 @@ func (s *ConfigSuite) TestEgressSubnets(c *gc.C) {
 	c.Assert(cfg.EgressSubnets(), gc.DeepEquals, []string{"10.0.0.1/32", "192.168.1.1/16"})
 }
 
+func (s *ConfigSuite) TestEgressNATAddress(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	_, ok := cfg.EgressNATAddress()
+	c.Assert(ok, jc.IsFalse)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"egress-nat-address": "203.0.113.10",
+	})
+	addr, ok := cfg.EgressNATAddress()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(addr, gc.Equals, "203.0.113.10")
+}
+
 func (s *ConfigSuite) TestCloudInitUserDataFromEnvironment(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{
 		config.CloudInitUserDataKey: validCloudInitUserData,
@@ -1442,6 +1535,44 @@ func (s *ConfigSuite) TestCloudInitUserDataFromEnvironment(c *gc.C) {
 	)
 }
 
+func (s *ConfigSuite) TestImageMetadataServiceURL(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	_, ok := cfg.ImageMetadataServiceURL()
+	c.Assert(ok, jc.IsFalse)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.ImageMetadataServiceURLKey: "https://images.example.com/lookup",
+	})
+	url, ok := cfg.ImageMetadataServiceURL()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(url, gc.Equals, "https://images.example.com/lookup")
+}
+
+func (s *ConfigSuite) TestCloudInitUserDataExtras(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		config.CloudInitUserDataKey: validCloudInitUserData,
+		config.CloudInitPackagesKey: "htop, nfs-common",
+		config.CloudInitRunCmdsKey:  "['mkdir /srv/data']",
+	})
+	c.Assert(cfg.CloudInitPackages(), gc.DeepEquals, []string{"htop", "nfs-common"})
+	c.Assert(cfg.CloudInitRunCmds(), gc.DeepEquals, []string{"mkdir /srv/data"})
+	c.Assert(cfg.CloudInitUserData(), gc.DeepEquals, map[string]interface{}{
+		"packages":        []interface{}{"python-keystoneclient", "python-glanceclient", "htop", "nfs-common"},
+		"preruncmd":       []interface{}{"mkdir /tmp/preruncmd", "mkdir /tmp/preruncmd2"},
+		"postruncmd":      []interface{}{"mkdir /tmp/postruncmd", "mkdir /tmp/postruncmd2", "mkdir /srv/data"},
+		"package_upgrade": false},
+	)
+}
+
+func (s *ConfigSuite) TestCloudInitUserDataExtrasOnly(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		config.CloudInitPackagesKey: "htop",
+	})
+	c.Assert(cfg.CloudInitUserData(), gc.DeepEquals, map[string]interface{}{
+		"packages": []interface{}{"htop"},
+	})
+}
+
 func (s *ConfigSuite) TestContainerInheritProperties(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{
 		"container-inherit-properties": "ca-certs,apt-primary",
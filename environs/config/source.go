@@ -52,6 +52,22 @@ func (c ConfigValues) AllAttrs() map[string]interface{} {
 	return result
 }
 
+// ConfigValueTrace describes how the effective value of a model
+// config attribute is resolved from its config sources.
+type ConfigValueTrace struct {
+	// Value is the effective value of the attribute.
+	Value interface{}
+
+	// Source is the name of the config source from where
+	// the effective value originates.
+	Source string
+
+	// Sources holds the value set for the attribute by each
+	// config source that sets it, in resolution order; later
+	// sources override earlier ones.
+	Sources []ConfigValue
+}
+
 // ConfigSchemaSource instances provide information on config attributes
 // and the default attribute values.
 type ConfigSchemaSource interface {
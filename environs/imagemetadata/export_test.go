@@ -5,6 +5,8 @@ package imagemetadata
 
 const CurrentStreamsVersion = currentStreamsVersion
 
+var ServiceTimeout = &serviceTimeout
+
 // SetSigningPublicKey sets a new public key for testing and returns the original key.
 func SetSigningPublicKey(key string) string {
 	oldKey := SimplestreamsImagesPublicKey
@@ -0,0 +1,96 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package imagemetadata
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/juju/errors"
+	jujuhttp "github.com/juju/http"
+	"github.com/juju/loggo"
+)
+
+var logger = loggo.GetLogger("juju.environs.imagemetadata")
+
+// serviceTimeout bounds how long a query to an image metadata service,
+// including reading its response, may take.
+var serviceTimeout = 30 * time.Second
+
+// serviceImage is the representation of an image returned by an image
+// metadata service. Unlike simplestreams metadata, each image carries
+// its own stream.
+type serviceImage struct {
+	ImageMetadata
+	Stream string `json:"stream,omitempty"`
+}
+
+// serviceResponse is the body of an image metadata service response.
+type serviceResponse struct {
+	Images []serviceImage `json:"images"`
+}
+
+// FetchFromService queries the site-defined image metadata service at
+// serviceURL for images matching the given constraint and tags.
+//
+// The service is sent a GET request with the query parameters "series",
+// "arch" and "tag", which may each be repeated, and "region", "endpoint"
+// and "stream". It is expected to respond with a JSON object holding an
+// "images" list, each entry of which has the same fields as simplestreams
+// image metadata, along with the image's "stream".
+func FetchFromService(serviceURL string, cons *ImageConstraint, tags []string) ([]*ImageMetadata, error) {
+	u, err := url.Parse(serviceURL)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing image metadata service URL")
+	}
+	query := u.Query()
+	for _, s := range cons.Series {
+		query.Add("series", s)
+	}
+	for _, a := range cons.Arches {
+		query.Add("arch", a)
+	}
+	for _, tag := range tags {
+		query.Add("tag", tag)
+	}
+	if cons.Region != "" {
+		query.Set("region", cons.Region)
+	}
+	if cons.Endpoint != "" {
+		query.Set("endpoint", cons.Endpoint)
+	}
+	if cons.Stream != "" {
+		query.Set("stream", cons.Stream)
+	}
+	u.RawQuery = query.Encode()
+
+	ctx, cancel := context.WithTimeout(context.Background(), serviceTimeout)
+	defer cancel()
+	client := jujuhttp.NewClient(jujuhttp.Config{
+		Logger: logger.Child("http"),
+	})
+	resp, err := client.Get(ctx, u.String())
+	if err != nil {
+		return nil, errors.Annotatef(err, "querying image metadata service %q", serviceURL)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("querying image metadata service %q: %s", serviceURL, resp.Status)
+	}
+
+	var body serviceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, errors.Annotatef(err, "decoding image metadata service %q response", serviceURL)
+	}
+	result := make([]*ImageMetadata, len(body.Images))
+	for i, image := range body.Images {
+		m := image.ImageMetadata
+		m.Stream = image.Stream
+		result[i] = &m
+	}
+	return result, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package imagemetadata_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/environs/simplestreams"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ServiceSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&ServiceSuite{})
+
+func (s *ServiceSuite) TestFetchFromService(c *gc.C) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		fmt.Fprint(w, `{"images": [{
+			"id": "ami-site-1",
+			"arch": "amd64",
+			"version": "20.04",
+			"region": "site-1",
+			"virt": "hvm",
+			"root_store": "ebs",
+			"stream": "site"
+		}]}`)
+	}))
+	defer server.Close()
+
+	cons := imagemetadata.NewImageConstraint(simplestreams.LookupParams{
+		CloudSpec: simplestreams.CloudSpec{Region: "site-1", Endpoint: "https://site-1"},
+		Series:    []string{"focal"},
+		Arches:    []string{"amd64", "arm64"},
+		Stream:    "site",
+	})
+	images, err := imagemetadata.FetchFromService(server.URL+"/images?key=value", cons, []string{"gpu", "fast"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(images, jc.DeepEquals, []*imagemetadata.ImageMetadata{{
+		Id:         "ami-site-1",
+		Arch:       "amd64",
+		Version:    "20.04",
+		RegionName: "site-1",
+		VirtType:   "hvm",
+		Storage:    "ebs",
+		Stream:     "site",
+	}})
+	c.Assert(query, jc.DeepEquals, url.Values{
+		"key":      {"value"},
+		"series":   {"focal"},
+		"arch":     {"amd64", "arm64"},
+		"tag":      {"gpu", "fast"},
+		"region":   {"site-1"},
+		"endpoint": {"https://site-1"},
+		"stream":   {"site"},
+	})
+}
+
+func (s *ServiceSuite) TestFetchFromServiceError(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	cons := imagemetadata.NewImageConstraint(simplestreams.LookupParams{
+		Series: []string{"focal"},
+		Arches: []string{"amd64"},
+	})
+	_, err := imagemetadata.FetchFromService(server.URL, cons, nil)
+	c.Assert(err, gc.ErrorMatches, `querying image metadata service ".*": 500 Internal Server Error`)
+}
+
+func (s *ServiceSuite) TestFetchFromServiceInvalidResponse(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "not json")
+	}))
+	defer server.Close()
+
+	cons := imagemetadata.NewImageConstraint(simplestreams.LookupParams{
+		Series: []string{"focal"},
+		Arches: []string{"amd64"},
+	})
+	_, err := imagemetadata.FetchFromService(server.URL, cons, nil)
+	c.Assert(err, gc.ErrorMatches, `decoding image metadata service ".*" response: .*`)
+}
+
+func (s *ServiceSuite) TestFetchFromServiceTimeout(c *gc.C) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+	s.PatchValue(imagemetadata.ServiceTimeout, coretesting.ShortWait)
+
+	cons := imagemetadata.NewImageConstraint(simplestreams.LookupParams{
+		Series: []string{"focal"},
+		Arches: []string{"amd64"},
+	})
+	_, err := imagemetadata.FetchFromService(server.URL, cons, nil)
+	c.Assert(err, gc.ErrorMatches, `querying image metadata service ".*": .*context deadline exceeded.*`)
+}
@@ -23,6 +23,8 @@ type InstanceType struct {
 	// These attributes are not supported by all clouds.
 	VirtType   *string // The type of virtualisation used by the hypervisor, must match the image.
 	CpuPower   *uint64
+	Gpus       uint64
+	GpuType    *string // The vendor of the attached GPUs, if any.
 	Tags       []string
 	Deprecated bool
 }
@@ -69,6 +71,12 @@ func (itype InstanceType) match(cons constraints.Value) (InstanceType, bool) {
 	if cons.CpuPower != nil && itype.CpuPower != nil && *itype.CpuPower < *cons.CpuPower {
 		return nothing, false
 	}
+	if cons.HasGpus() && itype.Gpus < *cons.Gpus {
+		return nothing, false
+	}
+	if cons.HasGpuType() && (itype.GpuType == nil || *itype.GpuType != *cons.GpuType) {
+		return nothing, false
+	}
 	if cons.Mem != nil && itype.Mem < *cons.Mem {
 		return nothing, false
 	}
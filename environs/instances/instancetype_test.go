@@ -21,6 +21,11 @@ var _ = gc.Suite(&instanceTypeSuite{})
 
 var hvm = "hvm"
 
+var (
+	gpuNvidia = "nvidia"
+	gpuAMD    = "amd"
+)
+
 // The instance types below do not necessarily reflect reality and are just
 // defined here for ease of testing special cases.
 var instanceTypes = []InstanceType{
@@ -221,6 +226,24 @@ var getInstanceTypesTest = []struct {
 		cons:           "virt-type=hvm",
 		expectedItypes: []string{"cc1.4xlarge", "cc2.8xlarge"},
 		itypesToUse:    nil,
+	}, {
+		about: "gpus filtered by constraint",
+		cons:  "gpus=2",
+		itypesToUse: []InstanceType{
+			{Id: "3", Name: "it-3", Arches: []string{"amd64"}, Mem: 4096, Gpus: 4, Cost: 300},
+			{Id: "2", Name: "it-2", Arches: []string{"amd64"}, Mem: 4096, Gpus: 2, Cost: 200},
+			{Id: "1", Name: "it-1", Arches: []string{"amd64"}, Mem: 4096, Gpus: 1, Cost: 100},
+		},
+		expectedItypes: []string{"it-2", "it-3"},
+	}, {
+		about: "gpu-type filtered by constraint",
+		cons:  "gpu-type=nvidia",
+		itypesToUse: []InstanceType{
+			{Id: "3", Name: "it-3", Arches: []string{"amd64"}, Mem: 4096, Gpus: 1, GpuType: &gpuNvidia},
+			{Id: "2", Name: "it-2", Arches: []string{"amd64"}, Mem: 4096, Gpus: 1, GpuType: &gpuAMD},
+			{Id: "1", Name: "it-1", Arches: []string{"amd64"}, Mem: 4096},
+		},
+		expectedItypes: []string{"it-3"},
 	}, {
 		about:          "deprecated image type requested by name",
 		cons:           "instance-type=dep.small",
@@ -5,6 +5,7 @@ package environs
 
 import (
 	"io"
+	"time"
 
 	"github.com/juju/jsonschema"
 	"github.com/juju/version"
@@ -613,3 +614,52 @@ type HardwareCharacteristicsDetector interface {
 	// controller instance.
 	DetectHardware() (*instance.HardwareCharacteristics, error)
 }
+
+// InstanceHardwareReader is implemented by environments that can read
+// the current hardware characteristics of running instances from the
+// cloud, such as after an instance has been resized out of band.
+type InstanceHardwareReader interface {
+	// InstanceHardware returns the hardware characteristics of the
+	// instances with the given ids, in the same order. If an instance
+	// cannot be found, the corresponding result is nil and
+	// ErrPartialInstances is returned; if none can be found,
+	// ErrNoInstances is returned.
+	InstanceHardware(ctx context.ProviderCallContext, ids []instance.Id) ([]*instance.HardwareCharacteristics, error)
+}
+
+// InstanceResizer is implemented by environments that can change the
+// hardware of existing instances.
+type InstanceResizer interface {
+	// ResizeInstance changes the hardware of the instance with the
+	// given id to the cheapest hardware that satisfies the constraints,
+	// returning its new hardware characteristics. The instance may be
+	// restarted while it is resized.
+	ResizeInstance(ctx context.ProviderCallContext, id instance.Id, cons constraints.Value) (*instance.HardwareCharacteristics, error)
+}
+
+// ScopedCredential is a credential issued by the cloud for the use of a
+// single application, in place of the model's credential.
+type ScopedCredential struct {
+	// Credential is the issued credential.
+	Credential cloud.Credential
+
+	// Expiry is when the cloud stops honouring the credential.
+	Expiry time.Time
+}
+
+// CredentialScoper is implemented by environments that can issue
+// credentials scoped to a single application, with access limited to
+// what the application needs, for the use of trusted applications.
+// Only the Kubernetes provider implements it. Issuing scoped credentials
+// from IAAS clouds, such as with AWS STS, is not supported, so the
+// trust-scope option is only offered on Kubernetes models.
+type CredentialScoper interface {
+	// ScopedCredential issues a credential for the named application,
+	// valid for at most the given duration. Any credentials issued
+	// to the application previously remain valid until they expire.
+	ScopedCredential(ctx context.ProviderCallContext, appName string, duration time.Duration) (ScopedCredential, error)
+
+	// RevokeScopedCredential revokes the access granted to the credentials
+	// issued for the named application.
+	RevokeScopedCredential(ctx context.ProviderCallContext, appName string) error
+}
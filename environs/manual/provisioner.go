@@ -65,6 +65,11 @@ type WinRMArgs struct {
 
 	// Client for interacting with windows machines
 	Client WinrmClientAPI
+
+	// Insecure allows the Administrator password to be sent over
+	// https without verifying the listener's certificate, when there
+	// is no CA cert in Keys.
+	Insecure bool
 }
 
 // WinrmClientAPI minimal interface for winrm windows machines interactions
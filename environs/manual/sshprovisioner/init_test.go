@@ -156,6 +156,25 @@ func (s *initialisationSuite) TestCheckProvisioned(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "subprocess encountered error code 255 \\(non-empty-stderr\\)")
 }
 
+func (s *initialisationSuite) TestCheckControllerSuitability(c *gc.C) {
+	script := sshprovisioner.ControllerSuitabilityScript([]int{17070, 37017})
+	c.Assert(script, jc.Contains, "for port in 17070 37017; do")
+
+	defer installFakeSSH(c, script, "", 0)()
+	err := sshprovisioner.CheckControllerSuitability("example.com", []int{17070, 37017})
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer installFakeSSH(c, script, "mongod is already running\nport 37017 is already in use", 0)()
+	err = sshprovisioner.CheckControllerSuitability("example.com", []int{17070, 37017})
+	c.Assert(err, gc.ErrorMatches, `example.com is not suitable for running a controller:
+  mongod is already running
+  port 37017 is already in use`)
+
+	defer installFakeSSH(c, script, []string{"", "non-empty-stderr"}, 255)()
+	err = sshprovisioner.CheckControllerSuitability("example.com", []int{17070, 37017})
+	c.Assert(err, gc.ErrorMatches, "subprocess encountered error code 255 \\(non-empty-stderr\\)")
+}
+
 func (s *initialisationSuite) TestInitUbuntuUserNonExisting(c *gc.C) {
 	defer installFakeSSH(c, "", "", 0)() // successful creation of ubuntu user
 	defer installFakeSSH(c, "", "", 1)() // simulate failure of ubuntu@ login
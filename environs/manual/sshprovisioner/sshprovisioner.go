@@ -185,6 +185,66 @@ func checkProvisioned(host string) (bool, error) {
 	return provisioned, nil
 }
 
+// CheckControllerSuitability checks that the host machine is able to
+// run a controller: that it uses systemd, is not already running a
+// mongod, and that none of the given ports are in use. An error
+// describing every problem found is returned if the host is unsuitable.
+var CheckControllerSuitability = checkControllerSuitability
+
+func checkControllerSuitability(host string, ports []int) error {
+	logger.Infof("Checking if %s is suitable for running a controller", host)
+
+	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, nil)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Stdin = strings.NewReader(controllerSuitabilityScript(ports))
+	if err := cmd.Run(); err != nil {
+		if stderr.Len() != 0 {
+			err = fmt.Errorf("%v (%v)", err, strings.TrimSpace(stderr.String()))
+		}
+		return err
+	}
+
+	var problems []string
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			problems = append(problems, line)
+		}
+	}
+	if len(problems) > 0 {
+		return errors.Errorf(
+			"%s is not suitable for running a controller:\n  %s",
+			host, strings.Join(problems, "\n  "),
+		)
+	}
+	return nil
+}
+
+// controllerSuitabilityScript returns the script to run on the remote
+// machine to check that it can run a controller listening on the given
+// ports. Each problem found is written to stdout on a line of its own.
+func controllerSuitabilityScript(ports []int) string {
+	portStrs := make([]string, len(ports))
+	for i, port := range ports {
+		portStrs[i] = strconv.Itoa(port)
+	}
+	return fmt.Sprintf(controllerSuitabilityScriptTemplate, strings.Join(portStrs, " "))
+}
+
+const controllerSuitabilityScriptTemplate = `#!/bin/bash
+if [ ! -d /run/systemd/system ]; then
+  echo "systemd is not the running init system"
+fi
+if pgrep -x mongod > /dev/null; then
+  echo "mongod is already running"
+fi
+for port in %s; do
+  if ss -Hltn "sport = :$port" | grep -q .; then
+    echo "port $port is already in use"
+  fi
+done`
+
 // detectionScript is the script to run on the remote machine to
 // detect the OS series and hardware characteristics.
 const detectionScript = `#!/bin/bash
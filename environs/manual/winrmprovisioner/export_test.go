@@ -5,4 +5,6 @@ package winrmprovisioner
 
 var (
 	WinDetectHardware = detectHardware
+	NewClient         = &newClient
+	BindInitScripts   = bindInitScripts
 )
@@ -12,6 +12,7 @@ import (
 	"io"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
@@ -52,7 +53,8 @@ const detectJujudProcess = `
 //		for more details
 //  - get the operating system name
 //      compare the values we find in the registry with the version information
-//		juju knows about. Once we find a match, we return the series
+//		juju knows about. We return the series of the longest matching name,
+//		or "unknown" if none match
 //  - get number of cores that the machine has
 //		the process is using the Wmi windows Api to interrogate the os for
 //		the physical number of cores that the machine has.
@@ -95,13 +97,18 @@ $version.Add("{{$key}}", "{{$value}}")
 		return $version["Windows Server 2016"].Trim()
 	}
 
+	# Product names may prefix each other, e.g. "Windows Server 2012"
+	# and "Windows Server 2012 R2", so use the longest that matches.
+	$matched = ""
 	foreach ($h in $version.keys) {
-		$flag = $name.StartsWith($h)
-
-		if ($flag) {
-			return $version[$h].Trim()
+		if ($name.StartsWith($h) -and $h.Length -gt $matched.Length) {
+			$matched = $h
 		}
 	}
+	if ($matched -eq "") {
+		return "unknown"
+	}
+	return $version[$matched].Trim()
 }
 function Get-NCores {
 	# NumberOfProcessors will return the physical processor, but not the core count of each CPU. So if you have 2 quad core CPUs, NumberOfProcessors will return 2, not 8
@@ -115,6 +122,10 @@ Get-OSName
 Get-NCores
 `
 
+// unknownWindowsSeries is written by the detectHardware script in place
+// of the series when the machine's Windows version has no series.
+const unknownWindowsSeries = "unknown"
+
 // newDetectHardwareScript will parse the detectHardware script and add
 // into the powershell hastable the key,val of the map returned from the
 // WindowsVersions func from the series pkg.
@@ -129,9 +140,36 @@ func newDetectHardwareScript() (string, error) {
 	return shell.NewPSEncodedCommand(in.String())
 }
 
+// newClient is the function used to create WinRM clients, overridden in tests.
+var newClient = func(cfg winrm.ClientConfig) (manual.WinrmClientAPI, error) {
+	client, err := winrm.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// cachedPassword returns a password getter which calls get at most once,
+// so that the user is only prompted for the password a single time.
+func cachedPassword(get winrm.GetPasswd) winrm.GetPasswd {
+	var (
+		once sync.Once
+		pass string
+		err  error
+	)
+	return func() (string, error) {
+		once.Do(func() {
+			pass, err = get()
+		})
+		return pass, err
+	}
+}
+
 // InitAdministratorUser will initially attempt to login as
 // the Administrator user using the secure client
-// only if this is false then this will make a new attempt with the unsecure http client.
+// only if this is false then this will make a new attempt using
+// password authentication, first over https and then with the
+// unsecure http client.
 func InitAdministratorUser(args *manual.ProvisionMachineArgs) error {
 	logger.Infof("Trying https client as user %s on %s", args.Host, args.User)
 	err := args.WinRM.Client.Ping()
@@ -141,20 +179,31 @@ func InitAdministratorUser(args *manual.ProvisionMachineArgs) error {
 	}
 
 	logger.Debugf("Https client authentication is not enabled on the host %s with user %s", args.Host, args.User)
-	if args.WinRM.Client, err = winrm.NewClient(winrm.ClientConfig{
-		User:     args.User,
-		Host:     args.Host,
-		Timeout:  25 * time.Second,
-		Password: winrm.TTYGetPasswd,
-		Secure:   false,
-	}); err != nil {
-		return errors.Annotatef(err, "cannot create a new http winrm client ")
-	}
+	password := cachedPassword(winrm.TTYGetPasswd)
+
+	// Hardened hosts usually only expose the https listener, with the
+	// unencrypted http listener disabled, so try password authentication
+	// over https before falling back to http. The password is only sent
+	// over https when the listener's certificate can be verified, or when
+	// the user explicitly allowed an insecure connection.
+	if err = passwordHTTPSClient(args, password); err != nil {
+		logger.Debugf("WinRM https listener does not accept password authentication on %s: %v", args.Host, err)
+
+		if args.WinRM.Client, err = newClient(winrm.ClientConfig{
+			User:     args.User,
+			Host:     args.Host,
+			Timeout:  25 * time.Second,
+			Password: password,
+			Secure:   false,
+		}); err != nil {
+			return errors.Annotatef(err, "cannot create a new http winrm client ")
+		}
 
-	logger.Infof("Trying http client as user %s on %s", args.Host, args.User)
-	if err = args.WinRM.Client.Ping(); err != nil {
-		logger.Debugf("WinRM insecure listener is not enabled on %s", args.Host)
-		return errors.Annotatef(err, "cannot provision, because all winrm default connections failed")
+		logger.Infof("Trying http client as user %s on %s", args.Host, args.User)
+		if err = args.WinRM.Client.Ping(); err != nil {
+			logger.Debugf("WinRM insecure listener is not enabled on %s", args.Host)
+			return errors.Annotatef(err, "cannot provision, because all winrm default connections failed")
+		}
 	}
 
 	defClient := args.WinRM.Client
@@ -180,9 +229,45 @@ func InitAdministratorUser(args *manual.ProvisionMachineArgs) error {
 
 }
 
+// passwordHTTPSClient sets args.WinRM.Client to a client that uses
+// password authentication over https, if the listener accepts it. The
+// listener's certificate is verified against the CA cert in args.WinRM.Keys;
+// without one, the password is only sent if args.WinRM.Insecure is set.
+func passwordHTTPSClient(args *manual.ProvisionMachineArgs, password winrm.GetPasswd) error {
+	cfg := winrm.ClientConfig{
+		User:     args.User,
+		Host:     args.Host,
+		Timeout:  25 * time.Second,
+		Password: password,
+		Secure:   true,
+	}
+	if args.WinRM.Keys != nil && args.WinRM.Keys.CACert() != nil {
+		cfg.CACert = args.WinRM.Keys.CACert()
+	} else if args.WinRM.Insecure {
+		logger.Warningf("Sending the password for %s to %s without verifying the https certificate", args.User, args.Host)
+		cfg.Insecure = true
+	} else {
+		return errors.New("no CA cert to verify the https listener, and insecure connections are not allowed")
+	}
+
+	logger.Infof("Trying https client with password authentication as user %s on %s", args.Host, args.User)
+	client, err := newClient(cfg)
+	if err != nil {
+		return errors.Annotatef(err, "cannot create a new https winrm client")
+	}
+	if err := client.Ping(); err != nil {
+		return errors.Trace(err)
+	}
+	args.WinRM.Client = client
+	return nil
+}
+
 // enableCertAuth enables https cert auth interactions
 // with the winrm listener and returns the client
 func enableCertAuth(args *manual.ProvisionMachineArgs) (manual.WinrmClientAPI, error) {
+	if args.WinRM.Keys == nil {
+		return nil, errors.New("no client certificate available")
+	}
 	var stderr bytes.Buffer
 	pass := args.WinRM.Client.Password()
 
@@ -215,7 +300,7 @@ func enableCertAuth(args *manual.ProvisionMachineArgs) (manual.WinrmClientAPI, e
 		cfg.CACert = caCert
 	}
 
-	return winrm.NewClient(cfg)
+	return newClient(cfg)
 }
 
 // bindInitScripts creates a series of scripts in a standard
@@ -252,7 +337,7 @@ func bindInitScripts(pass string, keys *winrm.X509) ([]string, error) {
 		return nil, err
 	}
 
-	return nil, nil
+	return scripts, nil
 }
 
 // setFiles powershell script that will manage and create the conf folder and files
@@ -403,6 +488,9 @@ func DetectSeriesAndHardwareCharacteristics(host string, cli manual.WinrmClientA
 	}
 
 	series = strings.Replace(info[2], "\r", "", -1)
+	if series == unknownWindowsSeries {
+		return hc, "", errors.NotSupportedf("windows version of %s", host)
+	}
 
 	if err = initHC(&hc, info); err != nil {
 		return hc, "", errors.Trace(err)
@@ -8,7 +8,10 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/juju/errors"
+	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/v2/winrm"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/core/arch"
@@ -16,7 +19,9 @@ import (
 	"github.com/juju/juju/environs/manual/winrmprovisioner"
 )
 
-type winrmprovisionerSuite struct{}
+type winrmprovisionerSuite struct {
+	testing.IsolationSuite
+}
 
 var _ = gc.Suite(&winrmprovisionerSuite{})
 
@@ -50,6 +55,115 @@ func (w *winrmprovisionerSuite) TestInitAdministratorError(c *gc.C) {
 	c.Assert(err, gc.NotNil)
 }
 
+func (w *winrmprovisionerSuite) patchNewClient(clients ...*fakeWinRM) *[]winrm.ClientConfig {
+	var configs []winrm.ClientConfig
+	w.PatchValue(winrmprovisioner.NewClient, func(cfg winrm.ClientConfig) (manual.WinrmClientAPI, error) {
+		configs = append(configs, cfg)
+		client := clients[0]
+		clients = clients[1:]
+		return client, nil
+	})
+	return &configs
+}
+
+func (w *winrmprovisionerSuite) TestInitAdministratorHTTPSPassword(c *gc.C) {
+	httpsClient := &fakeWinRM{
+		password: "secret",
+		fakePing: func() error { return nil },
+	}
+	configs := w.patchNewClient(httpsClient)
+
+	var stdout, stderr bytes.Buffer
+	args := manual.ProvisionMachineArgs{
+		Host:   winrmListenerAddr,
+		User:   "Administrator",
+		Stdout: &stdout,
+		Stderr: &stderr,
+		WinRM: manual.WinRMArgs{
+			Client: &fakeWinRM{
+				fakePing: func() error { return fmt.Errorf("cert auth refused") },
+			},
+			Insecure: true,
+		},
+	}
+	err := winrmprovisioner.InitAdministratorUser(&args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(args.WinRM.Client, gc.Equals, httpsClient)
+	c.Assert(*configs, gc.HasLen, 1)
+	c.Check((*configs)[0].Secure, jc.IsTrue)
+	c.Check((*configs)[0].Insecure, jc.IsTrue)
+}
+
+func (w *winrmprovisionerSuite) TestInitAdministratorHTTPFallback(c *gc.C) {
+	httpClient := &fakeWinRM{
+		password: "secret",
+		fakePing: func() error { return nil },
+	}
+	configs := w.patchNewClient(&fakeWinRM{
+		fakePing: func() error { return fmt.Errorf("https listener disabled") },
+	}, httpClient)
+
+	var stdout, stderr bytes.Buffer
+	args := manual.ProvisionMachineArgs{
+		Host:   winrmListenerAddr,
+		User:   "Administrator",
+		Stdout: &stdout,
+		Stderr: &stderr,
+		WinRM: manual.WinRMArgs{
+			Client: &fakeWinRM{
+				fakePing: func() error { return fmt.Errorf("cert auth refused") },
+			},
+			Insecure: true,
+		},
+	}
+	err := winrmprovisioner.InitAdministratorUser(&args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(args.WinRM.Client, gc.Equals, httpClient)
+	c.Assert(*configs, gc.HasLen, 2)
+	c.Check((*configs)[0].Secure, jc.IsTrue)
+	c.Check((*configs)[1].Secure, jc.IsFalse)
+}
+
+func (w *winrmprovisionerSuite) TestInitAdministratorHTTPSPasswordNeedsCACert(c *gc.C) {
+	httpClient := &fakeWinRM{
+		password: "secret",
+		fakePing: func() error { return nil },
+	}
+	configs := w.patchNewClient(httpClient)
+
+	var stdout, stderr bytes.Buffer
+	args := manual.ProvisionMachineArgs{
+		Host:   winrmListenerAddr,
+		User:   "Administrator",
+		Stdout: &stdout,
+		Stderr: &stderr,
+		WinRM: manual.WinRMArgs{
+			Client: &fakeWinRM{
+				fakePing: func() error { return fmt.Errorf("cert auth refused") },
+			},
+		},
+	}
+	err := winrmprovisioner.InitAdministratorUser(&args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(args.WinRM.Client, gc.Equals, httpClient)
+	// Without a CA cert, or an explicit opt-in, the password is
+	// never sent to an unverified https listener.
+	c.Assert(*configs, gc.HasLen, 1)
+	c.Check((*configs)[0].Secure, jc.IsFalse)
+}
+
+func (w *winrmprovisionerSuite) TestBindInitScripts(c *gc.C) {
+	scripts, err := winrmprovisioner.BindInitScripts("secret", winrm.NewX509())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scripts, gc.HasLen, 3)
+	for _, script := range scripts {
+		c.Check(script, gc.Not(gc.Equals), "")
+	}
+
+	_, err = winrmprovisioner.BindInitScripts("", winrm.NewX509())
+	c.Assert(err, gc.ErrorMatches, "The password is empty.*")
+}
+
 func (w *winrmprovisionerSuite) TestDetectSeriesAndHardwareCharacteristics(c *gc.C) {
 	arch := arch.DefaultArchitecture
 	mem := uint64(16)
@@ -76,6 +190,22 @@ func (w *winrmprovisionerSuite) TestDetectSeriesAndHardwareCharacteristics(c *gc
 	c.Assert(err, gc.IsNil)
 }
 
+func (w *winrmprovisionerSuite) TestDetectSeriesUnknownWindowsVersion(c *gc.C) {
+	fakeCli := &fakeWinRM{
+		fakeRun: func(cmd string, stdout, stderr io.Writer) error {
+			fmt.Fprintf(stdout, "amd64\r\n")
+			fmt.Fprintf(stdout, "16\r\n")
+			fmt.Fprintf(stdout, "unknown\r\n")
+			fmt.Fprintf(stdout, "4\r\n")
+			return nil
+		},
+	}
+
+	_, _, err := winrmprovisioner.DetectSeriesAndHardwareCharacteristics(winrmListenerAddr, fakeCli)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "windows version of .* not supported")
+}
+
 func (w *winrmprovisionerSuite) TestRunProvisionScript(c *gc.C) {
 	var stdin, stderr bytes.Buffer
 	fakeCli := &fakeWinRM{
@@ -65,6 +65,27 @@ func UnregisterToolsDataSourceFunc(id string) {
 	}
 }
 
+var (
+	agentMetadataKeyMu sync.RWMutex
+	agentMetadataKey   string
+)
+
+// SetAgentMetadataPublicKey sets the public key that agent metadata from
+// agent-metadata-url must be signed with, as given by the controller's
+// agent-metadata-public-key config. If it is empty, such metadata need
+// not be signed, and is verified against the Juju key if it is.
+func SetAgentMetadataPublicKey(key string) {
+	agentMetadataKeyMu.Lock()
+	defer agentMetadataKeyMu.Unlock()
+	agentMetadataKey = key
+}
+
+func agentMetadataPublicKey() string {
+	agentMetadataKeyMu.RLock()
+	defer agentMetadataKeyMu.RUnlock()
+	return agentMetadataKey
+}
+
 // GetMetadataSources returns the sources to use when looking for
 // simplestreams tools metadata for the given stream.
 func GetMetadataSources(env environs.BootstrapEnviron) ([]simplestreams.DataSource, error) {
@@ -77,12 +98,20 @@ func GetMetadataSources(env environs.BootstrapEnviron) ([]simplestreams.DataSour
 		if !config.SSLHostnameVerification() {
 			verify = utils.NoVerifySSLHostnames
 		}
+		// Custom agent metadata may be signed with a site key; if the
+		// controller has one, the metadata must be signed with it.
+		publicKey := keys.JujuPublicKey
+		siteKey := agentMetadataPublicKey()
+		if siteKey != "" {
+			publicKey = siteKey
+		}
 		dataSourceConfig := simplestreams.Config{
 			Description:          conf.AgentMetadataURLKey,
 			BaseURL:              userURL,
-			PublicSigningKey:     keys.JujuPublicKey,
+			PublicSigningKey:     publicKey,
 			HostnameVerification: verify,
 			Priority:             simplestreams.SPECIFIC_CLOUD_DATA,
+			RequireSigned:        siteKey != "",
 		}
 		if err := dataSourceConfig.Validate(); err != nil {
 			return nil, errors.Annotate(err, "simplestreams config validation failed")
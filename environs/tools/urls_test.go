@@ -75,6 +75,21 @@ func (s *URLsSuite) TestToolsSources(c *gc.C) {
 	})
 }
 
+func (s *URLsSuite) TestToolsSourcesSitePublicKey(c *gc.C) {
+	tools.SetAgentMetadataPublicKey(sstesting.SignedMetadataPublicKey)
+	defer tools.SetAgentMetadataPublicKey("")
+
+	// The site key replaces the Juju key, and the metadata must be
+	// signed with it.
+	env := s.env(c, "config-tools-metadata-url")
+	sources, err := tools.GetMetadataSources(env)
+	c.Assert(err, jc.ErrorIsNil)
+	sstesting.AssertExpectedSources(c, sources, []sstesting.SourceDetails{
+		{"config-tools-metadata-url/", sstesting.SignedMetadataPublicKey, true},
+		{"https://streams.canonical.com/juju/tools/", keys.JujuPublicKey, true},
+	})
+}
+
 func (s *URLsSuite) TestToolsMetadataURLsRegisteredFuncs(c *gc.C) {
 	tools.RegisterToolsDataSourceFunc("id0", func(environs.Environ) (simplestreams.DataSource, error) {
 		return simplestreams.NewDataSource(simplestreams.Config{
@@ -45,3 +45,16 @@ const RawK8sSpec = "raw-k8s-spec"
 
 // ActionsV2 enables the next generation actions UX.
 const ActionsV2 = "actions-v2"
+
+// ExternalMongo allows a controller to be bootstrapped against an
+// externally managed MongoDB replica set, configured with the
+// "external-mongo-uri" and "external-mongo-ca-cert" controller config
+// keys, rather than the co-located juju-db. The replica set is not
+// managed by Juju, so it is not added to or removed from as controllers
+// come and go. When TLS is used, the replica set members must present
+// certificates that are valid for the name "juju-mongodb".
+const ExternalMongo = "external-mongo"
+
+// ModelSpec enables the experimental declarative model spec, set with
+// juju apply, which a model is continuously reconciled towards.
+const ModelSpec = "model-spec"
@@ -54,6 +54,11 @@ const (
 	// timestamps to be written in RFC3339 format.
 	JujuStatusIsoTimeEnvKey = "JUJU_STATUS_ISO_TIME"
 
+	// JujuAPICacheTTLEnvKey is the env var which, if set to a duration,
+	// causes the responses to read-only commands such as status to be
+	// cached and reused for that long.
+	JujuAPICacheTTLEnvKey = "JUJU_API_CACHE_TTL"
+
 	// XDGDataHome is a path where data for the running user
 	// should be stored according to the xdg standard.
 	XDGDataHome = "XDG_DATA_HOME"
@@ -60,7 +60,6 @@ import (
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/binarystorage"
 	"github.com/juju/juju/state/stateenvirons"
-	statestorage "github.com/juju/juju/state/storage"
 	statetesting "github.com/juju/juju/state/testing"
 	statewatcher "github.com/juju/juju/state/watcher"
 	"github.com/juju/juju/testcharms"
@@ -793,7 +792,7 @@ func AddCharm(st *state.State, curl *charm.URL, ch charm.Charm, force bool) (*st
 		return nil, err
 	}
 
-	stor := statestorage.NewStorage(st.ModelUUID(), st.MongoSession())
+	stor := st.BlobStorage()
 	storagePath := fmt.Sprintf("/charms/%s-%s", curl.String(), digest)
 	if err := stor.Put(storagePath, f, size); err != nil {
 		return nil, fmt.Errorf("cannot put charm: %v", err)
@@ -13,7 +13,6 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/state"
-	"github.com/juju/juju/state/storage"
 	"github.com/juju/juju/version"
 )
 
@@ -52,7 +51,7 @@ func (s *RepoSuite) AssertCharmUploaded(c *gc.C, curl *charm.URL) {
 	ch, err := s.State.Charm(curl)
 	c.Assert(err, jc.ErrorIsNil)
 
-	storage := storage.NewStorage(s.State.ModelUUID(), s.State.MongoSession())
+	storage := s.State.BlobStorage()
 	r, _, err := storage.Get(ch.StoragePath())
 	c.Assert(err, jc.ErrorIsNil)
 	defer r.Close()
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/version"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/core/constraints"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/core/status"
@@ -33,9 +34,11 @@ type PrecheckBackend interface {
 	AllMachines() ([]PrecheckMachine, error)
 	AllApplications() ([]PrecheckApplication, error)
 	AllRelations() ([]PrecheckRelation, error)
+	ModelConstraints() (constraints.Value, error)
 	ControllerBackend() (PrecheckBackend, error)
 	CloudCredential(tag names.CloudCredentialTag) (state.Credential, error)
 	ListPendingResources(string) ([]resource.Resource, error)
+	ModelSpec() (state.ModelSpec, error)
 }
 
 // Pool defines the interface to a StatePool used by the migration
@@ -65,6 +68,8 @@ type PrecheckMachine interface {
 	Status() (status.StatusInfo, error)
 	InstanceStatus() (status.StatusInfo, error)
 	ShouldRebootOrShutdown() (state.RebootAction, error)
+	Constraints() (constraints.Value, error)
+	EgressNATAddress() string
 }
 
 // PrecheckApplication describes the state interface for an
@@ -75,6 +80,11 @@ type PrecheckApplication interface {
 	CharmURL() (*charm.URL, bool)
 	AllUnits() ([]PrecheckUnit, error)
 	MinUnits() int
+	Constraints() (constraints.Value, error)
+	SecretConfigKeys() ([]string, error)
+	SecretConfigAccesses() ([]state.SecretConfigAccess, error)
+	CharmSigned() (bool, error)
+	RelationSchemaEndpoints() []string
 }
 
 // PrecheckUnit describes state interface for a unit needed by
@@ -138,6 +148,30 @@ func SourcePrecheck(
 		return errors.Trace(err)
 	}
 
+	if err := ctx.checkConstraints(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := ctx.checkEgressNATAddresses(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := ctx.checkSecretConfig(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := ctx.checkCharmSignatures(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := ctx.checkRelationSchemas(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := ctx.checkModelSpec(); err != nil {
+		return errors.Trace(err)
+	}
+
 	if cleanupNeeded, err := backend.NeedsCleanup(); err != nil {
 		return errors.Annotate(err, "checking cleanups")
 	} else if cleanupNeeded {
@@ -416,6 +450,147 @@ func (ctx *precheckContext) checkUnitAgentStatus(unit PrecheckUnit) error {
 	return nil
 }
 
+// checkConstraints fails if the model, or any of its machines or
+// applications, has gpus or gpu-type constraints. The model description
+// cannot hold them yet, so they would be lost in the migration, and the
+// target would place new units differently.
+func (ctx *precheckContext) checkConstraints() error {
+	cons, err := ctx.backend.ModelConstraints()
+	if err != nil {
+		return errors.Annotate(err, "retrieving model constraints")
+	}
+	if err := checkGPUConstraints(cons); err != nil {
+		return errors.Annotate(err, "model")
+	}
+
+	machines, err := ctx.backend.AllMachines()
+	if err != nil {
+		return errors.Annotate(err, "retrieving machines")
+	}
+	for _, machine := range machines {
+		cons, err := machine.Constraints()
+		if err != nil {
+			return errors.Annotatef(err, "retrieving machine %s constraints", machine.Id())
+		}
+		if err := checkGPUConstraints(cons); err != nil {
+			return errors.Annotatef(err, "machine %s", machine.Id())
+		}
+	}
+
+	apps, err := ctx.backend.AllApplications()
+	if err != nil {
+		return errors.Annotate(err, "retrieving applications")
+	}
+	for _, app := range apps {
+		cons, err := app.Constraints()
+		if err != nil {
+			return errors.Annotatef(err, "retrieving application %s constraints", app.Name())
+		}
+		if err := checkGPUConstraints(cons); err != nil {
+			return errors.Annotatef(err, "application %s", app.Name())
+		}
+	}
+	return nil
+}
+
+func checkGPUConstraints(cons constraints.Value) error {
+	if cons.HasGpus() || cons.HasGpuType() {
+		return errors.New("gpus and gpu-type constraints cannot be migrated")
+	}
+	return nil
+}
+
+// checkEgressNATAddresses fails if any machine in the model has an
+// egress NAT address. The model description cannot hold it yet, so it
+// would be lost in the migration, and the machine's cross-model
+// relations on the target would be given the wrong egress address.
+func (ctx *precheckContext) checkEgressNATAddresses() error {
+	machines, err := ctx.backend.AllMachines()
+	if err != nil {
+		return errors.Annotate(err, "retrieving machines")
+	}
+	for _, machine := range machines {
+		if machine.EgressNATAddress() != "" {
+			return errors.Errorf("machine %s: egress NAT address cannot be migrated", machine.Id())
+		}
+	}
+	return nil
+}
+
+// checkSecretConfig fails if any application in the model has secret
+// config values, or records of them being read. The values are sealed
+// with the source controller's secret backend, which the target cannot
+// open, so neither is migrated.
+func (ctx *precheckContext) checkSecretConfig() error {
+	apps, err := ctx.backend.AllApplications()
+	if err != nil {
+		return errors.Annotate(err, "retrieving applications")
+	}
+	for _, app := range apps {
+		keys, err := app.SecretConfigKeys()
+		if err != nil {
+			return errors.Annotatef(err, "retrieving application %s secret config", app.Name())
+		}
+		accesses, err := app.SecretConfigAccesses()
+		if err != nil {
+			return errors.Annotatef(err, "retrieving application %s secret config accesses", app.Name())
+		}
+		if len(keys) > 0 || len(accesses) > 0 {
+			return errors.Errorf("application %s: secret config cannot be migrated", app.Name())
+		}
+	}
+	return nil
+}
+
+// checkRelationSchemas fails if any application has registered relation
+// schemas. They are not part of the model description, so relation data
+// would no longer be validated against them on the target.
+func (ctx *precheckContext) checkRelationSchemas() error {
+	apps, err := ctx.backend.AllApplications()
+	if err != nil {
+		return errors.Annotate(err, "retrieving applications")
+	}
+	for _, app := range apps {
+		if endpoints := app.RelationSchemaEndpoints(); len(endpoints) > 0 {
+			return errors.Errorf("application %s: relation schemas cannot be migrated", app.Name())
+		}
+	}
+	return nil
+}
+
+// checkModelSpec fails if the model has a declarative spec set with
+// juju apply. The spec is not part of the model description, so the
+// target would stop reconciling the model towards it.
+func (ctx *precheckContext) checkModelSpec() error {
+	_, err := ctx.backend.ModelSpec()
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "retrieving model spec")
+	}
+	return errors.New("model spec cannot be migrated")
+}
+
+// checkCharmSignatures fails if any application's charm was uploaded
+// with a verified signature. The signature is recorded for audit, but
+// is not part of the model description, so it would be lost.
+func (ctx *precheckContext) checkCharmSignatures() error {
+	apps, err := ctx.backend.AllApplications()
+	if err != nil {
+		return errors.Annotate(err, "retrieving applications")
+	}
+	for _, app := range apps {
+		signed, err := app.CharmSigned()
+		if err != nil {
+			return errors.Annotatef(err, "retrieving application %s charm", app.Name())
+		}
+		if signed {
+			return errors.Errorf("application %s: charm signature cannot be migrated", app.Name())
+		}
+	}
+	return nil
+}
+
 func checkAgentTools(modelVersion version.Number, agent agentToolsGetter, agentLabel string) error {
 	tools, err := agent.AgentTools()
 	if err != nil {
@@ -145,6 +145,15 @@ func (s *precheckAppShim) AllUnits() ([]PrecheckUnit, error) {
 	return out, nil
 }
 
+// CharmSigned implements PrecheckApplication.
+func (s *precheckAppShim) CharmSigned() (bool, error) {
+	ch, _, err := s.Application.Charm()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return ch.Signature() != nil, nil
+}
+
 // precheckRelationShim implements PrecheckRelation.
 type precheckRelationShim struct {
 	*state.Relation
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/core/constraints"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/core/status"
@@ -396,6 +397,72 @@ func (s *SourcePrecheckSuite) TestCrossModelUnitsNotYetInScope(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *SourcePrecheckSuite) TestModelGPUConstraints(c *gc.C) {
+	backend := newHappyBackend()
+	backend.modelConstraints = constraints.MustParse("gpus=1")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model: gpus and gpu-type constraints cannot be migrated")
+}
+
+func (s *SourcePrecheckSuite) TestMachineGPUConstraints(c *gc.C) {
+	backend := newHappyBackend()
+	backend.machines[1].(*fakeMachine).constraints = constraints.MustParse("gpu-type=nvidia")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "machine 1: gpus and gpu-type constraints cannot be migrated")
+}
+
+func (s *SourcePrecheckSuite) TestApplicationGPUConstraints(c *gc.C) {
+	backend := newHappyBackend()
+	backend.apps[1].(*fakeApp).constraints = constraints.MustParse("gpus=2 gpu-type=amd")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "application bar: gpus and gpu-type constraints cannot be migrated")
+}
+
+func (s *SourcePrecheckSuite) TestMachineEgressNATAddress(c *gc.C) {
+	backend := newHappyBackend()
+	backend.machines[1].(*fakeMachine).egressNAT = "203.0.113.1"
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "machine 1: egress NAT address cannot be migrated")
+}
+
+func (s *SourcePrecheckSuite) TestApplicationSecretConfig(c *gc.C) {
+	backend := newHappyBackend()
+	backend.apps[1].(*fakeApp).secretConfigKeys = []string{"password"}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "application bar: secret config cannot be migrated")
+}
+
+func (s *SourcePrecheckSuite) TestApplicationSecretConfigAccesses(c *gc.C) {
+	backend := newHappyBackend()
+	backend.apps[0].(*fakeApp).secretAccesses = []state.SecretConfigAccess{{
+		Unit: "foo/0",
+		Key:  "password",
+	}}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "application foo: secret config cannot be migrated")
+}
+
+func (s *SourcePrecheckSuite) TestApplicationCharmSigned(c *gc.C) {
+	backend := newHappyBackend()
+	backend.apps[1].(*fakeApp).charmSigned = true
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "application bar: charm signature cannot be migrated")
+}
+
+func (s *SourcePrecheckSuite) TestApplicationRelationSchemas(c *gc.C) {
+	backend := newHappyBackend()
+	backend.apps[1].(*fakeApp).relationSchemaEndpoints = []string{"db"}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "application bar: relation schemas cannot be migrated")
+}
+
+func (s *SourcePrecheckSuite) TestModelSpec(c *gc.C) {
+	backend := newHappyBackend()
+	backend.modelSpec = &state.ModelSpec{Spec: "applications: {}\n", Revision: 1}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model spec cannot be migrated")
+}
+
 type TargetPrecheckSuite struct {
 	precheckBaseSuite
 	modelInfo coremigration.ModelInfo
@@ -765,12 +832,16 @@ type fakeBackend struct {
 	relations  []migration.PrecheckRelation
 	allRelsErr error
 
+	modelConstraints constraints.Value
+
 	credentials    state.Credential
 	credentialsErr error
 
 	pendingResources    []resource.Resource
 	pendingResourcesErr error
 
+	modelSpec *state.ModelSpec
+
 	controllerBackend *fakeBackend
 }
 
@@ -814,10 +885,21 @@ func (b *fakeBackend) AllRelations() ([]migration.PrecheckRelation, error) {
 	return b.relations, b.allRelsErr
 }
 
+func (b *fakeBackend) ModelConstraints() (constraints.Value, error) {
+	return b.modelConstraints, nil
+}
+
 func (b *fakeBackend) ListPendingResources(app string) ([]resource.Resource, error) {
 	return b.pendingResources, b.pendingResourcesErr
 }
 
+func (b *fakeBackend) ModelSpec() (state.ModelSpec, error) {
+	if b.modelSpec == nil {
+		return state.ModelSpec{}, errors.NotFoundf("model spec")
+	}
+	return *b.modelSpec, nil
+}
+
 func (b *fakeBackend) ControllerBackend() (migration.PrecheckBackend, error) {
 	if b.controllerBackend == nil {
 		return b, nil
@@ -894,6 +976,8 @@ type fakeMachine struct {
 	status         status.Status
 	instanceStatus status.Status
 	rebootAction   state.RebootAction
+	constraints    constraints.Value
+	egressNAT      string
 }
 
 func (m *fakeMachine) Id() string {
@@ -941,12 +1025,26 @@ func (m *fakeMachine) ShouldRebootOrShutdown() (state.RebootAction, error) {
 	return m.rebootAction, nil
 }
 
+func (m *fakeMachine) Constraints() (constraints.Value, error) {
+	return m.constraints, nil
+}
+
+func (m *fakeMachine) EgressNATAddress() string {
+	return m.egressNAT
+}
+
 type fakeApp struct {
-	name     string
-	life     state.Life
-	charmURL string
-	units    []migration.PrecheckUnit
-	minunits int
+	name             string
+	life             state.Life
+	charmURL         string
+	units            []migration.PrecheckUnit
+	minunits         int
+	constraints      constraints.Value
+	secretConfigKeys []string
+	secretAccesses   []state.SecretConfigAccess
+	charmSigned      bool
+
+	relationSchemaEndpoints []string
 }
 
 func (a *fakeApp) Name() string {
@@ -973,6 +1071,26 @@ func (a *fakeApp) MinUnits() int {
 	return a.minunits
 }
 
+func (a *fakeApp) Constraints() (constraints.Value, error) {
+	return a.constraints, nil
+}
+
+func (a *fakeApp) SecretConfigKeys() ([]string, error) {
+	return a.secretConfigKeys, nil
+}
+
+func (a *fakeApp) SecretConfigAccesses() ([]state.SecretConfigAccess, error) {
+	return a.secretAccesses, nil
+}
+
+func (a *fakeApp) CharmSigned() (bool, error) {
+	return a.charmSigned, nil
+}
+
+func (a *fakeApp) RelationSchemaEndpoints() []string {
+	return a.relationSchemaEndpoints
+}
+
 type fakeUnit struct {
 	name        string
 	version     version.Binary
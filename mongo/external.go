@@ -0,0 +1,67 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mongo
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+	"gopkg.in/mgo.v2"
+
+	"github.com/juju/juju/mongo/utils"
+)
+
+// ExternalInfo returns the information used to dial an externally
+// managed replica set, whose members present certificates signed by
+// caCert.
+func ExternalInfo(rs *utils.ExternalReplicaSet, caCert string) Info {
+	return Info{
+		Addrs:      rs.Addrs,
+		CACert:     caCert,
+		DisableTLS: !rs.TLS,
+	}
+}
+
+// LoginExternal logs in to an externally managed replica set with the
+// credentials it was configured with, if any.
+func LoginExternal(session *mgo.Session, rs *utils.ExternalReplicaSet) error {
+	if rs.Username == "" {
+		return nil
+	}
+	if err := session.DB(rs.AuthSource).Login(rs.Username, rs.Password); err != nil {
+		return MaybeUnauthorizedf(err, "cannot log in to %s database as %q", rs.AuthSource, rs.Username)
+	}
+	return nil
+}
+
+// CheckExternalReplicaSet checks that an externally managed replica set
+// is suitable for use by a controller: that it can be dialled and logged
+// in to, and that it reports itself as a member of the expected replica
+// set.
+func CheckExternalReplicaSet(rs *utils.ExternalReplicaSet, caCert string, opts DialOpts) error {
+	dialInfo, err := DialInfo(ExternalInfo(rs, caCert), opts)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	dialInfo.ReplicaSetName = rs.ReplicaSet
+	session, err := mgo.DialWithInfo(dialInfo)
+	if err != nil {
+		return errors.Annotatef(err, "cannot dial external replica set %q at %v", rs.ReplicaSet, rs.Addrs)
+	}
+	defer session.Close()
+
+	if err := LoginExternal(session, rs); err != nil {
+		return errors.Trace(err)
+	}
+	results, err := replicaset.IsMaster(session)
+	if err != nil {
+		return errors.Annotate(err, "cannot query external replica set status")
+	}
+	if results.ReplicaSetName != rs.ReplicaSet {
+		return errors.Errorf(
+			"external mongo at %v is a member of replica set %q, expected %q",
+			rs.Addrs, results.ReplicaSetName, rs.ReplicaSet,
+		)
+	}
+	return nil
+}
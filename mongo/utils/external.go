@@ -0,0 +1,128 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package utils
+
+import (
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+const (
+	externalURIScheme   = "mongodb://"
+	defaultExternalPort = "27017"
+)
+
+// ExternalReplicaSet describes an externally managed MongoDB replica
+// set, used by a controller in place of its co-located mongod.
+type ExternalReplicaSet struct {
+	// Addrs holds the host:port addresses of the replica set's seed
+	// members.
+	Addrs []string
+
+	// ReplicaSet is the name of the replica set.
+	ReplicaSet string
+
+	// Username and Password hold the credentials used to log in
+	// to the replica set. They are either both set, or both empty.
+	Username string
+	Password string
+
+	// AuthSource is the database holding the user's credentials.
+	AuthSource string
+
+	// TLS reports whether connections to the replica set are made
+	// using TLS.
+	TLS bool
+}
+
+// ParseExternalURI parses a MongoDB connection string of the form
+//
+//	mongodb://[user:password@]host[:port][,host[:port]...][/[database]]?replicaSet=name[&options]
+//
+// describing an externally managed replica set. The "replicaSet" option
+// is required; the "tls" (or "ssl") and "authSource" options are also
+// understood, and any other option is rejected. TLS is used unless it is
+// explicitly disabled.
+func ParseExternalURI(uri string) (*ExternalReplicaSet, error) {
+	if !strings.HasPrefix(uri, externalURIScheme) {
+		return nil, errors.NotValidf("mongo URI without %q scheme", externalURIScheme)
+	}
+	rest := strings.TrimPrefix(uri, externalURIScheme)
+
+	var rawQuery string
+	if i := strings.Index(rest, "?"); i >= 0 {
+		rest, rawQuery = rest[:i], rest[i+1:]
+	}
+	var database string
+	if i := strings.Index(rest, "/"); i >= 0 {
+		rest, database = rest[:i], rest[i+1:]
+	}
+
+	rs := &ExternalReplicaSet{TLS: true}
+	if i := strings.LastIndex(rest, "@"); i >= 0 {
+		var userInfo string
+		userInfo, rest = rest[:i], rest[i+1:]
+		parts := strings.SplitN(userInfo, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, errors.NotValidf("mongo URI credentials without both username and password")
+		}
+		var err error
+		if rs.Username, err = url.PathUnescape(parts[0]); err != nil {
+			return nil, errors.NotValidf("mongo URI username")
+		}
+		if rs.Password, err = url.PathUnescape(parts[1]); err != nil {
+			return nil, errors.NotValidf("mongo URI password")
+		}
+	}
+
+	if rest == "" {
+		return nil, errors.NotValidf("mongo URI without hosts")
+	}
+	for _, host := range strings.Split(rest, ",") {
+		addr := host
+		if _, _, err := net.SplitHostPort(host); err != nil {
+			addr = net.JoinHostPort(host, defaultExternalPort)
+		}
+		if h, _, err := net.SplitHostPort(addr); err != nil || h == "" {
+			return nil, errors.NotValidf("mongo URI host %q", host)
+		}
+		rs.Addrs = append(rs.Addrs, addr)
+	}
+
+	options, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, errors.NotValidf("mongo URI options %q", rawQuery)
+	}
+	rs.AuthSource = database
+	for name, values := range options {
+		value := values[len(values)-1]
+		switch name {
+		case "replicaSet":
+			rs.ReplicaSet = value
+		case "authSource":
+			rs.AuthSource = value
+		case "tls", "ssl":
+			switch value {
+			case "true":
+				rs.TLS = true
+			case "false":
+				rs.TLS = false
+			default:
+				return nil, errors.NotValidf("mongo URI %s option %q", name, value)
+			}
+		default:
+			return nil, errors.NotSupportedf("mongo URI option %q", name)
+		}
+	}
+	if rs.ReplicaSet == "" {
+		return nil, errors.NotValidf("mongo URI without replicaSet option")
+	}
+	if rs.AuthSource == "" {
+		rs.AuthSource = "admin"
+	}
+	return rs, nil
+}
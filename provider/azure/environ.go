@@ -6,6 +6,7 @@ package azure
 import (
 	stdcontext "context"
 	"fmt"
+	"math"
 	"net/http"
 	"net/url"
 	"sort"
@@ -452,6 +453,8 @@ func (env *azureEnviron) ConstraintsValidator(ctx context.ProviderCallContext) (
 			constraints.Mem,
 			constraints.Cores,
 			constraints.Arch,
+			constraints.Gpus,
+			constraints.GpuType,
 		},
 	)
 	return validator, nil
@@ -2116,6 +2119,7 @@ nextResource:
 			cores    *int32
 			mem      *int32
 			rootDisk *int32
+			gpus     uint64
 		)
 		for _, capability := range *resource.Capabilities {
 			if capability.Name == nil || capability.Value == nil {
@@ -2131,6 +2135,11 @@ nextResource:
 			case "OSVhdSizeMB":
 				rootDiskValue, _ := strconv.Atoi(*capability.Value)
 				rootDisk = to.Int32Ptr(int32(rootDiskValue))
+			case "GPUs":
+				// Some sizes have a fraction of a GPU; a
+				// constraint of gpus=1 is satisfied by them.
+				gpusValue, _ := strconv.ParseFloat(*capability.Value, 64)
+				gpus = uint64(math.Ceil(gpusValue))
 			}
 		}
 		instanceType := newInstanceType(compute.VirtualMachineSize{
@@ -2139,6 +2148,11 @@ nextResource:
 			OsDiskSizeInMB: rootDisk,
 			MemoryInMB:     mem,
 		})
+		if gpus > 0 {
+			gpuType := gpuTypeForSize(instanceType.Name)
+			instanceType.Gpus = gpus
+			instanceType.GpuType = &gpuType
+		}
 		instanceTypes[instanceType.Name] = instanceType
 		// Create aliases for standard role sizes.
 		if strings.HasPrefix(instanceType.Name, "Standard_") {
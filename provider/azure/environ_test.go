@@ -2098,6 +2098,13 @@ func (s *environSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 	c.Assert(unsupported, jc.SameContents, []string{"tags", "cpu-power", "virt-type"})
 }
 
+func (s *environSuite) TestConstraintsValidatorGPUs(c *gc.C) {
+	validator := s.constraintsValidator(c)
+	unsupported, err := validator.Validate(constraints.MustParse("arch=amd64 gpus=1 gpu-type=nvidia"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unsupported, gc.HasLen, 0)
+}
+
 func (s *environSuite) TestConstraintsValidatorVocabulary(c *gc.C) {
 	validator := s.constraintsValidator(c)
 	_, err := validator.Validate(constraints.MustParse("arch=armhf"))
@@ -2322,6 +2329,51 @@ func (s *environSuite) TestInstanceInformation(c *gc.C) {
 	c.Assert(types.InstanceTypes, gc.HasLen, 2)
 }
 
+func (s *environSuite) TestInstanceInformationGPUs(c *gc.C) {
+	gpuSku := func(name, gpus string) compute.ResourceSku {
+		return compute.ResourceSku{
+			Name:         to.StringPtr(name),
+			Locations:    to.StringSlicePtr([]string{"westus"}),
+			ResourceType: to.StringPtr("virtualMachines"),
+			Capabilities: &[]compute.ResourceSkuCapabilities{{
+				Name:  to.StringPtr("MemoryGB"),
+				Value: to.StringPtr("56"),
+			}, {
+				Name:  to.StringPtr("vCPUs"),
+				Value: to.StringPtr("6"),
+			}, {
+				Name:  to.StringPtr("OSVhdSizeMB"),
+				Value: to.StringPtr("1047552"),
+			}, {
+				Name:  to.StringPtr("GPUs"),
+				Value: to.StringPtr(gpus),
+			}},
+		}
+	}
+	skus := append(*s.skus.Value,
+		gpuSku("Standard_NC6", "1"),
+		gpuSku("Standard_NV4as_v4", "0.125"),
+	)
+	s.skus = &compute.ResourceSkusResult{Value: &skus}
+
+	env := s.openEnviron(c)
+	s.sender = s.startInstanceSenders(startInstanceSenderParams{bootstrap: false})
+	types, err := env.InstanceTypes(s.callCtx, constraints.MustParse("gpus=1 gpu-type=nvidia"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(types.InstanceTypes, gc.HasLen, 2)
+	for _, itype := range types.InstanceTypes {
+		c.Check(itype.Id, gc.Equals, "Standard_NC6")
+		c.Check(itype.Gpus, gc.Equals, uint64(1))
+	}
+
+	types, err = env.InstanceTypes(s.callCtx, constraints.MustParse("gpus=1 gpu-type=amd"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(types.InstanceTypes, gc.HasLen, 2)
+	for _, itype := range types.InstanceTypes {
+		c.Check(itype.Id, gc.Equals, "Standard_NV4as_v4")
+	}
+}
+
 func (s *environSuite) TestInstanceInformationWithInvalidCredential(c *gc.C) {
 	env := s.openEnviron(c)
 	s.createSenderWithUnauthorisedStatusCode(c)
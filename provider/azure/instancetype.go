@@ -4,6 +4,8 @@
 package azure
 
 import (
+	"strings"
+
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/juju/errors"
@@ -118,6 +120,19 @@ func newInstanceType(size compute.VirtualMachineSize) instances.InstanceType {
 	}
 }
 
+// gpuTypeForSize returns the vendor of the GPUs of the named VM size.
+// The resource SKUs report how many GPUs a size has but not whose, so
+// it is taken from the size family: the NVv4 and NG families have AMD
+// GPUs, and every other GPU size NVIDIA ones.
+func gpuTypeForSize(name string) string {
+	name = strings.ToLower(name)
+	if strings.HasPrefix(name, "standard_ng") ||
+		(strings.HasPrefix(name, "standard_nv") && strings.HasSuffix(name, "as_v4")) {
+		return "amd"
+	}
+	return "nvidia"
+}
+
 func mbToMib(mb uint64) uint64 {
 	b := mb * 1000 * 1000
 	return uint64(float64(b) / 1024 / 1024)
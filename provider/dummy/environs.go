@@ -2029,7 +2029,7 @@ type fakePresence struct {
 func (*fakePresence) Disable()        {}
 func (*fakePresence) Enable()         {}
 func (*fakePresence) IsEnabled() bool { return true }
-func (*fakePresence) Connect(server, model, agent string, id uint64, controllerAgent bool, userData string) {
+func (*fakePresence) Connect(server, model, agent string, id uint64, controllerAgent bool, userData, remoteAddress string) {
 }
 func (*fakePresence) Disconnect(server string, id uint64)                            {}
 func (*fakePresence) Activity(server string, id uint64)                              {}
@@ -5,6 +5,7 @@ package ec2
 
 import (
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -80,6 +81,8 @@ const (
 	volumeStatusInUse     = "in-use"
 	volumeStatusCreating  = "creating"
 
+	snapshotStatusCompleted = "completed"
+
 	attachmentStatusAttaching = "attaching"
 	attachmentStatusAttached  = "attached"
 
@@ -980,6 +983,120 @@ func (v *ebsVolumeSource) ImportVolume(ctx context.ProviderCallContext, volumeId
 	}, nil
 }
 
+var _ storage.VolumeSnapshotter = (*ebsVolumeSource)(nil)
+
+// CreateVolumeSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) CreateVolumeSnapshots(ctx context.ProviderCallContext, params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		resp, err := v.env.ec2.CreateSnapshot(p.VolumeId, p.Description)
+		if err != nil {
+			err = maybeConvertCredentialError(err, ctx)
+			if common.IsCredentialNotValid(err) {
+				return nil, errors.Trace(err)
+			}
+			results[i].Error = errors.Annotatef(err, "creating snapshot of volume %q", p.VolumeId)
+			continue
+		}
+		if err := tagResources(v.env.ec2, ctx, p.ResourceTags, resp.Id); err != nil {
+			results[i].Error = errors.Annotate(err, "tagging snapshot")
+			continue
+		}
+		snapshot, err := ebsVolumeSnapshot(resp.Snapshot)
+		if err != nil {
+			results[i].Error = errors.Trace(err)
+			continue
+		}
+		results[i].Snapshot = &snapshot
+	}
+	return results, nil
+}
+
+// VolumeSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) VolumeSnapshots(ctx context.ProviderCallContext, volumeId string) ([]storage.VolumeSnapshot, error) {
+	filter := ec2.NewFilter()
+	filter.Add("volume-id", volumeId)
+	resp, err := v.env.ec2.Snapshots(nil, filter)
+	if err != nil {
+		return nil, errors.Trace(maybeConvertCredentialError(err, ctx))
+	}
+	snapshots := make([]storage.VolumeSnapshot, len(resp.Snapshots))
+	for i, snap := range resp.Snapshots {
+		if snapshots[i], err = ebsVolumeSnapshot(snap); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return snapshots, nil
+}
+
+// CreateVolumeFromSnapshot is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) CreateVolumeFromSnapshot(ctx context.ProviderCallContext, p storage.VolumeFromSnapshotParams) (storage.VolumeInfo, error) {
+	resp, err := v.env.ec2.Snapshots([]string{p.SnapshotId}, nil)
+	if err != nil {
+		return storage.VolumeInfo{}, errors.Trace(maybeConvertCredentialError(err, ctx))
+	}
+	if len(resp.Snapshots) != 1 {
+		return storage.VolumeInfo{}, errors.NotFoundf("snapshot %q", p.SnapshotId)
+	}
+	snap := resp.Snapshots[0]
+	if snap.Status != snapshotStatusCompleted {
+		return storage.VolumeInfo{}, errors.Errorf("cannot create volume from snapshot with status %q", snap.Status)
+	}
+
+	// EBS volumes can only be attached to instances in the same
+	// availability zone, so by default create the volume alongside
+	// the one that the snapshot was taken of.
+	zone := p.AvailabilityZone
+	if zone == "" {
+		vol, err := describeVolume(v.env.ec2, ctx, snap.VolumeId)
+		if err != nil {
+			return storage.VolumeInfo{}, errors.Annotatef(err,
+				"cannot determine availability zone of volume %q, specify one", snap.VolumeId)
+		}
+		zone = vol.AvailZone
+	}
+
+	createResp, err := v.env.ec2.CreateVolume(ec2.CreateVolume{
+		AvailZone:  zone,
+		SnapshotId: p.SnapshotId,
+	})
+	if err != nil {
+		return storage.VolumeInfo{}, errors.Trace(maybeConvertCredentialError(err, ctx))
+	}
+	volumeId := createResp.Id
+	if err := tagResources(v.env.ec2, ctx, p.ResourceTags, volumeId); err != nil {
+		return storage.VolumeInfo{}, errors.Annotate(err, "tagging volume")
+	}
+	vol, err := v.waitVolumeCreated(ctx, volumeId)
+	if err != nil {
+		return storage.VolumeInfo{}, errors.Trace(err)
+	}
+	return storage.VolumeInfo{
+		VolumeId:   volumeId,
+		Size:       gibToMib(uint64(vol.Size)),
+		Persistent: true,
+	}, nil
+}
+
+func ebsVolumeSnapshot(snap ec2.Snapshot) (storage.VolumeSnapshot, error) {
+	sizeInGib, err := strconv.ParseUint(snap.VolumeSize, 10, 64)
+	if err != nil {
+		return storage.VolumeSnapshot{}, errors.Annotatef(err, "parsing size of snapshot %q", snap.Id)
+	}
+	created, err := time.Parse(time.RFC3339, snap.StartTime)
+	if err != nil {
+		return storage.VolumeSnapshot{}, errors.Annotatef(err, "parsing start time of snapshot %q", snap.Id)
+	}
+	return storage.VolumeSnapshot{
+		SnapshotId:  snap.Id,
+		VolumeId:    snap.VolumeId,
+		Size:        gibToMib(sizeInGib),
+		Description: snap.Description,
+		Status:      snap.Status,
+		Created:     created,
+	}, nil
+}
+
 var errTooManyVolumes = errors.New("too many EBS volumes to attach")
 
 // blockDeviceNamer returns a function that cycles through block device names.
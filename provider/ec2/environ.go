@@ -12,6 +12,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/juju/clock"
 	"github.com/juju/collections/set"
@@ -74,6 +75,11 @@ type ec2Client interface {
 	DescribeInstanceTypeOfferings(*ec2.DescribeInstanceTypeOfferingsInput) (*ec2.DescribeInstanceTypeOfferingsOutput, error)
 	DescribeInstanceTypes(*ec2.DescribeInstanceTypesInput) (*ec2.DescribeInstanceTypesOutput, error)
 	DescribeSpotPriceHistory(*ec2.DescribeSpotPriceHistoryInput) (*ec2.DescribeSpotPriceHistoryOutput, error)
+	ModifyInstanceAttribute(*ec2.ModifyInstanceAttributeInput) (*ec2.ModifyInstanceAttributeOutput, error)
+	StartInstances(*ec2.StartInstancesInput) (*ec2.StartInstancesOutput, error)
+	StopInstances(*ec2.StopInstancesInput) (*ec2.StopInstancesOutput, error)
+	WaitUntilInstanceRunning(*ec2.DescribeInstancesInput) error
+	WaitUntilInstanceStopped(*ec2.DescribeInstancesInput) error
 }
 
 var _ ec2Client = (*ec2.EC2)(nil)
@@ -107,6 +113,8 @@ type environ struct {
 
 var _ environs.Environ = (*environ)(nil)
 var _ environs.Networking = (*environ)(nil)
+var _ environs.InstanceHardwareReader = (*environ)(nil)
+var _ environs.InstanceResizer = (*environ)(nil)
 
 func (e *environ) Config() *config.Config {
 	return e.ecfg().Config
@@ -1103,6 +1111,123 @@ func (e *environ) Instances(ctx context.ProviderCallContext, ids []instance.Id)
 	return insts, nil
 }
 
+// InstanceHardware is part of the environs.InstanceHardwareReader
+// interface. The memory and cores are those of the instance's current
+// instance type, so they reflect any resize made outside of Juju.
+func (e *environ) InstanceHardware(ctx context.ProviderCallContext, ids []instance.Id) ([]*instance.HardwareCharacteristics, error) {
+	insts, err := e.Instances(ctx, ids)
+	if err != nil && err != environs.ErrPartialInstances {
+		return nil, err
+	}
+	instTypes, typesErr := e.supportedInstanceTypes(ctx)
+	if typesErr != nil {
+		return nil, errors.Annotate(typesErr, "getting instance types")
+	}
+	results := make([]*instance.HardwareCharacteristics, len(ids))
+	for i, inst := range insts {
+		if inst == nil {
+			continue
+		}
+		ec2Inst := inst.(*sdkInstance).i
+		var hc instance.HardwareCharacteristics
+		if ec2Inst.Architecture != nil {
+			arch := archName(*ec2Inst.Architecture)
+			hc.Arch = &arch
+		}
+		if ec2Inst.Placement != nil && ec2Inst.Placement.AvailabilityZone != nil {
+			zone := *ec2Inst.Placement.AvailabilityZone
+			hc.AvailabilityZone = &zone
+		}
+		if ec2Inst.InstanceType != nil {
+			for _, instType := range instTypes {
+				if instType.Name != *ec2Inst.InstanceType {
+					continue
+				}
+				mem, cores := instType.Mem, instType.CpuCores
+				hc.Mem = &mem
+				hc.CpuCores = &cores
+				hc.CpuPower = instType.CpuPower
+				break
+			}
+		}
+		results[i] = &hc
+	}
+	return results, err
+}
+
+// ResizeInstance is part of the environs.InstanceResizer interface. The
+// instance is stopped, changed to the cheapest instance type matching
+// the constraints, and started again. Instances with instance store
+// root devices cannot be stopped, and so cannot be resized.
+func (e *environ) ResizeInstance(ctx context.ProviderCallContext, id instance.Id, cons constraints.Value) (*instance.HardwareCharacteristics, error) {
+	insts, err := e.Instances(ctx, []instance.Id{id})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ec2Inst := insts[0].(*sdkInstance).i
+
+	// The instance keeps its image, so the new instance type must
+	// support the instance's current architecture.
+	if !cons.HasArch() && ec2Inst.Architecture != nil {
+		arch := archName(*ec2Inst.Architecture)
+		cons.Arch = &arch
+	}
+	instTypes, err := e.supportedInstanceTypes(ctx)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting instance types")
+	}
+	matching, err := instances.MatchingInstanceTypes(instTypes, e.cloud.Region, cons)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	instType := matching[0].Name
+
+	if ec2Inst.InstanceType == nil || *ec2Inst.InstanceType != instType {
+		logger.Infof("resizing instance %q to instance type %q", id, instType)
+		if err := e.changeInstanceType(ctx, id, instType); err != nil {
+			return nil, errors.Annotatef(err, "resizing instance %q to %q", id, instType)
+		}
+	}
+
+	hardware, err := e.InstanceHardware(ctx, []instance.Id{id})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return hardware[0], nil
+}
+
+// changeInstanceType stops the instance, changes its instance type, and
+// starts it again, waiting for each state change to complete.
+func (e *environ) changeInstanceType(ctx context.ProviderCallContext, id instance.Id, instType string) error {
+	ids := []*string{aws.String(string(id))}
+	describe := &ec2.DescribeInstancesInput{InstanceIds: ids}
+	if _, err := e.ec2Client.StopInstances(&ec2.StopInstancesInput{InstanceIds: ids}); err != nil {
+		return errors.Annotate(maybeConvertCredentialError(err, ctx), "stopping instance")
+	}
+	if err := e.ec2Client.WaitUntilInstanceStopped(describe); err != nil {
+		return errors.Annotate(maybeConvertCredentialError(err, ctx), "waiting for instance to stop")
+	}
+	_, err := e.ec2Client.ModifyInstanceAttribute(&ec2.ModifyInstanceAttributeInput{
+		InstanceId:   aws.String(string(id)),
+		InstanceType: &ec2.AttributeValue{Value: aws.String(instType)},
+	})
+	if err != nil {
+		// Start the instance with its original type, so that a
+		// failed resize doesn't leave it stopped.
+		if _, startErr := e.ec2Client.StartInstances(&ec2.StartInstancesInput{InstanceIds: ids}); startErr != nil {
+			logger.Errorf("cannot restart instance %q: %v", id, startErr)
+		}
+		return errors.Annotate(maybeConvertCredentialError(err, ctx), "changing instance type")
+	}
+	if _, err := e.ec2Client.StartInstances(&ec2.StartInstancesInput{InstanceIds: ids}); err != nil {
+		return errors.Annotate(maybeConvertCredentialError(err, ctx), "starting instance")
+	}
+	if err := e.ec2Client.WaitUntilInstanceRunning(describe); err != nil {
+		return errors.Annotate(maybeConvertCredentialError(err, ctx), "waiting for instance to start")
+	}
+	return nil
+}
+
 // gatherInstances tries to get information on each instance
 // id whose corresponding insts slot is nil.
 //
@@ -1721,6 +1846,11 @@ func rulesToIPPerms(rules firewall.IngressRules) []amzec2.IPPerm {
 			FromPort: r.PortRange.FromPort,
 			ToPort:   r.PortRange.ToPort,
 		}
+		if r.PortRange.IsICMPType() {
+			// For ICMP, EC2 expects the message type in FromPort and
+			// the code in ToPort; allow all codes for the given type.
+			ipPerms[i].ToPort = -1
+		}
 		if len(r.SourceCIDRs) == 0 {
 			ipPerms[i].SourceIPs = []string{defaultRouteCIDRBlock}
 		} else {
@@ -1801,6 +1931,10 @@ func (e *environ) ingressRulesInGroup(ctx context.ProviderCallContext, name stri
 			ips = append(ips, defaultRouteCIDRBlock)
 		}
 		portRange := corenetwork.PortRange{Protocol: p.Protocol, FromPort: p.FromPort, ToPort: p.ToPort}
+		if portRange.IsICMPType() {
+			// See rulesToIPPerms; ToPort holds the ICMP code.
+			portRange.ToPort = portRange.FromPort
+		}
 		rules = append(rules, firewall.NewIngressRule(portRange, ips...))
 	}
 	if err := rules.Validate(); err != nil {
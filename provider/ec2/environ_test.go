@@ -169,6 +169,24 @@ func (*Suite) TestPortsToIPPerms(c *gc.C) {
 			SourceIPs:     []string{"0.0.0.0/0", "192.168.1.0/24"},
 			SourceIPV6IPs: []string{"::/0"},
 		}},
+	}, {
+		about: "all icmp",
+		rules: firewall.IngressRules{firewall.NewIngressRule(network.MustParsePortRange("icmp"))},
+		expected: []amzec2.IPPerm{{
+			Protocol:  "icmp",
+			FromPort:  -1,
+			ToPort:    -1,
+			SourceIPs: []string{"0.0.0.0/0"},
+		}},
+	}, {
+		about: "icmp type",
+		rules: firewall.IngressRules{firewall.NewIngressRule(network.MustParsePortRange("8/icmp"))},
+		expected: []amzec2.IPPerm{{
+			Protocol:  "icmp",
+			FromPort:  8,
+			ToPort:    -1,
+			SourceIPs: []string{"0.0.0.0/0"},
+		}},
 	}}
 
 	for i, t := range testCases {
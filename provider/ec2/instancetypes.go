@@ -346,6 +346,9 @@ func convertEC2InstanceType(
 			instType.Arches = append(instType.Arches, archName(*instArch))
 		}
 	}
+	if info.GpuInfo != nil {
+		instType.Gpus, instType.GpuType = gpuDetails(info.GpuInfo)
+	}
 	instZones, ok := instanceTypeZones[instType.Name]
 	if !ok {
 		instType.Deprecated = true
@@ -357,6 +360,35 @@ func convertEC2InstanceType(
 	return instType
 }
 
+// gpuDetails returns the total number of GPUs described by info, and
+// their vendor as a gpu-type constraint value if they are all made by
+// the same recognised vendor.
+func gpuDetails(info *ec2.GpuInfo) (uint64, *string) {
+	var (
+		count   uint64
+		vendors = set.NewStrings()
+	)
+	for _, gpu := range info.Gpus {
+		if gpu == nil || gpu.Count == nil {
+			continue
+		}
+		count += uint64(*gpu.Count)
+		vendor := ""
+		if gpu.Manufacturer != nil {
+			vendor = strings.ToLower(*gpu.Manufacturer)
+		}
+		vendors.Add(vendor)
+	}
+	if vendors.Size() != 1 {
+		return count, nil
+	}
+	vendor := vendors.Values()[0]
+	if !set.NewStrings(constraints.GpuTypes...).Contains(vendor) {
+		return count, nil
+	}
+	return count, &vendor
+}
+
 // instanceTypeCosts queries the latest spot price for the given instance types.
 func instanceTypeCosts(ec2Client ec2Client, instTypeNames []*string, zoneNames []string) (map[string]uint64, error) {
 	const (
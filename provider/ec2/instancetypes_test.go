@@ -4,6 +4,8 @@
 package ec2
 
 import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -43,3 +45,40 @@ func (s *InstanceTypesSuite) TestSupportsClassic(c *gc.C) {
 	assertDoesNotSupportClassic("t2.medium")
 	assertDoesNotSupportClassic("x1.32xlarge")
 }
+
+func (s *InstanceTypesSuite) TestConvertGPUInstanceType(c *gc.C) {
+	info := &ec2.InstanceTypeInfo{
+		InstanceType: aws.String("g4dn.12xlarge"),
+		VCpuInfo:     &ec2.VCpuInfo{DefaultVCpus: aws.Int64(48)},
+		MemoryInfo:   &ec2.MemoryInfo{SizeInMiB: aws.Int64(196608)},
+		GpuInfo: &ec2.GpuInfo{
+			Gpus: []*ec2.GpuDeviceInfo{{
+				Count:        aws.Int64(4),
+				Manufacturer: aws.String("NVIDIA"),
+				Name:         aws.String("T4"),
+			}},
+		},
+	}
+	instType := convertEC2InstanceType(info, nil, nil, nil)
+	c.Assert(instType.Gpus, gc.Equals, uint64(4))
+	c.Assert(instType.GpuType, gc.NotNil)
+	c.Assert(*instType.GpuType, gc.Equals, "nvidia")
+
+	info.GpuInfo.Gpus = append(info.GpuInfo.Gpus, &ec2.GpuDeviceInfo{
+		Count:        aws.Int64(1),
+		Manufacturer: aws.String("AMD"),
+		Name:         aws.String("Radeon Pro V520"),
+	})
+	instType = convertEC2InstanceType(info, nil, nil, nil)
+	c.Assert(instType.Gpus, gc.Equals, uint64(5))
+	c.Assert(instType.GpuType, gc.IsNil)
+
+	info.GpuInfo.Gpus = []*ec2.GpuDeviceInfo{{
+		Count:        aws.Int64(1),
+		Manufacturer: aws.String("Xilinx"),
+		Name:         aws.String("VU9P"),
+	}}
+	instType = convertEC2InstanceType(info, nil, nil, nil)
+	c.Assert(instType.Gpus, gc.Equals, uint64(1))
+	c.Assert(instType.GpuType, gc.IsNil)
+}
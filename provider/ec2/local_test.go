@@ -757,6 +757,45 @@ func (t *localServerSuite) TestStartInstanceHardwareCharacteristics(c *gc.C) {
 	c.Check(*hc.CpuCores, gc.Equals, uint64(2))
 }
 
+func (t *localServerSuite) TestInstanceHardware(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	inst, _ := testing.AssertStartInstance(c, env, t.callCtx, t.ControllerUUID, "1")
+
+	reader, ok := env.(environs.InstanceHardwareReader)
+	c.Assert(ok, jc.IsTrue)
+	hcs, err := reader.InstanceHardware(t.callCtx, []instance.Id{inst.Id(), "i-missing"})
+	c.Assert(err, gc.Equals, environs.ErrPartialInstances)
+	c.Assert(hcs, gc.HasLen, 2)
+	c.Assert(hcs[0], gc.NotNil)
+	c.Check(*hcs[0].Mem, gc.Equals, uint64(1024))
+	c.Check(*hcs[0].CpuCores, gc.Equals, uint64(2))
+	c.Check(hcs[1], gc.IsNil)
+}
+
+func (t *localServerSuite) TestResizeInstance(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	inst, _ := testing.AssertStartInstance(c, env, t.callCtx, t.ControllerUUID, "1")
+
+	resizer, ok := env.(environs.InstanceResizer)
+	c.Assert(ok, jc.IsTrue)
+	hc, err := resizer.ResizeInstance(t.callCtx, inst.Id(), constraints.MustParse("mem=4G"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(*hc.Mem, gc.Equals, uint64(4096))
+	c.Check(*hc.CpuCores, gc.Equals, uint64(2))
+
+	insts, err := env.Instances(t.callCtx, []instance.Id{inst.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(*ec2.InstanceSDKEC2(insts[0]).InstanceType, gc.Equals, "t3a.medium")
+}
+
+func (t *localServerSuite) TestResizeInstanceNoMatchingType(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	inst, _ := testing.AssertStartInstance(c, env, t.callCtx, t.ControllerUUID, "1")
+
+	_, err := env.(environs.InstanceResizer).ResizeInstance(t.callCtx, inst.Id(), constraints.MustParse("mem=1T"))
+	c.Assert(err, gc.ErrorMatches, `no instance types in test matching constraints "mem=1048576M"`)
+}
+
 func (t *localServerSuite) TestStartInstanceAvailZone(c *gc.C) {
 	inst, err := t.testStartInstanceAvailZone(c, "test-available")
 	c.Assert(err, jc.ErrorIsNil)
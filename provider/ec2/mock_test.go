@@ -11,6 +11,10 @@ import (
 
 type mockEC2Session struct {
 	newInstancesClient func() *amzec2.EC2
+
+	// instanceTypes holds the instance types set by
+	// ModifyInstanceAttribute, keyed by instance id.
+	instanceTypes map[string]string
 }
 
 func (*mockEC2Session) DescribeAvailabilityZones(*ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error) {
@@ -57,9 +61,13 @@ func (s *mockEC2Session) DescribeInstances(input *ec2.DescribeInstancesInput) (*
 	for _, r := range resp.Reservations {
 		res := &ec2.Reservation{}
 		for _, i := range r.Instances {
+			instanceType := i.InstanceType
+			if modified, ok := s.instanceTypes[i.InstanceId]; ok {
+				instanceType = modified
+			}
 			inst := &ec2.Instance{
 				InstanceId:   &i.InstanceId,
-				InstanceType: &i.InstanceType,
+				InstanceType: &instanceType,
 				State: &ec2.InstanceState{
 					Name: &i.State.Name,
 				},
@@ -146,3 +154,29 @@ func (*mockEC2Session) DescribeSpotPriceHistory(*ec2.DescribeSpotPriceHistoryInp
 		SpotPriceHistory: nil,
 	}, nil
 }
+
+func (s *mockEC2Session) ModifyInstanceAttribute(input *ec2.ModifyInstanceAttributeInput) (*ec2.ModifyInstanceAttributeOutput, error) {
+	if input.InstanceType != nil {
+		if s.instanceTypes == nil {
+			s.instanceTypes = make(map[string]string)
+		}
+		s.instanceTypes[*input.InstanceId] = *input.InstanceType.Value
+	}
+	return &ec2.ModifyInstanceAttributeOutput{}, nil
+}
+
+func (*mockEC2Session) StartInstances(*ec2.StartInstancesInput) (*ec2.StartInstancesOutput, error) {
+	return &ec2.StartInstancesOutput{}, nil
+}
+
+func (*mockEC2Session) StopInstances(*ec2.StopInstancesInput) (*ec2.StopInstancesOutput, error) {
+	return &ec2.StopInstancesOutput{}, nil
+}
+
+func (*mockEC2Session) WaitUntilInstanceRunning(*ec2.DescribeInstancesInput) error {
+	return nil
+}
+
+func (*mockEC2Session) WaitUntilInstanceStopped(*ec2.DescribeInstancesInput) error {
+	return nil
+}
@@ -202,6 +202,8 @@ func (env *environ) newRawInstance(
 		Tags:              tags,
 		AvailabilityZone:  args.AvailabilityZone,
 		AllocatePublicIP:  allocatePublicIP,
+		// Instances with GPUs attached can't be live migrated.
+		TerminateOnMaintenance: spec.InstanceType.Gpus > 0,
 	})
 	if err != nil {
 		// We currently treat all AddInstance failures
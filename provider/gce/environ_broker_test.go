@@ -176,6 +176,23 @@ func (s *environBrokerSuite) TestNewRawInstanceNoPublicIP(c *gc.C) {
 	c.Assert(nics[0].AccessConfigs, gc.HasLen, 0)
 }
 
+func (s *environBrokerSuite) TestNewRawInstanceGPUsTerminateOnMaintenance(c *gc.C) {
+	s.FakeConn.Inst = s.BaseInstance
+	s.FakeCommon.AZInstances = []common.AvailabilityZoneInstances{{
+		ZoneName:  "home-zone",
+		Instances: []instance.Id{s.Instance.Id()},
+	}}
+	s.spec.InstanceType.Gpus = 1
+
+	_, err := gce.NewRawInstance(s.Env, s.CallCtx, s.StartInstArgs, s.spec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	calls := s.FakeConn.Calls
+	c.Assert(calls, gc.Not(gc.HasLen), 0)
+	c.Check(calls[len(calls)-1].FuncName, gc.Equals, "AddInstance")
+	c.Check(calls[len(calls)-1].InstanceSpec.TerminateOnMaintenance, jc.IsTrue)
+}
+
 func (s *environBrokerSuite) TestNewRawInstanceZoneInvalidCredentialError(c *gc.C) {
 	s.FakeConn.Err = gce.InvalidCredentialError
 	c.Assert(s.InvalidatedCredentials, jc.IsFalse)
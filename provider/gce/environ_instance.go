@@ -179,10 +179,17 @@ func (env *environ) parsePlacement(ctx context.ProviderCallContext, placement st
 // specify a recognized instance type.
 func checkInstanceType(cons constraints.Value) bool {
 	// Constraint has an instance-type constraint so let's see if it is valid.
+	_, ok := knownInstanceType(*cons.InstanceType)
+	return ok
+}
+
+// knownInstanceType returns the instance type with the given name from
+// allInstanceTypes, and whether there is one.
+func knownInstanceType(name string) (instances.InstanceType, bool) {
 	for _, itype := range allInstanceTypes {
-		if itype.Name == *cons.InstanceType {
-			return true
+		if itype.Name == name {
+			return itype, true
 		}
 	}
-	return false
+	return instances.InstanceType{}, false
 }
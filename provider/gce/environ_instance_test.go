@@ -216,6 +216,21 @@ func (s *environInstSuite) TestListMachineTypes(c *gc.C) {
 
 }
 
+func (s *environInstSuite) TestListMachineTypesGPUs(c *gc.C) {
+	zone := google.NewZone("a-zone", google.StatusUp, "", "")
+	s.FakeConn.Zones = []google.AvailabilityZone{zone}
+	s.FakeConn.MachineTypes = []google.MachineType{
+		{Name: "n1-standard-4", GuestCpus: 4, MemoryMb: 15360},
+		{Name: "g2-standard-4", GuestCpus: 4, MemoryMb: 16384},
+	}
+
+	types, err := s.Env.InstanceTypes(s.CallCtx, constraints.MustParse("gpus=1 gpu-type=nvidia"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(types.InstanceTypes, gc.HasLen, 1)
+	c.Check(types.InstanceTypes[0].Name, gc.Equals, "g2-standard-4")
+	c.Check(types.InstanceTypes[0].Gpus, gc.Equals, uint64(1))
+}
+
 func (s *environInstSuite) TestAdoptResources(c *gc.C) {
 	john := s.NewInstance(c, "john")
 	misty := s.NewInstance(c, "misty")
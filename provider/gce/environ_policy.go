@@ -43,6 +43,8 @@ var instanceTypeConstraints = []string{
 	constraints.Cores,
 	constraints.CpuPower,
 	constraints.Mem,
+	constraints.Gpus,
+	constraints.GpuType,
 	constraints.Container, // VirtType
 }
 
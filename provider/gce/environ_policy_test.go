@@ -4,7 +4,6 @@
 package gce_test
 
 import (
-	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -176,6 +175,15 @@ func (s *environPolSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 	c.Check(unsupported, jc.SameContents, []string{"tags", "virt-type"})
 }
 
+func (s *environPolSuite) TestConstraintsValidatorGPUs(c *gc.C) {
+	validator, err := s.Env.ConstraintsValidator(s.CallCtx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	unsupported, err := validator.Validate(constraints.MustParse("arch=amd64 gpus=1 gpu-type=nvidia"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(unsupported, gc.HasLen, 0)
+}
+
 func (s *environPolSuite) TestConstraintsValidatorVocabInstType(c *gc.C) {
 	validator, err := s.Env.ConstraintsValidator(s.CallCtx)
 	c.Assert(err, jc.ErrorIsNil)
@@ -203,7 +211,7 @@ func (s *environPolSuite) TestConstraintsValidatorConflicts(c *gc.C) {
 	cons := constraints.MustParse("instance-type=n1-standard-1")
 	// We do not check arch or container since there is only one valid
 	// value for each and will always match.
-	consFallback := constraints.MustParse("cores=2 cpu-power=1000 mem=10000 tags=bar")
+	consFallback := constraints.MustParse("cores=2 cpu-power=1000 mem=10000 gpus=1 tags=bar")
 	merged, err := validator.Merge(consFallback, cons)
 	c.Assert(err, jc.ErrorIsNil)
 
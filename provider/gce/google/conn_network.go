@@ -63,6 +63,13 @@ func (gce Connection) OpenPortsWithNamer(target string, namer FirewallNamer, rul
 	if len(rules) == 0 {
 		return nil
 	}
+	for _, rule := range rules {
+		// Google firewalls can only allow all ICMP traffic, so refuse
+		// rather than silently opening more than was asked for.
+		if rule.PortRange.IsICMPType() {
+			return errors.NotSupportedf("opening ICMP type %d", rule.PortRange.FromPort)
+		}
+	}
 
 	// First gather the current ingress rules.
 	currentRuleSet, err := gce.firewallRules(target)
@@ -171,6 +171,16 @@ func (s *connSuite) TestConnectionOpenPortsAdd(c *gc.C) {
 	})
 }
 
+func (s *connSuite) TestConnectionOpenPortsICMPTypeNotSupported(c *gc.C) {
+	rules := corefirewall.IngressRules{
+		corefirewall.NewIngressRule(network.MustParsePortRange("8/icmp")),
+	}
+	err := s.Conn.OpenPortsWithNamer("spam", google.HashSuffixNamer, rules)
+	c.Assert(err, gc.ErrorMatches, "opening ICMP type 8 not supported")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 0)
+}
+
 func (s *connSuite) TestConnectionOpenPortsUpdateSameCIDR(c *gc.C) {
 	s.FakeConn.Firewalls = []*compute.Firewall{{
 		Name:         "spam-ad7554",
@@ -56,6 +56,11 @@ type InstanceSpec struct {
 	// AllocatePublicIP is true if the instance should be assigned a public IP
 	// address, exposing it to access from outside the internal network.
 	AllocatePublicIP bool
+
+	// TerminateOnMaintenance is true if the instance should be stopped,
+	// rather than live migrated, when its host is under maintenance.
+	// GCE requires this of instances with GPUs attached.
+	TerminateOnMaintenance bool
 }
 
 func (is InstanceSpec) raw() *compute.Instance {
@@ -65,10 +70,18 @@ func (is InstanceSpec) raw() *compute.Instance {
 		NetworkInterfaces: is.networkInterfaces(),
 		Metadata:          packMetadata(is.Metadata),
 		Tags:              &compute.Tags{Items: is.Tags},
+		Scheduling:        is.scheduling(),
 		// MachineType is set in the addInstance call.
 	}
 }
 
+func (is InstanceSpec) scheduling() *compute.Scheduling {
+	if !is.TerminateOnMaintenance {
+		return nil
+	}
+	return &compute.Scheduling{OnHostMaintenance: "TERMINATE"}
+}
+
 // Summary builds an InstanceSummary based on the spec and returns it.
 func (is InstanceSpec) Summary() InstanceSummary {
 	raw := is.raw()
@@ -48,6 +48,12 @@ func (env *environ) InstanceTypes(ctx context.ProviderCallContext, c constraints
 				Arches:   []string{arch.AMD64},
 				VirtType: &virtType,
 			}
+			// Machine types listed by the API carry no GPU
+			// information, so it is taken from the known types.
+			if known, ok := knownInstanceType(m.Name); ok {
+				i.Gpus = known.Gpus
+				i.GpuType = known.GpuType
+			}
 			resultUnique[m.Name] = i
 		}
 	}
@@ -12,8 +12,14 @@ import (
 var (
 	vtype  = "kvm"
 	arches = []string{arch.AMD64}
+	nvidia = "nvidia"
 )
 
+// gpuCost is the cost of the machine types with attached GPUs. The
+// other machine types have no cost set, so GPU machine types are only
+// chosen when a GPU is asked for, or when no other type matches.
+const gpuCost = 1
+
 // Instance types are not associated with disks in GCE, so we do not
 // set RootDisk.
 
@@ -154,4 +160,138 @@ var allInstanceTypes = []instances.InstanceType{
 		Mem:      1700,
 		VirtType: &vtype,
 	},
+
+	{ // Accelerator-optimized machine types, with NVIDIA A100 GPUs.
+		Name:     "a2-highgpu-1g",
+		Arches:   arches,
+		CpuCores: 12,
+		CpuPower: instances.CpuPower(3300),
+		Mem:      85000,
+		Gpus:     1,
+		GpuType:  &nvidia,
+		Cost:     gpuCost,
+		VirtType: &vtype,
+	}, {
+		Name:     "a2-highgpu-2g",
+		Arches:   arches,
+		CpuCores: 24,
+		CpuPower: instances.CpuPower(6600),
+		Mem:      170000,
+		Gpus:     2,
+		GpuType:  &nvidia,
+		Cost:     gpuCost,
+		VirtType: &vtype,
+	}, {
+		Name:     "a2-highgpu-4g",
+		Arches:   arches,
+		CpuCores: 48,
+		CpuPower: instances.CpuPower(13200),
+		Mem:      340000,
+		Gpus:     4,
+		GpuType:  &nvidia,
+		Cost:     gpuCost,
+		VirtType: &vtype,
+	}, {
+		Name:     "a2-highgpu-8g",
+		Arches:   arches,
+		CpuCores: 96,
+		CpuPower: instances.CpuPower(26400),
+		Mem:      680000,
+		Gpus:     8,
+		GpuType:  &nvidia,
+		Cost:     gpuCost,
+		VirtType: &vtype,
+	}, {
+		Name:     "a2-megagpu-16g",
+		Arches:   arches,
+		CpuCores: 96,
+		CpuPower: instances.CpuPower(26400),
+		Mem:      1360000,
+		Gpus:     16,
+		GpuType:  &nvidia,
+		Cost:     gpuCost,
+		VirtType: &vtype,
+	},
+
+	{ // Accelerator-optimized machine types, with NVIDIA L4 GPUs.
+		Name:     "g2-standard-4",
+		Arches:   arches,
+		CpuCores: 4,
+		CpuPower: instances.CpuPower(1100),
+		Mem:      16000,
+		Gpus:     1,
+		GpuType:  &nvidia,
+		Cost:     gpuCost,
+		VirtType: &vtype,
+	}, {
+		Name:     "g2-standard-8",
+		Arches:   arches,
+		CpuCores: 8,
+		CpuPower: instances.CpuPower(2200),
+		Mem:      32000,
+		Gpus:     1,
+		GpuType:  &nvidia,
+		Cost:     gpuCost,
+		VirtType: &vtype,
+	}, {
+		Name:     "g2-standard-12",
+		Arches:   arches,
+		CpuCores: 12,
+		CpuPower: instances.CpuPower(3300),
+		Mem:      48000,
+		Gpus:     1,
+		GpuType:  &nvidia,
+		Cost:     gpuCost,
+		VirtType: &vtype,
+	}, {
+		Name:     "g2-standard-16",
+		Arches:   arches,
+		CpuCores: 16,
+		CpuPower: instances.CpuPower(4400),
+		Mem:      64000,
+		Gpus:     1,
+		GpuType:  &nvidia,
+		Cost:     gpuCost,
+		VirtType: &vtype,
+	}, {
+		Name:     "g2-standard-24",
+		Arches:   arches,
+		CpuCores: 24,
+		CpuPower: instances.CpuPower(6600),
+		Mem:      96000,
+		Gpus:     2,
+		GpuType:  &nvidia,
+		Cost:     gpuCost,
+		VirtType: &vtype,
+	}, {
+		Name:     "g2-standard-32",
+		Arches:   arches,
+		CpuCores: 32,
+		CpuPower: instances.CpuPower(8800),
+		Mem:      128000,
+		Gpus:     1,
+		GpuType:  &nvidia,
+		Cost:     gpuCost,
+		VirtType: &vtype,
+	}, {
+		Name:     "g2-standard-48",
+		Arches:   arches,
+		CpuCores: 48,
+		CpuPower: instances.CpuPower(13200),
+		Mem:      192000,
+		Gpus:     4,
+		GpuType:  &nvidia,
+		Cost:     gpuCost,
+		VirtType: &vtype,
+	}, {
+		Name:     "g2-standard-96",
+		Arches:   arches,
+		CpuCores: 96,
+		CpuPower: instances.CpuPower(26400),
+		Mem:      384000,
+		Gpus:     8,
+		GpuType:  &nvidia,
+		Cost:     gpuCost,
+		VirtType: &vtype,
+	},
 }
@@ -511,6 +511,8 @@ type fakeConn struct {
 	Subnets   []*compute.Subnetwork
 	Networks_ []*compute.Network
 
+	MachineTypes []google.MachineType
+
 	GoogleDisks   []*google.Disk
 	GoogleDisk    *google.Disk
 	AttachedDisk  *google.AttachedDisk
@@ -722,6 +724,9 @@ func (fc *fakeConn) ListMachineTypes(zone string) ([]google.MachineType, error)
 	}
 	fc.Calls = append(fc.Calls, call)
 
+	if fc.MachineTypes != nil {
+		return fc.MachineTypes, nil
+	}
 	return []google.MachineType{
 		{Name: "type-1", MemoryMb: 1024},
 		{Name: "type-2", MemoryMb: 2048},
@@ -4,6 +4,8 @@
 package lxd
 
 import (
+	"os"
+	"sort"
 	"strings"
 	"sync"
 
@@ -190,9 +192,43 @@ func (env *environ) Create(context.ProviderCallContext, environs.CreateParams) e
 // Bootstrap implements environs.Environ.
 func (env *environ) Bootstrap(ctx environs.BootstrapContext, callCtx context.ProviderCallContext, params environs.BootstrapParams) (*environs.BootstrapResult, error) {
 	ctx.Infof("%s", bootstrapMessage)
+	if err := env.importImages(ctx, params.LXDImagePaths); err != nil {
+		return nil, errors.Annotate(err, "adding LXD images")
+	}
 	return env.base.BootstrapEnv(ctx, callCtx, params)
 }
 
+// importImages adds the local LXD images, keyed by series/arch, to the
+// server's image cache, where they are found in preference to remote
+// images when starting the controller.
+func (env *environ) importImages(ctx environs.BootstrapContext, imagePaths map[string]string) error {
+	names := make([]string, 0, len(imagePaths))
+	for name := range imagePaths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		parts := strings.Split(name, "/")
+		if len(parts) != 2 {
+			return errors.NotValidf("LXD image name %q", name)
+		}
+		ctx.Infof("Adding LXD image %s", name)
+		if err := env.importImage(imagePaths[name], parts[0], parts[1]); err != nil {
+			return errors.Annotatef(err, "adding LXD image %s", name)
+		}
+	}
+	return nil
+}
+
+func (env *environ) importImage(imagePath, series, arch string) error {
+	f, err := os.Open(imagePath)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = f.Close() }()
+	return errors.Trace(env.server().ImportImage(f, series, arch))
+}
+
 // Destroy shuts down all known machines and destroys the rest of the
 // known environment.
 func (env *environ) Destroy(ctx context.ProviderCallContext) error {
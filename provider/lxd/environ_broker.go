@@ -210,7 +210,12 @@ func (env *environ) getContainerSpec(
 			return cSpec, errors.Trace(err)
 		}
 
-		cSpec.Devices = nics
+		if cSpec.Devices == nil {
+			cSpec.Devices = make(map[string]map[string]string)
+		}
+		for name, nic := range nics {
+			cSpec.Devices[name] = nic
+		}
 	}
 
 	userData, err := providerinit.ComposeUserData(args.InstanceConfig, cloudCfg, lxdRenderer{})
@@ -5,6 +5,8 @@ package lxd_test
 
 import (
 	"context"
+	"io/ioutil"
+	"path/filepath"
 
 	"github.com/golang/mock/gomock"
 	"github.com/juju/cmd/cmdtesting"
@@ -125,6 +127,36 @@ func (s *environSuite) TestBootstrapAPI(c *gc.C) {
 	}})
 }
 
+func (s *environSuite) TestBootstrapImportsImages(c *gc.C) {
+	imagePath := filepath.Join(c.MkDir(), "focal.tar.gz")
+	err := ioutil.WriteFile(imagePath, []byte("image"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := envtesting.BootstrapContext(c)
+	params := environs.BootstrapParams{
+		ControllerConfig:         coretesting.FakeControllerConfig(),
+		SupportedBootstrapSeries: coretesting.FakeSupportedJujuSeries,
+		LXDImagePaths:            map[string]string{"focal/amd64": imagePath},
+	}
+	_, err = s.Env.Bootstrap(ctx, s.callCtx, params)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Stub.CheckCallNames(c, "ImportImage", "Bootstrap")
+	s.Stub.CheckCall(c, 0, "ImportImage", "focal", "amd64")
+}
+
+func (s *environSuite) TestBootstrapImportImageError(c *gc.C) {
+	ctx := envtesting.BootstrapContext(c)
+	params := environs.BootstrapParams{
+		ControllerConfig:         coretesting.FakeControllerConfig(),
+		SupportedBootstrapSeries: coretesting.FakeSupportedJujuSeries,
+		LXDImagePaths:            map[string]string{"focal/amd64": filepath.Join(c.MkDir(), "missing")},
+	}
+	_, err := s.Env.Bootstrap(ctx, s.callCtx, params)
+	c.Assert(err, gc.ErrorMatches, "adding LXD images: adding LXD image focal/amd64: .*no such file or directory")
+	s.Stub.CheckNoCalls(c)
+}
+
 func (s *environSuite) TestDestroy(c *gc.C) {
 	s.Client.Volumes = map[string][]api.StorageVolume{
 		"juju": {{
@@ -12,6 +12,7 @@ import (
 	cloudspec "github.com/juju/juju/environs/cloudspec"
 	lxd1 "github.com/lxc/lxd/client"
 	api "github.com/lxc/lxd/shared/api"
+	io "io"
 	reflect "reflect"
 )
 
@@ -533,6 +534,20 @@ func (mr *MockServerMockRecorder) HostArch() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HostArch", reflect.TypeOf((*MockServer)(nil).HostArch))
 }
 
+// ImportImage mocks base method
+func (m *MockServer) ImportImage(arg0 io.Reader, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportImage", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportImage indicates an expected call of ImportImage
+func (mr *MockServerMockRecorder) ImportImage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportImage", reflect.TypeOf((*MockServer)(nil).ImportImage), arg0, arg1, arg2)
+}
+
 // IsClustered mocks base method
 func (m *MockServer) IsClustered() bool {
 	m.ctrl.T.Helper()
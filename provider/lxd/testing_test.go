@@ -4,6 +4,7 @@
 package lxd
 
 import (
+	"io"
 	"net"
 	"os"
 	"strconv"
@@ -465,6 +466,11 @@ func (conn *StubClient) FindImage(
 	return lxd.SourcedImage{}, nil
 }
 
+func (conn *StubClient) ImportImage(_ io.Reader, series, imageArch string) error {
+	conn.AddCall("ImportImage", series, imageArch)
+	return conn.NextErr()
+}
+
 func (conn *StubClient) CreateCertificate(cert api.CertificatesPost) error {
 	conn.AddCall("CreateCertificate", cert)
 	return conn.NextErr()
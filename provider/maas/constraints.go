@@ -20,6 +20,8 @@ var unsupportedConstraints = []string{
 	constraints.InstanceType,
 	constraints.VirtType,
 	constraints.AllocatePublicIP,
+	constraints.Gpus,
+	constraints.GpuType,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
@@ -114,6 +114,12 @@ func (e *manualEnviron) Bootstrap(ctx environs.BootstrapContext, callCtx context
 	if provisioned {
 		return nil, manual.ErrProvisioned
 	}
+	if args.AdoptExistingMachine {
+		ports := []int{args.ControllerConfig.APIPort(), args.ControllerConfig.StatePort()}
+		if err := sshprovisioner.CheckControllerSuitability(e.host, ports); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 	hw, series, err := e.seriesAndHardwareCharacteristics()
 	if err != nil {
 		return nil, err
@@ -328,6 +334,8 @@ var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.VirtType,
 	constraints.AllocatePublicIP,
+	constraints.Gpus,
+	constraints.GpuType,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/environs/manual/sshprovisioner"
+	envtesting "github.com/juju/juju/environs/testing"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -201,6 +202,57 @@ func (s *environSuite) TestPrecheck(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *environSuite) patchBootstrapChecks(c *gc.C, suitabilityErr error) *[]int {
+	s.PatchValue(&sshprovisioner.CheckProvisioned, func(host string) (bool, error) {
+		c.Check(host, gc.Equals, "hostname")
+		return false, nil
+	})
+	s.PatchValue(&sshprovisioner.DetectSeriesAndHardwareCharacteristics,
+		func(string) (instance.HardwareCharacteristics, string, error) {
+			amd64 := "amd64"
+			return instance.HardwareCharacteristics{
+				Arch: &amd64,
+			}, "focal", nil
+		},
+	)
+	var checkedPorts []int
+	s.PatchValue(&sshprovisioner.CheckControllerSuitability, func(host string, ports []int) error {
+		c.Check(host, gc.Equals, "hostname")
+		checkedPorts = ports
+		return suitabilityErr
+	})
+	return &checkedPorts
+}
+
+func (s *environSuite) TestBootstrapAdoptExistingMachine(c *gc.C) {
+	checkedPorts := s.patchBootstrapChecks(c, nil)
+	result, err := s.env.Bootstrap(envtesting.BootstrapContext(c), s.callCtx, environs.BootstrapParams{
+		ControllerConfig:     coretesting.FakeControllerConfig(),
+		AdoptExistingMachine: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Series, gc.Equals, "focal")
+	c.Assert(*checkedPorts, jc.DeepEquals, []int{17777, 1234})
+}
+
+func (s *environSuite) TestBootstrapAdoptExistingMachineUnsuitable(c *gc.C) {
+	s.patchBootstrapChecks(c, errors.New("hostname is not suitable for running a controller"))
+	_, err := s.env.Bootstrap(envtesting.BootstrapContext(c), s.callCtx, environs.BootstrapParams{
+		ControllerConfig:     coretesting.FakeControllerConfig(),
+		AdoptExistingMachine: true,
+	})
+	c.Assert(err, gc.ErrorMatches, "hostname is not suitable for running a controller")
+}
+
+func (s *environSuite) TestBootstrapWithoutAdoptSkipsSuitabilityCheck(c *gc.C) {
+	checkedPorts := s.patchBootstrapChecks(c, errors.New("unexpected check"))
+	_, err := s.env.Bootstrap(envtesting.BootstrapContext(c), s.callCtx, environs.BootstrapParams{
+		ControllerConfig: coretesting.FakeControllerConfig(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*checkedPorts, gc.IsNil)
+}
+
 type controllerInstancesSuite struct {
 	baseEnvironSuite
 }
@@ -885,6 +885,14 @@ func (c *neutronFirewaller) ingressRulesInGroup(ctx context.ProviderCallContext,
 		if p.PortRangeMax != nil {
 			portRange.ToPort = *p.PortRangeMax
 		}
+		if portRange.Protocol == "icmp" {
+			// For ICMP the minimum holds the message type and the
+			// maximum the code, see rulesToRuleInfo.
+			if p.PortRangeMin == nil {
+				portRange.FromPort = -1
+			}
+			portRange.ToPort = portRange.FromPort
+		}
 		// Record the RemoteIPPrefix for the port range.
 		remotePrefix := p.RemoteIPPrefix
 		if remotePrefix == "" {
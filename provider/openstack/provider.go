@@ -574,6 +574,8 @@ func (e *Environ) neutron() *neutron.Client {
 var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.CpuPower,
+	constraints.Gpus,
+	constraints.GpuType,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
@@ -2061,6 +2063,12 @@ func rulesToRuleInfo(groupId string, rules firewall.IngressRules) []neutron.Rule
 			PortRangeMax:  r.PortRange.ToPort,
 			IPProtocol:    r.PortRange.Protocol,
 		}
+		if r.PortRange.IsICMPType() {
+			// Neutron matches the ICMP type against the minimum and
+			// the code against the maximum; leave the code unset so
+			// that any code for the type is allowed.
+			ruleInfo.PortRangeMax = 0
+		}
 		sourceCIDRs := r.SourceCIDRs.Values()
 		if len(sourceCIDRs) == 0 {
 			sourceCIDRs = append(sourceCIDRs, firewall.AllNetworksIPV4CIDR)
@@ -292,6 +292,17 @@ func (*localTests) TestPortsToRuleInfo(c *gc.C) {
 			ParentGroupId:  groupId,
 			EthernetType:   "IPv6",
 		}},
+	}, {
+		about: "icmp type",
+		rules: firewall.IngressRules{firewall.NewIngressRule(network.MustParsePortRange("8/icmp"))},
+		expected: []neutron.RuleInfoV2{{
+			Direction:      "ingress",
+			IPProtocol:     "icmp",
+			PortRangeMin:   8,
+			RemoteIPPrefix: "0.0.0.0/0",
+			ParentGroupId:  groupId,
+			EthernetType:   "IPv4",
+		}},
 	}}
 
 	for i, t := range testCases {
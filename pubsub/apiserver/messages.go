@@ -59,6 +59,11 @@ const ConnectTopic = "apiserver.agent-connect"
 // data: `APIConnection`
 const DisconnectTopic = "apiserver.agent-disconnect"
 
+// ActivityTopic is the topic name for the published message whenever
+// a connected agent pings the API server.
+// data: `APIConnection`
+const ActivityTopic = "apiserver.agent-activity"
+
 // APIConnection holds all the salient pieces of information that are
 // available when an agent connects to the API server.
 type APIConnection struct {
@@ -68,6 +73,7 @@ type APIConnection struct {
 	ConnectionID    uint64 `yaml:"connection-id"`
 	Origin          string `yaml:"origin"`
 	UserData        string `yaml:"user-data,omitempty"`
+	RemoteAddress   string `yaml:"remote-address,omitempty"`
 }
 
 // PresenceRequestTopic is used by the presence worker to ask another HA server
@@ -98,3 +104,19 @@ const RestartTopic = "apiserver.restart"
 // Restart message only contains the local-only indicator as the restart
 // is only ever for the same agent.
 type Restart common.LocalOnly
+
+// DrainTopic is used by the API server to listen for requests to drain
+// its connections ahead of a restart. A draining API server refuses new
+// connections, so that agents reconnect to another HA controller, and
+// closes each existing connection once its outstanding requests have
+// completed.
+const DrainTopic = "apiserver.drain"
+
+// Drain identifies the API server to drain. A local-only drain with
+// no machine ID is for the same agent; otherwise the message is
+// forwarded to all controllers and only the one running on the
+// identified machine drains.
+type Drain struct {
+	MachineID string `yaml:"machine-id,omitempty"`
+	LocalOnly bool   `yaml:"local-only"`
+}
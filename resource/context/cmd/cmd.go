@@ -9,4 +9,7 @@ type HookContext interface {
 	// Download downloads the named resource and returns
 	// the path to which it was downloaded.
 	Download(name string) (filePath string, _ error)
+
+	// Share shares the named resource with the subordinate application.
+	Share(name, applicationName string) error
 }
@@ -0,0 +1,98 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cmd
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// ShareCmdName is the name of the resource-share command.
+const ShareCmdName = "resource-share"
+
+// NewShareCmd creates a new ShareCmd for the given hook context.
+func NewShareCmd(c jujuc.ContextComponent) (*ShareCmd, error) {
+	return &ShareCmd{
+		compContext: c,
+	}, nil
+}
+
+// ShareCmd provides the functionality of the resource-share command.
+type ShareCmd struct {
+	cmd.CommandBase
+
+	compContext     jujuc.ContextComponent
+	resourceName    string
+	applicationName string
+}
+
+// Info implements cmd.Command.
+func (c ShareCmd) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    ShareCmdName,
+		Args:    "<resource name> <subordinate application>",
+		Purpose: "share a resource with a subordinate application",
+		Doc: `
+"resource-share" is used while a hook is running to share one of the
+unit's application resources with a subordinate application related to
+it. Once shared, the subordinate's units get the resource with
+"resource-get" in the usual way, without it having to be uploaded to
+the subordinate application as well.
+
+The subordinate's charm must define a file resource with the same name,
+and the subordinate application must be related to the unit's
+application with a container-scoped relation. The resource must have
+been uploaded to the unit's application.
+
+A shared resource always has the content of the application's resource,
+so it does not need to be shared again when that resource changes. The
+subordinate's "upgrade-charm" hook fires whenever the resource is shared
+and whenever the shared content changes.
+
+Sharing is replaced by any resource later uploaded to the subordinate
+application itself.
+
+Examples:
+
+    resource-share plugin-binary telegraf
+`,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *ShareCmd) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.Errorf("missing required resource name")
+	} else if len(args) < 2 {
+		return errors.Errorf("missing required application name")
+	} else if err := cmd.CheckEmpty(args[2:]); err != nil {
+		return errors.Trace(err)
+	}
+	if !names.IsValidApplication(args[1]) {
+		return errors.NotValidf("application name %q", args[1])
+	}
+	c.resourceName = args[0]
+	c.applicationName = args[1]
+	return nil
+}
+
+// Run implements cmd.Command.
+func (c ShareCmd) Run(ctx *cmd.Context) error {
+	hookContext, ok := c.compContext.(sharer)
+	if !ok {
+		return errors.Errorf("invalid component context")
+	}
+	if err := hookContext.Share(c.resourceName, c.applicationName); err != nil {
+		return errors.Annotate(err, "could not share resource")
+	}
+	return nil
+}
+
+type sharer interface {
+	Share(name, applicationName string) error
+}
@@ -0,0 +1,93 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+var _ = gc.Suite(&ShareCmdSuite{})
+
+type ShareCmdSuite struct {
+	testing.IsolationSuite
+
+	stub *testing.Stub
+	hctx *stubHookContext
+}
+
+func (s *ShareCmdSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.stub = &testing.Stub{}
+	s.hctx = &stubHookContext{stub: s.stub}
+}
+
+func (s *ShareCmdSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "missing required resource name",
+	}, {
+		args: []string{"spam"},
+		err:  "missing required application name",
+	}, {
+		args: []string{"spam", "eggs", "ham"},
+		err:  `unrecognized args: \["ham"\]`,
+	}, {
+		args: []string{"spam", "Bad_App"},
+		err:  `application name "Bad_App" not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		shareCmd := ShareCmd{}
+		err := shareCmd.Init(test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ShareCmdSuite) TestInit(c *gc.C) {
+	shareCmd := ShareCmd{}
+
+	err := shareCmd.Init([]string{"spam", "eggs"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(shareCmd.resourceName, gc.Equals, "spam")
+	c.Check(shareCmd.applicationName, gc.Equals, "eggs")
+}
+
+func (s *ShareCmdSuite) TestRunOkay(c *gc.C) {
+	shareCmd := ShareCmd{
+		compContext:     s.hctx,
+		resourceName:    "spam",
+		applicationName: "eggs",
+	}
+	ctx := cmdtesting.Context(c)
+
+	err := shareCmd.Run(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCalls(c, []testing.StubCall{{"Share", []interface{}{"spam", "eggs"}}})
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+}
+
+func (s *ShareCmdSuite) TestRunShareFailure(c *gc.C) {
+	shareCmd := ShareCmd{
+		compContext:     s.hctx,
+		resourceName:    "spam",
+		applicationName: "eggs",
+	}
+	failure := errors.New("<failure>")
+	s.stub.SetErrors(failure)
+
+	err := shareCmd.Run(cmdtesting.Context(c))
+
+	s.stub.CheckCallNames(c, "Share")
+	c.Check(errors.Cause(err), gc.Equals, failure)
+	c.Check(err, gc.ErrorMatches, "could not share resource: <failure>")
+}
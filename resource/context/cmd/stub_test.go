@@ -24,3 +24,8 @@ func (s *stubHookContext) Download(name string) (string, error) {
 }
 
 func (s *stubHookContext) Flush() error { return nil }
+
+func (s *stubHookContext) Share(name, applicationName string) error {
+	s.stub.AddCall("Share", name, applicationName)
+	return s.stub.NextErr()
+}
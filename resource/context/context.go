@@ -27,6 +27,10 @@ type APIClient interface {
 	// GetResource returns the resource info and content for the given
 	// name (and unit-implied application).
 	GetResource(resourceName string) (resource.Resource, io.ReadCloser, error)
+
+	// ShareResource shares the named resource of the unit-implied
+	// application with the subordinate application.
+	ShareResource(resourceName, applicationName string) error
 }
 
 // Content is the resources portion of a uniter hook context.
@@ -71,6 +75,12 @@ func (c *Context) Download(name string) (string, error) {
 	return path, nil
 }
 
+// Share shares the named resource with a related subordinate
+// application, so that the subordinate's units can download it too.
+func (c *Context) Share(name, applicationName string) error {
+	return errors.Trace(c.apiClient.ShareResource(name, applicationName))
+}
+
 // contextDeps implements all the external dependencies
 // of ContextDownload().
 type contextDeps struct {
@@ -503,6 +503,7 @@ func (st *State) machineDocForTemplate(template MachineTemplate, id string) *mac
 		DocID:                   st.docID(id),
 		Id:                      id,
 		ModelUUID:               st.ModelUUID(),
+		UUID:                    newEntityUUID(),
 		Series:                  template.Series,
 		Jobs:                    template.Jobs,
 		Clean:                   !template.Dirty,
@@ -104,7 +104,7 @@ type apiHostPortsDoc struct {
 // SetAPIHostPorts sets the addresses, if changed, of two collections:
 // - The list of *all* addresses at which the API is accessible.
 // - The list of addresses at which the API can be accessed by agents according
-//   to the controller management space configuration.
+//   to the controller management space and address advertisement configuration.
 // Each server is represented by one element in the top level slice.
 func (st *State) SetAPIHostPorts(newHostPorts []network.SpaceHostPorts) error {
 	controllers, closer := st.db().GetCollection(controllersC)
@@ -116,7 +116,7 @@ func (st *State) SetAPIHostPorts(newHostPorts []network.SpaceHostPorts) error {
 			return nil, errors.Trace(err)
 		}
 
-		newHostPortsForAgents, err := st.filterHostPortsForManagementSpace(newHostPorts)
+		newHostPortsForAgents, err := st.filterHostPortsForAgents(newHostPorts)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -179,12 +179,13 @@ func (st *State) getOpsForHostPortsChange(
 	return ops, nil
 }
 
-// filterHostPortsForManagementSpace filters the collection of API addresses
-// based on the configured management space for the controller.
+// filterHostPortsForAgents filters the collection of API addresses
+// based on the configured management space for the controller, and then
+// on the configured address advertisement CIDRs and scopes.
 // If there is no space configured, or if one of the slices is filtered down
 // to zero elements, just use the unfiltered slice for safety - we do not
 // want to cut off communication to the controller based on erroneous config.
-func (st *State) filterHostPortsForManagementSpace(
+func (st *State) filterHostPortsForAgents(
 	apiHostPorts []network.SpaceHostPorts,
 ) ([]network.SpaceHostPorts, error) {
 	config, err := st.ControllerConfig()
@@ -215,7 +216,7 @@ func (st *State) filterHostPortsForManagementSpace(
 		hostPortsForAgents = apiHostPorts
 	}
 
-	return hostPortsForAgents, nil
+	return config.FilterAdvertisedHostPorts(hostPortsForAgents), nil
 }
 
 // APIHostPortsForClients returns the collection of *all* known API addresses.
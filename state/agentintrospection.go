@@ -0,0 +1,179 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// introspectionRequestMarker separates the agent tag from the sequence
+// number in the id of an introspection request.
+const introspectionRequestMarker = "#"
+
+// IntrospectionRequest is a request, made through the API, for an
+// agent to report on itself by querying its introspection socket. The
+// agent records the result against the request, where it can be read
+// back by the requesting client.
+type IntrospectionRequest struct {
+	st  *State
+	doc introspectionRequestDoc
+}
+
+type introspectionRequestDoc struct {
+	DocID     string    `bson:"_id"`
+	Agent     string    `bson:"agent"`
+	Path      string    `bson:"path"`
+	Requested time.Time `bson:"requested"`
+	Completed bool      `bson:"completed"`
+	Output    []byte    `bson:"output,omitempty"`
+	Message   string    `bson:"message,omitempty"`
+}
+
+// Id returns the id of the request.
+func (r *IntrospectionRequest) Id() string {
+	return r.st.localID(r.doc.DocID)
+}
+
+// Agent returns the tag of the agent the request is for.
+func (r *IntrospectionRequest) Agent() names.Tag {
+	tag, err := names.ParseTag(r.doc.Agent)
+	if err != nil {
+		// The tag was valid when the request was added.
+		panic(err)
+	}
+	return tag
+}
+
+// Path returns the path, and any query, to request from the agent's
+// introspection socket.
+func (r *IntrospectionRequest) Path() string {
+	return r.doc.Path
+}
+
+// Requested returns when the request was made.
+func (r *IntrospectionRequest) Requested() time.Time {
+	return r.doc.Requested.UTC()
+}
+
+// Completed returns whether the agent has recorded a result.
+func (r *IntrospectionRequest) Completed() bool {
+	return r.doc.Completed
+}
+
+// Output returns the output recorded by the agent.
+func (r *IntrospectionRequest) Output() []byte {
+	return r.doc.Output
+}
+
+// Message returns the error message recorded by the agent if it could
+// not query its introspection socket.
+func (r *IntrospectionRequest) Message() string {
+	return r.doc.Message
+}
+
+// AddIntrospectionRequest records a request for the agent to report on
+// the given introspection path.
+func (st *State) AddIntrospectionRequest(agent names.Tag, path string) (*IntrospectionRequest, error) {
+	seq, err := sequence(st, "introspection")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	id := fmt.Sprintf("%s%s%d", agent.String(), introspectionRequestMarker, seq)
+	doc := introspectionRequestDoc{
+		DocID:     st.docID(id),
+		Agent:     agent.String(),
+		Path:      path,
+		Requested: st.clock().Now().UTC(),
+	}
+	ops := []txn.Op{{
+		C:      introspectionRequestsC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := st.db().RunTransaction(ops); err != nil {
+		return nil, errors.Annotatef(err, "cannot add introspection request for %s", names.ReadableString(agent))
+	}
+	return &IntrospectionRequest{st: st, doc: doc}, nil
+}
+
+// IntrospectionRequest returns the introspection request with the
+// given id.
+func (st *State) IntrospectionRequest(id string) (*IntrospectionRequest, error) {
+	requests, closer := st.db().GetCollection(introspectionRequestsC)
+	defer closer()
+
+	var doc introspectionRequestDoc
+	if err := requests.FindId(id).One(&doc); err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("introspection request %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get introspection request %q", id)
+	}
+	return &IntrospectionRequest{st: st, doc: doc}, nil
+}
+
+// Complete records the result of the request, or the reason the agent
+// could not query its introspection socket. A request can only be
+// completed once.
+func (r *IntrospectionRequest) Complete(output []byte, message string) error {
+	ops := []txn.Op{{
+		C:      introspectionRequestsC,
+		Id:     r.doc.DocID,
+		Assert: bson.D{{"completed", false}},
+		Update: bson.D{{"$set", bson.D{
+			{"completed", true},
+			{"output", output},
+			{"message", message},
+		}}},
+	}}
+	err := r.st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		if _, err := r.st.IntrospectionRequest(r.Id()); err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Errorf("introspection request %q already completed", r.Id())
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot complete introspection request %q", r.Id())
+	}
+	r.doc.Completed = true
+	r.doc.Output = output
+	r.doc.Message = message
+	return nil
+}
+
+// Remove removes the request. It is not an error to remove a request
+// which has already been removed.
+func (r *IntrospectionRequest) Remove() error {
+	ops := []txn.Op{{
+		C:      introspectionRequestsC,
+		Id:     r.doc.DocID,
+		Remove: true,
+	}}
+	return errors.Annotatef(r.st.db().RunTransaction(ops), "cannot remove introspection request %q", r.Id())
+}
+
+// WatchIntrospectionRequests returns a watcher which reports the ids
+// of the introspection requests for the agent as they are added,
+// completed and removed.
+func (st *State) WatchIntrospectionRequests(agent names.Tag) StringsWatcher {
+	prefix := agent.String() + introspectionRequestMarker
+	return newCollectionWatcher(st, colWCfg{
+		col: introspectionRequestsC,
+		filter: func(id interface{}) bool {
+			localID, err := st.strictLocalID(id.(string))
+			if err != nil {
+				return false
+			}
+			return strings.HasPrefix(localID, prefix)
+		},
+	})
+}
@@ -0,0 +1,104 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type IntrospectionRequestSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&IntrospectionRequestSuite{})
+
+func (s *IntrospectionRequestSuite) TestAddIntrospectionRequest(c *gc.C) {
+	req, err := s.State.AddIntrospectionRequest(names.NewMachineTag("0"), "/depengine")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(req.Id(), gc.Equals, "machine-0#0")
+	c.Assert(req.Agent(), gc.Equals, names.NewMachineTag("0"))
+	c.Assert(req.Path(), gc.Equals, "/depengine")
+	c.Assert(req.Completed(), jc.IsFalse)
+
+	req2, err := s.State.AddIntrospectionRequest(names.NewMachineTag("0"), "/depengine")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(req2.Id(), gc.Equals, "machine-0#1")
+
+	got, err := s.State.IntrospectionRequest(req.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got.Agent(), gc.Equals, names.NewMachineTag("0"))
+	c.Assert(got.Path(), gc.Equals, "/depengine")
+	c.Assert(got.Requested(), gc.Equals, req.Requested())
+}
+
+func (s *IntrospectionRequestSuite) TestIntrospectionRequestNotFound(c *gc.C) {
+	_, err := s.State.IntrospectionRequest("machine-0#7")
+	c.Assert(err, gc.ErrorMatches, `introspection request "machine-0#7" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *IntrospectionRequestSuite) TestComplete(c *gc.C) {
+	req, err := s.State.AddIntrospectionRequest(names.NewMachineTag("0"), "/debug/pprof/goroutine?debug=1")
+	c.Assert(err, jc.ErrorIsNil)
+	err = req.Complete([]byte("goroutine profile: total 3\n"), "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	got, err := s.State.IntrospectionRequest(req.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got.Completed(), jc.IsTrue)
+	c.Assert(string(got.Output()), gc.Equals, "goroutine profile: total 3\n")
+	c.Assert(got.Message(), gc.Equals, "")
+
+	err = got.Complete(nil, "boom")
+	c.Assert(err, gc.ErrorMatches, `introspection request "machine-0#0" already completed`)
+}
+
+func (s *IntrospectionRequestSuite) TestRemove(c *gc.C) {
+	req, err := s.State.AddIntrospectionRequest(names.NewMachineTag("0"), "/depengine")
+	c.Assert(err, jc.ErrorIsNil)
+	err = req.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.IntrospectionRequest(req.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing it again is fine.
+	err = req.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = req.Complete(nil, "")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *IntrospectionRequestSuite) TestWatchIntrospectionRequests(c *gc.C) {
+	existing, err := s.State.AddIntrospectionRequest(names.NewMachineTag("0"), "/depengine")
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.State.WatchIntrospectionRequests(names.NewMachineTag("0"))
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange(existing.Id())
+	wc.AssertNoChange()
+
+	req, err := s.State.AddIntrospectionRequest(names.NewMachineTag("0"), "/statepool")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(req.Id())
+	wc.AssertNoChange()
+
+	// Requests for other agents are not reported.
+	_, err = s.State.AddIntrospectionRequest(names.NewMachineTag("1"), "/depengine")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddIntrospectionRequest(names.NewMachineTag("0/lxd/0"), "/depengine")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	err = req.Complete([]byte("ok"), "")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(req.Id())
+	wc.AssertNoChange()
+}
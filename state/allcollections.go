@@ -97,6 +97,11 @@ func allCollections() CollectionSchema {
 		// upgrades and schema migrations.
 		upgradeInfoC: {global: true},
 
+		// This collection records the progress of the database upgrade
+		// steps, so that an interrupted upgrade can resume where it
+		// left off.
+		upgradeStepCheckpointsC: {global: true},
+
 		// This collection holds a convenient representation of the content of
 		// the simplestreams data source pointing to binaries required by juju.
 		//
@@ -533,6 +538,36 @@ func allCollections() CollectionSchema {
 		// eg addresses.
 		cloudServicesC: {},
 
+		// modelSpecsC holds the declarative spec of a model, set with
+		// juju apply, and the status of reconciling the model towards it.
+		modelSpecsC: {},
+
+		// introspectionRequestsC holds requests for agents to report on
+		// themselves through their introspection socket, and the
+		// results they report.
+		introspectionRequestsC: {},
+
+		// engineHealthC holds a summary of the unhealthy workers in
+		// each machine agent's dependency engine, as last reported by
+		// the agent.
+		engineHealthC: {},
+
+		// scopedCredentialsC holds the credentials issued by the cloud
+		// provider for the use of individual trusted applications.
+		scopedCredentialsC: {},
+
+		// secretConfigC holds the sealed values of the charm config
+		// options each application declares as secret.
+		secretConfigC: {},
+
+		// secretConfigAccessC records the units reading the values of
+		// secret charm config options, for audit.
+		secretConfigAccessC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "application", "time"},
+			}},
+		},
+
 		// ----------------------
 
 		// Raw-access collections
@@ -579,6 +614,7 @@ const (
 	guimetadataC               = "guimetadata"
 	guisettingsC               = "guisettings"
 	instanceDataC              = "instanceData"
+	introspectionRequestsC     = "introspectionrequests"
 	leaseHoldersC              = "leaseholders"
 	machinesC                  = "machines"
 	machineRemovalsC           = "machineremovals"
@@ -595,6 +631,7 @@ const (
 	modelUsersC                = "modelusers"
 	modelsC                    = "models"
 	modelEntityRefsC           = "modelEntityRefs"
+	modelSpecsC                = "modelspecs"
 	openedPortsC               = "openedPorts"
 	operationsC                = "operations"
 	payloadsC                  = "payloads"
@@ -608,6 +645,10 @@ const (
 	sequenceC                  = "sequence"
 	applicationsC              = "applications"
 	endpointBindingsC          = "endpointbindings"
+	engineHealthC              = "enginehealth"
+	scopedCredentialsC         = "scopedcredentials"
+	secretConfigC              = "secretconfig"
+	secretConfigAccessC        = "secretconfigaccess"
 	settingsC                  = "settings"
 	generationsC               = "generations"
 	refcountsC                 = "refcounts"
@@ -628,6 +669,7 @@ const (
 	unitsC                     = "units"
 	unitStatesC                = "unitstates"
 	upgradeInfoC               = "upgradeInfo"
+	upgradeStepCheckpointsC    = "upgradeStepCheckpoints"
 	userLastLoginC             = "userLastLogin"
 	usermodelnameC             = "usermodelname"
 	usersC                     = "users"
@@ -96,6 +96,62 @@ func (m *Model) Annotation(entity GlobalEntity, key string) (string, error) {
 	return ann[key], nil
 }
 
+// AllAnnotations returns the annotations of all the entities in the
+// model which have any, keyed by the entities' tags.
+func (m *Model) AllAnnotations() (map[names.Tag]map[string]string, error) {
+	annotations, closer := m.st.db().GetCollection(annotationsC)
+	defer closer()
+
+	var docs []annotatorDoc
+	if err := annotations.Find(nil).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[names.Tag]map[string]string)
+	for _, doc := range docs {
+		if len(doc.Annotations) == 0 {
+			continue
+		}
+		tag, ok := annotationTagForGlobalKey(m.UUID(), doc.GlobalKey)
+		if !ok {
+			continue
+		}
+		result[tag] = doc.Annotations
+	}
+	return result, nil
+}
+
+// annotationTagForGlobalKey returns the tag of the entity with the given
+// global key, as used for the ids of annotation documents.
+func annotationTagForGlobalKey(modelUUID, key string) (names.Tag, bool) {
+	if key == modelGlobalKey {
+		return names.NewModelTag(modelUUID), true
+	}
+	if len(key) < 3 || key[1] != '#' {
+		return nil, false
+	}
+	id := key[2:]
+	switch key[0] {
+	case 'm':
+		if names.IsValidMachine(id) {
+			return names.NewMachineTag(id), true
+		}
+	case 'a':
+		if names.IsValidApplication(id) {
+			return names.NewApplicationTag(id), true
+		}
+	case 'u':
+		id = strings.TrimSuffix(id, "#charm")
+		if names.IsValidUnit(id) {
+			return names.NewUnitTag(id), true
+		}
+	case 'c':
+		if names.IsValidCharm(id) {
+			return names.NewCharmTag(id), true
+		}
+	}
+	return nil, false
+}
+
 // insertAnnotationsOps returns the operations required to insert annotations in MongoDB.
 func insertAnnotationsOps(st *State, entity GlobalEntity, toInsert map[string]string) ([]txn.Op, error) {
 	tag := entity.Tag()
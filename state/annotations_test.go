@@ -11,8 +11,10 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 type AnnotationsSuite struct {
@@ -134,6 +136,61 @@ func (s *AnnotationsSuite) TestSetAnnotationsConcurrently(c *gc.C) {
 	assertAnnotation(c, s.Model, s.testEntity, key, last)
 }
 
+func (s *AnnotationsSuite) TestWatchAnnotations(c *gc.C) {
+	s.createTestAnnotation(c)
+	w := s.Model.WatchAnnotations()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent(s.testEntity.Tag().String())
+	wc.AssertNoChange()
+
+	// Updating existing annotations is reported.
+	s.assertSetAnnotation(c, "testkey", "fixed")
+	wc.AssertChangeInSingleEvent(s.testEntity.Tag().String())
+	wc.AssertNoChange()
+
+	// As are annotations on other entities.
+	app := s.Factory.MakeApplication(c, nil)
+	err := s.Model.SetAnnotations(app, map[string]string{"key": "value"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(app.Tag().String())
+
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	err = s.Model.SetAnnotations(unit, map[string]string{"key": "value"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(unit.Tag().String())
+
+	err = s.Model.SetAnnotations(s.Model, map[string]string{"key": "value"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(s.Model.Tag().String())
+	wc.AssertNoChange()
+}
+
+func (s *AnnotationsSuite) TestAllAnnotations(c *gc.C) {
+	all, err := s.Model.AllAnnotations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 0)
+
+	s.assertSetAnnotation(c, "env", "prod")
+	app := s.Factory.MakeApplication(c, nil)
+	err = s.Model.SetAnnotations(app, map[string]string{"env": "staging", "tier": "web"})
+	c.Assert(err, jc.ErrorIsNil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	err = s.Model.SetAnnotations(unit, map[string]string{"env": "staging"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Entities whose annotations have all been removed are not included.
+	err = s.Model.SetAnnotations(unit, map[string]string{"env": ""})
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err = s.Model.AllAnnotations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, jc.DeepEquals, map[names.Tag]map[string]string{
+		s.testEntity.Tag(): {"env": "prod"},
+		app.Tag():          {"env": "staging", "tier": "web"},
+	})
+}
+
 type AnnotationsModelSuite struct {
 	ConnSuite
 }
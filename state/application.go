@@ -77,6 +77,7 @@ type applicationDoc struct {
 	DocID       string `bson:"_id"`
 	Name        string `bson:"name"`
 	ModelUUID   string `bson:"model-uuid"`
+	UUID        string `bson:"uuid,omitempty"`
 	Series      string `bson:"series"`
 	Subordinate bool   `bson:"subordinate"`
 	// CharmURL and channel should be moved to CharmOrigin. Attempting it should
@@ -104,6 +105,10 @@ type applicationDoc struct {
 	// represents all application endpoints.
 	ExposedEndpoints map[string]ExposedEndpoint `bson:"exposed-endpoints,omitempty"`
 
+	// RelationSchemas holds the schemas registered by the charm for
+	// the relation data it receives, keyed by endpoint name.
+	RelationSchemas map[string]RelationSchema `bson:"relation-schemas,omitempty"`
+
 	// CAAS related attributes.
 	DesiredScale int    `bson:"scale"`
 	PasswordHash string `bson:"passwordhash"`
@@ -133,6 +138,11 @@ func (a *Application) Name() string {
 	return a.doc.Name
 }
 
+// UUID returns the application's UUID, which never changes.
+func (a *Application) UUID() string {
+	return a.doc.UUID
+}
+
 // Tag returns a name identifying the application.
 // The returned name will be different from other Tag values returned by any
 // other entities from the same state.
@@ -672,6 +682,8 @@ func (a *Application) removeOps(asserts bson.D, op *ForcedOperation) ([]txn.Op,
 		removeSettingsOp(settingsC, a.applicationConfigKey()),
 		removeModelApplicationRefOp(a.st, name),
 		removePodSpecOp(a.ApplicationTag()),
+		removeScopedCredentialOp(a.st, globalKey),
+		removeSecretConfigOp(a.st, globalKey),
 	)
 	return ops, nil
 }
@@ -2169,6 +2181,7 @@ func (a *Application) addUnitOpsWithCons(args applicationAddUnitOpsArgs) (string
 	udoc := &unitDoc{
 		DocID:                  docID,
 		Name:                   name,
+		UUID:                   newEntityUUID(),
 		Application:            a.doc.Name,
 		Series:                 a.doc.Series,
 		Life:                   Alive,
@@ -2432,19 +2445,27 @@ type AddUnitParams struct {
 // AddUnit adds a new principal unit to the application.
 func (a *Application) AddUnit(args AddUnitParams) (unit *Unit, err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot add unit to application %q", a)
-	name, ops, err := a.addUnitOps("", args, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if err := a.st.db().RunTransaction(ops); err == txn.ErrAborted {
-		if alive, err := isAlive(a.st, applicationsC, a.doc.DocID); err != nil {
+	var name string
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if alive, err := isAlive(a.st, applicationsC, a.doc.DocID); err != nil {
+				return nil, err
+			} else if !alive {
+				return nil, applicationNotAliveErr
+			}
+		}
+		quotaOps, err := a.st.unitQuotaAssertOps(1)
+		if err != nil {
 			return nil, err
-		} else if !alive {
-			return nil, applicationNotAliveErr
 		}
-		return nil, errors.New("inconsistent state")
-	} else if err != nil {
+		var ops []txn.Op
+		name, ops, err = a.addUnitOps("", args, nil)
+		if err != nil {
+			return nil, err
+		}
+		return append(quotaOps, ops...), nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
 		return nil, err
 	}
 	return a.st.Unit(name)
@@ -2686,6 +2707,24 @@ func (a *Application) UpdateCharmConfig(branchName string, changes charm.Setting
 		return errors.Trace(err)
 	}
 
+	// The values of secret options are stored sealed, apart from the
+	// charm settings. They are not versioned with branches.
+	secretChanges, changes, err := a.splitSecretConfig(changes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(secretChanges) > 0 {
+		if branchName != model.GenerationMaster {
+			return errors.NotSupportedf("changing secret config options on branch %q", branchName)
+		}
+		if err := a.updateSecretConfig(secretChanges); err != nil {
+			return errors.Trace(err)
+		}
+		if len(changes) == 0 {
+			return nil
+		}
+	}
+
 	// TODO(fwereade) state.Settings is itself really problematic in just
 	// about every use case. This needs to be resolved some time; but at
 	// least the settings docs are keyed by charm url as well as application
@@ -2752,6 +2791,10 @@ func (a *Application) UpdateApplicationConfig(
 	} else if err != nil {
 		return errors.Annotatef(err, "application config for application %q", a.doc.Name)
 	}
+	oldSecretOptions, err := application.ConfigAttributes(node.Map()).SecretOptions()
+	if err != nil {
+		return errors.Trace(err)
+	}
 	resetKeys := set.NewStrings(reset...)
 	for name, value := range changes {
 		if resetKeys.Contains(name) {
@@ -2774,8 +2817,23 @@ func (a *Application) UpdateApplicationConfig(
 	for _, key := range node.Keys() {
 		node.Set(key, coerced[key])
 	}
-	_, err = node.Write()
-	return err
+	newSecretOptions, err := application.ConfigAttributes(node.Map()).SecretOptions()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := a.validateSecretOptions(newSecretOptions); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err = node.Write(); err != nil {
+		return err
+	}
+
+	// Move the values of options whose secrecy has changed.
+	oldSecrets, newSecrets := set.NewStrings(oldSecretOptions...), set.NewStrings(newSecretOptions...)
+	return errors.Trace(a.moveSecretConfig(
+		newSecrets.Difference(oldSecrets).SortedValues(),
+		oldSecrets.Difference(newSecrets).SortedValues(),
+	))
 }
 
 // LeaderSettings returns a application's leader settings. If nothing has been set
@@ -2966,6 +3024,77 @@ func (a *Application) StorageConstraints() (map[string]StorageConstraints, error
 	return cons, nil
 }
 
+// UpdateStorageConstraints updates the storage constraints used to
+// provision storage for the application's future units. Only the pool,
+// size and count which are set for each store are updated; the rest are
+// kept. Storage instances which already exist are not affected.
+func (a *Application) UpdateStorageConstraints(updates map[string]StorageConstraints) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot update storage constraints for application %q", a.doc.Name)
+
+	sb, err := NewStorageBackend(a.st)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, applicationNotAliveErr
+		}
+		ch, _, err := a.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		current, err := a.StorageConstraints()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		newCons := make(map[string]StorageConstraints)
+		for name, cons := range current {
+			newCons[name] = cons
+		}
+		for name, update := range updates {
+			cons := newCons[name]
+			if update.Pool != "" {
+				cons.Pool = update.Pool
+			}
+			if update.Size != 0 {
+				cons.Size = update.Size
+			}
+			if update.Count != 0 {
+				cons.Count = update.Count
+			}
+			newCons[name] = cons
+		}
+		if err := addDefaultStorageConstraints(sb, newCons, ch.Meta()); err != nil {
+			return nil, errors.Annotate(err, "adding default storage constraints")
+		}
+		if err := validateStorageConstraints(sb, newCons, ch.Meta()); err != nil {
+			return nil, errors.Annotate(err, "validating storage constraints")
+		}
+
+		ops := []txn.Op{{
+			C:  applicationsC,
+			Id: a.doc.DocID,
+			Assert: bson.D{
+				{"life", Alive},
+				{"charmurl", a.doc.CharmURL},
+			},
+		}}
+		if current == nil {
+			ops = append(ops, createStorageConstraintsOp(a.storageConstraintsKey(), newCons))
+		} else {
+			ops = append(ops, replaceStorageConstraintsOp(a.storageConstraintsKey(), newCons))
+		}
+		return ops, nil
+	}
+	return errors.Trace(a.st.db().Run(buildTxn))
+}
+
 // DeviceConstraints returns the device constraints for the application.
 func (a *Application) DeviceConstraints() (map[string]DeviceConstraints, error) {
 	cons, err := readDeviceConstraints(a.st, a.deviceConstraintsKey())
@@ -22,6 +22,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/crossmodel"
@@ -2601,6 +2602,47 @@ func (s *ApplicationSuite) TestAddUnitWhenNotAlive(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot add unit to application "mysql": application "mysql" not found`)
 }
 
+func (s *ApplicationSuite) TestAddUnitQuotaExceeded(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.MaxUnitsPerModel: 2,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.Satisfies, errors.IsQuotaLimitExceeded)
+	c.Assert(err, gc.ErrorMatches, `cannot add unit to application "mysql": adding 1 unit\(s\) to a model with 2 units would exceed the controller limit of 2 units per model`)
+}
+
+func (s *ApplicationSuite) TestAddUnitQuotaExceededConcurrently(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.MaxUnitsPerModel: 2,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Another unit is added to the model after the quota is checked,
+	// but before our transaction is run.
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	defer state.SetBeforeHooks(c, s.State, func() {
+		_, err := wordpress.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	_, err = s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.Satisfies, errors.IsQuotaLimitExceeded)
+
+	count, err := s.State.UnitCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 2)
+}
+
 func (s *ApplicationSuite) TestAddCAASUnit(c *gc.C) {
 	st := s.Factory.MakeModel(c, &factory.ModelParams{
 		Name: "caas-model",
@@ -4041,6 +4083,28 @@ func (s *ApplicationSuite) TestWatchCharmConfig(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *ApplicationSuite) TestWatchApplicationConfig(c *gc.C) {
+	w := s.mysql.WatchApplicationConfig()
+	defer testing.AssertStop(c, w)
+
+	// Initial event.
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.mysql.UpdateApplicationConfig(application.ConfigAttributes{
+		"outlook": "positive",
+	}, nil, sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Non-change is not reported.
+	err = s.mysql.UpdateApplicationConfig(application.ConfigAttributes{
+		"outlook": "positive",
+	}, nil, sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
 var updateApplicationConfigTests = []struct {
 	about   string
 	initial application.ConfigAttributes
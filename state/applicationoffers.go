@@ -427,6 +427,9 @@ func (s *applicationOffers) AddOffer(offerArgs crossmodel.AddApplicationOfferArg
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := checkOfferEndpoints(result); err != nil {
+		return nil, errors.Trace(err)
+	}
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		// If we've tried once already and failed, check that
 		// model may have been destroyed.
@@ -499,6 +502,13 @@ func (s *applicationOffers) UpdateOffer(offerArgs crossmodel.AddApplicationOffer
 		return nil, errors.Trace(err)
 	}
 	doc := s.makeApplicationOfferDoc(s.st, offer.OfferUUID, offerArgs)
+	updated, err := s.makeApplicationOffer(doc)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := checkOfferEndpoints(updated); err != nil {
+		return nil, errors.Trace(err)
+	}
 	var refOps []txn.Op
 	if offerArgs.ApplicationName != offer.ApplicationName {
 		incRefOp, err := incApplicationOffersRefOp(s.st, offerArgs.ApplicationName)
@@ -761,6 +771,19 @@ func getApplicationEndpoints(application *Application, endpointNames map[string]
 	return result, nil
 }
 
+// checkOfferEndpoints returns an error if any of the offer's endpoints
+// cannot take part in a cross model relation. Subordinate applications
+// may be offered, but only their global endpoints: a container scoped
+// relation needs both units on the same machine.
+func checkOfferEndpoints(offer *crossmodel.ApplicationOffer) error {
+	for alias, ep := range offer.Endpoints {
+		if ep.Scope == charm.ScopeContainer {
+			return errors.NotSupportedf("offering container scoped endpoint %q", alias)
+		}
+	}
+	return nil
+}
+
 type offerSlice []applicationOfferDoc
 
 func (sr offerSlice) Len() int      { return len(sr) }
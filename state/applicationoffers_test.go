@@ -141,6 +141,36 @@ func (s *applicationOffersSuite) TestAddApplicationOfferBadEndpoints(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *applicationOffersSuite) TestAddApplicationOfferSubordinate(c *gc.C) {
+	s.AddTestingApplication(c, "logging", s.AddTestingCharm(c, "logging"))
+	sd := state.NewApplicationOffers(s.State)
+	owner := s.Factory.MakeUser(c, nil)
+	args := crossmodel.AddApplicationOfferArgs{
+		OfferName:       "hosted-logging",
+		ApplicationName: "logging",
+		Endpoints:       map[string]string{"logging": "logging-client"},
+		Owner:           owner.Name(),
+	}
+	offer, err := sd.AddOffer(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offer.Endpoints["logging"].Scope, gc.Equals, charm.ScopeGlobal)
+}
+
+func (s *applicationOffersSuite) TestAddApplicationOfferContainerScopedEndpoint(c *gc.C) {
+	s.AddTestingApplication(c, "logging", s.AddTestingCharm(c, "logging"))
+	sd := state.NewApplicationOffers(s.State)
+	owner := s.Factory.MakeUser(c, nil)
+	args := crossmodel.AddApplicationOfferArgs{
+		OfferName:       "hosted-logging",
+		ApplicationName: "logging",
+		Endpoints:       map[string]string{"info": "info"},
+		Owner:           owner.Name(),
+	}
+	_, err := sd.AddOffer(args)
+	c.Assert(err, gc.ErrorMatches, `cannot add application offer "hosted-logging": offering container scoped endpoint "info" not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *applicationOffersSuite) TestListOffersNone(c *gc.C) {
 	sd := state.NewApplicationOffers(s.State)
 	offers, err := sd.ListOffers()
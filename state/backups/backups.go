@@ -100,6 +100,10 @@ type Backups interface {
 
 	// Remove deletes the backup from storage.
 	Remove(id string) error
+
+	// Verify restores the backup into a throwaway database and checks
+	// that it is usable.
+	Verify(id string) (*Metadata, *VerifyResult, error)
 }
 
 type backups struct {
@@ -249,3 +253,20 @@ func (b *backups) List() ([]*Metadata, error) {
 func (b *backups) Remove(id string) error {
 	return errors.Trace(b.storage.Remove(id))
 }
+
+// Verify restores the backup into a throwaway database, separate from
+// the controller's, and checks that it is usable. The result reports
+// any problems found.
+func (b *backups) Verify(id string) (*Metadata, *VerifyResult, error) {
+	meta, archive, err := b.Get(id)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	defer func() { _ = archive.Close() }()
+
+	result, err := verifyArchive(archive, meta)
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "while verifying backup")
+	}
+	return meta, result, nil
+}
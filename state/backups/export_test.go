@@ -27,8 +27,12 @@ var (
 	StoreArchiveRef      = &storeArchive
 	GetMongodumpPath     = &getMongodumpPath
 	RunCommand           = &runCommandFn
+	NewThrowawayDB       = &newThrowawayDB
 )
 
+// ThrowawayDB exposes throwawayDB for testing.
+type ThrowawayDB = throwawayDB
+
 var _ filestorage.DocStorage = (*backupsDocStorage)(nil)
 var _ filestorage.RawFileStorage = (*backupBlobStorage)(nil)
 
@@ -52,6 +52,8 @@ type FakeBackups struct {
 	KeepCopy bool
 	// NoDownload holds the noDownload bool that was passed in.
 	NoDownload bool
+	// VerifyResult holds the verification result to return.
+	VerifyResult *backups.VerifyResult
 }
 
 var _ backups.Backups = (*FakeBackups)(nil)
@@ -111,6 +113,13 @@ func (b *FakeBackups) Remove(id string) error {
 	return errors.Trace(b.Error)
 }
 
+// Verify returns the metadata and verification result.
+func (b *FakeBackups) Verify(id string) (*backups.Metadata, *backups.VerifyResult, error) {
+	b.Calls = append(b.Calls, "Verify")
+	b.IDArg = id
+	return b.Meta, b.VerifyResult, b.Error
+}
+
 // TODO(ericsnow) FakeStorage should probably move over to the utils repo.
 
 // FakeStorage is a FileStorage implementation to use when testing
@@ -0,0 +1,337 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// VerifyResult holds the outcome of restoring a backup into a
+// throwaway database and checking what was restored.
+type VerifyResult struct {
+	// Databases holds the names of the databases that were restored.
+	Databases []string
+
+	// Collections holds the number of collections that were validated.
+	Collections int
+
+	// Problems holds the reasons the backup could not be restored
+	// cleanly. A backup without problems is restorable.
+	Problems []string
+}
+
+// Restorable reports whether the backup was restored without problems.
+func (r *VerifyResult) Restorable() bool {
+	return len(r.Problems) == 0
+}
+
+func (r *VerifyResult) addProblem(format string, args ...interface{}) {
+	r.Problems = append(r.Problems, fmt.Sprintf(format, args...))
+}
+
+const (
+	restoreName = "mongorestore"
+
+	// snapCommonDir is the only directory outside its private /tmp
+	// which the juju-db snap's mongod may write to.
+	snapCommonDir = "/var/snap/juju-db/common"
+
+	// throwawayStartTimeout is how long to wait for the throwaway
+	// mongod to accept connections.
+	throwawayStartTimeout = time.Minute
+)
+
+// throwawayDB is a database, separate from the controller's, into
+// which a backup can be restored and inspected.
+type throwawayDB interface {
+	// Restore restores the mongodump output in dumpDir.
+	Restore(dumpDir string) error
+
+	// DatabaseNames returns the names of the databases.
+	DatabaseNames() ([]string, error)
+
+	// CollectionNames returns the names of the collections in the
+	// database.
+	CollectionNames(db string) ([]string, error)
+
+	// Validate runs mongo's validate command on the collection,
+	// returning whether it is valid and any errors reported.
+	Validate(db, collection string) (bool, []string, error)
+
+	// Count returns the number of documents in the collection
+	// matching the query.
+	Count(db, collection string, query bson.M) (int, error)
+
+	// Close stops the database and removes its files.
+	Close() error
+}
+
+var newThrowawayDB = newThrowawayMongo
+
+// verifyArchive restores the database dump in the archive into a
+// throwaway database and checks that it is usable. Problems with the
+// archive are reported in the result; an error is only returned if the
+// verification itself could not be done.
+func verifyArchive(archive io.Reader, meta *Metadata) (*VerifyResult, error) {
+	result := &VerifyResult{}
+	ws, err := NewArchiveWorkspaceReader(archive)
+	if ws != nil {
+		defer func() { _ = ws.Close() }()
+	}
+	if err != nil {
+		result.addProblem("cannot unpack archive: %v", err)
+		return result, nil
+	}
+	dumped, err := listDatabases(ws.DBDumpDir)
+	if err != nil {
+		result.addProblem("cannot read database dump: %v", err)
+		return result, nil
+	}
+
+	db, err := newThrowawayDB()
+	if err != nil {
+		return nil, errors.Annotate(err, "starting throwaway database")
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			logger.Warningf("cleaning up throwaway database: %v", err)
+		}
+	}()
+	if err := db.Restore(ws.DBDumpDir); err != nil {
+		result.addProblem("cannot restore database dump: %v", err)
+		return result, nil
+	}
+	if err := checkRestored(db, dumped, meta, result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// checkRestored checks that each dumped database was restored, that
+// each of its collections passes validation, and that the juju
+// database describes the controller the backup was taken from,
+// including all of its HA nodes.
+func checkRestored(db throwawayDB, dumped set.Strings, meta *Metadata, result *VerifyResult) error {
+	names, err := db.DatabaseNames()
+	if err != nil {
+		return errors.Annotate(err, "listing restored databases")
+	}
+	restored := set.NewStrings(names...)
+	for _, name := range dumped.SortedValues() {
+		if !restored.Contains(name) {
+			result.addProblem("database %q was not restored", name)
+			continue
+		}
+		result.Databases = append(result.Databases, name)
+		collections, err := db.CollectionNames(name)
+		if err != nil {
+			return errors.Annotatef(err, "listing collections in %q", name)
+		}
+		for _, collection := range collections {
+			if strings.HasPrefix(collection, "system.") {
+				continue
+			}
+			valid, validateErrs, err := db.Validate(name, collection)
+			if err != nil {
+				return errors.Annotatef(err, "validating %s.%s", name, collection)
+			}
+			result.Collections++
+			if !valid {
+				result.addProblem("collection %s.%s is not valid: %s", name, collection, strings.Join(validateErrs, "; "))
+			}
+		}
+	}
+
+	if !dumped.Contains("juju") {
+		result.addProblem("juju database missing from backup")
+		return nil
+	}
+	if !restored.Contains("juju") {
+		// Already reported above.
+		return nil
+	}
+	if meta.Origin.Model != "" {
+		n, err := db.Count("juju", "models", bson.M{"_id": meta.Origin.Model})
+		if err != nil {
+			return errors.Annotate(err, "counting models")
+		}
+		if n == 0 {
+			result.addProblem("controller model %q missing from backup", meta.Origin.Model)
+		}
+	}
+	if meta.Controller.HANodes > 0 && meta.Controller.HANodes != UnknownInt64 {
+		n, err := db.Count("juju", "controllerNodes", nil)
+		if err != nil {
+			return errors.Annotate(err, "counting controller nodes")
+		}
+		if int64(n) != meta.Controller.HANodes {
+			result.addProblem("backup was taken with %d controller nodes but records %d", meta.Controller.HANodes, n)
+		}
+	}
+	return nil
+}
+
+// throwawayMongo is a standalone mongod listening only on localhost.
+// It is deliberately not started as a replica set member, so that the
+// replica set configuration in a backup of an HA controller cannot
+// cause it to contact the controller's other nodes.
+type throwawayMongo struct {
+	dir         string
+	port        int
+	cmd         *exec.Cmd
+	session     *mgo.Session
+	restorePath string
+}
+
+func newThrowawayMongo() (throwawayDB, error) {
+	mongodPath, err := getMongodPath()
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to get mongod path")
+	}
+	restorePath, err := getMongoToolPath(restoreName, os.Stat, exec.LookPath)
+	if err != nil {
+		return nil, errors.Annotate(err, "mongorestore not available")
+	}
+	var parentDir string
+	if isSnapTool(restorePath, restoreName) {
+		parentDir = snapCommonDir
+	}
+	dir, err := ioutil.TempDir(parentDir, "juju-backup-verify-")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	port, err := freePort()
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, errors.Trace(err)
+	}
+
+	m := &throwawayMongo{
+		dir:         dir,
+		port:        port,
+		restorePath: restorePath,
+	}
+	m.cmd = exec.Command(mongodPath,
+		"--dbpath", dir,
+		"--logpath", filepath.Join(dir, "mongod.log"),
+		"--bind_ip", "127.0.0.1",
+		"--port", strconv.Itoa(port),
+		"--nounixsocket",
+	)
+	if err := m.cmd.Start(); err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, errors.Annotate(err, "starting mongod")
+	}
+	m.session, err = mgo.DialWithInfo(&mgo.DialInfo{
+		Addrs:   []string{m.addr()},
+		Direct:  true,
+		Timeout: throwawayStartTimeout,
+	})
+	if err != nil {
+		_ = m.Close()
+		return nil, errors.Annotate(err, "connecting to mongod")
+	}
+	return m, nil
+}
+
+func (m *throwawayMongo) addr() string {
+	return net.JoinHostPort("127.0.0.1", strconv.Itoa(m.port))
+}
+
+// Restore is part of the throwawayDB interface.
+func (m *throwawayMongo) Restore(dumpDir string) error {
+	args := []string{
+		"--host", m.addr(),
+		"--drop",
+	}
+	if _, err := os.Stat(filepath.Join(dumpDir, "oplog.bson")); err == nil {
+		args = append(args, "--oplogReplay")
+	}
+	args = append(args, dumpDir)
+
+	// The juju-db snap's mongorestore sees a private /tmp, which is
+	// found at /tmp/snap.juju-db on the host.
+	if isSnapTool(m.restorePath, restoreName) {
+		snapDumpDir := filepath.Join(snapTmpDir, dumpDir)
+		if err := os.MkdirAll(filepath.Dir(snapDumpDir), 0700); err != nil {
+			return errors.Trace(err)
+		}
+		if err := os.Rename(dumpDir, snapDumpDir); err != nil {
+			return errors.Trace(err)
+		}
+		defer func() { _ = os.RemoveAll(snapDumpDir) }()
+	}
+	return errors.Trace(runCommandFn(m.restorePath, args...))
+}
+
+// DatabaseNames is part of the throwawayDB interface.
+func (m *throwawayMongo) DatabaseNames() ([]string, error) {
+	names, err := m.session.DatabaseNames()
+	return names, errors.Trace(err)
+}
+
+// CollectionNames is part of the throwawayDB interface.
+func (m *throwawayMongo) CollectionNames(db string) ([]string, error) {
+	names, err := m.session.DB(db).CollectionNames()
+	return names, errors.Trace(err)
+}
+
+// Validate is part of the throwawayDB interface.
+func (m *throwawayMongo) Validate(db, collection string) (bool, []string, error) {
+	var result struct {
+		Valid  bool     `bson:"valid"`
+		Errors []string `bson:"errors"`
+	}
+	err := m.session.DB(db).Run(bson.D{{"validate", collection}, {"full", true}}, &result)
+	if err != nil {
+		return false, nil, errors.Trace(err)
+	}
+	return result.Valid, result.Errors, nil
+}
+
+// Count is part of the throwawayDB interface.
+func (m *throwawayMongo) Count(db, collection string, query bson.M) (int, error) {
+	n, err := m.session.DB(db).C(collection).Find(query).Count()
+	return n, errors.Trace(err)
+}
+
+// Close is part of the throwawayDB interface.
+func (m *throwawayMongo) Close() error {
+	if m.session != nil {
+		m.session.Close()
+	}
+	if m.cmd.Process != nil {
+		_ = m.cmd.Process.Kill()
+		_ = m.cmd.Wait()
+	}
+	return errors.Trace(os.RemoveAll(m.dir))
+}
+
+func isSnapTool(path, toolName string) bool {
+	return filepath.Base(path) == snapToolPrefix+toolName
+}
+
+// freePort returns a localhost port which is not currently in use.
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, errors.Annotate(err, "finding a free port")
+	}
+	defer func() { _ = l.Close() }()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
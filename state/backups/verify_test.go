@@ -0,0 +1,190 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+)
+
+type verifySuite struct {
+	backupstesting.BaseSuite
+
+	db *fakeThrowawayDB
+}
+
+var _ = gc.Suite(&verifySuite{})
+
+func (s *verifySuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.db = &fakeThrowawayDB{
+		databases: []string{"admin", "juju", "local"},
+		collections: map[string][]string{
+			"juju": {"controllerNodes", "machines", "models", "system.indexes"},
+		},
+		counts: map[string]int{
+			"models":          1,
+			"controllerNodes": 1,
+		},
+	}
+	s.PatchValue(backups.NewThrowawayDB, func() (backups.ThrowawayDB, error) {
+		return s.db, nil
+	})
+}
+
+func (s *verifySuite) verify(c *gc.C) *backups.VerifyResult {
+	archive, err := backupstesting.NewArchiveBasic(s.Meta)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Storage.Meta = s.Meta
+	s.Storage.File = ioutil.NopCloser(archive)
+
+	meta, result, err := backups.NewBackups(s.Storage).Verify("spam")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(meta, gc.Equals, s.Meta)
+	c.Check(s.Storage.IDArg, gc.Equals, "spam")
+	return result
+}
+
+func (s *verifySuite) TestVerify(c *gc.C) {
+	s.Meta.Controller.HANodes = 1
+	result := s.verify(c)
+	c.Check(result.Restorable(), jc.IsTrue)
+	c.Check(result.Databases, jc.DeepEquals, []string{"juju"})
+	c.Check(result.Collections, gc.Equals, 3)
+	c.Check(result.Problems, gc.HasLen, 0)
+
+	c.Check(filepath.Base(s.db.restored), gc.Equals, "dump")
+	c.Check(s.db.validated, jc.DeepEquals, []string{"juju.controllerNodes", "juju.machines", "juju.models"})
+	c.Check(s.db.closed, jc.IsTrue)
+}
+
+func (s *verifySuite) TestVerifyInvalidCollection(c *gc.C) {
+	s.db.invalid = map[string][]string{
+		"machines": {"bad BSON object", "index count mismatch"},
+	}
+	result := s.verify(c)
+	c.Check(result.Restorable(), jc.IsFalse)
+	c.Check(result.Problems, jc.DeepEquals, []string{
+		"collection juju.machines is not valid: bad BSON object; index count mismatch",
+	})
+}
+
+func (s *verifySuite) TestVerifyMissingControllerModel(c *gc.C) {
+	s.db.counts["models"] = 0
+	result := s.verify(c)
+	c.Check(result.Problems, jc.DeepEquals, []string{
+		`controller model "49db53ac-a42f-4ab2-86e1-0c6fa0fec762" missing from backup`,
+	})
+}
+
+func (s *verifySuite) TestVerifyHANodesMismatch(c *gc.C) {
+	s.Meta.Controller.HANodes = 3
+	result := s.verify(c)
+	c.Check(result.Problems, jc.DeepEquals, []string{
+		"backup was taken with 3 controller nodes but records 1",
+	})
+}
+
+func (s *verifySuite) TestVerifyHANodesUnknown(c *gc.C) {
+	s.Meta.Controller.HANodes = backups.UnknownInt64
+	result := s.verify(c)
+	c.Check(result.Restorable(), jc.IsTrue)
+}
+
+func (s *verifySuite) TestVerifyDatabaseNotRestored(c *gc.C) {
+	s.db.databases = []string{"admin", "local"}
+	result := s.verify(c)
+	c.Check(result.Problems, jc.DeepEquals, []string{
+		`database "juju" was not restored`,
+	})
+}
+
+func (s *verifySuite) TestVerifyRestoreFails(c *gc.C) {
+	s.db.restoreErr = errors.New("Failed: error restoring juju.machines: corrupt BSON")
+	result := s.verify(c)
+	c.Check(result.Problems, jc.DeepEquals, []string{
+		"cannot restore database dump: Failed: error restoring juju.machines: corrupt BSON",
+	})
+	c.Check(s.db.closed, jc.IsTrue)
+}
+
+func (s *verifySuite) TestVerifyCorruptArchive(c *gc.C) {
+	s.Storage.Meta = s.Meta
+	s.Storage.File = ioutil.NopCloser(bytes.NewBufferString("not a backup"))
+
+	_, result, err := backups.NewBackups(s.Storage).Verify("spam")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Restorable(), jc.IsFalse)
+	c.Check(result.Problems, gc.HasLen, 1)
+	c.Check(result.Problems[0], gc.Matches, "cannot unpack archive: .*")
+	c.Check(s.db.restored, gc.Equals, "")
+}
+
+func (s *verifySuite) TestVerifyThrowawayDBFails(c *gc.C) {
+	s.PatchValue(backups.NewThrowawayDB, func() (backups.ThrowawayDB, error) {
+		return nil, errors.New("mongod not found")
+	})
+	archive, err := backupstesting.NewArchiveBasic(s.Meta)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Storage.Meta = s.Meta
+	s.Storage.File = ioutil.NopCloser(archive)
+
+	_, _, err = backups.NewBackups(s.Storage).Verify("spam")
+	c.Assert(err, gc.ErrorMatches, "while verifying backup: starting throwaway database: mongod not found")
+}
+
+func (s *verifySuite) TestVerifyGetFails(c *gc.C) {
+	s.Storage.Error = errors.NotFoundf("backup metadata %q", "spam")
+	_, _, err := backups.NewBackups(s.Storage).Verify("spam")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+type fakeThrowawayDB struct {
+	databases   []string
+	collections map[string][]string
+	counts      map[string]int
+	invalid     map[string][]string
+	restoreErr  error
+
+	restored  string
+	validated []string
+	closed    bool
+}
+
+func (db *fakeThrowawayDB) Restore(dumpDir string) error {
+	db.restored = dumpDir
+	return db.restoreErr
+}
+
+func (db *fakeThrowawayDB) DatabaseNames() ([]string, error) {
+	return db.databases, nil
+}
+
+func (db *fakeThrowawayDB) CollectionNames(name string) ([]string, error) {
+	return db.collections[name], nil
+}
+
+func (db *fakeThrowawayDB) Validate(name, collection string) (bool, []string, error) {
+	db.validated = append(db.validated, name+"."+collection)
+	errs, invalid := db.invalid[collection]
+	return !invalid, errs, nil
+}
+
+func (db *fakeThrowawayDB) Count(name, collection string, query bson.M) (int, error) {
+	return db.counts[collection], nil
+}
+
+func (db *fakeThrowawayDB) Close() error {
+	db.closed = true
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state/binarystorage"
+	"github.com/juju/juju/state/storage"
 )
 
 var binarystorageNew = binarystorage.New
@@ -17,15 +18,22 @@ var binarystorageNew = binarystorage.New
 // ToolsStorage returns a new binarystorage.StorageCloser that stores tools
 // metadata in the "juju" database "toolsmetadata" collection.
 func (st *State) ToolsStorage() (binarystorage.StorageCloser, error) {
-	modelStorage := newBinaryStorageCloser(st.database, toolsmetadataC, st.ModelUUID())
+	modelStorage, err := newBinaryStorageCloser(st.database, toolsmetadataC, st.ModelUUID(), st.newResourceStorage)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	if st.IsController() {
 		return modelStorage, nil
 	}
 	// This is a hosted model. Hosted models have their own tools
 	// catalogue, which we combine with the controller's.
-	controllerStorage := newBinaryStorageCloser(
-		st.database, toolsmetadataC, st.ControllerModelUUID(),
+	controllerStorage, err := newBinaryStorageCloser(
+		st.database, toolsmetadataC, st.ControllerModelUUID(), st.newResourceStorage,
 	)
+	if err != nil {
+		modelStorage.Close()
+		return nil, errors.Trace(err)
+	}
 	storage, err := binarystorage.NewLayeredStorage(modelStorage, controllerStorage)
 	if err != nil {
 		modelStorage.Close()
@@ -41,10 +49,12 @@ func (st *State) ToolsStorage() (binarystorage.StorageCloser, error) {
 // DashboardStorage returns a new binarystorage.StorageCloser that stores Dashboard archive
 // metadata in the "juju" database "guimetadata" collection.
 func (st *State) DashboardStorage() (binarystorage.StorageCloser, error) {
-	return newBinaryStorageCloser(st.database, guimetadataC, st.ControllerModelUUID()), nil
+	return newBinaryStorageCloser(st.database, guimetadataC, st.ControllerModelUUID(), st.newResourceStorage)
 }
 
-func newBinaryStorageCloser(db Database, collectionName, uuid string) binarystorage.StorageCloser {
+func newBinaryStorageCloser(
+	db Database, collectionName, uuid string, newResourceStorage storage.ResourceStorageFunc,
+) (binarystorage.StorageCloser, error) {
 	db, closer1 := db.CopyForModel(uuid)
 	metadataCollection, closer2 := db.GetCollection(collectionName)
 	txnRunner, closer3 := db.TransactionRunner()
@@ -53,15 +63,24 @@ func newBinaryStorageCloser(db Database, collectionName, uuid string) binarystor
 		closer2()
 		closer1()
 	}
-	storage := newBinaryStorage(uuid, metadataCollection, txnRunner)
-	return &storageCloser{storage, closer}
+	binaryStorage, err := newBinaryStorage(uuid, metadataCollection, txnRunner, newResourceStorage)
+	if err != nil {
+		closer()
+		return nil, errors.Trace(err)
+	}
+	return &storageCloser{binaryStorage, closer}, nil
 }
 
-func newBinaryStorage(uuid string, metadataCollection mongo.Collection, txnRunner jujutxn.Runner) binarystorage.Storage {
+func newBinaryStorage(
+	uuid string, metadataCollection mongo.Collection, txnRunner jujutxn.Runner, newResourceStorage storage.ResourceStorageFunc,
+) (binarystorage.Storage, error) {
 	db := metadataCollection.Writeable().Underlying().Database
-	rs := blobstore.NewGridFS(blobstoreDB, blobstoreDB, db.Session)
+	rs, err := newResourceStorage(db.Session)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	managedStorage := blobstore.NewManagedStorage(db, rs)
-	return binarystorageNew(uuid, managedStorage, metadataCollection, txnRunner)
+	return binarystorageNew(uuid, managedStorage, metadataCollection, txnRunner), nil
 }
 
 type storageCloser struct {
@@ -0,0 +1,89 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/blobstore.v2"
+	"gopkg.in/mgo.v2"
+
+	jujucontroller "github.com/juju/juju/controller"
+	"github.com/juju/juju/state/storage"
+)
+
+// newS3ResourceStorage is patched out in tests.
+var newS3ResourceStorage = storage.NewS3ResourceStorage
+
+// BlobStorage returns a storage.Storage for the model's blob data, such
+// as charm archives, held in the controller's configured blobstore
+// backend.
+func (st *State) BlobStorage() storage.Storage {
+	return storage.NewStorageWithResourceStorage(st.ModelUUID(), st.session, st.newResourceStorage)
+}
+
+// newResourceStorage is a storage.ResourceStorageFunc returning the
+// resource storage for the controller's configured blobstore backend.
+func (st *State) newResourceStorage(session *mgo.Session) (blobstore.ResourceStorage, error) {
+	cfg, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return st.resourceStorageForConfig(cfg, session)
+}
+
+// resourceStorageForConfig returns the resource storage for the given
+// controller config. When blob data is held in S3, blob data written
+// to GridFS before the switch remains readable until it is moved by
+// the MoveBlobsToS3 upgrade step.
+func (st *State) resourceStorageForConfig(cfg jujucontroller.Config, session *mgo.Session) (blobstore.ResourceStorage, error) {
+	gridFS, err := storage.GridFSResourceStorage(session)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if cfg.BlobstoreBackend() != jujucontroller.BlobstoreBackendS3 {
+		return gridFS, nil
+	}
+	s3, err := st.s3Storage.get(s3ConfigForController(cfg))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return storage.NewFallbackResourceStorage(s3, gridFS), nil
+}
+
+// s3StorageCache holds the S3 resource storage of a State, so that a
+// new AWS session is only created when the blobstore config changes.
+type s3StorageCache struct {
+	mu      sync.Mutex
+	config  storage.S3Config
+	storage blobstore.ResourceStorage
+}
+
+// get returns the S3 resource storage for the config, creating it if
+// the config differs from that of the cached storage.
+func (c *s3StorageCache) get(config storage.S3Config) (blobstore.ResourceStorage, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.storage != nil && c.config == config {
+		return c.storage, nil
+	}
+	s3, err := newS3ResourceStorage(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	c.config = config
+	c.storage = s3
+	return s3, nil
+}
+
+func s3ConfigForController(cfg jujucontroller.Config) storage.S3Config {
+	return storage.S3Config{
+		Endpoint:  cfg.BlobstoreS3Endpoint(),
+		Region:    cfg.BlobstoreS3Region(),
+		Bucket:    cfg.BlobstoreS3Bucket(),
+		AccessKey: cfg.BlobstoreS3AccessKey(),
+		SecretKey: cfg.BlobstoreS3SecretKey(),
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/blobstore.v2"
+
+	jujucontroller "github.com/juju/juju/controller"
+	"github.com/juju/juju/state/storage"
+)
+
+type blobStorageSuite struct {
+	internalStateSuite
+}
+
+var _ = gc.Suite(&blobStorageSuite{})
+
+func (s *blobStorageSuite) TestS3StorageCachedUntilConfigChanges(c *gc.C) {
+	var created []storage.S3Config
+	s.PatchValue(&newS3ResourceStorage, func(config storage.S3Config) (blobstore.ResourceStorage, error) {
+		created = append(created, config)
+		return storage.GridFSResourceStorage(s.state.session)
+	})
+
+	cfg := jujucontroller.Config{
+		jujucontroller.BlobstoreBackend:  jujucontroller.BlobstoreBackendS3,
+		jujucontroller.BlobstoreS3Region: "us-east-1",
+		jujucontroller.BlobstoreS3Bucket: "blobs",
+	}
+	for i := 0; i < 3; i++ {
+		_, err := s.state.resourceStorageForConfig(cfg, s.state.session)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(created, gc.HasLen, 1)
+
+	cfg[jujucontroller.BlobstoreS3Bucket] = "other-blobs"
+	_, err := s.state.resourceStorageForConfig(cfg, s.state.session)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.state.resourceStorageForConfig(cfg, s.state.session)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(created, gc.HasLen, 2)
+	c.Assert(created[1].Bucket, gc.Equals, "other-blobs")
+}
+
+func (s *blobStorageSuite) TestMongoBackendCreatesNoS3Storage(c *gc.C) {
+	s.PatchValue(&newS3ResourceStorage, func(storage.S3Config) (blobstore.ResourceStorage, error) {
+		c.Fatalf("unexpected S3 storage")
+		return nil, nil
+	})
+
+	cfg := jujucontroller.Config{
+		jujucontroller.BlobstoreBackend: jujucontroller.BlobstoreBackendMongo,
+	}
+	_, err := s.state.resourceStorageForConfig(cfg, s.state.session)
+	c.Assert(err, jc.ErrorIsNil)
+}
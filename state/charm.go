@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/juju/charm/v9"
 	"github.com/juju/collections/set"
@@ -22,7 +23,6 @@ import (
 	"github.com/juju/juju/mongo"
 	mongoutils "github.com/juju/juju/mongo/utils"
 	stateerrors "github.com/juju/juju/state/errors"
-	"github.com/juju/juju/state/storage"
 	jujuversion "github.com/juju/juju/version"
 )
 
@@ -104,6 +104,14 @@ type charmDoc struct {
 	StoragePath  string `bson:"storagepath"`
 	Macaroon     []byte `bson:"macaroon"`
 
+	// Signature records the verified detached signature uploaded
+	// with the charm archive, if any, for later audit.
+	Signature *CharmSignature `bson:"signature,omitempty"`
+
+	// SandboxProfile holds the sandboxing the charm's units need on
+	// their machines, read from the charm's sandbox-profile.yaml.
+	SandboxProfile *charmSandboxProfileDoc `bson:"sandbox-profile,omitempty"`
+
 	// The remaining fields hold data sufficient to define a
 	// charm.Charm.
 
@@ -122,6 +130,22 @@ type charmDoc struct {
 	LXDProfile *LXDProfile    `bson:"lxd-profile"`
 }
 
+// CharmSignature records a detached charm signature which was verified
+// against the controller's trusted charm signing keys.
+type CharmSignature struct {
+	// Format is the signature format, "pgp" or "cosign".
+	Format string `bson:"format"`
+
+	// KeyID identifies the trusted key which made the signature.
+	KeyID string `bson:"key-id"`
+
+	// Signature holds the signature as uploaded.
+	Signature []byte `bson:"signature"`
+
+	// VerifiedAt is when the signature was verified.
+	VerifiedAt time.Time `bson:"verified-at"`
+}
+
 // LXDProfile is the same as ProfilePut defined in github.com/lxc/lxd/shared/api/profile.go
 type LXDProfile struct {
 	Config      map[string]string            `bson:"config"`
@@ -166,6 +190,7 @@ type CharmInfo struct {
 	SHA256      string
 	Macaroon    macaroon.Slice
 	Version     string
+	Signature   *CharmSignature
 }
 
 // insertCharmOps returns the txn operations necessary to insert the supplied
@@ -185,6 +210,7 @@ func insertCharmOps(mb modelBackend, info CharmInfo) ([]txn.Op, error) {
 		Actions:      info.Charm.Actions(),
 		BundleSha256: info.SHA256,
 		StoragePath:  info.StoragePath,
+		Signature:    info.Signature,
 	}
 	lpc, ok := info.Charm.(charm.LXDProfiler)
 	if !ok {
@@ -192,6 +218,12 @@ func insertCharmOps(mb modelBackend, info CharmInfo) ([]txn.Op, error) {
 	}
 	doc.LXDProfile = safeLXDProfile(lpc.LXDProfile())
 
+	sandboxProfile, err := readCharmSandboxProfile(info.Charm)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	doc.SandboxProfile = sandboxProfile
+
 	if err := checkCharmDataIsStorable(doc); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -306,6 +338,12 @@ func updateCharmOps(mb modelBackend, info CharmInfo, assert bson.D) ([]txn.Op, e
 	}
 	data = append(data, bson.DocElem{"lxd-profile", safeLXDProfile(lpc.LXDProfile())})
 
+	sandboxProfile, err := readCharmSandboxProfile(info.Charm)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	data = append(data, bson.DocElem{"sandbox-profile", sandboxProfile})
+
 	if err := checkCharmDataIsStorable(data); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -317,6 +355,9 @@ func updateCharmOps(mb modelBackend, info CharmInfo, assert bson.D) ([]txn.Op, e
 		}
 		data = append(data, bson.DocElem{"macaroon", mac})
 	}
+	if info.Signature != nil {
+		data = append(data, bson.DocElem{"signature", info.Signature})
+	}
 
 	op.Update = bson.D{{"$set", data}}
 	return []txn.Op{op}, nil
@@ -535,7 +576,7 @@ func (c *Charm) Remove() error {
 		return errors.New("still alive")
 	}
 
-	stor := storage.NewStorage(c.st.ModelUUID(), c.st.MongoSession())
+	stor := c.st.BlobStorage()
 	err := stor.Remove(c.doc.StoragePath)
 	if errors.IsNotFound(err) {
 		// Not a problem, but we might still need to run the
@@ -627,6 +668,12 @@ func (c *Charm) BundleSha256() string {
 	return c.doc.BundleSha256
 }
 
+// Signature returns the verified signature uploaded with the charm
+// archive, or nil if the charm was not signed.
+func (c *Charm) Signature() *CharmSignature {
+	return c.doc.Signature
+}
+
 // IsUploaded returns whether the charm has been uploaded to the
 // model storage.
 func (c *Charm) IsUploaded() bool {
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/juju/charm/v9"
 	"github.com/juju/errors"
@@ -301,6 +302,27 @@ func (s *CharmSuite) TestAddCharmWithAuth(c *gc.C) {
 	assertMacaroonEquals(c, ms[0], info.Macaroon[0])
 }
 
+func (s *CharmSuite) TestAddCharmWithSignature(c *gc.C) {
+	info := s.dummyCharm(c, "")
+	info.Signature = &state.CharmSignature{
+		Format:     "pgp",
+		KeyID:      "0123456789ABCDEF",
+		Signature:  []byte("signature"),
+		VerifiedAt: time.Date(2021, 4, 1, 12, 0, 0, 0, time.UTC),
+	}
+	_, err := s.State.AddCharm(info)
+	c.Assert(err, jc.ErrorIsNil)
+
+	dummy, err := s.State.Charm(info.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	sig := dummy.Signature()
+	c.Assert(sig, gc.NotNil)
+	c.Assert(sig.Format, gc.Equals, "pgp")
+	c.Assert(sig.KeyID, gc.Equals, "0123456789ABCDEF")
+	c.Assert(sig.Signature, jc.DeepEquals, []byte("signature"))
+	c.Assert(sig.VerifiedAt.Equal(info.Signature.VerifiedAt), jc.IsTrue)
+}
+
 func (s *CharmSuite) TestAddCharmUpdatesPlaceholder(c *gc.C) {
 	// Check that adding charms updates any existing placeholder charm
 	// with the same URL.
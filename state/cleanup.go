@@ -5,6 +5,9 @@ package state
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/juju/charm/v9"
@@ -14,6 +17,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/mongo"
 	stateerrors "github.com/juju/juju/state/errors"
@@ -692,6 +696,7 @@ func (st *State) cleanupUnitsForDyingApplication(applicationname string, cleanup
 	}
 	var force bool
 	var maxWait time.Duration
+	var leaderDeadline time.Time
 	switch n := len(cleanupArgs); n {
 	case 0:
 	// It's valid to have no args: old cleanups have no args, so follow the old behaviour.
@@ -699,7 +704,7 @@ func (st *State) cleanupUnitsForDyingApplication(applicationname string, cleanup
 		if err := destroyStorageArg(); err != nil {
 			return err
 		}
-	case 3:
+	case 3, 4:
 		if err := destroyStorageArg(); err != nil {
 			return err
 		}
@@ -709,8 +714,13 @@ func (st *State) cleanupUnitsForDyingApplication(applicationname string, cleanup
 		if err := cleanupArgs[2].Unmarshal(&maxWait); err != nil {
 			return errors.Annotate(err, "unmarshalling cleanup arg 'maxWait'")
 		}
+		if n == 4 {
+			if err := cleanupArgs[3].Unmarshal(&leaderDeadline); err != nil {
+				return errors.Annotate(err, "unmarshalling cleanup arg 'leaderDeadline'")
+			}
+		}
 	default:
-		return errors.Errorf("expected 0, 1 or 3 arguments, got %d", n)
+		return errors.Errorf("expected 0, 1, 3 or 4 arguments, got %d", n)
 	}
 
 	// This won't miss units, because a Dying application cannot have units
@@ -827,6 +837,26 @@ func (st *State) cleanupDyingUnit(name string, cleanupArgs []bson.Raw) error {
 		return err
 	}
 
+	if !force {
+		wait, err := st.mustWaitToRemoveLeader(unit)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		now := st.stateClock.Now()
+		if leaderDeadline.IsZero() {
+			leaderDeadline = now.Add(maxDyingLeaderWait)
+		}
+		if wait && now.Before(leaderDeadline) {
+			logger.Debugf("delaying removal of leader unit %v until its application's other dying units are removed", name)
+			when := now.Add(dyingLeaderRetryDelay)
+			op := newCleanupAtOp(when, cleanupDyingUnit, name, destroyStorage, force, maxWait, leaderDeadline)
+			return errors.Trace(st.db().RunTransaction([]txn.Op{op}))
+		}
+		if wait {
+			logger.Warningf("removing leader unit %v after waiting %v for its application's other dying units", name, maxDyingLeaderWait)
+		}
+	}
+
 	// Mark the unit as departing from its joined relations, allowing
 	// related units to start converging to a state in which that unit
 	// is gone as quickly as possible.
@@ -874,6 +904,67 @@ func (st *State) cleanupDyingUnit(name string, cleanupArgs []bson.Raw) error {
 	}
 }
 
+const (
+	// dyingLeaderRetryDelay is how long to wait before checking again
+	// whether a dying application leader's removal can proceed.
+	dyingLeaderRetryDelay = 30 * time.Second
+
+	// maxDyingLeaderWait is how long a dying application leader's
+	// removal waits for the application's other dying units, before
+	// going ahead regardless. Another unit that never finishes dying
+	// must not keep the leader around forever.
+	maxDyingLeaderWait = 10 * time.Minute
+)
+
+// mustWaitToRemoveLeader reports whether removal of the given dying unit
+// should wait because it is its application's leader, it holds storage,
+// and other dying units of the application also hold storage. Charms use
+// leadership to mark the primary holder of shared storage, and removing
+// the primary first would have clustered workloads hand their data off
+// to units which are themselves being removed.
+func (st *State) mustWaitToRemoveLeader(unit *Unit) (bool, error) {
+	leaders, err := st.ApplicationLeaders()
+	if err != nil {
+		// The ordering is best effort; don't hold up the removal.
+		logger.Warningf("cannot get application leaders for unit %v removal: %v", unit.Name(), err)
+		return false, nil
+	}
+	if leaders[unit.ApplicationName()] != unit.Name() {
+		return false, nil
+	}
+	sb, err := NewStorageBackend(st)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	hasStorage := func(u *Unit) (bool, error) {
+		attachments, err := sb.UnitStorageAttachments(u.UnitTag())
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		return len(attachments) > 0, nil
+	}
+	if ok, err := hasStorage(unit); err != nil || !ok {
+		return false, errors.Trace(err)
+	}
+	app, err := unit.Application()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	for _, other := range units {
+		if other.Name() == unit.Name() || other.Life() != Dying {
+			continue
+		}
+		if ok, err := hasStorage(other); err != nil || ok {
+			return ok, errors.Trace(err)
+		}
+	}
+	return false, nil
+}
+
 func (st *State) scheduleForceCleanup(kind cleanupKind, name string, maxWait time.Duration) {
 	deadline := st.stateClock.Now().Add(maxWait)
 	op := newCleanupAtOp(deadline, kind, name, maxWait)
@@ -897,7 +988,7 @@ func (st *State) cleanupForceDestroyedUnit(unitId string, cleanupArgs []bson.Raw
 	unit, err := st.Unit(unitId)
 	if errors.IsNotFound(err) {
 		logger.Debugf("no need to force unit to dead %q", unitId)
-		return nil
+		return errors.Trace(st.removeUnitLeftovers(unitId, maxWait))
 	} else if err != nil {
 		return errors.Trace(err)
 	}
@@ -942,7 +1033,7 @@ func (st *State) cleanupForceDestroyedUnit(unitId string, cleanupArgs []bson.Raw
 	}
 
 	// Detach all storage.
-	err = st.forceRemoveUnitStorageAttachments(unit)
+	err = st.forceRemoveUnitStorageAttachments(unit.UnitTag())
 	if err != nil {
 		logger.Warningf("couldn't remove storage attachments for %q: %v", unitId, err)
 	}
@@ -964,24 +1055,24 @@ func (st *State) cleanupForceDestroyedUnit(unitId string, cleanupArgs []bson.Raw
 	return nil
 }
 
-func (st *State) forceRemoveUnitStorageAttachments(unit *Unit) error {
+func (st *State) forceRemoveUnitStorageAttachments(unitTag names.UnitTag) error {
 	sb, err := NewStorageBackend(st)
 	if err != nil {
 		return errors.Annotate(err, "couldn't get storage backend")
 	}
-	err = sb.DestroyUnitStorageAttachments(unit.UnitTag())
+	err = sb.DestroyUnitStorageAttachments(unitTag)
 	if err != nil {
-		return errors.Annotatef(err, "destroying storage attachments for %q", unit.Tag().Id())
+		return errors.Annotatef(err, "destroying storage attachments for %q", unitTag.Id())
 	}
-	attachments, err := sb.UnitStorageAttachments(unit.UnitTag())
+	attachments, err := sb.UnitStorageAttachments(unitTag)
 	if err != nil {
-		return errors.Annotatef(err, "getting storage attachments for %q", unit.Tag().Id())
+		return errors.Annotatef(err, "getting storage attachments for %q", unitTag.Id())
 	}
 	for _, attachment := range attachments {
 		err := sb.RemoveStorageAttachment(
-			attachment.StorageInstance(), unit.UnitTag(), true)
+			attachment.StorageInstance(), unitTag, true)
 		if err != nil {
-			logger.Warningf("couldn't remove storage attachment %q for %q: %v", attachment.StorageInstance(), unit, err)
+			logger.Warningf("couldn't remove storage attachment %q for %q: %v", attachment.StorageInstance(), unitTag.Id(), err)
 		}
 	}
 	return nil
@@ -998,7 +1089,7 @@ func (st *State) cleanupForceRemoveUnit(unitId string, cleanupArgs []bson.Raw) e
 	unit, err := st.Unit(unitId)
 	if errors.IsNotFound(err) {
 		logger.Debugf("no need to force remove unit %q", unitId)
-		return nil
+		return errors.Trace(st.removeUnitLeftovers(unitId, maxWait))
 	} else if err != nil {
 		return errors.Trace(err)
 	}
@@ -1006,7 +1097,139 @@ func (st *State) cleanupForceRemoveUnit(unitId string, cleanupArgs []bson.Raw) e
 	if len(opErrs) != 0 {
 		logger.Warningf("errors encountered force-removing unit %q: %v", unitId, opErrs)
 	}
-	return errors.Trace(err)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(st.removeUnitLeftovers(unitId, maxWait))
+}
+
+// removeUnitLeftovers removes any documents depending on a unit which
+// remain after the unit itself has been forcibly removed: relation
+// scopes, storage attachments and opened ports. Such documents would
+// otherwise block the removal of relations, storage, machines and
+// eventually the model. Any failure is returned, so that the cleanup
+// is retried.
+func (st *State) removeUnitLeftovers(unitName string, maxWait time.Duration) error {
+	if err := st.leaveRemovedUnitScopes(unitName, maxWait); err != nil {
+		return errors.Annotatef(err, "leaving relation scopes for removed unit %q", unitName)
+	}
+
+	unitTag := names.NewUnitTag(unitName)
+	sb, err := NewStorageBackend(st)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	attachments, err := sb.UnitStorageAttachments(unitTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(attachments) > 0 {
+		if err := st.forceRemoveUnitStorageAttachments(unitTag); err != nil {
+			return errors.Trace(err)
+		}
+		if attachments, err = sb.UnitStorageAttachments(unitTag); err != nil {
+			return errors.Trace(err)
+		} else if len(attachments) > 0 {
+			return errors.Errorf("%d storage attachments remain for removed unit %q", len(attachments), unitName)
+		}
+	}
+
+	openedPorts, closer := st.db().GetCollection(openedPortsC)
+	defer closer()
+	var portDocs []machinePortRangesDoc
+	query := bson.D{{"unit-port-ranges." + unitName, bson.D{{"$exists", true}}}}
+	if err := openedPorts.Find(query).All(&portDocs); err != nil {
+		return errors.Trace(err)
+	}
+	for _, doc := range portDocs {
+		buildTxn := func(int) ([]txn.Op, error) {
+			ops, err := removeUnitPortRangesOps(st, doc.MachineID, unitName)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if len(ops) == 0 {
+				return nil, jujutxn.ErrNoOperations
+			}
+			return ops, nil
+		}
+		if err := st.db().Run(buildTxn); err != nil {
+			return errors.Annotatef(err, "removing opened ports for removed unit %q", unitName)
+		}
+	}
+	st.reportForcedRemoval(fmt.Sprintf("forced removal of unit %q complete", unitName))
+	return nil
+}
+
+// reportForcedRemoval records the completion of a forced removal in
+// the model's status history, alongside the model's current status, so
+// that it is visible beyond the controller's log.
+func (st *State) reportForcedRemoval(message string) {
+	logger.Infof("%s", message)
+	modelStatus, err := getStatus(st.db(), modelGlobalKey, "model")
+	if err != nil {
+		logger.Warningf("couldn't record %q: %v", message, err)
+		return
+	}
+	doc := statusDoc{
+		Status:     modelStatus.Status,
+		StatusInfo: message,
+		Updated:    st.clock().Now().UnixNano(),
+	}
+	if _, err := probablyUpdateStatusHistory(st.db(), modelGlobalKey, doc); err != nil {
+		logger.Warningf("couldn't record %q: %v", message, err)
+	}
+}
+
+// leaveRemovedUnitScopes makes a removed unit leave any relation scopes
+// it is still in, so that the relations' unit counts are kept right.
+func (st *State) leaveRemovedUnitScopes(unitName string, maxWait time.Duration) error {
+	relationScopes, closer := st.db().GetCollection(relationScopesC)
+	defer closer()
+	var docs []relationScopeDoc
+	query := bson.D{{"key", bson.D{{"$regex", "#" + regexp.QuoteMeta(unitName) + "$"}}}}
+	if err := relationScopes.Find(query).All(&docs); err != nil {
+		return errors.Trace(err)
+	}
+	for _, doc := range docs {
+		scope, _, _, err := unpackScopeKey(doc.Key)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		relationID, err := strconv.Atoi(strings.Split(scope, "#")[1])
+		if err != nil {
+			return errors.Annotatef(err, "parsing relation scope key %q", doc.Key)
+		}
+		relation, err := st.Relation(relationID)
+		if errors.IsNotFound(err) {
+			// Nothing counts the scope of a removed relation.
+			err := st.db().RunTransaction([]txn.Op{{
+				C:      relationScopesC,
+				Id:     doc.DocID,
+				Remove: true,
+			}})
+			if err != nil {
+				return errors.Trace(err)
+			}
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		ru, err := relation.unit(unitName, "", true, true)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// The scope is taken from the key, since the principal of a
+		// removed subordinate can no longer be looked up.
+		ru.scope = scope
+		opErrs, err := ru.LeaveScopeWithForce(true, maxWait)
+		if len(opErrs) != 0 {
+			logger.Warningf("operational errors leaving scope of relation %v for removed unit %q: %v", relation, unitName, opErrs)
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
 }
 
 func (st *State) cleanupDyingUnitResources(unitId string, cleanupArgs []bson.Raw) error {
@@ -1233,7 +1456,7 @@ func (st *State) cleanupForceDestroyedMachineInternal(machineID string, maxWait
 
 	machine, err := st.Machine(machineID)
 	if errors.IsNotFound(err) {
-		return nil
+		return errors.Trace(st.removeMachineLeftovers(machineID))
 	} else if err != nil {
 		return errors.Trace(err)
 	}
@@ -1335,7 +1558,28 @@ func (st *State) cleanupForceRemoveMachine(machineId string, cleanupArgs []bson.
 	}
 
 	// Remove any storage still attached to the machine.
-	tag := names.NewMachineTag(machineId)
+	if err := forceRemoveMachineVolumeAttachments(sb, names.NewMachineTag(machineId)); err != nil {
+		return errors.Trace(err)
+	}
+
+	machine, err := st.Machine(machineId)
+	if errors.IsNotFound(err) {
+		return errors.Trace(st.removeMachineLeftovers(machineId))
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if err := machine.advanceLifecycle(Dead, true, false, maxWait); err != nil {
+		return errors.Trace(err)
+	}
+	if err := machine.Remove(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(st.removeMachineLeftovers(machineId))
+}
+
+// forceRemoveMachineVolumeAttachments detaches and removes all volumes
+// attached to the machine with the given tag.
+func forceRemoveMachineVolumeAttachments(sb *storageBackend, tag names.MachineTag) error {
 	machineVolumeAttachments, err := sb.MachineVolumeAttachments(tag)
 	if err != nil {
 		return errors.Trace(err)
@@ -1360,17 +1604,87 @@ func (st *State) cleanupForceRemoveMachine(machineId string, cleanupArgs []bson.
 			return errors.Trace(err)
 		}
 	}
+	return nil
+}
 
-	machine, err := st.Machine(machineId)
-	if errors.IsNotFound(err) {
-		return nil
-	} else if err != nil {
+// removeMachineLeftovers removes any documents depending on a machine
+// which remain after the machine itself has been forcibly removed:
+// link-layer devices, addresses, opened ports and storage attachments.
+// Such documents would otherwise block the removal of storage, subnets
+// and eventually the model. Any failure is returned, so that the
+// cleanup is retried.
+func (st *State) removeMachineLeftovers(machineID string) error {
+	tag := names.NewMachineTag(machineID)
+	sb, err := NewStorageBackend(st)
+	if err != nil {
 		return errors.Trace(err)
 	}
-	if err := machine.advanceLifecycle(Dead, true, false, maxWait); err != nil {
+	if err := forceRemoveMachineVolumeAttachments(sb, tag); err != nil {
+		return errors.Annotatef(err, "removing volume attachments for removed machine %s", machineID)
+	}
+	filesystemAttachments, err := sb.MachineFilesystemAttachments(tag)
+	if err != nil {
 		return errors.Trace(err)
 	}
-	return machine.Remove()
+	for _, fa := range filesystemAttachments {
+		if err := sb.DetachFilesystem(tag, fa.Filesystem()); err != nil && !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+		if err := sb.RemoveFilesystemAttachment(tag, fa.Filesystem(), true); err != nil && !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+	}
+
+	buildTxn := func(int) ([]txn.Op, error) {
+		ops, err := st.removeMachineNetworkLeftoversOps(machineID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return ops, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "removing network documents for removed machine %s", machineID)
+	}
+	st.reportForcedRemoval(fmt.Sprintf("forced removal of machine %s complete", machineID))
+	return nil
+}
+
+// removeMachineNetworkLeftoversOps returns the operations needed to
+// remove the link-layer devices, addresses and opened ports left behind
+// by a removed machine.
+func (st *State) removeMachineNetworkLeftoversOps(machineID string) ([]txn.Op, error) {
+	linkLayerDevices, closer := st.db().GetCollection(linkLayerDevicesC)
+	defer closer()
+	var devices []linkLayerDeviceDoc
+	err := linkLayerDevices.Find(bson.D{{"machine-id", machineID}}).Select(bson.D{{"_id", 1}, {"providerid", 1}}).All(&devices)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ops []txn.Op
+	for _, device := range devices {
+		ops = append(ops, removeLinkLayerDeviceUnconditionallyOps(device.DocID)...)
+		if device.ProviderID != "" {
+			ops = append(ops, st.networkEntityGlobalKeyRemoveOp("linklayerdevice", corenetwork.Id(device.ProviderID)))
+		}
+	}
+
+	addressOps, err := st.removeMatchingIPAddressesDocOps(findAddressesQuery(machineID, ""))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, addressOps...)
+
+	portRanges, err := getOpenedMachinePortRanges(st, machineID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if portRanges.docExists {
+		ops = append(ops, portRanges.removeOps()...)
+	}
+	return ops, nil
 }
 
 // cleanupContainers recursively calls cleanupForceDestroyedMachine on the supplied
@@ -1578,9 +1892,21 @@ func cleanupDyingEntityStorage(sb *storageBackend, hostTag names.Tag, manual boo
 // shutdown of units is not going to leave a machine in a difficult state.
 func (st *State) obliterateUnit(unitName string, force bool, maxWait time.Duration) ([]error, error) {
 	var opErrs []error
+	// removeLeftovers sweeps up anything depending on the unit once
+	// it has gone. Under force, a failure to do so is an operational
+	// error rather than one which stops the machine's removal.
+	removeLeftovers := func() []error {
+		if !force {
+			return opErrs
+		}
+		if err := st.removeUnitLeftovers(unitName, maxWait); err != nil {
+			return append(opErrs, err)
+		}
+		return opErrs
+	}
 	unit, err := st.Unit(unitName)
 	if errors.IsNotFound(err) {
-		return opErrs, nil
+		return removeLeftovers(), nil
 	} else if err != nil {
 		return opErrs, err
 	}
@@ -1596,7 +1922,7 @@ func (st *State) obliterateUnit(unitName string, force bool, maxWait time.Durati
 		opErrs = append(opErrs, err)
 	}
 	if err := unit.Refresh(); errors.IsNotFound(err) {
-		return opErrs, nil
+		return removeLeftovers(), nil
 	} else if err != nil {
 		if !force {
 			return opErrs, err
@@ -1629,7 +1955,13 @@ func (st *State) obliterateUnit(unitName string, force bool, maxWait time.Durati
 	}
 	errs, err = unit.RemoveWithForce(force, maxWait)
 	opErrs = append(opErrs, errs...)
-	return opErrs, err
+	if err != nil {
+		if !force {
+			return opErrs, err
+		}
+		opErrs = append(opErrs, err)
+	}
+	return removeLeftovers(), nil
 }
 
 // cleanupAttachmentsForDyingStorage sets all storage attachments related
@@ -5,20 +5,25 @@ package state_test
 
 import (
 	"bytes"
+	"fmt"
+	"io/ioutil"
 	"sort"
 	"time"
 
 	"github.com/juju/charm/v9"
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/caas"
 	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
 	k8stesting "github.com/juju/juju/caas/kubernetes/provider/testing"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/resource/resourcetesting"
 	"github.com/juju/juju/state"
@@ -614,6 +619,52 @@ func (s *CleanupSuite) TestForceDestroyMachineSchedulesRemove(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *CleanupSuite) TestForceRemoveMachineRemovesLeftovers(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.ForceDestroy(time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCleanupRuns(c)
+	assertLifeIs(c, machine, state.Dead)
+
+	// Simulate the machine being removed out from under the forced
+	// removal, leaving a link-layer device behind.
+	machines, closer := state.GetRawCollection(s.State, "machines")
+	defer closer()
+	err = machines.RemoveId(s.State.ModelUUID() + ":" + machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	devices, closer := state.GetRawCollection(s.State, "linklayerdevices")
+	defer closer()
+	err = devices.Insert(bson.M{
+		"_id":        s.State.ModelUUID() + ":m#" + machine.Id() + "#d#eth0",
+		"model-uuid": s.State.ModelUUID(),
+		"machine-id": machine.Id(),
+		"name":       "eth0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Clock.Advance(time.Minute)
+	s.assertCleanupCount(c, 1)
+
+	count, err := devices.Find(bson.M{"machine-id": machine.Id()}).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+	s.assertForcedRemovalReported(c, fmt.Sprintf("forced removal of machine %s complete", machine.Id()))
+}
+
+func (s *CleanupSuite) assertForcedRemovalReported(c *gc.C, message string) {
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	history, err := model.StatusHistory(status.StatusHistoryFilter{Size: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	for _, info := range history {
+		if info.Message == message {
+			return
+		}
+	}
+	c.Fatalf("%q not found in model status history %v", message, history)
+}
+
 func (s *CleanupSuite) TestForceDestroyMachineRemovesUpgradeSeriesLock(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -893,6 +944,108 @@ func (s *CleanupSuite) TestCleanupStorageInstances(c *gc.C) {
 	s.assertDoesNotNeedCleanup(c)
 }
 
+func (s *CleanupSuite) TestCleanupDyingLeaderWaitsForStorageHolders(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	storage := map[string]state.StorageConstraints{
+		"data": makeStorageCons("loop", 1024, 1),
+	}
+	application := s.AddTestingApplicationWithStorage(c, "storage-block", ch, storage)
+	leader, err := application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	other, err := application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	target := s.State.LeaseNotifyTarget(ioutil.Discard, loggo.GetLogger("cleanup_test"))
+	target.Claimed(lease.Key{
+		Namespace: lease.ApplicationLeadershipNamespace,
+		ModelUUID: s.State.ModelUUID(),
+		Lease:     application.Name(),
+	}, leader.Name())
+
+	err = leader.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = other.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCleanupRuns(c)
+
+	// The other unit's storage is detached, but the leader's is held
+	// back while another dying unit held storage.
+	_, err = s.storageBackend.StorageAttachment(names.NewStorageTag("data/1"), other.UnitTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	sa, err := s.storageBackend.StorageAttachment(names.NewStorageTag("data/0"), leader.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sa.Life(), gc.Equals, state.Alive)
+
+	// The leader's cleanup is retried later, and then proceeds.
+	s.assertNeedsCleanup(c)
+	s.Clock.Advance(time.Minute)
+	s.assertCleanupRuns(c)
+	_, err = s.storageBackend.StorageAttachment(names.NewStorageTag("data/0"), leader.UnitTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	s.assertDoesNotNeedCleanup(c)
+}
+
+func (s *CleanupSuite) TestCleanupDyingLeaderWaitIsBounded(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	storage := map[string]state.StorageConstraints{
+		"data": makeStorageCons("loop", 1024, 1),
+	}
+	application := s.AddTestingApplicationWithStorage(c, "storage-block", ch, storage)
+	leader, err := application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	other, err := application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	target := s.State.LeaseNotifyTarget(ioutil.Discard, loggo.GetLogger("cleanup_test"))
+	target.Claimed(lease.Key{
+		Namespace: lease.ApplicationLeadershipNamespace,
+		ModelUUID: s.State.ModelUUID(),
+		Lease:     application.Name(),
+	}, leader.Name())
+
+	// Attach the other unit's volume, so that its storage attachment
+	// stays until its agent removes it, which never happens here.
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = other.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+	err = other.SetAgentStatus(status.StatusInfo{
+		Status: status.Idle,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetProvisioned("inst-id", "", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	volume, err := s.storageBackend.StorageInstanceVolume(names.NewStorageTag("data/1"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.SetVolumeInfo(volume.VolumeTag(), state.VolumeInfo{VolumeId: "vol-123"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.SetVolumeAttachmentInfo(
+		machine.MachineTag(),
+		volume.VolumeTag(),
+		state.VolumeAttachmentInfo{DeviceName: "sdc"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = leader.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = other.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCleanupRuns(c)
+
+	// The other unit is stuck, so the leader keeps waiting for a while.
+	_, err = s.storageBackend.StorageAttachment(names.NewStorageTag("data/1"), other.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(time.Minute)
+	s.assertCleanupRuns(c)
+	sa, err := s.storageBackend.StorageAttachment(names.NewStorageTag("data/0"), leader.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sa.Life(), gc.Equals, state.Alive)
+
+	// Once the maximum wait has passed, the leader's removal proceeds.
+	s.Clock.Advance(10 * time.Minute)
+	s.assertCleanupRuns(c)
+	_, err = s.storageBackend.StorageAttachment(names.NewStorageTag("data/0"), leader.UnitTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *CleanupSuite) TestCleanupMachineStorage(c *gc.C) {
 	ch := s.AddTestingCharm(c, "storage-block")
 	storage := map[string]state.StorageConstraints{
@@ -1258,6 +1411,57 @@ func (s *CleanupSuite) TestForceDestroyUnitLeavesRelations(c *gc.C) {
 	s.assertCleanupCount(c, 2)
 }
 
+func (s *CleanupSuite) TestForceDestroyRemovedUnitLeavesRelations(c *gc.C) {
+	prr := newProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
+	prr.allEnterScope(c)
+
+	unit := prr.pu0
+	opErrs, err := unit.DestroyWithForce(true, dontWait)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(opErrs, gc.IsNil)
+
+	// dyingUnit schedules forceDestroyedUnit
+	s.assertCleanupRuns(c)
+
+	// Simulate the unit being removed without leaving its scopes.
+	units, closer := state.GetRawCollection(s.State, "units")
+	defer closer()
+	err = units.RemoveId(s.State.ModelUUID() + ":" + unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	assertUnitInScope(c, unit, prr.rel, true)
+
+	// forceDestroyedUnit finds the unit gone and leaves its scopes.
+	s.assertCleanupRuns(c)
+	assertUnitInScope(c, unit, prr.rel, false)
+	err = prr.rel.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(prr.rel.UnitCount(), gc.Equals, 3)
+	s.assertForcedRemovalReported(c, `forced removal of unit "mysql/0" complete`)
+}
+
+func (s *CleanupSuite) TestForceDestroyMachineWithRemovedUnitLeavesRelations(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	prr := newProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal, machine)
+	prr.allEnterScope(c)
+	unit := prr.pu0
+
+	// Simulate the unit being removed without leaving its scopes.
+	units, closer := state.GetRawCollection(s.State, "units")
+	defer closer()
+	err = units.RemoveId(s.State.ModelUUID() + ":" + unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.ForceDestroy(time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCleanupRuns(c)
+
+	// Obliterating the machine's units sweeps up the missing unit's
+	// scopes rather than skipping it.
+	assertUnitInScope(c, unit, prr.rel, false)
+	s.assertForcedRemovalReported(c, `forced removal of unit "mysql/0" complete`)
+}
+
 func (s *CleanupSuite) TestForceDestroyUnitRemovesStorageAttachments(c *gc.C) {
 	s.assertDoesNotNeedCleanup(c)
 
@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -35,6 +36,49 @@ func (c Credential) IsValid() bool {
 	return !c.cloudCredentialDoc.Invalid
 }
 
+// CredentialExpiryStatus describes how close a credential is to expiry.
+type CredentialExpiryStatus string
+
+const (
+	// CredentialNotExpiring is the status of credentials which don't
+	// expire, or don't expire soon.
+	CredentialNotExpiring CredentialExpiryStatus = ""
+
+	// CredentialExpiring is the status of credentials which expire
+	// within the warning period.
+	CredentialExpiring CredentialExpiryStatus = "expiring"
+
+	// CredentialExpired is the status of credentials which have expired.
+	CredentialExpired CredentialExpiryStatus = "expired"
+)
+
+// Expiry returns when the credential expires, and whether it expires
+// at all.
+func (c Credential) Expiry() (time.Time, bool) {
+	return cloud.CredentialExpiry(cloud.NewCredential(cloud.AuthType(c.AuthType), c.Attributes))
+}
+
+// ExpiryStatus returns the expiry status of the credential at the given
+// time, along with when it expires.
+func (c Credential) ExpiryStatus(now time.Time, warningPeriod time.Duration) (CredentialExpiryStatus, time.Time) {
+	expiry, ok := c.Expiry()
+	switch {
+	case !ok:
+		return CredentialNotExpiring, time.Time{}
+	case !now.Before(expiry):
+		return CredentialExpired, expiry
+	case now.Add(warningPeriod).After(expiry):
+		return CredentialExpiring, expiry
+	}
+	return CredentialNotExpiring, expiry
+}
+
+// ExpiryNotified returns the latest expiry status that has been notified
+// for the credential. It is reset whenever the credential is updated.
+func (c Credential) ExpiryNotified() CredentialExpiryStatus {
+	return c.cloudCredentialDoc.ExpiryNotifiedStatus
+}
+
 // cloudCredentialDoc records information about a user's cloud credentials.
 type cloudCredentialDoc struct {
 	DocID      string            `bson:"_id"`
@@ -60,6 +104,10 @@ type cloudCredentialDoc struct {
 	// This can range from cloud messages such as an expired credential to
 	// commercial reasons set via CLI or api calls.
 	InvalidReason string `bson:"invalid-reason,omitempty"`
+
+	// ExpiryNotifiedStatus records the latest expiry status that has been
+	// notified for the credential, so that each is notified once.
+	ExpiryNotifiedStatus CredentialExpiryStatus `bson:"expiry-notified,omitempty"`
 }
 
 // CloudCredential returns the cloud credential for the given tag.
@@ -78,6 +126,9 @@ func (st *State) CloudCredential(tag names.CloudCredentialTag) (Credential, erro
 			err, "getting cloud credential %q", tag.Id(),
 		)
 	}
+	if err := st.openAttributes(doc.Attributes); err != nil {
+		return Credential{}, errors.Annotatef(err, "getting cloud credential %q", tag.Id())
+	}
 	return Credential{doc}, nil
 }
 
@@ -100,6 +151,9 @@ func (st *State) CloudCredentials(user names.UserTag, cloudName string) (map[str
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		if err := st.openAttributes(doc.Attributes); err != nil {
+			return nil, errors.Annotatef(err, "getting cloud credential %q", tag.Id())
+		}
 		credentials[tag.Id()] = Credential{doc}
 	}
 	if err := iter.Close(); err != nil {
@@ -163,6 +217,15 @@ func (st *State) UpdateCloudCredential(tag names.CloudCredentialTag, credential
 		}
 	}
 
+	sealer, err := st.secretSealer()
+	if err != nil {
+		return errors.Annotate(err, annotationMsg)
+	}
+	attrs, err := sealedAttributes(sealer, credential.Attributes())
+	if err != nil {
+		return errors.Annotate(err, annotationMsg)
+	}
+
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		cloudName := tag.Cloud().Id()
 		aCloud, err := st.Cloud(cloudName)
@@ -174,13 +237,13 @@ func (st *State) UpdateCloudCredential(tag names.CloudCredentialTag, credential
 			return nil, errors.Trace(err)
 		}
 		if exists {
-			ops = append(ops, updateCloudCredentialOp(tag, credential))
+			ops = append(ops, updateCloudCredentialOp(tag, credential, attrs))
 		} else {
 			annotationMsg = "creating cloud credential"
 			if credential.Invalid || credential.InvalidReason != "" {
 				return nil, errors.NotSupportedf("adding invalid credential")
 			}
-			ops = append(ops, createCloudCredentialOp(tag, credential))
+			ops = append(ops, createCloudCredentialOp(tag, credential, attrs))
 		}
 		return ops, nil
 	}
@@ -267,6 +330,29 @@ func (st *State) InvalidateCloudCredential(tag names.CloudCredentialTag, reason
 	return nil
 }
 
+// SetCloudCredentialExpiryNotified records that the given expiry status
+// has been notified for the cloud credential with the given tag.
+func (st *State) SetCloudCredentialExpiryNotified(tag names.CloudCredentialTag, expiryStatus CredentialExpiryStatus) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		_, err := st.CloudCredential(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      cloudCredentialsC,
+			Id:     cloudCredentialDocID(tag),
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"expiry-notified", expiryStatus},
+			}}},
+		}}, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "recording expiry notification for cloud credential %v", tag.Id())
+	}
+	return nil
+}
+
 // RemoveCloudCredential removes a cloud credential with the given tag.
 func (st *State) RemoveCloudCredential(tag names.CloudCredentialTag) error {
 	buildTxn := func(attempt int) ([]txn.Op, error) {
@@ -286,8 +372,8 @@ func (st *State) RemoveCloudCredential(tag names.CloudCredentialTag) error {
 }
 
 // createCloudCredentialOp returns a txn.Op that will create
-// a cloud credential.
-func createCloudCredentialOp(tag names.CloudCredentialTag, cred cloud.Credential) txn.Op {
+// a cloud credential with the given, possibly sealed, attributes.
+func createCloudCredentialOp(tag names.CloudCredentialTag, cred cloud.Credential, attrs map[string]string) txn.Op {
 	return txn.Op{
 		C:      cloudCredentialsC,
 		Id:     cloudCredentialDocID(tag),
@@ -297,25 +383,27 @@ func createCloudCredentialOp(tag names.CloudCredentialTag, cred cloud.Credential
 			Cloud:      tag.Cloud().Id(),
 			Name:       tag.Name(),
 			AuthType:   string(cred.AuthType()),
-			Attributes: cred.Attributes(),
+			Attributes: attrs,
 			Revoked:    cred.Revoked,
 		},
 	}
 }
 
 // updateCloudCredentialOp returns a txn.Op that will update
-// a cloud credential.
-func updateCloudCredentialOp(tag names.CloudCredentialTag, cred cloud.Credential) txn.Op {
+// a cloud credential with the given, possibly sealed, attributes.
+func updateCloudCredentialOp(tag names.CloudCredentialTag, cred cloud.Credential, attrs map[string]string) txn.Op {
 	return txn.Op{
 		C:      cloudCredentialsC,
 		Id:     cloudCredentialDocID(tag),
 		Assert: txn.DocExists,
 		Update: bson.D{{"$set", bson.D{
 			{"auth-type", string(cred.AuthType())},
-			{"attributes", cred.Attributes()},
+			{"attributes", attrs},
 			{"revoked", cred.Revoked},
 			{"invalid", cred.Invalid},
 			{"invalid-reason", cred.InvalidReason},
+		}}, {"$unset", bson.D{
+			{"expiry-notified", nil},
 		}}},
 	}
 }
@@ -438,6 +526,9 @@ func (st *State) AllCloudCredentials(user names.UserTag) ([]Credential, error) {
 
 	credentials := make([]Credential, len(docs))
 	for i, doc := range docs {
+		if err := st.openAttributes(doc.Attributes); err != nil {
+			return nil, errors.Annotatef(err, "getting cloud credential %q", doc.DocID)
+		}
 		credentials[i] = Credential{doc}
 	}
 	return credentials, nil
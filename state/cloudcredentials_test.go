@@ -4,8 +4,19 @@
 package state_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -14,6 +25,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
@@ -587,3 +599,148 @@ func (s *CloudCredentialsSuite) TestInvalidateCloudCredentialNotFound(c *gc.C) {
 	err := s.State.InvalidateCloudCredential(tag, "just does not matter")
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
+
+func (s *CloudCredentialsSuite) TestUpdateCloudCredentialSealedWithVault(c *gc.C) {
+	// A fake Vault transit engine which "wraps" keys by prefixing them.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		c.Check(json.NewDecoder(r.Body).Decode(&req), jc.ErrorIsNil)
+		var data map[string]string
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/juju":
+			data = map[string]string{"ciphertext": "vault:v1:" + req["plaintext"]}
+		case "/v1/transit/decrypt/juju":
+			data = map[string]string{"plaintext": strings.TrimPrefix(req["ciphertext"], "vault:v1:")}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	}))
+	defer srv.Close()
+
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.SecretBackend:             "vault",
+		controller.SecretBackendVaultAddress: srv.URL,
+		controller.SecretBackendVaultToken:   "s.token",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.AddCloud(cloud.Cloud{
+		Name:      "stratus",
+		Type:      "low",
+		AuthTypes: cloud.AuthTypes{cloud.UserPassAuthType},
+	}, s.Owner.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	tag := names.NewCloudCredentialTag("stratus/bob/foobar")
+	cred := cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
+		"username": "bob",
+		"password": "sekrit",
+	})
+	err = s.State.UpdateCloudCredential(tag, cred)
+	c.Assert(err, jc.ErrorIsNil)
+
+	coll, closer := state.GetRawCollection(s.State, "cloudCredentials")
+	defer closer()
+	var doc struct {
+		Attributes map[string]string `bson:"attributes"`
+	}
+	err = coll.FindId("stratus#bob#foobar").One(&doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(doc.Attributes, gc.HasLen, 2)
+	for _, v := range doc.Attributes {
+		c.Assert(v, gc.Matches, "sealed:v1:.*")
+	}
+
+	out, err := s.State.CloudCredential(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Attributes, jc.DeepEquals, map[string]string{
+		"username": "bob",
+		"password": "sekrit",
+	})
+}
+
+func (s *CloudCredentialsSuite) addExpiringCredential(c *gc.C, name string, notAfter time.Time) names.CloudCredentialTag {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	c.Assert(err, jc.ErrorIsNil)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "juju-client"},
+		NotBefore:    notAfter.Add(-30 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	c.Assert(err, jc.ErrorIsNil)
+
+	dummy, err := s.State.Cloud("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	if !dummy.AuthTypes.Contains(cloud.CertificateAuthType) {
+		dummy.AuthTypes = append(dummy.AuthTypes, cloud.CertificateAuthType)
+		err = s.State.UpdateCloud(dummy)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	tag := names.NewCloudCredentialTag("dummy/" + s.Owner.Id() + "/" + name)
+	cred := cloud.NewCredential(cloud.CertificateAuthType, map[string]string{
+		"client-cert": string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+	})
+	err = s.State.UpdateCloudCredential(tag, cred)
+	c.Assert(err, jc.ErrorIsNil)
+	return tag
+}
+
+func (s *CloudCredentialsSuite) TestCredentialExpiryStatus(c *gc.C) {
+	now := s.Clock.Now().Truncate(time.Second)
+	tag := s.addExpiringCredential(c, "expiring", now.Add(48*time.Hour))
+	cred, err := s.State.CloudCredential(tag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	expiryStatus, expiry := cred.ExpiryStatus(now, 24*time.Hour)
+	c.Assert(expiryStatus, gc.Equals, state.CredentialNotExpiring)
+	c.Assert(expiry.Equal(now.Add(48*time.Hour)), jc.IsTrue)
+
+	expiryStatus, _ = cred.ExpiryStatus(now, 72*time.Hour)
+	c.Assert(expiryStatus, gc.Equals, state.CredentialExpiring)
+
+	expiryStatus, _ = cred.ExpiryStatus(now.Add(48*time.Hour), 72*time.Hour)
+	c.Assert(expiryStatus, gc.Equals, state.CredentialExpired)
+}
+
+func (s *CloudCredentialsSuite) TestSetCloudCredentialExpiryNotified(c *gc.C) {
+	tag := s.addExpiringCredential(c, "expiring", s.Clock.Now().Add(48*time.Hour))
+	err := s.State.SetCloudCredentialExpiryNotified(tag, state.CredentialExpiring)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cred, err := s.State.CloudCredential(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cred.ExpiryNotified(), gc.Equals, state.CredentialExpiring)
+
+	// Rotating the credential resets the notification.
+	err = s.State.UpdateCloudCredential(tag, cloud.NewCredential(cloud.EmptyAuthType, nil))
+	c.Assert(err, jc.ErrorIsNil)
+	cred, err = s.State.CloudCredential(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cred.ExpiryNotified(), gc.Equals, state.CredentialNotExpiring)
+}
+
+func (s *CloudCredentialsSuite) TestModelStatusCredentialExpiring(c *gc.C) {
+	notAfter := s.Clock.Now().Add(48 * time.Hour).Truncate(time.Second)
+	tag := s.addExpiringCredential(c, "expiring", notAfter)
+	st := s.Factory.MakeModel(c, &factory.ModelParams{
+		Owner:           s.Owner,
+		CloudCredential: tag,
+	})
+	defer st.Close()
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelStatus, err := m.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelStatus.Status, gc.Equals, status.Available)
+	c.Assert(modelStatus.Message, gc.Equals, "cloud credential expires at "+notAfter.UTC().Format(time.RFC3339))
+
+	err = s.State.InvalidateCloudCredential(tag, "expired")
+	c.Assert(err, jc.ErrorIsNil)
+	modelStatus, err = m.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelStatus.Status, gc.Equals, status.Suspended)
+}
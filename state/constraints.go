@@ -22,6 +22,10 @@ type constraintsDoc struct {
 	Arch             *string
 	CpuCores         *uint64
 	CpuPower         *uint64
+	Gpus             *uint64
+	GpuType          *string
+	CpuQuota         *uint64
+	MemoryLimit      *uint64
 	Mem              *uint64
 	RootDisk         *uint64
 	RootDiskSource   *string
@@ -40,6 +44,10 @@ func newConstraintsDoc(cons constraints.Value, id string) constraintsDoc {
 		Arch:             cons.Arch,
 		CpuCores:         cons.CpuCores,
 		CpuPower:         cons.CpuPower,
+		Gpus:             cons.Gpus,
+		GpuType:          cons.GpuType,
+		CpuQuota:         cons.CpuQuota,
+		MemoryLimit:      cons.MemoryLimit,
 		Mem:              cons.Mem,
 		RootDisk:         cons.RootDisk,
 		RootDiskSource:   cons.RootDiskSource,
@@ -59,6 +67,10 @@ func (doc constraintsDoc) value() constraints.Value {
 		Arch:             doc.Arch,
 		CpuCores:         doc.CpuCores,
 		CpuPower:         doc.CpuPower,
+		Gpus:             doc.Gpus,
+		GpuType:          doc.GpuType,
+		CpuQuota:         doc.CpuQuota,
+		MemoryLimit:      doc.MemoryLimit,
 		Mem:              doc.Mem,
 		RootDisk:         doc.RootDisk,
 		RootDiskSource:   doc.RootDiskSource,
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	"gopkg.in/mgo.v2"
@@ -124,6 +125,12 @@ func (st *State) UpdateControllerConfig(updateAttrs map[string]interface{}, remo
 }
 
 func (st *State) checkValidControllerConfig(updateAttrs map[string]interface{}, removeAttrs []string) error {
+	if err := st.checkBlobstoreConfigUpdate(updateAttrs, removeAttrs); err != nil {
+		return errors.Trace(err)
+	}
+	if err := st.checkSecretBackendConfigUpdate(updateAttrs, removeAttrs); err != nil {
+		return errors.Trace(err)
+	}
 	for k := range updateAttrs {
 		if err := checkUpdateControllerConfig(k); err != nil {
 			return errors.Trace(err)
@@ -144,6 +151,64 @@ func (st *State) checkValidControllerConfig(updateAttrs map[string]interface{},
 	return nil
 }
 
+// checkBlobstoreConfigUpdate ensures that the blobstore settings are not
+// changed once blob data is held in S3, as doing so would leave that
+// data behind.
+func (st *State) checkBlobstoreConfigUpdate(updateAttrs map[string]interface{}, removeAttrs []string) error {
+	var changed []string
+	for k := range updateAttrs {
+		if jujucontroller.BlobstoreConfigAttributes.Contains(k) {
+			changed = append(changed, k)
+		}
+	}
+	for _, k := range removeAttrs {
+		if jujucontroller.BlobstoreConfigAttributes.Contains(k) {
+			changed = append(changed, k)
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	cfg, err := st.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if cfg.BlobstoreBackend() == jujucontroller.BlobstoreBackendS3 {
+		return errors.Errorf("can't change %q once %s is %q", changed[0], jujucontroller.BlobstoreBackend, jujucontroller.BlobstoreBackendS3)
+	}
+	return nil
+}
+
+// checkSecretBackendConfigUpdate ensures that the secret backend and
+// transit key are not changed once secrets are sealed with Vault, as
+// doing so would leave them unreadable. The Vault address and token
+// may still be changed.
+func (st *State) checkSecretBackendConfigUpdate(updateAttrs map[string]interface{}, removeAttrs []string) error {
+	fixed := set.NewStrings(jujucontroller.SecretBackend, jujucontroller.SecretBackendVaultTransitKey)
+	var changed []string
+	for k := range updateAttrs {
+		if fixed.Contains(k) {
+			changed = append(changed, k)
+		}
+	}
+	for _, k := range removeAttrs {
+		if fixed.Contains(k) {
+			changed = append(changed, k)
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	cfg, err := st.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if cfg.SecretBackend() == jujucontroller.SecretBackendVault {
+		return errors.Errorf("can't change %q once %s is %q", changed[0], jujucontroller.SecretBackend, jujucontroller.SecretBackendVault)
+	}
+	return nil
+}
+
 func checkUpdateControllerConfig(name string) error {
 	if !jujucontroller.ControllerOnlyAttribute(name) {
 		return errors.Errorf("unknown controller config setting %q", name)
@@ -268,6 +333,9 @@ func (st *State) StateServingInfo() (jujucontroller.StateServingInfo, error) {
 	if info.StatePort == 0 {
 		return jujucontroller.StateServingInfo{}, errors.NotFoundf("state serving info")
 	}
+	if err := st.openSecrets(&info.PrivateKey, &info.CAPrivateKey, &info.SharedSecret, &info.SystemIdentity); err != nil {
+		return jujucontroller.StateServingInfo{}, errors.Annotate(err, "reading state serving info")
+	}
 	return jujucontroller.StateServingInfo{
 		APIPort:        info.APIPort,
 		StatePort:      info.StatePort,
@@ -293,6 +361,13 @@ func (st *State) SetStateServingInfo(info jujucontroller.StateServingInfo) error
 		// until an upgrade process is written.
 		logger.Warningf("state serving info has no CA certificate key")
 	}
+	sealer, err := st.secretSealer()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := sealSecrets(sealer, &info.PrivateKey, &info.CAPrivateKey, &info.SharedSecret, &info.SystemIdentity); err != nil {
+		return errors.Annotate(err, "storing state serving info")
+	}
 	ops := []txn.Op{{
 		C:  controllersC,
 		Id: stateServingInfoKey,
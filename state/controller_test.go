@@ -31,22 +31,45 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 	optional := set.NewStrings(
 		controller.AgentRateLimitMax,
 		controller.AgentRateLimitRate,
+		controller.AgentPingTimeout,
 		controller.AllowModelAccessKey,
 		controller.APIPortOpenDelay,
 		controller.AuditLogExcludeMethods,
 		controller.AutocertURLKey,
 		controller.AutocertDNSNameKey,
+		controller.BlobstoreBackend,
+		controller.BlobstoreS3AccessKey,
+		controller.BlobstoreS3Bucket,
+		controller.BlobstoreS3Endpoint,
+		controller.BlobstoreS3Region,
+		controller.BlobstoreS3SecretKey,
 		controller.CAASImageRepo,
+		controller.APIAdvertiseCIDRs,
+		controller.APIAdvertiseScopes,
 		controller.CAASOperatorImagePath,
 		controller.CharmStoreURL,
+		controller.CharmVettingWebhookURL,
+		controller.CharmSignaturePolicy,
+		controller.CharmSigningKeys,
+		controller.CharmSandboxSysctls,
+		controller.CharmSandboxAppArmor,
+		controller.AgentMetadataPublicKey,
 		controller.ControllerAPIPort,
+		controller.CredentialExpiryWebhookURL,
 		controller.ControllerName,
+		controller.ExternalMongoCACert,
+		controller.ExternalMongoURI,
 		controller.Features,
 		controller.IdentityURL,
 		controller.IdentityPublicKey,
 		controller.JujuDBSnapChannel,
 		controller.JujuHASpace,
 		controller.JujuManagementSpace,
+		controller.LifecycleWebhookURL,
+		controller.LifecycleWebhookSecret,
+		controller.LifecycleWebhookEvents,
+		controller.APIGatewayToken,
+		controller.ControllerCustomizationScript,
 		controller.MaxDebugLogDuration,
 		controller.MaxPruneTxnBatchSize,
 		controller.MaxPruneTxnPasses,
@@ -56,9 +79,18 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 		controller.MongoMemoryProfile,
 		controller.PruneTxnQueryCount,
 		controller.PruneTxnSleepTime,
+		controller.PruneTxnMaxSize,
+		controller.RemoveOrphanedDocuments,
 		controller.PublicDNSAddress,
+		controller.SecretBackend,
+		controller.SecretBackendVaultAddress,
+		controller.SecretBackendVaultCACert,
+		controller.SecretBackendVaultToken,
+		controller.SecretBackendVaultTransitKey,
 		controller.MaxCharmStateSize,
 		controller.MaxAgentStateSize,
+		controller.MaxModelsPerUser,
+		controller.MaxUnitsPerModel,
 		controller.NonSyncedWritesToRaftLog,
 	)
 	for _, controllerAttr := range controller.ControllerOnlyConfigAttributes {
@@ -145,6 +177,44 @@ func (s *ControllerSuite) TestUpdateControllerConfigRejectsDisallowedUpdates(c *
 	c.Assert(err, gc.ErrorMatches, `can't change "api-port" after bootstrap`)
 }
 
+func (s *ControllerSuite) TestUpdateControllerConfigBlobstoreFixedOnceS3(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.BlobstoreBackend:  "s3",
+		controller.BlobstoreS3Bucket: "juju",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.BlobstoreS3Bucket: "other",
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, `can't change "blobstore-s3-bucket" once blobstore-backend is "s3"`)
+
+	err = s.State.UpdateControllerConfig(nil, []string{controller.BlobstoreBackend})
+	c.Assert(err, gc.ErrorMatches, `can't change "blobstore-backend" once blobstore-backend is "s3"`)
+}
+
+func (s *ControllerSuite) TestUpdateControllerConfigSecretBackendFixedOnceVault(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.SecretBackend:             "vault",
+		controller.SecretBackendVaultAddress: "https://vault.example.com:8200",
+		controller.SecretBackendVaultToken:   "s.token",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.SecretBackendVaultToken: "s.other",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.SecretBackendVaultTransitKey: "transit/other",
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, `can't change "secret-backend-vault-transit-key" once secret-backend is "vault"`)
+
+	err = s.State.UpdateControllerConfig(nil, []string{controller.SecretBackend})
+	c.Assert(err, gc.ErrorMatches, `can't change "secret-backend" once secret-backend is "vault"`)
+}
+
 func (s *ControllerSuite) TestUpdateControllerConfigChecksSchema(c *gc.C) {
 	err := s.State.UpdateControllerConfig(map[string]interface{}{
 		controller.AuditLogExcludeMethods: []int{1, 2, 3},
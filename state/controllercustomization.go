@@ -0,0 +1,95 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// CustomizationStatus describes the progress of the controller
+// customization script on a controller node.
+type CustomizationStatus string
+
+const (
+	// CustomizationRunning means that the script is running.
+	CustomizationRunning CustomizationStatus = "running"
+
+	// CustomizationCompleted means that the script exited successfully.
+	CustomizationCompleted CustomizationStatus = "completed"
+
+	// CustomizationFailed means that the script could not be run, or
+	// exited with an error.
+	CustomizationFailed CustomizationStatus = "failed"
+)
+
+// ControllerCustomization records the outcome of running the
+// controller-customization-script on a controller node.
+type ControllerCustomization struct {
+	// Hash is the SHA256 hash of the script that was run.
+	Hash string
+
+	// Status is the progress of the script.
+	Status CustomizationStatus
+
+	// Message holds the reason the script failed.
+	Message string
+
+	// Since is when the status was set.
+	Since time.Time
+}
+
+// controllerCustomizationDoc is stored in the controller node document.
+type controllerCustomizationDoc struct {
+	Hash    string    `bson:"hash"`
+	Status  string    `bson:"status"`
+	Message string    `bson:"message,omitempty"`
+	Since   time.Time `bson:"since"`
+}
+
+// Customization returns the outcome of running the controller
+// customization script on the controller node. The second result is
+// false if the script has never been run on the node.
+func (c *controllerNode) Customization() (ControllerCustomization, bool) {
+	doc := c.doc.Customization
+	if doc == nil {
+		return ControllerCustomization{}, false
+	}
+	return ControllerCustomization{
+		Hash:    doc.Hash,
+		Status:  CustomizationStatus(doc.Status),
+		Message: doc.Message,
+		Since:   doc.Since.UTC(),
+	}, true
+}
+
+// SetCustomization records the outcome of running the controller
+// customization script on the controller node.
+func (c *controllerNode) SetCustomization(customization ControllerCustomization) error {
+	switch customization.Status {
+	case CustomizationRunning, CustomizationCompleted, CustomizationFailed:
+	default:
+		return errors.NotValidf("customization status %q", customization.Status)
+	}
+	doc := &controllerCustomizationDoc{
+		Hash:    customization.Hash,
+		Status:  string(customization.Status),
+		Message: customization.Message,
+		Since:   customization.Since.UTC().Round(time.Millisecond),
+	}
+	ops := []txn.Op{{
+		C:      controllerNodesC,
+		Id:     c.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"customization", doc}}}},
+	}}
+	if err := c.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(err, "cannot set customization for controller %s", c.Id())
+	}
+	c.doc.Customization = doc
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type ControllerCustomizationSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ControllerCustomizationSuite{})
+
+func (s *ControllerCustomizationSuite) TestSetCustomization(c *gc.C) {
+	m, err := s.State.AddMachine("bionic", state.JobHostUnits, state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+	node, err := s.State.ControllerNode(m.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, ok := node.Customization()
+	c.Assert(ok, jc.IsFalse)
+
+	since := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	err = node.SetCustomization(state.ControllerCustomization{
+		Hash:    "abc123",
+		Status:  state.CustomizationFailed,
+		Message: "exit status 1",
+		Since:   since,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	node, err = s.State.ControllerNode(m.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	customization, ok := node.Customization()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(customization, jc.DeepEquals, state.ControllerCustomization{
+		Hash:    "abc123",
+		Status:  state.CustomizationFailed,
+		Message: "exit status 1",
+		Since:   since,
+	})
+}
+
+func (s *ControllerCustomizationSuite) TestSetCustomizationInvalidStatus(c *gc.C) {
+	m, err := s.State.AddMachine("bionic", state.JobHostUnits, state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+	node, err := s.State.ControllerNode(m.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = node.SetCustomization(state.ControllerCustomization{
+		Hash:   "abc123",
+		Status: "exploded",
+	})
+	c.Assert(err, gc.ErrorMatches, `customization status "exploded" not valid`)
+}
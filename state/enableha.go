@@ -399,6 +399,8 @@ type ControllerNode interface {
 	SetHasVote(hasVote bool) error
 	Watch() NotifyWatcher
 	SetMongoPassword(password string) error
+	Customization() (ControllerCustomization, bool)
+	SetCustomization(customization ControllerCustomization) error
 }
 
 // ControllerIds returns the ids of the controller nodes.
@@ -545,6 +547,8 @@ type controllerNodeDoc struct {
 	WantsVote    bool         `bson:"wants-vote"`
 	PasswordHash string       `bson:"password-hash"`
 	AgentVersion *tools.Tools `bson:"agent-version,omitempty"`
+
+	Customization *controllerCustomizationDoc `bson:"customization,omitempty"`
 }
 
 // Id returns the controller id.
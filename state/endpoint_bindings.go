@@ -447,6 +447,43 @@ func (b *Bindings) validateForCharm(charmMeta *charm.Meta) error {
 	return nil
 }
 
+// MoveEndpointBindingsOps returns the transaction operations required to
+// rebind every application endpoint bound to the space with ID fromSpaceID
+// to the space with ID toSpaceID. Each operation asserts that the bindings
+// have not changed since they were read.
+func (st *State) MoveEndpointBindingsOps(fromSpaceID, toSpaceID string) ([]txn.Op, error) {
+	endpointBindings, closer := st.db().GetCollection(endpointBindingsC)
+	defer closer()
+
+	var docs []endpointBindingsDoc
+	if err := endpointBindings.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get endpoint bindings")
+	}
+
+	var ops []txn.Op
+	for _, doc := range docs {
+		var changed bool
+		escaped := make(bson.M, len(doc.Bindings))
+		for endpoint, spaceID := range doc.Bindings {
+			if spaceID == fromSpaceID {
+				spaceID = toSpaceID
+				changed = true
+			}
+			escaped[utils.EscapeKey(endpoint)] = spaceID
+		}
+		if !changed {
+			continue
+		}
+		ops = append(ops, txn.Op{
+			C:      endpointBindingsC,
+			Id:     st.localID(doc.DocID),
+			Assert: bson.D{{"txn-revno", doc.TxnRevno}},
+			Update: bson.M{"$set": bson.M{"bindings": escaped}},
+		})
+	}
+	return ops, nil
+}
+
 // DefaultEndpointBindingSpace returns the current space ID to be used for
 // the default endpoint binding.
 func (st *State) DefaultEndpointBindingSpace() (string, error) {
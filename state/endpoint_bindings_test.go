@@ -324,6 +324,31 @@ func (s *bindingsSuite) TestDefaultEndpointBindingSpaceDefault(c *gc.C) {
 	c.Assert(id, gc.Equals, network.AlphaSpaceId)
 }
 
+func (s *bindingsSuite) TestMoveEndpointBindingsOps(c *gc.C) {
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 1)
+	app := s.AddTestingApplicationWithBindings(c, "mysql", ch, map[string]string{
+		"":       s.appsSpace.Name(),
+		"server": s.dbSpace.Name(),
+	})
+	other := s.AddTestingApplicationWithBindings(c, "other", ch, map[string]string{
+		"": s.appsSpace.Name(),
+	})
+
+	ops, err := s.State.MoveEndpointBindingsOps(s.dbSpace.Id(), s.clientSpace.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ops, gc.HasLen, 1)
+	state.RunTransaction(c, s.State, ops)
+
+	bindings, err := app.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(bindings.Map()[""], gc.Equals, s.appsSpace.Id())
+	c.Check(bindings.Map()["server"], gc.Equals, s.clientSpace.Id())
+
+	bindings, err = other.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(bindings.Map()["server"], gc.Equals, s.appsSpace.Id())
+}
+
 func (s *bindingsSuite) copyMap(input map[string]string) map[string]string {
 	output := make(map[string]string, len(input))
 	for key, value := range input {
@@ -0,0 +1,181 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// EngineHealth summarises the health of the workers in a machine
+// agent's dependency engine, as last reported by the agent. Only the
+// workers which are unhealthy are recorded.
+type EngineHealth struct {
+	// Workers holds the unhealthy workers, sorted by name.
+	Workers []EngineWorkerHealth
+
+	// Updated is when the agent last reported.
+	Updated time.Time
+}
+
+// EngineWorkerHealth describes an unhealthy worker in a dependency
+// engine.
+type EngineWorkerHealth struct {
+	// Name is the name of the worker's manifold.
+	Name string
+
+	// Health is either "failing", for a worker which has stopped with
+	// an error and not restarted, or "bouncing", for a worker which
+	// keeps restarting.
+	Health string
+
+	// State is the state of the worker reported by the engine.
+	State string
+
+	// Error is the error the worker last stopped with, if any.
+	Error string
+
+	// Restarts is how many times the worker was started between the
+	// agent's last two reports.
+	Restarts int
+}
+
+type engineHealthDoc struct {
+	DocID     string                  `bson:"_id"`
+	MachineId string                  `bson:"machine-id"`
+	Workers   []engineWorkerHealthDoc `bson:"workers"`
+	Updated   int64                   `bson:"updated"`
+}
+
+type engineWorkerHealthDoc struct {
+	Name     string `bson:"name"`
+	Health   string `bson:"health"`
+	State    string `bson:"state"`
+	Error    string `bson:"error,omitempty"`
+	Restarts int    `bson:"restarts,omitempty"`
+}
+
+func (doc engineHealthDoc) health() EngineHealth {
+	health := EngineHealth{
+		Updated: time.Unix(0, doc.Updated).UTC(),
+	}
+	for _, w := range doc.Workers {
+		health.Workers = append(health.Workers, EngineWorkerHealth{
+			Name:     w.Name,
+			Health:   w.Health,
+			State:    w.State,
+			Error:    w.Error,
+			Restarts: w.Restarts,
+		})
+	}
+	return health
+}
+
+// SetEngineHealth records the health of the workers in the machine
+// agent's dependency engine, replacing any reported previously.
+func (m *Machine) SetEngineHealth(workers []EngineWorkerHealth) error {
+	doc := engineHealthDoc{
+		DocID:     m.st.docID(m.globalKey()),
+		MachineId: m.Id(),
+		Updated:   m.st.clock().Now().UnixNano(),
+	}
+	for _, w := range workers {
+		doc.Workers = append(doc.Workers, engineWorkerHealthDoc{
+			Name:     w.Name,
+			Health:   w.Health,
+			State:    w.State,
+			Error:    w.Error,
+			Restarts: w.Restarts,
+		})
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.Life() == Dead {
+			return nil, errors.NotFoundf("machine %s", m.Id())
+		}
+		coll, closer := m.st.db().GetCollection(engineHealthC)
+		defer closer()
+		n, err := coll.FindId(doc.DocID).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: notDeadDoc,
+		}}
+		if n == 0 {
+			return append(ops, txn.Op{
+				C:      engineHealthC,
+				Id:     doc.DocID,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			}), nil
+		}
+		return append(ops, txn.Op{
+			C:      engineHealthC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"workers", doc.Workers},
+				{"updated", doc.Updated},
+			}}},
+		}), nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot set engine health for machine %s", m.Id())
+	}
+	return nil
+}
+
+// EngineHealth returns the health of the workers in the machine
+// agent's dependency engine, as last reported by the agent. It returns
+// a NotFound error if the agent has not reported.
+func (m *Machine) EngineHealth() (EngineHealth, error) {
+	coll, closer := m.st.db().GetCollection(engineHealthC)
+	defer closer()
+
+	var doc engineHealthDoc
+	if err := coll.FindId(m.globalKey()).One(&doc); err == mgo.ErrNotFound {
+		return EngineHealth{}, errors.NotFoundf("engine health for machine %s", m.Id())
+	} else if err != nil {
+		return EngineHealth{}, errors.Annotatef(err, "cannot get engine health for machine %s", m.Id())
+	}
+	return doc.health(), nil
+}
+
+// AllEngineHealth returns the engine health last reported by each
+// machine agent in the model, keyed by machine id.
+func (st *State) AllEngineHealth() (map[string]EngineHealth, error) {
+	coll, closer := st.db().GetCollection(engineHealthC)
+	defer closer()
+
+	var docs []engineHealthDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get engine health")
+	}
+	result := make(map[string]EngineHealth, len(docs))
+	for _, doc := range docs {
+		result[doc.MachineId] = doc.health()
+	}
+	return result, nil
+}
+
+// removeEngineHealthOp returns the operation needed to remove the
+// engine health document associated with the given globalKey.
+func removeEngineHealthOp(mb modelBackend, globalKey string) txn.Op {
+	return txn.Op{
+		C:      engineHealthC,
+		Id:     mb.docID(globalKey),
+		Remove: true,
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type EngineHealthSuite struct {
+	ConnSuite
+
+	machine *state.Machine
+}
+
+var _ = gc.Suite(&EngineHealthSuite{})
+
+func (s *EngineHealthSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	var err error
+	s.machine, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+var unhealthyWorkers = []state.EngineWorkerHealth{{
+	Name:     "api-address-updater",
+	Health:   "bouncing",
+	State:    "started",
+	Restarts: 5,
+}, {
+	Name:   "machiner",
+	Health: "failing",
+	State:  "stopped",
+	Error:  "boom",
+}}
+
+func (s *EngineHealthSuite) TestEngineHealthNotReported(c *gc.C) {
+	_, err := s.machine.EngineHealth()
+	c.Assert(err, gc.ErrorMatches, "engine health for machine 0 not found")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *EngineHealthSuite) TestSetEngineHealth(c *gc.C) {
+	err := s.machine.SetEngineHealth(unhealthyWorkers)
+	c.Assert(err, jc.ErrorIsNil)
+
+	health, err := s.machine.EngineHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(health, jc.DeepEquals, state.EngineHealth{
+		Workers: unhealthyWorkers,
+		Updated: s.Clock.Now().UTC(),
+	})
+
+	// Reporting again replaces the previous report.
+	err = s.machine.SetEngineHealth(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	health, err = s.machine.EngineHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(health.Workers, gc.HasLen, 0)
+}
+
+func (s *EngineHealthSuite) TestAllEngineHealth(c *gc.C) {
+	other, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetEngineHealth(unhealthyWorkers)
+	c.Assert(err, jc.ErrorIsNil)
+	err = other.SetEngineHealth(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := s.State.AllEngineHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 2)
+	c.Assert(all["0"].Workers, jc.DeepEquals, unhealthyWorkers)
+	c.Assert(all["1"].Workers, gc.HasLen, 0)
+}
+
+func (s *EngineHealthSuite) TestSetEngineHealthDeadMachine(c *gc.C) {
+	err := s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetEngineHealth(unhealthyWorkers)
+	c.Assert(err, gc.ErrorMatches, "cannot set engine health for machine 0: machine 0 not found")
+}
+
+func (s *EngineHealthSuite) TestRemoveMachineRemovesEngineHealth(c *gc.C) {
+	err := s.machine.SetEngineHealth(unhealthyWorkers)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := s.State.AllEngineHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 0)
+}
@@ -0,0 +1,31 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/utils/v2"
+)
+
+// entityUUIDsAnnotation is the model annotation that carries the UUIDs
+// of applications, units, machines, relations and spaces across model
+// migration, keyed by spaceUUIDKey or the entity's global key. The model
+// description has no field to hold them. Annotation keys set through the
+// API may not contain dots, so this key cannot clash with a user's. The
+// migration master refuses to migrate models to controllers too old to
+// read it.
+const entityUUIDsAnnotation = "juju.entity-uuids"
+
+// newEntityUUID returns a new UUID for an application, unit, machine,
+// relation or space. The UUID is stored with the entity when it is
+// created and never changes, so that tools outside Juju can track the
+// entity without relying on its name.
+func newEntityUUID() string {
+	return utils.MustNewUUID().String()
+}
+
+// spaceUUIDKey returns the key under which the UUID of the space with
+// the given ID is migrated.
+func spaceUUIDKey(id string) string {
+	return "sp#" + id
+}
@@ -0,0 +1,62 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/collections/set"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/v2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing/factory"
+)
+
+type EntityUUIDSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&EntityUUIDSuite{})
+
+func (s *EntityUUIDSuite) TestNewEntitiesHaveUUIDs(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: wordpress})
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	relation, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	space, err := s.State.AddSpace("dmz", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	uuids := []string{
+		machine.UUID(),
+		wordpress.UUID(),
+		mysql.UUID(),
+		unit.UUID(),
+		relation.UUID(),
+		space.UUID(),
+	}
+	for _, uuid := range uuids {
+		c.Check(utils.IsValidUUIDString(uuid), jc.IsTrue, gc.Commentf("uuid %q", uuid))
+	}
+	c.Assert(set.NewStrings(uuids...).Size(), gc.Equals, len(uuids))
+}
+
+func (s *EntityUUIDSuite) TestUUIDSurvivesReload(c *gc.C) {
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+
+	app2, err := s.State.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app2.UUID(), gc.Equals, app.UUID())
+	unit2, err := s.State.Unit(unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit2.UUID(), gc.Equals, unit.UUID())
+	machine, err := s.State.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machine.UUID(), gc.Not(gc.Equals), "")
+}
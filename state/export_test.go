@@ -53,6 +53,8 @@ const (
 	GUISettingsC      = guisettingsC
 	GlobalSettingsC   = globalSettingsC
 	SettingsC         = settingsC
+
+	EntityUUIDsAnnotation = entityUUIDsAnnotation
 )
 
 var (
@@ -637,6 +639,11 @@ func ResetMigrationMode(c *gc.C, st *State) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func RecordMachineNetworkChange(c *gc.C, m *Machine) {
+	err := m.st.db().RunTransaction([]txn.Op{m.NetworkChangedOp()})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func RemoveRelationStatus(c *gc.C, rel *Relation) {
 	st := rel.st
 	ops := []txn.Op{removeStatusOp(st, rel.globalScope())}
@@ -280,8 +280,16 @@ func Initialize(args InitializeParams) (_ *Controller, err error) {
 		ops = append(ops, createSettingsOp(globalSettingsC, regionSettingsGlobalKey(args.Cloud.Name, k), v))
 	}
 
+	sealer, err := sealerForConfig(args.ControllerConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	for tag, cred := range args.CloudCredentials {
-		ops = append(ops, createCloudCredentialOp(tag, cred))
+		attrs, err := sealedAttributes(sealer, cred.Attributes())
+		if err != nil {
+			return nil, errors.Annotatef(err, "sealing cloud credential %q", tag.Id())
+		}
+		ops = append(ops, createCloudCredentialOp(tag, cred, attrs))
 	}
 	ops = append(ops, modelOps...)
 	ops = append(ops, storagePoolOps...)
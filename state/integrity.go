@@ -0,0 +1,383 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// The classes of consistency check run by VerifyIntegrity.
+const (
+	// IntegrityCheckRefcounts checks that the unit and relation counts
+	// of each application match the units and relations that exist.
+	IntegrityCheckRefcounts = "refcounts"
+
+	// IntegrityCheckLife checks that no entity outlives an entity it
+	// depends on, such as a live unit of a dead application.
+	IntegrityCheckLife = "life"
+
+	// IntegrityCheckBindings checks that endpoint bindings and subnets
+	// refer to spaces which exist.
+	IntegrityCheckBindings = "bindings"
+)
+
+// IntegrityChecks holds the classes of consistency check run by
+// VerifyIntegrity, in the order they are run.
+var IntegrityChecks = []string{
+	IntegrityCheckRefcounts,
+	IntegrityCheckLife,
+	IntegrityCheckBindings,
+}
+
+// IntegrityProblem describes an inconsistency between documents found by
+// VerifyIntegrity.
+type IntegrityProblem struct {
+	// Check is the class of check which found the problem.
+	Check string
+
+	// Collection is the name of the collection holding the
+	// inconsistent document.
+	Collection string
+
+	// ModelUUID is the UUID of the model the document belongs to.
+	ModelUUID string
+
+	// Id is the ID of the document within its model.
+	Id string
+
+	// Detail describes the inconsistency.
+	Detail string
+
+	// Repairable is true if the problem can be repaired safely.
+	Repairable bool
+
+	// Repaired is true if the problem has been repaired.
+	Repaired bool
+}
+
+// integrityApplication holds the fields of an application document
+// needed to check its integrity.
+type integrityApplication struct {
+	DocID         string `bson:"_id"`
+	Name          string `bson:"name"`
+	ModelUUID     string `bson:"model-uuid"`
+	Life          Life   `bson:"life"`
+	UnitCount     int    `bson:"unitcount"`
+	RelationCount int    `bson:"relationcount"`
+	TxnRevno      int64  `bson:"txn-revno"`
+
+	// units and relations count the unit and relation documents
+	// which refer to the application.
+	units     int
+	relations int
+}
+
+// VerifyIntegrity runs consistency checks across the collections of
+// every model on the controller, and returns the problems found. If
+// repair is true, the problems which can be repaired safely are
+// repaired; currently these are only mismatched application unit and
+// relation counts. A repair is skipped if the application has changed
+// since it was checked.
+func (st *State) VerifyIntegrity(repair bool) ([]IntegrityProblem, error) {
+	// The applications must be read before their units and relations
+	// are counted, so that a repair will fail if a unit or relation is
+	// added or removed in the meantime.
+	applications, err := st.integrityApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineLife, err := st.allMachineLife()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var problems []IntegrityProblem
+	unitProblems, err := st.checkUnitIntegrity(applications, machineLife)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	problems = append(problems, unitProblems...)
+	relationProblems, err := st.checkRelationIntegrity(applications)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	problems = append(problems, relationProblems...)
+	problems = append(problems, checkApplicationRefcounts(applications)...)
+	bindingProblems, err := st.checkBindingIntegrity()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	problems = append(problems, bindingProblems...)
+
+	if repair {
+		if err := st.repairApplicationRefcounts(applications, problems); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	checkOrder := make(map[string]int)
+	for i, check := range IntegrityChecks {
+		checkOrder[check] = i
+	}
+	sort.SliceStable(problems, func(i, j int) bool {
+		pi, pj := problems[i], problems[j]
+		if pi.Check != pj.Check {
+			return checkOrder[pi.Check] < checkOrder[pj.Check]
+		}
+		if pi.Collection != pj.Collection {
+			return pi.Collection < pj.Collection
+		}
+		return ensureModelUUID(pi.ModelUUID, pi.Id) < ensureModelUUID(pj.ModelUUID, pj.Id)
+	})
+	return problems, nil
+}
+
+// integrityApplications returns every application on the controller,
+// keyed by document ID.
+func (st *State) integrityApplications() (map[string]*integrityApplication, error) {
+	coll, closer := st.db().GetRawCollection(applicationsC)
+	defer closer()
+
+	applications := make(map[string]*integrityApplication)
+	iter := coll.Find(nil).Select(bson.D{
+		{"_id", 1}, {"name", 1}, {"model-uuid", 1}, {"life", 1},
+		{"unitcount", 1}, {"relationcount", 1}, {"txn-revno", 1},
+	}).Iter()
+	var doc integrityApplication
+	for iter.Next(&doc) {
+		app := doc
+		applications[doc.DocID] = &app
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Annotate(err, "reading applications")
+	}
+	return applications, nil
+}
+
+// checkUnitIntegrity counts the units of each application, and checks
+// that no unit is alive while its application or machine is dead.
+func (st *State) checkUnitIntegrity(
+	applications map[string]*integrityApplication, machineLife map[string]Life,
+) ([]IntegrityProblem, error) {
+	units, closer := st.db().GetRawCollection(unitsC)
+	defer closer()
+
+	var problems []IntegrityProblem
+	var doc struct {
+		Name        string `bson:"name"`
+		ModelUUID   string `bson:"model-uuid"`
+		Application string `bson:"application"`
+		MachineId   string `bson:"machineid"`
+		Life        Life   `bson:"life"`
+	}
+	iter := units.Find(nil).Select(bson.D{
+		{"name", 1}, {"model-uuid", 1}, {"application", 1}, {"machineid", 1}, {"life", 1},
+	}).Iter()
+	for iter.Next(&doc) {
+		app, ok := applications[ensureModelUUID(doc.ModelUUID, doc.Application)]
+		if ok {
+			app.units++
+		}
+		if doc.Life == Dead {
+			continue
+		}
+		if ok && app.Life == Dead {
+			problems = append(problems, IntegrityProblem{
+				Check:      IntegrityCheckLife,
+				Collection: unitsC,
+				ModelUUID:  doc.ModelUUID,
+				Id:         doc.Name,
+				Detail:     fmt.Sprintf("unit is %s but application %q is dead", doc.Life, doc.Application),
+			})
+		}
+		if doc.MachineId == "" {
+			continue
+		}
+		if life, ok := machineLife[ensureModelUUID(doc.ModelUUID, doc.MachineId)]; ok && life == Dead {
+			problems = append(problems, IntegrityProblem{
+				Check:      IntegrityCheckLife,
+				Collection: unitsC,
+				ModelUUID:  doc.ModelUUID,
+				Id:         doc.Name,
+				Detail:     fmt.Sprintf("unit is %s but machine %q is dead", doc.Life, doc.MachineId),
+			})
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Annotate(err, "reading units")
+	}
+	return problems, nil
+}
+
+// checkRelationIntegrity counts the relations of each application, and
+// checks that no relation is alive while one of its applications is dead.
+func (st *State) checkRelationIntegrity(applications map[string]*integrityApplication) ([]IntegrityProblem, error) {
+	relations, closer := st.db().GetRawCollection(relationsC)
+	defer closer()
+
+	var problems []IntegrityProblem
+	var doc relationDoc
+	iter := relations.Find(nil).Iter()
+	for iter.Next(&doc) {
+		var deadApplication string
+		for _, ep := range doc.Endpoints {
+			app, ok := applications[ensureModelUUID(doc.ModelUUID, ep.ApplicationName)]
+			if !ok {
+				// Remote applications, or applications which no longer
+				// exist, which are reported as orphans.
+				continue
+			}
+			app.relations++
+			if app.Life == Dead && deadApplication == "" {
+				deadApplication = ep.ApplicationName
+			}
+		}
+		if doc.Life == Dead || deadApplication == "" {
+			continue
+		}
+		problems = append(problems, IntegrityProblem{
+			Check:      IntegrityCheckLife,
+			Collection: relationsC,
+			ModelUUID:  doc.ModelUUID,
+			Id:         doc.Key,
+			Detail:     fmt.Sprintf("relation is %s but application %q is dead", doc.Life, deadApplication),
+		})
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Annotate(err, "reading relations")
+	}
+	return problems, nil
+}
+
+// checkApplicationRefcounts checks that the unit and relation counts of
+// each application match the units and relations counted.
+func checkApplicationRefcounts(applications map[string]*integrityApplication) []IntegrityProblem {
+	var problems []IntegrityProblem
+	for _, app := range applications {
+		if app.UnitCount != app.units {
+			problems = append(problems, IntegrityProblem{
+				Check:      IntegrityCheckRefcounts,
+				Collection: applicationsC,
+				ModelUUID:  app.ModelUUID,
+				Id:         app.Name,
+				Detail:     fmt.Sprintf("unit count is %d but %d units found", app.UnitCount, app.units),
+				Repairable: true,
+			})
+		}
+		if app.RelationCount != app.relations {
+			problems = append(problems, IntegrityProblem{
+				Check:      IntegrityCheckRefcounts,
+				Collection: applicationsC,
+				ModelUUID:  app.ModelUUID,
+				Id:         app.Name,
+				Detail:     fmt.Sprintf("relation count is %d but %d relations found", app.RelationCount, app.relations),
+				Repairable: true,
+			})
+		}
+	}
+	return problems
+}
+
+// checkBindingIntegrity checks that endpoint bindings and subnets refer
+// to spaces which exist.
+func (st *State) checkBindingIntegrity() ([]IntegrityProblem, error) {
+	spaces, err := st.allDocIDs(spacesC)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var problems []IntegrityProblem
+	bindings, closer := st.db().GetRawCollection(endpointBindingsC)
+	defer closer()
+	var bindingsDoc struct {
+		DocID     string      `bson:"_id"`
+		ModelUUID string      `bson:"model-uuid"`
+		Bindings  bindingsMap `bson:"bindings"`
+	}
+	iter := bindings.Find(nil).Iter()
+	for iter.Next(&bindingsDoc) {
+		_, id, _ := splitDocID(bindingsDoc.DocID)
+		for endpoint, spaceID := range bindingsDoc.Bindings {
+			if spaces.Contains(ensureModelUUID(bindingsDoc.ModelUUID, spaceID)) {
+				continue
+			}
+			binding := fmt.Sprintf("endpoint %q", endpoint)
+			if endpoint == "" {
+				binding = "default binding"
+			}
+			problems = append(problems, IntegrityProblem{
+				Check:      IntegrityCheckBindings,
+				Collection: endpointBindingsC,
+				ModelUUID:  bindingsDoc.ModelUUID,
+				Id:         id,
+				Detail:     fmt.Sprintf("%s refers to space %q, which does not exist", binding, spaceID),
+			})
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Annotate(err, "reading endpoint bindings")
+	}
+
+	subnets, closer := st.db().GetRawCollection(subnetsC)
+	defer closer()
+	var subnet subnetDoc
+	iter = subnets.Find(nil).Iter()
+	for iter.Next(&subnet) {
+		if subnet.SpaceID == "" || spaces.Contains(ensureModelUUID(subnet.ModelUUID, subnet.SpaceID)) {
+			continue
+		}
+		problems = append(problems, IntegrityProblem{
+			Check:      IntegrityCheckBindings,
+			Collection: subnetsC,
+			ModelUUID:  subnet.ModelUUID,
+			Id:         subnet.ID,
+			Detail:     fmt.Sprintf("subnet %s is in space %q, which does not exist", subnet.CIDR, subnet.SpaceID),
+		})
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Annotate(err, "reading subnets")
+	}
+	return problems, nil
+}
+
+// repairApplicationRefcounts sets the unit and relation counts of the
+// applications with refcount problems to the counts found, and marks
+// the problems repaired. The counts are only set if the application
+// document is unchanged since it was read.
+func (st *State) repairApplicationRefcounts(applications map[string]*integrityApplication, problems []IntegrityProblem) error {
+	repaired := make(map[string]bool)
+	for i, problem := range problems {
+		if problem.Check != IntegrityCheckRefcounts {
+			continue
+		}
+		docID := ensureModelUUID(problem.ModelUUID, problem.Id)
+		ok, done := repaired[docID]
+		if !done {
+			app := applications[docID]
+			err := st.runRawTransaction([]txn.Op{{
+				C:      applicationsC,
+				Id:     docID,
+				Assert: bson.D{{"txn-revno", app.TxnRevno}},
+				Update: bson.D{{"$set", bson.D{
+					{"unitcount", app.units},
+					{"relationcount", app.relations},
+				}}},
+			}})
+			if err == txn.ErrAborted {
+				logger.Debugf("application %q in model %s changed, not repairing its refcounts", app.Name, app.ModelUUID)
+			} else if err != nil {
+				return errors.Annotatef(err, "repairing refcounts of application %q in model %s", app.Name, app.ModelUUID)
+			}
+			ok = err == nil
+			repaired[docID] = ok
+		}
+		problems[i].Repaired = ok
+	}
+	return nil
+}
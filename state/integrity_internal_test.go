@@ -0,0 +1,153 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type integritySuite struct {
+	internalStateSuite
+}
+
+var _ = gc.Suite(&integritySuite{})
+
+func (s *integritySuite) update(c *gc.C, collection, id string, update bson.D) {
+	coll, closer := s.state.db().GetRawCollection(collection)
+	defer closer()
+	err := coll.UpdateId(ensureModelUUID(s.state.ModelUUID(), id), update)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *integritySuite) TestVerifyIntegrityNoProblems(c *gc.C) {
+	wordpress := AddTestingApplication(c, s.state, "wordpress", AddTestingCharm(c, s.state, "wordpress"))
+	_, err := wordpress.AddUnit(AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	AddTestingApplication(c, s.state, "mysql", AddTestingCharm(c, s.state, "mysql"))
+	eps, err := s.state.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.state.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	problems, err := s.state.VerifyIntegrity(false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, gc.HasLen, 0)
+}
+
+func (s *integritySuite) TestVerifyIntegrity(c *gc.C) {
+	uuid := s.state.ModelUUID()
+	machine, err := s.state.AddMachine("quantal", JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	wordpress := AddTestingApplication(c, s.state, "wordpress", AddTestingCharm(c, s.state, "wordpress"))
+	unit, err := wordpress.AddUnit(AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+	mysql := AddTestingApplication(c, s.state, "mysql", AddTestingCharm(c, s.state, "mysql"))
+	_, err = mysql.AddUnit(AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	eps, err := s.state.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.state.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.update(c, applicationsC, "wordpress", bson.D{{"$set", bson.D{{"unitcount", 3}}}})
+	s.update(c, applicationsC, "mysql", bson.D{{"$set", bson.D{{"life", Dead}}}})
+	s.update(c, machinesC, machine.Id(), bson.D{{"$set", bson.D{{"life", Dead}}}})
+	s.update(c, endpointBindingsC, applicationGlobalKey("wordpress"), bson.D{{"$set", bson.D{{"bindings.db", "42"}}}})
+	coll, closer := s.state.db().GetRawCollection(subnetsC)
+	defer closer()
+	err = coll.Insert(bson.M{
+		"_id":        uuid + ":7",
+		"subnet-id":  "7",
+		"model-uuid": uuid,
+		"cidr":       "10.0.0.0/24",
+		"space-id":   "99",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	problems, err := s.state.VerifyIntegrity(false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, jc.DeepEquals, []IntegrityProblem{{
+		Check:      IntegrityCheckRefcounts,
+		Collection: applicationsC,
+		ModelUUID:  uuid,
+		Id:         "wordpress",
+		Detail:     "unit count is 3 but 1 units found",
+		Repairable: true,
+	}, {
+		Check:      IntegrityCheckLife,
+		Collection: relationsC,
+		ModelUUID:  uuid,
+		Id:         rel.String(),
+		Detail:     `relation is alive but application "mysql" is dead`,
+	}, {
+		Check:      IntegrityCheckLife,
+		Collection: unitsC,
+		ModelUUID:  uuid,
+		Id:         "mysql/0",
+		Detail:     `unit is alive but application "mysql" is dead`,
+	}, {
+		Check:      IntegrityCheckLife,
+		Collection: unitsC,
+		ModelUUID:  uuid,
+		Id:         "wordpress/0",
+		Detail:     `unit is alive but machine "0" is dead`,
+	}, {
+		Check:      IntegrityCheckBindings,
+		Collection: endpointBindingsC,
+		ModelUUID:  uuid,
+		Id:         applicationGlobalKey("wordpress"),
+		Detail:     `endpoint "db" refers to space "42", which does not exist`,
+	}, {
+		Check:      IntegrityCheckBindings,
+		Collection: subnetsC,
+		ModelUUID:  uuid,
+		Id:         "7",
+		Detail:     `subnet 10.0.0.0/24 is in space "99", which does not exist`,
+	}})
+}
+
+func (s *integritySuite) TestVerifyIntegrityRepair(c *gc.C) {
+	wordpress := AddTestingApplication(c, s.state, "wordpress", AddTestingCharm(c, s.state, "wordpress"))
+	_, err := wordpress.AddUnit(AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.update(c, applicationsC, "wordpress", bson.D{{"$set", bson.D{{"unitcount", 3}, {"relationcount", 2}}}})
+
+	problems, err := s.state.VerifyIntegrity(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, gc.HasLen, 2)
+	for _, problem := range problems {
+		c.Check(problem.Repaired, jc.IsTrue)
+	}
+
+	problems, err = s.state.VerifyIntegrity(false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(problems, gc.HasLen, 0)
+	err = wordpress.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(wordpress.doc.UnitCount, gc.Equals, 1)
+	c.Check(wordpress.doc.RelationCount, gc.Equals, 0)
+}
+
+func (s *integritySuite) TestVerifyIntegrityRepairApplicationChanged(c *gc.C) {
+	wordpress := AddTestingApplication(c, s.state, "wordpress", AddTestingCharm(c, s.state, "wordpress"))
+	s.update(c, applicationsC, "wordpress", bson.D{{"$set", bson.D{{"unitcount", 3}}}})
+
+	defer SetBeforeHooks(c, s.state, func() {
+		_, err := wordpress.AddUnit(AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	problems, err := s.state.VerifyIntegrity(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(problems, gc.HasLen, 1)
+	c.Check(problems[0].Repaired, jc.IsFalse)
+
+	err = wordpress.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(wordpress.doc.UnitCount, gc.Equals, 4)
+}
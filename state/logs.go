@@ -205,6 +205,160 @@ func InitDbLogsForModel(session *mgo.Session, modelUUID string, size int) error
 	return nil
 }
 
+// ResizeModelLogs resizes the capped logs collection of every model to
+// sizeMB, without taking the collections offline. Collections which are
+// already the right size are left alone.
+func (st *State) ResizeModelLogs(sizeMB int) error {
+	if sizeMB < 1 {
+		return errors.NotValidf("non-positive logs size %v", sizeMB)
+	}
+	session := st.MongoSession()
+	models, err := modelUUIDs(session)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, uuid := range models {
+		if err := resizeModelLogs(session, uuid, sizeMB); err != nil {
+			return errors.Annotatef(err, "resizing logs for model %s", uuid)
+		}
+	}
+	return nil
+}
+
+// resizeLogsBatchSize is the number of log records copied at a time
+// when resizing a logs collection.
+const resizeLogsBatchSize = 1000
+
+var (
+	// resizeLogsIdleTimeout is how long the copy of a logs collection
+	// waits for new records once it has caught up, before the new
+	// collection replaces the old one.
+	resizeLogsIdleTimeout = 250 * time.Millisecond
+
+	// resizeLogsMaxFollow is how long the copy keeps following new
+	// records once it has copied those there when it started, so that
+	// it finishes even if records are written continuously.
+	resizeLogsMaxFollow = 10 * time.Second
+)
+
+// resizeModelLogs resizes the logs collection for the model by copying
+// its records into a new capped collection of the right size, and then
+// renaming the new collection over the old one. Unlike convertToCapped,
+// this doesn't lock the database while the records are copied, so log
+// records can still be written and read. Records written between the
+// end of the copy and the rename are lost.
+func resizeModelLogs(session *mgo.Session, modelUUID string, sizeMB int) error {
+	db := session.DB(logsDB)
+	logsColl := db.C(logCollectionName(modelUUID))
+	capped, maxSize, err := getCollectionCappedInfo(logsColl)
+	if errors.IsNotFound(err) || (err == nil && !capped) {
+		// Following the records written during the copy needs a
+		// capped collection.
+		return errors.Trace(InitDbLogsForModel(session, modelUUID, sizeMB))
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if maxSize == sizeMB {
+		return nil
+	}
+	logger.Infof("resizing logs collection for %s from %d to %d MiB", modelUUID, maxSize, sizeMB)
+
+	// The new collection's name doesn't start with logsCPrefix, so
+	// that it isn't mistaken for a model's logs.
+	newColl := db.C("resize." + logsColl.Name)
+	if err := newColl.DropCollection(); err != nil && !isMgoNamespaceNotFound(err) {
+		return errors.Trace(err)
+	}
+	if err := newColl.Create(&mgo.CollectionInfo{
+		Capped:   true,
+		MaxBytes: sizeMB * humanize.MiByte,
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	for _, key := range logIndexes {
+		if err := newColl.EnsureIndex(mgo.Index{Key: key}); err != nil {
+			return errors.Annotatef(err, "cannot create index for logs collection %v", newColl.Name)
+		}
+	}
+
+	if err := copyLogs(logsColl, newColl); err != nil {
+		return errors.Trace(err)
+	}
+
+	err = session.Run(bson.D{
+		{"renameCollection", logsDB + "." + newColl.Name},
+		{"to", logsDB + "." + logsColl.Name},
+		{"dropTarget", true},
+	}, nil)
+	return errors.Annotate(err, "replacing logs collection")
+}
+
+// copyLogs copies the log records from one capped collection to
+// another, in batches, in the order they were written. Record IDs
+// can't be used to pick up where a copy left off, as they are made by
+// whichever controller wrote the record, so the copy follows the
+// collection with a tailable cursor instead. This also copies the
+// records written during the copy. It returns once no new record has
+// been written for resizeLogsIdleTimeout, or once it has been following
+// new records for resizeLogsMaxFollow.
+func copyLogs(from, to *mgo.Collection) error {
+	initial, err := from.Count()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	iter := from.Find(nil).Sort("$natural").Tail(resizeLogsIdleTimeout)
+	batch := make([]interface{}, 0, resizeLogsBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		bulk := to.Bulk()
+		bulk.Insert(batch...)
+		if _, err := bulk.Run(); err != nil {
+			return errors.Trace(err)
+		}
+		batch = batch[:0]
+		return nil
+	}
+	var (
+		copied   int
+		deadline time.Time
+		caughtUp bool
+		doc      bson.D
+	)
+	for iter.Next(&doc) {
+		batch = append(batch, doc)
+		doc = nil
+		copied++
+		if copied == initial {
+			deadline = time.Now().Add(resizeLogsMaxFollow)
+		}
+		if len(batch) == resizeLogsBatchSize {
+			if err := flush(); err != nil {
+				_ = iter.Close()
+				return errors.Trace(err)
+			}
+		}
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			caughtUp = true
+			break
+		}
+	}
+	if iter.Timeout() {
+		caughtUp = true
+	}
+	if err := iter.Close(); err != nil {
+		return errors.Annotate(err, "following logs collection")
+	}
+	// The cursor is closed by the server if the collection was empty,
+	// or if the records it had reached were overwritten. The resize is
+	// tried again when the size next changes.
+	if !caughtUp && copied > 0 {
+		return errors.New("lost position in logs collection during copy")
+	}
+	return errors.Trace(flush())
+}
+
 // lastSentDoc captures timestamp of the last log record forwarded
 // to a log sink.
 type lastSentDoc struct {
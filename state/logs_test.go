@@ -4,6 +4,7 @@
 package state_test
 
 import (
+	"fmt"
 	"math/rand"
 	"strconv"
 	"strings"
@@ -774,6 +775,78 @@ func (s *LogTailerSuite) assertTailer(c *gc.C, tailer state.LogTailer, expectedC
 	}
 }
 
+func (s *LogsSuite) TestResizeModelLogs(c *gc.C) {
+	logger := state.NewDbLogger(s.State)
+	defer logger.Close()
+	var records []state.LogRecord
+	for i := 0; i < 10; i++ {
+		records = append(records, state.LogRecord{
+			Time:    coretesting.ZeroTime().Add(time.Duration(i) * time.Second),
+			Entity:  "machine-0",
+			Module:  "some.where",
+			Level:   loggo.INFO,
+			Message: fmt.Sprintf("log%d", i),
+		})
+	}
+	err := logger.Log(records)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.ResizeModelLogs(5)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var stats bson.M
+	err = s.logsColl.Database.Run(bson.D{
+		{"collStats", s.logsColl.Name},
+		{"scale", 1024 * 1024},
+	}, &stats)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stats["capped"], jc.IsTrue)
+	c.Assert(stats["maxSize"], gc.Equals, 5)
+
+	// The records are kept, in order, and the indexes recreated.
+	var docs []bson.M
+	err = s.logsColl.Find(nil).Sort("$natural").All(&docs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(docs, gc.HasLen, 10)
+	for i, doc := range docs {
+		c.Check(doc["x"], gc.Equals, fmt.Sprintf("log%d", i))
+	}
+	s.TestIndexesCreated(c)
+
+	names, err := s.logsColl.Database.CollectionNames()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(names, gc.Not(jc.Contains), "resize."+s.logsColl.Name)
+}
+
+func (s *LogsSuite) TestResizeModelLogsKeepsNaturalOrder(c *gc.C) {
+	// Records written by different controllers don't have IDs in the
+	// order they were written.
+	now := time.Now()
+	for i := 0; i < 5; i++ {
+		err := s.logsColl.Insert(bson.M{
+			"_id": bson.NewObjectIdWithTime(now.Add(-time.Duration(i) * time.Hour)),
+			"x":   fmt.Sprintf("log%d", i),
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	err := s.State.ResizeModelLogs(5)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var docs []bson.M
+	err = s.logsColl.Find(nil).Sort("$natural").All(&docs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(docs, gc.HasLen, 5)
+	for i, doc := range docs {
+		c.Check(doc["x"], gc.Equals, fmt.Sprintf("log%d", i))
+	}
+}
+
+func (s *LogsSuite) TestResizeModelLogsNotValid(c *gc.C) {
+	err := s.State.ResizeModelLogs(0)
+	c.Assert(err, gc.ErrorMatches, "non-positive logs size 0 not valid")
+}
+
 type DBLogSizeSuite struct {
 	coretesting.BaseSuite
 }
@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"net"
 	"strings"
 	"time"
 
@@ -101,6 +102,7 @@ type machineDoc struct {
 	DocID          string `bson:"_id"`
 	Id             string `bson:"machineid"`
 	ModelUUID      string `bson:"model-uuid"`
+	UUID           string `bson:"uuid,omitempty"`
 	Nonce          string
 	Series         string
 	ContainerType  string
@@ -143,6 +145,16 @@ type machineDoc struct {
 
 	// AgentStartedAt records the time when the machine agent started.
 	AgentStartedAt time.Time `bson:"agent-started-at,omitempty"`
+
+	// EgressNATAddress is the address of the NAT gateway through which
+	// traffic from the machine egresses, if one has been declared.
+	EgressNATAddress string `bson:"egress-nat-address,omitempty"`
+
+	// NetworkRevision is incremented whenever provider-sourced changes
+	// are merged into the machine's link-layer data. It contributes to
+	// the address hash watched by units, so that they refresh their
+	// network info when the provider reports a change.
+	NetworkRevision int `bson:"network-revision,omitempty"`
 }
 
 func newMachine(st *State, doc *machineDoc) *Machine {
@@ -158,6 +170,11 @@ func (m *Machine) Id() string {
 	return m.doc.Id
 }
 
+// UUID returns the machine's UUID, which never changes.
+func (m *Machine) UUID() string {
+	return m.doc.UUID
+}
+
 // Principals returns the principals for the machine.
 func (m *Machine) Principals() []string {
 	return m.doc.Principals
@@ -276,6 +293,54 @@ func (m *Machine) HardwareCharacteristics() (*instance.HardwareCharacteristics,
 	return hardwareCharacteristics(instData), nil
 }
 
+// SetHardwareCharacteristics updates the recorded hardware
+// characteristics of the machine's provisioned instance, such as after
+// the instance has been resized in the cloud. Only the characteristics
+// that are set in hc are updated; the others are left alone.
+func (m *Machine) SetHardwareCharacteristics(hc instance.HardwareCharacteristics) error {
+	var set bson.D
+	if hc.Arch != nil {
+		set = append(set, bson.DocElem{Name: "arch", Value: *hc.Arch})
+	}
+	if hc.Mem != nil {
+		set = append(set, bson.DocElem{Name: "mem", Value: *hc.Mem})
+	}
+	if hc.RootDisk != nil {
+		set = append(set, bson.DocElem{Name: "rootdisk", Value: *hc.RootDisk})
+	}
+	if hc.RootDiskSource != nil {
+		set = append(set, bson.DocElem{Name: "rootdisksource", Value: *hc.RootDiskSource})
+	}
+	if hc.CpuCores != nil {
+		set = append(set, bson.DocElem{Name: "cpucores", Value: *hc.CpuCores})
+	}
+	if hc.CpuPower != nil {
+		set = append(set, bson.DocElem{Name: "cpupower", Value: *hc.CpuPower})
+	}
+	if hc.Tags != nil {
+		set = append(set, bson.DocElem{Name: "tags", Value: *hc.Tags})
+	}
+	if hc.AvailabilityZone != nil {
+		set = append(set, bson.DocElem{Name: "availzone", Value: *hc.AvailabilityZone})
+	}
+	if len(set) == 0 {
+		return nil
+	}
+	ops := []txn.Op{{
+		C:      instanceDataC,
+		Id:     m.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", set}},
+	}}
+	if err := m.st.db().RunTransaction(ops); err != nil {
+		if err == txn.ErrAborted {
+			err = errors.NotProvisionedf("machine %v", m.Id())
+		}
+		return errors.Annotatef(err, "cannot set hardware characteristics of machine %v", m)
+	}
+	return nil
+}
+
 func getInstanceData(st *State, id string) (instanceData, error) {
 	instanceDataCollection, closer := st.db().GetCollection(instanceDataC)
 	defer closer()
@@ -1105,6 +1170,7 @@ func (m *Machine) removeOps() ([]txn.Op, error) {
 		removeMachineBlockDevicesOp(m.Id()),
 		removeModelMachineRefOp(m.st, m.Id()),
 		removeSSHHostKeyOp(m.globalKey()),
+		removeEngineHealthOp(m.st, m.globalKey()),
 		removeInstanceDataOp(m.doc.DocID),
 	}
 	linkLayerDevicesOps, err := m.removeAllLinkLayerDevicesOps()
@@ -2241,6 +2307,38 @@ func (m *Machine) AgentStartTime() time.Time {
 	return m.doc.AgentStartedAt
 }
 
+// EgressNATAddress returns the address of the NAT gateway through which
+// traffic from the machine egresses, or an empty string if none has been
+// declared.
+func (m *Machine) EgressNATAddress() string {
+	return m.doc.EgressNATAddress
+}
+
+// SetEgressNATAddress declares the address of the NAT gateway through which
+// traffic from the machine egresses. An empty address clears the declaration.
+func (m *Machine) SetEgressNATAddress(address string) error {
+	if address != "" && net.ParseIP(address) == nil {
+		return errors.NotValidf("egress NAT address %q", address)
+	}
+	var update bson.D
+	if address == "" {
+		update = bson.D{{"$unset", bson.D{{"egress-nat-address", nil}}}}
+	} else {
+		update = bson.D{{"$set", bson.D{{"egress-nat-address", address}}}}
+	}
+	ops := []txn.Op{{
+		C:      machinesC,
+		Id:     m.doc.DocID,
+		Assert: notDeadDoc,
+		Update: update,
+	}}
+	if err := m.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(onAbort(err, stateerrors.ErrDead), "cannot set egress NAT address on machine %v", m)
+	}
+	m.doc.EgressNATAddress = address
+	return nil
+}
+
 // AssertAliveOp returns an assert-only transaction operation
 // that ensures the machine is alive.
 func (m *Machine) AssertAliveOp() txn.Op {
@@ -2251,6 +2349,18 @@ func (m *Machine) AssertAliveOp() txn.Op {
 	}
 }
 
+// NetworkChangedOp returns a transaction operation that records a change to
+// the machine's link-layer data, so that units on the machine refresh their
+// network info.
+func (m *Machine) NetworkChangedOp() txn.Op {
+	return txn.Op{
+		C:      machinesC,
+		Id:     m.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$inc", bson.D{{"network-revision", 1}}}},
+	}
+}
+
 // assertMachineNotDeadOp returns an assert-only transaction operation that
 // ensures the machine is not dead.
 func assertMachineNotDeadOp(st *State, machineID string) txn.Op {
@@ -202,7 +202,12 @@ func removePortsForUnitOps(st *State, unit *Unit) ([]txn.Op, error) {
 		// No assigned machine, so there won't be any ports.
 		return nil, nil
 	}
+	return removeUnitPortRangesOps(st, machineID, unit.Name())
+}
 
+// removeUnitPortRangesOps returns the ops needed to remove all opened
+// ports for the named unit on the given machine.
+func removeUnitPortRangesOps(st *State, machineID, unitName string) ([]txn.Op, error) {
 	machinePortRanges, err := getOpenedMachinePortRanges(st, machineID)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -211,7 +216,6 @@ func removePortsForUnitOps(st *State, unit *Unit) ([]txn.Op, error) {
 		return nil, nil
 	}
 
-	unitName := unit.Name()
 	if machinePortRanges.doc.UnitRanges == nil || machinePortRanges.doc.UnitRanges[unitName] == nil {
 		// No entry for the unit; nothing to do here
 		return nil, nil
@@ -136,6 +136,31 @@ func (s *MachineSuite) TestRecordAgentStartTime(c *gc.C) {
 	c.Assert(s.machine.AgentStartTime(), gc.Equals, now)
 }
 
+func (s *MachineSuite) TestSetEgressNATAddress(c *gc.C) {
+	c.Assert(s.machine.EgressNATAddress(), gc.Equals, "")
+
+	err := s.machine.SetEgressNATAddress("203.0.113.10")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.EgressNATAddress(), gc.Equals, "203.0.113.10")
+
+	m, err := s.State.Machine(s.machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.EgressNATAddress(), gc.Equals, "203.0.113.10")
+
+	err = m.SetEgressNATAddress("")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.EgressNATAddress(), gc.Equals, "")
+}
+
+func (s *MachineSuite) TestSetEgressNATAddressInvalid(c *gc.C) {
+	err := s.machine.SetEgressNATAddress("not-an-ip")
+	c.Assert(err, gc.ErrorMatches, `egress NAT address "not-an-ip" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
 func (s *MachineSuite) TestSetKeepInstance(c *gc.C) {
 	err := s.machine.SetProvisioned("1234", "", "nonce", nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -149,6 +174,41 @@ func (s *MachineSuite) TestSetKeepInstance(c *gc.C) {
 	c.Assert(keep, jc.IsTrue)
 }
 
+func (s *MachineSuite) TestSetHardwareCharacteristics(c *gc.C) {
+	arch, mem, cores, rootDisk := "amd64", uint64(4096), uint64(2), uint64(8192)
+	err := s.machine.SetProvisioned("1234", "", "nonce", &instance.HardwareCharacteristics{
+		Arch:     &arch,
+		Mem:      &mem,
+		CpuCores: &cores,
+		RootDisk: &rootDisk,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newMem, newCores, zone := uint64(16384), uint64(8), "us-east-1a"
+	err = s.machine.SetHardwareCharacteristics(instance.HardwareCharacteristics{
+		Mem:              &newMem,
+		CpuCores:         &newCores,
+		AvailabilityZone: &zone,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	m, err := s.State.Machine(s.machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	hc, err := m.HardwareCharacteristics()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(*hc.Arch, gc.Equals, arch)
+	c.Check(*hc.Mem, gc.Equals, newMem)
+	c.Check(*hc.CpuCores, gc.Equals, newCores)
+	c.Check(*hc.RootDisk, gc.Equals, rootDisk)
+	c.Check(*hc.AvailabilityZone, gc.Equals, zone)
+}
+
+func (s *MachineSuite) TestSetHardwareCharacteristicsNotProvisioned(c *gc.C) {
+	mem := uint64(1024)
+	err := s.machine.SetHardwareCharacteristics(instance.HardwareCharacteristics{Mem: &mem})
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
 func (s *MachineSuite) TestAddMachineInsideMachineModelDying(c *gc.C) {
 	model, err := s.State.Model()
 	c.Assert(err, jc.ErrorIsNil)
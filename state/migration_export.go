@@ -4,6 +4,7 @@
 package state
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -92,10 +93,14 @@ func (st *State) exportImpl(cfg ExportConfig) (description.Model, error) {
 		return nil, errors.Trace(err)
 	}
 	export := exporter{
-		st:      st,
-		cfg:     cfg,
-		dbModel: dbModel,
-		logger:  loggo.GetLogger("juju.state.export-model"),
+		st:          st,
+		cfg:         cfg,
+		dbModel:     dbModel,
+		logger:      loggo.GetLogger("juju.state.export-model"),
+		entityUUIDs: make(map[string]string),
+		relationEncryption: relationEncryption{
+			KeyGenerations: make(map[string]int),
+		},
 	}
 	if err := export.readAllStatuses(); err != nil {
 		return nil, errors.Annotate(err, "reading statuses")
@@ -222,6 +227,12 @@ func (st *State) exportImpl(cfg ExportConfig) (description.Model, error) {
 	if err := export.externalControllers(); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := export.entityUUIDAnnotation(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := export.relationEncryptionAnnotation(); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	// If we are doing a partial export, it doesn't really make sense
 	// to validate the model.
@@ -288,6 +299,11 @@ type exporter struct {
 	// Map of application name to units. Populated as part
 	// of the applications export.
 	units map[string][]*Unit
+	// Map of global key to the UUID of each exported application,
+	// unit, machine, relation and space.
+	entityUUIDs map[string]string
+
+	relationEncryption relationEncryption
 }
 
 func (e *exporter) sequences() error {
@@ -481,6 +497,11 @@ func (e *exporter) newMachine(exParent description.Machine, machine *Machine, in
 	} else {
 		exMachine = exParent.AddContainer(args)
 	}
+	// The model description cannot yet hold the egress NAT address; the
+	// migration prechecks refuse models that declare one.
+	if machine.doc.EgressNATAddress != "" {
+		e.logger.Warningf("egress NAT address of machine %s is not migrated", machine.Id())
+	}
 	exMachine.SetAddresses(
 		e.newAddressArgsSlice(machine.doc.MachineAddresses),
 		e.newAddressArgsSlice(machine.doc.Addresses))
@@ -563,6 +584,7 @@ func (e *exporter) newMachine(exParent description.Machine, machine *Machine, in
 	}
 
 	exMachine.SetAnnotations(e.getAnnotations(globalKey))
+	e.addEntityUUID(globalKey, machine.UUID())
 
 	constraintsArgs, err := e.constraintsArgs(globalKey)
 	if err != nil {
@@ -922,6 +944,7 @@ func (e *exporter) addApplication(ctx addApplicationContext) error {
 	exApplication.SetStatus(statusArgs)
 	exApplication.SetStatusHistory(e.statusHistoryArgs(globalKey))
 	exApplication.SetAnnotations(e.getAnnotations(globalKey))
+	e.addEntityUUID(globalKey, application.UUID())
 	exApplication.SetCharmOrigin(charmOriginArgs)
 
 	globalAppWorkloadKey := applicationGlobalOperatorKey(appName)
@@ -1051,6 +1074,7 @@ func (e *exporter) addApplication(ctx addApplicationContext) error {
 			e.statusHistoryArgs(globalCCKey)
 		}
 		exUnit.SetAnnotations(e.getAnnotations(globalKey))
+		e.addEntityUUID(globalKey, unit.UUID())
 
 		constraintsArgs, err := e.constraintsArgs(agentKey)
 		if err != nil {
@@ -1191,6 +1215,10 @@ func (e *exporter) relations() error {
 			Key: relation.String(),
 		})
 		globalKey := relation.globalScope()
+		e.addEntityUUID(globalKey, relation.UUID())
+		if generation := relation.KeyGeneration(); generation > 0 {
+			e.relationEncryption.KeyGenerations[relation.String()] = generation
+		}
 		statusArgs, err := e.statusArgs(globalKey)
 		if err == nil {
 			exRelation.SetStatus(statusArgs)
@@ -1479,6 +1507,9 @@ func (e *exporter) spaces() error {
 	e.logger.Debugf("read %d spaces", len(spaces))
 
 	for _, space := range spaces {
+		// The alpha space keeps its UUID even though it is not exported.
+		e.addEntityUUID(spaceUUIDKey(space.Id()), space.UUID())
+
 		// We do not export the alpha space because it is created by default
 		// with the new model. This is OK, because it is immutable.
 		// Any subnets added to the space will still be exported.
@@ -1904,6 +1935,62 @@ func (e *exporter) readAllConstraints() error {
 	return nil
 }
 
+// addEntityUUID records the UUID of an exported entity, so that the
+// entity keeps it when the model is imported.
+func (e *exporter) addEntityUUID(key, uuid string) {
+	if uuid != "" {
+		e.entityUUIDs[key] = uuid
+	}
+}
+
+// entityUUIDAnnotation adds the UUIDs of the exported entities to the
+// model's annotations, as the model description has no field for them.
+func (e *exporter) entityUUIDAnnotation() error {
+	if len(e.entityUUIDs) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(e.entityUUIDs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	annotations := make(map[string]string)
+	for key, value := range e.model.Annotations() {
+		annotations[key] = value
+	}
+	annotations[entityUUIDsAnnotation] = string(data)
+	e.model.SetAnnotations(annotations)
+	return nil
+}
+
+// relationEncryptionAnnotation adds the relation data encryption settings
+// of the exported remote applications and relations to the model's
+// annotations, as the model description has no fields for them.
+func (e *exporter) relationEncryptionAnnotation() error {
+	remoteApps, err := e.st.AllRemoteApplications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, app := range remoteApps {
+		if app.EncryptRelationData() {
+			e.relationEncryption.RemoteApplications = append(e.relationEncryption.RemoteApplications, app.Name())
+		}
+	}
+	if e.relationEncryption.isEmpty() {
+		return nil
+	}
+	data, err := json.Marshal(e.relationEncryption)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	annotations := make(map[string]string)
+	for key, value := range e.model.Annotations() {
+		annotations[key] = value
+	}
+	annotations[relationEncryptionAnnotation] = string(data)
+	e.model.SetAnnotations(annotations)
+	return nil
+}
+
 // getAnnotations doesn't really care if there are any there or not
 // for the key, but if they were there, they are removed so we can
 // check at the end of the export for anything we have forgotten.
@@ -2177,6 +2264,11 @@ func (e *exporter) constraintsArgs(globalKey string) (description.ConstraintsArg
 	if optionalErr != nil {
 		return description.ConstraintsArgs{}, errors.Trace(optionalErr)
 	}
+	// The model description cannot yet hold GPU constraints; the
+	// migration prechecks refuse models that set them.
+	if doc["gpus"] != nil || doc["gputype"] != nil {
+		e.logger.Warningf("gpus and gpu-type constraints for %q are not migrated", globalKey)
+	}
 	return result, nil
 }
 
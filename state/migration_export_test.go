@@ -5,6 +5,7 @@ package state_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"math/rand"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/juju/charm/v9"
 	charmresource "github.com/juju/charm/v9/resource"
+	"github.com/juju/collections/set"
 	"github.com/juju/description/v2"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -204,7 +206,10 @@ func (s *MigrationExportSuite) TestModelInfo(c *gc.C) {
 	c.Assert(modelCfg, jc.DeepEquals, modelAttrs)
 	c.Assert(model.LatestToolsVersion(), gc.Equals, latestTools)
 	c.Assert(model.EnvironVersion(), gc.Equals, environVersion)
-	c.Assert(model.Annotations(), jc.DeepEquals, testAnnotations)
+	annotations := model.Annotations()
+	c.Assert(annotations[state.EntityUUIDsAnnotation], gc.Not(gc.Equals), "")
+	delete(annotations, state.EntityUUIDsAnnotation)
+	c.Assert(annotations, jc.DeepEquals, testAnnotations)
 	constraints := model.Constraints()
 	c.Assert(constraints, gc.NotNil)
 	c.Assert(constraints.Architecture(), gc.Equals, "amd64")
@@ -1205,6 +1210,37 @@ func (s *MigrationExportSuite) TestSpaces(c *gc.C) {
 	c.Assert(space.Public(), jc.IsTrue)
 }
 
+func (s *MigrationExportSuite) TestEntityUUIDs(c *gc.C) {
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: wordpress})
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	relation, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	space := s.Factory.MakeSpace(c, &factory.SpaceParams{Name: "one"})
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	var uuids map[string]string
+	err = json.Unmarshal([]byte(model.Annotations()[state.EntityUUIDsAnnotation]), &uuids)
+	c.Assert(err, jc.ErrorIsNil)
+	exported := set.NewStrings()
+	for _, uuid := range uuids {
+		exported.Add(uuid)
+	}
+	for _, uuid := range []string{
+		wordpress.UUID(), unit.UUID(), machine.UUID(), relation.UUID(), space.UUID(),
+	} {
+		c.Check(exported.Contains(uuid), jc.IsTrue, gc.Commentf("uuid %q", uuid))
+	}
+}
+
 func (s *MigrationExportSuite) TestMultipleSpaces(c *gc.C) {
 	s.Factory.MakeSpace(c, &factory.SpaceParams{Name: "one"})
 	s.Factory.MakeSpace(c, &factory.SpaceParams{Name: "two"})
@@ -5,6 +5,7 @@ package state
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -273,6 +274,14 @@ type importer struct {
 	// applicationUnits is populated at the end of loading the applications, and is a
 	// map of application name to the units of that application.
 	applicationUnits map[string]map[string]*Unit
+	// entityUUIDs is populated from the model annotations, and is a map
+	// of global key to the UUID of each exported application, unit,
+	// machine, relation and space.
+	entityUUIDs map[string]string
+	// relationEncryption is populated from the model annotations, and
+	// holds the relation data encryption settings of the remote
+	// applications and relations.
+	relationEncryption relationEncryption
 }
 
 func (i *importer) modelExtras() error {
@@ -282,7 +291,23 @@ func (i *importer) modelExtras() error {
 		}
 	}
 
-	if annotations := i.model.Annotations(); len(annotations) > 0 {
+	annotations := make(map[string]string)
+	for key, value := range i.model.Annotations() {
+		if key == entityUUIDsAnnotation {
+			if err := json.Unmarshal([]byte(value), &i.entityUUIDs); err != nil {
+				return errors.Annotate(err, "entity UUIDs")
+			}
+			continue
+		}
+		if key == relationEncryptionAnnotation {
+			if err := json.Unmarshal([]byte(value), &i.relationEncryption); err != nil {
+				return errors.Annotate(err, "relation encryption")
+			}
+			continue
+		}
+		annotations[key] = value
+	}
+	if len(annotations) > 0 {
 		if err := i.dbModel.SetAnnotations(i.dbModel, annotations); err != nil {
 			return errors.Trace(err)
 		}
@@ -619,6 +644,7 @@ func (i *importer) makeMachineDoc(m description.Machine) (*machineDoc, error) {
 		DocID:                    i.st.docID(id),
 		Id:                       id,
 		ModelUUID:                i.st.ModelUUID(),
+		UUID:                     i.entityUUID(machineGlobalKey(id)),
 		Nonce:                    m.Nonce(),
 		Series:                   m.Series(),
 		ContainerType:            m.ContainerType(),
@@ -1263,6 +1289,7 @@ func (i *importer) makeApplicationDoc(a description.Application) (*applicationDo
 
 	return &applicationDoc{
 		Name:                 a.Name(),
+		UUID:                 i.entityUUID(applicationGlobalKey(a.Name())),
 		Series:               a.Series(),
 		Subordinate:          a.Subordinate(),
 		CharmURL:             charmURL,
@@ -1420,6 +1447,7 @@ func (i *importer) makeUnitDoc(s description.Application, u description.Unit) (*
 
 	return &unitDoc{
 		Name:                   u.Name(),
+		UUID:                   i.entityUUID(unitGlobalKey(u.Name())),
 		Application:            s.Name(),
 		Series:                 s.Series(),
 		CharmURL:               charmURL,
@@ -1506,6 +1534,8 @@ func (i *importer) makeRemoteApplicationDoc(app description.RemoteApplication) *
 		IsConsumerProxy: app.IsConsumerProxy(),
 		Bindings:        app.Bindings(),
 		Macaroon:        app.Macaroon(),
+
+		EncryptRelationData: i.relationEncryption.encryptsRelationData(app.Name()),
 	}
 	descEndpoints := app.Endpoints()
 	eps := make([]remoteEndpointDoc, len(descEndpoints))
@@ -1631,9 +1661,12 @@ func (i *importer) makeRelationDoc(rel description.Relation) *relationDoc {
 	endpoints := rel.Endpoints()
 	doc := &relationDoc{
 		Key:       rel.Key(),
+		UUID:      i.entityUUID(relationGlobalScope(rel.Id())),
 		Id:        rel.Id(),
 		Endpoints: make([]Endpoint, len(endpoints)),
 		Life:      Alive,
+
+		KeyGeneration: i.relationEncryption.KeyGenerations[rel.Key()],
 	}
 	for i, ep := range endpoints {
 		doc.Endpoints[i] = Endpoint{
@@ -1740,10 +1773,52 @@ func (i *importer) spaces() error {
 		}
 	}
 
+	// Give the imported spaces, and the alpha space created with the
+	// model, the UUIDs they had in the exported model.
+	var ops []txn.Op
+	for _, id := range append(i.spaceIDs(), network.AlphaSpaceId) {
+		uuid, ok := i.entityUUIDs[spaceUUIDKey(id)]
+		if !ok {
+			continue
+		}
+		ops = append(ops, txn.Op{
+			C:      spacesC,
+			Id:     i.st.docID(id),
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"uuid", uuid}}}},
+		})
+	}
+	if len(ops) > 0 {
+		if err := i.st.db().RunTransaction(ops); err != nil {
+			return errors.Annotate(err, "space UUIDs")
+		}
+	}
+
 	i.logger.Debugf("importing spaces succeeded")
 	return nil
 }
 
+// spaceIDs returns the IDs of the imported spaces that kept the ID they
+// had in the exported model.
+func (i *importer) spaceIDs() []string {
+	var ids []string
+	for _, s := range i.model.Spaces() {
+		if s.Id() != "" && s.Name() != network.AlphaSpaceName {
+			ids = append(ids, s.Id())
+		}
+	}
+	return ids
+}
+
+// entityUUID returns the UUID that the entity with the given global key
+// had in the exported model, or a new UUID if it had none.
+func (i *importer) entityUUID(key string) string {
+	if uuid, ok := i.entityUUIDs[key]; ok {
+		return uuid
+	}
+	return newEntityUUID()
+}
+
 func (i *importer) linklayerdevices() error {
 	i.logger.Debugf("importing linklayerdevices")
 	for _, device := range i.model.LinkLayerDevices() {
@@ -21,6 +21,7 @@ import (
 	"gopkg.in/juju/environschema.v1"
 	"gopkg.in/yaml.v2"
 
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/instance"
@@ -636,6 +637,24 @@ func (s *MigrationImportSuite) TestApplications(c *gc.C) {
 	s.assertImportedApplication(c, application, pwd, cons, exported, newModel, newSt, true)
 }
 
+func (s *MigrationImportSuite) TestApplicationScopedCredentialRebuilt(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	credential := cloud.NewCredential(cloud.OAuth2AuthType, map[string]string{
+		"Token": "source-token",
+	})
+	err := application.SetScopedCredential(credential, time.Date(2021, 6, 7, 8, 9, 10, 0, time.UTC))
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c, s.State)
+
+	// The source controller's scoped credential isn't carried over; the
+	// target controller issues a new one for the application.
+	imported, err := newSt.Application(application.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = imported.ScopedCredential()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *MigrationImportSuite) TestApplicationStatus(c *gc.C) {
 	cons := constraints.MustParse("arch=amd64 mem=8G")
 	testCharm, application, pwd := s.setupSourceApplications(c, s.State, cons, false)
@@ -1262,6 +1281,79 @@ func (s *MigrationImportSuite) TestRelations(c *gc.C) {
 	c.Assert(settings.Map(), gc.DeepEquals, relSettings)
 }
 
+func (s *MigrationImportSuite) TestEntityUUIDs(c *gc.C) {
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: wordpress})
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	relation, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	space := s.Factory.MakeSpace(c, &factory.SpaceParams{Name: "one"})
+	alphaSpace, err := s.State.SpaceByName(network.AlphaSpaceName)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c, s.State)
+
+	newWordpress, err := newSt.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(newWordpress.UUID(), gc.Equals, wordpress.UUID())
+	newUnit, err := newSt.Unit(unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(newUnit.UUID(), gc.Equals, unit.UUID())
+	newMachine, err := newSt.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(newMachine.UUID(), gc.Equals, machine.UUID())
+	newRelation, err := newSt.Relation(relation.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(newRelation.UUID(), gc.Equals, relation.UUID())
+	newSpace, err := newSt.SpaceByName("one")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(newSpace.UUID(), gc.Equals, space.UUID())
+	newAlphaSpace, err := newSt.SpaceByName(network.AlphaSpaceName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(newAlphaSpace.UUID(), gc.Equals, alphaSpace.UUID())
+}
+
+func (s *MigrationImportSuite) TestRelationEncryption(c *gc.C) {
+	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	_, err := s.State.AddRemoteApplication(state.AddRemoteApplicationParams{
+		Name:        "gravy-rainbow",
+		SourceModel: s.Model.ModelTag(),
+		Token:       "charisma",
+		Endpoints: []charm.Relation{{
+			Interface: "mysql",
+			Name:      "db",
+			Role:      charm.RoleProvider,
+			Scope:     charm.ScopeGlobal,
+		}},
+		EncryptRelationData: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	eps, err := s.State.InferEndpoints("wordpress", "gravy-rainbow")
+	c.Assert(err, jc.ErrorIsNil)
+	relation, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = relation.RotateKeys()
+	c.Assert(err, jc.ErrorIsNil)
+
+	newModel, newSt := s.importModel(c, s.State)
+
+	newRemoteApp, err := newSt.RemoteApplication("gravy-rainbow")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(newRemoteApp.EncryptRelationData(), jc.IsTrue)
+	newRelation, err := newSt.Relation(relation.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(newRelation.KeyGeneration(), gc.Equals, 2)
+	annotations, err := newModel.Annotations(newModel)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(annotations, gc.HasLen, 0)
+}
+
 func (s *MigrationImportSuite) assertRelationsMissingStatus(c *gc.C, hasUnits bool) {
 	wordpress := state.AddTestingApplication(c, s.State, "wordpress", state.AddTestingCharm(c, s.State, "wordpress"))
 	state.AddTestingApplication(c, s.State, "mysql", state.AddTestingCharm(c, s.State, "mysql"))
@@ -132,6 +132,7 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// upgradeInfoC is used to coordinate upgrades and schema migrations,
 		// and aren't needed for model migrations.
 		upgradeInfoC,
+		upgradeStepCheckpointsC,
 		// Not exported, but the tools will possibly need to be either bundled
 		// with the representation or sent separately.
 		toolsmetadataC,
@@ -209,6 +210,31 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// running within a unit. This is a new feature that is not
 		// backwards compatible with older controllers.
 		unitStatesC,
+
+		// The model spec is not part of the model description. There
+		// is a precheck to ensure that the model has no spec.
+		modelSpecsC,
+
+		// Introspection requests are short lived, and only meaningful
+		// to the agents running against the source controller.
+		introspectionRequestsC,
+
+		// Engine health is reported again by each agent once it is
+		// running against the target controller.
+		engineHealthC,
+
+		// Scoped credentials are sealed with the source controller's
+		// secret backend. They are rebuilt on the target controller:
+		// its scoped-credentials worker issues new ones when the model
+		// starts there.
+		scopedCredentialsC,
+
+		// Secret config values are sealed with the source controller's
+		// secret backend, which the target cannot open. There is a
+		// precheck to ensure that the model has no secret config
+		// values or records of them being read.
+		secretConfigC,
+		secretConfigAccessC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -247,9 +273,10 @@ func (s *MigrationSuite) TestModelDocFields(c *gc.C) {
 		"Name",
 		// Life will always be alive, or we won't be migrating.
 		"Life",
-		// ForceDestroyed is only relevant for models that are being
-		// removed.
+		// ForceDestroyed and TimeOfDying are only relevant for models
+		// that are being removed.
 		"ForceDestroyed",
+		"TimeOfDying",
 		// ControllerUUID is recreated when the new model is created
 		// in the new controller (yay name changes).
 		"ControllerUUID",
@@ -324,6 +351,12 @@ func (s *MigrationSuite) TestMachineDocFields(c *gc.C) {
 		"ForceDestroyed",
 		// Ignored; it gets populated on demand when the agent restarts
 		"AgentStartedAt",
+		// Not yet part of the model description; the migration
+		// prechecks refuse models that declare one.
+		"EgressNATAddress",
+		// Only used to signal link-layer changes to local watchers;
+		// it starts again from zero after import.
+		"NetworkRevision",
 	)
 	migrated := set.NewStrings(
 		"Addresses",
@@ -343,6 +376,8 @@ func (s *MigrationSuite) TestMachineDocFields(c *gc.C) {
 		"SupportedContainers",
 		"SupportedContainersKnown",
 		"Tools",
+		// Carried in a model annotation.
+		"UUID",
 	)
 	s.AssertExportedFields(c, machineDoc{}, migrated.Union(ignored))
 }
@@ -392,6 +427,9 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the application name
 		// appears in relation endpoints.
 		"RelationCount",
+		// RelationSchemas are not part of the model description. There
+		// is a precheck to ensure that no application has any.
+		"RelationSchemas",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -411,6 +449,8 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		"DesiredScale",
 		"Placement",
 		"HasResources",
+		// Carried in a model annotation.
+		"UUID",
 	)
 	s.AssertExportedFields(c, applicationDoc{}, migrated.Union(ignored))
 }
@@ -424,6 +464,8 @@ func (s *MigrationSuite) TestUnitDocFields(c *gc.C) {
 		"Application",
 		// Resolved is not migrated as we check that all is good before we start.
 		"Resolved",
+		// Drain is not migrated as units being drained are on their way out.
+		"Drain",
 		// Series and CharmURL also come from the application.
 		"Series",
 		"CharmURL",
@@ -437,6 +479,8 @@ func (s *MigrationSuite) TestUnitDocFields(c *gc.C) {
 		"MachineId",
 		"Tools",
 		"PasswordHash",
+		// Carried in a model annotation.
+		"UUID",
 	)
 	s.AssertExportedFields(c, unitDoc{}, migrated.Union(ignored))
 }
@@ -482,6 +526,10 @@ func (s *MigrationSuite) TestRelationDocFields(c *gc.C) {
 		"Endpoints",
 		"Suspended",
 		"SuspendedReason",
+		// UUID is carried in a model annotation.
+		"UUID",
+		// KeyGeneration is carried in a model annotation.
+		"KeyGeneration",
 		// Life isn't exported, only alive.
 		"Life",
 		// UnitCount isn't explicitly exported, but defined by the stored
@@ -570,6 +618,10 @@ func (s *MigrationSuite) TestConstraintsDocFields(c *gc.C) {
 		"Arch",
 		"CpuCores",
 		"CpuPower",
+		// Not yet part of the model description; the migration
+		// prechecks refuse models that set them.
+		"Gpus",
+		"GpuType",
 		"Mem",
 		"RootDisk",
 		"RootDiskSource",
@@ -609,6 +661,8 @@ func (s *MigrationSuite) TestSpaceDocFields(c *gc.C) {
 		"Name",
 		"IsPublic",
 		"ProviderId",
+		// Carried in a model annotation.
+		"UUID",
 	)
 	s.AssertExportedFields(c, spaceDoc{}, migrated.Union(ignored))
 }
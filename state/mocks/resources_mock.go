@@ -217,6 +217,21 @@ func (mr *MockResourcesMockRecorder) SetUnitResource(arg0, arg1, arg2 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUnitResource", reflect.TypeOf((*MockResources)(nil).SetUnitResource), arg0, arg1, arg2)
 }
 
+// ShareResource mocks base method
+func (m *MockResources) ShareResource(arg0, arg1, arg2, arg3 string) (resource0.Resource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareResource", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(resource0.Resource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShareResource indicates an expected call of ShareResource
+func (mr *MockResourcesMockRecorder) ShareResource(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareResource", reflect.TypeOf((*MockResources)(nil).ShareResource), arg0, arg1, arg2, arg3)
+}
+
 // UpdatePendingResource mocks base method
 func (m *MockResources) UpdatePendingResource(arg0, arg1, arg2 string, arg3 resource.Resource, arg4 io.Reader) (resource0.Resource, error) {
 	m.ctrl.T.Helper()
@@ -124,6 +124,10 @@ type modelDoc struct {
 	// this model. It only has any meaning when the model is dying or
 	// dead.
 	ForceDestroyed bool `bson:"force-destroyed,omitempty"`
+
+	// TimeOfDying is when the model was destroyed. It is zero while
+	// the model is alive.
+	TimeOfDying time.Time `bson:"time-of-dying,omitempty"`
 }
 
 // slaLevel enumerates the support levels available to a model.
@@ -698,33 +702,71 @@ func (m *Model) ForceDestroyed() bool {
 	return m.doc.ForceDestroyed
 }
 
+// TimeOfDying returns when the model was destroyed, or the zero time
+// if it is alive.
+func (m *Model) TimeOfDying() time.Time {
+	return m.doc.TimeOfDying
+}
+
 // Owner returns tag representing the owner of the model.
 // The owner is the user that created the model.
 func (m *Model) Owner() names.UserTag {
 	return names.NewUserTag(m.doc.Owner)
 }
 
-func modelStatusInvalidCredential() status.StatusInfo {
-	return status.StatusInfo{Status: status.Suspended, Message: "suspended since cloud credential is not valid"}
+// modelStatusInvalidCredential returns the status of a model whose
+// credential is not valid, including the reason it was invalidated.
+func modelStatusInvalidCredential(reason string) status.StatusInfo {
+	message := "suspended since cloud credential is not valid"
+	if reason != "" {
+		message += ": " + reason
+	}
+	return status.StatusInfo{Status: status.Suspended, Message: message}
+}
+
+// modelStatusCredentialExpiry returns the message shown in the status
+// of an available model whose credential is expiring or has expired.
+func (m *Model) modelStatusCredentialExpiry(credential Credential) (string, error) {
+	cfg, err := m.st.ControllerConfig()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	switch expiryStatus, expiry := credential.ExpiryStatus(m.st.clock().Now(), cfg.CredentialExpiryWarningPeriod()); expiryStatus {
+	case CredentialExpiring:
+		return fmt.Sprintf("cloud credential expires at %s", expiry.UTC().Format(time.RFC3339)), nil
+	case CredentialExpired:
+		return fmt.Sprintf("cloud credential expired at %s", expiry.UTC().Format(time.RFC3339)), nil
+	}
+	return "", nil
 }
 
 // Status returns the status of the model.
 func (m *Model) Status() (status.StatusInfo, error) {
 	// If model credential is invalid, model is suspended.
+	var credential *Credential
 	if credentialTag, hasCredential := m.CloudCredentialTag(); hasCredential {
-		credential, err := m.st.CloudCredential(credentialTag)
+		cred, err := m.st.CloudCredential(credentialTag)
 		if err != nil {
 			return status.StatusInfo{}, errors.Annotatef(err, "could not get model credential %v", credentialTag.Id())
 		}
-		if !credential.IsValid() {
-			return modelStatusInvalidCredential(), nil
+		if !cred.IsValid() {
+			return modelStatusInvalidCredential(cred.InvalidReason), nil
 		}
+		credential = &cred
 	}
 
 	modelStatus, err := getStatus(m.st.db(), m.globalKey(), "model")
 	if err != nil {
 		return modelStatus, err
 	}
+	// Warn of an expiring credential without hiding any other status.
+	if credential != nil && modelStatus.Status == status.Available && modelStatus.Message == "" {
+		message, err := m.modelStatusCredentialExpiry(*credential)
+		if err != nil {
+			return status.StatusInfo{}, errors.Trace(err)
+		}
+		modelStatus.Message = message
+	}
 	return modelStatus, nil
 }
 
@@ -1143,6 +1143,24 @@ func (s *ModelSuite) TestForceDestroySetsForceDestroyed(c *gc.C) {
 	c.Assert(model.ForceDestroyed(), gc.Equals, true)
 }
 
+func (s *ModelSuite) TestDestroySetsTimeOfDying(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(model.TimeOfDying().IsZero(), jc.IsTrue)
+
+	err = model.Destroy(state.DestroyModelParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = model.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(model.Life(), gc.Equals, state.Dying)
+	c.Assert(model.TimeOfDying().IsZero(), jc.IsFalse)
+}
+
 func (s *ModelSuite) TestNonForceDestroy(c *gc.C) {
 	st := s.Factory.MakeModel(c, nil)
 	defer st.Close()
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/schema"
 	"github.com/juju/version"
+	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/controller"
 	environscloudspec "github.com/juju/juju/environs/cloudspec"
@@ -164,24 +165,39 @@ func (model *Model) modelConfigValues(modelCfg attrValues) (config.ConfigValues,
 	return result, nil
 }
 
-// UpdateModelConfigDefaultValues updates the inherited settings used when creating a new model.
+// UpdateModelConfigDefaultValues updates the inherited settings used when
+// creating a new model. Existing models on the affected cloud or region
+// that inherit any of the changed attributes are updated to use the new
+// inherited values, so that watchers of their config see the change.
 func (st *State) UpdateModelConfigDefaultValues(updateAttrs map[string]interface{}, removeAttrs []string, regionSpec *environscloudspec.CloudRegionSpec) error {
 	var key string
 
-	if regionSpec != nil {
-		if regionSpec.Region == "" {
-			key = cloudGlobalKey(regionSpec.Cloud)
-		} else {
-			key = regionSettingsGlobalKey(regionSpec.Cloud, regionSpec.Region)
-		}
-	} else {
+	if regionSpec == nil {
 		// For backwards compatibility default to the model's cloud.
 		model, err := st.Model()
 		if err != nil {
 			return errors.Trace(err)
 		}
-		key = cloudGlobalKey(model.CloudName())
+		regionSpec = &environscloudspec.CloudRegionSpec{Cloud: model.CloudName()}
+	}
+	if regionSpec.Region == "" {
+		key = cloudGlobalKey(regionSpec.Cloud)
+	} else {
+		key = regionSettingsGlobalKey(regionSpec.Cloud, regionSpec.Region)
+	}
+
+	// Find the models that inherit the changed attributes before
+	// changing them, as inheritance is determined by comparing each
+	// model's values with the current defaults.
+	changedAttrs := append([]string(nil), removeAttrs...)
+	for attr := range updateAttrs {
+		changedAttrs = append(changedAttrs, attr)
+	}
+	inheriting, err := st.modelsInheritingConfig(*regionSpec, changedAttrs)
+	if err != nil {
+		return errors.Trace(err)
 	}
+
 	settings, err := readSettings(st.db(), globalSettingsC, key)
 	if err != nil {
 		if !errors.IsNotFound(err) {
@@ -192,6 +208,7 @@ func (st *State) UpdateModelConfigDefaultValues(updateAttrs map[string]interface
 		if err != nil {
 			return errors.Annotatef(err, "model %q", st.ModelUUID())
 		}
+		st.updateInheritingModels(inheriting)
 		return nil
 	}
 
@@ -222,8 +239,88 @@ func (st *State) UpdateModelConfigDefaultValues(updateAttrs map[string]interface
 	for _, r := range removeAttrs {
 		settings.Delete(r)
 	}
-	_, err = settings.Write()
-	return err
+	if _, err = settings.Write(); err != nil {
+		return errors.Trace(err)
+	}
+	st.updateInheritingModels(inheriting)
+	return nil
+}
+
+// modelsInheritingConfig returns the attributes, keyed by model UUID,
+// that each alive model on the specified cloud or region inherits
+// rather than sets explicitly. Attributes a model doesn't have at all
+// are treated as inherited, so that new defaults are picked up.
+func (st *State) modelsInheritingConfig(regionSpec environscloudspec.CloudRegionSpec, attrs []string) (map[string][]string, error) {
+	if len(attrs) == 0 {
+		return nil, nil
+	}
+	models, closer := st.db().GetCollection(modelsC)
+	defer closer()
+
+	query := bson.D{{"cloud", regionSpec.Cloud}, {"life", Alive}}
+	if regionSpec.Region != "" {
+		query = append(query, bson.DocElem{"cloud-region", regionSpec.Region})
+	}
+	var docs []bson.M
+	if err := models.Find(query).Select(bson.M{"_id": 1}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "getting models on cloud %q", regionSpec.Cloud)
+	}
+
+	result := make(map[string][]string)
+	for _, doc := range docs {
+		uuid := doc["_id"].(string)
+		inherited, err := st.modelInheritedAttrs(uuid, attrs)
+		if err != nil {
+			return nil, errors.Annotatef(err, "reading config for model %q", uuid)
+		}
+		if len(inherited) > 0 {
+			result[uuid] = inherited
+		}
+	}
+	return result, nil
+}
+
+// modelInheritedAttrs returns those of the specified attributes that
+// the model with the given UUID doesn't set explicitly.
+func (st *State) modelInheritedAttrs(modelUUID string, attrs []string) ([]string, error) {
+	model, closer, err := st.model(modelUUID)
+	defer func() { _ = closer() }()
+	if err != nil || model == nil {
+		return nil, errors.Trace(err)
+	}
+	values, err := model.ModelConfigValues()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var inherited []string
+	for _, attr := range attrs {
+		if value, ok := values[attr]; !ok || value.Source != config.JujuModelConfigSource {
+			inherited = append(inherited, attr)
+		}
+	}
+	return inherited, nil
+}
+
+// updateInheritingModels resets the supplied attributes, keyed by model
+// UUID, to their inherited values. A failure to update one model is
+// logged rather than returned, as the defaults have already changed
+// and the remaining models should still pick them up.
+func (st *State) updateInheritingModels(inheriting map[string][]string) {
+	for uuid, attrs := range inheriting {
+		if err := st.resetModelConfig(uuid, attrs); err != nil {
+			logger.Warningf("cannot update inherited config for model %q: %v", uuid, err)
+		}
+	}
+}
+
+func (st *State) resetModelConfig(modelUUID string, attrs []string) error {
+	model, closer, err := st.model(modelUUID)
+	defer func() { _ = closer() }()
+	if err != nil || model == nil {
+		return errors.Trace(err)
+	}
+	// Removing an attribute resets it to its inherited value.
+	return errors.Trace(model.UpdateModelConfig(nil, attrs))
 }
 
 // ModelConfigValues returns the config values for the model represented
@@ -236,6 +333,46 @@ func (model *Model) ModelConfigValues() (config.ConfigValues, error) {
 	return model.modelConfigValues(cfg.AllAttrs())
 }
 
+// ModelConfigValueTrace returns the effective value of the named model
+// config attribute, along with the value set by each of the config
+// sources it is resolved from.
+func (model *Model) ModelConfigValueTrace(key string) (config.ConfigValueTrace, error) {
+	values, err := model.ModelConfigValues()
+	if err != nil {
+		return config.ConfigValueTrace{}, errors.Trace(err)
+	}
+	rspec, err := model.st.regionSpec()
+	if err != nil {
+		return config.ConfigValueTrace{}, errors.Trace(err)
+	}
+	var trace config.ConfigValueTrace
+	for _, src := range modelConfigSources(model.st, rspec) {
+		cfg, err := src.sourceFunc()
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return config.ConfigValueTrace{}, errors.Annotatef(err, "reading %s settings", src.name)
+		}
+		if val, ok := cfg[key]; ok {
+			trace.Sources = append(trace.Sources, config.ConfigValue{Value: val, Source: src.name})
+		}
+	}
+	effective, ok := values[key]
+	if !ok {
+		if len(trace.Sources) == 0 {
+			return config.ConfigValueTrace{}, errors.NotFoundf("model config attribute %q", key)
+		}
+		return trace, nil
+	}
+	if effective.Source == config.JujuModelConfigSource {
+		trace.Sources = append(trace.Sources, effective)
+	}
+	trace.Value = effective.Value
+	trace.Source = effective.Source
+	return trace, nil
+}
+
 // ModelConfigDefaultValues returns the default config values to be used
 // when creating a new model, and the origin of those values.
 func (st *State) ModelConfigDefaultValues(cloudName string) (config.ModelDefaultAttributes, error) {
@@ -642,3 +642,150 @@ func (s *ModelConfigSourceSuite) TestUpdateModelConfigDefaultValuesUnknownRegion
 				Value: "changed-proxy",
 			}}})
 }
+
+func (s *ModelConfigSourceSuite) TestModelConfigValueTrace(c *gc.C) {
+	trace, err := s.Model.ModelConfigValueTrace("http-proxy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(trace, jc.DeepEquals, config.ConfigValueTrace{
+		Value:  "http://proxy",
+		Source: "controller",
+		Sources: []config.ConfigValue{
+			{Value: "", Source: "default"},
+			{Value: "http://proxy", Source: "controller"},
+		},
+	})
+
+	trace, err = s.Model.ModelConfigValueTrace("no-proxy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(trace, jc.DeepEquals, config.ConfigValueTrace{
+		Value:  "dummy-proxy",
+		Source: "region",
+		Sources: []config.ConfigValue{
+			{Value: "127.0.0.1,localhost,::1", Source: "default"},
+			{Value: "dummy-proxy", Source: "region"},
+		},
+	})
+}
+
+func (s *ModelConfigSourceSuite) TestModelConfigValueTraceModelSource(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{"http-proxy": "http://model-proxy"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	trace, err := s.Model.ModelConfigValueTrace("http-proxy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(trace, jc.DeepEquals, config.ConfigValueTrace{
+		Value:  "http://model-proxy",
+		Source: "model",
+		Sources: []config.ConfigValue{
+			{Value: "", Source: "default"},
+			{Value: "http://proxy", Source: "controller"},
+			{Value: "http://model-proxy", Source: "model"},
+		},
+	})
+}
+
+func (s *ModelConfigSourceSuite) TestModelConfigValueTraceNotFound(c *gc.C) {
+	_, err := s.Model.ModelConfigValueTrace("no-such-key")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ModelConfigSourceSuite) TestUpdateModelConfigDefaultsPropagates(c *gc.C) {
+	err := s.State.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"http-proxy": "http://new-proxy",
+		"ftp-proxy":  "http://new-ftp-proxy",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	values, err := s.Model.ModelConfigValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values["http-proxy"], jc.DeepEquals, config.ConfigValue{
+		Value: "http://new-proxy", Source: "controller",
+	})
+	c.Assert(values["ftp-proxy"], jc.DeepEquals, config.ConfigValue{
+		Value: "http://new-ftp-proxy", Source: "controller",
+	})
+
+	// Removing the default reverts inheriting models to the next source.
+	err = s.State.UpdateModelConfigDefaultValues(nil, []string{"http-proxy"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	values, err = s.Model.ModelConfigValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values["http-proxy"], jc.DeepEquals, config.ConfigValue{
+		Value: "", Source: "default",
+	})
+}
+
+func (s *ModelConfigSourceSuite) TestUpdateModelConfigDefaultsKeepsModelValues(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{"http-proxy": "http://model-proxy"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"http-proxy": "http://new-proxy",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	values, err := s.Model.ModelConfigValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values["http-proxy"], jc.DeepEquals, config.ConfigValue{
+		Value: "http://model-proxy", Source: "model",
+	})
+}
+
+func (s *ModelConfigSourceSuite) TestUpdateModelConfigDefaultsRegionOverridesController(c *gc.C) {
+	// The model inherits no-proxy from its region, so a controller
+	// default doesn't change it.
+	err := s.State.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"no-proxy": "controller-proxy",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	values, err := s.Model.ModelConfigValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values["no-proxy"], jc.DeepEquals, config.ConfigValue{
+		Value: "dummy-proxy", Source: "region",
+	})
+
+	// Changing the region default does.
+	rspec, err := environscloudspec.NewCloudRegionSpec("dummy", "dummy-region")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"no-proxy": "changed-proxy",
+	}, nil, rspec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	values, err = s.Model.ModelConfigValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values["no-proxy"], jc.DeepEquals, config.ConfigValue{
+		Value: "changed-proxy", Source: "region",
+	})
+}
+
+func (s *ModelConfigSourceSuite) TestUpdateModelConfigRegionDefaultsOtherRegion(c *gc.C) {
+	rspec, err := environscloudspec.NewCloudRegionSpec("dummy", "nether-region")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"http-proxy": "http://nether-proxy",
+	}, nil, rspec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	values, err := s.Model.ModelConfigValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values["http-proxy"], jc.DeepEquals, config.ConfigValue{
+		Value: "http://proxy", Source: "controller",
+	})
+}
+
+func (s *ModelConfigSourceSuite) TestUpdateModelConfigDefaultsTriggersWatcher(c *gc.C) {
+	s.WaitForModelWatchersIdle(c, s.Model.UUID())
+	w := s.Model.WatchForModelConfigChanges()
+	defer statetesting.AssertStop(c, w)
+
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.State.UpdateModelConfigDefaultValues(map[string]interface{}{
+		"http-proxy": "http://new-proxy",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -298,6 +298,24 @@ func (s *ModelCredentialSuite) TestInvalidateModelCredentialTouchesAllCredential
 	}
 }
 
+func (s *ModelCredentialSuite) TestInvalidateModelCredentialStatusShowsReason(c *gc.C) {
+	cloudName, credentialOwner, credentialTag := assertCredentialCreated(c, s.ConnSuite)
+	modelUUID := assertModelCreated(c, s.ConnSuite, cloudName, credentialTag, credentialOwner.Tag(), "model-for-cloud")
+
+	oneModelState, helper, err := s.StatePool.GetModel(modelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	defer helper.Release()
+	c.Assert(oneModelState.State().InvalidateModelCredential("token revoked"), jc.ErrorIsNil)
+
+	modelStatus, err := oneModelState.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelStatus.Status, gc.Equals, status.Suspended)
+	c.Assert(modelStatus.Message, gc.Equals, "suspended since cloud credential is not valid: token revoked")
+
+	history := assertModelHistories(c, s.StatePool, modelUUID, status.Suspended, status.Available)
+	c.Assert(history[0].Message, gc.Equals, "suspended since cloud credential is not valid: token revoked")
+}
+
 func (s *ModelCredentialSuite) TestSetCredentialRevertsModelStatus(c *gc.C) {
 	// 1. create a credential
 	cloudName, credentialOwner, credentialTag := assertCredentialCreated(c, s.ConnSuite)
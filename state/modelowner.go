@@ -0,0 +1,142 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/permission"
+)
+
+// TransferOwnership makes the specified user the owner of the model.
+// The new owner is granted admin access to the model and to each of the
+// application offers it hosts, in the same transaction as the ownership
+// change. Access held by the previous owner is left as it was, so that
+// it may be revoked separately once the transfer is complete.
+func (m *Model) TransferOwnership(newOwner names.UserTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot transfer ownership of model %q to %q", m.Name(), newOwner.Id())
+
+	if newOwner.IsLocal() {
+		if _, err := m.st.User(newOwner); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.Life() != Alive {
+			return nil, errors.New("model is no longer alive")
+		}
+		if m.MigrationMode() != MigrationModeNone {
+			return nil, errors.New("model is being migrated")
+		}
+		if m.Owner() == newOwner {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if err := m.checkOwnerModelNameAvailable(newOwner); err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		ops := []txn.Op{{
+			C:  modelsC,
+			Id: m.doc.UUID,
+			Assert: bson.D{
+				{"owner", m.doc.Owner},
+				{"life", Alive},
+				{"migration-mode", MigrationModeNone},
+			},
+			Update: bson.D{{"$set", bson.D{{"owner", newOwner.Id()}}}},
+		}, {
+			C:      usermodelnameC,
+			Id:     m.uniqueIndexID(),
+			Assert: txn.DocExists,
+			Remove: true,
+		},
+			createUniqueOwnerModelNameOp(newOwner, m.doc.Name),
+		}
+
+		accessOps, err := m.ownerModelAccessOps(newOwner)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, accessOps...)
+
+		offerOps, err := m.ownerOfferAccessOps(newOwner)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, offerOps...), nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return m.Refresh()
+}
+
+// checkOwnerModelNameAvailable returns an error satisfying
+// errors.IsAlreadyExists if the specified user already owns a model
+// with the same name as this one.
+func (m *Model) checkOwnerModelNameAvailable(owner names.UserTag) error {
+	models, closer := m.st.db().GetCollection(modelsC)
+	defer closer()
+	count, err := models.Find(bson.D{
+		{"owner", owner.Id()},
+		{"name", m.doc.Name},
+	}).Count()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if count > 0 {
+		return errors.AlreadyExistsf("model %q for %s", m.doc.Name, owner.Id())
+	}
+	return nil
+}
+
+// ownerModelAccessOps returns the operations needed to give the
+// specified user admin access to the model, adding them as a model
+// user if necessary.
+func (m *Model) ownerModelAccessOps(owner names.UserTag) ([]txn.Op, error) {
+	_, err := m.st.modelUser(m.doc.UUID, owner)
+	if errors.IsNotFound(err) {
+		return createModelUserOps(
+			m.doc.UUID, owner, m.Owner(), "", m.st.nowToTheSecond(), permission.AdminAccess,
+		), nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return []txn.Op{
+		updatePermissionOp(modelKey(m.doc.UUID), userGlobalKey(userAccessID(owner)), permission.AdminAccess),
+	}, nil
+}
+
+// ownerOfferAccessOps returns the operations needed to give the
+// specified user admin access to each of the model's offers.
+func (m *Model) ownerOfferAccessOps(owner names.UserTag) ([]txn.Op, error) {
+	offers, err := NewApplicationOffers(m.st).AllApplicationOffers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ops []txn.Op
+	subjectKey := userGlobalKey(userAccessID(owner))
+	for _, offer := range offers {
+		objectKey := applicationOfferKey(offer.OfferUUID)
+		_, err := m.st.userPermission(objectKey, subjectKey)
+		if errors.IsNotFound(err) {
+			ops = append(ops, createPermissionOp(objectKey, subjectKey, permission.AdminAccess))
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, updatePermissionOp(objectKey, subjectKey, permission.AdminAccess))
+	}
+	return ops, nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ModelOwnerSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ModelOwnerSuite{})
+
+func (s *ModelOwnerSuite) TestTransferOwnership(c *gc.C) {
+	s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	offer, err := state.NewApplicationOffers(s.State).AddOffer(crossmodel.AddApplicationOfferArgs{
+		OfferName:       "hosted-mysql",
+		ApplicationName: "mysql",
+		Endpoints:       map[string]string{"server": "server"},
+		Owner:           s.Owner.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true}).UserTag()
+
+	err = s.Model.TransferOwnership(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.Model.Owner(), gc.Equals, bob)
+
+	m, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Owner(), gc.Equals, bob)
+
+	access, err := s.State.UserAccess(bob, s.Model.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access.Access, gc.Equals, permission.AdminAccess)
+	c.Assert(access.CreatedBy, gc.Equals, s.Owner)
+
+	offerAccess, err := s.State.GetOfferAccess(offer.OfferUUID, bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offerAccess, gc.Equals, permission.AdminAccess)
+
+	// The previous owner's access is untouched.
+	access, err = s.State.UserAccess(s.Owner, s.Model.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access.Access, gc.Equals, permission.AdminAccess)
+}
+
+func (s *ModelOwnerSuite) TestTransferOwnershipExistingModelUser(c *gc.C) {
+	bob := s.Factory.MakeModelUser(c, &factory.ModelUserParams{
+		User:   "bob",
+		Access: permission.ReadAccess,
+	}).UserTag
+
+	err := s.Model.TransferOwnership(bob)
+	c.Assert(err, jc.ErrorIsNil)
+
+	access, err := s.State.UserAccess(bob, s.Model.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access.Access, gc.Equals, permission.AdminAccess)
+}
+
+func (s *ModelOwnerSuite) TestTransferOwnershipSameOwner(c *gc.C) {
+	err := s.Model.TransferOwnership(s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.Model.Owner(), gc.Equals, s.Owner)
+}
+
+func (s *ModelOwnerSuite) TestTransferOwnershipUnknownUser(c *gc.C) {
+	err := s.Model.TransferOwnership(names.NewUserTag("nobody"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(s.Model.Owner(), gc.Equals, s.Owner)
+}
+
+func (s *ModelOwnerSuite) TestTransferOwnershipNameClash(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true}).UserTag()
+	st := s.Factory.MakeModel(c, &factory.ModelParams{
+		Name:  s.Model.Name(),
+		Owner: bob,
+	})
+	defer st.Close()
+
+	err := s.Model.TransferOwnership(bob)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	c.Assert(err, gc.ErrorMatches, `cannot transfer ownership of model "testmodel" to "bob": model "testmodel" for bob already exists`)
+}
+
+func (s *ModelOwnerSuite) TestTransferOwnershipDyingModel(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true}).UserTag()
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Destroy(state.DestroyModelParams{}), jc.ErrorIsNil)
+
+	err = m.TransferOwnership(bob)
+	c.Assert(err, gc.ErrorMatches, `cannot transfer ownership of model .* to "bob": model is no longer alive`)
+}
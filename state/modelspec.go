@@ -0,0 +1,220 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+const (
+	// modelSpecKey is the key of the model spec document.
+	modelSpecKey = "spec"
+
+	// modelSpecStatusKey is the key of the document holding the status
+	// of reconciling the model towards its spec. It is kept apart from
+	// the spec so that recording the status doesn't trigger the spec
+	// watcher.
+	modelSpecStatusKey = "status"
+)
+
+// ModelSpec holds the declarative spec of a model, as set with
+// juju apply.
+type ModelSpec struct {
+	// Spec holds the YAML of the spec.
+	Spec string
+
+	// Revision is incremented each time the spec is set.
+	Revision int
+}
+
+// ModelSpecStatus holds the status of reconciling a model towards its
+// spec.
+type ModelSpecStatus struct {
+	// Revision is the revision of the spec last reconciled.
+	Revision int
+
+	// Reconciled is when the model was last reconciled.
+	Reconciled time.Time
+
+	// Drift describes the differences between the model and its spec
+	// which could not be reconciled.
+	Drift []string
+}
+
+type modelSpecDoc struct {
+	DocID    string `bson:"_id"`
+	Spec     string `bson:"spec"`
+	Revision int    `bson:"revision"`
+}
+
+type modelSpecStatusDoc struct {
+	DocID      string    `bson:"_id"`
+	Revision   int       `bson:"revision"`
+	Reconciled time.Time `bson:"reconciled"`
+	Drift      []string  `bson:"drift,omitempty"`
+}
+
+// ModelSpec returns the declarative spec of the model, or an error
+// satisfying errors.IsNotFound if none has been set.
+func (st *State) ModelSpec() (ModelSpec, error) {
+	specs, closer := st.db().GetCollection(modelSpecsC)
+	defer closer()
+
+	var doc modelSpecDoc
+	if err := specs.FindId(modelSpecKey).One(&doc); err == mgo.ErrNotFound {
+		return ModelSpec{}, errors.NotFoundf("model spec")
+	} else if err != nil {
+		return ModelSpec{}, errors.Annotate(err, "cannot get model spec")
+	}
+	return ModelSpec{Spec: doc.Spec, Revision: doc.Revision}, nil
+}
+
+// SetModelSpec sets the declarative spec of the model, and returns its
+// new revision. The spec is not validated here.
+func (st *State) SetModelSpec(spec string) (int, error) {
+	var revision int
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		current, err := st.ModelSpec()
+		if errors.IsNotFound(err) {
+			revision = 1
+			return []txn.Op{{
+				C:      modelSpecsC,
+				Id:     modelSpecKey,
+				Assert: txn.DocMissing,
+				Insert: &modelSpecDoc{
+					Spec:     spec,
+					Revision: revision,
+				},
+			}}, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if current.Spec == spec {
+			revision = current.Revision
+			return nil, jujutxn.ErrNoOperations
+		}
+		revision = current.Revision + 1
+		return []txn.Op{{
+			C:      modelSpecsC,
+			Id:     modelSpecKey,
+			Assert: bson.D{{"revision", current.Revision}},
+			Update: bson.D{{"$set", bson.D{
+				{"spec", spec},
+				{"revision", revision},
+			}}},
+		}}, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return 0, errors.Annotate(err, "cannot set model spec")
+	}
+	return revision, nil
+}
+
+// RemoveModelSpec removes the declarative spec of the model, and the
+// status of reconciling the model towards it. The model is left as it
+// is, and is no longer reconciled.
+func (st *State) RemoveModelSpec() error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		specs, closer := st.db().GetCollection(modelSpecsC)
+		defer closer()
+
+		var ops []txn.Op
+		for _, key := range []string{modelSpecKey, modelSpecStatusKey} {
+			n, err := specs.FindId(key).Count()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if n > 0 {
+				ops = append(ops, txn.Op{
+					C:      modelSpecsC,
+					Id:     key,
+					Assert: txn.DocExists,
+					Remove: true,
+				})
+			}
+		}
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return ops, nil
+	}
+	return errors.Annotate(st.db().Run(buildTxn), "cannot remove model spec")
+}
+
+// ModelSpecStatus returns the status of reconciling the model towards its
+// spec, or an error satisfying errors.IsNotFound if the model has not been
+// reconciled.
+func (st *State) ModelSpecStatus() (ModelSpecStatus, error) {
+	specs, closer := st.db().GetCollection(modelSpecsC)
+	defer closer()
+
+	var doc modelSpecStatusDoc
+	if err := specs.FindId(modelSpecStatusKey).One(&doc); err == mgo.ErrNotFound {
+		return ModelSpecStatus{}, errors.NotFoundf("model spec status")
+	} else if err != nil {
+		return ModelSpecStatus{}, errors.Annotate(err, "cannot get model spec status")
+	}
+	return ModelSpecStatus{
+		Revision:   doc.Revision,
+		Reconciled: doc.Reconciled.UTC(),
+		Drift:      doc.Drift,
+	}, nil
+}
+
+// SetModelSpecStatus records the status of reconciling the model towards
+// its spec. The status is not recorded if the spec has been removed.
+func (st *State) SetModelSpecStatus(status ModelSpecStatus) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := st.ModelSpec(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		specs, closer := st.db().GetCollection(modelSpecsC)
+		defer closer()
+
+		ops := []txn.Op{{
+			C:      modelSpecsC,
+			Id:     modelSpecKey,
+			Assert: txn.DocExists,
+		}}
+		n, err := specs.FindId(modelSpecStatusKey).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n == 0 {
+			return append(ops, txn.Op{
+				C:      modelSpecsC,
+				Id:     modelSpecStatusKey,
+				Assert: txn.DocMissing,
+				Insert: &modelSpecStatusDoc{
+					Revision:   status.Revision,
+					Reconciled: status.Reconciled,
+					Drift:      status.Drift,
+				},
+			}), nil
+		}
+		return append(ops, txn.Op{
+			C:      modelSpecsC,
+			Id:     modelSpecStatusKey,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"revision", status.Revision},
+				{"reconciled", status.Reconciled},
+				{"drift", status.Drift},
+			}}},
+		}), nil
+	}
+	return errors.Annotate(st.db().Run(buildTxn), "cannot set model spec status")
+}
+
+// WatchModelSpec returns a watcher which notifies when the declarative
+// spec of the model is set or removed.
+func (st *State) WatchModelSpec() NotifyWatcher {
+	return newEntityWatcher(st, modelSpecsC, st.docID(modelSpecKey))
+}
@@ -0,0 +1,130 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type ModelSpecSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ModelSpecSuite{})
+
+func (s *ModelSpecSuite) TestModelSpecNotFound(c *gc.C) {
+	_, err := s.State.ModelSpec()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.ModelSpecStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ModelSpecSuite) TestSetModelSpec(c *gc.C) {
+	revision, err := s.State.SetModelSpec("applications: {}\n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(revision, gc.Equals, 1)
+
+	spec, err := s.State.ModelSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec, jc.DeepEquals, state.ModelSpec{Spec: "applications: {}\n", Revision: 1})
+
+	// Setting the same spec again doesn't change the revision.
+	revision, err = s.State.SetModelSpec("applications: {}\n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(revision, gc.Equals, 1)
+
+	revision, err = s.State.SetModelSpec("relations: []\n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(revision, gc.Equals, 2)
+	spec, err = s.State.ModelSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec, jc.DeepEquals, state.ModelSpec{Spec: "relations: []\n", Revision: 2})
+}
+
+func (s *ModelSpecSuite) TestSetModelSpecStatus(c *gc.C) {
+	_, err := s.State.SetModelSpec("applications: {}\n")
+	c.Assert(err, jc.ErrorIsNil)
+
+	reconciled := time.Date(2021, 5, 4, 3, 2, 1, 0, time.UTC)
+	err = s.State.SetModelSpecStatus(state.ModelSpecStatus{
+		Revision:   1,
+		Reconciled: reconciled,
+		Drift:      []string{`application "mysql" is not deployed`},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	status, err := s.State.ModelSpecStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, state.ModelSpecStatus{
+		Revision:   1,
+		Reconciled: reconciled,
+		Drift:      []string{`application "mysql" is not deployed`},
+	})
+
+	err = s.State.SetModelSpecStatus(state.ModelSpecStatus{
+		Revision:   1,
+		Reconciled: reconciled.Add(time.Minute),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	status, err = s.State.ModelSpecStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, state.ModelSpecStatus{
+		Revision:   1,
+		Reconciled: reconciled.Add(time.Minute),
+	})
+}
+
+func (s *ModelSpecSuite) TestSetModelSpecStatusNoSpec(c *gc.C) {
+	err := s.State.SetModelSpecStatus(state.ModelSpecStatus{Revision: 1})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ModelSpecSuite) TestRemoveModelSpec(c *gc.C) {
+	_, err := s.State.SetModelSpec("applications: {}\n")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetModelSpecStatus(state.ModelSpecStatus{Revision: 1})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveModelSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ModelSpec()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.ModelSpecStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing it again is a no-op.
+	err = s.State.RemoveModelSpec()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelSpecSuite) TestWatchModelSpec(c *gc.C) {
+	w := s.State.WatchModelSpec()
+	defer statetesting.AssertStop(c, w)
+
+	// Initial event.
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	_, err := s.State.SetModelSpec("applications: {}\n")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Recording the reconciliation status doesn't trigger the watcher.
+	err = s.State.SetModelSpecStatus(state.ModelSpecStatus{Revision: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	err = s.State.RemoveModelSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	statetesting.AssertStop(c, w)
+	wc.AssertClosed()
+}
@@ -324,7 +324,7 @@ func (s *ModelSummariesSuite) TestContainsModelStatusSuspended(c *gc.C) {
 	expectedStatus := map[string]status.StatusInfo{
 		"shared": {
 			Status:  status.Suspended,
-			Message: "suspended since cloud credential is not valid",
+			Message: "suspended since cloud credential is not valid: test",
 		},
 		"user1model": {
 			Status:  status.Busy,
@@ -0,0 +1,304 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// OrphanedDocument describes a document that refers to an entity which
+// no longer exists, and which will therefore never be cleaned up by the
+// usual removal of that entity.
+type OrphanedDocument struct {
+	// Collection is the name of the collection holding the document.
+	Collection string
+
+	// ModelUUID is the UUID of the model the document belongs to.
+	ModelUUID string
+
+	// Id is the ID of the document within its model.
+	Id string
+
+	// Reason describes why the document is orphaned.
+	Reason string
+}
+
+// docID returns the controller-wide ID of the document.
+func (o OrphanedDocument) docID() string {
+	return ensureModelUUID(o.ModelUUID, o.Id)
+}
+
+// FindOrphanedDocuments returns the orphaned documents in every model on
+// the controller. It looks for units whose application does not exist,
+// link-layer devices of machines which are dead or do not exist, and
+// relations with an endpoint on an application which does not exist.
+func (st *State) FindOrphanedDocuments() ([]OrphanedDocument, error) {
+	applications, err := st.allDocIDs(applicationsC, remoteApplicationsC)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineLife, err := st.allMachineLife()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var orphans []OrphanedDocument
+	for _, find := range []func(set.Strings, map[string]Life) ([]OrphanedDocument, error){
+		st.findOrphanedUnits,
+		st.findOrphanedLinkLayerDevices,
+		st.findOrphanedRelations,
+	} {
+		found, err := find(applications, machineLife)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		orphans = append(orphans, found...)
+	}
+	sort.Slice(orphans, func(i, j int) bool {
+		if orphans[i].Collection != orphans[j].Collection {
+			return orphans[i].Collection < orphans[j].Collection
+		}
+		return orphans[i].docID() < orphans[j].docID()
+	})
+	return orphans, nil
+}
+
+func (st *State) findOrphanedUnits(applications set.Strings, _ map[string]Life) ([]OrphanedDocument, error) {
+	units, closer := st.db().GetRawCollection(unitsC)
+	defer closer()
+
+	var orphans []OrphanedDocument
+	var doc unitDoc
+	iter := units.Find(nil).Select(bson.D{{"name", 1}, {"model-uuid", 1}, {"application", 1}}).Iter()
+	for iter.Next(&doc) {
+		if applications.Contains(ensureModelUUID(doc.ModelUUID, doc.Application)) {
+			continue
+		}
+		orphans = append(orphans, OrphanedDocument{
+			Collection: unitsC,
+			ModelUUID:  doc.ModelUUID,
+			Id:         doc.Name,
+			Reason:     fmt.Sprintf("application %q not found", doc.Application),
+		})
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Annotate(err, "reading units")
+	}
+	return orphans, nil
+}
+
+func (st *State) findOrphanedLinkLayerDevices(_ set.Strings, machineLife map[string]Life) ([]OrphanedDocument, error) {
+	devices, closer := st.db().GetRawCollection(linkLayerDevicesC)
+	defer closer()
+
+	var orphans []OrphanedDocument
+	var doc linkLayerDeviceDoc
+	iter := devices.Find(nil).Iter()
+	for iter.Next(&doc) {
+		var reason string
+		life, ok := machineLife[ensureModelUUID(doc.ModelUUID, doc.MachineID)]
+		switch {
+		case !ok:
+			reason = fmt.Sprintf("machine %q not found", doc.MachineID)
+		case life == Dead:
+			reason = fmt.Sprintf("machine %q is dead", doc.MachineID)
+		default:
+			continue
+		}
+		_, id, _ := splitDocID(doc.DocID)
+		orphans = append(orphans, OrphanedDocument{
+			Collection: linkLayerDevicesC,
+			ModelUUID:  doc.ModelUUID,
+			Id:         id,
+			Reason:     reason,
+		})
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Annotate(err, "reading link-layer devices")
+	}
+	return orphans, nil
+}
+
+func (st *State) findOrphanedRelations(applications set.Strings, _ map[string]Life) ([]OrphanedDocument, error) {
+	relations, closer := st.db().GetRawCollection(relationsC)
+	defer closer()
+
+	var orphans []OrphanedDocument
+	var doc relationDoc
+	iter := relations.Find(nil).Iter()
+	for iter.Next(&doc) {
+		for _, ep := range doc.Endpoints {
+			if applications.Contains(ensureModelUUID(doc.ModelUUID, ep.ApplicationName)) {
+				continue
+			}
+			orphans = append(orphans, OrphanedDocument{
+				Collection: relationsC,
+				ModelUUID:  doc.ModelUUID,
+				Id:         doc.Key,
+				Reason:     fmt.Sprintf("application %q not found", ep.ApplicationName),
+			})
+			break
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Annotate(err, "reading relations")
+	}
+	return orphans, nil
+}
+
+// allDocIDs returns the IDs of all the documents in the given collections.
+func (st *State) allDocIDs(collections ...string) (set.Strings, error) {
+	ids := set.NewStrings()
+	for _, name := range collections {
+		coll, closer := st.db().GetRawCollection(name)
+		var doc struct {
+			DocID string `bson:"_id"`
+		}
+		iter := coll.Find(nil).Select(bson.D{{"_id", 1}}).Iter()
+		for iter.Next(&doc) {
+			ids.Add(doc.DocID)
+		}
+		err := iter.Close()
+		closer()
+		if err != nil {
+			return nil, errors.Annotatef(err, "reading %s", name)
+		}
+	}
+	return ids, nil
+}
+
+// allMachineLife returns the life of every machine on the controller,
+// keyed by machine document ID.
+func (st *State) allMachineLife() (map[string]Life, error) {
+	machines, closer := st.db().GetRawCollection(machinesC)
+	defer closer()
+
+	life := make(map[string]Life)
+	var doc struct {
+		DocID string `bson:"_id"`
+		Life  Life   `bson:"life"`
+	}
+	iter := machines.Find(nil).Select(bson.D{{"_id", 1}, {"life", 1}}).Iter()
+	for iter.Next(&doc) {
+		life[doc.DocID] = doc.Life
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Annotate(err, "reading machines")
+	}
+	return life, nil
+}
+
+// RemoveOrphanedDocuments removes the given orphaned documents, as found
+// by FindOrphanedDocuments. Each document is removed only if it is still
+// orphaned. Only the orphaned documents themselves, and the addresses of
+// orphaned link-layer devices, are removed.
+func (st *State) RemoveOrphanedDocuments(orphans []OrphanedDocument) error {
+	applications, err := st.allDocIDs(applicationsC, remoteApplicationsC)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	machineLife, err := st.allMachineLife()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, orphan := range orphans {
+		ops, err := st.removeOrphanedDocumentOps(orphan, applications, machineLife)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(ops) == 0 {
+			err = txn.ErrAborted
+		} else {
+			err = st.runRawTransaction(ops)
+		}
+		if err == txn.ErrAborted {
+			logger.Debugf("%s %q in model %s is no longer orphaned", orphan.Collection, orphan.Id, orphan.ModelUUID)
+		} else if err != nil {
+			return errors.Annotatef(err, "removing %s %q in model %s", orphan.Collection, orphan.Id, orphan.ModelUUID)
+		}
+	}
+	return nil
+}
+
+// removeOrphanedDocumentOps returns the operations needed to remove the
+// orphaned document, asserting that the entities it refers to are still
+// missing or dead.
+func (st *State) removeOrphanedDocumentOps(
+	orphan OrphanedDocument, applications set.Strings, machineLife map[string]Life,
+) ([]txn.Op, error) {
+	var ops []txn.Op
+	assertApplicationMissing := func(name string) {
+		id := ensureModelUUID(orphan.ModelUUID, name)
+		ops = append(ops,
+			txn.Op{C: applicationsC, Id: id, Assert: txn.DocMissing},
+			txn.Op{C: remoteApplicationsC, Id: id, Assert: txn.DocMissing},
+		)
+	}
+
+	switch orphan.Collection {
+	case unitsC:
+		applicationName, err := names.UnitApplication(orphan.Id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		assertApplicationMissing(applicationName)
+
+	case relationsC:
+		// Relation keys are made of the space separated endpoints,
+		// each of the form <application>:<relation>.
+		for _, ep := range strings.Fields(orphan.Id) {
+			applicationName := strings.SplitN(ep, ":", 2)[0]
+			if !applications.Contains(ensureModelUUID(orphan.ModelUUID, applicationName)) {
+				assertApplicationMissing(applicationName)
+			}
+		}
+		if len(ops) == 0 {
+			// The relation's applications have all reappeared.
+			return nil, nil
+		}
+
+	case linkLayerDevicesC:
+		machineID, deviceName, ok := parseLinkLayerDeviceGlobalKey(orphan.Id)
+		if !ok {
+			return nil, errors.Errorf("link-layer device %q has unexpected key format", orphan.Id)
+		}
+		machineDocID := ensureModelUUID(orphan.ModelUUID, machineID)
+		var machineAssert interface{} = txn.DocMissing
+		if _, ok := machineLife[machineDocID]; ok {
+			machineAssert = isDeadDoc
+		}
+		ops = append(ops, txn.Op{C: machinesC, Id: machineDocID, Assert: machineAssert})
+
+		addresses, closer := st.db().GetRawCollection(ipAddressesC)
+		defer closer()
+		var address struct {
+			DocID string `bson:"_id"`
+		}
+		query := append(bson.D{{"model-uuid", orphan.ModelUUID}}, findAddressesQuery(machineID, deviceName)...)
+		iter := addresses.Find(query).Select(bson.D{{"_id", 1}}).Iter()
+		for iter.Next(&address) {
+			ops = append(ops, txn.Op{C: ipAddressesC, Id: address.DocID, Remove: true})
+		}
+		if err := iter.Close(); err != nil {
+			return nil, errors.Annotate(err, "reading addresses")
+		}
+
+	default:
+		return nil, errors.NotSupportedf("removing orphaned %s documents", orphan.Collection)
+	}
+	return append(ops, txn.Op{
+		C:      orphan.Collection,
+		Id:     orphan.docID(),
+		Assert: txn.DocExists,
+		Remove: true,
+	}), nil
+}
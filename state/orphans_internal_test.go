@@ -0,0 +1,155 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type orphansSuite struct {
+	internalStateSuite
+}
+
+var _ = gc.Suite(&orphansSuite{})
+
+func (s *orphansSuite) insert(c *gc.C, collection string, docs ...interface{}) {
+	coll, closer := s.state.db().GetRawCollection(collection)
+	defer closer()
+	err := coll.Insert(docs...)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *orphansSuite) count(c *gc.C, collection string, query bson.D) int {
+	coll, closer := s.state.db().GetRawCollection(collection)
+	defer closer()
+	n, err := coll.Find(query).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	return n
+}
+
+func (s *orphansSuite) addOrphans(c *gc.C) string {
+	uuid := s.state.ModelUUID()
+
+	_, err := s.state.AddMachine("focal", JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	m1, err := s.state.AddMachine("focal", JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = m1.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+
+	app := AddTestingApplication(c, s.state, "wordpress", AddTestingCharm(c, s.state, "wordpress"))
+	_, err = app.AddUnit(AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.insert(c, unitsC, bson.M{
+		"_id":         uuid + ":ghost/0",
+		"name":        "ghost/0",
+		"model-uuid":  uuid,
+		"application": "ghost",
+	})
+	s.insert(c, relationsC, bson.M{
+		"_id":        uuid + ":ghost:db wordpress:db",
+		"key":        "ghost:db wordpress:db",
+		"model-uuid": uuid,
+		"endpoints": []bson.M{
+			{"applicationname": "ghost"},
+			{"applicationname": "wordpress"},
+		},
+	})
+	device := func(machineID string) bson.M {
+		return bson.M{
+			"_id":        uuid + ":m#" + machineID + "#d#eth0",
+			"name":       "eth0",
+			"model-uuid": uuid,
+			"machine-id": machineID,
+		}
+	}
+	s.insert(c, linkLayerDevicesC, device("0"), device("1"), device("42"))
+	s.insert(c, ipAddressesC, bson.M{
+		"_id":         uuid + ":m#42#d#eth0#ip#10.0.0.42",
+		"model-uuid":  uuid,
+		"machine-id":  "42",
+		"device-name": "eth0",
+	})
+	return uuid
+}
+
+func (s *orphansSuite) TestFindOrphanedDocuments(c *gc.C) {
+	uuid := s.addOrphans(c)
+
+	orphans, err := s.state.FindOrphanedDocuments()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(orphans, jc.DeepEquals, []OrphanedDocument{{
+		Collection: linkLayerDevicesC,
+		ModelUUID:  uuid,
+		Id:         "m#1#d#eth0",
+		Reason:     `machine "1" is dead`,
+	}, {
+		Collection: linkLayerDevicesC,
+		ModelUUID:  uuid,
+		Id:         "m#42#d#eth0",
+		Reason:     `machine "42" not found`,
+	}, {
+		Collection: relationsC,
+		ModelUUID:  uuid,
+		Id:         "ghost:db wordpress:db",
+		Reason:     `application "ghost" not found`,
+	}, {
+		Collection: unitsC,
+		ModelUUID:  uuid,
+		Id:         "ghost/0",
+		Reason:     `application "ghost" not found`,
+	}})
+}
+
+func (s *orphansSuite) TestFindOrphanedDocumentsAcrossModels(c *gc.C) {
+	st := s.newState(c)
+	s.insert(c, unitsC, bson.M{
+		"_id":         st.ModelUUID() + ":ghost/0",
+		"name":        "ghost/0",
+		"model-uuid":  st.ModelUUID(),
+		"application": "ghost",
+	})
+
+	orphans, err := s.state.FindOrphanedDocuments()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(orphans, gc.HasLen, 1)
+	c.Check(orphans[0].ModelUUID, gc.Equals, st.ModelUUID())
+}
+
+func (s *orphansSuite) TestRemoveOrphanedDocuments(c *gc.C) {
+	uuid := s.addOrphans(c)
+
+	orphans, err := s.state.FindOrphanedDocuments()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.state.RemoveOrphanedDocuments(orphans)
+	c.Assert(err, jc.ErrorIsNil)
+
+	orphans, err = s.state.FindOrphanedDocuments()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(orphans, gc.HasLen, 0)
+
+	c.Check(s.count(c, unitsC, bson.D{{"model-uuid", uuid}}), gc.Equals, 1)
+	c.Check(s.count(c, linkLayerDevicesC, bson.D{{"model-uuid", uuid}}), gc.Equals, 1)
+	c.Check(s.count(c, ipAddressesC, bson.D{{"model-uuid", uuid}}), gc.Equals, 0)
+	c.Check(s.count(c, relationsC, bson.D{{"model-uuid", uuid}}), gc.Equals, 0)
+}
+
+func (s *orphansSuite) TestRemoveOrphanedDocumentsNoLongerOrphaned(c *gc.C) {
+	uuid := s.addOrphans(c)
+
+	orphans, err := s.state.FindOrphanedDocuments()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The application reappears before the orphans are removed.
+	AddTestingApplication(c, s.state, "ghost", AddTestingCharm(c, s.state, "mysql"))
+	err = s.state.RemoveOrphanedDocuments(orphans)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.count(c, unitsC, bson.D{{"_id", uuid + ":ghost/0"}}), gc.Equals, 1)
+	c.Check(s.count(c, relationsC, bson.D{{"model-uuid", uuid}}), gc.Equals, 1)
+	c.Check(s.count(c, linkLayerDevicesC, bson.D{{"model-uuid", uuid}}), gc.Equals, 1)
+}
@@ -83,11 +83,8 @@ func (sp statePersistence) Run(transactions jujutxn.TransactionSource) error {
 
 // NewStorage returns a new blob storage for the model.
 func (sp *statePersistence) NewStorage() storage.Storage {
-	modelUUID := sp.st.ModelUUID()
 	// TODO(ericsnow) Copy the session?
-	session := sp.st.session
-	store := storage.NewStorage(modelUUID, session)
-	return store
+	return sp.st.BlobStorage()
 }
 
 // ApplicationExistsOps returns the operations that verify that the
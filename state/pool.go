@@ -187,12 +187,13 @@ func OpenStatePool(args OpenParams) (_ *StatePool, err error) {
 	if err = pool.watcherRunner.StartWorker(txnLogWorker, func() (worker.Worker, error) {
 		return watcher.NewTxnWatcher(
 			watcher.TxnWatcherConfig{
-				Session:        pool.txnWatcherSession,
-				JujuDBName:     jujuDB,
-				CollectionName: txnLogC,
-				Hub:            pool.hub,
-				Clock:          args.Clock,
-				Logger:         loggo.GetLogger("juju.state.pool.txnwatcher"),
+				Session:         pool.txnWatcherSession,
+				JujuDBName:      jujuDB,
+				CollectionName:  txnLogC,
+				Hub:             pool.hub,
+				Clock:           args.Clock,
+				Logger:          loggo.GetLogger("juju.state.pool.txnwatcher"),
+				UseChangeStream: true,
 			})
 	}); err != nil {
 		pool.txnWatcherSession.Close()
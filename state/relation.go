@@ -43,12 +43,18 @@ type relationDoc struct {
 	DocID           string     `bson:"_id"`
 	Key             string     `bson:"key"`
 	ModelUUID       string     `bson:"model-uuid"`
+	UUID            string     `bson:"uuid,omitempty"`
 	Id              int        `bson:"id"`
 	Endpoints       []Endpoint `bson:"endpoints"`
 	Life            Life       `bson:"life"`
 	UnitCount       int        `bson:"unitcount"`
 	Suspended       bool       `bson:"suspended"`
 	SuspendedReason string     `bson:"suspended-reason"`
+
+	// KeyGeneration is non-zero for cross-model relations whose settings
+	// are encrypted by the related units. It is incremented whenever
+	// the units are to replace their relation keys.
+	KeyGeneration int `bson:"key-generation,omitempty"`
 }
 
 // Relation represents a relation between one or two application endpoints.
@@ -88,6 +94,69 @@ func (r *Relation) SuspendedReason() string {
 	return r.doc.SuspendedReason
 }
 
+// KeyGeneration returns the generation of the keys with which the
+// related units encrypt the relation settings, or zero if the settings
+// are not encrypted.
+func (r *Relation) KeyGeneration() int {
+	return r.doc.KeyGeneration
+}
+
+// RotateKeys increments the key generation of a relation whose settings
+// are encrypted, so that the related units replace their relation keys,
+// and returns the new generation.
+func (r *Relation) RotateKeys() (int, error) {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := r.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if r.doc.KeyGeneration == 0 {
+			return nil, errors.NotSupportedf("rotating keys of unencrypted relation %q", r)
+		}
+		return []txn.Op{{
+			C:      relationsC,
+			Id:     r.doc.DocID,
+			Assert: bson.D{{"key-generation", r.doc.KeyGeneration}},
+			Update: bson.D{{"$set", bson.D{{"key-generation", r.doc.KeyGeneration + 1}}}},
+		}}, nil
+	}
+	if err := r.st.db().Run(buildTxn); err != nil {
+		return 0, errors.Annotatef(err, "cannot rotate keys of relation %q", r)
+	}
+	r.doc.KeyGeneration++
+	return r.doc.KeyGeneration, nil
+}
+
+// SetKeyGeneration records the key generation of the relation as
+// rotated by the consuming model. Generations only advance; older
+// generations are ignored.
+func (r *Relation) SetKeyGeneration(generation int) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := r.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if generation <= r.doc.KeyGeneration {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      relationsC,
+			Id:     r.doc.DocID,
+			Assert: bson.D{{"key-generation", bson.D{{"$lt", generation}}}},
+			Update: bson.D{{"$set", bson.D{{"key-generation", generation}}}},
+		}}, nil
+	}
+	if err := r.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot set key generation of relation %q", r)
+	}
+	if generation > r.doc.KeyGeneration {
+		r.doc.KeyGeneration = generation
+	}
+	return nil
+}
+
 // Refresh refreshes the contents of the relation from the underlying
 // state. It returns an error that satisfies errors.IsNotFound if the
 // relation has been removed.
@@ -623,6 +692,11 @@ func (r *Relation) Id() int {
 	return r.doc.Id
 }
 
+// UUID returns the relation's UUID, which never changes.
+func (r *Relation) UUID() string {
+	return r.doc.UUID
+}
+
 // Endpoint returns the endpoint of the relation for the named application.
 // If the application is not part of the relation, an error will be returned.
 func (r *Relation) Endpoint(applicationname string) (Endpoint, error) {
@@ -678,8 +752,9 @@ func (r *Relation) RemoteUnit(unitName string) (*RelationUnit, error) {
 	if _, err := r.st.RemoteApplication(appName); err != nil {
 		return nil, errors.Trace(err)
 	}
-	// Only non-subordinate applications may be offered for remote
-	// relation, so all remote units are principals.
+	// Cross model relations are always global scoped, so remote
+	// units are treated as principals here, even when they belong
+	// to an offered subordinate application.
 	const principal = ""
 	const isPrincipal = true
 	const isLocalUnit = false
@@ -834,6 +909,30 @@ func (r *Relation) ApplicationSettings(appName string) (map[string]interface{},
 	return s.Map(), nil
 }
 
+// AllUnitSettings returns the settings of every unit in scope in the
+// relation, on either side, keyed by unit name.
+func (r *Relation) AllUnitSettings() (map[string]map[string]interface{}, error) {
+	relationScopes, closer := r.st.db().GetCollection(relationScopesC)
+	defer closer()
+
+	// Container scoped relations have a scope for each principal unit,
+	// so match on the relation's global scope prefix.
+	sel := bson.D{{"key", bson.D{{"$regex", "^" + r.globalScope() + "#"}}}}
+	var docs []relationScopeDoc
+	if err := relationScopes.Find(sel).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]map[string]interface{})
+	for _, doc := range docs {
+		s, err := readSettings(r.st.db(), settingsC, doc.Key)
+		if err != nil {
+			return nil, errors.Annotatef(err, "relation %q unit %q", r.String(), doc.unitName())
+		}
+		result[doc.unitName()] = s.Map()
+	}
+	return result, nil
+}
+
 // UpdateApplicationSettings updates the given application's settings
 // in this relation. It requires a current leadership token.
 func (r *Relation) UpdateApplicationSettings(appName string, token leadership.Token, updates map[string]interface{}) error {
@@ -780,6 +780,71 @@ func (s *RelationSuite) TestInvalidStatus(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot set invalid status "invalid"`)
 }
 
+func (s *RelationSuite) addCrossModelRelation(c *gc.C, encrypt bool) *state.Relation {
+	rwordpress, err := s.State.AddRemoteApplication(state.AddRemoteApplicationParams{
+		Name:            "remote-wordpress",
+		SourceModel:     names.NewModelTag("source-model"),
+		IsConsumerProxy: true,
+		OfferUUID:       "offer-uuid",
+		Endpoints: []charm.Relation{{
+			Interface: "mysql",
+			Limit:     1,
+			Name:      "db",
+			Role:      charm.RoleRequirer,
+			Scope:     charm.ScopeGlobal,
+		}},
+		EncryptRelationData: encrypt,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wordpressEP, err := rwordpress.Endpoint("db")
+	c.Assert(err, jc.ErrorIsNil)
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	mysqlEP, err := mysql.Endpoint("server")
+	c.Assert(err, jc.ErrorIsNil)
+	relation, err := s.State.AddRelation(wordpressEP, mysqlEP)
+	c.Assert(err, jc.ErrorIsNil)
+	return relation
+}
+
+func (s *RelationSuite) TestKeyGeneration(c *gc.C) {
+	relation := s.addCrossModelRelation(c, true)
+	c.Assert(relation.KeyGeneration(), gc.Equals, 1)
+}
+
+func (s *RelationSuite) TestRotateKeys(c *gc.C) {
+	relation := s.addCrossModelRelation(c, true)
+	generation, err := relation.RotateKeys()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(generation, gc.Equals, 2)
+	c.Assert(relation.KeyGeneration(), gc.Equals, 2)
+
+	relation, err = s.State.Relation(relation.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(relation.KeyGeneration(), gc.Equals, 2)
+}
+
+func (s *RelationSuite) TestRotateKeysUnencrypted(c *gc.C) {
+	relation := s.addCrossModelRelation(c, false)
+	c.Assert(relation.KeyGeneration(), gc.Equals, 0)
+	_, err := relation.RotateKeys()
+	c.Assert(err, gc.ErrorMatches, `cannot rotate keys of relation ".*": rotating keys of unencrypted relation ".*" not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *RelationSuite) TestSetKeyGeneration(c *gc.C) {
+	relation := s.addCrossModelRelation(c, false)
+	err := relation.SetKeyGeneration(3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(relation.KeyGeneration(), gc.Equals, 3)
+
+	// Older generations are ignored.
+	err = relation.SetKeyGeneration(2)
+	c.Assert(err, jc.ErrorIsNil)
+	relation, err = s.State.Relation(relation.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(relation.KeyGeneration(), gc.Equals, 3)
+}
+
 func (s *RelationSuite) TestSetSuspend(c *gc.C) {
 	rel := s.setupRelationStatus(c)
 	// Suspend doesn't need an offer connection to be there.
@@ -898,6 +963,62 @@ func (s *RelationSuite) TestApplicationSettingsErrors(c *gc.C) {
 	c.Assert(settings, gc.HasLen, 0)
 }
 
+func (s *RelationSuite) TestAllUnitSettings(c *gc.C) {
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	eps, err := s.State.InferEndpoints("mysql", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	relation, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings, err := relation.AllUnitSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.HasLen, 0)
+
+	enterScope := func(app *state.Application, settings map[string]interface{}) {
+		unit, err := app.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		ru, err := relation.Unit(unit)
+		c.Assert(err, jc.ErrorIsNil)
+		err = ru.EnterScope(settings)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	enterScope(mysql, map[string]interface{}{"host": "10.0.0.1"})
+	enterScope(wordpress, map[string]interface{}{"host": "10.0.0.2"})
+	// Units not in scope aren't included.
+	_, err = wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings, err = relation.AllUnitSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, map[string]map[string]interface{}{
+		"mysql/0":     {"host": "10.0.0.1"},
+		"wordpress/0": {"host": "10.0.0.2"},
+	})
+}
+
+func (s *RelationSuite) TestAllUnitSettingsContainerScope(c *gc.C) {
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	s.AddTestingApplication(c, "logging", s.AddTestingCharm(c, "logging"))
+	eps, err := s.State.InferEndpoints("mysql", "logging")
+	c.Assert(err, jc.ErrorIsNil)
+	relation, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	unit, err := mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := relation.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(map[string]interface{}{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings, err := relation.AllUnitSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, map[string]map[string]interface{}{
+		"mysql/0": {"foo": "bar"},
+	})
+}
+
 func (s *RelationSuite) TestUpdateApplicationSettingsSuccess(c *gc.C) {
 	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
 	s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
@@ -0,0 +1,40 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+// relationEncryptionAnnotation is the model annotation that carries the
+// relation data encryption settings of cross-model relations across model
+// migration, as the model description has no fields for them. The keys
+// themselves are held by the related units and are not migrated. The
+// migration master refuses to migrate models to controllers too old to
+// read it.
+const relationEncryptionAnnotation = "juju.relation-encryption"
+
+// relationEncryption holds the relation data encryption settings carried
+// in the relationEncryptionAnnotation.
+type relationEncryption struct {
+	// RemoteApplications holds the names of the remote applications
+	// whose relations have their settings encrypted.
+	RemoteApplications []string `json:"remote-applications,omitempty"`
+
+	// KeyGenerations maps the keys of relations whose settings are
+	// encrypted to their key generations.
+	KeyGenerations map[string]int `json:"key-generations,omitempty"`
+}
+
+// isEmpty returns true if no relations have their settings encrypted.
+func (r relationEncryption) isEmpty() bool {
+	return len(r.RemoteApplications) == 0 && len(r.KeyGenerations) == 0
+}
+
+// encryptsRelationData returns true if the named remote application's
+// relations have their settings encrypted.
+func (r relationEncryption) encryptsRelationData(remoteApplication string) bool {
+	for _, name := range r.RemoteApplications {
+		if name == remoteApplication {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/gojsonschema"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// RelationSchema holds the JSON schemas an application requires the
+// relation data it receives over one of its endpoints to satisfy.
+// An empty schema places no requirements on the corresponding data bag.
+type RelationSchema struct {
+	// Unit is the schema for the unit settings of the remote units.
+	Unit string `bson:"unit,omitempty"`
+
+	// Application is the schema for the application settings of the
+	// remote application.
+	Application string `bson:"application,omitempty"`
+}
+
+// IsEmpty returns true if the schema places no requirements on either
+// relation data bag.
+func (s RelationSchema) IsEmpty() bool {
+	return s.Unit == "" && s.Application == ""
+}
+
+// ValidateUnitSettings returns an error if the supplied unit settings do
+// not satisfy the unit schema.
+func (s RelationSchema) ValidateUnitSettings(settings map[string]interface{}) error {
+	return errors.Annotate(validateRelationSettings(s.Unit, settings), "unit settings")
+}
+
+// ValidateApplicationSettings returns an error if the supplied application
+// settings do not satisfy the application schema.
+func (s RelationSchema) ValidateApplicationSettings(settings map[string]interface{}) error {
+	return errors.Annotate(validateRelationSettings(s.Application, settings), "application settings")
+}
+
+func validateRelationSettings(schema string, settings map[string]interface{}) error {
+	if schema == "" {
+		return nil
+	}
+	if settings == nil {
+		settings = map[string]interface{}{}
+	}
+	result, err := gojsonschema.Validate(
+		gojsonschema.NewStringLoader(schema),
+		gojsonschema.NewGoLoader(settings),
+	)
+	if err != nil {
+		return errors.Annotate(err, "invalid schema")
+	}
+	if result.Valid() {
+		return nil
+	}
+	problems := make([]string, len(result.Errors()))
+	for i, resultErr := range result.Errors() {
+		problems[i] = resultErr.String()
+	}
+	return errors.NewNotValid(nil, strings.Join(problems, "; "))
+}
+
+func checkRelationSchema(schema string) error {
+	if schema == "" {
+		return nil
+	}
+	_, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(schema))
+	return errors.Trace(err)
+}
+
+// RelationSchema returns the schemas registered for the named endpoint
+// of the application.
+func (a *Application) RelationSchema(endpoint string) RelationSchema {
+	return a.doc.RelationSchemas[endpoint]
+}
+
+// RelationSchemaEndpoints returns the sorted names of the endpoints for
+// which the application has registered relation schemas.
+func (a *Application) RelationSchemaEndpoints() []string {
+	var endpoints []string
+	for endpoint := range a.doc.RelationSchemas {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+	return endpoints
+}
+
+// SetRelationSchema registers the schemas that relation data sent to the
+// application over the named endpoint must satisfy. An empty schema
+// removes any previously registered schemas for the endpoint.
+func (a *Application) SetRelationSchema(endpoint string, schema RelationSchema) error {
+	if _, err := a.Endpoint(endpoint); err != nil {
+		return errors.Annotate(err, "cannot set relation schema")
+	}
+	if err := checkRelationSchema(schema.Unit); err != nil {
+		return errors.Annotate(err, "cannot set relation schema: invalid unit schema")
+	}
+	if err := checkRelationSchema(schema.Application); err != nil {
+		return errors.Annotate(err, "cannot set relation schema: invalid application schema")
+	}
+	field := "relation-schemas." + endpoint
+	update := bson.D{{"$set", bson.D{{field, schema}}}}
+	if schema.IsEmpty() {
+		update = bson.D{{"$unset", bson.D{{field, nil}}}}
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			alive, err := isAlive(a.st, applicationsC, a.doc.DocID)
+			if err != nil {
+				return nil, errors.Trace(err)
+			} else if !alive {
+				return nil, applicationNotAliveErr
+			}
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: isAliveDoc,
+			Update: update,
+		}}, nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return errors.Annotate(err, "cannot set relation schema")
+	}
+	if schema.IsEmpty() {
+		delete(a.doc.RelationSchemas, endpoint)
+		return nil
+	}
+	if a.doc.RelationSchemas == nil {
+		a.doc.RelationSchemas = make(map[string]RelationSchema)
+	}
+	a.doc.RelationSchemas[endpoint] = schema
+	return nil
+}
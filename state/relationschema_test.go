@@ -0,0 +1,101 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+const serverSchema = `{
+	"type": "object",
+	"properties": {
+		"port": {"type": "string", "pattern": "^[0-9]+$"}
+	},
+	"required": ["port"]
+}`
+
+type RelationSchemaSuite struct {
+	ConnSuite
+	mysql *state.Application
+}
+
+var _ = gc.Suite(&RelationSchemaSuite{})
+
+func (s *RelationSchemaSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.mysql = s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+}
+
+func (s *RelationSchemaSuite) TestSetRelationSchema(c *gc.C) {
+	schema := state.RelationSchema{Unit: serverSchema}
+	err := s.mysql.SetRelationSchema("server", schema)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.RelationSchema("server"), jc.DeepEquals, schema)
+
+	app, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.RelationSchema("server"), jc.DeepEquals, schema)
+}
+
+func (s *RelationSchemaSuite) TestRelationSchemaEndpoints(c *gc.C) {
+	c.Assert(s.mysql.RelationSchemaEndpoints(), gc.HasLen, 0)
+	err := s.mysql.SetRelationSchema("server", state.RelationSchema{Unit: serverSchema})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.RelationSchemaEndpoints(), jc.DeepEquals, []string{"server"})
+}
+
+func (s *RelationSchemaSuite) TestSetRelationSchemaEmptyRemoves(c *gc.C) {
+	err := s.mysql.SetRelationSchema("server", state.RelationSchema{Application: serverSchema})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetRelationSchema("server", state.RelationSchema{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.RelationSchema("server").IsEmpty(), jc.IsTrue)
+
+	app, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.RelationSchema("server").IsEmpty(), jc.IsTrue)
+}
+
+func (s *RelationSchemaSuite) TestSetRelationSchemaUnknownEndpoint(c *gc.C) {
+	err := s.mysql.SetRelationSchema("db", state.RelationSchema{Unit: serverSchema})
+	c.Assert(err, gc.ErrorMatches, `cannot set relation schema: application "mysql" has no "db" relation`)
+}
+
+func (s *RelationSchemaSuite) TestSetRelationSchemaInvalid(c *gc.C) {
+	err := s.mysql.SetRelationSchema("server", state.RelationSchema{Unit: "{not json"})
+	c.Assert(err, gc.ErrorMatches, "cannot set relation schema: invalid unit schema: .*")
+}
+
+func (s *RelationSchemaSuite) TestSetRelationSchemaDying(c *gc.C) {
+	_, err := s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetRelationSchema("server", state.RelationSchema{Unit: serverSchema})
+	c.Assert(err, gc.ErrorMatches, "cannot set relation schema: application is not found or not alive")
+}
+
+type RelationSchemaValidationSuite struct{}
+
+var _ = gc.Suite(&RelationSchemaValidationSuite{})
+
+func (s *RelationSchemaValidationSuite) TestValidateSettings(c *gc.C) {
+	schema := state.RelationSchema{Unit: serverSchema}
+	err := schema.ValidateUnitSettings(map[string]interface{}{"port": "3306"})
+	c.Assert(err, jc.ErrorIsNil)
+	// No application schema means anything goes.
+	err = schema.ValidateApplicationSettings(map[string]interface{}{"port": "x"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = schema.ValidateUnitSettings(map[string]interface{}{"port": "x"})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `unit settings: .*port.*`)
+
+	err = schema.ValidateUnitSettings(nil)
+	c.Assert(err, gc.ErrorMatches, `unit settings: .*port.*required.*`)
+}
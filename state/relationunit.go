@@ -63,7 +63,9 @@ func (ru *RelationUnit) UnitName() string {
 // If the unit is a principal and the relation has container scope, EnterScope
 // will also create the required subordinate unit, if it does not already exist;
 // this is because there's no point having a principal in scope if there is no
-// corresponding subordinate to join it.
+// corresponding subordinate to join it. Likewise, if the unit is a subordinate
+// and the other end of the container-scoped relation is also a subordinate,
+// EnterScope will place a unit of the other subordinate on the same principal.
 //
 // Once a unit has entered a scope, it stays in scope without further
 // intervention; the relation will not be able to become Dead until all units
@@ -233,7 +235,7 @@ func (ru *RelationUnit) subordinateOps() ([]txn.Op, string, error) {
 	units, closer := ru.st.db().GetCollection(unitsC)
 	defer closer()
 
-	if !ru.isPrincipal || ru.endpoint.Scope != charm.ScopeContainer {
+	if ru.endpoint.Scope != charm.ScopeContainer {
 		return nil, "", nil
 	}
 	related, err := ru.relation.RelatedEndpoints(ru.endpoint.ApplicationName)
@@ -243,10 +245,19 @@ func (ru *RelationUnit) subordinateOps() ([]txn.Op, string, error) {
 	if len(related) != 1 {
 		return nil, "", errors.Errorf("expected single related endpoint, got %v", related)
 	}
+	principalName := ru.unitName
+	if !ru.isPrincipal {
+		// A subordinate unit is only responsible for placing a unit of
+		// a related subordinate alongside it, on its own principal.
+		var ok bool
+		if principalName, ok, err = ru.subordinatePrincipal(related[0].ApplicationName); err != nil || !ok {
+			return nil, "", err
+		}
+	}
 	// Find the machine ID that the principal unit is deployed on, and use
 	// that for the subordinate. It is worthwhile noting that if the unit is
 	// in a CAAS model, there are no machines.
-	principal, err := ru.st.Unit(ru.unitName)
+	principal, err := ru.st.Unit(principalName)
 	if err != nil {
 		return nil, "", errors.Annotate(err, "unable to load principal unit")
 	}
@@ -261,7 +272,7 @@ func (ru *RelationUnit) subordinateOps() ([]txn.Op, string, error) {
 		principalMachineID, _ = principal.AssignedMachineId()
 	}
 
-	applicationname, unitName := related[0].ApplicationName, ru.unitName
+	applicationname, unitName := related[0].ApplicationName, principalName
 	selSubordinate := bson.D{{"application", applicationname}, {"principal", unitName}}
 	var lDoc lifeDoc
 	if err := units.Find(selSubordinate).One(&lDoc); err == mgo.ErrNotFound {
@@ -285,6 +296,29 @@ func (ru *RelationUnit) subordinateOps() ([]txn.Op, string, error) {
 	}}, lDoc.Id, nil
 }
 
+// subordinatePrincipal returns the name of the principal unit of the
+// relation unit's subordinate unit, and whether the other application
+// in the relation is also a subordinate, and so should have a unit
+// placed on that principal.
+func (ru *RelationUnit) subordinatePrincipal(otherApplication string) (string, bool, error) {
+	if !ru.isLocalUnit {
+		return "", false, nil
+	}
+	app, err := ru.st.Application(otherApplication)
+	if err != nil {
+		return "", false, errors.Trace(err)
+	}
+	if app.IsPrincipal() {
+		return "", false, nil
+	}
+	unit, err := ru.st.Unit(ru.unitName)
+	if err != nil {
+		return "", false, errors.Annotate(err, "unable to load subordinate unit")
+	}
+	principalName, ok := unit.PrincipalName()
+	return principalName, ok, nil
+}
+
 // PrepareLeaveScope causes the unit to be reported as departed by watchers,
 // but does not *actually* leave the scope, to avoid triggering relation
 // cleanup.
@@ -954,6 +954,44 @@ func (s *RelationUnitSuite) TestValidSubordinateToSubordinate(c *gc.C) {
 	c.Assert(res, jc.IsTrue)
 }
 
+func (s *RelationUnitSuite) TestSubordinateEnterScopePlacesRelatedSubordinate(c *gc.C) {
+	// Relate mysql and logging, creating logging units on the mysql units.
+	mysqlLogging := newProReqRelation(c, &s.ConnSuite, charm.ScopeContainer)
+	monApp := s.AddTestingApplication(c, "monitoring", s.AddTestingCharm(c, "monitoring"))
+	loggingApp := mysqlLogging.rapp
+
+	// Relate logging and monitoring only; there's no relation between
+	// mysql and monitoring to place the monitoring units.
+	ep1, err := loggingApp.Endpoint("juju-info")
+	c.Assert(err, jc.ErrorIsNil)
+	ep2, err := monApp.Endpoint("info")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(ep1, ep2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ru, err := rel.Unit(mysqlLogging.ru0)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A monitoring unit is placed on the logging unit's principal.
+	units, err := monApp.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+	principal, ok := units[0].PrincipalName()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(principal, gc.Equals, mysqlLogging.pu0.Name())
+
+	// Entering scope from the placed unit doesn't place another.
+	monRU, err := rel.Unit(units[0])
+	c.Assert(err, jc.ErrorIsNil)
+	err = monRU.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	units, err = monApp.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+}
+
 type PeerRelation struct {
 	rel                *state.Relation
 	app                *state.Application
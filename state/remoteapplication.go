@@ -33,18 +33,19 @@ type RemoteApplication struct {
 
 // remoteApplicationDoc represents the internal state of a remote application in MongoDB.
 type remoteApplicationDoc struct {
-	DocID           string              `bson:"_id"`
-	Name            string              `bson:"name"`
-	OfferUUID       string              `bson:"offer-uuid"`
-	URL             string              `bson:"url,omitempty"`
-	SourceModelUUID string              `bson:"source-model-uuid"`
-	Endpoints       []remoteEndpointDoc `bson:"endpoints"`
-	Spaces          []remoteSpaceDoc    `bson:"spaces"`
-	Bindings        map[string]string   `bson:"bindings"`
-	Life            Life                `bson:"life"`
-	RelationCount   int                 `bson:"relationcount"`
-	IsConsumerProxy bool                `bson:"is-consumer-proxy"`
-	Macaroon        string              `bson:"macaroon,omitempty"`
+	DocID               string              `bson:"_id"`
+	Name                string              `bson:"name"`
+	OfferUUID           string              `bson:"offer-uuid"`
+	URL                 string              `bson:"url,omitempty"`
+	SourceModelUUID     string              `bson:"source-model-uuid"`
+	Endpoints           []remoteEndpointDoc `bson:"endpoints"`
+	Spaces              []remoteSpaceDoc    `bson:"spaces"`
+	Bindings            map[string]string   `bson:"bindings"`
+	Life                Life                `bson:"life"`
+	RelationCount       int                 `bson:"relationcount"`
+	IsConsumerProxy     bool                `bson:"is-consumer-proxy"`
+	Macaroon            string              `bson:"macaroon,omitempty"`
+	EncryptRelationData bool                `bson:"encrypt-relation-data,omitempty"`
 }
 
 // remoteEndpointDoc represents the internal state of a remote application endpoint in MongoDB.
@@ -132,6 +133,12 @@ func (s *RemoteApplication) SourceModel() names.ModelTag {
 	return names.NewModelTag(s.doc.SourceModelUUID)
 }
 
+// EncryptRelationData returns true if the settings exchanged over
+// relations with the remote application are to be encrypted.
+func (s *RemoteApplication) EncryptRelationData() bool {
+	return s.doc.EncryptRelationData
+}
+
 // IsConsumerProxy returns the application is created
 // from a registration operation by a consuming model.
 func (s *RemoteApplication) IsConsumerProxy() bool {
@@ -754,6 +761,10 @@ type AddRemoteApplicationParams struct {
 
 	// Macaroon is used for authentication on the offering side.
 	Macaroon *macaroon.Macaroon
+
+	// EncryptRelationData is true when the settings exchanged over
+	// relations with the remote application are to be encrypted.
+	EncryptRelationData bool
 }
 
 // Validate returns an error if there's a problem with the
@@ -812,15 +823,16 @@ func (st *State) AddRemoteApplication(args AddRemoteApplicationParams) (_ *Remot
 	applicationID := st.docID(args.Name)
 	// Create the application addition operations.
 	appDoc := &remoteApplicationDoc{
-		DocID:           applicationID,
-		Name:            args.Name,
-		OfferUUID:       args.OfferUUID,
-		SourceModelUUID: args.SourceModel.Id(),
-		URL:             args.URL,
-		Bindings:        args.Bindings,
-		Life:            Alive,
-		IsConsumerProxy: args.IsConsumerProxy,
-		Macaroon:        macJSON,
+		DocID:               applicationID,
+		Name:                args.Name,
+		OfferUUID:           args.OfferUUID,
+		SourceModelUUID:     args.SourceModel.Id(),
+		URL:                 args.URL,
+		Bindings:            args.Bindings,
+		Life:                Alive,
+		IsConsumerProxy:     args.IsConsumerProxy,
+		Macaroon:            macJSON,
+		EncryptRelationData: args.EncryptRelationData,
 	}
 	eps := make([]remoteEndpointDoc, len(args.Endpoints))
 	for i, ep := range args.Endpoints {
@@ -450,6 +450,16 @@ func (s *remoteApplicationSuite) TestAddRemoteApplicationFromConsumer(c *gc.C) {
 	c.Assert(foo.IsConsumerProxy(), jc.IsTrue)
 }
 
+func (s *remoteApplicationSuite) TestAddRemoteApplicationEncryptRelationData(c *gc.C) {
+	foo, err := s.State.AddRemoteApplication(state.AddRemoteApplicationParams{
+		Name: "foo", OfferUUID: "offer-uuid", SourceModel: s.Model.ModelTag(), EncryptRelationData: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(foo.EncryptRelationData(), jc.IsTrue)
+	foo, err = s.State.RemoteApplication("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(foo.EncryptRelationData(), jc.IsTrue)
+}
+
 func (s *remoteApplicationSuite) TestAddEndpoints(c *gc.C) {
 	origEps := []charm.Relation{
 		{Name: "ep1", Role: charm.RoleRequirer, Scope: charm.ScopeGlobal, Limit: 1},
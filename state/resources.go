@@ -40,6 +40,11 @@ type Resources interface {
 	// SetUnitResource sets the resource metadata for a specific unit.
 	SetUnitResource(unitName, userID string, res charmresource.Resource) (resource.Resource, error)
 
+	// ShareResource shares the application's resource with a related
+	// subordinate application. The subordinate's resource reads its
+	// content from the application's resource.
+	ShareResource(applicationID, name, subordinateID, userID string) (resource.Resource, error)
+
 	// UpdatePendingResource adds the resource to blob storage and updates the metadata.
 	UpdatePendingResource(applicationID, pendingID, userID string, res charmresource.Resource, r io.Reader) (resource.Resource, error)
 
@@ -6,6 +6,8 @@ package state
 import (
 	"fmt"
 
+	"github.com/juju/charm/v9"
+	charmresource "github.com/juju/charm/v9/resource"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 
@@ -71,3 +73,47 @@ func (st rawState) VerifyApplication(id string) error {
 	}
 	return nil
 }
+
+// SubordinateResourceMeta returns the metadata for the named resource
+// of the subordinate application, checking that the subordinate is
+// related to the principal application by a container-scoped relation.
+func (st rawState) SubordinateResourceMeta(principalID, subordinateID, name string) (charmresource.Meta, error) {
+	app, err := st.base.Application(subordinateID)
+	if err != nil {
+		return charmresource.Meta{}, errors.Trace(err)
+	}
+	if app.IsPrincipal() {
+		return charmresource.Meta{}, errors.NotValidf("application %q is not a subordinate", subordinateID)
+	}
+	if err := st.verifyContainerRelation(app, principalID); err != nil {
+		return charmresource.Meta{}, errors.Trace(err)
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return charmresource.Meta{}, errors.Trace(err)
+	}
+	meta, ok := ch.Meta().Resources[name]
+	if !ok {
+		return charmresource.Meta{}, errors.NotFoundf("resource %q in charm for application %q", name, subordinateID)
+	}
+	return meta, nil
+}
+
+func (st rawState) verifyContainerRelation(app *Application, principalID string) error {
+	relations, err := app.Relations()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, rel := range relations {
+		ep, err := rel.Endpoint(principalID)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if ep.Scope == charm.ScopeContainer {
+			return nil
+		}
+	}
+	return errors.NotFoundf("container-scoped relation between %q and %q", principalID, app.Name())
+}
@@ -59,6 +59,10 @@ type storedResource struct {
 
 	// storagePath is the path to where the resource content is stored.
 	storagePath string
+
+	// sharedFrom is the ID of the application whose resource provides
+	// the content for this one, if the resource is shared.
+	sharedFrom string
 }
 
 // charmStoreResource holds the info for a resource as provided by the
@@ -134,6 +138,7 @@ func resourceDocToUpdateOp(doc *resourceDoc) bson.M {
 		"username":                   doc.Username,
 		"timestamp-when-added":       doc.Timestamp,
 		"storage-path":               doc.StoragePath,
+		"shared-from":                doc.SharedFrom,
 		"download-progress":          doc.DownloadProgress,
 		"timestamp-when-last-polled": doc.LastPolled,
 	}}
@@ -348,6 +353,11 @@ type resourceDoc struct {
 
 	StoragePath string `bson:"storage-path"`
 
+	// SharedFrom holds the ID of the application that this resource
+	// is shared from. The resource's content is read from the
+	// application's resource of the same name.
+	SharedFrom string `bson:"shared-from,omitempty"`
+
 	DownloadProgress *int64 `bson:"download-progress,omitempty"`
 
 	LastPolled time.Time `bson:"timestamp-when-last-polled"`
@@ -398,6 +408,7 @@ func resource2doc(id string, stored storedResource) *resourceDoc {
 		Timestamp: res.Timestamp,
 
 		StoragePath: stored.storagePath,
+		SharedFrom:  stored.sharedFrom,
 	}
 }
 
@@ -411,6 +422,7 @@ func doc2resource(doc resourceDoc) (storedResource, error) {
 	stored := storedResource{
 		Resource:    res,
 		storagePath: doc.StoragePath,
+		sharedFrom:  doc.SharedFrom,
 	}
 	return stored, nil
 }
@@ -75,6 +75,11 @@ func (p ResourcePersistence) ListResources(applicationID string) (resource.Appli
 		if doc.PendingID != "" {
 			continue
 		}
+		if doc.UnitID == "" && doc.LastPolled.IsZero() {
+			if doc, err = p.resolveShared(doc); err != nil {
+				return resource.ApplicationResources{}, errors.Trace(err)
+			}
+		}
 
 		res, err := doc2basicResource(doc)
 		if err != nil {
@@ -147,6 +152,9 @@ func (p ResourcePersistence) GetResource(id string) (res resource.Resource, stor
 	if err != nil {
 		return res, "", errors.Trace(err)
 	}
+	if doc, err = p.resolveShared(doc); err != nil {
+		return res, "", errors.Trace(err)
+	}
 
 	stored, err := doc2resource(doc)
 	if err != nil {
@@ -227,6 +235,51 @@ func (p ResourcePersistence) SetResource(res resource.Resource) error {
 	return nil
 }
 
+// ShareResource sets the info for a resource whose content is shared
+// from the resource of the same name of the source application. The
+// shared resource reads its content from the source resource, so any
+// later changes to that resource are seen by the shared one too.
+func (p ResourcePersistence) ShareResource(res resource.Resource, sourceApplicationID string) error {
+	rpLogger.Tracef("share resource %q of %q with %q", res.Name, sourceApplicationID, res.ApplicationID)
+	if err := res.Validate(); err != nil {
+		return errors.Annotate(err, "bad resource")
+	}
+	stored := storedResource{
+		Resource:   res,
+		sharedFrom: sourceApplicationID,
+	}
+	sourceID := applicationResourceID(newResourceID(sourceApplicationID, res.Name))
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		// This is an "upsert".
+		var ops []txn.Op
+		switch attempt {
+		case 0:
+			ops = newInsertResourceOps(stored)
+		case 1:
+			ops = newUpdateResourceOps(stored)
+		default:
+			// Either insert or update will work so we should not get here.
+			return nil, errors.New("sharing the resource failed")
+		}
+		ops = append(ops, txn.Op{
+			C:      resourcesC,
+			Id:     sourceID,
+			Assert: txn.DocExists,
+		})
+		ops = append(ops, p.base.ApplicationExistsOps(res.ApplicationID)...)
+		ops = append(ops, p.base.ApplicationExistsOps(sourceApplicationID)...)
+		// The subordinate's resource now has new content, so its
+		// units must be told about it.
+		ops = append(ops, p.base.IncCharmModifiedVersionOps(res.ApplicationID)...)
+		return ops, nil
+	}
+	if err := p.base.Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 // SetCharmStoreResource stores the resource info that was retrieved
 // from the charm store.
 func (p ResourcePersistence) SetCharmStoreResource(id, applicationID string, res charmresource.Resource, lastPolled time.Time) error {
@@ -320,6 +373,34 @@ func (p ResourcePersistence) setUnitResource(unitID string, res resource.Resourc
 	return nil
 }
 
+// resolveShared returns the doc with the content details of the
+// resource it is shared from, if any. If that resource no longer
+// exists then the doc is returned as a placeholder.
+func (p ResourcePersistence) resolveShared(doc resourceDoc) (resourceDoc, error) {
+	if doc.SharedFrom == "" {
+		return doc, nil
+	}
+	source, err := p.getOne(newResourceID(doc.SharedFrom, doc.Name))
+	if errors.IsNotFound(err) {
+		doc.Fingerprint = nil
+		doc.Size = 0
+		doc.Username = ""
+		doc.Timestamp = time.Time{}
+		doc.StoragePath = ""
+		return doc, nil
+	} else if err != nil {
+		return doc, errors.Trace(err)
+	}
+	doc.Origin = source.Origin
+	doc.Revision = source.Revision
+	doc.Fingerprint = source.Fingerprint
+	doc.Size = source.Size
+	doc.Username = source.Username
+	doc.Timestamp = source.Timestamp
+	doc.StoragePath = source.StoragePath
+	return doc, nil
+}
+
 func (p ResourcePersistence) getStored(res resource.Resource) (storedResource, error) {
 	doc, err := p.getOne(res.ID)
 	if errors.IsNotFound(err) {
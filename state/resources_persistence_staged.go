@@ -7,6 +7,7 @@ import (
 	"bytes"
 
 	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 )
 
@@ -95,6 +96,16 @@ func (staged StagedResource) Activate() error {
 			if hasNewBytes {
 				incOps := staged.base.IncCharmModifiedVersionOps(staged.stored.ApplicationID)
 				ops = append(ops, incOps...)
+
+				// Applications sharing the resource get the new
+				// bytes too.
+				sharers, err := staged.sharedWith()
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				for _, applicationID := range sharers {
+					ops = append(ops, staged.base.IncCharmModifiedVersionOps(applicationID)...)
+				}
 			}
 		}
 		return ops, nil
@@ -120,3 +131,24 @@ func (staged StagedResource) hasNewBytes() (bool, error) {
 		return diff, nil
 	}
 }
+
+// sharedWith returns the IDs of the applications that share
+// the staged resource.
+func (staged StagedResource) sharedWith() ([]string, error) {
+	var docs []resourceDoc
+	query := bson.D{
+		{"shared-from", staged.stored.ApplicationID},
+		{"name", staged.stored.Name},
+	}
+	if err := staged.base.All(resourcesC, query, &docs); err != nil {
+		return nil, errors.Annotate(err, "couldn't read shared resources")
+	}
+	var applicationIDs []string
+	for _, doc := range docs {
+		if doc.UnitID != "" || doc.PendingID != "" {
+			continue
+		}
+		applicationIDs = append(applicationIDs, doc.ApplicationID)
+	}
+	return applicationIDs, nil
+}
@@ -119,14 +119,14 @@ func (s *StagedResourceSuite) TestUnstageOkay(c *gc.C) {
 func (s *StagedResourceSuite) TestActivateOkay(c *gc.C) {
 	staged, doc := s.newStagedResource(c, "a-application", "spam")
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, nil, nil, nil, nil, ignoredErr)
+	s.stub.SetErrors(nil, nil, nil, nil, nil, nil, ignoredErr)
 
 	err := staged.Activate()
 	c.Assert(err, jc.ErrorIsNil)
 
-	s.stub.CheckCallNames(c, "Run", "ApplicationExistsOps", "One", "IncCharmModifiedVersionOps", "RunTransaction")
+	s.stub.CheckCallNames(c, "Run", "ApplicationExistsOps", "One", "IncCharmModifiedVersionOps", "All", "RunTransaction")
 	s.stub.CheckCall(c, 3, "IncCharmModifiedVersionOps", "a-application")
-	s.stub.CheckCall(c, 4, "RunTransaction", []txn.Op{{
+	s.stub.CheckCall(c, 5, "RunTransaction", []txn.Op{{
 		C:      "resources",
 		Id:     "resource#a-application/spam",
 		Assert: txn.DocMissing,
@@ -145,14 +145,14 @@ func (s *StagedResourceSuite) TestActivateOkay(c *gc.C) {
 func (s *StagedResourceSuite) TestActivateExists(c *gc.C) {
 	staged, doc := s.newStagedResource(c, "a-application", "spam")
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, nil, nil, nil, txn.ErrAborted, nil, nil, nil, nil, ignoredErr)
+	s.stub.SetErrors(nil, nil, nil, nil, nil, txn.ErrAborted, nil, nil, nil, nil, nil, ignoredErr)
 
 	err := staged.Activate()
 	c.Assert(err, jc.ErrorIsNil)
 
-	s.stub.CheckCallNames(c, "Run", "ApplicationExistsOps", "One", "IncCharmModifiedVersionOps", "RunTransaction", "ApplicationExistsOps", "One", "IncCharmModifiedVersionOps", "RunTransaction")
+	s.stub.CheckCallNames(c, "Run", "ApplicationExistsOps", "One", "IncCharmModifiedVersionOps", "All", "RunTransaction", "ApplicationExistsOps", "One", "IncCharmModifiedVersionOps", "All", "RunTransaction")
 	s.stub.CheckCall(c, 3, "IncCharmModifiedVersionOps", "a-application")
-	s.stub.CheckCall(c, 4, "RunTransaction", []txn.Op{{
+	s.stub.CheckCall(c, 5, "RunTransaction", []txn.Op{{
 		C:      "resources",
 		Id:     "resource#a-application/spam",
 		Assert: txn.DocMissing,
@@ -166,8 +166,8 @@ func (s *StagedResourceSuite) TestActivateExists(c *gc.C) {
 		Id:     "resource#a-application/spam#staged",
 		Remove: true,
 	}})
-	s.stub.CheckCall(c, 7, "IncCharmModifiedVersionOps", "a-application")
-	s.stub.CheckCall(c, 8, "RunTransaction", []txn.Op{{
+	s.stub.CheckCall(c, 8, "IncCharmModifiedVersionOps", "a-application")
+	s.stub.CheckCall(c, 10, "RunTransaction", []txn.Op{{
 		C:      "resources",
 		Id:     "resource#a-application/spam",
 		Assert: txn.DocExists,
@@ -187,6 +187,7 @@ func (s *StagedResourceSuite) TestActivateExists(c *gc.C) {
 			"username":                   doc.Username,
 			"timestamp-when-added":       doc.Timestamp,
 			"storage-path":               doc.StoragePath,
+			"shared-from":                doc.SharedFrom,
 			"download-progress":          doc.DownloadProgress,
 			"timestamp-when-last-polled": doc.LastPolled,
 		}},
@@ -200,3 +201,29 @@ func (s *StagedResourceSuite) TestActivateExists(c *gc.C) {
 		Remove: true,
 	}})
 }
+
+func (s *StagedResourceSuite) TestActivateSharedResource(c *gc.C) {
+	staged, _ := s.newStagedResource(c, "a-application", "spam")
+	s.base.ReturnAll = []resourceDoc{{
+		ApplicationID: "a-subordinate",
+		Name:          "spam",
+		SharedFrom:    "a-application",
+	}, {
+		ApplicationID: "a-subordinate",
+		UnitID:        "a-subordinate/0",
+		Name:          "spam",
+		SharedFrom:    "a-application",
+	}}
+	ignoredErr := errors.New("<never reached>")
+	s.stub.SetErrors(nil, nil, nil, nil, nil, nil, nil, ignoredErr)
+
+	err := staged.Activate()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Run", "ApplicationExistsOps", "One", "IncCharmModifiedVersionOps", "All", "IncCharmModifiedVersionOps", "RunTransaction")
+	c.Check(s.stub.Calls()[4].Args[1], jc.DeepEquals, bson.D{
+		{"shared-from", "a-application"},
+		{"name", "spam"},
+	})
+	s.stub.CheckCall(c, 5, "IncCharmModifiedVersionOps", "a-subordinate")
+}
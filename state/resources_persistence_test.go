@@ -188,6 +188,25 @@ func (s *ResourcePersistenceSuite) TestGetResourceOkay(c *gc.C) {
 	c.Check(storagePath, gc.Equals, expected.storagePath)
 }
 
+func (s *ResourcePersistenceSuite) TestGetResourceSharedSourceMissing(c *gc.C) {
+	expected, doc := newPersistenceResource(c, "a-subordinate", "spam")
+	doc.StoragePath = ""
+	doc.SharedFrom = "a-application"
+	s.base.ReturnOne = doc
+	p := NewResourcePersistence(s.base)
+	s.stub.SetErrors(nil, errors.NotFoundf("resource"))
+
+	res, storagePath, err := p.GetResource("a-subordinate/spam")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "One", "One")
+	s.stub.CheckCall(c, 1, "One", "resources", "resource#a-application/spam", &resourceDoc{})
+	c.Check(res.IsPlaceholder(), jc.IsTrue)
+	c.Check(res.Name, gc.Equals, expected.Name)
+	c.Check(res.ApplicationID, gc.Equals, "a-subordinate")
+	c.Check(storagePath, gc.Equals, "")
+}
+
 func (s *ResourcePersistenceSuite) TestStageResourceOkay(c *gc.C) {
 	res, doc := newPersistenceResource(c, "a-application", "spam")
 	doc.DocID += "#staged"
@@ -300,6 +319,49 @@ func (s *ResourcePersistenceSuite) TestSetResourceNotFound(c *gc.C) {
 	}})
 }
 
+func (s *ResourcePersistenceSuite) TestShareResource(c *gc.C) {
+	res, doc := newPersistenceResource(c, "a-subordinate", "spam")
+	doc.StoragePath = ""
+	doc.SharedFrom = "a-application"
+	s.base.ReturnApplicationExistsOps = nil
+	s.base.ReturnIncCharmModifiedVersionOps = []txn.Op{{
+		C:      "application",
+		Id:     "a-subordinate",
+		Update: bson.M{"$inc": bson.M{"charmmodifiedversion": 1}},
+	}}
+	p := NewResourcePersistence(s.base)
+	ignoredErr := errors.New("<never reached>")
+	s.stub.SetErrors(nil, nil, nil, nil, nil, ignoredErr)
+
+	err := p.ShareResource(res.Resource, "a-application")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c,
+		"Run",
+		"ApplicationExistsOps",
+		"ApplicationExistsOps",
+		"IncCharmModifiedVersionOps",
+		"RunTransaction",
+	)
+	s.stub.CheckCall(c, 1, "ApplicationExistsOps", "a-subordinate")
+	s.stub.CheckCall(c, 2, "ApplicationExistsOps", "a-application")
+	s.stub.CheckCall(c, 3, "IncCharmModifiedVersionOps", "a-subordinate")
+	s.stub.CheckCall(c, 4, "RunTransaction", []txn.Op{{
+		C:      "resources",
+		Id:     "resource#a-subordinate/spam",
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-application/spam",
+		Assert: txn.DocExists,
+	}, {
+		C:      "application",
+		Id:     "a-subordinate",
+		Update: bson.M{"$inc": bson.M{"charmmodifiedversion": 1}},
+	}})
+}
+
 func (s *ResourcePersistenceSuite) TestSetCharmStoreResourceOkay(c *gc.C) {
 	lastPolled := coretesting.NonZeroTime().UTC()
 	applicationname := "a-application"
@@ -415,6 +477,7 @@ func (s *ResourcePersistenceSuite) TestSetUnitResourceExists(c *gc.C) {
 			"username":                   doc.Username,
 			"timestamp-when-added":       doc.Timestamp,
 			"storage-path":               doc.StoragePath,
+			"shared-from":                doc.SharedFrom,
 			"download-progress":          doc.DownloadProgress,
 			"timestamp-when-last-polled": doc.LastPolled,
 		}},
@@ -526,6 +589,7 @@ func (s *ResourcePersistenceSuite) TestNewResourcePendingResourceOpsExists(c *gc
 			"username":                   expected.Username,
 			"timestamp-when-added":       expected.Timestamp,
 			"storage-path":               expected.StoragePath,
+			"shared-from":                expected.SharedFrom,
 			"download-progress":          expected.DownloadProgress,
 			"timestamp-when-last-polled": expected.LastPolled,
 		}},
@@ -549,6 +613,7 @@ func (s *ResourcePersistenceSuite) TestNewResourcePendingResourceOpsExists(c *gc
 			"username":                   csresourceDoc.Username,
 			"timestamp-when-added":       csresourceDoc.Timestamp,
 			"storage-path":               csresourceDoc.StoragePath,
+			"shared-from":                csresourceDoc.SharedFrom,
 			"download-progress":          csresourceDoc.DownloadProgress,
 			"timestamp-when-last-polled": csresourceDoc.LastPolled,
 		}},
@@ -45,6 +45,10 @@ type resourcePersistence interface {
 	// SetResource stores the info for the resource.
 	SetResource(args resource.Resource) error
 
+	// ShareResource stores the info for a resource whose content is
+	// shared from the resource of the same name of the source application.
+	ShareResource(res resource.Resource, sourceApplicationID string) error
+
 	// SetCharmStoreResource stores the resource info that was retrieved
 	// from the charm store.
 	SetCharmStoreResource(id, applicationID string, res charmresource.Resource, lastPolled time.Time) error
@@ -209,6 +213,47 @@ func (st resourceState) SetUnitResource(unitName, userID string, chRes charmreso
 	return res, nil
 }
 
+// ShareResource shares the application's resource with a subordinate
+// application related to it, so that the subordinate's units can
+// download the resource without it being uploaded again.
+func (st resourceState) ShareResource(applicationID, name, subordinateID, userID string) (resource.Resource, error) {
+	rLogger.Tracef("sharing resource %q of application %q with %q", name, applicationID, subordinateID)
+	var empty resource.Resource
+
+	source, err := st.GetResource(applicationID, name)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	if source.IsPlaceholder() {
+		return empty, errors.NotFoundf("resource %q", name)
+	}
+	if source.Type != charmresource.TypeFile {
+		return empty, errors.NotSupportedf("sharing %s resources", source.Type)
+	}
+	meta, err := st.raw.SubordinateResourceMeta(applicationID, subordinateID, name)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	if meta.Type != source.Type {
+		return empty, errors.NotValidf("resource %q of application %q with type %s", name, subordinateID, meta.Type)
+	}
+
+	res := resource.Resource{
+		Resource: charmresource.Resource{
+			Meta:   meta,
+			Origin: charmresource.OriginUpload,
+		},
+		ID:            newResourceID(subordinateID, name),
+		ApplicationID: subordinateID,
+		Username:      userID,
+		Timestamp:     st.clock.Now().UTC(),
+	}
+	if err := st.persist.ShareResource(res, applicationID); err != nil {
+		return empty, errors.Trace(err)
+	}
+	return st.GetResource(subordinateID, name)
+}
+
 // AddPendingResource stores the resource in the Juju model.
 func (st resourceState) AddPendingResource(applicationID, userID string, chRes charmresource.Resource) (pendingID string, err error) {
 	pendingID, err = newPendingID()
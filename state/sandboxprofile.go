@@ -0,0 +1,294 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/charm/v9"
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/tomb.v2"
+
+	corecharm "github.com/juju/juju/core/charm"
+	"github.com/juju/juju/state/watcher"
+)
+
+// charmSandboxProfileDoc holds a charm's sandbox profile. Sysctl names
+// contain dots, which mongo doesn't allow in keys, so the maps are
+// stored as lists.
+type charmSandboxProfileDoc struct {
+	Sysctls          []sandboxEntryDoc `bson:"sysctls,omitempty"`
+	AppArmorProfiles []sandboxEntryDoc `bson:"apparmor-profiles,omitempty"`
+}
+
+type sandboxEntryDoc struct {
+	Name  string `bson:"name"`
+	Value string `bson:"value"`
+}
+
+func toSandboxEntryDocs(m map[string]string) []sandboxEntryDoc {
+	if len(m) == 0 {
+		return nil
+	}
+	docs := make([]sandboxEntryDoc, 0, len(m))
+	for name, value := range m {
+		docs = append(docs, sandboxEntryDoc{Name: name, Value: value})
+	}
+	sort.Slice(docs, func(i, j int) bool { return docs[i].Name < docs[j].Name })
+	return docs
+}
+
+func fromSandboxEntryDocs(docs []sandboxEntryDoc) map[string]string {
+	if len(docs) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(docs))
+	for _, doc := range docs {
+		m[doc.Name] = doc.Value
+	}
+	return m
+}
+
+// readCharmSandboxProfile returns the sandbox profile declared by the
+// charm, ready for storage, or nil if it declares none.
+func readCharmSandboxProfile(ch charm.Charm) (*charmSandboxProfileDoc, error) {
+	profile, err := corecharm.ReadSandboxProfile(ch)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if profile.Empty() {
+		return nil, nil
+	}
+	return &charmSandboxProfileDoc{
+		Sysctls:          toSandboxEntryDocs(profile.Sysctls),
+		AppArmorProfiles: toSandboxEntryDocs(profile.AppArmorProfiles),
+	}, nil
+}
+
+// SandboxProfile returns the sandbox profile declared by the charm in
+// its sandbox-profile.yaml, or nil if it declares none.
+func (c *Charm) SandboxProfile() *corecharm.SandboxProfile {
+	if c.doc.SandboxProfile == nil {
+		return nil
+	}
+	return &corecharm.SandboxProfile{
+		Sysctls:          fromSandboxEntryDocs(c.doc.SandboxProfile.Sysctls),
+		AppArmorProfiles: fromSandboxEntryDocs(c.doc.SandboxProfile.AppArmorProfiles),
+	}
+}
+
+// SandboxProfiles returns the sandbox profiles to be applied to the
+// machine, keyed by the name of the application which needs them. The
+// profile of an application's charm is applied as soon as the
+// application is upgraded, before its units on the machine have moved
+// to the new charm, and the profiles of the charms still in use by
+// those units are kept until they have; where both declare the same
+// sysctl or AppArmor profile, the application's charm wins.
+func (m *Machine) SandboxProfiles() (map[string]*corecharm.SandboxProfile, error) {
+	charmURLs, err := m.sandboxCharmURLs()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]*corecharm.SandboxProfile)
+	for appName, urls := range charmURLs {
+		merged := &corecharm.SandboxProfile{}
+		for _, url := range urls {
+			curl, err := charm.ParseURL(url)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ch, err := m.st.Charm(curl)
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			profile := ch.SandboxProfile()
+			if profile.Empty() {
+				continue
+			}
+			merged.Sysctls = mergeSandboxEntries(merged.Sysctls, profile.Sysctls)
+			merged.AppArmorProfiles = mergeSandboxEntries(merged.AppArmorProfiles, profile.AppArmorProfiles)
+		}
+		if !merged.Empty() {
+			result[appName] = merged
+		}
+	}
+	return result, nil
+}
+
+func mergeSandboxEntries(into, from map[string]string) map[string]string {
+	if len(from) == 0 {
+		return into
+	}
+	if into == nil {
+		into = make(map[string]string, len(from))
+	}
+	for name, value := range from {
+		into[name] = value
+	}
+	return into
+}
+
+// sandboxCharmURLs returns the charm URLs in use on the machine, keyed by
+// application name. The units' charm URLs come first, followed by the
+// application's, so that the latter takes precedence when merged.
+func (m *Machine) sandboxCharmURLs() (map[string][]string, error) {
+	units, closer := m.st.db().GetCollection(unitsC)
+	defer closer()
+
+	var principals []unitDoc
+	if err := units.Find(bson.D{{"machineid", m.doc.Id}}).All(&principals); err != nil {
+		return nil, errors.Annotatef(err, "getting units of machine %s", m.doc.Id)
+	}
+	var subordinateNames []string
+	for _, doc := range principals {
+		subordinateNames = append(subordinateNames, doc.Subordinates...)
+	}
+	var subordinates []unitDoc
+	if len(subordinateNames) > 0 {
+		if err := units.Find(bson.D{{"name", bson.D{{"$in", subordinateNames}}}}).All(&subordinates); err != nil {
+			return nil, errors.Annotatef(err, "getting subordinate units of machine %s", m.doc.Id)
+		}
+	}
+
+	unitURLs := make(map[string][]string)
+	for _, doc := range append(principals, subordinates...) {
+		if doc.CharmURL != nil {
+			unitURLs[doc.Application] = append(unitURLs[doc.Application], doc.CharmURL.String())
+		} else if _, ok := unitURLs[doc.Application]; !ok {
+			unitURLs[doc.Application] = nil
+		}
+	}
+	appNames := make([]string, 0, len(unitURLs))
+	for name := range unitURLs {
+		appNames = append(appNames, name)
+	}
+
+	applications, closer := m.st.db().GetCollection(applicationsC)
+	defer closer()
+	var apps []applicationDoc
+	if err := applications.Find(bson.D{{"name", bson.D{{"$in", appNames}}}}).All(&apps); err != nil {
+		return nil, errors.Annotatef(err, "getting applications on machine %s", m.doc.Id)
+	}
+
+	result := make(map[string][]string, len(unitURLs))
+	for _, app := range apps {
+		urls := unitURLs[app.Name]
+		sort.Strings(urls)
+		if app.CharmURL != nil {
+			urls = append(urls, app.CharmURL.String())
+		}
+		result[app.Name] = dedupKeepLast(urls)
+	}
+	return result, nil
+}
+
+// dedupKeepLast removes duplicates from urls, keeping the position
+// of the last occurrence of each.
+func dedupKeepLast(urls []string) []string {
+	seen := make(map[string]bool, len(urls))
+	result := make([]string, 0, len(urls))
+	for i := len(urls) - 1; i >= 0; i-- {
+		if seen[urls[i]] {
+			continue
+		}
+		seen[urls[i]] = true
+		result = append([]string{urls[i]}, result...)
+	}
+	return result
+}
+
+// sandboxProfilesKey summarises the charms in use on the machine, so
+// that the watcher can tell when it changes.
+func (m *Machine) sandboxProfilesKey() (string, error) {
+	charmURLs, err := m.sandboxCharmURLs()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	entries := make([]string, 0, len(charmURLs))
+	for appName, urls := range charmURLs {
+		entries = append(entries, appName+"="+strings.Join(urls, ","))
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, ";"), nil
+}
+
+// WatchSandboxProfiles returns a NotifyWatcher which notifies when the
+// sandbox profiles to be applied to the machine may have changed:
+// when a unit is added to or removed from it, or when the charm of one
+// of its units or of their applications changes.
+func (m *Machine) WatchSandboxProfiles() NotifyWatcher {
+	return newMachineSandboxProfilesWatcher(m)
+}
+
+type machineSandboxProfilesWatcher struct {
+	commonWatcher
+	machine *Machine
+	out     chan struct{}
+}
+
+var _ Watcher = (*machineSandboxProfilesWatcher)(nil)
+
+func newMachineSandboxProfilesWatcher(m *Machine) NotifyWatcher {
+	w := &machineSandboxProfilesWatcher{
+		commonWatcher: newCommonWatcher(m.st),
+		out:           make(chan struct{}),
+		machine:       &Machine{st: m.st, doc: m.doc},
+	}
+	w.tomb.Go(func() error {
+		defer close(w.out)
+		return w.loop()
+	})
+	return w
+}
+
+// Changes returns the event channel for w.
+func (w *machineSandboxProfilesWatcher) Changes() <-chan struct{} {
+	return w.out
+}
+
+func (w *machineSandboxProfilesWatcher) loop() error {
+	unitsCh := make(chan watcher.Change)
+	w.watcher.WatchCollectionWithFilter(unitsC, unitsCh, isLocalID(w.backend))
+	defer w.watcher.UnwatchCollection(unitsC, unitsCh)
+	applicationsCh := make(chan watcher.Change)
+	w.watcher.WatchCollectionWithFilter(applicationsC, applicationsCh, isLocalID(w.backend))
+	defer w.watcher.UnwatchCollection(applicationsC, applicationsCh)
+
+	key, err := w.machine.sandboxProfilesKey()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	out := w.out
+	for {
+		select {
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case change := <-unitsCh:
+			if _, ok := collect(change, unitsCh, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+		case change := <-applicationsCh:
+			if _, ok := collect(change, applicationsCh, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+		case out <- struct{}{}:
+			out = nil
+			continue
+		}
+		newKey, err := w.machine.sandboxProfilesKey()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if newKey != key {
+			key = newKey
+			out = w.out
+		}
+	}
+}
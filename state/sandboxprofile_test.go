@@ -0,0 +1,127 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/charm/v9"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	corecharm "github.com/juju/juju/core/charm"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type SandboxProfileSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&SandboxProfileSuite{})
+
+const (
+	sandboxProfileV1 = `
+sysctls:
+  net.core.somaxconn: "1024"
+apparmor-profiles:
+  worker: "profile worker {}"
+`
+	sandboxProfileV2 = `
+sysctls:
+  net.core.somaxconn: "4096"
+  vm.max_map_count: "262144"
+`
+)
+
+func (s *SandboxProfileSuite) addSandboxCharm(c *gc.C, profile string, revision int) *state.Charm {
+	return state.AddCustomCharm(c, s.State, "dummy", "sandbox-profile.yaml", profile, "quantal", revision)
+}
+
+func (s *SandboxProfileSuite) TestCharmSandboxProfile(c *gc.C) {
+	ch := s.addSandboxCharm(c, sandboxProfileV1, 1)
+	c.Assert(ch.SandboxProfile(), jc.DeepEquals, &corecharm.SandboxProfile{
+		Sysctls:          map[string]string{"net.core.somaxconn": "1024"},
+		AppArmorProfiles: map[string]string{"worker": "profile worker {}"},
+	})
+
+	ch, err := s.State.Charm(ch.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ch.SandboxProfile().Sysctls, jc.DeepEquals, map[string]string{"net.core.somaxconn": "1024"})
+
+	c.Assert(s.AddTestingCharm(c, "mysql").SandboxProfile(), gc.IsNil)
+}
+
+func (s *SandboxProfileSuite) TestMachineSandboxProfilesFollowUpgrade(c *gc.C) {
+	ch1 := s.addSandboxCharm(c, sandboxProfileV1, 1)
+	app := s.AddTestingApplication(c, "dummy", ch1)
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.AssignToMachine(machine), jc.ErrorIsNil)
+	c.Assert(unit.SetCharmURL(ch1.URL()), jc.ErrorIsNil)
+
+	profiles, err := machine.SandboxProfiles()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profiles, jc.DeepEquals, map[string]*corecharm.SandboxProfile{
+		"dummy": ch1.SandboxProfile(),
+	})
+
+	// Once the application is upgraded, the new charm's profile is
+	// applied alongside the one still in use by the unit, and wins.
+	ch2 := s.addSandboxCharm(c, sandboxProfileV2, 2)
+	c.Assert(app.SetCharm(state.SetCharmConfig{Charm: ch2}), jc.ErrorIsNil)
+	profiles, err = machine.SandboxProfiles()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profiles, jc.DeepEquals, map[string]*corecharm.SandboxProfile{
+		"dummy": {
+			Sysctls: map[string]string{
+				"net.core.somaxconn": "4096",
+				"vm.max_map_count":   "262144",
+			},
+			AppArmorProfiles: map[string]string{"worker": "profile worker {}"},
+		},
+	})
+
+	// Once the unit has been upgraded, only the new profile remains.
+	c.Assert(unit.SetCharmURL(ch2.URL()), jc.ErrorIsNil)
+	profiles, err = machine.SandboxProfiles()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profiles, jc.DeepEquals, map[string]*corecharm.SandboxProfile{
+		"dummy": ch2.SandboxProfile(),
+	})
+}
+
+func (s *SandboxProfileSuite) TestWatchSandboxProfiles(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	w := machine.WatchSandboxProfiles()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	// A unit added elsewhere is not reported.
+	ch1 := s.addSandboxCharm(c, sandboxProfileV1, 1)
+	app := s.AddTestingApplication(c, "dummy", ch1)
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// Assigning it to the machine is.
+	c.Assert(unit.AssignToMachine(machine), jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// So is the unit's charm being set.
+	c.Assert(unit.SetCharmURL(ch1.URL()), jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Changing the application's config is not.
+	c.Assert(app.UpdateCharmConfig(model.GenerationMaster, charm.Settings{"outlook": "fine"}), jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// Upgrading the application is.
+	ch2 := s.addSandboxCharm(c, sandboxProfileV2, 2)
+	c.Assert(app.SetCharm(state.SetCharmConfig{Charm: ch2}), jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
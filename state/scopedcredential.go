@@ -0,0 +1,141 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/cloud"
+)
+
+// ScopedCredential is a credential issued by the cloud provider for the
+// use of a single trusted application, in place of the model's
+// credential. It grants only the access the application needs, and
+// expires.
+type ScopedCredential struct {
+	// Credential is the credential issued by the provider.
+	Credential cloud.Credential
+
+	// Expiry is when the provider stops honouring the credential.
+	Expiry time.Time
+}
+
+type scopedCredentialDoc struct {
+	DocID       string            `bson:"_id"`
+	Application string            `bson:"application"`
+	AuthType    string            `bson:"auth-type"`
+	Attributes  map[string]string `bson:"attributes,omitempty"`
+	Expiry      int64             `bson:"expiry"`
+}
+
+// SetScopedCredential records the credential issued by the cloud
+// provider for the application's use, replacing any recorded
+// previously. The credential's attributes are sealed with the
+// controller's secret backend, as cloud credentials are.
+func (a *Application) SetScopedCredential(credential cloud.Credential, expiry time.Time) error {
+	sealer, err := a.st.secretSealer()
+	if err != nil {
+		return errors.Annotatef(err, "cannot set scoped credential for application %q", a.Name())
+	}
+	attrs, err := sealedAttributes(sealer, credential.Attributes())
+	if err != nil {
+		return errors.Annotatef(err, "cannot set scoped credential for application %q", a.Name())
+	}
+	doc := scopedCredentialDoc{
+		DocID:       a.st.docID(a.globalKey()),
+		Application: a.Name(),
+		AuthType:    string(credential.AuthType()),
+		Attributes:  attrs,
+		Expiry:      expiry.UnixNano(),
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.Life() != Alive {
+			return nil, errors.NotFoundf("application %q", a.Name())
+		}
+		coll, closer := a.st.db().GetCollection(scopedCredentialsC)
+		defer closer()
+		n, err := coll.FindId(doc.DocID).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: isAliveDoc,
+		}}
+		if n == 0 {
+			return append(ops, txn.Op{
+				C:      scopedCredentialsC,
+				Id:     doc.DocID,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			}), nil
+		}
+		return append(ops, txn.Op{
+			C:      scopedCredentialsC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"auth-type", doc.AuthType},
+				{"attributes", doc.Attributes},
+				{"expiry", doc.Expiry},
+			}}},
+		}), nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot set scoped credential for application %q", a.Name())
+	}
+	return nil
+}
+
+// ScopedCredential returns the credential issued by the cloud provider
+// for the application's use. It returns a NotFound error if no
+// credential has been issued.
+func (a *Application) ScopedCredential() (ScopedCredential, error) {
+	coll, closer := a.st.db().GetCollection(scopedCredentialsC)
+	defer closer()
+
+	var doc scopedCredentialDoc
+	if err := coll.FindId(a.globalKey()).One(&doc); err == mgo.ErrNotFound {
+		return ScopedCredential{}, errors.NotFoundf("scoped credential for application %q", a.Name())
+	} else if err != nil {
+		return ScopedCredential{}, errors.Annotatef(err, "cannot get scoped credential for application %q", a.Name())
+	}
+	if err := a.st.openAttributes(doc.Attributes); err != nil {
+		return ScopedCredential{}, errors.Annotatef(err, "cannot get scoped credential for application %q", a.Name())
+	}
+	return ScopedCredential{
+		Credential: cloud.NewCredential(cloud.AuthType(doc.AuthType), doc.Attributes),
+		Expiry:     time.Unix(0, doc.Expiry).UTC(),
+	}, nil
+}
+
+// RemoveScopedCredential removes the credential issued by the cloud
+// provider for the application's use, if there is one.
+func (a *Application) RemoveScopedCredential() error {
+	err := a.st.db().RunTransaction([]txn.Op{
+		removeScopedCredentialOp(a.st, a.globalKey()),
+	})
+	return errors.Annotatef(err, "cannot remove scoped credential for application %q", a.Name())
+}
+
+// removeScopedCredentialOp returns the operation needed to remove the
+// scoped credential document associated with the given globalKey.
+func removeScopedCredentialOp(mb modelBackend, globalKey string) txn.Op {
+	return txn.Op{
+		C:      scopedCredentialsC,
+		Id:     mb.docID(globalKey),
+		Remove: true,
+	}
+}
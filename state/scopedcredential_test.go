@@ -0,0 +1,144 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+)
+
+type ScopedCredentialSuite struct {
+	ConnSuite
+
+	application *state.Application
+}
+
+var _ = gc.Suite(&ScopedCredentialSuite{})
+
+func (s *ScopedCredentialSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.application = s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+}
+
+var scopedCredential = cloud.NewCredential(cloud.OAuth2AuthType, map[string]string{
+	"Token": "scoped-token",
+})
+
+func (s *ScopedCredentialSuite) TestScopedCredentialNotIssued(c *gc.C) {
+	_, err := s.application.ScopedCredential()
+	c.Assert(err, gc.ErrorMatches, `scoped credential for application "wordpress" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ScopedCredentialSuite) TestSetScopedCredential(c *gc.C) {
+	expiry := time.Date(2021, 6, 7, 8, 9, 10, 0, time.UTC)
+	err := s.application.SetScopedCredential(scopedCredential, expiry)
+	c.Assert(err, jc.ErrorIsNil)
+
+	credential, err := s.application.ScopedCredential()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(credential, jc.DeepEquals, state.ScopedCredential{
+		Credential: scopedCredential,
+		Expiry:     expiry,
+	})
+
+	// Setting the credential again replaces the previous one.
+	rotated := cloud.NewCredential(cloud.OAuth2AuthType, map[string]string{
+		"Token": "rotated-token",
+	})
+	err = s.application.SetScopedCredential(rotated, expiry.Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	credential, err = s.application.ScopedCredential()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(credential.Credential, jc.DeepEquals, rotated)
+	c.Assert(credential.Expiry, gc.Equals, expiry.Add(time.Hour))
+}
+
+func (s *ScopedCredentialSuite) TestSetScopedCredentialSealed(c *gc.C) {
+	// A fake Vault transit engine which "wraps" keys by prefixing them.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		c.Check(json.NewDecoder(r.Body).Decode(&req), jc.ErrorIsNil)
+		var data map[string]string
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/juju":
+			data = map[string]string{"ciphertext": "vault:v1:" + req["plaintext"]}
+		case "/v1/transit/decrypt/juju":
+			data = map[string]string{"plaintext": strings.TrimPrefix(req["ciphertext"], "vault:v1:")}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	}))
+	defer srv.Close()
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.SecretBackend:             "vault",
+		controller.SecretBackendVaultAddress: srv.URL,
+		controller.SecretBackendVaultToken:   "s.token",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.application.SetScopedCredential(scopedCredential, time.Now())
+	c.Assert(err, jc.ErrorIsNil)
+
+	coll, closer := state.GetRawCollection(s.State, "scopedcredentials")
+	defer closer()
+	var doc struct {
+		Attributes map[string]string `bson:"attributes"`
+	}
+	err = coll.Find(nil).One(&doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(doc.Attributes["Token"], gc.Matches, "sealed:v1:.*")
+
+	credential, err := s.application.ScopedCredential()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(credential.Credential, jc.DeepEquals, scopedCredential)
+}
+
+func (s *ScopedCredentialSuite) TestSetScopedCredentialDyingApplication(c *gc.C) {
+	unit, err := s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit, gc.NotNil)
+	err = s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.application.SetScopedCredential(scopedCredential, time.Now())
+	c.Assert(err, gc.ErrorMatches, `cannot set scoped credential for application "wordpress": application "wordpress" not found`)
+}
+
+func (s *ScopedCredentialSuite) TestRemoveScopedCredential(c *gc.C) {
+	err := s.application.SetScopedCredential(scopedCredential, time.Now())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.RemoveScopedCredential()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.application.ScopedCredential()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing a credential which was never issued is not an error.
+	err = s.application.RemoveScopedCredential()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ScopedCredentialSuite) TestRemoveApplicationRemovesScopedCredential(c *gc.C) {
+	err := s.application.SetScopedCredential(scopedCredential, time.Now())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	coll := s.State.MongoSession().DB("juju").C("scopedcredentials")
+	n, err := coll.Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(n, gc.Equals, 0)
+}
@@ -0,0 +1,381 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/charm/v9"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/application"
+	mgoutils "github.com/juju/juju/mongo/utils"
+)
+
+// secretConfigDoc holds the values of an application's secret charm
+// config options, sealed with the controller's secret backend. They are
+// kept apart from the charm settings, so that they are never included in
+// the config delivered to units or reported to clients.
+type secretConfigDoc struct {
+	DocID       string            `bson:"_id"`
+	Application string            `bson:"application"`
+	Values      map[string]string `bson:"values"`
+}
+
+// secretConfigAccessDoc records a unit reading the value of a secret
+// charm config option.
+type secretConfigAccessDoc struct {
+	DocID       string `bson:"_id"`
+	Application string `bson:"application"`
+	Unit        string `bson:"unit"`
+	Key         string `bson:"key"`
+	Time        int64  `bson:"time"`
+}
+
+// SecretConfigAccess records a unit reading the value of a secret charm
+// config option.
+type SecretConfigAccess struct {
+	// Unit is the name of the unit which read the value.
+	Unit string
+
+	// Key is the name of the secret config option.
+	Key string
+
+	// Time is when the value was read.
+	Time time.Time
+}
+
+// secretOptions returns the names of the charm config options declared
+// as secret in the application config.
+func (a *Application) secretOptions() (set.Strings, error) {
+	cfg, err := a.ApplicationConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	names, err := cfg.SecretOptions()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return set.NewStrings(names...), nil
+}
+
+// validateSecretOptions checks that the named options are string options
+// of the application's charm.
+func (a *Application) validateSecretOptions(names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	ch, _, err := a.Charm()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, name := range names {
+		option, ok := ch.Config().Options[name]
+		if !ok {
+			return errors.NotValidf("%s option %q not defined by charm", application.SecretOptionsConfigKey, name)
+		}
+		if option.Type != "string" {
+			return errors.NotValidf("%s option %q of type %q", application.SecretOptionsConfigKey, name, option.Type)
+		}
+	}
+	return nil
+}
+
+// splitSecretConfig separates the changes to the application's secret
+// config options from the input charm settings changes.
+func (a *Application) splitSecretConfig(changes charm.Settings) (secret, other charm.Settings, _ error) {
+	secretOptions, err := a.secretOptions()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if secretOptions.IsEmpty() {
+		return nil, changes, nil
+	}
+	secret = make(charm.Settings)
+	other = make(charm.Settings)
+	for name, value := range changes {
+		if secretOptions.Contains(name) {
+			secret[name] = value
+		} else {
+			other[name] = value
+		}
+	}
+	return secret, other, nil
+}
+
+// updateSecretConfig seals and stores the values of the application's
+// secret config options. Values set to nil are removed.
+func (a *Application) updateSecretConfig(changes charm.Settings) error {
+	sealer, err := a.st.secretSealer()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sets := make(map[string]string)
+	var unsets []string
+	for name, value := range changes {
+		if value == nil {
+			unsets = append(unsets, mgoutils.EscapeKey(name))
+			continue
+		}
+		str, ok := value.(string)
+		if !ok {
+			return errors.NotValidf("secret config value for %q", name)
+		}
+		if err := sealSecrets(sealer, &str); err != nil {
+			return errors.Annotatef(err, "option %q", name)
+		}
+		sets[mgoutils.EscapeKey(name)] = str
+	}
+
+	docID := a.st.docID(a.globalKey())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.Life() != Alive {
+			return nil, errors.NotFoundf("application %q", a.Name())
+		}
+		coll, closer := a.st.db().GetCollection(secretConfigC)
+		defer closer()
+		n, err := coll.FindId(docID).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: isAliveDoc,
+		}}
+		if n == 0 {
+			if len(sets) == 0 {
+				return nil, jujutxn.ErrNoOperations
+			}
+			return append(ops, txn.Op{
+				C:      secretConfigC,
+				Id:     docID,
+				Assert: txn.DocMissing,
+				Insert: &secretConfigDoc{
+					DocID:       docID,
+					Application: a.Name(),
+					Values:      sets,
+				},
+			}), nil
+		}
+		var update bson.D
+		if len(sets) > 0 {
+			fields := make(bson.M)
+			for key, value := range sets {
+				fields["values."+key] = value
+			}
+			update = append(update, bson.DocElem{Name: "$set", Value: fields})
+		}
+		if len(unsets) > 0 {
+			fields := make(bson.M)
+			for _, key := range unsets {
+				fields["values."+key] = 1
+			}
+			update = append(update, bson.DocElem{Name: "$unset", Value: fields})
+		}
+		return append(ops, txn.Op{
+			C:      secretConfigC,
+			Id:     docID,
+			Assert: txn.DocExists,
+			Update: update,
+		}), nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot update secret config for application %q", a.Name())
+	}
+	return nil
+}
+
+// sealedSecretConfig returns the sealed values of the application's
+// secret config options, keyed by option name.
+func (a *Application) sealedSecretConfig() (map[string]string, error) {
+	coll, closer := a.st.db().GetCollection(secretConfigC)
+	defer closer()
+
+	var doc secretConfigDoc
+	if err := coll.FindId(a.globalKey()).One(&doc); err == mgo.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get secret config for application %q", a.Name())
+	}
+	values := make(map[string]string, len(doc.Values))
+	for key, value := range doc.Values {
+		values[mgoutils.UnescapeKey(key)] = value
+	}
+	return values, nil
+}
+
+// moveSecretConfig moves the values of options newly declared as secret
+// out of the charm settings, and the values of options no longer
+// declared as secret back into them. Values are always written to their
+// new home before they are removed from the old one, so that none are
+// lost if a write fails.
+func (a *Application) moveSecretConfig(added, removed []string) error {
+	if len(removed) > 0 {
+		sealed, err := a.sealedSecretConfig()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		current, err := readSettings(a.st.db(), settingsC, a.charmConfigKey())
+		if err != nil {
+			return errors.Annotatef(err, "charm config for application %q", a.doc.Name)
+		}
+		secretChanges := make(charm.Settings)
+		for _, name := range removed {
+			value, ok := sealed[name]
+			if !ok {
+				continue
+			}
+			if err := a.st.openSecrets(&value); err != nil {
+				return errors.Annotatef(err, "option %q", name)
+			}
+			current.Set(name, value)
+			secretChanges[name] = nil
+		}
+		if len(secretChanges) > 0 {
+			if _, err := current.Write(); err != nil {
+				return errors.Trace(err)
+			}
+			if err := a.updateSecretConfig(secretChanges); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+
+	if len(added) > 0 {
+		current, err := readSettings(a.st.db(), settingsC, a.charmConfigKey())
+		if err != nil {
+			return errors.Annotatef(err, "charm config for application %q", a.doc.Name)
+		}
+		secretChanges := make(charm.Settings)
+		for _, name := range added {
+			if value, ok := current.Get(name); ok {
+				secretChanges[name] = value
+				current.Delete(name)
+			}
+		}
+		if len(secretChanges) > 0 {
+			if err := a.updateSecretConfig(secretChanges); err != nil {
+				return errors.Trace(err)
+			}
+			if _, err := current.Write(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return nil
+}
+
+// SecretConfigKeys returns the names of the application's secret config
+// options which have a value set.
+func (a *Application) SecretConfigKeys() ([]string, error) {
+	sealed, err := a.sealedSecretConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	keys := make([]string, 0, len(sealed))
+	for key := range sealed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// SecretConfigValue returns the value of the application's secret config
+// option with the input name, or the charm's default if no value is set.
+// Every read is recorded against the unit, for audit.
+func (u *Unit) SecretConfigValue(key string) (string, error) {
+	app, err := u.Application()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	secretOptions, err := app.secretOptions()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if !secretOptions.Contains(key) {
+		return "", errors.NotValidf("secret config option %q", key)
+	}
+
+	sealed, err := app.sealedSecretConfig()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	value, ok := sealed[key]
+	if ok {
+		if err := u.st.openSecrets(&value); err != nil {
+			return "", errors.Annotatef(err, "option %q", key)
+		}
+	} else {
+		ch, _, err := app.Charm()
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		value, _ = ch.Config().Options[key].Default.(string)
+	}
+
+	if err := u.recordSecretConfigAccess(key); err != nil {
+		return "", errors.Trace(err)
+	}
+	return value, nil
+}
+
+func (u *Unit) recordSecretConfigAccess(key string) error {
+	doc := &secretConfigAccessDoc{
+		DocID:       bson.NewObjectId().Hex(),
+		Application: u.ApplicationName(),
+		Unit:        u.Name(),
+		Key:         key,
+		Time:        u.st.clock().Now().UnixNano(),
+	}
+	err := u.st.db().RunTransaction([]txn.Op{{
+		C:      secretConfigAccessC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}})
+	return errors.Annotatef(err, "cannot record secret config access for unit %q", u.Name())
+}
+
+// SecretConfigAccesses returns the recorded reads of the application's
+// secret config options, oldest first.
+func (a *Application) SecretConfigAccesses() ([]SecretConfigAccess, error) {
+	coll, closer := a.st.db().GetCollection(secretConfigAccessC)
+	defer closer()
+
+	var docs []secretConfigAccessDoc
+	if err := coll.Find(bson.D{{"application", a.Name()}}).Sort("time").All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get secret config accesses for application %q", a.Name())
+	}
+	accesses := make([]SecretConfigAccess, len(docs))
+	for i, doc := range docs {
+		accesses[i] = SecretConfigAccess{
+			Unit: doc.Unit,
+			Key:  doc.Key,
+			Time: time.Unix(0, doc.Time).UTC(),
+		}
+	}
+	return accesses, nil
+}
+
+// removeSecretConfigOp returns the operation needed to remove the secret
+// config document associated with the given globalKey.
+func removeSecretConfigOp(mb modelBackend, globalKey string) txn.Op {
+	return txn.Op{
+		C:      secretConfigC,
+		Id:     mb.docID(globalKey),
+		Remove: true,
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/charm/v9"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/state"
+)
+
+type SecretConfigSuite struct {
+	ConnSuite
+
+	application *state.Application
+	unit        *state.Unit
+}
+
+var _ = gc.Suite(&SecretConfigSuite{})
+
+func (s *SecretConfigSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.application = s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	unit, err := s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.unit = unit
+}
+
+var secretConfigSchema = environschema.Fields{
+	application.SecretOptionsConfigKey: environschema.Attr{Type: environschema.Tstring},
+}
+
+func (s *SecretConfigSuite) setSecretOptions(c *gc.C, value string) {
+	err := s.application.UpdateApplicationConfig(application.ConfigAttributes{
+		application.SecretOptionsConfigKey: value,
+	}, nil, secretConfigSchema, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SecretConfigSuite) TestMarkingOptionSecretMovesValue(c *gc.C) {
+	err := s.application.UpdateCharmConfig(model.GenerationMaster, charm.Settings{"username": "admin"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.setSecretOptions(c, "username")
+
+	cfg, err := s.application.CharmConfig(model.GenerationMaster)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg["username"], gc.IsNil)
+	keys, err := s.application.SecretConfigKeys()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(keys, jc.DeepEquals, []string{"username"})
+
+	value, err := s.unit.SecretConfigValue("username")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, gc.Equals, "admin")
+}
+
+func (s *SecretConfigSuite) TestUnmarkingOptionSecretRestoresValue(c *gc.C) {
+	s.setSecretOptions(c, "username")
+	err := s.application.UpdateCharmConfig(model.GenerationMaster, charm.Settings{"username": "admin"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.setSecretOptions(c, "")
+
+	keys, err := s.application.SecretConfigKeys()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(keys, gc.HasLen, 0)
+	cfg, err := s.application.CharmConfig(model.GenerationMaster)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg["username"], gc.Equals, "admin")
+}
+
+func (s *SecretConfigSuite) TestUpdateCharmConfigStoresSecretApart(c *gc.C) {
+	s.setSecretOptions(c, "username")
+	err := s.application.UpdateCharmConfig(model.GenerationMaster, charm.Settings{
+		"username": "admin",
+		"outlook":  "sunny",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.application.CharmConfig(model.GenerationMaster)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg["username"], gc.IsNil)
+	c.Assert(cfg["outlook"], gc.Equals, "sunny")
+
+	value, err := s.unit.SecretConfigValue("username")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, gc.Equals, "admin")
+
+	// Resetting the option falls back to the charm default.
+	err = s.application.UpdateCharmConfig(model.GenerationMaster, charm.Settings{"username": nil})
+	c.Assert(err, jc.ErrorIsNil)
+	value, err = s.unit.SecretConfigValue("username")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, gc.Equals, "admin001")
+}
+
+func (s *SecretConfigSuite) TestSecretConfigValueRecordsAccess(c *gc.C) {
+	s.setSecretOptions(c, "username")
+	err := s.application.UpdateCharmConfig(model.GenerationMaster, charm.Settings{"username": "admin"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.unit.SecretConfigValue("username")
+	c.Assert(err, jc.ErrorIsNil)
+
+	accesses, err := s.application.SecretConfigAccesses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(accesses, gc.HasLen, 1)
+	c.Assert(accesses[0].Unit, gc.Equals, s.unit.Name())
+	c.Assert(accesses[0].Key, gc.Equals, "username")
+	c.Assert(accesses[0].Time.IsZero(), jc.IsFalse)
+}
+
+func (s *SecretConfigSuite) TestSecretConfigValueNotSecret(c *gc.C) {
+	_, err := s.unit.SecretConfigValue("outlook")
+	c.Assert(err, gc.ErrorMatches, `secret config option "outlook" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *SecretConfigSuite) TestUpdateCharmConfigSecretOnBranch(c *gc.C) {
+	s.setSecretOptions(c, "username")
+	c.Assert(s.State.AddBranch("new-branch", "branch-user"), jc.ErrorIsNil)
+
+	err := s.application.UpdateCharmConfig("new-branch", charm.Settings{"username": "admin"})
+	c.Assert(err, gc.ErrorMatches, `changing secret config options on branch "new-branch" not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *SecretConfigSuite) TestSecretOptionsMustBeStrings(c *gc.C) {
+	err := s.application.UpdateApplicationConfig(application.ConfigAttributes{
+		application.SecretOptionsConfigKey: "skill-level",
+	}, nil, secretConfigSchema, nil)
+	c.Assert(err, gc.ErrorMatches, `secret-options option "skill-level" of type "int" not valid`)
+}
+
+func (s *SecretConfigSuite) TestSecretOptionsMustBeDefined(c *gc.C) {
+	err := s.application.UpdateApplicationConfig(application.ConfigAttributes{
+		application.SecretOptionsConfigKey: "unknown",
+	}, nil, secretConfigSchema, nil)
+	c.Assert(err, gc.ErrorMatches, `secret-options option "unknown" not defined by charm not valid`)
+}
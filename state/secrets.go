@@ -0,0 +1,138 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+
+	jujucontroller "github.com/juju/juju/controller"
+	"github.com/juju/juju/state/secrets"
+)
+
+var (
+	sealersMu sync.Mutex
+	// sealers caches the Sealer for each secret backend configuration,
+	// so that unwrapped data keys are reused between States.
+	sealers = make(map[string]secrets.Sealer)
+)
+
+// secretSealer returns the secrets.Sealer for the controller's
+// configured secret backend.
+func (st *State) secretSealer() (secrets.Sealer, error) {
+	cfg, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return sealerForConfig(cfg)
+}
+
+// sealerForConfig returns the secrets.Sealer for the given controller
+// config. Secrets stored before a secret backend was configured remain
+// readable, and are sealed when next written.
+func sealerForConfig(cfg jujucontroller.Config) (secrets.Sealer, error) {
+	if cfg.SecretBackend() != jujucontroller.SecretBackendVault {
+		return secrets.NewInternalSealer(), nil
+	}
+	vaultConfig := secrets.VaultConfig{
+		Address:    cfg.SecretBackendVaultAddress(),
+		Token:      cfg.SecretBackendVaultToken(),
+		TransitKey: cfg.SecretBackendVaultTransitKey(),
+		CACert:     cfg.SecretBackendVaultCACert(),
+	}
+	cacheKey := strings.Join([]string{
+		vaultConfig.Address, vaultConfig.Token, vaultConfig.TransitKey, vaultConfig.CACert,
+	}, "\x00")
+
+	sealersMu.Lock()
+	defer sealersMu.Unlock()
+	if sealer, ok := sealers[cacheKey]; ok {
+		return sealer, nil
+	}
+	wrapper, err := secrets.NewVaultKeyWrapper(vaultConfig)
+	if err != nil {
+		return nil, errors.Annotate(err, "configuring Vault secret backend")
+	}
+	sealer := secrets.NewEnvelopeSealer(wrapper)
+	sealers[cacheKey] = sealer
+	return sealer, nil
+}
+
+// sealSecrets seals the given secrets in place.
+func sealSecrets(sealer secrets.Sealer, values ...*string) error {
+	for _, value := range values {
+		if *value == "" || secrets.IsSealed(*value) {
+			continue
+		}
+		sealed, err := sealer.Seal(*value)
+		if err != nil {
+			return errors.Annotate(err, "sealing secret")
+		}
+		*value = sealed
+	}
+	return nil
+}
+
+// openSecrets opens the given secrets in place. The controller config
+// is only read if any of them are sealed.
+func (st *State) openSecrets(values ...*string) error {
+	var sealer secrets.Sealer
+	for _, value := range values {
+		if !secrets.IsSealed(*value) {
+			continue
+		}
+		if sealer == nil {
+			var err error
+			if sealer, err = st.secretSealer(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		opened, err := sealer.Open(*value)
+		if err != nil {
+			return errors.Annotate(err, "opening secret")
+		}
+		*value = opened
+	}
+	return nil
+}
+
+// sealedAttributes returns a copy of the attributes with their values
+// sealed.
+func sealedAttributes(sealer secrets.Sealer, attrs map[string]string) (map[string]string, error) {
+	if len(attrs) == 0 {
+		return attrs, nil
+	}
+	out := make(map[string]string, len(attrs))
+	for k, v := range attrs {
+		if err := sealSecrets(sealer, &v); err != nil {
+			return nil, errors.Annotatef(err, "attribute %q", k)
+		}
+		out[k] = v
+	}
+	return out, nil
+}
+
+// openAttributes opens the sealed values of the attributes in place.
+func (st *State) openAttributes(attrs map[string]string) error {
+	var sealer secrets.Sealer
+	for k, v := range attrs {
+		if !secrets.IsSealed(v) {
+			continue
+		}
+		if sealer == nil {
+			var err error
+			if sealer, err = st.secretSealer(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		opened, err := sealer.Open(v)
+		if err != nil {
+			return errors.Annotatef(err, "opening attribute %q", k)
+		}
+		attrs[k] = opened
+	}
+	return nil
+}
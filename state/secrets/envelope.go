@@ -0,0 +1,169 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+)
+
+// sealedPrefix marks a sealed value. The rest of the value is the
+// base64 encoded JSON envelope.
+const sealedPrefix = "sealed:v1:"
+
+// maxCachedKeys bounds the number of unwrapped data keys kept by an
+// envelope sealer, so that reading the same secrets repeatedly doesn't
+// call the key management service each time.
+const maxCachedKeys = 1024
+
+// IsSealed reports whether the value is a sealed secret.
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, sealedPrefix)
+}
+
+// envelope holds a secret encrypted with AES-GCM under a data key, and
+// the data key wrapped by the key management service.
+type envelope struct {
+	Key   string `json:"key"`
+	Nonce []byte `json:"nonce"`
+	Data  []byte `json:"data"`
+}
+
+// NewEnvelopeSealer returns a Sealer which encrypts each secret with a
+// new data key, wrapped by the given KeyWrapper.
+func NewEnvelopeSealer(wrapper KeyWrapper) Sealer {
+	return &envelopeSealer{
+		wrapper: wrapper,
+		keys:    make(map[string][]byte),
+	}
+}
+
+type envelopeSealer struct {
+	wrapper KeyWrapper
+
+	mu   sync.Mutex
+	keys map[string][]byte
+}
+
+// Seal is part of the Sealer interface.
+func (s *envelopeSealer) Seal(secret string) (string, error) {
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return "", errors.Trace(err)
+	}
+	wrapped, err := s.wrapper.WrapKey(key)
+	if err != nil {
+		return "", errors.Annotate(err, "wrapping data key")
+	}
+	gcm, err := newCipher(key)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	env := envelope{
+		Key:   wrapped,
+		Nonce: make([]byte, gcm.NonceSize()),
+	}
+	if _, err := io.ReadFull(rand.Reader, env.Nonce); err != nil {
+		return "", errors.Trace(err)
+	}
+	env.Data = gcm.Seal(nil, env.Nonce, []byte(secret), nil)
+	data, err := json.Marshal(env)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	s.cacheKey(wrapped, key)
+	return sealedPrefix + base64.StdEncoding.EncodeToString(data), nil
+}
+
+// Open is part of the Sealer interface.
+func (s *envelopeSealer) Open(value string) (string, error) {
+	if !IsSealed(value) {
+		return value, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, sealedPrefix))
+	if err != nil {
+		return "", errors.Annotate(err, "decoding sealed secret")
+	}
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return "", errors.Annotate(err, "decoding sealed secret")
+	}
+	key, err := s.unwrapKey(env.Key)
+	if err != nil {
+		return "", errors.Annotate(err, "unwrapping data key")
+	}
+	gcm, err := newCipher(key)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(env.Nonce) != gcm.NonceSize() {
+		return "", errors.NotValidf("sealed secret nonce")
+	}
+	secret, err := gcm.Open(nil, env.Nonce, env.Data, nil)
+	if err != nil {
+		return "", errors.New("cannot open sealed secret: corrupt value")
+	}
+	return string(secret), nil
+}
+
+func (s *envelopeSealer) unwrapKey(wrapped string) ([]byte, error) {
+	s.mu.Lock()
+	key, ok := s.keys[wrapped]
+	s.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+	key, err := s.wrapper.UnwrapKey(wrapped)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	s.cacheKey(wrapped, key)
+	return key, nil
+}
+
+func (s *envelopeSealer) cacheKey(wrapped string, key []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.keys) >= maxCachedKeys {
+		s.keys = make(map[string][]byte)
+	}
+	s.keys[wrapped] = key
+}
+
+func newCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// NewInternalSealer returns the Sealer used when secrets are stored in
+// mongo as they are. It can't open sealed secrets.
+func NewInternalSealer() Sealer {
+	return internalSealer{}
+}
+
+type internalSealer struct{}
+
+// Seal is part of the Sealer interface.
+func (internalSealer) Seal(secret string) (string, error) {
+	return secret, nil
+}
+
+// Open is part of the Sealer interface.
+func (internalSealer) Open(value string) (string, error) {
+	if IsSealed(value) {
+		return "", errors.New("cannot open sealed secret without a secret backend")
+	}
+	return value, nil
+}
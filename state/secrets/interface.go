@@ -0,0 +1,31 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets seals the secrets the controller stores in mongo, such
+// as cloud credentials and the CA private key, with envelope encryption:
+// each secret is encrypted with its own data key, which is in turn
+// wrapped by a key held in an external key management service and
+// stored, wrapped, alongside the secret.
+package secrets
+
+// Sealer seals secrets before they are stored, and opens them again
+// once read.
+type Sealer interface {
+	// Seal returns the sealed form of the secret.
+	Seal(secret string) (string, error)
+
+	// Open returns the secret held in the value. Values which aren't
+	// sealed are returned as they are, so that secrets stored before
+	// sealing was enabled can still be read.
+	Open(value string) (string, error)
+}
+
+// KeyWrapper wraps and unwraps data keys with a key held by a key
+// management service, which never leaves it.
+type KeyWrapper interface {
+	// WrapKey returns the data key encrypted with the service's key.
+	WrapKey(key []byte) (string, error)
+
+	// UnwrapKey returns the data key held in a wrapped key.
+	UnwrapKey(wrapped string) ([]byte, error)
+}
@@ -0,0 +1,192 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/secrets"
+	"github.com/juju/juju/testing"
+)
+
+type sealerSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&sealerSuite{})
+
+// fakeWrapper "wraps" keys by base64 encoding them, counting calls.
+type fakeWrapper struct {
+	wraps, unwraps int
+}
+
+func (w *fakeWrapper) WrapKey(key []byte) (string, error) {
+	w.wraps++
+	return "fake:" + base64.StdEncoding.EncodeToString(key), nil
+}
+
+func (w *fakeWrapper) UnwrapKey(wrapped string) ([]byte, error) {
+	w.unwraps++
+	return base64.StdEncoding.DecodeString(strings.TrimPrefix(wrapped, "fake:"))
+}
+
+func (s *sealerSuite) TestEnvelopeSealRoundTrip(c *gc.C) {
+	wrapper := &fakeWrapper{}
+	sealer := secrets.NewEnvelopeSealer(wrapper)
+	sealed, err := sealer.Seal("top secret")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secrets.IsSealed(sealed), jc.IsTrue)
+	c.Assert(sealed, gc.Not(jc.Contains), "top secret")
+
+	// A new sealer, as on another controller, unwraps the key.
+	other := secrets.NewEnvelopeSealer(wrapper)
+	opened, err := other.Open(sealed)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(opened, gc.Equals, "top secret")
+	c.Assert(wrapper.unwraps, gc.Equals, 1)
+
+	// The unwrapped key is cached.
+	_, err = other.Open(sealed)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(wrapper.unwraps, gc.Equals, 1)
+}
+
+func (s *sealerSuite) TestEnvelopeSealUsesNewDataKeys(c *gc.C) {
+	wrapper := &fakeWrapper{}
+	sealer := secrets.NewEnvelopeSealer(wrapper)
+	one, err := sealer.Seal("secret")
+	c.Assert(err, jc.ErrorIsNil)
+	two, err := sealer.Seal("secret")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(one, gc.Not(gc.Equals), two)
+	c.Assert(wrapper.wraps, gc.Equals, 2)
+}
+
+func (s *sealerSuite) TestEnvelopeOpenUnsealed(c *gc.C) {
+	sealer := secrets.NewEnvelopeSealer(&fakeWrapper{})
+	opened, err := sealer.Open("plain")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(opened, gc.Equals, "plain")
+}
+
+func (s *sealerSuite) TestEnvelopeOpenTampered(c *gc.C) {
+	sealer := secrets.NewEnvelopeSealer(&fakeWrapper{})
+	_, err := sealer.Open("sealed:v1:!!!")
+	c.Assert(err, gc.ErrorMatches, "decoding sealed secret: .*")
+}
+
+func (s *sealerSuite) TestInternalSealer(c *gc.C) {
+	sealer := secrets.NewInternalSealer()
+	sealed, err := sealer.Seal("secret")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sealed, gc.Equals, "secret")
+
+	envelopeSealed, err := secrets.NewEnvelopeSealer(&fakeWrapper{}).Seal("secret")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = sealer.Open(envelopeSealed)
+	c.Assert(err, gc.ErrorMatches, "cannot open sealed secret without a secret backend")
+}
+
+type vaultSuite struct {
+	testing.BaseSuite
+	server   *httptest.Server
+	requests []*http.Request
+	status   int
+}
+
+var _ = gc.Suite(&vaultSuite{})
+
+func (s *vaultSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.requests = nil
+	s.status = http.StatusOK
+	// The fake transit engine "encrypts" by prefixing the plaintext.
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests = append(s.requests, r)
+		var args map[string]string
+		c.Check(json.NewDecoder(r.Body).Decode(&args), jc.ErrorIsNil)
+		if s.status != http.StatusOK {
+			w.WriteHeader(s.status)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"errors": []string{"permission denied"}})
+			return
+		}
+		data := map[string]string{}
+		if strings.HasSuffix(r.URL.Path, "/encrypt/juju") {
+			data["ciphertext"] = "vault:v1:" + args["plaintext"]
+		} else {
+			data["plaintext"] = strings.TrimPrefix(args["ciphertext"], "vault:v1:")
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *vaultSuite) newWrapper(c *gc.C) secrets.KeyWrapper {
+	wrapper, err := secrets.NewVaultKeyWrapper(secrets.VaultConfig{
+		Address:    s.server.URL,
+		Token:      "s.token",
+		TransitKey: "transit/juju",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return wrapper
+}
+
+func (s *vaultSuite) TestWrapUnwrap(c *gc.C) {
+	wrapper := s.newWrapper(c)
+	wrapped, err := wrapper.WrapKey([]byte("data key"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(wrapped, gc.Equals, "vault:v1:"+base64.StdEncoding.EncodeToString([]byte("data key")))
+
+	key, err := wrapper.UnwrapKey(wrapped)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(key), gc.Equals, "data key")
+
+	c.Assert(s.requests, gc.HasLen, 2)
+	c.Assert(s.requests[0].Method, gc.Equals, "POST")
+	c.Assert(s.requests[0].URL.Path, gc.Equals, "/v1/transit/encrypt/juju")
+	c.Assert(s.requests[0].Header.Get("X-Vault-Token"), gc.Equals, "s.token")
+	c.Assert(s.requests[1].URL.Path, gc.Equals, "/v1/transit/decrypt/juju")
+}
+
+func (s *vaultSuite) TestPermissionDenied(c *gc.C) {
+	s.status = http.StatusForbidden
+	_, err := s.newWrapper(c).WrapKey([]byte("data key"))
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+	c.Assert(err, gc.ErrorMatches, "Vault encrypt with transit key transit/juju: permission denied")
+}
+
+func (s *vaultSuite) TestServerError(c *gc.C) {
+	s.status = http.StatusInternalServerError
+	_, err := s.newWrapper(c).UnwrapKey("vault:v1:abc")
+	c.Assert(err, gc.ErrorMatches, `Vault decrypt failed \(500 Internal Server Error\): permission denied`)
+}
+
+func (s *vaultSuite) TestEnvelopeWithVault(c *gc.C) {
+	sealer := secrets.NewEnvelopeSealer(s.newWrapper(c))
+	sealed, err := sealer.Seal("secret")
+	c.Assert(err, jc.ErrorIsNil)
+	opened, err := secrets.NewEnvelopeSealer(s.newWrapper(c)).Open(sealed)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(opened, gc.Equals, "secret")
+}
+
+func (s *vaultSuite) TestInvalidConfig(c *gc.C) {
+	for _, cfg := range []secrets.VaultConfig{
+		{Token: "t", TransitKey: "transit/juju"},
+		{Address: s.server.URL, TransitKey: "transit/juju"},
+		{Address: s.server.URL, Token: "t", TransitKey: "juju"},
+		{Address: s.server.URL, Token: "t", TransitKey: "transit/"},
+	} {
+		_, err := secrets.NewVaultKeyWrapper(cfg)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
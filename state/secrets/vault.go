@@ -0,0 +1,164 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// VaultConfig holds the details of the Vault transit key used to wrap
+// data keys.
+type VaultConfig struct {
+	// Address is the URL of the Vault server.
+	Address string
+
+	// Token is used to authenticate to Vault. It needs the
+	// "update" capability on the transit key's encrypt and decrypt
+	// endpoints.
+	Token string
+
+	// TransitKey is the path of the transit key, as "<mount>/<key>".
+	TransitKey string
+
+	// CACert, if set, is the PEM encoded CA certificate which signed
+	// the Vault server's certificate.
+	CACert string
+}
+
+// Validate checks that the config is usable.
+func (cfg VaultConfig) Validate() error {
+	if cfg.Address == "" {
+		return errors.NotValidf("empty Vault address")
+	}
+	if cfg.Token == "" {
+		return errors.NotValidf("empty Vault token")
+	}
+	if i := strings.LastIndex(cfg.TransitKey, "/"); i <= 0 || i == len(cfg.TransitKey)-1 {
+		return errors.NotValidf("Vault transit key %q", cfg.TransitKey)
+	}
+	return nil
+}
+
+// NewVaultKeyWrapper returns a KeyWrapper which wraps data keys with a
+// HashiCorp Vault transit key.
+func NewVaultKeyWrapper(cfg VaultConfig) (KeyWrapper, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	base, err := url.Parse(cfg.Address)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing Vault address")
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.CACert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(cfg.CACert)) {
+			return nil, errors.NotValidf("Vault CA certificate")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	i := strings.LastIndex(cfg.TransitKey, "/")
+	return &vaultKeyWrapper{
+		base:   base,
+		token:  cfg.Token,
+		mount:  cfg.TransitKey[:i],
+		key:    cfg.TransitKey[i+1:],
+		client: &http.Client{Transport: transport, Timeout: 30 * time.Second},
+	}, nil
+}
+
+type vaultKeyWrapper struct {
+	base   *url.URL
+	token  string
+	mount  string
+	key    string
+	client *http.Client
+}
+
+// WrapKey is part of the KeyWrapper interface.
+func (w *vaultKeyWrapper) WrapKey(key []byte) (string, error) {
+	var result struct {
+		Ciphertext string `json:"ciphertext"`
+	}
+	err := w.call("encrypt", map[string]string{
+		"plaintext": base64.StdEncoding.EncodeToString(key),
+	}, &result)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if result.Ciphertext == "" {
+		return "", errors.New("Vault returned no ciphertext")
+	}
+	return result.Ciphertext, nil
+}
+
+// UnwrapKey is part of the KeyWrapper interface.
+func (w *vaultKeyWrapper) UnwrapKey(wrapped string) ([]byte, error) {
+	var result struct {
+		Plaintext string `json:"plaintext"`
+	}
+	if err := w.call("decrypt", map[string]string{"ciphertext": wrapped}, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	key, err := base64.StdEncoding.DecodeString(result.Plaintext)
+	if err != nil {
+		return nil, errors.Annotate(err, "decoding data key from Vault")
+	}
+	return key, nil
+}
+
+// call makes a request to the transit key's encrypt or decrypt
+// endpoint, unmarshalling the response data into result.
+func (w *vaultKeyWrapper) call(op string, args interface{}, result interface{}) error {
+	body, err := json.Marshal(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	u := *w.base
+	u.Path = path.Join("/", u.Path, "v1", w.mount, op, w.key)
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("X-Vault-Token", w.token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return errors.Annotatef(err, "calling Vault %s", op)
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []string        `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil && resp.StatusCode == http.StatusOK {
+		return errors.Annotatef(err, "decoding Vault %s response", op)
+	}
+	switch {
+	case resp.StatusCode == http.StatusForbidden:
+		return errors.Unauthorizedf("Vault %s with transit key %s/%s: %s", op, w.mount, w.key, vaultErrors(response.Errors))
+	case resp.StatusCode != http.StatusOK:
+		return errors.Errorf("Vault %s failed (%s): %s", op, resp.Status, vaultErrors(response.Errors))
+	}
+	return errors.Annotatef(json.Unmarshal(response.Data, result), "decoding Vault %s response", op)
+}
+
+func vaultErrors(errs []string) string {
+	if len(errs) == 0 {
+		return "no error details"
+	}
+	return strings.Join(errs, "; ")
+}
@@ -30,6 +30,7 @@ type Space struct {
 type spaceDoc struct {
 	DocId      string `bson:"_id"`
 	Id         string `bson:"spaceid"`
+	UUID       string `bson:"uuid,omitempty"`
 	Life       Life   `bson:"life"`
 	Name       string `bson:"name"`
 	IsPublic   bool   `bson:"is-public"`
@@ -41,6 +42,12 @@ func (s *Space) Id() string {
 	return s.doc.Id
 }
 
+// UUID returns the space's UUID. Unlike the space's name, it never
+// changes.
+func (s *Space) UUID() string {
+	return s.doc.UUID
+}
+
 // Life returns whether the space is Alive, Dying or Dead.
 func (s *Space) Life() Life {
 	return s.doc.Life
@@ -244,6 +251,7 @@ func (st *State) addSpaceTxnOps(id, name string, providerId network.Id, isPublic
 	doc := spaceDoc{
 		DocId:      st.docID(id),
 		Id:         id,
+		UUID:       newEntityUUID(),
 		Life:       Alive,
 		Name:       name,
 		IsPublic:   isPublic,
@@ -416,6 +424,7 @@ func (st *State) createDefaultSpaceOp() txn.Op {
 		Assert: txn.DocMissing,
 		Insert: spaceDoc{
 			Id:       network.AlphaSpaceId,
+			UUID:     newEntityUUID(),
 			Life:     Alive,
 			Name:     network.AlphaSpaceName,
 			IsPublic: true,
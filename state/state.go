@@ -78,6 +78,10 @@ type State struct {
 	newPolicy              NewPolicyFunc
 	runTransactionObserver RunTransactionObserverFunc
 
+	// s3Storage caches the S3 resource storage used when the
+	// controller's blob data is held in S3.
+	s3Storage s3StorageCache
+
 	// workers is responsible for keeping the various sub-workers
 	// available by starting new ones as they fail. It doesn't do
 	// that yet, but having a type that collects them together is the
@@ -924,6 +928,7 @@ func (st *State) addPeerRelationsOps(applicationname string, peers map[string]ch
 			DocID:     st.docID(relKey),
 			Key:       relKey,
 			ModelUUID: st.ModelUUID(),
+			UUID:      newEntityUUID(),
 			Id:        relId,
 			Endpoints: eps,
 			Life:      Alive,
@@ -1152,6 +1157,7 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 		DocID:         applicationID,
 		Name:          args.Name,
 		ModelUUID:     st.ModelUUID(),
+		UUID:          newEntityUUID(),
 		Series:        args.Series,
 		Subordinate:   args.Charm.Meta().Subordinate,
 		CharmURL:      args.Charm.URL(),
@@ -1263,6 +1269,11 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 		}
 
 		// Collect unit-adding operations.
+		quotaOps, err := st.unitQuotaAssertOps(args.NumUnits)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, quotaOps...)
 		for x := 0; x < args.NumUnits; x++ {
 			unitName, unitOps, err := app.addApplicationUnitOps(applicationAddUnitOpsArgs{
 				cons:          args.Constraints,
@@ -2014,6 +2025,7 @@ func (st *State) AddRelation(eps ...Endpoint) (r *Relation, err error) {
 		// Collect per-application operations, checking sanity as we go.
 		var ops []txn.Op
 		var subordinateCount int
+		var keyGeneration int
 		appSeries := map[string][]string{}
 		for _, ep := range eps {
 			app, err := aliveApplication(st, ep.ApplicationName)
@@ -2036,6 +2048,9 @@ func (st *State) AddRelation(eps ...Endpoint) (r *Relation, err error) {
 					Assert: bson.D{{"life", Alive}},
 					Update: bson.D{{"$inc", bson.D{{"relationcount", 1}}}},
 				})
+				if app.(*RemoteApplication).EncryptRelationData() {
+					keyGeneration = 1
+				}
 			} else {
 				localApp := app.(*Application)
 				if localApp.doc.Subordinate {
@@ -2089,12 +2104,14 @@ func (st *State) AddRelation(eps ...Endpoint) (r *Relation, err error) {
 		}
 		docID := st.docID(key)
 		doc = &relationDoc{
-			DocID:     docID,
-			Key:       key,
-			ModelUUID: st.ModelUUID(),
-			Id:        id,
-			Endpoints: eps,
-			Life:      Alive,
+			DocID:         docID,
+			Key:           key,
+			ModelUUID:     st.ModelUUID(),
+			UUID:          newEntityUUID(),
+			Id:            id,
+			Endpoints:     eps,
+			Life:          Alive,
+			KeyGeneration: keyGeneration,
 		}
 		relationStatusDoc := statusDoc{
 			Status:    status.Joining,
@@ -2263,6 +2280,66 @@ func (st *State) UnitsFor(machineId string) ([]*Unit, error) {
 	return m.Units()
 }
 
+// UnitCount returns the number of units in the model.
+func (st *State) UnitCount() (int, error) {
+	units, closer := st.db().GetCollection(unitsC)
+	defer closer()
+
+	count, err := units.Count()
+	if err != nil {
+		return 0, errors.Annotate(err, "counting units")
+	}
+	return count, nil
+}
+
+// unitQuotaAssertOps returns assert-only transaction operations ensuring
+// that adding numUnits units keeps the model within the controller's
+// max-units-per-model limit. The unit count of every application in the
+// model is asserted, so that a concurrent change to the number of units
+// aborts the transaction and the limit is checked again.
+func (st *State) unitQuotaAssertOps(numUnits int) ([]txn.Op, error) {
+	if numUnits < 1 {
+		return nil, nil
+	}
+	cfg, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	maxUnits := cfg.MaxUnitsPerModel()
+	if maxUnits == 0 {
+		return nil, nil
+	}
+
+	applications, closer := st.db().GetCollection(applicationsC)
+	defer closer()
+
+	var docs []struct {
+		DocID     string `bson:"_id"`
+		UnitCount int    `bson:"unitcount"`
+	}
+	if err := applications.Find(nil).Select(bson.D{{"_id", 1}, {"unitcount", 1}}).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "reading application unit counts")
+	}
+
+	var count int
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		count += doc.UnitCount
+		ops[i] = txn.Op{
+			C:      applicationsC,
+			Id:     doc.DocID,
+			Assert: bson.D{{"unitcount", doc.UnitCount}},
+		}
+	}
+	if count+numUnits > maxUnits {
+		return nil, errors.QuotaLimitExceededf(
+			"adding %d unit(s) to a model with %d units would exceed the controller limit of %d units per model",
+			numUnits, count, maxUnits,
+		)
+	}
+	return ops, nil
+}
+
 // UnitsInError returns the units which have an agent status of Error.
 func (st *State) UnitsInError() ([]*Unit, error) {
 	// First, find the agents in error state.
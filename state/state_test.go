@@ -1930,6 +1930,26 @@ func (s *StateSuite) TestAllApplications(c *gc.C) {
 	c.Assert(names[1], gc.Equals, "wordpress")
 }
 
+func (s *StateSuite) TestUnitCount(c *gc.C) {
+	count, err := s.State.UnitCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+
+	app := s.Factory.MakeApplication(c, nil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+
+	// Units in other models are not counted.
+	otherState := s.Factory.MakeModel(c, nil)
+	defer otherState.Close()
+	f := factory.NewFactory(otherState, s.StatePool)
+	f.MakeUnit(c, nil)
+
+	count, err = s.State.UnitCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 2)
+}
+
 var inferEndpointsTests = []struct {
 	summary string
 	inputs  [][]string
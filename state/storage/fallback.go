@@ -0,0 +1,47 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"io"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/blobstore.v2"
+)
+
+// NewFallbackResourceStorage returns a blobstore.ResourceStorage that
+// stores blob data in primary, but which also reads and removes blob
+// data still held in fallback. It is used while blob data is moved from
+// one backend to another.
+func NewFallbackResourceStorage(primary, fallback blobstore.ResourceStorage) blobstore.ResourceStorage {
+	return &fallbackResourceStorage{primary, fallback}
+}
+
+type fallbackResourceStorage struct {
+	primary  blobstore.ResourceStorage
+	fallback blobstore.ResourceStorage
+}
+
+// Get is defined on the blobstore.ResourceStorage interface.
+func (s *fallbackResourceStorage) Get(path string) (io.ReadCloser, error) {
+	r, err := s.primary.Get(path)
+	if errors.IsNotFound(err) {
+		return s.fallback.Get(path)
+	}
+	return r, err
+}
+
+// Put is defined on the blobstore.ResourceStorage interface.
+func (s *fallbackResourceStorage) Put(path string, r io.Reader, length int64) (string, error) {
+	return s.primary.Put(path, r, length)
+}
+
+// Remove is defined on the blobstore.ResourceStorage interface.
+func (s *fallbackResourceStorage) Remove(path string) error {
+	err := s.primary.Remove(path)
+	if errors.IsNotFound(err) {
+		return s.fallback.Remove(path)
+	}
+	return err
+}
@@ -0,0 +1,89 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/storage"
+	"github.com/juju/juju/testing"
+)
+
+type FallbackStorageSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&FallbackStorageSuite{})
+
+func (s *FallbackStorageSuite) TestGet(c *gc.C) {
+	primary := memResourceStorage{"a": "primary"}
+	fallback := memResourceStorage{"a": "stale", "b": "fallback"}
+	stor := storage.NewFallbackResourceStorage(primary, fallback)
+
+	for path, expect := range map[string]string{"a": "primary", "b": "fallback"} {
+		r, err := stor.Get(path)
+		c.Assert(err, jc.ErrorIsNil)
+		data, err := ioutil.ReadAll(r)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(string(data), gc.Equals, expect)
+	}
+	_, err := stor.Get("c")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *FallbackStorageSuite) TestPut(c *gc.C) {
+	primary := memResourceStorage{}
+	fallback := memResourceStorage{}
+	stor := storage.NewFallbackResourceStorage(primary, fallback)
+
+	_, err := stor.Put("a", strings.NewReader("data"), 4)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(primary, jc.DeepEquals, memResourceStorage{"a": "data"})
+	c.Assert(fallback, gc.HasLen, 0)
+}
+
+func (s *FallbackStorageSuite) TestRemove(c *gc.C) {
+	primary := memResourceStorage{"a": "primary"}
+	fallback := memResourceStorage{"a": "stale", "b": "fallback"}
+	stor := storage.NewFallbackResourceStorage(primary, fallback)
+
+	c.Assert(stor.Remove("a"), jc.ErrorIsNil)
+	c.Assert(stor.Remove("b"), jc.ErrorIsNil)
+	c.Assert(stor.Remove("c"), jc.Satisfies, errors.IsNotFound)
+	c.Assert(primary, gc.HasLen, 0)
+	c.Assert(fallback, jc.DeepEquals, memResourceStorage{"a": "stale"})
+}
+
+type memResourceStorage map[string]string
+
+func (m memResourceStorage) Get(path string) (io.ReadCloser, error) {
+	data, ok := m[path]
+	if !ok {
+		return nil, errors.NotFoundf("resource at path %q", path)
+	}
+	return ioutil.NopCloser(strings.NewReader(data)), nil
+}
+
+func (m memResourceStorage) Put(path string, r io.Reader, length int64) (string, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	m[path] = string(data)
+	return "", nil
+}
+
+func (m memResourceStorage) Remove(path string) error {
+	if _, ok := m[path]; !ok {
+		return errors.NotFoundf("resource at path %q", path)
+	}
+	delete(m, path)
+	return nil
+}
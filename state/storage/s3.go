@@ -0,0 +1,150 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"crypto/md5"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/juju/errors"
+	"gopkg.in/juju/blobstore.v2"
+)
+
+// S3Config holds the details of an S3-compatible object store used to
+// hold blob data.
+type S3Config struct {
+	// Endpoint is the URL of the object store. If it is empty, AWS S3
+	// is used; otherwise requests are made using path-style bucket
+	// addressing, as most S3-compatible stores expect.
+	Endpoint string
+
+	// Region is the region of the object store.
+	Region string
+
+	// Bucket is the name of the bucket in which blobs are stored.
+	Bucket string
+
+	// AccessKey and SecretKey hold the credentials used to access the
+	// bucket. If they are empty, credentials are taken from the
+	// environment, such as an instance profile.
+	AccessKey string
+	SecretKey string
+}
+
+// NewS3ResourceStorage returns a blobstore.ResourceStorage that holds
+// blob data as objects in the configured S3 bucket, keyed by the paths
+// allocated by the blobstore catalog.
+func NewS3ResourceStorage(config S3Config) (blobstore.ResourceStorage, error) {
+	awsConfig := aws.NewConfig().WithRegion(config.Region)
+	if config.AccessKey != "" {
+		awsConfig = awsConfig.WithCredentials(
+			credentials.NewStaticCredentials(config.AccessKey, config.SecretKey, ""),
+		)
+	}
+	if config.Endpoint != "" {
+		awsConfig = awsConfig.WithEndpoint(config.Endpoint).WithS3ForcePathStyle(true)
+	}
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, errors.Annotate(err, "creating S3 session")
+	}
+	return newS3ResourceStorage(s3.New(sess), config.Bucket), nil
+}
+
+func newS3ResourceStorage(client s3iface.S3API, bucket string) blobstore.ResourceStorage {
+	return &s3ResourceStorage{
+		client:   client,
+		uploader: s3manager.NewUploaderWithClient(client),
+		bucket:   bucket,
+	}
+}
+
+type s3ResourceStorage struct {
+	client   s3iface.S3API
+	uploader *s3manager.Uploader
+	bucket   string
+}
+
+// Get is defined on the blobstore.ResourceStorage interface.
+func (s *s3ResourceStorage) Get(path string) (io.ReadCloser, error) {
+	out, err := s.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(path),
+	})
+	if isS3NotFound(err) {
+		return nil, errors.NotFoundf("resource at path %q", path)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get resource %q from bucket %q", path, s.bucket)
+	}
+	return out.Body, nil
+}
+
+// Put is defined on the blobstore.ResourceStorage interface. The
+// returned checksum is the MD5 hash of the data, as for GridFS.
+func (s *s3ResourceStorage) Put(path string, r io.Reader, length int64) (string, error) {
+	hash := md5.New()
+	counter := &countingReader{r: io.TeeReader(io.LimitReader(r, length), hash)}
+	_, err := s.uploader.Upload(&s3manager.UploadInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(path),
+		Body:   counter,
+	})
+	if err != nil {
+		return "", errors.Annotatef(err, "cannot put resource %q in bucket %q", path, s.bucket)
+	}
+	if counter.n != length {
+		_ = s.Remove(path)
+		return "", errors.Errorf("expected %d bytes, got %d", length, counter.n)
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
+// Remove is defined on the blobstore.ResourceStorage interface.
+func (s *s3ResourceStorage) Remove(path string) error {
+	// S3 does not report an error when deleting a missing object, so
+	// check for it first to honour the ResourceStorage contract.
+	_, err := s.client.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(path),
+	})
+	if isS3NotFound(err) {
+		return errors.NotFoundf("resource at path %q", path)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot remove resource %q from bucket %q", path, s.bucket)
+	}
+	_, err = s.client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(path),
+	})
+	return errors.Annotatef(err, "cannot remove resource %q from bucket %q", path, s.bucket)
+}
+
+func isS3NotFound(err error) bool {
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch awsErr.Code() {
+		case s3.ErrCodeNoSuchKey, "NotFound":
+			return true
+		}
+	}
+	return false
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
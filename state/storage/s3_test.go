@@ -0,0 +1,118 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/blobstore.v2"
+
+	"github.com/juju/juju/state/storage"
+	"github.com/juju/juju/testing"
+)
+
+type S3StorageSuite struct {
+	testing.BaseSuite
+	server  *httptest.Server
+	objects map[string]string
+	storage blobstore.ResourceStorage
+}
+
+var _ = gc.Suite(&S3StorageSuite{})
+
+func (s *S3StorageSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.objects = make(map[string]string)
+	s.server = httptest.NewServer(fakeS3Handler(s.objects))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+
+	var err error
+	s.storage, err = storage.NewS3ResourceStorage(storage.S3Config{
+		Endpoint:  s.server.URL,
+		Region:    "us-east-1",
+		Bucket:    "juju",
+		AccessKey: "access",
+		SecretKey: "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *S3StorageSuite) TestPutGet(c *gc.C) {
+	checksum, err := s.storage.Put("abc/def", strings.NewReader("hello world"), 11)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checksum, gc.Equals, "5eb63bbbe01eeed093cb22bb8f5acdc3")
+	c.Assert(s.objects, jc.DeepEquals, map[string]string{"/juju/abc/def": "hello world"})
+
+	r, err := s.storage.Get("abc/def")
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "hello world")
+}
+
+func (s *S3StorageSuite) TestPutShortRead(c *gc.C) {
+	_, err := s.storage.Put("abc", strings.NewReader("hello"), 11)
+	c.Assert(err, gc.ErrorMatches, "expected 11 bytes, got 5")
+	c.Assert(s.objects, gc.HasLen, 0)
+}
+
+func (s *S3StorageSuite) TestGetNotFound(c *gc.C) {
+	_, err := s.storage.Get("missing")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *S3StorageSuite) TestRemove(c *gc.C) {
+	s.objects["/juju/abc"] = "hello"
+	err := s.storage.Remove("abc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.objects, gc.HasLen, 0)
+
+	err = s.storage.Remove("abc")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+// fakeS3Handler returns an http.Handler implementing enough of the S3
+// API, with path-style addressing, to store objects in the given map.
+func fakeS3Handler(objects map[string]string) http.Handler {
+	var mu sync.Mutex
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		key := req.URL.Path
+		switch req.Method {
+		case "PUT":
+			data, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			objects[key] = string(data)
+		case "GET", "HEAD":
+			data, ok := objects[key]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				if req.Method == "GET" {
+					_, _ = w.Write([]byte(`<Error><Code>NoSuchKey</Code></Error>`))
+				}
+				return
+			}
+			if req.Method == "GET" {
+				_, _ = w.Write([]byte(data))
+			}
+		case "DELETE":
+			delete(objects, key)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+}
@@ -6,6 +6,7 @@ package storage
 import (
 	"io"
 
+	"github.com/juju/errors"
 	"gopkg.in/juju/blobstore.v2"
 	"gopkg.in/mgo.v2"
 )
@@ -41,25 +42,51 @@ type Storage interface {
 	Remove(path string) error
 }
 
-// NewStorage returns a Storage for the model with the specified UUID.
+// ResourceStorageFunc returns the blobstore.ResourceStorage holding the
+// blob data catalogued in the given session's metadata database.
+type ResourceStorageFunc func(session *mgo.Session) (blobstore.ResourceStorage, error)
+
+// GridFSResourceStorage is a ResourceStorageFunc that holds blob data in
+// the controller's "blobstore" GridFS.
+func GridFSResourceStorage(session *mgo.Session) (blobstore.ResourceStorage, error) {
+	return blobstore.NewGridFS(blobstoreDB, blobstoreDB, session), nil
+}
+
+// NewStorage returns a Storage for the model with the specified UUID,
+// holding blob data in GridFS.
 func NewStorage(modelUUID string, session *mgo.Session) Storage {
-	return stateStorage{modelUUID, session}
+	return NewStorageWithResourceStorage(modelUUID, session, GridFSResourceStorage)
+}
+
+// NewStorageWithResourceStorage returns a Storage for the model with the
+// specified UUID, holding blob data in the resource storage returned by
+// newResourceStorage.
+func NewStorageWithResourceStorage(modelUUID string, session *mgo.Session, newResourceStorage ResourceStorageFunc) Storage {
+	return stateStorage{modelUUID, session, newResourceStorage}
 }
 
 type stateStorage struct {
-	modelUUID string
-	session   *mgo.Session
+	modelUUID          string
+	session            *mgo.Session
+	newResourceStorage ResourceStorageFunc
 }
 
-func (s stateStorage) blobstore() (*mgo.Session, blobstore.ManagedStorage) {
+func (s stateStorage) blobstore() (*mgo.Session, blobstore.ManagedStorage, error) {
 	session := s.session.Copy()
-	rs := blobstore.NewGridFS(blobstoreDB, blobstoreDB, session)
+	rs, err := s.newResourceStorage(session)
+	if err != nil {
+		session.Close()
+		return nil, nil, errors.Trace(err)
+	}
 	db := session.DB(metadataDB)
-	return session, blobstore.NewManagedStorage(db, rs)
+	return session, blobstore.NewManagedStorage(db, rs), nil
 }
 
 func (s stateStorage) Get(path string) (r io.ReadCloser, length int64, err error) {
-	session, ms := s.blobstore()
+	session, ms, err := s.blobstore()
+	if err != nil {
+		return nil, -1, err
+	}
 	r, length, err = ms.GetForBucket(s.modelUUID, path)
 	if err != nil {
 		session.Close()
@@ -69,19 +96,28 @@ func (s stateStorage) Get(path string) (r io.ReadCloser, length int64, err error
 }
 
 func (s stateStorage) Put(path string, r io.Reader, length int64) error {
-	session, ms := s.blobstore()
+	session, ms, err := s.blobstore()
+	if err != nil {
+		return err
+	}
 	defer session.Close()
 	return ms.PutForBucket(s.modelUUID, path, r, length)
 }
 
 func (s stateStorage) PutAndCheckHash(path string, r io.Reader, length int64, hash string) error {
-	session, ms := s.blobstore()
+	session, ms, err := s.blobstore()
+	if err != nil {
+		return err
+	}
 	defer session.Close()
 	return ms.PutForBucketAndCheckHash(s.modelUUID, path, r, length, hash)
 }
 
 func (s stateStorage) Remove(path string) error {
-	session, ms := s.blobstore()
+	session, ms, err := s.blobstore()
+	if err != nil {
+		return err
+	}
 	defer session.Close()
 	return ms.RemoveForBucket(s.modelUUID, path)
 }
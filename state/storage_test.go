@@ -619,6 +619,51 @@ func (s *StorageStateSuite) TestProviderFallbackToType(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *StorageStateSuite) TestUpdateApplicationStorageConstraints(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	app := s.AddTestingApplicationWithStorage(c, "storage-block", ch, map[string]state.StorageConstraints{
+		"data": makeStorageCons("loop", 1024, 1),
+	})
+
+	err := app.UpdateStorageConstraints(map[string]state.StorageConstraints{
+		"data": makeStorageCons("loop-pool", 2048, 0),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	cons, err := app.StorageConstraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons, jc.DeepEquals, map[string]state.StorageConstraints{
+		"data":    makeStorageCons("loop-pool", 2048, 1),
+		"allecto": makeStorageCons("loop", 1024, 0),
+	})
+
+	// Units added afterwards use the updated constraints.
+	u, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	attachments, err := s.storageBackend.UnitStorageAttachments(u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 1)
+	volume := s.storageInstanceVolume(c, attachments[0].StorageInstance())
+	params, ok := volume.Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(params.Pool, gc.Equals, "loop-pool")
+	c.Assert(params.Size, gc.Equals, uint64(2048))
+}
+
+func (s *StorageStateSuite) TestUpdateApplicationStorageConstraintsValidation(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	app := s.AddTestingApplication(c, "storage-block", ch)
+
+	err := app.UpdateStorageConstraints(map[string]state.StorageConstraints{
+		"nope": makeStorageCons("loop", 1024, 1),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot update storage constraints for application "storage-block": validating storage constraints: charm "storage-block" has no store called "nope"`)
+
+	err = app.UpdateStorageConstraints(map[string]state.StorageConstraints{
+		"data": makeStorageCons("ebs-fast", 0, 0),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot update storage constraints for application "storage-block": validating storage constraints: pool "ebs-fast" not found`)
+}
+
 func (s *StorageStateSuite) TestAddUnit(c *gc.C) {
 	s.assertStorageUnitsAdded(c)
 }
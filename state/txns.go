@@ -12,6 +12,8 @@ import (
 	jujutxn "github.com/juju/txn"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/controller"
 )
 
 func readTxnRevno(db Database, collectionName string, id interface{}) (int64, error) {
@@ -44,17 +46,50 @@ func (st *State) MaybePruneTransactions() error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	sizeMB, err := st.txnsSizeMB()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return runner.MaybePruneTransactions(pruneOptions(cfg, sizeMB, time.Now()))
+}
+
+// txnsSizeMB returns the size in MiB of the txns collection.
+func (st *State) txnsSizeMB() (int, error) {
+	coll, closer := st.db().GetRawCollection(txnsC)
+	defer closer()
+	stats, err := collStats(coll)
+	if errors.IsNotFound(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return dbCollectionSizeToInt(stats, txnsC)
+}
+
+// pruneOptions returns the options for pruning transactions,
+// given the controller config and the size in MiB of the txns
+// collection.
+func pruneOptions(cfg controller.Config, txnsSizeMB int, now time.Time) jujutxn.PruneOptions {
 	// Prune txns when txn count has increased by 10% since last prune.
-	return runner.MaybePruneTransactions(jujutxn.PruneOptions{
+	opts := jujutxn.PruneOptions{
 		PruneFactor:                1.1,
 		MinNewTransactions:         1000,
 		MaxNewTransactions:         100000,
-		MaxTime:                    time.Now().Add(-time.Hour),
+		MaxTime:                    now.Add(-time.Hour),
 		MaxBatchTransactions:       cfg.MaxPruneTxnBatchSize(),
 		MaxBatches:                 cfg.MaxPruneTxnPasses(),
 		SmallBatchTransactionCount: cfg.PruneTxnQueryCount(),
 		BatchTransactionSleepTime:  cfg.PruneTxnSleepTime(),
-	})
+	}
+	// A burst of activity can grow the collection a lot while the
+	// count of transactions stays within the growth thresholds. Once
+	// it is too big, prune whenever there are any new transactions.
+	if maxSizeMB := cfg.PruneTxnMaxSizeMB(); maxSizeMB > 0 && txnsSizeMB >= maxSizeMB {
+		logger.Infof("txns collection is %dMiB, at least %dMiB, pruning regardless of growth", txnsSizeMB, maxSizeMB)
+		opts.PruneFactor = 1
+		opts.MinNewTransactions = 1
+	}
+	return opts
 }
 
 type multiModelRunner struct {
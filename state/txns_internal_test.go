@@ -0,0 +1,57 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	coretesting "github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&pruneOptionsSuite{})
+
+type pruneOptionsSuite struct {
+	testing.IsolationSuite
+}
+
+func (s *pruneOptionsSuite) config(c *gc.C, attrs map[string]interface{}) controller.Config {
+	cfg, err := controller.NewConfig(coretesting.ControllerTag.Id(), coretesting.CACert, attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	return cfg
+}
+
+func (s *pruneOptionsSuite) TestGrowthThresholds(c *gc.C) {
+	now := time.Now()
+	opts := pruneOptions(s.config(c, map[string]interface{}{
+		"prune-txn-query-count": 500,
+		"prune-txn-sleep-time":  "5ms",
+	}), 10, now)
+	c.Check(opts.PruneFactor, gc.Equals, float32(1.1))
+	c.Check(opts.MinNewTransactions, gc.Equals, 1000)
+	c.Check(opts.MaxNewTransactions, gc.Equals, 100000)
+	c.Check(opts.MaxTime, gc.Equals, now.Add(-time.Hour))
+	c.Check(opts.SmallBatchTransactionCount, gc.Equals, 500)
+	c.Check(opts.BatchTransactionSleepTime, gc.Equals, 5*time.Millisecond)
+}
+
+func (s *pruneOptionsSuite) TestOverMaxSize(c *gc.C) {
+	opts := pruneOptions(s.config(c, map[string]interface{}{
+		"prune-txn-max-size": "100M",
+	}), 100, time.Now())
+	c.Check(opts.PruneFactor, gc.Equals, float32(1))
+	c.Check(opts.MinNewTransactions, gc.Equals, 1)
+}
+
+func (s *pruneOptionsSuite) TestMaxSizeDisabled(c *gc.C) {
+	opts := pruneOptions(s.config(c, map[string]interface{}{
+		"prune-txn-max-size": "0",
+	}), 100000, time.Now())
+	c.Check(opts.PruneFactor, gc.Equals, float32(1.1))
+	c.Check(opts.MinNewTransactions, gc.Equals, 1000)
+}
@@ -82,6 +82,7 @@ type unitDoc struct {
 	DocID                  string `bson:"_id"`
 	Name                   string `bson:"name"`
 	ModelUUID              string `bson:"model-uuid"`
+	UUID                   string `bson:"uuid,omitempty"`
 	Application            string
 	Series                 string
 	CharmURL               *charm.URL
@@ -90,7 +91,8 @@ type unitDoc struct {
 	StorageAttachmentCount int `bson:"storageattachmentcount"`
 	MachineId              string
 	Resolved               ResolvedMode
-	Tools                  *tools.Tools `bson:",omitempty"`
+	Drain                  UnitDrainStatus `bson:"drain,omitempty"`
+	Tools                  *tools.Tools    `bson:",omitempty"`
 	Life                   Life
 	TxnRevno               int64 `bson:"txn-revno"`
 	PasswordHash           string
@@ -191,6 +193,11 @@ func (u *Unit) Name() string {
 	return u.doc.Name
 }
 
+// UUID returns the unit's UUID, which never changes.
+func (u *Unit) UUID() string {
+	return u.doc.UUID
+}
+
 // unitGlobalKey returns the global database key for the named unit.
 func unitGlobalKey(name string) string {
 	return "u#" + name + "#charm"
@@ -247,9 +254,31 @@ func (u *Unit) WorkloadVersion() (string, error) {
 	return unitStatus.Message, nil
 }
 
+// WorkloadVersionInfo returns the version of the running workload, as
+// the status message, along with the time that version was first set.
+func (u *Unit) WorkloadVersionInfo() (status.StatusInfo, error) {
+	info, err := getStatus(u.st.db(), u.globalWorkloadVersionKey(), "workload")
+	if errors.IsNotFound(err) {
+		return status.StatusInfo{}, nil
+	} else if err != nil {
+		return status.StatusInfo{}, errors.Trace(err)
+	}
+	return info, nil
+}
+
 // SetWorkloadVersion sets the version of the workload that the unit
 // is currently running.
 func (u *Unit) SetWorkloadVersion(version string) error {
+	// Charms commonly report their workload version on every hook;
+	// only record changes, so that the workload version history holds
+	// the time each version was first reported.
+	current, err := u.WorkloadVersion()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if current == version {
+		return nil
+	}
 	// Store in status rather than an attribute of the unit doc - we
 	// want to avoid everything being an attr of the main docs to
 	// stop a swarm of watchers being notified for irrelevant changes.
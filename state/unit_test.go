@@ -2443,6 +2443,38 @@ func (s *UnitSuite) TestWatchUnit(c *gc.C) {
 	testing.NewNotifyWatcherC(c, s.State, w).AssertOneChange()
 }
 
+func (s *UnitSuite) TestWatchStatus(c *gc.C) {
+	s.WaitForModelWatchersIdle(c, s.Model.UUID())
+	w := s.unit.WatchStatus()
+	defer testing.AssertStop(c, w)
+
+	// Initial event.
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	now := coretesting.ZeroTime()
+	err := s.unit.SetAgentStatus(status.StatusInfo{
+		Status:  status.Executing,
+		Message: "running install hook",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.unit.SetStatus(status.StatusInfo{
+		Status:  status.Active,
+		Message: "ready",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Changing the unit document itself is not reported.
+	err = s.unit.SetPassword("arble-farble-dying-yarble")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
 func (s *UnitSuite) TestUnitAgentTools(c *gc.C) {
 	preventUnitDestroyRemove(c, s.unit)
 	testAgentTools(c, s.unit, `unit "wordpress/0"`)
@@ -2606,6 +2638,46 @@ func (s *UnitSuite) TestWorkloadVersion(c *gc.C) {
 	c.Check(version, gc.Equals, "3.combined")
 }
 
+func (s *UnitSuite) TestWorkloadVersionHistoryRecordsChanges(c *gc.C) {
+	ch := state.AddTestingCharm(c, s.State, "dummy")
+	app := state.AddTestingApplication(c, s.State, "alexandrite", ch)
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	info, err := unit.WorkloadVersionInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.Message, gc.Equals, "")
+
+	err = unit.SetWorkloadVersion("1.0")
+	c.Assert(err, jc.ErrorIsNil)
+	first := s.Clock.Now()
+	s.Clock.Advance(time.Minute)
+
+	// Setting the same version again doesn't change when it was
+	// first reported.
+	err = unit.SetWorkloadVersion("1.0")
+	c.Assert(err, jc.ErrorIsNil)
+	info, err = unit.WorkloadVersionInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.Message, gc.Equals, "1.0")
+	c.Check(info.Since.UnixNano(), gc.Equals, first.UnixNano())
+
+	s.Clock.Advance(time.Minute)
+	err = unit.SetWorkloadVersion("1.1")
+	c.Assert(err, jc.ErrorIsNil)
+	info, err = unit.WorkloadVersionInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.Message, gc.Equals, "1.1")
+	c.Check(info.Since.UnixNano(), gc.Equals, s.Clock.Now().UnixNano())
+
+	history, err := unit.WorkloadVersionHistory().StatusHistory(status.StatusHistoryFilter{Size: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Check(history[0].Message, gc.Equals, "1.1")
+	c.Check(history[1].Message, gc.Equals, "1.0")
+	c.Check(history[1].Since.UnixNano(), gc.Equals, first.UnixNano())
+}
+
 func (s *UnitSuite) TestDestroyWithForceWorksOnDyingUnit(c *gc.C) {
 	// Ensure that a cleanup is scheduled if we force destroy a unit
 	// that's already dying.
@@ -2723,6 +2795,12 @@ func (s *UnitSuite) TestWatchMachineAndEndpointAddressesHash(c *gc.C) {
 	err = app.SetCharm(cfg)
 	c.Assert(err, jc.ErrorIsNil)
 	wc.AssertChange("c895e8b57123efd2194d48b74db431e4db4c3ae4fa75f55aa6f32c7f39f29abd")
+
+	// Recording a link-layer change for the machine should trigger a change
+	// even though the machine addresses are unaffected.
+	state.RecordMachineNetworkChange(c, m1)
+	wc.AssertChanges()
+	wc.AssertNoChange()
 }
 
 func unitMachine(c *gc.C, st *state.State, u *state.Unit) *state.Machine {
@@ -0,0 +1,97 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// UnitDrainStatus describes the progress of draining a unit ahead of
+// its removal.
+type UnitDrainStatus string
+
+const (
+	// UnitDrainNone means the unit has not been asked to drain.
+	UnitDrainNone UnitDrainStatus = ""
+
+	// UnitDrainRequested means the unit has been asked to drain, but
+	// its charm has not yet completed the pre-remove hook.
+	UnitDrainRequested UnitDrainStatus = "requested"
+
+	// UnitDrainCompleted means the unit's charm has completed the
+	// pre-remove hook, and the unit is ready to be removed.
+	UnitDrainCompleted UnitDrainStatus = "completed"
+)
+
+// DrainStatus returns the progress of draining the unit.
+func (u *Unit) DrainStatus() UnitDrainStatus {
+	return u.doc.Drain
+}
+
+// RequestDrain asks the unit to drain ahead of its removal, by running
+// the charm's pre-remove hook. Requesting a drain for a unit that has
+// already been asked to drain has no effect.
+func (u *Unit) RequestDrain() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot request drain of unit %q", u)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if u.doc.Life != Alive {
+			return nil, errors.New("unit is not alive")
+		}
+		if u.doc.Drain != UnitDrainNone {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: append(isAliveDoc, bson.DocElem{"drain", bson.D{{"$exists", false}}}),
+			Update: bson.D{{"$set", bson.D{{"drain", UnitDrainRequested}}}},
+		}}, nil
+	}
+	if err := u.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	if u.doc.Drain == UnitDrainNone {
+		u.doc.Drain = UnitDrainRequested
+	}
+	return nil
+}
+
+// SetDrained records that the unit's charm has completed the pre-remove
+// hook, and that the unit is ready to be removed. It is an error to call
+// SetDrained if the unit has not been asked to drain.
+func (u *Unit) SetDrained() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set unit %q drained", u)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		switch u.doc.Drain {
+		case UnitDrainCompleted:
+			return nil, jujutxn.ErrNoOperations
+		case UnitDrainNone:
+			return nil, errors.NotValidf("drain not requested")
+		}
+		return []txn.Op{{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: bson.D{{"drain", UnitDrainRequested}},
+			Update: bson.D{{"$set", bson.D{{"drain", UnitDrainCompleted}}}},
+		}}, nil
+	}
+	if err := u.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	u.doc.Drain = UnitDrainCompleted
+	return nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type UnitDrainSuite struct {
+	ConnSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&UnitDrainSuite{})
+
+func (s *UnitDrainSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	app := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.unit = unit
+}
+
+func (s *UnitDrainSuite) TestDrain(c *gc.C) {
+	c.Assert(s.unit.DrainStatus(), gc.Equals, state.UnitDrainNone)
+
+	err := s.unit.RequestDrain()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.DrainStatus(), gc.Equals, state.UnitDrainRequested)
+
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.DrainStatus(), gc.Equals, state.UnitDrainRequested)
+
+	err = s.unit.SetDrained()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.DrainStatus(), gc.Equals, state.UnitDrainCompleted)
+
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.DrainStatus(), gc.Equals, state.UnitDrainCompleted)
+}
+
+func (s *UnitDrainSuite) TestRequestDrainIdempotent(c *gc.C) {
+	err := s.unit.RequestDrain()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetDrained()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Asking a drained unit to drain again does not restart the drain.
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.RequestDrain()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.DrainStatus(), gc.Equals, state.UnitDrainCompleted)
+}
+
+func (s *UnitDrainSuite) TestRequestDrainDeadUnit(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.RequestDrain()
+	c.Assert(err, gc.ErrorMatches, `cannot request drain of unit "mysql/0": unit is not alive`)
+}
+
+func (s *UnitDrainSuite) TestSetDrainedNotRequested(c *gc.C) {
+	err := s.unit.SetDrained()
+	c.Assert(err, gc.ErrorMatches, `cannot set unit "mysql/0" drained: drain not requested not valid`)
+}
@@ -0,0 +1,78 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/version"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// upgradeStepCheckpointDoc records that a database upgrade step has
+// completed, so that it isn't run again if the upgrade is interrupted
+// and restarted.
+type upgradeStepCheckpointDoc struct {
+	// Id is a hash of the step's target version and description.
+	Id            string    `bson:"_id"`
+	TargetVersion string    `bson:"target-version"`
+	Description   string    `bson:"description"`
+	Completed     time.Time `bson:"completed"`
+}
+
+// upgradeStepCheckpointId returns the id of the checkpoint document
+// for the upgrade step with the given target version and description.
+func upgradeStepCheckpointId(targetVersion version.Number, description string) string {
+	hash := sha256.Sum256([]byte(targetVersion.String() + "\n" + description))
+	return fmt.Sprintf("%x", hash)
+}
+
+// UpgradeStepCompleted reports whether the upgrade step with the given
+// target version and description has been recorded as completed.
+func (st *State) UpgradeStepCompleted(targetVersion version.Number, description string) (bool, error) {
+	checkpoints, closer := st.db().GetCollection(upgradeStepCheckpointsC)
+	defer closer()
+	id := upgradeStepCheckpointId(targetVersion, description)
+	var doc upgradeStepCheckpointDoc
+	if err := checkpoints.FindId(id).One(&doc); err == mgo.ErrNotFound {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Annotatef(err, "cannot read checkpoint for upgrade step %q", description)
+	}
+	return true, nil
+}
+
+// SetUpgradeStepCompleted records that the upgrade step with the given
+// target version and description has completed. Recording a step that
+// is already completed is not an error.
+func (st *State) SetUpgradeStepCompleted(targetVersion version.Number, description string) error {
+	buildTxn := func(int) ([]txn.Op, error) {
+		completed, err := st.UpgradeStepCompleted(targetVersion, description)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if completed {
+			return nil, jujutxn.ErrNoOperations
+		}
+		id := upgradeStepCheckpointId(targetVersion, description)
+		return []txn.Op{{
+			C:      upgradeStepCheckpointsC,
+			Id:     id,
+			Assert: txn.DocMissing,
+			Insert: &upgradeStepCheckpointDoc{
+				Id:            id,
+				TargetVersion: targetVersion.String(),
+				Description:   description,
+				Completed:     st.clock().Now().UTC(),
+			},
+		}}, nil
+	}
+	err := st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot record upgrade step %q as completed", description)
+}
@@ -0,0 +1,41 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type UpgradeCheckpointsSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&UpgradeCheckpointsSuite{})
+
+func (s *UpgradeCheckpointsSuite) assertCompleted(c *gc.C, targetVersion, description string, expect bool) {
+	completed, err := s.State.UpgradeStepCompleted(vers(targetVersion), description)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(completed, gc.Equals, expect)
+}
+
+func (s *UpgradeCheckpointsSuite) TestSetUpgradeStepCompleted(c *gc.C) {
+	s.assertCompleted(c, "2.7.0", "add controller node docs", false)
+
+	err := s.State.SetUpgradeStepCompleted(vers("2.7.0"), "add controller node docs")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCompleted(c, "2.7.0", "add controller node docs", true)
+
+	// Checkpoints are specific to the step's version and description.
+	s.assertCompleted(c, "2.8.0", "add controller node docs", false)
+	s.assertCompleted(c, "2.7.0", "recreate spaces with IDs", false)
+}
+
+func (s *UpgradeCheckpointsSuite) TestSetUpgradeStepCompletedTwice(c *gc.C) {
+	err := s.State.SetUpgradeStepCompleted(vers("2.7.0"), "add controller node docs")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetUpgradeStepCompleted(vers("2.7.0"), "add controller node docs")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCompleted(c, "2.7.0", "add controller node docs", true)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/os/v2/series"
 	"github.com/juju/replicaset"
 	"github.com/kr/pretty"
+	"gopkg.in/juju/blobstore.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
@@ -470,7 +471,7 @@ func updateLegacyLXDCredentialsOps(st *State, cred cloud.Credential) ([]txn.Op,
 		if c.Type != "lxd" {
 			continue
 		}
-		op := updateCloudCredentialOp(cloudCredentialTag, cred)
+		op := updateCloudCredentialOp(cloudCredentialTag, cred, cred.Attributes())
 		upgradesLogger.Infof("updating credential %q: %v", cloudCredentialTag, op)
 		ops = append(ops, op)
 	}
@@ -3350,3 +3351,93 @@ func RemoveUnusedLinkLayerDeviceProviderIDs(pool *StatePool) error {
 	logger.Infof("deleted %d unused link-layer device provider IDs", before-after)
 	return nil
 }
+
+// MoveBlobsToS3 moves blob data held in GridFS to the controller's S3
+// blobstore, if blob data is configured to be held in S3. Blob data
+// is removed from GridFS once it has been copied, so the step may be
+// safely re-run.
+func MoveBlobsToS3(pool *StatePool) error {
+	st := pool.SystemState()
+	cfg, err := st.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if cfg.BlobstoreBackend() != controller.BlobstoreBackendS3 {
+		return nil
+	}
+	s3, err := st.s3Storage.get(s3ConfigForController(cfg))
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	session := st.session.Copy()
+	defer session.Close()
+	gridFS := blobstore.NewGridFS(blobstoreDB, blobstoreDB, session)
+
+	// The blobstore catalog records the path and length of each
+	// stored resource; resources still being uploaded have no path.
+	var doc struct {
+		Path   string `bson:"path"`
+		Length int64  `bson:"length"`
+	}
+	var moved int
+	iter := session.DB(jujuDB).C("storedResources").Find(
+		bson.M{"path": bson.M{"$nin": []interface{}{"", nil}}},
+	).Select(bson.M{"path": 1, "length": 1}).Iter()
+	for iter.Next(&doc) {
+		r, err := gridFS.Get(doc.Path)
+		if errors.IsNotFound(err) {
+			// Already moved.
+			continue
+		} else if err != nil {
+			_ = iter.Close()
+			return errors.Annotatef(err, "reading blob %q", doc.Path)
+		}
+		_, err = s3.Put(doc.Path, r, doc.Length)
+		_ = r.Close()
+		if err != nil {
+			_ = iter.Close()
+			return errors.Annotatef(err, "moving blob %q", doc.Path)
+		}
+		if err := gridFS.Remove(doc.Path); err != nil && !errors.IsNotFound(err) {
+			_ = iter.Close()
+			return errors.Annotatef(err, "removing blob %q from GridFS", doc.Path)
+		}
+		moved++
+	}
+	if err := iter.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	upgradesLogger.Infof("moved %d blobs to S3 bucket %q", moved, cfg.BlobstoreS3Bucket())
+	return nil
+}
+
+// AddUUIDsToEntities gives a UUID to each application, unit, machine,
+// relation and space that does not yet have one.
+func AddUUIDsToEntities(pool *StatePool) error {
+	return errors.Trace(runForAllModelStates(pool, func(st *State) error {
+		noUUID := bson.D{{"uuid", bson.D{{"$exists", false}}}}
+		var ops []txn.Op
+		for _, collName := range []string{applicationsC, unitsC, machinesC, relationsC, spacesC} {
+			coll, closer := st.db().GetCollection(collName)
+			var docs []bson.M
+			err := coll.Find(noUUID).Select(bson.M{"_id": 1}).All(&docs)
+			closer()
+			if err != nil {
+				return errors.Annotatef(err, "reading %s", collName)
+			}
+			for _, doc := range docs {
+				ops = append(ops, txn.Op{
+					C:      collName,
+					Id:     doc["_id"],
+					Assert: noUUID,
+					Update: bson.D{{"$set", bson.D{{"uuid", newEntityUUID()}}}},
+				})
+			}
+		}
+		if len(ops) > 0 {
+			return errors.Trace(st.db().RunTransaction(ops))
+		}
+		return nil
+	}))
+}
@@ -5,7 +5,12 @@ package state
 
 import (
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/charm/v9"
@@ -33,6 +38,7 @@ import (
 	"github.com/juju/juju/environs/config"
 	mongoutils "github.com/juju/juju/mongo/utils"
 	"github.com/juju/juju/state/cloudimagemetadata"
+	"github.com/juju/juju/state/storage"
 	"github.com/juju/juju/storage/provider"
 	"github.com/juju/juju/testcharms"
 	coretesting "github.com/juju/juju/testing"
@@ -4940,8 +4946,125 @@ func (s *upgradesSuite) TestRemoveUnusedLinkLayerDeviceProviderIDs(c *gc.C) {
 	}))
 }
 
+func (s *upgradesSuite) TestMoveBlobsToS3(c *gc.C) {
+	stor := storage.NewStorage(s.state.ModelUUID(), s.state.MongoSession())
+	err := stor.Put("charms/foo", strings.NewReader("charm data"), 10)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var mu sync.Mutex
+	objects := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if req.Method != "PUT" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		data, err := ioutil.ReadAll(req.Body)
+		c.Check(err, jc.ErrorIsNil)
+		objects[req.URL.Path] = string(data)
+	}))
+	defer server.Close()
+
+	err = s.state.UpdateControllerConfig(map[string]interface{}{
+		controller.BlobstoreBackend:     "s3",
+		controller.BlobstoreS3Endpoint:  server.URL,
+		controller.BlobstoreS3Bucket:    "juju",
+		controller.BlobstoreS3AccessKey: "access",
+		controller.BlobstoreS3SecretKey: "secret",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = MoveBlobsToS3(s.pool)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(objects, gc.HasLen, 1)
+	for path, data := range objects {
+		c.Check(path, gc.Matches, "/juju/.+")
+		c.Check(data, gc.Equals, "charm data")
+	}
+	files, err := s.state.MongoSession().DB(blobstoreDB).GridFS(blobstoreDB).Find(nil).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(files, gc.Equals, 0)
+
+	// Running the step again does nothing.
+	err = MoveBlobsToS3(s.pool)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(objects, gc.HasLen, 1)
+}
+
+func (s *upgradesSuite) TestMoveBlobsToS3NotConfigured(c *gc.C) {
+	stor := storage.NewStorage(s.state.ModelUUID(), s.state.MongoSession())
+	err := stor.Put("charms/foo", strings.NewReader("charm data"), 10)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = MoveBlobsToS3(s.pool)
+	c.Assert(err, jc.ErrorIsNil)
+
+	r, _, err := stor.Get("charms/foo")
+	c.Assert(err, jc.ErrorIsNil)
+	_ = r.Close()
+}
+
 type docById []bson.M
 
 func (d docById) Len() int           { return len(d) }
 func (d docById) Swap(i, j int)      { d[i], d[j] = d[j], d[i] }
 func (d docById) Less(i, j int) bool { return d[i]["_id"].(string) < d[j]["_id"].(string) }
+
+func (s *upgradesSuite) TestAddUUIDsToEntities(c *gc.C) {
+	model1 := s.makeModel(c, "model-1", coretesting.Attrs{})
+	model2 := s.makeModel(c, "model-2", coretesting.Attrs{})
+	defer func() {
+		_ = model1.Close()
+		_ = model2.Close()
+	}()
+	uuid1 := model1.ModelUUID()
+	uuid2 := model2.ModelUUID()
+
+	apps, closer := s.state.db().GetRawCollection(applicationsC)
+	defer closer()
+	units, closer := s.state.db().GetRawCollection(unitsC)
+	defer closer()
+
+	err := apps.Insert(
+		bson.M{"_id": ensureModelUUID(uuid1, "app1"), "model-uuid": uuid1},
+		bson.M{"_id": ensureModelUUID(uuid2, "app2"), "model-uuid": uuid2, "uuid": "existing-uuid"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	err = units.Insert(
+		bson.M{"_id": ensureModelUUID(uuid1, "app1/0"), "model-uuid": uuid1},
+		bson.M{"_id": ensureModelUUID(uuid2, "app2/0"), "model-uuid": uuid2},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	readUUIDs := func() map[interface{}]string {
+		result := make(map[interface{}]string)
+		for _, coll := range []*mgo.Collection{apps, units} {
+			var docs []bson.M
+			err := coll.Find(nil).All(&docs)
+			c.Assert(err, jc.ErrorIsNil)
+			for _, doc := range docs {
+				uuid, _ := doc["uuid"].(string)
+				result[doc["_id"]] = uuid
+			}
+		}
+		return result
+	}
+
+	err = AddUUIDsToEntities(s.pool)
+	c.Assert(err, jc.ErrorIsNil)
+	uuids := readUUIDs()
+	c.Assert(uuids, gc.HasLen, 4)
+	c.Check(uuids[ensureModelUUID(uuid2, "app2")], gc.Equals, "existing-uuid")
+	seen := set.NewStrings()
+	for id, uuid := range uuids {
+		c.Check(uuid, gc.Not(gc.Equals), "", gc.Commentf("%v", id))
+		c.Check(seen.Contains(uuid), jc.IsFalse, gc.Commentf("%v", id))
+		seen.Add(uuid)
+	}
+
+	// Running the step again leaves the UUIDs alone.
+	err = AddUUIDsToEntities(s.pool)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(readUUIDs(), jc.DeepEquals, uuids)
+}
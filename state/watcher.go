@@ -1941,6 +1941,29 @@ func (m *Model) Watch() NotifyWatcher {
 	return newEntityWatcher(m.st, modelsC, m.doc.UUID)
 }
 
+// WatchAnnotations returns a StringsWatcher that notifies of changes to
+// the annotations of any entity in the model. The watcher reports the
+// tags of the entities whose annotations have changed; the initial event
+// holds the tags of all entities that have annotations.
+func (m *Model) WatchAnnotations() StringsWatcher {
+	modelUUID := m.UUID()
+	return newCollectionWatcher(m.st, colWCfg{
+		col: annotationsC,
+		filter: func(id interface{}) bool {
+			key, err := m.st.strictLocalID(id.(string))
+			if err != nil {
+				return false
+			}
+			_, ok := annotationTagForGlobalKey(modelUUID, key)
+			return ok
+		},
+		idconv: func(key string) string {
+			tag, _ := annotationTagForGlobalKey(modelUUID, key)
+			return tag.String()
+		},
+	})
+}
+
 // WatchUpgradeInfo returns a watcher for observing changes to upgrade
 // synchronisation state.
 func (st *State) WatchUpgradeInfo() NotifyWatcher {
@@ -2012,6 +2035,13 @@ func (a *Application) WatchCharmConfig() (NotifyWatcher, error) {
 	return newEntityWatcher(a.st, settingsC, a.st.docID(configKey)), nil
 }
 
+// WatchApplicationConfig returns a watcher for observing changes to the
+// application's configuration settings, as opposed to its charm
+// configuration settings.
+func (a *Application) WatchApplicationConfig() NotifyWatcher {
+	return newEntityWatcher(a.st, settingsC, a.st.docID(a.applicationConfigKey()))
+}
+
 // WatchConfigSettings returns a watcher for observing changes to the
 // unit's application configuration settings. The unit must have a charm URL
 // set before this method is called, and the returned watcher will be
@@ -2068,6 +2098,20 @@ func (u *Unit) WatchMeterStatus() NotifyWatcher {
 	})
 }
 
+// WatchStatus returns a watcher observing changes to the unit's agent
+// and workload status.
+func (u *Unit) WatchStatus() NotifyWatcher {
+	return newDocWatcher(u.st, []docKey{
+		{
+			statusesC,
+			u.st.docID(u.globalAgentKey()),
+		}, {
+			statusesC,
+			u.st.docID(u.globalKey()),
+		},
+	})
+}
+
 // WatchLXDProfileUpgradeNotifications returns a watcher that observes the status
 // of a lxd profile upgrade by monitoring changes on the unit machine's lxd profile
 // upgrade completed field that is specific to an application name.  Used by
@@ -4389,6 +4433,13 @@ func hashMachineAddressesForEndpointBindings(m *Machine, bindingsToSpaceIDs map[
 		hashAddr(hash, address)
 	}
 
+	// Link-layer changes merged from the provider do not necessarily change
+	// the addresses above, but can still change the network info for the
+	// unit's endpoints.
+	if m.doc.NetworkRevision > 0 {
+		_, _ = hash.Write([]byte(fmt.Sprintf("network-revision:%d", m.doc.NetworkRevision)))
+	}
+
 	// Also include binding assignments to the hash. We don't care about
 	// address assignments at this point; if the machine addresses change
 	// (e.g. due to a reboot), the above code block would yield a different
@@ -0,0 +1,114 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package watcher
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// changeStreamMaxAwait is how long a read of a change stream waits for
+// new events before returning, so that the reader can notice when it
+// should stop.
+const changeStreamMaxAwait = time.Second
+
+// These are variables so that tests can stand in for a server that
+// provides change streams.
+var (
+	supportsChangeStreams = serverSupportsChangeStreams
+	watchChangeStream     = readChangeStream
+)
+
+// serverSupportsChangeStreams reports whether the server the session is
+// connected to can provide change streams. They need MongoDB 3.6 or
+// later, running as a replica set.
+func serverSupportsChangeStreams(session *mgo.Session) (bool, error) {
+	info, err := session.BuildInfo()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if !info.VersionAtLeast(3, 6) {
+		return false, nil
+	}
+	var result struct {
+		SetName string `bson:"setName"`
+	}
+	if err := session.Run("isMaster", &result); err != nil {
+		return false, errors.Trace(err)
+	}
+	return result.SetName != "", nil
+}
+
+// changeStreamCursor holds the reply of the aggregate and getMore
+// commands used to read a change stream.
+type changeStreamCursor struct {
+	Cursor struct {
+		Id         int64      `bson:"id"`
+		FirstBatch []bson.Raw `bson:"firstBatch"`
+		NextBatch  []bson.Raw `bson:"nextBatch"`
+	} `bson:"cursor"`
+}
+
+// readChangeStream opens a change stream on the documents inserted into
+// the collection, and sends to changed, without blocking, whenever any
+// are read. It returns nil once dying is closed, or an error if the change
+// stream cannot be read.
+//
+// The mgo driver has no support for change streams, so the commands
+// are issued directly.
+func readChangeStream(coll *mgo.Collection, changed chan<- struct{}, dying <-chan struct{}) error {
+	db := coll.Database
+	var result changeStreamCursor
+	err := db.Run(bson.D{
+		{"aggregate", coll.Name},
+		{"pipeline", []bson.M{
+			{"$changeStream": bson.M{}},
+			{"$match": bson.M{"operationType": "insert"}},
+			{"$project": bson.M{"_id": 1}},
+		}},
+		{"cursor", bson.M{}},
+	}, &result)
+	if err != nil {
+		return errors.Annotate(err, "opening change stream")
+	}
+	cursorId := result.Cursor.Id
+	defer func() {
+		if cursorId != 0 {
+			_ = db.Run(bson.D{{"killCursors", coll.Name}, {"cursors", []int64{cursorId}}}, nil)
+		}
+	}()
+
+	batch := result.Cursor.FirstBatch
+	for {
+		if len(batch) > 0 {
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		}
+		if cursorId == 0 {
+			return errors.New("change stream closed by the server")
+		}
+		select {
+		case <-dying:
+			return nil
+		default:
+		}
+
+		result = changeStreamCursor{}
+		err := db.Run(bson.D{
+			{"getMore", cursorId},
+			{"collection", coll.Name},
+			{"maxTimeMS", int64(changeStreamMaxAwait / time.Millisecond)},
+		}, &result)
+		if err != nil {
+			return errors.Annotate(err, "reading change stream")
+		}
+		cursorId = result.Cursor.Id
+		batch = result.Cursor.NextBatch
+	}
+}
@@ -8,7 +8,11 @@ const (
 	TxnWatcherErrorShortWait = txnWatcherErrorShortWait
 )
 
-var OutOfSyncError = outOfSyncError{}
+var (
+	OutOfSyncError        = outOfSyncError{}
+	SupportsChangeStreams = &supportsChangeStreams
+	WatchChangeStream     = &watchChangeStream
+)
 
 func NewTestHubWatcher(hub HubSource, clock Clock, modelUUID string, logger Logger) (*HubWatcher, <-chan struct{}) {
 	return newHubWatcher(hub, clock, modelUUID, logger)
@@ -61,6 +61,17 @@ var (
 		MaxDelay: 5 * time.Second,
 	}
 
+	// ChangeStreamPollStrategy is used in place of PollStrategy while
+	// a change stream reports new transactions as they are logged.
+	// Polling is then only a safety net, so it backs off further.
+	//
+	// It must not be changed when any watchers are active.
+	ChangeStreamPollStrategy retry.Strategy = retry.Exponential{
+		Initial:  txnWatcherShortWait,
+		Factor:   1.5,
+		MaxDelay: 30 * time.Second,
+	}
+
 	// ErrorStrategy is used to determine how long
 	// to delay between poll intervals when attempting
 	// to recover from a mongo error.
@@ -104,6 +115,10 @@ type TxnWatcher struct {
 	jujuDBName     string
 	collectionName string
 
+	// useChangeStream is true if the watcher should learn of
+	// new transactions from a change stream, when supported.
+	useChangeStream bool
+
 	// notifySync is copied from the package variable when the watcher
 	// is created.
 	notifySync func()
@@ -151,6 +166,12 @@ type TxnWatcherConfig struct {
 	// IteratorFunc can be overridden in tests to control what values the
 	// watcher sees.
 	IteratorFunc func(*mgo.Collection) mongo.Iterator
+	// UseChangeStream causes the watcher to sync as soon as a MongoDB
+	// change stream reports a new transaction, rather than waiting
+	// for the next poll. The log collection is still polled, less
+	// often, and only polled as before if change streams are not
+	// supported by the server.
+	UseChangeStream bool
 }
 
 // Validate ensures that all the values that have to be set are set.
@@ -181,15 +202,16 @@ func NewTxnWatcher(config TxnWatcherConfig) (*TxnWatcher, error) {
 	}
 
 	w := &TxnWatcher{
-		hub:            config.Hub,
-		clock:          config.Clock,
-		logger:         config.Logger,
-		session:        config.Session,
-		jujuDBName:     config.JujuDBName,
-		collectionName: config.CollectionName,
-		iteratorFunc:   config.IteratorFunc,
-		notifySync:     TxnPollNotifyFunc,
-		reportRequest:  make(chan chan map[string]interface{}),
+		hub:             config.Hub,
+		clock:           config.Clock,
+		logger:          config.Logger,
+		session:         config.Session,
+		jujuDBName:      config.JujuDBName,
+		collectionName:  config.CollectionName,
+		useChangeStream: config.UseChangeStream,
+		iteratorFunc:    config.IteratorFunc,
+		notifySync:      TxnPollNotifyFunc,
+		reportRequest:   make(chan chan map[string]interface{}),
 	}
 	if w.iteratorFunc == nil {
 		w.iteratorFunc = w.iter
@@ -282,6 +304,13 @@ func (w *TxnWatcher) loop() error {
 	syncRetryCount := 0
 	backoffStrategy := PollStrategy
 
+	// If a change stream tells us of new transactions,
+	// we can afford to poll less often.
+	changed, streamFailed := w.startChangeStream()
+	if changed != nil {
+		backoffStrategy = ChangeStreamPollStrategy
+	}
+
 	// Also make sure we have prepared the timer before
 	// we tell people we've started.
 	now := w.clock.Now()
@@ -300,6 +329,15 @@ func (w *TxnWatcher) loop() error {
 				backoff = backoffStrategy.NewTimer(w.clock.Now())
 			}
 			next = w.clock.After(d)
+		case <-changed:
+			// A transaction has been logged, so sync straight away.
+		case err := <-streamFailed:
+			w.logger.Warningf("txn watcher change stream failed, polling instead: %v", err)
+			changed, streamFailed = nil, nil
+			backoffStrategy = PollStrategy
+			backoff = backoffStrategy.NewTimer(w.clock.Now())
+			next = w.clock.After(txnWatcherShortWait)
+			continue
 		case resCh := <-w.reportRequest:
 			report := map[string]interface{}{
 				// How long was sync-events in our last flush
@@ -315,6 +353,8 @@ func (w *TxnWatcher) loop() error {
 				// How many database records have we read. note: because we have to iterate until we get to lastId,
 				// this is often a bit bigger than total-sync-events
 				"iterator-step-count": w.iteratorStepCount,
+				// Are we told of new transactions by a change stream
+				"change-stream": changed != nil,
 			}
 			select {
 			case <-w.tomb.Dying():
@@ -343,7 +383,7 @@ func (w *TxnWatcher) loop() error {
 			// Something's happened, so reset the exponential backoff
 			// so we'll retry again quickly.
 			if syncRetryCount > 0 || added {
-				backoff = PollStrategy.NewTimer(w.clock.Now())
+				backoff = backoffStrategy.NewTimer(w.clock.Now())
 				next = w.clock.After(txnWatcherShortWait)
 			}
 			syncRetryCount = 0
@@ -372,6 +412,40 @@ func (w *TxnWatcher) loop() error {
 	}
 }
 
+// startChangeStream starts reading a change stream on the log collection,
+// if the watcher is configured to and the server supports it. The first
+// returned channel receives a value when new transactions are logged,
+// and the second receives the error that stops the change stream.
+// Both are nil if no change stream is used.
+func (w *TxnWatcher) startChangeStream() (<-chan struct{}, <-chan error) {
+	if !w.useChangeStream {
+		return nil, nil
+	}
+	if ok, err := supportsChangeStreams(w.session); err != nil {
+		w.logger.Warningf("cannot determine change stream support, polling instead: %v", err)
+		return nil, nil
+	} else if !ok {
+		w.logger.Debugf("change streams not supported, polling instead")
+		return nil, nil
+	}
+
+	changed := make(chan struct{}, 1)
+	failed := make(chan error, 1)
+	w.tomb.Go(func() error {
+		// The change stream blocks the session while waiting
+		// for events, so it needs one of its own.
+		session := w.session.Copy()
+		defer session.Close()
+		coll := session.DB(w.jujuDBName).C(w.collectionName)
+		if err := watchChangeStream(coll, changed, w.tomb.Dying()); err != nil {
+			failed <- err
+		}
+		return nil
+	})
+	w.logger.Debugf("using change stream to watch %s", w.collectionName)
+	return changed, failed
+}
+
 // flush sends all pending events to their respective channels.
 func (w *TxnWatcher) flush() {
 	// refreshEvents are stored newest first.
@@ -80,17 +80,24 @@ func (s *TxnWatcherSuite) newWatcher(c *gc.C, expect int) (*watcher.TxnWatcher,
 }
 
 func (s *TxnWatcherSuite) newWatcherWithError(c *gc.C, expect int, watcherError error) (*watcher.TxnWatcher, *fakeHub) {
+	return s.newWatcherWithConfig(c, expect, watcherError, false)
+}
+
+func (s *TxnWatcherSuite) newWatcherWithConfig(
+	c *gc.C, expect int, watcherError error, useChangeStream bool,
+) (*watcher.TxnWatcher, *fakeHub) {
 	hub := newFakeHub(c, expect)
 	logger := loggo.GetLogger("test")
 	logger.SetLogLevel(loggo.TRACE)
 	w, err := watcher.NewTxnWatcher(watcher.TxnWatcherConfig{
-		Session:        s.MgoSuite.Session,
-		JujuDBName:     "juju",
-		CollectionName: s.log.Name,
-		Hub:            hub,
-		Clock:          s.clock,
-		Logger:         logger,
-		IteratorFunc:   s.iteratorFunc,
+		Session:         s.MgoSuite.Session,
+		JujuDBName:      "juju",
+		CollectionName:  s.log.Name,
+		Hub:             hub,
+		Clock:           s.clock,
+		Logger:          logger,
+		IteratorFunc:    s.iteratorFunc,
+		UseChangeStream: useChangeStream,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	// Wait for the main loop to have started.
@@ -188,6 +195,83 @@ func (s *TxnWatcherSuite) TestInsert(c *gc.C) {
 	})
 }
 
+func (s *TxnWatcherSuite) TestInsertUseChangeStream(c *gc.C) {
+	s.PatchValue(watcher.SupportsChangeStreams, func(*mgo.Session) (bool, error) {
+		return true, nil
+	})
+	inserted := make(chan struct{})
+	s.PatchValue(watcher.WatchChangeStream, func(_ *mgo.Collection, changed chan<- struct{}, dying <-chan struct{}) error {
+		for {
+			select {
+			case <-inserted:
+				changed <- struct{}{}
+			case <-dying:
+				return nil
+			}
+		}
+	})
+	w, hub := s.newWatcherWithConfig(c, 1, nil, true)
+	c.Assert(w.Report()["change-stream"], jc.IsTrue)
+
+	revno := s.insert(c, "test", "a")
+
+	// The clock is not advanced, so only the change stream
+	// can wake the watcher.
+	inserted <- struct{}{}
+	hub.waitForExpected(c)
+
+	c.Assert(hub.values, jc.DeepEquals, []watcher.Change{
+		{"test", "a", revno},
+	})
+}
+
+func (s *TxnWatcherSuite) TestInsertChangeStreamFailed(c *gc.C) {
+	s.PatchValue(watcher.SupportsChangeStreams, func(*mgo.Session) (bool, error) {
+		return true, nil
+	})
+	s.PatchValue(watcher.WatchChangeStream, func(*mgo.Collection, chan<- struct{}, <-chan struct{}) error {
+		return errors.New("boom")
+	})
+	w, hub := s.newWatcherWithConfig(c, 1, nil, true)
+
+	// Wait for the watcher to notice the change stream failed.
+	for a := testing.LongAttempt.Start(); ; {
+		if w.Report()["change-stream"] == false {
+			break
+		}
+		if !a.Next() {
+			c.Fatalf("watcher still using the failed change stream")
+		}
+	}
+
+	revno := s.insert(c, "test", "a")
+
+	// The watcher polls for the change instead.
+	s.advanceTime(c, watcher.TxnWatcherShortWait, 1)
+	hub.waitForExpected(c)
+
+	c.Assert(hub.values, jc.DeepEquals, []watcher.Change{
+		{"test", "a", revno},
+	})
+}
+
+func (s *TxnWatcherSuite) TestInsertChangeStreamUnsupported(c *gc.C) {
+	s.PatchValue(watcher.SupportsChangeStreams, func(*mgo.Session) (bool, error) {
+		return false, nil
+	})
+	w, hub := s.newWatcherWithConfig(c, 1, nil, true)
+	c.Assert(w.Report()["change-stream"], jc.IsFalse)
+
+	revno := s.insert(c, "test", "a")
+
+	s.advanceTime(c, watcher.TxnWatcherShortWait, 1)
+	hub.waitForExpected(c)
+
+	c.Assert(hub.values, jc.DeepEquals, []watcher.Change{
+		{"test", "a", revno},
+	})
+}
+
 func (s *TxnWatcherSuite) TestUpdate(c *gc.C) {
 	s.insert(c, "test", "a")
 
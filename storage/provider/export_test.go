@@ -26,7 +26,7 @@ func LoopVolumeSource(
 		etcDir,
 		set.NewStrings(),
 	}
-	return &loopVolumeSource{dirFuncs, run, storageDir}, dirFuncs
+	return &loopVolumeSource{dirFuncs, run, storageDir, 0}, dirFuncs
 }
 
 func LoopProvider(
@@ -133,6 +133,7 @@ func TmpfsFilesystemSource(etcDir, storageDir string, run func(string, ...string
 		},
 		run,
 		storageDir,
+		0,
 	}
 }
 
@@ -32,9 +32,9 @@ type loopProvider struct {
 var _ storage.Provider = (*loopProvider)(nil)
 
 // ValidateConfig is defined on the Provider interface.
-func (*loopProvider) ValidateConfig(*storage.Config) error {
-	// Loop provider has no configuration.
-	return nil
+func (*loopProvider) ValidateConfig(cfg *storage.Config) error {
+	_, err := machineQuota(cfg)
+	return errors.Trace(err)
 }
 
 // validateFullConfig validates a fully-constructed storage config,
@@ -56,12 +56,14 @@ func (lp *loopProvider) VolumeSource(sourceConfig *storage.Config) (storage.Volu
 	if err := lp.validateFullConfig(sourceConfig); err != nil {
 		return nil, err
 	}
-	// storageDir is validated by validateFullConfig.
+	// storageDir and the quota are validated by validateFullConfig.
 	storageDir, _ := sourceConfig.ValueString(storage.ConfigStorageDir)
+	quota, _ := machineQuota(sourceConfig)
 	return &loopVolumeSource{
 		&osDirFuncs{lp.run},
 		lp.run,
 		storageDir,
+		quota,
 	}, nil
 }
 
@@ -101,6 +103,11 @@ type loopVolumeSource struct {
 	dirFuncs   dirFuncs
 	run        runCommandFunc
 	storageDir string
+
+	// quota is the maximum total size, in MiB, of the loop
+	// devices' backing files in storageDir, or zero if there
+	// is no limit.
+	quota uint64
 }
 
 var _ storage.VolumeSource = (*loopVolumeSource)(nil)
@@ -124,6 +131,15 @@ func (lvs *loopVolumeSource) createVolume(params storage.VolumeParams) (storage.
 	if err := ensureDir(lvs.dirFuncs, filepath.Dir(loopFilePath)); err != nil {
 		return storage.Volume{}, errors.Trace(err)
 	}
+	if lvs.quota > 0 {
+		inUse, err := loopFilesSize(lvs.storageDir)
+		if err != nil {
+			return storage.Volume{}, errors.Trace(err)
+		}
+		if err := checkMachineQuota("loop volume", lvs.quota, inUse, params.Size); err != nil {
+			return storage.Volume{}, errors.Trace(err)
+		}
+	}
 	if err := createBlockFile(lvs.run, loopFilePath, params.Size); err != nil {
 		return storage.Volume{}, errors.Annotate(err, "could not create block file")
 	}
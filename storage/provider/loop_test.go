@@ -66,11 +66,29 @@ func (s *loopSuite) TestValidateConfig(c *gc.C) {
 	cfg, err := storage.NewConfig("name", provider.LoopProviderType, map[string]interface{}{})
 	c.Assert(err, jc.ErrorIsNil)
 	err = p.ValidateConfig(cfg)
-	// The loop provider does not have any user
-	// configuration, so an empty map will pass.
+	// The loop provider's only user configuration
+	// is the optional machine quota, so an empty
+	// map will pass.
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *loopSuite) TestValidateConfigMachineQuota(c *gc.C) {
+	p := s.loopProvider(c)
+	cfg, err := storage.NewConfig("name", provider.LoopProviderType, map[string]interface{}{
+		"machine-quota": "10G",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err = storage.NewConfig("name", provider.LoopProviderType, map[string]interface{}{
+		"machine-quota": "lots",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, `invalid machine-quota "lots": .*`)
+}
+
 func (s *loopSuite) TestSupports(c *gc.C) {
 	p := s.loopProvider(c)
 	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsTrue)
@@ -130,6 +148,40 @@ func (s *loopSuite) TestCreateVolumesNoAttachment(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *loopSuite) TestCreateVolumesMachineQuota(c *gc.C) {
+	p := s.loopProvider(c)
+	cfg, err := storage.NewConfig("name", provider.LoopProviderType, map[string]interface{}{
+		"storage-dir":   s.storageDir,
+		"machine-quota": "3M",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	source, err := p.VolumeSource(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Simulate an existing 2MiB loop volume on the machine.
+	f, err := os.Create(filepath.Join(s.storageDir, "volume-0"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = f.Truncate(2 * 1024 * 1024)
+	f.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.commands.expect("fallocate", "-l", "1MiB", filepath.Join(s.storageDir, "volume-1"))
+	results, err := source.CreateVolumes(s.callCtx, []storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("1"),
+		Size: 1,
+	}, {
+		Tag:  names.NewVolumeTag("2"),
+		Size: 2,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[1].Error, jc.Satisfies, errors.IsQuotaLimitExceeded)
+	c.Assert(results[1].Error, gc.ErrorMatches,
+		"creating volume: loop volume of 2.0MiB would exceed machine quota of 3.0MiB \\(2.0MiB in use\\)",
+	)
+}
+
 func (s *loopSuite) TestDestroyVolumes(c *gc.C) {
 	source, _ := s.loopVolumeSource(c)
 	fileName := filepath.Join(s.storageDir, "volume-0")
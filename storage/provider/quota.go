@@ -0,0 +1,103 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/errors"
+	"github.com/juju/utils/v2"
+
+	"github.com/juju/juju/storage"
+)
+
+// MachineQuota is the name of the pool attribute that limits the total
+// size of storage that the loop and tmpfs providers will create on any
+// one machine. Loop devices are backed by files in the machine's root
+// filesystem and tmpfs filesystems by its memory, so without a quota a
+// charm may exhaust either.
+const MachineQuota = "machine-quota"
+
+// machineQuota returns the machine quota, in MiB, specified by the
+// storage config, or zero if there is no quota.
+func machineQuota(cfg *storage.Config) (uint64, error) {
+	value, ok := cfg.Attrs()[MachineQuota]
+	if !ok || value == nil {
+		return 0, nil
+	}
+	quota, err := utils.ParseSize(fmt.Sprint(value))
+	if err != nil {
+		return 0, errors.Annotatef(err, "invalid %s %q", MachineQuota, value)
+	}
+	return quota, nil
+}
+
+// checkMachineQuota returns an error satisfying errors.IsQuotaLimitExceeded
+// if adding storage of the given size, in MiB, to the storage already in
+// use on the machine would exceed the quota. A zero quota is unlimited.
+func checkMachineQuota(kind string, quota, inUse, size uint64) error {
+	if quota == 0 || inUse+size <= quota {
+		return nil
+	}
+	return errors.NewQuotaLimitExceeded(nil, fmt.Sprintf(
+		"%s of %s would exceed machine quota of %s (%s in use)",
+		kind,
+		humanize.IBytes(size*humanize.MiByte),
+		humanize.IBytes(quota*humanize.MiByte),
+		humanize.IBytes(inUse*humanize.MiByte),
+	))
+}
+
+// loopFilesSize returns the total size, in MiB, of the loop device
+// backing files in the given storage directory.
+func loopFilesSize(storageDir string) (uint64, error) {
+	files, err := ioutil.ReadDir(storageDir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.Annotate(err, "reading storage directory")
+	}
+	var total uint64
+	for _, fi := range files {
+		if fi.IsDir() || !strings.HasPrefix(fi.Name(), "volume-") {
+			continue
+		}
+		total += uint64(fi.Size()) / humanize.MiByte
+	}
+	return total, nil
+}
+
+// tmpfsFilesystemsSize returns the total size, in MiB, of the tmpfs
+// filesystems recorded in the given storage directory.
+func tmpfsFilesystemsSize(storageDir string) (uint64, error) {
+	var total uint64
+	err := filepath.Walk(storageDir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if fi.IsDir() || !strings.HasSuffix(path, ".info") {
+			return nil
+		}
+		var info filesystemInfo
+		if err := utils.ReadYaml(path, &info); err != nil {
+			return errors.Annotatef(err, "reading %q", path)
+		}
+		if info.Size != nil {
+			total += *info.Size
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, errors.Annotate(err, "reading storage directory")
+	}
+	return total, nil
+}
@@ -32,8 +32,8 @@ var (
 
 // ValidateConfig is defined on the Provider interface.
 func (p *tmpfsProvider) ValidateConfig(cfg *storage.Config) error {
-	// Tmpfs provider has no configuration.
-	return nil
+	_, err := machineQuota(cfg)
+	return errors.Trace(err)
 }
 
 // validateFullConfig validates a fully-constructed storage config,
@@ -60,12 +60,14 @@ func (p *tmpfsProvider) FilesystemSource(sourceConfig *storage.Config) (storage.
 	if err := p.validateFullConfig(sourceConfig); err != nil {
 		return nil, err
 	}
-	// storageDir is validated by validateFullConfig.
+	// storageDir and the quota are validated by validateFullConfig.
 	storageDir, _ := sourceConfig.ValueString(storage.ConfigStorageDir)
+	quota, _ := machineQuota(sourceConfig)
 	return &tmpfsFilesystemSource{
 		&osDirFuncs{p.run},
 		p.run,
 		storageDir,
+		quota,
 	}, nil
 }
 
@@ -98,6 +100,11 @@ type tmpfsFilesystemSource struct {
 	dirFuncs   dirFuncs
 	run        runCommandFunc
 	storageDir string
+
+	// quota is the maximum total size, in MiB, of the tmpfs
+	// filesystems recorded in storageDir, or zero if there
+	// is no limit.
+	quota uint64
 }
 
 var _ storage.FilesystemSource = (*tmpfsFilesystemSource)(nil)
@@ -138,6 +145,16 @@ func (s *tmpfsFilesystemSource) createFilesystem(params storage.FilesystemParams
 		sizeInMiB = x - x%pageSizeInMiB
 	}
 
+	if s.quota > 0 {
+		inUse, err := tmpfsFilesystemsSize(s.storageDir)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := checkMachineQuota("tmpfs filesystem", s.quota, inUse, sizeInMiB); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
 	info := storage.FilesystemInfo{
 		FilesystemId: params.Tag.String(),
 		Size:         sizeInMiB,
@@ -156,10 +173,22 @@ func (s *tmpfsFilesystemSource) createFilesystem(params storage.FilesystemParams
 
 // DestroyFilesystems is defined on the FilesystemSource interface.
 func (s *tmpfsFilesystemSource) DestroyFilesystems(ctx context.ProviderCallContext, filesystemIds []string) ([]error, error) {
-	// DestroyFilesystems is a no-op; there is nothing to destroy,
-	// since the filesystem is ephemeral and disappears once
-	// detached.
-	return make([]error, len(filesystemIds)), nil
+	// The filesystem is ephemeral and disappears once detached;
+	// all that is left to destroy is the recorded filesystem info,
+	// which would otherwise count towards the machine quota.
+	results := make([]error, len(filesystemIds))
+	for i, filesystemId := range filesystemIds {
+		tag, err := names.ParseFilesystemTag(filesystemId)
+		if err != nil {
+			results[i] = errors.Errorf("invalid tmpfs filesystem ID %q", filesystemId)
+			continue
+		}
+		err = os.Remove(s.filesystemInfoFile(tag))
+		if err != nil && !os.IsNotExist(err) {
+			results[i] = errors.Annotatef(err, "destroying %q", filesystemId)
+		}
+	}
+	return results, nil
 }
 
 // ReleaseFilesystems is defined on the FilesystemSource interface.
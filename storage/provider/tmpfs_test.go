@@ -4,9 +4,9 @@
 package provider_test
 
 import (
-	"errors"
 	"runtime"
 
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -69,11 +69,29 @@ func (s *tmpfsSuite) TestValidateConfig(c *gc.C) {
 	cfg, err := storage.NewConfig("name", provider.TmpfsProviderType, map[string]interface{}{})
 	c.Assert(err, jc.ErrorIsNil)
 	err = p.ValidateConfig(cfg)
-	// The tmpfs provider does not have any user
-	// configuration, so an empty map will pass.
+	// The tmpfs provider's only user configuration
+	// is the optional machine quota, so an empty
+	// map will pass.
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *tmpfsSuite) TestValidateConfigMachineQuota(c *gc.C) {
+	p := s.tmpfsProvider(c)
+	cfg, err := storage.NewConfig("name", provider.TmpfsProviderType, map[string]interface{}{
+		"machine-quota": 512,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err = storage.NewConfig("name", provider.TmpfsProviderType, map[string]interface{}{
+		"machine-quota": "10X",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, `invalid machine-quota "10X": .*`)
+}
+
 func (s *tmpfsSuite) TestSupports(c *gc.C) {
 	p := s.tmpfsProvider(c)
 	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsFalse)
@@ -146,6 +164,55 @@ func (s *tmpfsSuite) TestCreateFilesystemsHugePages(c *gc.C) {
 	}})
 }
 
+func (s *tmpfsSuite) TestCreateFilesystemsMachineQuota(c *gc.C) {
+	p := s.tmpfsProvider(c)
+	cfg, err := storage.NewConfig("name", provider.TmpfsProviderType, map[string]interface{}{
+		"storage-dir":   s.storageDir,
+		"machine-quota": "4M",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	source, err := p.FilesystemSource(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("1"),
+		Size: 3,
+	}, {
+		Tag:  names.NewFilesystemTag("2"),
+		Size: 2,
+	}, {
+		Tag:  names.NewFilesystemTag("3"),
+		Size: 1,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[1].Error, jc.Satisfies, errors.IsQuotaLimitExceeded)
+	c.Assert(results[1].Error, gc.ErrorMatches,
+		"tmpfs filesystem of 2.0MiB would exceed machine quota of 4.0MiB \\(3.0MiB in use\\)",
+	)
+	c.Assert(results[2].Error, jc.ErrorIsNil)
+
+	// Destroying a filesystem releases its share of the quota.
+	errs, err := source.DestroyFilesystems(s.callCtx, []string{"filesystem-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+	results, err = source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("2"),
+		Size: 2,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+}
+
+func (s *tmpfsSuite) TestDestroyFilesystemsInvalidFilesystemId(c *gc.C) {
+	source := s.tmpfsFilesystemSource(c)
+	errs, err := source.DestroyFilesystems(s.callCtx, []string{"../super/important/stuff"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 1)
+	c.Assert(errs[0], gc.ErrorMatches, `invalid tmpfs filesystem ID "\.\./super/important/stuff"`)
+}
+
 func (s *tmpfsSuite) TestCreateFilesystemsIsUse(c *gc.C) {
 	source := s.tmpfsFilesystemSource(c)
 	results, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
@@ -0,0 +1,93 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"time"
+
+	"github.com/juju/juju/environs/context"
+)
+
+// VolumeSnapshotter provides an interface for taking snapshots of
+// volumes, and for creating new volumes from them. A VolumeSource may
+// implement VolumeSnapshotter if the provider supports snapshots.
+type VolumeSnapshotter interface {
+	// CreateVolumeSnapshots takes a snapshot of each of the specified
+	// volumes. The snapshots may still be in progress when
+	// CreateVolumeSnapshots returns.
+	CreateVolumeSnapshots(ctx context.ProviderCallContext, params []VolumeSnapshotParams) ([]CreateVolumeSnapshotsResult, error)
+
+	// VolumeSnapshots returns the snapshots of the volume with the
+	// specified provider ID.
+	VolumeSnapshots(ctx context.ProviderCallContext, volumeId string) ([]VolumeSnapshot, error)
+
+	// CreateVolumeFromSnapshot creates a new volume from the specified
+	// snapshot. The volume is not attached to any machine, and may be
+	// imported into the model as if by VolumeImporter.ImportVolume.
+	CreateVolumeFromSnapshot(ctx context.ProviderCallContext, params VolumeFromSnapshotParams) (VolumeInfo, error)
+}
+
+// VolumeSnapshotParams holds the parameters for taking a snapshot of
+// a volume.
+type VolumeSnapshotParams struct {
+	// VolumeId is the unique provider-supplied ID for the volume
+	// to take a snapshot of.
+	VolumeId string
+
+	// Description is a description of the snapshot, if the storage
+	// provider supports one.
+	Description string
+
+	// ResourceTags is a set of tags to set on the snapshot, if the
+	// storage provider supports tags.
+	ResourceTags map[string]string
+}
+
+// VolumeSnapshot describes a snapshot of a volume.
+type VolumeSnapshot struct {
+	// SnapshotId is the unique provider-supplied ID for the snapshot.
+	SnapshotId string
+
+	// VolumeId is the provider ID of the volume that the snapshot
+	// was taken of.
+	VolumeId string
+
+	// Size is the size of the snapshotted volume, in MiB.
+	Size uint64
+
+	// Description is the description of the snapshot, if any.
+	Description string
+
+	// Status is the provider-specific status of the snapshot,
+	// such as "pending" or "completed".
+	Status string
+
+	// Created is the time at which the snapshot was started.
+	Created time.Time
+}
+
+// CreateVolumeSnapshotsResult contains the result of a
+// VolumeSnapshotter.CreateVolumeSnapshots call for one volume.
+// Snapshot should only be used if Error is nil.
+type CreateVolumeSnapshotsResult struct {
+	Snapshot *VolumeSnapshot
+	Error    error
+}
+
+// VolumeFromSnapshotParams holds the parameters for creating a volume
+// from a snapshot.
+type VolumeFromSnapshotParams struct {
+	// SnapshotId is the unique provider-supplied ID for the snapshot
+	// to create the volume from.
+	SnapshotId string
+
+	// AvailabilityZone is the availability zone in which to create the
+	// volume. If empty, the volume is created in the zone of the volume
+	// that the snapshot was taken of, where that applies.
+	AvailabilityZone string
+
+	// ResourceTags is a set of tags to set on the created volume, if
+	// the storage provider supports tags.
+	ResourceTags map[string]string
+}
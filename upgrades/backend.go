@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/juju/replicaset"
+	"github.com/juju/version"
 
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/controller"
@@ -25,6 +26,8 @@ type StateBackend interface {
 	StateServingInfo() (controller.StateServingInfo, error)
 	ControllerConfig() (controller.Config, error)
 	LeaseNotifyTarget(io.Writer, raftleasestore.Logger) raftlease.NotifyTarget
+	UpgradeStepCompleted(version.Number, string) (bool, error)
+	SetUpgradeStepCompleted(version.Number, string) error
 
 	StripLocalUserDomain() error
 	RenameAddModelPermission() error
@@ -91,6 +94,8 @@ type StateBackend interface {
 	ExposeWildcardEndpointForExposedApplications() error
 	RemoveLinkLayerDevicesRefsCollection() error
 	RemoveUnusedLinkLayerDeviceProviderIDs() error
+	MoveBlobsToS3() error
+	AddUUIDsToEntities() error
 }
 
 // Model is an interface providing access to the details of a model within the
@@ -117,6 +122,14 @@ func (s stateBackend) StateServingInfo() (controller.StateServingInfo, error) {
 	return s.pool.SystemState().StateServingInfo()
 }
 
+func (s stateBackend) UpgradeStepCompleted(targetVersion version.Number, description string) (bool, error) {
+	return s.pool.SystemState().UpgradeStepCompleted(targetVersion, description)
+}
+
+func (s stateBackend) SetUpgradeStepCompleted(targetVersion version.Number, description string) error {
+	return s.pool.SystemState().SetUpgradeStepCompleted(targetVersion, description)
+}
+
 func (s stateBackend) StripLocalUserDomain() error {
 	return state.StripLocalUserDomain(s.pool)
 }
@@ -383,3 +396,11 @@ func (s stateBackend) RemoveLinkLayerDevicesRefsCollection() error {
 func (s stateBackend) RemoveUnusedLinkLayerDeviceProviderIDs() error {
 	return state.RemoveUnusedLinkLayerDeviceProviderIDs(s.pool)
 }
+
+func (s stateBackend) MoveBlobsToS3() error {
+	return state.MoveBlobsToS3(s.pool)
+}
+
+func (s stateBackend) AddUUIDsToEntities() error {
+	return state.AddUUIDsToEntities(s.pool)
+}
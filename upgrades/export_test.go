@@ -15,6 +15,7 @@ var (
 	MoveUnitAgentStateToController = moveUnitAgentStateToController
 
 	StoreDeployedUnitsInMachineAgentConf = storeDeployedUnitsInMachineAgentConf
+	EncryptAgentConfigSecrets            = encryptAgentConfigSecrets
 )
 
 type ModelConfigUpdater environConfigUpdater
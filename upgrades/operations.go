@@ -45,6 +45,7 @@ var stateUpgradeOperations = func() []Operation {
 		upgradeToVersion{version.MustParse("2.8.2"), stateStepsFor282()},
 		upgradeToVersion{version.MustParse("2.8.6"), stateStepsFor286()},
 		upgradeToVersion{version.MustParse("2.9.0"), stateStepsFor29()},
+		upgradeToVersion{version.MustParse("3.0.0"), stateStepsFor30()},
 	}
 	return steps
 }
@@ -64,6 +65,7 @@ var upgradeOperations = func() []Operation {
 		upgradeToVersion{version.MustParse("2.7.6"), stepsFor276()},
 		upgradeToVersion{version.MustParse("2.8.0"), stepsFor28()},
 		upgradeToVersion{version.MustParse("2.9.0"), stepsFor29()},
+		upgradeToVersion{version.MustParse("3.0.0"), stepsFor30()},
 	}
 	return steps
 }
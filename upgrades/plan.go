@@ -0,0 +1,88 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgrades
+
+import (
+	"github.com/juju/version"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// AllTargets holds every machine target, for planning the
+// steps run on any machine.
+var AllTargets = []Target{AllMachines, HostMachine, Controller, DatabaseMaster}
+
+// PlannedStep describes an upgrade step that would be run
+// when upgrading between two versions.
+type PlannedStep struct {
+	// TargetVersion is the version of the operation which
+	// includes the step.
+	TargetVersion version.Number
+
+	// Description is the description of the step.
+	Description string
+
+	// Targets are the machine targets the step applies to.
+	Targets []Target
+
+	// StateStep is true if the step is run directly against state,
+	// which happens before the API-based steps are run.
+	StateStep bool
+}
+
+// PlanUpgrade returns the steps that would be run, in order, when
+// upgrading from the "from" version to the "to" version on machines
+// with the given targets. No steps are run.
+// Only the steps known to this version of Juju can be planned, so
+// steps for later versions than this one are never returned.
+func PlanUpgrade(from, to version.Number, targets []Target) []PlannedStep {
+	var steps []PlannedStep
+	if hasStateTarget(targets) {
+		steps = planUpgradeSteps(steps, newOpsIterator(from, to, stateUpgradeOperations()), targets, true)
+	}
+	return planUpgradeSteps(steps, newOpsIterator(from, to, upgradeOperations()), targets, false)
+}
+
+// PlanResult returns the plan made by PlanUpgrade in the form returned
+// by the UpgradePlan API call.
+func PlanResult(from, to version.Number, targets []Target) params.UpgradePlanResult {
+	result := params.UpgradePlanResult{
+		FromVersion: from,
+		ToVersion:   to,
+	}
+	for _, step := range PlanUpgrade(from, to, targets) {
+		stepTargets := make([]string, len(step.Targets))
+		for i, target := range step.Targets {
+			stepTargets[i] = string(target)
+		}
+		result.Steps = append(result.Steps, params.UpgradePlanStep{
+			TargetVersion: step.TargetVersion,
+			Description:   step.Description,
+			Targets:       stepTargets,
+			State:         step.StateStep,
+		})
+	}
+	return result
+}
+
+// planUpgradeSteps appends the steps from the upgrade operations
+// relevant to the targets given, in the order runUpgradeSteps
+// would run them.
+func planUpgradeSteps(steps []PlannedStep, ops *opsIterator, targets []Target, stateSteps bool) []PlannedStep {
+	for ops.Next() {
+		op := ops.Get()
+		for _, step := range op.Steps() {
+			if !targetsMatch(targets, step.Targets()) {
+				continue
+			}
+			steps = append(steps, PlannedStep{
+				TargetVersion: op.TargetVersion(),
+				Description:   step.Description(),
+				Targets:       step.Targets(),
+				StateStep:     stateSteps,
+			})
+		}
+	}
+	return steps
+}
@@ -0,0 +1,101 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgrades_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/upgrades"
+)
+
+type planSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&planSuite{})
+
+func (s *planSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.PatchValue(upgrades.StateUpgradeOperations, stateUpgradeOperations)
+	s.PatchValue(upgrades.UpgradeOperations, upgradeOperations)
+}
+
+func planDescriptions(steps []upgrades.PlannedStep) []string {
+	descriptions := []string{}
+	for _, step := range steps {
+		descriptions = append(descriptions, step.Description)
+	}
+	return descriptions
+}
+
+func (s *planSuite) TestPlanUpgrade(c *gc.C) {
+	steps := upgrades.PlanUpgrade(
+		version.MustParse("1.20.0"), version.MustParse("1.22.0"), targets(upgrades.DatabaseMaster))
+	c.Assert(steps, jc.DeepEquals, []upgrades.PlannedStep{{
+		TargetVersion: version.MustParse("1.21.0"),
+		Description:   "state step 1 - 1.21.0",
+		Targets:       []upgrades.Target{upgrades.DatabaseMaster},
+		StateStep:     true,
+	}, {
+		TargetVersion: version.MustParse("1.22.0"),
+		Description:   "state step 1 - 1.22.0",
+		Targets:       []upgrades.Target{upgrades.DatabaseMaster},
+		StateStep:     true,
+	}, {
+		TargetVersion: version.MustParse("1.21.0"),
+		Description:   "step 1 - 1.21.0",
+		Targets:       []upgrades.Target{upgrades.AllMachines},
+	}, {
+		TargetVersion: version.MustParse("1.22.0"),
+		Description:   "step 2 - 1.22.0",
+		Targets:       []upgrades.Target{upgrades.AllMachines},
+	}})
+}
+
+func (s *planSuite) TestPlanUpgradeNoStateTarget(c *gc.C) {
+	steps := upgrades.PlanUpgrade(
+		version.MustParse("1.17.0"), version.MustParse("1.18.0"), targets(upgrades.HostMachine))
+	c.Assert(planDescriptions(steps), jc.DeepEquals, []string{
+		"step 1 - 1.17.1",
+		"step 1 - 1.18.0",
+	})
+}
+
+func (s *planSuite) TestPlanUpgradeAllTargets(c *gc.C) {
+	steps := upgrades.PlanUpgrade(
+		version.MustParse("1.17.1"), version.MustParse("1.18.0"), upgrades.AllTargets)
+	c.Assert(planDescriptions(steps), jc.DeepEquals, []string{
+		"step 1 - 1.18.0",
+		"step 2 - 1.18.0",
+	})
+}
+
+func (s *planSuite) TestPlanUpgradeSameVersion(c *gc.C) {
+	steps := upgrades.PlanUpgrade(
+		version.MustParse("1.18.0"), version.MustParse("1.18.0"), upgrades.AllTargets)
+	c.Assert(steps, gc.HasLen, 0)
+}
+
+func (s *planSuite) TestPlanResult(c *gc.C) {
+	result := upgrades.PlanResult(
+		version.MustParse("1.21.0"), version.MustParse("1.22.0"), targets(upgrades.DatabaseMaster))
+	c.Assert(result, jc.DeepEquals, params.UpgradePlanResult{
+		FromVersion: version.MustParse("1.21.0"),
+		ToVersion:   version.MustParse("1.22.0"),
+		Steps: []params.UpgradePlanStep{{
+			TargetVersion: version.MustParse("1.22.0"),
+			Description:   "state step 1 - 1.22.0",
+			Targets:       []string{"databaseMaster"},
+			State:         true,
+		}, {
+			TargetVersion: version.MustParse("1.22.0"),
+			Description:   "step 2 - 1.22.0",
+			Targets:       []string{"allMachines"},
+		}},
+	})
+}
@@ -0,0 +1,78 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgrades
+
+import (
+	"path/filepath"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/agent"
+)
+
+// stateStepsFor30 returns upgrade steps for Juju 3.0.0
+func stateStepsFor30() []Step {
+	return []Step{
+		&upgradeStep{
+			description: "move blobstore content from GridFS to S3",
+			targets:     []Target{DatabaseMaster},
+			run: func(context Context) error {
+				return context.State().MoveBlobsToS3()
+			},
+		},
+		&upgradeStep{
+			description: "add UUIDs to applications, units, machines, relations and spaces",
+			targets:     []Target{DatabaseMaster},
+			run: func(context Context) error {
+				return context.State().AddUUIDsToEntities()
+			},
+		},
+	}
+}
+
+// stepsFor30 returns upgrade steps for Juju 3.0.0 that only need the API.
+func stepsFor30() []Step {
+	return []Step{
+		&upgradeStep{
+			description: "encrypt secrets in agent configs",
+			targets:     []Target{AllMachines},
+			run:         encryptAgentConfigSecrets,
+		},
+	}
+}
+
+// encryptAgentConfigSecrets creates the machine-local agent config key
+// and rewrites the configs of the other agents on the machine, which
+// encrypts their secrets. The machine agent's own config is written
+// with its secrets encrypted once the upgrade steps have run.
+func encryptAgentConfigSecrets(ctx Context) error {
+	agentConf := ctx.AgentConfig()
+	dataDir := agentConf.DataDir()
+	if err := agent.EnsureConfigKey(dataDir); err != nil {
+		return errors.Annotate(err, "creating agent config key")
+	}
+
+	ownPath := agent.ConfigPath(dataDir, agentConf.Tag())
+	paths, err := filepath.Glob(filepath.Join(agent.BaseDir(dataDir), "*", agent.AgentConfigFilename))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, path := range paths {
+		if path == ownPath {
+			continue
+		}
+		conf, err := agent.ReadConfig(path)
+		if err != nil {
+			// Don't let one broken agent stop the upgrade; its config
+			// is encrypted when it's next written.
+			logger.Errorf("cannot encrypt agent config: %v", err)
+			continue
+		}
+		if err := conf.Write(); err != nil {
+			return errors.Annotatef(err, "rewriting agent config %q", path)
+		}
+		logger.Debugf("encrypted secrets in agent config %q", path)
+	}
+	return nil
+}
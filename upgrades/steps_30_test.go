@@ -0,0 +1,115 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgrades_test
+
+import (
+	"io/ioutil"
+
+	"github.com/golang/mock/gomock"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/upgrades"
+	"github.com/juju/juju/upgrades/mocks"
+	configsettermocks "github.com/juju/juju/worker/upgradedatabase/mocks"
+)
+
+var v300 = version.MustParse("3.0.0")
+
+type steps30Suite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&steps30Suite{})
+
+func (s *steps30Suite) TestMoveBlobsToS3(c *gc.C) {
+	step := findStateStep(c, v300, "move blobstore content from GridFS to S3")
+	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
+}
+
+func (s *steps30Suite) TestEncryptAgentConfigSecrets(c *gc.C) {
+	step := findStep(c, v300, "encrypt secrets in agent configs")
+	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.AllMachines})
+}
+
+type encryptAgentConfig30Suite struct {
+	testing.BaseSuite
+
+	dataDir string
+}
+
+var _ = gc.Suite(&encryptAgentConfig30Suite{})
+
+func (s *encryptAgentConfig30Suite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.dataDir = c.MkDir()
+}
+
+func (s *encryptAgentConfig30Suite) writeAgentConf(c *gc.C, tag names.Tag) string {
+	conf, err := agent.NewAgentConfig(
+		agent.AgentConfigParams{
+			Paths: agent.Paths{
+				DataDir: s.dataDir,
+			},
+			Tag:               tag,
+			Password:          "secret",
+			Controller:        testing.ControllerTag,
+			Model:             testing.ModelTag,
+			APIAddresses:      []string{"localhost:4321"},
+			CACert:            testing.CACert,
+			UpgradedToVersion: version.MustParse("2.42.0"),
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conf.Write(), jc.ErrorIsNil)
+	return agent.ConfigPath(s.dataDir, tag)
+}
+
+func (s *encryptAgentConfig30Suite) readFile(c *gc.C, path string) string {
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	return string(data)
+}
+
+func (s *encryptAgentConfig30Suite) TestEncryptsOtherAgentConfigs(c *gc.C) {
+	machineTag := names.NewMachineTag("42")
+	machinePath := s.writeAgentConf(c, machineTag)
+	unitPath := s.writeAgentConf(c, names.NewUnitTag("principal/1"))
+
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	ctx := mocks.NewMockContext(ctrl)
+	agentConfig := configsettermocks.NewMockConfigSetter(ctrl)
+	ctx.EXPECT().AgentConfig().Return(agentConfig).AnyTimes()
+	agentConfig.EXPECT().DataDir().Return(s.dataDir).AnyTimes()
+	agentConfig.EXPECT().Tag().Return(machineTag).AnyTimes()
+
+	err := upgrades.EncryptAgentConfigSecrets(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The unit agent's config is rewritten encrypted; the machine
+	// agent's own config is left to the upgrade steps worker.
+	c.Assert(s.readFile(c, unitPath), gc.Not(jc.Contains), "oldpassword: secret")
+	c.Assert(s.readFile(c, unitPath), jc.Contains, "oldpassword: encrypted:")
+	c.Assert(s.readFile(c, machinePath), jc.Contains, "oldpassword: secret")
+
+	unitConf, err := agent.ReadConfig(unitPath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitConf.OldPassword(), gc.Equals, "secret")
+
+	// Running the step again is harmless.
+	err = upgrades.EncryptAgentConfigSecrets(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	unitConf, err = agent.ReadConfig(unitPath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitConf.OldPassword(), gc.Equals, "secret")
+}
+
+func (s *steps30Suite) TestAddUUIDsToEntities(c *gc.C) {
+	step := findStateStep(c, v300, "add UUIDs to applications, units, machines, relations and spaces")
+	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
+}
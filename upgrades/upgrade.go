@@ -100,7 +100,7 @@ func PerformUpgrade(from version.Number, targets []Target, context Context) erro
 		}
 	}
 	ops := newUpgradeOpsIterator(from)
-	if err := runUpgradeSteps(ops, targets, context.APIContext()); err != nil {
+	if err := runUpgradeSteps(ops, targets, context.APIContext(), nil); err != nil {
 		return errors.Trace(err)
 	}
 	logger.Infof("All upgrade steps completed successfully")
@@ -109,8 +109,12 @@ func PerformUpgrade(from version.Number, targets []Target, context Context) erro
 
 // PerformStateUpgrade runs the upgrades steps
 // that target Controller or DatabaseMaster.
+// The completion of each step that targets only DatabaseMaster is
+// recorded in state, so that if the upgrade is interrupted, it
+// resumes at the first incomplete step when it is run again.
 func PerformStateUpgrade(from version.Number, targets []Target, context Context) error {
-	return errors.Trace(runUpgradeSteps(newStateUpgradeOpsIterator(from), targets, context.StateContext()))
+	stateContext := context.StateContext()
+	return errors.Trace(runUpgradeSteps(newStateUpgradeOpsIterator(from), targets, stateContext, stateContext.State()))
 }
 
 func hasStateTarget(targets []Target) bool {
@@ -129,17 +133,22 @@ func hasStateTarget(targets []Target) bool {
 // subsequent steps may required successful completion of earlier
 // ones. The steps must be idempotent so that the entire upgrade
 // operation can be retried.
-func runUpgradeSteps(ops *opsIterator, targets []Target, context Context) error {
+//
+// If checkpoints is not nil, steps it records as completed are
+// skipped, and the steps that are checkpointed are recorded there
+// as they complete.
+func runUpgradeSteps(ops *opsIterator, targets []Target, context Context, checkpoints StateBackend) error {
 	for ops.Next() {
-		for _, step := range ops.Get().Steps() {
-			if targetsMatch(targets, step.Targets()) {
-				logger.Infof("running upgrade step: %v", step.Description())
-				if err := step.Run(context); err != nil {
-					logger.Errorf("upgrade step %q failed: %v", step.Description(), err)
-					return &upgradeError{
-						description: step.Description(),
-						err:         err,
-					}
+		op := ops.Get()
+		for _, step := range op.Steps() {
+			if !targetsMatch(targets, step.Targets()) {
+				continue
+			}
+			if err := runUpgradeStep(op.TargetVersion(), step, context, checkpoints); err != nil {
+				logger.Errorf("upgrade step %q failed: %v", step.Description(), err)
+				return &upgradeError{
+					description: step.Description(),
+					err:         err,
 				}
 			}
 		}
@@ -147,6 +156,47 @@ func runUpgradeSteps(ops *opsIterator, targets []Target, context Context) error
 	return nil
 }
 
+// runUpgradeStep runs a single upgrade step, unless checkpoints
+// records that it has already completed.
+func runUpgradeStep(targetVersion version.Number, step Step, context Context, checkpoints StateBackend) error {
+	checkpoint := checkpoints != nil && isCheckpointed(step)
+	if checkpoint {
+		completed, err := checkpoints.UpgradeStepCompleted(targetVersion, step.Description())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if completed {
+			logger.Infof("skipping completed upgrade step: %v", step.Description())
+			return nil
+		}
+	}
+	logger.Infof("running upgrade step: %v", step.Description())
+	if err := step.Run(context); err != nil {
+		return err
+	}
+	if checkpoint {
+		return errors.Trace(checkpoints.SetUpgradeStepCompleted(targetVersion, step.Description()))
+	}
+	return nil
+}
+
+// isCheckpointed returns true if the completion of the step is
+// recorded, which is the case for steps that target only the
+// DatabaseMaster. Other steps may change the machines running
+// them, and so are always run.
+func isCheckpointed(step Step) bool {
+	stepTargets := step.Targets()
+	if len(stepTargets) == 0 {
+		return false
+	}
+	for _, target := range stepTargets {
+		if target != DatabaseMaster {
+			return false
+		}
+	}
+	return true
+}
+
 // targetsMatch returns true if any machineTargets match any of
 // stepTargets.
 func targetsMatch(machineTargets []Target, stepTargets []Target) bool {
@@ -205,7 +205,8 @@ func (mock *mockAgentConfig) Model() names.ModelTag {
 type mockStateBackend struct {
 	upgrades.StateBackend
 	testing.Stub
-	models []upgrades.Model
+	models    []upgrades.Model
+	completed map[string]bool
 }
 
 func (mock *mockStateBackend) ControllerUUID() string {
@@ -213,6 +214,23 @@ func (mock *mockStateBackend) ControllerUUID() string {
 	return "a-b-c-d"
 }
 
+func (mock *mockStateBackend) UpgradeStepCompleted(targetVersion version.Number, description string) (bool, error) {
+	mock.MethodCall(mock, "UpgradeStepCompleted", targetVersion, description)
+	return mock.completed[targetVersion.String()+" "+description], mock.NextErr()
+}
+
+func (mock *mockStateBackend) SetUpgradeStepCompleted(targetVersion version.Number, description string) error {
+	mock.MethodCall(mock, "SetUpgradeStepCompleted", targetVersion, description)
+	if err := mock.NextErr(); err != nil {
+		return err
+	}
+	if mock.completed == nil {
+		mock.completed = make(map[string]bool)
+	}
+	mock.completed[targetVersion.String()+" "+description] = true
+	return nil
+}
+
 func stateUpgradeOperations() []upgrades.Operation {
 	steps := []upgrades.Operation{
 		&mockUpgradeOperation{
@@ -595,6 +613,59 @@ func (s *upgradeSuite) TestStateStepsNotAttemptedWhenNoStateTarget(c *gc.C) {
 	check(upgrades.HostMachine, 0, nil)
 }
 
+func checkpointedOperations() []upgrades.Operation {
+	return []upgrades.Operation{
+		&mockUpgradeOperation{
+			targetVersion: version.MustParse("1.21.0"),
+			steps: []upgrades.Step{
+				newUpgradeStep("db step 1", upgrades.DatabaseMaster),
+				newUpgradeStep("controller step", upgrades.Controller),
+				newUpgradeStep("db step 2", upgrades.DatabaseMaster),
+			},
+		},
+	}
+}
+
+func (s *upgradeSuite) TestStateStepsCheckpointed(c *gc.C) {
+	s.PatchValue(upgrades.StateUpgradeOperations, checkpointedOperations)
+	s.PatchValue(&jujuversion.Current, version.MustParse("1.21.0"))
+
+	state := &mockStateBackend{}
+	ctx := &mockContext{state: state}
+	err := upgrades.PerformStateUpgrade(version.MustParse("1.20.0"), targets(upgrades.DatabaseMaster), ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.messages, jc.DeepEquals, []string{"db step 1", "controller step", "db step 2"})
+	// Steps which run on other controllers too aren't checkpointed.
+	c.Assert(state.completed, jc.DeepEquals, map[string]bool{
+		"1.21.0 db step 1": true,
+		"1.21.0 db step 2": true,
+	})
+}
+
+func (s *upgradeSuite) TestStateStepsResumeAtFirstIncompleteStep(c *gc.C) {
+	s.PatchValue(upgrades.StateUpgradeOperations, checkpointedOperations)
+	s.PatchValue(&jujuversion.Current, version.MustParse("1.21.0"))
+
+	state := &mockStateBackend{completed: map[string]bool{"1.21.0 db step 1": true}}
+	ctx := &mockContext{state: state}
+	err := upgrades.PerformStateUpgrade(version.MustParse("1.20.0"), targets(upgrades.DatabaseMaster), ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.messages, jc.DeepEquals, []string{"controller step", "db step 2"})
+}
+
+func (s *upgradeSuite) TestStateStepCheckpointError(c *gc.C) {
+	s.PatchValue(upgrades.StateUpgradeOperations, checkpointedOperations)
+	s.PatchValue(&jujuversion.Current, version.MustParse("1.21.0"))
+
+	state := &mockStateBackend{}
+	state.SetErrors(nil, errors.New("boom"))
+	ctx := &mockContext{state: state}
+	err := upgrades.PerformStateUpgrade(version.MustParse("1.20.0"), targets(upgrades.DatabaseMaster), ctx)
+	c.Assert(err, gc.ErrorMatches, "db step 1: boom")
+	c.Assert(ctx.messages, jc.DeepEquals, []string{"db step 1"})
+	c.Assert(state.completed, gc.HasLen, 0)
+}
+
 func (s *upgradeSuite) TestUpgradeOperationsOrdered(c *gc.C) {
 	var previous version.Number
 	for i, utv := range (*upgrades.UpgradeOperations)() {
@@ -635,6 +706,7 @@ func (s *upgradeSuite) TestStateUpgradeOperationsVersions(c *gc.C) {
 		"2.8.2",
 		"2.8.6",
 		"2.9.0",
+		"3.0.0",
 	})
 }
 
@@ -651,6 +723,7 @@ func (s *upgradeSuite) TestUpgradeOperationsVersions(c *gc.C) {
 		"2.7.6",
 		"2.8.0",
 		"2.9.0",
+		"3.0.0",
 	})
 }
 
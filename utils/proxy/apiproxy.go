@@ -0,0 +1,34 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package proxy
+
+import (
+	"net/url"
+
+	"github.com/juju/errors"
+)
+
+// ParseAPIProxy parses the address of the proxy through which agents
+// connect to the controller. The proxy is either an HTTP proxy,
+// reached with an http URL and used with the CONNECT method, or a
+// SOCKS5 proxy, reached with a socks5 URL. An empty value means that
+// no proxy is used, and a nil URL is returned.
+func ParseAPIProxy(value string) (*url.URL, error) {
+	if value == "" {
+		return nil, nil
+	}
+	proxyURL, err := url.Parse(value)
+	if err != nil {
+		return nil, errors.Errorf("invalid API proxy address %q: %v", value, err)
+	}
+	switch proxyURL.Scheme {
+	case "http", "socks5":
+	default:
+		return nil, errors.Errorf("API proxy address %q has unsupported scheme %q, expected http or socks5", value, proxyURL.Scheme)
+	}
+	if proxyURL.Host == "" {
+		return nil, errors.Errorf("API proxy address %q has no host", value)
+	}
+	return proxyURL, nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apicaller
+
+import (
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/juju/clock"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api"
+)
+
+var (
+	// interfaceAddrs is patched in tests.
+	interfaceAddrs = net.InterfaceAddrs
+
+	addressHealthMu sync.Mutex
+	// addressHealth holds the API address health shared by all of
+	// the connections made in this process, keyed on the agent's
+	// preferred subnets configuration.
+	addressHealth = make(map[string]*api.AddressHealth)
+)
+
+// agentAddressHealth returns the API address health tracker for the
+// agent, creating it if necessary. Trackers are shared by agents in the
+// same process so that what one learns about the controllers benefits
+// the others.
+func agentAddressHealth(agentConfig agent.Config, logger Logger) *api.AddressHealth {
+	value := agentConfig.Value(agent.APIDialPreferredSubnets)
+
+	addressHealthMu.Lock()
+	defer addressHealthMu.Unlock()
+	if health, ok := addressHealth[value]; ok {
+		return health
+	}
+	health := api.NewAddressHealth(clock.WallClock, preferredSubnets(value, logger))
+	addressHealth[value] = health
+	return health
+}
+
+// preferredSubnets returns the subnets whose API addresses should be
+// dialled first, according to the supplied agent config value.
+func preferredSubnets(value string, logger Logger) []*net.IPNet {
+	switch value {
+	case "none":
+		return nil
+	case "":
+		return localSubnets(logger)
+	}
+	var subnets []*net.IPNet
+	for _, cidr := range strings.Split(value, ",") {
+		_, subnet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			logger.Errorf("ignoring invalid %s value %q: %v", agent.APIDialPreferredSubnets, cidr, err)
+			continue
+		}
+		subnets = append(subnets, subnet)
+	}
+	return subnets
+}
+
+// localSubnets returns the subnets of the machine's network interfaces,
+// excluding loopback and link-local ones.
+func localSubnets(logger Logger) []*net.IPNet {
+	addrs, err := interfaceAddrs()
+	if err != nil {
+		logger.Debugf("cannot get interface addresses: %v", err)
+		return nil
+	}
+	var subnets []*net.IPNet
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok {
+			continue
+		}
+		ip := ipNet.IP
+		if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() {
+			continue
+		}
+		subnets = append(subnets, &net.IPNet{
+			IP:   ip.Mask(ipNet.Mask),
+			Mask: ipNet.Mask,
+		})
+	}
+	return subnets
+}
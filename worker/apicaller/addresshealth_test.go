@@ -0,0 +1,56 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apicaller_test
+
+import (
+	"errors"
+	"net"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/apicaller"
+)
+
+type preferredSubnetsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&preferredSubnetsSuite{})
+
+func (s *preferredSubnetsSuite) TestNone(c *gc.C) {
+	subnets := apicaller.PreferredSubnets("none", loggo.GetLogger("test"))
+	c.Assert(subnets, gc.HasLen, 0)
+}
+
+func (s *preferredSubnetsSuite) TestExplicit(c *gc.C) {
+	subnets := apicaller.PreferredSubnets("10.0.0.0/24, bad, fd00::/64", loggo.GetLogger("test"))
+	c.Assert(subnets, gc.HasLen, 2)
+	c.Check(subnets[0].String(), gc.Equals, "10.0.0.0/24")
+	c.Check(subnets[1].String(), gc.Equals, "fd00::/64")
+}
+
+func (s *preferredSubnetsSuite) TestLocal(c *gc.C) {
+	s.PatchValue(apicaller.InterfaceAddrs, func() ([]net.Addr, error) {
+		return []net.Addr{
+			&net.IPNet{IP: net.ParseIP("127.0.0.1"), Mask: net.CIDRMask(8, 32)},
+			&net.IPNet{IP: net.ParseIP("fe80::1"), Mask: net.CIDRMask(64, 128)},
+			&net.IPNet{IP: net.ParseIP("192.168.1.20"), Mask: net.CIDRMask(24, 32)},
+			&net.IPAddr{IP: net.ParseIP("10.1.1.1")},
+		}, nil
+	})
+	subnets := apicaller.PreferredSubnets("", loggo.GetLogger("test"))
+	c.Assert(subnets, gc.HasLen, 1)
+	c.Check(subnets[0].String(), gc.Equals, "192.168.1.0/24")
+}
+
+func (s *preferredSubnetsSuite) TestLocalError(c *gc.C) {
+	s.PatchValue(apicaller.InterfaceAddrs, func() ([]net.Addr, error) {
+		return nil, errors.New("boom")
+	})
+	subnets := apicaller.PreferredSubnets("", loggo.GetLogger("test"))
+	c.Assert(subnets, jc.DeepEquals, []*net.IPNet(nil))
+}
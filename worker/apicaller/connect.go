@@ -4,6 +4,7 @@
 package apicaller
 
 import (
+	"net/url"
 	"time"
 
 	"github.com/juju/errors"
@@ -52,7 +53,8 @@ func OnlyConnect(a agent.Agent, apiOpen api.OpenFunc, logger Logger) (api.Connec
 	if !ok {
 		return nil, errors.New("API info not available")
 	}
-	conn, _, err := connectFallback(apiOpen, info, agentConfig.OldPassword(), logger)
+	health := agentAddressHealth(agentConfig, logger)
+	conn, _, err := connectFallback(apiOpen, info, agentConfig.OldPassword(), health, agentConfig.APIProxy(), logger)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -79,7 +81,7 @@ func OnlyConnect(a agent.Agent, apiOpen api.OpenFunc, logger Logger) (api.Connec
 // until it's managed to log in, and any suicide-cutoff point we pick here
 // will be objectively bad in some circumstances.)
 func connectFallback(
-	apiOpen api.OpenFunc, info *api.Info, fallbackPassword string, logger Logger,
+	apiOpen api.OpenFunc, info *api.Info, fallbackPassword string, health *api.AddressHealth, proxy *url.URL, logger Logger,
 ) (
 	conn api.Connection, didFallback bool, err error,
 ) {
@@ -104,6 +106,12 @@ func connectFallback(
 			// before responding to the login request, but the pause is
 			// in the realm of five to ten seconds.
 			Timeout: time.Minute,
+			// Controllers that recently failed to connect are dialled
+			// last, and nearby fast ones first, rather than all at once.
+			AddressHealth: health,
+			// Agents in proxied networks reach the controller through
+			// the proxy recorded in their agent config.
+			Proxy: proxy,
 		})
 	}
 
@@ -206,7 +214,8 @@ func ScaryConnect(a agent.Agent, apiOpen api.OpenFunc, logger Logger) (_ api.Con
 	}()
 
 	// Start connection...
-	conn, usedOldPassword, err := connectFallback(apiOpen, info, oldPassword, logger)
+	health := agentAddressHealth(agentConfig, logger)
+	conn, usedOldPassword, err := connectFallback(apiOpen, info, oldPassword, health, agentConfig.APIProxy(), logger)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
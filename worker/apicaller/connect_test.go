@@ -5,6 +5,7 @@ package apicaller_test
 
 import (
 	"errors"
+	"net/url"
 
 	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
@@ -71,6 +72,28 @@ func testEntityFine(c *gc.C, life apiagent.Life) {
 	}})
 }
 
+func (*ScaryConnectSuite) TestConnectUsesAPIProxy(c *gc.C) {
+	proxyURL, err := url.Parse("socks5://10.0.0.1:1080")
+	c.Assert(err, jc.ErrorIsNil)
+	stub := &testing.Stub{}
+	expectConn := &mockConn{stub: stub}
+	var dialOpts api.DialOpts
+	apiOpen := func(info *api.Info, opts api.DialOpts) (api.Connection, error) {
+		dialOpts = opts
+		return expectConn, nil
+	}
+
+	conn, err := apicaller.OnlyConnect(&mockAgent{
+		stub:   stub,
+		model:  coretesting.ModelTag,
+		entity: names.NewMachineTag("42"),
+		proxy:  proxyURL,
+	}, apiOpen, loggo.GetLogger("test"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(conn, gc.Equals, expectConn)
+	c.Check(dialOpts.Proxy, gc.Equals, proxyURL)
+}
+
 func (*ScaryConnectSuite) TestEntityDead(c *gc.C) {
 	// permanent failure case
 	stub := &testing.Stub{}
@@ -11,3 +11,13 @@ var Strategy = &checkProvisionedStrategy
 // NewConnFacade is a dirty hack; should be explicit config; not
 // currently convenient.
 var NewConnFacade = &newConnFacade
+
+// AgentAddressHealth exposes the shared API address health tracker
+// so tests can check it is passed when dialling.
+var AgentAddressHealth = agentAddressHealth
+
+// PreferredSubnets exposes preferredSubnets for testing.
+var PreferredSubnets = preferredSubnets
+
+// InterfaceAddrs allows the local interface addresses to be patched.
+var InterfaceAddrs = &interfaceAddrs
@@ -97,7 +97,8 @@ func (config ManifoldConfig) startFunc() dependency.StartFunc {
 			return nil, errors.Annotatef(err, "[%s] %q cannot open api",
 				shortModelUUID(cfg.Model()), cfg.Tag().String())
 		}
-		return newAPIConnWorker(conn), nil
+		health := agentAddressHealth(agent.CurrentConfig(), config.Logger)
+		return newAPIConnWorker(conn, health), nil
 	}
 }
 
@@ -4,8 +4,10 @@
 package apicaller_test
 
 import (
+	"net/url"
 	"time"
 
+	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -30,12 +32,14 @@ type mockAgent struct {
 	stub   *testing.Stub
 	entity names.Tag
 	model  names.ModelTag
+	proxy  *url.URL
 }
 
 func (mock *mockAgent) CurrentConfig() agent.Config {
 	return dummyConfig{
 		entity: mock.entity,
 		model:  mock.model,
+		proxy:  mock.proxy,
 	}
 }
 
@@ -51,6 +55,7 @@ type dummyConfig struct {
 	agent.Config
 	entity names.Tag
 	model  names.ModelTag
+	proxy  *url.URL
 }
 
 func (dummy dummyConfig) Tag() names.Tag {
@@ -69,10 +74,18 @@ func (dummy dummyConfig) APIInfo() (*api.Info, bool) {
 	}, true
 }
 
+func (dummy dummyConfig) Value(key string) string {
+	return ""
+}
+
 func (dummy dummyConfig) OldPassword() string {
 	return "old"
 }
 
+func (dummy dummyConfig) APIProxy() *url.URL {
+	return dummy.proxy
+}
+
 type mockSetter struct {
 	stub *testing.Stub
 	agent.ConfigSetter
@@ -197,6 +210,7 @@ func openCalls(model names.ModelTag, entity names.Tag, passwords ...string) []te
 				DialAddressInterval: 200 * time.Millisecond,
 				DialTimeout:         3 * time.Second,
 				Timeout:             time.Minute,
+				AddressHealth:       apicaller.AgentAddressHealth(dummyConfig{}, loggo.GetLogger("test")),
 			}},
 		}
 	}
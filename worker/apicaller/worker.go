@@ -23,15 +23,27 @@ var logger interface{}
 // The lack of error return is considered and intentional; it signals the
 // transfer of responsibility for the connection from the caller to the
 // worker.
-func newAPIConnWorker(conn api.Connection) worker.Worker {
-	w := &apiConnWorker{conn: conn}
+func newAPIConnWorker(conn api.Connection, health *api.AddressHealth) worker.Worker {
+	w := &apiConnWorker{conn: conn, health: health}
 	w.tomb.Go(w.loop)
 	return w
 }
 
 type apiConnWorker struct {
-	tomb tomb.Tomb
-	conn api.Connection
+	tomb   tomb.Tomb
+	conn   api.Connection
+	health *api.AddressHealth
+}
+
+// Report is shown in the juju_engine_report.
+func (w *apiConnWorker) Report() map[string]interface{} {
+	result := map[string]interface{}{
+		"address": w.conn.Addr(),
+	}
+	if w.health != nil {
+		result["address-health"] = w.health.Report()
+	}
+	return result
 }
 
 // Kill is part of the worker.Worker interface.
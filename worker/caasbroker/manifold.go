@@ -78,8 +78,14 @@ func manifoldOutput(in worker.Worker, out interface{}) error {
 		*result = inTracker.Broker()
 	case *storage.ProviderRegistry:
 		*result = inTracker.Broker()
+	case *environs.CredentialScoper:
+		scoper, ok := inTracker.Broker().(environs.CredentialScoper)
+		if !ok {
+			return errors.NotSupportedf("scoped credentials")
+		}
+		*result = scoper
 	default:
-		return errors.Errorf("expected *caas.Broker, *storage.ProviderRegistry or *environs.CloudDestroyer, got %T", out)
+		return errors.Errorf("expected *caas.Broker, *storage.ProviderRegistry, *environs.CloudDestroyer or *environs.CredentialScoper, got %T", out)
 	}
 	return nil
 }
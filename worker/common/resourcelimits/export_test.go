@@ -0,0 +1,15 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcelimits
+
+// NewLimiterForTest returns a Limiter that writes its slice to
+// systemdDir, reads cgroup statistics from cgroupRoot and runs
+// systemctl with run.
+func NewLimiterForTest(unitName, systemdDir, cgroupRoot string, run func(string, ...string) error) *Limiter {
+	l := NewLimiter(unitName)
+	l.systemdDir = systemdDir
+	l.cgroupRoot = cgroupRoot
+	l.runCommand = run
+	return l
+}
@@ -0,0 +1,242 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcelimits
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+const (
+	systemdDir = "/etc/systemd/system"
+	cgroupRoot = "/sys/fs/cgroup"
+
+	// parentSlice is the slice the unit slices are created in; systemd
+	// derives it from the "juju-" prefix of their names.
+	parentSlice = "juju.slice"
+)
+
+// Limits describes the resources available to the workload processes
+// of a unit.
+type Limits struct {
+	// CPUQuota is the CPU time available, as a percentage of a single
+	// CPU. Zero means unlimited.
+	CPUQuota uint64
+
+	// MemoryLimit is the memory available, in MiB. Zero means
+	// unlimited.
+	MemoryLimit uint64
+}
+
+// IsZero returns true if no limits are set.
+func (l Limits) IsZero() bool {
+	return l.CPUQuota == 0 && l.MemoryLimit == 0
+}
+
+// Limiter applies resource limits to the workload processes of a unit
+// by running them in a systemd slice, and reports when the processes
+// in the slice have been throttled.
+type Limiter struct {
+	unitName   string
+	slice      string
+	systemdDir string
+	cgroupRoot string
+	runCommand func(name string, args ...string) error
+
+	active        bool
+	cpuThrottled  uint64
+	memoryReached uint64
+}
+
+// NewLimiter returns a Limiter for the named unit.
+func NewLimiter(unitName string) *Limiter {
+	return &Limiter{
+		unitName:   unitName,
+		slice:      SliceName(unitName),
+		systemdDir: systemdDir,
+		cgroupRoot: cgroupRoot,
+		runCommand: runCommand,
+	}
+}
+
+// SliceName returns the name of the systemd slice that the workload
+// processes of the named unit run in.
+func SliceName(unitName string) string {
+	return "juju-" + strings.NewReplacer("-", "_", "/", "_").Replace(unitName) + ".slice"
+}
+
+// Slice returns the name of the systemd slice that the workload
+// processes of the unit should run in, or "" if the unit has no limits.
+func (l *Limiter) Slice() string {
+	if !l.active {
+		return ""
+	}
+	return l.slice
+}
+
+// Apply writes the unit's slice with the given limits, and (re)starts
+// it. If the limits are zero, the slice is removed. Applying the same
+// limits again does nothing. An errors.NotSupported error is returned
+// if the machine isn't running systemd.
+func (l *Limiter) Apply(limits Limits) error {
+	if _, err := os.Stat(l.systemdDir); os.IsNotExist(err) {
+		return errors.NotSupportedf("resource limits without systemd")
+	}
+	path := filepath.Join(l.systemdDir, l.slice)
+	existing, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Annotatef(err, "reading slice %q", l.slice)
+	}
+
+	if limits.IsZero() {
+		l.active = false
+		if existing == nil {
+			return nil
+		}
+		if err := os.Remove(path); err != nil {
+			return errors.Annotatef(err, "removing slice %q", l.slice)
+		}
+		return errors.Trace(l.runCommand("systemctl", "daemon-reload"))
+	}
+
+	content := l.sliceConfig(limits)
+	if bytes.Equal(existing, content) {
+		l.active = true
+		return nil
+	}
+	if err := ioutil.WriteFile(path, content, 0644); err != nil {
+		return errors.Annotatef(err, "writing slice %q", l.slice)
+	}
+	if err := l.runCommand("systemctl", "daemon-reload"); err != nil {
+		return errors.Trace(err)
+	}
+	if err := l.runCommand("systemctl", "start", l.slice); err != nil {
+		return errors.Trace(err)
+	}
+	l.active = true
+	return nil
+}
+
+// sliceConfig returns the systemd unit file for the slice. Both
+// MemoryMax and the older MemoryLimit are written, so that the limit
+// applies on hosts using either cgroup hierarchy.
+func (l *Limiter) sliceConfig(limits Limits) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "[Unit]\nDescription=Workload resource limits for juju unit %s\n\n[Slice]\n", l.unitName)
+	if limits.CPUQuota > 0 {
+		fmt.Fprintf(&buf, "CPUAccounting=true\nCPUQuota=%d%%\n", limits.CPUQuota)
+	}
+	if limits.MemoryLimit > 0 {
+		fmt.Fprintf(&buf, "MemoryAccounting=true\nMemoryMax=%dM\nMemoryLimit=%dM\n", limits.MemoryLimit, limits.MemoryLimit)
+	}
+	return buf.Bytes()
+}
+
+// Throttled returns the ways in which the processes in the unit's
+// slice have been throttled since Throttled was last called.
+func (l *Limiter) Throttled() ([]string, error) {
+	if !l.active {
+		return nil, nil
+	}
+	var reasons []string
+
+	cpuThrottled, err := l.cpuThrottled()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if cpuThrottled > l.cpuThrottled {
+		reasons = append(reasons, "cpu throttled")
+	}
+	l.cpuThrottled = cpuThrottled
+
+	memoryReached, err := l.memoryReached()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if memoryReached > l.memoryReached {
+		reasons = append(reasons, "memory limit reached")
+	}
+	l.memoryReached = memoryReached
+
+	return reasons, nil
+}
+
+// cpuThrottled returns the number of periods in which the slice was
+// throttled for exceeding its CPU quota.
+func (l *Limiter) cpuThrottled() (uint64, error) {
+	for _, dir := range []string{
+		filepath.Join(l.cgroupRoot, parentSlice, l.slice),
+		filepath.Join(l.cgroupRoot, "cpu,cpuacct", parentSlice, l.slice),
+	} {
+		stats, err := readStats(filepath.Join(dir, "cpu.stat"))
+		if os.IsNotExist(errors.Cause(err)) {
+			continue
+		} else if err != nil {
+			return 0, errors.Trace(err)
+		}
+		return stats["nr_throttled"], nil
+	}
+	// No process has run in the slice yet.
+	return 0, nil
+}
+
+// memoryReached returns the number of times the slice hit its memory
+// limit.
+func (l *Limiter) memoryReached() (uint64, error) {
+	stats, err := readStats(filepath.Join(l.cgroupRoot, parentSlice, l.slice, "memory.events"))
+	if err == nil {
+		return stats["max"] + stats["oom_kill"], nil
+	} else if !os.IsNotExist(errors.Cause(err)) {
+		return 0, errors.Trace(err)
+	}
+	data, err := ioutil.ReadFile(filepath.Join(l.cgroupRoot, "memory", parentSlice, l.slice, "memory.failcnt"))
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.Trace(err)
+	}
+	count, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	return count, errors.Trace(err)
+}
+
+// readStats reads a cgroup file of "key value" lines.
+func readStats(path string) (map[string]uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	stats := make(map[string]uint64)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		stats[fields[0]] = value
+	}
+	return stats, errors.Trace(scanner.Err())
+}
+
+func runCommand(name string, args ...string) error {
+	out, err := exec.Command(name, args...).CombinedOutput()
+	if err != nil {
+		return errors.Annotatef(err, "running %s %s: %s", name, strings.Join(args, " "), bytes.TrimSpace(out))
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcelimits_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/common/resourcelimits"
+)
+
+type limiterSuite struct {
+	systemdDir string
+	cgroupRoot string
+	commands   []string
+	limiter    *resourcelimits.Limiter
+}
+
+var _ = gc.Suite(&limiterSuite{})
+
+func (s *limiterSuite) SetUpTest(c *gc.C) {
+	s.systemdDir = c.MkDir()
+	s.cgroupRoot = c.MkDir()
+	s.commands = nil
+	s.limiter = resourcelimits.NewLimiterForTest("my-app/0", s.systemdDir, s.cgroupRoot,
+		func(name string, args ...string) error {
+			s.commands = append(s.commands, name+" "+strings.Join(args, " "))
+			return nil
+		},
+	)
+}
+
+func (s *limiterSuite) slicePath() string {
+	return filepath.Join(s.systemdDir, "juju-my_app_0.slice")
+}
+
+func (s *limiterSuite) writeCgroupFile(c *gc.C, dir, name, content string) {
+	dir = filepath.Join(s.cgroupRoot, dir, "juju.slice", "juju-my_app_0.slice")
+	c.Assert(os.MkdirAll(dir, 0755), jc.ErrorIsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644), jc.ErrorIsNil)
+}
+
+func (s *limiterSuite) TestSliceName(c *gc.C) {
+	c.Assert(resourcelimits.SliceName("mysql/0"), gc.Equals, "juju-mysql_0.slice")
+	c.Assert(resourcelimits.SliceName("my-app/10"), gc.Equals, "juju-my_app_10.slice")
+}
+
+func (s *limiterSuite) TestApply(c *gc.C) {
+	c.Assert(s.limiter.Slice(), gc.Equals, "")
+
+	err := s.limiter.Apply(resourcelimits.Limits{CPUQuota: 150, MemoryLimit: 512})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.limiter.Slice(), gc.Equals, "juju-my_app_0.slice")
+	c.Assert(s.commands, jc.DeepEquals, []string{
+		"systemctl daemon-reload",
+		"systemctl start juju-my_app_0.slice",
+	})
+
+	data, err := ioutil.ReadFile(s.slicePath())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, `
+[Unit]
+Description=Workload resource limits for juju unit my-app/0
+
+[Slice]
+CPUAccounting=true
+CPUQuota=150%
+MemoryAccounting=true
+MemoryMax=512M
+MemoryLimit=512M
+`[1:])
+}
+
+func (s *limiterSuite) TestApplyUnchanged(c *gc.C) {
+	limits := resourcelimits.Limits{CPUQuota: 50}
+	c.Assert(s.limiter.Apply(limits), jc.ErrorIsNil)
+	s.commands = nil
+	c.Assert(s.limiter.Apply(limits), jc.ErrorIsNil)
+	c.Assert(s.commands, gc.HasLen, 0)
+	c.Assert(s.limiter.Slice(), gc.Equals, "juju-my_app_0.slice")
+}
+
+func (s *limiterSuite) TestApplyZeroRemovesSlice(c *gc.C) {
+	c.Assert(s.limiter.Apply(resourcelimits.Limits{MemoryLimit: 1024}), jc.ErrorIsNil)
+	s.commands = nil
+
+	c.Assert(s.limiter.Apply(resourcelimits.Limits{}), jc.ErrorIsNil)
+	c.Assert(s.limiter.Slice(), gc.Equals, "")
+	c.Assert(s.commands, jc.DeepEquals, []string{"systemctl daemon-reload"})
+	_, err := os.Stat(s.slicePath())
+	c.Assert(os.IsNotExist(err), jc.IsTrue)
+
+	// Without a slice there is nothing to do.
+	s.commands = nil
+	c.Assert(s.limiter.Apply(resourcelimits.Limits{}), jc.ErrorIsNil)
+	c.Assert(s.commands, gc.HasLen, 0)
+}
+
+func (s *limiterSuite) TestApplyWithoutSystemd(c *gc.C) {
+	limiter := resourcelimits.NewLimiterForTest("mysql/0", filepath.Join(s.systemdDir, "missing"), s.cgroupRoot,
+		func(string, ...string) error { return nil },
+	)
+	err := limiter.Apply(resourcelimits.Limits{CPUQuota: 100})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *limiterSuite) TestThrottledInactive(c *gc.C) {
+	reasons, err := s.limiter.Throttled()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reasons, gc.HasLen, 0)
+}
+
+func (s *limiterSuite) TestThrottledNoProcesses(c *gc.C) {
+	c.Assert(s.limiter.Apply(resourcelimits.Limits{CPUQuota: 100}), jc.ErrorIsNil)
+	reasons, err := s.limiter.Throttled()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reasons, gc.HasLen, 0)
+}
+
+func (s *limiterSuite) TestThrottledUnified(c *gc.C) {
+	c.Assert(s.limiter.Apply(resourcelimits.Limits{CPUQuota: 100, MemoryLimit: 512}), jc.ErrorIsNil)
+	s.writeCgroupFile(c, "", "cpu.stat", "usage_usec 100\nnr_periods 20\nnr_throttled 3\n")
+	s.writeCgroupFile(c, "", "memory.events", "low 0\nhigh 0\nmax 0\noom 0\noom_kill 0\n")
+
+	reasons, err := s.limiter.Throttled()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reasons, jc.DeepEquals, []string{"cpu throttled"})
+
+	// Nothing has changed since the last check.
+	reasons, err = s.limiter.Throttled()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reasons, gc.HasLen, 0)
+
+	s.writeCgroupFile(c, "", "memory.events", "low 0\nhigh 0\nmax 2\noom 1\noom_kill 1\n")
+	reasons, err = s.limiter.Throttled()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reasons, jc.DeepEquals, []string{"memory limit reached"})
+}
+
+func (s *limiterSuite) TestThrottledLegacy(c *gc.C) {
+	c.Assert(s.limiter.Apply(resourcelimits.Limits{CPUQuota: 100, MemoryLimit: 512}), jc.ErrorIsNil)
+	s.writeCgroupFile(c, "cpu,cpuacct", "cpu.stat", "nr_periods 20\nnr_throttled 1\nthrottled_time 500\n")
+	s.writeCgroupFile(c, "memory", "memory.failcnt", "4\n")
+
+	reasons, err := s.limiter.Throttled()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reasons, jc.DeepEquals, []string{"cpu throttled", "memory limit reached"})
+}
@@ -14,6 +14,7 @@ import (
 	names "github.com/juju/names/v4"
 	shell "github.com/juju/utils/v2/shell"
 	version "github.com/juju/version"
+	url "net/url"
 	reflect "reflect"
 )
 
@@ -121,6 +122,20 @@ func (mr *MockConfigMockRecorder) APIInfo() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIInfo", reflect.TypeOf((*MockConfig)(nil).APIInfo))
 }
 
+// APIProxy mocks base method
+func (m *MockConfig) APIProxy() *url.URL {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APIProxy")
+	ret0, _ := ret[0].(*url.URL)
+	return ret0
+}
+
+// APIProxy indicates an expected call of APIProxy
+func (mr *MockConfigMockRecorder) APIProxy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIProxy", reflect.TypeOf((*MockConfig)(nil).APIProxy))
+}
+
 // CACert mocks base method
 func (m *MockConfig) CACert() string {
 	m.ctrl.T.Helper()
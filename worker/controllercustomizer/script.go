@@ -0,0 +1,83 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllercustomizer
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// RunScript writes the script to a temporary file and runs it,
+// returning its combined output. Scripts without a "#!" line are run
+// with bash.
+//
+// The script is run in its own process group, which is killed if the
+// context is cancelled. Output is collected in a file rather than a
+// pipe, so that a daemon started in the background by the script does
+// not hold RunScript open after the script itself has exited.
+func RunScript(ctx context.Context, script string) ([]byte, error) {
+	f, err := ioutil.TempFile("", "juju-controller-customization")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	if _, err := f.WriteString(script); err != nil {
+		_ = f.Close()
+		return nil, errors.Trace(err)
+	}
+	if err := f.Chmod(0700); err != nil {
+		_ = f.Close()
+		return nil, errors.Trace(err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	out, err := ioutil.TempFile("", "juju-controller-customization-output")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() {
+		_ = out.Close()
+		_ = os.Remove(out.Name())
+	}()
+
+	var cmd *exec.Cmd
+	if strings.HasPrefix(script, "#!") {
+		cmd = exec.Command(f.Name())
+	} else {
+		cmd = exec.Command("/bin/bash", f.Name())
+	}
+	cmd.Stdout = out
+	cmd.Stderr = out
+	setProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		if killErr := killProcessGroup(cmd); killErr != nil {
+			logger.Warningf("killing controller customization script: %v", killErr)
+		}
+		<-done
+		err = ctx.Err()
+	}
+
+	output, readErr := ioutil.ReadFile(out.Name())
+	if readErr != nil && err == nil {
+		err = readErr
+	}
+	return output, errors.Trace(err)
+}
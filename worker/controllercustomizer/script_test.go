@@ -0,0 +1,84 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllercustomizer_test
+
+import (
+	"context"
+	"runtime"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/controllercustomizer"
+)
+
+type ScriptSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ScriptSuite{})
+
+func (s *ScriptSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	if runtime.GOOS == "windows" {
+		c.Skip("controller customization scripts are not run on windows")
+	}
+}
+
+func (s *ScriptSuite) TestRunScript(c *gc.C) {
+	output, err := controllercustomizer.RunScript(context.Background(), "#!/bin/sh\necho hello\n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(output), gc.Equals, "hello\n")
+}
+
+func (s *ScriptSuite) TestRunScriptWithoutInterpreter(c *gc.C) {
+	output, err := controllercustomizer.RunScript(context.Background(), "echo ${BASH_VERSION:+bash}\n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(output), gc.Equals, "bash\n")
+}
+
+func (s *ScriptSuite) TestRunScriptFails(c *gc.C) {
+	output, err := controllercustomizer.RunScript(context.Background(), "#!/bin/sh\necho oops\nexit 3\n")
+	c.Assert(err, gc.ErrorMatches, "exit status 3")
+	c.Assert(string(output), gc.Equals, "oops\n")
+}
+
+func (s *ScriptSuite) TestRunScriptWithBackgroundProcess(c *gc.C) {
+	// The background process keeps running after the script exits, but
+	// mustn't stop RunScript from returning.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		output, err := controllercustomizer.RunScript(context.Background(), "#!/bin/sh\nsleep 600 &\necho started\n")
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(string(output), gc.Equals, "started\n")
+	}()
+	select {
+	case <-done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for script")
+	}
+}
+
+func (s *ScriptSuite) TestRunScriptCancelled(c *gc.C) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		output, err := controllercustomizer.RunScript(ctx, "#!/bin/sh\necho waiting\nsleep 600 &\nwait\n")
+		c.Check(errors.Cause(err), gc.Equals, context.Canceled)
+		c.Check(string(output), gc.Equals, "waiting\n")
+	}()
+	time.Sleep(coretesting.ShortWait)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for script to be killed")
+	}
+}
@@ -0,0 +1,17 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllercustomizer
+
+import (
+	"os/exec"
+)
+
+// setProcessGroup does nothing on windows, where controllers are not
+// run.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup kills the process started by the command.
+func killProcessGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
@@ -0,0 +1,221 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package controllercustomizer provides a worker which runs the
+// controller's controller-customization-script on each controller
+// machine, so that site-specific setup such as installing a monitoring
+// agent or setting kernel parameters is applied to every controller,
+// including those added later by enable-ha. The outcome is recorded
+// against the controller node and shown by show-controller.
+package controllercustomizer
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/catacomb"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.worker.controllercustomizer")
+
+// scriptTimeout is how long the script is allowed to run before it is
+// killed and marked as failed.
+const scriptTimeout = 30 * time.Minute
+
+// Backend defines the state methods used by the worker.
+type Backend interface {
+	ControllerConfig() (controller.Config, error)
+	WatchControllerConfig() NotifyWatcher
+}
+
+// NotifyWatcher is the watcher returned by Backend.WatchControllerConfig.
+type NotifyWatcher interface {
+	worker.Worker
+	Changes() <-chan struct{}
+}
+
+// ControllerNode is the controller node the worker runs the script on.
+type ControllerNode interface {
+	Id() string
+	Refresh() error
+	Customization() (state.ControllerCustomization, bool)
+	SetCustomization(state.ControllerCustomization) error
+}
+
+// RunScriptFunc runs the script, returning its combined output. The
+// script should be killed if the context is cancelled.
+type RunScriptFunc func(ctx context.Context, script string) ([]byte, error)
+
+// Config holds the configuration and dependencies for the worker.
+type Config struct {
+	Backend   Backend
+	Node      ControllerNode
+	RunScript RunScriptFunc
+	Clock     clock.Clock
+}
+
+// Validate returns an error if the config cannot be used to start
+// the worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Node == nil {
+		return errors.NotValidf("nil Node")
+	}
+	if config.RunScript == nil {
+		return errors.NotValidf("nil RunScript")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// NewWorker returns a worker which runs the controller customization
+// script on the controller node whenever it has not yet been run
+// there.
+func NewWorker(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Worker runs the controller customization script.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	watcher := w.config.Backend.WatchControllerConfig()
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("controller config watcher closed")
+			}
+			if err := w.customize(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// customize runs the script if it has not already been run on the
+// node. A script which fails or times out is not run again until it
+// changes. Nor is one which was still running when the agent stopped:
+// it may have restarted the machine, so it is marked as failed rather
+// than run again.
+func (w *Worker) customize() error {
+	cfg, err := w.config.Backend.ControllerConfig()
+	if err != nil {
+		return errors.Annotate(err, "getting controller config")
+	}
+	script := cfg.ControllerCustomizationScript()
+	if script == "" {
+		return nil
+	}
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(script)))
+
+	if err := w.config.Node.Refresh(); err != nil {
+		return errors.Trace(err)
+	}
+	current, ok := w.config.Node.Customization()
+	if ok && current.Hash == hash {
+		if current.Status != state.CustomizationRunning {
+			return nil
+		}
+		logger.Errorf("controller customization script was interrupted on controller %s", w.config.Node.Id())
+		return errors.Trace(w.setStatus(hash, state.CustomizationFailed, "interrupted before completion"))
+	}
+
+	if err := w.setStatus(hash, state.CustomizationRunning, ""); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("running controller customization script on controller %s", w.config.Node.Id())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	timedOut := make(chan struct{})
+	go func() {
+		select {
+		case <-w.catacomb.Dying():
+			cancel()
+		case <-w.config.Clock.After(scriptTimeout):
+			close(timedOut)
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	output, err := w.config.RunScript(ctx, script)
+	logger.Debugf("controller customization script output:\n%s", output)
+	select {
+	case <-w.catacomb.Dying():
+		// The script was killed; it is marked as failed when the
+		// worker restarts.
+		return w.catacomb.ErrDying()
+	case <-timedOut:
+		err = errors.Errorf("timed out after %v", scriptTimeout)
+	default:
+	}
+	if err != nil {
+		message := err.Error()
+		if last := lastLine(output); last != "" {
+			message = fmt.Sprintf("%s: %s", message, last)
+		}
+		logger.Errorf("controller customization script failed: %s", message)
+		return errors.Trace(w.setStatus(hash, state.CustomizationFailed, message))
+	}
+	return errors.Trace(w.setStatus(hash, state.CustomizationCompleted, ""))
+}
+
+func (w *Worker) setStatus(hash string, status state.CustomizationStatus, message string) error {
+	err := w.config.Node.SetCustomization(state.ControllerCustomization{
+		Hash:    hash,
+		Status:  status,
+		Message: message,
+		Since:   w.config.Clock.Now(),
+	})
+	return errors.Annotatef(err, "setting controller customization status to %s", status)
+}
+
+// lastLine returns the last non-empty line of the output, which is
+// usually the most useful in explaining a failure.
+func lastLine(output []byte) string {
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
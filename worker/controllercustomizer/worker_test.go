@@ -0,0 +1,318 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllercustomizer_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/controllercustomizer"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+	backend *fakeBackend
+	node    *fakeNode
+	clock   *testclock.Clock
+
+	mu        sync.Mutex
+	runErr    error
+	runOutput string
+	runBlocks bool
+	scripts   chan string
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+const script = "#!/bin/sh\nsysctl -w vm.swappiness=10\n"
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &fakeBackend{
+		cfg: controller.Config{controller.ControllerCustomizationScript: script},
+		watcher: &fakeWatcher{
+			changes: make(chan struct{}),
+			stopped: make(chan struct{}),
+		},
+	}
+	s.node = &fakeNode{statuses: make(chan state.ControllerCustomization, 10)}
+	s.clock = testclock.NewClock(time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.runErr = nil
+	s.runOutput = ""
+	s.runBlocks = false
+	s.scripts = make(chan string, 10)
+}
+
+func (s *WorkerSuite) runScript(ctx context.Context, script string) ([]byte, error) {
+	s.scripts <- script
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.runBlocks {
+		<-ctx.Done()
+		return []byte(s.runOutput), ctx.Err()
+	}
+	return []byte(s.runOutput), s.runErr
+}
+
+func (s *WorkerSuite) newWorker(c *gc.C) *controllercustomizer.Worker {
+	w, err := controllercustomizer.NewWorker(controllercustomizer.Config{
+		Backend:   s.backend,
+		Node:      s.node,
+		RunScript: s.runScript,
+		Clock:     s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func hash(script string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(script)))
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	_, err := controllercustomizer.NewWorker(controllercustomizer.Config{})
+	c.Assert(err, gc.ErrorMatches, "nil Backend not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *WorkerSuite) TestRunsScript(c *gc.C) {
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sendChange(c)
+	s.assertStatus(c, state.CustomizationRunning, "")
+	s.assertRun(c, script)
+	s.assertStatus(c, state.CustomizationCompleted, "")
+
+	// The script isn't run again until it changes.
+	s.sendChange(c)
+	s.assertNotRun(c)
+
+	newScript := "sysctl -w vm.swappiness=20\n"
+	s.backend.setConfig(controller.Config{controller.ControllerCustomizationScript: newScript})
+	s.sendChange(c)
+	s.assertStatus(c, state.CustomizationRunning, "")
+	s.assertRun(c, newScript)
+	customization := s.assertStatus(c, state.CustomizationCompleted, "")
+	c.Assert(customization.Hash, gc.Equals, hash(newScript))
+	c.Assert(customization.Since, gc.Equals, s.clock.Now())
+}
+
+func (s *WorkerSuite) TestNoScript(c *gc.C) {
+	s.backend.setConfig(controller.Config{})
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sendChange(c)
+	s.assertNotRun(c)
+}
+
+func (s *WorkerSuite) TestAlreadyRun(c *gc.C) {
+	s.node.customization = &state.ControllerCustomization{
+		Hash:   hash(script),
+		Status: state.CustomizationFailed,
+	}
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sendChange(c)
+	s.assertNotRun(c)
+}
+
+func (s *WorkerSuite) TestInterruptedRunFails(c *gc.C) {
+	s.node.customization = &state.ControllerCustomization{
+		Hash:   hash(script),
+		Status: state.CustomizationRunning,
+	}
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	// The script may have rebooted the machine, so it isn't run again.
+	s.sendChange(c)
+	s.assertStatus(c, state.CustomizationFailed, "interrupted before completion")
+	s.assertNotRun(c)
+
+	s.sendChange(c)
+	s.assertNotRun(c)
+}
+
+func (s *WorkerSuite) TestScriptTimesOut(c *gc.C) {
+	s.runBlocks = true
+	s.runOutput = "waiting for lock\n"
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sendChange(c)
+	s.assertStatus(c, state.CustomizationRunning, "")
+	s.assertRun(c, script)
+	err := s.clock.WaitAdvance(30*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStatus(c, state.CustomizationFailed, "timed out after 30m0s: waiting for lock")
+	workertest.CheckAlive(c, w)
+}
+
+func (s *WorkerSuite) TestKilledWhileRunning(c *gc.C) {
+	s.runBlocks = true
+	w := s.newWorker(c)
+	defer workertest.DirtyKill(c, w)
+
+	s.sendChange(c)
+	s.assertStatus(c, state.CustomizationRunning, "")
+	s.assertRun(c, script)
+	workertest.CleanKill(c, w)
+
+	// The status is left as running, to be marked as failed when the
+	// worker restarts.
+	customization, ok := s.node.Customization()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(customization.Status, gc.Equals, state.CustomizationRunning)
+}
+
+func (s *WorkerSuite) TestScriptFails(c *gc.C) {
+	s.runErr = errors.New("exit status 1")
+	s.runOutput = "installing agent\nE: Unable to locate package monitoring-agent\n"
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sendChange(c)
+	s.assertStatus(c, state.CustomizationRunning, "")
+	s.assertRun(c, script)
+	s.assertStatus(c, state.CustomizationFailed, "exit status 1: E: Unable to locate package monitoring-agent")
+
+	// The failure doesn't stop the worker, and the script isn't run
+	// again until it changes.
+	s.sendChange(c)
+	s.assertNotRun(c)
+	workertest.CheckAlive(c, w)
+}
+
+func (s *WorkerSuite) TestWatcherClosed(c *gc.C) {
+	w := s.newWorker(c)
+	defer workertest.DirtyKill(c, w)
+
+	close(s.backend.watcher.changes)
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "controller config watcher closed")
+}
+
+func (s *WorkerSuite) sendChange(c *gc.C) {
+	select {
+	case s.backend.watcher.changes <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending change")
+	}
+}
+
+func (s *WorkerSuite) assertRun(c *gc.C, expect string) {
+	select {
+	case script := <-s.scripts:
+		c.Assert(script, gc.Equals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for script to run")
+	}
+}
+
+func (s *WorkerSuite) assertNotRun(c *gc.C) {
+	select {
+	case script := <-s.scripts:
+		c.Fatalf("unexpected script run: %q", script)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) assertStatus(c *gc.C, status state.CustomizationStatus, message string) state.ControllerCustomization {
+	select {
+	case customization := <-s.node.statuses:
+		c.Assert(customization.Status, gc.Equals, status)
+		c.Assert(customization.Message, gc.Equals, message)
+		return customization
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for status %s", status)
+	}
+	panic("unreachable")
+}
+
+type fakeBackend struct {
+	mu      sync.Mutex
+	cfg     controller.Config
+	watcher *fakeWatcher
+}
+
+func (b *fakeBackend) setConfig(cfg controller.Config) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.cfg = cfg
+}
+
+func (b *fakeBackend) ControllerConfig() (controller.Config, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.cfg, nil
+}
+
+func (b *fakeBackend) WatchControllerConfig() controllercustomizer.NotifyWatcher {
+	return b.watcher
+}
+
+type fakeNode struct {
+	mu            sync.Mutex
+	customization *state.ControllerCustomization
+	statuses      chan state.ControllerCustomization
+}
+
+func (n *fakeNode) Id() string {
+	return "0"
+}
+
+func (n *fakeNode) Refresh() error {
+	return nil
+}
+
+func (n *fakeNode) Customization() (state.ControllerCustomization, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.customization == nil {
+		return state.ControllerCustomization{}, false
+	}
+	return *n.customization, true
+}
+
+func (n *fakeNode) SetCustomization(customization state.ControllerCustomization) error {
+	n.mu.Lock()
+	n.customization = &customization
+	n.mu.Unlock()
+	n.statuses <- customization
+	return nil
+}
+
+type fakeWatcher struct {
+	changes chan struct{}
+	stopped chan struct{}
+	once    sync.Once
+}
+
+func (w *fakeWatcher) Changes() <-chan struct{} {
+	return w.changes
+}
+
+func (w *fakeWatcher) Kill() {
+	w.once.Do(func() { close(w.stopped) })
+}
+
+func (w *fakeWatcher) Wait() error {
+	<-w.stopped
+	return nil
+}
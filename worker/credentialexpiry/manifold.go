@@ -0,0 +1,73 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialexpiry
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/dependency"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/credentialvalidator"
+)
+
+// ManifoldConfig describes how to create a worker that checks whether
+// the model's cloud credential is about to expire.
+type ManifoldConfig struct {
+	APICallerName string
+	Clock         clock.Clock
+
+	Period    time.Duration
+	NewFacade func(base.APICaller) (Facade, error)
+	NewWorker func(Config) (worker.Worker, error)
+	Logger    Logger
+}
+
+// Manifold returns a dependency.Manifold that runs a credential expiry
+// worker according to the supplied configuration.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.APICallerName,
+		},
+		Start: func(context dependency.Context) (worker.Worker, error) {
+			if config.Clock == nil {
+				return nil, errors.NotValidf("nil Clock")
+			}
+			var apiCaller base.APICaller
+			if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+				return nil, errors.Trace(err)
+			}
+			facade, err := config.NewFacade(apiCaller)
+			if err != nil {
+				return nil, errors.Annotatef(err, "cannot create facade")
+			}
+
+			worker, err := config.NewWorker(Config{
+				ExpiryChecker: facade,
+				Clock:         config.Clock,
+				Period:        config.Period,
+				Logger:        config.Logger,
+			})
+			if err != nil {
+				return nil, errors.Annotatef(err, "cannot create worker")
+			}
+			return worker, nil
+		},
+	}
+}
+
+// NewAPIFacade returns a Facade backed by the supplied APICaller.
+func NewAPIFacade(apiCaller base.APICaller) (Facade, error) {
+	return credentialvalidator.NewFacade(apiCaller), nil
+}
+
+// Facade has all the controller methods used by the credential expiry
+// worker.
+type Facade interface {
+	ExpiryChecker
+}
@@ -0,0 +1,80 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialexpiry_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/dependency"
+	dt "github.com/juju/worker/v2/dependency/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/credentialexpiry"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func (s *ManifoldSuite) TestInputs(c *gc.C) {
+	manifold := credentialexpiry.Manifold(credentialexpiry.ManifoldConfig{
+		APICallerName: "api-caller",
+	})
+	c.Check(manifold.Inputs, jc.DeepEquals, []string{"api-caller"})
+}
+
+func (s *ManifoldSuite) TestMissingAPICaller(c *gc.C) {
+	manifold := credentialexpiry.Manifold(credentialexpiry.ManifoldConfig{
+		APICallerName: "api-caller",
+		Clock:         testclock.NewClock(time.Time{}),
+	})
+	_, err := manifold.Start(dt.StubContext(nil, map[string]interface{}{
+		"api-caller": dependency.ErrMissing,
+	}))
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+}
+
+func (s *ManifoldSuite) TestStart(c *gc.C) {
+	checker := &fakeChecker{}
+	apiCaller := &fakeAPICaller{}
+	clock := testclock.NewClock(time.Time{})
+	var config credentialexpiry.Config
+	manifold := credentialexpiry.Manifold(credentialexpiry.ManifoldConfig{
+		APICallerName: "api-caller",
+		Clock:         clock,
+		Period:        time.Hour,
+		NewFacade: func(caller base.APICaller) (credentialexpiry.Facade, error) {
+			c.Check(caller, gc.Equals, base.APICaller(apiCaller))
+			return checker, nil
+		},
+		NewWorker: func(cfg credentialexpiry.Config) (worker.Worker, error) {
+			config = cfg
+			return nil, errors.New("boom")
+		},
+		Logger: coretesting.NoopLogger{},
+	})
+	_, err := manifold.Start(dt.StubContext(nil, map[string]interface{}{
+		"api-caller": apiCaller,
+	}))
+	c.Assert(err, gc.ErrorMatches, "cannot create worker: boom")
+	c.Assert(config, jc.DeepEquals, credentialexpiry.Config{
+		ExpiryChecker: checker,
+		Clock:         clock,
+		Period:        time.Hour,
+		Logger:        coretesting.NoopLogger{},
+	})
+}
+
+type fakeAPICaller struct {
+	base.APICaller
+}
@@ -0,0 +1,123 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialexpiry
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v2"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/api/credentialvalidator"
+)
+
+// ExpiryChecker exposes the capability required by the worker.
+type ExpiryChecker interface {
+	// CheckModelCredentialExpiry has the controller check whether the
+	// model's cloud credential is about to expire, notifying the
+	// credential expiry webhook of any change.
+	CheckModelCredentialExpiry() (credentialvalidator.CredentialExpiry, error)
+}
+
+// Config defines the operation of a credential expiry worker.
+type Config struct {
+	// ExpiryChecker is the worker's view of the controller.
+	ExpiryChecker ExpiryChecker
+
+	// Clock is the worker's view of time.
+	Clock clock.Clock
+
+	// Period is the time between credential expiry checks.
+	Period time.Duration
+
+	// Logger is the logger used by this worker.
+	Logger Logger
+}
+
+// Logger is the logging interface used by the worker.
+type Logger interface {
+	Debugf(message string, args ...interface{})
+	Infof(message string, args ...interface{})
+	Warningf(message string, args ...interface{})
+}
+
+// Validate returns an error if the configuration cannot be expected
+// to start a functional worker.
+func (config Config) Validate() error {
+	if config.ExpiryChecker == nil {
+		return errors.NotValidf("nil ExpiryChecker")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Period <= 0 {
+		return errors.NotValidf("non-positive Period")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that checks the expiry of the model's cloud
+// credential once when started and subsequently every Period.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &expiryWorker{
+		config: config,
+	}
+	w.tomb.Go(w.loop)
+	return w, nil
+}
+
+type expiryWorker struct {
+	tomb   tomb.Tomb
+	config Config
+}
+
+func (w *expiryWorker) loop() error {
+	var delay time.Duration
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.config.Clock.After(delay):
+			w.check()
+		}
+		delay = w.config.Period
+	}
+}
+
+// check checks the credential's expiry. Failures, such as an unreachable
+// webhook, are logged rather than returned, so that they are retried at
+// the next check rather than as soon as the worker is restarted.
+func (w *expiryWorker) check() {
+	expiry, err := w.config.ExpiryChecker.CheckModelCredentialExpiry()
+	if err != nil {
+		w.config.Logger.Warningf("cannot check cloud credential expiry: %v", err)
+		return
+	}
+	switch expiry.Status {
+	case "expiring":
+		w.config.Logger.Warningf("cloud credential %q expires at %s", expiry.CloudCredential, expiry.Expiry.Format(time.RFC3339))
+	case "expired":
+		w.config.Logger.Warningf("cloud credential %q expired at %s", expiry.CloudCredential, expiry.Expiry.Format(time.RFC3339))
+	default:
+		w.config.Logger.Debugf("cloud credential %q is not expiring", expiry.CloudCredential)
+	}
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *expiryWorker) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *expiryWorker) Wait() error {
+	return w.tomb.Wait()
+}
@@ -0,0 +1,109 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialexpiry_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/credentialvalidator"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/credentialexpiry"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock   *testclock.Clock
+	checker *fakeChecker
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(coretesting.ZeroTime())
+	s.checker = &fakeChecker{calls: make(chan struct{}, 10)}
+}
+
+func (s *WorkerSuite) newWorker(c *gc.C) worker.Worker {
+	w, err := credentialexpiry.NewWorker(credentialexpiry.Config{
+		ExpiryChecker: s.checker,
+		Clock:         s.clock,
+		Period:        time.Hour,
+		Logger:        coretesting.NoopLogger{},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *WorkerSuite) waitCall(c *gc.C) {
+	select {
+	case <-s.checker.calls:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for expiry check")
+	}
+}
+
+func (s *WorkerSuite) waitNoCall(c *gc.C) {
+	select {
+	case <-s.checker.calls:
+		c.Fatalf("unexpected expiry check")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	_, err := credentialexpiry.NewWorker(credentialexpiry.Config{
+		ExpiryChecker: s.checker,
+		Clock:         s.clock,
+		Logger:        coretesting.NoopLogger{},
+	})
+	c.Assert(err, gc.ErrorMatches, "non-positive Period not valid")
+}
+
+func (s *WorkerSuite) TestChecksImmediatelyThenEveryPeriod(c *gc.C) {
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCall(c)
+	c.Assert(s.clock.WaitAdvance(time.Hour-time.Nanosecond, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.waitNoCall(c)
+	s.clock.Advance(time.Nanosecond)
+	s.waitCall(c)
+	s.checker.CheckCallNames(c, "CheckModelCredentialExpiry", "CheckModelCredentialExpiry")
+}
+
+func (s *WorkerSuite) TestCheckErrorIsNotFatal(c *gc.C) {
+	s.checker.SetErrors(errors.New("webhook unavailable"))
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCall(c)
+	c.Assert(s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.waitCall(c)
+	workertest.CheckAlive(c, w)
+}
+
+type fakeChecker struct {
+	testing.Stub
+	calls chan struct{}
+}
+
+func (f *fakeChecker) CheckModelCredentialExpiry() (credentialvalidator.CredentialExpiry, error) {
+	f.AddCall("CheckModelCredentialExpiry")
+	f.calls <- struct{}{}
+	return credentialvalidator.CredentialExpiry{
+		CloudCredential: "cloud/user/credential",
+		Status:          "expiring",
+		Expiry:          coretesting.ZeroTime().Add(48 * time.Hour),
+	}, f.NextErr()
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/agent/tools"
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/core/logsink"
 	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/core/paths"
 	jujuversion "github.com/juju/juju/version"
@@ -153,7 +154,7 @@ func NewUnitAgent(config UnitAgentConfig) (*UnitAgent, error) {
 
 func (a *UnitAgent) start() (worker.Worker, error) {
 	a.logger.Tracef("starting workers for %q", a.name)
-	loggingContext, bufferedLogger, err := a.initLogging()
+	loggingContext, bufferedLogger, loggingSinks, err := a.initLogging()
 	if err != nil {
 		a.logger.Tracef("init logging failed %s", err)
 		return nil, errors.Trace(err)
@@ -183,6 +184,7 @@ func (a *UnitAgent) start() (worker.Worker, error) {
 	a.logger.Tracef("creating unit manifolds for %q", a.name)
 	manifolds := a.unitManifolds(UnitManifoldsConfig{
 		LoggingContext:      loggingContext,
+		LoggingSinks:        loggingSinks,
 		Agent:               a,
 		LogSource:           bufferedLogger.Logs(),
 		LeadershipGuarantee: 30 * time.Second,
@@ -228,7 +230,7 @@ func (a *UnitAgent) running() bool {
 	return a.workerRunning
 }
 
-func (a *UnitAgent) initLogging() (*loggo.Context, *logsender.BufferedLogWriter, error) {
+func (a *UnitAgent) initLogging() (*loggo.Context, *logsender.BufferedLogWriter, logsink.Sinks, error) {
 	loggingContext := loggo.NewContext(loggo.INFO)
 
 	logFilename := agent.LogFilename(a.agentConf)
@@ -244,19 +246,29 @@ func (a *UnitAgent) initLogging() (*loggo.Context, *logsender.BufferedLogWriter,
 		MaxBackups: 2,
 		Compress:   true,
 	}
-	if err := loggingContext.AddWriter(
-		"file", loggo.NewSimpleWriter(writer, loggo.DefaultFormatter)); err != nil {
+	bufferedLogger := logsender.NewBufferedLogWriter(1048576)
+
+	// Each writer is a log sink, so that the model's logging config can
+	// give it its own level.
+	loggingSinks := logsink.Sinks{
+		logsink.File:       logsink.NewWriter(loggo.NewSimpleWriter(writer, loggo.DefaultFormatter), loggo.UNSPECIFIED),
+		logsink.Controller: logsink.NewWriter(bufferedLogger, loggo.UNSPECIFIED),
+		logsink.Syslog:     logsink.NewWriter(logsink.NewSyslogWriter("juju-"+a.tag.String()), logsink.Disabled),
+	}
+	if err := loggingContext.AddWriter("file", loggingSinks[logsink.File]); err != nil {
 		a.logger.Errorf("unable to configure file logging for unit %q: %v", a.name, err)
 	}
-
-	bufferedLogger, err := logsender.InstallBufferedLogWriter(loggingContext, 1048576)
-	if err != nil {
-		return nil, nil, errors.Annotate(err, "unable to add buffered log writer")
+	if err := loggingContext.AddWriter("buffered-logs", loggingSinks[logsink.Controller]); err != nil {
+		bufferedLogger.Close()
+		return nil, nil, nil, errors.Annotate(err, "unable to add buffered log writer")
+	}
+	if err := loggingContext.AddWriter("syslog", loggingSinks[logsink.Syslog]); err != nil {
+		a.logger.Errorf("unable to configure syslog logging for unit %q: %v", a.name, err)
 	}
 	// Add line for starting agent to logging context.
 	loggingContext.GetLogger("juju").Infof("Starting unit workers for %q", a.name)
 	a.setupLogging(loggingContext, a.agentConf)
-	return loggingContext, bufferedLogger, nil
+	return loggingContext, bufferedLogger, loggingSinks, nil
 }
 
 // ChangeConfig modifies this configuration using the given mutator.
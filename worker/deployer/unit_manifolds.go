@@ -18,6 +18,7 @@ import (
 	commonapi "github.com/juju/juju/api/common"
 	msapi "github.com/juju/juju/api/meterstatus"
 	"github.com/juju/juju/cmd/jujud/agent/engine"
+	"github.com/juju/juju/core/logsink"
 	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/worker"
@@ -47,6 +48,10 @@ type UnitManifoldsConfig struct {
 	// for the unit get tagged with the right source.
 	LoggingContext *loggo.Context
 
+	// LoggingSinks holds the unit's log sinks, whose levels are set
+	// from the model's logging config.
+	LoggingSinks logsink.Sinks
+
 	// Agent contains the agent that will be wrapped and made available to
 	// its dependencies via a dependency.Engine.
 	Agent coreagent.Agent
@@ -174,6 +179,7 @@ func UnitManifolds(config UnitManifoldsConfig) dependency.Manifolds {
 			AgentName:       agentName,
 			APICallerName:   apiCallerName,
 			LoggingContext:  config.LoggingContext,
+			Sinks:           config.LoggingSinks,
 			Logger:          config.LoggingContext.GetLogger("juju.worker.logger"),
 			UpdateAgentFunc: config.UpdateLoggerConfig,
 		})),
@@ -0,0 +1,60 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package enginehealthreporter
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/dependency"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/cmd/jujud/agent/engine"
+)
+
+// ManifoldConfig describes the dependencies of an engine health
+// reporter.
+type ManifoldConfig struct {
+	AgentName     string
+	APICallerName string
+
+	// Engine is the dependency engine running the manifold.
+	Engine Reporter
+
+	Clock           clock.Clock
+	Period          time.Duration
+	BounceThreshold int
+
+	NewFacade func(base.APICaller) Facade
+	NewWorker func(Config) (worker.Worker, error)
+}
+
+// start is used by engine.AgentAPIManifold to create a StartFunc.
+func (config ManifoldConfig) start(a agent.Agent, apiCaller base.APICaller) (worker.Worker, error) {
+	machineTag, ok := a.CurrentConfig().Tag().(names.MachineTag)
+	if !ok {
+		return nil, errors.Errorf("this manifold can only be used inside a machine")
+	}
+	return config.NewWorker(Config{
+		Facade:          config.NewFacade(apiCaller),
+		Engine:          config.Engine,
+		MachineTag:      machineTag,
+		Clock:           config.Clock,
+		Period:          config.Period,
+		BounceThreshold: config.BounceThreshold,
+	})
+}
+
+// Manifold returns a dependency.Manifold as configured.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	typedConfig := engine.AgentAPIManifoldConfig{
+		AgentName:     config.AgentName,
+		APICallerName: config.APICallerName,
+	}
+	return engine.AgentAPIManifold(typedConfig, config.start)
+}
@@ -0,0 +1,116 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package enginehealthreporter_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/dependency"
+	dt "github.com/juju/worker/v2/dependency/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/base"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/enginehealthreporter"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func (s *ManifoldSuite) manifold(c *gc.C, w worker.Worker) dependency.Manifold {
+	facade := &fakeFacade{}
+	engine := &fakeEngine{}
+	clock := testclock.NewClock(coretesting.ZeroTime())
+	return enginehealthreporter.Manifold(enginehealthreporter.ManifoldConfig{
+		AgentName:       "agent",
+		APICallerName:   "api-caller",
+		Engine:          engine,
+		Clock:           clock,
+		Period:          time.Minute,
+		BounceThreshold: 3,
+		NewFacade: func(base.APICaller) enginehealthreporter.Facade {
+			return facade
+		},
+		NewWorker: func(config enginehealthreporter.Config) (worker.Worker, error) {
+			c.Check(config.Facade, gc.Equals, facade)
+			c.Check(config.Engine, gc.Equals, engine)
+			c.Check(config.MachineTag, gc.Equals, names.NewMachineTag("4"))
+			c.Check(config.Clock, gc.Equals, clock)
+			c.Check(config.Period, gc.Equals, time.Minute)
+			c.Check(config.BounceThreshold, gc.Equals, 3)
+			return w, nil
+		},
+	})
+}
+
+func (s *ManifoldSuite) TestInputs(c *gc.C) {
+	c.Check(s.manifold(c, nil).Inputs, jc.DeepEquals, []string{"agent", "api-caller"})
+}
+
+func (s *ManifoldSuite) TestStartMissingAPICaller(c *gc.C) {
+	context := dt.StubContext(nil, map[string]interface{}{
+		"agent":      &fakeAgent{tag: names.NewMachineTag("4")},
+		"api-caller": dependency.ErrMissing,
+	})
+	w, err := s.manifold(c, nil).Start(context)
+	c.Assert(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+	c.Assert(w, gc.IsNil)
+}
+
+func (s *ManifoldSuite) TestStartSuccess(c *gc.C) {
+	expect := &fakeWorker{}
+	context := dt.StubContext(nil, map[string]interface{}{
+		"agent":      &fakeAgent{tag: names.NewMachineTag("4")},
+		"api-caller": &fakeCaller{},
+	})
+	w, err := s.manifold(c, expect).Start(context)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w, gc.Equals, expect)
+}
+
+func (s *ManifoldSuite) TestStartNotMachine(c *gc.C) {
+	context := dt.StubContext(nil, map[string]interface{}{
+		"agent":      &fakeAgent{tag: names.NewUnitTag("mysql/0")},
+		"api-caller": &fakeCaller{},
+	})
+	w, err := s.manifold(c, &fakeWorker{}).Start(context)
+	c.Assert(err, gc.ErrorMatches, "this manifold can only be used inside a machine")
+	c.Assert(w, gc.IsNil)
+}
+
+type fakeAgent struct {
+	agent.Agent
+	tag names.Tag
+}
+
+func (mock *fakeAgent) CurrentConfig() agent.Config {
+	return &fakeConfig{tag: mock.tag}
+}
+
+type fakeConfig struct {
+	agent.Config
+	tag names.Tag
+}
+
+func (mock *fakeConfig) Tag() names.Tag {
+	return mock.tag
+}
+
+type fakeCaller struct {
+	base.APICaller
+}
+
+type fakeWorker struct {
+	worker.Worker
+}
@@ -0,0 +1,15 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package enginehealthreporter
+
+import (
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/enginehealthreporter"
+)
+
+// NewFacade creates a Facade from a base.APICaller.
+// It's a sensible value for ManifoldConfig.NewFacade.
+func NewFacade(apiCaller base.APICaller) Facade {
+	return enginehealthreporter.NewClient(apiCaller)
+}
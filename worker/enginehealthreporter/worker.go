@@ -0,0 +1,243 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package enginehealthreporter provides a worker which periodically
+// summarises the health of the workers in a machine agent's dependency
+// engine and reports the unhealthy ones to the controller, so they can
+// be seen in machine status without access to the agent's
+// introspection socket.
+//
+// The workers of units deployed by the machine agent are included,
+// named by unit: "mysql/0/uniter", for example.
+package enginehealthreporter
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v4"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/dependency"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+var logger = loggo.GetLogger("juju.worker.enginehealthreporter")
+
+const (
+	// HealthFailing is reported for a worker which has stopped with an
+	// error and has not been restarted.
+	HealthFailing = "failing"
+
+	// HealthBouncing is reported for a worker which has been started
+	// at least BounceThreshold times since the previous report.
+	HealthBouncing = "bouncing"
+)
+
+// Facade exposes the controller capabilities required by the worker.
+type Facade interface {
+	ReportEngineHealth(names.MachineTag, []params.EngineWorkerHealth) error
+}
+
+// Reporter exposes the report of a dependency engine.
+type Reporter interface {
+	Report() map[string]interface{}
+}
+
+// Config defines the operation of an engine health reporter.
+type Config struct {
+	// Facade is used to report the engine's health to the controller.
+	Facade Facade
+
+	// Engine is the dependency engine whose health is reported.
+	Engine Reporter
+
+	// MachineTag identifies the machine agent running the engine.
+	MachineTag names.MachineTag
+
+	// Clock is the worker's view of time.
+	Clock clock.Clock
+
+	// Period is the time between samples of the engine's report.
+	Period time.Duration
+
+	// BounceThreshold is the number of times a worker must be started
+	// between two samples for it to be reported as bouncing.
+	BounceThreshold int
+}
+
+// Validate returns an error if the configuration cannot be expected
+// to start a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Engine == nil {
+		return errors.NotValidf("nil Engine")
+	}
+	if config.MachineTag.Id() == "" {
+		return errors.NotValidf("empty MachineTag")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Period <= 0 {
+		return errors.NotValidf("non-positive Period")
+	}
+	if config.BounceThreshold <= 0 {
+		return errors.NotValidf("non-positive BounceThreshold")
+	}
+	return nil
+}
+
+// NewWorker returns a worker which samples the engine's report every
+// Period, and reports the engine's unhealthy workers whenever they
+// change.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &reporterWorker{
+		config: config,
+	}
+	w.tomb.Go(w.loop)
+	return w, nil
+}
+
+type reporterWorker struct {
+	tomb   tomb.Tomb
+	config Config
+
+	// startCounts holds the start count of each manifold at the
+	// previous sample.
+	startCounts map[string]int
+
+	// reported holds the workers last reported successfully; it is
+	// nil until the first report is made.
+	reported []params.EngineWorkerHealth
+}
+
+func (w *reporterWorker) loop() error {
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.config.Clock.After(w.config.Period):
+			w.check()
+		}
+	}
+}
+
+// check samples the engine's report and reports any change in its
+// health. Failures to report are logged rather than returned, so that
+// this worker doesn't itself bounce while the controller is unhappy;
+// the report is retried after the next sample.
+func (w *reporterWorker) check() {
+	workers := w.unhealthyWorkers()
+	if w.reported != nil && reflect.DeepEqual(workers, w.reported) {
+		return
+	}
+	if err := w.config.Facade.ReportEngineHealth(w.config.MachineTag, workers); err != nil {
+		logger.Warningf("cannot report engine health: %v", err)
+		return
+	}
+	w.reported = workers
+}
+
+// unhealthyWorkers returns the workers in the engine's report which are
+// failing or bouncing, sorted by name. It never returns nil.
+func (w *reporterWorker) unhealthyWorkers() []params.EngineWorkerHealth {
+	startCounts := make(map[string]int)
+	workers := w.engineUnhealthyWorkers("", w.config.Engine.Report(), startCounts)
+	w.startCounts = startCounts
+	sort.Slice(workers, func(i, j int) bool {
+		return workers[i].Name < workers[j].Name
+	})
+	return workers
+}
+
+// engineUnhealthyWorkers returns the failing or bouncing workers in the
+// given engine report, and in the engines of any units deployed by its
+// workers. Worker names are prefixed with the given prefix, and the
+// start count of every worker is recorded in startCounts.
+func (w *reporterWorker) engineUnhealthyWorkers(
+	prefix string, engineReport map[string]interface{}, startCounts map[string]int,
+) []params.EngineWorkerHealth {
+	manifolds, _ := engineReport["manifolds"].(map[string]interface{})
+	workers := []params.EngineWorkerHealth{}
+	for manifoldName, value := range manifolds {
+		report, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name := prefix + manifoldName
+		for unitName, unitReport := range unitEngineReports(report) {
+			workers = append(workers, w.engineUnhealthyWorkers(unitName+"/", unitReport, startCounts)...)
+		}
+
+		state, _ := report[dependency.KeyState].(string)
+		errMessage, _ := report[dependency.KeyError].(string)
+		startCount, _ := report["start-count"].(int)
+		startCounts[name] = startCount
+
+		// Workers waiting on their dependencies, or being restarted
+		// deliberately, are not unhealthy.
+		switch errMessage {
+		case dependency.ErrMissing.Error(), dependency.ErrBounce.Error():
+			errMessage = ""
+		}
+
+		var restarts int
+		if previous, ok := w.startCounts[name]; ok {
+			restarts = startCount - previous
+		}
+		health := params.EngineWorkerHealth{
+			Name:     name,
+			State:    state,
+			Error:    errMessage,
+			Restarts: restarts,
+		}
+		switch {
+		case restarts >= w.config.BounceThreshold:
+			health.Health = HealthBouncing
+		case errMessage != "" && state != "started":
+			health.Health = HealthFailing
+		default:
+			continue
+		}
+		workers = append(workers, health)
+	}
+	return workers
+}
+
+// unitEngineReports returns the dependency engine reports of the units
+// run by the deployer, keyed by unit name, if the given manifold report
+// is the deployer's.
+func unitEngineReports(manifoldReport map[string]interface{}) map[string]map[string]interface{} {
+	report, _ := manifoldReport["report"].(map[string]interface{})
+	units, _ := report["units"].(map[string]interface{})
+	unitWorkers, _ := units["workers"].(map[string]interface{})
+	result := make(map[string]map[string]interface{})
+	for unitName, value := range unitWorkers {
+		unitWorker, _ := value.(map[string]interface{})
+		if unitReport, ok := unitWorker["report"].(map[string]interface{}); ok {
+			result[unitName] = unitReport
+		}
+	}
+	return result
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *reporterWorker) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *reporterWorker) Wait() error {
+	return w.tomb.Wait()
+}
@@ -0,0 +1,261 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package enginehealthreporter_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/dependency"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/enginehealthreporter"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock  *testclock.Clock
+	engine *fakeEngine
+	facade *fakeFacade
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(coretesting.ZeroTime())
+	s.engine = &fakeEngine{}
+	s.facade = &fakeFacade{reports: make(chan []params.EngineWorkerHealth, 10)}
+}
+
+func (s *WorkerSuite) config() enginehealthreporter.Config {
+	return enginehealthreporter.Config{
+		Facade:          s.facade,
+		Engine:          s.engine,
+		MachineTag:      names.NewMachineTag("4"),
+		Clock:           s.clock,
+		Period:          time.Minute,
+		BounceThreshold: 3,
+	}
+}
+
+func (s *WorkerSuite) newWorker(c *gc.C) worker.Worker {
+	w, err := enginehealthreporter.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *WorkerSuite) sample(c *gc.C) {
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) waitReport(c *gc.C) []params.EngineWorkerHealth {
+	select {
+	case workers := <-s.facade.reports:
+		return workers
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for engine health report")
+	}
+	return nil
+}
+
+func (s *WorkerSuite) waitNoReport(c *gc.C) {
+	select {
+	case workers := <-s.facade.reports:
+		c.Fatalf("unexpected engine health report %v", workers)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	config.BounceThreshold = 0
+	_, err := enginehealthreporter.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "non-positive BounceThreshold not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *WorkerSuite) TestReportsHealthyEngine(c *gc.C) {
+	s.engine.setManifolds(map[string]interface{}{
+		"machiner": manifoldReport("started", "", 1),
+	})
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sample(c)
+	c.Assert(s.waitReport(c), jc.DeepEquals, []params.EngineWorkerHealth{})
+}
+
+func (s *WorkerSuite) TestReportsFailingWorkers(c *gc.C) {
+	s.engine.setManifolds(map[string]interface{}{
+		"machiner":   manifoldReport("stopped", "boom", 1),
+		"deployer":   manifoldReport("stopped", dependency.ErrMissing.Error(), 0),
+		"api-caller": manifoldReport("started", "", 1),
+	})
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sample(c)
+	c.Assert(s.waitReport(c), jc.DeepEquals, []params.EngineWorkerHealth{{
+		Name:   "machiner",
+		Health: "failing",
+		State:  "stopped",
+		Error:  "boom",
+	}})
+}
+
+func (s *WorkerSuite) TestReportsBouncingWorkers(c *gc.C) {
+	s.engine.setManifolds(map[string]interface{}{
+		"machiner": manifoldReport("started", "", 1),
+		"deployer": manifoldReport("started", "", 1),
+	})
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sample(c)
+	c.Assert(s.waitReport(c), gc.HasLen, 0)
+
+	s.engine.setManifolds(map[string]interface{}{
+		"machiner": manifoldReport("starting", "", 5),
+		"deployer": manifoldReport("started", "", 3),
+	})
+	s.sample(c)
+	c.Assert(s.waitReport(c), jc.DeepEquals, []params.EngineWorkerHealth{{
+		Name:     "machiner",
+		Health:   "bouncing",
+		State:    "starting",
+		Restarts: 4,
+	}})
+}
+
+func (s *WorkerSuite) TestReportsUnitWorkers(c *gc.C) {
+	deployer := func(uniterStartCount int) map[string]interface{} {
+		report := manifoldReport("started", "", 1)
+		report["report"] = map[string]interface{}{
+			"units": map[string]interface{}{
+				"workers": map[string]interface{}{
+					"mysql/0": map[string]interface{}{
+						"report": map[string]interface{}{
+							"manifolds": map[string]interface{}{
+								"uniter": manifoldReport("started", "", uniterStartCount),
+							},
+						},
+					},
+				},
+			},
+		}
+		return report
+	}
+	s.engine.setManifolds(map[string]interface{}{"deployer": deployer(1)})
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sample(c)
+	c.Assert(s.waitReport(c), gc.HasLen, 0)
+
+	s.engine.setManifolds(map[string]interface{}{"deployer": deployer(7)})
+	s.sample(c)
+	c.Assert(s.waitReport(c), jc.DeepEquals, []params.EngineWorkerHealth{{
+		Name:     "mysql/0/uniter",
+		Health:   "bouncing",
+		State:    "started",
+		Restarts: 6,
+	}})
+}
+
+func (s *WorkerSuite) TestReportsOnlyChanges(c *gc.C) {
+	s.engine.setManifolds(map[string]interface{}{
+		"machiner": manifoldReport("stopped", "boom", 1),
+	})
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sample(c)
+	c.Assert(s.waitReport(c), gc.HasLen, 1)
+	s.sample(c)
+	s.waitNoReport(c)
+
+	s.engine.setManifolds(map[string]interface{}{
+		"machiner": manifoldReport("started", "", 2),
+	})
+	s.sample(c)
+	c.Assert(s.waitReport(c), gc.HasLen, 0)
+}
+
+func (s *WorkerSuite) TestRetriesFailedReport(c *gc.C) {
+	s.facade.setError(errors.New("kaboom"))
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sample(c)
+	c.Assert(s.waitReport(c), gc.HasLen, 0)
+
+	s.facade.setError(nil)
+	s.sample(c)
+	c.Assert(s.waitReport(c), gc.HasLen, 0)
+	workertest.CheckAlive(c, w)
+}
+
+func manifoldReport(state, err string, startCount int) map[string]interface{} {
+	report := map[string]interface{}{
+		"state":       state,
+		"start-count": startCount,
+	}
+	if err != "" {
+		report["error"] = err
+	}
+	return report
+}
+
+type fakeEngine struct {
+	mu        sync.Mutex
+	manifolds map[string]interface{}
+}
+
+func (e *fakeEngine) setManifolds(manifolds map[string]interface{}) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.manifolds = manifolds
+}
+
+func (e *fakeEngine) Report() map[string]interface{} {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return map[string]interface{}{
+		"state":     "started",
+		"manifolds": e.manifolds,
+	}
+}
+
+type fakeFacade struct {
+	mu      sync.Mutex
+	err     error
+	reports chan []params.EngineWorkerHealth
+}
+
+func (f *fakeFacade) setError(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+func (f *fakeFacade) ReportEngineHealth(tag names.MachineTag, workers []params.EngineWorkerHealth) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if tag != names.NewMachineTag("4") {
+		return errors.Errorf("unexpected tag %s", tag)
+	}
+	f.reports <- workers
+	return f.err
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/api/firewaller"
 	"github.com/juju/juju/api/remoterelations"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/network"
@@ -96,6 +97,12 @@ type Config struct {
 	Clock  clock.Clock
 	Logger Logger
 
+	// ReconcileInterval is how often the firewaller compares the rules
+	// in the provider with the opened ports in the model, removing any
+	// rules that are no longer backed by opened ports. If zero, the
+	// rules are only reconciled when the firewaller starts.
+	ReconcileInterval time.Duration
+
 	CredentialAPI common.CredentialAPI
 
 	// WatchMachineNotify is called when the Firewaller starts watching the
@@ -130,6 +137,9 @@ func (cfg Config) Validate() error {
 	if cfg.CredentialAPI == nil {
 		return errors.NotValidf("nil Credential Facade")
 	}
+	if cfg.ReconcileInterval < 0 {
+		return errors.NotValidf("negative ReconcileInterval")
+	}
 	return nil
 }
 
@@ -151,7 +161,9 @@ type Firewaller struct {
 	unitds               map[names.UnitTag]*unitData
 	applicationids       map[names.ApplicationTag]*applicationData
 	exposedChange        chan *exposedChange
+	firewallModeChange   chan *firewallModeChange
 	spaceInfos           network.SpaceInfos
+	mode                 string
 	globalMode           bool
 	globalIngressRuleRef map[string]int // map of rule names to count of occurrences
 
@@ -166,6 +178,7 @@ type Firewaller struct {
 	relationIngress            map[names.RelationTag]*remoteRelationData
 	relationWorkerRunner       *worker.Runner
 	pollClock                  clock.Clock
+	reconcileInterval          time.Duration
 	logger                     Logger
 
 	cloudCallContext context.ProviderCallContext
@@ -197,9 +210,12 @@ func NewFirewaller(cfg Config) (worker.Worker, error) {
 		unitds:                     make(map[names.UnitTag]*unitData),
 		applicationids:             make(map[names.ApplicationTag]*applicationData),
 		exposedChange:              make(chan *exposedChange),
+		firewallModeChange:         make(chan *firewallModeChange),
+		mode:                       cfg.Mode,
 		relationIngress:            make(map[names.RelationTag]*remoteRelationData),
 		localRelationsChange:       make(chan *remoteRelationNetworkChange),
 		pollClock:                  clk,
+		reconcileInterval:          cfg.ReconcileInterval,
 		logger:                     cfg.Logger,
 		relationWorkerRunner: worker.NewRunner(worker.RunnerParams{
 			Clock: clk,
@@ -281,12 +297,22 @@ func (fw *Firewaller) loop() error {
 	if err := fw.setUp(); err != nil {
 		return errors.Trace(err)
 	}
-	var reconciled bool
+	var (
+		reconciled bool
+		// reconcileTimer is only started once the initial
+		// reconciliation has been done.
+		reconcileTimer <-chan time.Time
+	)
 	portsChange := fw.portsWatcher.Changes()
 	for {
 		select {
 		case <-fw.catacomb.Dying():
 			return fw.catacomb.ErrDying()
+		case <-reconcileTimer:
+			if err := fw.reconcile(); err != nil {
+				return errors.Trace(err)
+			}
+			reconcileTimer = fw.nextReconcile()
 		case change, ok := <-fw.machinesWatcher.Changes():
 			if !ok {
 				return errors.New("machines watcher closed")
@@ -298,15 +324,10 @@ func (fw *Firewaller) loop() error {
 			}
 			if !reconciled {
 				reconciled = true
-				var err error
-				if fw.globalMode {
-					err = fw.reconcileGlobal()
-				} else {
-					err = fw.reconcileInstances()
-				}
-				if err != nil {
+				if err := fw.reconcile(); err != nil {
 					return errors.Trace(err)
 				}
+				reconcileTimer = fw.nextReconcile()
 			}
 		case change, ok := <-portsChange:
 			if !ok {
@@ -356,6 +377,15 @@ func (fw *Firewaller) loop() error {
 			if err := fw.flushUnits(unitds); err != nil {
 				return errors.Annotate(err, "cannot change firewall ports")
 			}
+		case change := <-fw.firewallModeChange:
+			change.applicationd.firewallMode = change.firewallMode
+			var unitds []*unitData
+			for _, unitd := range change.applicationd.unitds {
+				unitds = append(unitds, unitd)
+			}
+			if err := fw.flushUnits(unitds); err != nil {
+				return errors.Annotate(err, "cannot change firewall ports")
+			}
 		}
 	}
 }
@@ -542,11 +572,16 @@ func (fw *Firewaller) startApplication(app *firewaller.Application) error {
 	if err != nil {
 		return err
 	}
+	firewallMode, err := fw.applicationFirewallMode(app)
+	if err != nil {
+		return err
+	}
 	applicationd := &applicationData{
 		fw:               fw,
 		application:      app,
 		exposed:          exposed,
 		exposedEndpoints: exposedEndpoints,
+		firewallMode:     firewallMode,
 		unitds:           make(map[names.UnitTag]*unitData),
 	}
 	fw.applicationids[app.Tag()] = applicationd
@@ -554,7 +589,7 @@ func (fw *Firewaller) startApplication(app *firewaller.Application) error {
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &applicationd.catacomb,
 		Work: func() error {
-			return applicationd.watchLoop(exposed, exposedEndpoints)
+			return applicationd.watchLoop(exposed, exposedEndpoints, firewallMode)
 		},
 	})
 	if err != nil {
@@ -566,6 +601,48 @@ func (fw *Firewaller) startApplication(app *firewaller.Application) error {
 	return nil
 }
 
+// applicationFirewallMode returns the firewall-mode set in the
+// application's config, or an empty string if the model's firewall-mode
+// applies. The provider can only open ports in the model's firewall-mode,
+// so the only override honoured is "none", which leaves the application's
+// ports to be firewalled by something other than Juju.
+func (fw *Firewaller) applicationFirewallMode(app *firewaller.Application) (string, error) {
+	mode, err := app.FirewallMode()
+	if errors.IsNotImplemented(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	if mode != "" && mode != application.FirewallModeNone && mode != fw.mode {
+		fw.logger.Warningf(
+			"application %q firewall-mode %q is not supported in a model with firewall-mode %q, using %q",
+			app.Name(), mode, fw.mode, fw.mode,
+		)
+		return "", nil
+	}
+	return mode, nil
+}
+
+// nextReconcile returns a channel that fires when the provider's rules
+// should next be reconciled, or nil if periodic reconciliation is disabled.
+func (fw *Firewaller) nextReconcile() <-chan time.Time {
+	if fw.reconcileInterval == 0 {
+		return nil
+	}
+	return fw.pollClock.After(fw.reconcileInterval)
+}
+
+// reconcile brings the provider's firewall rules in line with the model.
+// It is run when the firewaller starts and then periodically, so that
+// rules left behind by units or machines that went away while the
+// firewaller wasn't watching are pruned.
+func (fw *Firewaller) reconcile() error {
+	if fw.globalMode {
+		return fw.reconcileGlobal()
+	}
+	return fw.reconcileInstances()
+}
+
 // reconcileGlobal compares the initially started watcher for machines,
 // units and applications with the opened and closed ports globally and
 // opens and closes the appropriate ports for the whole environment.
@@ -625,7 +702,7 @@ func (fw *Firewaller) reconcileInstances() error {
 		}
 		envInstances, err := fw.environInstances.Instances(fw.cloudCallContext, []instance.Id{instanceId})
 		if err == environs.ErrNoInstances {
-			return nil
+			continue
 		}
 		if err != nil {
 			return err
@@ -634,7 +711,7 @@ func (fw *Firewaller) reconcileInstances() error {
 
 		fwInstance, ok := envInstances[0].(instances.InstanceFirewaller)
 		if !ok {
-			return nil
+			continue
 		}
 
 		initialRules, err := fwInstance.IngressRules(fw.cloudCallContext, machineId)
@@ -834,6 +911,10 @@ func (fw *Firewaller) ingressRulesForMachineUnit(machine *machineData, unit *uni
 	if len(unitPortRanges) == 0 {
 		return nil, nil // no ports opened by the charm
 	}
+	if unit.applicationd.firewallMode == application.FirewallModeNone {
+		fw.logger.Debugf("not firewalling %q: application firewall-mode is %q", unit.tag, application.FirewallModeNone)
+		return nil, nil
+	}
 
 	var rules firewall.IngressRules
 	var err error
@@ -1240,18 +1321,28 @@ type exposedChange struct {
 	exposedEndpoints map[string]params.ExposedEndpoint
 }
 
-// applicationData holds application details and watches exposure changes.
+// firewallModeChange contains the changed firewall-mode for one specific
+// application.
+type firewallModeChange struct {
+	applicationd *applicationData
+	firewallMode string
+}
+
+// applicationData holds application details and watches exposure and
+// firewall-mode changes.
 type applicationData struct {
 	catacomb         catacomb.Catacomb
 	fw               *Firewaller
 	application      *firewaller.Application
 	exposed          bool
 	exposedEndpoints map[string]params.ExposedEndpoint
+	firewallMode     string
 	unitds           map[names.UnitTag]*unitData
 }
 
-// watchLoop watches the application's exposed flag for changes.
-func (ad *applicationData) watchLoop(curExposed bool, curExposedEndpoints map[string]params.ExposedEndpoint) error {
+// watchLoop watches the application's exposed flag and firewall-mode for
+// changes.
+func (ad *applicationData) watchLoop(curExposed bool, curExposedEndpoints map[string]params.ExposedEndpoint, curFirewallMode string) error {
 	appWatcher, err := ad.application.Watch()
 	if err != nil {
 		if params.IsCodeNotFound(err) {
@@ -1262,10 +1353,49 @@ func (ad *applicationData) watchLoop(curExposed bool, curExposedEndpoints map[st
 	if err := ad.catacomb.Add(appWatcher); err != nil {
 		return errors.Trace(err)
 	}
+	// Controllers without per-application firewall modes can't be
+	// watched, leaving firewallModeChanges nil.
+	var firewallModeChanges watcher.NotifyChannel
+	firewallModeWatcher, err := ad.application.WatchFirewallMode()
+	if errors.IsNotImplemented(err) {
+		ad.fw.logger.Debugf("application(%q) firewall-mode not supported by controller", ad.application.Name())
+	} else if params.IsCodeNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	} else {
+		if err := ad.catacomb.Add(firewallModeWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		firewallModeChanges = firewallModeWatcher.Changes()
+	}
 	for {
 		select {
 		case <-ad.catacomb.Dying():
 			return ad.catacomb.ErrDying()
+		case _, ok := <-firewallModeChanges:
+			if !ok {
+				return errors.New("application firewall-mode watcher closed")
+			}
+			newFirewallMode, err := ad.fw.applicationFirewallMode(ad.application)
+			if err != nil {
+				if errors.IsNotFound(err) {
+					ad.fw.logger.Debugf("application(%q).FirewallMode() returned NotFound: %v", ad.application.Name(), err)
+					return nil
+				}
+				return errors.Trace(err)
+			}
+			if newFirewallMode == curFirewallMode {
+				continue
+			}
+			ad.fw.logger.Tracef("application(%q) firewall-mode changed: %q", ad.application.Name(), newFirewallMode)
+
+			curFirewallMode = newFirewallMode
+			select {
+			case <-ad.catacomb.Dying():
+				return ad.catacomb.ErrDying()
+			case ad.fw.firewallModeChange <- &firewallModeChange{ad, newFirewallMode}:
+			}
 		case _, ok := <-appWatcher.Changes():
 			if !ok {
 				return errors.New("application watcher closed")
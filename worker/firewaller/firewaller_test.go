@@ -19,6 +19,7 @@ import (
 	"github.com/juju/utils/v2"
 	"github.com/juju/worker/v2"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/environschema.v1"
 	"gopkg.in/macaroon-bakery.v2/bakery"
 	"gopkg.in/mgo.v2/txn"
 
@@ -30,6 +31,7 @@ import (
 	"github.com/juju/juju/api/remoterelations"
 	apitesting "github.com/juju/juju/api/testing"
 	"github.com/juju/juju/apiserver/params"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/network/firewall"
@@ -187,11 +189,13 @@ func (s *firewallerBaseSuite) startInstance(c *gc.C, m *state.Machine) instances
 type InstanceModeSuite struct {
 	firewallerBaseSuite
 	watchMachineNotify func(tag names.MachineTag)
+	reconcileInterval  time.Duration
 }
 
 var _ = gc.Suite(&InstanceModeSuite{})
 
 func (s *InstanceModeSuite) SetUpTest(c *gc.C) {
+	s.reconcileInterval = 0
 	s.firewallerBaseSuite.setUpTest(c, config.FwInstance)
 }
 
@@ -239,6 +243,7 @@ func (s *InstanceModeSuite) newFirewallerWithClockAndIPV6CIDRSupport(c *gc.C, cl
 		Logger:             loggo.GetLogger("test"),
 		CredentialAPI:      s.credentialsFacade,
 		WatchMachineNotify: s.watchMachineNotify,
+		ReconcileInterval:  s.reconcileInterval,
 	}
 	fw, err := firewaller.NewFirewaller(cfg)
 	c.Assert(err, jc.ErrorIsNil)
@@ -457,6 +462,44 @@ func (s *InstanceModeSuite) TestStartWithState(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *InstanceModeSuite) TestReconcileRemovesOrphanRules(c *gc.C) {
+	s.reconcileInterval = time.Minute
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	err := app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		allEndpoints: {ExposeToCIDRs: []string{firewall.AllNetworksIPV4CIDR}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+
+	mustOpenPortRanges(c, s.State, u, allEndpoints, []network.PortRange{
+		network.MustParsePortRange("80/tcp"),
+	})
+
+	// The mock clock fires immediately, so reconciliation runs
+	// repeatedly once the firewaller has started.
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	s.assertIngressRules(c, inst, m.Id(), firewall.IngressRules{
+		firewall.NewIngressRule(network.MustParsePortRange("80/tcp"), firewall.AllNetworksIPV4CIDR),
+	})
+
+	// Open a port behind the firewaller's back, as if it had been left
+	// behind by a unit that went away while nobody was watching.
+	fwInst, ok := inst.(instances.InstanceFirewaller)
+	c.Assert(ok, jc.IsTrue)
+	err = fwInst.OpenPorts(s.callCtx, m.Id(), firewall.IngressRules{
+		firewall.NewIngressRule(network.MustParsePortRange("8080/tcp"), firewall.AllNetworksIPV4CIDR),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertIngressRules(c, inst, m.Id(), firewall.IngressRules{
+		firewall.NewIngressRule(network.MustParsePortRange("80/tcp"), firewall.AllNetworksIPV4CIDR),
+	})
+}
+
 func (s *InstanceModeSuite) TestStartWithPartialState(c *gc.C) {
 	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -593,6 +636,37 @@ func (s *InstanceModeSuite) TestSetClearExposedApplication(c *gc.C) {
 	s.assertIngressRules(c, inst, m.Id(), nil)
 }
 
+func (s *InstanceModeSuite) TestApplicationFirewallModeNone(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	err := app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		allEndpoints: {ExposeToCIDRs: []string{firewall.AllNetworksIPV4CIDR}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	mustOpenPortRanges(c, s.State, u, allEndpoints, []network.PortRange{
+		network.MustParsePortRange("80/tcp"),
+	})
+
+	s.assertIngressRules(c, inst, m.Id(), firewall.IngressRules{
+		firewall.NewIngressRule(network.MustParsePortRange("80/tcp"), firewall.AllNetworksIPV4CIDR),
+	})
+
+	// Opting the application out of firewalling closes its ports.
+	setApplicationFirewallMode(c, app, "none")
+	s.assertIngressRules(c, inst, m.Id(), nil)
+
+	// Falling back to the model's firewall-mode opens them again.
+	setApplicationFirewallMode(c, app, "")
+	s.assertIngressRules(c, inst, m.Id(), firewall.IngressRules{
+		firewall.NewIngressRule(network.MustParsePortRange("80/tcp"), firewall.AllNetworksIPV4CIDR),
+	})
+}
+
 func (s *InstanceModeSuite) TestRemoveUnit(c *gc.C) {
 	fw := s.newFirewaller(c)
 	defer statetesting.AssertKillAndWait(c, fw)
@@ -1987,3 +2061,19 @@ func mustClosePortRanges(c *gc.C, st *state.State, u *state.Unit, endpointName s
 
 	c.Assert(st.ApplyOperation(unitPortRanges.Changes()), jc.ErrorIsNil)
 }
+
+// setApplicationFirewallMode sets the application's firewall-mode, or
+// resets it to the model's firewall-mode when mode is empty.
+func setApplicationFirewallMode(c *gc.C, app *state.Application, mode string) {
+	changes := coreapplication.ConfigAttributes{}
+	var reset []string
+	if mode == "" {
+		reset = []string{"firewall-mode"}
+	} else {
+		changes["firewall-mode"] = mode
+	}
+	err := app.UpdateApplicationConfig(changes, reset, environschema.Fields{
+		"firewall-mode": {Type: environschema.Tstring},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
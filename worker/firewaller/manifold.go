@@ -4,6 +4,8 @@
 package firewaller
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/worker/v2"
 	"github.com/juju/worker/v2/dependency"
@@ -43,6 +45,9 @@ type ManifoldConfig struct {
 	NewFirewallerFacade          func(base.APICaller) (FirewallerAPI, error)
 	NewFirewallerWorker          func(Config) (worker.Worker, error)
 	NewCredentialValidatorFacade func(base.APICaller) (common.CredentialAPI, error)
+
+	// ReconcileInterval is passed to the worker, see Config.
+	ReconcileInterval time.Duration
 }
 
 // Manifold returns a Manifold that encapsulates the firewaller worker.
@@ -160,6 +165,7 @@ func (cfg ManifoldConfig) start(context dependency.Context) (worker.Worker, erro
 		NewCrossModelFacadeFunc: crossmodelFirewallerFacadeFunc(cfg.NewControllerConnection),
 		CredentialAPI:           credentialAPI,
 		Logger:                  cfg.Logger,
+		ReconcileInterval:       cfg.ReconcileInterval,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -244,7 +244,7 @@ func (s *introspectionSuite) TestEnabledPresenceReporter(c *gc.C) {
 	workertest.CheckKill(c, s.worker)
 	s.recorder = presence.New(testclock.NewClock(time.Now()))
 	s.recorder.Enable()
-	s.recorder.Connect("server", "model-uuid", "agent-1", 42, false, "")
+	s.recorder.Connect("server", "model-uuid", "agent-1", 42, false, "", "")
 	s.startWorker(c)
 
 	response := s.call(c, "/presence")
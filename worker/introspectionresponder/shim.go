@@ -0,0 +1,15 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspectionresponder
+
+import (
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/introspectionresponder"
+)
+
+// NewFacade creates a Facade from a base.APICaller.
+// It's a sensible value for ManifoldConfig.NewFacade.
+func NewFacade(apiCaller base.APICaller) Facade {
+	return introspectionresponder.NewClient(apiCaller)
+}
@@ -0,0 +1,60 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspectionresponder
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// MaxOutputSize is the most output recorded for a single request.
+// Anything beyond it is discarded, and the request reports that the
+// output was truncated.
+const MaxOutputSize = 4 << 20
+
+// NewSocketIntrospector returns a function which queries the
+// introspection worker listening on the abstract domain socket with
+// the given name.
+func NewSocketIntrospector(socketName string) func(path string) ([]byte, error) {
+	client := &http.Client{
+		Transport: &http.Transport{
+			Dial: func(proto, addr string) (net.Conn, error) {
+				return net.Dial("unix", "@"+socketName)
+			},
+		},
+	}
+	return func(path string) ([]byte, error) {
+		return introspect(client, path)
+	}
+}
+
+func introspect(client *http.Client, path string) ([]byte, error) {
+	resp, err := client.Get("http://unix.socket/" + strings.TrimPrefix(path, "/"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+
+	output, err := ioutil.ReadAll(io.LimitReader(resp.Body, MaxOutputSize+1))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf(
+			"response returned %d (%s): %s",
+			resp.StatusCode,
+			http.StatusText(resp.StatusCode),
+			strings.TrimSpace(string(output)),
+		)
+	}
+	if len(output) > MaxOutputSize {
+		return output[:MaxOutputSize], errors.Errorf("output truncated to %d bytes", MaxOutputSize)
+	}
+	return output, nil
+}
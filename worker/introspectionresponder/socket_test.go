@@ -0,0 +1,64 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspectionresponder_test
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/introspectionresponder"
+)
+
+type SocketSuite struct {
+	testing.IsolationSuite
+
+	name string
+}
+
+var _ = gc.Suite(&SocketSuite{})
+
+func (s *SocketSuite) SetUpTest(c *gc.C) {
+	if runtime.GOOS != "linux" {
+		c.Skip("abstract domain sockets not supported on non-linux")
+	}
+	s.IsolationSuite.SetUpTest(c)
+	s.name = fmt.Sprintf("introspectionresponder-test-%d", os.Getpid())
+	l, err := net.Listen("unix", "@"+s.name)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/depengine", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "engine report %s", r.URL.RawQuery)
+	})
+	mux.HandleFunc("/large", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, strings.Repeat("x", introspectionresponder.MaxOutputSize+10))
+	})
+	go http.Serve(l, mux)
+	s.AddCleanup(func(*gc.C) { l.Close() })
+}
+
+func (s *SocketSuite) TestIntrospect(c *gc.C) {
+	output, err := introspectionresponder.NewSocketIntrospector(s.name)("/depengine?debug=1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(output), gc.Equals, "engine report debug=1")
+}
+
+func (s *SocketSuite) TestIntrospectNotFound(c *gc.C) {
+	_, err := introspectionresponder.NewSocketIntrospector(s.name)("/missing")
+	c.Assert(err, gc.ErrorMatches, `response returned 404 \(Not Found\): 404 page not found`)
+}
+
+func (s *SocketSuite) TestIntrospectTruncated(c *gc.C) {
+	output, err := introspectionresponder.NewSocketIntrospector(s.name)("/large")
+	c.Assert(err, gc.ErrorMatches, `output truncated to 4194304 bytes`)
+	c.Assert(output, gc.HasLen, introspectionresponder.MaxOutputSize)
+}
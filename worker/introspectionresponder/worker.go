@@ -0,0 +1,106 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package introspectionresponder provides a worker which answers the
+// introspection requests made of a machine agent through the
+// controller, by querying the agent's own introspection socket.
+package introspectionresponder
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/worker/v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+var logger = loggo.GetLogger("juju.worker.introspectionresponder")
+
+// Facade defines the capabilities required by the worker from the API.
+type Facade interface {
+	WatchRequests() (watcher.StringsWatcher, error)
+	Request(id string) (params.IntrospectionRequest, error)
+	Respond(response params.IntrospectionResponse) error
+}
+
+// WorkerConfig defines the worker's dependencies.
+type WorkerConfig struct {
+	Facade Facade
+
+	// Introspect returns the response from the agent's introspection
+	// socket for the given path.
+	Introspect func(path string) ([]byte, error)
+}
+
+// Validate returns an error if the configuration is not complete.
+func (c WorkerConfig) Validate() error {
+	if c.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if c.Introspect == nil {
+		return errors.NotValidf("nil Introspect")
+	}
+	return nil
+}
+
+// NewWorker returns a worker which responds to the introspection
+// requests made of the agent.
+func NewWorker(config WorkerConfig) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return watcher.NewStringsWorker(watcher.StringsConfig{
+		Handler: &handler{config},
+	})
+}
+
+// handler implements watcher.StringsHandler.
+type handler struct {
+	config WorkerConfig
+}
+
+// SetUp is part of the watcher.StringsHandler interface.
+func (h *handler) SetUp() (watcher.StringsWatcher, error) {
+	return h.config.Facade.WatchRequests()
+}
+
+// Handle is part of the watcher.StringsHandler interface. The watcher
+// reports requests as they are added, completed and removed, so only
+// requests which are still pending are answered.
+func (h *handler) Handle(_ <-chan struct{}, ids []string) error {
+	for _, id := range ids {
+		req, err := h.config.Facade.Request(id)
+		if gone(err) {
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "getting introspection request %q", id)
+		}
+		if req.Completed {
+			continue
+		}
+		logger.Debugf("responding to introspection request %q for %q", id, req.Path)
+		response := params.IntrospectionResponse{Id: id}
+		response.Output, err = h.config.Introspect(req.Path)
+		if err != nil {
+			response.Error = err.Error()
+		}
+		if err := h.config.Facade.Respond(response); gone(err) {
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "responding to introspection request %q", id)
+		}
+	}
+	return nil
+}
+
+// TearDown is part of the watcher.StringsHandler interface.
+func (h *handler) TearDown() error {
+	return nil
+}
+
+// gone returns whether the error indicates that the request has been
+// removed.
+func gone(err error) bool {
+	return params.IsCodeNotFound(err) || params.IsCodeUnauthorized(err)
+}
@@ -0,0 +1,143 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspectionresponder_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/introspectionresponder"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	facade  *mockFacade
+	changes chan []string
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.changes = make(chan []string, 1)
+	s.facade = &mockFacade{
+		watcher: watchertest.NewMockStringsWatcher(s.changes),
+		requests: map[string]params.IntrospectionRequest{
+			"machine-0#0": {Id: "machine-0#0", Path: "/depengine"},
+			"machine-0#1": {Id: "machine-0#1", Path: "/statepool", Completed: true},
+			"machine-0#2": {Id: "machine-0#2", Path: "/debug/pprof/heap"},
+		},
+		responses: make(chan params.IntrospectionResponse, 3),
+	}
+}
+
+func introspect(path string) ([]byte, error) {
+	if path == "/debug/pprof/heap" {
+		return nil, errors.New("boom")
+	}
+	return []byte("output of " + path), nil
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	_, err := introspectionresponder.NewWorker(introspectionresponder.WorkerConfig{
+		Introspect: introspect,
+	})
+	c.Assert(err, gc.ErrorMatches, "nil Facade not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	_, err = introspectionresponder.NewWorker(introspectionresponder.WorkerConfig{
+		Facade: s.facade,
+	})
+	c.Assert(err, gc.ErrorMatches, "nil Introspect not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *WorkerSuite) TestRespondsToPendingRequests(c *gc.C) {
+	w, err := introspectionresponder.NewWorker(introspectionresponder.WorkerConfig{
+		Facade:     s.facade,
+		Introspect: introspect,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	// Requests which are completed or gone are skipped.
+	s.changes <- []string{"machine-0#0", "machine-0#1", "machine-0#2", "machine-0#3"}
+
+	c.Assert(s.nextResponse(c), jc.DeepEquals, params.IntrospectionResponse{
+		Id:     "machine-0#0",
+		Output: []byte("output of /depengine"),
+	})
+	c.Assert(s.nextResponse(c), jc.DeepEquals, params.IntrospectionResponse{
+		Id:    "machine-0#2",
+		Error: "boom",
+	})
+	select {
+	case resp := <-s.facade.responses:
+		c.Fatalf("unexpected response %#v", resp)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestRequestError(c *gc.C) {
+	s.facade.err = errors.New("splat")
+	w, err := introspectionresponder.NewWorker(introspectionresponder.WorkerConfig{
+		Facade:     s.facade,
+		Introspect: introspect,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.changes <- []string{"machine-0#0"}
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, `getting introspection request "machine-0#0": splat`)
+}
+
+func (s *WorkerSuite) nextResponse(c *gc.C) params.IntrospectionResponse {
+	select {
+	case resp := <-s.facade.responses:
+		return resp
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for response")
+	}
+	panic("unreachable")
+}
+
+type mockFacade struct {
+	watcher   watcher.StringsWatcher
+	requests  map[string]params.IntrospectionRequest
+	responses chan params.IntrospectionResponse
+	err       error
+}
+
+func (f *mockFacade) WatchRequests() (watcher.StringsWatcher, error) {
+	return f.watcher, nil
+}
+
+func (f *mockFacade) Request(id string) (params.IntrospectionRequest, error) {
+	if f.err != nil {
+		return params.IntrospectionRequest{}, f.err
+	}
+	req, ok := f.requests[id]
+	if !ok {
+		return params.IntrospectionRequest{}, &params.Error{
+			Message: "permission denied",
+			Code:    params.CodeUnauthorized,
+		}
+	}
+	return req, nil
+}
+
+func (f *mockFacade) Respond(response params.IntrospectionResponse) error {
+	f.responses <- response
+	return nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lifecyclewebhook
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	jujuhttp "github.com/juju/http"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/dependency"
+
+	"github.com/juju/juju/core/multiwatcher"
+	workerstate "github.com/juju/juju/worker/state"
+)
+
+// ManifoldConfig holds the information necessary to run a lifecycle
+// webhook worker in a dependency.Engine.
+type ManifoldConfig struct {
+	ClockName        string
+	StateName        string
+	MultiwatcherName string
+
+	RetryDelay time.Duration
+	NewWorker  func(Config) (worker.Worker, error)
+}
+
+func (config ManifoldConfig) Validate() error {
+	if config.ClockName == "" {
+		return errors.NotValidf("empty ClockName")
+	}
+	if config.StateName == "" {
+		return errors.NotValidf("empty StateName")
+	}
+	if config.MultiwatcherName == "" {
+		return errors.NotValidf("empty MultiwatcherName")
+	}
+	if config.RetryDelay <= 0 {
+		return errors.NotValidf("non-positive RetryDelay")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// Manifold returns a dependency.Manifold that will run a lifecycle
+// webhook worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.ClockName,
+			config.StateName,
+			config.MultiwatcherName,
+		},
+		Start: config.start,
+	}
+}
+
+// start is a method on ManifoldConfig because it's more readable than a closure.
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var clock clock.Clock
+	if err := context.Get(config.ClockName, &clock); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var factory multiwatcher.Factory
+	if err := context.Get(config.MultiwatcherName, &factory); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var stTracker workerstate.StateTracker
+	if err := context.Get(config.StateName, &stTracker); err != nil {
+		return nil, errors.Trace(err)
+	}
+	statePool, err := stTracker.Use()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	w, err := config.NewWorker(Config{
+		ControllerConfig: statePool.SystemState(),
+		WatcherFactory:   factory.WatchController,
+		HTTPClient:       jujuhttp.NewClient(jujuhttp.Config{Logger: logger.Child("http")}),
+		Clock:            clock,
+		RetryDelay:       config.RetryDelay,
+	})
+	if err != nil {
+		_ = stTracker.Done()
+		return nil, errors.Trace(err)
+	}
+	go func() {
+		_ = w.Wait()
+		_ = stTracker.Done()
+	}()
+	return w, nil
+}
+
+// NewWorkerShim calls NewWorker, returning the result as a worker.Worker
+// for use in ManifoldConfig.
+func NewWorkerShim(config Config) (worker.Worker, error) {
+	w, err := NewWorker(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lifecyclewebhook_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/lifecyclewebhook"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+	config lifecyclewebhook.ManifoldConfig
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func (s *ManifoldSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.config = lifecyclewebhook.ManifoldConfig{
+		ClockName:        "clock",
+		StateName:        "state",
+		MultiwatcherName: "multiwatcher",
+		RetryDelay:       time.Second,
+		NewWorker: func(lifecyclewebhook.Config) (worker.Worker, error) {
+			return nil, nil
+		},
+	}
+}
+
+func (s *ManifoldSuite) TestValid(c *gc.C) {
+	c.Check(s.config.Validate(), jc.ErrorIsNil)
+}
+
+func (s *ManifoldSuite) TestInputs(c *gc.C) {
+	c.Check(lifecyclewebhook.Manifold(s.config).Inputs, jc.DeepEquals, []string{"clock", "state", "multiwatcher"})
+}
+
+func (s *ManifoldSuite) TestMissingClockName(c *gc.C) {
+	s.config.ClockName = ""
+	s.checkNotValid(c, "empty ClockName not valid")
+}
+
+func (s *ManifoldSuite) TestMissingStateName(c *gc.C) {
+	s.config.StateName = ""
+	s.checkNotValid(c, "empty StateName not valid")
+}
+
+func (s *ManifoldSuite) TestMissingMultiwatcherName(c *gc.C) {
+	s.config.MultiwatcherName = ""
+	s.checkNotValid(c, "empty MultiwatcherName not valid")
+}
+
+func (s *ManifoldSuite) TestZeroRetryDelay(c *gc.C) {
+	s.config.RetryDelay = 0
+	s.checkNotValid(c, "non-positive RetryDelay not valid")
+}
+
+func (s *ManifoldSuite) TestMissingNewWorker(c *gc.C) {
+	s.config.NewWorker = nil
+	s.checkNotValid(c, "nil NewWorker not valid")
+}
+
+func (s *ManifoldSuite) checkNotValid(c *gc.C, expect string) {
+	err := s.config.Validate()
+	c.Check(err, gc.ErrorMatches, expect)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
@@ -0,0 +1,379 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package lifecyclewebhook provides a controller worker which posts
+// machine, unit and model lifecycle events, across all of the
+// controller's models, to the controller's lifecycle webhook. This lets
+// external systems such as ticketing systems or CMDBs follow a
+// deployment without polling the allwatcher.
+package lifecyclewebhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v4"
+	"github.com/juju/retry"
+	"github.com/juju/worker/v2/catacomb"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/multiwatcher"
+	"github.com/juju/juju/core/status"
+)
+
+var logger = loggo.GetLogger("juju.worker.lifecyclewebhook")
+
+const (
+	// SignatureHeader holds the HMAC-SHA256 signature of the body of
+	// each notification, as "sha256=<hex digest>", when the controller
+	// has a lifecycle webhook secret.
+	SignatureHeader = "X-Juju-Signature"
+
+	// EventHeader holds the name of the event being notified.
+	EventHeader = "X-Juju-Event"
+
+	// requestTimeout is how long to wait for the webhook to respond to
+	// a single notification.
+	requestTimeout = 30 * time.Second
+
+	// deliveryAttempts is how many times a notification is posted
+	// before it is given up on.
+	deliveryAttempts = 5
+)
+
+// Event is posted as JSON to the lifecycle webhook.
+type Event struct {
+	Event       string    `json:"event"`
+	Time        time.Time `json:"time"`
+	ModelUUID   string    `json:"model-uuid"`
+	ModelName   string    `json:"model-name,omitempty"`
+	Entity      string    `json:"entity"`
+	Application string    `json:"application,omitempty"`
+	MachineId   string    `json:"machine-id,omitempty"`
+	InstanceId  string    `json:"instance-id,omitempty"`
+}
+
+// ControllerConfigGetter provides the controller configuration, which
+// holds the webhook's url, secret and the events to post.
+type ControllerConfigGetter interface {
+	ControllerConfig() (controller.Config, error)
+}
+
+// HTTPClient posts notifications to the webhook.
+type HTTPClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Config holds the configuration and dependencies for the worker.
+type Config struct {
+	ControllerConfig ControllerConfigGetter
+	WatcherFactory   func() multiwatcher.Watcher
+	HTTPClient       HTTPClient
+	Clock            clock.Clock
+
+	// RetryDelay is how long to wait before posting a notification
+	// again after the first failure. The delay doubles after each
+	// further failure.
+	RetryDelay time.Duration
+}
+
+// Validate returns an error if the config cannot be used to start
+// the worker.
+func (config Config) Validate() error {
+	if config.ControllerConfig == nil {
+		return errors.NotValidf("nil ControllerConfig")
+	}
+	if config.WatcherFactory == nil {
+		return errors.NotValidf("nil WatcherFactory")
+	}
+	if config.HTTPClient == nil {
+		return errors.NotValidf("nil HTTPClient")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.RetryDelay <= 0 {
+		return errors.NotValidf("non-positive RetryDelay")
+	}
+	return nil
+}
+
+// NewWorker returns a worker which watches all of the controller's
+// models and posts their lifecycle events to the lifecycle webhook.
+func NewWorker(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config:      config,
+		modelNames:  make(map[string]string),
+		provisioned: make(map[multiwatcher.EntityID]bool),
+		started:     make(map[multiwatcher.EntityID]bool),
+		destroyed:   make(map[string]bool),
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Worker posts lifecycle events to the lifecycle webhook.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// modelNames holds the names of the models, by uuid, to include
+	// in the events.
+	modelNames map[string]string
+
+	// provisioned records the machines known to have an instance, and
+	// started the units whose agents are known to have been idle.
+	provisioned map[multiwatcher.EntityID]bool
+	started     map[multiwatcher.EntityID]bool
+
+	// destroyed records the models whose destruction has been posted,
+	// so that it is posted only once.
+	destroyed map[string]bool
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	watcher := w.config.WatcherFactory()
+	defer func() { _ = watcher.Stop() }()
+
+	changes := make(chan []multiwatcher.Delta)
+	watcherErr := make(chan error, 1)
+	go func() {
+		for {
+			deltas, err := watcher.Next()
+			if err != nil {
+				watcherErr <- err
+				return
+			}
+			select {
+			case changes <- deltas:
+			case <-w.catacomb.Dying():
+				return
+			}
+		}
+	}()
+
+	// The first set of deltas describes the world as it is when the
+	// worker starts; only changes after that are posted.
+	first := true
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case err := <-watcherErr:
+			return errors.Annotate(err, "watching lifecycle events")
+		case deltas := <-changes:
+			events := w.process(deltas, first)
+			first = false
+			if len(events) == 0 {
+				continue
+			}
+			if err := w.notify(events); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// process updates the worker's view of the models with the deltas,
+// and returns the lifecycle events they represent. No events are
+// returned for the initial deltas.
+func (w *Worker) process(deltas []multiwatcher.Delta, initial bool) []Event {
+	var events []Event
+	for _, d := range deltas {
+		var event *Event
+		switch entity := d.Entity.(type) {
+		case *multiwatcher.ModelInfo:
+			event = w.processModel(entity, d.Removed)
+		case *multiwatcher.MachineInfo:
+			event = w.processMachine(entity, d.Removed)
+		case *multiwatcher.UnitInfo:
+			event = w.processUnit(entity, d.Removed)
+		}
+		if event != nil && !initial {
+			event.Time = w.config.Clock.Now().UTC()
+			if event.ModelName == "" {
+				event.ModelName = w.modelNames[event.ModelUUID]
+			}
+			events = append(events, *event)
+		}
+	}
+	return events
+}
+
+func (w *Worker) processModel(info *multiwatcher.ModelInfo, removed bool) *Event {
+	if info.Name != "" {
+		w.modelNames[info.ModelUUID] = info.Name
+	}
+	var event *Event
+	if (removed || info.Life == life.Dead) && !w.destroyed[info.ModelUUID] {
+		event = &Event{
+			Event:     controller.LifecycleEventModelDestroyed,
+			ModelUUID: info.ModelUUID,
+			ModelName: w.modelNames[info.ModelUUID],
+			Entity:    names.NewModelTag(info.ModelUUID).String(),
+		}
+		w.destroyed[info.ModelUUID] = true
+	}
+	if removed {
+		delete(w.modelNames, info.ModelUUID)
+		delete(w.destroyed, info.ModelUUID)
+	}
+	return event
+}
+
+func (w *Worker) processMachine(info *multiwatcher.MachineInfo, removed bool) *Event {
+	id := info.EntityID()
+	if removed {
+		delete(w.provisioned, id)
+		return nil
+	}
+	if info.InstanceID == "" || w.provisioned[id] {
+		return nil
+	}
+	w.provisioned[id] = true
+	return &Event{
+		Event:      controller.LifecycleEventMachineProvisioned,
+		ModelUUID:  info.ModelUUID,
+		Entity:     names.NewMachineTag(info.ID).String(),
+		MachineId:  info.ID,
+		InstanceId: info.InstanceID,
+	}
+}
+
+func (w *Worker) processUnit(info *multiwatcher.UnitInfo, removed bool) *Event {
+	id := info.EntityID()
+	event := &Event{
+		ModelUUID:   info.ModelUUID,
+		Entity:      names.NewUnitTag(info.Name).String(),
+		Application: info.Application,
+		MachineId:   info.MachineID,
+	}
+	if removed {
+		delete(w.started, id)
+		event.Event = controller.LifecycleEventUnitRemoved
+		return event
+	}
+	// A unit has started once its agent is first idle, which is
+	// after the install and start hooks have run.
+	if info.AgentStatus.Current != status.Idle || w.started[id] {
+		return nil
+	}
+	w.started[id] = true
+	event.Event = controller.LifecycleEventUnitStarted
+	return event
+}
+
+// notify posts the events enabled in the controller config to the
+// lifecycle webhook, if there is one. Events which cannot be delivered
+// are logged and dropped, rather than holding up later events.
+func (w *Worker) notify(events []Event) error {
+	cfg, err := w.config.ControllerConfig.ControllerConfig()
+	if err != nil {
+		return errors.Annotate(err, "getting controller config")
+	}
+	webhookURL := cfg.LifecycleWebhookURL()
+	if webhookURL == "" {
+		return nil
+	}
+	enabled := cfg.LifecycleWebhookEvents()
+	secret := cfg.LifecycleWebhookSecret()
+	for _, event := range events {
+		if !enabled.Contains(event.Event) {
+			continue
+		}
+		if err := w.deliver(webhookURL, secret, event); err != nil {
+			select {
+			case <-w.catacomb.Dying():
+				return w.catacomb.ErrDying()
+			default:
+			}
+			logger.Errorf("cannot post %s event for %s to lifecycle webhook: %v", event.Event, event.Entity, err)
+		}
+	}
+	return nil
+}
+
+// deliver posts the event to the webhook, retrying with an increasing
+// delay until it is accepted or the attempts run out.
+func (w *Worker) deliver(webhookURL, secret string, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return retry.Call(retry.CallArgs{
+		Func: func() error {
+			return w.post(webhookURL, secret, event.Event, body)
+		},
+		NotifyFunc: func(err error, attempt int) {
+			logger.Debugf("posting %s event for %s, attempt %d: %v", event.Event, event.Entity, attempt, err)
+		},
+		Attempts:    deliveryAttempts,
+		Delay:       w.config.RetryDelay,
+		BackoffFunc: retry.DoubleDelay,
+		Stop:        w.catacomb.Dying(),
+		Clock:       w.config.Clock,
+	})
+}
+
+// post makes a single attempt at posting the body to the webhook. Any
+// response other than 2xx is an error.
+func (w *Worker) post(webhookURL, secret, event string, body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "POST", webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, event)
+	if secret != "" {
+		req.Header.Set(SignatureHeader, Signature(secret, body))
+	}
+
+	resp, err := w.config.HTTPClient.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("lifecycle webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// Signature returns the value of the signature header for a
+// notification body signed with the secret, which receivers can use to
+// check that the notification came from the controller.
+func Signature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,357 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lifecyclewebhook_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/multiwatcher"
+	"github.com/juju/juju/core/status"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/lifecyclewebhook"
+)
+
+const modelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+
+	clock   *testclock.Clock
+	cfg     controller.Config
+	watcher *fakeWatcher
+	client  *fakeHTTPClient
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.cfg = controller.Config{
+		controller.LifecycleWebhookURL:    "https://cmdb.example.com/juju",
+		controller.LifecycleWebhookSecret: "sekrit",
+	}
+	s.watcher = &fakeWatcher{
+		changes: make(chan []multiwatcher.Delta),
+		stopped: make(chan struct{}),
+	}
+	s.client = &fakeHTTPClient{requests: make(chan *http.Request, 10)}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) *lifecyclewebhook.Worker {
+	w, err := lifecyclewebhook.NewWorker(lifecyclewebhook.Config{
+		ControllerConfig: s,
+		WatcherFactory:   func() multiwatcher.Watcher { return s.watcher },
+		HTTPClient:       s.client,
+		Clock:            s.clock,
+		RetryDelay:       time.Second,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+// ControllerConfig is part of the lifecyclewebhook.ControllerConfigGetter
+// interface.
+func (s *WorkerSuite) ControllerConfig() (controller.Config, error) {
+	return s.cfg, nil
+}
+
+func (s *WorkerSuite) sendDeltas(c *gc.C, deltas ...multiwatcher.Delta) {
+	select {
+	case s.watcher.changes <- deltas:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending deltas")
+	}
+}
+
+func (s *WorkerSuite) sendInitialDeltas(c *gc.C) {
+	s.sendDeltas(c,
+		multiwatcher.Delta{Entity: &multiwatcher.ModelInfo{
+			ModelUUID: modelUUID,
+			Name:      "prod",
+			Life:      life.Alive,
+		}},
+		multiwatcher.Delta{Entity: &multiwatcher.MachineInfo{
+			ModelUUID:  modelUUID,
+			ID:         "0",
+			InstanceID: "i-0",
+		}},
+		multiwatcher.Delta{Entity: &multiwatcher.UnitInfo{
+			ModelUUID:   modelUUID,
+			Name:        "mysql/0",
+			Application: "mysql",
+			MachineID:   "0",
+			AgentStatus: multiwatcher.StatusInfo{Current: status.Idle},
+		}},
+	)
+}
+
+func (s *WorkerSuite) nextEvent(c *gc.C) (*http.Request, lifecyclewebhook.Event) {
+	select {
+	case req := <-s.client.requests:
+		body, err := ioutil.ReadAll(req.Body)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/json")
+		if secret := s.cfg.LifecycleWebhookSecret(); secret != "" {
+			c.Check(req.Header.Get(lifecyclewebhook.SignatureHeader), gc.Equals, lifecyclewebhook.Signature(secret, body))
+		}
+		var event lifecyclewebhook.Event
+		err = json.Unmarshal(body, &event)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(req.Header.Get(lifecyclewebhook.EventHeader), gc.Equals, event.Event)
+		return req, event
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for lifecycle event")
+	}
+	return nil, lifecyclewebhook.Event{}
+}
+
+func (s *WorkerSuite) assertNoEvent(c *gc.C) {
+	select {
+	case req := <-s.client.requests:
+		c.Fatalf("unexpected request with event %q", req.Header.Get(lifecyclewebhook.EventHeader))
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestValidateConfig(c *gc.C) {
+	_, err := lifecyclewebhook.NewWorker(lifecyclewebhook.Config{
+		ControllerConfig: s,
+		HTTPClient:       s.client,
+		Clock:            s.clock,
+		RetryDelay:       time.Second,
+	})
+	c.Assert(err, gc.ErrorMatches, "nil WatcherFactory not valid")
+}
+
+func (s *WorkerSuite) TestInitialDeltasNotPosted(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.sendInitialDeltas(c)
+	s.assertNoEvent(c)
+}
+
+func (s *WorkerSuite) TestMachineProvisioned(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+	s.sendInitialDeltas(c)
+
+	machine := &multiwatcher.MachineInfo{ModelUUID: modelUUID, ID: "1"}
+	s.sendDeltas(c, multiwatcher.Delta{Entity: machine})
+	s.assertNoEvent(c)
+
+	provisioned := *machine
+	provisioned.InstanceID = "i-1"
+	s.sendDeltas(c, multiwatcher.Delta{Entity: &provisioned})
+	req, event := s.nextEvent(c)
+	c.Check(req.URL.String(), gc.Equals, "https://cmdb.example.com/juju")
+	c.Check(event, jc.DeepEquals, lifecyclewebhook.Event{
+		Event:      "machine-provisioned",
+		Time:       s.clock.Now().UTC(),
+		ModelUUID:  modelUUID,
+		ModelName:  "prod",
+		Entity:     "machine-1",
+		MachineId:  "1",
+		InstanceId: "i-1",
+	})
+
+	// Later changes to the machine are not posted again.
+	s.sendDeltas(c, multiwatcher.Delta{Entity: &provisioned})
+	s.assertNoEvent(c)
+}
+
+func (s *WorkerSuite) TestUnitStartedAndRemoved(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+	s.sendInitialDeltas(c)
+
+	unit := &multiwatcher.UnitInfo{
+		ModelUUID:   modelUUID,
+		Name:        "mysql/1",
+		Application: "mysql",
+		MachineID:   "1",
+		AgentStatus: multiwatcher.StatusInfo{Current: status.Executing},
+	}
+	s.sendDeltas(c, multiwatcher.Delta{Entity: unit})
+	s.assertNoEvent(c)
+
+	started := *unit
+	started.AgentStatus = multiwatcher.StatusInfo{Current: status.Idle}
+	s.sendDeltas(c, multiwatcher.Delta{Entity: &started})
+	_, event := s.nextEvent(c)
+	c.Check(event, jc.DeepEquals, lifecyclewebhook.Event{
+		Event:       "unit-started",
+		Time:        s.clock.Now().UTC(),
+		ModelUUID:   modelUUID,
+		ModelName:   "prod",
+		Entity:      "unit-mysql-1",
+		Application: "mysql",
+		MachineId:   "1",
+	})
+
+	// Running another hook does not start the unit again.
+	s.sendDeltas(c, multiwatcher.Delta{Entity: unit})
+	s.sendDeltas(c, multiwatcher.Delta{Entity: &started})
+	s.assertNoEvent(c)
+
+	s.sendDeltas(c, multiwatcher.Delta{Removed: true, Entity: &started})
+	_, event = s.nextEvent(c)
+	c.Check(event.Event, gc.Equals, "unit-removed")
+	c.Check(event.Entity, gc.Equals, "unit-mysql-1")
+
+	// Units which were running when the worker started are reported
+	// when removed.
+	s.sendDeltas(c, multiwatcher.Delta{Removed: true, Entity: &multiwatcher.UnitInfo{
+		ModelUUID: modelUUID,
+		Name:      "mysql/0",
+	}})
+	_, event = s.nextEvent(c)
+	c.Check(event.Event, gc.Equals, "unit-removed")
+	c.Check(event.Entity, gc.Equals, "unit-mysql-0")
+}
+
+func (s *WorkerSuite) TestModelDestroyed(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+	s.sendInitialDeltas(c)
+
+	model := &multiwatcher.ModelInfo{ModelUUID: modelUUID, Name: "prod", Life: life.Dead}
+	s.sendDeltas(c, multiwatcher.Delta{Entity: model})
+	_, event := s.nextEvent(c)
+	c.Check(event, jc.DeepEquals, lifecyclewebhook.Event{
+		Event:     "model-destroyed",
+		Time:      s.clock.Now().UTC(),
+		ModelUUID: modelUUID,
+		ModelName: "prod",
+		Entity:    "model-" + modelUUID,
+	})
+
+	// The removal of a dead model is not posted again.
+	s.sendDeltas(c, multiwatcher.Delta{Removed: true, Entity: model})
+	s.assertNoEvent(c)
+}
+
+func (s *WorkerSuite) TestEventsFiltered(c *gc.C) {
+	s.cfg[controller.LifecycleWebhookEvents] = []interface{}{"unit-removed"}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+	s.sendInitialDeltas(c)
+
+	s.sendDeltas(c,
+		multiwatcher.Delta{Entity: &multiwatcher.MachineInfo{ModelUUID: modelUUID, ID: "1", InstanceID: "i-1"}},
+		multiwatcher.Delta{Removed: true, Entity: &multiwatcher.UnitInfo{ModelUUID: modelUUID, Name: "mysql/0"}},
+	)
+	_, event := s.nextEvent(c)
+	c.Check(event.Event, gc.Equals, "unit-removed")
+	s.assertNoEvent(c)
+}
+
+func (s *WorkerSuite) TestNoWebhook(c *gc.C) {
+	delete(s.cfg, controller.LifecycleWebhookURL)
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+	s.sendInitialDeltas(c)
+
+	s.sendDeltas(c, multiwatcher.Delta{Removed: true, Entity: &multiwatcher.UnitInfo{ModelUUID: modelUUID, Name: "mysql/0"}})
+	s.assertNoEvent(c)
+}
+
+func (s *WorkerSuite) TestUnsigned(c *gc.C) {
+	delete(s.cfg, controller.LifecycleWebhookSecret)
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+	s.sendInitialDeltas(c)
+
+	s.sendDeltas(c, multiwatcher.Delta{Removed: true, Entity: &multiwatcher.UnitInfo{ModelUUID: modelUUID, Name: "mysql/0"}})
+	req, _ := s.nextEvent(c)
+	c.Check(req.Header.Get(lifecyclewebhook.SignatureHeader), gc.Equals, "")
+}
+
+func (s *WorkerSuite) TestRetriesFailedDelivery(c *gc.C) {
+	s.client.statuses = []int{http.StatusServiceUnavailable, http.StatusInternalServerError}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+	s.sendInitialDeltas(c)
+
+	s.sendDeltas(c, multiwatcher.Delta{Removed: true, Entity: &multiwatcher.UnitInfo{ModelUUID: modelUUID, Name: "mysql/0"}})
+	_, first := s.nextEvent(c)
+
+	err := s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	_, second := s.nextEvent(c)
+
+	// The delay doubles after each failure.
+	err = s.clock.WaitAdvance(2*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	_, third := s.nextEvent(c)
+	s.assertNoEvent(c)
+
+	c.Check(second, jc.DeepEquals, first)
+	c.Check(third, jc.DeepEquals, first)
+}
+
+func (s *WorkerSuite) TestSignature(c *gc.C) {
+	c.Check(
+		lifecyclewebhook.Signature("key", []byte("The quick brown fox jumps over the lazy dog")),
+		gc.Equals,
+		"sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8",
+	)
+}
+
+type fakeWatcher struct {
+	changes chan []multiwatcher.Delta
+	stopped chan struct{}
+	once    sync.Once
+}
+
+func (w *fakeWatcher) Next() ([]multiwatcher.Delta, error) {
+	select {
+	case deltas := <-w.changes:
+		return deltas, nil
+	case <-w.stopped:
+		return nil, multiwatcher.NewErrStopped()
+	}
+}
+
+func (w *fakeWatcher) Stop() error {
+	w.once.Do(func() { close(w.stopped) })
+	return nil
+}
+
+// fakeHTTPClient records the requests made, and responds with each of
+// the statuses in turn, then with 200 OK.
+type fakeHTTPClient struct {
+	mu       sync.Mutex
+	statuses []int
+	requests chan *http.Request
+}
+
+func (f *fakeHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	f.mu.Lock()
+	code := http.StatusOK
+	if len(f.statuses) > 0 {
+		code, f.statuses = f.statuses[0], f.statuses[1:]
+	}
+	f.mu.Unlock()
+
+	f.requests <- req
+	return &http.Response{
+		Status:     http.StatusText(code),
+		StatusCode: code,
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+	}, nil
+}
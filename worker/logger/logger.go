@@ -9,6 +9,7 @@ import (
 	"github.com/juju/names/v4"
 	"github.com/juju/worker/v2"
 
+	"github.com/juju/juju/core/logsink"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/state/mgo"
 )
@@ -28,6 +29,10 @@ type WorkerConfig struct {
 	Logger   Logger
 	Override string
 
+	// Sinks holds the writers for the agent's log sinks, whose levels
+	// are set from the logging config.
+	Sinks logsink.Sinks
+
 	Callback func(string) error
 }
 
@@ -91,16 +96,24 @@ func (l *loggerWorker) setLogging() {
 
 	if loggingConfig != l.lastConfig {
 		logger.Debugf("reconfiguring logging from %q to %q", l.lastConfig, loggingConfig)
+		// This shouldn't fail as the loggingConfig should be
+		// validated by the original Config before it gets here.
+		sinkConfig, err := logsink.ParseConfig(loggingConfig)
+		if err != nil {
+			logger.Warningf("configure loggers failed: %v", err)
+			return
+		}
 		context := l.config.Context
 		context.ResetLoggerLevels()
-		if err := context.ConfigureLoggers(loggingConfig); err != nil {
-			// This shouldn't occur as the loggingConfig should be
-			// validated by the original Config before it gets here.
+		if err := context.ConfigureLoggers(sinkConfig.Modules); err != nil {
 			logger.Warningf("configure loggers failed: %v", err)
 			// Try to reset to what we had before
-			context.ConfigureLoggers(l.lastConfig)
+			if lastConfig, err := logsink.ParseConfig(l.lastConfig); err == nil {
+				context.ConfigureLoggers(lastConfig.Modules)
+			}
 			return
 		}
+		l.config.Sinks.Configure(sinkConfig.Levels)
 		mgo.ConfigureMgoLogging()
 		l.lastConfig = loggingConfig
 		// Save the logging config in the agent.conf file.
@@ -4,6 +4,7 @@
 package logger_test
 
 import (
+	"bytes"
 	"time"
 
 	"github.com/juju/errors"
@@ -14,6 +15,7 @@ import (
 	"github.com/juju/worker/v2"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/core/logsink"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/worker/logger"
 )
@@ -139,6 +141,30 @@ func (s *LoggerSuite) TestConfigOverride(c *gc.C) {
 	s.waitLoggingInfo(c, expected)
 }
 
+func (s *LoggerSuite) TestSinkLevels(c *gc.C) {
+	controllerSink := logsink.NewWriter(loggo.NewSimpleWriter(&bytes.Buffer{}, nil), loggo.UNSPECIFIED)
+	syslogSink := logsink.NewWriter(loggo.NewSimpleWriter(&bytes.Buffer{}, nil), logsink.Disabled)
+	s.config.Sinks = logsink.Sinks{
+		logsink.Controller: controllerSink,
+		logsink.Syslog:     syslogSink,
+	}
+	s.loggerAPI.config = "<root>=TRACE;sink:controller=WARNING;sink:syslog=ERROR"
+
+	loggingWorker := s.makeLogger(c)
+	defer worker.Stop(loggingWorker)
+
+	// The sink entries configure the sinks, not the loggers.
+	s.waitLoggingInfo(c, "<root>=TRACE")
+	timeout := time.After(testing.LongWait)
+	for syslogSink.Level() != loggo.ERROR || controllerSink.Level() != loggo.WARNING {
+		select {
+		case <-timeout:
+			c.Fatalf("timeout while waiting for sink levels to change")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
 type mockNotifyWatcher struct {
 	changes chan struct{}
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/logger"
+	"github.com/juju/juju/core/logsink"
 )
 
 // Logger represents a loggo logger for the purpose of recording what is going
@@ -28,6 +29,7 @@ type ManifoldConfig struct {
 	AgentName       string
 	APICallerName   string
 	LoggingContext  *loggo.Context
+	Sinks           logsink.Sinks
 	Logger          Logger
 	UpdateAgentFunc func(string) error
 }
@@ -60,6 +62,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				Tag:      currentConfig.Tag(),
 				Logger:   config.Logger,
 				Override: loggingOverride,
+				Sinks:    config.Sinks,
 				Callback: config.UpdateAgentFunc,
 			}
 			return NewLogger(workerConfig)
@@ -37,6 +37,9 @@ type LogStats struct {
 
 	// Dropped is the number of log messages dropped from the queue.
 	Dropped uint64
+
+	// Spooled is the number of log messages written to the spool.
+	Spooled uint64
 }
 
 // LogRecordCh defines the channel type used to send log message
@@ -45,6 +48,10 @@ type LogRecordCh chan *LogRecord
 
 const writerName = "buffered-logs"
 
+// spoolBufferLen is the most log messages held in memory by a
+// BufferedLogWriter once it has a spool.
+const spoolBufferLen = 1024
+
 // InstallBufferedLogWriter creates and returns a new BufferedLogWriter,
 // registering it with Loggo.
 func InstallBufferedLogWriter(context *loggo.Context, maxLen int) (*BufferedLogWriter, error) {
@@ -76,23 +83,30 @@ func UninstallBufferedLogWriter() error {
 // returned by the Logs method.
 //
 // Up to maxLen log messages will be buffered. If this limit is
-// exceeded, the oldest records will be automatically discarded.
+// exceeded, the oldest records will be automatically discarded, unless
+// a spool has been enabled with EnableSpool, in which case they are
+// written to the spool until it is full.
 type BufferedLogWriter struct {
-	maxLen int
-	in     LogRecordCh
-	out    LogRecordCh
+	maxLen  int
+	in      LogRecordCh
+	out     LogRecordCh
+	spoolCh chan *Spool
 
 	mu    sync.Mutex
 	stats LogStats
+
+	spoolMu sync.Mutex
+	spooled bool
 }
 
 // NewBufferedLogWriter returns a new BufferedLogWriter which will
 // cache up to maxLen log messages.
 func NewBufferedLogWriter(maxLen int) *BufferedLogWriter {
 	w := &BufferedLogWriter{
-		maxLen: maxLen,
-		in:     make(LogRecordCh),
-		out:    make(LogRecordCh),
+		maxLen:  maxLen,
+		in:      make(LogRecordCh),
+		out:     make(LogRecordCh),
+		spoolCh: make(chan *Spool),
 	}
 	go w.loop()
 	return w
@@ -100,12 +114,41 @@ func NewBufferedLogWriter(maxLen int) *BufferedLogWriter {
 
 func (w *BufferedLogWriter) loop() {
 	buffer := deque.New()
+	maxLen := w.maxLen
+	var spool *Spool      // Holds the records which overflow the buffer, if enabled.
 	var outCh LogRecordCh // Output channel - set when there's something to send.
 	var outRec *LogRecord // Next LogRecord to send to the output channel.
 
+	// overflow moves the LogRecord at the front of the buffer to the
+	// spool or, if that isn't possible, discards it.
+	overflow := func() {
+		item, _ := buffer.PopFront()
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if spool != nil && spool.Push(item.(*LogRecord)) == nil {
+			w.stats.Spooled++
+			return
+		}
+		outRec.DroppedAfter++
+		w.stats.Dropped++
+	}
+
 	for {
-		// If there's something in the buffer and there's nothing
-		// queued up to send, set up the next LogRecord to send.
+		// If there's something in the spool or the buffer and there's
+		// nothing queued up to send, set up the next LogRecord to send.
+		// The spool holds older records than the buffer, so it is
+		// emptied first.
+		if outCh == nil && spool != nil && spool.Len() > 0 {
+			rec, err := spool.Pop()
+			if err != nil {
+				// The spool can't be read; carry on without it.
+				_ = spool.Close()
+				spool = nil
+			} else if rec != nil {
+				outRec = rec
+				outCh = w.out
+			}
+		}
 		if outCh == nil {
 			if item, haveItem := buffer.PopFront(); haveItem {
 				outRec = item.(*LogRecord)
@@ -116,7 +159,11 @@ func (w *BufferedLogWriter) loop() {
 		select {
 		case inRec, ok := <-w.in:
 			if !ok {
-				// Input channel has been closed; finish up.
+				// Input channel has been closed; finish up. Any
+				// records in the spool are kept for the next run.
+				if spool != nil {
+					_ = spool.Close()
+				}
 				close(w.out)
 				return
 			}
@@ -125,14 +172,22 @@ func (w *BufferedLogWriter) loop() {
 
 			w.mu.Lock()
 			w.stats.Enqueued++
-			if buffer.Len() > w.maxLen {
-				// The buffer has exceeded the limit - discard the
-				// next LogRecord from the front of the queue.
-				buffer.PopFront()
-				outRec.DroppedAfter++
-				w.stats.Dropped++
-			}
 			w.mu.Unlock()
+			if buffer.Len() > maxLen {
+				// The buffer has exceeded the limit - spool or
+				// discard the next LogRecord from the front of the
+				// queue.
+				overflow()
+			}
+
+		case newSpool := <-w.spoolCh:
+			spool = newSpool
+			if maxLen > spoolBufferLen {
+				maxLen = spoolBufferLen
+			}
+			for buffer.Len() > maxLen {
+				overflow()
+			}
 
 		case outCh <- outRec:
 			outCh = nil // Signal that send happened.
@@ -145,6 +200,30 @@ func (w *BufferedLogWriter) loop() {
 
 }
 
+// EnableSpool opens the spool file at path and has the writer move
+// the log messages which don't fit in its memory buffer into the
+// spool, rather than discarding them, until the spool holds maxSize
+// bytes of messages. Once the spool is enabled, fewer messages are held
+// in memory. Messages left in the spool when the writer is closed are
+// sent once the spool is enabled again.
+//
+// EnableSpool does nothing if the writer already has a spool, and
+// must not be called once the writer has been closed.
+func (w *BufferedLogWriter) EnableSpool(path string, maxSize int64) error {
+	w.spoolMu.Lock()
+	defer w.spoolMu.Unlock()
+	if w.spooled {
+		return nil
+	}
+	spool, err := OpenSpool(path, maxSize)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.spooled = true
+	w.spoolCh <- spool
+	return nil
+}
+
 // Write sends a new log message to the writer. This implements the loggo.Writer interface.
 func (w *BufferedLogWriter) Write(entry loggo.Entry) {
 	w.in <- &LogRecord{
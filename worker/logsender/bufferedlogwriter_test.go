@@ -5,6 +5,7 @@ package logsender_test
 
 import (
 	"fmt"
+	"path/filepath"
 	"strconv"
 	"time"
 
@@ -128,6 +129,48 @@ func (s *bufferedLogWriterSuite) TestLimiting(c *gc.C) {
 	})
 }
 
+func (s *bufferedLogWriterSuite) TestSpooling(c *gc.C) {
+	err := s.writer.EnableSpool(filepath.Join(c.MkDir(), "log-spool"), 1024*1024)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Write more logs than the buffer allows; rather than being
+	// dropped, the excess is spooled and sent in order.
+	for i := 0; i < maxLen+3; i++ {
+		s.writer.Write(loggo.Entry{
+			Level:     loggo.INFO,
+			Module:    "module",
+			Filename:  "filename",
+			Line:      42,
+			Timestamp: time.Now(),
+			Message:   fmt.Sprintf("log%d", i),
+		})
+	}
+	for i := 0; i < maxLen+3; i++ {
+		rec := s.receiveOne(c)
+		c.Assert(rec.Message, gc.Equals, fmt.Sprintf("log%d", i))
+		c.Assert(rec.DroppedAfter, gc.Equals, 0)
+	}
+
+	logsendertest.ExpectLogStats(c, s.writer, logsender.LogStats{
+		Enqueued: maxLen + 3,
+		Sent:     maxLen + 3,
+		Spooled:  2,
+	})
+}
+
+func (s *bufferedLogWriterSuite) TestSpoolingFull(c *gc.C) {
+	err := s.writer.EnableSpool(filepath.Join(c.MkDir(), "log-spool"), 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The spool has no room, so logs are dropped as usual.
+	for i := 0; i < maxLen+3; i++ {
+		s.writer.Write(loggo.Entry{Message: fmt.Sprintf("log%d", i)})
+	}
+	rec := s.receiveOne(c)
+	c.Assert(rec.Message, gc.Equals, "log0")
+	c.Assert(rec.DroppedAfter, gc.Equals, 2)
+}
+
 func (s *bufferedLogWriterSuite) TestClose(c *gc.C) {
 	s.writer.Close()
 	s.shouldClose = false // Prevent the usual teardown (calling Close twice will panic)
@@ -34,6 +34,12 @@ var (
 		[]string{},
 		prometheus.Labels{},
 	)
+	jujuLogsenderSpooledTotalDesc = prometheus.NewDesc(
+		"juju_logsender_spooled_total",
+		"Total number of log messages written to the spool.",
+		[]string{},
+		prometheus.Labels{},
+	)
 )
 
 // BufferedLogWriterMetrics is a prometheus.Collector that collects metrics
@@ -48,6 +54,7 @@ func (BufferedLogWriterMetrics) Describe(ch chan<- *prometheus.Desc) {
 	ch <- jujuLogsenderEnqueuedTotalDesc
 	ch <- jujuLogsenderSentTotalDesc
 	ch <- jujuLogsenderDroppedTotalDesc
+	ch <- jujuLogsenderSpooledTotalDesc
 }
 
 // Collect is part of the prometheus.Collector interface.
@@ -73,4 +80,9 @@ func (b BufferedLogWriterMetrics) Collect(ch chan<- prometheus.Metric) {
 		prometheus.CounterValue,
 		float64(stats.Dropped),
 	)
+	ch <- prometheus.MustNewConstMetric(
+		jujuLogsenderSpooledTotalDesc,
+		prometheus.CounterValue,
+		float64(stats.Spooled),
+	)
 }
@@ -43,11 +43,12 @@ func (s *bufferedLogWriterSuite) TestDescribe(c *gc.C) {
 	for desc := range ch {
 		descs = append(descs, desc)
 	}
-	c.Assert(descs, gc.HasLen, 4)
+	c.Assert(descs, gc.HasLen, 5)
 	c.Assert(descs[0].String(), gc.Matches, `.*fqName: "juju_logsender_capacity".*`)
 	c.Assert(descs[1].String(), gc.Matches, `.*fqName: "juju_logsender_enqueued_total".*`)
 	c.Assert(descs[2].String(), gc.Matches, `.*fqName: "juju_logsender_sent_total".*`)
 	c.Assert(descs[3].String(), gc.Matches, `.*fqName: "juju_logsender_dropped_total".*`)
+	c.Assert(descs[4].String(), gc.Matches, `.*fqName: "juju_logsender_spooled_total".*`)
 }
 
 func (s *bufferedLogWriterSuite) TestCollect(c *gc.C) {
@@ -77,9 +78,9 @@ func (s *bufferedLogWriterSuite) TestCollect(c *gc.C) {
 	for metric := range ch {
 		metrics = append(metrics, metric)
 	}
-	c.Assert(metrics, gc.HasLen, 4)
+	c.Assert(metrics, gc.HasLen, 5)
 
-	var dtoMetrics [4]dto.Metric
+	var dtoMetrics [5]dto.Metric
 	for i, metric := range metrics {
 		err := metric.Write(&dtoMetrics[i])
 		c.Assert(err, jc.ErrorIsNil)
@@ -88,10 +89,11 @@ func (s *bufferedLogWriterSuite) TestCollect(c *gc.C) {
 	float64ptr := func(v float64) *float64 {
 		return &v
 	}
-	c.Assert(dtoMetrics, jc.DeepEquals, [4]dto.Metric{
+	c.Assert(dtoMetrics, jc.DeepEquals, [5]dto.Metric{
 		{Counter: &dto.Counter{Value: float64ptr(3)}},
 		{Counter: &dto.Counter{Value: float64ptr(5)}},
 		{Counter: &dto.Counter{Value: float64ptr(3)}},
 		{Counter: &dto.Counter{Value: float64ptr(1)}},
+		{Counter: &dto.Counter{Value: float64ptr(0)}},
 	})
 }
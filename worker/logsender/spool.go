@@ -0,0 +1,213 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logsender
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+)
+
+// DefaultSpoolSize is the default maximum size, in bytes, of the log
+// records held in an agent's log spool.
+const DefaultSpoolSize = 100 * 1024 * 1024
+
+// errSpoolFull is returned by Spool.Push when there is no room for the
+// record.
+var errSpoolFull = errors.New("log spool full")
+
+// spoolRecord is the form in which a LogRecord is written to the spool.
+type spoolRecord struct {
+	Time         time.Time `json:"t"`
+	Module       string    `json:"m"`
+	Location     string    `json:"l,omitempty"`
+	Level        string    `json:"v"`
+	Message      string    `json:"x"`
+	DroppedAfter int       `json:"d,omitempty"`
+}
+
+// Spool is a disk-backed queue of log records, used by a
+// BufferedLogWriter to hold the records that do not fit in memory
+// while the agent cannot send them to the controller. Records are
+// appended to the spool file as lines of JSON and survive agent
+// restarts. A Spool is not safe for concurrent use.
+type Spool struct {
+	path    string
+	maxSize int64
+
+	file   *os.File
+	reader *bufio.Reader
+
+	// size is the size of the spool file, and read the number of
+	// bytes at its start which have been popped. count is the number
+	// of records which have not been popped.
+	size  int64
+	read  int64
+	count int
+}
+
+// OpenSpool opens the spool file at path, creating it if necessary.
+// Records pushed to the spool are refused once the unsent records would
+// take up more than maxSize bytes. Any records left in the spool by an
+// earlier run of the agent are popped first.
+func OpenSpool(path string, maxSize int64) (*Spool, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, errors.Annotate(err, "opening log spool")
+	}
+	s := &Spool{
+		path:    path,
+		maxSize: maxSize,
+		file:    file,
+	}
+	if err := s.load(); err != nil {
+		_ = file.Close()
+		return nil, errors.Annotate(err, "loading log spool")
+	}
+	return s, nil
+}
+
+// load counts the records in the spool file, discarding any partial
+// record at its end left by an agent which stopped while writing it.
+func (s *Spool) load() error {
+	var size int64
+	reader := bufio.NewReader(s.file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		size += int64(len(line))
+		s.count++
+	}
+	if err := s.file.Truncate(size); err != nil {
+		return errors.Trace(err)
+	}
+	s.size = size
+	return errors.Trace(s.rewind(0))
+}
+
+// rewind positions the spool to read from offset.
+func (s *Spool) rewind(offset int64) error {
+	if _, err := s.file.Seek(offset, io.SeekStart); err != nil {
+		return errors.Trace(err)
+	}
+	s.read = offset
+	if s.reader == nil {
+		s.reader = bufio.NewReader(s.file)
+	} else {
+		s.reader.Reset(s.file)
+	}
+	return nil
+}
+
+// Len returns the number of records in the spool.
+func (s *Spool) Len() int {
+	return s.count
+}
+
+// Push appends the record to the spool.
+func (s *Spool) Push(rec *LogRecord) error {
+	data, err := json.Marshal(spoolRecord{
+		Time:         rec.Time,
+		Module:       rec.Module,
+		Location:     rec.Location,
+		Level:        rec.Level.String(),
+		Message:      rec.Message,
+		DroppedAfter: rec.DroppedAfter,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	data = append(data, '\n')
+	if s.size-s.read+int64(len(data)) > s.maxSize {
+		return errSpoolFull
+	}
+	if _, err := s.file.WriteAt(data, s.size); err != nil {
+		return errors.Annotate(err, "writing log spool")
+	}
+	s.size += int64(len(data))
+	s.count++
+	return nil
+}
+
+// Pop removes the oldest record from the spool and returns it. It
+// returns nil if the spool is empty. A record which cannot be read
+// back is skipped.
+func (s *Spool) Pop() (*LogRecord, error) {
+	for s.count > 0 {
+		line, err := s.reader.ReadBytes('\n')
+		if err != nil {
+			return nil, errors.Annotate(err, "reading log spool")
+		}
+		s.read += int64(len(line))
+		s.count--
+		if err := s.compact(); err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		var rec spoolRecord
+		if err := json.Unmarshal(bytes.TrimSpace(line), &rec); err != nil {
+			continue
+		}
+		level, ok := loggo.ParseLevel(rec.Level)
+		if !ok {
+			level = loggo.UNSPECIFIED
+		}
+		return &LogRecord{
+			Time:         rec.Time,
+			Module:       rec.Module,
+			Location:     rec.Location,
+			Level:        level,
+			Message:      rec.Message,
+			DroppedAfter: rec.DroppedAfter,
+		}, nil
+	}
+	return nil, nil
+}
+
+// compact stops the spool file from growing without bound. The file is
+// emptied once all of its records have been popped, and its unpopped
+// records are moved to the start of the file once the popped ones take
+// up more than the spool's maximum size.
+func (s *Spool) compact() error {
+	if s.count > 0 && s.read <= s.maxSize {
+		return nil
+	}
+	return errors.Trace(s.discardPopped())
+}
+
+// discardPopped removes the popped records from the spool file.
+func (s *Spool) discardPopped() error {
+	remaining := make([]byte, s.size-s.read)
+	if _, err := s.file.ReadAt(remaining, s.read); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := s.file.WriteAt(remaining, 0); err != nil {
+		return errors.Trace(err)
+	}
+	if err := s.file.Truncate(int64(len(remaining))); err != nil {
+		return errors.Trace(err)
+	}
+	s.size = int64(len(remaining))
+	return errors.Trace(s.rewind(0))
+}
+
+// Close closes the spool file. Unpopped records remain in the file to
+// be popped when it is next opened.
+func (s *Spool) Close() error {
+	err := s.discardPopped()
+	if closeErr := s.file.Close(); err == nil {
+		err = closeErr
+	}
+	return errors.Trace(err)
+}
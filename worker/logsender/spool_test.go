@@ -0,0 +1,154 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logsender_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/logsender"
+)
+
+type spoolSuite struct {
+	coretesting.BaseSuite
+	path string
+}
+
+var _ = gc.Suite(&spoolSuite{})
+
+func (s *spoolSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.path = filepath.Join(c.MkDir(), "log-spool")
+}
+
+func (s *spoolSuite) open(c *gc.C, maxSize int64) *logsender.Spool {
+	spool, err := logsender.OpenSpool(s.path, maxSize)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { _ = spool.Close() })
+	return spool
+}
+
+func record(i int) *logsender.LogRecord {
+	return &logsender.LogRecord{
+		Time:     time.Date(2021, 5, 1, 12, 0, i, 0, time.UTC),
+		Module:   "module",
+		Location: "filename:42",
+		Level:    loggo.WARNING,
+		Message:  fmt.Sprintf("log%d", i),
+	}
+}
+
+func (s *spoolSuite) TestPushPop(c *gc.C) {
+	spool := s.open(c, 1024*1024)
+	for i := 0; i < 3; i++ {
+		err := spool.Push(record(i))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(spool.Len(), gc.Equals, 3)
+
+	for i := 0; i < 3; i++ {
+		rec, err := spool.Pop()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(rec, jc.DeepEquals, record(i))
+	}
+	c.Assert(spool.Len(), gc.Equals, 0)
+
+	rec, err := spool.Pop()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rec, gc.IsNil)
+
+	// An emptied spool doesn't take up any space.
+	info, err := os.Stat(s.path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Size(), gc.Equals, int64(0))
+}
+
+func (s *spoolSuite) TestFull(c *gc.C) {
+	spool := s.open(c, 300)
+	var pushed int
+	for ; ; pushed++ {
+		if err := spool.Push(record(pushed)); err != nil {
+			c.Assert(err, gc.ErrorMatches, "log spool full")
+			break
+		}
+	}
+	c.Assert(pushed > 0, jc.IsTrue)
+	c.Assert(spool.Len(), gc.Equals, pushed)
+
+	// Popping a record makes room for another.
+	_, err := spool.Pop()
+	c.Assert(err, jc.ErrorIsNil)
+	err = spool.Push(record(pushed))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *spoolSuite) TestCompacts(c *gc.C) {
+	spool := s.open(c, 300)
+	for i := 0; i < 20; i++ {
+		err := spool.Push(record(i))
+		c.Assert(err, jc.ErrorIsNil)
+		err = spool.Push(record(i + 100))
+		c.Assert(err, jc.ErrorIsNil)
+		rec, err := spool.Pop()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(rec, gc.NotNil)
+	}
+	c.Assert(spool.Len(), gc.Equals, 20)
+
+	info, err := os.Stat(s.path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Size() <= 2*300, jc.IsTrue)
+}
+
+func (s *spoolSuite) TestReopen(c *gc.C) {
+	spool, err := logsender.OpenSpool(s.path, 1024*1024)
+	c.Assert(err, jc.ErrorIsNil)
+	for i := 0; i < 3; i++ {
+		err := spool.Push(record(i))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	_, err = spool.Pop()
+	c.Assert(err, jc.ErrorIsNil)
+	err = spool.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Records which were never popped are kept, and a partly written
+	// record is discarded.
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_WRONLY, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = f.WriteString(`{"t":"2021-05-01T`)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(f.Close(), jc.ErrorIsNil)
+
+	spool = s.open(c, 1024*1024)
+	var messages []string
+	for spool.Len() > 0 {
+		rec, err := spool.Pop()
+		c.Assert(err, jc.ErrorIsNil)
+		messages = append(messages, rec.Message)
+	}
+	c.Assert(messages, jc.DeepEquals, []string{"log1", "log2"})
+}
+
+func (s *spoolSuite) TestSkipsCorruptRecords(c *gc.C) {
+	err := ioutil.WriteFile(s.path, []byte("not json\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	spool := s.open(c, 1024*1024)
+	c.Assert(spool.Len(), gc.Equals, 1)
+	err = spool.Push(record(1))
+	c.Assert(err, jc.ErrorIsNil)
+
+	rec, err := spool.Pop()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rec, jc.DeepEquals, record(1))
+}
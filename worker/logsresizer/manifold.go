@@ -0,0 +1,98 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logsresizer
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/dependency"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	workerstate "github.com/juju/juju/worker/state"
+)
+
+// ManifoldConfig holds the information necessary to run a logs
+// resizer worker in a dependency.Engine.
+type ManifoldConfig struct {
+	StateName string
+	NewWorker func(Config) (worker.Worker, error)
+}
+
+func (config ManifoldConfig) Validate() error {
+	if config.StateName == "" {
+		return errors.NotValidf("empty StateName")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// Manifold returns a dependency.Manifold that will run a logs resizer
+// worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.StateName,
+		},
+		Start: config.start,
+	}
+}
+
+// start is a method on ManifoldConfig because it's more readable than a closure.
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var stTracker workerstate.StateTracker
+	if err := context.Get(config.StateName, &stTracker); err != nil {
+		return nil, errors.Trace(err)
+	}
+	statePool, err := stTracker.Use()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	w, err := config.NewWorker(Config{
+		Backend: stateBackend{statePool.SystemState()},
+	})
+	if err != nil {
+		_ = stTracker.Done()
+		return nil, errors.Trace(err)
+	}
+	go func() {
+		_ = w.Wait()
+		_ = stTracker.Done()
+	}()
+	return w, nil
+}
+
+// NewWorkerShim calls NewWorker, returning the result as a worker.Worker
+// for use in ManifoldConfig.
+func NewWorkerShim(config Config) (worker.Worker, error) {
+	w, err := NewWorker(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// stateBackend adapts a *state.State to the Backend interface.
+type stateBackend struct {
+	st *state.State
+}
+
+func (b stateBackend) ControllerConfig() (controller.Config, error) {
+	return b.st.ControllerConfig()
+}
+
+func (b stateBackend) WatchControllerConfig() NotifyWatcher {
+	return b.st.WatchControllerConfig()
+}
+
+func (b stateBackend) ResizeModelLogs(sizeMB int) error {
+	return b.st.ResizeModelLogs(sizeMB)
+}
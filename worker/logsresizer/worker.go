@@ -0,0 +1,131 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package logsresizer provides a controller worker which keeps the
+// capped logs collections of the controller's models at the size set by
+// the controller's model-logs-size config. When the setting changes,
+// the collections are resized while they are in use, rather than when
+// the controller next restarts.
+//
+// The statuseshistory and actions collections are not capped, as their
+// documents are updated and pruned in place, which capped collections
+// don't allow. They are kept to size by the status history and action
+// pruners instead, following the max-status-history-size and
+// max-action-results-size model config, which can be set for every
+// model on the controller with juju model-defaults.
+package logsresizer
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/catacomb"
+
+	"github.com/juju/juju/controller"
+)
+
+var logger = loggo.GetLogger("juju.worker.logsresizer")
+
+// Backend defines the state methods used by the worker.
+type Backend interface {
+	ControllerConfig() (controller.Config, error)
+	WatchControllerConfig() NotifyWatcher
+	ResizeModelLogs(sizeMB int) error
+}
+
+// NotifyWatcher is the watcher returned by Backend.WatchControllerConfig.
+type NotifyWatcher interface {
+	worker.Worker
+	Changes() <-chan struct{}
+}
+
+// Config holds the configuration and dependencies for the worker.
+type Config struct {
+	Backend Backend
+}
+
+// Validate returns an error if the config cannot be used to start
+// the worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	return nil
+}
+
+// NewWorker returns a worker which resizes the models' logs
+// collections whenever the controller's model-logs-size changes.
+func NewWorker(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Worker resizes the models' logs collections.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// size is the size, in MiB, that the collections were last
+	// successfully resized to.
+	size int
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	watcher := w.config.Backend.WatchControllerConfig()
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("controller config watcher closed")
+			}
+			if err := w.resize(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// resize resizes the logs collections if model-logs-size has changed.
+// A failed resize is logged and tried again on the next change, rather
+// than stopping the worker.
+func (w *Worker) resize() error {
+	cfg, err := w.config.Backend.ControllerConfig()
+	if err != nil {
+		return errors.Annotate(err, "getting controller config")
+	}
+	size := cfg.ModelLogsSizeMB()
+	if size == w.size {
+		return nil
+	}
+	logger.Debugf("resizing model logs collections to %d MiB", size)
+	if err := w.config.Backend.ResizeModelLogs(size); err != nil {
+		logger.Errorf("cannot resize model logs collections to %d MiB: %v", size, err)
+		return nil
+	}
+	w.size = size
+	return nil
+}
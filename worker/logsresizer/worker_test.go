@@ -0,0 +1,170 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logsresizer_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/logsresizer"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+	backend *fakeBackend
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &fakeBackend{
+		cfg: controller.Config{controller.ModelLogsSize: "20M"},
+		watcher: &fakeWatcher{
+			changes: make(chan struct{}),
+			stopped: make(chan struct{}),
+		},
+		resized: make(chan int, 10),
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	_, err := logsresizer.NewWorker(logsresizer.Config{})
+	c.Assert(err, gc.ErrorMatches, "nil Backend not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *WorkerSuite) TestResizesOnChange(c *gc.C) {
+	w, err := logsresizer.NewWorker(logsresizer.Config{Backend: s.backend})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	// The initial event resizes the collections to the current size.
+	s.sendChange(c)
+	s.assertResized(c, 20)
+
+	// Changes which leave the size alone don't resize anything.
+	s.sendChange(c)
+	s.assertNotResized(c)
+
+	s.backend.setConfig(controller.Config{controller.ModelLogsSize: "1G"})
+	s.sendChange(c)
+	s.assertResized(c, 1024)
+}
+
+func (s *WorkerSuite) TestRetriesFailedResize(c *gc.C) {
+	s.backend.setResizeError(errors.New("boom"))
+	w, err := logsresizer.NewWorker(logsresizer.Config{Backend: s.backend})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.sendChange(c)
+	s.assertResized(c, 20)
+
+	// The failure doesn't stop the worker, and the resize is tried
+	// again on the next change.
+	s.backend.setResizeError(nil)
+	s.sendChange(c)
+	s.assertResized(c, 20)
+	workertest.CheckAlive(c, w)
+}
+
+func (s *WorkerSuite) TestWatcherClosed(c *gc.C) {
+	w, err := logsresizer.NewWorker(logsresizer.Config{Backend: s.backend})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	close(s.backend.watcher.changes)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "controller config watcher closed")
+}
+
+func (s *WorkerSuite) sendChange(c *gc.C) {
+	select {
+	case s.backend.watcher.changes <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending change")
+	}
+}
+
+func (s *WorkerSuite) assertResized(c *gc.C, size int) {
+	select {
+	case resized := <-s.backend.resized:
+		c.Assert(resized, gc.Equals, size)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for resize")
+	}
+}
+
+func (s *WorkerSuite) assertNotResized(c *gc.C) {
+	select {
+	case resized := <-s.backend.resized:
+		c.Fatalf("unexpected resize to %d MiB", resized)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type fakeBackend struct {
+	mu        sync.Mutex
+	cfg       controller.Config
+	resizeErr error
+
+	watcher *fakeWatcher
+	resized chan int
+}
+
+func (b *fakeBackend) setConfig(cfg controller.Config) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.cfg = cfg
+}
+
+func (b *fakeBackend) setResizeError(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.resizeErr = err
+}
+
+func (b *fakeBackend) ControllerConfig() (controller.Config, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.cfg, nil
+}
+
+func (b *fakeBackend) WatchControllerConfig() logsresizer.NotifyWatcher {
+	return b.watcher
+}
+
+func (b *fakeBackend) ResizeModelLogs(sizeMB int) error {
+	b.resized <- sizeMB
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.resizeErr
+}
+
+type fakeWatcher struct {
+	changes chan struct{}
+	stopped chan struct{}
+	once    sync.Once
+}
+
+func (w *fakeWatcher) Changes() <-chan struct{} {
+	return w.changes
+}
+
+func (w *fakeWatcher) Kill() {
+	w.once.Do(func() { close(w.stopped) })
+}
+
+func (w *fakeWatcher) Wait() error {
+	<-w.stopped
+	return nil
+}
@@ -41,6 +41,13 @@ var (
 	// this case.
 	ErrMigrated = errors.New("model has migrated")
 
+	// minTargetVersion is the oldest controller version that a model
+	// can be migrated to. Entity UUIDs and the encryption settings of
+	// cross-model relations travel in reserved model annotations, as the
+	// model description has no fields for them, and older controllers
+	// would import them as ordinary annotations.
+	minTargetVersion = version.MustParse("3.0-beta1")
+
 	// utcZero matches the deserialised zero times coming back from
 	// MigrationTarget.LatestLogTime, because they have a non-nil
 	// location.
@@ -351,6 +358,11 @@ func (w *Worker) prechecks(status coremigration.MigrationStatus) error {
 		return errors.Errorf("unexpected target controller UUID (got %s, expected %s)",
 			conn.ControllerTag(), status.TargetInfo.ControllerTag)
 	}
+	if targetVersion, ok := conn.ServerVersion(); !ok {
+		return errors.New("cannot determine target controller version")
+	} else if targetVersion.Compare(minTargetVersion) < 0 {
+		return errors.Errorf("target controller version %s is older than %s", targetVersion, minTargetVersion)
+	}
 
 	targetClient := migrationtarget.NewClient(conn)
 	err = targetClient.Prechecks(model)
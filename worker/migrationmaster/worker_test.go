@@ -166,6 +166,7 @@ func (s *Suite) SetUpTest(c *gc.C) {
 	s.connection = &stubConnection{
 		stub:          s.stub,
 		controllerTag: targetControllerTag,
+		serverVersion: jujuversion.Current,
 		logStream:     &mockStream{},
 	}
 	s.connectionErr = nil
@@ -442,6 +443,40 @@ func (s *Suite) TestQUIESCEWrongController(c *gc.C) {
 	))
 }
 
+func (s *Suite) TestQUIESCETargetTooOld(c *gc.C) {
+	s.facade.queueStatus(s.makeStatus(coremigration.QUIESCE))
+	s.connection.serverVersion = version.MustParse("2.9.0")
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.Prechecks", nil},
+			{"facade.ModelInfo", nil},
+			apiOpenControllerCall,
+			apiCloseCall,
+		},
+		abortCalls,
+	))
+}
+
+func (s *Suite) TestQUIESCETargetVersionUnknown(c *gc.C) {
+	s.facade.queueStatus(s.makeStatus(coremigration.QUIESCE))
+	s.connection.serverVersion = version.Zero
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.Prechecks", nil},
+			{"facade.ModelInfo", nil},
+			apiOpenControllerCall,
+			apiCloseCall,
+		},
+		abortCalls,
+	))
+}
+
 func (s *Suite) TestQUIESCESourceChecksFail(c *gc.C) {
 	s.facade.queueStatus(s.makeStatus(coremigration.QUIESCE))
 	s.facade.prechecksErr = errors.New("boom")
@@ -1275,6 +1310,7 @@ type stubConnection struct {
 	importErr           error
 	processRelationsErr error
 	controllerTag       names.ControllerTag
+	serverVersion       version.Number
 
 	streamErr error
 	logStream *mockStream
@@ -1337,6 +1373,10 @@ func (c *stubConnection) ControllerTag() names.ControllerTag {
 	return c.controllerTag
 }
 
+func (c *stubConnection) ServerVersion() (version.Number, bool) {
+	return c.serverVersion, c.serverVersion != version.Zero
+}
+
 func (c *stubConnection) ConnectControllerStream(path string, attrs url.Values, headers http.Header) (base.Stream, error) {
 	c.stub.AddCall("ConnectControllerStream", path, attrs, headers)
 	if c.streamErr != nil {
@@ -388,7 +388,12 @@ func (c *cacheWorker) translate(d multiwatcher.Delta) interface{} {
 		// as only "in-flight" branches should ever be in the cache.
 		return c.translateBranch(d)
 	default:
-		return nil
+		// Other entities aren't cached, but changes to them
+		// still advance the revno of their model.
+		if id.ModelUUID == "" {
+			return nil
+		}
+		return cache.ModelEntityChange{ModelUUID: id.ModelUUID}
 	}
 }
 
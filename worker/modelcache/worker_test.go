@@ -623,6 +623,26 @@ func (s *WorkerSuite) TestAddBranch(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *WorkerSuite) TestUncachedEntityAdvancesModelRevno(c *gc.C) {
+	changes := s.captureEvents(c, cachetest.ModelEntityEvents)
+	w := s.start(c)
+
+	controller := s.getController(c, w)
+	mod, err := controller.WaitForModel(s.State.ModelUUID(), clock.WallClock)
+	c.Assert(err, jc.ErrorIsNil)
+	revno := mod.Revno()
+
+	app := s.Factory.MakeApplication(c, nil)
+	c.Assert(s.Model.SetAnnotations(app, map[string]string{"foo": "bar"}), jc.ErrorIsNil)
+	s.State.StartSync()
+
+	change := s.nextChange(c, changes)
+	obtained, ok := change.(cache.ModelEntityChange)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(obtained.ModelUUID, gc.Equals, s.State.ModelUUID())
+	c.Check(mod.Revno() > revno, jc.IsTrue)
+}
+
 func (s *WorkerSuite) TestRemoveBranch(c *gc.C) {
 	changes := s.captureEvents(c, cachetest.BranchEvents)
 	w := s.start(c)
@@ -0,0 +1,93 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelreconciler provides a worker which reconciles a model
+// towards the declarative spec set with juju apply.
+package modelreconciler
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/catacomb"
+
+	"github.com/juju/juju/core/watcher"
+)
+
+// period is the amount of time to wait before reconciling the model,
+// since the last time it was reconciled. The model drifts from its spec
+// without the spec changing, so it must be reconciled periodically as
+// well as whenever the spec is set.
+const period = 5 * time.Minute
+
+// Facade describes the API used by the worker.
+type Facade interface {
+	Reconcile() error
+	WatchModelSpec() (watcher.NotifyWatcher, error)
+}
+
+// Reconciler reconciles a model towards its spec.
+type Reconciler struct {
+	catacomb catacomb.Catacomb
+	facade   Facade
+	watcher  watcher.NotifyWatcher
+	clock    clock.Clock
+	logger   Logger
+}
+
+// NewReconciler returns a worker.Worker that reconciles the model
+// periodically, and whenever its spec is set.
+func NewReconciler(facade Facade, clock clock.Clock, logger Logger) (worker.Worker, error) {
+	watcher, err := facade.WatchModelSpec()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	r := &Reconciler{
+		facade:  facade,
+		watcher: watcher,
+		clock:   clock,
+		logger:  logger,
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &r.catacomb,
+		Work: r.loop,
+		Init: []worker.Worker{watcher},
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return r, nil
+}
+
+func (r *Reconciler) loop() error {
+	timer := r.clock.NewTimer(period)
+	defer timer.Stop()
+	for {
+		select {
+		case <-r.catacomb.Dying():
+			return r.catacomb.ErrDying()
+		case _, ok := <-r.watcher.Changes():
+			if !ok {
+				return errors.New("change channel closed")
+			}
+		case <-timer.Chan():
+		}
+		if err := r.facade.Reconcile(); err != nil {
+			// Retry when the timer next fires, rather than
+			// restarting the worker.
+			r.logger.Errorf("cannot reconcile model: %v", err)
+		}
+		timer.Reset(period)
+	}
+}
+
+// Kill is part of the worker.Worker interface.
+func (r *Reconciler) Kill() {
+	r.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (r *Reconciler) Wait() error {
+	return r.catacomb.Wait()
+}
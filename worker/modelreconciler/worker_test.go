@@ -0,0 +1,133 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelreconciler_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/modelreconciler"
+)
+
+type ReconcilerSuite struct {
+	coretesting.BaseSuite
+	facade  *mockFacade
+	changes chan struct{}
+	clock   *testclock.Clock
+	logger  loggo.Logger
+}
+
+var _ = gc.Suite(&ReconcilerSuite{})
+
+func (s *ReconcilerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.changes = make(chan struct{}, 1)
+	s.changes <- struct{}{}
+	s.facade = &mockFacade{
+		watcher: watchertest.NewMockNotifyWatcher(s.changes),
+		calls:   make(chan string, 1),
+	}
+	s.clock = testclock.NewClock(time.Time{})
+	s.logger = loggo.GetLogger("test")
+}
+
+func (s *ReconcilerSuite) assertReceived(c *gc.C, expect string) {
+	select {
+	case call := <-s.facade.calls:
+		c.Assert(call, gc.Equals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for %s", expect)
+	}
+}
+
+func (s *ReconcilerSuite) assertEmpty(c *gc.C) {
+	select {
+	case call := <-s.facade.calls:
+		c.Fatalf("unexpected %s", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *ReconcilerSuite) TestReconcileOnChange(c *gc.C) {
+	w, err := modelreconciler.NewReconciler(s.facade, s.clock, s.logger)
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() { c.Assert(worker.Stop(w), jc.ErrorIsNil) }()
+
+	s.assertReceived(c, "WatchModelSpec")
+	s.assertReceived(c, "Reconcile")
+	s.assertEmpty(c)
+
+	s.changes <- struct{}{}
+	s.assertReceived(c, "Reconcile")
+	s.assertEmpty(c)
+}
+
+func (s *ReconcilerSuite) TestReconcilePeriodically(c *gc.C) {
+	w, err := modelreconciler.NewReconciler(s.facade, s.clock, s.logger)
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() { c.Assert(worker.Stop(w), jc.ErrorIsNil) }()
+
+	s.assertReceived(c, "WatchModelSpec")
+	s.assertReceived(c, "Reconcile")
+	s.assertEmpty(c)
+
+	for i := 0; i < 2; i++ {
+		s.clock.WaitAdvance(4*time.Minute, coretesting.LongWait, 1)
+		s.assertEmpty(c)
+		s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+		s.assertReceived(c, "Reconcile")
+		s.assertEmpty(c)
+	}
+}
+
+func (s *ReconcilerSuite) TestWatchModelSpecError(c *gc.C) {
+	s.facade.err = []error{errors.New("boom")}
+	_, err := modelreconciler.NewReconciler(s.facade, s.clock, s.logger)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.assertReceived(c, "WatchModelSpec")
+	s.assertEmpty(c)
+}
+
+func (s *ReconcilerSuite) TestReconcileError(c *gc.C) {
+	s.facade.err = []error{nil, errors.New("boom")}
+	w, err := modelreconciler.NewReconciler(s.facade, s.clock, s.logger)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertReceived(c, "WatchModelSpec")
+	s.assertReceived(c, "Reconcile")
+	c.Assert(worker.Stop(w), jc.ErrorIsNil)
+	c.Assert(c.GetTestLog(), jc.Contains, "ERROR test cannot reconcile model: boom")
+}
+
+type mockFacade struct {
+	watcher watcher.NotifyWatcher
+	calls   chan string
+	err     []error
+}
+
+func (m *mockFacade) nextErr() (err error) {
+	if len(m.err) > 0 {
+		err, m.err = m.err[0], m.err[1:]
+	}
+	return err
+}
+
+func (m *mockFacade) Reconcile() error {
+	m.calls <- "Reconcile"
+	return m.nextErr()
+}
+
+func (m *mockFacade) WatchModelSpec() (watcher.NotifyWatcher, error) {
+	m.calls <- "WatchModelSpec"
+	return m.watcher, m.nextErr()
+}
@@ -0,0 +1,80 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package orphanfinder provides a controller worker which periodically
+// looks for orphaned documents in the controller's database: documents
+// left behind by entities which have been removed, and which would
+// otherwise never be cleaned up.
+package orphanfinder
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/worker/v2"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	jworker "github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.orphanfinder")
+
+// Backend defines the state methods used by the orphan finder.
+type Backend interface {
+	FindOrphanedDocuments() ([]state.OrphanedDocument, error)
+	RemoveOrphanedDocuments([]state.OrphanedDocument) error
+	ControllerConfig() (controller.Config, error)
+}
+
+// New returns a worker which looks for orphaned documents every interval,
+// and logs those it finds. If the controller's remove-orphaned-documents
+// setting is enabled, it also removes them.
+func New(backend Backend, interval time.Duration, clock clock.Clock) worker.Worker {
+	return jworker.NewSimpleWorker(func(stopCh <-chan struct{}) error {
+		for {
+			select {
+			case <-clock.After(interval):
+				if err := FindOrphans(backend); err != nil {
+					return errors.Annotate(err, "finding orphaned documents")
+				}
+			case <-stopCh:
+				return nil
+			}
+		}
+	})
+}
+
+// FindOrphans looks for orphaned documents once, logs those it finds,
+// and removes them if the controller config says to.
+func FindOrphans(backend Backend) error {
+	orphans, err := backend.FindOrphanedDocuments()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(orphans) == 0 {
+		logger.Debugf("no orphaned documents found")
+		return nil
+	}
+	for _, orphan := range orphans {
+		logger.Warningf("orphaned %s document %q in model %s: %s",
+			orphan.Collection, orphan.Id, orphan.ModelUUID, orphan.Reason)
+	}
+
+	cfg, err := backend.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !cfg.RemoveOrphanedDocuments() {
+		logger.Warningf("found %d orphaned documents; set %s to remove them",
+			len(orphans), controller.RemoveOrphanedDocuments)
+		return nil
+	}
+	if err := backend.RemoveOrphanedDocuments(orphans); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("removed %d orphaned documents", len(orphans))
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package orphanfinder_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/orphanfinder"
+)
+
+type OrphanFinderSuite struct {
+	coretesting.BaseSuite
+	backend *fakeBackend
+}
+
+var _ = gc.Suite(&OrphanFinderSuite{})
+
+func (s *OrphanFinderSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &fakeBackend{
+		orphans: []state.OrphanedDocument{{
+			Collection: "units",
+			ModelUUID:  coretesting.ModelTag.Id(),
+			Id:         "ghost/0",
+			Reason:     `application "ghost" not found`,
+		}},
+		found: make(chan struct{}, 10),
+	}
+}
+
+func (s *OrphanFinderSuite) TestFindOrphansReportsOnly(c *gc.C) {
+	err := orphanfinder.FindOrphans(s.backend)
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "FindOrphanedDocuments", "ControllerConfig")
+}
+
+func (s *OrphanFinderSuite) TestFindOrphansRemoves(c *gc.C) {
+	s.backend.remove = true
+	err := orphanfinder.FindOrphans(s.backend)
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "FindOrphanedDocuments", "ControllerConfig", "RemoveOrphanedDocuments")
+	s.backend.CheckCall(c, 2, "RemoveOrphanedDocuments", s.backend.orphans)
+}
+
+func (s *OrphanFinderSuite) TestFindOrphansNoneFound(c *gc.C) {
+	s.backend.orphans = nil
+	s.backend.remove = true
+	err := orphanfinder.FindOrphans(s.backend)
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "FindOrphanedDocuments")
+}
+
+func (s *OrphanFinderSuite) TestFindOrphansError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	err := orphanfinder.FindOrphans(s.backend)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *OrphanFinderSuite) TestWorkerFindsEveryInterval(c *gc.C) {
+	clock := testclock.NewClock(time.Now())
+	w := orphanfinder.New(s.backend, time.Hour, clock)
+	defer workertest.CleanKill(c, w)
+
+	for i := 0; i < 3; i++ {
+		err := clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+		select {
+		case <-s.backend.found:
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for orphans to be found")
+		}
+	}
+}
+
+func (s *OrphanFinderSuite) TestWorkerStopsOnError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	clock := testclock.NewClock(time.Now())
+	w := orphanfinder.New(s.backend, time.Hour, clock)
+	defer workertest.DirtyKill(c, w)
+
+	err := clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "finding orphaned documents: boom")
+}
+
+type fakeBackend struct {
+	testing.Stub
+	orphans []state.OrphanedDocument
+	remove  bool
+	found   chan struct{}
+}
+
+func (b *fakeBackend) FindOrphanedDocuments() ([]state.OrphanedDocument, error) {
+	b.MethodCall(b, "FindOrphanedDocuments")
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	b.found <- struct{}{}
+	return b.orphans, nil
+}
+
+func (b *fakeBackend) RemoveOrphanedDocuments(orphans []state.OrphanedDocument) error {
+	b.MethodCall(b, "RemoveOrphanedDocuments", orphans)
+	return b.NextErr()
+}
+
+func (b *fakeBackend) ControllerConfig() (controller.Config, error) {
+	b.MethodCall(b, "ControllerConfig")
+	return controller.Config{controller.RemoveOrphanedDocuments: b.remove}, b.NextErr()
+}
@@ -109,7 +109,9 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	supportsHA := model.Type() != state.ModelTypeCAAS
+	// An externally managed replica set's membership is not ours to
+	// change as controllers come and go.
+	supportsHA := model.Type() != state.ModelTypeCAAS && !usesExternalMongo(agentConfig)
 
 	w, err := config.NewWorker(Config{
 		State:                StateShim{st},
@@ -129,3 +131,9 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 	}
 	return common.NewCleanupWorker(w, func() { _ = stTracker.Done() }), nil
 }
+
+// usesExternalMongo reports whether the controller agent uses an
+// externally managed replica set rather than its co-located juju-db.
+func usesExternalMongo(agentConfig agent.Config) bool {
+	return agentConfig.Value(agent.ExternalMongoURI) != ""
+}
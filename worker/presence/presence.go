@@ -107,6 +107,9 @@ func (w *wrapper) loop(started chan struct{}) error {
 	if err := multiplexer.Add(apiserver.DisconnectTopic, w.agentDisconnect); err != nil {
 		return errors.Trace(err)
 	}
+	if err := multiplexer.Add(apiserver.ActivityTopic, w.agentActivity); err != nil {
+		return errors.Trace(err)
+	}
 	if err := multiplexer.Add(apiserver.PresenceRequestTopic, w.presenceRequest); err != nil {
 		return errors.Trace(err)
 	}
@@ -172,7 +175,7 @@ func (w *wrapper) agentLogin(topic string, data apiserver.APIConnection, err err
 		}
 		w.logger.Tracef("api connect %s:%s -> %s (%v)", data.ModelUUID, agentName, data.Origin, data.ConnectionID)
 	}
-	w.recorder.Connect(data.Origin, data.ModelUUID, data.AgentTag, data.ConnectionID, data.ControllerAgent, data.UserData)
+	w.recorder.Connect(data.Origin, data.ModelUUID, data.AgentTag, data.ConnectionID, data.ControllerAgent, data.UserData, data.RemoteAddress)
 }
 
 func (w *wrapper) agentDisconnect(topic string, data apiserver.APIConnection, err error) {
@@ -184,6 +187,14 @@ func (w *wrapper) agentDisconnect(topic string, data apiserver.APIConnection, er
 	w.recorder.Disconnect(data.Origin, data.ConnectionID)
 }
 
+func (w *wrapper) agentActivity(topic string, data apiserver.APIConnection, err error) {
+	if err != nil {
+		w.logger.Errorf("agentActivity error %v", err)
+		return
+	}
+	w.recorder.Activity(data.Origin, data.ConnectionID)
+}
+
 func (w *wrapper) presenceRequest(topic string, data apiserver.OriginTarget, err error) {
 	if err != nil {
 		w.logger.Errorf("connectionChange error %v", err)
@@ -213,6 +224,7 @@ func (w *wrapper) presenceRequest(topic string, data apiserver.OriginTarget, err
 			ConnectionID:    value.ConnectionID,
 			Origin:          value.Server,
 			UserData:        value.UserData,
+			RemoteAddress:   value.RemoteAddress,
 		}
 	}
 	_, err = w.hub.Publish(apiserver.PresenceResponseTopic, response)
@@ -250,6 +262,7 @@ func (w *wrapper) presenceResponse(topic string, data apiserver.PresenceResponse
 			ConnectionID:    conn.ConnectionID,
 			ControllerAgent: conn.ControllerAgent,
 			UserData:        conn.UserData,
+			RemoteAddress:   conn.RemoteAddress,
 		})
 	}
 
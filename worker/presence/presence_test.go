@@ -101,12 +101,12 @@ func (s *PresenceSuite) TestReport(c *gc.C) {
 	w := s.worker(c)
 	defer workertest.CleanKill(c, w)
 
-	s.recorder.Connect("machine-0", "model-uuid", "agent", 1, false, "")
-	s.recorder.Connect("machine-0", "model-uuid", "agent", 2, false, "")
-	s.recorder.Connect("machine-0", "model-uuid", "agent", 3, false, "")
-	s.recorder.Connect("machine-1", "model-uuid", "agent", 4, false, "")
-	s.recorder.Connect("machine-1", "model-uuid", "agent", 5, false, "")
-	s.recorder.Connect("machine-2", "model-uuid", "agent", 6, false, "")
+	s.recorder.Connect("machine-0", "model-uuid", "agent", 1, false, "", "")
+	s.recorder.Connect("machine-0", "model-uuid", "agent", 2, false, "", "")
+	s.recorder.Connect("machine-0", "model-uuid", "agent", 3, false, "", "")
+	s.recorder.Connect("machine-1", "model-uuid", "agent", 4, false, "", "")
+	s.recorder.Connect("machine-1", "model-uuid", "agent", 5, false, "", "")
+	s.recorder.Connect("machine-2", "model-uuid", "agent", 6, false, "", "")
 
 	reporter, ok := w.(worker.Reporter)
 	c.Assert(ok, jc.IsTrue)
@@ -226,6 +226,7 @@ func (s *PresenceSuite) TestConnectTopic(c *gc.C) {
 			ConnectionID:    42,
 			ControllerAgent: true,
 			UserData:        "test",
+			RemoteAddress:   "10.0.0.5:49152",
 		})
 	c.Assert(err, jc.ErrorIsNil)
 	s.AssertDone(c, done)
@@ -237,6 +238,7 @@ func (s *PresenceSuite) TestConnectTopic(c *gc.C) {
 		Status:          corepresence.Alive,
 		ControllerAgent: true,
 		UserData:        "test",
+		RemoteAddress:   "10.0.0.5:49152",
 	})
 }
 
@@ -257,6 +259,27 @@ func (s *PresenceSuite) TestDisconnectTopic(c *gc.C) {
 	s.AssertConnections(c, alive(agent1))
 }
 
+func (s *PresenceSuite) TestActivityTopic(c *gc.C) {
+	w := s.worker(c)
+	defer workertest.CleanKill(c, w)
+
+	connect(s.recorder, agent1, agent2)
+	s.clock.Advance(time.Minute)
+
+	done, err := s.hub.Publish(
+		apiserver.ActivityTopic,
+		apiserver.APIConnection{
+			Origin:       agent2.Server,
+			ConnectionID: agent2.ConnectionID,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AssertDone(c, done)
+
+	seen := alive(agent2)
+	seen.LastSeen = s.clock.Now()
+	s.AssertConnections(c, alive(agent1), seen)
+}
+
 func (s *PresenceSuite) TestPresenceRequest(c *gc.C) {
 	w := s.worker(c)
 	defer workertest.CleanKill(c, w)
@@ -357,6 +380,7 @@ func (s *PresenceSuite) CheckConnection(c *gc.C, conn apiserver.APIConnection, a
 	c.Check(conn.ConnectionID, gc.Equals, agent.ConnectionID)
 	c.Check(conn.Origin, gc.Equals, agent.Server)
 	c.Check(conn.UserData, gc.Equals, agent.UserData)
+	c.Check(conn.RemoteAddress, gc.Equals, agent.RemoteAddress)
 }
 
 func apiConn(value corepresence.Value) apiserver.APIConnection {
@@ -367,6 +391,7 @@ func apiConn(value corepresence.Value) apiserver.APIConnection {
 		ConnectionID:    value.ConnectionID,
 		Origin:          value.Server,
 		UserData:        value.UserData,
+		RemoteAddress:   value.RemoteAddress,
 	}
 }
 
@@ -382,7 +407,7 @@ func missing(v corepresence.Value) corepresence.Value {
 
 func connect(r corepresence.Recorder, values ...corepresence.Value) {
 	for _, info := range values {
-		r.Connect(info.Server, info.Model, info.Agent, info.ConnectionID, info.ControllerAgent, info.UserData)
+		r.Connect(info.Server, info.Model, info.Agent, info.ConnectionID, info.ControllerAgent, info.UserData, info.RemoteAddress)
 	}
 }
 
@@ -393,11 +418,12 @@ var (
 	ourServer   = ourTag.String()
 	otherServer = "machine-2"
 	agent1      = corepresence.Value{
-		Model:        modelUUID,
-		Server:       ourServer,
-		Agent:        "machine-0",
-		ConnectionID: 1237,
-		UserData:     "foo",
+		Model:         modelUUID,
+		Server:        ourServer,
+		Agent:         "machine-0",
+		ConnectionID:  1237,
+		UserData:      "foo",
+		RemoteAddress: "10.0.0.5:49152",
 	}
 	agent2 = corepresence.Value{
 		Model:        modelUUID,
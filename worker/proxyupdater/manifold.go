@@ -4,6 +4,8 @@
 package proxyupdater
 
 import (
+	"net/url"
+
 	"github.com/juju/errors"
 	"github.com/juju/proxy"
 	"github.com/juju/worker/v2"
@@ -74,6 +76,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				InProcessUpdate:     config.InProcessUpdate,
 				Logger:              config.Logger,
 				RunFunc:             config.RunFunc,
+				APIProxyUpdate:      apiProxyUpdater(agent),
 			})
 			if err != nil {
 				return nil, errors.Trace(err)
@@ -82,3 +85,25 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 		},
 	}
 }
+
+// apiProxyUpdater returns a function that records the API proxy in the
+// agent's configuration, so that it is used the next time the agent
+// connects to the controller.
+func apiProxyUpdater(a agent.Agent) func(*url.URL) error {
+	return func(apiProxy *url.URL) error {
+		if sameURL(a.CurrentConfig().APIProxy(), apiProxy) {
+			return nil
+		}
+		return a.ChangeConfig(func(setter agent.ConfigSetter) error {
+			setter.SetAPIProxy(apiProxy)
+			return nil
+		})
+	}
+}
+
+func sameURL(a, b *url.URL) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.String() == b.String()
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/url"
 	stdexec "os/exec"
 
 	"github.com/juju/errors"
@@ -21,6 +22,7 @@ import (
 	"github.com/juju/juju/api/proxyupdater"
 	"github.com/juju/juju/core/snap"
 	"github.com/juju/juju/core/watcher"
+	jujuproxy "github.com/juju/juju/utils/proxy"
 )
 
 type Config struct {
@@ -33,6 +35,11 @@ type Config struct {
 	InProcessUpdate     func(proxy.Settings) error
 	RunFunc             func(string, string, ...string) (string, error)
 	Logger              Logger
+
+	// APIProxyUpdate, if set, is called with the proxy through which
+	// the agent should connect to the controller whenever it changes.
+	// A nil URL means the agent should connect directly.
+	APIProxyUpdate func(*url.URL) error
 }
 
 // Validate ensures that all the required fields have values.
@@ -70,6 +77,8 @@ type proxyWorker struct {
 	snapStoreAssertions string
 	snapStoreProxyURL   string
 
+	apiProxy string
+
 	// The whole point of the first value is to make sure that the the files
 	// are written out the first time through, even if they are the same as
 	// "last" time, as the initial value for last time is the zeroed struct.
@@ -311,9 +320,34 @@ func (w *proxyWorker) onChange() error {
 
 	w.handleProxyValues(config.LegacyProxy, config.JujuProxy)
 	w.handleSnapProxyValues(config.SnapProxy, config.SnapStoreProxyId, config.SnapStoreProxyAssertions, config.SnapStoreProxyURL)
+	if err := w.handleAPIProxyValue(config.APIProxy); err != nil {
+		return err
+	}
 	return w.handleAptProxyValues(config.APTProxy)
 }
 
+func (w *proxyWorker) handleAPIProxyValue(value string) error {
+	if w.config.APIProxyUpdate == nil {
+		return nil
+	}
+	if value == w.apiProxy && !w.first {
+		return nil
+	}
+	apiProxy, err := jujuproxy.ParseAPIProxy(value)
+	if err != nil {
+		// The controller validates the value, so this should not happen;
+		// keep using the existing setting rather than failing the worker.
+		w.config.Logger.Errorf("ignoring API proxy setting: %v", err)
+		return nil
+	}
+	w.config.Logger.Debugf("new API proxy setting %q", value)
+	if err := w.config.APIProxyUpdate(apiProxy); err != nil {
+		return errors.Annotate(err, "updating API proxy")
+	}
+	w.apiProxy = value
+	return nil
+}
+
 // SetUp is defined on the worker.NotifyWatchHandler interface.
 func (w *proxyWorker) SetUp() (watcher.NotifyWatcher, error) {
 	// We need to set this up initially as the NotifyWorker sucks up the first
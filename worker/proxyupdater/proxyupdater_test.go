@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path"
 	"path/filepath"
@@ -608,3 +609,40 @@ DATA...
 		"proxy.store=WhatDoesTheBigRedButtonDo",
 	})
 }
+
+func (s *ProxyUpdaterSuite) TestAPIProxyUpdated(c *gc.C) {
+	updated := make(chan *url.URL, 1)
+	s.config.APIProxyUpdate = func(apiProxy *url.URL) error {
+		updated <- apiProxy
+		return nil
+	}
+	s.api.proxies = proxyupdaterapi.ProxyConfiguration{
+		APIProxy: "socks5://10.0.0.1:1080",
+	}
+
+	updater, err := proxyupdater.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, updater)
+
+	select {
+	case apiProxy := <-updated:
+		c.Assert(apiProxy, gc.NotNil)
+		c.Assert(apiProxy.String(), gc.Equals, "socks5://10.0.0.1:1080")
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("API proxy not updated")
+	}
+}
+
+func (s *ProxyUpdaterSuite) TestAPIProxyInvalidIgnored(c *gc.C) {
+	s.config.APIProxyUpdate = func(apiProxy *url.URL) error {
+		c.Errorf("unexpected API proxy update %v", apiProxy)
+		return nil
+	}
+	s.api.proxies = proxyupdaterapi.ProxyConfiguration{
+		APIProxy: "ftp://10.0.0.1",
+	}
+
+	updater, err := proxyupdater.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	workertest.CleanKill(c, updater)
+}
@@ -124,6 +124,14 @@ func (m *mockRelationsFacade) SaveMacaroon(entity names.Tag, mac *macaroon.Macar
 	return m.stub.NextErr()
 }
 
+func (m *mockRelationsFacade) RotateRelationKeys(relationTag names.Tag) (int, error) {
+	m.stub.MethodCall(m, "RotateRelationKeys", relationTag)
+	if err := m.stub.NextErr(); err != nil {
+		return 0, err
+	}
+	return 2, nil
+}
+
 func (m *mockRelationsFacade) GetToken(entity names.Tag) (string, error) {
 	m.stub.MethodCall(m, "GetToken", entity)
 	if err := m.stub.NextErr(); err != nil {
@@ -148,12 +156,13 @@ func (m *mockRelationsFacade) RemoteApplications(names []string) ([]params.Remot
 		if app, ok := m.remoteApplications[name]; ok {
 			result[i] = params.RemoteApplicationResult{
 				Result: &params.RemoteApplication{
-					Name:            app.name,
-					OfferUUID:       app.offeruuid,
-					Life:            app.life,
-					ModelUUID:       app.modelUUID,
-					IsConsumerProxy: app.registered,
-					Macaroon:        mac,
+					Name:                app.name,
+					OfferUUID:           app.offeruuid,
+					Life:                app.life,
+					ModelUUID:           app.modelUUID,
+					IsConsumerProxy:     app.registered,
+					Macaroon:            mac,
+					EncryptRelationData: app.encrypt,
 				},
 			}
 		} else {
@@ -412,6 +421,7 @@ type mockRemoteApplication struct {
 	life       life.Value
 	modelUUID  string
 	registered bool
+	encrypt    bool
 }
 
 type mockRemoteRelationWatcher struct {
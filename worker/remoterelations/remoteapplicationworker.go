@@ -38,6 +38,10 @@ type remoteApplicationWorker struct {
 	localRelationChanges  chan RelationUnitChangeEvent
 	remoteRelationChanges chan RelationUnitChangeEvent
 
+	// encryptRelationData is true if the settings exchanged over
+	// relations to the remote application are to be encrypted.
+	encryptRelationData bool
+
 	// offerMacaroon is used to confirm that permission has been granted to consume
 	// the remote application to which this worker pertains.
 	offerMacaroon *macaroon.Macaroon
@@ -606,6 +610,14 @@ func (w *remoteApplicationWorker) registerRemoteRelation(
 		arg.Macaroons = macaroon.Slice{w.offerMacaroon}
 		arg.BakeryVersion = bakery.LatestVersion
 	}
+	// The relation keys are rotated each time the relation is registered,
+	// along with the relation macaroon. The units at either end hold the
+	// keys; only the generation is sent to the offering model.
+	if w.encryptRelationData {
+		if arg.KeyGeneration, err = w.localModelFacade.RotateRelationKeys(relationTag); err != nil {
+			return fail(errors.Annotatef(err, "rotating keys for %v", relationTag))
+		}
+	}
 	remoteRelation, err := w.remoteModelFacade.RegisterRemoteRelations(arg)
 	if err != nil {
 		return fail(errors.Trace(err))
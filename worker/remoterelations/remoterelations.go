@@ -72,6 +72,10 @@ type RemoteRelationsFacade interface {
 	// SaveMacaroon saves the macaroon for the entity.
 	SaveMacaroon(entity names.Tag, mac *macaroon.Macaroon) error
 
+	// RotateRelationKeys increments the key generation of the
+	// cross-model relation and returns the new generation.
+	RotateRelationKeys(relationTag names.Tag) (int, error)
+
 	// ExportEntities allocates unique, remote entity IDs for the
 	// given entities in the local model.
 	ExportEntities([]names.Tag) ([]params.TokenResult, error)
@@ -259,6 +263,7 @@ func (w *Worker) handleApplicationChanges(applicationIds []string) error {
 				remoteModelUUID:                   remoteApp.ModelUUID,
 				isConsumerProxy:                   remoteApp.IsConsumerProxy,
 				offerMacaroon:                     remoteApp.Macaroon,
+				encryptRelationData:               remoteApp.EncryptRelationData,
 				localRelationChanges:              make(chan RelationUnitChangeEvent),
 				remoteRelationChanges:             make(chan RelationUnitChangeEvent),
 				localModelFacade:                  w.config.RelationsFacade,
@@ -401,6 +401,57 @@ func (s *remoteRelationsSuite) TestRemoteRelationsWorkers(c *gc.C) {
 	c.Check(relWatcher.killed(), jc.IsTrue)
 }
 
+func (s *remoteRelationsSuite) TestRemoteRelationsRotatesKeys(c *gc.C) {
+	s.relationsFacade.relations["db2:db django:db"] = newMockRelation(123)
+	app := newMockRemoteApplication("db2", "db2url")
+	app.encrypt = true
+	s.relationsFacade.remoteApplications["db2"] = app
+	s.relationsFacade.controllerInfo["remote-model-uuid"] = s.remoteControllerInfo
+	s.relationsFacade.relationsEndpoints["db2:db django:db"] = &relationEndpointInfo{
+		localApplicationName: "django",
+		localEndpoint: params.RemoteEndpoint{
+			Name:      "db2",
+			Role:      "requires",
+			Interface: "db2",
+		},
+		remoteEndpointName: "data",
+	}
+
+	w, err := remoterelations.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	s.relationsFacade.remoteApplicationsWatcher.changes <- []string{"db2"}
+
+	var relWatcher *mockStringsWatcher
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		var ok bool
+		if relWatcher, ok = s.relationsFacade.remoteApplicationRelationsWatcher("db2"); ok {
+			break
+		}
+	}
+	c.Assert(relWatcher, gc.NotNil)
+	relWatcher.changes <- []string{"db2:db django:db"}
+
+	// The relation keys are rotated for each registration, and the new
+	// generation is sent to the offering model.
+	relTag := names.NewRelationTag("db2:db django:db")
+	var rotated bool
+	var sentGeneration int
+	for a := coretesting.LongAttempt.Start(); a.Next() && sentGeneration == 0; {
+		for _, call := range s.stub.Calls() {
+			switch call.FuncName {
+			case "RotateRelationKeys":
+				c.Assert(call.Args[0], gc.Equals, relTag)
+				rotated = true
+			case "RegisterRemoteRelations":
+				sentGeneration = call.Args[0].([]params.RegisterRemoteRelationArg)[0].KeyGeneration
+			}
+		}
+	}
+	c.Assert(rotated, jc.IsTrue)
+	c.Assert(sentGeneration, gc.Equals, 2)
+}
+
 func (s *remoteRelationsSuite) TestRemoteRelationsRevoked(c *gc.C) {
 	// The consume permission is revoked after an offer is consumed.
 	// Subsequent api calls against that offer will fail and record an
@@ -5,6 +5,8 @@
 package retrystrategy
 
 import (
+	"reflect"
+
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	"github.com/juju/worker/v2"
@@ -36,8 +38,7 @@ func (c WorkerConfig) Validate() error {
 	if c.AgentTag == nil {
 		return errors.NotValidf("nil AgentTag")
 	}
-	empty := params.RetryStrategy{}
-	if c.RetryStrategy == empty {
+	if reflect.DeepEqual(c.RetryStrategy, params.RetryStrategy{}) {
 		return errors.NotValidf("empty RetryStrategy")
 	}
 	return nil
@@ -88,7 +89,7 @@ func (h retryStrategyHandler) Handle(_ <-chan struct{}) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if newRetryStrategy != h.config.RetryStrategy {
+	if !reflect.DeepEqual(newRetryStrategy, h.config.RetryStrategy) {
 		h.config.Logger.Debugf("bouncing retrystrategy worker to get new values")
 		return dependency.ErrBounce
 	}
@@ -0,0 +1,50 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sandboxprofiler
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/dependency"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/cmd/jujud/agent/engine"
+)
+
+// ManifoldConfig describes the dependencies of a sandbox profiler.
+type ManifoldConfig struct {
+	AgentName     string
+	APICallerName string
+
+	SysctlDir   string
+	AppArmorDir string
+	RunCommand  func(name string, args ...string) error
+
+	NewFacade func(base.APICaller) Facade
+	NewWorker func(WorkerConfig) (worker.Worker, error)
+}
+
+// start is used by engine.AgentAPIManifold to create a StartFunc.
+func (config ManifoldConfig) start(a agent.Agent, apiCaller base.APICaller) (worker.Worker, error) {
+	if _, ok := a.CurrentConfig().Tag().(names.MachineTag); !ok {
+		return nil, errors.Errorf("this manifold can only be used inside a machine")
+	}
+	return config.NewWorker(WorkerConfig{
+		Facade:      config.NewFacade(apiCaller),
+		SysctlDir:   config.SysctlDir,
+		AppArmorDir: config.AppArmorDir,
+		RunCommand:  config.RunCommand,
+	})
+}
+
+// Manifold returns a dependency.Manifold as configured.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	typedConfig := engine.AgentAPIManifoldConfig{
+		AgentName:     config.AgentName,
+		APICallerName: config.APICallerName,
+	}
+	return engine.AgentAPIManifold(typedConfig, config.start)
+}
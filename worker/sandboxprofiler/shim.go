@@ -0,0 +1,31 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sandboxprofiler
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/sandboxprofiles"
+)
+
+// NewFacade creates a Facade from a base.APICaller.
+// It's a sensible value for ManifoldConfig.NewFacade.
+func NewFacade(apiCaller base.APICaller) Facade {
+	return sandboxprofiles.NewClient(apiCaller)
+}
+
+// RunCommand runs the named command, returning an error including its
+// output if it fails. It's a sensible value for
+// ManifoldConfig.RunCommand.
+func RunCommand(name string, args ...string) error {
+	output, err := exec.Command(name, args...).CombinedOutput()
+	if err != nil {
+		return errors.Annotatef(err, "running %s: %s", name, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package sandboxprofiler provides a worker which applies the sandbox
+// profiles declared by the charms of the units on a machine: it sets
+// the kernel parameters they ask for, and loads their AppArmor
+// profiles, unloading those no longer needed.
+package sandboxprofiler
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/worker/v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+var logger = loggo.GetLogger("juju.worker.sandboxprofiler")
+
+const (
+	// sysctlFile is the name of the file in the sysctl directory
+	// holding the kernel parameters set for charms.
+	sysctlFile = "60-juju-charm-sandbox.conf"
+
+	// appArmorPrefix prefixes the names of the files in the AppArmor
+	// directory holding the profiles loaded for charms.
+	appArmorPrefix = "juju-charm-"
+)
+
+// Facade defines the capabilities required by the worker from the API.
+type Facade interface {
+	WatchSandboxProfiles() (watcher.NotifyWatcher, error)
+	SandboxProfiles() ([]params.SandboxProfile, error)
+}
+
+// WorkerConfig defines the worker's dependencies.
+type WorkerConfig struct {
+	Facade Facade
+
+	// SysctlDir is the directory holding the machine's sysctl
+	// configuration, usually /etc/sysctl.d.
+	SysctlDir string
+
+	// AppArmorDir is the directory holding the machine's AppArmor
+	// profiles, usually /etc/apparmor.d.
+	AppArmorDir string
+
+	// RunCommand runs the named command with the given arguments,
+	// returning an error including its output if it fails.
+	RunCommand func(name string, args ...string) error
+}
+
+// Validate returns an error if the configuration is not complete.
+func (c WorkerConfig) Validate() error {
+	if c.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if c.SysctlDir == "" {
+		return errors.NotValidf("empty SysctlDir")
+	}
+	if c.AppArmorDir == "" {
+		return errors.NotValidf("empty AppArmorDir")
+	}
+	if c.RunCommand == nil {
+		return errors.NotValidf("nil RunCommand")
+	}
+	return nil
+}
+
+// NewWorker returns a worker which applies the sandbox profiles of the
+// charms on the machine whenever they change.
+func NewWorker(config WorkerConfig) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return watcher.NewNotifyWorker(watcher.NotifyConfig{
+		Handler: &handler{config},
+	})
+}
+
+// handler implements watcher.NotifyHandler.
+type handler struct {
+	config WorkerConfig
+}
+
+// SetUp is part of the watcher.NotifyHandler interface.
+func (h *handler) SetUp() (watcher.NotifyWatcher, error) {
+	return h.config.Facade.WatchSandboxProfiles()
+}
+
+// Handle is part of the watcher.NotifyHandler interface.
+func (h *handler) Handle(_ <-chan struct{}) error {
+	profiles, err := h.config.Facade.SandboxProfiles()
+	if err != nil {
+		return errors.Annotate(err, "getting sandbox profiles")
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].Application < profiles[j].Application
+	})
+	if err := h.applySysctls(profiles); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(h.applyAppArmorProfiles(profiles))
+}
+
+// TearDown is part of the watcher.NotifyHandler interface.
+func (h *handler) TearDown() error {
+	return nil
+}
+
+// applySysctls writes the kernel parameters asked for by all the
+// applications to a single file, and loads it if it has changed. Where
+// applications ask for different values of the same parameter, the
+// first application's value is kept and the conflict is logged, rather
+// than failing to apply the others'. Parameters no longer asked for
+// keep their current values until the machine is rebooted.
+func (h *handler) applySysctls(profiles []params.SandboxProfile) error {
+	values := make(map[string]string)
+	owners := make(map[string]string)
+	for _, profile := range profiles {
+		for name, value := range profile.Sysctls {
+			if owner, ok := owners[name]; ok {
+				if values[name] != value {
+					logger.Errorf("application %q sets sysctl %q to %q, conflicting with %q set by application %q",
+						profile.Application, name, value, values[name], owner)
+				}
+				continue
+			}
+			values[name] = value
+			owners[name] = profile.Application
+		}
+	}
+
+	path := filepath.Join(h.config.SysctlDir, sysctlFile)
+	if len(values) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return errors.Annotate(err, "removing charm sysctls")
+		}
+		return nil
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var buf bytes.Buffer
+	buf.WriteString("# Kernel parameters set for charms by juju; do not edit.\n")
+	for _, name := range names {
+		fmt.Fprintf(&buf, "# set for %s\n%s = %s\n", owners[name], name, values[name])
+	}
+
+	changed, err := writeIfChanged(path, buf.Bytes())
+	if err != nil {
+		return errors.Annotate(err, "writing charm sysctls")
+	}
+	if !changed {
+		return nil
+	}
+	logger.Infof("setting charm sysctls %s", strings.Join(names, ", "))
+	return errors.Annotate(h.config.RunCommand("sysctl", "-p", path), "setting charm sysctls")
+}
+
+// applyAppArmorProfiles loads the AppArmor profiles asked for by the
+// applications which have changed, and unloads and removes those which
+// are no longer asked for.
+func (h *handler) applyAppArmorProfiles(profiles []params.SandboxProfile) error {
+	wanted := make(map[string]bool)
+	for _, profile := range profiles {
+		for name, text := range profile.AppArmorProfiles {
+			fileName := appArmorPrefix + profile.Application + "-" + name
+			wanted[fileName] = true
+			path := filepath.Join(h.config.AppArmorDir, fileName)
+			changed, err := writeIfChanged(path, []byte(text))
+			if err != nil {
+				return errors.Annotatef(err, "writing AppArmor profile %q for application %q", name, profile.Application)
+			}
+			if !changed {
+				continue
+			}
+			logger.Infof("loading AppArmor profile %q for application %q", name, profile.Application)
+			if err := h.config.RunCommand("apparmor_parser", "-r", path); err != nil {
+				return errors.Annotatef(err, "loading AppArmor profile %q for application %q", name, profile.Application)
+			}
+		}
+	}
+
+	existing, err := filepath.Glob(filepath.Join(h.config.AppArmorDir, appArmorPrefix+"*"))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, path := range existing {
+		if wanted[filepath.Base(path)] {
+			continue
+		}
+		logger.Infof("unloading AppArmor profile %s", filepath.Base(path))
+		if err := h.config.RunCommand("apparmor_parser", "-R", path); err != nil {
+			return errors.Annotatef(err, "unloading AppArmor profile %s", filepath.Base(path))
+		}
+		if err := os.Remove(path); err != nil {
+			return errors.Annotatef(err, "removing AppArmor profile %s", filepath.Base(path))
+		}
+	}
+	return nil
+}
+
+// writeIfChanged writes the data to the file at path unless it
+// already holds it, returning whether it was written.
+func writeIfChanged(path string, data []byte) (bool, error) {
+	current, err := ioutil.ReadFile(path)
+	if err == nil && bytes.Equal(current, data) {
+		return false, nil
+	} else if err != nil && !os.IsNotExist(err) {
+		return false, errors.Trace(err)
+	}
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
@@ -0,0 +1,198 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sandboxprofiler_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/sandboxprofiler"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	facade      *mockFacade
+	changes     chan struct{}
+	commands    chan string
+	sysctlDir   string
+	appArmorDir string
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.changes = make(chan struct{}, 1)
+	s.commands = make(chan string, 10)
+	s.sysctlDir = c.MkDir()
+	s.appArmorDir = c.MkDir()
+	s.facade = &mockFacade{
+		watcher: watchertest.NewMockNotifyWatcher(s.changes),
+		profiles: []params.SandboxProfile{{
+			Application: "redis",
+			Sysctls:     map[string]string{"vm.overcommit_memory": "1"},
+		}, {
+			Application:      "nginx",
+			Sysctls:          map[string]string{"net.core.somaxconn": "4096", "vm.overcommit_memory": "2"},
+			AppArmorProfiles: map[string]string{"worker": "profile worker {}\n"},
+		}},
+	}
+}
+
+func (s *WorkerSuite) config() sandboxprofiler.WorkerConfig {
+	return sandboxprofiler.WorkerConfig{
+		Facade:      s.facade,
+		SysctlDir:   s.sysctlDir,
+		AppArmorDir: s.appArmorDir,
+		RunCommand: func(name string, args ...string) error {
+			s.commands <- name + " " + strings.Join(args, " ")
+			return nil
+		},
+	}
+}
+
+func (s *WorkerSuite) newWorker(c *gc.C) worker.Worker {
+	w, err := sandboxprofiler.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	config.Facade = nil
+	_, err := sandboxprofiler.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "nil Facade not valid")
+
+	config = s.config()
+	config.SysctlDir = ""
+	_, err = sandboxprofiler.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "empty SysctlDir not valid")
+
+	config = s.config()
+	config.AppArmorDir = ""
+	_, err = sandboxprofiler.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "empty AppArmorDir not valid")
+
+	config = s.config()
+	config.RunCommand = nil
+	_, err = sandboxprofiler.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "nil RunCommand not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *WorkerSuite) TestAppliesProfiles(c *gc.C) {
+	stale := filepath.Join(s.appArmorDir, "juju-charm-mysql-worker")
+	err := ioutil.WriteFile(stale, []byte("profile mysql {}\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	other := filepath.Join(s.appArmorDir, "usr.sbin.mysqld")
+	err = ioutil.WriteFile(other, []byte("profile mysqld {}\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+	s.changes <- struct{}{}
+
+	sysctlPath := filepath.Join(s.sysctlDir, "60-juju-charm-sandbox.conf")
+	profilePath := filepath.Join(s.appArmorDir, "juju-charm-nginx-worker")
+	c.Assert(s.nextCommand(c), gc.Equals, "sysctl -p "+sysctlPath)
+	c.Assert(s.nextCommand(c), gc.Equals, "apparmor_parser -r "+profilePath)
+	c.Assert(s.nextCommand(c), gc.Equals, "apparmor_parser -R "+stale)
+
+	// The first application's value wins a conflict.
+	data, err := ioutil.ReadFile(sysctlPath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, `
+# Kernel parameters set for charms by juju; do not edit.
+# set for nginx
+net.core.somaxconn = 4096
+# set for nginx
+vm.overcommit_memory = 2
+`[1:])
+	data, err = ioutil.ReadFile(profilePath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "profile worker {}\n")
+	_, err = os.Stat(stale)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+	_, err = os.Stat(other)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Nothing is reapplied if nothing has changed.
+	s.changes <- struct{}{}
+	s.assertNoCommand(c)
+}
+
+func (s *WorkerSuite) TestRemovesProfiles(c *gc.C) {
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+	s.changes <- struct{}{}
+	s.nextCommand(c)
+	s.nextCommand(c)
+
+	s.facade.profiles = nil
+	s.changes <- struct{}{}
+	profilePath := filepath.Join(s.appArmorDir, "juju-charm-nginx-worker")
+	c.Assert(s.nextCommand(c), gc.Equals, "apparmor_parser -R "+profilePath)
+	_, err := os.Stat(filepath.Join(s.sysctlDir, "60-juju-charm-sandbox.conf"))
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+	_, err = os.Stat(profilePath)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *WorkerSuite) TestProfilesError(c *gc.C) {
+	s.facade.err = errors.New("splat")
+	w := s.newWorker(c)
+	s.changes <- struct{}{}
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "getting sandbox profiles: splat")
+}
+
+func (s *WorkerSuite) nextCommand(c *gc.C) string {
+	select {
+	case command := <-s.commands:
+		return command
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for command")
+	}
+	panic("unreachable")
+}
+
+func (s *WorkerSuite) assertNoCommand(c *gc.C) {
+	select {
+	case command := <-s.commands:
+		c.Fatalf("unexpected command %q", command)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type mockFacade struct {
+	watcher  watcher.NotifyWatcher
+	profiles []params.SandboxProfile
+	err      error
+}
+
+func (f *mockFacade) WatchSandboxProfiles() (watcher.NotifyWatcher, error) {
+	return f.watcher, nil
+}
+
+func (f *mockFacade) SandboxProfiles() ([]params.SandboxProfile, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.profiles, nil
+}
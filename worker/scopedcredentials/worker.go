@@ -0,0 +1,167 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package scopedcredentials provides a worker which issues credentials
+// scoped to the trusted applications whose trust is scoped to them,
+// rotates the credentials before they expire, and revokes them once
+// they are no longer needed.
+package scopedcredentials
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v2/catacomb"
+
+	"github.com/juju/juju/api/scopedcredentials"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/worker/common"
+)
+
+const (
+	// period is the amount of time to wait between checking for
+	// credentials to issue, rotate or revoke. Trust is granted with
+	// application config, which is not watched, so a newly trusted
+	// application waits at most this long for its credential.
+	period = time.Minute
+
+	// lifetime is how long each issued credential is valid for.
+	lifetime = time.Hour
+
+	// renewBefore is how long before a credential expires that it is
+	// replaced, leaving time to retry if the cloud is unavailable.
+	renewBefore = 20 * time.Minute
+)
+
+// Facade describes the API used by the worker.
+type Facade interface {
+	Applications() ([]scopedcredentials.Application, error)
+	SetScopedCredential(appName string, credential cloud.Credential, expiry time.Time) error
+	RemoveScopedCredential(appName string) error
+}
+
+// Config holds the dependencies of the worker.
+type Config struct {
+	Facade        Facade
+	Scoper        environs.CredentialScoper
+	CredentialAPI common.CredentialAPI
+	Clock         clock.Clock
+	Logger        Logger
+}
+
+// Validate returns an error if the config cannot be used to start
+// the worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Scoper == nil {
+		return errors.NotValidf("nil Scoper")
+	}
+	if config.CredentialAPI == nil {
+		return errors.NotValidf("nil CredentialAPI")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// Worker issues, rotates and revokes scoped credentials.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+	callCtx  context.ProviderCallContext
+}
+
+// NewWorker returns a worker which issues, rotates and revokes the
+// credentials scoped to trusted applications.
+func NewWorker(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config: config,
+	}
+	w.callCtx = common.NewCloudCallContext(config.CredentialAPI, w.catacomb.Dying)
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+func (w *Worker) loop() error {
+	timer := w.config.Clock.NewTimer(period)
+	defer timer.Stop()
+	for {
+		if err := w.update(); err != nil {
+			// Retry when the timer next fires, rather than
+			// restarting the worker.
+			w.config.Logger.Errorf("cannot update scoped credentials: %v", err)
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-timer.Chan():
+			timer.Reset(period)
+		}
+	}
+}
+
+func (w *Worker) update() error {
+	apps, err := w.config.Facade.Applications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, app := range apps {
+		if app.Revoke {
+			if err := w.revoke(app.Name); err != nil {
+				w.config.Logger.Errorf("cannot revoke scoped credential for %q: %v", app.Name, err)
+			}
+			continue
+		}
+		if w.config.Clock.Now().Before(app.Expiry.Add(-renewBefore)) {
+			continue
+		}
+		if err := w.issue(app.Name); err != nil {
+			w.config.Logger.Errorf("cannot issue scoped credential for %q: %v", app.Name, err)
+		}
+	}
+	return nil
+}
+
+func (w *Worker) issue(appName string) error {
+	credential, err := w.config.Scoper.ScopedCredential(w.callCtx, appName, lifetime)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.config.Logger.Debugf("issued scoped credential for %q, expiring %v", appName, credential.Expiry)
+	return errors.Trace(w.config.Facade.SetScopedCredential(appName, credential.Credential, credential.Expiry))
+}
+
+func (w *Worker) revoke(appName string) error {
+	if err := w.config.Scoper.RevokeScopedCredential(w.callCtx, appName); err != nil {
+		return errors.Trace(err)
+	}
+	w.config.Logger.Debugf("revoked scoped credential for %q", appName)
+	return errors.Trace(w.config.Facade.RemoveScopedCredential(appName))
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
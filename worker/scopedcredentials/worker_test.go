@@ -0,0 +1,226 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package scopedcredentials_test
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/scopedcredentials"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	coretesting "github.com/juju/juju/testing"
+	worker_scopedcredentials "github.com/juju/juju/worker/scopedcredentials"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+	calls  chan string
+	facade *mockFacade
+	scoper *mockScoper
+	clock  *testclock.Clock
+	logger loggo.Logger
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+var now = time.Date(2021, 6, 7, 8, 0, 0, 0, time.UTC)
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.calls = make(chan string, 10)
+	s.facade = &mockFacade{calls: s.calls}
+	s.scoper = &mockScoper{calls: s.calls}
+	s.clock = testclock.NewClock(now)
+	s.logger = loggo.GetLogger("test")
+}
+
+func (s *WorkerSuite) config() worker_scopedcredentials.Config {
+	return worker_scopedcredentials.Config{
+		Facade:        s.facade,
+		Scoper:        s.scoper,
+		CredentialAPI: &mockCredentialAPI{},
+		Clock:         s.clock,
+		Logger:        s.logger,
+	}
+}
+
+func (s *WorkerSuite) assertReceived(c *gc.C, expect ...string) {
+	for _, e := range expect {
+		select {
+		case call := <-s.calls:
+			c.Assert(call, gc.Equals, e)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for %s", e)
+		}
+	}
+}
+
+func (s *WorkerSuite) assertEmpty(c *gc.C) {
+	select {
+	case call := <-s.calls:
+		c.Fatalf("unexpected %s", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	config.Scoper = nil
+	_, err := worker_scopedcredentials.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "nil Scoper not valid")
+}
+
+func (s *WorkerSuite) TestIssue(c *gc.C) {
+	s.facade.apps = []scopedcredentials.Application{{
+		// Never issued.
+		Name: "wordpress",
+	}, {
+		// Expires soon.
+		Name:   "mysql",
+		Expiry: now.Add(10 * time.Minute),
+	}, {
+		// Still valid for a while.
+		Name:   "postgresql",
+		Expiry: now.Add(30 * time.Minute),
+	}}
+	w, err := worker_scopedcredentials.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() { c.Assert(worker.Stop(w), jc.ErrorIsNil) }()
+
+	s.assertReceived(c,
+		"Applications",
+		"ScopedCredential wordpress 1h0m0s",
+		"SetScopedCredential wordpress wordpress-token",
+		"ScopedCredential mysql 1h0m0s",
+		"SetScopedCredential mysql mysql-token",
+	)
+	s.assertEmpty(c)
+}
+
+func (s *WorkerSuite) TestRotatePeriodically(c *gc.C) {
+	s.facade.apps = []scopedcredentials.Application{{
+		Name:   "wordpress",
+		Expiry: now.Add(21 * time.Minute),
+	}}
+	w, err := worker_scopedcredentials.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() { c.Assert(worker.Stop(w), jc.ErrorIsNil) }()
+
+	s.assertReceived(c, "Applications")
+	s.assertEmpty(c)
+
+	s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	s.assertReceived(c,
+		"Applications",
+		"ScopedCredential wordpress 1h0m0s",
+		"SetScopedCredential wordpress wordpress-token",
+	)
+	s.assertEmpty(c)
+}
+
+func (s *WorkerSuite) TestRevoke(c *gc.C) {
+	s.facade.apps = []scopedcredentials.Application{{
+		Name:   "wordpress",
+		Expiry: now.Add(time.Hour),
+		Revoke: true,
+	}}
+	w, err := worker_scopedcredentials.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() { c.Assert(worker.Stop(w), jc.ErrorIsNil) }()
+
+	s.assertReceived(c,
+		"Applications",
+		"RevokeScopedCredential wordpress",
+		"RemoveScopedCredential wordpress",
+	)
+	s.assertEmpty(c)
+}
+
+func (s *WorkerSuite) TestIssueErrorContinues(c *gc.C) {
+	s.facade.apps = []scopedcredentials.Application{
+		{Name: "wordpress"},
+		{Name: "mysql"},
+	}
+	s.scoper.err = map[string]error{"wordpress": errors.New("boom")}
+	w, err := worker_scopedcredentials.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertReceived(c,
+		"Applications",
+		"ScopedCredential wordpress 1h0m0s",
+		"ScopedCredential mysql 1h0m0s",
+		"SetScopedCredential mysql mysql-token",
+	)
+	c.Assert(worker.Stop(w), jc.ErrorIsNil)
+	c.Assert(c.GetTestLog(), jc.Contains, `ERROR test cannot issue scoped credential for "wordpress": boom`)
+}
+
+func (s *WorkerSuite) TestApplicationsError(c *gc.C) {
+	s.facade.err = errors.New("boom")
+	w, err := worker_scopedcredentials.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertReceived(c, "Applications")
+	c.Assert(worker.Stop(w), jc.ErrorIsNil)
+	c.Assert(c.GetTestLog(), jc.Contains, "ERROR test cannot update scoped credentials: boom")
+}
+
+type mockFacade struct {
+	calls chan<- string
+	apps  []scopedcredentials.Application
+	err   error
+}
+
+func (m *mockFacade) Applications() ([]scopedcredentials.Application, error) {
+	m.calls <- "Applications"
+	return m.apps, m.err
+}
+
+func (m *mockFacade) SetScopedCredential(appName string, credential cloud.Credential, expiry time.Time) error {
+	m.calls <- fmt.Sprintf("SetScopedCredential %s %s", appName, credential.Attributes()["Token"])
+	return nil
+}
+
+func (m *mockFacade) RemoveScopedCredential(appName string) error {
+	m.calls <- fmt.Sprintf("RemoveScopedCredential %s", appName)
+	return nil
+}
+
+type mockScoper struct {
+	calls chan<- string
+	err   map[string]error
+}
+
+func (m *mockScoper) ScopedCredential(_ context.ProviderCallContext, appName string, duration time.Duration) (environs.ScopedCredential, error) {
+	m.calls <- fmt.Sprintf("ScopedCredential %s %v", appName, duration)
+	if err := m.err[appName]; err != nil {
+		return environs.ScopedCredential{}, err
+	}
+	return environs.ScopedCredential{
+		Credential: cloud.NewCredential(cloud.OAuth2AuthType, map[string]string{
+			"Token": appName + "-token",
+		}),
+		Expiry: now.Add(duration),
+	}, nil
+}
+
+func (m *mockScoper) RevokeScopedCredential(_ context.ProviderCallContext, appName string) error {
+	m.calls <- fmt.Sprintf("RevokeScopedCredential %s", appName)
+	return nil
+}
+
+type mockCredentialAPI struct{}
+
+func (*mockCredentialAPI) InvalidateModelCredential(string) error {
+	return nil
+}
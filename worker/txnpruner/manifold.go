@@ -10,6 +10,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/worker/v2"
 	"github.com/juju/worker/v2/dependency"
+	"github.com/prometheus/client_golang/prometheus"
 
 	workerstate "github.com/juju/juju/worker/state"
 )
@@ -20,8 +21,9 @@ type ManifoldConfig struct {
 	ClockName string
 	StateName string
 
-	PruneInterval time.Duration
-	NewWorker     func(TransactionPruner, time.Duration, clock.Clock) worker.Worker
+	PruneInterval        time.Duration
+	NewWorker            func(TransactionPruner, time.Duration, clock.Clock) worker.Worker
+	PrometheusRegisterer prometheus.Registerer
 }
 
 func (config ManifoldConfig) Validate() error {
@@ -37,6 +39,9 @@ func (config ManifoldConfig) Validate() error {
 	if config.NewWorker == nil {
 		return errors.NotValidf("nil NewWorker")
 	}
+	if config.PrometheusRegisterer == nil {
+		return errors.NotValidf("nil PrometheusRegisterer")
+	}
 	return nil
 }
 
@@ -72,9 +77,18 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		return nil, errors.Trace(err)
 	}
 
-	worker := config.NewWorker(statePool.SystemState(), config.PruneInterval, clock)
+	metrics := NewMetricsCollector()
+	_ = config.PrometheusRegisterer.Register(metrics)
+	pruner := instrumentedPruner{
+		TransactionPruner: statePool.SystemState(),
+		metrics:           metrics,
+		clock:             clock,
+	}
+
+	worker := config.NewWorker(pruner, config.PruneInterval, clock)
 	go func() {
 		worker.Wait()
+		config.PrometheusRegisterer.Unregister(metrics)
 		stTracker.Done()
 	}()
 	return worker, nil
@@ -12,6 +12,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/worker/v2"
 	"github.com/juju/worker/v2/workertest"
+	"github.com/prometheus/client_golang/prometheus"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/worker/txnpruner"
@@ -43,6 +44,7 @@ func (s *ManifoldSuite) validConfig() txnpruner.ManifoldConfig {
 			s.stub.AddCall("NewWorker", tp, interval, clock)
 			return s.worker
 		},
+		PrometheusRegisterer: prometheus.NewRegistry(),
 	}
 }
 
@@ -70,6 +72,11 @@ func (s *ManifoldSuite) TestMissingNewWorker(c *gc.C) {
 	s.checkNotValid(c, "nil NewWorker not valid")
 }
 
+func (s *ManifoldSuite) TestMissingPrometheusRegisterer(c *gc.C) {
+	s.config.PrometheusRegisterer = nil
+	s.checkNotValid(c, "nil PrometheusRegisterer not valid")
+}
+
 func (s *ManifoldSuite) checkNotValid(c *gc.C, expect string) {
 	err := s.config.Validate()
 	c.Check(err, gc.ErrorMatches, expect)
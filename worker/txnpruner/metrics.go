@@ -0,0 +1,75 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package txnpruner
+
+import (
+	"github.com/juju/clock"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsNamespace = "juju_txnpruner"
+
+// Collector is a prometheus.Collector that collects metrics about
+// transaction pruning.
+type Collector struct {
+	runs     prometheus.Counter
+	failures prometheus.Counter
+	duration prometheus.Histogram
+}
+
+// NewMetricsCollector returns a new Collector.
+func NewMetricsCollector() *Collector {
+	return &Collector{
+		runs: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "runs_total",
+			Help:      "The number of times pruning of transactions has been attempted.",
+		}),
+		failures: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "failures_total",
+			Help:      "The number of times pruning of transactions has failed.",
+		}),
+		duration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "duration_seconds",
+			Help:      "The time taken to prune transactions, including deciding not to.",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 4, 10),
+		}),
+	}
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.runs.Describe(ch)
+	c.failures.Describe(ch)
+	c.duration.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.runs.Collect(ch)
+	c.failures.Collect(ch)
+	c.duration.Collect(ch)
+}
+
+// instrumentedPruner records metrics for each
+// attempt to prune transactions.
+type instrumentedPruner struct {
+	TransactionPruner
+	metrics *Collector
+	clock   clock.Clock
+}
+
+// MaybePruneTransactions is part of the TransactionPruner interface.
+func (p instrumentedPruner) MaybePruneTransactions() error {
+	start := p.clock.Now()
+	err := p.TransactionPruner.MaybePruneTransactions()
+	p.metrics.duration.Observe(p.clock.Now().Sub(start).Seconds())
+	p.metrics.runs.Inc()
+	if err != nil {
+		p.metrics.failures.Inc()
+	}
+	return err
+}
@@ -0,0 +1,72 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package txnpruner
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	gc "gopkg.in/check.v1"
+)
+
+type metricsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&metricsSuite{})
+
+type fakePruner struct {
+	err error
+}
+
+func (p fakePruner) MaybePruneTransactions() error {
+	return p.err
+}
+
+func counterValue(c *gc.C, counter prometheus.Counter) float64 {
+	var m dto.Metric
+	c.Assert(counter.Write(&m), jc.ErrorIsNil)
+	return m.GetCounter().GetValue()
+}
+
+func (s *metricsSuite) TestInstrumentedPruner(c *gc.C) {
+	metrics := NewMetricsCollector()
+	p := instrumentedPruner{
+		TransactionPruner: fakePruner{},
+		metrics:           metrics,
+		clock:             testclock.NewClock(time.Now()),
+	}
+	c.Assert(p.MaybePruneTransactions(), jc.ErrorIsNil)
+
+	p.TransactionPruner = fakePruner{err: errors.New("boom")}
+	c.Assert(p.MaybePruneTransactions(), gc.ErrorMatches, "boom")
+
+	c.Check(counterValue(c, metrics.runs), gc.Equals, float64(2))
+	c.Check(counterValue(c, metrics.failures), gc.Equals, float64(1))
+
+	var m dto.Metric
+	c.Assert(metrics.duration.Write(&m), jc.ErrorIsNil)
+	c.Check(m.GetHistogram().GetSampleCount(), gc.Equals, uint64(2))
+}
+
+func (s *metricsSuite) TestCollectorRegisters(c *gc.C) {
+	registry := prometheus.NewRegistry()
+	c.Assert(registry.Register(NewMetricsCollector()), jc.ErrorIsNil)
+	families, err := registry.Gather()
+	c.Assert(err, jc.ErrorIsNil)
+	var names []string
+	for _, family := range families {
+		names = append(names, family.GetName())
+	}
+	c.Check(names, jc.SameContents, []string{
+		"juju_txnpruner_duration_seconds",
+		"juju_txnpruner_failures_total",
+		"juju_txnpruner_runs_total",
+	})
+}
@@ -16,6 +16,10 @@ const (
 	LeaderElected         hooks.Kind = "leader-elected"
 	LeaderDeposed         hooks.Kind = "leader-deposed"
 	LeaderSettingsChanged hooks.Kind = "leader-settings-changed"
+
+	// PreRemove is run when the unit has been asked to drain ahead of
+	// its removal, so that stateful workloads may hand off their data.
+	PreRemove hooks.Kind = "pre-remove"
 )
 
 // Info holds details required to execute a hook. Not all fields are
@@ -78,7 +82,7 @@ func (hi Info) Validate() error {
 		}
 		return nil
 	// TODO(fwereade): define these in charm/hooks...
-	case LeaderElected, LeaderDeposed, LeaderSettingsChanged:
+	case LeaderElected, LeaderDeposed, LeaderSettingsChanged, PreRemove:
 		return nil
 	}
 	return fmt.Errorf("unknown hook kind %q", hi.Kind)
@@ -13,12 +13,14 @@ import (
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/proxyupdater"
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/worker/common/reboot"
+	"github.com/juju/juju/worker/common/resourcelimits"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/uniter/charm"
 	"github.com/juju/juju/worker/uniter/operation"
@@ -126,6 +128,14 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				return nil, errors.Errorf("expected a unit tag, got %v", tag)
 			}
 			uniterFacade := uniter.NewState(apiConn, unitTag)
+			proxyConfig, err := proxyupdater.NewAPI(apiConn, unitTag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			var resourceLimiter ResourceLimiter
+			if config.ModelType == model.IAAS {
+				resourceLimiter = resourcelimits.NewLimiter(unitTag.Id())
+			}
 			uniter, err := NewUniter(&UniterParams{
 				UniterFacade:                 uniterFacade,
 				UnitTag:                      unitTag,
@@ -146,6 +156,8 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				Logger:                       config.Logger,
 				Embedded:                     config.Embedded,
 				EnforcedCharmModifiedVersion: config.EnforcedCharmModifiedVersion,
+				ProxyConfig:                  proxyConfig,
+				ResourceLimiter:              resourceLimiter,
 			})
 			if err != nil {
 				return nil, errors.Trace(err)
@@ -64,6 +64,8 @@ func (opc *operationCallbacks) CommitHook(hi hook.Info) error {
 		return opc.u.relationStateTracker.CommitHook(hi)
 	case hi.Kind.IsStorage():
 		return opc.u.storage.CommitHook(hi)
+	case hi.Kind == hook.PreRemove:
+		return opc.u.unit.SetDrained()
 	}
 	return nil
 }
@@ -299,6 +299,8 @@ func (rh *runHook) Commit(state State) (*State, error) {
 		newState.Stopped = true
 	case hooks.Remove:
 		newState.Removed = true
+	case hook.PreRemove:
+		newState.Drained = true
 	}
 
 	return newState, nil
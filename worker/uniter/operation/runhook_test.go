@@ -574,6 +574,26 @@ func (s *RunHookSuite) TestCommitSuccess_Start_Preserve(c *gc.C) {
 	}
 }
 
+func (s *RunHookSuite) TestCommitSuccess_PreRemove_SetDrained(c *gc.C) {
+	for i, newHook := range []newHook{
+		operation.Factory.NewRunHook,
+		operation.Factory.NewSkipHook,
+	} {
+		c.Logf("variant %d", i)
+		s.testCommitSuccess(c,
+			newHook,
+			hook.Info{Kind: hook.PreRemove},
+			operation.State{Started: true},
+			operation.State{
+				Started: true,
+				Drained: true,
+				Kind:    operation.Continue,
+				Step:    operation.Pending,
+			},
+		)
+	}
+}
+
 func (s *RunHookSuite) assertCommitSuccess_RelationBroken_SetStatus(c *gc.C, suspended, leader bool) {
 	ctx := &MockContext{
 		isLeader: leader,
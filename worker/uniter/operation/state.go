@@ -74,6 +74,9 @@ type State struct {
 	// Removed indicates whether the remove hook has run.
 	Removed bool `yaml:"removed"`
 
+	// Drained indicates whether the pre-remove hook has run.
+	Drained bool `yaml:"drained,omitempty"`
+
 	// StatusSet indicates whether the charm being deployed has ever invoked
 	// the status-set hook tool.
 	StatusSet bool `yaml:"status-set"`
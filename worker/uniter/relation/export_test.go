@@ -49,6 +49,8 @@ func NewStateTrackerForSyncScopesTest(cfg StateTrackerForTestConfig) (RelationSt
 		unit:            cfg.Unit,
 		leaderCtx:       cfg.LeadershipContext,
 		abort:           make(chan struct{}),
+		subordinate:     cfg.Subordinate,
+		principalName:   cfg.PrincipalName,
 		relationers:     cfg.Relationers,
 		remoteAppName:   cfg.RemoteAppName,
 		relationCreated: make(map[int]bool),
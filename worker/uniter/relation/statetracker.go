@@ -282,20 +282,31 @@ func (r *relationStateTracker) SynchronizeScopes(remote remotestate.Snapshot) er
 func (r *relationStateTracker) maybeSetSubordinateDying() error {
 	// If no Alive relations remain between a subordinate unit's application
 	// and its principal's application, the subordinate must become Dying.
+	// A subordinate that was placed on its principal by a relation with
+	// another subordinate, and so has no relation with its principal's
+	// application, instead remains while any such relation is Alive.
 	principalApp, err := names.UnitApplication(r.principalName)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	var principalRelated, subordinateRelated bool
 	for _, relationer := range r.relationers {
 		relUnit := relationer.RelationUnit()
-		if relUnit.Relation().OtherApplication() != principalApp {
+		if relUnit.Endpoint().Scope != charm.ScopeContainer {
 			continue
 		}
-		scope := relUnit.Endpoint().Scope
-		if scope == charm.ScopeContainer && !relationer.IsDying() {
-			return nil
+		if relUnit.Relation().OtherApplication() == principalApp {
+			if !relationer.IsDying() {
+				return nil
+			}
+			principalRelated = true
+		} else if !relationer.IsDying() {
+			subordinateRelated = true
 		}
 	}
+	if subordinateRelated && !principalRelated {
+		return nil
+	}
 	return r.unit.Destroy()
 }
 
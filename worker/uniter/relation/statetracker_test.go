@@ -585,6 +585,53 @@ func (s *syncScopesSuite) TestSynchronizeScopesSeenNotDying(c *gc.C) {
 	c.Assert(rst.RemoteApplication(1), gc.Equals, "mysql")
 }
 
+func (s *syncScopesSuite) TestSynchronizeScopesSubordinateRelatedToSubordinate(c *gc.C) {
+	// Setup
+	defer s.setupMocks(c).Finish()
+
+	cfg := relation.StateTrackerForTestConfig{
+		St:                s.state,
+		Unit:              s.unit,
+		LeadershipContext: s.leadershipContext,
+		StateManager:      s.stateMgr,
+		Subordinate:       true,
+		PrincipalName:     "ubuntu/0",
+		Relationers:       map[int]relation.Relationer{1: s.relationer},
+		RemoteAppName:     map[int]string{1: "logging"},
+		CharmDir:          s.charmDir,
+	}
+	rst, err := relation.NewStateTrackerForSyncScopesTest(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Setup for SynchronizeScopes
+	s.relationer.EXPECT().RelationUnit().Return(s.relationUnit).Times(2)
+	s.relationUnit.EXPECT().Relation().Return(s.relation).Times(2)
+	s.expectRelationUpdateSuspended(false)
+
+	// The unit has no relation with its principal's application, but
+	// is kept alive by its relation with a colocated subordinate, so
+	// it is not destroyed.
+	s.relationUnit.EXPECT().Endpoint().Return(uniter.Endpoint{
+		Relation: charm.Relation{Scope: charm.ScopeContainer},
+	})
+	s.relation.EXPECT().OtherApplication().Return("logging")
+	s.relationer.EXPECT().IsDying().Return(false)
+
+	remoteState := remotestate.Snapshot{
+		Relations: map[int]remotestate.RelationSnapshot{
+			1: {
+				Life: life.Alive,
+				Members: map[string]int64{
+					"logging/0": 1,
+				},
+			},
+		},
+	}
+
+	err = rst.SynchronizeScopes(remoteState)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 //
 // Relationer
 //
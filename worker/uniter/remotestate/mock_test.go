@@ -217,6 +217,7 @@ type mockUnit struct {
 	life                             life.Value
 	providerID                       string
 	resolved                         params.ResolvedMode
+	drainRequested                   bool
 	application                      mockApplication
 	unitWatcher                      *mockNotifyWatcher
 	addressesWatcher                 *mockStringsWatcher
@@ -250,6 +251,10 @@ func (u *mockUnit) Resolved() params.ResolvedMode {
 	return u.resolved
 }
 
+func (u *mockUnit) DrainRequested() bool {
+	return u.drainRequested
+}
+
 func (u *mockUnit) Application() (remotestate.Application, error) {
 	return &u.application, nil
 }
@@ -45,6 +45,10 @@ type Snapshot struct {
 	// ProviderID is the cloud container's provider ID.
 	ProviderID string
 
+	// DrainRequested reports whether the unit has been
+	// asked to drain ahead of its removal.
+	DrainRequested bool
+
 	// RetryHookVersion increments each time a failed
 	// hook is meant to be retried if ResolvedMode is
 	// set to ResolvedNone.
@@ -19,14 +19,26 @@ import (
 	"github.com/juju/juju/wrench"
 )
 
+// HookRetryStatus describes the automatic retries of a failed hook.
+type HookRetryStatus struct {
+	// Attempts is the number of times the hook has been retried
+	// automatically.
+	Attempts int
+
+	// Retrying is true if the hook will be retried automatically.
+	Retrying bool
+}
+
 // ResolverConfig defines configuration for the uniter resolver.
 type ResolverConfig struct {
 	ModelType           model.ModelType
 	ClearResolved       func() error
-	ReportHookError     func(hook.Info) error
+	ReportHookError     func(hook.Info, HookRetryStatus) error
 	ShouldRetryHooks    bool
 	StartRetryHookTimer func()
 	StopRetryHookTimer  func()
+	MaxHookRetries      int
+	RetryHookKinds      []hooks.Kind
 	VerifyCharmProfile  resolver.Resolver
 	UpgradeSeries       resolver.Resolver
 	Reboot              resolver.Resolver
@@ -43,6 +55,7 @@ type ResolverConfig struct {
 type uniterResolver struct {
 	config                ResolverConfig
 	retryHookTimerStarted bool
+	retryHookAttempts     int
 }
 
 // NewUniterResolver returns a new resolver.Resolver for the uniter.
@@ -96,12 +109,15 @@ func (s *uniterResolver) NextOp(
 		return nil, resolver.ErrRestart
 	}
 
-	if s.retryHookTimerStarted && (localState.Kind != operation.RunHook || localState.Step != operation.Pending) {
-		// The hook-retry timer is running, but there is no pending
-		// hook operation. We're not in an error state, so stop the
-		// timer now to reset the backoff state.
-		s.config.StopRetryHookTimer()
-		s.retryHookTimerStarted = false
+	if localState.Kind != operation.RunHook || localState.Step != operation.Pending {
+		// There is no pending hook operation. We're not in an error
+		// state, so stop the hook-retry timer if it is running to
+		// reset the backoff state, and forget any retries made.
+		if s.retryHookTimerStarted {
+			s.config.StopRetryHookTimer()
+			s.retryHookTimerStarted = false
+		}
+		s.retryHookAttempts = 0
 	}
 
 	op, err = s.config.CreatedRelations.NextOp(localState, remoteState, opFactory)
@@ -230,7 +246,11 @@ func (s *uniterResolver) nextOpHookError(
 ) (operation.Operation, error) {
 
 	// Report the hook error.
-	if err := s.config.ReportHookError(*localState.Hook); err != nil {
+	retry := HookRetryStatus{
+		Attempts: s.retryHookAttempts,
+		Retrying: s.shouldRetryHook(*localState.Hook),
+	}
+	if err := s.config.ReportHookError(*localState.Hook, retry); err != nil {
 		return nil, errors.Trace(err)
 	}
 
@@ -249,9 +269,10 @@ func (s *uniterResolver) nextOpHookError(
 			// timer. If the hook succeeds, we'll enter nextOp
 			// and stop the timer.
 			s.retryHookTimerStarted = false
+			s.retryHookAttempts++
 			return opFactory.NewRunHook(*localState.Hook)
 		}
-		if !s.retryHookTimerStarted && s.config.ShouldRetryHooks {
+		if !s.retryHookTimerStarted && retry.Retrying {
 			// We haven't yet started a retry timer, so start one
 			// now. If we retry and fail, retryHookTimerStarted is
 			// cleared so that we'll still start it again.
@@ -262,6 +283,7 @@ func (s *uniterResolver) nextOpHookError(
 	case params.ResolvedRetryHooks:
 		s.config.StopRetryHookTimer()
 		s.retryHookTimerStarted = false
+		s.retryHookAttempts = 0
 		if err := s.config.ClearResolved(); err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -269,6 +291,7 @@ func (s *uniterResolver) nextOpHookError(
 	case params.ResolvedNoHooks:
 		s.config.StopRetryHookTimer()
 		s.retryHookTimerStarted = false
+		s.retryHookAttempts = 0
 		if err := s.config.ClearResolved(); err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -280,6 +303,26 @@ func (s *uniterResolver) nextOpHookError(
 	}
 }
 
+// shouldRetryHook returns whether the given failed hook should be
+// retried automatically, according to the configured retry policy.
+func (s *uniterResolver) shouldRetryHook(hookInfo hook.Info) bool {
+	if !s.config.ShouldRetryHooks {
+		return false
+	}
+	if s.config.MaxHookRetries > 0 && s.retryHookAttempts >= s.config.MaxHookRetries {
+		return false
+	}
+	if len(s.config.RetryHookKinds) == 0 {
+		return true
+	}
+	for _, kind := range s.config.RetryHookKinds {
+		if kind == hookInfo.Kind {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *uniterResolver) charmModified(local resolver.LocalState, remote remotestate.Snapshot) bool {
 	// CAAS models may not yet have read the charm url from state.
 	if remote.CharmURL == nil {
@@ -349,6 +392,12 @@ func (s *uniterResolver) nextOp(
 		return opFactory.NewRunHook(hook.Info{Kind: hooks.ConfigChanged})
 	}
 
+	// The unit has been asked to drain ahead of its removal, so give
+	// the charm the chance to hand off its data before anything else.
+	if remoteState.DrainRequested && !localState.Drained {
+		return opFactory.NewRunHook(hook.Info{Kind: hook.PreRemove})
+	}
+
 	op, err := s.config.Relations.NextOp(localState, remoteState, opFactory)
 	if errors.Cause(err) != resolver.ErrNoOperation {
 		return op, err
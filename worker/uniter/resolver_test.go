@@ -41,7 +41,7 @@ type baseResolverSuite struct {
 	resolverConfig uniter.ResolverConfig
 
 	clearResolved   func() error
-	reportHookError func(hook.Info) error
+	reportHookError func(hook.Info, uniter.HookRetryStatus) error
 }
 
 type resolverSuite struct {
@@ -94,7 +94,7 @@ func (s *baseResolverSuite) SetUpTest(c *gc.C, modelType model.ModelType, reboot
 	logger := loggo.GetLogger("test")
 	s.resolverConfig = uniter.ResolverConfig{
 		ClearResolved:       func() error { return s.clearResolved() },
-		ReportHookError:     func(info hook.Info) error { return s.reportHookError(info) },
+		ReportHookError:     func(info hook.Info, retry uniter.HookRetryStatus) error { return s.reportHookError(info, retry) },
 		StartRetryHookTimer: func() { s.stub.AddCall("StartRetryHookTimer") },
 		StopRetryHookTimer:  func() { s.stub.AddCall("StopRetryHookTimer") },
 		ShouldRetryHooks:    true,
@@ -127,7 +127,7 @@ func (s *baseResolverSuite) SetUpTest(c *gc.C, modelType model.ModelType, reboot
 		}
 	}
 
-	s.reportHookError = func(hook.Info) error {
+	s.reportHookError = func(hook.Info, uniter.HookRetryStatus) error {
 		return nil
 		//return errors.New("unexpected report hook error")
 	}
@@ -151,6 +151,26 @@ func (s *resolverSuite) TestStartedNotInstalled(c *gc.C) {
 	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
 }
 
+func (s *resolverSuite) TestDrainRequested(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmURL: s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+	s.remoteState.DrainRequested = true
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run pre-remove hook")
+
+	// Once drained, the hook is not run again.
+	localState.Drained = true
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
 // TestNotStartedNotInstalled tests whether the next operation for an
 // uninstalled local state is an install hook operation.
 func (s *resolverSuite) TestNotStartedNotInstalled(c *gc.C) {
@@ -240,7 +260,7 @@ func (s *iaasResolverSuite) TestUniterIdlesWhenRemoteStateIsUpgradeSeriesComplet
 func (s *resolverSuite) TestHookErrorDoesNotStartRetryTimerIfShouldRetryFalse(c *gc.C) {
 	s.resolverConfig.ShouldRetryHooks = false
 	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
-	s.reportHookError = func(hook.Info) error { return nil }
+	s.reportHookError = func(hook.Info, uniter.HookRetryStatus) error { return nil }
 	localState := resolver.LocalState{
 		CharmURL: s.charmURL,
 		State: operation.State{
@@ -260,7 +280,7 @@ func (s *resolverSuite) TestHookErrorDoesNotStartRetryTimerIfShouldRetryFalse(c
 }
 
 func (s *resolverSuite) TestHookErrorStartRetryTimer(c *gc.C) {
-	s.reportHookError = func(hook.Info) error { return nil }
+	s.reportHookError = func(hook.Info, uniter.HookRetryStatus) error { return nil }
 	localState := resolver.LocalState{
 		CharmURL: s.charmURL,
 		State: operation.State{
@@ -285,7 +305,7 @@ func (s *resolverSuite) TestHookErrorStartRetryTimer(c *gc.C) {
 }
 
 func (s *resolverSuite) TestHookErrorStartRetryTimerAgain(c *gc.C) {
-	s.reportHookError = func(hook.Info) error { return nil }
+	s.reportHookError = func(hook.Info, uniter.HookRetryStatus) error { return nil }
 	localState := resolver.LocalState{
 		CharmURL: s.charmURL,
 		State: operation.State{
@@ -315,6 +335,76 @@ func (s *resolverSuite) TestHookErrorStartRetryTimerAgain(c *gc.C) {
 	s.stub.CheckCallNames(c, "StartRetryHookTimer", "StartRetryHookTimer")
 }
 
+func (s *resolverSuite) TestHookErrorRetryLimit(c *gc.C) {
+	s.resolverConfig.MaxHookRetries = 1
+	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
+	var reported []uniter.HookRetryStatus
+	s.reportHookError = func(_ hook.Info, retry uniter.HookRetryStatus) error {
+		reported = append(reported, retry)
+		return nil
+	}
+	localState := resolver.LocalState{
+		CharmURL: s.charmURL,
+		State: operation.State{
+			Kind:      operation.RunHook,
+			Step:      operation.Pending,
+			Installed: true,
+			Started:   true,
+			Hook: &hook.Info{
+				Kind: hooks.ConfigChanged,
+			},
+		},
+	}
+
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	s.stub.CheckCallNames(c, "StartRetryHookTimer")
+
+	s.remoteState.RetryHookVersion = 1
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run config-changed hook")
+	localState.RetryHookVersion = 1
+
+	// The retry failed, and the limit has been reached, so the
+	// timer is not started again.
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	s.stub.CheckCallNames(c, "StartRetryHookTimer")
+	c.Assert(reported, jc.DeepEquals, []uniter.HookRetryStatus{
+		{Attempts: 0, Retrying: true},
+		{Attempts: 0, Retrying: true},
+		{Attempts: 1, Retrying: false},
+	})
+}
+
+func (s *resolverSuite) TestHookErrorRetryHookKinds(c *gc.C) {
+	s.resolverConfig.RetryHookKinds = []hooks.Kind{hooks.Install}
+	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
+	var reported []uniter.HookRetryStatus
+	s.reportHookError = func(_ hook.Info, retry uniter.HookRetryStatus) error {
+		reported = append(reported, retry)
+		return nil
+	}
+	localState := resolver.LocalState{
+		CharmURL: s.charmURL,
+		State: operation.State{
+			Kind:      operation.RunHook,
+			Step:      operation.Pending,
+			Installed: true,
+			Started:   true,
+			Hook: &hook.Info{
+				Kind: hooks.ConfigChanged,
+			},
+		},
+	}
+	// config-changed is not retried automatically.
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	s.stub.CheckNoCalls(c)
+	c.Assert(reported, jc.DeepEquals, []uniter.HookRetryStatus{{}})
+}
+
 func (s *resolverSuite) TestResolvedRetryHooksStopRetryTimer(c *gc.C) {
 	// Resolving a failed hook should stop the retry timer.
 	s.testResolveHookErrorStopRetryTimer(c, params.ResolvedRetryHooks)
@@ -328,7 +418,7 @@ func (s *resolverSuite) TestResolvedNoHooksStopRetryTimer(c *gc.C) {
 func (s *resolverSuite) testResolveHookErrorStopRetryTimer(c *gc.C, mode params.ResolvedMode) {
 	s.stub.ResetCalls()
 	s.clearResolved = func() error { return nil }
-	s.reportHookError = func(hook.Info) error { return nil }
+	s.reportHookError = func(hook.Info, uniter.HookRetryStatus) error { return nil }
 	localState := resolver.LocalState{
 		CharmURL: s.charmURL,
 		State: operation.State{
@@ -353,7 +443,7 @@ func (s *resolverSuite) testResolveHookErrorStopRetryTimer(c *gc.C, mode params.
 }
 
 func (s *resolverSuite) TestRunHookStopRetryTimer(c *gc.C) {
-	s.reportHookError = func(hook.Info) error { return nil }
+	s.reportHookError = func(hook.Info, uniter.HookRetryStatus) error { return nil }
 	localState := resolver.LocalState{
 		CharmURL: s.charmURL,
 		State: operation.State{
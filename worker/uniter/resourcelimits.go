@@ -0,0 +1,74 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/worker/common/resourcelimits"
+)
+
+// resourceLimitsRefreshInterval is the least time between reads of the
+// unit's resource limits, which are refreshed when the uniter is idle.
+const resourceLimitsRefreshInterval = 5 * time.Minute
+
+// refreshResourceLimits reads the unit's resource limits from its
+// application's constraints, and applies them to the unit's slice.
+// Failures are logged rather than returned, so that they don't stop
+// the unit's hooks from running; if limits can't be applied on this
+// machine or controller, the limiter is disabled.
+func (u *Uniter) refreshResourceLimits() {
+	if u.resourceLimiter == nil {
+		return
+	}
+	now := u.clock.Now()
+	if !u.resourceLimitsRefreshed.IsZero() && now.Sub(u.resourceLimitsRefreshed) < resourceLimitsRefreshInterval {
+		return
+	}
+	u.resourceLimitsRefreshed = now
+
+	limits, err := u.unit.ResourceLimits()
+	if errors.IsNotImplemented(err) {
+		u.logger.Debugf("controller does not support unit resource limits")
+		u.resourceLimiter = nil
+		return
+	} else if err != nil {
+		u.logger.Warningf("cannot get resource limits: %v", err)
+		return
+	}
+	var toApply resourcelimits.Limits
+	if limits.CPUQuota != nil {
+		toApply.CPUQuota = *limits.CPUQuota
+	}
+	if limits.MemoryLimit != nil {
+		toApply.MemoryLimit = *limits.MemoryLimit
+	}
+	err = u.resourceLimiter.Apply(toApply)
+	if errors.IsNotSupported(err) {
+		if !toApply.IsZero() {
+			u.logger.Warningf("cannot apply resource limits: %v", err)
+		}
+		u.resourceLimiter = nil
+	} else if err != nil {
+		u.logger.Warningf("cannot apply resource limits: %v", err)
+	}
+}
+
+// resourceLimitsStatus returns a message describing how the unit's
+// workload processes have been throttled since the uniter was last
+// idle, or "" if they haven't been.
+func (u *Uniter) resourceLimitsStatus() string {
+	if u.resourceLimiter == nil {
+		return ""
+	}
+	reasons, err := u.resourceLimiter.Throttled()
+	if err != nil {
+		u.logger.Warningf("cannot check resource limits: %v", err)
+		return ""
+	}
+	return strings.Join(reasons, ", ")
+}
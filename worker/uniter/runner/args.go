@@ -85,3 +85,14 @@ func hookCommand(hook string) []string {
 	}
 	return []string{hook}
 }
+
+// sliceCommand wraps the command so that systemd runs it in a scope
+// within the given slice, limiting the resources of the command and
+// any processes it starts. The command keeps the caller's environment,
+// working directory and output.
+func sliceCommand(slice string, command []string) []string {
+	if slice == "" || jujuos.HostOS() == jujuos.Windows {
+		return command
+	}
+	return append([]string{"systemd-run", "--scope", "--quiet", "--slice=" + slice, "--"}, command...)
+}
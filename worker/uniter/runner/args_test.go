@@ -99,3 +99,21 @@ func (s *WindowsHookSuite) TestSearchHookWindowsError(c *gc.C) {
 	c.Assert(err.Error(), gc.Equals, filepath.FromSlash("hooks/something-happened does not exist"))
 	c.Assert(obtained, gc.Equals, "")
 }
+
+type SliceCommandSuite struct {
+	envtesting.IsolationSuite
+}
+
+var _ = gc.Suite(&SliceCommandSuite{})
+
+func (s *SliceCommandSuite) TestSliceCommand(c *gc.C) {
+	s.PatchValue(&os.HostOS, func() os.OSType { return os.Ubuntu })
+	c.Assert(runner.SliceCommand("juju-mysql_0.slice", []string{"/path/to/hook"}), gc.DeepEquals, []string{
+		"systemd-run", "--scope", "--quiet", "--slice=juju-mysql_0.slice", "--", "/path/to/hook",
+	})
+}
+
+func (s *SliceCommandSuite) TestSliceCommandNoSlice(c *gc.C) {
+	s.PatchValue(&os.HostOS, func() os.OSType { return os.Ubuntu })
+	c.Assert(runner.SliceCommand("", []string{"/path/to/hook"}), gc.DeepEquals, []string{"/path/to/hook"})
+}
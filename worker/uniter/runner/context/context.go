@@ -25,6 +25,7 @@ import (
 	"github.com/juju/juju/caas"
 	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
 	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/quota"
@@ -130,6 +131,7 @@ type HookUnit interface {
 	Name() string
 	NetworkInfo(bindings []string, relationId *int) (map[string]params.NetworkInfoResult, error)
 	RequestReboot() error
+	SecretConfigValue(string) (string, error)
 	SetUnitStatus(unitStatus status.Status, info string, data map[string]interface{}) error
 	SetAgentStatus(agentStatus status.Status, info string, data map[string]interface{}) error
 	State() (params.UnitStateResult, error)
@@ -137,6 +139,7 @@ type HookUnit interface {
 	UnitStatus() (params.StatusResult, error)
 	UpdateNetworkInfo() error
 	CommitHookChanges(params.CommitHookChangesArgs) error
+	CreateStorageSnapshot(names.StorageTag, string) (params.StorageSnapshot, error)
 }
 
 // HookContext is the implementation of runner.Context.
@@ -230,6 +233,10 @@ type HookContext struct {
 	// jujuProxySettings are the current juju proxy settings that the uniter knows about.
 	jujuProxySettings proxy.Settings
 
+	// resourceSlice is the systemd slice that limits the resources of
+	// the unit's workload processes, if any.
+	resourceSlice string
+
 	// meterStatus is the status of the unit's metering.
 	meterStatus *meterStatus
 
@@ -499,6 +506,12 @@ func (ctx *HookContext) ModelType() model.ModelType {
 	return ctx.modelType
 }
 
+// ResourceSlice returns the systemd slice that charm processes should be
+// run in to limit their resources, or "" if they aren't limited.
+func (ctx *HookContext) ResourceSlice() string {
+	return ctx.resourceSlice
+}
+
 // UnitStatus will return the status for the current Unit.
 // Implements jujuc.HookContext.ContextStatus, part of runner.Context.
 func (ctx *HookContext) UnitStatus() (*jujuc.StatusInfo, error) {
@@ -648,6 +661,15 @@ func (ctx *HookContext) AvailabilityZone() (string, error) {
 	return ctx.availabilityzone, nil
 }
 
+// Devices returns the devices, such as GPUs, allocated to the executing
+// unit's machine. Only devices with nodes on the machine are reported,
+// which inside a container are those passed through to it.
+// Implements jujuc.HookContext.ContextInstance, part of runner.Context.
+func (ctx *HookContext) Devices() ([]devices.Device, error) {
+	gpus, err := devices.LocalGPUs()
+	return gpus, errors.Trace(err)
+}
+
 // StorageTags returns a list of tags for storage instances
 // attached to the unit or an error if they are not available.
 // Implements jujuc.HookContext.ContextStorage, part of runner.Context.
@@ -689,6 +711,20 @@ func (ctx *HookContext) AddUnitStorage(cons map[string]params.StorageConstraints
 	return nil
 }
 
+// CreateStorageSnapshot takes a provider snapshot of the volume backing
+// the storage instance with the supplied tag, which must be attached to
+// the unit. Unlike most hook tool changes, the snapshot is taken
+// immediately rather than when the context is flushed, so that charms
+// can resume their workload once it has been taken.
+// Implements jujuc.HookContext.ContextStorage, part of runner.Context.
+func (ctx *HookContext) CreateStorageSnapshot(tag names.StorageTag, description string) (params.StorageSnapshot, error) {
+	if _, err := ctx.storage.Storage(tag); err != nil {
+		return params.StorageSnapshot{}, errors.Trace(err)
+	}
+	snapshot, err := ctx.unit.CreateStorageSnapshot(tag, description)
+	return snapshot, errors.Trace(err)
+}
+
 // OpenPortRange marks the supplied port range for opening.
 // Implements jujuc.HookContext.ContextNetworking, part of runner.Context.
 func (ctx *HookContext) OpenPortRange(endpointName string, portRange network.PortRange) error {
@@ -727,6 +763,15 @@ func (ctx *HookContext) ConfigSettings() (charm.Settings, error) {
 	return result, nil
 }
 
+// SecretConfigValue returns the value of the application's secret charm
+// config option with the supplied key. Unlike other config, the value is
+// not cached in the context; each read is recorded by the controller.
+// Implements jujuc.HookContext.ContextUnit, part of runner.Context.
+func (ctx *HookContext) SecretConfigValue(key string) (string, error) {
+	value, err := ctx.unit.SecretConfigValue(key)
+	return value, errors.Trace(err)
+}
+
 // GoalState returns the goal state for the current unit.
 // Implements jujuc.HookContext.ContextUnit, part of runner.Context.
 func (ctx *HookContext) GoalState() (*application.GoalState, error) {
@@ -976,6 +1021,9 @@ func (ctx *HookContext) HookVars(paths Paths, remote bool, getEnv GetEnvFunc) ([
 			"JUJU_AGENT_CA_CERT="+path.Join(paths.GetBaseDir(), caas.CACertFile),
 		)
 	}
+	if ctx.resourceSlice != "" {
+		vars = append(vars, "JUJU_UNIT_SLICE="+ctx.resourceSlice)
+	}
 	if ctx.meterStatus != nil {
 		vars = append(vars,
 			"JUJU_METER_STATUS="+ctx.meterStatus.code,
@@ -1088,7 +1136,24 @@ func (ctx *HookContext) doFlush(process string) error {
 	}
 
 	for _, rctx := range ctx.relations {
-		unitSettings, appSettings := rctx.FinalSettings()
+		if rctx.encrypted() {
+			// The leader reseals the application settings of encrypted
+			// relations even if the charm hasn't touched them, so that
+			// new remote units are given its settings key.
+			isLeader, err := ctx.IsLeader()
+			if err != nil {
+				return errors.Annotatef(err, "cannot determine leadership")
+			}
+			if isLeader {
+				if _, err := rctx.ApplicationSettings(); err != nil {
+					return errors.Trace(err)
+				}
+			}
+		}
+		unitSettings, appSettings, err := rctx.FinalSettings()
+		if err != nil {
+			return errors.Trace(err)
+		}
 		if len(unitSettings)+len(appSettings) == 0 {
 			continue // no settings need updating
 		}
@@ -1289,6 +1354,26 @@ func (ctx *HookContext) SetUnitWorkloadVersion(version string) error {
 	return result.OneError()
 }
 
+// SetRelationSchema registers the schema that relation data written by
+// related applications over the named endpoint must satisfy.
+// Implements jujuc.HookContext.ContextRelations, part of runner.Context.
+func (ctx *HookContext) SetRelationSchema(endpoint string, application bool, schema string) error {
+	var result params.ErrorResults
+	args := params.RelationSchemaArgs{
+		Args: []params.RelationSchemaArg{{
+			Tag:         ctx.unit.Tag().String(),
+			Endpoint:    endpoint,
+			Application: application,
+			Schema:      schema,
+		}},
+	}
+	err := ctx.state.Facade().FacadeCall("SetRelationSchemas", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // NetworkInfo returns the network info for the given bindings on the given relation.
 // Implements jujuc.HookContext.ContextNetworking, part of runner.Context.
 func (ctx *HookContext) NetworkInfo(bindingNames []string, relationId int) (map[string]params.NetworkInfoResult, error) {
@@ -6,6 +6,7 @@ package context
 import (
 	"fmt"
 	"math/rand"
+	"sort"
 	"time"
 
 	"github.com/juju/charm/v9/hooks"
@@ -13,6 +14,7 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
 
+	"github.com/juju/juju/api/proxyupdater"
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
@@ -82,6 +84,14 @@ type contextFactory struct {
 	zone       string
 	principal  string
 
+	// proxyConfig, if set, supplies the unit's proxy settings with any
+	// application overrides applied.
+	proxyConfig ProxyConfigGetter
+
+	// resourceSlicer, if set, supplies the systemd slice that limits
+	// the resources of the unit's workload processes.
+	resourceSlicer ResourceSlicer
+
 	// Callback to get relation state snapshot.
 	getRelationInfos RelationsFunc
 	relationCaches   map[int]*RelationCache
@@ -90,6 +100,19 @@ type contextFactory struct {
 	rand *rand.Rand
 }
 
+// ProxyConfigGetter provides the proxy settings for a unit.
+type ProxyConfigGetter interface {
+	ProxyConfig() (proxyupdater.ProxyConfiguration, error)
+}
+
+// ResourceSlicer provides the systemd slice that limits the resources
+// of a unit's workload processes.
+type ResourceSlicer interface {
+	// Slice returns the name of the slice, or "" if the unit's
+	// resources aren't limited.
+	Slice() string
+}
+
 // FactoryConfig contains configuration values
 // for the context factory.
 type FactoryConfig struct {
@@ -101,6 +124,16 @@ type FactoryConfig struct {
 	Paths            Paths
 	Clock            Clock
 	Logger           loggo.Logger
+
+	// ProxyConfig is optional; when set, the hook environment gets the
+	// unit's proxy settings, including any application overrides,
+	// rather than the model's.
+	ProxyConfig ProxyConfigGetter
+
+	// ResourceSlicer is optional; when set, charm processes are run in
+	// the slice it provides, which is also passed to them in the
+	// JUJU_UNIT_SLICE environment variable.
+	ResourceSlicer ResourceSlicer
 }
 
 // NewContextFactory returns a ContextFactory capable of creating execution contexts backed
@@ -149,6 +182,8 @@ func NewContextFactory(config FactoryConfig) (ContextFactory, error) {
 		zone:             zone,
 		principal:        principal,
 		modelType:        m.ModelType,
+		proxyConfig:      config.ProxyConfig,
+		resourceSlicer:   config.ResourceSlicer,
 	}
 	return f, nil
 }
@@ -285,12 +320,32 @@ func (f *contextFactory) getContextRelations() map[int]*ContextRelation {
 			cache = NewRelationCache(relationUnit.ReadSettings, memberNames)
 		}
 		relationCaches[id] = cache
-		contextRelations[id] = NewContextRelation(relationUnit, cache)
+		contextRelation := NewContextRelation(relationUnit, cache)
+		contextRelation.keysDir = f.paths.ComponentDir(relationKeysDir)
+		contextRelation.peerNames = f.peerUnitNames
+		contextRelations[id] = contextRelation
 	}
 	f.relationCaches = relationCaches
 	return contextRelations
 }
 
+// peerUnitNames returns the names of the other units of the unit's
+// application.
+func (f *contextFactory) peerUnitNames() ([]string, error) {
+	goalState, err := f.state.GoalState()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var names []string
+	for name := range goalState.Units {
+		if name != f.unit.Name() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
 // updateContext fills in all unspecialized fields that require an API call to
 // discover.
 //
@@ -326,6 +381,17 @@ func (f *contextFactory) updateContext(ctx *HookContext) (err error) {
 	}
 	ctx.legacyProxySettings = modelConfig.LegacyProxySettings()
 	ctx.jujuProxySettings = modelConfig.JujuProxySettings()
+	if f.proxyConfig != nil {
+		proxyConfig, err := f.proxyConfig.ProxyConfig()
+		if err != nil {
+			return errors.Annotate(err, "could not retrieve proxy settings for unit")
+		}
+		ctx.legacyProxySettings = proxyConfig.LegacyProxy
+		ctx.jujuProxySettings = proxyConfig.JujuProxy
+	}
+	if f.resourceSlicer != nil {
+		ctx.resourceSlice = f.resourceSlicer.Slice()
+	}
 
 	statusCode, statusInfo, err := f.unit.MeterStatus()
 	if err != nil {
@@ -249,6 +249,22 @@ func (s *EnvSuite) TestEnvUbuntu(c *gc.C) {
 	}
 }
 
+func (s *EnvSuite) TestEnvResourceSlice(c *gc.C) {
+	s.PatchValue(&jujuos.HostOS, func() jujuos.OSType { return jujuos.Ubuntu })
+	s.PatchValue(&series.HostSeries, func() (string, error) { return "focal", nil })
+
+	ctx, _ := s.getContext(false)
+	paths, _ := s.getPaths()
+	context.SetResourceSlice(ctx, "juju-this_unit_123.slice")
+	c.Assert(ctx.ResourceSlice(), gc.Equals, "juju-this_unit_123.slice")
+
+	actualVars, err := ctx.HookVars(paths, false, func(string) string { return "" })
+	c.Assert(err, jc.ErrorIsNil)
+	vars, err := keyvalues.Parse(actualVars, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(vars["JUJU_UNIT_SLICE"], gc.Equals, "juju-this_unit_123.slice")
+}
+
 func (s *EnvSuite) TestEnvCentos(c *gc.C) {
 	s.PatchValue(&jujuos.HostOS, func() jujuos.OSType { return jujuos.CentOS })
 	s.PatchValue(&jujuversion.Current, version.MustParse("1.2.3"))
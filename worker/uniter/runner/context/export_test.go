@@ -200,6 +200,12 @@ func NewModelHookContext(p ModelHookContextParams) *HookContext {
 	}
 }
 
+// SetResourceSlice sets the slice that limits the resources of the
+// context's charm processes.
+func SetResourceSlice(ctx *HookContext, slice string) {
+	ctx.resourceSlice = slice
+}
+
 func ContextEnvInfo(hctx *HookContext) (name, uuid string) {
 	return hctx.modelName, hctx.uuid
 }
@@ -245,3 +251,11 @@ func CachedAppSettings(cf0 ContextFactory, relId int, appName string) (params.Se
 func (ctx *HookContext) SLALevel() string {
 	return ctx.slaLevel
 }
+
+func SetRelationKeysDir(ctx *ContextRelation, dir string) {
+	ctx.keysDir = dir
+}
+
+func SetRelationPeerNames(ctx *ContextRelation, peerNames func() ([]string, error)) {
+	ctx.peerNames = peerNames
+}
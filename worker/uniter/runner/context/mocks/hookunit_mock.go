@@ -94,6 +94,21 @@ func (mr *MockHookUnitMockRecorder) CommitHookChanges(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitHookChanges", reflect.TypeOf((*MockHookUnit)(nil).CommitHookChanges), arg0)
 }
 
+// CreateStorageSnapshot mocks base method
+func (m *MockHookUnit) CreateStorageSnapshot(arg0 names.StorageTag, arg1 string) (params.StorageSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStorageSnapshot", arg0, arg1)
+	ret0, _ := ret[0].(params.StorageSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStorageSnapshot indicates an expected call of CreateStorageSnapshot
+func (mr *MockHookUnitMockRecorder) CreateStorageSnapshot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStorageSnapshot", reflect.TypeOf((*MockHookUnit)(nil).CreateStorageSnapshot), arg0, arg1)
+}
+
 // ConfigSettings mocks base method
 func (m *MockHookUnit) ConfigSettings() (charm.Settings, error) {
 	m.ctrl.T.Helper()
@@ -180,6 +195,21 @@ func (mr *MockHookUnitMockRecorder) RequestReboot() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestReboot", reflect.TypeOf((*MockHookUnit)(nil).RequestReboot))
 }
 
+// SecretConfigValue mocks base method
+func (m *MockHookUnit) SecretConfigValue(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecretConfigValue", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SecretConfigValue indicates an expected call of SecretConfigValue
+func (mr *MockHookUnitMockRecorder) SecretConfigValue(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretConfigValue", reflect.TypeOf((*MockHookUnit)(nil).SecretConfigValue), arg0)
+}
+
 // SetAgentStatus mocks base method
 func (m *MockHookUnit) SetAgentStatus(arg0 status.Status, arg1 string, arg2 map[string]interface{}) error {
 	m.ctrl.T.Helper()
@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/relation"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
@@ -40,6 +41,11 @@ type Relation interface {
 
 	// Life returns the relation's current life state.
 	Life() life.Value
+
+	// KeyGeneration returns the generation of the keys with which the
+	// related units encrypt their settings, or zero if the relation's
+	// settings are not encrypted.
+	KeyGeneration() int
 }
 
 type RelationUnit interface {
@@ -94,6 +100,26 @@ type ContextRelation struct {
 
 	// cache holds remote unit membership and settings.
 	cache *RelationCache
+
+	// keysDir is the directory holding the unit's keys for the relation
+	// if its settings are encrypted.
+	keysDir string
+
+	// keys holds the unit's keys for the relation, newest first, once
+	// they have been needed.
+	keys []*crossmodel.RelationKeys
+
+	// peerNames, if set, returns the names of the other units of the
+	// unit's application. When the relation's settings are encrypted,
+	// the leader wraps the application settings key for them.
+	peerNames func() ([]string, error)
+
+	// storedSettings and storedApplicationSettings hold the unit and
+	// application settings as they are stored, encrypted, when the
+	// relation's settings are encrypted; settings and applicationSettings
+	// then hold them decrypted.
+	storedSettings            params.Settings
+	storedApplicationSettings params.Settings
 }
 
 // NewContextRelation creates a new context for the given relation unit.
@@ -128,11 +154,17 @@ func (ctx *ContextRelation) UnitNames() []string {
 }
 
 func (ctx *ContextRelation) ReadSettings(unit string) (settings params.Settings, err error) {
-	return ctx.cache.Settings(unit)
+	if settings, err = ctx.cache.Settings(unit); err != nil || !ctx.encrypted() {
+		return settings, err
+	}
+	return ctx.openSettings(settings)
 }
 
 func (ctx *ContextRelation) ReadApplicationSettings(app string) (settings params.Settings, err error) {
-	return ctx.cache.ApplicationSettings(app)
+	if settings, err = ctx.cache.ApplicationSettings(app); err != nil || !ctx.encrypted() {
+		return settings, err
+	}
+	return ctx.openSettings(settings)
 }
 
 func (ctx *ContextRelation) Settings() (jujuc.Settings, error) {
@@ -141,6 +173,14 @@ func (ctx *ContextRelation) Settings() (jujuc.Settings, error) {
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		if ctx.encrypted() {
+			ctx.storedSettings = node.Map()
+			settings, err := ctx.openSettings(ctx.storedSettings)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			node = node.WithSettings(settings)
+		}
 		ctx.settings = node
 	}
 	return ctx.settings, nil
@@ -148,30 +188,173 @@ func (ctx *ContextRelation) Settings() (jujuc.Settings, error) {
 
 func (ctx *ContextRelation) ApplicationSettings() (jujuc.Settings, error) {
 	if ctx.applicationSettings == nil {
-		settings, err := ctx.ru.ApplicationSettings()
+		node, err := ctx.ru.ApplicationSettings()
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		ctx.applicationSettings = settings
+		if ctx.encrypted() {
+			ctx.storedApplicationSettings = node.Map()
+			settings, err := ctx.openSettings(ctx.storedApplicationSettings)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			node = node.WithSettings(settings)
+		}
+		ctx.applicationSettings = node
 	}
 	return ctx.applicationSettings, nil
 }
 
 // WriteSettings persists all changes made to the relation settings (unit and application)
 func (ctx *ContextRelation) WriteSettings() error {
-	unitSettings, appSettings := ctx.FinalSettings()
+	unitSettings, appSettings, err := ctx.FinalSettings()
+	if err != nil {
+		return errors.Trace(err)
+	}
 	return errors.Trace(ctx.ru.UpdateRelationSettings(unitSettings, appSettings))
 }
 
 // FinalSettings returns the changes made to the relation settings (unit and application)
-func (ctx *ContextRelation) FinalSettings() (unitSettings, appSettings params.Settings) {
+func (ctx *ContextRelation) FinalSettings() (unitSettings, appSettings params.Settings, err error) {
+	if ctx.encrypted() {
+		return ctx.finalEncryptedSettings()
+	}
 	if ctx.applicationSettings != nil && ctx.applicationSettings.IsDirty() {
 		appSettings = ctx.applicationSettings.FinalResult()
 	}
 	if ctx.settings != nil {
 		unitSettings = ctx.settings.FinalResult()
 	}
-	return unitSettings, appSettings
+	return unitSettings, appSettings, nil
+}
+
+// finalEncryptedSettings returns the changes which make the stored
+// settings of an encrypted relation hold the unit's settings, and the
+// application settings if they have been read, encrypted with the
+// unit's current keys, and its settings key wrapped for each remote
+// unit which has published a public key. The unit settings are sealed
+// even if the charm hasn't touched them, so that keys are exchanged
+// with new remote units and rotated when the key generation changes.
+//
+// The application settings key is also wrapped for the other units of
+// the application which have published a public key, so that the next
+// leader can read the settings its predecessor wrote. Application
+// settings the unit can't read are left alone unless the charm has
+// changed them, as resealing them would delete them.
+func (ctx *ContextRelation) finalEncryptedSettings() (unitSettings, appSettings params.Settings, err error) {
+	if _, err := ctx.Settings(); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	keys, err := ctx.relationKeys()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	var readers []string
+	for _, member := range ctx.cache.MemberNames() {
+		settings, err := ctx.cache.Settings(member)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		readers = append(readers, settings[crossmodel.RelationPublicKeySetting])
+	}
+	unitSettings, err = crossmodel.SealRelationSettings(
+		keys[0], ctx.storedSettings, ctx.settings.FinalResult(), readers)
+	if err != nil {
+		return nil, nil, errors.Annotatef(err, "encrypting settings of relation %d", ctx.relationId)
+	}
+	if ctx.applicationSettings == nil {
+		return unitSettings, nil, nil
+	}
+	if !ctx.applicationSettings.IsDirty() &&
+		!crossmodel.CanOpenRelationSettings(keys, ctx.storedApplicationSettings) {
+		return unitSettings, nil, nil
+	}
+	peers, err := ctx.peerPublicKeys()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	appSettings, err = crossmodel.SealRelationSettings(
+		keys[0], ctx.storedApplicationSettings, ctx.applicationSettings.FinalResult(), append(readers, peers...))
+	if err != nil {
+		return nil, nil, errors.Annotatef(err, "encrypting application settings of relation %d", ctx.relationId)
+	}
+	return unitSettings, appSettings, nil
+}
+
+// peerPublicKeys returns the public keys published in the relation by
+// the other units of the unit's application.
+func (ctx *ContextRelation) peerPublicKeys() ([]string, error) {
+	if ctx.peerNames == nil {
+		return nil, nil
+	}
+	peers, err := ctx.peerNames()
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting peers of relation %d", ctx.relationId)
+	}
+	var keys []string
+	for _, peer := range peers {
+		settings, err := ctx.ru.ReadSettings(peer)
+		if params.IsCodeNotFound(err) {
+			// The peer hasn't entered the relation's scope yet.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		keys = append(keys, settings[crossmodel.RelationPublicKeySetting])
+	}
+	return keys, nil
+}
+
+// encrypted reports whether the relation's settings are encrypted by
+// the related units.
+func (ctx *ContextRelation) encrypted() bool {
+	return ctx.ru.Relation().KeyGeneration() > 0
+}
+
+// openSettings returns the stored settings of an encrypted relation
+// which the unit's keys can decrypt.
+func (ctx *ContextRelation) openSettings(stored params.Settings) (params.Settings, error) {
+	keys, err := ctx.relationKeys()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return crossmodel.OpenRelationSettings(keys, stored), nil
+}
+
+// relationKeys returns the unit's keys for an encrypted relation,
+// newest first, making new keys when the relation's key generation
+// has moved on since they were made. The previous keys are kept so
+// that the unit can read settings sealed for them until the remote
+// units catch up.
+func (ctx *ContextRelation) relationKeys() ([]*crossmodel.RelationKeys, error) {
+	if ctx.keys != nil {
+		return ctx.keys, nil
+	}
+	// Nothing watches the key generation, so pick up any rotation
+	// once per context.
+	rel := ctx.ru.Relation()
+	if err := rel.Refresh(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	keys, err := readRelationKeys(ctx.keysDir, ctx.relationId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(keys) == 0 || keys[0].Generation < rel.KeyGeneration() {
+		current, err := crossmodel.NewRelationKeys(rel.KeyGeneration())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		keys = append([]*crossmodel.RelationKeys{current}, keys...)
+		if len(keys) > 2 {
+			keys = keys[:2]
+		}
+		if err := writeRelationKeys(ctx.keysDir, ctx.relationId, keys); err != nil {
+			return nil, errors.Annotatef(err, "writing keys of relation %d", ctx.relationId)
+		}
+	}
+	ctx.keys = keys
+	return keys, nil
 }
 
 // Suspended returns true if the relation is suspended.
@@ -4,8 +4,11 @@
 package context_test
 
 import (
+	"os"
+	"path/filepath"
 	"time"
 
+	"github.com/juju/charm/v9"
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/v2"
@@ -14,6 +17,7 @@ import (
 	"github.com/juju/juju/api"
 	apiuniter "github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/relation"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/juju/testing"
@@ -223,3 +227,268 @@ type relUnitShim struct {
 func (r *relUnitShim) Relation() context.Relation {
 	return r.RelationUnit.Relation()
 }
+
+type EncryptedRelationSuite struct {
+	// stored holds the relation settings of the units and applications
+	// on both sides of the relation, as the controllers store them.
+	stored map[string]params.Settings
+
+	wordpressKeys string
+	mysqlKeys     string
+}
+
+var _ = gc.Suite(&EncryptedRelationSuite{})
+
+func (s *EncryptedRelationSuite) SetUpTest(c *gc.C) {
+	s.stored = make(map[string]params.Settings)
+	s.wordpressKeys = c.MkDir()
+	s.mysqlKeys = c.MkDir()
+}
+
+// newContext returns the relation context of a hook run by the unit,
+// which sees the given units on the other side of the relation.
+func (s *EncryptedRelationSuite) newContext(
+	keysDir, unit string, keyGeneration int, members ...string,
+) *context.ContextRelation {
+	ru := &encryptedRelationUnit{
+		relation: &encryptedRelation{keyGeneration: keyGeneration},
+		unit:     unit,
+		stored:   s.stored,
+	}
+	ctx := context.NewContextRelation(ru, context.NewRelationCache(ru.ReadSettings, members))
+	context.SetRelationKeysDir(ctx, keysDir)
+	return ctx
+}
+
+// exchangeSettings has wordpress/0 write its settings, and both units
+// run hooks until they have exchanged their keys.
+func (s *EncryptedRelationSuite) exchangeSettings(c *gc.C) {
+	ctx := s.newContext(s.wordpressKeys, "wordpress/0", 1, "mysql/0")
+	settings, err := ctx.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	settings.Set("password", "s3cret")
+	settings.Set("private-address", "10.0.0.1")
+	appSettings, err := ctx.ApplicationSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	appSettings.Set("database", "wordpress")
+	err = ctx.WriteSettings()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Until mysql/0 publishes its key, it can't read the settings.
+	ctx = s.newContext(s.mysqlKeys, "mysql/0", 1, "wordpress/0")
+	read, err := ctx.ReadSettings("wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(read, jc.DeepEquals, params.Settings{"private-address": "10.0.0.1"})
+	err = ctx.WriteSettings()
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx = s.newContext(s.wordpressKeys, "wordpress/0", 1, "mysql/0")
+	_, err = ctx.ApplicationSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.WriteSettings()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *EncryptedRelationSuite) TestSettingsExchanged(c *gc.C) {
+	s.exchangeSettings(c)
+	c.Assert(s.stored["wordpress/0"]["password"], gc.Not(gc.Equals), "")
+	c.Assert(s.stored["wordpress/0"]["password"], gc.Not(jc.Contains), "s3cret")
+	c.Assert(s.stored["wordpress/0"]["private-address"], gc.Equals, "10.0.0.1")
+	c.Assert(s.stored["wordpress"]["database"], gc.Not(jc.Contains), "wordpress")
+
+	ctx := s.newContext(s.mysqlKeys, "mysql/0", 1, "wordpress/0")
+	read, err := ctx.ReadSettings("wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(read, jc.DeepEquals, params.Settings{
+		"password":        "s3cret",
+		"private-address": "10.0.0.1",
+	})
+	read, err = ctx.ReadApplicationSettings("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(read, jc.DeepEquals, params.Settings{"database": "wordpress"})
+
+	ctx = s.newContext(s.wordpressKeys, "wordpress/0", 1, "mysql/0")
+	settings, err := ctx.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings.Map(), jc.DeepEquals, params.Settings{
+		"password":        "s3cret",
+		"private-address": "10.0.0.1",
+	})
+}
+
+func (s *EncryptedRelationSuite) TestUnchangedSettingsNotWritten(c *gc.C) {
+	s.exchangeSettings(c)
+	ctx := s.newContext(s.mysqlKeys, "mysql/0", 1, "wordpress/0")
+	err := ctx.WriteSettings()
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx = s.newContext(s.wordpressKeys, "wordpress/0", 1, "mysql/0")
+	_, err = ctx.ApplicationSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	unitSettings, appSettings, err := ctx.FinalSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitSettings, gc.HasLen, 0)
+	c.Assert(appSettings, gc.HasLen, 0)
+}
+
+func (s *EncryptedRelationSuite) TestRotateKeys(c *gc.C) {
+	s.exchangeSettings(c)
+	publicKey := s.stored["wordpress/0"][crossmodel.RelationPublicKeySetting]
+
+	// When wordpress/0 rotates its keys, it seals its settings again,
+	// for the key mysql/0 has published.
+	ctx := s.newContext(s.wordpressKeys, "wordpress/0", 2, "mysql/0")
+	err := ctx.WriteSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.stored["wordpress/0"][crossmodel.RelationPublicKeySetting], gc.Not(gc.Equals), publicKey)
+
+	// When mysql/0 rotates its keys, it can still read the settings
+	// sealed for its previous keys.
+	ctx = s.newContext(s.mysqlKeys, "mysql/0", 2, "wordpress/0")
+	read, err := ctx.ReadSettings("wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(read["password"], gc.Equals, "s3cret")
+	err = ctx.WriteSettings()
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx = s.newContext(s.wordpressKeys, "wordpress/0", 2, "mysql/0")
+	err = ctx.WriteSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	ctx = s.newContext(s.mysqlKeys, "mysql/0", 2, "wordpress/0")
+	read, err = ctx.ReadSettings("wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(read["password"], gc.Equals, "s3cret")
+}
+
+func (s *EncryptedRelationSuite) TestNewLeaderReadsApplicationSettings(c *gc.C) {
+	s.exchangeSettings(c)
+	peerKeys := c.MkDir()
+	ctx := s.newContext(peerKeys, "wordpress/1", 1, "mysql/0")
+	err := ctx.WriteSettings()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The leader wraps the application settings key for wordpress/1
+	// once it has published its key.
+	ctx = s.newContext(s.wordpressKeys, "wordpress/0", 1, "mysql/0")
+	context.SetRelationPeerNames(ctx, func() ([]string, error) {
+		return []string{"wordpress/1"}, nil
+	})
+	_, err = ctx.ApplicationSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.WriteSettings()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// When wordpress/1 becomes the leader, it can read the application
+	// settings and seal them again without losing them.
+	ctx = s.newContext(peerKeys, "wordpress/1", 1, "mysql/0")
+	appSettings, err := ctx.ApplicationSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(appSettings.Map(), jc.DeepEquals, params.Settings{"database": "wordpress"})
+	err = ctx.WriteSettings()
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx = s.newContext(s.mysqlKeys, "mysql/0", 1, "wordpress/0", "wordpress/1")
+	read, err := ctx.ReadApplicationSettings("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(read, jc.DeepEquals, params.Settings{"database": "wordpress"})
+}
+
+func (s *EncryptedRelationSuite) TestUnreadableApplicationSettingsKept(c *gc.C) {
+	s.exchangeSettings(c)
+	stored := make(params.Settings)
+	for k, v := range s.stored["wordpress"] {
+		stored[k] = v
+	}
+
+	// A new leader which the application settings key wasn't wrapped
+	// for doesn't reseal them, which would delete them.
+	ctx := s.newContext(c.MkDir(), "wordpress/1", 1, "mysql/0")
+	_, err := ctx.ApplicationSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	_, appSettings, err := ctx.FinalSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(appSettings, gc.HasLen, 0)
+	err = ctx.WriteSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.stored["wordpress"], jc.DeepEquals, stored)
+}
+
+func (s *EncryptedRelationSuite) TestKeysPrivate(c *gc.C) {
+	s.exchangeSettings(c)
+	info, err := os.Stat(filepath.Join(s.wordpressKeys, "1.yaml"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Mode().Perm(), gc.Equals, os.FileMode(0600))
+}
+
+type encryptedRelation struct {
+	context.Relation
+	keyGeneration int
+}
+
+func (r *encryptedRelation) Id() int {
+	return 1
+}
+
+func (r *encryptedRelation) Refresh() error {
+	return nil
+}
+
+func (r *encryptedRelation) KeyGeneration() int {
+	return r.keyGeneration
+}
+
+// encryptedRelationUnit is a RelationUnit of a unit in a cross-model
+// relation, whose settings are stored as the controllers store them.
+type encryptedRelationUnit struct {
+	context.RelationUnit
+	relation *encryptedRelation
+	unit     string
+	stored   map[string]params.Settings
+}
+
+func (ru *encryptedRelationUnit) Relation() context.Relation {
+	return ru.relation
+}
+
+func (ru *encryptedRelationUnit) Endpoint() apiuniter.Endpoint {
+	return apiuniter.Endpoint{Relation: charm.Relation{Name: "db"}}
+}
+
+func (ru *encryptedRelationUnit) application() string {
+	appName, _ := names.UnitApplication(ru.unit)
+	return appName
+}
+
+func (ru *encryptedRelationUnit) Settings() (*apiuniter.Settings, error) {
+	settings, _ := ru.ReadSettings(ru.unit)
+	return apiuniter.NewSettings("relation-1", ru.unit, settings), nil
+}
+
+func (ru *encryptedRelationUnit) ApplicationSettings() (*apiuniter.Settings, error) {
+	settings, _ := ru.ReadSettings(ru.application())
+	return apiuniter.NewSettings("relation-1", ru.application(), settings), nil
+}
+
+func (ru *encryptedRelationUnit) ReadSettings(name string) (params.Settings, error) {
+	settings := make(params.Settings)
+	for k, v := range ru.stored[name] {
+		settings[k] = v
+	}
+	return settings, nil
+}
+
+func (ru *encryptedRelationUnit) UpdateRelationSettings(unit, application params.Settings) error {
+	for name, changes := range map[string]params.Settings{ru.unit: unit, ru.application(): application} {
+		if ru.stored[name] == nil {
+			ru.stored[name] = make(params.Settings)
+		}
+		for k, v := range changes {
+			if v == "" {
+				delete(ru.stored[name], k)
+			} else {
+				ru.stored[name][k] = v
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package context
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/core/crossmodel"
+)
+
+// relationKeysDir is the name of the unit's component directory holding
+// its keys for the cross-model relations whose settings it encrypts.
+// The keys never leave the unit.
+const relationKeysDir = "relation-keys"
+
+func relationKeysPath(dir string, relationId int) string {
+	return filepath.Join(dir, fmt.Sprintf("%d.yaml", relationId))
+}
+
+// readRelationKeys returns the unit's keys for the relation with the
+// given id, newest first.
+func readRelationKeys(dir string, relationId int) ([]*crossmodel.RelationKeys, error) {
+	data, err := ioutil.ReadFile(relationKeysPath(dir, relationId))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var keys []*crossmodel.RelationKeys
+	if err := yaml.Unmarshal(data, &keys); err != nil {
+		return nil, errors.Annotatef(err, "reading keys of relation %d", relationId)
+	}
+	return keys, nil
+}
+
+// writeRelationKeys records the unit's keys for the relation with the
+// given id, readable only by the unit agent.
+func writeRelationKeys(dir string, relationId int, keys []*crossmodel.RelationKeys) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return errors.Trace(err)
+	}
+	data, err := yaml.Marshal(keys)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(utils.AtomicWriteFile(relationKeysPath(dir, relationId), data, 0600))
+}
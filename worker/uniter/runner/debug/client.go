@@ -13,6 +13,7 @@ import (
 type hookArgs struct {
 	Hooks   []string `yaml:"hooks,omitempty"`
 	DebugAt string   `yaml:"debug-at,omitempty"`
+	Script  string   `yaml:"script,omitempty"`
 }
 
 // ClientScript returns a bash script suitable for executing
@@ -36,6 +37,29 @@ func ClientScript(c *HooksContext, match []string, debugAt string) string {
 	return s
 }
 
+// ScriptClientScript returns a bash script suitable for executing on the
+// unit system to run the given script, instead of an interactive shell,
+// whenever a matching hook or action fires; the hook or action itself
+// runs after the script. The output of each run is streamed back to the
+// client until the session is terminated.
+func ScriptClientScript(c *HooksContext, match []string, script string) string {
+	for _, m := range match {
+		if m == "*" {
+			match = nil
+			break
+		}
+	}
+
+	s := strings.Replace(debugHooksScriptClientScript, "{unit_name}", c.Unit, -1)
+	s = strings.Replace(s, "{entry_flock}", c.ClientFileLock(), -1)
+	s = strings.Replace(s, "{exit_flock}", c.ClientExitFileLock(), -1)
+	s = strings.Replace(s, "{script_log}", c.ClientScriptLog(), -1)
+
+	yamlArgs := marshalArgs(hookArgs{Hooks: match, Script: script})
+	s = strings.Replace(s, "{hook_args}", base64.StdEncoding.EncodeToString(yamlArgs), 1)
+	return s
+}
+
 // Base64HookArgs returns the encoded arguments for defining debug-hook behavior.
 // This is a base64 encoded yaml blob containing serialized arguments.
 func Base64HookArgs(match []string, debugAt string) string {
@@ -44,8 +68,12 @@ func Base64HookArgs(match []string, debugAt string) string {
 }
 
 func encodeArgs(args []string, debugAt string) []byte {
+	return marshalArgs(hookArgs{Hooks: args, DebugAt: debugAt})
+}
+
+func marshalArgs(args hookArgs) []byte {
 	// Marshal to YAML, then encode in base64 to avoid shell escapes.
-	yamlArgs, err := goyaml.Marshal(hookArgs{Hooks: args, DebugAt: debugAt})
+	yamlArgs, err := goyaml.Marshal(args)
 	if err != nil {
 		// This should not happen: we're in full control.
 		panic(err)
@@ -115,6 +143,52 @@ fi
 exit $?
 `
 
+// debugHooksScriptClientScript holds the same locks as the interactive
+// client, but rather than attaching to the tmux session it follows the
+// log written by each run of the debug script.
+const debugHooksScriptClientScript = `#!/bin/bash
+(
+cleanup_on_exit()
+{
+	tmux kill-session -t {unit_name} 2>/dev/null
+	rm -f {script_log}
+}
+trap cleanup_on_exit EXIT
+
+# Lock the juju-<unit>-debug lockfile.
+flock -n 8 || {
+	echo "Found an existing debug session for {unit_name}" >&2
+	exit 1
+}
+(
+# Close the inherited lock FD, or tmux will keep it open.
+exec 8>&-
+
+# Write out the debug-hooks args.
+echo "{hook_args}" | base64 -d > {entry_flock}
+
+# Lock the juju-<unit>-debug-exit lockfile.
+flock -n 9 || exit 1
+
+# Wait for tmux to be installed.
+while [ ! -f /usr/bin/tmux ]; do
+    sleep 1
+done
+
+touch {script_log}
+(
+    # Close the inherited lock FD, or tmux will keep it open.
+    exec 9>&-
+    if ! tmux has-session -t {unit_name}; then
+		tmux new-session -d -s {unit_name}
+	fi
+	exec tail -n 0 -F {script_log}
+)
+) 9>{exit_flock}
+) 8>{entry_flock}
+exit $?
+`
+
 const tmuxConf = `
 # Status bar
 set-option -g status-bg black
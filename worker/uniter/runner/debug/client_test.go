@@ -67,6 +67,22 @@ func (*DebugHooksClientSuite) TestClientScript(c *gc.C) {
 		gc.Matches, expected)
 }
 
+func (*DebugHooksClientSuite) TestScriptClientScript(c *gc.C) {
+	ctx := debug.NewHooksContext("foo/8")
+
+	result := debug.ScriptClientScript(ctx, []string{"install", "*"}, "echo hi\n")
+	c.Assert(result, gc.Not(gc.Matches), "(.|\n)*{(unit_name|entry_flock|exit_flock|script_log|hook_args)}(.|\n)*")
+	c.Assert(result, gc.Not(gc.Matches), "(.|\n)*tmux attach-session(.|\n)*")
+	c.Assert(result, gc.Matches, fmt.Sprintf("(.|\n)*tail -n 0 -F %s(.|\n)*", regexp.QuoteMeta(ctx.ClientScriptLog())))
+
+	re := regexp.MustCompile(`echo "([^"]*)" \| base64 -d`)
+	match := re.FindStringSubmatch(result)
+	c.Assert(match, gc.HasLen, 2)
+	c.Check(decodeArgs(c, match[1]), gc.DeepEquals, map[string]interface{}{
+		"script": "echo hi\n",
+	})
+}
+
 func (*DebugHooksClientSuite) TestBase64HookArgsNoValues(c *gc.C) {
 	// Tests of how we encode parameters for how debug-hooks will operate
 	testEncodeRoundTrips(c, nil, "", map[string]interface{}{})
@@ -30,6 +30,12 @@ func (c *HooksContext) ClientExitFileLock() string {
 	return c.ClientFileLock() + "-exit"
 }
 
+// ClientScriptLog returns the path of the file to which the output of
+// a non-interactive debug script is written.
+func (c *HooksContext) ClientScriptLog() string {
+	return c.ClientFileLock() + "-script.log"
+}
+
 func (c *HooksContext) tmuxSessionName() string {
 	return c.Unit
 }
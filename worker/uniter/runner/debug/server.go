@@ -24,6 +24,7 @@ type ServerSession struct {
 	*HooksContext
 	hooks   set.Strings
 	debugAt string
+	script  string
 
 	output io.Writer
 }
@@ -39,6 +40,12 @@ func (s *ServerSession) DebugAt() string {
 	return s.debugAt
 }
 
+// Script returns the script to run before each matching hook, in place
+// of an interactive shell, if the client requested one.
+func (s *ServerSession) Script() string {
+	return s.script
+}
+
 // waitClientExit executes flock, waiting for the SSH client to exit.
 // This is a var so it can be replaced for testing.
 var waitClientExit = func(s *ServerSession) {
@@ -66,6 +73,10 @@ func (s *ServerSession) RunHook(hookName, charmDir string, env []string, hookRun
 	if s.debugAt != "" {
 		env = utils.Setenv(env, "JUJU_DEBUG_AT="+s.debugAt)
 	}
+	if s.script != "" {
+		env = utils.Setenv(env, "JUJU_DEBUG_SCRIPT="+filepath.Join(debugDir, "script.sh"))
+		env = utils.Setenv(env, "JUJU_DEBUG_SCRIPT_LOG="+s.ClientScriptLog())
+	}
 
 	cmd := exec.Command("/bin/bash", "-s")
 	cmd.Env = env
@@ -118,6 +129,9 @@ func (s *ServerSession) writeDebugFiles(debugDir, help, hookRunner string) error
 		{"init.sh", debugHooksInitScript, 0755},
 		{"hook.sh", debugHooksHookScript, 0755},
 	}
+	if s.script != "" {
+		files = append(files, file{"script.sh", s.script, 0755})
+	}
 	for _, file := range files {
 		if err := ioutil.WriteFile(
 			filepath.Join(debugDir, file.filename),
@@ -153,7 +167,12 @@ func (c *HooksContext) FindSession() (*ServerSession, error) {
 		return nil, err
 	}
 	hooks := set.NewStrings(args.Hooks...)
-	session := &ServerSession{HooksContext: c, hooks: hooks, debugAt: args.DebugAt}
+	session := &ServerSession{
+		HooksContext: c,
+		hooks:        hooks,
+		debugAt:      args.DebugAt,
+		script:       args.Script,
+	}
 	return session, nil
 }
 
@@ -222,10 +241,26 @@ trap 'echo $? > $JUJU_DEBUG/hook_exit_status' EXIT
 // hook_exit_status.
 // With JUJU_DEBUG_AT, we just exec the hook directly, and record its exit status before exit.
 // It is the responsibility of the code handling JUJU_DEBUG_AT to handle prompting.
+// With JUJU_DEBUG_SCRIPT, we run the client supplied script and then the hook itself, appending
+// the output of both to the log followed by the client, and record the hook's exit status. A
+// failing script is noted in the log but does not stop the hook from running.
 const debugHooksHookScript = `#!/bin/bash
 . __JUJU_DEBUG__/env.sh
 echo $$ > $JUJU_DEBUG/hook.pid
-if [ -z "$JUJU_DEBUG_AT" ] ; then
+if [ -n "$JUJU_DEBUG_SCRIPT" ] ; then
+	echo "=== $JUJU_UNIT_NAME: $JUJU_DISPATCH_PATH" >> "$JUJU_DEBUG_SCRIPT_LOG"
+	"$JUJU_DEBUG_SCRIPT" 2>&1 | tee -a "$JUJU_DEBUG_SCRIPT_LOG"
+	script_status=${PIPESTATUS[0]}
+	if [ $script_status -ne 0 ] ; then
+		echo "=== debug script exited with status $script_status" >> "$JUJU_DEBUG_SCRIPT_LOG"
+	fi
+	if [ -x "__JUJU_HOOK_RUNNER__" ] ; then
+		__JUJU_HOOK_RUNNER__ 2>&1 | tee -a "$JUJU_DEBUG_SCRIPT_LOG"
+		echo ${PIPESTATUS[0]} > $JUJU_DEBUG/hook_exit_status
+	else
+		echo 0 > $JUJU_DEBUG/hook_exit_status
+	fi
+elif [ -z "$JUJU_DEBUG_AT" ] ; then
 	exec /bin/bash --noprofile --init-file $JUJU_DEBUG/init.sh
 elif [ ! -x "__JUJU_HOOK_RUNNER__" ] ; then
 	juju-log --log-level INFO "debugging is enabled, but no handler for $JUJU_HOOK_NAME, skipping"
@@ -315,6 +315,67 @@ func (s *DebugHooksServerSuite) TestRunHookDebugAtNoHook(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *DebugHooksServerSuite) TestRunHookScript(c *gc.C) {
+	const hookName = "myhook"
+	hookRunner := s.tmpdir + "/" + hookName
+	err := ioutil.WriteFile(hookRunner, []byte(`#!/bin/bash --norc
+echo ran hook
+exit 2
+`), 0777)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The hook runs after the script, even though the script fails,
+	// and the hook's exit status is the one reported.
+	err = s.runHookScript(c, hookName, hookRunner)
+	c.Assert(err, gc.ErrorMatches, "exit status 2")
+
+	log, err := ioutil.ReadFile(s.ctx.ClientScriptLog())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(log), gc.Equals, `=== foo/8: hooks/myhook
+ran myhook
+=== debug script exited with status 3
+ran hook
+`)
+}
+
+func (s *DebugHooksServerSuite) TestRunHookScriptNoHook(c *gc.C) {
+	err := s.runHookScript(c, "no-hook", "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	log, err := ioutil.ReadFile(s.ctx.ClientScriptLog())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(log), gc.Equals, `=== foo/8: hooks/no-hook
+ran no-hook
+=== debug script exited with status 3
+`)
+}
+
+func (s *DebugHooksServerSuite) runHookScript(c *gc.C, hookName, hookRunner string) error {
+	s.fakeTmux(c)
+	err := ioutil.WriteFile(s.ctx.ClientFileLock(), []byte("script: |\n  echo ran $JUJU_HOOK_NAME\n  exit 3\n"), 0777)
+	c.Assert(err, jc.ErrorIsNil)
+	var output bytes.Buffer
+	session, err := s.ctx.FindSessionWithWriter(&output)
+	c.Assert(session, gc.NotNil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(session.Script(), gc.Equals, "echo ran $JUJU_HOOK_NAME\nexit 3\n")
+
+	flockAcquired := make(chan struct{}, 0)
+	s.PatchValue(&waitClientExit, func(*ServerSession) {
+		flockAcquired <- struct{}{}
+	})
+	env := os.Environ()
+	env = append(env, "JUJU_DISPATCH_PATH=hooks/"+hookName)
+	env = append(env, "JUJU_HOOK_NAME="+hookName)
+	err = session.RunHook(hookName, s.tmpdir, env, hookRunner)
+	select {
+	case <-flockAcquired:
+	case <-time.After(testing.ShortWait):
+		c.Fatalf("timed out waiting for hook to acquire flock")
+	}
+	return err
+}
+
 func (s *DebugHooksServerSuite) verifyEnvshFile(c *gc.C, envshPath string, hookName string) {
 	data, err := ioutil.ReadFile(envshPath)
 	c.Assert(err, jc.ErrorIsNil)
@@ -11,6 +11,7 @@ var (
 	MergeWindowsEnvironment = mergeWindowsEnvironment
 	SearchHook              = discoverHookScript
 	HookCommand             = hookCommand
+	SliceCommand            = sliceCommand
 	LookPath                = lookPath
 )
 
@@ -15,6 +15,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/relation"
@@ -122,6 +123,10 @@ type ContextUnit interface {
 	// Config returns the current application configuration of the executing unit.
 	ConfigSettings() (charm.Settings, error)
 
+	// SecretConfigValue returns the value of the application's secret
+	// charm config option with the supplied key.
+	SecretConfigValue(key string) (string, error)
+
 	// GoalState returns the goal state for the current unit.
 	GoalState() (*application.GoalState, error)
 
@@ -170,6 +175,10 @@ type ContextInstance interface {
 
 	// RequestReboot will set the reboot flag to true on the machine agent
 	RequestReboot(prio RebootPriority) error
+
+	// Devices returns the devices, such as GPUs, allocated to the
+	// executing unit's machine.
+	Devices() ([]devices.Device, error)
 }
 
 // ContextNetworking is the part of a hook context related to network
@@ -243,6 +252,10 @@ type ContextStorage interface {
 
 	// AddUnitStorage saves storage constraints in the context.
 	AddUnitStorage(map[string]params.StorageConstraints) error
+
+	// CreateStorageSnapshot takes a provider snapshot of the volume
+	// backing the attached storage instance with the supplied tag.
+	CreateStorageSnapshot(tag names.StorageTag, description string) (params.StorageSnapshot, error)
 }
 
 // ContextComponents exposes modular Juju components as they relate to
@@ -262,6 +275,11 @@ type ContextRelations interface {
 	// RelationIds returns the ids of all relations the executing unit is
 	// currently participating in or an error if they are not available.
 	RelationIds() ([]int, error)
+
+	// SetRelationSchema registers the JSON schema that unit settings, or
+	// application settings if application is true, written by related
+	// applications over the named endpoint must satisfy.
+	SetRelationSchema(endpoint string, application bool, schema string) error
 }
 
 // ContextComponent is a single modular Juju component as it relates to
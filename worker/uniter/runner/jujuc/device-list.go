@@ -0,0 +1,74 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/core/devices"
+)
+
+// DeviceListCommand implements the device-list command.
+type DeviceListCommand struct {
+	cmd.CommandBase
+	ctx        Context
+	out        cmd.Output
+	deviceType string
+}
+
+// NewDeviceListCommand returns a new DeviceListCommand with the given context.
+func NewDeviceListCommand(ctx Context) (cmd.Command, error) {
+	return &DeviceListCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *DeviceListCommand) Info() *cmd.Info {
+	doc := `
+device-list lists the devices allocated to the unit's machine, such as
+the GPUs requested with the gpus and gpu-type constraints. Inside a
+container only the devices passed through to the container are listed.
+
+Each device is reported with its name, type and device node path, and
+where known its vendor (as a gpu-type constraint value), PCI vendor ID
+and PCI address.
+
+A device type may be specified, in which case only devices of that type
+are listed. The only type currently reported is "gpu".
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "device-list",
+		Args:    "[<device-type>]",
+		Purpose: "list devices allocated to the unit's machine",
+		Doc:     doc,
+	})
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *DeviceListCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters.Formatters())
+}
+
+// Init is part of the cmd.Command interface.
+func (c *DeviceListCommand) Init(args []string) (err error) {
+	c.deviceType, err = cmd.ZeroOrOneArgs(args)
+	return err
+}
+
+// Run is part of the cmd.Command interface.
+func (c *DeviceListCommand) Run(ctx *cmd.Context) error {
+	all, err := c.ctx.Devices()
+	if err != nil {
+		return errors.Annotate(err, "cannot list devices")
+	}
+	result := make([]devices.Device, 0, len(all))
+	for _, device := range all {
+		if c.deviceType == "" || string(device.Type) == c.deviceType {
+			result = append(result, device)
+		}
+	}
+	return c.out.Write(ctx, result)
+}
@@ -0,0 +1,91 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"encoding/json"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/core/devices"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/juju/worker/uniter/runner/jujuc/jujuctesting"
+)
+
+type deviceListSuite struct {
+	jujuctesting.ContextSuite
+}
+
+var _ = gc.Suite(&deviceListSuite{})
+
+var testGPUs = []devices.Device{{
+	Name:       "card0",
+	Type:       devices.GPU,
+	Path:       "/dev/dri/card0",
+	Vendor:     "nvidia",
+	VendorID:   "10de",
+	PCIAddress: "0000:01:00.0",
+}, {
+	Name:     "card1",
+	Type:     devices.GPU,
+	Path:     "/dev/dri/card1",
+	VendorID: "1234",
+}}
+
+func (s *deviceListSuite) run(c *gc.C, args ...string) (*cmd.Context, int) {
+	hctx, info := s.NewHookContext()
+	info.Instance.Devices = testGPUs
+	com, err := jujuc.NewCommand(hctx, cmdString("device-list"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, args)
+	return ctx, code
+}
+
+func (s *deviceListSuite) TestOutputFormatYAML(c *gc.C) {
+	ctx, code := s.run(c)
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+	var out []devices.Device
+	c.Assert(goyaml.Unmarshal(bufferBytes(ctx.Stdout), &out), jc.ErrorIsNil)
+	c.Assert(out, jc.DeepEquals, testGPUs)
+}
+
+func (s *deviceListSuite) TestOutputFormatJSON(c *gc.C) {
+	ctx, code := s.run(c, "--format", "json")
+	c.Assert(code, gc.Equals, 0)
+	var out []devices.Device
+	c.Assert(json.Unmarshal(bufferBytes(ctx.Stdout), &out), jc.ErrorIsNil)
+	c.Assert(out, jc.DeepEquals, testGPUs)
+}
+
+func (s *deviceListSuite) TestOutputFiltered(c *gc.C) {
+	ctx, code := s.run(c, "--format", "json", "gpu")
+	c.Assert(code, gc.Equals, 0)
+	var out []devices.Device
+	c.Assert(json.Unmarshal(bufferBytes(ctx.Stdout), &out), jc.ErrorIsNil)
+	c.Assert(out, jc.DeepEquals, testGPUs)
+
+	ctx, code = s.run(c, "--format", "json", "tpu")
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stdout), gc.Equals, "[]\n")
+}
+
+func (s *deviceListSuite) TestError(c *gc.C) {
+	s.Stub.SetErrors(errors.New("boom"))
+	ctx, code := s.run(c)
+	c.Assert(code, gc.Equals, 1)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot list devices: boom\n")
+}
+
+func (s *deviceListSuite) TestTooManyArgs(c *gc.C) {
+	ctx, code := s.run(c, "gpu", "tpu")
+	c.Assert(code, gc.Equals, 2)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "ERROR unrecognized args: [\"tpu\"]\n")
+}
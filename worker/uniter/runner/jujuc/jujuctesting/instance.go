@@ -6,6 +6,7 @@ package jujuctesting
 import (
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
 
@@ -13,6 +14,7 @@ import (
 type Instance struct {
 	AvailabilityZone string
 	RebootPriority   *jujuc.RebootPriority
+	Devices          []devices.Device
 }
 
 // ContextInstance is a test double for jujuc.ContextInstance.
@@ -38,3 +40,10 @@ func (c *ContextInstance) RequestReboot(priority jujuc.RebootPriority) error {
 	c.info.RebootPriority = &priority
 	return nil
 }
+
+// Devices implements jujuc.ContextInstance.
+func (c *ContextInstance) Devices() ([]devices.Device, error) {
+	c.stub.AddCall("Devices")
+
+	return c.info.Devices, c.stub.NextErr()
+}
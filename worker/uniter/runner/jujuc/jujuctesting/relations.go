@@ -16,6 +16,7 @@ import (
 // Relations holds the values for the hook context.
 type Relations struct {
 	Relations map[int]jujuc.ContextRelation
+	Schemas   map[string]string
 }
 
 // Reset clears the Relations data.
@@ -86,3 +87,20 @@ func (c *ContextRelations) RelationIds() ([]int, error) {
 	}
 	return ids, c.stub.NextErr()
 }
+
+// SetRelationSchema implements jujuc.ContextRelations.
+func (c *ContextRelations) SetRelationSchema(endpoint string, application bool, schema string) error {
+	c.stub.AddCall("SetRelationSchema", endpoint, application, schema)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+	if c.info.Schemas == nil {
+		c.info.Schemas = make(map[string]string)
+	}
+	key := endpoint
+	if application {
+		key += "/app"
+	}
+	c.info.Schemas[key] = schema
+	return nil
+}
@@ -122,3 +122,13 @@ func (c *ContextStorage) AddUnitStorage(all map[string]params.StorageConstraints
 	c.info.AddUnitStorage(all)
 	return c.stub.NextErr()
 }
+
+// CreateStorageSnapshot implements jujuc.ContextStorage.
+func (c *ContextStorage) CreateStorageSnapshot(tag names.StorageTag, description string) (params.StorageSnapshot, error) {
+	c.stub.AddCall("CreateStorageSnapshot", tag, description)
+	return params.StorageSnapshot{
+		StorageTag:  tag.String(),
+		SnapshotId:  "snap-" + tag.Id(),
+		Description: description,
+	}, c.stub.NextErr()
+}
@@ -15,6 +15,7 @@ import (
 type Unit struct {
 	Name           string
 	ConfigSettings charm.Settings
+	SecretConfig   map[string]string
 	GoalState      application.GoalState
 	K8sSpec        string
 	RawK8sSpec     string
@@ -45,6 +46,19 @@ func (c *ContextUnit) ConfigSettings() (charm.Settings, error) {
 	return c.info.ConfigSettings, nil
 }
 
+// SecretConfigValue implements jujuc.ContextUnit.
+func (c *ContextUnit) SecretConfigValue(key string) (string, error) {
+	c.stub.AddCall("SecretConfigValue", key)
+	if err := c.stub.NextErr(); err != nil {
+		return "", errors.Trace(err)
+	}
+	value, ok := c.info.SecretConfig[key]
+	if !ok {
+		return "", errors.NotValidf("secret config option %q", key)
+	}
+	return value, nil
+}
+
 // GoalState implements jujuc.ContextUnit.
 func (c *ContextUnit) GoalState() (*application.GoalState, error) {
 	c.stub.AddCall("GoalState")
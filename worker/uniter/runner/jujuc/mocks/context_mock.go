@@ -12,6 +12,7 @@ import (
 	charm "github.com/juju/charm/v9"
 	params "github.com/juju/juju/apiserver/params"
 	application "github.com/juju/juju/core/application"
+	devices "github.com/juju/juju/core/devices"
 	network "github.com/juju/juju/core/network"
 	jujuc "github.com/juju/juju/worker/uniter/runner/jujuc"
 	names "github.com/juju/names/v4"
@@ -97,6 +98,21 @@ func (mr *MockContextMockRecorder) AddUnitStorage(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUnitStorage", reflect.TypeOf((*MockContext)(nil).AddUnitStorage), arg0)
 }
 
+// CreateStorageSnapshot mocks base method
+func (m *MockContext) CreateStorageSnapshot(arg0 names.StorageTag, arg1 string) (params.StorageSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStorageSnapshot", arg0, arg1)
+	ret0, _ := ret[0].(params.StorageSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStorageSnapshot indicates an expected call of CreateStorageSnapshot
+func (mr *MockContextMockRecorder) CreateStorageSnapshot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStorageSnapshot", reflect.TypeOf((*MockContext)(nil).CreateStorageSnapshot), arg0, arg1)
+}
+
 // ApplicationStatus mocks base method
 func (m *MockContext) ApplicationStatus() (jujuc.ApplicationStatusInfo, error) {
 	m.ctrl.T.Helper()
@@ -200,6 +216,21 @@ func (mr *MockContextMockRecorder) DeleteCharmStateValue(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCharmStateValue", reflect.TypeOf((*MockContext)(nil).DeleteCharmStateValue), arg0)
 }
 
+// Devices mocks base method
+func (m *MockContext) Devices() ([]devices.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Devices")
+	ret0, _ := ret[0].([]devices.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Devices indicates an expected call of Devices
+func (mr *MockContextMockRecorder) Devices() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Devices", reflect.TypeOf((*MockContext)(nil).Devices))
+}
+
 // GetCharmState mocks base method
 func (m *MockContext) GetCharmState() (map[string]string, error) {
 	m.ctrl.T.Helper()
@@ -496,6 +527,21 @@ func (mr *MockContextMockRecorder) RequestReboot(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestReboot", reflect.TypeOf((*MockContext)(nil).RequestReboot), arg0)
 }
 
+// SecretConfigValue mocks base method
+func (m *MockContext) SecretConfigValue(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecretConfigValue", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SecretConfigValue indicates an expected call of SecretConfigValue
+func (mr *MockContextMockRecorder) SecretConfigValue(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretConfigValue", reflect.TypeOf((*MockContext)(nil).SecretConfigValue), arg0)
+}
+
 // SetActionFailed mocks base method
 func (m *MockContext) SetActionFailed() error {
 	m.ctrl.T.Helper()
@@ -580,6 +626,20 @@ func (mr *MockContextMockRecorder) SetRawK8sSpec(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRawK8sSpec", reflect.TypeOf((*MockContext)(nil).SetRawK8sSpec), arg0)
 }
 
+// SetRelationSchema mocks base method
+func (m *MockContext) SetRelationSchema(arg0 string, arg1 bool, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRelationSchema", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRelationSchema indicates an expected call of SetRelationSchema
+func (mr *MockContextMockRecorder) SetRelationSchema(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRelationSchema", reflect.TypeOf((*MockContext)(nil).SetRelationSchema), arg0, arg1, arg2)
+}
+
 // SetUnitStatus mocks base method
 func (m *MockContext) SetUnitStatus(arg0 jujuc.StatusInfo) error {
 	m.ctrl.T.Helper()
@@ -51,7 +51,8 @@ func (c *NetworkGetCommand) Info() *cmd.Info {
 network-get returns the network config for a given binding name. By default
 it returns the list of interfaces and associated addresses in the space for
 the binding, as well as the ingress address for the binding. If defined, any
-egress subnets are also returned.
+egress subnets are also returned, as are any shadow addresses, such as public
+or floating IPs that the cloud routes to the unit's machine.
 If one of the following flags are specified, just that value is returned.
 If more than one flag is specified, a map of values is returned.
     --bind-address: the address the local unit should listen on to serve connections, as well
@@ -16,7 +16,7 @@ import (
 )
 
 const (
-	portFormat = "<port>[/<protocol>] or <from>-<to>[/<protocol>] or icmp"
+	portFormat = "<port>[/<protocol>] or <from>-<to>[/<protocol>] or icmp or <type>/icmp"
 )
 
 // portCommand implements the open-port and close-port commands.
@@ -67,6 +67,11 @@ var openPortInfo = &cmd.Info{
 	Doc: `
 open-port registers a request to open the specified port or port range.
 
+The protocol may be "tcp" (the default), "udp" or "icmp". ICMP does not use
+ports: "icmp" opens all ICMP traffic, while "<type>/icmp" only opens the given
+ICMP message type (0-255), such as "8/icmp" for echo requests. Not every cloud
+can restrict ICMP by type.
+
 By default, the specified port or port range will be opened for all defined
 application endpoints. The --endpoints option can be used to constrain the 
 open request to a comma-delimited list of application endpoints.
@@ -87,6 +92,8 @@ var closePortInfo = &cmd.Info{
 	Doc: `
 close-port registers a request to open the specified port or port range.
 
+The protocol may be "tcp" (the default), "udp" or "icmp", as for open-port.
+
 By default, the specified port or port range will be closed for all defined
 application endpoints. The --endpoints option can be used to constrain the 
 close request to a comma-delimited list of application endpoints.
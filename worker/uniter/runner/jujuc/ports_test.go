@@ -35,6 +35,8 @@ var portsTests = []struct {
 	{[]string{"open-port", "123/udp"}, makeAllEndpointsRanges("99/tcp", "123/udp")},
 	{[]string{"close-port", "9999/UDP"}, makeAllEndpointsRanges("99/tcp", "123/udp")},
 	{[]string{"open-port", "icmp"}, makeAllEndpointsRanges("icmp", "99/tcp", "123/udp")},
+	{[]string{"open-port", "8/ICMP"}, makeAllEndpointsRanges("icmp", "8/icmp", "99/tcp", "123/udp")},
+	{[]string{"close-port", "8/icmp"}, makeAllEndpointsRanges("icmp", "99/tcp", "123/udp")},
 	// Tests with --endpoints.
 	{[]string{"open-port", "--endpoints", "foo,bar", "1337/tcp"}, network.GroupedPortRanges{
 		// Pre-existing ports from previous tests
@@ -91,7 +93,7 @@ func (s *PortsSuite) TestHelp(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	flags := cmdtesting.NewFlagSet()
 	c.Assert(string(open.Info().Help(flags)), gc.Equals, `
-Usage: open-port <port>[/<protocol>] or <from>-<to>[/<protocol>] or icmp
+Usage: open-port <port>[/<protocol>] or <from>-<to>[/<protocol>] or icmp or <type>/icmp
 
 Summary:
 register a request to open a port or port range
@@ -99,6 +101,11 @@ register a request to open a port or port range
 Details:
 open-port registers a request to open the specified port or port range.
 
+The protocol may be "tcp" (the default), "udp" or "icmp". ICMP does not use
+ports: "icmp" opens all ICMP traffic, while "<type>/icmp" only opens the given
+ICMP message type (0-255), such as "8/icmp" for echo requests. Not every cloud
+can restrict ICMP by type.
+
 By default, the specified port or port range will be opened for all defined
 application endpoints. The --endpoints option can be used to constrain the 
 open request to a comma-delimited list of application endpoints.
@@ -107,7 +114,7 @@ open request to a comma-delimited list of application endpoints.
 	close, err := jujuc.NewCommand(hctx, cmdString("close-port"))
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(string(close.Info().Help(flags)), gc.Equals, `
-Usage: close-port <port>[/<protocol>] or <from>-<to>[/<protocol>] or icmp
+Usage: close-port <port>[/<protocol>] or <from>-<to>[/<protocol>] or icmp or <type>/icmp
 
 Summary:
 register a request to close a port or port range
@@ -115,12 +122,37 @@ register a request to close a port or port range
 Details:
 close-port registers a request to open the specified port or port range.
 
+The protocol may be "tcp" (the default), "udp" or "icmp", as for open-port.
+
 By default, the specified port or port range will be closed for all defined
 application endpoints. The --endpoints option can be used to constrain the 
 close request to a comma-delimited list of application endpoints.
 `[1:])
 }
 
+var badPortsTests = []struct {
+	args []string
+	err  string
+}{
+	{nil, "no port or range specified"},
+	{[]string{"80/sctp"}, `invalid protocol "sctp", expected "tcp", "udp", or "icmp"`},
+	{[]string{"0-8/icmp"}, `protocol "icmp" doesn't support port ranges; got "0-8"`},
+	{[]string{"300/icmp"}, "icmp type must be between 0 and 255, got 300"},
+}
+
+func (s *PortsSuite) TestBadArgs(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	for _, name := range []string{"open-port", "close-port"} {
+		for i, t := range badPortsTests {
+			c.Logf("test %d: %s %v", i, name, t.args)
+			com, err := jujuc.NewCommand(hctx, cmdString(name))
+			c.Assert(err, jc.ErrorIsNil)
+			err = cmdtesting.InitCommand(jujuc.NewJujucCommandWrappedForTest(com), t.args)
+			c.Check(err, gc.ErrorMatches, t.err)
+		}
+	}
+}
+
 // Since the deprecation warning gets output during Run, we really need
 // some valid commands to run
 var portsFormatDeprecationTests = []struct {
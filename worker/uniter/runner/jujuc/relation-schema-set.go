@@ -0,0 +1,76 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+)
+
+// RelationSchemaSetCommand implements the relation-schema-set command.
+type RelationSchemaSetCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	endpoint    string
+	application bool
+	schemaFile  cmd.FileVar
+}
+
+// NewRelationSchemaSetCommand makes a relation-schema-set command.
+func NewRelationSchemaSetCommand(ctx Context) (cmd.Command, error) {
+	return &RelationSchemaSetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *RelationSchemaSetCommand) Info() *cmd.Info {
+	doc := `
+relation-schema-set registers a JSON schema that the relation data
+written by related applications over the named endpoint must satisfy.
+Writes made with relation-set by the other side of the relation that
+do not satisfy the schema are rejected.
+
+The schema applies to the settings of each related unit, or with --app
+to the related application's settings. The schema is read from the
+file given with --file, or from stdin. An empty schema removes any
+schema previously registered for the endpoint.
+
+Only the leader may register schemas.
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "relation-schema-set",
+		Args:    "[--app] [--file <schema file>] <endpoint>",
+		Purpose: "register a schema for relation data",
+		Doc:     doc,
+	})
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *RelationSchemaSetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.schemaFile.SetStdin()
+	c.schemaFile.Path = "-"
+	f.Var(&c.schemaFile, "file", "file containing the JSON schema")
+	f.BoolVar(&c.application, "app", false, "register the schema for application settings")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *RelationSchemaSetCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("no endpoint specified")
+	}
+	c.endpoint = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *RelationSchemaSetCommand) Run(ctx *cmd.Context) error {
+	schema, err := c.schemaFile.Read(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.ctx.SetRelationSchema(c.endpoint, c.application, string(schema))
+}
@@ -0,0 +1,86 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+const testRelationSchema = `{"type": "object", "required": ["port"]}`
+
+type RelationSchemaSetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&RelationSchemaSetSuite{})
+
+func (s *RelationSchemaSetSuite) createCommand(c *gc.C, err error) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("relation-schema-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, jujuc.NewJujucCommandWrappedForTest(com)
+}
+
+func (s *RelationSchemaSetSuite) TestInit(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{
+		{nil, "no endpoint specified"},
+		{[]string{"db", "extra"}, `unrecognized args: \["extra"\]`},
+	} {
+		c.Logf("test %d: %#v", i, t.args)
+		_, com := s.createCommand(c, nil)
+		cmdtesting.TestInit(c, com, t.args, t.err)
+	}
+}
+
+func (s *RelationSchemaSetSuite) TestSetFromFile(c *gc.C) {
+	hctx, com := s.createCommand(c, nil)
+	dir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(dir, "schema.json"), []byte(testRelationSchema), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := cmdtesting.Context(c)
+	ctx.Dir = dir
+	code := cmd.Main(com, ctx, []string{"--app", "--file", "schema.json", "db"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.Relations.Schemas, jc.DeepEquals, map[string]string{
+		"db/app": testRelationSchema,
+	})
+}
+
+func (s *RelationSchemaSetSuite) TestSetFromStdin(c *gc.C) {
+	hctx, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = bytes.NewBufferString(testRelationSchema)
+	code := cmd.Main(com, ctx, []string{"db"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.Relations.Schemas, jc.DeepEquals, map[string]string{
+		"db": testRelationSchema,
+	})
+}
+
+func (s *RelationSchemaSetSuite) TestSetError(c *gc.C) {
+	_, com := s.createCommand(c, errors.New("permission denied"))
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = bytes.NewBufferString(testRelationSchema)
+	code := cmd.Main(com, ctx, []string{"db"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR permission denied\n")
+}
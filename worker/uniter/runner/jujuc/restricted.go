@@ -12,6 +12,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/core/network"
 )
 
@@ -26,6 +27,9 @@ type RestrictedContext struct{}
 // ConfigSettings implements hooks.Context.
 func (*RestrictedContext) ConfigSettings() (charm.Settings, error) { return nil, ErrRestrictedContext }
 
+// SecretConfigValue implements hooks.Context.
+func (*RestrictedContext) SecretConfigValue(string) (string, error) { return "", ErrRestrictedContext }
+
 // GoalState implements hooks.Context.
 func (*RestrictedContext) GoalState() (*application.GoalState, error) {
 	return &application.GoalState{}, ErrRestrictedContext
@@ -97,6 +101,11 @@ func (*RestrictedContext) SetApplicationStatus(StatusInfo) error {
 // AvailabilityZone implements hooks.Context.
 func (*RestrictedContext) AvailabilityZone() (string, error) { return "", ErrRestrictedContext }
 
+// Devices implements hooks.Context.
+func (*RestrictedContext) Devices() ([]devices.Device, error) {
+	return nil, ErrRestrictedContext
+}
+
 // RequestReboot implements hooks.Context.
 func (*RestrictedContext) RequestReboot(prio RebootPriority) error {
 	return ErrRestrictedContext
@@ -163,6 +172,11 @@ func (*RestrictedContext) AddUnitStorage(map[string]params.StorageConstraints) e
 	return ErrRestrictedContext
 }
 
+// CreateStorageSnapshot implements hooks.Context.
+func (*RestrictedContext) CreateStorageSnapshot(names.StorageTag, string) (params.StorageSnapshot, error) {
+	return params.StorageSnapshot{}, ErrRestrictedContext
+}
+
 // Relation implements hooks.Context.
 func (*RestrictedContext) Relation(id int) (ContextRelation, error) {
 	return nil, ErrRestrictedContext
@@ -171,6 +185,11 @@ func (*RestrictedContext) Relation(id int) (ContextRelation, error) {
 // RelationIds implements hooks.Context.
 func (*RestrictedContext) RelationIds() ([]int, error) { return nil, ErrRestrictedContext }
 
+// SetRelationSchema implements hooks.Context.
+func (*RestrictedContext) SetRelationSchema(string, bool, string) error {
+	return ErrRestrictedContext
+}
+
 // HookRelation implements hooks.Context.
 func (*RestrictedContext) HookRelation() (ContextRelation, error) {
 	return nil, ErrRestrictedContext
@@ -0,0 +1,62 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+)
+
+// SecretConfigGetCommand implements the secret-config-get command.
+type SecretConfigGetCommand struct {
+	cmd.CommandBase
+	ctx Context
+	Key string
+	out cmd.Output
+}
+
+// NewSecretConfigGetCommand makes a jujuc secret-config-get command.
+func NewSecretConfigGetCommand(ctx Context) (cmd.Command, error) {
+	return &SecretConfigGetCommand{ctx: ctx}, nil
+}
+
+func (c *SecretConfigGetCommand) Info() *cmd.Info {
+	doc := `
+secret-config-get prints the value of a charm config option which the
+operator has declared secret with the application's secret-options config.
+If no value has been set, the option's default is printed.
+
+The values of secret options are not included in the output of config-get.
+Every read is recorded by the controller.
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "secret-config-get",
+		Args:    "<key>",
+		Purpose: "print the value of a secret config option",
+		Doc:     doc,
+	})
+}
+
+func (c *SecretConfigGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters.Formatters())
+}
+
+func (c *SecretConfigGetCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("no key specified")
+	}
+	c.Key = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *SecretConfigGetCommand) Run(ctx *cmd.Context) error {
+	value, err := c.ctx.SecretConfigValue(c.Key)
+	if err != nil {
+		return errors.Annotatef(err, "cannot get secret config option %q", c.Key)
+	}
+	return c.out.Write(ctx, value)
+}
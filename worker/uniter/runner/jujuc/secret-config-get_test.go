@@ -0,0 +1,63 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretConfigGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretConfigGetSuite{})
+
+func (s *SecretConfigGetSuite) run(c *gc.C, args ...string) (*cmd.Context, int) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.SecretConfig = map[string]string{"password": "s3cret"}
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-config-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, args)
+	return ctx, code
+}
+
+func (s *SecretConfigGetSuite) TestGet(c *gc.C) {
+	ctx, code := s.run(c, "password")
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Assert(bufferString(ctx.Stdout), gc.Equals, "s3cret\n")
+	s.Stub.CheckCall(c, 0, "SecretConfigValue", "password")
+}
+
+func (s *SecretConfigGetSuite) TestGetNotSecret(c *gc.C) {
+	ctx, code := s.run(c, "title")
+	c.Assert(code, gc.Equals, 1)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, `ERROR cannot get secret config option "title": secret config option "title" not valid`+"\n")
+}
+
+func (s *SecretConfigGetSuite) TestGetError(c *gc.C) {
+	s.Stub.SetErrors(errors.New("boom"))
+	ctx, code := s.run(c, "password")
+	c.Assert(code, gc.Equals, 1)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, `ERROR cannot get secret config option "password": boom`+"\n")
+}
+
+func (s *SecretConfigGetSuite) TestNoKey(c *gc.C) {
+	ctx, code := s.run(c)
+	c.Assert(code, gc.Equals, 2)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "ERROR no key specified\n")
+}
+
+func (s *SecretConfigGetSuite) TestTooManyArgs(c *gc.C) {
+	ctx, code := s.run(c, "password", "title")
+	c.Assert(code, gc.Equals, 2)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "ERROR unrecognized args: [\"title\"]\n")
+}
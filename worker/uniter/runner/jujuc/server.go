@@ -56,6 +56,8 @@ var baseCommands = map[string]creator{
 	"relation-ids" + cmdSuffix:            NewRelationIdsCommand,
 	"relation-list" + cmdSuffix:           NewRelationListCommand,
 	"relation-set" + cmdSuffix:            NewRelationSetCommand,
+	"relation-schema-set" + cmdSuffix:     NewRelationSchemaSetCommand,
+	"secret-config-get" + cmdSuffix:       NewSecretConfigGetCommand,
 	"unit-get" + cmdSuffix:                NewUnitGetCommand,
 	"add-metric" + cmdSuffix:              NewAddMetricCommand,
 	"juju-reboot" + cmdSuffix:             NewJujuRebootCommand,
@@ -73,6 +75,7 @@ var baseCommands = map[string]creator{
 
 	"goal-state" + cmdSuffix:     NewGoalStateCommand,
 	"credential-get" + cmdSuffix: NewCredentialGetCommand,
+	"device-list" + cmdSuffix:    NewDeviceListCommand,
 
 	"action-get" + cmdSuffix:  NewActionGetCommand,
 	"action-set" + cmdSuffix:  NewActionSetCommand,
@@ -93,9 +96,10 @@ func constructCommandCreator(name string, newCmd functionCmdCreator) creator {
 }
 
 var storageCommands = map[string]creator{
-	"storage-add" + cmdSuffix:  NewStorageAddCommand,
-	"storage-get" + cmdSuffix:  NewStorageGetCommand,
-	"storage-list" + cmdSuffix: NewStorageListCommand,
+	"storage-add" + cmdSuffix:      NewStorageAddCommand,
+	"storage-get" + cmdSuffix:      NewStorageGetCommand,
+	"storage-list" + cmdSuffix:     NewStorageListCommand,
+	"storage-snapshot" + cmdSuffix: NewStorageSnapshotCommand,
 }
 
 var leaderCommands = map[string]creator{
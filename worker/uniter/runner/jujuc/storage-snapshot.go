@@ -0,0 +1,74 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	jujucmd "github.com/juju/juju/cmd"
+)
+
+// StorageSnapshotCommand implements the storage-snapshot command.
+type StorageSnapshotCommand struct {
+	cmd.CommandBase
+	ctx             Context
+	storageTag      names.StorageTag
+	storageTagProxy gnuflag.Value
+	description     string
+	out             cmd.Output
+}
+
+// NewStorageSnapshotCommand makes a jujuc storage-snapshot command.
+func NewStorageSnapshotCommand(ctx Context) (cmd.Command, error) {
+	c := &StorageSnapshotCommand{ctx: ctx}
+	sV, err := newStorageIdValue(ctx, &c.storageTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	c.storageTagProxy = sV
+	return c, nil
+}
+
+func (c *StorageSnapshotCommand) Info() *cmd.Info {
+	doc := `
+storage-snapshot takes a snapshot of the volume backing a storage instance
+attached to the unit, and prints the ID of the snapshot.
+
+The snapshot is started before the command returns, so a charm can take
+an application-consistent snapshot by flushing and pausing writes to the
+storage, running storage-snapshot, and then resuming its workload.
+
+Only storage backed by a volume whose storage provider supports snapshots,
+such as EBS, can be snapshotted.
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "storage-snapshot",
+		Purpose: "take a snapshot of a storage instance",
+		Doc:     doc,
+	})
+}
+
+func (c *StorageSnapshotCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters.Formatters())
+	f.Var(c.storageTagProxy, "s", "specify a storage instance by id")
+	f.StringVar(&c.description, "description", "", "a description of the snapshot")
+}
+
+func (c *StorageSnapshotCommand) Init(args []string) error {
+	if c.storageTag == (names.StorageTag{}) {
+		return errors.New("no storage instance specified")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *StorageSnapshotCommand) Run(ctx *cmd.Context) error {
+	snapshot, err := c.ctx.CreateStorageSnapshot(c.storageTag, c.description)
+	if err != nil {
+		return errors.Annotatef(err, "cannot snapshot storage %s", c.storageTag.Id())
+	}
+	return c.out.Write(ctx, snapshot.SnapshotId)
+}
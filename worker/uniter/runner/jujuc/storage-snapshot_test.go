@@ -0,0 +1,52 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type storageSnapshotSuite struct {
+	storageSuite
+}
+
+var _ = gc.Suite(&storageSnapshotSuite{})
+
+func (s *storageSnapshotSuite) run(c *gc.C, args ...string) (*cmd.Context, int) {
+	hctx, _ := s.newHookContext()
+	com, err := jujuc.NewCommand(hctx, cmdString("storage-snapshot"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, args)
+	return ctx, code
+}
+
+func (s *storageSnapshotSuite) TestSnapshotHookStorage(c *gc.C) {
+	ctx, code := s.run(c, "--description", "nightly")
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Assert(bufferString(ctx.Stdout), gc.Equals, "snap-data/0\n")
+	s.Stub.CheckCallNames(c, "HookStorage", "Storage", "CreateStorageSnapshot")
+	s.Stub.CheckCall(c, 2, "CreateStorageSnapshot", names.NewStorageTag("data/0"), "nightly")
+}
+
+func (s *storageSnapshotSuite) TestSnapshotUnknownStorage(c *gc.C) {
+	ctx, code := s.run(c, "-s", "data/1")
+	c.Assert(code, gc.Equals, 2)
+	c.Assert(bufferString(ctx.Stderr), gc.Matches, `ERROR invalid value "data/1" for .*: storage not found\n`)
+}
+
+func (s *storageSnapshotSuite) TestSnapshotError(c *gc.C) {
+	s.Stub.SetErrors(errors.New("snapshots not supported"))
+	ctx, code := s.run(c)
+	c.Assert(code, gc.Equals, 1)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot snapshot storage data/0: snapshots not supported\n")
+}
@@ -184,6 +184,21 @@ func (runner *runner) Context() Context {
 	return runner.context
 }
 
+// resourceSlicer is implemented by contexts whose charm processes are
+// run in a systemd slice that limits their resources.
+type resourceSlicer interface {
+	ResourceSlice() string
+}
+
+// resourceSlice returns the systemd slice that charm processes are run
+// in, or "" if their resources aren't limited.
+func (runner *runner) resourceSlice() string {
+	if slicer, ok := runner.context.(resourceSlicer); ok {
+		return slicer.ResourceSlice()
+	}
+	return ""
+}
+
 func (runner *runner) getExecutor(rMode runMode) (ExecFunc, error) {
 	switch rMode {
 	case runOnLocal:
@@ -591,7 +606,7 @@ func (runner *runner) runCharmProcessOnRemote(hook, hookName, charmDir string, e
 
 // Check still tested
 func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, env []string) error {
-	hookCmd := hookCommand(hook)
+	hookCmd := sliceCommand(runner.resourceSlice(), hookCommand(hook))
 	ps := exec.Command(hookCmd[0], hookCmd[1:]...)
 	ps.Env = env
 	ps.Dir = charmDir
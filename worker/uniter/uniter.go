@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	corecharm "github.com/juju/charm/v9"
+	"github.com/juju/charm/v9/hooks"
 	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
@@ -28,6 +30,7 @@ import (
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 	jworker "github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/common/resourcelimits"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/uniter/actions"
 	"github.com/juju/juju/worker/uniter/charm"
@@ -68,6 +71,14 @@ type RebootQuerier interface {
 	Query(tag names.Tag) (bool, error)
 }
 
+// ResourceLimiter is implemented by types that limit the resources of a
+// unit's workload processes, and report when they have been throttled.
+type ResourceLimiter interface {
+	Apply(limits resourcelimits.Limits) error
+	Slice() string
+	Throttled() ([]string, error)
+}
+
 // RemoteInitFunc is used to init remote state
 type RemoteInitFunc func(remotestate.ContainerRunningStatus, <-chan struct{}) error
 
@@ -153,6 +164,16 @@ type Uniter struct {
 	// rebooted so we can notify the charms accordingly.
 	rebootQuerier RebootQuerier
 	logger        Logger
+
+	// proxyConfig supplies the unit's proxy settings for the hook
+	// environment; if nil, the model's settings are used.
+	proxyConfig context.ProxyConfigGetter
+
+	// resourceLimiter, if set, applies the unit's resource limits to
+	// its workload processes. resourceLimitsRefreshed records when the
+	// limits were last read.
+	resourceLimiter         ResourceLimiter
+	resourceLimitsRefreshed time.Time
 }
 
 // UniterParams hold all the necessary parameters for a new Uniter.
@@ -188,6 +209,8 @@ type UniterParams struct {
 	Logger                       Logger
 	Embedded                     bool
 	EnforcedCharmModifiedVersion int
+	ProxyConfig                  context.ProxyConfigGetter
+	ResourceLimiter              ResourceLimiter
 }
 
 // NewOperationExecutorFunc is a func which returns an operations.Executor.
@@ -253,6 +276,8 @@ func newUniter(uniterParams *UniterParams) func() (worker.Worker, error) {
 			logger:                        uniterParams.Logger,
 			embedded:                      uniterParams.Embedded,
 			enforcedCharmModifiedVersion:  uniterParams.EnforcedCharmModifiedVersion,
+			proxyConfig:                   uniterParams.ProxyConfig,
+			resourceLimiter:               uniterParams.ResourceLimiter,
 		}
 		plan := catacomb.Plan{
 			Site: &u.catacomb,
@@ -326,6 +351,10 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 	var watcher *remotestate.RemoteStateWatcher
 
 	u.logger.Infof("hooks are retried %v", u.hookRetryStrategy.ShouldRetry)
+	var retryHookKinds []hooks.Kind
+	for _, kind := range u.hookRetryStrategy.Hooks {
+		retryHookKinds = append(retryHookKinds, hooks.Kind(kind))
+	}
 	retryHookChan := make(chan struct{}, 1)
 	// TODO(katco): 2016-08-09: This type is deprecated: lp:1611427
 	retryHookTimer := utils.NewBackoffTimer(utils.BackoffTimerConfig{
@@ -391,7 +420,8 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 			// error state.
 			return nil
 		}
-		return setAgentStatus(u, status.Idle, "", nil)
+		u.refreshResourceLimits()
+		return setAgentStatus(u, status.Idle, u.resourceLimitsStatus(), nil)
 	}
 
 	clearResolved := func() error {
@@ -432,6 +462,8 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 			ShouldRetryHooks:    u.hookRetryStrategy.ShouldRetry,
 			StartRetryHookTimer: retryHookTimer.Start,
 			StopRetryHookTimer:  retryHookTimer.Reset,
+			MaxHookRetries:      u.hookRetryStrategy.MaxRetries,
+			RetryHookKinds:      retryHookKinds,
 			Actions: actions.NewResolver(
 				u.logger.Child("actions"),
 			),
@@ -758,6 +790,7 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	if err != nil {
 		return errors.Annotatef(err, "cannot create deployer")
 	}
+	u.refreshResourceLimits()
 	contextFactory, err := context.NewContextFactory(context.FactoryConfig{
 		State:            u.st,
 		Unit:             u.unit,
@@ -767,6 +800,8 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		Paths:            u.paths,
 		Clock:            u.clock,
 		Logger:           u.logger.Child("context"),
+		ProxyConfig:      u.proxyConfig,
+		ResourceSlicer:   u.resourceLimiter,
 	})
 	if err != nil {
 		return err
@@ -885,7 +920,7 @@ func (u *Uniter) acquireExecutionLock(action string) (func(), error) {
 	return releaser, nil
 }
 
-func (u *Uniter) reportHookError(hookInfo hook.Info) error {
+func (u *Uniter) reportHookError(hookInfo hook.Info, retry HookRetryStatus) error {
 	// Set the agent status to "error". We must do this here in case the
 	// hook is interrupted (e.g. unit agent crashes), rather than immediately
 	// after attempting a runHookOp.
@@ -904,5 +939,11 @@ func (u *Uniter) reportHookError(hookInfo hook.Info) error {
 	}
 	statusData["hook"] = hookName
 	statusMessage := fmt.Sprintf("hook failed: %q", hookName)
+	if retry.Attempts > 0 {
+		statusData["retry-attempts"] = retry.Attempts
+		if !retry.Retrying {
+			statusMessage = fmt.Sprintf("%s after %d automatic retries", statusMessage, retry.Attempts)
+		}
+	}
 	return setAgentStatus(u, status.Error, statusMessage, statusData)
 }
@@ -5,8 +5,6 @@
 package mocks
 
 import (
-	reflect "reflect"
-
 	gomock "github.com/golang/mock/gomock"
 	agent "github.com/juju/juju/agent"
 	api "github.com/juju/juju/api"
@@ -17,6 +15,8 @@ import (
 	names "github.com/juju/names/v4"
 	shell "github.com/juju/utils/v2/shell"
 	version "github.com/juju/version"
+	url "net/url"
+	reflect "reflect"
 )
 
 // MockAgent is a mock of Agent interface
@@ -123,6 +123,20 @@ func (mr *MockConfigMockRecorder) APIInfo() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIInfo", reflect.TypeOf((*MockConfig)(nil).APIInfo))
 }
 
+// APIProxy mocks base method
+func (m *MockConfig) APIProxy() *url.URL {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APIProxy")
+	ret0, _ := ret[0].(*url.URL)
+	return ret0
+}
+
+// APIProxy indicates an expected call of APIProxy
+func (mr *MockConfigMockRecorder) APIProxy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIProxy", reflect.TypeOf((*MockConfig)(nil).APIProxy))
+}
+
 // CACert mocks base method
 func (m *MockConfig) CACert() string {
 	m.ctrl.T.Helper()
@@ -487,6 +501,20 @@ func (mr *MockConfigSetterMockRecorder) APIInfo() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIInfo", reflect.TypeOf((*MockConfigSetter)(nil).APIInfo))
 }
 
+// APIProxy mocks base method
+func (m *MockConfigSetter) APIProxy() *url.URL {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APIProxy")
+	ret0, _ := ret[0].(*url.URL)
+	return ret0
+}
+
+// APIProxy indicates an expected call of APIProxy
+func (mr *MockConfigSetterMockRecorder) APIProxy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIProxy", reflect.TypeOf((*MockConfigSetter)(nil).APIProxy))
+}
+
 // CACert mocks base method
 func (m *MockConfigSetter) CACert() string {
 	m.ctrl.T.Helper()
@@ -724,6 +752,18 @@ func (mr *MockConfigSetterMockRecorder) SetAPIHostPorts(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAPIHostPorts", reflect.TypeOf((*MockConfigSetter)(nil).SetAPIHostPorts), arg0)
 }
 
+// SetAPIProxy mocks base method
+func (m *MockConfigSetter) SetAPIProxy(arg0 *url.URL) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAPIProxy", arg0)
+}
+
+// SetAPIProxy indicates an expected call of SetAPIProxy
+func (mr *MockConfigSetterMockRecorder) SetAPIProxy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAPIProxy", reflect.TypeOf((*MockConfigSetter)(nil).SetAPIProxy), arg0)
+}
+
 // SetCACert mocks base method
 func (m *MockConfigSetter) SetCACert(arg0 string) {
 	m.ctrl.T.Helper()
@@ -356,6 +356,9 @@ func (w *upgradesteps) runUpgradeSteps(agentConfig agent.ConfigSetter) error {
 	logger.Infof("starting upgrade from %v to %v for %q", w.fromVersion, w.toVersion, w.tag)
 
 	targets := upgradeTargets(w.isController)
+	for _, step := range upgrades.PlanUpgrade(w.fromVersion, w.toVersion, targets) {
+		logger.Infof("planned upgrade step for %v: %v", step.TargetVersion, step.Description)
+	}
 	attempts := getUpgradeRetryStrategy()
 	for attempt := attempts.Start(); attempt.Next(); {
 		upgradeErr = PerformUpgrade(w.fromVersion, targets, context)